@@ -32,6 +32,42 @@ const (
 	// resource.
 	AnnotationKeyDescription = "kargo.akuity.io/description"
 
+	// AnnotationKeyIdempotencyKey is an annotation key that is set on a
+	// Promotion created by the Kargo API server to record the idempotency key
+	// specified by the client that requested it. The Promotion is also labeled
+	// with a hash of the key, using IdempotencyKeyLabelKey, so that it can be
+	// found when the same key is specified again.
+	AnnotationKeyIdempotencyKey = "kargo.akuity.io/idempotency-key"
+
+	// AnnotationKeyEncryptedDataKey is an annotation key that is set on a
+	// credentials Secret whose sensitive fields have been envelope-encrypted by
+	// the Kargo API server. Its value is the base64-encoded data encryption key
+	// used to encrypt those fields, itself encrypted by a key management
+	// service.
+	AnnotationKeyEncryptedDataKey = "kargo.akuity.io/encrypted-data-key"
+
+	// AnnotationKeyPromotion is an annotation key that is set by the Kargo
+	// controller on an Argo CD Application upon the successful completion of a
+	// Promotion that updated it. Its value is the name of that Promotion.
+	AnnotationKeyPromotion = "kargo.akuity.io/promotion"
+
+	// AnnotationKeyFreight is an annotation key that is set by the Kargo
+	// controller on an Argo CD Application upon the successful completion of a
+	// Promotion that updated it. Its value is the name of the promoted Freight.
+	AnnotationKeyFreight = "kargo.akuity.io/freight"
+
+	// AnnotationKeyFreightAlias is an annotation key that is set by the Kargo
+	// controller on an Argo CD Application upon the successful completion of a
+	// Promotion that updated it. Its value is the alias of the promoted
+	// Freight.
+	AnnotationKeyFreightAlias = "kargo.akuity.io/freight-alias"
+
+	// AnnotationKeyFreightArtifacts is an annotation key that is set by the
+	// Kargo controller on an Argo CD Application upon the successful completion
+	// of a Promotion that updated it. Its value is a comma-separated summary of
+	// the versions of the artifacts referenced by the promoted Freight.
+	AnnotationKeyFreightArtifacts = "kargo.akuity.io/freight-artifacts"
+
 	AnnotationValueTrue = "true"
 )
 
@@ -0,0 +1,196 @@
+package v1alpha1
+
+import (
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterConfigName is the only name a ClusterConfig resource may have. Only
+// a ClusterConfig with this name is honored.
+const ClusterConfigName = "cluster"
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name=Age,type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ClusterConfig is a cluster-scoped resource type that operators may use to
+// declare Kargo-wide settings. Only a single ClusterConfig, named "cluster", is
+// honored.
+type ClusterConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// Spec describes the configuration.
+	Spec ClusterConfigSpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// ClusterConfigSpec describes Kargo-wide settings.
+type ClusterConfigSpec struct {
+	// Hosts declares settings for specific container image registries, Git
+	// hosts, and chart repositories. These settings are consumed by every
+	// component of Kargo that interacts with those hosts.
+	//
+	// +listType=map
+	// +listMapKey=host
+	Hosts []HostConfig `json:"hosts,omitempty" protobuf:"bytes,1,rep,name=hosts"`
+	// Announcements are notices (e.g. of planned maintenance or of a freeze on
+	// promotions) that operators wish to bring to the attention of users. They
+	// are surfaced by the CLI upon login and by the UI.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	Announcements []Announcement `json:"announcements,omitempty" protobuf:"bytes,2,rep,name=announcements"`
+}
+
+// ActiveAnnouncements returns the Announcements that are in effect at the
+// provided time and that target the specified Project. Announcements that do
+// not target any particular Project are always included. If the specified
+// Project is empty, only such system-wide Announcements are returned.
+func (c *ClusterConfigSpec) ActiveAnnouncements(project string, now time.Time) []Announcement {
+	var announcements []Announcement
+	for _, a := range c.Announcements {
+		if !a.IsActive(now) {
+			continue
+		}
+		if len(a.Projects) > 0 && (project == "" || !slices.Contains(a.Projects, project)) {
+			continue
+		}
+		announcements = append(announcements, a)
+	}
+	return announcements
+}
+
+// GetHostConfig returns the settings for the specified host, or nil if no
+// settings have been declared for it.
+func (c *ClusterConfigSpec) GetHostConfig(host string) *HostConfig {
+	for i := range c.Hosts {
+		if c.Hosts[i].Host == host {
+			return &c.Hosts[i]
+		}
+	}
+	return nil
+}
+
+// HostConfig declares settings for a single host.
+type HostConfig struct {
+	// Host is the host name, optionally including a port, to which these
+	// settings apply. e.g. ghcr.io or registry.example.com:5000.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[\w\.-]+(:[\d]+)?$`
+	Host string `json:"host" protobuf:"bytes,1,opt,name=host"`
+	// RateLimit is the maximum number of requests per second that Kargo will
+	// send to this host when it is a container image registry. When left
+	// unspecified, a default limit applies.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	RateLimit int32 `json:"rateLimit,omitempty" protobuf:"varint,2,opt,name=rateLimit"`
+	// CABundle is a PEM-encoded bundle of CA certificates that Kargo will trust,
+	// in addition to the system's trusted CAs, when connecting to this host
+	// over TLS. This is useful for hosts with certificates issued by a private
+	// CA.
+	//
+	// +kubebuilder:validation:Optional
+	CABundle string `json:"caBundle,omitempty" protobuf:"bytes,3,opt,name=caBundle"`
+	// Mirror is the host name, optionally including a port, of a registry that
+	// mirrors this host when it is a container image registry (e.g. a
+	// pull-through cache). When specified, Kargo reads from the mirror instead
+	// of from this host.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[\w\.-]+(:[\d]+)?$`
+	Mirror string `json:"mirror,omitempty" protobuf:"bytes,4,opt,name=mirror"`
+	// CredentialsSecretRef references a Secret containing username and
+	// password keys that are used as credentials for this host when no
+	// credentials for the repository in question are found in either a
+	// Project's namespace or a global credentials namespace. The Secret must
+	// bear the label kargo.akuity.io/cred-type to be visible to Kargo.
+	//
+	// +kubebuilder:validation:Optional
+	CredentialsSecretRef *SecretReference `json:"credentialsSecretRef,omitempty" protobuf:"bytes,5,opt,name=credentialsSecretRef"`
+	// WebhookSecretRef references a Secret containing a secret key whose
+	// value is the shared secret used to verify webhook requests that
+	// originate from this host.
+	//
+	// +kubebuilder:validation:Optional
+	WebhookSecretRef *SecretReference `json:"webhookSecretRef,omitempty" protobuf:"bytes,6,opt,name=webhookSecretRef"`
+}
+
+// AnnouncementSeverity indicates how urgently an Announcement should be
+// brought to the attention of users.
+//
+// +kubebuilder:validation:Enum=Info;Warning;Critical
+type AnnouncementSeverity string
+
+const (
+	AnnouncementSeverityInfo     AnnouncementSeverity = "Info"
+	AnnouncementSeverityWarning  AnnouncementSeverity = "Warning"
+	AnnouncementSeverityCritical AnnouncementSeverity = "Critical"
+)
+
+// Announcement is a notice published by operators for the attention of all
+// users or of the users of specific Projects.
+type Announcement struct {
+	// Name uniquely identifies the Announcement.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Severity indicates how urgently the Announcement should be brought to
+	// the attention of users. Accepted values are Info, Warning, and Critical.
+	// This field defaults to Info.
+	//
+	// +kubebuilder:default=Info
+	Severity AnnouncementSeverity `json:"severity,omitempty" protobuf:"bytes,2,opt,name=severity"`
+	// Message is the text of the Announcement.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Message string `json:"message" protobuf:"bytes,3,opt,name=message"`
+	// Projects optionally restricts the Announcement to users of the specified
+	// Projects. If unspecified, the Announcement is system-wide.
+	//
+	// +kubebuilder:validation:Optional
+	Projects []string `json:"projects,omitempty" protobuf:"bytes,4,rep,name=projects"`
+	// StartTime is an optional time before which the Announcement is not yet
+	// in effect.
+	//
+	// +kubebuilder:validation:Optional
+	StartTime *metav1.Time `json:"startTime,omitempty" protobuf:"bytes,5,opt,name=startTime"`
+	// EndTime is an optional time at which the Announcement ceases to be in
+	// effect.
+	//
+	// +kubebuilder:validation:Optional
+	EndTime *metav1.Time `json:"endTime,omitempty" protobuf:"bytes,6,opt,name=endTime"`
+}
+
+// IsActive returns true if the Announcement is in effect at the provided time.
+func (a *Announcement) IsActive(now time.Time) bool {
+	if a.StartTime != nil && now.Before(a.StartTime.Time) {
+		return false
+	}
+	return a.EndTime == nil || now.Before(a.EndTime.Time)
+}
+
+// SecretReference is a reference to a Secret in a specific namespace.
+type SecretReference struct {
+	// Namespace is the namespace of the Secret.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace" protobuf:"bytes,1,opt,name=namespace"`
+	// Name is the name of the Secret.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name" protobuf:"bytes,2,opt,name=name"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterConfigList is a list of ClusterConfig resources.
+type ClusterConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	Items           []ClusterConfig `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
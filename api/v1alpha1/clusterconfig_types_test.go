@@ -0,0 +1,48 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterConfigSpecActiveAnnouncements(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	spec := ClusterConfigSpec{
+		Announcements: []Announcement{
+			{Name: "system-wide"},
+			{Name: "targeted", Projects: []string{"fake-project"}},
+			{Name: "other-project", Projects: []string{"other-project"}},
+			{Name: "not-started", StartTime: &metav1.Time{Time: now.Add(time.Minute)}},
+			{Name: "started", StartTime: &metav1.Time{Time: now}},
+			{Name: "ended", EndTime: &metav1.Time{Time: now}},
+			{Name: "not-ended", EndTime: &metav1.Time{Time: now.Add(time.Minute)}},
+		},
+	}
+	testCases := []struct {
+		name     string
+		project  string
+		expected []string
+	}{
+		{
+			name:     "no project",
+			expected: []string{"system-wide", "started", "not-ended"},
+		},
+		{
+			name:     "project",
+			project:  "fake-project",
+			expected: []string{"system-wide", "targeted", "started", "not-ended"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var names []string
+			for _, a := range spec.ActiveAnnouncements(testCase.project, now) {
+				names = append(names, a.Name)
+			}
+			require.Equal(t, testCase.expected, names)
+		})
+	}
+}
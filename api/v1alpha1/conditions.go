@@ -0,0 +1,19 @@
+package v1alpha1
+
+const (
+	// ConditionTypeReady denotes whether the most recent reconciliation of a
+	// Stage or Warehouse succeeded. The condition's ObservedGeneration
+	// identifies the generation of the resource's spec it pertains to.
+	ConditionTypeReady = "Ready"
+
+	// ConditionReasonReconciling is the reason for a Ready condition with a
+	// status of Unknown. It indicates that the resource's spec has changed and
+	// that the controller has begun, but not yet finished, reconciling it.
+	ConditionReasonReconciling = "Reconciling"
+	// ConditionReasonSynced is the reason for a Ready condition with a status
+	// of True.
+	ConditionReasonSynced = "Synced"
+	// ConditionReasonSyncFailed is the reason for a Ready condition with a
+	// status of False. The condition's message describes the failure.
+	ConditionReasonSyncFailed = "SyncFailed"
+)
@@ -22,6 +22,7 @@ const (
 	AnnotationKeyEventFreightCommits         = "event.kargo.akuity.io/freight-commits"
 	AnnotationKeyEventFreightImages          = "event.kargo.akuity.io/freight-images"
 	AnnotationKeyEventFreightCharts          = "event.kargo.akuity.io/freight-charts"
+	AnnotationKeyEventFreightPackages        = "event.kargo.akuity.io/freight-packages"
 	AnnotationKeyEventStageName              = "event.kargo.akuity.io/stage-name"
 	AnnotationKeyEventAnalysisRunName        = "event.kargo.akuity.io/analysis-run-name"
 	AnnotationKeyEventVerificationPending    = "event.kargo.akuity.io/verification-pending"
@@ -35,6 +36,10 @@ const (
 	EventReasonPromotionFailed                 = "PromotionFailed"
 	EventReasonPromotionErrored                = "PromotionErrored"
 	EventReasonFreightApproved                 = "FreightApproved"
+	EventReasonFreightApprovalExpired          = "FreightApprovalExpired"
+	EventReasonFreightBlocked                  = "FreightBlocked"
+	EventReasonFreightUnblocked                = "FreightUnblocked"
+	EventReasonFreightArtifactsUnavailable     = "FreightArtifactsUnavailable"
 	EventReasonFreightVerificationSucceeded    = "FreightVerificationSucceeded"
 	EventReasonFreightVerificationFailed       = "FreightVerificationFailed"
 	EventReasonFreightVerificationErrored      = "FreightVerificationErrored"
@@ -94,6 +99,27 @@ func NewFreightApprovedEventAnnotations(actor string, f *Freight, stageName stri
 	return annotations
 }
 
+// NewFreightBlockedEventAnnotations returns annotations for a Freight blocked
+// or unblocked event.
+func NewFreightBlockedEventAnnotations(actor string, f *Freight) map[string]string {
+	return NewFreightEventAnnotations(actor, f)
+}
+
+// NewFreightEventAnnotations returns annotations for an event concerning a
+// piece of Freight that has no more specific annotations of its own.
+func NewFreightEventAnnotations(actor string, f *Freight) map[string]string {
+	annotations := map[string]string{
+		AnnotationKeyEventProject:           f.Namespace,
+		AnnotationKeyEventFreightCreateTime: f.CreationTimestamp.Format(time.RFC3339),
+		AnnotationKeyEventFreightAlias:      f.Alias,
+		AnnotationKeyEventFreightName:       f.Name,
+	}
+	if actor != "" {
+		annotations[AnnotationKeyEventActor] = actor
+	}
+	return annotations
+}
+
 // NewPromotionEventAnnotations returns annotations for a Promotion related event.
 // It may skip some fields when error occurred during serialization, to record event with best-effort.
 func NewPromotionEventAnnotations(
@@ -148,6 +174,14 @@ func NewPromotionEventAnnotations(
 				annotations[AnnotationKeyEventFreightCharts] = string(data)
 			}
 		}
+		if len(f.Packages) > 0 {
+			data, err := json.Marshal(f.Packages)
+			if err != nil {
+				log.WithError(err).Error("marshal freight packages in JSON")
+			} else {
+				annotations[AnnotationKeyEventFreightPackages] = string(data)
+			}
+		}
 	}
 	return annotations
 }
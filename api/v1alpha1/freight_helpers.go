@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -86,14 +87,15 @@ func GetFreightByAlias(
 }
 
 // IsFreightAvailable answers whether the specified Freight is available to the
-// specified Stage having the specified upstream stages. Freight is available
-// if:
+// specified Stage having the specified upstream stages. Blocked Freight is
+// never available. Otherwise, Freight is available if:
 //
 //  1. No upstreamStages are specified
 //     OR
 //  2. The Freight has been verified in ANY of the specified upstream stages
 //     OR
-//  3. The Freight is approved for the specified stage
+//  3. The Freight is approved for the specified stage and the approval has not
+//     expired
 //
 // Note: The rationale for returning true when no upstream stages are specified
 // is that some Stages have no upstream Stages (e.g. a Stage that subscribes to
@@ -103,6 +105,9 @@ func IsFreightAvailable(
 	stage string,
 	upstreamStages []string,
 ) bool {
+	if freight.IsBlocked() {
+		return false
+	}
 	if len(upstreamStages) == 0 {
 		return true
 	}
@@ -112,9 +117,26 @@ func IsFreightAvailable(
 		}
 	}
 	if stage != "" {
-		if _, ok := freight.Status.ApprovedFor[stage]; ok {
+		if approval, ok := freight.Status.ApprovedFor[stage]; ok &&
+			!approval.HasExpired(time.Now()) {
 			return true
 		}
 	}
 	return false
 }
+
+// FilterFreightByService returns those of the provided Freight that were
+// produced for the specified service. If no service is specified, all of the
+// provided Freight is returned.
+func FilterFreightByService(freight []Freight, service string) []Freight {
+	if service == "" {
+		return freight
+	}
+	filtered := make([]Freight, 0, len(freight))
+	for _, f := range freight {
+		if f.Service == service {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered
+}
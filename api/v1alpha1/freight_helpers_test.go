@@ -3,6 +3,7 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -123,6 +124,12 @@ func TestIsFreightAvailable(t *testing.T) {
 			},
 			ApprovedFor: map[string]ApprovedStage{
 				"fake-stage-2": {},
+				"fake-stage-4": {
+					ExpiresAt: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+				},
+				"fake-stage-5": {
+					ExpiresAt: &metav1.Time{Time: time.Now().Add(time.Hour)},
+				},
 			},
 		},
 	}
@@ -147,6 +154,18 @@ func TestIsFreightAvailable(t *testing.T) {
 			upstreamStages: []string{"fake-stage-3"},
 			available:      true,
 		},
+		{
+			name:           "approved for Stage until later",
+			stage:          "fake-stage-5",
+			upstreamStages: []string{"fake-stage-3"},
+			available:      true,
+		},
+		{
+			name:           "approval for Stage expired",
+			stage:          "fake-stage-4",
+			upstreamStages: []string{"fake-stage-3"},
+			available:      false,
+		},
 		{
 			name:           "unavailable",
 			stage:          "fake-stage-3",
@@ -167,4 +186,54 @@ func TestIsFreightAvailable(t *testing.T) {
 			)
 		})
 	}
+	t.Run("blocked", func(t *testing.T) {
+		blockedFreight := testFreight.DeepCopy()
+		blockedFreight.Status.Blocked = &FreightBlock{Reason: "fake-reason"}
+		require.False(t, IsFreightAvailable(blockedFreight, "", nil))
+		require.False(t, IsFreightAvailable(blockedFreight, "fake-stage-2", []string{"fake-stage-1"}))
+	})
+}
+
+func TestFilterFreightByService(t *testing.T) {
+	freight := []Freight{
+		{ObjectMeta: metav1.ObjectMeta{Name: "fake-freight-1"}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "fake-freight-2"},
+			Service:    "fake-service",
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "fake-freight-3"},
+			Service:    "another-fake-service",
+		},
+	}
+	testCases := []struct {
+		name     string
+		service  string
+		expected []string
+	}{
+		{
+			name:     "no service specified",
+			expected: []string{"fake-freight-1", "fake-freight-2", "fake-freight-3"},
+		},
+		{
+			name:     "service specified",
+			service:  "fake-service",
+			expected: []string{"fake-freight-2"},
+		},
+		{
+			name:     "no Freight for service",
+			service:  "nonexistent-service",
+			expected: []string{},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			filtered := FilterFreightByService(freight, testCase.service)
+			names := make([]string, len(filtered))
+			for i, f := range filtered {
+				names[i] = f.Name
+			}
+			require.Equal(t, testCase.expected, names)
+		})
+	}
 }
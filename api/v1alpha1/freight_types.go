@@ -6,16 +6,32 @@ import (
 	"path"
 	"sort"
 	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/akuity/kargo/internal/git"
 	"github.com/akuity/kargo/internal/helm"
 )
 
+const (
+	// FreightConditionTypeArtifactsUnavailable denotes that one or more of the
+	// artifacts referenced by a piece of Freight can no longer be found in its
+	// upstream repository, e.g. because a tag was deleted, an image was
+	// untagged, or a branch was force-pushed. Such Freight may be impossible to
+	// (re-)promote, so rollbacks to it are likely to fail.
+	FreightConditionTypeArtifactsUnavailable = "ArtifactsUnavailable"
+
+	// FreightConditionReasonArtifactsNotFound is the reason for an
+	// ArtifactsUnavailable condition with a status of True.
+	FreightConditionReasonArtifactsNotFound = "ArtifactsNotFound"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name=Alias,type=string,JSONPath=`.metadata.labels.kargo\.akuity\.io/alias`
+// +kubebuilder:printcolumn:name=Blocked,type=string,JSONPath=`.status.blocked.reason`
 // +kubebuilder:printcolumn:name=Age,type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Freight represents a collection of versioned artifacts.
@@ -38,12 +54,18 @@ type Freight struct {
 	//
 	// +kubebuilder:validation:Required
 	Warehouse string `json:"warehouse,omitempty" protobuf:"bytes,8,opt,name=warehouse"`
+	// Service is the name of the service for which this Freight was produced.
+	// This field is only populated if the Warehouse that created this Freight
+	// has a Git subscription that specifies Services.
+	Service string `json:"service,omitempty" protobuf:"bytes,9,opt,name=service"`
 	// Commits describes specific Git repository commits.
 	Commits []GitCommit `json:"commits,omitempty" protobuf:"bytes,3,rep,name=commits"`
 	// Images describes specific versions of specific container images.
 	Images []Image `json:"images,omitempty" protobuf:"bytes,4,rep,name=images"`
 	// Charts describes specific versions of specific Helm charts.
 	Charts []Chart `json:"charts,omitempty" protobuf:"bytes,5,rep,name=charts"`
+	// Packages describes specific versions of specific packages.
+	Packages []Package `json:"packages,omitempty" protobuf:"bytes,10,rep,name=packages"`
 	// Status describes the current status of this Freight.
 	Status FreightStatus `json:"status,omitempty" protobuf:"bytes,6,opt,name=status"`
 }
@@ -52,12 +74,36 @@ func (f *Freight) GetStatus() *FreightStatus {
 	return &f.Status
 }
 
+// HasUnavailableArtifacts returns true if one or more of the artifacts
+// referenced by the Freight are known to be unavailable upstream and false
+// otherwise.
+func (f *Freight) HasUnavailableArtifacts() bool {
+	return meta.IsStatusConditionTrue(
+		f.Status.Conditions,
+		FreightConditionTypeArtifactsUnavailable,
+	)
+}
+
+// IsBlocked returns true if the Freight has been blocked and false otherwise.
+func (f *Freight) IsBlocked() bool {
+	return f.Status.Blocked != nil
+}
+
 // GenerateID deterministically calculates a piece of Freight's ID based on its
 // contents and returns it.
 func (f *Freight) GenerateID() string {
-	size := len(f.Commits) + len(f.Images) + len(f.Charts)
+	size := len(f.Commits) + len(f.Images) + len(f.Charts) + len(f.Packages)
 	artifacts := make([]string, 0, size)
 	for _, commit := range f.Commits {
+		repo := git.NormalizeURL(commit.RepoURL)
+		if commit.Branch != "" {
+			// If we know which branch the commit was found on, incorporate it into
+			// the canonical representation of a commit used when calculating Freight
+			// ID. This is necessary because a subscription matching multiple
+			// branches could find the same commit at the head of more than one of
+			// them, and Freight produced for each branch must remain distinct.
+			repo = fmt.Sprintf("%s#%s", repo, commit.Branch)
+		}
 		if commit.Tag != "" {
 			// If we have a tag, incorporate it into the canonical representation of a
 			// commit used when calculating Freight ID. This is necessary because one
@@ -68,12 +114,12 @@ func (f *Freight) GenerateID() string {
 			// Freight for the new tag.
 			artifacts = append(
 				artifacts,
-				fmt.Sprintf("%s:%s:%s", git.NormalizeURL(commit.RepoURL), commit.Tag, commit.ID),
+				fmt.Sprintf("%s:%s:%s", repo, commit.Tag, commit.ID),
 			)
 		} else {
 			artifacts = append(
 				artifacts,
-				fmt.Sprintf("%s:%s", git.NormalizeURL(commit.RepoURL), commit.ID),
+				fmt.Sprintf("%s:%s", repo, commit.ID),
 			)
 		}
 	}
@@ -100,7 +146,26 @@ func (f *Freight) GenerateID() string {
 			),
 		)
 	}
+	for _, pkg := range f.Packages {
+		artifacts = append(
+			artifacts,
+			fmt.Sprintf(
+				"%s:%s:%s:%s",
+				pkg.Type,
+				strings.TrimSuffix(pkg.RepoURL, "/"),
+				pkg.Name,
+				pkg.Version,
+			),
+		)
+	}
 	sort.Strings(artifacts)
+	if f.Service != "" {
+		// If the Freight was produced for a service, incorporate the service into
+		// the ID. This is necessary because a single commit can touch the paths of
+		// more than one service. If we don't incorporate the service into the ID,
+		// the Freight produced for each of those services would collide.
+		artifacts = append([]string{"service:" + f.Service}, artifacts...)
+	}
 	return fmt.Sprintf(
 		"%x",
 		sha1.Sum([]byte(strings.Join(artifacts, "|"))),
@@ -134,6 +199,15 @@ type GitCommit struct {
 	Author string `json:"author,omitempty" protobuf:"bytes,7,opt,name=author"`
 	// Committer is the person who committed the commit.
 	Committer string `json:"committer,omitempty" protobuf:"bytes,8,opt,name=committer"`
+	// Trailers contains the values of the Git trailers selected by the
+	// GitSubscription through which the commit was discovered, indexed by the
+	// keys specified therein.
+	Trailers map[string]string `json:"trailers,omitempty" protobuf:"bytes,9,rep,name=trailers" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// SignatureStatus indicates whether the signature of the commit (or of the
+	// tag that resolved to it) was verified when the commit was discovered. It
+	// is only populated if the GitSubscription through which the commit was
+	// discovered specifies that signatures be verified.
+	SignatureStatus GitSignatureStatus `json:"signatureStatus,omitempty" protobuf:"bytes,10,opt,name=signatureStatus"`
 }
 
 // FreightStatus describes a piece of Freight's most recently observed state.
@@ -146,14 +220,62 @@ type FreightStatus struct {
 	// might wish to promote a piece of Freight to a given Stage without
 	// transiting the entire pipeline.
 	ApprovedFor map[string]ApprovedStage `json:"approvedFor,omitempty" protobuf:"bytes,2,rep,name=approvedFor" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Blocked, if set, indicates that this Freight has been blocked by a user.
+	// This is useful when a release turns out to be bad after it has already
+	// been partially rolled out. Blocked Freight is not available to any Stage
+	// and cannot be promoted anywhere until it is unblocked.
+	Blocked *FreightBlock `json:"blocked,omitempty" protobuf:"bytes,3,opt,name=blocked"`
+	// Conditions contains the last observations of the Freight's current state.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchMergeKey:"type" patchStrategy:"merge" protobuf:"bytes,4,rep,name=conditions"`
+	// PromotedTo describes the Stages to which this Freight has been
+	// successfully promoted.
+	PromotedTo map[string]PromotedStage `json:"promotedTo,omitempty" protobuf:"bytes,5,rep,name=promotedTo" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 // VerifiedStage describes a Stage in which Freight has been verified.
-type VerifiedStage struct{}
+type VerifiedStage struct {
+	// VerifiedAt is the time at which the Freight was first verified in the
+	// Stage.
+	VerifiedAt *metav1.Time `json:"verifiedAt,omitempty" protobuf:"bytes,1,opt,name=verifiedAt"`
+}
+
+// PromotedStage describes a Stage to which Freight has been promoted.
+type PromotedStage struct {
+	// PromotedAt is the time at which the Freight was first successfully
+	// promoted to the Stage.
+	PromotedAt *metav1.Time `json:"promotedAt,omitempty" protobuf:"bytes,1,opt,name=promotedAt"`
+}
 
 // ApprovedStage describes a Stage for which Freight has been (manually)
 // approved.
-type ApprovedStage struct{}
+type ApprovedStage struct {
+	// ApprovedAt is the time at which the Freight was approved for the Stage.
+	ApprovedAt *metav1.Time `json:"approvedAt,omitempty" protobuf:"bytes,1,opt,name=approvedAt"`
+	// ExpiresAt, if set, is the time after which the approval is automatically
+	// revoked unless the Freight has been promoted to the Stage by then.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty" protobuf:"bytes,2,opt,name=expiresAt"`
+}
+
+// HasExpired returns true if the approval has an expiration time that is not
+// after the provided time.
+func (a *ApprovedStage) HasExpired(now time.Time) bool {
+	return a.ExpiresAt != nil && !a.ExpiresAt.Time.After(now)
+}
+
+// FreightBlock describes why, when, and by whom a piece of Freight was
+// blocked.
+type FreightBlock struct {
+	// Reason is a human-readable explanation of why the Freight was blocked.
+	Reason string `json:"reason,omitempty" protobuf:"bytes,1,opt,name=reason"`
+	// Actor is the name of the entity that blocked the Freight.
+	Actor string `json:"actor,omitempty" protobuf:"bytes,2,opt,name=actor"`
+	// Time is the time at which the Freight was blocked.
+	Time *metav1.Time `json:"time,omitempty" protobuf:"bytes,3,opt,name=time"`
+}
 
 // +kubebuilder:object:root=true
 
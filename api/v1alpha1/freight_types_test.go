@@ -106,4 +106,33 @@ func TestFreightGenerateID(t *testing.T) {
 	// Changing anything should change the result
 	freight.Commits[0].ID = "a-different-fake-commit"
 	require.NotEqual(t, expected, freight.GenerateID())
+	// Freight for a service should not collide with otherwise identical Freight
+	// for another service
+	expected = freight.GenerateID()
+	freight.Service = "fake-service"
+	serviceID := freight.GenerateID()
+	require.NotEqual(t, expected, serviceID)
+	freight.Service = "another-fake-service"
+	require.NotEqual(t, expected, freight.GenerateID())
+	require.NotEqual(t, serviceID, freight.GenerateID())
+}
+
+func TestFreightGenerateIDWithBranches(t *testing.T) {
+	newFreight := func(branch string) Freight {
+		return Freight{
+			Commits: []GitCommit{{
+				RepoURL: "fake-git-repo",
+				ID:      "fake-commit-id",
+				Branch:  branch,
+			}},
+		}
+	}
+	// The same commit at the head of two branches should yield distinct IDs
+	releaseA := newFreight("release/a")
+	releaseB := newFreight("release/b")
+	require.NotEqual(t, releaseA.GenerateID(), releaseB.GenerateID())
+	// And neither should collide with the same commit found without a branch
+	noBranch := newFreight("")
+	require.NotEqual(t, noBranch.GenerateID(), releaseA.GenerateID())
+	require.NotEqual(t, noBranch.GenerateID(), releaseB.GenerateID())
 }
@@ -27,14 +27,20 @@ var (
 // addKnownTypes adds the set of types defined in this package to the supplied scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(GroupVersion,
+		&ClusterConfig{},
+		&ClusterConfigList{},
 		&Freight{},
 		&FreightList{},
 		&Stage{},
 		&StageList{},
 		&Project{},
 		&ProjectList{},
+		&ProjectRole{},
+		&ProjectRoleList{},
 		&Promotion{},
 		&PromotionList{},
+		&PromotionPlan{},
+		&PromotionPlanList{},
 		&Warehouse{},
 		&WarehouseList{},
 	)
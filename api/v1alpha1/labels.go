@@ -4,17 +4,19 @@ const (
 	AliasLabelKey = "kargo.akuity.io/alias"
 
 	// Credentials
-	CredentialTypeLabelKey        = "kargo.akuity.io/cred-type" // nolint: gosec
-	CredentialTypeLabelValueGit   = "git"
-	CredentialTypeLabelValueHelm  = "helm"
-	CredentialTypeLabelValueImage = "image"
+	CredentialTypeLabelKey          = "kargo.akuity.io/cred-type" // nolint: gosec
+	CredentialTypeLabelValueGit     = "git"
+	CredentialTypeLabelValueHelm    = "helm"
+	CredentialTypeLabelValueImage   = "image"
+	CredentialTypeLabelValuePackage = "package"
 
 	// Kargo core API
-	FreightLabelKey   = "kargo.akuity.io/freight"
-	ProjectLabelKey   = "kargo.akuity.io/project"
-	PromotionLabelKey = "kargo.akuity.io/promotion"
-	ShardLabelKey     = "kargo.akuity.io/shard"
-	StageLabelKey     = "kargo.akuity.io/stage"
+	FreightLabelKey        = "kargo.akuity.io/freight"
+	IdempotencyKeyLabelKey = "kargo.akuity.io/idempotency-key"
+	ProjectLabelKey        = "kargo.akuity.io/project"
+	PromotionLabelKey      = "kargo.akuity.io/promotion"
+	ShardLabelKey          = "kargo.akuity.io/shard"
+	StageLabelKey          = "kargo.akuity.io/stage"
 
 	LabelTrueValue = "true"
 
@@ -1,6 +1,9 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -53,6 +56,286 @@ type ProjectSpec struct {
 	// PromotionPolicies defines policies governing the promotion of Freight to
 	// specific Stages within this Project.
 	PromotionPolicies []PromotionPolicy `json:"promotionPolicies,omitempty" protobuf:"bytes,1,rep,name=promotionPolicies"`
+	// GitConfig optionally specifies the Git identity, and a key for signing
+	// commits, to be used when Kargo commits changes to a Git repository on
+	// behalf of this Project. This makes such commits attributable to the team
+	// that owns the Project and permits them to satisfy branch protection rules
+	// that require signed commits. If the name or email address is left
+	// unspecified, the controller-wide default is used. The controller-wide
+	// signing key, however, is never used for a Project that specifies its own
+	// Git config, since that key is tied to the controller-wide identity.
+	GitConfig *ProjectGitConfig `json:"gitConfig,omitempty" protobuf:"bytes,2,opt,name=gitConfig"`
+	// MaintenanceMode optionally freezes promotions within this Project, e.g.
+	// during incident response. While maintenance mode is active, no Freight is
+	// automatically promoted to any of the Project's Stages, regardless of their
+	// PromotionPolicies, and manual promotions may optionally be rejected as
+	// well.
+	MaintenanceMode *MaintenanceMode `json:"maintenanceMode,omitempty" protobuf:"bytes,3,opt,name=maintenanceMode"`
+	// CommitMessageTemplates defines reusable templates for the messages of
+	// commits that Kargo makes to Git repositories on behalf of this Project.
+	// The GitRepoUpdates of any of the Project's Stages may reference one of
+	// these by name so that all of the Project's promotion commits follow a
+	// consistent format.
+	//
+	// +listType=map
+	// +listMapKey=name
+	CommitMessageTemplates []CommitMessageTemplate `json:"commitMessageTemplates,omitempty" protobuf:"bytes,4,rep,name=commitMessageTemplates"`
+	// Vars defines variables that are available, as vars.<name>, to the
+	// expressions evaluated during the promotion of Freight to any of the
+	// Project's Stages, e.g. in commit message templates. A Stage may override
+	// any of these by defining a variable of the same name.
+	//
+	// +listType=map
+	// +listMapKey=name
+	Vars []ExpressionVariable `json:"vars,omitempty" protobuf:"bytes,5,rep,name=vars"`
+	// PromotionHooks defines reusable actions that any of the Project's Stages
+	// may reference by name from their PrePromotionHooks or PostPromotionHooks.
+	//
+	// +listType=map
+	// +listMapKey=name
+	PromotionHooks []ProjectPromotionHook `json:"promotionHooks,omitempty" protobuf:"bytes,6,rep,name=promotionHooks"`
+	// Isolation optionally isolates the execution of this Project's Promotions
+	// from that of other Projects' Promotions.
+	Isolation *ProjectIsolation `json:"isolation,omitempty" protobuf:"bytes,7,opt,name=isolation"`
+	// ImagePullSecretTargets lists the namespaces, optionally in clusters
+	// registered with Argo CD, in which the Project's Stages may have the Kargo
+	// controller maintain image pull Secrets. A Stage's ImagePullSecrets may
+	// only reference namespaces listed here. When left unspecified, the
+	// Project's Stages may not maintain image pull Secrets at all.
+	ImagePullSecretTargets []ImagePullSecretTarget `json:"imagePullSecretTargets,omitempty" protobuf:"bytes,8,rep,name=imagePullSecretTargets"`
+}
+
+// ImagePullSecretTarget describes a namespace in which a Project's Stages may
+// have the Kargo controller maintain image pull Secrets.
+type ImagePullSecretTarget struct {
+	// Cluster is the name or API server URL of a cluster registered with Argo
+	// CD. This field is optional. When left unspecified, it refers to the same
+	// cluster as Kargo.
+	//
+	// +kubebuilder:validation:Optional
+	Cluster string `json:"cluster,omitempty" protobuf:"bytes,1,opt,name=cluster"`
+	// Namespace is the name of the namespace. This is a required field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	Namespace string `json:"namespace" protobuf:"bytes,2,opt,name=namespace"`
+}
+
+// ProjectIsolation describes how the execution of a Project's Promotions is
+// isolated from that of other Projects' Promotions.
+type ProjectIsolation struct {
+	// DedicatedPromotionRunner specifies whether the Project's Promotions are
+	// executed by a promotion runner dedicated to the Project instead of by the
+	// shared Kargo controller. The runner is deployed to the Project's namespace
+	// and uses its own ServiceAccount, which is only permitted to access the
+	// Project's own resources. A NetworkPolicy prevents the runner from
+	// receiving any inbound traffic. The shared controller continues to
+	// orchestrate the Project's Stages, e.g. by creating Promotions, but never
+	// executes them.
+	DedicatedPromotionRunner bool `json:"dedicatedPromotionRunner,omitempty" protobuf:"varint,1,opt,name=dedicatedPromotionRunner"`
+}
+
+// ProjectPromotionHook is an action defined by a Project to be taken before or
+// after the other promotion mechanisms of any of its Stages that reference it.
+type ProjectPromotionHook struct {
+	// Name is the name by which Stages reference the hook.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// HTTP describes an HTTP request to be sent.
+	HTTP HTTPPromotionHook `json:"http" protobuf:"bytes,2,opt,name=http"`
+}
+
+// ExpressionVariable is a named value that may be referenced by expressions.
+type ExpressionVariable struct {
+	// Name is the name by which expressions reference the variable.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=^[a-zA-Z_][a-zA-Z0-9_]*$
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Value is the value of the variable.
+	Value string `json:"value" protobuf:"bytes,2,opt,name=value"`
+}
+
+// CommitMessageTemplate is a named template for the messages of commits that
+// Kargo makes to Git repositories.
+type CommitMessageTemplate struct {
+	// Name is the name by which GitRepoUpdates reference the template.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Template is the commit message template. It may contain any number of
+	// expressions, each enclosed in ${{ and }}. Expressions have access to the
+	// following:
+	//
+	//   - ctx: an object with project, stage, and promotion fields.
+	//   - freight: the Freight being promoted, with name, warehouse, commits,
+	//     images, and charts fields.
+	//   - changes: a list of summaries of the changes being committed.
+	//   - summary: the commit message Kargo would have used by default.
+	//   - outputs: the metadata recorded so far by the Promotion.
+	//   - pullRequests: the URLs of any pull requests opened so far by the
+	//     Promotion, indexed by repository URL.
+	//   - vars: the variables defined by the Project and Stage.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Template string `json:"template" protobuf:"bytes,2,opt,name=template"`
+}
+
+// GetCommitMessageTemplate returns the CommitMessageTemplate with the provided
+// name. If no such template exists, nil is returned.
+func (p *Project) GetCommitMessageTemplate(name string) *CommitMessageTemplate {
+	if p.Spec == nil {
+		return nil
+	}
+	for i := range p.Spec.CommitMessageTemplates {
+		if p.Spec.CommitMessageTemplates[i].Name == name {
+			return &p.Spec.CommitMessageTemplates[i]
+		}
+	}
+	return nil
+}
+
+// HasDedicatedPromotionRunner returns true if the Project's Promotions are
+// executed by a promotion runner dedicated to the Project.
+func (p *Project) HasDedicatedPromotionRunner() bool {
+	return p.Spec != nil && p.Spec.Isolation != nil &&
+		p.Spec.Isolation.DedicatedPromotionRunner
+}
+
+// AllowsImagePullSecret returns true if the Project permits its Stages to
+// maintain the provided ImagePullSecret, i.e. if the Secret's cluster and
+// namespace are among the Project's ImagePullSecretTargets.
+func (p *Project) AllowsImagePullSecret(ips ImagePullSecret) bool {
+	if p.Spec == nil {
+		return false
+	}
+	for _, target := range p.Spec.ImagePullSecretTargets {
+		if target.Cluster == ips.Cluster && target.Namespace == ips.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPromotionHook returns the ProjectPromotionHook with the provided name. If
+// no such hook exists, nil is returned.
+func (p *Project) GetPromotionHook(name string) *ProjectPromotionHook {
+	if p.Spec == nil {
+		return nil
+	}
+	for i := range p.Spec.PromotionHooks {
+		if p.Spec.PromotionHooks[i].Name == name {
+			return &p.Spec.PromotionHooks[i]
+		}
+	}
+	return nil
+}
+
+// ResolveVars returns the variables available to expressions evaluated during
+// the promotion of Freight to the provided Stage, indexed by name. Variables
+// defined by the Stage take precedence over those of the same name defined by
+// the Project. Either argument may be nil.
+func ResolveVars(project *Project, stage *Stage) map[string]string {
+	vars := map[string]string{}
+	if project != nil && project.Spec != nil {
+		for _, v := range project.Spec.Vars {
+			vars[v.Name] = v.Value
+		}
+	}
+	if stage != nil {
+		for _, v := range stage.Spec.Vars {
+			vars[v.Name] = v.Value
+		}
+	}
+	return vars
+}
+
+// MaintenanceMode describes a freeze of promotions within a Project.
+type MaintenanceMode struct {
+	// Enabled indicates whether maintenance mode is in effect. This field
+	// defaults to false.
+	Enabled bool `json:"enabled,omitempty" protobuf:"varint,1,opt,name=enabled"`
+	// RejectManualPromotions indicates whether manually requested promotions
+	// should be rejected while maintenance mode is in effect. This field
+	// defaults to false, meaning only automatic promotions are paused.
+	RejectManualPromotions bool `json:"rejectManualPromotions,omitempty" protobuf:"varint,2,opt,name=rejectManualPromotions"`
+	// Message is an optional explanation of the maintenance, which is included
+	// in the reason given for rejecting a manual promotion.
+	Message string `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
+	// EndTime is an optional time at which maintenance mode automatically ends.
+	// If unspecified, maintenance mode remains in effect until it is disabled.
+	EndTime *metav1.Time `json:"endTime,omitempty" protobuf:"bytes,4,opt,name=endTime"`
+}
+
+// ActiveMaintenanceMode returns the Project's MaintenanceMode if it is in
+// effect at the provided time. Otherwise, it returns nil.
+func (p *Project) ActiveMaintenanceMode(now time.Time) *MaintenanceMode {
+	if p.Spec == nil || p.Spec.MaintenanceMode == nil {
+		return nil
+	}
+	m := p.Spec.MaintenanceMode
+	if !m.Enabled || (m.EndTime != nil && !now.Before(m.EndTime.Time)) {
+		return nil
+	}
+	return m
+}
+
+// Describe returns a human-readable description of the MaintenanceMode
+// suitable for explaining why a promotion was rejected or paused.
+func (m *MaintenanceMode) Describe() string {
+	desc := "Project is in maintenance mode"
+	if m.EndTime != nil {
+		desc = fmt.Sprintf("%s until %s", desc, m.EndTime.UTC().Format(time.RFC3339))
+	}
+	if m.Message != "" {
+		desc = fmt.Sprintf("%s: %s", desc, m.Message)
+	}
+	return desc
+}
+
+// ProjectGitConfig describes the Git identity and signing key to be used when
+// Kargo commits changes to a Git repository on behalf of a Project.
+type ProjectGitConfig struct {
+	// Name is the name to be used as the author and committer of commits.
+	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	// Email is the email address to be used as the author and committer of
+	// commits.
+	Email string `json:"email,omitempty" protobuf:"bytes,2,opt,name=email"`
+	// SigningKey optionally references a key to be used for signing commits.
+	SigningKey *GitSigningKey `json:"signingKey,omitempty" protobuf:"bytes,3,opt,name=signingKey"`
+}
+
+// GitSigningKeyType is the type of a key used for signing Git commits.
+//
+// +kubebuilder:validation:Enum=gpg;ssh
+type GitSigningKeyType string
+
+const (
+	// GitSigningKeyTypeGPG denotes a GPG private key. When signing commits
+	// using a GPG key, the name and email address of the committer must match
+	// the identity of the key.
+	GitSigningKeyTypeGPG GitSigningKeyType = "gpg"
+	// GitSigningKeyTypeSSH denotes an SSH private key.
+	GitSigningKeyTypeSSH GitSigningKeyType = "ssh"
+)
+
+// GitSigningKeySecretKey is the key within a Secret referenced by a
+// GitSigningKey under which the private signing key is stored.
+const GitSigningKeySecretKey = "signingKey"
+
+// GitSigningKey references a private key for signing Git commits.
+type GitSigningKey struct {
+	// Type is the type of the signing key. Accepted values are "gpg" and "ssh".
+	// This field defaults to "gpg".
+	//
+	// +kubebuilder:default=gpg
+	Type GitSigningKeyType `json:"type,omitempty" protobuf:"bytes,1,opt,name=type"`
+	// SecretName is the name of a Secret in the Project's namespace that stores
+	// the private signing key under the key "signingKey".
+	//
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName" protobuf:"bytes,2,opt,name=secretName"`
 }
 
 // PromotionPolicy defines policies governing the promotion of Freight to a
@@ -69,6 +352,30 @@ type PromotionPolicy struct {
 	// users to define Stages that are automatically updated as soon as new
 	// artifacts are detected.
 	AutoPromotionEnabled bool `json:"autoPromotionEnabled,omitempty" protobuf:"varint,2,opt,name=autoPromotionEnabled"`
+	// BackPromotionEnabled indicates whether Freight that is promoted directly
+	// to a downstream Stage, bypassing the Stage referenced by the Stage field
+	// (e.g. a hotfix that was manually approved for a downstream Stage), should
+	// automatically be promoted to the bypassed Stage afterward. This keeps the
+	// Stages of a pipeline convergent without requiring manual cleanup. This
+	// field defaults to false.
+	BackPromotionEnabled bool `json:"backPromotionEnabled,omitempty" protobuf:"varint,3,opt,name=backPromotionEnabled"`
+	// DriftRemediationEnabled indicates whether the Stage referenced by the Stage
+	// field should automatically be re-promoted to its current Freight when
+	// drift is detected, thereby overwriting any changes made outside of Kargo.
+	// Drift detection must also be enabled for the Stage itself. This field
+	// defaults to false.
+	DriftRemediationEnabled bool `json:"driftRemediationEnabled,omitempty" protobuf:"varint,4,opt,name=driftRemediationEnabled"`
+	// AutoPromotionMinInterval is the minimum amount of time that must elapse
+	// after a Promotion to the Stage referenced by the Stage field concludes
+	// before Freight is automatically promoted to that Stage again, e.g. "30m".
+	// While a Promotion is in progress or this interval has not yet elapsed, new
+	// Freight is not automatically promoted. Once it has, only the newest
+	// available Freight is, so any Freight that became available in the interim
+	// is skipped. This protects the Stage from being redeployed for every new
+	// piece of Freight from a busy Warehouse. This field is optional and only
+	// has an effect when auto-promotion is enabled. When not specified,
+	// auto-promotion is not throttled.
+	AutoPromotionMinInterval *metav1.Duration `json:"autoPromotionMinInterval,omitempty" protobuf:"bytes,5,opt,name=autoPromotionMinInterval"`
 }
 
 // ProjectStatus describes a Project's current status.
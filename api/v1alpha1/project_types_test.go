@@ -0,0 +1,216 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProjectActiveMaintenanceMode(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name   string
+		spec   *ProjectSpec
+		active bool
+	}{
+		{
+			name: "no spec",
+		},
+		{
+			name: "no maintenance mode",
+			spec: &ProjectSpec{},
+		},
+		{
+			name: "maintenance mode disabled",
+			spec: &ProjectSpec{
+				MaintenanceMode: &MaintenanceMode{},
+			},
+		},
+		{
+			name: "maintenance mode enabled without end time",
+			spec: &ProjectSpec{
+				MaintenanceMode: &MaintenanceMode{Enabled: true},
+			},
+			active: true,
+		},
+		{
+			name: "maintenance mode enabled until a future time",
+			spec: &ProjectSpec{
+				MaintenanceMode: &MaintenanceMode{
+					Enabled: true,
+					EndTime: &metav1.Time{Time: now.Add(time.Minute)},
+				},
+			},
+			active: true,
+		},
+		{
+			name: "maintenance mode ended",
+			spec: &ProjectSpec{
+				MaintenanceMode: &MaintenanceMode{
+					Enabled: true,
+					EndTime: &metav1.Time{Time: now},
+				},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			project := &Project{Spec: testCase.spec}
+			m := project.ActiveMaintenanceMode(now)
+			if testCase.active {
+				require.Same(t, testCase.spec.MaintenanceMode, m)
+			} else {
+				require.Nil(t, m)
+			}
+		})
+	}
+}
+
+func TestProjectAllowsImagePullSecret(t *testing.T) {
+	spec := &ProjectSpec{
+		ImagePullSecretTargets: []ImagePullSecretTarget{
+			{Namespace: "local-ns"},
+			{Cluster: "remote", Namespace: "remote-ns"},
+		},
+	}
+	testCases := []struct {
+		name    string
+		spec    *ProjectSpec
+		ips     ImagePullSecret
+		allowed bool
+	}{
+		{
+			name: "no spec",
+			ips:  ImagePullSecret{Namespace: "local-ns"},
+		},
+		{
+			name: "no targets",
+			spec: &ProjectSpec{},
+			ips:  ImagePullSecret{Namespace: "local-ns"},
+		},
+		{
+			name:    "local namespace allowed",
+			spec:    spec,
+			ips:     ImagePullSecret{Namespace: "local-ns"},
+			allowed: true,
+		},
+		{
+			name:    "remote namespace allowed",
+			spec:    spec,
+			ips:     ImagePullSecret{Cluster: "remote", Namespace: "remote-ns"},
+			allowed: true,
+		},
+		{
+			name: "namespace allowed only in another cluster",
+			spec: spec,
+			ips:  ImagePullSecret{Namespace: "remote-ns"},
+		},
+		{
+			name: "namespace not allowed",
+			spec: spec,
+			ips:  ImagePullSecret{Cluster: "remote", Namespace: "kube-system"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			project := &Project{Spec: testCase.spec}
+			require.Equal(t, testCase.allowed, project.AllowsImagePullSecret(testCase.ips))
+		})
+	}
+}
+
+func TestMaintenanceModeDescribe(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mode     MaintenanceMode
+		expected string
+	}{
+		{
+			name:     "without end time or message",
+			expected: "Project is in maintenance mode",
+		},
+		{
+			name: "with end time and message",
+			mode: MaintenanceMode{
+				Message: "incident in progress",
+				EndTime: &metav1.Time{
+					Time: time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC),
+				},
+			},
+			expected: "Project is in maintenance mode until 2024-03-01T12:00:00Z: incident in progress",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, testCase.mode.Describe())
+		})
+	}
+}
+
+func TestResolveVars(t *testing.T) {
+	testProject := &Project{
+		Spec: &ProjectSpec{
+			Vars: []ExpressionVariable{
+				{Name: "registry", Value: "registry.example.com"},
+				{Name: "team", Value: "platform"},
+			},
+		},
+	}
+	testStage := &Stage{
+		Spec: StageSpec{
+			Vars: []ExpressionVariable{
+				{Name: "registry", Value: "prod-registry.example.com"},
+				{Name: "env", Value: "prod"},
+			},
+		},
+	}
+	testCases := []struct {
+		name     string
+		project  *Project
+		stage    *Stage
+		expected map[string]string
+	}{
+		{
+			name:     "no Project or Stage",
+			expected: map[string]string{},
+		},
+		{
+			name:     "Project without spec",
+			project:  &Project{},
+			expected: map[string]string{},
+		},
+		{
+			name:    "Project vars only",
+			project: testProject,
+			expected: map[string]string{
+				"registry": "registry.example.com",
+				"team":     "platform",
+			},
+		},
+		{
+			name:  "Stage vars only",
+			stage: testStage,
+			expected: map[string]string{
+				"registry": "prod-registry.example.com",
+				"env":      "prod",
+			},
+		},
+		{
+			name:    "Stage vars override Project vars",
+			project: testProject,
+			stage:   testStage,
+			expected: map[string]string{
+				"registry": "prod-registry.example.com",
+				"team":     "platform",
+				"env":      "prod",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, ResolveVars(testCase.project, testCase.stage))
+		})
+	}
+}
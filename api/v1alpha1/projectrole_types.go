@@ -0,0 +1,89 @@
+package v1alpha1
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ProjectRoleConditionTypeReady denotes whether the ServiceAccount, Role,
+	// and RoleBinding underlying a ProjectRole reflect its spec.
+	ProjectRoleConditionTypeReady = "Ready"
+
+	// ProjectRoleConditionReasonSynced is the reason for a Ready condition with
+	// a status of True.
+	ProjectRoleConditionReasonSynced = "Synced"
+	// ProjectRoleConditionReasonSyncFailed is the reason for a Ready condition
+	// with a status of False.
+	ProjectRoleConditionReasonSyncFailed = "SyncFailed"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name=Ready,type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name=Age,type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ProjectRole is a resource type that declaratively defines a Kargo Role within
+// a Project's namespace. It reconciles to a ServiceAccount, Role, and
+// RoleBinding of the same name, which grant the rules it specifies to any user
+// whose OIDC claims match the subjects it specifies. This permits a Project's
+// access control to be managed alongside its Stages and Warehouses instead of
+// imperatively.
+type ProjectRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// Spec describes the Kargo Role.
+	//
+	// +kubebuilder:validation:Required
+	Spec ProjectRoleSpec `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+	// Status describes the ProjectRole's current status.
+	Status ProjectRoleStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+func (p *ProjectRole) GetStatus() *ProjectRoleStatus {
+	return &p.Status
+}
+
+// ProjectRoleSpec describes a Kargo Role.
+type ProjectRoleSpec struct {
+	// Subjects specifies the users to whom the Kargo Role is granted.
+	Subjects ProjectRoleSubjects `json:"subjects,omitempty" protobuf:"bytes,1,opt,name=subjects"`
+	// Rules specifies the permissions the Kargo Role grants. Only resource types
+	// that Kargo Roles support may be referenced and the API groups of the rules
+	// are ignored in favor of each resource type's own API group.
+	Rules []rbacv1.PolicyRule `json:"rules,omitempty" protobuf:"bytes,2,rep,name=rules"`
+}
+
+// ProjectRoleSubjects specifies users by their OIDC claims. A user matching any
+// of the specified claims is granted the Kargo Role.
+type ProjectRoleSubjects struct {
+	// Subs is a list of OIDC subject claims.
+	Subs []string `json:"subs,omitempty" protobuf:"bytes,1,rep,name=subs"`
+	// Emails is a list of OIDC email claims.
+	Emails []string `json:"emails,omitempty" protobuf:"bytes,2,rep,name=emails"`
+	// Groups is a list of OIDC groups claims.
+	Groups []string `json:"groups,omitempty" protobuf:"bytes,3,rep,name=groups"`
+}
+
+// ProjectRoleStatus describes a ProjectRole's current status.
+type ProjectRoleStatus struct {
+	// ObservedGeneration represents the .metadata.generation that this
+	// ProjectRole was reconciled against.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" protobuf:"varint,1,opt,name=observedGeneration"`
+	// Conditions contains the last observations of the ProjectRole's current
+	// state.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchMergeKey:"type" patchStrategy:"merge" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProjectRoleList is a list of ProjectRole resources.
+type ProjectRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	Items           []ProjectRole `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
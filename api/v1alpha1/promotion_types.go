@@ -103,6 +103,40 @@ type PromotionStatus struct {
 	Metadata map[string]string `json:"metadata,omitempty" protobuf:"bytes,3,rep,name=metadata" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Freight is the detail of the piece of freight that was referenced by this promotion.
 	Freight *FreightReference `json:"freight,omitempty" protobuf:"bytes,5,opt,name=freight"`
+	// PromotionMechanisms is a snapshot of the Stage's promotion mechanisms as
+	// of when this Promotion was executed. This permits the configuration a
+	// Promotion was executed with to be compared to that of other Promotions
+	// even after the Stage has been modified.
+	PromotionMechanisms *PromotionMechanisms `json:"promotionMechanisms,omitempty" protobuf:"bytes,6,opt,name=promotionMechanisms"`
+	// PromotionMechanismsRevision is the revision of the Stage's promotion
+	// mechanisms that this Promotion was executed with. Promotions executed with
+	// identical promotion mechanisms have the same revision, so that a change to
+	// a Stage's promotion mechanisms can be spotted without comparing snapshots.
+	PromotionMechanismsRevision string `json:"promotionMechanismsRevision,omitempty" protobuf:"bytes,10,opt,name=promotionMechanismsRevision"`
+	// FinishedAt is the time at which the Promotion reached a terminal phase.
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty" protobuf:"bytes,7,opt,name=finishedAt"`
+	// PrePromotionHooks records, in order, the outcome of each of the Stage's
+	// PrePromotionHooks that has been executed.
+	PrePromotionHooks []PromotionHookStatus `json:"prePromotionHooks,omitempty" protobuf:"bytes,8,rep,name=prePromotionHooks"`
+	// PostPromotionHooks records, in order, the outcome of each of the Stage's
+	// PostPromotionHooks that has been executed.
+	PostPromotionHooks []PromotionHookStatus `json:"postPromotionHooks,omitempty" protobuf:"bytes,9,rep,name=postPromotionHooks"`
+}
+
+// PromotionHookStatus describes the outcome of the execution of a
+// PromotionHook.
+type PromotionHookStatus struct {
+	// Name identifies the hook. It is the name of the Project's PromotionHook
+	// that was referenced, if any, the URL the hook sent a request to, or the
+	// host on which the hook executed a command.
+	Name string `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	// Succeeded indicates whether the hook succeeded.
+	Succeeded bool `json:"succeeded,omitempty" protobuf:"varint,2,opt,name=succeeded"`
+	// Message describes why the hook failed, if it did.
+	Message string `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
+	// Output is the combined standard output and standard error of the command
+	// executed by an SSH hook. Only the last 4 KiB of output are retained.
+	Output string `json:"output,omitempty" protobuf:"bytes,4,opt,name=output"`
 }
 
 // WithPhase returns a copy of PromotionStatus with the given phase
@@ -0,0 +1,135 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type PromotionPlanPhase string
+
+const (
+	// PromotionPlanPhasePending denotes a PromotionPlan whose Stages have not
+	// yet been determined.
+	PromotionPlanPhasePending PromotionPlanPhase = "Pending"
+	// PromotionPlanPhaseRunning denotes a PromotionPlan that is promoting its
+	// Freight through its Stages.
+	PromotionPlanPhaseRunning PromotionPlanPhase = "Running"
+	// PromotionPlanPhaseSucceeded denotes a PromotionPlan whose Freight has
+	// been promoted to and verified in every one of its Stages.
+	PromotionPlanPhaseSucceeded PromotionPlanPhase = "Succeeded"
+	// PromotionPlanPhaseFailed denotes a PromotionPlan that was abandoned
+	// because its Freight could not be promoted to or verified in one of its
+	// Stages.
+	PromotionPlanPhaseFailed PromotionPlanPhase = "Failed"
+)
+
+// IsTerminal returns true if the PromotionPlanPhase is a terminal one.
+func (p *PromotionPlanPhase) IsTerminal() bool {
+	switch *p {
+	case PromotionPlanPhaseSucceeded, PromotionPlanPhaseFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+type PromotionPlanStepPhase string
+
+const (
+	// PromotionPlanStepPhasePending denotes a step that has not yet been
+	// started because the Freight has not yet been verified in the Stage of
+	// the previous step.
+	PromotionPlanStepPhasePending PromotionPlanStepPhase = "Pending"
+	// PromotionPlanStepPhasePromoting denotes a step whose Promotion has not
+	// yet completed.
+	PromotionPlanStepPhasePromoting PromotionPlanStepPhase = "Promoting"
+	// PromotionPlanStepPhaseVerifying denotes a step whose Promotion has
+	// succeeded and that is waiting for the Freight to be verified in the
+	// Stage.
+	PromotionPlanStepPhaseVerifying PromotionPlanStepPhase = "Verifying"
+	// PromotionPlanStepPhaseSucceeded denotes a step whose Freight has been
+	// verified in the Stage.
+	PromotionPlanStepPhaseSucceeded PromotionPlanStepPhase = "Succeeded"
+	// PromotionPlanStepPhaseFailed denotes a step whose Promotion or
+	// verification failed.
+	PromotionPlanStepPhaseFailed PromotionPlanStepPhase = "Failed"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name=Freight,type=string,JSONPath=`.spec.freight`
+// +kubebuilder:printcolumn:name=Stage,type=string,JSONPath=`.spec.stage`
+// +kubebuilder:printcolumn:name=Phase,type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name=Age,type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PromotionPlan represents a request to promote a piece of Freight all the way
+// to a Stage, through each of the intermediate Stages between the Stages the
+// Freight is already verified in and that Stage. The Freight is promoted to
+// each intermediate Stage in turn, and only once it has been verified in the
+// previous one. The progress of the whole pipeline is tracked in the
+// PromotionPlan's status.
+type PromotionPlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// Spec describes the desired promotion of the Freight.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:message="spec is immutable",rule="self == oldSelf"
+	Spec PromotionPlanSpec `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+	// Status describes the progress of the promotion of the Freight.
+	Status PromotionPlanStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+func (p *PromotionPlan) GetStatus() *PromotionPlanStatus {
+	return &p.Status
+}
+
+// PromotionPlanSpec describes the desired promotion of a piece of Freight.
+type PromotionPlanSpec struct {
+	// Freight specifies the name of the piece of Freight to be promoted. This is
+	// a required field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Freight string `json:"freight" protobuf:"bytes,1,opt,name=freight"`
+	// Stage specifies the name of the last Stage the Freight is to be promoted
+	// to. This is a required field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Stage string `json:"stage" protobuf:"bytes,2,opt,name=stage"`
+}
+
+// PromotionPlanStatus describes the progress of a PromotionPlan.
+type PromotionPlanStatus struct {
+	// Phase describes where the PromotionPlan currently is in its lifecycle.
+	Phase PromotionPlanPhase `json:"phase,omitempty" protobuf:"bytes,1,opt,name=phase"`
+	// Message is a display message about the PromotionPlan, including any
+	// errors preventing it from progressing.
+	Message string `json:"message,omitempty" protobuf:"bytes,2,opt,name=message"`
+	// Steps describes, in order, the Stages the Freight is promoted to and the
+	// progress of each of those promotions. The Steps are determined when the
+	// PromotionPlan is first reconciled.
+	Steps []PromotionPlanStep `json:"steps,omitempty" protobuf:"bytes,3,rep,name=steps"`
+	// FinishedAt is the time when the PromotionPlan succeeded or failed.
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty" protobuf:"bytes,4,opt,name=finishedAt"`
+}
+
+// PromotionPlanStep describes the promotion of a PromotionPlan's Freight to
+// one Stage.
+type PromotionPlanStep struct {
+	// Stage is the name of the Stage the Freight is promoted to.
+	Stage string `json:"stage" protobuf:"bytes,1,opt,name=stage"`
+	// Phase describes where the step currently is in its lifecycle.
+	Phase PromotionPlanStepPhase `json:"phase,omitempty" protobuf:"bytes,2,opt,name=phase"`
+	// Promotion is the name of the Promotion that promotes the Freight to the
+	// Stage. It is empty if the step has not yet been started or if the
+	// Freight did not need to be promoted to the Stage.
+	Promotion string `json:"promotion,omitempty" protobuf:"bytes,3,opt,name=promotion"`
+}
+
+// +kubebuilder:object:root=true
+
+// PromotionPlanList is a list of PromotionPlan resources.
+type PromotionPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	Items           []PromotionPlan `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
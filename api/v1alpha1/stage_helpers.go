@@ -146,6 +146,11 @@ func ReverifyStageFreight(
 	if curFreight.VerificationInfo.ID == "" {
 		return fmt.Errorf("stage verification info has no ID")
 	}
+	if !curFreight.VerificationInfo.Phase.IsTerminal() {
+		// The controller only honors requests to rerun a verification that has
+		// completed, so refuse the request rather than have it silently ignored.
+		return errors.New("stage verification is still in progress")
+	}
 
 	rr := VerificationRequest{
 		ID: curFreight.VerificationInfo.ID,
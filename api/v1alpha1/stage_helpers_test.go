@@ -266,6 +266,31 @@ func TestReverifyStageFreight(t *testing.T) {
 		require.ErrorContains(t, err, "stage verification info has no ID")
 	})
 
+	t.Run("verification in progress", func(t *testing.T) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "fake-stage",
+					Namespace: "fake-namespace",
+				},
+				Status: StageStatus{
+					CurrentFreight: &FreightReference{
+						VerificationInfo: &VerificationInfo{
+							ID:    "fake-id",
+							Phase: VerificationPhaseRunning,
+						},
+					},
+				},
+			},
+		).Build()
+
+		err := ReverifyStageFreight(context.TODO(), c, types.NamespacedName{
+			Namespace: "fake-namespace",
+			Name:      "fake-stage",
+		})
+		require.ErrorContains(t, err, "stage verification is still in progress")
+	})
+
 	t.Run("success", func(t *testing.T) {
 		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
 			&Stage{
@@ -276,7 +301,8 @@ func TestReverifyStageFreight(t *testing.T) {
 				Status: StageStatus{
 					CurrentFreight: &FreightReference{
 						VerificationInfo: &VerificationInfo{
-							ID: "fake-id",
+							ID:    "fake-id",
+							Phase: VerificationPhaseSuccessful,
 						},
 					},
 				},
@@ -1,6 +1,10 @@
 package v1alpha1
 
 import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -18,6 +22,64 @@ const (
 	StagePhaseVerifying StagePhase = "Verifying"
 )
 
+const (
+	// StageConditionTypeDrifted denotes that the targets of a Stage's promotion
+	// mechanisms have been changed outside of Kargo since the Stage's last
+	// successful Promotion.
+	StageConditionTypeDrifted = "Drifted"
+
+	// StageConditionReasonDriftDetected is the reason for a Drifted condition
+	// with a status of True.
+	StageConditionReasonDriftDetected = "DriftDetected"
+	// StageConditionReasonNoDrift is the reason for a Drifted condition with a
+	// status of False.
+	StageConditionReasonNoDrift = "NoDrift"
+	// StageConditionReasonDriftDetectionFailed is the reason for a Drifted
+	// condition with a status of Unknown.
+	StageConditionReasonDriftDetectionFailed = "DriftDetectionFailed"
+
+	// StageConditionTypeArtifactsUnavailable denotes that the Stage's current
+	// Freight, or Freight in its history, references artifacts that can no
+	// longer be found upstream. Rolling back to affected Freight is likely to
+	// fail.
+	StageConditionTypeArtifactsUnavailable = "ArtifactsUnavailable"
+
+	// StageConditionReasonFreightArtifactsNotFound is the reason for an
+	// ArtifactsUnavailable condition with a status of True.
+	StageConditionReasonFreightArtifactsNotFound = "FreightArtifactsNotFound"
+
+	// StageConditionTypeQualified denotes whether the Stage's QualificationHook
+	// has permitted the Stage's current Freight to be marked as verified in the
+	// Stage.
+	StageConditionTypeQualified = "Qualified"
+
+	// StageConditionReasonQualificationAllowed is the reason for a Qualified
+	// condition with a status of True.
+	StageConditionReasonQualificationAllowed = "QualificationAllowed"
+	// StageConditionReasonQualificationDenied is the reason for a Qualified
+	// condition with a status of False.
+	StageConditionReasonQualificationDenied = "QualificationDenied"
+	// StageConditionReasonQualificationFailed is the reason for a Qualified
+	// condition with a status of Unknown.
+	StageConditionReasonQualificationFailed = "QualificationFailed"
+
+	// StageConditionTypeImagePullSecretsSynced denotes whether the image pull
+	// Secrets described by the Stage's ImagePullSecrets field are populated
+	// with the credentials for the images referenced by its current Freight.
+	StageConditionTypeImagePullSecretsSynced = "ImagePullSecretsSynced"
+
+	// StageConditionReasonImagePullSecretsSynced is the reason for an
+	// ImagePullSecretsSynced condition with a status of True.
+	StageConditionReasonImagePullSecretsSynced = "ImagePullSecretsSynced"
+	// StageConditionReasonImagePullSecretsSyncFailed is the reason for an
+	// ImagePullSecretsSynced condition with a status of False due to an error.
+	StageConditionReasonImagePullSecretsSyncFailed = "ImagePullSecretsSyncFailed"
+	// StageConditionReasonImagePullSecretsDisabled is the reason for an
+	// ImagePullSecretsSynced condition with a status of False because
+	// maintenance of image pull Secrets is disabled on the Kargo controller.
+	StageConditionReasonImagePullSecretsDisabled = "ImagePullSecretsDisabled"
+)
+
 type VerificationPhase string
 
 // Note: VerificationPhases are identical to AnalysisRunPhases. In almost all
@@ -59,7 +121,7 @@ func (v *VerificationPhase) IsTerminal() bool {
 	}
 }
 
-// +kubebuilder:validation:Enum={ImageAndTag,Tag,ImageAndDigest,Digest}
+// +kubebuilder:validation:Enum={ImageAndTag,Tag,ImageAndDigest,Digest,ImageAndTagAndDigest,TagAndDigest}
 type ImageUpdateValueType string
 
 const (
@@ -67,6 +129,14 @@ const (
 	ImageUpdateValueTypeTag            ImageUpdateValueType = "Tag"
 	ImageUpdateValueTypeImageAndDigest ImageUpdateValueType = "ImageAndDigest"
 	ImageUpdateValueTypeDigest         ImageUpdateValueType = "Digest"
+	// ImageUpdateValueTypeImageAndTagAndDigest results in a digest-pinned image
+	// reference that retains the tag for readability, e.g.
+	// "nginx:1.25.0@sha256:...".
+	ImageUpdateValueTypeImageAndTagAndDigest ImageUpdateValueType = "ImageAndTagAndDigest"
+	// ImageUpdateValueTypeTagAndDigest results in a digest-pinned tag, e.g.
+	// "1.25.0@sha256:...", for charts that combine a separately configured image
+	// name with a tag.
+	ImageUpdateValueTypeTagAndDigest ImageUpdateValueType = "TagAndDigest"
 )
 
 type HealthState string
@@ -118,6 +188,7 @@ const (
 // +kubebuilder:printcolumn:name=Current Freight,type=string,JSONPath=`.status.currentFreight.name`
 // +kubebuilder:printcolumn:name=Health,type=string,JSONPath=`.status.health.status`
 // +kubebuilder:printcolumn:name=Phase,type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name=Ready,type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:printcolumn:name=Age,type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Stage is the Kargo API's main type.
@@ -161,6 +232,213 @@ type StageSpec struct {
 	// Verification describes how to verify a Stage's current Freight is fit for
 	// promotion downstream.
 	Verification *Verification `json:"verification,omitempty" protobuf:"bytes,3,opt,name=verification"`
+	// QualificationHook describes an external service that is consulted before
+	// the Stage's current Freight is marked as verified in the Stage, thereby
+	// qualifying it for promotion to downstream Stages. This is an optional
+	// field. When not specified, Freight is marked as verified as soon as the
+	// Stage is healthy and any verification has succeeded.
+	QualificationHook *QualificationHook `json:"qualificationHook,omitempty" protobuf:"bytes,9,opt,name=qualificationHook"`
+	// DriftDetection describes whether and how to detect changes made outside of
+	// Kargo to the targets of the Stage's promotion mechanisms since the Stage's
+	// last successful Promotion. This is an optional field. When not specified,
+	// drift is not detected.
+	DriftDetection *DriftDetection `json:"driftDetection,omitempty" protobuf:"bytes,5,opt,name=driftDetection"`
+	// HealthChecks describes any HTTP endpoints that should be checked to assess
+	// the Stage's health and how tolerant the Stage is of failed health checks.
+	// This is an optional field. When not specified, the Stage is marked
+	// Unhealthy as soon as a single health check fails.
+	HealthChecks *HealthChecks `json:"healthChecks,omitempty" protobuf:"bytes,6,opt,name=healthChecks"`
+	// GitProviderNotifications describes whether and how the Git hosting
+	// providers (e.g. GitHub or GitLab) of repositories whose commits are
+	// referenced by Freight are notified when that Freight is promoted to the
+	// Stage. This is an optional field. When not specified, no notifications are
+	// sent.
+	GitProviderNotifications *GitProviderNotifications `json:"gitProviderNotifications,omitempty" protobuf:"bytes,7,opt,name=gitProviderNotifications"`
+	// Vars defines variables that are available, as vars.<name>, to the
+	// expressions evaluated during the promotion of Freight to the Stage. These
+	// take precedence over any variables of the same name defined by the
+	// Stage's Project.
+	//
+	// +listType=map
+	// +listMapKey=name
+	Vars []ExpressionVariable `json:"vars,omitempty" protobuf:"bytes,8,rep,name=vars"`
+	// ImagePullSecrets describes Secrets, in the namespaces to which the Stage
+	// deploys, that the Kargo controller should keep populated with the same
+	// credentials it uses to discover the images referenced by the Stage's
+	// current Freight. This keeps the credentials used to pull those images at
+	// runtime in lockstep with the credentials used to discover them, e.g. when
+	// tokens are rotated. This is only honored if maintenance of image pull
+	// Secrets has been enabled in the Kargo controller's configuration. This is
+	// an optional field.
+	//
+	// +kubebuilder:validation:Optional
+	ImagePullSecrets []ImagePullSecret `json:"imagePullSecrets,omitempty" protobuf:"bytes,10,rep,name=imagePullSecrets"`
+}
+
+// ImagePullSecret describes a Secret of type kubernetes.io/dockerconfigjson
+// that is maintained by the Kargo controller on behalf of a Stage.
+type ImagePullSecret struct {
+	// Cluster is the name or API server URL of a cluster registered with Argo
+	// CD in which the Secret should be maintained. Kargo connects to that
+	// cluster using the details in the corresponding Argo CD cluster Secret.
+	// This field is optional. When left unspecified, the Secret is maintained in
+	// the same cluster as Kargo.
+	//
+	// +kubebuilder:validation:Optional
+	Cluster string `json:"cluster,omitempty" protobuf:"bytes,1,opt,name=cluster"`
+	// Namespace is the namespace in which the Secret should be maintained. This
+	// is a required field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	Namespace string `json:"namespace" protobuf:"bytes,2,opt,name=namespace"`
+	// Name is the name of the Secret. If a Secret by this name already exists,
+	// it must have been created by Kargo for the same Stage. This is a required
+	// field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$
+	Name string `json:"name" protobuf:"bytes,3,opt,name=name"`
+	// ServiceAccounts is a list of names of ServiceAccounts in the namespace
+	// that should reference the Secret as one of their imagePullSecrets. This
+	// field is optional.
+	//
+	// +kubebuilder:validation:Optional
+	ServiceAccounts []string `json:"serviceAccounts,omitempty" protobuf:"bytes,4,rep,name=serviceAccounts"`
+}
+
+// GitProviderNotifications describes how Git hosting providers are notified
+// when Freight referencing commits in the repositories they host is promoted to
+// or verified in a Stage. Credentials for each repository are looked up the same way as for
+// Git-based promotion mechanisms and must permit the notifications to be
+// posted.
+type GitProviderNotifications struct {
+	// Environment is the name of the environment reported to Git hosting
+	// providers. This is an optional field. When not specified, the name of the
+	// Stage is used.
+	Environment string `json:"environment,omitempty" protobuf:"bytes,1,opt,name=environment"`
+	// DeploymentStatuses indicates whether the outcome of each Promotion to the
+	// Stage is recorded as the status of a deployment of each commit referenced
+	// by the promoted Freight (e.g. using GitHub Deployments or GitLab
+	// environments).
+	DeploymentStatuses bool `json:"deploymentStatuses,omitempty" protobuf:"varint,2,opt,name=deploymentStatuses"`
+	// PullRequestComments indicates whether the pull requests that introduced
+	// each commit referenced by the promoted Freight are commented on when a
+	// Promotion to the Stage succeeds.
+	PullRequestComments bool `json:"pullRequestComments,omitempty" protobuf:"varint,3,opt,name=pullRequestComments"`
+	// RepoURLs optionally restricts notifications to the repositories with the
+	// specified URLs. When not specified, the Git hosting providers of all
+	// repositories whose commits are referenced by the promoted Freight are
+	// notified.
+	RepoURLs []string `json:"repoURLs,omitempty" protobuf:"bytes,4,rep,name=repoURLs"`
+	// CommitStatuses indicates whether the outcome of verifying Freight in the
+	// Stage is recorded as a status of each commit referenced by the Freight
+	// (e.g. using GitHub commit statuses or GitLab pipeline statuses). Each
+	// status is named kargo/<environment>, so that statuses from different
+	// Stages are distinguishable on the same commit.
+	CommitStatuses bool `json:"commitStatuses,omitempty" protobuf:"varint,5,opt,name=commitStatuses"`
+}
+
+// HealthChecks describes how tolerant a Stage is of failed health checks. This
+// is useful for preventing the brief disruption that commonly follows a
+// Promotion, such as Pods being replaced, from marking the Stage Unhealthy.
+// While a failed health check is tolerated, the Stage is marked Progressing
+// instead of Unhealthy.
+type HealthChecks struct {
+	// GracePeriod is the period following the Stage's last successful
+	// Promotion during which failed health checks are tolerated. e.g. "2m".
+	// This field is optional.
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty" protobuf:"bytes,1,opt,name=gracePeriod"`
+	// FailureThreshold is the number of consecutive health checks that must fail
+	// before the Stage is marked Unhealthy. This field is optional. When left
+	// unspecified, a single failed health check marks the Stage Unhealthy once
+	// any GracePeriod has elapsed.
+	//
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold int32 `json:"failureThreshold,omitempty" protobuf:"varint,2,opt,name=failureThreshold"`
+	// HTTP describes HTTP endpoints that are checked, in addition to any Argo CD
+	// Applications updated by the Stage's promotion mechanisms, to assess the
+	// Stage's health. This permits the health of Stages that are not deployed
+	// using Argo CD to be assessed. This field is optional.
+	//
+	// +kubebuilder:validation:Optional
+	HTTP []HTTPHealthCheck `json:"http,omitempty" protobuf:"bytes,3,rep,name=http"`
+}
+
+// HTTPHealthCheck describes an HTTP endpoint that is checked to assess the
+// health of a Stage. The endpoint is deemed healthy if it responds with one of
+// the expected status codes and, if specified, a body matching the expected
+// pattern.
+type HTTPHealthCheck struct {
+	// URL is the URL of the endpoint. It may contain expressions, enclosed in
+	// ${{ and }}, that reference the Stage's current Freight. This is a required
+	// field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url" protobuf:"bytes,1,opt,name=url"`
+	// Method is the HTTP method used to check the endpoint. This field is
+	// optional. When left unspecified, GET is used.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=GET;HEAD
+	Method string `json:"method,omitempty" protobuf:"bytes,2,opt,name=method"`
+	// ExpectedStatusCodes are the status codes the endpoint is expected to
+	// respond with. This field is optional. When left unspecified, any 2xx status
+	// code is expected.
+	//
+	// +kubebuilder:validation:Optional
+	ExpectedStatusCodes []int32 `json:"expectedStatusCodes,omitempty" protobuf:"varint,3,rep,name=expectedStatusCodes"`
+	// ExpectedBody is a regular expression the body of the endpoint's response
+	// is expected to match. It may contain expressions, enclosed in ${{ and }},
+	// that reference the Stage's current Freight. This permits, for instance,
+	// verifying that the endpoint reports the version of an image that was
+	// promoted. This field is optional.
+	//
+	// +kubebuilder:validation:Optional
+	ExpectedBody string `json:"expectedBody,omitempty" protobuf:"bytes,4,opt,name=expectedBody"`
+	// Timeout is the maximum amount of time to wait for the endpoint to respond.
+	// This field is optional. When left unspecified, a timeout of 10 seconds is
+	// used.
+	//
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" protobuf:"bytes,5,opt,name=timeout"`
+	// InsecureSkipTLSVerify specifies whether certificate verification errors
+	// should be ignored when checking the endpoint. This field is optional.
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty" protobuf:"varint,6,opt,name=insecureSkipTLSVerify"`
+}
+
+// QualificationHook describes an external service that decides whether a
+// Stage's current Freight may be marked as verified in the Stage. The service
+// is sent an HTTP POST request whose JSON body describes the Stage, the Freight,
+// and the results of the Freight's verification, and must respond with a 2xx
+// status code and a JSON body of the form {"allowed": <bool>, "reason":
+// <string>}.
+type QualificationHook struct {
+	// URL is the URL of the service. This is a required field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url" protobuf:"bytes,1,opt,name=url"`
+	// Timeout is the maximum amount of time to wait for the service to respond.
+	// This field is optional. When left unspecified, a timeout of 10 seconds is
+	// used.
+	//
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" protobuf:"bytes,2,opt,name=timeout"`
+	// InsecureSkipTLSVerify specifies whether certificate verification errors
+	// should be ignored when calling the service. This field is optional.
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty" protobuf:"varint,3,opt,name=insecureSkipTLSVerify"`
+}
+
+// DriftDetection describes whether and how to detect changes made outside of
+// Kargo to the targets of a Stage's promotion mechanisms.
+type DriftDetection struct {
+	// Enabled indicates whether drift detection is enabled for the Stage. When
+	// enabled, the Stage controller periodically compares the head of every Git
+	// branch written to by the Stage's GitRepoUpdates and the target revision of
+	// every Argo CD Application source updated by the Stage's ArgoCDAppUpdates
+	// to what the Stage's last successful Promotion wrote. Any discrepancy is
+	// reflected by the Stage's Drifted condition.
+	Enabled bool `json:"enabled,omitempty" protobuf:"varint,1,opt,name=enabled"`
 }
 
 // Subscriptions describes a Stage's sources of Freight.
@@ -168,11 +446,38 @@ type Subscriptions struct {
 	// Warehouse is a subscription to a Warehouse. This field is mutually
 	// exclusive with the UpstreamStages field.
 	Warehouse string `json:"warehouse,omitempty" protobuf:"bytes,1,opt,name=warehouse"`
+	// Service optionally limits the subscription to a Warehouse to the Freight
+	// that the Warehouse produces for the named service. This is only useful
+	// when the Warehouse has a Git subscription that specifies Services. This
+	// field may only be used in conjunction with the Warehouse field.
+	Service string `json:"service,omitempty" protobuf:"bytes,3,opt,name=service"`
 	// UpstreamStages identifies other Stages as potential sources of Freight
 	// for this Stage. This field is mutually exclusive with the Repos field.
 	UpstreamStages []StageSubscription `json:"upstreamStages,omitempty" protobuf:"bytes,2,rep,name=upstreamStages"`
+	// Artifacts optionally limits the kinds of artifacts the Stage consumes.
+	// Accepted values are "Git", "Image", "Chart", and "Package". When
+	// specified, Freight is only automatically promoted to the Stage if it
+	// differs from the Stage's current Freight in at least one artifact of the
+	// listed kinds. This prevents, for instance, Freight that only updates a
+	// chart from being promoted to a Stage that vendors its chart separately.
+	// Manual promotions are unaffected. When left unspecified, the Stage
+	// consumes artifacts of all kinds.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Artifacts []ArtifactKind `json:"artifacts,omitempty" protobuf:"bytes,4,rep,name=artifacts"`
 }
 
+// +kubebuilder:validation:Enum=Git;Image;Chart;Package
+type ArtifactKind string
+
+const (
+	ArtifactKindGit     ArtifactKind = "Git"
+	ArtifactKindImage   ArtifactKind = "Image"
+	ArtifactKindChart   ArtifactKind = "Chart"
+	ArtifactKindPackage ArtifactKind = "Package"
+)
+
 // StageSubscription defines a subscription to Freight from another Stage.
 type StageSubscription struct {
 	// Name specifies the name of a Stage.
@@ -194,6 +499,168 @@ type PromotionMechanisms struct {
 	// updates specified by the GitRepoUpdates field, if any, are applied BEFORE
 	// these.
 	ArgoCDAppUpdates []ArgoCDAppUpdate `json:"argoCDAppUpdates,omitempty" protobuf:"bytes,2,rep,name=argoCDAppUpdates"`
+	// ArgoRolloutUpdates describes updates that should be applied to Argo
+	// Rollouts Rollout resources to drive their progress as part of
+	// incorporating Freight into the Stage. This field is optional, as such
+	// actions are not required in all cases. Note that all updates specified by
+	// the GitRepoUpdates and ArgoCDAppUpdates fields, if any, are applied BEFORE
+	// these.
+	ArgoRolloutUpdates []ArgoRolloutUpdate `json:"argoRolloutUpdates,omitempty" protobuf:"bytes,3,rep,name=argoRolloutUpdates"`
+	// PrePromotionHooks describes actions, e.g. scaling down workloads that
+	// should not run while Freight is being promoted, that should be taken, in
+	// order, BEFORE any of the other promotion mechanisms are executed. They are
+	// executed only once per Promotion. If any of them fails, the Promotion
+	// fails without any of the other promotion mechanisms being executed. This
+	// field is optional.
+	PrePromotionHooks []PromotionHook `json:"prePromotionHooks,omitempty" protobuf:"bytes,4,rep,name=prePromotionHooks"`
+	// PostPromotionHooks describes actions, e.g. warming caches, that should be
+	// taken, in order, AFTER all of the other promotion mechanisms have
+	// succeeded. If any of them fails, the Promotion fails, unless the hook's
+	// ContinueOnFailure field is true. This field is optional.
+	PostPromotionHooks []PromotionHook `json:"postPromotionHooks,omitempty" protobuf:"bytes,5,rep,name=postPromotionHooks"`
+}
+
+// Revision returns an identifier of the configuration described by the
+// PromotionMechanisms. Identical configurations always have the same revision.
+// It is the first 12 characters of the hex-encoded SHA-256 hash of the
+// PromotionMechanisms' JSON representation. An empty string is returned if the
+// PromotionMechanisms are nil.
+func (p *PromotionMechanisms) Revision() string {
+	if p == nil {
+		return ""
+	}
+	// Marshaling cannot fail for a type composed only of strings, numbers,
+	// booleans, slices, maps with string keys, and pointers to such.
+	data, _ := json.Marshal(p)
+	return fmt.Sprintf("%x", sha256.Sum256(data))[:12]
+}
+
+// PromotionHook describes an action to be taken before or after the other
+// promotion mechanisms of a Stage are executed. Exactly one of the HTTP, SSH,
+// and ProjectHook fields must be specified.
+type PromotionHook struct {
+	// HTTP describes an HTTP request to be sent.
+	//
+	// +kubebuilder:validation:Optional
+	HTTP *HTTPPromotionHook `json:"http,omitempty" protobuf:"bytes,1,opt,name=http"`
+	// ProjectHook references, by name, one of the Project's PromotionHooks. This
+	// permits the same action to be reused by many Stages.
+	//
+	// +kubebuilder:validation:Optional
+	ProjectHook string `json:"projectHook,omitempty" protobuf:"bytes,2,opt,name=projectHook"`
+	// ContinueOnFailure specifies whether the Promotion should proceed even if
+	// the hook fails. The value in this field only has any effect for
+	// PostPromotionHooks. A failed pre-promotion hook always fails the
+	// Promotion.
+	ContinueOnFailure bool `json:"continueOnFailure,omitempty" protobuf:"varint,3,opt,name=continueOnFailure"`
+	// SSH describes a command to be executed on a remote host over SSH. This
+	// permits workloads deployed to virtual machines, rather than to
+	// Kubernetes, to be updated as part of a Promotion.
+	//
+	// +kubebuilder:validation:Optional
+	SSH *SSHPromotionHook `json:"ssh,omitempty" protobuf:"bytes,4,opt,name=ssh"`
+}
+
+// SSHPromotionHookUsernameSecretKey is the key within a Secret referenced by
+// an SSHPromotionHook under which the name of the user to authenticate as is
+// stored.
+const SSHPromotionHookUsernameSecretKey = "username"
+
+// SSHPromotionHookPrivateKeySecretKey is the key within a Secret referenced by
+// an SSHPromotionHook under which the private key to authenticate with is
+// stored.
+const SSHPromotionHookPrivateKeySecretKey = "privateKey"
+
+// SSHPromotionHookKnownHostsSecretKey is the key within a Secret referenced by
+// an SSHPromotionHook under which the public keys of the host, in the format of
+// an OpenSSH known_hosts file, are stored.
+const SSHPromotionHookKnownHostsSecretKey = "knownHosts"
+
+// SSHPromotionHook describes a command executed on a remote host over SSH by a
+// PromotionHook.
+type SSHPromotionHook struct {
+	// Host is the address of the host, optionally followed by a colon and a
+	// port. When no port is specified, port 22 is used.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host" protobuf:"bytes,1,opt,name=host"`
+	// SecretName is the name of a Secret in the Project's namespace that stores
+	// the name of the user to authenticate as under the key "username", the
+	// private key to authenticate with under the key "privateKey", and the
+	// public keys of the host, in the format of an OpenSSH known_hosts file,
+	// under the key "knownHosts". The host's key is always verified.
+	//
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName" protobuf:"bytes,2,opt,name=secretName"`
+	// Command is the command to be executed. It may contain expressions, as
+	// described for the URL field of HTTPPromotionHook. Values substituted into
+	// the command are not quoted. The hook fails if the command exits with a
+	// non-zero status.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Command string `json:"command" protobuf:"bytes,3,opt,name=command"`
+	// Timeout is the maximum amount of time the command may take to complete.
+	// This field is optional. When left unspecified, a timeout of 1 minute is
+	// used.
+	//
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" protobuf:"bytes,4,opt,name=timeout"`
+}
+
+// HTTPPromotionHook describes an HTTP request sent by a PromotionHook.
+type HTTPPromotionHook struct {
+	// URL is the URL to send the request to. It may contain expressions,
+	// enclosed in ${{ and }}, that have access to the following:
+	//
+	//   - ctx: an object with project, stage, and promotion fields.
+	//   - freight: the Freight being promoted, with name, warehouse, commits,
+	//     images, and charts fields.
+	//   - vars: the variables defined by the Project and Stage.
+	//
+	// This is a required field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url" protobuf:"bytes,1,opt,name=url"`
+	// Method is the HTTP method of the request. This field is optional. When
+	// left unspecified, POST is used.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=GET;POST;PUT;PATCH;DELETE
+	Method string `json:"method,omitempty" protobuf:"bytes,2,opt,name=method"`
+	// Headers are headers to be sent with the request. Their values may contain
+	// expressions, as described for the URL field. This field is optional.
+	//
+	// +kubebuilder:validation:Optional
+	Headers []HTTPHeader `json:"headers,omitempty" protobuf:"bytes,3,rep,name=headers"`
+	// Body is the body of the request. It may contain expressions, as described
+	// for the URL field. This field is optional.
+	//
+	// +kubebuilder:validation:Optional
+	Body string `json:"body,omitempty" protobuf:"bytes,4,opt,name=body"`
+	// ExpectedStatusCodes are the status codes the request is expected to be
+	// responded to with. Any other status code fails the hook. This field is
+	// optional. When left unspecified, any 2xx status code is expected.
+	//
+	// +kubebuilder:validation:Optional
+	ExpectedStatusCodes []int32 `json:"expectedStatusCodes,omitempty" protobuf:"varint,5,rep,name=expectedStatusCodes"`
+	// Timeout is the maximum amount of time to wait for a response. This field
+	// is optional. When left unspecified, a timeout of 10 seconds is used.
+	//
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" protobuf:"bytes,6,opt,name=timeout"`
+	// InsecureSkipTLSVerify specifies whether certificate verification errors
+	// should be ignored when sending the request. This field is optional.
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty" protobuf:"varint,7,opt,name=insecureSkipTLSVerify"`
+}
+
+// HTTPHeader is a header of an HTTP request.
+type HTTPHeader struct {
+	// Name is the name of the header.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Value is the value of the header.
+	Value string `json:"value" protobuf:"bytes,2,opt,name=value"`
 }
 
 // GitRepoUpdate describes updates that should be applied to a Git repository
@@ -209,6 +676,12 @@ type GitRepoUpdate struct {
 	// should be ignored when connecting to the repository. This should be enabled
 	// only with great caution.
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty" protobuf:"varint,2,opt,name=insecureSkipTLSVerify"`
+	// InsecureHTTP specifies whether the repository may be accessed over plain,
+	// unencrypted HTTP. This is only honored if the repository's host has also
+	// been permitted to be accessed this way by the Kargo controller's
+	// configuration. Any credentials for the repository are sent unencrypted.
+	// This should be enabled only with great caution.
+	InsecureHTTP bool `json:"insecureHTTP,omitempty" protobuf:"varint,11,opt,name=insecureHTTP"`
 	// ReadBranch specifies a particular branch of the repository from which to
 	// locate contents that will be written to the branch specified by the
 	// WriteBranch field. This field is optional. When not specified, the
@@ -238,6 +711,102 @@ type GitRepoUpdate struct {
 	// Helm describes how to use Helm to incorporate Freight into the Stage. This
 	// is mutually exclusive with the Render and Kustomize fields.
 	Helm *HelmPromotionMechanism `json:"helm,omitempty" protobuf:"bytes,8,opt,name=helm"`
+	// CommitMessageTemplate optionally specifies the name of one of the
+	// Project's CommitMessageTemplates to be used for composing the messages of
+	// commits made to the repository. If left unspecified, Kargo composes a
+	// message from a summary of the changes being committed.
+	CommitMessageTemplate string `json:"commitMessageTemplate,omitempty" protobuf:"bytes,9,opt,name=commitMessageTemplate"`
+	// Preserve optionally specifies attributes of the repository's files that
+	// are to be preserved as-is when Render, Kustomize, or Helm update those
+	// files. Preserving them keeps promotions from producing noisy diffs in
+	// repositories whose files, for instance, use CRLF line endings.
+	Preserve *GitFilePreservation `json:"preserve,omitempty" protobuf:"bytes,10,opt,name=preserve"`
+	// PushConflicts optionally specifies how to handle the remote branch having
+	// been updated after the repository was cloned, which causes the push of
+	// the commit to the WriteBranch to be rejected. If left unspecified, the
+	// promotion fails.
+	PushConflicts *GitPushConflictHandling `json:"pushConflicts,omitempty" protobuf:"bytes,12,opt,name=pushConflicts"`
+	// Changelog optionally specifies a changelog file in the repository to
+	// which an entry describing each Promotion is prepended, providing a
+	// human-readable history of what has been promoted to the Stage.
+	Changelog *GitRepoChangelog `json:"changelog,omitempty" protobuf:"bytes,14,opt,name=changelog"`
+}
+
+// GitRepoChangelog describes a changelog file in a Git repository to which an
+// entry describing each Promotion is prepended.
+type GitRepoChangelog struct {
+	// Path is the path to the changelog file, relative to the root of the
+	// repository. The file is created if it does not already exist.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=^[\w-\.]+(/[\w-\.]+)*$
+	Path string `json:"path" protobuf:"bytes,1,opt,name=path"`
+	// Template optionally specifies a template for each entry. Expressions
+	// enclosed in ${{ and }} are evaluated against the same environment as
+	// those in commit message templates, with the addition of the Freight
+	// previously promoted to the Stage, referenced as previousFreight, and the
+	// date on which the Promotion was created, referenced as date. If left
+	// unspecified, each entry lists the artifacts referenced by the promoted
+	// Freight and, for each of its commits, the range of commits promoted since
+	// the previous Freight.
+	//
+	// +kubebuilder:validation:Optional
+	Template string `json:"template,omitempty" protobuf:"bytes,2,opt,name=template"`
+}
+
+// GitFilePreservation specifies attributes of the files in a Git repository
+// that are to be preserved as-is when those files are updated.
+type GitFilePreservation struct {
+	// LineEndings specifies whether files using CRLF line endings should retain
+	// them after having been updated.
+	LineEndings bool `json:"lineEndings,omitempty" protobuf:"varint,1,opt,name=lineEndings"`
+	// FileModes specifies whether files should retain their permission bits,
+	// including their executable bits, after having been updated.
+	FileModes bool `json:"fileModes,omitempty" protobuf:"varint,2,opt,name=fileModes"`
+	// Symlinks specifies whether symbolic links should remain symbolic links
+	// after the files they link to have been updated through them. If a
+	// symbolic link is replaced by a regular file, the regular file's content
+	// is written to the file the symbolic link pointed to instead, provided
+	// that file is within the repository, and the symbolic link is restored.
+	Symlinks bool `json:"symlinks,omitempty" protobuf:"varint,3,opt,name=symlinks"`
+}
+
+// GitPushConflictStrategy specifies how to handle a push being rejected
+// because the remote branch has been updated after the repository was cloned.
+//
+// +kubebuilder:validation:Enum=Fail;Rebase;Rerender
+type GitPushConflictStrategy string
+
+const (
+	// GitPushConflictStrategyFail denotes that the promotion fails when the
+	// push is rejected.
+	GitPushConflictStrategyFail GitPushConflictStrategy = "Fail"
+	// GitPushConflictStrategyRebase denotes that the commit is rebased onto the
+	// remote branch before the push is retried. If the rebase results in
+	// conflicts, the promotion fails.
+	GitPushConflictStrategyRebase GitPushConflictStrategy = "Rebase"
+	// GitPushConflictStrategyRerender denotes that the repository is cloned
+	// again and the changes are made afresh on top of the updated remote branch
+	// before the push is retried.
+	GitPushConflictStrategyRerender GitPushConflictStrategy = "Rerender"
+)
+
+// GitPushConflictHandling describes how to handle a push being rejected
+// because the remote branch has been updated after the repository was cloned.
+type GitPushConflictHandling struct {
+	// Strategy specifies how to handle the rejected push. Accepted values are
+	// Fail, Rebase, and Rerender. If left unspecified, the default is Fail.
+	//
+	// +kubebuilder:default=Fail
+	Strategy GitPushConflictStrategy `json:"strategy,omitempty" protobuf:"bytes,1,opt,name=strategy"`
+	// MaxAttempts is the maximum number of attempts to push the commit,
+	// including the first, when Strategy is Rebase or Rerender. If left
+	// unspecified, the default is 3.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +kubebuilder:default=3
+	MaxAttempts int32 `json:"maxAttempts,omitempty" protobuf:"varint,2,opt,name=maxAttempts"`
 }
 
 // PullRequestPromotionMechanism describes how to generate a pull request against the write branch during promotion
@@ -247,6 +816,44 @@ type PullRequestPromotionMechanism struct {
 	GitHub *GitHubPullRequest `json:"github,omitempty" protobuf:"bytes,1,opt,name=github"`
 	// GitLab indicates git provider is GitLab
 	GitLab *GitLabPullRequest `json:"gitlab,omitempty" protobuf:"bytes,2,opt,name=gitlab"`
+	// FallbackOnProtectedBranch indicates that changes should be pushed
+	// directly to the write branch and that a pull request should only be
+	// opened if the push is rejected because the write branch is protected.
+	// This simplifies configuration for repositories with mixed branch
+	// protection rules. This field defaults to false, meaning a pull request is
+	// always opened.
+	FallbackOnProtectedBranch bool `json:"fallbackOnProtectedBranch,omitempty" protobuf:"varint,3,opt,name=fallbackOnProtectedBranch"`
+	// BranchCleanup optionally specifies whether the branch from which a pull
+	// request was opened is to be deleted from the remote repository once the
+	// pull request has been closed. If left unspecified, the branch is
+	// retained.
+	BranchCleanup *PullRequestBranchCleanup `json:"branchCleanup,omitempty" protobuf:"bytes,4,opt,name=branchCleanup"`
+}
+
+// PullRequestBranchCleanupPolicy specifies when the branch from which a pull
+// request was opened is to be deleted.
+//
+// +kubebuilder:validation:Enum=OnMerge;Always
+type PullRequestBranchCleanupPolicy string
+
+const (
+	// PullRequestBranchCleanupPolicyOnMerge denotes that the branch is deleted
+	// once the pull request has been merged and is otherwise retained, so the
+	// changes of a pull request that was closed without being merged remain
+	// available for inspection.
+	PullRequestBranchCleanupPolicyOnMerge PullRequestBranchCleanupPolicy = "OnMerge"
+	// PullRequestBranchCleanupPolicyAlways denotes that the branch is deleted
+	// once the pull request has been closed, whether or not it was merged.
+	PullRequestBranchCleanupPolicyAlways PullRequestBranchCleanupPolicy = "Always"
+)
+
+// PullRequestBranchCleanup describes whether the branch from which a pull
+// request was opened is to be deleted once the pull request has been closed.
+type PullRequestBranchCleanup struct {
+	// Policy specifies when the branch is to be deleted. Defaults to OnMerge.
+	//
+	// +kubebuilder:default=OnMerge
+	Policy PullRequestBranchCleanupPolicy `json:"policy,omitempty" protobuf:"bytes,1,opt,name=policy"`
 }
 
 type GitHubPullRequest struct {
@@ -287,8 +894,100 @@ type KustomizePromotionMechanism struct {
 	// Images describes images for which `kustomize edit set image` should be
 	// executed and the paths in which those commands should be executed.
 	//
-	// +kubebuilder:validation:MinItems=1
-	Images []KustomizeImageUpdate `json:"images" protobuf:"bytes,1,rep,name=images"`
+	// +kubebuilder:validation:Optional
+	Images []KustomizeImageUpdate `json:"images,omitempty" protobuf:"bytes,1,rep,name=images"`
+	// Resources describes resources that should be added to or removed from
+	// kustomizations using `kustomize edit add resource` and `kustomize edit
+	// remove resource`. These are applied before any Patches or Images.
+	//
+	// +kubebuilder:validation:Optional
+	Resources []KustomizeResourcesUpdate `json:"resources,omitempty" protobuf:"bytes,2,rep,name=resources"`
+	// Patches describes patches that should be added to or removed from
+	// kustomizations using `kustomize edit add patch` and `kustomize edit
+	// remove patch`. These are applied after any Resources and before any
+	// Images.
+	//
+	// +kubebuilder:validation:Optional
+	Patches []KustomizePatchesUpdate `json:"patches,omitempty" protobuf:"bytes,3,rep,name=patches"`
+}
+
+// KustomizeResourcesUpdate describes resources to be added to or removed from
+// a kustomization.
+type KustomizeResourcesUpdate struct {
+	// Path specifies the path of the directory containing the kustomization to
+	// be updated. This is a required field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=^[\w-\.]+(/[\w-\.]+)*$
+	Path string `json:"path" protobuf:"bytes,1,opt,name=path"`
+	// Add lists resources, i.e. paths relative to Path or URLs, to be added to
+	// the kustomization. Resources the kustomization already includes are left
+	// as they are.
+	//
+	// +kubebuilder:validation:Optional
+	Add []string `json:"add,omitempty" protobuf:"bytes,2,rep,name=add"`
+	// Remove lists resources to be removed from the kustomization. Resources
+	// the kustomization does not include are ignored.
+	//
+	// +kubebuilder:validation:Optional
+	Remove []string `json:"remove,omitempty" protobuf:"bytes,3,rep,name=remove"`
+}
+
+// KustomizePatchesUpdate describes patches to be added to or removed from a
+// kustomization.
+type KustomizePatchesUpdate struct {
+	// Path specifies the path of the directory containing the kustomization to
+	// be updated. This is a required field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=^[\w-\.]+(/[\w-\.]+)*$
+	Path string `json:"path" protobuf:"bytes,1,opt,name=path"`
+	// Add lists patches to be added to the kustomization.
+	//
+	// +kubebuilder:validation:Optional
+	Add []KustomizePatch `json:"add,omitempty" protobuf:"bytes,2,rep,name=add"`
+	// Remove lists patches to be removed from the kustomization. A patch is
+	// removed only if both its path and its target match.
+	//
+	// +kubebuilder:validation:Optional
+	Remove []KustomizePatch `json:"remove,omitempty" protobuf:"bytes,3,rep,name=remove"`
+}
+
+// KustomizePatch describes a patch in a kustomization. The patch may be either
+// a strategic merge patch or a JSON6902 patch. A JSON6902 patch must specify a
+// Target.
+type KustomizePatch struct {
+	// Path specifies the path of the file containing the patch, relative to the
+	// kustomization's directory. This is a required field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path" protobuf:"bytes,1,opt,name=path"`
+	// Target optionally selects the resources to which the patch applies. If
+	// left unspecified, a strategic merge patch applies to the resource it
+	// identifies itself.
+	//
+	// +kubebuilder:validation:Optional
+	Target *KustomizePatchTarget `json:"target,omitempty" protobuf:"bytes,2,opt,name=target"`
+}
+
+// KustomizePatchTarget selects the resources to which a KustomizePatch
+// applies.
+type KustomizePatchTarget struct {
+	// Group is the API group of the resources to be patched.
+	Group string `json:"group,omitempty" protobuf:"bytes,1,opt,name=group"`
+	// Version is the API version of the resources to be patched.
+	Version string `json:"version,omitempty" protobuf:"bytes,2,opt,name=version"`
+	// Kind is the kind of the resources to be patched.
+	Kind string `json:"kind,omitempty" protobuf:"bytes,3,opt,name=kind"`
+	// Name is the name of the resource to be patched.
+	Name string `json:"name,omitempty" protobuf:"bytes,4,opt,name=name"`
+	// Namespace is the namespace of the resources to be patched.
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,5,opt,name=namespace"`
+	// LabelSelector selects the resources to be patched by their labels.
+	LabelSelector string `json:"labelSelector,omitempty" protobuf:"bytes,6,opt,name=labelSelector"`
+	// AnnotationSelector selects the resources to be patched by their
+	// annotations.
+	AnnotationSelector string `json:"annotationSelector,omitempty" protobuf:"bytes,7,opt,name=annotationSelector"`
 }
 
 // KustomizeImageUpdate describes how to run `kustomize edit set image`
@@ -320,6 +1019,69 @@ type HelmPromotionMechanism struct {
 	// Charts describes how specific chart versions can be incorporated into an
 	// umbrella chart.
 	Charts []HelmChartDependencyUpdate `json:"charts,omitempty" protobuf:"bytes,2,rep,name=charts"`
+	// Template describes how to render a Helm chart into plain manifests after
+	// any image and chart updates have been applied.
+	//
+	// +kubebuilder:validation:Optional
+	Template *HelmTemplate `json:"template,omitempty" protobuf:"bytes,3,opt,name=template"`
+}
+
+// HelmTemplate describes how to use `helm template` to render a Helm chart
+// into plain manifests. Values are merged in a deterministic order: values
+// files in the order listed, then inline Values, then SetValues in the order
+// listed, with later sources taking precedence over earlier ones.
+type HelmTemplate struct {
+	// ChartPath is the path to the chart to be rendered. This is a required
+	// field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=^[\w-\.]+(/[\w-\.]+)*$
+	ChartPath string `json:"chartPath" protobuf:"bytes,1,opt,name=chartPath"`
+	// ReleaseName is the release name to render the chart with. If left
+	// unspecified, the name of the directory containing the chart is used.
+	//
+	// +kubebuilder:validation:Optional
+	ReleaseName string `json:"releaseName,omitempty" protobuf:"bytes,2,opt,name=releaseName"`
+	// Namespace is the namespace to render the chart for.
+	//
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,3,opt,name=namespace"`
+	// ValuesFilePaths are paths to Helm values files to render the chart with,
+	// in the order in which they should be merged.
+	//
+	// +kubebuilder:validation:Optional
+	ValuesFilePaths []string `json:"valuesFilePaths,omitempty" protobuf:"bytes,4,rep,name=valuesFilePaths"`
+	// Values is a YAML document containing values to render the chart with.
+	// These are merged after all values files.
+	//
+	// +kubebuilder:validation:Optional
+	Values string `json:"values,omitempty" protobuf:"bytes,5,opt,name=values"`
+	// SetValues are individual values to render the chart with, in the manner
+	// of `helm template --set`. These are merged after all values files and
+	// inline Values.
+	//
+	// +kubebuilder:validation:Optional
+	SetValues []HelmSetValue `json:"setValues,omitempty" protobuf:"bytes,6,rep,name=setValues"`
+	// OutPath is the path to the file the rendered manifests should be written
+	// to. Any existing file at this path is overwritten. This is a required
+	// field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=^[\w-\.]+(/[\w-\.]+)*$
+	OutPath string `json:"outPath" protobuf:"bytes,7,opt,name=outPath"`
+}
+
+// HelmSetValue describes a single value to render a Helm chart with.
+type HelmSetValue struct {
+	// Key is the key of the value, using the same dotted notation as
+	// `helm template --set`. This is a required field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key" protobuf:"bytes,1,opt,name=key"`
+	// Value is the value to set. It may contain expressions, e.g.
+	// ${{ freight.images[0].tag }}, which are evaluated against the Freight
+	// being promoted.
+	Value string `json:"value" protobuf:"bytes,2,opt,name=value"`
 }
 
 // HelmImageUpdate describes how a specific image version can be incorporated
@@ -403,6 +1165,133 @@ type ArgoCDAppUpdate struct {
 	// SourceUpdates describes updates to be applied to various sources of the
 	// specified Argo CD Application resource.
 	SourceUpdates []ArgoCDSourceUpdate `json:"sourceUpdates,omitempty" protobuf:"bytes,3,rep,name=sourceUpdates"`
+	// SyncOptions describes options for the sync operation Kargo initiates
+	// after updating the specified Argo CD Application resource.
+	//
+	// +kubebuilder:validation:Optional
+	SyncOptions *ArgoCDSyncOptions `json:"syncOptions,omitempty" protobuf:"bytes,4,opt,name=syncOptions"`
+}
+
+// ArgoCDSyncOptions describes options for a sync operation of an Argo CD
+// Application resource.
+type ArgoCDSyncOptions struct {
+	// Prune specifies whether resources that are no longer defined by the
+	// Application's sources should be deleted.
+	Prune bool `json:"prune,omitempty" protobuf:"varint,1,opt,name=prune"`
+	// Force specifies whether resources should be deleted and recreated if they
+	// cannot be updated.
+	Force bool `json:"force,omitempty" protobuf:"varint,2,opt,name=force"`
+	// ApplyOutOfSyncOnly specifies whether only resources that are out of sync
+	// should be applied.
+	ApplyOutOfSyncOnly bool `json:"applyOutOfSyncOnly,omitempty" protobuf:"varint,3,opt,name=applyOutOfSyncOnly"`
+	// Replace specifies whether resources should be replaced instead of
+	// applied.
+	Replace bool `json:"replace,omitempty" protobuf:"varint,4,opt,name=replace"`
+	// Resources, if specified, limits the sync operation to the described
+	// resources. If left unspecified, all of the Application's resources are
+	// synced.
+	//
+	// +kubebuilder:validation:Optional
+	Resources []ArgoCDSyncResource `json:"resources,omitempty" protobuf:"bytes,5,rep,name=resources"`
+}
+
+// ArgoCDSyncResource identifies a resource managed by an Argo CD Application.
+type ArgoCDSyncResource struct {
+	// Group is the API group of the resource. It should be left unspecified for
+	// resources of the core API group.
+	//
+	// +kubebuilder:validation:Optional
+	Group string `json:"group,omitempty" protobuf:"bytes,1,opt,name=group"`
+	// Kind is the kind of the resource.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind" protobuf:"bytes,2,opt,name=kind"`
+	// Name is the name of the resource.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name" protobuf:"bytes,3,opt,name=name"`
+	// Namespace is the namespace of the resource. It should be left unspecified
+	// for cluster-scoped resources.
+	//
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,4,opt,name=namespace"`
+}
+
+// ArgoRolloutAction is an action that can be taken on an Argo Rollouts
+// Rollout resource.
+//
+// +kubebuilder:validation:Enum=Promote;PromoteFull;Abort
+type ArgoRolloutAction string
+
+const (
+	// ArgoRolloutActionPromote resumes a paused Rollout, advancing it to the
+	// next step of its canary strategy.
+	ArgoRolloutActionPromote ArgoRolloutAction = "Promote"
+	// ArgoRolloutActionPromoteFull promotes a Rollout to full weight, skipping
+	// all remaining steps of its canary strategy.
+	ArgoRolloutActionPromoteFull ArgoRolloutAction = "PromoteFull"
+	// ArgoRolloutActionAbort aborts a Rollout, shifting all traffic back to its
+	// stable version.
+	ArgoRolloutActionAbort ArgoRolloutAction = "Abort"
+)
+
+// ArgoRolloutUpdate describes updates that should be applied to an Argo
+// Rollouts Rollout resource to drive its progress.
+type ArgoRolloutUpdate struct {
+	// Name specifies the name of the Rollout resource to be updated.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Namespace specifies the namespace of the Rollout resource to be updated.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	Namespace string `json:"namespace" protobuf:"bytes,2,opt,name=namespace"`
+	// Cluster is the name or API server URL of a cluster registered with Argo
+	// CD in which the Rollout resource resides. Kargo connects to that cluster
+	// using the details in the corresponding Argo CD cluster Secret. This field
+	// is optional. When left unspecified, the Rollout resource is assumed to
+	// reside in the same cluster as Kargo.
+	//
+	// +kubebuilder:validation:Optional
+	Cluster string `json:"cluster,omitempty" protobuf:"bytes,5,opt,name=cluster"`
+	// CanarySteps, if specified, replaces the steps of the Rollout's canary
+	// strategy. This field is optional.
+	//
+	// +kubebuilder:validation:Optional
+	CanarySteps []ArgoRolloutCanaryStep `json:"canarySteps,omitempty" protobuf:"bytes,3,rep,name=canarySteps"`
+	// Action, if specified, is an action to take on the Rollout after its
+	// canary steps, if any, have been replaced. This field is optional.
+	//
+	// +kubebuilder:validation:Optional
+	Action ArgoRolloutAction `json:"action,omitempty" protobuf:"bytes,4,opt,name=action"`
+}
+
+// ArgoRolloutCanaryStep describes a single step of an Argo Rollouts Rollout's
+// canary strategy. Exactly one of its fields should be specified.
+type ArgoRolloutCanaryStep struct {
+	// SetWeight is the percentage of traffic to shift to the canary.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	SetWeight *int32 `json:"setWeight,omitempty" protobuf:"varint,1,opt,name=setWeight"`
+	// Pause pauses the Rollout, either for the specified duration or, if no
+	// duration is specified, until it is promoted.
+	//
+	// +kubebuilder:validation:Optional
+	Pause *ArgoRolloutPause `json:"pause,omitempty" protobuf:"bytes,2,opt,name=pause"`
+}
+
+// ArgoRolloutPause describes a pause step of an Argo Rollouts Rollout's canary
+// strategy.
+type ArgoRolloutPause struct {
+	// Duration is how long to pause for. If left unspecified, the Rollout is
+	// paused until it is promoted.
+	//
+	// +kubebuilder:validation:Optional
+	Duration *metav1.Duration `json:"duration,omitempty" protobuf:"bytes,1,opt,name=duration"`
 }
 
 // ArgoCDSourceUpdate describes updates that should be applied to one of an Argo
@@ -529,6 +1418,18 @@ type StageStatus struct {
 	CurrentPromotion *PromotionInfo `json:"currentPromotion,omitempty" protobuf:"bytes,7,opt,name=currentPromotion"`
 	// LastPromotion is a reference to the last completed promotion.
 	LastPromotion *PromotionInfo `json:"lastPromotion,omitempty" protobuf:"bytes,10,opt,name=lastPromotion"`
+	// Conditions contains the last observations of the Stage's current state.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchMergeKey:"type" patchStrategy:"merge" protobuf:"bytes,12,rep,name=conditions"`
+	// ImagePullSecrets records the image pull Secrets currently maintained by
+	// the Kargo controller on behalf of the Stage and the ServiceAccounts they
+	// have been attached to. Secrets that are no longer described by the Stage's
+	// spec, or that belong to a Stage that is being deleted, are deleted and
+	// detached from those ServiceAccounts.
+	ImagePullSecrets []ImagePullSecret `json:"imagePullSecrets,omitempty" protobuf:"bytes,13,rep,name=imagePullSecrets"`
 }
 
 // FreightReference is a simplified representation of a piece of Freight -- not
@@ -546,6 +1447,8 @@ type FreightReference struct {
 	Images []Image `json:"images,omitempty" protobuf:"bytes,3,rep,name=images"`
 	// Charts describes specific versions of specific Helm charts.
 	Charts []Chart `json:"charts,omitempty" protobuf:"bytes,4,rep,name=charts"`
+	// Packages describes specific versions of specific packages.
+	Packages []Package `json:"packages,omitempty" protobuf:"bytes,8,rep,name=packages"`
 	// VerificationInfo is information about any verification process that was
 	// associated with this Freight for this Stage.
 	VerificationInfo *VerificationInfo `json:"verificationInfo,omitempty" protobuf:"bytes,5,opt,name=verificationInfo"`
@@ -601,6 +1504,10 @@ type Image struct {
 	// Digest identifies a specific version of the image in the repository
 	// specified by RepoURL. This is a more precise identifier than Tag.
 	Digest string `json:"digest,omitempty" protobuf:"bytes,4,opt,name=digest"`
+	// BuildMetadata contains the fields of build metadata extracted from an
+	// artifact referring to the image, indexed by name, if the subscription
+	// through which the image was discovered specified any.
+	BuildMetadata map[string]string `json:"buildMetadata,omitempty" protobuf:"bytes,5,rep,name=buildMetadata" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 // Chart describes a specific version of a Helm chart.
@@ -618,6 +1525,19 @@ type Chart struct {
 	Version string `json:"version,omitempty" protobuf:"bytes,3,opt,name=version"`
 }
 
+// Package describes a specific version of a package from a package registry.
+type Package struct {
+	// Type is the type of the package registry.
+	Type PackageType `json:"type,omitempty" protobuf:"bytes,1,opt,name=type"`
+	// RepoURL specifies the URL of the package registry.
+	RepoURL string `json:"repoURL,omitempty" protobuf:"bytes,2,opt,name=repoURL"`
+	// Name specifies the name of the package. For Maven packages, this is the
+	// group ID and artifact ID of the package, separated by a colon.
+	Name string `json:"name,omitempty" protobuf:"bytes,3,opt,name=name"`
+	// Version specifies a particular version of the package.
+	Version string `json:"version,omitempty" protobuf:"bytes,4,opt,name=version"`
+}
+
 // Equals returns a bool indicating whether two GitCommits are equivalent.
 func (g *GitCommit) Equals(rhs *GitCommit) bool {
 	if g == nil && rhs == nil {
@@ -639,6 +1559,26 @@ type Health struct {
 	Issues []string `json:"issues,omitempty" protobuf:"bytes,2,rep,name=issues"`
 	// ArgoCDApps describes the current state of any related ArgoCD Applications.
 	ArgoCDApps []ArgoCDAppStatus `json:"argoCDApps,omitempty" protobuf:"bytes,3,rep,name=argoCDApps"`
+	// ConsecutiveFailures is the number of consecutive health checks, up to and
+	// including the most recent one, that have failed. It is compared to the
+	// FailureThreshold of the Stage's HealthChecks to determine whether the
+	// Stage is Unhealthy.
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty" protobuf:"varint,4,opt,name=consecutiveFailures"`
+	// HTTPEndpoints describes the current state of any HTTP endpoints checked to
+	// assess the Stage's health.
+	HTTPEndpoints []HTTPEndpointStatus `json:"httpEndpoints,omitempty" protobuf:"bytes,5,rep,name=httpEndpoints"`
+}
+
+// HTTPEndpointStatus describes the current state of a single HTTP endpoint
+// checked to assess a Stage's health.
+type HTTPEndpointStatus struct {
+	// URL is the URL of the endpoint, with any expressions evaluated.
+	URL string `json:"url" protobuf:"bytes,1,opt,name=url"`
+	// StatusCode is the status code the endpoint last responded with. It is
+	// zero if the endpoint could not be reached.
+	StatusCode int32 `json:"statusCode,omitempty" protobuf:"varint,2,opt,name=statusCode"`
+	// Status describes the health of the endpoint.
+	Status HealthState `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
 }
 
 // ArgoCDAppStatus describes the current state of a single ArgoCD Application.
@@ -651,6 +1591,44 @@ type ArgoCDAppStatus struct {
 	HealthStatus ArgoCDAppHealthStatus `json:"healthStatus,omitempty" protobuf:"bytes,3,opt,name=healthStatus"`
 	// SyncStatus is the sync status of the ArgoCD Application.
 	SyncStatus ArgoCDAppSyncStatus `json:"syncStatus,omitempty" protobuf:"bytes,4,opt,name=syncStatus"`
+	// OperationState describes the most recent operation, e.g. a sync,
+	// performed on the ArgoCD Application, if any.
+	OperationState *ArgoCDAppOperationState `json:"operationState,omitempty" protobuf:"bytes,5,opt,name=operationState"`
+	// UnhealthyResources describes the resources managed by the ArgoCD
+	// Application that are Degraded or Missing. At most ten such resources are
+	// described.
+	UnhealthyResources []ArgoCDAppResourceStatus `json:"unhealthyResources,omitempty" protobuf:"bytes,6,rep,name=unhealthyResources"`
+}
+
+// ArgoCDAppOperationState describes the most recent operation performed on an
+// ArgoCD Application.
+type ArgoCDAppOperationState struct {
+	// Phase is the phase of the operation, e.g. Running, Succeeded, or Failed.
+	Phase string `json:"phase,omitempty" protobuf:"bytes,1,opt,name=phase"`
+	// Message is a human-readable message describing the outcome of the
+	// operation.
+	Message string `json:"message,omitempty" protobuf:"bytes,2,opt,name=message"`
+	// SyncRevision is the revision the ArgoCD Application was synced to by the
+	// operation, if it was a sync.
+	SyncRevision string `json:"syncRevision,omitempty" protobuf:"bytes,3,opt,name=syncRevision"`
+	// FinishedAt is the time at which the operation finished. It is nil if
+	// the operation is still running.
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty" protobuf:"bytes,4,opt,name=finishedAt"`
+}
+
+// ArgoCDAppResourceStatus describes the health of a single resource managed by
+// an ArgoCD Application.
+type ArgoCDAppResourceStatus struct {
+	// Group is the API group of the resource.
+	Group string `json:"group,omitempty" protobuf:"bytes,1,opt,name=group"`
+	// Kind is the kind of the resource.
+	Kind string `json:"kind" protobuf:"bytes,2,opt,name=kind"`
+	// Namespace is the namespace of the resource, if it is namespaced.
+	Namespace string `json:"namespace,omitempty" protobuf:"bytes,3,opt,name=namespace"`
+	// Name is the name of the resource.
+	Name string `json:"name" protobuf:"bytes,4,opt,name=name"`
+	// HealthStatus is the health of the resource.
+	HealthStatus ArgoCDAppHealthStatus `json:"healthStatus" protobuf:"bytes,5,opt,name=healthStatus"`
 }
 
 // ArgoCDAppHealthStatus describes the health of an ArgoCD Application.
@@ -682,6 +1660,8 @@ type PromotionInfo struct {
 	Freight FreightReference `json:"freight" protobuf:"bytes,2,opt,name=freight"`
 	// Status is the (optional) status of the promotion
 	Status *PromotionStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+	// FinishedAt is the time at which the promotion reached a terminal phase.
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty" protobuf:"bytes,4,opt,name=finishedAt"`
 }
 
 // Verification describes how to verify that a Promotion has been successful
@@ -696,8 +1676,45 @@ type Verification struct {
 	AnalysisRunMetadata *AnalysisRunMetadata `json:"analysisRunMetadata,omitempty" protobuf:"bytes,2,opt,name=analysisRunMetadata"`
 	// Args lists arguments that should be added to all AnalysisRuns.
 	Args []AnalysisRunArgument `json:"args,omitempty" protobuf:"bytes,3,rep,name=args"`
+	// Policy specifies whether Freight must be successfully verified before it
+	// is marked as verified in the Stage, thereby qualifying it for promotion to
+	// downstream Stages. Accepted values are "Required" and "Optional". This
+	// field defaults to "Required". When "Optional", any AnalysisTemplates are
+	// still used to verify the Freight, but the results of that verification
+	// only hold back the Freight if it fails before AutoQualifyAfter elapses.
+	// This is useful for low-stakes Stages, such as those used for development.
+	//
+	// +kubebuilder:default=Required
+	Policy VerificationPolicy `json:"policy,omitempty" protobuf:"bytes,4,opt,name=policy"`
+	// AutoQualifyAfter is the duration after verification starts after which
+	// Freight is marked as verified in the Stage if verification has neither
+	// succeeded nor failed by then. It only applies when Policy is "Optional".
+	// If left unspecified, Freight is marked as verified in the Stage as soon as
+	// the Stage is healthy, regardless of the results of any verification.
+	AutoQualifyAfter *metav1.Duration `json:"autoQualifyAfter,omitempty" protobuf:"bytes,5,opt,name=autoQualifyAfter"`
 }
 
+// IsOptional returns true if successful verification is not required for
+// Freight to be marked as verified in a Stage.
+func (v *Verification) IsOptional() bool {
+	return v != nil && v.Policy == VerificationPolicyOptional
+}
+
+// VerificationPolicy specifies whether successful verification is required
+// for Freight to be marked as verified in a Stage.
+//
+// +kubebuilder:validation:Enum=Required;Optional
+type VerificationPolicy string
+
+const (
+	// VerificationPolicyRequired denotes that Freight must be successfully
+	// verified before it is marked as verified in a Stage.
+	VerificationPolicyRequired VerificationPolicy = "Required"
+	// VerificationPolicyOptional denotes that Freight may be marked as verified
+	// in a Stage without having been successfully verified.
+	VerificationPolicyOptional VerificationPolicy = "Optional"
+)
+
 // AnalysisTemplateReference is a reference to an AnalysisTemplate.
 type AnalysisTemplateReference struct {
 	// Name is the name of the AnalysisTemplate in the same project/namespace as
@@ -722,7 +1739,14 @@ type AnalysisRunArgument struct {
 	//
 	// +kubebuilder:validation:Required
 	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
-	// Value is the value of the argument.
+	// Value is the value of the argument. The value may contain expressions,
+	// enclosed in ${{ and }}, that are evaluated against the Freight being
+	// verified. Expressions may reference ctx.project, ctx.stage, freight (with
+	// name, alias, warehouse, commits, images, and charts fields), and outputs
+	// (the metadata recorded by the Stage's last Promotion), and may use the
+	// imageFrom(repoURL), commitFrom(repoURL), and chartFrom(repoURL[, name])
+	// functions to look up specific artifacts in the Freight. e.g.
+	// ${{ imageFrom('ghcr.io/example/app').tag }}
 	//
 	// +kubebuilder:validation:Required
 	Value string `json:"value,omitempty" protobuf:"bytes,2,opt,name=value"`
@@ -174,3 +174,21 @@ func TestVerificationInfoStack_UpdateOrPush(t *testing.T) {
 		})
 	}
 }
+
+func TestPromotionMechanismsRevision(t *testing.T) {
+	var nilMechs *PromotionMechanisms
+	require.Empty(t, nilMechs.Revision())
+
+	mechs := &PromotionMechanisms{
+		ArgoCDAppUpdates: []ArgoCDAppUpdate{{
+			AppName: "fake-app",
+		}},
+	}
+	revision := mechs.Revision()
+	require.Len(t, revision, 12)
+	// Identical configurations have the same revision
+	require.Equal(t, revision, mechs.DeepCopy().Revision())
+	// Any change yields a different revision
+	mechs.ArgoCDAppUpdates[0].AppName = "other-fake-app"
+	require.NotEqual(t, revision, mechs.Revision())
+}
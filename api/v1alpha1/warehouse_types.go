@@ -2,21 +2,23 @@ package v1alpha1
 
 import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-// +kubebuilder:validation:Enum={Lexical,NewestFromBranch,NewestTag,SemVer}
+// +kubebuilder:validation:Enum={CalVer,Lexical,NewestFromBranch,NewestTag,SemVer}
 type CommitSelectionStrategy string
 
 const (
+	CommitSelectionStrategyCalVer           CommitSelectionStrategy = "CalVer"
 	CommitSelectionStrategyLexical          CommitSelectionStrategy = "Lexical"
 	CommitSelectionStrategyNewestFromBranch CommitSelectionStrategy = "NewestFromBranch"
 	CommitSelectionStrategyNewestTag        CommitSelectionStrategy = "NewestTag"
 	CommitSelectionStrategySemVer           CommitSelectionStrategy = "SemVer"
 )
 
-// +kubebuilder:validation:Enum={Digest,Lexical,NewestBuild,SemVer}
+// +kubebuilder:validation:Enum={Digest,Expression,Lexical,NewestBuild,SemVer}
 type ImageSelectionStrategy string
 
 const (
 	ImageSelectionStrategyDigest      ImageSelectionStrategy = "Digest"
+	ImageSelectionStrategyExpression  ImageSelectionStrategy = "Expression"
 	ImageSelectionStrategyLexical     ImageSelectionStrategy = "Lexical"
 	ImageSelectionStrategyNewestBuild ImageSelectionStrategy = "NewestBuild"
 	ImageSelectionStrategySemVer      ImageSelectionStrategy = "SemVer"
@@ -25,6 +27,7 @@ const (
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name=Shard,type=string,JSONPath=`.spec.shard`
+// +kubebuilder:printcolumn:name=Ready,type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 // +kubebuilder:printcolumn:name=Age,type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Warehouse is a source of Freight.
@@ -66,6 +69,59 @@ type WarehouseSpec struct {
 	//
 	// +kubebuilder:validation:MinItems=1
 	Subscriptions []RepoSubscription `json:"subscriptions" protobuf:"bytes,1,rep,name=subscriptions"`
+	// Polling describes how often this Warehouse polls its subscriptions for
+	// new artifacts. This field is optional. When left unspecified,
+	// subscriptions are polled every five minutes.
+	//
+	// +kubebuilder:validation:Optional
+	Polling *WarehousePolling `json:"polling,omitempty" protobuf:"bytes,4,opt,name=polling"`
+}
+
+// WarehousePollingMode defines how the interval at which a Warehouse polls its
+// subscriptions is determined.
+// +kubebuilder:validation:Enum={Fixed,Adaptive}
+type WarehousePollingMode string
+
+const (
+	// WarehousePollingModeFixed indicates that a Warehouse polls its
+	// subscriptions at a fixed interval.
+	WarehousePollingModeFixed WarehousePollingMode = "Fixed"
+	// WarehousePollingModeAdaptive indicates that a Warehouse polls its
+	// subscriptions more frequently after new artifacts have been discovered and
+	// progressively less frequently while no new artifacts are discovered.
+	WarehousePollingModeAdaptive WarehousePollingMode = "Adaptive"
+)
+
+// WarehousePolling describes how often a Warehouse polls its subscriptions for
+// new artifacts.
+type WarehousePolling struct {
+	// Mode describes how the interval at which the Warehouse polls its
+	// subscriptions is determined. "Fixed" polls at the interval specified by
+	// the Interval field. "Adaptive" polls at the interval specified by the
+	// MinInterval field after new artifacts have been discovered and doubles
+	// the interval each time no new artifacts are discovered, up to the
+	// interval specified by the Interval field. This field is optional. When
+	// left unspecified, the field is implicitly treated as if its value were
+	// "Fixed".
+	//
+	// +kubebuilder:default=Fixed
+	// +kubebuilder:validation:Optional
+	Mode WarehousePollingMode `json:"mode,omitempty" protobuf:"bytes,1,opt,name=mode"`
+	// Interval is the interval at which the Warehouse polls its subscriptions
+	// in Fixed mode and the longest interval at which it polls them in Adaptive
+	// mode. This field is optional. When left unspecified, an interval of five
+	// minutes is used.
+	//
+	// +kubebuilder:validation:Optional
+	Interval *metav1.Duration `json:"interval,omitempty" protobuf:"bytes,2,opt,name=interval"`
+	// MinInterval is the shortest interval at which the Warehouse polls its
+	// subscriptions in Adaptive mode. It applies right after new artifacts have
+	// been discovered. It is ignored in Fixed mode and must not exceed Interval.
+	// This field is optional. When left unspecified, an interval of one minute
+	// is used.
+	//
+	// +kubebuilder:validation:Optional
+	MinInterval *metav1.Duration `json:"minInterval,omitempty" protobuf:"bytes,3,opt,name=minInterval"`
 }
 
 // FreightCreationPolicy defines how Freight is created by a Warehouse.
@@ -88,6 +144,8 @@ type RepoSubscription struct {
 	Image *ImageSubscription `json:"image,omitempty" protobuf:"bytes,2,opt,name=image"`
 	// Chart describes a subscription to a Helm chart repository.
 	Chart *ChartSubscription `json:"chart,omitempty" protobuf:"bytes,3,opt,name=chart"`
+	// Package describes a subscription to a package in a package registry.
+	Package *PackageSubscription `json:"package,omitempty" protobuf:"bytes,4,opt,name=package"`
 }
 
 // GitSubscription defines a subscription to a Git repository.
@@ -107,12 +165,21 @@ type GitSubscription struct {
 	// Branch references a particular branch of the repository. The value in this
 	// field only has any effect when the CommitSelectionStrategy is
 	// NewestFromBranch or left unspecified (which is implicitly the same as
-	// NewestFromBranch). This field is optional. When left unspecified, (and the
-	// CommitSelectionStrategy is NewestFromBranch or unspecified), the
-	// subscription is implicitly to the repository's default branch.
+	// NewestFromBranch), or when RestrictTagsToBranch is true. This field is
+	// optional. When left unspecified, (and the CommitSelectionStrategy is
+	// NewestFromBranch or unspecified), the subscription is implicitly to the
+	// repository's default branch, which is detected anew every time commits
+	// are discovered.
+	//
+	// When the CommitSelectionStrategy is NewestFromBranch (or unspecified),
+	// this field may instead specify a pattern, prefixed with "glob:" or
+	// "regexp:", that matches the names of any number of branches (e.g.
+	// glob:release/*). Commits are then discovered from every matching branch
+	// and the head of each such branch is a candidate for inclusion in
+	// Freight, with a distinct piece of Freight produced for each branch.
 	//
 	// +kubebuilder:validation:MinLength=1
-	// +kubebuilder:validation:Pattern=`^\w+([-/]\w+)*$`
+	// +kubebuilder:validation:Pattern=`^(\w+([-/]\w+)*|(glob|regexp):.+)$`
 	Branch string `json:"branch,omitempty" protobuf:"bytes,3,opt,name=branch"`
 	// SemverConstraint specifies constraints on what new tagged commits are
 	// considered in determining the newest commit of interest. The value in this
@@ -124,18 +191,47 @@ type GitSubscription struct {
 	//
 	// +kubebuilder:validation:Optional
 	SemverConstraint string `json:"semverConstraint,omitempty" protobuf:"bytes,4,opt,name=semverConstraint"`
+	// SemverPrefix is an optional prefix (e.g. release-v or service-a/v) that
+	// is stripped from tags before they are parsed as semantic versions. Tags
+	// that do not begin with the prefix are ignored. This permits the use of
+	// the SemVer CommitSelectionStrategy in repositories, such as monorepos,
+	// whose tags are namespaced. The value in this field only has any effect
+	// when the CommitSelectionStrategy is SemVer. This field is optional.
+	//
+	// +kubebuilder:validation:Optional
+	SemverPrefix string `json:"semverPrefix,omitempty" protobuf:"bytes,18,opt,name=semverPrefix"`
+	// StrictSemvers specifies whether only tags that are complete semantic
+	// versions (i.e. MAJOR.MINOR.PATCH, optionally preceded by a "v") should be
+	// considered. When false, loose versions such as 1.0 or v2 are also
+	// considered. The value in this field only has any effect when the
+	// CommitSelectionStrategy is SemVer. This field is optional.
+	//
+	// +kubebuilder:validation:Optional
+	StrictSemvers bool `json:"strictSemvers,omitempty" protobuf:"varint,19,opt,name=strictSemvers"`
+	// CalVerLayout specifies the calendar versioning layout (e.g.
+	// YYYY.0M.MICRO) that tags are parsed against in determining the newest
+	// commit of interest. Tags that do not conform to the layout are ignored
+	// and the remaining tags are ordered by the versions they represent. A
+	// layout is composed of the tokens YYYY, YY, 0Y, MM, 0M, WW, 0W, DD, 0D,
+	// MAJOR, MINOR, and MICRO, described at https://calver.org, any of which may
+	// be separated or surrounded by literal text (e.g. vYYYY.0M.MICRO). The
+	// value in this field only has any effect when the CommitSelectionStrategy
+	// is CalVer, in which case it is required.
+	//
+	// +kubebuilder:validation:Optional
+	CalVerLayout string `json:"calVerLayout,omitempty" protobuf:"bytes,17,opt,name=calVerLayout"`
 	// AllowTags is a regular expression that can optionally be used to limit the
 	// tags that are considered in determining the newest commit of interest. The
 	// value in this field only has any effect when the CommitSelectionStrategy is
-	// Lexical, NewestTag, or SemVer. This field is optional.
+	// CalVer, Lexical, NewestTag, or SemVer. This field is optional.
 	//
 	// +kubebuilder:validation:Optional
 	AllowTags string `json:"allowTags,omitempty" protobuf:"bytes,5,opt,name=allowTags"`
 	// IgnoreTags is a list of tags that must be ignored when determining the
 	// newest commit of interest. No regular expressions or glob patterns are
 	// supported yet. The value in this field only has any effect when the
-	// CommitSelectionStrategy is Lexical, NewestTag, or SemVer. This field is
-	// optional.
+	// CommitSelectionStrategy is CalVer, Lexical, NewestTag, or SemVer. This
+	// field is optional.
 	//
 	// +kubebuilder:validation:Optional
 	IgnoreTags []string `json:"ignoreTags,omitempty" protobuf:"bytes,6,rep,name=ignoreTags"`
@@ -143,13 +239,21 @@ type GitSubscription struct {
 	// should be ignored when connecting to the repository. This should be enabled
 	// only with great caution.
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty" protobuf:"varint,7,opt,name=insecureSkipTLSVerify"`
+	// InsecureHTTP specifies whether the repository may be accessed over plain,
+	// unencrypted HTTP. This is only honored if the repository's host has also
+	// been permitted to be accessed this way by the Kargo controller's
+	// configuration. Any credentials for the repository are sent unencrypted.
+	// This should be enabled only with great caution.
+	InsecureHTTP bool `json:"insecureHTTP,omitempty" protobuf:"varint,13,opt,name=insecureHTTP"`
 	// IncludePaths is a list of selectors that designate paths in the repository
 	// that should trigger the production of new Freight when changes are detected
 	// therein. When specified, only changes in the identified paths will trigger
 	// Freight production. When not specified, changes in any path will trigger
 	// Freight production. Selectors may be defined using:
 	//   1. Exact paths to files or directories (ex. "charts/foo")
-	//   2. Glob patterns (prefix the pattern with "glob:"; ex. "glob:*.yaml")
+	//   2. Glob patterns (prefix the pattern with "glob:"; ex. "glob:*.yaml").
+	//      "**" matches any number of directories (ex.
+	//      "glob:apps/**/overlays/prod/**")
 	//   3. Regular expressions (prefix the pattern with "regex:" or "regexp:";
 	//      ex. "regexp:^.*\.yaml$")
 	// Paths selected by IncludePaths may be unselected by ExcludePaths. This
@@ -164,7 +268,9 @@ type GitSubscription struct {
 	// Freight production will be defined solely by IncludePaths. Selectors may be
 	// defined using:
 	//   1. Exact paths to files or directories (ex. "charts/foo")
-	//   2. Glob patterns (prefix the pattern with "glob:"; ex. "glob:*.yaml")
+	//   2. Glob patterns (prefix the pattern with "glob:"; ex. "glob:*.yaml").
+	//      "**" matches any number of directories (ex.
+	//      "glob:apps/**/overlays/prod/**")
 	//   3. Regular expressions (prefix the pattern with "regex:" or "regexp:";
 	//      ex. "regexp:^.*\.yaml$")
 	// Paths selected by IncludePaths may be unselected by ExcludePaths. This
@@ -172,15 +278,242 @@ type GitSubscription struct {
 	// subset of them.
 	// +kubebuilder:validation:Optional
 	ExcludePaths []string `json:"excludePaths,omitempty" protobuf:"bytes,9,rep,name=excludePaths"`
+	// Trailers is a list of keys of Git trailers (e.g. "Change-Id" or "Ticket")
+	// to be extracted from the messages of discovered commits. The values of
+	// the selected trailers are recorded along with each discovered commit and
+	// carried into any Freight that references it, so that they may be
+	// referenced, for instance, by promotion mechanisms. Keys are matched
+	// case-insensitively. This field is optional. When left unspecified, no
+	// trailers are extracted.
+	//
+	// +kubebuilder:validation:Optional
+	Trailers []string `json:"trailers,omitempty" protobuf:"bytes,10,rep,name=trailers"`
+	// ExcludeMergeCommits specifies whether merge commits (i.e. commits with
+	// more than one parent) should be ignored when discovering commits. This is
+	// useful, for instance, for selecting only squashed commits in repositories
+	// that also contain merge commits. The value in this field only has any
+	// effect when the CommitSelectionStrategy is NewestFromBranch or left
+	// unspecified. This field is mutually exclusive with the OnlyMergeCommits
+	// field and is optional.
+	//
+	// +kubebuilder:validation:Optional
+	ExcludeMergeCommits bool `json:"excludeMergeCommits,omitempty" protobuf:"varint,20,opt,name=excludeMergeCommits"`
+	// OnlyMergeCommits specifies whether only merge commits (i.e. commits with
+	// more than one parent) should be considered when discovering commits. This
+	// is useful, for instance, for selecting only the commits that merged pull
+	// requests into a branch. The value in this field only has any effect when
+	// the CommitSelectionStrategy is NewestFromBranch or left unspecified. This
+	// field is mutually exclusive with the ExcludeMergeCommits field and is
+	// optional.
+	//
+	// +kubebuilder:validation:Optional
+	OnlyMergeCommits bool `json:"onlyMergeCommits,omitempty" protobuf:"varint,21,opt,name=onlyMergeCommits"`
+	// Services optionally designates paths in the repository that each contain
+	// a distinct service, as is common in monorepos. When specified, commits are
+	// discovered independently for each service, as if IncludePaths were set to
+	// the service's path, and a distinct piece of Freight is produced for each
+	// service. Each such piece of Freight references only the latest commit
+	// discovered for its service, along with the latest artifacts discovered for
+	// all of the Warehouse's other subscriptions. This field is mutually
+	// exclusive with the IncludePaths field and may be specified by at most one
+	// of a Warehouse's subscriptions.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	Services []GitService `json:"services,omitempty" protobuf:"bytes,11,rep,name=services"`
+	// RestrictTagsToBranch specifies whether only tags whose commits are
+	// reachable from the branch specified by the Branch field (or the
+	// repository's default branch, if Branch is unspecified) should be
+	// considered in determining the newest commit of interest. This prevents,
+	// for instance, tags cut from old release branches from being selected
+	// over tags from the main line of development. The value in this field only
+	// has any effect when the CommitSelectionStrategy is CalVer, Lexical,
+	// NewestTag, or SemVer. This field is optional.
+	//
+	// +kubebuilder:validation:Optional
+	RestrictTagsToBranch bool `json:"restrictTagsToBranch,omitempty" protobuf:"varint,12,opt,name=restrictTagsToBranch"`
+	// ExpressionFilter is an optional expression that must evaluate to true for
+	// a commit to be considered. It is applied in addition to all other
+	// selection criteria and is intended for cases those criteria cannot
+	// express. The expression has access to the variables id, tag, author,
+	// committer, subject (or message), body (the commit message without its
+	// subject), date, trailers (a map of trailer keys to lists of values), and
+	// paths (the paths changed by the commit). The same metadata is available
+	// as the fields of the commit variable (e.g. commit.Subject). For example:
+	// commit.Author contains "release-bot" && !(commit.Subject startsWith "chore:")
+	//
+	// +kubebuilder:validation:Optional
+	ExpressionFilter string `json:"expressionFilter,omitempty" protobuf:"bytes,14,opt,name=expressionFilter"`
+	// DiscoveryLimit is an optional limit on the number of commits or tags that
+	// can be discovered. The limit is applied after filtering commits or tags
+	// based on all other selection criteria. If the subscription specifies
+	// Services, the limit applies to each service individually.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=20
+	DiscoveryLimit int32 `json:"discoveryLimit,omitempty" protobuf:"varint,15,opt,name=discoveryLimit"`
+	// VerifySignatures optionally requires the signatures of discovered commits
+	// to be verified using trusted public keys. When the CommitSelectionStrategy
+	// is CalVer, Lexical, NewestTag, or SemVer, a tag is considered verified if
+	// either the tag itself or the commit it resolves to bears a valid
+	// signature. This field is optional. When left unspecified, signatures are
+	// not verified.
+	//
+	// +kubebuilder:validation:Optional
+	VerifySignatures *GitSignatureVerification `json:"verifySignatures,omitempty" protobuf:"bytes,16,opt,name=verifySignatures"`
+	// DiscoveryMode specifies how commits and tags are discovered. Accepted
+	// values are "Clone", which discovers them from a blobless clone of the
+	// repository, and "ProviderAPI", which discovers them through the REST API
+	// of the repository's git provider (GitHub or GitLab) without cloning it.
+	// The latter is considerably cheaper for large repositories, but does not
+	// support signature verification, the RestrictTagsToBranch field, the
+	// NewestTag CommitSelectionStrategy, or expression filters in combination
+	// with tag-based CommitSelectionStrategies. This field defaults to "Clone".
+	//
+	// +kubebuilder:default=Clone
+	DiscoveryMode GitDiscoveryMode `json:"discoveryMode,omitempty" protobuf:"bytes,22,opt,name=discoveryMode"`
+	// CloneDepth, if specified, limits the clone of the repository from which
+	// commits are discovered to the specified number of most recent commits of
+	// each branch. This makes cloning very large repositories considerably
+	// cheaper, but commits beyond this depth are never discovered, including
+	// when they are needed to satisfy the DiscoveryLimit. This field is optional
+	// and can only be used when the CommitSelectionStrategy is NewestFromBranch
+	// (or unspecified), the DiscoveryMode is Clone, and neither IncludePaths nor
+	// ExcludePaths is specified.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	CloneDepth int32 `json:"cloneDepth,omitempty" protobuf:"varint,23,opt,name=cloneDepth"`
+	// MirrorURL optionally specifies a mirror of the repository from which
+	// commits are discovered instead of the RepoURL. This may be the URL of an
+	// internal mirror service or a file:// URL of a mirror or a bundle on a
+	// volume mounted into the controller, which must lie beneath one of the
+	// directories permitted by this installation of Kargo. This is useful in
+	// air-gapped environments where the controller cannot reach the RepoURL.
+	// Discovered commits are still attributed to the RepoURL. This field is
+	// optional and cannot be used when the DiscoveryMode is ProviderAPI.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^(https?://(\w+([\.-]\w+)*@)?\w+([\.-]\w+)*(:[\d]+)?(/.*)?|file:///.+)$`
+	MirrorURL string `json:"mirrorURL,omitempty" protobuf:"bytes,24,opt,name=mirrorURL"`
+	// SkipMarkers specifies the markers that cause commits to be skipped when
+	// discovering commits from the history of a branch, e.g. to prevent commits
+	// made by bots from producing new Freight. If unspecified, commits whose
+	// subject contains "[kargo skip]" or whose message has a "Kargo-Skip: true"
+	// trailer are skipped. This field can only be used when the
+	// CommitSelectionStrategy is NewestFromBranch (or unspecified).
+	//
+	// +kubebuilder:validation:Optional
+	SkipMarkers *GitCommitSkipMarkers `json:"skipMarkers,omitempty" protobuf:"bytes,25,opt,name=skipMarkers"`
+}
+
+// GitCommitSkipMarkers describes the markers that cause commits to be skipped
+// when discovering commits from the history of a branch. A marker that is left
+// empty is disabled.
+type GitCommitSkipMarkers struct {
+	// Subject is a string that causes commits whose subject contains it to be
+	// skipped, e.g. "[kargo skip]".
+	//
+	// +kubebuilder:validation:Optional
+	Subject string `json:"subject,omitempty" protobuf:"bytes,1,opt,name=subject"`
+	// Trailer is a trailer, in the format "Key: value", that causes commits
+	// whose message has it to be skipped, e.g. "Kargo-Skip: true". Keys and
+	// values are compared case-insensitively.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9-]+:\s*\S.*$`
+	Trailer string `json:"trailer,omitempty" protobuf:"bytes,2,opt,name=trailer"`
+}
+
+// +kubebuilder:validation:Enum=Clone;ProviderAPI
+type GitDiscoveryMode string
+
+const (
+	// GitDiscoveryModeClone specifies that commits and tags are discovered from
+	// a blobless clone of the repository.
+	GitDiscoveryModeClone GitDiscoveryMode = "Clone"
+	// GitDiscoveryModeProviderAPI specifies that commits and tags are
+	// discovered through the REST API of the repository's git provider.
+	GitDiscoveryModeProviderAPI GitDiscoveryMode = "ProviderAPI"
+)
+
+const (
+	// GitSignatureVerificationGPGKeysSecretKey is the key within a Secret
+	// referenced by a GitSignatureVerification under which any number of
+	// trusted, ASCII-armored GPG public keys are stored.
+	GitSignatureVerificationGPGKeysSecretKey = "gpgKeys"
+	// GitSignatureVerificationSSHKeysSecretKey is the key within a Secret
+	// referenced by a GitSignatureVerification under which any number of
+	// trusted SSH public keys are stored, one per line, either bare or in the
+	// allowed signers format understood by ssh-keygen.
+	GitSignatureVerificationSSHKeysSecretKey = "sshKeys"
+)
+
+// +kubebuilder:validation:Enum=Skip;Mark
+type UnverifiedCommitPolicy string
+
+const (
+	// UnverifiedCommitPolicySkip specifies that commits whose signatures cannot
+	// be verified are not discovered at all.
+	UnverifiedCommitPolicySkip UnverifiedCommitPolicy = "Skip"
+	// UnverifiedCommitPolicyMark specifies that commits whose signatures cannot
+	// be verified are discovered, but marked as unverified.
+	UnverifiedCommitPolicyMark UnverifiedCommitPolicy = "Mark"
+)
+
+// GitSignatureVerification describes how the signatures of commits discovered
+// through a GitSubscription are verified.
+type GitSignatureVerification struct {
+	// SecretName is the name of a Secret in the Warehouse's namespace that
+	// stores the trusted public keys. ASCII-armored GPG public keys are stored
+	// under the key "gpgKeys" and SSH public keys under the key "sshKeys". At
+	// least one of these keys must be present.
+	//
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName" protobuf:"bytes,1,opt,name=secretName"`
+	// UnverifiedPolicy specifies what happens to commits that are unsigned or
+	// whose signatures cannot be verified using the trusted public keys. Accepted
+	// values are "Skip", which excludes them from discovery, and "Mark", which
+	// discovers them, but records their signature status as "Unverified". This
+	// field defaults to "Skip".
+	//
+	// +kubebuilder:default=Skip
+	UnverifiedPolicy UnverifiedCommitPolicy `json:"unverifiedPolicy,omitempty" protobuf:"bytes,2,opt,name=unverifiedPolicy"`
+}
+
+// GitService describes a service residing at a path within a Git repository.
+type GitService struct {
+	// Name is the name of the service. It is recorded with the commits
+	// discovered for the service and with the Freight produced for it.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Path is a selector that designates the path in the repository containing
+	// the service. Changes in the selected path trigger the production of new
+	// Freight for the service. The selector may be defined using any of the
+	// forms supported by IncludePaths.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path" protobuf:"bytes,2,opt,name=path"`
 }
 
 // ImageSubscription defines a subscription to an image repository.
 type ImageSubscription struct {
 	// RepoURL specifies the URL of the image repository to subscribe to. The
-	// value in this field MUST NOT include an image tag. This field is required.
+	// value in this field MUST NOT include an image tag. The path of the
+	// repository (but not the registry) may contain * wildcards, each matching
+	// any sequence of characters other than a slash, in which case the
+	// subscription applies to every matching repository in the registry. e.g.
+	// ghcr.io/acme/service-* subscribes to all repositories of the acme
+	// namespace whose names begin with service-, including repositories
+	// created in the future. This field is required.
 	//
 	// +kubebuilder:validation:MinLength=1
-	// +kubebuilder:validation:Pattern=`^(\w+([\.-]\w+)*(:[\d]+)?/)?(\w+([\.-]\w+)*)(/\w+([\.-]\w+)*)*$`
+	// +kubebuilder:validation:Pattern=`^(\w+([\.-]\w+)*(:[\d]+)?/)?([\w*]+([\.-][\w*]+)*)(/[\w*]+([\.-][\w*]+)*)*$`
 	RepoURL string `json:"repoURL" protobuf:"bytes,1,opt,name=repoURL"`
 	// GitRepoURL optionally specifies the URL of a Git repository that contains
 	// the source code for the image repository referenced by the RepoURL field.
@@ -222,14 +555,17 @@ type ImageSubscription struct {
 	//
 	// +kubebuilder:validation:Optional
 	IgnoreTags []string `json:"ignoreTags,omitempty" protobuf:"bytes,6,rep,name=ignoreTags"`
-	// Platform is a string of the form <os>/<arch> that limits the tags that can
-	// be considered when searching for new versions of an image. This field is
-	// optional. When left unspecified, it is implicitly equivalent to the
-	// OS/architecture of the Kargo controller. Care should be taken to set this
-	// value correctly in cases where the image referenced by this
-	// ImageRepositorySubscription will run on a Kubernetes node with a different
-	// OS/architecture than the Kargo controller. At present this is uncommon, but
-	// not unheard of.
+	// Platform is a string of the form <os>/<arch>[/<variant>] that limits the
+	// tags that can be considered when searching for new versions of an image.
+	// Multiple platforms may be specified as a comma-delimited list, each
+	// component may contain wildcards, and platforms prefixed with a "!" are
+	// excluded. e.g. "linux/*,!linux/386" matches any Linux platform except
+	// linux/386. This field is optional. When left unspecified, it is
+	// implicitly equivalent to the OS/architecture of the Kargo controller. Care
+	// should be taken to set this value correctly in cases where the image
+	// referenced by this ImageRepositorySubscription will run on a Kubernetes
+	// node with a different OS/architecture than the Kargo controller. At
+	// present this is uncommon, but not unheard of.
 	//
 	// +kubebuilder:validation:Optional
 	Platform string `json:"platform,omitempty" protobuf:"bytes,7,opt,name=platform"`
@@ -237,6 +573,123 @@ type ImageSubscription struct {
 	// should be ignored when connecting to the repository. This should be enabled
 	// only with great caution.
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty" protobuf:"varint,8,opt,name=insecureSkipTLSVerify"`
+	// SortExpression is an expression that maps an image tag (available to the
+	// expression as the variable tag) to a sortable key, which must be a number,
+	// string, or time. Tags are considered in descending order by that key. Tags
+	// for which the expression returns nil or fails to evaluate are ignored. The
+	// value in this field only has any effect when the ImageSelectionStrategy is
+	// Expression, in which case it is required. e.g. the expression
+	// int(regexpCapture("build-(\\d+)", tag)[1]) sorts tags of the form
+	// main-build-123 by build number.
+	//
+	// +kubebuilder:validation:Optional
+	SortExpression string `json:"sortExpression,omitempty" protobuf:"bytes,9,opt,name=sortExpression"`
+	// BuildMetadata optionally specifies an artifact that refers to each
+	// discovered image by way of the OCI referrers API (e.g. a build provenance
+	// attestation) from which metadata about the image's build, such as a
+	// build URL or the SHA of the commit it was built from, is to be extracted.
+	// This permits images to be correlated with the commits they were built
+	// from. This field is optional.
+	//
+	// +kubebuilder:validation:Optional
+	BuildMetadata *ImageBuildMetadataSource `json:"buildMetadata,omitempty" protobuf:"bytes,10,opt,name=buildMetadata"`
+	// RequireDigest specifies whether every discovered image must have had its
+	// digest resolved. When true, any discovered image whose digest could not be
+	// resolved is disregarded, which guarantees that all Freight referencing
+	// images from this repository can be promoted using digest-pinned image
+	// references, regardless of the ImageSelectionStrategy. This field is
+	// optional and defaults to false.
+	//
+	// +kubebuilder:validation:Optional
+	RequireDigest bool `json:"requireDigest,omitempty" protobuf:"varint,11,opt,name=requireDigest"`
+	// RevisionCheck optionally specifies that Freight should only be produced
+	// when the newest discovered image was built from the newest commit
+	// discovered from a Git repository to which the same Warehouse subscribes.
+	// This prevents Freight from referencing an image and a commit that do not
+	// belong together, as can happen when an image has not yet been built from
+	// a newly discovered commit. This field is optional.
+	//
+	// +kubebuilder:validation:Optional
+	RevisionCheck *ImageRevisionCheck `json:"revisionCheck,omitempty" protobuf:"bytes,12,opt,name=revisionCheck"`
+	// PreferCreatedAnnotation specifies whether the creation date of each image
+	// should be read from the org.opencontainers.image.created annotation of
+	// its index or manifest, when present, instead of from its config blob.
+	// This is useful for images built reproducibly (e.g. by BuildKit with
+	// SOURCE_DATE_EPOCH set), whose config blobs record a fixed date, and it
+	// reduces the number of requests made to the registry. The value in this
+	// field only has any effect when the ImageSelectionStrategy is NewestBuild.
+	// This field is optional and defaults to false.
+	//
+	// +kubebuilder:validation:Optional
+	PreferCreatedAnnotation bool `json:"preferCreatedAnnotation,omitempty" protobuf:"varint,13,opt,name=preferCreatedAnnotation"`
+	// CacheRegistryResponses specifies whether the registry's responses to
+	// requests for the repository's tags and manifests should be cached and
+	// revalidated using conditional requests (i.e. using the ETag and
+	// Last-Modified headers). When the registry reports that nothing has
+	// changed, cached responses and previously discovered images are reused,
+	// which greatly reduces traffic to large repositories. This field is
+	// optional and defaults to false.
+	//
+	// +kubebuilder:validation:Optional
+	CacheRegistryResponses bool `json:"cacheRegistryResponses,omitempty" protobuf:"varint,14,opt,name=cacheRegistryResponses"`
+}
+
+// ImageRevisionCheck describes how to verify that an image was built from a
+// commit discovered by the same Warehouse.
+type ImageRevisionCheck struct {
+	// GitRepoURL is the URL of the Git repository from which the image is
+	// expected to have been built. The Warehouse MUST also subscribe to this
+	// repository. This field is required.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^https?://(\w+([\.-]\w+)*@)?\w+([\.-]\w+)*(:[\d]+)?(/.*)?$`
+	GitRepoURL string `json:"gitRepoURL" protobuf:"bytes,1,opt,name=gitRepoURL"`
+	// BuildMetadataField optionally specifies the name of a field of the image's
+	// build metadata (see ImageSubscription.BuildMetadata) that holds the
+	// revision the image was built from, e.g. a field extracted from a build
+	// provenance attestation. When left unspecified, the revision is read from
+	// the image's org.opencontainers.image.revision label.
+	//
+	// +kubebuilder:validation:Optional
+	BuildMetadataField string `json:"buildMetadataField,omitempty" protobuf:"bytes,2,opt,name=buildMetadataField"`
+}
+
+// ImageBuildMetadataSource describes an artifact that refers to an image and
+// the fields of build metadata to be extracted from it.
+type ImageBuildMetadataSource struct {
+	// ArtifactType is the artifact type of the referring artifact. e.g.
+	// "application/vnd.in-toto+json". If an image is referred to by more than
+	// one artifact of this type, the first one listed by the registry is used.
+	//
+	// +kubebuilder:validation:MinLength=1
+	ArtifactType string `json:"artifactType" protobuf:"bytes,1,opt,name=artifactType"`
+	// Fields specifies the fields of build metadata to be extracted from the
+	// referring artifact.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Fields []ImageBuildMetadataField `json:"fields" protobuf:"bytes,2,rep,name=fields"`
+}
+
+// ImageBuildMetadataField describes a single field of build metadata to be
+// extracted from an artifact that refers to an image.
+type ImageBuildMetadataField struct {
+	// Name is the name of the field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Value is the value of the field. It may contain expressions, enclosed in
+	// ${{ and }}, that are evaluated against the referring artifact. These have
+	// access to the following:
+	//
+	//   - content: the JSON content of the artifact. If the content is a DSSE
+	//     envelope, as is the case for in-toto attestations, this is the
+	//     envelope's decoded payload instead.
+	//   - annotations: the annotations of the artifact's manifest.
+	//
+	// If the value evaluates to an empty string, the field is omitted.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Value string `json:"value" protobuf:"bytes,2,opt,name=value"`
 }
 
 // ChartSubscription defines a subscription to a Helm chart repository.
@@ -267,6 +720,80 @@ type ChartSubscription struct {
 	//
 	// +kubebuilder:validation:Optional
 	SemverConstraint string `json:"semverConstraint,omitempty" protobuf:"bytes,3,opt,name=semverConstraint"`
+	// ExcludeVersions is a list of chart versions that should never be
+	// discovered. Each entry may be either an exact version (e.g. 1.2.3) or a
+	// semver constraint (e.g. 2.0.x-0) matching the versions to
+	// exclude. This field is optional.
+	// More info: https://github.com/masterminds/semver#checking-version-constraints
+	//
+	// +kubebuilder:validation:Optional
+	ExcludeVersions []string `json:"excludeVersions,omitempty" protobuf:"bytes,4,rep,name=excludeVersions"`
+	// DiscoveryLimit is an optional limit on the number of chart versions that
+	// can be discovered. The limit is applied after filtering versions based on
+	// the SemverConstraint and ExcludeVersions fields.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=20
+	DiscoveryLimit int32 `json:"discoveryLimit,omitempty" protobuf:"varint,5,opt,name=discoveryLimit"`
+}
+
+// PackageType is the type of a package registry.
+// +kubebuilder:validation:Enum={PyPI,npm,Maven}
+type PackageType string
+
+const (
+	// PackageTypePyPI denotes a Python package index implementing the simple
+	// repository API.
+	PackageTypePyPI PackageType = "PyPI"
+	// PackageTypeNPM denotes an npm registry.
+	PackageTypeNPM PackageType = "npm"
+	// PackageTypeMaven denotes a Maven repository. Gradle consumes packages
+	// from Maven repositories as well.
+	PackageTypeMaven PackageType = "Maven"
+)
+
+// PackageSubscription defines a subscription to a package in a package
+// registry.
+type PackageSubscription struct {
+	// Type is the type of the package registry. This is a required field.
+	//
+	// +kubebuilder:validation:Required
+	Type PackageType `json:"type" protobuf:"bytes,1,opt,name=type"`
+	// RepoURL specifies the URL of the package registry. For PyPI, this is the
+	// URL of an index implementing the simple repository API (e.g.
+	// https://pypi.org/simple). For npm, this is the URL of a registry (e.g.
+	// https://registry.npmjs.org). For Maven, this is the URL of a repository
+	// (e.g. https://repo.maven.apache.org/maven2). This field is optional. When
+	// left unspecified, the public registry for the package type is used.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^https?://([\w\d\.\-]+)(:[\d]+)?(/.*)*$`
+	RepoURL string `json:"repoURL,omitempty" protobuf:"bytes,2,opt,name=repoURL"`
+	// Name specifies the name of the package. For PyPI and npm, this is the name
+	// under which the package is published (e.g. requests or @example/lib).
+	// For Maven, this is the group ID and artifact ID of the package, separated
+	// by a colon (e.g. com.example:lib). This is a required field.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name" protobuf:"bytes,3,opt,name=name"`
+	// SemverConstraint specifies constraints on what new package versions are
+	// permissible. Versions that cannot be parsed as semantic versions are
+	// never discovered. This field is optional. When left unspecified, there
+	// will be no constraints, which means the latest version of the package
+	// will always be used.
+	// More info: https://github.com/masterminds/semver#checking-version-constraints
+	//
+	// +kubebuilder:validation:Optional
+	SemverConstraint string `json:"semverConstraint,omitempty" protobuf:"bytes,4,opt,name=semverConstraint"`
+	// DiscoveryLimit is an optional limit on the number of package versions
+	// that can be discovered. The limit is applied after filtering versions
+	// based on the SemverConstraint field.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=20
+	DiscoveryLimit int32 `json:"discoveryLimit,omitempty" protobuf:"varint,5,opt,name=discoveryLimit"`
 }
 
 // WarehouseStatus describes a Warehouse's most recently observed state.
@@ -285,8 +812,25 @@ type WarehouseStatus struct {
 	// LastFreightID is a reference to the system-assigned identifier (name) of
 	// the most recent Freight produced by the Warehouse.
 	LastFreightID string `json:"lastFreightID,omitempty" protobuf:"bytes,8,opt,name=lastFreightID"`
-	// DiscoveredArtifacts holds the artifacts discovered by the Warehouse.
+	// DiscoveredArtifacts holds a summary of the artifacts discovered by the
+	// Warehouse. To keep the size of the Warehouse bounded, only the most
+	// recently discovered artifacts for each subscription are included. Full
+	// discovery results can be obtained using the PreviewWarehouse API.
 	DiscoveredArtifacts *DiscoveredArtifacts `json:"discoveredArtifacts,omitempty" protobuf:"bytes,7,opt,name=discoveredArtifacts"`
+	// Conditions contains the last observations of the Warehouse's current
+	// state.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchMergeKey:"type" patchStrategy:"merge" protobuf:"bytes,9,rep,name=conditions"`
+	// PollInterval is the interval after which the Warehouse will next poll its
+	// subscriptions for new artifacts, as determined by the Warehouse's polling
+	// configuration and, in Adaptive mode, by whether new artifacts were
+	// discovered the last time they were polled.
+	//
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty" protobuf:"bytes,10,opt,name=pollInterval"`
 }
 
 // DiscoveredArtifacts holds the artifacts discovered by the Warehouse for its
@@ -307,6 +851,16 @@ type DiscoveredArtifacts struct {
 	//
 	// +optional
 	Charts []ChartDiscoveryResult `json:"charts,omitempty" protobuf:"bytes,3,rep,name=charts"`
+	// Packages holds the package versions discovered by the Warehouse for the
+	// package subscriptions.
+	//
+	// +optional
+	Packages []PackageDiscoveryResult `json:"packages,omitempty" protobuf:"bytes,5,rep,name=packages"`
+	// Truncated indicates that the discovery results for one or more
+	// subscriptions were truncated.
+	//
+	// +optional
+	Truncated bool `json:"truncated,omitempty" protobuf:"varint,4,opt,name=truncated"`
 }
 
 // GitDiscoveryResult represents the result of a Git discovery operation for a
@@ -317,6 +871,19 @@ type GitDiscoveryResult struct {
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:Pattern=`^https?://(\w+([\.-]\w+)*@)?\w+([\.-]\w+)*(:[\d]+)?(/.*)?$`
 	RepoURL string `json:"repoURL" protobuf:"bytes,1,opt,name=repoURL"`
+	// Service is the name of the service, as specified in the GitSubscription,
+	// for which commits were discovered. This field is optional, and only
+	// populated if the GitSubscription specifies Services.
+	Service string `json:"service,omitempty" protobuf:"bytes,3,opt,name=service"`
+	// Branch is the name of the branch from which commits were discovered. This
+	// field is optional, and only populated if the GitSubscription's Branch is
+	// a pattern matching any number of branches.
+	Branch string `json:"branch,omitempty" protobuf:"bytes,4,opt,name=branch"`
+	// DefaultBranch is the name of the repository's default branch (i.e. the
+	// branch its HEAD refers to) as detected when commits were discovered. This
+	// field is optional, and only populated if the GitSubscription does not
+	// specify a Branch.
+	DefaultBranch string `json:"defaultBranch,omitempty" protobuf:"bytes,5,opt,name=defaultBranch"`
 	// Commits is a list of commits discovered by the Warehouse for the
 	// GitSubscription. An empty list indicates that the discovery operation was
 	// successful, but no commits matching the GitSubscription criteria were found.
@@ -349,8 +916,30 @@ type DiscoveredCommit struct {
 	// CreatorDate is the commit creation date as specified by the commit, or
 	// the tagger date if the commit belongs to an annotated tag.
 	CreatorDate *metav1.Time `json:"creatorDate,omitempty" protobuf:"bytes,7,opt,name=creatorDate"`
+	// Trailers contains the values of the Git trailers selected by the
+	// GitSubscription's Trailers field, indexed by the keys specified therein.
+	// If a trailer occurs more than once in the commit message, its values are
+	// joined by commas.
+	Trailers map[string]string `json:"trailers,omitempty" protobuf:"bytes,8,rep,name=trailers" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// SignatureStatus indicates whether the signature of the commit (or of the
+	// tag that resolved to it) was verified. It is only populated if the
+	// GitSubscription's VerifySignatures field is specified.
+	SignatureStatus GitSignatureStatus `json:"signatureStatus,omitempty" protobuf:"bytes,9,opt,name=signatureStatus"`
 }
 
+// GitSignatureStatus describes the outcome of verifying the signature of a
+// commit or tag.
+type GitSignatureStatus string
+
+const (
+	// GitSignatureStatusVerified denotes a commit or tag bearing a valid
+	// signature made with a trusted key.
+	GitSignatureStatusVerified GitSignatureStatus = "Verified"
+	// GitSignatureStatusUnverified denotes a commit or tag that is unsigned or
+	// whose signature could not be verified using any trusted key.
+	GitSignatureStatusUnverified GitSignatureStatus = "Unverified"
+)
+
 // ImageDiscoveryResult represents the result of an image discovery operation
 // for an ImageSubscription.
 type ImageDiscoveryResult struct {
@@ -393,6 +982,15 @@ type DiscoveredImageReference struct {
 	// CreatedAt is the time the image was created. This field is optional, and
 	// not populated for every ImageSelectionStrategy.
 	CreatedAt *metav1.Time `json:"createdAt,omitempty" protobuf:"bytes,4,opt,name=createdAt"`
+	// BuildMetadata contains the fields of build metadata extracted from an
+	// artifact referring to the image, indexed by name. This field is optional,
+	// and only populated if the ImageSubscription specifies BuildMetadata and
+	// the image is referred to by an artifact of the specified type.
+	BuildMetadata map[string]string `json:"buildMetadata,omitempty" protobuf:"bytes,5,rep,name=buildMetadata" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Revision is the revision of the source code the image was built from, as
+	// recorded by the image's org.opencontainers.image.revision label. This
+	// field is optional, and only populated if the image has such a label.
+	Revision string `json:"revision,omitempty" protobuf:"bytes,6,opt,name=revision"`
 }
 
 // ChartDiscoveryResult represents the result of a chart discovery operation for
@@ -418,6 +1016,32 @@ type ChartDiscoveryResult struct {
 	Versions []string `json:"versions" protobuf:"bytes,4,rep,name=versions"`
 }
 
+// PackageDiscoveryResult represents the result of a package discovery
+// operation for a PackageSubscription.
+type PackageDiscoveryResult struct {
+	// Type is the type of the package registry, as specified in the
+	// PackageSubscription.
+	Type PackageType `json:"type" protobuf:"bytes,1,opt,name=type"`
+	// RepoURL is the URL of the package registry from which versions were
+	// discovered. If the PackageSubscription does not specify a RepoURL, this
+	// is the URL of the public registry for the package type.
+	RepoURL string `json:"repoURL" protobuf:"bytes,2,opt,name=repoURL"`
+	// Name is the name of the package, as specified in the
+	// PackageSubscription.
+	Name string `json:"name" protobuf:"bytes,3,opt,name=name"`
+	// SemverConstraint is the constraint for which versions were discovered.
+	// This field is optional, and only populated if the PackageSubscription
+	// specifies a SemverConstraint.
+	SemverConstraint string `json:"semverConstraint,omitempty" protobuf:"bytes,4,opt,name=semverConstraint"`
+	// Versions is a list of versions discovered by the Warehouse for the
+	// PackageSubscription. An empty list indicates that the discovery operation
+	// was successful, but no versions matching the PackageSubscription criteria
+	// were found.
+	//
+	// +optional
+	Versions []string `json:"versions" protobuf:"bytes,5,rep,name=versions"`
+}
+
 // +kubebuilder:object:root=true
 
 // WarehouseList is a list of Warehouse resources.
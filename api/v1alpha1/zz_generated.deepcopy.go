@@ -5,6 +5,8 @@
 package v1alpha1
 
 import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -82,9 +84,45 @@ func (in *AnalysisTemplateReference) DeepCopy() *AnalysisTemplateReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Announcement) DeepCopyInto(out *Announcement) {
+	*out = *in
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Announcement.
+func (in *Announcement) DeepCopy() *Announcement {
+	if in == nil {
+		return nil
+	}
+	out := new(Announcement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApprovedStage) DeepCopyInto(out *ApprovedStage) {
 	*out = *in
+	if in.ApprovedAt != nil {
+		in, out := &in.ApprovedAt, &out.ApprovedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovedStage.
@@ -112,11 +150,56 @@ func (in *ArgoCDAppHealthStatus) DeepCopy() *ArgoCDAppHealthStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAppOperationState) DeepCopyInto(out *ArgoCDAppOperationState) {
+	*out = *in
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAppOperationState.
+func (in *ArgoCDAppOperationState) DeepCopy() *ArgoCDAppOperationState {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAppOperationState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAppResourceStatus) DeepCopyInto(out *ArgoCDAppResourceStatus) {
+	*out = *in
+	out.HealthStatus = in.HealthStatus
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAppResourceStatus.
+func (in *ArgoCDAppResourceStatus) DeepCopy() *ArgoCDAppResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAppResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDAppStatus) DeepCopyInto(out *ArgoCDAppStatus) {
 	*out = *in
 	out.HealthStatus = in.HealthStatus
 	in.SyncStatus.DeepCopyInto(&out.SyncStatus)
+	if in.OperationState != nil {
+		in, out := &in.OperationState, &out.OperationState
+		*out = new(ArgoCDAppOperationState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UnhealthyResources != nil {
+		in, out := &in.UnhealthyResources, &out.UnhealthyResources
+		*out = make([]ArgoCDAppResourceStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAppStatus.
@@ -159,6 +242,11 @@ func (in *ArgoCDAppUpdate) DeepCopyInto(out *ArgoCDAppUpdate) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.SyncOptions != nil {
+		in, out := &in.SyncOptions, &out.SyncOptions
+		*out = new(ArgoCDSyncOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAppUpdate.
@@ -266,6 +354,108 @@ func (in *ArgoCDSourceUpdate) DeepCopy() *ArgoCDSourceUpdate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSyncOptions) DeepCopyInto(out *ArgoCDSyncOptions) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ArgoCDSyncResource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSyncOptions.
+func (in *ArgoCDSyncOptions) DeepCopy() *ArgoCDSyncOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSyncOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSyncResource) DeepCopyInto(out *ArgoCDSyncResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSyncResource.
+func (in *ArgoCDSyncResource) DeepCopy() *ArgoCDSyncResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSyncResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoRolloutCanaryStep) DeepCopyInto(out *ArgoRolloutCanaryStep) {
+	*out = *in
+	if in.SetWeight != nil {
+		in, out := &in.SetWeight, &out.SetWeight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Pause != nil {
+		in, out := &in.Pause, &out.Pause
+		*out = new(ArgoRolloutPause)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoRolloutCanaryStep.
+func (in *ArgoRolloutCanaryStep) DeepCopy() *ArgoRolloutCanaryStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoRolloutCanaryStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoRolloutPause) DeepCopyInto(out *ArgoRolloutPause) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoRolloutPause.
+func (in *ArgoRolloutPause) DeepCopy() *ArgoRolloutPause {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoRolloutPause)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoRolloutUpdate) DeepCopyInto(out *ArgoRolloutUpdate) {
+	*out = *in
+	if in.CanarySteps != nil {
+		in, out := &in.CanarySteps, &out.CanarySteps
+		*out = make([]ArgoRolloutCanaryStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoRolloutUpdate.
+func (in *ArgoRolloutUpdate) DeepCopy() *ArgoRolloutUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoRolloutUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Chart) DeepCopyInto(out *Chart) {
 	*out = *in
@@ -304,6 +494,11 @@ func (in *ChartDiscoveryResult) DeepCopy() *ChartDiscoveryResult {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChartSubscription) DeepCopyInto(out *ChartSubscription) {
 	*out = *in
+	if in.ExcludeVersions != nil {
+		in, out := &in.ExcludeVersions, &out.ExcludeVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChartSubscription.
@@ -316,6 +511,108 @@ func (in *ChartSubscription) DeepCopy() *ChartSubscription {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConfig.
+func (in *ClusterConfig) DeepCopy() *ClusterConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterConfigList) DeepCopyInto(out *ClusterConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConfigList.
+func (in *ClusterConfigList) DeepCopy() *ClusterConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterConfigSpec) DeepCopyInto(out *ClusterConfigSpec) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]HostConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Announcements != nil {
+		in, out := &in.Announcements, &out.Announcements
+		*out = make([]Announcement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConfigSpec.
+func (in *ClusterConfigSpec) DeepCopy() *ClusterConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommitMessageTemplate) DeepCopyInto(out *CommitMessageTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommitMessageTemplate.
+func (in *CommitMessageTemplate) DeepCopy() *CommitMessageTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(CommitMessageTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiscoveredArtifacts) DeepCopyInto(out *DiscoveredArtifacts) {
 	*out = *in
@@ -340,6 +637,13 @@ func (in *DiscoveredArtifacts) DeepCopyInto(out *DiscoveredArtifacts) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = make([]PackageDiscoveryResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveredArtifacts.
@@ -359,6 +663,13 @@ func (in *DiscoveredCommit) DeepCopyInto(out *DiscoveredCommit) {
 		in, out := &in.CreatorDate, &out.CreatorDate
 		*out = (*in).DeepCopy()
 	}
+	if in.Trailers != nil {
+		in, out := &in.Trailers, &out.Trailers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveredCommit.
@@ -378,6 +689,13 @@ func (in *DiscoveredImageReference) DeepCopyInto(out *DiscoveredImageReference)
 		in, out := &in.CreatedAt, &out.CreatedAt
 		*out = (*in).DeepCopy()
 	}
+	if in.BuildMetadata != nil {
+		in, out := &in.BuildMetadata, &out.BuildMetadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveredImageReference.
@@ -390,6 +708,36 @@ func (in *DiscoveredImageReference) DeepCopy() *DiscoveredImageReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftDetection) DeepCopyInto(out *DriftDetection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftDetection.
+func (in *DriftDetection) DeepCopy() *DriftDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExpressionVariable) DeepCopyInto(out *ExpressionVariable) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpressionVariable.
+func (in *ExpressionVariable) DeepCopy() *ExpressionVariable {
+	if in == nil {
+		return nil
+	}
+	out := new(ExpressionVariable)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Freight) DeepCopyInto(out *Freight) {
 	*out = *in
@@ -398,18 +746,27 @@ func (in *Freight) DeepCopyInto(out *Freight) {
 	if in.Commits != nil {
 		in, out := &in.Commits, &out.Commits
 		*out = make([]GitCommit, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Images != nil {
 		in, out := &in.Images, &out.Images
 		*out = make([]Image, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Charts != nil {
 		in, out := &in.Charts, &out.Charts
 		*out = make([]Chart, len(*in))
 		copy(*out, *in)
 	}
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = make([]Package, len(*in))
+		copy(*out, *in)
+	}
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -432,15 +789,34 @@ func (in *Freight) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FreightList) DeepCopyInto(out *FreightList) {
+func (in *FreightBlock) DeepCopyInto(out *FreightBlock) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Freight, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreightBlock.
+func (in *FreightBlock) DeepCopy() *FreightBlock {
+	if in == nil {
+		return nil
+	}
+	out := new(FreightBlock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreightList) DeepCopyInto(out *FreightList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Freight, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
@@ -469,18 +845,27 @@ func (in *FreightReference) DeepCopyInto(out *FreightReference) {
 	if in.Commits != nil {
 		in, out := &in.Commits, &out.Commits
 		*out = make([]GitCommit, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Images != nil {
 		in, out := &in.Images, &out.Images
 		*out = make([]Image, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Charts != nil {
 		in, out := &in.Charts, &out.Charts
 		*out = make([]Chart, len(*in))
 		copy(*out, *in)
 	}
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = make([]Package, len(*in))
+		copy(*out, *in)
+	}
 	if in.VerificationInfo != nil {
 		in, out := &in.VerificationInfo, &out.VerificationInfo
 		*out = new(VerificationInfo)
@@ -533,14 +918,33 @@ func (in *FreightStatus) DeepCopyInto(out *FreightStatus) {
 		in, out := &in.VerifiedIn, &out.VerifiedIn
 		*out = make(map[string]VerifiedStage, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	if in.ApprovedFor != nil {
 		in, out := &in.ApprovedFor, &out.ApprovedFor
 		*out = make(map[string]ApprovedStage, len(*in))
 		for key, val := range *in {
-			(*out)[key] = val
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Blocked != nil {
+		in, out := &in.Blocked, &out.Blocked
+		*out = new(FreightBlock)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PromotedTo != nil {
+		in, out := &in.PromotedTo, &out.PromotedTo
+		*out = make(map[string]PromotedStage, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
 		}
 	}
 }
@@ -558,6 +962,13 @@ func (in *FreightStatus) DeepCopy() *FreightStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitCommit) DeepCopyInto(out *GitCommit) {
 	*out = *in
+	if in.Trailers != nil {
+		in, out := &in.Trailers, &out.Trailers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitCommit.
@@ -570,6 +981,21 @@ func (in *GitCommit) DeepCopy() *GitCommit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitCommitSkipMarkers) DeepCopyInto(out *GitCommitSkipMarkers) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitCommitSkipMarkers.
+func (in *GitCommitSkipMarkers) DeepCopy() *GitCommitSkipMarkers {
+	if in == nil {
+		return nil
+	}
+	out := new(GitCommitSkipMarkers)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitDiscoveryResult) DeepCopyInto(out *GitDiscoveryResult) {
 	*out = *in
@@ -592,6 +1018,21 @@ func (in *GitDiscoveryResult) DeepCopy() *GitDiscoveryResult {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitFilePreservation) DeepCopyInto(out *GitFilePreservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitFilePreservation.
+func (in *GitFilePreservation) DeepCopy() *GitFilePreservation {
+	if in == nil {
+		return nil
+	}
+	out := new(GitFilePreservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitHubPullRequest) DeepCopyInto(out *GitHubPullRequest) {
 	*out = *in
@@ -622,6 +1063,56 @@ func (in *GitLabPullRequest) DeepCopy() *GitLabPullRequest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitProviderNotifications) DeepCopyInto(out *GitProviderNotifications) {
+	*out = *in
+	if in.RepoURLs != nil {
+		in, out := &in.RepoURLs, &out.RepoURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitProviderNotifications.
+func (in *GitProviderNotifications) DeepCopy() *GitProviderNotifications {
+	if in == nil {
+		return nil
+	}
+	out := new(GitProviderNotifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitPushConflictHandling) DeepCopyInto(out *GitPushConflictHandling) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitPushConflictHandling.
+func (in *GitPushConflictHandling) DeepCopy() *GitPushConflictHandling {
+	if in == nil {
+		return nil
+	}
+	out := new(GitPushConflictHandling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitRepoChangelog) DeepCopyInto(out *GitRepoChangelog) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitRepoChangelog.
+func (in *GitRepoChangelog) DeepCopy() *GitRepoChangelog {
+	if in == nil {
+		return nil
+	}
+	out := new(GitRepoChangelog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitRepoUpdate) DeepCopyInto(out *GitRepoUpdate) {
 	*out = *in
@@ -645,6 +1136,21 @@ func (in *GitRepoUpdate) DeepCopyInto(out *GitRepoUpdate) {
 		*out = new(HelmPromotionMechanism)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Preserve != nil {
+		in, out := &in.Preserve, &out.Preserve
+		*out = new(GitFilePreservation)
+		**out = **in
+	}
+	if in.PushConflicts != nil {
+		in, out := &in.PushConflicts, &out.PushConflicts
+		*out = new(GitPushConflictHandling)
+		**out = **in
+	}
+	if in.Changelog != nil {
+		in, out := &in.Changelog, &out.Changelog
+		*out = new(GitRepoChangelog)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitRepoUpdate.
@@ -657,6 +1163,51 @@ func (in *GitRepoUpdate) DeepCopy() *GitRepoUpdate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitService) DeepCopyInto(out *GitService) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitService.
+func (in *GitService) DeepCopy() *GitService {
+	if in == nil {
+		return nil
+	}
+	out := new(GitService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitSignatureVerification) DeepCopyInto(out *GitSignatureVerification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSignatureVerification.
+func (in *GitSignatureVerification) DeepCopy() *GitSignatureVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(GitSignatureVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitSigningKey) DeepCopyInto(out *GitSigningKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSigningKey.
+func (in *GitSigningKey) DeepCopy() *GitSigningKey {
+	if in == nil {
+		return nil
+	}
+	out := new(GitSigningKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitSubscription) DeepCopyInto(out *GitSubscription) {
 	*out = *in
@@ -675,6 +1226,26 @@ func (in *GitSubscription) DeepCopyInto(out *GitSubscription) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Trailers != nil {
+		in, out := &in.Trailers, &out.Trailers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]GitService, len(*in))
+		copy(*out, *in)
+	}
+	if in.VerifySignatures != nil {
+		in, out := &in.VerifySignatures, &out.VerifySignatures
+		*out = new(GitSignatureVerification)
+		**out = **in
+	}
+	if in.SkipMarkers != nil {
+		in, out := &in.SkipMarkers, &out.SkipMarkers
+		*out = new(GitCommitSkipMarkers)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitSubscription.
@@ -687,6 +1258,91 @@ func (in *GitSubscription) DeepCopy() *GitSubscription {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPEndpointStatus) DeepCopyInto(out *HTTPEndpointStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPEndpointStatus.
+func (in *HTTPEndpointStatus) DeepCopy() *HTTPEndpointStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPEndpointStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHeader) DeepCopyInto(out *HTTPHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHeader.
+func (in *HTTPHeader) DeepCopy() *HTTPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPHealthCheck) DeepCopyInto(out *HTTPHealthCheck) {
+	*out = *in
+	if in.ExpectedStatusCodes != nil {
+		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPHealthCheck.
+func (in *HTTPHealthCheck) DeepCopy() *HTTPHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPPromotionHook) DeepCopyInto(out *HTTPPromotionHook) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]HTTPHeader, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpectedStatusCodes != nil {
+		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPPromotionHook.
+func (in *HTTPPromotionHook) DeepCopy() *HTTPPromotionHook {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPPromotionHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Health) DeepCopyInto(out *Health) {
 	*out = *in
@@ -702,6 +1358,11 @@ func (in *Health) DeepCopyInto(out *Health) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HTTPEndpoints != nil {
+		in, out := &in.HTTPEndpoints, &out.HTTPEndpoints
+		*out = make([]HTTPEndpointStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Health.
@@ -714,6 +1375,33 @@ func (in *Health) DeepCopy() *Health {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthChecks) DeepCopyInto(out *HealthChecks) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = make([]HTTPHealthCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthChecks.
+func (in *HealthChecks) DeepCopy() *HealthChecks {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthChecks)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HelmChartDependencyUpdate) DeepCopyInto(out *HelmChartDependencyUpdate) {
 	*out = *in
@@ -757,6 +1445,11 @@ func (in *HelmPromotionMechanism) DeepCopyInto(out *HelmPromotionMechanism) {
 		*out = make([]HelmChartDependencyUpdate, len(*in))
 		copy(*out, *in)
 	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(HelmTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmPromotionMechanism.
@@ -770,54 +1463,221 @@ func (in *HelmPromotionMechanism) DeepCopy() *HelmPromotionMechanism {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Image) DeepCopyInto(out *Image) {
+func (in *HelmSetValue) DeepCopyInto(out *HelmSetValue) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Image.
-func (in *Image) DeepCopy() *Image {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmSetValue.
+func (in *HelmSetValue) DeepCopy() *HelmSetValue {
 	if in == nil {
 		return nil
 	}
-	out := new(Image)
+	out := new(HelmSetValue)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageDiscoveryResult) DeepCopyInto(out *ImageDiscoveryResult) {
+func (in *HelmTemplate) DeepCopyInto(out *HelmTemplate) {
 	*out = *in
-	if in.References != nil {
-		in, out := &in.References, &out.References
-		*out = make([]DiscoveredImageReference, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ValuesFilePaths != nil {
+		in, out := &in.ValuesFilePaths, &out.ValuesFilePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SetValues != nil {
+		in, out := &in.SetValues, &out.SetValues
+		*out = make([]HelmSetValue, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageDiscoveryResult.
-func (in *ImageDiscoveryResult) DeepCopy() *ImageDiscoveryResult {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmTemplate.
+func (in *HelmTemplate) DeepCopy() *HelmTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageDiscoveryResult)
+	out := new(HelmTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageSubscription) DeepCopyInto(out *ImageSubscription) {
+func (in *HostConfig) DeepCopyInto(out *HostConfig) {
 	*out = *in
-	if in.IgnoreTags != nil {
-		in, out := &in.IgnoreTags, &out.IgnoreTags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.WebhookSecretRef != nil {
+		in, out := &in.WebhookSecretRef, &out.WebhookSecretRef
+		*out = new(SecretReference)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSubscription.
-func (in *ImageSubscription) DeepCopy() *ImageSubscription {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostConfig.
+func (in *HostConfig) DeepCopy() *HostConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HostConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Image) DeepCopyInto(out *Image) {
+	*out = *in
+	if in.BuildMetadata != nil {
+		in, out := &in.BuildMetadata, &out.BuildMetadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Image.
+func (in *Image) DeepCopy() *Image {
+	if in == nil {
+		return nil
+	}
+	out := new(Image)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageBuildMetadataField) DeepCopyInto(out *ImageBuildMetadataField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageBuildMetadataField.
+func (in *ImageBuildMetadataField) DeepCopy() *ImageBuildMetadataField {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageBuildMetadataField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageBuildMetadataSource) DeepCopyInto(out *ImageBuildMetadataSource) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]ImageBuildMetadataField, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageBuildMetadataSource.
+func (in *ImageBuildMetadataSource) DeepCopy() *ImageBuildMetadataSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageBuildMetadataSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageDiscoveryResult) DeepCopyInto(out *ImageDiscoveryResult) {
+	*out = *in
+	if in.References != nil {
+		in, out := &in.References, &out.References
+		*out = make([]DiscoveredImageReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageDiscoveryResult.
+func (in *ImageDiscoveryResult) DeepCopy() *ImageDiscoveryResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageDiscoveryResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullSecret) DeepCopyInto(out *ImagePullSecret) {
+	*out = *in
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecret.
+func (in *ImagePullSecret) DeepCopy() *ImagePullSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullSecretTarget) DeepCopyInto(out *ImagePullSecretTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretTarget.
+func (in *ImagePullSecretTarget) DeepCopy() *ImagePullSecretTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecretTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRevisionCheck) DeepCopyInto(out *ImageRevisionCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRevisionCheck.
+func (in *ImageRevisionCheck) DeepCopy() *ImageRevisionCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRevisionCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSubscription) DeepCopyInto(out *ImageSubscription) {
+	*out = *in
+	if in.IgnoreTags != nil {
+		in, out := &in.IgnoreTags, &out.IgnoreTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BuildMetadata != nil {
+		in, out := &in.BuildMetadata, &out.BuildMetadata
+		*out = new(ImageBuildMetadataSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RevisionCheck != nil {
+		in, out := &in.RevisionCheck, &out.RevisionCheck
+		*out = new(ImageRevisionCheck)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSubscription.
+func (in *ImageSubscription) DeepCopy() *ImageSubscription {
 	if in == nil {
 		return nil
 	}
@@ -876,6 +1736,70 @@ func (in *KustomizeImageUpdate) DeepCopy() *KustomizeImageUpdate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KustomizePatch) DeepCopyInto(out *KustomizePatch) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(KustomizePatchTarget)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KustomizePatch.
+func (in *KustomizePatch) DeepCopy() *KustomizePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(KustomizePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KustomizePatchTarget) DeepCopyInto(out *KustomizePatchTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KustomizePatchTarget.
+func (in *KustomizePatchTarget) DeepCopy() *KustomizePatchTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(KustomizePatchTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KustomizePatchesUpdate) DeepCopyInto(out *KustomizePatchesUpdate) {
+	*out = *in
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make([]KustomizePatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]KustomizePatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KustomizePatchesUpdate.
+func (in *KustomizePatchesUpdate) DeepCopy() *KustomizePatchesUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(KustomizePatchesUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KustomizePromotionMechanism) DeepCopyInto(out *KustomizePromotionMechanism) {
 	*out = *in
@@ -884,6 +1808,20 @@ func (in *KustomizePromotionMechanism) DeepCopyInto(out *KustomizePromotionMecha
 		*out = make([]KustomizeImageUpdate, len(*in))
 		copy(*out, *in)
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]KustomizeResourcesUpdate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]KustomizePatchesUpdate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KustomizePromotionMechanism.
@@ -896,6 +1834,100 @@ func (in *KustomizePromotionMechanism) DeepCopy() *KustomizePromotionMechanism {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KustomizeResourcesUpdate) DeepCopyInto(out *KustomizeResourcesUpdate) {
+	*out = *in
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KustomizeResourcesUpdate.
+func (in *KustomizeResourcesUpdate) DeepCopy() *KustomizeResourcesUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(KustomizeResourcesUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceMode) DeepCopyInto(out *MaintenanceMode) {
+	*out = *in
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceMode.
+func (in *MaintenanceMode) DeepCopy() *MaintenanceMode {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceMode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Package) DeepCopyInto(out *Package) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Package.
+func (in *Package) DeepCopy() *Package {
+	if in == nil {
+		return nil
+	}
+	out := new(Package)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageDiscoveryResult) DeepCopyInto(out *PackageDiscoveryResult) {
+	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageDiscoveryResult.
+func (in *PackageDiscoveryResult) DeepCopy() *PackageDiscoveryResult {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageDiscoveryResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageSubscription) DeepCopyInto(out *PackageSubscription) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageSubscription.
+func (in *PackageSubscription) DeepCopy() *PackageSubscription {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageSubscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Project) DeepCopyInto(out *Project) {
 	*out = *in
@@ -927,6 +1959,41 @@ func (in *Project) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectGitConfig) DeepCopyInto(out *ProjectGitConfig) {
+	*out = *in
+	if in.SigningKey != nil {
+		in, out := &in.SigningKey, &out.SigningKey
+		*out = new(GitSigningKey)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectGitConfig.
+func (in *ProjectGitConfig) DeepCopy() *ProjectGitConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectGitConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectIsolation) DeepCopyInto(out *ProjectIsolation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectIsolation.
+func (in *ProjectIsolation) DeepCopy() *ProjectIsolation {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectIsolation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectList) DeepCopyInto(out *ProjectList) {
 	*out = *in
@@ -959,12 +2026,201 @@ func (in *ProjectList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectPromotionHook) DeepCopyInto(out *ProjectPromotionHook) {
+	*out = *in
+	in.HTTP.DeepCopyInto(&out.HTTP)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectPromotionHook.
+func (in *ProjectPromotionHook) DeepCopy() *ProjectPromotionHook {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectPromotionHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRole) DeepCopyInto(out *ProjectRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRole.
+func (in *ProjectRole) DeepCopy() *ProjectRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRoleList) DeepCopyInto(out *ProjectRoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProjectRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleList.
+func (in *ProjectRoleList) DeepCopy() *ProjectRoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRoleSpec) DeepCopyInto(out *ProjectRoleSpec) {
+	*out = *in
+	in.Subjects.DeepCopyInto(&out.Subjects)
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]rbacv1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleSpec.
+func (in *ProjectRoleSpec) DeepCopy() *ProjectRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRoleStatus) DeepCopyInto(out *ProjectRoleStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleStatus.
+func (in *ProjectRoleStatus) DeepCopy() *ProjectRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectRoleSubjects) DeepCopyInto(out *ProjectRoleSubjects) {
+	*out = *in
+	if in.Subs != nil {
+		in, out := &in.Subs, &out.Subs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Emails != nil {
+		in, out := &in.Emails, &out.Emails
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectRoleSubjects.
+func (in *ProjectRoleSubjects) DeepCopy() *ProjectRoleSubjects {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectRoleSubjects)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectSpec) DeepCopyInto(out *ProjectSpec) {
 	*out = *in
 	if in.PromotionPolicies != nil {
 		in, out := &in.PromotionPolicies, &out.PromotionPolicies
 		*out = make([]PromotionPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GitConfig != nil {
+		in, out := &in.GitConfig, &out.GitConfig
+		*out = new(ProjectGitConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceMode != nil {
+		in, out := &in.MaintenanceMode, &out.MaintenanceMode
+		*out = new(MaintenanceMode)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CommitMessageTemplates != nil {
+		in, out := &in.CommitMessageTemplates, &out.CommitMessageTemplates
+		*out = make([]CommitMessageTemplate, len(*in))
+		copy(*out, *in)
+	}
+	if in.Vars != nil {
+		in, out := &in.Vars, &out.Vars
+		*out = make([]ExpressionVariable, len(*in))
+		copy(*out, *in)
+	}
+	if in.PromotionHooks != nil {
+		in, out := &in.PromotionHooks, &out.PromotionHooks
+		*out = make([]ProjectPromotionHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Isolation != nil {
+		in, out := &in.Isolation, &out.Isolation
+		*out = new(ProjectIsolation)
+		**out = **in
+	}
+	if in.ImagePullSecretTargets != nil {
+		in, out := &in.ImagePullSecretTargets, &out.ImagePullSecretTargets
+		*out = make([]ImagePullSecretTarget, len(*in))
 		copy(*out, *in)
 	}
 }
@@ -994,6 +2250,25 @@ func (in *ProjectStatus) DeepCopy() *ProjectStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotedStage) DeepCopyInto(out *PromotedStage) {
+	*out = *in
+	if in.PromotedAt != nil {
+		in, out := &in.PromotedAt, &out.PromotedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotedStage.
+func (in *PromotedStage) DeepCopy() *PromotedStage {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotedStage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Promotion) DeepCopyInto(out *Promotion) {
 	*out = *in
@@ -1021,6 +2296,46 @@ func (in *Promotion) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotionHook) DeepCopyInto(out *PromotionHook) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPPromotionHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSH != nil {
+		in, out := &in.SSH, &out.SSH
+		*out = new(SSHPromotionHook)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionHook.
+func (in *PromotionHook) DeepCopy() *PromotionHook {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotionHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotionHookStatus) DeepCopyInto(out *PromotionHookStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionHookStatus.
+func (in *PromotionHookStatus) DeepCopy() *PromotionHookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotionHookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PromotionInfo) DeepCopyInto(out *PromotionInfo) {
 	*out = *in
@@ -1030,6 +2345,10 @@ func (in *PromotionInfo) DeepCopyInto(out *PromotionInfo) {
 		*out = new(PromotionStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionInfo.
@@ -1091,6 +2410,27 @@ func (in *PromotionMechanisms) DeepCopyInto(out *PromotionMechanisms) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ArgoRolloutUpdates != nil {
+		in, out := &in.ArgoRolloutUpdates, &out.ArgoRolloutUpdates
+		*out = make([]ArgoRolloutUpdate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PrePromotionHooks != nil {
+		in, out := &in.PrePromotionHooks, &out.PrePromotionHooks
+		*out = make([]PromotionHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostPromotionHooks != nil {
+		in, out := &in.PostPromotionHooks, &out.PostPromotionHooks
+		*out = make([]PromotionHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionMechanisms.
@@ -1103,9 +2443,127 @@ func (in *PromotionMechanisms) DeepCopy() *PromotionMechanisms {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotionPlan) DeepCopyInto(out *PromotionPlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionPlan.
+func (in *PromotionPlan) DeepCopy() *PromotionPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotionPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PromotionPlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotionPlanList) DeepCopyInto(out *PromotionPlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PromotionPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionPlanList.
+func (in *PromotionPlanList) DeepCopy() *PromotionPlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotionPlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PromotionPlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotionPlanSpec) DeepCopyInto(out *PromotionPlanSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionPlanSpec.
+func (in *PromotionPlanSpec) DeepCopy() *PromotionPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotionPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotionPlanStatus) DeepCopyInto(out *PromotionPlanStatus) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]PromotionPlanStep, len(*in))
+		copy(*out, *in)
+	}
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionPlanStatus.
+func (in *PromotionPlanStatus) DeepCopy() *PromotionPlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotionPlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromotionPlanStep) DeepCopyInto(out *PromotionPlanStep) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionPlanStep.
+func (in *PromotionPlanStep) DeepCopy() *PromotionPlanStep {
+	if in == nil {
+		return nil
+	}
+	out := new(PromotionPlanStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PromotionPolicy) DeepCopyInto(out *PromotionPolicy) {
 	*out = *in
+	if in.AutoPromotionMinInterval != nil {
+		in, out := &in.AutoPromotionMinInterval, &out.AutoPromotionMinInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionPolicy.
@@ -1148,6 +2606,25 @@ func (in *PromotionStatus) DeepCopyInto(out *PromotionStatus) {
 		*out = new(FreightReference)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PromotionMechanisms != nil {
+		in, out := &in.PromotionMechanisms, &out.PromotionMechanisms
+		*out = new(PromotionMechanisms)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.PrePromotionHooks != nil {
+		in, out := &in.PrePromotionHooks, &out.PrePromotionHooks
+		*out = make([]PromotionHookStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostPromotionHooks != nil {
+		in, out := &in.PostPromotionHooks, &out.PostPromotionHooks
+		*out = make([]PromotionHookStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromotionStatus.
@@ -1160,6 +2637,21 @@ func (in *PromotionStatus) DeepCopy() *PromotionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullRequestBranchCleanup) DeepCopyInto(out *PullRequestBranchCleanup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestBranchCleanup.
+func (in *PullRequestBranchCleanup) DeepCopy() *PullRequestBranchCleanup {
+	if in == nil {
+		return nil
+	}
+	out := new(PullRequestBranchCleanup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PullRequestPromotionMechanism) DeepCopyInto(out *PullRequestPromotionMechanism) {
 	*out = *in
@@ -1173,6 +2665,11 @@ func (in *PullRequestPromotionMechanism) DeepCopyInto(out *PullRequestPromotionM
 		*out = new(GitLabPullRequest)
 		**out = **in
 	}
+	if in.BranchCleanup != nil {
+		in, out := &in.BranchCleanup, &out.BranchCleanup
+		*out = new(PullRequestBranchCleanup)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestPromotionMechanism.
@@ -1185,6 +2682,26 @@ func (in *PullRequestPromotionMechanism) DeepCopy() *PullRequestPromotionMechani
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QualificationHook) DeepCopyInto(out *QualificationHook) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QualificationHook.
+func (in *QualificationHook) DeepCopy() *QualificationHook {
+	if in == nil {
+		return nil
+	}
+	out := new(QualificationHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepoSubscription) DeepCopyInto(out *RepoSubscription) {
 	*out = *in
@@ -1201,6 +2718,11 @@ func (in *RepoSubscription) DeepCopyInto(out *RepoSubscription) {
 	if in.Chart != nil {
 		in, out := &in.Chart, &out.Chart
 		*out = new(ChartSubscription)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Package != nil {
+		in, out := &in.Package, &out.Package
+		*out = new(PackageSubscription)
 		**out = **in
 	}
 }
@@ -1215,6 +2737,41 @@ func (in *RepoSubscription) DeepCopy() *RepoSubscription {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHPromotionHook) DeepCopyInto(out *SSHPromotionHook) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHPromotionHook.
+func (in *SSHPromotionHook) DeepCopy() *SSHPromotionHook {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHPromotionHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretReference) DeepCopyInto(out *SecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Stage) DeepCopyInto(out *Stage) {
 	*out = *in
@@ -1288,6 +2845,38 @@ func (in *StageSpec) DeepCopyInto(out *StageSpec) {
 		*out = new(Verification)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.QualificationHook != nil {
+		in, out := &in.QualificationHook, &out.QualificationHook
+		*out = new(QualificationHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(DriftDetection)
+		**out = **in
+	}
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = new(HealthChecks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitProviderNotifications != nil {
+		in, out := &in.GitProviderNotifications, &out.GitProviderNotifications
+		*out = new(GitProviderNotifications)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Vars != nil {
+		in, out := &in.Vars, &out.Vars
+		*out = make([]ExpressionVariable, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]ImagePullSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageSpec.
@@ -1330,6 +2919,20 @@ func (in *StageStatus) DeepCopyInto(out *StageStatus) {
 		*out = new(PromotionInfo)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]ImagePullSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StageStatus.
@@ -1365,6 +2968,11 @@ func (in *Subscriptions) DeepCopyInto(out *Subscriptions) {
 		*out = make([]StageSubscription, len(*in))
 		copy(*out, *in)
 	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]ArtifactKind, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subscriptions.
@@ -1395,6 +3003,11 @@ func (in *Verification) DeepCopyInto(out *Verification) {
 		*out = make([]AnalysisRunArgument, len(*in))
 		copy(*out, *in)
 	}
+	if in.AutoQualifyAfter != nil {
+		in, out := &in.AutoQualifyAfter, &out.AutoQualifyAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Verification.
@@ -1459,6 +3072,10 @@ func (in VerificationInfoStack) DeepCopy() VerificationInfoStack {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VerifiedStage) DeepCopyInto(out *VerifiedStage) {
 	*out = *in
+	if in.VerifiedAt != nil {
+		in, out := &in.VerifiedAt, &out.VerifiedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerifiedStage.
@@ -1530,6 +3147,31 @@ func (in *WarehouseList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarehousePolling) DeepCopyInto(out *WarehousePolling) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinInterval != nil {
+		in, out := &in.MinInterval, &out.MinInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarehousePolling.
+func (in *WarehousePolling) DeepCopy() *WarehousePolling {
+	if in == nil {
+		return nil
+	}
+	out := new(WarehousePolling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WarehouseSpec) DeepCopyInto(out *WarehouseSpec) {
 	*out = *in
@@ -1540,6 +3182,11 @@ func (in *WarehouseSpec) DeepCopyInto(out *WarehouseSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Polling != nil {
+		in, out := &in.Polling, &out.Polling
+		*out = new(WarehousePolling)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarehouseSpec.
@@ -1560,6 +3207,18 @@ func (in *WarehouseStatus) DeepCopyInto(out *WarehouseStatus) {
 		*out = new(DiscoveredArtifacts)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarehouseStatus.
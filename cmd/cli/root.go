@@ -9,6 +9,7 @@ import (
 
 	"github.com/akuity/kargo/internal/cli/cmd/apply"
 	"github.com/akuity/kargo/internal/cli/cmd/approve"
+	"github.com/akuity/kargo/internal/cli/cmd/block"
 	cliconfigcmd "github.com/akuity/kargo/internal/cli/cmd/config"
 	"github.com/akuity/kargo/internal/cli/cmd/create"
 	"github.com/akuity/kargo/internal/cli/cmd/dashboard"
@@ -17,13 +18,18 @@ import (
 	"github.com/akuity/kargo/internal/cli/cmd/grant"
 	"github.com/akuity/kargo/internal/cli/cmd/login"
 	"github.com/akuity/kargo/internal/cli/cmd/logout"
+	"github.com/akuity/kargo/internal/cli/cmd/logs"
+	"github.com/akuity/kargo/internal/cli/cmd/migrate"
 	"github.com/akuity/kargo/internal/cli/cmd/promote"
 	"github.com/akuity/kargo/internal/cli/cmd/refresh"
 	"github.com/akuity/kargo/internal/cli/cmd/revoke"
 	"github.com/akuity/kargo/internal/cli/cmd/server"
+	"github.com/akuity/kargo/internal/cli/cmd/unblock"
 	"github.com/akuity/kargo/internal/cli/cmd/update"
+	"github.com/akuity/kargo/internal/cli/cmd/validate"
 	"github.com/akuity/kargo/internal/cli/cmd/verify"
 	"github.com/akuity/kargo/internal/cli/cmd/version"
+	"github.com/akuity/kargo/internal/cli/cmd/whoami"
 	clicfg "github.com/akuity/kargo/internal/cli/config"
 	"github.com/akuity/kargo/internal/cli/io"
 )
@@ -45,20 +51,26 @@ func NewRootCommand(cfg clicfg.CLIConfig) *cobra.Command {
 	// Register the subcommands.
 	cmd.AddCommand(apply.NewCommand(cfg, streams))
 	cmd.AddCommand(approve.NewCommand(cfg))
+	cmd.AddCommand(block.NewCommand(cfg))
 	cmd.AddCommand(cliconfigcmd.NewCommand(cfg, streams))
 	cmd.AddCommand(create.NewCommand(cfg, streams))
 	cmd.AddCommand(delete.NewCommand(cfg, streams))
 	cmd.AddCommand(get.NewCommand(cfg, streams))
 	cmd.AddCommand(grant.NewCommand(cfg, streams))
-	cmd.AddCommand(login.NewCommand(cfg))
+	cmd.AddCommand(login.NewCommand(cfg, streams))
 	cmd.AddCommand(logout.NewCommand())
+	cmd.AddCommand(logs.NewCommand(cfg, streams))
+	cmd.AddCommand(migrate.NewCommand(cfg, streams))
 	cmd.AddCommand(refresh.NewCommand(cfg))
 	cmd.AddCommand(revoke.NewCommand(cfg, streams))
+	cmd.AddCommand(unblock.NewCommand(cfg))
 	cmd.AddCommand(update.NewCommand(cfg, streams))
+	cmd.AddCommand(validate.NewCommand(cfg, streams))
 	cmd.AddCommand(dashboard.NewCommand(cfg))
 	cmd.AddCommand(promote.NewCommand(cfg, streams))
 	cmd.AddCommand(verify.NewCommand(cfg))
 	cmd.AddCommand(version.NewCommand(cfg, streams))
+	cmd.AddCommand(whoami.NewCommand(cfg, streams))
 	cmd.AddCommand(server.NewCommand())
 	cmd.AddCommand(
 		cobracompletefig.CreateCompletionSpecCommand(
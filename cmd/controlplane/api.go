@@ -10,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8s "k8s.io/client-go/kubernetes"
 	kubescheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
@@ -22,7 +23,9 @@ import (
 	"github.com/akuity/kargo/internal/api/config"
 	"github.com/akuity/kargo/internal/api/kubernetes"
 	"github.com/akuity/kargo/internal/api/rbac"
+	"github.com/akuity/kargo/internal/controller/git"
 	rollouts "github.com/akuity/kargo/internal/controller/rollouts/api/v1alpha1"
+	"github.com/akuity/kargo/internal/credentials"
 	"github.com/akuity/kargo/internal/kubeclient"
 	libEvent "github.com/akuity/kargo/internal/kubernetes/event"
 	"github.com/akuity/kargo/internal/os"
@@ -35,6 +38,8 @@ type apiOptions struct {
 	Host string
 	Port string
 
+	MetricsBindAddress string
+
 	Logger *log.Logger
 }
 
@@ -63,6 +68,9 @@ func (o *apiOptions) complete() {
 
 	o.Host = os.GetEnv("HOST", "0.0.0.0")
 	o.Port = os.GetEnv("PORT", "8080")
+
+	// Metrics are not served unless an address to serve them on is specified
+	o.MetricsBindAddress = os.GetEnv("METRICS_BIND_ADDRESS", "0")
 }
 
 func (o *apiOptions) run(ctx context.Context) error {
@@ -74,6 +82,13 @@ func (o *apiOptions) run(ctx context.Context) error {
 
 	cfg := config.ServerConfigFromEnv()
 
+	credentialsCfg, err := credentials.KubernetesDatabaseConfigFromEnv()
+	if err != nil {
+		return fmt.Errorf("error initializing credentials database: %w", err)
+	}
+	// Previewing Warehouses may require cloning git repositories over plain HTTP
+	git.ConfigureInsecureHTTPHosts(credentialsCfg.GitInsecureHTTPHosts)
+
 	clientCfg, internalClient, recorder, err := o.setupAPIClient(ctx)
 	if err != nil {
 		return fmt.Errorf("error setting up internal Kubernetes API client: %w", err)
@@ -107,12 +122,19 @@ func (o *apiOptions) run(ctx context.Context) error {
 		}).Info("SSO via OpenID Connect is enabled")
 	}
 
+	clientset, err := k8s.NewForConfig(clientCfg)
+	if err != nil {
+		return fmt.Errorf("error creating Kubernetes clientset for Kargo API server: %w", err)
+	}
+
 	srv := api.NewServer(
 		cfg,
 		kubeClient,
 		internalClient,
+		clientset.CoreV1(),
 		rbac.NewKubernetesRolesDatabase(kubeClient),
 		recorder,
+		credentialsCfg,
 	)
 	l, err := net.Listen("tcp", fmt.Sprintf("%s:%s", o.Host, o.Port))
 	if err != nil {
@@ -155,11 +177,12 @@ func (o *apiOptions) setupAPIClient(ctx context.Context) (*rest.Config, client.C
 	mgr, err := ctrl.NewManager(restCfg, ctrl.Options{
 		Scheme: scheme,
 		Metrics: server.Options{
-			BindAddress: "0",
+			BindAddress: o.MetricsBindAddress,
 		},
 		Client: client.Options{
 			Cache: &client.CacheOptions{
 				DisableFor: []client.Object{
+					&corev1.Pod{},
 					&corev1.Secret{},
 				},
 			},
@@ -21,6 +21,9 @@ import (
 	libargocd "github.com/akuity/kargo/internal/argocd"
 	"github.com/akuity/kargo/internal/controller"
 	argocd "github.com/akuity/kargo/internal/controller/argocd/api/v1alpha1"
+	"github.com/akuity/kargo/internal/controller/clusterconfigs"
+	"github.com/akuity/kargo/internal/controller/git"
+	"github.com/akuity/kargo/internal/controller/promotionplans"
 	"github.com/akuity/kargo/internal/controller/promotions"
 	rollouts "github.com/akuity/kargo/internal/controller/rollouts/api/v1alpha1"
 	"github.com/akuity/kargo/internal/controller/stages"
@@ -37,10 +40,16 @@ type controllerOptions struct {
 	ShardName  string
 	KubeConfig string
 
+	// PromotionRunnerProject is the name of the Project to which the controller
+	// is dedicated, if it is running as a promotion runner.
+	PromotionRunnerProject string
+
 	ArgoCDEnabled       bool
 	ArgoCDKubeConfig    string
 	ArgoCDNamespaceOnly bool
 
+	MetricsBindAddress string
+
 	Logger *log.Logger
 }
 
@@ -70,6 +79,9 @@ func (o *controllerOptions) complete() {
 	o.ArgoCDEnabled = types.MustParseBool(os.GetEnv("ARGOCD_INTEGRATION_ENABLED", "true"))
 	o.ArgoCDKubeConfig = os.GetEnv("ARGOCD_KUBECONFIG", "")
 	o.ArgoCDNamespaceOnly = types.MustParseBool(os.GetEnv("ARGOCD_WATCH_ARGOCD_NAMESPACE_ONLY", "false"))
+
+	// Metrics are not served unless an address to serve them on is specified
+	o.MetricsBindAddress = os.GetEnv("METRICS_BIND_ADDRESS", "0")
 }
 
 func (o *controllerOptions) run(ctx context.Context) error {
@@ -82,11 +94,20 @@ func (o *controllerOptions) run(ctx context.Context) error {
 	if o.ShardName != "" {
 		startupLogEntry = startupLogEntry.WithField("shard", o.ShardName)
 	}
-	startupLogEntry.Info("Starting Kargo Controller")
 
 	promotionsReconcilerCfg := promotions.ReconcilerConfigFromEnv()
 	stagesReconcilerCfg := stages.ReconcilerConfigFromEnv()
 
+	if o.PromotionRunnerProject = promotionsReconcilerCfg.PromotionRunnerProject; o.PromotionRunnerProject != "" {
+		// A promotion runner only ever needs to see Argo CD Applications in Argo
+		// CD's own namespace, which is the only one it is permitted to access.
+		o.ArgoCDNamespaceOnly = true
+		startupLogEntry.WithField("project", o.PromotionRunnerProject).
+			Info("Starting Kargo Promotion Runner")
+	} else {
+		startupLogEntry.Info("Starting Kargo Controller")
+	}
+
 	kargoMgr, err := o.setupKargoManager(ctx, stagesReconcilerCfg)
 	if err != nil {
 		return fmt.Errorf("error initializing Kargo controller manager: %w", err)
@@ -97,9 +118,14 @@ func (o *controllerOptions) run(ctx context.Context) error {
 		return fmt.Errorf("error initializing Argo CD Application controller manager: %w", err)
 	}
 
+	credentialsCfg, err := credentials.KubernetesDatabaseConfigFromEnv()
+	if err != nil {
+		return fmt.Errorf("error initializing credentials database: %w", err)
+	}
+	git.ConfigureInsecureHTTPHosts(credentialsCfg.GitInsecureHTTPHosts)
 	credentialsDB := credentials.NewKubernetesDatabase(
 		kargoMgr.GetClient(),
-		credentials.KubernetesDatabaseConfigFromEnv(),
+		credentialsCfg,
 	)
 
 	if err := o.setupReconcilers(
@@ -178,15 +204,28 @@ func (o *controllerOptions) setupKargoManager(
 			},
 		},
 	}
+	var clientOpts client.Options
+	if o.PromotionRunnerProject != "" {
+		// A promotion runner is only permitted to access the namespace of the
+		// Project it is dedicated to and to get that Project itself. Since it is
+		// not permitted to list or watch Projects, they must not be cached.
+		cacheOpts.DefaultNamespaces = map[string]cache.Config{
+			o.PromotionRunnerProject: {},
+		}
+		clientOpts.Cache = &client.CacheOptions{
+			DisableFor: []client.Object{&kargoapi.Project{}},
+		}
+	}
 
 	return ctrl.NewManager(
 		restCfg,
 		ctrl.Options{
 			Scheme: scheme,
 			Metrics: server.Options{
-				BindAddress: "0",
+				BindAddress: o.MetricsBindAddress,
 			},
-			Cache: cacheOpts,
+			Cache:  cacheOpts,
+			Client: clientOpts,
 		},
 	)
 }
@@ -275,19 +314,39 @@ func (o *controllerOptions) setupReconcilers(
 		return fmt.Errorf("error setting up Promotions reconciler: %w", err)
 	}
 
+	// A promotion runner only executes Promotions. Everything else is
+	// orchestrated by the shared controller.
+	if o.PromotionRunnerProject != "" {
+		return nil
+	}
+
+	if err := clusterconfigs.SetupReconcilerWithManager(kargoMgr); err != nil {
+		return fmt.Errorf("error setting up ClusterConfig reconciler: %w", err)
+	}
+
 	if err := stages.SetupReconcilerWithManager(
 		ctx,
 		kargoMgr,
 		argocdMgr,
+		credentialsDB,
 		stagesReconcilerCfg,
 	); err != nil {
 		return fmt.Errorf("error setting up Stages reconciler: %w", err)
 	}
 
+	if err := promotionplans.SetupReconcilerWithManager(
+		ctx,
+		kargoMgr,
+		o.ShardName,
+	); err != nil {
+		return fmt.Errorf("error setting up PromotionPlans reconciler: %w", err)
+	}
+
 	if err := warehouses.SetupReconcilerWithManager(
+		ctx,
 		kargoMgr,
 		credentialsDB,
-		o.ShardName,
+		warehouses.ReconcilerConfigFromEnv(),
 	); err != nil {
 		return fmt.Errorf("error setting up Warehouses reconciler: %w", err)
 	}
@@ -6,16 +6,22 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/api/kubernetes"
+	argocd "github.com/akuity/kargo/internal/controller/argocd/api/v1alpha1"
 	"github.com/akuity/kargo/internal/controller/management/namespaces"
+	"github.com/akuity/kargo/internal/controller/management/projectroles"
 	"github.com/akuity/kargo/internal/controller/management/projects"
 	"github.com/akuity/kargo/internal/os"
 	versionpkg "github.com/akuity/kargo/internal/version"
@@ -59,7 +65,9 @@ func (o *managementControllerOptions) run(ctx context.Context) error {
 		"commit":  version.GitCommit,
 	}).Info("Starting Kargo Management Controller")
 
-	kargoMgr, err := o.setupManager(ctx)
+	projectsCfg := projects.ReconcilerConfigFromEnv()
+
+	kargoMgr, err := o.setupManager(ctx, &projectsCfg)
 	if err != nil {
 		return fmt.Errorf("error initializing Kargo controller manager: %w", err)
 	}
@@ -70,18 +78,25 @@ func (o *managementControllerOptions) run(ctx context.Context) error {
 
 	if err := projects.SetupReconcilerWithManager(
 		kargoMgr,
-		projects.ReconcilerConfigFromEnv(),
+		projectsCfg,
 	); err != nil {
 		return fmt.Errorf("error setting up Projects reconciler: %w", err)
 	}
 
+	if err := projectroles.SetupReconcilerWithManager(kargoMgr); err != nil {
+		return fmt.Errorf("error setting up ProjectRoles reconciler: %w", err)
+	}
+
 	if err := kargoMgr.Start(ctx); err != nil {
 		return fmt.Errorf("error starting kargo manager: %w", err)
 	}
 	return nil
 }
 
-func (o *managementControllerOptions) setupManager(ctx context.Context) (manager.Manager, error) {
+func (o *managementControllerOptions) setupManager(
+	ctx context.Context,
+	projectsCfg *projects.ReconcilerConfig,
+) (manager.Manager, error) {
 	restCfg, err := kubernetes.GetRestConfig(ctx, o.KubeConfig)
 	if err != nil {
 		return nil, fmt.Errorf("error loading REST config for Kargo controller manager: %w", err)
@@ -101,6 +116,18 @@ func (o *managementControllerOptions) setupManager(ctx context.Context) (manager
 			err,
 		)
 	}
+	if err = appsv1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf(
+			"error adding Kubernetes apps API to Kargo controller manager scheme: %w",
+			err,
+		)
+	}
+	if err = networkingv1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf(
+			"error adding Kubernetes networking API to Kargo controller manager scheme: %w",
+			err,
+		)
+	}
 	if err = kargoapi.AddToScheme(scheme); err != nil {
 		return nil, fmt.Errorf(
 			"error adding Kargo API to Kargo controller manager scheme: %w",
@@ -108,6 +135,33 @@ func (o *managementControllerOptions) setupManager(ctx context.Context) (manager
 		)
 	}
 
+	cacheOpts := cache.Options{}
+	if projectsCfg.PromotionRunnerImage != "" && projectsCfg.ArgoCDNamespace != "" {
+		if !argoCDExists(ctx, restCfg, projectsCfg.ArgoCDNamespace) {
+			o.Logger.Warn(
+				"Argo CD integration was enabled, but no Argo CD CRDs were found. " +
+					"Proceeding without Argo CD integration for promotion runners.",
+			)
+			projectsCfg.ArgoCDNamespace = ""
+		} else {
+			if err = argocd.AddToScheme(scheme); err != nil {
+				return nil, fmt.Errorf(
+					"error adding Argo CD API to Kargo controller manager scheme: %w",
+					err,
+				)
+			}
+			// Only Applications in the Argo CD namespace are of interest when
+			// determining what promotion runners may update.
+			cacheOpts.ByObject = map[client.Object]cache.ByObject{
+				&argocd.Application{}: {
+					Namespaces: map[string]cache.Config{
+						projectsCfg.ArgoCDNamespace: {},
+					},
+				},
+			}
+		}
+	}
+
 	return ctrl.NewManager(
 		restCfg,
 		ctrl.Options{
@@ -115,6 +169,7 @@ func (o *managementControllerOptions) setupManager(ctx context.Context) (manager
 			Metrics: server.Options{
 				BindAddress: "0",
 			},
+			Cache: cacheOpts,
 		},
 	)
 }
@@ -23,6 +23,7 @@ func Execute(ctx context.Context) error {
 	rootCmd.AddCommand(newControllerCommand())
 	rootCmd.AddCommand(newGarbageCollectorCommand())
 	rootCmd.AddCommand(newManagementControllerCommand())
+	rootCmd.AddCommand(newSandboxCommand())
 	rootCmd.AddCommand(newVersionCommand())
 	rootCmd.AddCommand(newWebhooksServerCommand())
 	return rootCmd.ExecuteContext(ctx)
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	libExec "github.com/akuity/kargo/internal/exec"
+)
+
+type sandboxOptions struct {
+	Limits libExec.Limits
+}
+
+// newSandboxCommand returns the command used internally by the controller to
+// execute the commands on which promotion mechanisms rely subject to resource
+// limits. It is not intended to be invoked directly.
+func newSandboxCommand() *cobra.Command {
+	cmdOpts := &sandboxOptions{}
+
+	cmd := &cobra.Command{
+		Use:               libExec.SandboxCommand + " [flags] -- command [args...]",
+		Hidden:            true,
+		DisableAutoGenTag: true,
+		SilenceErrors:     true,
+		SilenceUsage:      true,
+		Args:              cobra.MinimumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return libExec.ApplyLimits(cmdOpts.Limits, args)
+		},
+	}
+
+	cmd.Flags().DurationVar(
+		&cmdOpts.Limits.CPUTime,
+		"cpu-time",
+		0,
+		"Maximum amount of CPU time the command may consume",
+	)
+	cmd.Flags().Int64Var(
+		&cmdOpts.Limits.Memory,
+		"memory",
+		0,
+		"Maximum number of bytes of memory the command may allocate",
+	)
+	cmd.Flags().Int64Var(
+		&cmdOpts.Limits.FileSize,
+		"file-size",
+		0,
+		"Maximum size, in bytes, of any single file the command may write",
+	)
+
+	return cmd
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/akuity/kargo/internal/webhook/freight"
 	"github.com/akuity/kargo/internal/webhook/project"
 	"github.com/akuity/kargo/internal/webhook/promotion"
+	"github.com/akuity/kargo/internal/webhook/promotionplan"
 	"github.com/akuity/kargo/internal/webhook/stage"
 	"github.com/akuity/kargo/internal/webhook/warehouse"
 )
@@ -120,6 +121,9 @@ func (o *webhooksServerOptions) run(ctx context.Context) error {
 	if err = promotion.SetupWebhookWithManager(ctx, webhookCfg, mgr); err != nil {
 		return fmt.Errorf("setup Promotion webhook: %w", err)
 	}
+	if err = promotionplan.SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("setup PromotionPlan webhook: %w", err)
+	}
 	if err = stage.SetupWebhookWithManager(webhookCfg, mgr); err != nil {
 		return fmt.Errorf("setup Stage webhook: %w", err)
 	}
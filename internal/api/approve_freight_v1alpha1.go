@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"connectrpc.com/connect"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,6 +43,17 @@ func (s *server) ApproveFreight(
 		return nil, err
 	}
 
+	var validFor time.Duration
+	if validForStr := req.Msg.GetValidFor(); validForStr != "" {
+		var err error
+		if validFor, err = time.ParseDuration(validForStr); err != nil || validFor <= 0 {
+			return nil, connect.NewError(
+				connect.CodeInvalidArgument,
+				fmt.Errorf("valid_for %q is not a positive duration", validForStr),
+			)
+		}
+	}
+
 	if err := s.validateProjectExistsFn(ctx, project); err != nil {
 		return nil, err
 	}
@@ -104,11 +117,21 @@ func (s *server) ApproveFreight(
 		newStatus.ApprovedFor = map[string]kargoapi.ApprovedStage{}
 	}
 
-	if _, ok := newStatus.ApprovedFor[stageName]; ok {
+	// An existing approval that does not expire cannot be improved upon, unless
+	// it is being replaced by one that does.
+	if approval, ok := newStatus.ApprovedFor[stageName]; ok &&
+		approval.ExpiresAt == nil && validFor == 0 {
 		return &connect.Response[svcv1alpha1.ApproveFreightResponse]{}, nil
 	}
 
-	newStatus.ApprovedFor[stageName] = kargoapi.ApprovedStage{}
+	now := time.Now()
+	approval := kargoapi.ApprovedStage{
+		ApprovedAt: &metav1.Time{Time: now},
+	}
+	if validFor > 0 {
+		approval.ExpiresAt = &metav1.Time{Time: now.Add(validFor)}
+	}
+	newStatus.ApprovedFor[stageName] = approval
 
 	if err := s.patchFreightStatusFn(ctx, freight, newStatus); err != nil {
 		return nil, fmt.Errorf("patch status: %w", err)
@@ -120,6 +143,12 @@ func (s *server) ApproveFreight(
 		actor = kargoapi.FormatEventUserActor(u)
 		eventMsg += fmt.Sprintf(" by %q", actor)
 	}
+	if approval.ExpiresAt != nil {
+		eventMsg += fmt.Sprintf(
+			" until %s",
+			approval.ExpiresAt.UTC().Format(time.RFC3339),
+		)
+	}
 
 	s.recorder.AnnotatedEventf(
 		freight,
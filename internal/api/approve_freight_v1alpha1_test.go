@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/stretchr/testify/require"
@@ -45,6 +46,25 @@ func TestApproveFreight(t *testing.T) {
 				require.Equal(t, connect.CodeInvalidArgument, connErr.Code())
 			},
 		},
+		{
+			name: "invalid validity duration",
+			req: &svcv1alpha1.ApproveFreightRequest{
+				Project:  "fake-project",
+				Name:     "fake-freight",
+				Stage:    "fake-stage",
+				ValidFor: "-72h",
+			},
+			server: &server{},
+			assertions: func(
+				t *testing.T,
+				_ *fakeevent.EventRecorder,
+				_ *connect.Response[svcv1alpha1.ApproveFreightResponse],
+				err error,
+			) {
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+				require.ErrorContains(t, err, "not a positive duration")
+			},
+		},
 		{
 			name: "error validating project",
 			req: &svcv1alpha1.ApproveFreightRequest{
@@ -371,6 +391,76 @@ func TestApproveFreight(t *testing.T) {
 				require.Equal(t, kargoapi.EventReasonFreightApproved, event.Reason)
 			},
 		},
+		{
+			name: "success with expiration",
+			req: &svcv1alpha1.ApproveFreightRequest{
+				Project:  "fake-project",
+				Name:     "fake-freight",
+				Stage:    "fake-stage",
+				ValidFor: "72h",
+			},
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getFreightByNameOrAliasFn: func(
+					context.Context,
+					client.Client,
+					string,
+					string,
+					string,
+				) (*kargoapi.Freight, error) {
+					// Already approved without an expiration
+					return &kargoapi.Freight{
+						Status: kargoapi.FreightStatus{
+							ApprovedFor: map[string]kargoapi.ApprovedStage{
+								"fake-stage": {},
+							},
+						},
+					}, nil
+				},
+				getStageFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Stage, error) {
+					return &kargoapi.Stage{}, nil
+				},
+				authorizeFn: func(
+					context.Context,
+					string,
+					schema.GroupVersionResource,
+					string,
+					client.ObjectKey,
+				) error {
+					return nil
+				},
+				patchFreightStatusFn: func(
+					_ context.Context,
+					_ *kargoapi.Freight,
+					status kargoapi.FreightStatus,
+				) error {
+					approval := status.ApprovedFor["fake-stage"]
+					if approval.ApprovedAt == nil || approval.ExpiresAt == nil ||
+						approval.ExpiresAt.Sub(approval.ApprovedAt.Time) != 72*time.Hour {
+						return errors.New("unexpected approval")
+					}
+					return nil
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				recorder *fakeevent.EventRecorder,
+				_ *connect.Response[svcv1alpha1.ApproveFreightResponse],
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Len(t, recorder.Events, 1)
+				event := <-recorder.Events
+				require.Equal(t, kargoapi.EventReasonFreightApproved, event.Reason)
+				require.Contains(t, event.Message, "until")
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/api/user"
+	"github.com/akuity/kargo/internal/logging"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+// BlockFreight blocks a piece of Freight project-wide, preventing any further
+// promotion of it to any Stage. Stages that are currently using the Freight
+// are warned via events.
+func (s *server) BlockFreight(
+	ctx context.Context,
+	req *connect.Request[svcv1alpha1.BlockFreightRequest],
+) (*connect.Response[svcv1alpha1.BlockFreightResponse], error) {
+	project := req.Msg.GetProject()
+	if err := validateFieldNotEmpty("project", project); err != nil {
+		return nil, err
+	}
+
+	name := req.Msg.GetName()
+	alias := req.Msg.GetAlias()
+	if (name == "" && alias == "") ||
+		(name != "" && alias != "") {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("exactly one of name or alias should not be empty"),
+		)
+	}
+
+	reason := req.Msg.GetReason()
+	if err := validateFieldNotEmpty("reason", reason); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateProjectExistsFn(ctx, project); err != nil {
+		return nil, err
+	}
+
+	freight, err := s.getFreightByNameOrAliasFn(
+		ctx,
+		s.client,
+		project,
+		name,
+		alias,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get freight: %w", err)
+	}
+	if freight == nil {
+		if name != "" {
+			err = fmt.Errorf("freight %q not found in namespace %q", name, project)
+		} else {
+			err = fmt.Errorf("freight with alias %q not found in namespace %q", alias, project)
+		}
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	if freight.IsBlocked() && freight.Status.Blocked.Reason == reason {
+		return &connect.Response[svcv1alpha1.BlockFreightResponse]{}, nil
+	}
+
+	var actor string
+	if u, ok := user.InfoFromContext(ctx); ok {
+		actor = kargoapi.FormatEventUserActor(u)
+	}
+
+	newStatus := *freight.Status.DeepCopy()
+	newStatus.Blocked = &kargoapi.FreightBlock{
+		Reason: reason,
+		Actor:  actor,
+		Time:   &metav1.Time{Time: metav1.Now().Time},
+	}
+	if err = s.patchFreightStatusFn(ctx, freight, newStatus); err != nil {
+		return nil, fmt.Errorf("patch status: %w", err)
+	}
+
+	eventMsg := fmt.Sprintf("Freight blocked: %s", reason)
+	if actor != "" {
+		eventMsg = fmt.Sprintf("Freight blocked by %q: %s", actor, reason)
+	}
+	s.recorder.AnnotatedEventf(
+		freight,
+		kargoapi.NewFreightBlockedEventAnnotations(actor, freight),
+		corev1.EventTypeWarning,
+		kargoapi.EventReasonFreightBlocked,
+		eventMsg,
+	)
+
+	// Warn any Stages that are currently using the Freight. Failure to do so
+	// is not fatal, as the Freight has already been blocked.
+	stages := kargoapi.StageList{}
+	if err = s.listStagesFn(ctx, &stages, client.InNamespace(project)); err != nil {
+		logging.LoggerFromContext(ctx).WithError(err).
+			Warn("error listing Stages to warn about blocked Freight")
+		return &connect.Response[svcv1alpha1.BlockFreightResponse]{}, nil
+	}
+	for i := range stages.Items {
+		stage := &stages.Items[i]
+		if stage.Status.CurrentFreight == nil ||
+			stage.Status.CurrentFreight.Name != freight.Name {
+			continue
+		}
+		annotations := kargoapi.NewFreightBlockedEventAnnotations(actor, freight)
+		annotations[kargoapi.AnnotationKeyEventStageName] = stage.Name
+		s.recorder.AnnotatedEventf(
+			stage,
+			annotations,
+			corev1.EventTypeWarning,
+			kargoapi.EventReasonFreightBlocked,
+			"Current Freight %q has been blocked: %s",
+			freight.Name,
+			reason,
+		)
+	}
+
+	return &connect.Response[svcv1alpha1.BlockFreightResponse]{}, nil
+}
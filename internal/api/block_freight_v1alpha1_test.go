@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	fakeevent "github.com/akuity/kargo/internal/kubernetes/event/fake"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func TestBlockFreight(t *testing.T) {
+	testCases := []struct {
+		name       string
+		req        *svcv1alpha1.BlockFreightRequest
+		server     *server
+		assertions func(*testing.T, *fakeevent.EventRecorder, error)
+	}{
+		{
+			name:   "input validation error",
+			req:    &svcv1alpha1.BlockFreightRequest{},
+			server: &server{},
+			assertions: func(t *testing.T, _ *fakeevent.EventRecorder, err error) {
+				require.Error(t, err)
+				var connErr *connect.Error
+				require.True(t, errors.As(err, &connErr))
+				require.Equal(t, connect.CodeInvalidArgument, connErr.Code())
+			},
+		},
+		{
+			name: "reason not specified",
+			req: &svcv1alpha1.BlockFreightRequest{
+				Project: "fake-project",
+				Name:    "fake-freight",
+			},
+			server: &server{},
+			assertions: func(t *testing.T, _ *fakeevent.EventRecorder, err error) {
+				require.Error(t, err)
+				var connErr *connect.Error
+				require.True(t, errors.As(err, &connErr))
+				require.Equal(t, connect.CodeInvalidArgument, connErr.Code())
+			},
+		},
+		{
+			name: "Freight not found",
+			req: &svcv1alpha1.BlockFreightRequest{
+				Project: "fake-project",
+				Name:    "fake-freight",
+				Reason:  "fake-reason",
+			},
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getFreightByNameOrAliasFn: func(
+					context.Context,
+					client.Client,
+					string, string, string,
+				) (*kargoapi.Freight, error) {
+					return nil, nil
+				},
+			},
+			assertions: func(t *testing.T, _ *fakeevent.EventRecorder, err error) {
+				require.Error(t, err)
+				var connErr *connect.Error
+				require.True(t, errors.As(err, &connErr))
+				require.Equal(t, connect.CodeNotFound, connErr.Code())
+			},
+		},
+		{
+			name: "error patching Freight status",
+			req: &svcv1alpha1.BlockFreightRequest{
+				Project: "fake-project",
+				Name:    "fake-freight",
+				Reason:  "fake-reason",
+			},
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getFreightByNameOrAliasFn: func(
+					context.Context,
+					client.Client,
+					string, string, string,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{}, nil
+				},
+				patchFreightStatusFn: func(
+					context.Context,
+					*kargoapi.Freight,
+					kargoapi.FreightStatus,
+				) error {
+					return errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, _ *fakeevent.EventRecorder, err error) {
+				require.ErrorContains(t, err, "patch status: something went wrong")
+			},
+		},
+		{
+			name: "success",
+			req: &svcv1alpha1.BlockFreightRequest{
+				Project: "fake-project",
+				Name:    "fake-freight",
+				Reason:  "fake-reason",
+			},
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getFreightByNameOrAliasFn: func(
+					context.Context,
+					client.Client,
+					string, string, string,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "fake-freight",
+							Namespace: "fake-project",
+						},
+					}, nil
+				},
+				patchFreightStatusFn: func(
+					_ context.Context,
+					_ *kargoapi.Freight,
+					status kargoapi.FreightStatus,
+				) error {
+					if status.Blocked == nil || status.Blocked.Reason != "fake-reason" {
+						return errors.New("unexpected status")
+					}
+					return nil
+				},
+				listStagesFn: func(
+					_ context.Context,
+					list client.ObjectList,
+					_ ...client.ListOption,
+				) error {
+					stages := list.(*kargoapi.StageList) // nolint: forcetypeassert
+					stages.Items = []kargoapi.Stage{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "using-freight"},
+							Status: kargoapi.StageStatus{
+								CurrentFreight: &kargoapi.FreightReference{
+									Name: "fake-freight",
+								},
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "not-using-freight"},
+							Status: kargoapi.StageStatus{
+								CurrentFreight: &kargoapi.FreightReference{
+									Name: "other-freight",
+								},
+							},
+						},
+					}
+					return nil
+				},
+			},
+			assertions: func(t *testing.T, recorder *fakeevent.EventRecorder, err error) {
+				require.NoError(t, err)
+				// One event for the Freight and one for the Stage using it
+				require.Len(t, recorder.Events, 2)
+				for range 2 {
+					event := <-recorder.Events
+					require.Equal(t, corev1.EventTypeWarning, event.EventType)
+					require.Equal(t, kargoapi.EventReasonFreightBlocked, event.Reason)
+				}
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			recorder := fakeevent.NewEventRecorder(2)
+			testCase.server.recorder = recorder
+			_, err := testCase.server.BlockFreight(
+				context.Background(),
+				connect.NewRequest(testCase.req),
+			)
+			testCase.assertions(t, recorder, err)
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"connectrpc.com/connect"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+const (
+	artifactTypeImage   = "image"
+	artifactTypeCommit  = "commit"
+	artifactTypeChart   = "chart"
+	artifactTypePackage = "package"
+)
+
+// ComparePromotions compares two Promotions of the same Stage, describing how
+// the Freight they promoted, the commits they made, and the promotion
+// mechanisms they were executed with differ.
+func (s *server) ComparePromotions(
+	ctx context.Context,
+	req *connect.Request[svcv1alpha1.ComparePromotionsRequest],
+) (*connect.Response[svcv1alpha1.ComparePromotionsResponse], error) {
+	project := req.Msg.GetProject()
+	if err := validateFieldNotEmpty("project", project); err != nil {
+		return nil, err
+	}
+	fromName := req.Msg.GetFrom()
+	if err := validateFieldNotEmpty("from", fromName); err != nil {
+		return nil, err
+	}
+	toName := req.Msg.GetTo()
+	if err := validateFieldNotEmpty("to", toName); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateProjectExistsFn(ctx, project); err != nil {
+		return nil, err
+	}
+
+	from, err := s.getPromotionForComparison(ctx, project, fromName)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.getPromotionForComparison(ctx, project, toName)
+	if err != nil {
+		return nil, err
+	}
+	if from.Spec.Stage != to.Spec.Stage {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			fmt.Errorf(
+				"Promotion %q is of Stage %q, but Promotion %q is of Stage %q; "+
+					"only Promotions of the same Stage can be compared",
+				fromName, from.Spec.Stage, toName, to.Spec.Stage,
+			),
+		)
+	}
+
+	fromFreight, err := s.getPromotionFreight(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	toFreight, err := s.getPromotionFreight(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var freightChanges []*svcv1alpha1.ArtifactChange
+	for _, artifactType := range []string{
+		artifactTypeImage,
+		artifactTypeCommit,
+		artifactTypeChart,
+		artifactTypePackage,
+	} {
+		freightChanges = append(
+			freightChanges,
+			diffArtifacts(
+				artifactType,
+				artifactVersions(artifactType, fromFreight),
+				artifactVersions(artifactType, toFreight),
+			)...,
+		)
+	}
+
+	return connect.NewResponse(&svcv1alpha1.ComparePromotionsResponse{
+		From:           from,
+		To:             to,
+		FreightChanges: freightChanges,
+		RenderedCommitChanges: diffArtifacts(
+			artifactTypeCommit,
+			renderedCommits(from),
+			renderedCommits(to),
+		),
+		PromotionMechanismsChanged: from.Status.PromotionMechanisms != nil &&
+			to.Status.PromotionMechanisms != nil &&
+			!equality.Semantic.DeepEqual(
+				from.Status.PromotionMechanisms,
+				to.Status.PromotionMechanisms,
+			),
+	}), nil
+}
+
+// getPromotionForComparison returns the Promotion with the specified name in
+// the specified project or a connect.Error if it does not exist.
+func (s *server) getPromotionForComparison(
+	ctx context.Context,
+	project string,
+	name string,
+) (*kargoapi.Promotion, error) {
+	promo, err := s.getPromotionFn(
+		ctx,
+		s.client,
+		types.NamespacedName{
+			Namespace: project,
+			Name:      name,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get promotion: %w", err)
+	}
+	if promo == nil {
+		return nil, connect.NewError(
+			connect.CodeNotFound,
+			fmt.Errorf("Promotion %q not found in project %q", name, project),
+		)
+	}
+	return promo, nil
+}
+
+// getPromotionFreight returns a reference to the Freight promoted by the
+// provided Promotion. The reference recorded in the Promotion's status is
+// preferred, as it reflects any commits made by the Promotion. If the Promotion
+// has not recorded one, e.g. because it has not been executed yet, a reference
+// to the Freight it specifies is returned instead. If that Freight no longer
+// exists, nil is returned.
+func (s *server) getPromotionFreight(
+	ctx context.Context,
+	promo *kargoapi.Promotion,
+) (*kargoapi.FreightReference, error) {
+	if promo.Status.Freight != nil {
+		return promo.Status.Freight, nil
+	}
+	freight, err := s.getFreightByNameOrAliasFn(
+		ctx,
+		s.client,
+		promo.Namespace,
+		promo.Spec.Freight,
+		"",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get freight: %w", err)
+	}
+	if freight == nil {
+		return nil, nil
+	}
+	return &kargoapi.FreightReference{
+		Name:      freight.Name,
+		Commits:   freight.Commits,
+		Images:    freight.Images,
+		Charts:    freight.Charts,
+		Packages:  freight.Packages,
+		Warehouse: freight.Warehouse,
+	}, nil
+}
+
+// artifactVersions returns the versions of all artifacts of the specified type
+// referenced by the provided Freight, indexed by repository URL.
+func artifactVersions(
+	artifactType string,
+	freight *kargoapi.FreightReference,
+) map[string]string {
+	versions := map[string]string{}
+	if freight == nil {
+		return versions
+	}
+	switch artifactType {
+	case artifactTypeImage:
+		for _, image := range freight.Images {
+			version := image.Tag
+			if image.Digest != "" {
+				if version != "" {
+					version += "@"
+				}
+				version += image.Digest
+			}
+			versions[image.RepoURL] = version
+		}
+	case artifactTypeCommit:
+		for _, commit := range freight.Commits {
+			versions[commit.RepoURL] = commit.ID
+		}
+	case artifactTypeChart:
+		for _, chart := range freight.Charts {
+			// Charts in OCI registries have no name apart from their repository URL
+			key := chart.RepoURL
+			if chart.Name != "" {
+				key = strings.TrimSuffix(key, "/") + "/" + chart.Name
+			}
+			versions[key] = chart.Version
+		}
+	case artifactTypePackage:
+		for _, pkg := range freight.Packages {
+			versions[pkg.RepoURL+"/"+pkg.Name] = pkg.Version
+		}
+	}
+	return versions
+}
+
+// renderedCommits returns the IDs of the commits made by the provided
+// Promotion, indexed by repository URL.
+func renderedCommits(promo *kargoapi.Promotion) map[string]string {
+	commits := map[string]string{}
+	if promo.Status.Freight == nil {
+		return commits
+	}
+	for _, commit := range promo.Status.Freight.Commits {
+		if commit.HealthCheckCommit != "" {
+			commits[commit.RepoURL] = commit.HealthCheckCommit
+		}
+	}
+	return commits
+}
+
+// diffArtifacts returns ArtifactChanges describing the differences between the
+// provided versions of artifacts of the specified type, each indexed by
+// repository URL. The ArtifactChanges are sorted by repository URL.
+func diffArtifacts(
+	artifactType string,
+	from map[string]string,
+	to map[string]string,
+) []*svcv1alpha1.ArtifactChange {
+	repoURLs := make([]string, 0, len(from)+len(to))
+	for repoURL := range from {
+		repoURLs = append(repoURLs, repoURL)
+	}
+	for repoURL := range to {
+		if _, ok := from[repoURL]; !ok {
+			repoURLs = append(repoURLs, repoURL)
+		}
+	}
+	sort.Strings(repoURLs)
+	var changes []*svcv1alpha1.ArtifactChange
+	for _, repoURL := range repoURLs {
+		if from[repoURL] == to[repoURL] {
+			continue
+		}
+		changes = append(changes, &svcv1alpha1.ArtifactChange{
+			Type:    artifactType,
+			RepoUrl: repoURL,
+			From:    from[repoURL],
+			To:      to[repoURL],
+		})
+	}
+	return changes
+}
@@ -0,0 +1,380 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func TestComparePromotions(t *testing.T) {
+	validReq := &svcv1alpha1.ComparePromotionsRequest{
+		Project: "fake-project",
+		From:    "fake-from",
+		To:      "fake-to",
+	}
+	testCases := []struct {
+		name       string
+		req        *svcv1alpha1.ComparePromotionsRequest
+		server     *server
+		assertions func(*testing.T, *connect.Response[svcv1alpha1.ComparePromotionsResponse], error)
+	}{
+		{
+			name: "empty project",
+			req: &svcv1alpha1.ComparePromotionsRequest{
+				From: "fake-from",
+				To:   "fake-to",
+			},
+			server: &server{},
+			assertions: func(
+				t *testing.T,
+				_ *connect.Response[svcv1alpha1.ComparePromotionsResponse],
+				err error,
+			) {
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			},
+		},
+		{
+			name: "empty from",
+			req: &svcv1alpha1.ComparePromotionsRequest{
+				Project: "fake-project",
+				To:      "fake-to",
+			},
+			server: &server{},
+			assertions: func(
+				t *testing.T,
+				_ *connect.Response[svcv1alpha1.ComparePromotionsResponse],
+				err error,
+			) {
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			},
+		},
+		{
+			name: "empty to",
+			req: &svcv1alpha1.ComparePromotionsRequest{
+				Project: "fake-project",
+				From:    "fake-from",
+			},
+			server: &server{},
+			assertions: func(
+				t *testing.T,
+				_ *connect.Response[svcv1alpha1.ComparePromotionsResponse],
+				err error,
+			) {
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			},
+		},
+		{
+			name: "error validating project",
+			req:  validReq,
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return errors.New("something went wrong")
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				_ *connect.Response[svcv1alpha1.ComparePromotionsResponse],
+				err error,
+			) {
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "error getting Promotion",
+			req:  validReq,
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getPromotionFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Promotion, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				_ *connect.Response[svcv1alpha1.ComparePromotionsResponse],
+				err error,
+			) {
+				require.ErrorContains(t, err, "get promotion")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "Promotion not found",
+			req:  validReq,
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getPromotionFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Promotion, error) {
+					return nil, nil
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				_ *connect.Response[svcv1alpha1.ComparePromotionsResponse],
+				err error,
+			) {
+				require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+			},
+		},
+		{
+			name: "Promotions of different Stages",
+			req:  validReq,
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getPromotionFn: func(
+					_ context.Context,
+					_ client.Client,
+					key types.NamespacedName,
+				) (*kargoapi.Promotion, error) {
+					return &kargoapi.Promotion{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: key.Namespace,
+							Name:      key.Name,
+						},
+						Spec: kargoapi.PromotionSpec{
+							Stage: "stage-of-" + key.Name,
+						},
+					}, nil
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				_ *connect.Response[svcv1alpha1.ComparePromotionsResponse],
+				err error,
+			) {
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+				require.ErrorContains(t, err, "only Promotions of the same Stage can be compared")
+			},
+		},
+		{
+			name: "error getting Freight",
+			req:  validReq,
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getPromotionFn: func(
+					_ context.Context,
+					_ client.Client,
+					key types.NamespacedName,
+				) (*kargoapi.Promotion, error) {
+					return &kargoapi.Promotion{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: key.Namespace,
+							Name:      key.Name,
+						},
+						Spec: kargoapi.PromotionSpec{
+							Stage:   "fake-stage",
+							Freight: "fake-freight",
+						},
+					}, nil
+				},
+				getFreightByNameOrAliasFn: func(
+					context.Context,
+					client.Client,
+					string,
+					string,
+					string,
+				) (*kargoapi.Freight, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				_ *connect.Response[svcv1alpha1.ComparePromotionsResponse],
+				err error,
+			) {
+				require.ErrorContains(t, err, "get freight")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "success",
+			req:  validReq,
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getPromotionFn: func(
+					_ context.Context,
+					_ client.Client,
+					key types.NamespacedName,
+				) (*kargoapi.Promotion, error) {
+					promo := &kargoapi.Promotion{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: key.Namespace,
+							Name:      key.Name,
+						},
+						Spec: kargoapi.PromotionSpec{
+							Stage:   "fake-stage",
+							Freight: "freight-of-" + key.Name,
+						},
+					}
+					if key.Name == "fake-from" {
+						promo.Status = kargoapi.PromotionStatus{
+							Freight: &kargoapi.FreightReference{
+								Name: "freight-of-fake-from",
+								Images: []kargoapi.Image{{
+									RepoURL: "fake-image",
+									Tag:     "v1.0.0",
+								}},
+								Commits: []kargoapi.GitCommit{{
+									RepoURL:           "fake-git-repo",
+									ID:                "fake-commit-1",
+									HealthCheckCommit: "fake-rendered-commit-1",
+								}},
+							},
+							PromotionMechanisms: &kargoapi.PromotionMechanisms{
+								GitRepoUpdates: []kargoapi.GitRepoUpdate{{
+									RepoURL:     "fake-git-repo",
+									WriteBranch: "stage/fake-stage",
+								}},
+							},
+						}
+					} else {
+						promo.Status = kargoapi.PromotionStatus{
+							PromotionMechanisms: &kargoapi.PromotionMechanisms{
+								GitRepoUpdates: []kargoapi.GitRepoUpdate{{
+									RepoURL:     "fake-git-repo",
+									WriteBranch: "env/fake-stage",
+								}},
+							},
+						}
+					}
+					return promo, nil
+				},
+				getFreightByNameOrAliasFn: func(
+					_ context.Context,
+					_ client.Client,
+					_ string,
+					name string,
+					_ string,
+				) (*kargoapi.Freight, error) {
+					require.Equal(t, "freight-of-fake-to", name)
+					return &kargoapi.Freight{
+						ObjectMeta: metav1.ObjectMeta{Name: name},
+						Images: []kargoapi.Image{{
+							RepoURL: "fake-image",
+							Tag:     "v1.1.0",
+						}},
+						Commits: []kargoapi.GitCommit{{
+							RepoURL: "fake-git-repo",
+							ID:      "fake-commit-1",
+						}},
+						Charts: []kargoapi.Chart{{
+							RepoURL: "oci://fake-registry/charts",
+							Name:    "fake-chart",
+							Version: "1.0.0",
+						}},
+					}, nil
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				res *connect.Response[svcv1alpha1.ComparePromotionsResponse],
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, "fake-from", res.Msg.GetFrom().Name)
+				require.Equal(t, "fake-to", res.Msg.GetTo().Name)
+				require.Equal(
+					t,
+					[]*svcv1alpha1.ArtifactChange{
+						{
+							Type:    artifactTypeImage,
+							RepoUrl: "fake-image",
+							From:    "v1.0.0",
+							To:      "v1.1.0",
+						},
+						{
+							Type:    artifactTypeChart,
+							RepoUrl: "oci://fake-registry/charts/fake-chart",
+							To:      "1.0.0",
+						},
+					},
+					res.Msg.GetFreightChanges(),
+				)
+				require.Equal(
+					t,
+					[]*svcv1alpha1.ArtifactChange{{
+						Type:    artifactTypeCommit,
+						RepoUrl: "fake-git-repo",
+						From:    "fake-rendered-commit-1",
+					}},
+					res.Msg.GetRenderedCommitChanges(),
+				)
+				require.True(t, res.Msg.GetPromotionMechanismsChanged())
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			res, err := testCase.server.ComparePromotions(
+				context.Background(),
+				connect.NewRequest(testCase.req),
+			)
+			testCase.assertions(t, res, err)
+		})
+	}
+}
+
+func TestArtifactVersions(t *testing.T) {
+	freight := &kargoapi.FreightReference{
+		Images: []kargoapi.Image{
+			{RepoURL: "tag-only", Tag: "v1.0.0"},
+			{RepoURL: "digest-only", Digest: "sha256:abc"},
+			{RepoURL: "tag-and-digest", Tag: "v1.0.0", Digest: "sha256:abc"},
+		},
+		Commits: []kargoapi.GitCommit{
+			{RepoURL: "fake-git-repo", ID: "fake-commit"},
+		},
+		Charts: []kargoapi.Chart{
+			{RepoURL: "https://fake-registry", Name: "fake-chart", Version: "1.0.0"},
+			{RepoURL: "oci://fake-registry/fake-chart", Version: "2.0.0"},
+		},
+	}
+	require.Equal(
+		t,
+		map[string]string{
+			"tag-only":       "v1.0.0",
+			"digest-only":    "sha256:abc",
+			"tag-and-digest": "v1.0.0@sha256:abc",
+		},
+		artifactVersions(artifactTypeImage, freight),
+	)
+	require.Equal(
+		t,
+		map[string]string{"fake-git-repo": "fake-commit"},
+		artifactVersions(artifactTypeCommit, freight),
+	)
+	require.Equal(
+		t,
+		map[string]string{
+			"https://fake-registry/fake-chart": "1.0.0",
+			"oci://fake-registry/fake-chart":   "2.0.0",
+		},
+		artifactVersions(artifactTypeChart, freight),
+	)
+	require.Empty(t, artifactVersions(artifactTypeImage, nil))
+}
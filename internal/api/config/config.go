@@ -27,6 +27,8 @@ type ServerConfig struct {
 	ArgoCDConfig                ArgoCDConfig
 	PermissiveCORSPolicyEnabled bool
 	RolloutsIntegrationEnabled  bool
+	LiteUIEnabled               bool
+	GitWebhookReceiverEnabled   bool
 }
 
 func ServerConfigFromEnv() ServerConfig {
@@ -53,6 +55,10 @@ func ServerConfigFromEnv() ServerConfig {
 		types.MustParseBool(os.GetEnv("PERMISSIVE_CORS_POLICY_ENABLED", "false"))
 	cfg.RolloutsIntegrationEnabled =
 		types.MustParseBool(os.GetEnv("ROLLOUTS_INTEGRATION_ENABLED", "true"))
+	cfg.LiteUIEnabled =
+		types.MustParseBool(os.GetEnv("LITE_UI_ENABLED", "false"))
+	cfg.GitWebhookReceiverEnabled =
+		types.MustParseBool(os.GetEnv("GIT_WEBHOOK_RECEIVER_ENABLED", "false"))
 	return cfg
 }
 
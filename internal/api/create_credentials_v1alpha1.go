@@ -45,6 +45,11 @@ func (s *server) CreateCredentials(
 	}
 
 	secret := credentialsToSecret(creds)
+	if s.credentialsKMS != nil {
+		if err := libCreds.EncryptSecret(ctx, s.credentialsKMS, secret); err != nil {
+			return nil, fmt.Errorf("encrypt secret: %w", err)
+		}
+	}
 	if err := s.client.Create(ctx, secret); err != nil {
 		return nil, fmt.Errorf("create secret: %w", err)
 	}
@@ -69,11 +74,12 @@ func (s *server) validateCredentials(creds credentials) error {
 	switch creds.credType {
 	case kargoapi.CredentialTypeLabelValueGit,
 		kargoapi.CredentialTypeLabelValueHelm,
-		kargoapi.CredentialTypeLabelValueImage:
+		kargoapi.CredentialTypeLabelValueImage,
+		kargoapi.CredentialTypeLabelValuePackage:
 	default:
 		return connect.NewError(
 			connect.CodeInvalidArgument,
-			errors.New("type should be one of git, helm, or image"),
+			errors.New("type should be one of git, helm, image, or package"),
 		)
 	}
 	if creds.repoURL == "" {
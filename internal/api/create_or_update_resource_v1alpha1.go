@@ -25,7 +25,7 @@ func (s *server) CreateOrUpdateResource(
 	results := make([]*svcv1alpha1.CreateOrUpdateResourceResult, 0, len(resources))
 	for _, r := range resources {
 		resource := r // Avoid implicit memory aliasing
-		result, err := s.createOrUpdateResource(ctx, &resource)
+		result, err := s.createOrUpdateResource(ctx, &resource, req.Msg.GetDryRun())
 		if err != nil && len(resources) == 1 {
 			return nil, err
 		}
@@ -41,6 +41,7 @@ func (s *server) CreateOrUpdateResource(
 func (s *server) createOrUpdateResource(
 	ctx context.Context,
 	obj *unstructured.Unstructured,
+	dryRun bool,
 ) (*svcv1alpha1.CreateOrUpdateResourceResult, error) {
 	// Note: It would be tempting to blindly attempt creating the resource and
 	// then update it instead if it already exists, but many resource types have
@@ -48,6 +49,15 @@ func (s *server) createOrUpdateResource(
 	// error from a webhook to obscure the fact that the resource already exists.
 	// So we'll explicitly check if the resource exists and then decide whether to
 	// create or update it.
+	var createOpts []client.CreateOption
+	var updateOpts []client.UpdateOption
+	if dryRun {
+		// A server-side dry run is subject to the same admission webhooks as a
+		// real request, but nothing is persisted.
+		createOpts = append(createOpts, client.DryRunAll)
+		updateOpts = append(updateOpts, client.DryRunAll)
+	}
+
 	existingObj := obj.DeepCopy()
 	if err := s.client.Get(ctx, client.ObjectKeyFromObject(obj), existingObj); err != nil {
 		if !kubeerr.IsNotFound(err) {
@@ -61,7 +71,7 @@ func (s *server) createOrUpdateResource(
 	}
 
 	if existingObj == nil { // Create the resource
-		if err := s.client.Create(ctx, obj); err != nil {
+		if err := s.client.Create(ctx, obj, createOpts...); err != nil {
 			return &svcv1alpha1.CreateOrUpdateResourceResult{
 				Result: &svcv1alpha1.CreateOrUpdateResourceResult_Error{
 					Error: fmt.Errorf("create resource: %w", err).Error(),
@@ -85,8 +95,16 @@ func (s *server) createOrUpdateResource(
 
 	// If we get to here, the resource already exists, so we can update it.
 
+	liveManifest, err := sigyaml.Marshal(existingObj)
+	if err != nil {
+		return &svcv1alpha1.CreateOrUpdateResourceResult{
+			Result: &svcv1alpha1.CreateOrUpdateResourceResult_Error{
+				Error: fmt.Errorf("marshal live manifest: %w", err).Error(),
+			},
+		}, err
+	}
 	obj.SetResourceVersion(existingObj.GetResourceVersion())
-	if err := s.client.Update(ctx, obj); err != nil {
+	if err = s.client.Update(ctx, obj, updateOpts...); err != nil {
 		return &svcv1alpha1.CreateOrUpdateResourceResult{
 			Result: &svcv1alpha1.CreateOrUpdateResourceResult_Error{
 				Error: fmt.Errorf("update resource: %w", err).Error(),
@@ -105,5 +123,6 @@ func (s *server) createOrUpdateResource(
 		Result: &svcv1alpha1.CreateOrUpdateResourceResult_UpdatedResourceManifest{
 			UpdatedResourceManifest: updatedManifest,
 		},
+		LiveResourceManifest: liveManifest,
 	}, nil
 }
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"connectrpc.com/connect"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rolloutsapi "github.com/akuity/kargo/internal/controller/rollouts/api/v1alpha1"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+const (
+	// analysisJobNameKey is the key of the Measurement metadata in which Argo
+	// Rollouts records the name of the Job that took a measurement.
+	analysisJobNameKey = "job-name"
+	// analysisJobNamespaceKey is the key of the Measurement metadata in which
+	// Argo Rollouts records the namespace of the Job that took a measurement.
+	analysisJobNamespaceKey = "job-namespace"
+	// jobNameLabelKey is the key of the label Kubernetes applies to the Pods of
+	// a Job to identify the Job by name.
+	jobNameLabelKey = "job-name"
+	// maxLogLineSize is the maximum size, in bytes, of a single line of logs.
+	// Longer lines are split across multiple chunks.
+	maxLogLineSize = 64 * 1024
+)
+
+func (s *server) GetAnalysisRunLogs(
+	ctx context.Context,
+	req *connect.Request[svcv1alpha1.GetAnalysisRunLogsRequest],
+	stream *connect.ServerStream[svcv1alpha1.GetAnalysisRunLogsResponse],
+) error {
+	if !s.cfg.RolloutsIntegrationEnabled {
+		return connect.NewError(
+			connect.CodeUnimplemented,
+			errors.New("Argo Rollouts integration is not enabled"),
+		)
+	}
+
+	namespace := req.Msg.GetNamespace()
+	if err := validateFieldNotEmpty("namespace", namespace); err != nil {
+		return err
+	}
+
+	name := req.Msg.GetName()
+	if err := validateFieldNotEmpty("name", name); err != nil {
+		return err
+	}
+
+	// The AnalysisRun is retrieved using the authorizing client. A user who is
+	// permitted to view the AnalysisRun is permitted to view its logs, which are
+	// then retrieved using the API server's own permissions.
+	ar, err := s.getAnalysisRunFn(ctx, s.client, types.NamespacedName{
+		Namespace: namespace,
+		Name:      name,
+	})
+	if err != nil {
+		return err
+	}
+	if ar == nil {
+		return connect.NewError(
+			connect.CodeNotFound,
+			fmt.Errorf("AnalysisRun %q not found in namespace %q", name, namespace),
+		)
+	}
+
+	return s.getAnalysisRunLogs(
+		ctx,
+		ar,
+		req.Msg.GetMetricName(),
+		req.Msg.GetContainerName(),
+		stream.Send,
+	)
+}
+
+// getAnalysisRunLogs retrieves the logs of the containers of all Pods of the
+// Jobs that took measurements for the provided AnalysisRun and passes them, one
+// line at a time, to the provided send function. If metricName is non-empty,
+// only the logs of Jobs that took measurements for the named metric are
+// retrieved. If containerName is non-empty, only the logs of the named
+// container are retrieved. Metrics not measured by Jobs have no logs.
+func (s *server) getAnalysisRunLogs(
+	ctx context.Context,
+	ar *rolloutsapi.AnalysisRun,
+	metricName string,
+	containerName string,
+	send func(*svcv1alpha1.GetAnalysisRunLogsResponse) error,
+) error {
+	var metricFound bool
+	for _, result := range ar.Status.MetricResults {
+		if metricName != "" && result.Name != metricName {
+			continue
+		}
+		metricFound = true
+		for _, measurement := range result.Measurements {
+			jobName := measurement.Metadata[analysisJobNameKey]
+			if jobName == "" {
+				continue
+			}
+			// Only Jobs in the AnalysisRun's own namespace are considered, as
+			// the user has not necessarily been authorized to view anything
+			// elsewhere.
+			if jobNamespace := measurement.Metadata[analysisJobNamespaceKey]; jobNamespace != "" &&
+				jobNamespace != ar.Namespace {
+				continue
+			}
+			pods := corev1.PodList{}
+			if err := s.listPodsFn(
+				ctx,
+				&pods,
+				client.InNamespace(ar.Namespace),
+				client.MatchingLabels{jobNameLabelKey: jobName},
+			); err != nil {
+				return fmt.Errorf(
+					"error listing Pods of Job %q in namespace %q: %w",
+					jobName,
+					ar.Namespace,
+					err,
+				)
+			}
+			for _, pod := range pods.Items {
+				// Containers of pending Pods have no logs yet
+				if pod.Status.Phase == corev1.PodPending {
+					continue
+				}
+				for _, container := range pod.Spec.Containers {
+					if containerName != "" && container.Name != containerName {
+						continue
+					}
+					if err := s.sendPodLogs(
+						ctx,
+						result.Name,
+						pod.Namespace,
+						pod.Name,
+						container.Name,
+						send,
+					); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	if !metricFound && metricName != "" {
+		return connect.NewError(
+			connect.CodeNotFound,
+			fmt.Errorf(
+				"AnalysisRun %q in namespace %q has no results for metric %q",
+				ar.Name,
+				ar.Namespace,
+				metricName,
+			),
+		)
+	}
+	return nil
+}
+
+// sendPodLogs retrieves the logs of the specified container and passes them,
+// one line at a time, to the provided send function.
+func (s *server) sendPodLogs(
+	ctx context.Context,
+	metricName string,
+	namespace string,
+	podName string,
+	containerName string,
+	send func(*svcv1alpha1.GetAnalysisRunLogsResponse) error,
+) error {
+	logs, err := s.getPodLogsFn(ctx, namespace, podName, containerName)
+	if err != nil {
+		return fmt.Errorf(
+			"error getting logs of container %q of Pod %q in namespace %q: %w",
+			containerName,
+			podName,
+			namespace,
+			err,
+		)
+	}
+	defer logs.Close()
+
+	reader := bufio.NewReaderSize(logs, maxLogLineSize)
+	for {
+		line, _, err := reader.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf(
+				"error reading logs of container %q of Pod %q in namespace %q: %w",
+				containerName,
+				podName,
+				namespace,
+				err,
+			)
+		}
+		if err = send(&svcv1alpha1.GetAnalysisRunLogsResponse{
+			MetricName:    metricName,
+			PodName:       podName,
+			ContainerName: containerName,
+			Chunk:         string(line),
+		}); err != nil {
+			return fmt.Errorf("send response: %w", err)
+		}
+	}
+}
+
+// getPodLogsFn returns a function that closes over the provided client and,
+// when invoked, returns a stream of the logs of the specified container.
+func getPodLogsFn(podsClient corev1client.PodsGetter) func(
+	ctx context.Context,
+	namespace string,
+	pod string,
+	container string,
+) (io.ReadCloser, error) {
+	return func(
+		ctx context.Context,
+		namespace string,
+		pod string,
+		container string,
+	) (io.ReadCloser, error) {
+		return podsClient.Pods(namespace).GetLogs(
+			pod,
+			&corev1.PodLogOptions{Container: container},
+		).Stream(ctx)
+	}
+}
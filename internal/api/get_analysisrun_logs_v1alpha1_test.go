@@ -0,0 +1,322 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/akuity/kargo/internal/api/config"
+	rollouts "github.com/akuity/kargo/internal/controller/rollouts/api/v1alpha1"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func TestGetAnalysisRunLogs(t *testing.T) {
+	testCases := map[string]struct {
+		req              *svcv1alpha1.GetAnalysisRunLogsRequest
+		rolloutsDisabled bool
+		getAnalysisRunFn func(
+			context.Context,
+			client.Client,
+			types.NamespacedName,
+		) (*rollouts.AnalysisRun, error)
+		assertions func(*testing.T, error)
+	}{
+		"Argo Rollouts integration is not enabled": {
+			req: &svcv1alpha1.GetAnalysisRunLogsRequest{
+				Namespace: "kargo-demo",
+				Name:      "test",
+			},
+			rolloutsDisabled: true,
+			assertions: func(t *testing.T, err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+			},
+		},
+		"empty namespace": {
+			req: &svcv1alpha1.GetAnalysisRunLogsRequest{
+				Name: "test",
+			},
+			assertions: func(t *testing.T, err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			},
+		},
+		"empty name": {
+			req: &svcv1alpha1.GetAnalysisRunLogsRequest{
+				Namespace: "kargo-demo",
+			},
+			assertions: func(t *testing.T, err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			},
+		},
+		"error getting AnalysisRun": {
+			req: &svcv1alpha1.GetAnalysisRunLogsRequest{
+				Namespace: "kargo-demo",
+				Name:      "test",
+			},
+			getAnalysisRunFn: func(
+				context.Context,
+				client.Client,
+				types.NamespacedName,
+			) (*rollouts.AnalysisRun, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		"AnalysisRun not found": {
+			req: &svcv1alpha1.GetAnalysisRunLogsRequest{
+				Namespace: "kargo-demo",
+				Name:      "test",
+			},
+			getAnalysisRunFn: func(
+				context.Context,
+				client.Client,
+				types.NamespacedName,
+			) (*rollouts.AnalysisRun, error) {
+				return nil, nil
+			},
+			assertions: func(t *testing.T, err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s := &server{
+				cfg: config.ServerConfig{
+					RolloutsIntegrationEnabled: !testCase.rolloutsDisabled,
+				},
+				getAnalysisRunFn: testCase.getAnalysisRunFn,
+			}
+			testCase.assertions(
+				t,
+				s.GetAnalysisRunLogs(
+					context.Background(),
+					connect.NewRequest(testCase.req),
+					nil,
+				),
+			)
+		})
+	}
+}
+
+func TestGetAnalysisRunLogsFromJobs(t *testing.T) {
+	testAnalysisRun := &rollouts.AnalysisRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "kargo-demo",
+			Name:      "test",
+		},
+		Status: rollouts.AnalysisRunStatus{
+			MetricResults: []rollouts.MetricResult{
+				{
+					Name: "job-metric",
+					Measurements: []rollouts.Measurement{{
+						Metadata: map[string]string{
+							"job-name":      "fake-job",
+							"job-namespace": "kargo-demo",
+						},
+					}},
+				},
+				{
+					Name: "foreign-job-metric",
+					Measurements: []rollouts.Measurement{{
+						Metadata: map[string]string{
+							"job-name":      "foreign-job",
+							"job-namespace": "elsewhere",
+						},
+					}},
+				},
+				{
+					Name:         "web-metric",
+					Measurements: []rollouts.Measurement{{}},
+				},
+			},
+		},
+	}
+	testPods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "kargo-demo",
+				Name:      "fake-job-abc",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "main"}, {Name: "sidecar"}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodFailed},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "kargo-demo",
+				Name:      "fake-job-def",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "main"}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodPending},
+		},
+	}
+	listPodsFn := func(
+		_ context.Context,
+		list client.ObjectList,
+		opts ...client.ListOption,
+	) error {
+		listOpts := &client.ListOptions{}
+		for _, opt := range opts {
+			opt.ApplyToList(listOpts)
+		}
+		if listOpts.Namespace != "kargo-demo" ||
+			!listOpts.LabelSelector.Matches(labels.Set{"job-name": "fake-job"}) {
+			return errors.New("unexpected list options")
+		}
+		list.(*corev1.PodList).Items = testPods // nolint: forcetypeassert
+		return nil
+	}
+	getPodLogsFn := func(
+		_ context.Context,
+		_ string,
+		pod string,
+		container string,
+	) (io.ReadCloser, error) {
+		return io.NopCloser(
+			strings.NewReader(pod + "/" + container + " line 1\n" + pod + "/" + container + " line 2\n"),
+		), nil
+	}
+
+	testCases := []struct {
+		name          string
+		metricName    string
+		containerName string
+		listPodsFn    func(context.Context, client.ObjectList, ...client.ListOption) error
+		getPodLogsFn  func(context.Context, string, string, string) (io.ReadCloser, error)
+		assertions    func(*testing.T, []*svcv1alpha1.GetAnalysisRunLogsResponse, error)
+	}{
+		{
+			name: "error listing Pods",
+			listPodsFn: func(context.Context, client.ObjectList, ...client.ListOption) error {
+				return errors.New("something went wrong")
+			},
+			getPodLogsFn: getPodLogsFn,
+			assertions: func(t *testing.T, _ []*svcv1alpha1.GetAnalysisRunLogsResponse, err error) {
+				require.ErrorContains(t, err, "error listing Pods of Job")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name:       "error getting logs",
+			listPodsFn: listPodsFn,
+			getPodLogsFn: func(context.Context, string, string, string) (io.ReadCloser, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, _ []*svcv1alpha1.GetAnalysisRunLogsResponse, err error) {
+				require.ErrorContains(t, err, "error getting logs of container")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name:         "unknown metric",
+			metricName:   "nonexistent",
+			listPodsFn:   listPodsFn,
+			getPodLogsFn: getPodLogsFn,
+			assertions: func(t *testing.T, _ []*svcv1alpha1.GetAnalysisRunLogsResponse, err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+			},
+		},
+		{
+			name:         "metric not measured by Jobs",
+			metricName:   "web-metric",
+			listPodsFn:   listPodsFn,
+			getPodLogsFn: getPodLogsFn,
+			assertions: func(t *testing.T, res []*svcv1alpha1.GetAnalysisRunLogsResponse, err error) {
+				require.NoError(t, err)
+				require.Empty(t, res)
+			},
+		},
+		{
+			name:         "all logs",
+			listPodsFn:   listPodsFn,
+			getPodLogsFn: getPodLogsFn,
+			assertions: func(t *testing.T, res []*svcv1alpha1.GetAnalysisRunLogsResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]*svcv1alpha1.GetAnalysisRunLogsResponse{
+						{
+							MetricName:    "job-metric",
+							PodName:       "fake-job-abc",
+							ContainerName: "main",
+							Chunk:         "fake-job-abc/main line 1",
+						},
+						{
+							MetricName:    "job-metric",
+							PodName:       "fake-job-abc",
+							ContainerName: "main",
+							Chunk:         "fake-job-abc/main line 2",
+						},
+						{
+							MetricName:    "job-metric",
+							PodName:       "fake-job-abc",
+							ContainerName: "sidecar",
+							Chunk:         "fake-job-abc/sidecar line 1",
+						},
+						{
+							MetricName:    "job-metric",
+							PodName:       "fake-job-abc",
+							ContainerName: "sidecar",
+							Chunk:         "fake-job-abc/sidecar line 2",
+						},
+					},
+					res,
+				)
+			},
+		},
+		{
+			name:          "logs of a single container",
+			metricName:    "job-metric",
+			containerName: "sidecar",
+			listPodsFn:    listPodsFn,
+			getPodLogsFn:  getPodLogsFn,
+			assertions: func(t *testing.T, res []*svcv1alpha1.GetAnalysisRunLogsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res, 2)
+				for _, r := range res {
+					require.Equal(t, "sidecar", r.ContainerName)
+				}
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			s := &server{
+				listPodsFn:   testCase.listPodsFn,
+				getPodLogsFn: testCase.getPodLogsFn,
+			}
+			var res []*svcv1alpha1.GetAnalysisRunLogsResponse
+			err := s.getAnalysisRunLogs(
+				context.Background(),
+				testAnalysisRun,
+				testCase.metricName,
+				testCase.containerName,
+				func(r *svcv1alpha1.GetAnalysisRunLogsResponse) error {
+					res = append(res, r)
+					return nil
+				},
+			)
+			testCase.assertions(t, res, err)
+		})
+	}
+}
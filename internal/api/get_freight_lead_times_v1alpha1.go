@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+// GetFreightLeadTimes returns, for each of a Project's Freight, when it was
+// first promoted to and verified in each Stage and how long after the creation
+// of the Freight that happened.
+func (s *server) GetFreightLeadTimes(
+	ctx context.Context,
+	req *connect.Request[svcv1alpha1.GetFreightLeadTimesRequest],
+) (*connect.Response[svcv1alpha1.GetFreightLeadTimesResponse], error) {
+	project := req.Msg.GetProject()
+	if err := validateFieldNotEmpty("project", project); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateProjectExistsFn(ctx, project); err != nil {
+		return nil, err
+	}
+
+	var list kargoapi.FreightList
+	if err := s.client.List(ctx, &list, client.InNamespace(project)); err != nil {
+		return nil, fmt.Errorf("list freight: %w", err)
+	}
+
+	var since time.Time
+	if req.Msg.GetSince() != nil {
+		since = req.Msg.GetSince().AsTime()
+	}
+
+	freight := make([]*svcv1alpha1.FreightLeadTimes, 0, len(list.Items))
+	for _, f := range list.Items {
+		if f.CreationTimestamp.Time.Before(since) {
+			continue
+		}
+		freight = append(freight, freightLeadTimes(f))
+	}
+	sort.SliceStable(freight, func(i, j int) bool {
+		return freight[i].CreatedAt.AsTime().After(freight[j].CreatedAt.AsTime())
+	})
+	return connect.NewResponse(&svcv1alpha1.GetFreightLeadTimesResponse{
+		Freight: freight,
+	}), nil
+}
+
+// freightLeadTimes returns the lead times of the provided Freight in each Stage
+// it has been promoted to or verified in.
+func freightLeadTimes(f kargoapi.Freight) *svcv1alpha1.FreightLeadTimes {
+	created := f.CreationTimestamp.Time
+	stages := map[string]*svcv1alpha1.StageLeadTime{}
+	getStage := func(name string) *svcv1alpha1.StageLeadTime {
+		if _, ok := stages[name]; !ok {
+			stages[name] = &svcv1alpha1.StageLeadTime{}
+		}
+		return stages[name]
+	}
+	for stage, promoted := range f.Status.PromotedTo {
+		if promoted.PromotedAt == nil {
+			continue
+		}
+		lt := getStage(stage)
+		lt.PromotedAt = timestamppb.New(promoted.PromotedAt.Time)
+		lt.PromotionLeadTimeSeconds = promoted.PromotedAt.Sub(created).Seconds()
+	}
+	for stage, verified := range f.Status.VerifiedIn {
+		if verified.VerifiedAt == nil {
+			continue
+		}
+		lt := getStage(stage)
+		lt.VerifiedAt = timestamppb.New(verified.VerifiedAt.Time)
+		lt.VerificationLeadTimeSeconds = verified.VerifiedAt.Sub(created).Seconds()
+	}
+	return &svcv1alpha1.FreightLeadTimes{
+		Name:      f.Name,
+		Alias:     f.Labels[kargoapi.AliasLabelKey],
+		CreatedAt: timestamppb.New(created),
+		Stages:    stages,
+	}
+}
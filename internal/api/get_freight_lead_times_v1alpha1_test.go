@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/api/kubernetes"
+	"github.com/akuity/kargo/internal/api/user"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func TestGetFreightLeadTimes(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	olderFreight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "kargo-demo",
+			Name:              "older-freight",
+			CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)),
+			Labels: map[string]string{
+				kargoapi.AliasLabelKey: "fake-alias",
+			},
+		},
+		Status: kargoapi.FreightStatus{
+			PromotedTo: map[string]kargoapi.PromotedStage{
+				"test": {PromotedAt: ptr.To(metav1.NewTime(now.Add(-110 * time.Minute)))},
+				"prod": {PromotedAt: ptr.To(metav1.NewTime(now.Add(-time.Hour)))},
+			},
+			VerifiedIn: map[string]kargoapi.VerifiedStage{
+				"test": {VerifiedAt: ptr.To(metav1.NewTime(now.Add(-100 * time.Minute)))},
+				// Verified before verification times were recorded
+				"prod": {},
+			},
+		},
+	}
+	newerFreight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "kargo-demo",
+			Name:              "newer-freight",
+			CreationTimestamp: metav1.NewTime(now.Add(-10 * time.Minute)),
+		},
+	}
+
+	testCases := []struct {
+		name                    string
+		req                     *svcv1alpha1.GetFreightLeadTimesRequest
+		validateProjectExistsFn func(context.Context, string) error
+		assertions              func(
+			*testing.T,
+			*connect.Response[svcv1alpha1.GetFreightLeadTimesResponse],
+			error,
+		)
+	}{
+		{
+			name: "empty project",
+			req:  &svcv1alpha1.GetFreightLeadTimesRequest{},
+			assertions: func(
+				t *testing.T,
+				res *connect.Response[svcv1alpha1.GetFreightLeadTimesResponse],
+				err error,
+			) {
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		{
+			name: "error validating project",
+			req: &svcv1alpha1.GetFreightLeadTimesRequest{
+				Project: "kargo-demo",
+			},
+			validateProjectExistsFn: func(context.Context, string) error {
+				return errors.New("something went wrong")
+			},
+			assertions: func(
+				t *testing.T,
+				res *connect.Response[svcv1alpha1.GetFreightLeadTimesResponse],
+				err error,
+			) {
+				require.ErrorContains(t, err, "something went wrong")
+				require.Nil(t, res)
+			},
+		},
+		{
+			name: "success",
+			req: &svcv1alpha1.GetFreightLeadTimesRequest{
+				Project: "kargo-demo",
+			},
+			assertions: func(
+				t *testing.T,
+				res *connect.Response[svcv1alpha1.GetFreightLeadTimesResponse],
+				err error,
+			) {
+				require.NoError(t, err)
+				freight := res.Msg.GetFreight()
+				require.Len(t, freight, 2)
+
+				// Most recently created first
+				require.Equal(t, "newer-freight", freight[0].GetName())
+				require.Empty(t, freight[0].GetStages())
+
+				require.Equal(t, "older-freight", freight[1].GetName())
+				require.Equal(t, "fake-alias", freight[1].GetAlias())
+				require.Len(t, freight[1].GetStages(), 2)
+				test := freight[1].GetStages()["test"]
+				require.Equal(t, float64(10*60), test.GetPromotionLeadTimeSeconds())
+				require.Equal(t, float64(20*60), test.GetVerificationLeadTimeSeconds())
+				prod := freight[1].GetStages()["prod"]
+				require.Equal(t, float64(60*60), prod.GetPromotionLeadTimeSeconds())
+				require.NotNil(t, prod.GetPromotedAt())
+				require.Nil(t, prod.GetVerifiedAt())
+				require.Zero(t, prod.GetVerificationLeadTimeSeconds())
+			},
+		},
+		{
+			name: "freight created before since is excluded",
+			req: &svcv1alpha1.GetFreightLeadTimesRequest{
+				Project: "kargo-demo",
+				Since:   timestamppb.New(now.Add(-time.Hour)),
+			},
+			assertions: func(
+				t *testing.T,
+				res *connect.Response[svcv1alpha1.GetFreightLeadTimesResponse],
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Len(t, res.Msg.GetFreight(), 1)
+				require.Equal(t, "newer-freight", res.Msg.GetFreight()[0].GetName())
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Simulate an admin user to prevent any authz issues with the authorizing
+			// client.
+			ctx := user.ContextWithInfo(
+				context.Background(),
+				user.Info{
+					IsAdmin: true,
+				},
+			)
+
+			client, err := kubernetes.NewClient(
+				ctx,
+				&rest.Config{},
+				kubernetes.ClientOptions{
+					NewInternalClient: func(
+						_ context.Context,
+						_ *rest.Config,
+						scheme *runtime.Scheme,
+					) (client.Client, error) {
+						return fake.NewClientBuilder().
+							WithScheme(scheme).
+							WithObjects(olderFreight, newerFreight).
+							Build(), nil
+					},
+				},
+			)
+			require.NoError(t, err)
+
+			validateProjectExistsFn := testCase.validateProjectExistsFn
+			if validateProjectExistsFn == nil {
+				validateProjectExistsFn = func(context.Context, string) error {
+					return nil
+				}
+			}
+			svr := &server{
+				client:                  client,
+				validateProjectExistsFn: validateProjectExistsFn,
+			}
+			res, err := svr.GetFreightLeadTimes(ctx, connect.NewRequest(testCase.req))
+			testCase.assertions(t, res, err)
+		})
+	}
+}
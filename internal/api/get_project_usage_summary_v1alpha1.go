@@ -0,0 +1,131 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"connectrpc.com/connect"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+// GetProjectUsageSummary summarizes the outcomes of a Project's Promotions,
+// both across the Project and for each of its Stages. Lead time is measured
+// from the creation of the Freight being promoted to the successful completion
+// of the Promotion.
+func (s *server) GetProjectUsageSummary(
+	ctx context.Context,
+	req *connect.Request[svcv1alpha1.GetProjectUsageSummaryRequest],
+) (*connect.Response[svcv1alpha1.GetProjectUsageSummaryResponse], error) {
+	project := req.Msg.GetProject()
+	if err := validateFieldNotEmpty("project", project); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateProjectExistsFn(ctx, project); err != nil {
+		return nil, err
+	}
+
+	var promos kargoapi.PromotionList
+	if err := s.client.List(ctx, &promos, client.InNamespace(project)); err != nil {
+		return nil, fmt.Errorf("list promotions: %w", err)
+	}
+	var freight kargoapi.FreightList
+	if err := s.client.List(ctx, &freight, client.InNamespace(project)); err != nil {
+		return nil, fmt.Errorf("list freight: %w", err)
+	}
+	freightCreationTimes := make(map[string]time.Time, len(freight.Items))
+	for _, f := range freight.Items {
+		freightCreationTimes[f.Name] = f.CreationTimestamp.Time
+	}
+
+	var since time.Time
+	if req.Msg.GetSince() != nil {
+		since = req.Msg.GetSince().AsTime()
+	}
+
+	projectUsage := &usageAccumulator{}
+	stageUsage := map[string]*usageAccumulator{}
+	for _, promo := range promos.Items {
+		if !promo.Status.Phase.IsTerminal() || promo.CreationTimestamp.Time.Before(since) {
+			continue
+		}
+		if _, ok := stageUsage[promo.Spec.Stage]; !ok {
+			stageUsage[promo.Spec.Stage] = &usageAccumulator{}
+		}
+		var leadTime *time.Duration
+		if created, ok := freightCreationTimes[promo.Spec.Freight]; ok &&
+			promo.Status.FinishedAt != nil {
+			d := promo.Status.FinishedAt.Sub(created)
+			leadTime = &d
+		}
+		projectUsage.add(promo.Status.Phase, leadTime)
+		stageUsage[promo.Spec.Stage].add(promo.Status.Phase, leadTime)
+	}
+
+	stages := make(map[string]*svcv1alpha1.UsageSummary, len(stageUsage))
+	for stage, usage := range stageUsage {
+		stages[stage] = usage.summary()
+	}
+	return connect.NewResponse(&svcv1alpha1.GetProjectUsageSummaryResponse{
+		Project: projectUsage.summary(),
+		Stages:  stages,
+	}), nil
+}
+
+// usageAccumulator accumulates the outcomes of Promotions so that they can be
+// summarized.
+type usageAccumulator struct {
+	succeeded int32
+	failed    int32
+	leadTimes []time.Duration
+}
+
+// add records the outcome of a single Promotion that finished in the provided
+// phase. The lead time is only recorded for Promotions that succeeded and may
+// be nil if it is unknown.
+func (u *usageAccumulator) add(phase kargoapi.PromotionPhase, leadTime *time.Duration) {
+	switch phase {
+	case kargoapi.PromotionPhaseSucceeded:
+		u.succeeded++
+		if leadTime != nil {
+			u.leadTimes = append(u.leadTimes, *leadTime)
+		}
+	case kargoapi.PromotionPhaseFailed, kargoapi.PromotionPhaseErrored:
+		u.failed++
+	}
+}
+
+// summary returns a UsageSummary of the accumulated outcomes.
+func (u *usageAccumulator) summary() *svcv1alpha1.UsageSummary {
+	summary := &svcv1alpha1.UsageSummary{
+		Promotions: u.succeeded + u.failed,
+		Succeeded:  u.succeeded,
+		Failed:     u.failed,
+	}
+	if summary.Promotions > 0 {
+		summary.FailureRate = float64(u.failed) / float64(summary.Promotions)
+	}
+	summary.MedianLeadTimeSeconds = medianDuration(u.leadTimes).Seconds()
+	return summary
+}
+
+// medianDuration returns the median of the provided durations, or zero if
+// there are none. The provided slice is sorted in place.
+func medianDuration(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+	mid := len(durations) / 2
+	if len(durations)%2 == 1 {
+		return durations[mid]
+	}
+	return (durations[mid-1] + durations[mid]) / 2
+}
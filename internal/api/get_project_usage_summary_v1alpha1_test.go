@@ -0,0 +1,253 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/api/kubernetes"
+	"github.com/akuity/kargo/internal/api/user"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func TestGetProjectUsageSummary(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	newPromo := func(
+		name string,
+		stage string,
+		created time.Time,
+		phase kargoapi.PromotionPhase,
+		finished *time.Time,
+	) *kargoapi.Promotion {
+		promo := &kargoapi.Promotion{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "kargo-demo",
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: kargoapi.PromotionSpec{
+				Stage:   stage,
+				Freight: "fake-freight",
+			},
+			Status: kargoapi.PromotionStatus{
+				Phase: phase,
+			},
+		}
+		if finished != nil {
+			promo.Status.FinishedAt = ptr.To(metav1.NewTime(*finished))
+		}
+		return promo
+	}
+	freight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "kargo-demo",
+			Name:              "fake-freight",
+			CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+		},
+	}
+
+	testCases := []struct {
+		name                    string
+		req                     *svcv1alpha1.GetProjectUsageSummaryRequest
+		validateProjectExistsFn func(context.Context, string) error
+		objects                 []client.Object
+		assertions              func(
+			*testing.T,
+			*connect.Response[svcv1alpha1.GetProjectUsageSummaryResponse],
+			error,
+		)
+	}{
+		{
+			name: "empty project",
+			req:  &svcv1alpha1.GetProjectUsageSummaryRequest{},
+			assertions: func(
+				t *testing.T,
+				res *connect.Response[svcv1alpha1.GetProjectUsageSummaryResponse],
+				err error,
+			) {
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		{
+			name: "error validating project",
+			req: &svcv1alpha1.GetProjectUsageSummaryRequest{
+				Project: "kargo-demo",
+			},
+			validateProjectExistsFn: func(context.Context, string) error {
+				return errors.New("something went wrong")
+			},
+			assertions: func(
+				t *testing.T,
+				res *connect.Response[svcv1alpha1.GetProjectUsageSummaryResponse],
+				err error,
+			) {
+				require.ErrorContains(t, err, "something went wrong")
+				require.Nil(t, res)
+			},
+		},
+		{
+			name: "success",
+			req: &svcv1alpha1.GetProjectUsageSummaryRequest{
+				Project: "kargo-demo",
+			},
+			objects: []client.Object{
+				freight,
+				newPromo(
+					"test-1", "test", now.Add(-50*time.Minute),
+					kargoapi.PromotionPhaseSucceeded, ptr.To(now.Add(-40*time.Minute)),
+				),
+				newPromo(
+					"test-2", "test", now.Add(-30*time.Minute),
+					kargoapi.PromotionPhaseFailed, ptr.To(now.Add(-20*time.Minute)),
+				),
+				newPromo(
+					"prod-1", "prod", now.Add(-20*time.Minute),
+					kargoapi.PromotionPhaseSucceeded, ptr.To(now),
+				),
+				newPromo(
+					"prod-2", "prod", now,
+					kargoapi.PromotionPhaseRunning, nil,
+				),
+			},
+			assertions: func(
+				t *testing.T,
+				res *connect.Response[svcv1alpha1.GetProjectUsageSummaryResponse],
+				err error,
+			) {
+				require.NoError(t, err)
+				project := res.Msg.GetProject()
+				require.Equal(t, int32(3), project.GetPromotions())
+				require.Equal(t, int32(2), project.GetSucceeded())
+				require.Equal(t, int32(1), project.GetFailed())
+				require.InDelta(t, 1.0/3.0, project.GetFailureRate(), 0.0001)
+				// Median of 20m and 60m
+				require.Equal(t, float64(40*60), project.GetMedianLeadTimeSeconds())
+
+				require.Len(t, res.Msg.GetStages(), 2)
+				test := res.Msg.GetStages()["test"]
+				require.Equal(t, int32(2), test.GetPromotions())
+				require.Equal(t, 0.5, test.GetFailureRate())
+				require.Equal(t, float64(20*60), test.GetMedianLeadTimeSeconds())
+				prod := res.Msg.GetStages()["prod"]
+				require.Equal(t, int32(1), prod.GetPromotions())
+				require.Equal(t, float64(0), prod.GetFailureRate())
+				require.Equal(t, float64(60*60), prod.GetMedianLeadTimeSeconds())
+			},
+		},
+		{
+			name: "promotions before since are excluded",
+			req: &svcv1alpha1.GetProjectUsageSummaryRequest{
+				Project: "kargo-demo",
+				Since:   timestamppb.New(now.Add(-25 * time.Minute)),
+			},
+			objects: []client.Object{
+				freight,
+				newPromo(
+					"test-1", "test", now.Add(-50*time.Minute),
+					kargoapi.PromotionPhaseSucceeded, ptr.To(now.Add(-40*time.Minute)),
+				),
+				newPromo(
+					"prod-1", "prod", now.Add(-20*time.Minute),
+					kargoapi.PromotionPhaseErrored, ptr.To(now),
+				),
+			},
+			assertions: func(
+				t *testing.T,
+				res *connect.Response[svcv1alpha1.GetProjectUsageSummaryResponse],
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, int32(1), res.Msg.GetProject().GetPromotions())
+				require.Equal(t, int32(1), res.Msg.GetProject().GetFailed())
+				require.Equal(t, float64(1), res.Msg.GetProject().GetFailureRate())
+				require.Zero(t, res.Msg.GetProject().GetMedianLeadTimeSeconds())
+				require.Len(t, res.Msg.GetStages(), 1)
+				require.Contains(t, res.Msg.GetStages(), "prod")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// Simulate an admin user to prevent any authz issues with the authorizing
+			// client.
+			ctx := user.ContextWithInfo(
+				context.Background(),
+				user.Info{
+					IsAdmin: true,
+				},
+			)
+
+			client, err := kubernetes.NewClient(
+				ctx,
+				&rest.Config{},
+				kubernetes.ClientOptions{
+					NewInternalClient: func(
+						_ context.Context,
+						_ *rest.Config,
+						scheme *runtime.Scheme,
+					) (client.Client, error) {
+						return fake.NewClientBuilder().
+							WithScheme(scheme).
+							WithObjects(testCase.objects...).
+							Build(), nil
+					},
+				},
+			)
+			require.NoError(t, err)
+
+			validateProjectExistsFn := testCase.validateProjectExistsFn
+			if validateProjectExistsFn == nil {
+				validateProjectExistsFn = func(context.Context, string) error {
+					return nil
+				}
+			}
+			svr := &server{
+				client:                  client,
+				validateProjectExistsFn: validateProjectExistsFn,
+			}
+			res, err := svr.GetProjectUsageSummary(ctx, connect.NewRequest(testCase.req))
+			testCase.assertions(t, res, err)
+		})
+	}
+}
+
+func TestMedianDuration(t *testing.T) {
+	testCases := []struct {
+		name      string
+		durations []time.Duration
+		expected  time.Duration
+	}{
+		{
+			name:     "no durations",
+			expected: 0,
+		},
+		{
+			name:      "odd number of durations",
+			durations: []time.Duration{3 * time.Second, time.Second, 2 * time.Second},
+			expected:  2 * time.Second,
+		},
+		{
+			name:      "even number of durations",
+			durations: []time.Duration{4 * time.Second, time.Second, 2 * time.Second, 3 * time.Second},
+			expected:  2500 * time.Millisecond,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, medianDuration(testCase.durations))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func (s *server) GetPromotionPlan(
+	ctx context.Context,
+	req *connect.Request[svcv1alpha1.GetPromotionPlanRequest],
+) (*connect.Response[svcv1alpha1.GetPromotionPlanResponse], error) {
+	project := req.Msg.GetProject()
+	if err := validateFieldNotEmpty("project", project); err != nil {
+		return nil, err
+	}
+
+	name := req.Msg.GetName()
+	if err := validateFieldNotEmpty("name", name); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateProjectExists(ctx, project); err != nil {
+		return nil, err
+	}
+
+	// Get the PromotionPlan from the Kubernetes API as an unstructured object.
+	// Using an unstructured object allows us to return the object _as presented
+	// by the API_ if a raw format is requested.
+	u := unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": kargoapi.GroupVersion.String(),
+			"kind":       "PromotionPlan",
+		},
+	}
+	if err := s.client.Get(ctx, client.ObjectKey{
+		Name:      name,
+		Namespace: project,
+	}, &u); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			err = fmt.Errorf("PromotionPlan %q not found in project %q", name, project)
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, err
+	}
+
+	switch req.Msg.GetFormat() {
+	case svcv1alpha1.RawFormat_RAW_FORMAT_JSON, svcv1alpha1.RawFormat_RAW_FORMAT_YAML:
+		_, raw, err := objectOrRaw(&u, req.Msg.GetFormat())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		return connect.NewResponse(&svcv1alpha1.GetPromotionPlanResponse{
+			Result: &svcv1alpha1.GetPromotionPlanResponse_Raw{
+				Raw: raw,
+			},
+		}), nil
+	default:
+		plan := kargoapi.PromotionPlan{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &plan); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		obj, _, err := objectOrRaw(&plan, req.Msg.GetFormat())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		return connect.NewResponse(&svcv1alpha1.GetPromotionPlanResponse{
+			Result: &svcv1alpha1.GetPromotionPlanResponse_PromotionPlan{
+				PromotionPlan: obj,
+			},
+		}), nil
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+// ListAnnouncements returns the Announcements declared by operators in the
+// ClusterConfig that are currently in effect. System-wide Announcements are
+// always returned. If a Project is specified, Announcements targeting that
+// Project are returned as well.
+func (s *server) ListAnnouncements(
+	ctx context.Context,
+	req *connect.Request[svcv1alpha1.ListAnnouncementsRequest],
+) (*connect.Response[svcv1alpha1.ListAnnouncementsResponse], error) {
+	project := req.Msg.GetProject()
+	if project != "" {
+		if err := s.validateProjectExistsFn(ctx, project); err != nil {
+			return nil, err
+		}
+	}
+
+	// ClusterConfig is read using the internal client because Announcements
+	// are meant to be visible to every authenticated user, regardless of their
+	// permissions.
+	cfg := &kargoapi.ClusterConfig{}
+	if err := s.internalClient.Get(
+		ctx,
+		client.ObjectKey{Name: kargoapi.ClusterConfigName},
+		cfg,
+	); err != nil {
+		if apierrors.IsNotFound(err) {
+			return connect.NewResponse(&svcv1alpha1.ListAnnouncementsResponse{}), nil
+		}
+		return nil, fmt.Errorf("get cluster config: %w", err)
+	}
+
+	announcements := cfg.Spec.ActiveAnnouncements(project, time.Now())
+	res := &svcv1alpha1.ListAnnouncementsResponse{
+		Announcements: make([]*kargoapi.Announcement, len(announcements)),
+	}
+	for i := range announcements {
+		res.Announcements[i] = &announcements[i]
+	}
+	return connect.NewResponse(res), nil
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func TestListAnnouncements(t *testing.T) {
+	clusterConfig := &kargoapi.ClusterConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: kargoapi.ClusterConfigName,
+		},
+		Spec: kargoapi.ClusterConfigSpec{
+			Announcements: []kargoapi.Announcement{
+				{
+					Name:     "maintenance",
+					Severity: kargoapi.AnnouncementSeverityWarning,
+					Message:  "Kargo will be upgraded on Saturday",
+				},
+				{
+					Name:     "freeze",
+					Severity: kargoapi.AnnouncementSeverityCritical,
+					Message:  "Promotions to production are frozen",
+					Projects: []string{"kargo-demo"},
+				},
+			},
+		},
+	}
+	testCases := []struct {
+		name                    string
+		req                     *svcv1alpha1.ListAnnouncementsRequest
+		objects                 []client.Object
+		validateProjectExistsFn func(context.Context, string) error
+		assertions              func(*testing.T, *connect.Response[svcv1alpha1.ListAnnouncementsResponse], error)
+	}{
+		{
+			name: "project does not exist",
+			req: &svcv1alpha1.ListAnnouncementsRequest{
+				Project: "kargo-x",
+			},
+			validateProjectExistsFn: func(context.Context, string) error {
+				return connect.NewError(connect.CodeNotFound, errors.New("not found"))
+			},
+			assertions: func(
+				t *testing.T,
+				_ *connect.Response[svcv1alpha1.ListAnnouncementsResponse],
+				err error,
+			) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+			},
+		},
+		{
+			name: "no cluster config",
+			req:  &svcv1alpha1.ListAnnouncementsRequest{},
+			assertions: func(
+				t *testing.T,
+				res *connect.Response[svcv1alpha1.ListAnnouncementsResponse],
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Empty(t, res.Msg.GetAnnouncements())
+			},
+		},
+		{
+			name:    "system-wide announcements",
+			req:     &svcv1alpha1.ListAnnouncementsRequest{},
+			objects: []client.Object{clusterConfig},
+			assertions: func(
+				t *testing.T,
+				res *connect.Response[svcv1alpha1.ListAnnouncementsResponse],
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Len(t, res.Msg.GetAnnouncements(), 1)
+				require.Equal(t, "maintenance", res.Msg.GetAnnouncements()[0].Name)
+			},
+		},
+		{
+			name: "project announcements",
+			req: &svcv1alpha1.ListAnnouncementsRequest{
+				Project: "kargo-demo",
+			},
+			objects: []client.Object{clusterConfig},
+			assertions: func(
+				t *testing.T,
+				res *connect.Response[svcv1alpha1.ListAnnouncementsResponse],
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Len(t, res.Msg.GetAnnouncements(), 2)
+				require.Equal(t, "maintenance", res.Msg.GetAnnouncements()[0].Name)
+				require.Equal(t, "freeze", res.Msg.GetAnnouncements()[1].Name)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, kargoapi.AddToScheme(scheme))
+
+			validateProjectExistsFn := testCase.validateProjectExistsFn
+			if validateProjectExistsFn == nil {
+				validateProjectExistsFn = func(context.Context, string) error {
+					return nil
+				}
+			}
+			svr := &server{
+				internalClient: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(testCase.objects...).
+					Build(),
+				validateProjectExistsFn: validateProjectExistsFn,
+			}
+			res, err := svr.ListAnnouncements(context.Background(), connect.NewRequest(testCase.req))
+			testCase.assertions(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"net/http"
+
+	"github.com/klauspost/compress/gzhttp"
+
+	httputil "github.com/akuity/kargo/internal/http"
+)
+
+// liteUIPath is the path under which the lite UI is served.
+const liteUIPath = "/lite/"
+
+var (
+	// lite is a minimal, read-only view of Projects' Stages, Freight, and
+	// Promotions. Unlike the full UI, it is always compiled into the API server
+	// and has no external dependencies, which makes it suitable for air-gapped
+	// environments. It obtains all data from the API server's own endpoints.
+	//
+	//go:embed lite
+	lite embed.FS
+)
+
+// newLiteUIRequestHandler returns an http.Handler that serves the lite UI.
+// The lite UI is a single page, so it is served for any GET request beneath
+// liteUIPath.
+func newLiteUIRequestHandler() (http.Handler, error) {
+	liteFS, err := fs.Sub(lite, "lite")
+	if err != nil {
+		return nil, fmt.Errorf("error initializing lite UI file system: %w", err)
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		httputil.SetNoCacheHeaders(w)
+		http.ServeFileFS(w, req, liteFS, "index.html")
+	})
+	return gzhttp.GzipHandler(handler), nil
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiteUIRequestHandler(t *testing.T) {
+	handler, err := newLiteUIRequestHandler()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		method     string
+		path       string
+		assertions func(*testing.T, *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "index",
+			method: http.MethodGet,
+			path:   liteUIPath,
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.Contains(t, rr.Header().Get("Content-Type"), "text/html")
+				require.Contains(t, rr.Body.String(), "KargoService")
+			},
+		},
+		{
+			name:   "unknown path",
+			method: http.MethodGet,
+			path:   liteUIPath + "projects/kargo-demo",
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.Contains(t, rr.Body.String(), "KargoService")
+			},
+		},
+		{
+			name:   "method not allowed",
+			method: http.MethodPost,
+			path:   liteUIPath,
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, httptest.NewRequest(testCase.method, testCase.path, nil))
+			testCase.assertions(t, rr)
+		})
+	}
+}
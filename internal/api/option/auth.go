@@ -210,6 +210,7 @@ func (a *authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 		var err error
 		if ctx, err =
 			a.authenticate(ctx, req.Spec().Procedure, req.Header()); err != nil {
+			recordAuthFailure(req.Spec().Procedure)
 			return nil, connect.NewError(connect.CodeUnauthenticated, err)
 		}
 		return next(ctx, req)
@@ -240,6 +241,7 @@ func (a *authInterceptor) WrapStreamingHandler(
 			conn.Spec().Procedure,
 			conn.RequestHeader(),
 		); err != nil {
+			recordAuthFailure(conn.Spec().Procedure)
 			return connect.NewError(connect.CodeUnauthenticated, err)
 		}
 		return next(ctx, conn)
@@ -0,0 +1,149 @@
+package option
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/akuity/kargo/internal/api/user"
+)
+
+const (
+	// metricsUserAdmin is the value of the user label for requests made by the
+	// Kargo API server's admin user.
+	metricsUserAdmin = "admin"
+	// metricsUserAnonymous is the value of the user label for requests that
+	// were not authenticated, e.g. because authentication was not required.
+	metricsUserAnonymous = "anonymous"
+	// metricsUserKubernetes is the value of the user label for requests
+	// authenticated using a bearer token that is assumed to be a credential for
+	// a Kubernetes user, whose identity is unknown to the Kargo API server.
+	metricsUserKubernetes = "kubernetes"
+)
+
+var (
+	apiRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kargo_api_requests_total",
+			Help: "Total number of requests handled by the Kargo API server",
+		},
+		[]string{"service", "method", "code", "user"},
+	)
+	apiRequestDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kargo_api_request_duration_seconds",
+			Help:    "Duration of unary requests handled by the Kargo API server",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"service", "method"},
+	)
+	apiAuthFailuresTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kargo_api_auth_failures_total",
+			Help: "Total number of requests rejected by the Kargo API server because they could not be authenticated",
+		},
+		[]string{"service", "method"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		apiRequestsTotal,
+		apiRequestDurationSeconds,
+		apiAuthFailuresTotal,
+	)
+}
+
+var (
+	_ connect.Interceptor = &metricsInterceptor{}
+)
+
+// metricsInterceptor records metrics about the requests handled by the Kargo
+// API server. It must follow any authentication interceptor so that requests
+// can be attributed to the users who made them.
+type metricsInterceptor struct{}
+
+func newMetricsInterceptor() connect.Interceptor {
+	return &metricsInterceptor{}
+}
+
+// WrapUnary implements connect.Interceptor.
+func (i *metricsInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(
+		ctx context.Context,
+		req connect.AnyRequest,
+	) (connect.AnyResponse, error) {
+		start := time.Now()
+		res, err := next(ctx, req)
+		service, method := splitProcedure(req.Spec().Procedure)
+		apiRequestDurationSeconds.WithLabelValues(service, method).
+			Observe(time.Since(start).Seconds())
+		apiRequestsTotal.WithLabelValues(service, method, codeOf(err), metricsUser(ctx)).Inc()
+		return res, err
+	}
+}
+
+// WrapStreamingClient implements connect.Interceptor.
+func (i *metricsInterceptor) WrapStreamingClient(
+	next connect.StreamingClientFunc,
+) connect.StreamingClientFunc {
+	// This is a no-op because this interceptor is only used with handlers.
+	return next
+}
+
+// WrapStreamingHandler implements connect.Interceptor.
+func (i *metricsInterceptor) WrapStreamingHandler(
+	next connect.StreamingHandlerFunc,
+) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		err := next(ctx, conn)
+		service, method := splitProcedure(conn.Spec().Procedure)
+		apiRequestsTotal.WithLabelValues(service, method, codeOf(err), metricsUser(ctx)).Inc()
+		return err
+	}
+}
+
+// recordAuthFailure records that a request to the specified procedure was
+// rejected because it could not be authenticated.
+func recordAuthFailure(procedure string) {
+	service, method := splitProcedure(procedure)
+	apiAuthFailuresTotal.WithLabelValues(service, method).Inc()
+}
+
+// splitProcedure splits the provided procedure into its service and method.
+func splitProcedure(procedure string) (string, string) {
+	return path.Dir(procedure)[1:], path.Base(procedure)
+}
+
+// codeOf returns the name of the code of the provided error, or "ok" if the
+// error is nil.
+func codeOf(err error) string {
+	if err == nil {
+		return "ok"
+	}
+	return connect.CodeOf(err).String()
+}
+
+// metricsUser returns the value of the user label for a request made with the
+// provided context.
+func metricsUser(ctx context.Context) string {
+	info, ok := user.InfoFromContext(ctx)
+	switch {
+	case !ok:
+		return metricsUserAnonymous
+	case info.IsAdmin:
+		return metricsUserAdmin
+	case info.Email != "":
+		return info.Email
+	case info.Subject != "":
+		return info.Subject
+	case info.BearerToken != "":
+		return metricsUserKubernetes
+	default:
+		return metricsUserAnonymous
+	}
+}
@@ -0,0 +1,79 @@
+package option
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/kargo/internal/api/user"
+)
+
+func TestSplitProcedure(t *testing.T) {
+	service, method := splitProcedure("/akuity.io.kargo.service.v1alpha1.KargoService/ListProjects")
+	require.Equal(t, "akuity.io.kargo.service.v1alpha1.KargoService", service)
+	require.Equal(t, "ListProjects", method)
+}
+
+func TestCodeOf(t *testing.T) {
+	require.Equal(t, "ok", codeOf(nil))
+	require.Equal(
+		t,
+		"not_found",
+		codeOf(connect.NewError(connect.CodeNotFound, errors.New("not found"))),
+	)
+	require.Equal(t, "unknown", codeOf(errors.New("something went wrong")))
+}
+
+func TestMetricsUser(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{
+			name:     "no user info",
+			ctx:      context.Background(),
+			expected: metricsUserAnonymous,
+		},
+		{
+			name:     "admin",
+			ctx:      user.ContextWithInfo(context.Background(), user.Info{IsAdmin: true}),
+			expected: metricsUserAdmin,
+		},
+		{
+			name: "email",
+			ctx: user.ContextWithInfo(
+				context.Background(),
+				user.Info{
+					Subject: "fake-subject",
+					Email:   "fake@example.com",
+				},
+			),
+			expected: "fake@example.com",
+		},
+		{
+			name: "subject",
+			ctx: user.ContextWithInfo(
+				context.Background(),
+				user.Info{Subject: "fake-subject"},
+			),
+			expected: "fake-subject",
+		},
+		{
+			name: "kubernetes bearer token",
+			ctx: user.ContextWithInfo(
+				context.Background(),
+				user.Info{BearerToken: "fake-token"},
+			),
+			expected: metricsUserKubernetes,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, metricsUser(testCase.ctx))
+		})
+	}
+}
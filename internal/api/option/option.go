@@ -29,6 +29,9 @@ func NewHandlerOption(
 		}
 		interceptors = append(interceptors, authInterceptor)
 	}
+	// The metrics interceptor follows the authentication interceptor so that
+	// requests can be attributed to the users who made them.
+	interceptors = append(interceptors, newMetricsInterceptor())
 	return connect.WithHandlerOptions(
 		connect.WithInterceptors(interceptors...),
 		connect.WithRecover(
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"connectrpc.com/connect"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+// PreviewWarehouse discovers the artifacts that a Warehouse would discover if
+// it were reconciled right now. The Warehouse may be an existing one, or a
+// (possibly unsaved) Warehouse spec may be provided. In either case, no
+// Freight is produced and no resources are modified.
+func (s *server) PreviewWarehouse(
+	ctx context.Context,
+	req *connect.Request[svcv1alpha1.PreviewWarehouseRequest],
+) (*connect.Response[svcv1alpha1.PreviewWarehouseResponse], error) {
+	project := req.Msg.GetProject()
+	if err := validateFieldNotEmpty("project", project); err != nil {
+		return nil, err
+	}
+
+	spec := req.Msg.GetSpec()
+	name := req.Msg.GetName()
+	if spec == nil {
+		if err := validateFieldNotEmpty("name", name); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.validateProjectExists(ctx, project); err != nil {
+		return nil, err
+	}
+
+	var warehouse *kargoapi.Warehouse
+	if spec != nil {
+		if len(spec.Subscriptions) == 0 {
+			return nil, connect.NewError(
+				connect.CodeInvalidArgument,
+				errors.New("spec must contain at least one subscription"),
+			)
+		}
+		// Previewing an arbitrary spec uses the project's credentials to reach
+		// out to repositories, so we require the same permissions that would be
+		// required to create the Warehouse.
+		if err := s.authorizeFn(
+			ctx,
+			"create",
+			schema.GroupVersionResource{
+				Group:    kargoapi.GroupVersion.Group,
+				Version:  kargoapi.GroupVersion.Version,
+				Resource: "warehouses",
+			},
+			"",
+			types.NamespacedName{
+				Namespace: project,
+				Name:      name,
+			},
+		); err != nil {
+			return nil, err
+		}
+		warehouse = &kargoapi.Warehouse{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: project,
+				Name:      name,
+			},
+			Spec: *spec,
+		}
+	} else {
+		warehouse = &kargoapi.Warehouse{}
+		if err := s.client.Get(
+			ctx,
+			client.ObjectKey{
+				Namespace: project,
+				Name:      name,
+			},
+			warehouse,
+		); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				return nil, connect.NewError(
+					connect.CodeNotFound,
+					fmt.Errorf("Warehouse %q not found in project %q", name, project),
+				)
+			}
+			return nil, err
+		}
+	}
+
+	// Discovery results can be large for Warehouses with many subscriptions, so
+	// they may be requested one page of subscriptions at a time.
+	var nextPageToken string
+	if pageSize := int(req.Msg.GetPageSize()); pageSize > 0 || req.Msg.GetPageToken() != "" {
+		subs := warehouse.Spec.Subscriptions
+		var offset int
+		if token := req.Msg.GetPageToken(); token != "" {
+			var err error
+			if offset, err = strconv.Atoi(token); err != nil || offset < 0 || offset > len(subs) {
+				return nil, connect.NewError(
+					connect.CodeInvalidArgument,
+					fmt.Errorf("invalid page token %q", token),
+				)
+			}
+		}
+		end := len(subs)
+		if pageSize > 0 && offset+pageSize < end {
+			end = offset + pageSize
+			nextPageToken = strconv.Itoa(end)
+		}
+		// Work on a copy so that the subscriptions of the Warehouse itself are
+		// left untouched.
+		warehouse = warehouse.DeepCopy()
+		warehouse.Spec.Subscriptions = subs[offset:end]
+	}
+
+	artifacts, err := s.discoverArtifactsFn(ctx, warehouse)
+	if err != nil {
+		return nil, connect.NewError(
+			connect.CodeFailedPrecondition,
+			fmt.Errorf("error discovering artifacts: %w", err),
+		)
+	}
+
+	return connect.NewResponse(&svcv1alpha1.PreviewWarehouseResponse{
+		DiscoveredArtifacts: artifacts,
+		NextPageToken:       nextPageToken,
+	}), nil
+}
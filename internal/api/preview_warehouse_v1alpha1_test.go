@@ -0,0 +1,312 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/api/kubernetes"
+	"github.com/akuity/kargo/internal/api/user"
+	"github.com/akuity/kargo/internal/api/validation"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func TestPreviewWarehouse(t *testing.T) {
+	testSpec := &kargoapi.WarehouseSpec{
+		Subscriptions: []kargoapi.RepoSubscription{{
+			Image: &kargoapi.ImageSubscription{
+				RepoURL: "fake-repo",
+			},
+		}},
+	}
+	testArtifacts := &kargoapi.DiscoveredArtifacts{
+		Images: []kargoapi.ImageDiscoveryResult{{
+			RepoURL: "fake-repo",
+			References: []kargoapi.DiscoveredImageReference{{
+				Tag:    "v1.0.0",
+				Digest: "sha256:abc",
+			}},
+		}},
+	}
+	testPagedSpec := &kargoapi.WarehouseSpec{
+		Subscriptions: []kargoapi.RepoSubscription{
+			{Image: &kargoapi.ImageSubscription{RepoURL: "fake-repo-1"}},
+			{Image: &kargoapi.ImageSubscription{RepoURL: "fake-repo-2"}},
+			{Image: &kargoapi.ImageSubscription{RepoURL: "fake-repo-3"}},
+		},
+	}
+	discoverPageFn := func(
+		_ context.Context,
+		warehouse *kargoapi.Warehouse,
+	) (*kargoapi.DiscoveredArtifacts, error) {
+		artifacts := &kargoapi.DiscoveredArtifacts{}
+		for _, sub := range warehouse.Spec.Subscriptions {
+			artifacts.Images = append(artifacts.Images, kargoapi.ImageDiscoveryResult{
+				RepoURL: sub.Image.RepoURL,
+			})
+		}
+		return artifacts, nil
+	}
+	testCases := map[string]struct {
+		req                 *svcv1alpha1.PreviewWarehouseRequest
+		authorizeFn         func(context.Context, string, schema.GroupVersionResource, string, client.ObjectKey) error
+		discoverArtifactsFn func(context.Context, *kargoapi.Warehouse) (*kargoapi.DiscoveredArtifacts, error)
+		assertions          func(*testing.T, *connect.Response[svcv1alpha1.PreviewWarehouseResponse], error)
+	}{
+		"empty project": {
+			req: &svcv1alpha1.PreviewWarehouseRequest{},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.PreviewWarehouseResponse], err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		"neither name nor spec": {
+			req: &svcv1alpha1.PreviewWarehouseRequest{
+				Project: "kargo-demo",
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.PreviewWarehouseResponse], err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		"non-existing project": {
+			req: &svcv1alpha1.PreviewWarehouseRequest{
+				Project: "kargo-x",
+				Name:    "test",
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.PreviewWarehouseResponse], err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		"non-existing Warehouse": {
+			req: &svcv1alpha1.PreviewWarehouseRequest{
+				Project: "kargo-demo",
+				Name:    "non-existing",
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.PreviewWarehouseResponse], err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		"spec without subscriptions": {
+			req: &svcv1alpha1.PreviewWarehouseRequest{
+				Project: "kargo-demo",
+				Spec:    &kargoapi.WarehouseSpec{},
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.PreviewWarehouseResponse], err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		"spec not authorized": {
+			req: &svcv1alpha1.PreviewWarehouseRequest{
+				Project: "kargo-demo",
+				Spec:    testSpec,
+			},
+			authorizeFn: func(context.Context, string, schema.GroupVersionResource, string, client.ObjectKey) error {
+				return connect.NewError(connect.CodePermissionDenied, errors.New("not allowed"))
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.PreviewWarehouseResponse], err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		"error discovering artifacts": {
+			req: &svcv1alpha1.PreviewWarehouseRequest{
+				Project: "kargo-demo",
+				Name:    "test",
+			},
+			discoverArtifactsFn: func(context.Context, *kargoapi.Warehouse) (*kargoapi.DiscoveredArtifacts, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.PreviewWarehouseResponse], err error) {
+				require.ErrorContains(t, err, "something went wrong")
+				require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		"existing Warehouse": {
+			req: &svcv1alpha1.PreviewWarehouseRequest{
+				Project: "kargo-demo",
+				Name:    "test",
+			},
+			discoverArtifactsFn: func(
+				_ context.Context,
+				warehouse *kargoapi.Warehouse,
+			) (*kargoapi.DiscoveredArtifacts, error) {
+				if warehouse.Spec.Subscriptions[0].Image.RepoURL != "existing-repo" {
+					return nil, errors.New("unexpected Warehouse")
+				}
+				return testArtifacts, nil
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.PreviewWarehouseResponse], err error) {
+				require.NoError(t, err)
+				require.Equal(t, testArtifacts, res.Msg.GetDiscoveredArtifacts())
+			},
+		},
+		"unsaved spec": {
+			req: &svcv1alpha1.PreviewWarehouseRequest{
+				Project: "kargo-demo",
+				Spec:    testSpec,
+			},
+			authorizeFn: func(
+				_ context.Context,
+				verb string,
+				gvr schema.GroupVersionResource,
+				_ string,
+				key client.ObjectKey,
+			) error {
+				if verb != "create" || gvr.Resource != "warehouses" || key.Namespace != "kargo-demo" {
+					return errors.New("unexpected authorization check")
+				}
+				return nil
+			},
+			discoverArtifactsFn: func(
+				_ context.Context,
+				warehouse *kargoapi.Warehouse,
+			) (*kargoapi.DiscoveredArtifacts, error) {
+				if warehouse.Namespace != "kargo-demo" ||
+					warehouse.Spec.Subscriptions[0].Image.RepoURL != "fake-repo" {
+					return nil, errors.New("unexpected Warehouse")
+				}
+				return testArtifacts, nil
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.PreviewWarehouseResponse], err error) {
+				require.NoError(t, err)
+				require.Equal(t, testArtifacts, res.Msg.GetDiscoveredArtifacts())
+			},
+		},
+		"first page": {
+			req: &svcv1alpha1.PreviewWarehouseRequest{
+				Project:  "kargo-demo",
+				Spec:     testPagedSpec,
+				PageSize: 2,
+			},
+			authorizeFn: func(context.Context, string, schema.GroupVersionResource, string, client.ObjectKey) error {
+				return nil
+			},
+			discoverArtifactsFn: discoverPageFn,
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.PreviewWarehouseResponse], err error) {
+				require.NoError(t, err)
+				images := res.Msg.GetDiscoveredArtifacts().Images
+				require.Len(t, images, 2)
+				require.Equal(t, "fake-repo-1", images[0].RepoURL)
+				require.Equal(t, "fake-repo-2", images[1].RepoURL)
+				require.Equal(t, "2", res.Msg.GetNextPageToken())
+				// The request's spec must not have been modified
+				require.Len(t, testPagedSpec.Subscriptions, 3)
+			},
+		},
+		"last page": {
+			req: &svcv1alpha1.PreviewWarehouseRequest{
+				Project:   "kargo-demo",
+				Spec:      testPagedSpec,
+				PageSize:  2,
+				PageToken: "2",
+			},
+			authorizeFn: func(context.Context, string, schema.GroupVersionResource, string, client.ObjectKey) error {
+				return nil
+			},
+			discoverArtifactsFn: discoverPageFn,
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.PreviewWarehouseResponse], err error) {
+				require.NoError(t, err)
+				images := res.Msg.GetDiscoveredArtifacts().Images
+				require.Len(t, images, 1)
+				require.Equal(t, "fake-repo-3", images[0].RepoURL)
+				require.Empty(t, res.Msg.GetNextPageToken())
+			},
+		},
+		"invalid page token": {
+			req: &svcv1alpha1.PreviewWarehouseRequest{
+				Project:   "kargo-demo",
+				Spec:      testPagedSpec,
+				PageSize:  2,
+				PageToken: "bogus",
+			},
+			authorizeFn: func(context.Context, string, schema.GroupVersionResource, string, client.ObjectKey) error {
+				return nil
+			},
+			discoverArtifactsFn: discoverPageFn,
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.PreviewWarehouseResponse], err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// Simulate an admin user to prevent any authz issues with the authorizing
+			// client.
+			ctx := user.ContextWithInfo(
+				context.Background(),
+				user.Info{
+					IsAdmin: true,
+				},
+			)
+
+			client, err := kubernetes.NewClient(
+				ctx,
+				&rest.Config{},
+				kubernetes.ClientOptions{
+					NewInternalClient: func(
+						_ context.Context,
+						_ *rest.Config,
+						scheme *runtime.Scheme,
+					) (client.Client, error) {
+						return fake.NewClientBuilder().
+							WithScheme(scheme).
+							WithObjects(
+								mustNewObject[corev1.Namespace]("testdata/namespace.yaml"),
+								&kargoapi.Warehouse{
+									ObjectMeta: metav1.ObjectMeta{
+										Namespace: "kargo-demo",
+										Name:      "test",
+									},
+									Spec: kargoapi.WarehouseSpec{
+										Subscriptions: []kargoapi.RepoSubscription{{
+											Image: &kargoapi.ImageSubscription{
+												RepoURL: "existing-repo",
+											},
+										}},
+									},
+								},
+							).
+							Build(), nil
+					},
+				},
+			)
+			require.NoError(t, err)
+
+			svr := &server{
+				client:              client,
+				authorizeFn:         testCase.authorizeFn,
+				discoverArtifactsFn: testCase.discoverArtifactsFn,
+			}
+			svr.externalValidateProjectFn = validation.ValidateProject
+			res, err := svr.PreviewWarehouse(ctx, connect.NewRequest(testCase.req))
+			testCase.assertions(t, res, err)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/kargo"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+// PromoteThroughStages creates a PromotionPlan resource to promote the
+// specified Freight to the specified Stage by way of every intermediate Stage
+// it has not yet been verified in.
+func (s *server) PromoteThroughStages(
+	ctx context.Context,
+	req *connect.Request[svcv1alpha1.PromoteThroughStagesRequest],
+) (*connect.Response[svcv1alpha1.PromoteThroughStagesResponse], error) {
+	project := req.Msg.GetProject()
+	if err := validateFieldNotEmpty("project", project); err != nil {
+		return nil, err
+	}
+
+	stageName := req.Msg.GetStage()
+	if err := validateFieldNotEmpty("stage", stageName); err != nil {
+		return nil, err
+	}
+
+	freightName := req.Msg.GetFreight()
+	freightAlias := req.Msg.GetFreightAlias()
+	if (freightName == "" && freightAlias == "") || (freightName != "" && freightAlias != "") {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("exactly one of freight or freightAlias should not be empty"),
+		)
+	}
+
+	if err := s.validateProjectExistsFn(ctx, project); err != nil {
+		return nil, err
+	}
+
+	stage, err := s.getStageFn(
+		ctx,
+		s.client,
+		types.NamespacedName{
+			Namespace: project,
+			Name:      stageName,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get stage: %w", err)
+	}
+	if stage == nil {
+		return nil, connect.NewError(
+			connect.CodeNotFound,
+			fmt.Errorf(
+				"Stage %q not found in namespace %q",
+				stageName,
+				project,
+			),
+		)
+	}
+
+	freight, err := s.getFreightByNameOrAliasFn(
+		ctx,
+		s.client,
+		project,
+		freightName,
+		freightAlias,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get freight: %w", err)
+	}
+	if freight == nil {
+		if freightName != "" {
+			err = fmt.Errorf("freight %q not found in namespace %q", freightName, project)
+		} else {
+			err = fmt.Errorf("freight with alias %q not found in namespace %q", freightAlias, project)
+		}
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	if freight.IsBlocked() {
+		return nil, connect.NewError(
+			connect.CodeFailedPrecondition,
+			fmt.Errorf(
+				"Freight %q is blocked: %s",
+				freight.Name,
+				freight.Status.Blocked.Reason,
+			),
+		)
+	}
+
+	stages := kargoapi.StageList{}
+	if err = s.listStagesFn(ctx, &stages, client.InNamespace(project)); err != nil {
+		return nil, fmt.Errorf("list stages: %w", err)
+	}
+	path, err := kargo.PromotionPlanStages(freight, stages.Items, stageName)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	// The PromotionPlan will promote the Freight to every Stage in the path, so
+	// the user must be permitted to promote to all of them.
+	for _, name := range path {
+		if err = s.authorizeFn(
+			ctx,
+			"promote",
+			schema.GroupVersionResource{
+				Group:    kargoapi.GroupVersion.Group,
+				Version:  kargoapi.GroupVersion.Version,
+				Resource: "stages",
+			},
+			"",
+			types.NamespacedName{
+				Namespace: project,
+				Name:      name,
+			},
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.validateManualPromotionsAllowed(ctx, project); err != nil {
+		return nil, err
+	}
+
+	plan := kargo.NewPromotionPlan(ctx, *stage, freight.Name)
+	if err = s.createPromotionPlanFn(ctx, &plan); err != nil {
+		return nil, fmt.Errorf("create promotion plan: %w", err)
+	}
+	return connect.NewResponse(&svcv1alpha1.PromoteThroughStagesResponse{
+		PromotionPlan: &plan,
+	}), nil
+}
@@ -0,0 +1,283 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func TestPromoteThroughStages(t *testing.T) {
+	testStages := []kargoapi.Stage{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "fake-project", Name: "test"},
+			Spec: kargoapi.StageSpec{
+				Subscriptions: kargoapi.Subscriptions{Warehouse: "fake-warehouse"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "fake-project", Name: "prod"},
+			Spec: kargoapi.StageSpec{
+				Subscriptions: kargoapi.Subscriptions{
+					UpstreamStages: []kargoapi.StageSubscription{{Name: "test"}},
+				},
+			},
+		},
+	}
+	testFreight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "fake-project", Name: "fake-freight"},
+		Warehouse:  "fake-warehouse",
+	}
+
+	// newServer returns a server that will successfully create a PromotionPlan
+	// for testFreight and the prod Stage unless overridden.
+	newServer := func(authorized ...string) *server {
+		return &server{
+			validateProjectExistsFn: func(context.Context, string) error {
+				return nil
+			},
+			getStageFn: func(
+				_ context.Context,
+				_ client.Client,
+				key types.NamespacedName,
+			) (*kargoapi.Stage, error) {
+				for _, stage := range testStages {
+					if stage.Name == key.Name {
+						return stage.DeepCopy(), nil
+					}
+				}
+				return nil, nil
+			},
+			getFreightByNameOrAliasFn: func(
+				context.Context,
+				client.Client,
+				string,
+				string,
+				string,
+			) (*kargoapi.Freight, error) {
+				return testFreight.DeepCopy(), nil
+			},
+			listStagesFn: func(
+				_ context.Context,
+				list client.ObjectList,
+				_ ...client.ListOption,
+			) error {
+				list.(*kargoapi.StageList).Items = testStages // nolint: forcetypeassert
+				return nil
+			},
+			authorizeFn: func(
+				_ context.Context,
+				_ string,
+				_ schema.GroupVersionResource,
+				_ string,
+				key client.ObjectKey,
+			) error {
+				for _, stage := range authorized {
+					if stage == key.Name {
+						return nil
+					}
+				}
+				return connect.NewError(connect.CodePermissionDenied, errors.New("not authorized"))
+			},
+			getProjectFn: func(
+				context.Context,
+				client.Client,
+				string,
+			) (*kargoapi.Project, error) {
+				return &kargoapi.Project{}, nil
+			},
+			createPromotionPlanFn: func(
+				context.Context,
+				client.Object,
+				...client.CreateOption,
+			) error {
+				return nil
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		req        *svcv1alpha1.PromoteThroughStagesRequest
+		server     *server
+		assertions func(
+			*testing.T,
+			*connect.Response[svcv1alpha1.PromoteThroughStagesResponse],
+			error,
+		)
+	}{
+		{
+			name:   "input validation error",
+			req:    &svcv1alpha1.PromoteThroughStagesRequest{},
+			server: &server{},
+			assertions: func(
+				t *testing.T,
+				_ *connect.Response[svcv1alpha1.PromoteThroughStagesResponse],
+				err error,
+			) {
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+			},
+		},
+		{
+			name: "Stage not found",
+			req: &svcv1alpha1.PromoteThroughStagesRequest{
+				Project: "fake-project",
+				Stage:   "missing",
+				Freight: "fake-freight",
+			},
+			server: newServer("test", "prod"),
+			assertions: func(
+				t *testing.T,
+				_ *connect.Response[svcv1alpha1.PromoteThroughStagesResponse],
+				err error,
+			) {
+				require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+			},
+		},
+		{
+			name: "Freight cannot reach Stage",
+			req: &svcv1alpha1.PromoteThroughStagesRequest{
+				Project: "fake-project",
+				Stage:   "prod",
+				Freight: "fake-freight",
+			},
+			server: func() *server {
+				s := newServer("test", "prod")
+				s.getFreightByNameOrAliasFn = func(
+					context.Context,
+					client.Client,
+					string,
+					string,
+					string,
+				) (*kargoapi.Freight, error) {
+					freight := testFreight.DeepCopy()
+					freight.Warehouse = "other-warehouse"
+					return freight, nil
+				}
+				return s
+			}(),
+			assertions: func(
+				t *testing.T,
+				_ *connect.Response[svcv1alpha1.PromoteThroughStagesResponse],
+				err error,
+			) {
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+				require.ErrorContains(t, err, "cannot reach Stage")
+			},
+		},
+		{
+			name: "promoting to intermediate Stage not authorized",
+			req: &svcv1alpha1.PromoteThroughStagesRequest{
+				Project: "fake-project",
+				Stage:   "prod",
+				Freight: "fake-freight",
+			},
+			server: newServer("prod"),
+			assertions: func(
+				t *testing.T,
+				_ *connect.Response[svcv1alpha1.PromoteThroughStagesResponse],
+				err error,
+			) {
+				require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+			},
+		},
+		{
+			name: "manual promotions rejected by maintenance mode",
+			req: &svcv1alpha1.PromoteThroughStagesRequest{
+				Project: "fake-project",
+				Stage:   "prod",
+				Freight: "fake-freight",
+			},
+			server: func() *server {
+				s := newServer("test", "prod")
+				s.getProjectFn = func(
+					context.Context,
+					client.Client,
+					string,
+				) (*kargoapi.Project, error) {
+					return &kargoapi.Project{
+						Spec: &kargoapi.ProjectSpec{
+							MaintenanceMode: &kargoapi.MaintenanceMode{
+								Enabled:                true,
+								RejectManualPromotions: true,
+							},
+						},
+					}, nil
+				}
+				return s
+			}(),
+			assertions: func(
+				t *testing.T,
+				_ *connect.Response[svcv1alpha1.PromoteThroughStagesResponse],
+				err error,
+			) {
+				require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+			},
+		},
+		{
+			name: "error creating PromotionPlan",
+			req: &svcv1alpha1.PromoteThroughStagesRequest{
+				Project: "fake-project",
+				Stage:   "prod",
+				Freight: "fake-freight",
+			},
+			server: func() *server {
+				s := newServer("test", "prod")
+				s.createPromotionPlanFn = func(
+					context.Context,
+					client.Object,
+					...client.CreateOption,
+				) error {
+					return errors.New("something went wrong")
+				}
+				return s
+			}(),
+			assertions: func(
+				t *testing.T,
+				_ *connect.Response[svcv1alpha1.PromoteThroughStagesResponse],
+				err error,
+			) {
+				require.EqualError(t, err, "create promotion plan: something went wrong")
+			},
+		},
+		{
+			name: "success",
+			req: &svcv1alpha1.PromoteThroughStagesRequest{
+				Project: "fake-project",
+				Stage:   "prod",
+				Freight: "fake-freight",
+			},
+			server: newServer("test", "prod"),
+			assertions: func(
+				t *testing.T,
+				res *connect.Response[svcv1alpha1.PromoteThroughStagesResponse],
+				err error,
+			) {
+				require.NoError(t, err)
+				plan := res.Msg.GetPromotionPlan()
+				require.NotNil(t, plan)
+				require.Equal(t, "fake-project", plan.Namespace)
+				require.Equal(t, "prod", plan.Spec.Stage)
+				require.Equal(t, "fake-freight", plan.Spec.Freight)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			res, err := testCase.server.PromoteThroughStages(
+				context.Background(),
+				connect.NewRequest(testCase.req),
+			)
+			testCase.assertions(t, res, err)
+		})
+	}
+}
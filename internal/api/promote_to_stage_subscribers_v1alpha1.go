@@ -92,6 +92,17 @@ func (s *server) PromoteToStageSubscribers(
 		}
 		return nil, connect.NewError(connect.CodeNotFound, err)
 	}
+	if freight.IsBlocked() {
+		return nil, connect.NewError(
+			connect.CodeFailedPrecondition,
+			fmt.Errorf(
+				"Freight %q is blocked: %s",
+				freight.Name,
+				freight.Status.Blocked.Reason,
+			),
+		)
+	}
+
 	if !s.isFreightAvailableFn(
 		freight,
 		"",                  // approved for not considered
@@ -130,6 +141,10 @@ func (s *server) PromoteToStageSubscribers(
 		}
 	}
 
+	if err := s.validateManualPromotionsAllowed(ctx, project); err != nil {
+		return nil, err
+	}
+
 	promoteErrs := make([]error, 0, len(subscribers))
 	createdPromos := make([]*kargoapi.Promotion, 0, len(subscribers))
 	for _, subscriber := range subscribers {
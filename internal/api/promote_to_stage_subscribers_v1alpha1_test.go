@@ -624,6 +624,15 @@ func TestPromoteToStageSubscribers(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			recorder := fakeevent.NewEventRecorder(1)
 			testCase.server.recorder = recorder
+			if testCase.server.getProjectFn == nil {
+				testCase.server.getProjectFn = func(
+					context.Context,
+					client.Client,
+					string,
+				) (*kargoapi.Project, error) {
+					return &kargoapi.Project{}, nil
+				}
+			}
 			resp, err := testCase.server.PromoteToStageSubscribers(
 				context.Background(),
 				connect.NewRequest(testCase.req),
@@ -2,13 +2,16 @@ package api
 
 import (
 	"context"
+	"crypto/sha1"
 	"errors"
 	"fmt"
 
 	"connectrpc.com/connect"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/api/user"
@@ -17,7 +20,9 @@ import (
 )
 
 // PromoteToStage creates a Promotion resource to transition a specified Stage
-// into the state represented by the specified Freight.
+// into the state represented by the specified Freight. If the request specifies
+// an idempotency key that was already used to create a Promotion of the same
+// Freight to the same Stage, that Promotion is returned instead.
 func (s *server) PromoteToStage(
 	ctx context.Context,
 	req *connect.Request[svcv1alpha1.PromoteToStageRequest],
@@ -86,6 +91,17 @@ func (s *server) PromoteToStage(
 		return nil, connect.NewError(connect.CodeNotFound, err)
 	}
 
+	if freight.IsBlocked() {
+		return nil, connect.NewError(
+			connect.CodeFailedPrecondition,
+			fmt.Errorf(
+				"Freight %q is blocked: %s",
+				freight.Name,
+				freight.Status.Blocked.Reason,
+			),
+		)
+	}
+
 	upstreamStages := make([]string, len(stage.Spec.Subscriptions.UpstreamStages))
 	for i, upstreamStage := range stage.Spec.Subscriptions.UpstreamStages {
 		upstreamStages[i] = upstreamStage.Name
@@ -118,9 +134,56 @@ func (s *server) PromoteToStage(
 		return nil, err
 	}
 
+	idempotencyKey := req.Msg.GetIdempotencyKey()
+	if idempotencyKey != "" {
+		existing, err := s.getPromotionByIdempotencyKey(ctx, project, idempotencyKey)
+		if err != nil {
+			return nil, fmt.Errorf("get promotion by idempotency key: %w", err)
+		}
+		if existing != nil {
+			return idempotentPromoteToStageResponse(existing, stage, freight, idempotencyKey)
+		}
+	}
+
+	if err := s.validateManualPromotionsAllowed(ctx, project); err != nil {
+		return nil, err
+	}
+
 	promotion := kargo.NewPromotion(ctx, *stage, freight.Name)
+	if idempotencyKey != "" {
+		// Concurrent requests with the same idempotency key may all get this far
+		// before any of them has created a Promotion. Naming the Promotion
+		// deterministically ensures only one of them can succeed in creating it.
+		promotion.Name = idempotentPromotionName(project, stage.Name, idempotencyKey)
+		promotion.Labels = map[string]string{
+			kargoapi.IdempotencyKeyLabelKey: idempotencyKeyLabelValue(idempotencyKey),
+		}
+		promotion.Annotations[kargoapi.AnnotationKeyIdempotencyKey] = idempotencyKey
+	}
 	if err := s.createPromotionFn(ctx, &promotion); err != nil {
-		return nil, fmt.Errorf("create promotion: %w", err)
+		if idempotencyKey == "" || !apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("create promotion: %w", err)
+		}
+		// Another request with the same idempotency key won the race.
+		existing, err := s.getPromotionFn(
+			ctx,
+			s.client,
+			types.NamespacedName{
+				Namespace: project,
+				Name:      promotion.Name,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("get promotion: %w", err)
+		}
+		if existing == nil ||
+			existing.Annotations[kargoapi.AnnotationKeyIdempotencyKey] != idempotencyKey {
+			return nil, connect.NewError(
+				connect.CodeAlreadyExists,
+				fmt.Errorf("Promotion %q already exists", promotion.Name),
+			)
+		}
+		return idempotentPromoteToStageResponse(existing, stage, freight, idempotencyKey)
 	}
 	s.recordPromotionCreatedEvent(ctx, &promotion, freight)
 	return connect.NewResponse(&svcv1alpha1.PromoteToStageResponse{
@@ -128,6 +191,87 @@ func (s *server) PromoteToStage(
 	}), nil
 }
 
+// idempotentPromoteToStageResponse returns a response containing the provided
+// Promotion, which was previously created using the provided idempotency key,
+// if that Promotion is of the same Freight to the same Stage. Otherwise, an
+// error is returned.
+func idempotentPromoteToStageResponse(
+	existing *kargoapi.Promotion,
+	stage *kargoapi.Stage,
+	freight *kargoapi.Freight,
+	idempotencyKey string,
+) (*connect.Response[svcv1alpha1.PromoteToStageResponse], error) {
+	if existing.Spec.Stage != stage.Name || existing.Spec.Freight != freight.Name {
+		return nil, connect.NewError(
+			connect.CodeAlreadyExists,
+			fmt.Errorf(
+				"idempotency key %q was already used to promote Freight %q to Stage %q",
+				idempotencyKey,
+				existing.Spec.Freight,
+				existing.Spec.Stage,
+			),
+		)
+	}
+	return connect.NewResponse(&svcv1alpha1.PromoteToStageResponse{
+		Promotion: existing,
+	}), nil
+}
+
+// getPromotionByIdempotencyKey returns the Promotion in the specified project
+// that was created by a request specifying the provided idempotency key. If no
+// such Promotion exists, nil is returned.
+func (s *server) getPromotionByIdempotencyKey(
+	ctx context.Context,
+	project string,
+	idempotencyKey string,
+) (*kargoapi.Promotion, error) {
+	list := kargoapi.PromotionList{}
+	if err := s.listPromotionsFn(
+		ctx,
+		&list,
+		client.InNamespace(project),
+		client.MatchingLabels{
+			kargoapi.IdempotencyKeyLabelKey: idempotencyKeyLabelValue(idempotencyKey),
+		},
+	); err != nil {
+		return nil, err
+	}
+	for _, promo := range list.Items {
+		// Guard against the unlikely event of a hash collision.
+		if promo.Annotations[kargoapi.AnnotationKeyIdempotencyKey] == idempotencyKey {
+			return &promo, nil
+		}
+	}
+	return nil, nil
+}
+
+// idempotencyKeyLabelValue returns a hash of the provided idempotency key that
+// is suitable for use as a label value, which, unlike the key itself, is
+// limited in length and in the characters it may contain.
+func idempotencyKeyLabelValue(idempotencyKey string) string {
+	return fmt.Sprintf("%x", sha1.Sum([]byte(idempotencyKey)))
+}
+
+// idempotentPromotionName returns a name for a Promotion of any Freight to the
+// specified Stage that is derived from the provided idempotency key, so that
+// retried requests using the same key always map to the same Promotion. The
+// Freight is deliberately not part of the name, so that reusing a key to
+// promote different Freight is detected as a conflict.
+func idempotentPromotionName(project, stage, idempotencyKey string) string {
+	// A 40 character hash and a separator leave 212 of the 253 characters
+	// permitted in a resource name for the Stage name.
+	const maxStagePrefixLength = 212
+	shortStageName := stage
+	if len(shortStageName) > maxStagePrefixLength {
+		shortStageName = shortStageName[:maxStagePrefixLength]
+	}
+	return fmt.Sprintf(
+		"%s.%x",
+		shortStageName,
+		sha1.Sum([]byte(project+"/"+stage+"/"+idempotencyKey)),
+	)
+}
+
 func (s *server) recordPromotionCreatedEvent(
 	ctx context.Context,
 	p *kargoapi.Promotion,
@@ -3,14 +3,19 @@ package api
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 
 	"connectrpc.com/connect"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	fakeevent "github.com/akuity/kargo/internal/kubernetes/event/fake"
@@ -403,6 +408,578 @@ func TestPromoteToStage(t *testing.T) {
 				require.Equal(t, "create promotion: something went wrong", err.Error())
 			},
 		},
+		{
+			name: "manual promotions rejected by maintenance mode",
+			req: &svcv1alpha1.PromoteToStageRequest{
+				Project: "fake-project",
+				Stage:   "fake-stage",
+				Freight: "fake-freight",
+			},
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getProjectFn: func(
+					context.Context,
+					client.Client,
+					string,
+				) (*kargoapi.Project, error) {
+					return &kargoapi.Project{
+						Spec: &kargoapi.ProjectSpec{
+							MaintenanceMode: &kargoapi.MaintenanceMode{
+								Enabled:                true,
+								RejectManualPromotions: true,
+								Message:                "incident in progress",
+							},
+						},
+					}, nil
+				},
+				getStageFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Stage, error) {
+					return &kargoapi.Stage{}, nil
+				},
+				getFreightByNameOrAliasFn: func(
+					context.Context,
+					client.Client,
+					string, string, string,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{}, nil
+				},
+				isFreightAvailableFn: func(*kargoapi.Freight, string, []string) bool {
+					return true
+				},
+				authorizeFn: func(
+					context.Context,
+					string,
+					schema.GroupVersionResource,
+					string,
+					client.ObjectKey,
+				) error {
+					return nil
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				_ *fakeevent.EventRecorder,
+				_ *connect.Response[svcv1alpha1.PromoteToStageResponse],
+				err error,
+			) {
+				require.Error(t, err)
+				var connErr *connect.Error
+				require.True(t, errors.As(err, &connErr))
+				require.Equal(t, connect.CodeFailedPrecondition, connErr.Code())
+				require.Contains(t, connErr.Message(), "maintenance mode")
+				require.Contains(t, connErr.Message(), "incident in progress")
+			},
+		},
+		{
+			name: "existing Promotion with idempotency key",
+			req: &svcv1alpha1.PromoteToStageRequest{
+				Project:        "fake-project",
+				Stage:          "fake-stage",
+				Freight:        "fake-freight",
+				IdempotencyKey: "fake-key",
+			},
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getStageFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Stage, error) {
+					return &kargoapi.Stage{
+						ObjectMeta: metav1.ObjectMeta{Name: "fake-stage"},
+					}, nil
+				},
+				getFreightByNameOrAliasFn: func(
+					context.Context,
+					client.Client,
+					string, string, string,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{
+						ObjectMeta: metav1.ObjectMeta{Name: "fake-freight"},
+					}, nil
+				},
+				isFreightAvailableFn: func(*kargoapi.Freight, string, []string) bool {
+					return true
+				},
+				authorizeFn: func(
+					context.Context,
+					string,
+					schema.GroupVersionResource,
+					string,
+					client.ObjectKey,
+				) error {
+					return nil
+				},
+				listPromotionsFn: func(
+					_ context.Context,
+					objList client.ObjectList,
+					_ ...client.ListOption,
+				) error {
+					objList.(*kargoapi.PromotionList).Items = []kargoapi.Promotion{{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "existing-promotion",
+							Annotations: map[string]string{
+								kargoapi.AnnotationKeyIdempotencyKey: "fake-key",
+							},
+						},
+						Spec: kargoapi.PromotionSpec{
+							Stage:   "fake-stage",
+							Freight: "fake-freight",
+						},
+					}}
+					return nil
+				},
+				createPromotionFn: func(
+					context.Context,
+					client.Object,
+					...client.CreateOption,
+				) error {
+					return errors.New("unexpected creation of Promotion")
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				recorder *fakeevent.EventRecorder,
+				res *connect.Response[svcv1alpha1.PromoteToStageResponse],
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, "existing-promotion", res.Msg.GetPromotion().Name)
+				require.Empty(t, recorder.Events)
+			},
+		},
+		{
+			name: "idempotency key used for another Promotion",
+			req: &svcv1alpha1.PromoteToStageRequest{
+				Project:        "fake-project",
+				Stage:          "fake-stage",
+				Freight:        "fake-freight",
+				IdempotencyKey: "fake-key",
+			},
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getStageFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Stage, error) {
+					return &kargoapi.Stage{
+						ObjectMeta: metav1.ObjectMeta{Name: "fake-stage"},
+					}, nil
+				},
+				getFreightByNameOrAliasFn: func(
+					context.Context,
+					client.Client,
+					string, string, string,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{
+						ObjectMeta: metav1.ObjectMeta{Name: "fake-freight"},
+					}, nil
+				},
+				isFreightAvailableFn: func(*kargoapi.Freight, string, []string) bool {
+					return true
+				},
+				authorizeFn: func(
+					context.Context,
+					string,
+					schema.GroupVersionResource,
+					string,
+					client.ObjectKey,
+				) error {
+					return nil
+				},
+				listPromotionsFn: func(
+					_ context.Context,
+					objList client.ObjectList,
+					_ ...client.ListOption,
+				) error {
+					objList.(*kargoapi.PromotionList).Items = []kargoapi.Promotion{{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "existing-promotion",
+							Annotations: map[string]string{
+								kargoapi.AnnotationKeyIdempotencyKey: "fake-key",
+							},
+						},
+						Spec: kargoapi.PromotionSpec{
+							Stage:   "other-stage",
+							Freight: "fake-freight",
+						},
+					}}
+					return nil
+				},
+				createPromotionFn: func(
+					context.Context,
+					client.Object,
+					...client.CreateOption,
+				) error {
+					return errors.New("unexpected creation of Promotion")
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				_ *fakeevent.EventRecorder,
+				_ *connect.Response[svcv1alpha1.PromoteToStageResponse],
+				err error,
+			) {
+				require.Error(t, err)
+				var connErr *connect.Error
+				require.True(t, errors.As(err, &connErr))
+				require.Equal(t, connect.CodeAlreadyExists, connErr.Code())
+				require.Contains(t, connErr.Message(), `idempotency key "fake-key" was already used`)
+			},
+		},
+		{
+			name: "success with idempotency key",
+			req: &svcv1alpha1.PromoteToStageRequest{
+				Project:        "fake-project",
+				Stage:          "fake-stage",
+				Freight:        "fake-freight",
+				IdempotencyKey: "fake-key",
+			},
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getStageFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Stage, error) {
+					return &kargoapi.Stage{
+						ObjectMeta: metav1.ObjectMeta{Name: "fake-stage"},
+					}, nil
+				},
+				getFreightByNameOrAliasFn: func(
+					context.Context,
+					client.Client,
+					string, string, string,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{}, nil
+				},
+				isFreightAvailableFn: func(*kargoapi.Freight, string, []string) bool {
+					return true
+				},
+				authorizeFn: func(
+					context.Context,
+					string,
+					schema.GroupVersionResource,
+					string,
+					client.ObjectKey,
+				) error {
+					return nil
+				},
+				listPromotionsFn: func(
+					context.Context,
+					client.ObjectList,
+					...client.ListOption,
+				) error {
+					return nil
+				},
+				createPromotionFn: func(
+					context.Context,
+					client.Object,
+					...client.CreateOption,
+				) error {
+					return nil
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				_ *fakeevent.EventRecorder,
+				res *connect.Response[svcv1alpha1.PromoteToStageResponse],
+				err error,
+			) {
+				require.NoError(t, err)
+				promo := res.Msg.GetPromotion()
+				require.Equal(
+					t,
+					idempotentPromotionName("fake-project", "fake-stage", "fake-key"),
+					promo.Name,
+				)
+				require.Equal(
+					t,
+					"fake-key",
+					promo.Annotations[kargoapi.AnnotationKeyIdempotencyKey],
+				)
+				require.Equal(
+					t,
+					idempotencyKeyLabelValue("fake-key"),
+					promo.Labels[kargoapi.IdempotencyKeyLabelKey],
+				)
+			},
+		},
+		{
+			name: "Promotion with idempotency key created concurrently",
+			req: &svcv1alpha1.PromoteToStageRequest{
+				Project:        "fake-project",
+				Stage:          "fake-stage",
+				Freight:        "fake-freight",
+				IdempotencyKey: "fake-key",
+			},
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getStageFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Stage, error) {
+					return &kargoapi.Stage{
+						ObjectMeta: metav1.ObjectMeta{Name: "fake-stage"},
+					}, nil
+				},
+				getFreightByNameOrAliasFn: func(
+					context.Context,
+					client.Client,
+					string, string, string,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{
+						ObjectMeta: metav1.ObjectMeta{Name: "fake-freight"},
+					}, nil
+				},
+				isFreightAvailableFn: func(*kargoapi.Freight, string, []string) bool {
+					return true
+				},
+				authorizeFn: func(
+					context.Context,
+					string,
+					schema.GroupVersionResource,
+					string,
+					client.ObjectKey,
+				) error {
+					return nil
+				},
+				listPromotionsFn: func(
+					context.Context,
+					client.ObjectList,
+					...client.ListOption,
+				) error {
+					return nil
+				},
+				createPromotionFn: func(
+					_ context.Context,
+					obj client.Object,
+					_ ...client.CreateOption,
+				) error {
+					return apierrors.NewAlreadyExists(
+						kargoapi.GroupVersion.WithResource("promotions").GroupResource(),
+						obj.GetName(),
+					)
+				},
+				getPromotionFn: func(
+					_ context.Context,
+					_ client.Client,
+					key types.NamespacedName,
+				) (*kargoapi.Promotion, error) {
+					return &kargoapi.Promotion{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: key.Namespace,
+							Name:      key.Name,
+							Annotations: map[string]string{
+								kargoapi.AnnotationKeyIdempotencyKey: "fake-key",
+							},
+						},
+						Spec: kargoapi.PromotionSpec{
+							Stage:   "fake-stage",
+							Freight: "fake-freight",
+						},
+					}, nil
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				recorder *fakeevent.EventRecorder,
+				res *connect.Response[svcv1alpha1.PromoteToStageResponse],
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					idempotentPromotionName("fake-project", "fake-stage", "fake-key"),
+					res.Msg.GetPromotion().Name,
+				)
+				require.Empty(t, recorder.Events)
+			},
+		},
+		{
+			name: "Promotion with idempotency key created concurrently for other Freight",
+			req: &svcv1alpha1.PromoteToStageRequest{
+				Project:        "fake-project",
+				Stage:          "fake-stage",
+				Freight:        "fake-freight",
+				IdempotencyKey: "fake-key",
+			},
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getStageFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Stage, error) {
+					return &kargoapi.Stage{
+						ObjectMeta: metav1.ObjectMeta{Name: "fake-stage"},
+					}, nil
+				},
+				getFreightByNameOrAliasFn: func(
+					context.Context,
+					client.Client,
+					string, string, string,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{
+						ObjectMeta: metav1.ObjectMeta{Name: "fake-freight"},
+					}, nil
+				},
+				isFreightAvailableFn: func(*kargoapi.Freight, string, []string) bool {
+					return true
+				},
+				authorizeFn: func(
+					context.Context,
+					string,
+					schema.GroupVersionResource,
+					string,
+					client.ObjectKey,
+				) error {
+					return nil
+				},
+				listPromotionsFn: func(
+					context.Context,
+					client.ObjectList,
+					...client.ListOption,
+				) error {
+					return nil
+				},
+				createPromotionFn: func(
+					_ context.Context,
+					obj client.Object,
+					_ ...client.CreateOption,
+				) error {
+					return apierrors.NewAlreadyExists(
+						kargoapi.GroupVersion.WithResource("promotions").GroupResource(),
+						obj.GetName(),
+					)
+				},
+				getPromotionFn: func(
+					_ context.Context,
+					_ client.Client,
+					key types.NamespacedName,
+				) (*kargoapi.Promotion, error) {
+					return &kargoapi.Promotion{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: key.Namespace,
+							Name:      key.Name,
+							Annotations: map[string]string{
+								kargoapi.AnnotationKeyIdempotencyKey: "fake-key",
+							},
+						},
+						Spec: kargoapi.PromotionSpec{
+							Stage:   "fake-stage",
+							Freight: "other-freight",
+						},
+					}, nil
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				_ *fakeevent.EventRecorder,
+				_ *connect.Response[svcv1alpha1.PromoteToStageResponse],
+				err error,
+			) {
+				require.Error(t, err)
+				var connErr *connect.Error
+				require.True(t, errors.As(err, &connErr))
+				require.Equal(t, connect.CodeAlreadyExists, connErr.Code())
+				require.Contains(t, connErr.Message(), `idempotency key "fake-key" was already used`)
+			},
+		},
+		{
+			name: "error getting concurrently created Promotion",
+			req: &svcv1alpha1.PromoteToStageRequest{
+				Project:        "fake-project",
+				Stage:          "fake-stage",
+				Freight:        "fake-freight",
+				IdempotencyKey: "fake-key",
+			},
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getStageFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Stage, error) {
+					return &kargoapi.Stage{
+						ObjectMeta: metav1.ObjectMeta{Name: "fake-stage"},
+					}, nil
+				},
+				getFreightByNameOrAliasFn: func(
+					context.Context,
+					client.Client,
+					string, string, string,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{
+						ObjectMeta: metav1.ObjectMeta{Name: "fake-freight"},
+					}, nil
+				},
+				isFreightAvailableFn: func(*kargoapi.Freight, string, []string) bool {
+					return true
+				},
+				authorizeFn: func(
+					context.Context,
+					string,
+					schema.GroupVersionResource,
+					string,
+					client.ObjectKey,
+				) error {
+					return nil
+				},
+				listPromotionsFn: func(
+					context.Context,
+					client.ObjectList,
+					...client.ListOption,
+				) error {
+					return nil
+				},
+				createPromotionFn: func(
+					_ context.Context,
+					obj client.Object,
+					_ ...client.CreateOption,
+				) error {
+					return apierrors.NewAlreadyExists(
+						kargoapi.GroupVersion.WithResource("promotions").GroupResource(),
+						obj.GetName(),
+					)
+				},
+				getPromotionFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Promotion, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				_ *fakeevent.EventRecorder,
+				_ *connect.Response[svcv1alpha1.PromoteToStageResponse],
+				err error,
+			) {
+				require.ErrorContains(t, err, "get promotion")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
 		{
 			name: "success",
 			req: &svcv1alpha1.PromoteToStageRequest{
@@ -478,6 +1055,15 @@ func TestPromoteToStage(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			recorder := fakeevent.NewEventRecorder(1)
 			testCase.server.recorder = recorder
+			if testCase.server.getProjectFn == nil {
+				testCase.server.getProjectFn = func(
+					context.Context,
+					client.Client,
+					string,
+				) (*kargoapi.Project, error) {
+					return &kargoapi.Project{}, nil
+				}
+			}
 			res, err := testCase.server.PromoteToStage(
 				context.Background(),
 				connect.NewRequest(testCase.req),
@@ -486,3 +1072,104 @@ func TestPromoteToStage(t *testing.T) {
 		})
 	}
 }
+
+func TestPromoteToStageConcurrentRetries(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kargoapi.AddToScheme(scheme))
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	s := &server{
+		validateProjectExistsFn: func(context.Context, string) error {
+			return nil
+		},
+		getProjectFn: func(
+			context.Context,
+			client.Client,
+			string,
+		) (*kargoapi.Project, error) {
+			return &kargoapi.Project{}, nil
+		},
+		getStageFn: func(
+			context.Context,
+			client.Client,
+			types.NamespacedName,
+		) (*kargoapi.Stage, error) {
+			return &kargoapi.Stage{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "fake-project",
+					Name:      "fake-stage",
+				},
+			}, nil
+		},
+		getFreightByNameOrAliasFn: func(
+			context.Context,
+			client.Client,
+			string, string, string,
+		) (*kargoapi.Freight, error) {
+			return &kargoapi.Freight{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "fake-project",
+					Name:      "fake-freight",
+				},
+			}, nil
+		},
+		isFreightAvailableFn: func(*kargoapi.Freight, string, []string) bool {
+			return true
+		},
+		authorizeFn: func(
+			context.Context,
+			string,
+			schema.GroupVersionResource,
+			string,
+			client.ObjectKey,
+		) error {
+			return nil
+		},
+		getPromotionFn: func(
+			ctx context.Context,
+			_ client.Client,
+			key types.NamespacedName,
+		) (*kargoapi.Promotion, error) {
+			return kargoapi.GetPromotion(ctx, c, key)
+		},
+		createPromotionFn: c.Create,
+		listPromotionsFn:  c.List,
+	}
+
+	const retries = 10
+	s.recorder = fakeevent.NewEventRecorder(retries)
+	names := make([]string, retries)
+	errs := make([]error, retries)
+	var wg sync.WaitGroup
+	for i := 0; i < retries; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			res, err := s.PromoteToStage(
+				context.Background(),
+				connect.NewRequest(&svcv1alpha1.PromoteToStageRequest{
+					Project:        "fake-project",
+					Stage:          "fake-stage",
+					Freight:        "fake-freight",
+					IdempotencyKey: "fake-key",
+				}),
+			)
+			if err == nil {
+				names[i] = res.Msg.GetPromotion().Name
+			}
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+
+	expectedName := idempotentPromotionName("fake-project", "fake-stage", "fake-key")
+	for i := 0; i < retries; i++ {
+		require.NoError(t, errs[i])
+		require.Equal(t, expectedName, names[i])
+	}
+
+	promos := kargoapi.PromotionList{}
+	require.NoError(t, c.List(context.Background(), &promos, client.InNamespace("fake-project")))
+	require.Len(t, promos.Items, 1)
+	require.Equal(t, expectedName, promos.Items[0].Name)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path"
 	"sort"
+	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/Masterminds/semver/v3"
@@ -98,6 +99,14 @@ func (s *server) QueryFreight(
 		freight = freightList.Items
 	}
 
+	freight = filterFreight(
+		freight,
+		req.Msg.GetOrigin(),
+		req.Msg.GetVerifiedIn(),
+		req.Msg.GetApprovedFor(),
+		req.Msg.GetArtifact(),
+	)
+
 	// Split the Freight into groups
 	var freightGroups map[string]*svcv1alpha1.FreightList
 	switch groupBy {
@@ -131,7 +140,11 @@ func (s *server) getAvailableFreightForStage(
 	subs kargoapi.Subscriptions,
 ) ([]kargoapi.Freight, error) {
 	if subs.Warehouse != "" {
-		return s.getFreightFromWarehouseFn(ctx, project, subs.Warehouse)
+		freight, err := s.getFreightFromWarehouseFn(ctx, project, subs.Warehouse)
+		if err != nil {
+			return nil, err
+		}
+		return kargoapi.FilterFreightByService(freight, subs.Service), nil
 	}
 	verifiedFreight, err := s.getVerifiedFreightFn(
 		ctx,
@@ -262,6 +275,68 @@ func (s *server) getVerifiedFreight(
 	return verifiedFreightList, nil
 }
 
+// filterFreight returns those of the provided Freight that originate from the
+// specified Warehouse, have been verified in the specified Stage, have been
+// approved for the specified Stage, and reference an artifact matching the
+// specified substring. Criteria that are left unspecified are not applied.
+func filterFreight(
+	freight []kargoapi.Freight,
+	origin string,
+	verifiedIn string,
+	approvedFor string,
+	artifact string,
+) []kargoapi.Freight {
+	if origin == "" && verifiedIn == "" && approvedFor == "" && artifact == "" {
+		return freight
+	}
+	filtered := make([]kargoapi.Freight, 0, len(freight))
+	for _, f := range freight {
+		if origin != "" && f.Warehouse != origin {
+			continue
+		}
+		if verifiedIn != "" {
+			if _, ok := f.Status.VerifiedIn[verifiedIn]; !ok {
+				continue
+			}
+		}
+		if approvedFor != "" {
+			if _, ok := f.Status.ApprovedFor[approvedFor]; !ok {
+				continue
+			}
+		}
+		if artifact != "" && !freightReferencesArtifact(f, artifact) {
+			continue
+		}
+		filtered = append(filtered, f)
+	}
+	return filtered
+}
+
+// freightReferencesArtifact returns true if the provided Freight references
+// any image, commit, chart, or package whose repository URL, name, tag,
+// digest, commit ID, or version contains the provided substring.
+func freightReferencesArtifact(freight kargoapi.Freight, substr string) bool {
+	var fields []string
+	for _, i := range freight.Images {
+		fields = append(fields, i.RepoURL, i.Tag, i.Digest)
+	}
+	for _, c := range freight.Commits {
+		fields = append(fields, c.RepoURL, c.ID, c.Tag)
+	}
+	for _, c := range freight.Charts {
+		fields = append(fields, c.RepoURL, c.Name, c.Version)
+	}
+	for _, p := range freight.Packages {
+		fields = append(fields, p.RepoURL, p.Name, p.Version)
+	}
+	for _, field := range fields {
+		if strings.Contains(field, substr) {
+			return true
+		}
+	}
+	return false
+}
+
 func groupByImageRepo(
 	freight []kargoapi.Freight,
 	group string,
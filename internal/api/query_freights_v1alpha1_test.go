@@ -416,6 +416,40 @@ func TestGetAvailableFreightForStage(t *testing.T) {
 				require.Len(t, freight, 2)
 			},
 		},
+		{
+			name: "success getting Freight for service from Warehouse",
+			subs: kargoapi.Subscriptions{
+				Warehouse: "fake-warehouse",
+				Service:   "fake-service",
+			},
+			server: &server{
+				getFreightFromWarehouseFn: func(
+					context.Context,
+					string,
+					string,
+				) ([]kargoapi.Freight, error) {
+					return []kargoapi.Freight{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "fake-freight",
+							},
+							Service: "fake-service",
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "another-fake-freight",
+							},
+							Service: "another-fake-service",
+						},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, freight []kargoapi.Freight, err error) {
+				require.NoError(t, err)
+				require.Len(t, freight, 1)
+				require.Equal(t, "fake-freight", freight[0].Name)
+			},
+		},
 		{
 			name: "error getting Freight verified in upstream Stages",
 			subs: kargoapi.Subscriptions{
@@ -668,6 +702,116 @@ func TestGetVerifiedFreight(t *testing.T) {
 	}
 }
 
+func TestFilterFreight(t *testing.T) {
+	testFreight := []kargoapi.Freight{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "a"},
+			Warehouse:  "fake-warehouse",
+			Images: []kargoapi.Image{{
+				RepoURL: "fake-repo-url",
+				Tag:     "1.42.0",
+			}},
+			Status: kargoapi.FreightStatus{
+				VerifiedIn: map[string]kargoapi.VerifiedStage{"uat": {}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "b"},
+			Warehouse:  "fake-warehouse",
+			Charts: []kargoapi.Chart{{
+				RepoURL: "fake-chart-repo-url",
+				Name:    "fake-chart",
+				Version: "1.43.0",
+			}},
+			Status: kargoapi.FreightStatus{
+				ApprovedFor: map[string]kargoapi.ApprovedStage{"prod": {}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "c"},
+			Warehouse:  "another-fake-warehouse",
+			Commits: []kargoapi.GitCommit{{
+				RepoURL: "fake-git-repo-url",
+				ID:      "abc1234",
+			}},
+			Status: kargoapi.FreightStatus{
+				VerifiedIn: map[string]kargoapi.VerifiedStage{"uat": {}},
+			},
+		},
+	}
+	testCases := []struct {
+		name        string
+		origin      string
+		verifiedIn  string
+		approvedFor string
+		artifact    string
+		expected    []string
+	}{
+		{
+			name:     "no filters",
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "by origin",
+			origin:   "fake-warehouse",
+			expected: []string{"a", "b"},
+		},
+		{
+			name:       "by verified in",
+			verifiedIn: "uat",
+			expected:   []string{"a", "c"},
+		},
+		{
+			name:        "by approved for",
+			approvedFor: "prod",
+			expected:    []string{"b"},
+		},
+		{
+			name:     "by image tag",
+			artifact: "1.42.",
+			expected: []string{"a"},
+		},
+		{
+			name:     "by chart name",
+			artifact: "fake-chart",
+			expected: []string{"b"},
+		},
+		{
+			name:     "by commit ID",
+			artifact: "abc",
+			expected: []string{"c"},
+		},
+		{
+			name:       "by multiple criteria",
+			origin:     "fake-warehouse",
+			verifiedIn: "uat",
+			artifact:   "1.",
+			expected:   []string{"a"},
+		},
+		{
+			name:     "no matches",
+			artifact: "2.0.",
+			expected: []string{},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			filtered := filterFreight(
+				testFreight,
+				testCase.origin,
+				testCase.verifiedIn,
+				testCase.approvedFor,
+				testCase.artifact,
+			)
+			names := make([]string, len(filtered))
+			for i, f := range filtered {
+				names[i] = f.Name
+			}
+			require.Equal(t, testCase.expected, names)
+		})
+	}
+}
+
 func TestGroupByImageRepo(t *testing.T) {
 	testFreight := []kargoapi.Freight{
 		{Images: []kargoapi.Image{{RepoURL: "fake-repo-url"}}},
@@ -154,11 +154,12 @@ func buildRule(
 // nolint: goconst
 func validateResourceTypeName(resource string) error {
 	switch resource {
-	case "analysisruns", "analysistemplates", "events", "freights", "freights/status", "roles",
-		"rolebindings", "promotions", "secrets", "serviceaccounts", "stages", "warehouses":
+	case "analysisruns", "analysistemplates", "events", "freights", "freights/status", "projectroles",
+		"roles", "rolebindings", "promotionplans", "promotions", "secrets", "serviceaccounts", "stages",
+		"warehouses":
 		return nil
-	case "analysisrun", "analysistemplate", "event", "freight", "role",
-		"rolebinding", "promotion", "secret", "serviceaccount", "stage", "warehouse":
+	case "analysisrun", "analysistemplate", "event", "freight", "projectrole", "role",
+		"rolebinding", "promotion", "promotionplan", "secret", "serviceaccount", "stage", "warehouse":
 		return kubeerr.NewBadRequest(
 			fmt.Sprintf(`unrecognized resource type %q; did you mean "%ss"?`, resource, resource),
 		)
@@ -179,7 +180,8 @@ func getGroupName(resourceType string) string {
 		return ""
 	case "rolebindings", "roles":
 		return rbacv1.SchemeGroupVersion.Group
-	case "freights", "freights/status", "promotions", "stages", "warehouses":
+	case "freights", "freights/status", "projectroles", "promotionplans", "promotions", "stages",
+		"warehouses":
 		return kargoapi.GroupVersion.Group
 	case "analysisruns", "analysistemplates":
 		return rolloutsapi.GroupVersion.Group
@@ -819,6 +819,16 @@ func isKargoManaged(obj metav1.Object) bool {
 	return obj.GetAnnotations()[rbacapi.AnnotationKeyManaged] == rbacapi.AnnotationValueTrue
 }
 
+// projectRoleOwner returns the name of the ProjectRole that controls the
+// provided object, if any. Otherwise, it returns an empty string.
+func projectRoleOwner(obj metav1.Object) string {
+	if owner := metav1.GetControllerOf(obj); owner != nil &&
+		owner.APIVersion == kargoapi.GroupVersion.String() && owner.Kind == "ProjectRole" {
+		return owner.Name
+	}
+	return ""
+}
+
 func manageableResources(
 	sa corev1.ServiceAccount,
 	roles []rbacv1.Role,
@@ -832,6 +842,15 @@ func manageableResources(
 			),
 		)
 	}
+	if owner := projectRoleOwner(&sa); owner != "" {
+		return nil, nil, kubeerr.NewBadRequest(
+			fmt.Sprintf(
+				"ServiceAccount %q in namespace %q is managed by ProjectRole %q and "+
+					"can only be modified by modifying the ProjectRole",
+				sa.Name, sa.Namespace, owner,
+			),
+		)
+	}
 	if len(roles) > 1 {
 		return nil, nil, kubeerr.NewBadRequest(
 			fmt.Sprintf(
@@ -11,6 +11,7 @@ import (
 	kubeerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -1077,6 +1078,19 @@ func TestManageableResources(t *testing.T) {
 		require.True(t, kubeerr.IsBadRequest(err))
 	})
 
+	t.Run("ServiceAccount is managed by a ProjectRole", func(t *testing.T) {
+		sa := managedServiceAccount(nil)
+		sa.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: kargoapi.GroupVersion.String(),
+			Kind:       "ProjectRole",
+			Name:       testKargoRoleName,
+			Controller: ptr.To(true),
+		}}
+		_, _, err := manageableResources(*sa, nil, nil)
+		require.True(t, kubeerr.IsBadRequest(err))
+		require.ErrorContains(t, err, "managed by ProjectRole")
+	})
+
 	t.Run("multiple Roles", func(t *testing.T) {
 		_, _, err := manageableResources(
 			*managedServiceAccount(nil),
@@ -5,6 +5,7 @@ import (
 	"embed"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"net"
 	"net/http"
@@ -21,6 +22,7 @@ import (
 	"golang.org/x/net/http2/h2c"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -32,6 +34,10 @@ import (
 	"github.com/akuity/kargo/internal/api/rbac"
 	"github.com/akuity/kargo/internal/api/validation"
 	rollouts "github.com/akuity/kargo/internal/controller/rollouts/api/v1alpha1"
+	libCreds "github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/credentials/kms"
+	"github.com/akuity/kargo/internal/discovery"
+	"github.com/akuity/kargo/internal/gitwebhook"
 	httputil "github.com/akuity/kargo/internal/http"
 	"github.com/akuity/kargo/internal/logging"
 	"github.com/akuity/kargo/pkg/api/service/v1alpha1/svcv1alpha1connect"
@@ -50,6 +56,9 @@ type server struct {
 	internalClient client.Client
 	rolesDB        rbac.RolesDatabase
 	recorder       record.EventRecorder
+	// credentialsKMS is the key management service used to envelope-encrypt
+	// credentials Secrets. It is nil if credentials are stored unencrypted.
+	credentialsKMS kms.Service
 
 	// The following behaviors are overridable for testing purposes:
 
@@ -66,11 +75,21 @@ type server struct {
 	) error
 
 	// Common lookups:
+	getProjectFn func(
+		context.Context,
+		client.Client,
+		string,
+	) (*kargoapi.Project, error)
 	getStageFn func(
 		context.Context,
 		client.Client,
 		types.NamespacedName,
 	) (*kargoapi.Stage, error)
+	getPromotionFn func(
+		context.Context,
+		client.Client,
+		types.NamespacedName,
+	) (*kargoapi.Promotion, error)
 	getFreightByNameOrAliasFn func(
 		ctx context.Context,
 		c client.Client,
@@ -91,6 +110,20 @@ type server struct {
 		...client.CreateOption,
 	) error
 
+	// Idempotent Promotions:
+	listPromotionsFn func(
+		context.Context,
+		client.ObjectList,
+		...client.ListOption,
+	) error
+
+	// Promotion plans:
+	createPromotionPlanFn func(
+		context.Context,
+		client.Object,
+		...client.CreateOption,
+	) error
+
 	// Promote subscribers:
 	findStageSubscribersFn func(ctx context.Context, stage *kargoapi.Stage) ([]kargoapi.Stage, error)
 
@@ -131,6 +164,29 @@ type server struct {
 		newStatus kargoapi.FreightStatus,
 	) error
 
+	// Freight blocking:
+	listStagesFn func(
+		context.Context,
+		client.ObjectList,
+		...client.ListOption,
+	) error
+
+	// Warehouse preview:
+	discoverArtifactsFn func(
+		context.Context,
+		*kargoapi.Warehouse,
+	) (*kargoapi.DiscoveredArtifacts, error)
+
+	// Warehouse validation:
+	dryRunWarehouseFn func(
+		context.Context,
+		*kargoapi.Warehouse,
+	) error
+	checkWarehouseConnectivityFn func(
+		context.Context,
+		*kargoapi.Warehouse,
+	) []string
+
 	// Rollouts integration:
 	getAnalysisTemplateFn func(
 		context.Context,
@@ -144,6 +200,19 @@ type server struct {
 		types.NamespacedName,
 	) (*rollouts.AnalysisRun, error)
 
+	listPodsFn func(
+		context.Context,
+		client.ObjectList,
+		...client.ListOption,
+	) error
+
+	getPodLogsFn func(
+		ctx context.Context,
+		namespace string,
+		pod string,
+		container string,
+	) (io.ReadCloser, error)
+
 	// Special authorizations:
 	authorizeFn func(
 		ctx context.Context,
@@ -162,8 +231,10 @@ func NewServer(
 	cfg config.ServerConfig,
 	kubeClient kubernetes.Client,
 	internalClient client.Client,
+	podsClient corev1client.PodsGetter,
 	rolesDB rbac.RolesDatabase,
 	recorder record.EventRecorder,
+	credentialsCfg libCreds.KubernetesDatabaseConfig,
 ) Server {
 	s := &server{
 		cfg:            cfg,
@@ -175,10 +246,14 @@ func NewServer(
 
 	s.validateProjectExistsFn = s.validateProjectExists
 	s.externalValidateProjectFn = validation.ValidateProject
+	s.getProjectFn = kargoapi.GetProject
 	s.getStageFn = kargoapi.GetStage
+	s.getPromotionFn = kargoapi.GetPromotion
 	s.getFreightByNameOrAliasFn = kargoapi.GetFreightByNameOrAlias
 	s.isFreightAvailableFn = kargoapi.IsFreightAvailable
 	s.createPromotionFn = kubeClient.Create
+	s.listPromotionsFn = kubeClient.List
+	s.createPromotionPlanFn = kubeClient.Create
 	s.findStageSubscribersFn = s.findStageSubscribers
 	s.listFreightFn = kubeClient.List
 	s.getAvailableFreightForStageFn = s.getAvailableFreightForStage
@@ -186,9 +261,21 @@ func NewServer(
 	s.getVerifiedFreightFn = s.getVerifiedFreight
 	s.patchFreightAliasFn = s.patchFreightAlias
 	s.patchFreightStatusFn = s.patchFreightStatus
+	s.listStagesFn = kubeClient.List
 	s.authorizeFn = kubeClient.Authorize
 	s.getAnalysisTemplateFn = rollouts.GetAnalysisTemplate
 	s.getAnalysisRunFn = rollouts.GetAnalysisRun
+	s.listPodsFn = internalClient.List
+	s.getPodLogsFn = getPodLogsFn(podsClient)
+	s.credentialsKMS = credentialsCfg.KMS
+	discoverer := discovery.NewDiscoverer(
+		internalClient,
+		libCreds.NewKubernetesDatabase(internalClient, credentialsCfg),
+		discovery.Config{},
+	)
+	s.discoverArtifactsFn = discoverer.DiscoverArtifacts
+	s.dryRunWarehouseFn = s.dryRunWarehouse
+	s.checkWarehouseConnectivityFn = discoverer.CheckConnectivity
 
 	return s
 }
@@ -209,6 +296,19 @@ func (s *server) Serve(ctx context.Context, l net.Listener) error {
 		return fmt.Errorf("error initializing dashboard handler: %w", err)
 	}
 	mux.Handle("/", dashboardHandler)
+	if s.cfg.LiteUIEnabled {
+		liteUIHandler, err := newLiteUIRequestHandler()
+		if err != nil {
+			return fmt.Errorf("error initializing lite UI handler: %w", err)
+		}
+		mux.Handle(liteUIPath, liteUIHandler)
+	}
+	if s.cfg.GitWebhookReceiverEnabled {
+		// Requests from git providers are authenticated by the receiver itself
+		// using each Project's shared secret, so it acts using the API
+		// server's own permissions.
+		mux.Handle(gitwebhook.Path, gitwebhook.NewReceiver(s.internalClient))
+	}
 	if s.cfg.DexProxyConfig != nil {
 		dexProxyCfg := dex.ProxyConfigFromEnv()
 		dexProxy, err := dex.NewProxy(dexProxyCfg)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -13,6 +14,7 @@ import (
 	"github.com/akuity/kargo/internal/api/config"
 	"github.com/akuity/kargo/internal/api/kubernetes"
 	"github.com/akuity/kargo/internal/api/rbac"
+	libCreds "github.com/akuity/kargo/internal/credentials"
 	fakeevent "github.com/akuity/kargo/internal/kubernetes/event/fake"
 )
 
@@ -38,8 +40,10 @@ func TestNewServer(t *testing.T) {
 		testServerConfig,
 		testClient,
 		testClient,
+		k8sfake.NewSimpleClientset().CoreV1(),
 		rbac.NewKubernetesRolesDatabase(testClient),
 		testRecorder,
+		libCreds.KubernetesDatabaseConfig{},
 	).(*server)
 
 	require.True(t, ok)
@@ -52,9 +56,11 @@ func TestNewServer(t *testing.T) {
 	require.NotNil(t, s.validateProjectExistsFn)
 	require.NotNil(t, s.externalValidateProjectFn)
 	require.NotNil(t, s.getStageFn)
+	require.NotNil(t, s.getPromotionFn)
 	require.NotNil(t, s.getFreightByNameOrAliasFn)
 	require.NotNil(t, s.isFreightAvailableFn)
 	require.NotNil(t, s.createPromotionFn)
+	require.NotNil(t, s.createPromotionPlanFn)
 	require.NotNil(t, s.findStageSubscribersFn)
 	require.NotNil(t, s.listFreightFn)
 	require.NotNil(t, s.getAvailableFreightForStageFn)
@@ -64,4 +70,6 @@ func TestNewServer(t *testing.T) {
 	require.NotNil(t, s.patchFreightStatusFn)
 	require.NotNil(t, s.authorizeFn)
 	require.NotNil(t, s.getAnalysisRunFn)
+	require.NotNil(t, s.listPodsFn)
+	require.NotNil(t, s.getPodLogsFn)
 }
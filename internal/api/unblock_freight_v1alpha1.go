@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	corev1 "k8s.io/api/core/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/api/user"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+// UnblockFreight lifts a block previously placed on a piece of Freight,
+// making it available for promotion again.
+func (s *server) UnblockFreight(
+	ctx context.Context,
+	req *connect.Request[svcv1alpha1.UnblockFreightRequest],
+) (*connect.Response[svcv1alpha1.UnblockFreightResponse], error) {
+	project := req.Msg.GetProject()
+	if err := validateFieldNotEmpty("project", project); err != nil {
+		return nil, err
+	}
+
+	name := req.Msg.GetName()
+	alias := req.Msg.GetAlias()
+	if (name == "" && alias == "") ||
+		(name != "" && alias != "") {
+		return nil, connect.NewError(
+			connect.CodeInvalidArgument,
+			errors.New("exactly one of name or alias should not be empty"),
+		)
+	}
+
+	if err := s.validateProjectExistsFn(ctx, project); err != nil {
+		return nil, err
+	}
+
+	freight, err := s.getFreightByNameOrAliasFn(
+		ctx,
+		s.client,
+		project,
+		name,
+		alias,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get freight: %w", err)
+	}
+	if freight == nil {
+		if name != "" {
+			err = fmt.Errorf("freight %q not found in namespace %q", name, project)
+		} else {
+			err = fmt.Errorf("freight with alias %q not found in namespace %q", alias, project)
+		}
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+
+	if !freight.IsBlocked() {
+		return &connect.Response[svcv1alpha1.UnblockFreightResponse]{}, nil
+	}
+
+	newStatus := *freight.Status.DeepCopy()
+	newStatus.Blocked = nil
+	if err = s.patchFreightStatusFn(ctx, freight, newStatus); err != nil {
+		return nil, fmt.Errorf("patch status: %w", err)
+	}
+
+	var actor string
+	eventMsg := "Freight unblocked"
+	if u, ok := user.InfoFromContext(ctx); ok {
+		actor = kargoapi.FormatEventUserActor(u)
+		eventMsg += fmt.Sprintf(" by %q", actor)
+	}
+	s.recorder.AnnotatedEventf(
+		freight,
+		kargoapi.NewFreightBlockedEventAnnotations(actor, freight),
+		corev1.EventTypeNormal,
+		kargoapi.EventReasonFreightUnblocked,
+		eventMsg,
+	)
+	return &connect.Response[svcv1alpha1.UnblockFreightResponse]{}, nil
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	fakeevent "github.com/akuity/kargo/internal/kubernetes/event/fake"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func TestUnblockFreight(t *testing.T) {
+	testCases := []struct {
+		name       string
+		req        *svcv1alpha1.UnblockFreightRequest
+		server     *server
+		assertions func(*testing.T, *fakeevent.EventRecorder, error)
+	}{
+		{
+			name:   "input validation error",
+			req:    &svcv1alpha1.UnblockFreightRequest{},
+			server: &server{},
+			assertions: func(t *testing.T, _ *fakeevent.EventRecorder, err error) {
+				require.Error(t, err)
+				var connErr *connect.Error
+				require.True(t, errors.As(err, &connErr))
+				require.Equal(t, connect.CodeInvalidArgument, connErr.Code())
+			},
+		},
+		{
+			name: "Freight not found",
+			req: &svcv1alpha1.UnblockFreightRequest{
+				Project: "fake-project",
+				Alias:   "fake-alias",
+			},
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getFreightByNameOrAliasFn: func(
+					context.Context,
+					client.Client,
+					string, string, string,
+				) (*kargoapi.Freight, error) {
+					return nil, nil
+				},
+			},
+			assertions: func(t *testing.T, _ *fakeevent.EventRecorder, err error) {
+				require.Error(t, err)
+				var connErr *connect.Error
+				require.True(t, errors.As(err, &connErr))
+				require.Equal(t, connect.CodeNotFound, connErr.Code())
+			},
+		},
+		{
+			name: "Freight not blocked",
+			req: &svcv1alpha1.UnblockFreightRequest{
+				Project: "fake-project",
+				Name:    "fake-freight",
+			},
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getFreightByNameOrAliasFn: func(
+					context.Context,
+					client.Client,
+					string, string, string,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{}, nil
+				},
+			},
+			assertions: func(t *testing.T, recorder *fakeevent.EventRecorder, err error) {
+				require.NoError(t, err)
+				require.Empty(t, recorder.Events)
+			},
+		},
+		{
+			name: "success",
+			req: &svcv1alpha1.UnblockFreightRequest{
+				Project: "fake-project",
+				Name:    "fake-freight",
+			},
+			server: &server{
+				validateProjectExistsFn: func(context.Context, string) error {
+					return nil
+				},
+				getFreightByNameOrAliasFn: func(
+					context.Context,
+					client.Client,
+					string, string, string,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{
+						Status: kargoapi.FreightStatus{
+							Blocked: &kargoapi.FreightBlock{Reason: "fake-reason"},
+						},
+					}, nil
+				},
+				patchFreightStatusFn: func(
+					_ context.Context,
+					_ *kargoapi.Freight,
+					status kargoapi.FreightStatus,
+				) error {
+					if status.Blocked != nil {
+						return errors.New("unexpected status")
+					}
+					return nil
+				},
+			},
+			assertions: func(t *testing.T, recorder *fakeevent.EventRecorder, err error) {
+				require.NoError(t, err)
+				require.Len(t, recorder.Events, 1)
+				event := <-recorder.Events
+				require.Equal(t, corev1.EventTypeNormal, event.EventType)
+				require.Equal(t, kargoapi.EventReasonFreightUnblocked, event.Reason)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			recorder := fakeevent.NewEventRecorder(1)
+			testCase.server.recorder = recorder
+			_, err := testCase.server.UnblockFreight(
+				context.Background(),
+				connect.NewRequest(testCase.req),
+			)
+			testCase.assertions(t, recorder, err)
+		})
+	}
+}
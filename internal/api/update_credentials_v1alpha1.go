@@ -75,8 +75,21 @@ func (s *server) UpdateCredentials(
 		)
 	}
 
+	// Encrypted fields are decrypted before the update is applied and, if a
+	// key management service is configured, all are encrypted again using a
+	// new data encryption key.
+	if err := libCreds.DecryptSecret(ctx, s.credentialsKMS, &secret); err != nil {
+		return nil, fmt.Errorf("decrypt secret: %w", err)
+	}
+
 	applyCredentialsUpdateToSecret(&secret, credsUpdate)
 
+	if s.credentialsKMS != nil {
+		if err := libCreds.EncryptSecret(ctx, s.credentialsKMS, &secret); err != nil {
+			return nil, fmt.Errorf("encrypt secret: %w", err)
+		}
+	}
+
 	if err := s.client.Update(ctx, &secret); err != nil {
 		return nil, fmt.Errorf("update secret: %w", err)
 	}
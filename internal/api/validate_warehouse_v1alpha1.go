@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	kubeerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+// ValidateWarehouse validates a Warehouse without creating or modifying it.
+// The Warehouse may be an existing one, or a (possibly unsaved) Warehouse spec
+// may be provided. A provided spec is first subjected to a server-side dry run
+// of its creation or update, which fails if the spec is invalid. Then, the
+// credentials for every subscribed repository are resolved and each repository
+// is contacted. Repositories that cannot be reached are reported as warnings
+// rather than errors, as they may be only temporarily unavailable.
+func (s *server) ValidateWarehouse(
+	ctx context.Context,
+	req *connect.Request[svcv1alpha1.ValidateWarehouseRequest],
+) (*connect.Response[svcv1alpha1.ValidateWarehouseResponse], error) {
+	project := req.Msg.GetProject()
+	if err := validateFieldNotEmpty("project", project); err != nil {
+		return nil, err
+	}
+
+	name := req.Msg.GetName()
+	if err := validateFieldNotEmpty("name", name); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateProjectExistsFn(ctx, project); err != nil {
+		return nil, err
+	}
+
+	var warehouse *kargoapi.Warehouse
+	if spec := req.Msg.GetSpec(); spec != nil {
+		// Validating an arbitrary spec uses the project's credentials to reach
+		// out to repositories, so we require the same permissions that would be
+		// required to create the Warehouse.
+		if err := s.authorizeFn(
+			ctx,
+			"create",
+			schema.GroupVersionResource{
+				Group:    kargoapi.GroupVersion.Group,
+				Version:  kargoapi.GroupVersion.Version,
+				Resource: "warehouses",
+			},
+			"",
+			types.NamespacedName{
+				Namespace: project,
+				Name:      name,
+			},
+		); err != nil {
+			return nil, err
+		}
+		warehouse = &kargoapi.Warehouse{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: project,
+				Name:      name,
+			},
+			Spec: *spec,
+		}
+		if err := s.dryRunWarehouseFn(ctx, warehouse); err != nil {
+			return nil, err
+		}
+	} else {
+		warehouse = &kargoapi.Warehouse{}
+		if err := s.client.Get(
+			ctx,
+			client.ObjectKey{
+				Namespace: project,
+				Name:      name,
+			},
+			warehouse,
+		); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				return nil, connect.NewError(
+					connect.CodeNotFound,
+					fmt.Errorf("Warehouse %q not found in project %q", name, project),
+				)
+			}
+			return nil, err
+		}
+	}
+
+	return connect.NewResponse(&svcv1alpha1.ValidateWarehouseResponse{
+		Warnings: s.checkWarehouseConnectivityFn(ctx, warehouse),
+	}), nil
+}
+
+// dryRunWarehouse performs a server-side dry run of the creation of the
+// provided Warehouse or, if a Warehouse by the same name already exists, of
+// its update. Either way, the Warehouse is subjected to the same validation
+// as a real request, but nothing is persisted.
+func (s *server) dryRunWarehouse(
+	ctx context.Context,
+	warehouse *kargoapi.Warehouse,
+) error {
+	existing := &kargoapi.Warehouse{}
+	err := s.client.Get(ctx, client.ObjectKeyFromObject(warehouse), existing)
+	if err != nil && !kubeerr.IsNotFound(err) {
+		return fmt.Errorf("get warehouse: %w", err)
+	}
+	if err != nil {
+		if err = s.client.Create(ctx, warehouse.DeepCopy(), client.DryRunAll); err != nil {
+			return connect.NewError(
+				connect.CodeInvalidArgument,
+				fmt.Errorf("validate warehouse: %w", err),
+			)
+		}
+		return nil
+	}
+	existing.Spec = warehouse.Spec
+	if err = s.client.Update(ctx, existing, client.DryRunAll); err != nil {
+		return connect.NewError(
+			connect.CodeInvalidArgument,
+			fmt.Errorf("validate warehouse: %w", err),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/api/kubernetes"
+	"github.com/akuity/kargo/internal/api/user"
+	"github.com/akuity/kargo/internal/api/validation"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func TestValidateWarehouse(t *testing.T) {
+	testSpec := &kargoapi.WarehouseSpec{
+		Subscriptions: []kargoapi.RepoSubscription{{
+			Image: &kargoapi.ImageSubscription{
+				RepoURL: "fake-repo",
+			},
+		}},
+	}
+	testCases := map[string]struct {
+		req                          *svcv1alpha1.ValidateWarehouseRequest
+		authorizeFn                  func(context.Context, string, schema.GroupVersionResource, string, client.ObjectKey) error
+		dryRunWarehouseFn            func(context.Context, *kargoapi.Warehouse) error
+		checkWarehouseConnectivityFn func(context.Context, *kargoapi.Warehouse) []string
+		assertions                   func(*testing.T, *connect.Response[svcv1alpha1.ValidateWarehouseResponse], error)
+	}{
+		"empty project": {
+			req: &svcv1alpha1.ValidateWarehouseRequest{
+				Name: "test",
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.ValidateWarehouseResponse], err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		"empty name": {
+			req: &svcv1alpha1.ValidateWarehouseRequest{
+				Project: "kargo-demo",
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.ValidateWarehouseResponse], err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		"non-existing project": {
+			req: &svcv1alpha1.ValidateWarehouseRequest{
+				Project: "kargo-x",
+				Name:    "test",
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.ValidateWarehouseResponse], err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		"non-existing Warehouse": {
+			req: &svcv1alpha1.ValidateWarehouseRequest{
+				Project: "kargo-demo",
+				Name:    "non-existing",
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.ValidateWarehouseResponse], err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		"spec not authorized": {
+			req: &svcv1alpha1.ValidateWarehouseRequest{
+				Project: "kargo-demo",
+				Name:    "new",
+				Spec:    testSpec,
+			},
+			authorizeFn: func(context.Context, string, schema.GroupVersionResource, string, client.ObjectKey) error {
+				return connect.NewError(connect.CodePermissionDenied, errors.New("not allowed"))
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.ValidateWarehouseResponse], err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		"invalid spec": {
+			req: &svcv1alpha1.ValidateWarehouseRequest{
+				Project: "kargo-demo",
+				Name:    "new",
+				Spec:    testSpec,
+			},
+			authorizeFn: func(context.Context, string, schema.GroupVersionResource, string, client.ObjectKey) error {
+				return nil
+			},
+			dryRunWarehouseFn: func(context.Context, *kargoapi.Warehouse) error {
+				return connect.NewError(connect.CodeInvalidArgument, errors.New("invalid"))
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.ValidateWarehouseResponse], err error) {
+				require.ErrorContains(t, err, "invalid")
+				require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		"existing Warehouse": {
+			req: &svcv1alpha1.ValidateWarehouseRequest{
+				Project: "kargo-demo",
+				Name:    "test",
+			},
+			checkWarehouseConnectivityFn: func(_ context.Context, warehouse *kargoapi.Warehouse) []string {
+				if warehouse.Spec.Subscriptions[0].Image.RepoURL != "existing-repo" {
+					return []string{"unexpected Warehouse"}
+				}
+				return nil
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.ValidateWarehouseResponse], err error) {
+				require.NoError(t, err)
+				require.Empty(t, res.Msg.GetWarnings())
+			},
+		},
+		"unsaved spec with warnings": {
+			req: &svcv1alpha1.ValidateWarehouseRequest{
+				Project: "kargo-demo",
+				Name:    "new",
+				Spec:    testSpec,
+			},
+			authorizeFn: func(
+				_ context.Context,
+				verb string,
+				gvr schema.GroupVersionResource,
+				_ string,
+				key client.ObjectKey,
+			) error {
+				if verb != "create" || gvr.Resource != "warehouses" || key.Namespace != "kargo-demo" {
+					return errors.New("unexpected authorization check")
+				}
+				return nil
+			},
+			dryRunWarehouseFn: func(_ context.Context, warehouse *kargoapi.Warehouse) error {
+				if warehouse.Namespace != "kargo-demo" || warehouse.Name != "new" {
+					return errors.New("unexpected Warehouse")
+				}
+				return nil
+			},
+			checkWarehouseConnectivityFn: func(_ context.Context, warehouse *kargoapi.Warehouse) []string {
+				return []string{
+					"unable to access image repo " + warehouse.Spec.Subscriptions[0].Image.RepoURL,
+				}
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.ValidateWarehouseResponse], err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]string{"unable to access image repo fake-repo"},
+					res.Msg.GetWarnings(),
+				)
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// Simulate an admin user to prevent any authz issues with the authorizing
+			// client.
+			ctx := user.ContextWithInfo(
+				context.Background(),
+				user.Info{
+					IsAdmin: true,
+				},
+			)
+
+			client, err := kubernetes.NewClient(
+				ctx,
+				&rest.Config{},
+				kubernetes.ClientOptions{
+					NewInternalClient: func(
+						_ context.Context,
+						_ *rest.Config,
+						scheme *runtime.Scheme,
+					) (client.Client, error) {
+						return fake.NewClientBuilder().
+							WithScheme(scheme).
+							WithObjects(
+								mustNewObject[corev1.Namespace]("testdata/namespace.yaml"),
+								&kargoapi.Warehouse{
+									ObjectMeta: metav1.ObjectMeta{
+										Namespace: "kargo-demo",
+										Name:      "test",
+									},
+									Spec: kargoapi.WarehouseSpec{
+										Subscriptions: []kargoapi.RepoSubscription{{
+											Image: &kargoapi.ImageSubscription{
+												RepoURL: "existing-repo",
+											},
+										}},
+									},
+								},
+							).
+							Build(), nil
+					},
+				},
+			)
+			require.NoError(t, err)
+
+			svr := &server{
+				client:                       client,
+				authorizeFn:                  testCase.authorizeFn,
+				dryRunWarehouseFn:            testCase.dryRunWarehouseFn,
+				checkWarehouseConnectivityFn: testCase.checkWarehouseConnectivityFn,
+			}
+			svr.externalValidateProjectFn = validation.ValidateProject
+			svr.validateProjectExistsFn = svr.validateProjectExists
+			res, err := svr.ValidateWarehouse(ctx, connect.NewRequest(testCase.req))
+			testCase.assertions(t, res, err)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"connectrpc.com/connect"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -35,6 +36,28 @@ func (s *server) validateProjectExists(ctx context.Context, project string) erro
 	return nil
 }
 
+// validateManualPromotionsAllowed returns an error if the specified Project is
+// in maintenance mode and rejects manual promotions.
+func (s *server) validateManualPromotionsAllowed(ctx context.Context, project string) error {
+	p, err := s.getProjectFn(ctx, s.client, project)
+	if err != nil {
+		return fmt.Errorf("get project: %w", err)
+	}
+	if p == nil {
+		return connect.NewError(
+			connect.CodeNotFound,
+			fmt.Errorf("project %q not found", project),
+		)
+	}
+	if m := p.ActiveMaintenanceMode(time.Now()); m != nil && m.RejectManualPromotions {
+		return connect.NewError(
+			connect.CodeFailedPrecondition,
+			fmt.Errorf("manual promotions are not permitted: %s", m.Describe()),
+		)
+	}
+	return nil
+}
+
 func validateGroupByOrderBy(group string, groupBy string, orderBy string) error {
 	if group != "" && groupBy == "" {
 		return connect.NewError(
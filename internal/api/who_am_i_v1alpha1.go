@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"connectrpc.com/connect"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/api/user"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+var (
+	stagesGVR  = kargoapi.GroupVersion.WithResource("stages")
+	secretsGVR = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+)
+
+// credentialsVerbs are the verbs that managing credentials requires
+// permission for.
+var credentialsVerbs = []string{"create", "update", "delete"}
+
+// WhoAmI returns information about the caller and, optionally, the caller's
+// effective permissions within each project. Promoting Freight to a Stage and
+// approving Freight for a Stage both require the "promote" verb on the Stage.
+// Managing credentials requires permission to create, update, and delete
+// Secrets.
+func (s *server) WhoAmI(
+	ctx context.Context,
+	req *connect.Request[svcv1alpha1.WhoAmIRequest],
+) (*connect.Response[svcv1alpha1.WhoAmIResponse], error) {
+	userInfo, ok := user.InfoFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(
+			connect.CodeUnauthenticated,
+			errors.New("no user information found"),
+		)
+	}
+
+	res := &svcv1alpha1.WhoAmIResponse{
+		Admin:   userInfo.IsAdmin,
+		Subject: userInfo.Subject,
+		Email:   userInfo.Email,
+		Groups:  userInfo.Groups,
+	}
+	for _, serviceAccounts := range userInfo.ServiceAccountsByNamespace {
+		for serviceAccount := range serviceAccounts {
+			res.Roles = append(res.Roles, serviceAccount.String())
+		}
+	}
+	slices.Sort(res.Roles)
+
+	if !req.Msg.GetPermissions() {
+		return connect.NewResponse(res), nil
+	}
+
+	var projects []string
+	if project := req.Msg.GetProject(); project != "" {
+		if err := s.validateProjectExistsFn(ctx, project); err != nil {
+			return nil, err
+		}
+		projects = []string{project}
+	} else {
+		var list kargoapi.ProjectList
+		if err := s.client.List(ctx, &list); err != nil {
+			return nil, fmt.Errorf("error listing Projects: %w", err)
+		}
+		projects = make([]string, len(list.Items))
+		for i, project := range list.Items {
+			projects[i] = project.Name
+		}
+		sort.Strings(projects)
+	}
+
+	res.ProjectPermissions = make([]*svcv1alpha1.ProjectPermissions, len(projects))
+	for i, project := range projects {
+		permissions, err := s.getProjectPermissions(ctx, project)
+		if err != nil {
+			return nil, err
+		}
+		res.ProjectPermissions[i] = &svcv1alpha1.ProjectPermissions{
+			Project:     project,
+			Permissions: permissions,
+		}
+	}
+	return connect.NewResponse(res), nil
+}
+
+// getProjectPermissions returns the caller's effective permissions within the
+// specified project.
+func (s *server) getProjectPermissions(
+	ctx context.Context,
+	project string,
+) ([]*svcv1alpha1.Permission, error) {
+	// Permission to promote may be granted for all Stages or for specific ones,
+	// so it is checked for both.
+	stageNames := []string{""}
+	var stages kargoapi.StageList
+	if err := s.listStagesFn(ctx, &stages, client.InNamespace(project)); err != nil {
+		if !isPermissionDenied(err) {
+			return nil, fmt.Errorf("error listing Stages in project %q: %w", project, err)
+		}
+		// The caller cannot see the individual Stages, so we can only report
+		// whether they may promote to all of them.
+	}
+	for _, stage := range stages.Items {
+		stageNames = append(stageNames, stage.Name)
+	}
+	sort.Strings(stageNames[1:])
+
+	permissions := make([]*svcv1alpha1.Permission, 0, len(stageNames)+len(credentialsVerbs))
+	for _, stageName := range stageNames {
+		permission, err := s.getPermission(ctx, project, "promote", stagesGVR, stageName)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+	for _, verb := range credentialsVerbs {
+		permission, err := s.getPermission(ctx, project, verb, secretsGVR, "")
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+	return permissions, nil
+}
+
+// getPermission determines whether the caller may perform the specified verb
+// on the specified resource within the specified project.
+func (s *server) getPermission(
+	ctx context.Context,
+	project string,
+	verb string,
+	gvr schema.GroupVersionResource,
+	name string,
+) (*svcv1alpha1.Permission, error) {
+	permission := &svcv1alpha1.Permission{
+		Verb:     verb,
+		Resource: gvr.Resource,
+		Name:     name,
+	}
+	err := s.authorizeFn(
+		ctx,
+		verb,
+		gvr,
+		"",
+		types.NamespacedName{
+			Namespace: project,
+			Name:      name,
+		},
+	)
+	switch {
+	case err == nil:
+		permission.Allowed = true
+	case !isPermissionDenied(err):
+		return nil, fmt.Errorf(
+			"error checking permission to %s %s in project %q: %w",
+			verb,
+			gvr.Resource,
+			project,
+			err,
+		)
+	}
+	return permission, nil
+}
+
+// isPermissionDenied returns true if the provided error indicates that the
+// caller lacks permission to perform an action.
+func isPermissionDenied(err error) bool {
+	return apierrors.IsForbidden(err) || connect.CodeOf(err) == connect.CodePermissionDenied
+}
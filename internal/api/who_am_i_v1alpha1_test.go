@@ -0,0 +1,257 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/api/kubernetes"
+	"github.com/akuity/kargo/internal/api/user"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+func TestWhoAmI(t *testing.T) {
+	testUserInfo := user.Info{
+		Subject: "fake-subject",
+		Email:   "fake@example.com",
+		Groups:  []string{"fake-group"},
+		ServiceAccountsByNamespace: map[string]map[types.NamespacedName]struct{}{
+			"project-a": {
+				{Namespace: "project-a", Name: "kargo-viewer"}: {},
+				{Namespace: "project-a", Name: "kargo-admin"}:  {},
+			},
+		},
+	}
+	listStagesFn := func(
+		_ context.Context,
+		list client.ObjectList,
+		_ ...client.ListOption,
+	) error {
+		stages := list.(*kargoapi.StageList) // nolint: forcetypeassert
+		stages.Items = []kargoapi.Stage{
+			{ObjectMeta: metav1.ObjectMeta{Name: "uat"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "prod"}},
+		}
+		return nil
+	}
+	// authorizeFn permits promotion to the "uat" Stage of project-a and the
+	// creation of Secrets in any project.
+	authorizeFn := func(
+		_ context.Context,
+		verb string,
+		gvr schema.GroupVersionResource,
+		_ string,
+		key client.ObjectKey,
+	) error {
+		if (gvr.Resource == "stages" && key.Namespace == "project-a" && key.Name == "uat") ||
+			(gvr.Resource == "secrets" && verb == "create") {
+			return nil
+		}
+		return apierrors.NewForbidden(
+			schema.GroupResource{Group: gvr.Group, Resource: gvr.Resource},
+			key.Name,
+			errors.New("not permitted"),
+		)
+	}
+
+	testCases := map[string]struct {
+		req                     *svcv1alpha1.WhoAmIRequest
+		noUserInfo              bool
+		admin                   bool
+		validateProjectExistsFn func(context.Context, string) error
+		listStagesFn            func(context.Context, client.ObjectList, ...client.ListOption) error
+		authorizeFn             func(context.Context, string, schema.GroupVersionResource, string, client.ObjectKey) error
+		assertions              func(*testing.T, *connect.Response[svcv1alpha1.WhoAmIResponse], error)
+	}{
+		"no user information": {
+			req:        &svcv1alpha1.WhoAmIRequest{},
+			noUserInfo: true,
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.WhoAmIResponse], err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		"without permissions": {
+			req: &svcv1alpha1.WhoAmIRequest{},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.WhoAmIResponse], err error) {
+				require.NoError(t, err)
+				require.False(t, res.Msg.GetAdmin())
+				require.Equal(t, "fake-subject", res.Msg.GetSubject())
+				require.Equal(t, "fake@example.com", res.Msg.GetEmail())
+				require.Equal(t, []string{"fake-group"}, res.Msg.GetGroups())
+				require.Equal(
+					t,
+					[]string{"project-a/kargo-admin", "project-a/kargo-viewer"},
+					res.Msg.GetRoles(),
+				)
+				require.Empty(t, res.Msg.GetProjectPermissions())
+			},
+		},
+		"project does not exist": {
+			req: &svcv1alpha1.WhoAmIRequest{
+				Project:     "project-c",
+				Permissions: true,
+			},
+			validateProjectExistsFn: func(context.Context, string) error {
+				return connect.NewError(connect.CodeNotFound, errors.New("not found"))
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.WhoAmIResponse], err error) {
+				require.Error(t, err)
+				require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+				require.Nil(t, res)
+			},
+		},
+		"error checking permission": {
+			req: &svcv1alpha1.WhoAmIRequest{
+				Project:     "project-a",
+				Permissions: true,
+			},
+			listStagesFn: listStagesFn,
+			authorizeFn: func(context.Context, string, schema.GroupVersionResource, string, client.ObjectKey) error {
+				return errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.WhoAmIResponse], err error) {
+				require.ErrorContains(t, err, "something went wrong")
+				require.Nil(t, res)
+			},
+		},
+		"single project": {
+			req: &svcv1alpha1.WhoAmIRequest{
+				Project:     "project-a",
+				Permissions: true,
+			},
+			listStagesFn: listStagesFn,
+			authorizeFn:  authorizeFn,
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.WhoAmIResponse], err error) {
+				require.NoError(t, err)
+				require.Len(t, res.Msg.GetProjectPermissions(), 1)
+				projectPermissions := res.Msg.GetProjectPermissions()[0]
+				require.Equal(t, "project-a", projectPermissions.GetProject())
+				require.Equal(
+					t,
+					[]*svcv1alpha1.Permission{
+						{Verb: "promote", Resource: "stages"},
+						{Verb: "promote", Resource: "stages", Name: "prod"},
+						{Verb: "promote", Resource: "stages", Name: "uat", Allowed: true},
+						{Verb: "create", Resource: "secrets", Allowed: true},
+						{Verb: "update", Resource: "secrets"},
+						{Verb: "delete", Resource: "secrets"},
+					},
+					projectPermissions.GetPermissions(),
+				)
+			},
+		},
+		"cannot list Stages": {
+			req: &svcv1alpha1.WhoAmIRequest{
+				Project:     "project-a",
+				Permissions: true,
+			},
+			listStagesFn: func(context.Context, client.ObjectList, ...client.ListOption) error {
+				return apierrors.NewForbidden(
+					schema.GroupResource{Group: kargoapi.GroupVersion.Group, Resource: "stages"},
+					"",
+					errors.New("not permitted"),
+				)
+			},
+			authorizeFn: authorizeFn,
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.WhoAmIResponse], err error) {
+				require.NoError(t, err)
+				require.Len(t, res.Msg.GetProjectPermissions(), 1)
+				require.Equal(
+					t,
+					[]*svcv1alpha1.Permission{
+						{Verb: "promote", Resource: "stages"},
+						{Verb: "create", Resource: "secrets", Allowed: true},
+						{Verb: "update", Resource: "secrets"},
+						{Verb: "delete", Resource: "secrets"},
+					},
+					res.Msg.GetProjectPermissions()[0].GetPermissions(),
+				)
+			},
+		},
+		"all projects": {
+			req: &svcv1alpha1.WhoAmIRequest{
+				Permissions: true,
+			},
+			// Simulate an admin user to prevent any authz issues with the
+			// authorizing client.
+			admin:        true,
+			listStagesFn: listStagesFn,
+			authorizeFn:  authorizeFn,
+			assertions: func(t *testing.T, res *connect.Response[svcv1alpha1.WhoAmIResponse], err error) {
+				require.NoError(t, err)
+				require.Len(t, res.Msg.GetProjectPermissions(), 2)
+				require.Equal(t, "project-a", res.Msg.GetProjectPermissions()[0].GetProject())
+				require.Equal(t, "project-b", res.Msg.GetProjectPermissions()[1].GetProject())
+				for _, permission := range res.Msg.GetProjectPermissions()[1].GetPermissions() {
+					if permission.GetResource() == "stages" {
+						require.False(t, permission.GetAllowed())
+					}
+				}
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if !testCase.noUserInfo {
+				userInfo := testUserInfo
+				userInfo.IsAdmin = testCase.admin
+				ctx = user.ContextWithInfo(ctx, userInfo)
+			}
+
+			kubeClient, err := kubernetes.NewClient(
+				ctx,
+				&rest.Config{},
+				kubernetes.ClientOptions{
+					NewInternalClient: func(
+						_ context.Context,
+						_ *rest.Config,
+						scheme *runtime.Scheme,
+					) (client.Client, error) {
+						return fake.NewClientBuilder().
+							WithScheme(scheme).
+							WithObjects(
+								&kargoapi.Project{
+									ObjectMeta: metav1.ObjectMeta{Name: "project-b"},
+								},
+								&kargoapi.Project{
+									ObjectMeta: metav1.ObjectMeta{Name: "project-a"},
+								},
+							).
+							Build(), nil
+					},
+				},
+			)
+			require.NoError(t, err)
+
+			validateProjectExistsFn := testCase.validateProjectExistsFn
+			if validateProjectExistsFn == nil {
+				validateProjectExistsFn = func(context.Context, string) error {
+					return nil
+				}
+			}
+			svr := &server{
+				client:                  kubeClient,
+				validateProjectExistsFn: validateProjectExistsFn,
+				listStagesFn:            testCase.listStagesFn,
+				authorizeFn:             testCase.authorizeFn,
+			}
+			res, err := svr.WhoAmI(ctx, connect.NewRequest(testCase.req))
+			testCase.assertions(t, res, err)
+		})
+	}
+}
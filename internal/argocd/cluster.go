@@ -0,0 +1,163 @@
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// secretTypeLabelKey is the key of the label Argo CD uses to identify the
+	// type of its declarative Secrets.
+	secretTypeLabelKey = "argocd.argoproj.io/secret-type"
+	// secretTypeCluster is the value of the secretTypeLabelKey label of Secrets
+	// that describe clusters registered with Argo CD.
+	secretTypeCluster = "cluster"
+
+	clusterSecretNameKey   = "name"
+	clusterSecretServerKey = "server"
+	clusterSecretConfigKey = "config"
+)
+
+// clusterConfig mirrors the subset of Argo CD's ClusterConfig, stored in the
+// "config" key of a cluster Secret, that Kargo is able to use to connect to a
+// cluster.
+type clusterConfig struct {
+	Username           string           `json:"username,omitempty"`
+	Password           string           `json:"password,omitempty"`
+	BearerToken        string           `json:"bearerToken,omitempty"`
+	TLSClientConfig    tlsClientConfig  `json:"tlsClientConfig"`
+	AWSAuthConfig      *json.RawMessage `json:"awsAuthConfig,omitempty"`
+	ExecProviderConfig *json.RawMessage `json:"execProviderConfig,omitempty"`
+}
+
+// tlsClientConfig mirrors Argo CD's TLSClientConfig.
+type tlsClientConfig struct {
+	Insecure   bool   `json:"insecure"`
+	ServerName string `json:"serverName,omitempty"`
+	CertData   []byte `json:"certData,omitempty"`
+	KeyData    []byte `json:"keyData,omitempty"`
+	CAData     []byte `json:"caData,omitempty"`
+}
+
+// GetClusterRESTConfig returns a *rest.Config for connecting to the cluster
+// registered with Argo CD under the provided name or API server URL. The
+// connection details are taken from the corresponding declarative cluster
+// Secret in Argo CD's namespace. Clusters that Argo CD authenticates to using
+// AWS IAM or an exec provider are not supported.
+func GetClusterRESTConfig(
+	ctx context.Context,
+	argocdClient client.Client,
+	cluster string,
+) (*rest.Config, error) {
+	if argocdClient == nil {
+		return nil, errors.New(
+			"Argo CD integration is disabled on this controller; cannot look up " +
+				"clusters registered with Argo CD",
+		)
+	}
+
+	secrets := corev1.SecretList{}
+	if err := argocdClient.List(
+		ctx,
+		&secrets,
+		client.InNamespace(Namespace()),
+		client.MatchingLabels{secretTypeLabelKey: secretTypeCluster},
+	); err != nil {
+		return nil, fmt.Errorf(
+			"error listing Argo CD cluster Secrets in namespace %q: %w",
+			Namespace(),
+			err,
+		)
+	}
+
+	for _, secret := range secrets.Items {
+		name := string(secret.Data[clusterSecretNameKey])
+		server := string(secret.Data[clusterSecretServerKey])
+		if cluster != name && cluster != server {
+			continue
+		}
+		cfg, err := clusterSecretToRESTConfig(secret)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error reading Argo CD cluster Secret %q in namespace %q: %w",
+				secret.Name,
+				secret.Namespace,
+				err,
+			)
+		}
+		return cfg, nil
+	}
+
+	return nil, fmt.Errorf(
+		"no cluster named %q or with server %q is registered with Argo CD",
+		cluster,
+		cluster,
+	)
+}
+
+// GetClusterClientFn returns a function that returns a client for a target
+// cluster, i.e. a cluster that Kargo acts upon on behalf of a Stage. A target
+// cluster is identified by the name or API server URL it is registered with
+// Argo CD under, and its client connects to it using the corresponding
+// declarative cluster Secret, so that no separate set of credentials needs to
+// be maintained for it. An empty name refers to the cluster Kargo itself runs
+// in, for which the provided localClient is returned.
+func GetClusterClientFn(
+	localClient client.Client,
+	argocdClient client.Client,
+) func(ctx context.Context, cluster string) (client.Client, error) {
+	return func(ctx context.Context, cluster string) (client.Client, error) {
+		if strings.TrimSpace(cluster) == "" {
+			if localClient == nil {
+				return nil, errors.New("no client available for the local cluster")
+			}
+			return localClient, nil
+		}
+		restCfg, err := GetClusterRESTConfig(ctx, argocdClient, cluster)
+		if err != nil {
+			return nil, err
+		}
+		return client.New(restCfg, client.Options{})
+	}
+}
+
+// clusterSecretToRESTConfig builds a *rest.Config from the provided Argo CD
+// cluster Secret.
+func clusterSecretToRESTConfig(secret corev1.Secret) (*rest.Config, error) {
+	server := string(secret.Data[clusterSecretServerKey])
+	if server == "" {
+		return nil, fmt.Errorf("Secret has no %q key", clusterSecretServerKey)
+	}
+	cfg := clusterConfig{}
+	if data := secret.Data[clusterSecretConfigKey]; len(data) > 0 {
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("error unmarshaling %q key: %w", clusterSecretConfigKey, err)
+		}
+	}
+	if cfg.AWSAuthConfig != nil {
+		return nil, errors.New("clusters using AWS IAM authentication are not supported")
+	}
+	if cfg.ExecProviderConfig != nil {
+		return nil, errors.New("clusters using an exec provider are not supported")
+	}
+	return &rest.Config{
+		Host:        server,
+		Username:    cfg.Username,
+		Password:    cfg.Password,
+		BearerToken: cfg.BearerToken,
+		TLSClientConfig: rest.TLSClientConfig{
+			Insecure:   cfg.TLSClientConfig.Insecure,
+			ServerName: cfg.TLSClientConfig.ServerName,
+			CertData:   cfg.TLSClientConfig.CertData,
+			KeyData:    cfg.TLSClientConfig.KeyData,
+			CAData:     cfg.TLSClientConfig.CAData,
+		},
+	}, nil
+}
@@ -0,0 +1,155 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetClusterRESTConfig(t *testing.T) {
+	newClusterSecret := func(name string, data map[string]string) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: Namespace(),
+				Name:      name,
+				Labels: map[string]string{
+					secretTypeLabelKey: secretTypeCluster,
+				},
+			},
+			Data: map[string][]byte{},
+		}
+		for k, v := range data {
+			secret.Data[k] = []byte(v)
+		}
+		return secret
+	}
+
+	testCases := []struct {
+		name         string
+		argocdClient client.Client
+		cluster      string
+		assertions   func(*testing.T, *rest.Config, error)
+	}{
+		{
+			name:    "Argo CD integration disabled",
+			cluster: "fake-cluster",
+			assertions: func(t *testing.T, _ *rest.Config, err error) {
+				require.ErrorContains(t, err, "Argo CD integration is disabled")
+			},
+		},
+		{
+			name: "cluster not found",
+			argocdClient: fake.NewClientBuilder().WithObjects(
+				newClusterSecret("other-cluster", map[string]string{
+					"name":   "other-cluster",
+					"server": "https://other-cluster.example.com",
+				}),
+			).Build(),
+			cluster: "fake-cluster",
+			assertions: func(t *testing.T, _ *rest.Config, err error) {
+				require.ErrorContains(t, err, "no cluster named \"fake-cluster\"")
+			},
+		},
+		{
+			name: "Secret without the cluster type label is ignored",
+			argocdClient: fake.NewClientBuilder().WithObjects(
+				func() *corev1.Secret {
+					secret := newClusterSecret("fake-cluster", map[string]string{
+						"name":   "fake-cluster",
+						"server": "https://fake-cluster.example.com",
+					})
+					secret.Labels = nil
+					return secret
+				}(),
+			).Build(),
+			cluster: "fake-cluster",
+			assertions: func(t *testing.T, _ *rest.Config, err error) {
+				require.ErrorContains(t, err, "no cluster named \"fake-cluster\"")
+			},
+		},
+		{
+			name: "unsupported authentication",
+			argocdClient: fake.NewClientBuilder().WithObjects(
+				newClusterSecret("fake-cluster", map[string]string{
+					"name":   "fake-cluster",
+					"server": "https://fake-cluster.example.com",
+					"config": `{"awsAuthConfig":{"clusterName":"fake-cluster"}}`,
+				}),
+			).Build(),
+			cluster: "fake-cluster",
+			assertions: func(t *testing.T, _ *rest.Config, err error) {
+				require.ErrorContains(t, err, "AWS IAM authentication are not supported")
+			},
+		},
+		{
+			name: "cluster found by name",
+			argocdClient: fake.NewClientBuilder().WithObjects(
+				newClusterSecret("fake-cluster", map[string]string{
+					"name":   "fake-cluster",
+					"server": "https://fake-cluster.example.com",
+					"config": `{
+						"bearerToken": "fake-token",
+						"tlsClientConfig": {
+							"insecure": false,
+							"caData": "ZmFrZS1jYQ=="
+						}
+					}`,
+				}),
+			).Build(),
+			cluster: "fake-cluster",
+			assertions: func(t *testing.T, cfg *rest.Config, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "https://fake-cluster.example.com", cfg.Host)
+				require.Equal(t, "fake-token", cfg.BearerToken)
+				require.Equal(t, []byte("fake-ca"), cfg.TLSClientConfig.CAData)
+			},
+		},
+		{
+			name: "cluster found by server",
+			argocdClient: fake.NewClientBuilder().WithObjects(
+				newClusterSecret("fake-cluster", map[string]string{
+					"name":   "fake-cluster",
+					"server": "https://fake-cluster.example.com",
+					"config": `{"username":"fake-user","password":"fake-pass"}`,
+				}),
+			).Build(),
+			cluster: "https://fake-cluster.example.com",
+			assertions: func(t *testing.T, cfg *rest.Config, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "https://fake-cluster.example.com", cfg.Host)
+				require.Equal(t, "fake-user", cfg.Username)
+				require.Equal(t, "fake-pass", cfg.Password)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cfg, err := GetClusterRESTConfig(
+				context.Background(),
+				testCase.argocdClient,
+				testCase.cluster,
+			)
+			testCase.assertions(t, cfg, err)
+		})
+	}
+}
+
+func TestGetClusterClientFn(t *testing.T) {
+	localClient := fake.NewClientBuilder().Build()
+
+	c, err := GetClusterClientFn(localClient, nil)(context.Background(), "")
+	require.NoError(t, err)
+	require.Same(t, localClient, c)
+
+	_, err = GetClusterClientFn(nil, nil)(context.Background(), "")
+	require.ErrorContains(t, err, "no client available for the local cluster")
+
+	_, err = GetClusterClientFn(localClient, nil)(context.Background(), "fake-cluster")
+	require.ErrorContains(t, err, "Argo CD integration is disabled")
+}
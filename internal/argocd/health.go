@@ -20,6 +20,10 @@ var healthErrorConditions = []argocd.ApplicationConditionType{
 	argocd.ApplicationConditionInvalidSpecError,
 }
 
+// maxUnhealthyResources is the maximum number of unhealthy resources managed
+// by an Argo CD Application that are described by its status.
+const maxUnhealthyResources = 10
+
 // compositeError is an interface for wrapped standard errors produced by
 // errors.Join.
 type compositeError interface {
@@ -74,19 +78,13 @@ func (h *applicationHealth) EvaluateHealth(
 			namespace = Namespace()
 		}
 
-		health.ArgoCDApps[i] = kargoapi.ArgoCDAppStatus{
+		state, appStatus, err := h.GetApplicationHealth(ctx, types.NamespacedName{
 			Namespace: namespace,
 			Name:      update.AppName,
-		}
-
-		state, healthStatus, syncStatus, err := h.GetApplicationHealth(ctx, types.NamespacedName{
-			Namespace: health.ArgoCDApps[i].Namespace,
-			Name:      health.ArgoCDApps[i].Name,
 		}, freight)
 
 		health.Status = health.Status.Merge(state)
-		health.ArgoCDApps[i].HealthStatus = healthStatus
-		health.ArgoCDApps[i].SyncStatus = syncStatus
+		health.ArgoCDApps[i] = appStatus
 
 		if err != nil {
 			if cErr, ok := err.(compositeError); ok {
@@ -104,22 +102,26 @@ func (h *applicationHealth) EvaluateHealth(
 
 // GetApplicationHealth assesses the health of an Argo CD Application by looking
 // at its conditions, health status, and sync status. Based on these, it returns
-// an overall health state, the Argo CD Application's health status, and its sync
-// status. If it can not (fully) assess the health of the Argo CD Application, it
-// returns an error with a message explaining why.
+// an overall health state and the Argo CD Application's status, which includes
+// its health status, its sync status, the state of its most recent operation,
+// and any of its resources that are unhealthy. If it can not (fully) assess the
+// health of the Argo CD Application, it returns an error with a message
+// explaining why.
 func (h *applicationHealth) GetApplicationHealth(
 	ctx context.Context,
 	key types.NamespacedName,
 	freight kargoapi.FreightReference,
-) (kargoapi.HealthState, kargoapi.ArgoCDAppHealthStatus, kargoapi.ArgoCDAppSyncStatus, error) {
-	var (
-		healthStatus = kargoapi.ArgoCDAppHealthStatus{
+) (kargoapi.HealthState, kargoapi.ArgoCDAppStatus, error) {
+	appStatus := kargoapi.ArgoCDAppStatus{
+		Namespace: key.Namespace,
+		Name:      key.Name,
+		HealthStatus: kargoapi.ArgoCDAppHealthStatus{
 			Status: kargoapi.ArgoCDAppHealthStateUnknown,
-		}
-		syncStatus = kargoapi.ArgoCDAppSyncStatus{
+		},
+		SyncStatus: kargoapi.ArgoCDAppSyncStatus{
 			Status: kargoapi.ArgoCDAppSyncStateUnknown,
-		}
-	)
+		},
+	}
 
 	app := &argocd.Application{}
 	if err := h.Client.Get(ctx, key, app); err != nil {
@@ -127,23 +129,25 @@ func (h *applicationHealth) GetApplicationHealth(
 		if client.IgnoreNotFound(err) == nil {
 			err = fmt.Errorf("unable to find Argo CD Application %q in namespace %q", key.Name, key.Namespace)
 		}
-		return kargoapi.HealthStateUnknown, healthStatus, syncStatus, err
+		return kargoapi.HealthStateUnknown, appStatus, err
 	}
 
 	// Mirror the health and sync status of the Argo CD Application.
 	if app.Status.Health.Status != "" {
-		healthStatus = kargoapi.ArgoCDAppHealthStatus{
+		appStatus.HealthStatus = kargoapi.ArgoCDAppHealthStatus{
 			Status:  kargoapi.ArgoCDAppHealthState(app.Status.Health.Status),
 			Message: app.Status.Health.Message,
 		}
 	}
 	if app.Status.Sync.Status != "" {
-		syncStatus = kargoapi.ArgoCDAppSyncStatus{
+		appStatus.SyncStatus = kargoapi.ArgoCDAppSyncStatus{
 			Status:    kargoapi.ArgoCDAppSyncState(app.Status.Sync.Status),
 			Revision:  app.Status.Sync.Revision,
 			Revisions: app.Status.Sync.Revisions,
 		}
 	}
+	appStatus.OperationState = appOperationState(app)
+	appStatus.UnhealthyResources = unhealthyAppResources(app)
 
 	// TODO: We should re-evaluate this soon. It may have been fixed in recent
 	//       versions.
@@ -155,7 +159,7 @@ func (h *applicationHealth) GetApplicationHealth(
 			key.Name,
 			key.Namespace,
 		)
-		return kargoapi.HealthStateUnknown, healthStatus, syncStatus, err
+		return kargoapi.HealthStateUnknown, appStatus, err
 	}
 
 	// Check for any error conditions. If these are found, the application is
@@ -172,7 +176,7 @@ func (h *applicationHealth) GetApplicationHealth(
 				condition.Message,
 			))
 		}
-		return kargoapi.HealthStateUnhealthy, healthStatus, syncStatus, errors.Join(issues...)
+		return kargoapi.HealthStateUnhealthy, appStatus, errors.Join(issues...)
 	}
 
 	// If we have a desired revision, we should confirm the Argo CD Application
@@ -181,14 +185,104 @@ func (h *applicationHealth) GetApplicationHealth(
 	// default.
 	if desiredRevision := GetDesiredRevision(app, freight); desiredRevision != "" {
 		if healthState, err := stageHealthForAppSync(app, desiredRevision); err != nil {
-			return healthState, healthStatus, syncStatus, err
+			return healthState, appStatus, operationError(app, err)
 		}
 	}
 
 	// With all the above checks passed, we can now assume the Argo CD
 	// Application's health state is reliable.
 	healthState, err := stageHealthForAppHealth(app)
-	return healthState, healthStatus, syncStatus, err
+	if healthState == kargoapi.HealthStateUnhealthy {
+		err = unhealthyResourcesError(app, appStatus.UnhealthyResources, err)
+	}
+	return healthState, appStatus, err
+}
+
+// appOperationState returns an ArgoCDAppOperationState describing the most
+// recent operation performed on the provided Argo CD Application, or nil if no
+// operation has been performed on it.
+func appOperationState(app *argocd.Application) *kargoapi.ArgoCDAppOperationState {
+	if app.Status.OperationState == nil {
+		return nil
+	}
+	opState := &kargoapi.ArgoCDAppOperationState{
+		Phase:      string(app.Status.OperationState.Phase),
+		Message:    app.Status.OperationState.Message,
+		FinishedAt: app.Status.OperationState.FinishedAt,
+	}
+	if app.Status.OperationState.SyncResult != nil {
+		opState.SyncRevision = app.Status.OperationState.SyncResult.Revision
+	}
+	return opState
+}
+
+// unhealthyAppResources returns ArgoCDAppResourceStatuses describing up to
+// maxUnhealthyResources resources managed by the provided Argo CD Application
+// that are Degraded or Missing.
+func unhealthyAppResources(app *argocd.Application) []kargoapi.ArgoCDAppResourceStatus {
+	var resources []kargoapi.ArgoCDAppResourceStatus
+	for _, res := range app.Status.Resources {
+		if res.Health == nil ||
+			(res.Health.Status != argocd.HealthStatusDegraded &&
+				res.Health.Status != argocd.HealthStatusMissing) {
+			continue
+		}
+		resources = append(resources, kargoapi.ArgoCDAppResourceStatus{
+			Group:     res.Group,
+			Kind:      res.Kind,
+			Namespace: res.Namespace,
+			Name:      res.Name,
+			HealthStatus: kargoapi.ArgoCDAppHealthStatus{
+				Status:  kargoapi.ArgoCDAppHealthState(res.Health.Status),
+				Message: res.Health.Message,
+			},
+		})
+		if len(resources) == maxUnhealthyResources {
+			break
+		}
+	}
+	return resources
+}
+
+// operationError adds the message of the most recent operation performed on
+// the provided Argo CD Application to the provided error if that operation
+// failed.
+func operationError(app *argocd.Application, err error) error {
+	opState := app.Status.OperationState
+	if opState == nil || !opState.Phase.Failed() || opState.Message == "" {
+		return err
+	}
+	return errors.Join(err, fmt.Errorf(
+		"last operation on Argo CD Application %q in namespace %q failed: %s",
+		app.GetName(),
+		app.GetNamespace(),
+		opState.Message,
+	))
+}
+
+// unhealthyResourcesError adds a description of each of the provided unhealthy
+// resources managed by the provided Argo CD Application to the provided error.
+func unhealthyResourcesError(
+	app *argocd.Application,
+	resources []kargoapi.ArgoCDAppResourceStatus,
+	err error,
+) error {
+	errs := []error{err}
+	for _, res := range resources {
+		resErr := fmt.Errorf(
+			"%s %q of Argo CD Application %q in namespace %q is %s",
+			res.Kind,
+			res.Name,
+			app.GetName(),
+			app.GetNamespace(),
+			res.HealthStatus.Status,
+		)
+		if res.HealthStatus.Message != "" {
+			resErr = fmt.Errorf("%w: %s", resErr, res.HealthStatus.Message)
+		}
+		errs = append(errs, resErr)
+	}
+	return errors.Join(errs...)
 }
 
 // stageHealthForAppSync returns the v1alpha1.HealthState for an Argo CD
@@ -271,8 +271,7 @@ func TestApplicationHealth_GetApplicationHealth(t *testing.T) {
 		assertions  func(
 			*testing.T,
 			kargoapi.HealthState,
-			kargoapi.ArgoCDAppHealthStatus,
-			kargoapi.ArgoCDAppSyncStatus,
+			kargoapi.ArgoCDAppStatus,
 			error,
 		)
 	}{
@@ -282,15 +281,14 @@ func TestApplicationHealth_GetApplicationHealth(t *testing.T) {
 			assertions: func(
 				t *testing.T,
 				state kargoapi.HealthState,
-				healthStatus kargoapi.ArgoCDAppHealthStatus,
-				syncStatus kargoapi.ArgoCDAppSyncStatus,
+				appStatus kargoapi.ArgoCDAppStatus,
 				err error,
 			) {
 				require.ErrorContains(t, err, "unable to find Argo CD Application")
 
 				require.Equal(t, kargoapi.HealthStateUnknown, state)
-				require.Equal(t, kargoapi.ArgoCDAppHealthStateUnknown, healthStatus.Status)
-				require.Equal(t, kargoapi.ArgoCDAppSyncStateUnknown, syncStatus.Status)
+				require.Equal(t, kargoapi.ArgoCDAppHealthStateUnknown, appStatus.HealthStatus.Status)
+				require.Equal(t, kargoapi.ArgoCDAppSyncStateUnknown, appStatus.SyncStatus.Status)
 			},
 		},
 		{
@@ -310,16 +308,15 @@ func TestApplicationHealth_GetApplicationHealth(t *testing.T) {
 			assertions: func(
 				t *testing.T,
 				state kargoapi.HealthState,
-				healthStatus kargoapi.ArgoCDAppHealthStatus,
-				syncStatus kargoapi.ArgoCDAppSyncStatus,
+				appStatus kargoapi.ArgoCDAppStatus,
 				err error,
 			) {
 				require.ErrorContains(t, err, "error finding Argo CD Application")
 				require.ErrorContains(t, err, "something went wrong")
 
 				require.Equal(t, kargoapi.HealthStateUnknown, state)
-				require.Equal(t, kargoapi.ArgoCDAppHealthStateUnknown, healthStatus.Status)
-				require.Equal(t, kargoapi.ArgoCDAppSyncStateUnknown, syncStatus.Status)
+				require.Equal(t, kargoapi.ArgoCDAppHealthStateUnknown, appStatus.HealthStatus.Status)
+				require.Equal(t, kargoapi.ArgoCDAppSyncStateUnknown, appStatus.SyncStatus.Status)
 			},
 		},
 		{
@@ -351,8 +348,7 @@ func TestApplicationHealth_GetApplicationHealth(t *testing.T) {
 			assertions: func(
 				t *testing.T,
 				state kargoapi.HealthState,
-				healthStatus kargoapi.ArgoCDAppHealthStatus,
-				syncStatus kargoapi.ArgoCDAppSyncStatus,
+				appStatus kargoapi.ArgoCDAppStatus,
 				err error,
 			) {
 				require.ErrorContains(t, err, "bugs in Argo CD currently prevent a comprehensive assessment")
@@ -361,12 +357,12 @@ func TestApplicationHealth_GetApplicationHealth(t *testing.T) {
 				require.Equal(t, kargoapi.ArgoCDAppHealthStatus{
 					Status:  kargoapi.ArgoCDAppHealthStateHealthy,
 					Message: "fake-message",
-				}, healthStatus)
+				}, appStatus.HealthStatus)
 				require.Equal(t, kargoapi.ArgoCDAppSyncStatus{
 					Status:    kargoapi.ArgoCDAppSyncStateSynced,
 					Revision:  "fake-revision",
 					Revisions: []string{"fake-revision1", "fake-revision2"},
-				}, syncStatus)
+				}, appStatus.SyncStatus)
 			},
 		},
 		{
@@ -400,8 +396,7 @@ func TestApplicationHealth_GetApplicationHealth(t *testing.T) {
 			assertions: func(
 				t *testing.T,
 				state kargoapi.HealthState,
-				healthStatus kargoapi.ArgoCDAppHealthStatus,
-				syncStatus kargoapi.ArgoCDAppSyncStatus,
+				appStatus kargoapi.ArgoCDAppStatus,
 				err error,
 			) {
 				require.Error(t, err)
@@ -416,10 +411,10 @@ func TestApplicationHealth_GetApplicationHealth(t *testing.T) {
 				require.Equal(t, kargoapi.ArgoCDAppHealthStatus{
 					Status:  kargoapi.ArgoCDAppHealthStateHealthy,
 					Message: "fake-message",
-				}, healthStatus)
+				}, appStatus.HealthStatus)
 				require.Equal(t, kargoapi.ArgoCDAppSyncStatus{
 					Status: kargoapi.ArgoCDAppSyncStateSynced,
-				}, syncStatus)
+				}, appStatus.SyncStatus)
 			},
 		},
 		{
@@ -456,8 +451,7 @@ func TestApplicationHealth_GetApplicationHealth(t *testing.T) {
 			assertions: func(
 				t *testing.T,
 				state kargoapi.HealthState,
-				healthStatus kargoapi.ArgoCDAppHealthStatus,
-				syncStatus kargoapi.ArgoCDAppSyncStatus,
+				appStatus kargoapi.ArgoCDAppStatus,
 				err error,
 			) {
 				require.ErrorContains(t, err, "is out of sync")
@@ -465,11 +459,147 @@ func TestApplicationHealth_GetApplicationHealth(t *testing.T) {
 				require.Equal(t, kargoapi.HealthStateUnhealthy, state)
 				require.Equal(t, kargoapi.ArgoCDAppHealthStatus{
 					Status: kargoapi.ArgoCDAppHealthStateHealthy,
-				}, healthStatus)
+				}, appStatus.HealthStatus)
 				require.Equal(t, kargoapi.ArgoCDAppSyncStatus{
 					Status:   kargoapi.ArgoCDAppSyncStateSynced,
 					Revision: "fake-revision",
-				}, syncStatus)
+				}, appStatus.SyncStatus)
+			},
+		},
+		{
+			name: "Failed sync operation is described",
+			key:  types.NamespacedName{Namespace: "fake-namespace", Name: "fake-name"},
+			application: &argocd.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "fake-namespace",
+					Name:      "fake-name",
+				},
+				Spec: argocd.ApplicationSpec{
+					Source: &argocd.ApplicationSource{
+						RepoURL: "https://example.com/universe/42",
+					},
+				},
+				Status: argocd.ApplicationStatus{
+					Health: argocd.HealthStatus{
+						Status: argocd.HealthStatusHealthy,
+					},
+					Sync: argocd.SyncStatus{
+						Status:   argocd.SyncStatusCodeSynced,
+						Revision: "fake-revision",
+					},
+					OperationState: &argocd.OperationState{
+						Phase:   argocd.OperationFailed,
+						Message: "one or more objects failed to apply",
+						SyncResult: &argocd.SyncOperationResult{
+							Revision: "other-fake-revision",
+						},
+					},
+				},
+			},
+			freight: kargoapi.FreightReference{
+				Commits: []kargoapi.GitCommit{
+					{
+						RepoURL: "https://example.com/universe/42",
+						ID:      "other-fake-revision",
+					},
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				state kargoapi.HealthState,
+				appStatus kargoapi.ArgoCDAppStatus,
+				err error,
+			) {
+				require.ErrorContains(t, err, "is out of sync")
+				require.ErrorContains(t, err, "last operation on Argo CD Application")
+				require.ErrorContains(t, err, "one or more objects failed to apply")
+
+				require.Equal(t, kargoapi.HealthStateUnhealthy, state)
+				require.Equal(t, &kargoapi.ArgoCDAppOperationState{
+					Phase:        "Failed",
+					Message:      "one or more objects failed to apply",
+					SyncRevision: "other-fake-revision",
+				}, appStatus.OperationState)
+			},
+		},
+		{
+			name: "Degraded resources are described",
+			key:  types.NamespacedName{Namespace: "fake-namespace", Name: "fake-name"},
+			application: &argocd.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "fake-namespace",
+					Name:      "fake-name",
+				},
+				Spec: argocd.ApplicationSpec{
+					Source: &argocd.ApplicationSource{
+						RepoURL: "https://example.com/universe/42",
+					},
+				},
+				Status: argocd.ApplicationStatus{
+					Health: argocd.HealthStatus{
+						Status: argocd.HealthStatusDegraded,
+					},
+					Sync: argocd.SyncStatus{
+						Status:   argocd.SyncStatusCodeSynced,
+						Revision: "fake-revision",
+					},
+					Resources: []argocd.ResourceStatus{
+						{
+							Group:     "apps",
+							Version:   "v1",
+							Kind:      "Deployment",
+							Namespace: "fake-app-namespace",
+							Name:      "fake-deployment",
+							Health: &argocd.HealthStatus{
+								Status:  argocd.HealthStatusDegraded,
+								Message: "Deployment exceeded its progress deadline",
+							},
+						},
+						{
+							Version:   "v1",
+							Kind:      "Service",
+							Namespace: "fake-app-namespace",
+							Name:      "fake-service",
+							Health: &argocd.HealthStatus{
+								Status: argocd.HealthStatusHealthy,
+							},
+						},
+						{
+							Version:   "v1",
+							Kind:      "ConfigMap",
+							Namespace: "fake-app-namespace",
+							Name:      "fake-configmap",
+						},
+					},
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				state kargoapi.HealthState,
+				appStatus kargoapi.ArgoCDAppStatus,
+				err error,
+			) {
+				require.ErrorContains(t, err, `has health state "Degraded"`)
+				require.ErrorContains(
+					t,
+					err,
+					`Deployment "fake-deployment" of Argo CD Application "fake-name" in `+
+						`namespace "fake-namespace" is Degraded: Deployment exceeded its progress deadline`,
+				)
+
+				require.Equal(t, kargoapi.HealthStateUnhealthy, state)
+				require.Equal(t, []kargoapi.ArgoCDAppResourceStatus{
+					{
+						Group:     "apps",
+						Kind:      "Deployment",
+						Namespace: "fake-app-namespace",
+						Name:      "fake-deployment",
+						HealthStatus: kargoapi.ArgoCDAppHealthStatus{
+							Status:  kargoapi.ArgoCDAppHealthStateDegraded,
+							Message: "Deployment exceeded its progress deadline",
+						},
+					},
+				}, appStatus.UnhealthyResources)
 			},
 		},
 		{
@@ -498,8 +628,7 @@ func TestApplicationHealth_GetApplicationHealth(t *testing.T) {
 			assertions: func(
 				t *testing.T,
 				state kargoapi.HealthState,
-				healthStatus kargoapi.ArgoCDAppHealthStatus,
-				syncStatus kargoapi.ArgoCDAppSyncStatus,
+				appStatus kargoapi.ArgoCDAppStatus,
 				err error,
 			) {
 				require.NoError(t, err)
@@ -507,11 +636,11 @@ func TestApplicationHealth_GetApplicationHealth(t *testing.T) {
 				require.Equal(t, kargoapi.HealthStateHealthy, state)
 				require.Equal(t, kargoapi.ArgoCDAppHealthStatus{
 					Status: kargoapi.ArgoCDAppHealthStateHealthy,
-				}, healthStatus)
+				}, appStatus.HealthStatus)
 				require.Equal(t, kargoapi.ArgoCDAppSyncStatus{
 					Status:   kargoapi.ArgoCDAppSyncStateSynced,
 					Revision: "fake-revision",
-				}, syncStatus)
+				}, appStatus.SyncStatus)
 			},
 		},
 		{
@@ -548,8 +677,7 @@ func TestApplicationHealth_GetApplicationHealth(t *testing.T) {
 			assertions: func(
 				t *testing.T,
 				state kargoapi.HealthState,
-				healthStatus kargoapi.ArgoCDAppHealthStatus,
-				syncStatus kargoapi.ArgoCDAppSyncStatus,
+				appStatus kargoapi.ArgoCDAppStatus,
 				err error,
 			) {
 				require.NoError(t, err)
@@ -557,11 +685,11 @@ func TestApplicationHealth_GetApplicationHealth(t *testing.T) {
 				require.Equal(t, kargoapi.HealthStateHealthy, state)
 				require.Equal(t, kargoapi.ArgoCDAppHealthStatus{
 					Status: kargoapi.ArgoCDAppHealthStateHealthy,
-				}, healthStatus)
+				}, appStatus.HealthStatus)
 				require.Equal(t, kargoapi.ArgoCDAppSyncStatus{
 					Status:   kargoapi.ArgoCDAppSyncStateSynced,
 					Revision: "fake-revision",
-				}, syncStatus)
+				}, appStatus.SyncStatus)
 			},
 		},
 	}
@@ -575,12 +703,12 @@ func TestApplicationHealth_GetApplicationHealth(t *testing.T) {
 			h := &applicationHealth{
 				Client: c.Build(),
 			}
-			state, healthStatus, syncStatus, err := h.GetApplicationHealth(
+			state, appStatus, err := h.GetApplicationHealth(
 				context.TODO(),
 				testCase.key,
 				testCase.freight,
 			)
-			testCase.assertions(t, state, healthStatus, syncStatus, err)
+			testCase.assertions(t, state, appStatus, err)
 		})
 	}
 }
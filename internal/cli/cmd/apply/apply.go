@@ -31,6 +31,8 @@ type applyOptions struct {
 
 	Filenames []string
 	Recursive bool
+	DryRun    bool
+	Diff      bool
 }
 
 func NewCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra.Command {
@@ -50,6 +52,13 @@ kargo apply -f stage.yaml
 
 # Apply the YAML resources in the stages directory
 kargo apply -f stages/
+
+# Validate the resources in stage.yaml without applying them
+kargo apply -f stage.yaml --dry-run
+
+# Show how the resources in the stages directory differ from the current
+# resources, then apply them if they are valid
+kargo apply -f stages/ --diff
 `),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := cmdOpts.validate(); err != nil {
@@ -76,6 +85,15 @@ func (o *applyOptions) addFlags(cmd *cobra.Command) {
 
 	option.Filenames(cmd.Flags(), &o.Filenames, "Filename or directory to use to apply the resource(s)")
 	option.Recursive(cmd.Flags(), &o.Recursive)
+	option.DryRun(
+		cmd.Flags(), &o.DryRun,
+		"Validate the resource(s) on the server, including by admission webhooks, without applying them",
+	)
+	option.Diff(
+		cmd.Flags(), &o.Diff,
+		"Show how the resource(s) differ from the current resource(s) before applying them; "+
+			"nothing is applied if any resource is invalid",
+	)
 
 	if err := cmd.MarkFlagRequired(option.FilenameFlag); err != nil {
 		panic(fmt.Errorf("could not mark filename flag as required: %w", err))
@@ -112,6 +130,28 @@ func (o *applyOptions) run(ctx context.Context) error {
 		return fmt.Errorf("get client from config: %w", err)
 	}
 
+	if o.DryRun || o.Diff {
+		var resp *connect.Response[kargosvcapi.CreateOrUpdateResourceResponse]
+		if resp, err = kargoSvcCli.CreateOrUpdateResource(ctx,
+			connect.NewRequest(&kargosvcapi.CreateOrUpdateResourceRequest{
+				Manifest: manifest,
+				DryRun:   true,
+			})); err != nil {
+			return fmt.Errorf("validate resource: %w", err)
+		}
+		if o.Diff {
+			if err = o.printDiffs(resp.Msg.GetResults()); err != nil {
+				return err
+			}
+		}
+		if o.DryRun {
+			return o.printResults(resp.Msg.GetResults(), " (server dry run)")
+		}
+		if err = resultErrors(resp.Msg.GetResults()); err != nil {
+			return err
+		}
+	}
+
 	// TODO: Current implementation of apply is not the same as `kubectl` does.
 	// It actually "replaces" resource with the given file.
 	// We should provide the same implementation as `kubectl` does.
@@ -122,23 +162,29 @@ func (o *applyOptions) run(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("apply resource: %w", err)
 	}
+	return o.printResults(resp.Msg.GetResults(), "")
+}
 
-	resCap := len(resp.Msg.GetResults())
+// printResults prints the resources that were created or updated, as described
+// by the provided results, with the provided suffix appended to the operation.
+// Any errors described by the results are returned.
+func (o *applyOptions) printResults(
+	results []*kargosvcapi.CreateOrUpdateResourceResult,
+	operationSuffix string,
+) error {
+	resCap := len(results)
 	createdRes := make([]*kargosvcapi.CreateOrUpdateResourceResult_CreatedResourceManifest, 0, resCap)
 	updatedRes := make([]*kargosvcapi.CreateOrUpdateResourceResult_UpdatedResourceManifest, 0, resCap)
-	errs := make([]error, 0, resCap)
-	for _, r := range resp.Msg.GetResults() {
+	for _, r := range results {
 		switch typedRes := r.GetResult().(type) {
 		case *kargosvcapi.CreateOrUpdateResourceResult_CreatedResourceManifest:
 			createdRes = append(createdRes, typedRes)
 		case *kargosvcapi.CreateOrUpdateResourceResult_UpdatedResourceManifest:
 			updatedRes = append(updatedRes, typedRes)
-		case *kargosvcapi.CreateOrUpdateResourceResult_Error:
-			errs = append(errs, errors.New(typedRes.Error))
 		}
 	}
 
-	printer, err := o.toPrinter("created")
+	printer, err := o.toPrinter("created" + operationSuffix)
 	if err != nil {
 		return fmt.Errorf("new printer: %w", err)
 	}
@@ -153,7 +199,7 @@ func (o *applyOptions) run(ctx context.Context) error {
 		_ = printer.PrintObj(&obj, o.IOStreams.Out)
 	}
 
-	printer, err = o.toPrinter("updated")
+	printer, err = o.toPrinter("updated" + operationSuffix)
 	if err != nil {
 		return fmt.Errorf("new printer: %w", err)
 	}
@@ -167,6 +213,43 @@ func (o *applyOptions) run(ctx context.Context) error {
 		}
 		_ = printer.PrintObj(&obj, o.IOStreams.Out)
 	}
+	return resultErrors(results)
+}
+
+// printDiffs prints, for each resource described by the provided results of a
+// dry run, a diff between the resource as it currently exists and the resource
+// as it would exist if applied.
+func (o *applyOptions) printDiffs(results []*kargosvcapi.CreateOrUpdateResourceResult) error {
+	for _, r := range results {
+		var applied []byte
+		switch typedRes := r.GetResult().(type) {
+		case *kargosvcapi.CreateOrUpdateResourceResult_CreatedResourceManifest:
+			applied = typedRes.CreatedResourceManifest
+		case *kargosvcapi.CreateOrUpdateResourceResult_UpdatedResourceManifest:
+			applied = typedRes.UpdatedResourceManifest
+		default:
+			continue
+		}
+		diff, err := diffManifests(r.GetLiveResourceManifest(), applied)
+		if err != nil {
+			return fmt.Errorf("diff manifests: %w", err)
+		}
+		if _, err = fmt.Fprint(o.IOStreams.Out, diff); err != nil {
+			return fmt.Errorf("print diff: %w", err)
+		}
+	}
+	return nil
+}
+
+// resultErrors returns any errors described by the provided results, joined
+// together.
+func resultErrors(results []*kargosvcapi.CreateOrUpdateResourceResult) error {
+	errs := make([]error, 0, len(results))
+	for _, r := range results {
+		if typedRes, ok := r.GetResult().(*kargosvcapi.CreateOrUpdateResourceResult_Error); ok {
+			errs = append(errs, errors.New(typedRes.Error))
+		}
+	}
 	return errors.Join(errs...)
 }
 
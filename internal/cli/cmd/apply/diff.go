@@ -0,0 +1,65 @@
+package apply
+
+import (
+	"fmt"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	sigyaml "sigs.k8s.io/yaml"
+)
+
+// diffManifests returns a unified diff between the provided live and applied
+// manifests of a single resource. The live manifest is empty if the resource
+// does not exist yet. Fields managed exclusively by the server are ignored, so
+// that only changes to the resource itself are shown. If the manifests do not
+// differ, an empty string is returned.
+func diffManifests(live, applied []byte) (string, error) {
+	appliedObj, appliedYAML, err := normalizeManifest(applied)
+	if err != nil {
+		return "", fmt.Errorf("normalize applied manifest: %w", err)
+	}
+	var liveYAML string
+	if len(live) > 0 {
+		if _, liveYAML, err = normalizeManifest(live); err != nil {
+			return "", fmt.Errorf("normalize live manifest: %w", err)
+		}
+	}
+	if liveYAML == appliedYAML {
+		return "", nil
+	}
+	name := fmt.Sprintf("%s/%s", appliedObj.GetKind(), appliedObj.GetName())
+	if ns := appliedObj.GetNamespace(); ns != "" {
+		name = fmt.Sprintf("%s/%s/%s", appliedObj.GetKind(), ns, appliedObj.GetName())
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(liveYAML),
+		B:        difflib.SplitLines(appliedYAML),
+		FromFile: "live/" + name,
+		ToFile:   "applied/" + name,
+		Context:  3,
+	})
+}
+
+// normalizeManifest parses the provided manifest, removes fields managed
+// exclusively by the server, and returns both the parsed resource and the
+// resulting YAML.
+func normalizeManifest(manifest []byte) (*unstructured.Unstructured, string, error) {
+	obj := &unstructured.Unstructured{}
+	if err := sigyaml.Unmarshal(manifest, obj); err != nil {
+		return nil, "", err
+	}
+	for _, field := range []string{
+		"creationTimestamp",
+		"generation",
+		"managedFields",
+		"resourceVersion",
+		"uid",
+	} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	normalized, err := sigyaml.Marshal(obj.Object)
+	if err != nil {
+		return nil, "", err
+	}
+	return obj, string(normalized), nil
+}
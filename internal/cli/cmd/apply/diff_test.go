@@ -0,0 +1,88 @@
+package apply
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffManifests(t *testing.T) {
+	const liveManifest = `apiVersion: kargo.akuity.io/v1alpha1
+kind: Stage
+metadata:
+  name: test
+  namespace: kargo-demo
+  resourceVersion: "1"
+  generation: 1
+  uid: fake-uid
+spec:
+  shard: a
+`
+	testCases := []struct {
+		name       string
+		live       string
+		applied    string
+		assertions func(t *testing.T, diff string, err error)
+	}{
+		{
+			name:    "invalid applied manifest",
+			applied: "{",
+			assertions: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, "normalize applied manifest")
+			},
+		},
+		{
+			name:    "unchanged apart from server-managed fields",
+			live:    liveManifest,
+			applied: strings.Replace(liveManifest, `resourceVersion: "1"`, `resourceVersion: "2"`, 1),
+			assertions: func(t *testing.T, diff string, err error) {
+				require.NoError(t, err)
+				require.Empty(t, diff)
+			},
+		},
+		{
+			name: "updated",
+			live: liveManifest,
+			applied: `apiVersion: kargo.akuity.io/v1alpha1
+kind: Stage
+metadata:
+  name: test
+  namespace: kargo-demo
+  resourceVersion: "2"
+  generation: 2
+  uid: fake-uid
+spec:
+  shard: b
+`,
+			assertions: func(t *testing.T, diff string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, diff, "--- live/Stage/kargo-demo/test")
+				require.Contains(t, diff, "+++ applied/Stage/kargo-demo/test")
+				require.Contains(t, diff, "-  shard: a\n")
+				require.Contains(t, diff, "+  shard: b\n")
+				require.NotContains(t, diff, "resourceVersion")
+				require.NotContains(t, diff, "generation")
+			},
+		},
+		{
+			name: "created",
+			applied: `apiVersion: kargo.akuity.io/v1alpha1
+kind: Project
+metadata:
+  name: kargo-demo
+`,
+			assertions: func(t *testing.T, diff string, err error) {
+				require.NoError(t, err)
+				require.Contains(t, diff, "+++ applied/Project/kargo-demo")
+				require.Contains(t, diff, "+kind: Project\n")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			diff, err := diffManifests([]byte(testCase.live), []byte(testCase.applied))
+			testCase.assertions(t, diff, err)
+		})
+	}
+}
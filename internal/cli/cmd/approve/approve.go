@@ -4,17 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/spf13/cobra"
 
 	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/completion"
 	"github.com/akuity/kargo/internal/cli/config"
 	"github.com/akuity/kargo/internal/cli/option"
 	"github.com/akuity/kargo/internal/cli/templates"
 	v1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
 )
 
+// validForFlag is the flag name for the valid-for flag.
+const validForFlag = "valid-for"
+
 type approvalOptions struct {
 	Config        config.CLIConfig
 	ClientOptions client.Options
@@ -23,6 +28,7 @@ type approvalOptions struct {
 	FreightName  string
 	FreightAlias string
 	Stage        string
+	ValidFor     time.Duration
 }
 
 func NewCommand(cfg config.CLIConfig) *cobra.Command {
@@ -31,7 +37,8 @@ func NewCommand(cfg config.CLIConfig) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Use:   "approve [--project=project] (--freight=freight | --freight-alias=alias) --stage=stage",
+		Use: "approve [--project=project] (--freight=freight | --freight-alias=alias) --stage=stage " +
+			"[--valid-for=duration]",
 		Short: "Manually approve a piece of freight for promotion to a stage",
 		Args:  option.NoArgs,
 		Example: templates.Example(`
@@ -41,6 +48,10 @@ kargo approve --project=my-project --freight=abc1234 --stage=qa
 # Approve a piece of freight specified by alias for the QA stage
 kargo approve --project=my-project --freight-alias=wonky-wombat --stage=qa
 
+# Approve a piece of freight for the prod stage for 72 hours, after which the
+# approval is revoked if the freight has not been promoted to the stage
+kargo approve --project=my-project --freight=abc1234 --stage=prod --valid-for=72h
+
 # Approve a piece of freight specified by name for the QA stage in the default project
 kargo config set-project my-project
 kargo approve --freight=abc1234 --stage=qa
@@ -75,6 +86,11 @@ func (o *approvalOptions) addFlags(cmd *cobra.Command) {
 	option.Freight(cmd.Flags(), &o.FreightName, "The name of the freight to approve.")
 	option.FreightAlias(cmd.Flags(), &o.FreightAlias, "The alias of the freight to approve.")
 	option.Stage(cmd.Flags(), &o.Stage, "The stage for which to approve the freight.")
+	cmd.Flags().DurationVar(
+		&o.ValidFor, validForFlag, 0,
+		"How long the approval remains valid if the freight is not promoted to the stage. "+
+			"If not set, the approval does not expire.",
+	)
 
 	if err := cmd.MarkFlagRequired(option.StageFlag); err != nil {
 		panic(fmt.Errorf("could not mark %s flag as required: %w", option.StageFlag, err))
@@ -82,6 +98,13 @@ func (o *approvalOptions) addFlags(cmd *cobra.Command) {
 
 	cmd.MarkFlagsOneRequired(option.FreightFlag, option.FreightAliasFlag)
 	cmd.MarkFlagsMutuallyExclusive(option.FreightFlag, option.FreightAliasFlag)
+
+	completion.RegisterProjectFlag(cmd, o.Config, &o.ClientOptions)
+	completion.RegisterFreightFlags(
+		cmd, o.Config, &o.ClientOptions, &o.Project,
+		option.FreightFlag, option.FreightAliasFlag,
+	)
+	completion.RegisterStageFlags(cmd, o.Config, &o.ClientOptions, &o.Project, option.StageFlag)
 }
 
 // validate performs validation of the options. If the options are invalid, an
@@ -102,6 +125,9 @@ func (o *approvalOptions) validate() error {
 	if o.Stage == "" {
 		errs = append(errs, fmt.Errorf("%s is required", option.StageFlag))
 	}
+	if o.ValidFor < 0 {
+		errs = append(errs, fmt.Errorf("%s must not be negative", validForFlag))
+	}
 	return errors.Join(errs...)
 }
 
@@ -112,17 +138,16 @@ func (o *approvalOptions) run(ctx context.Context) error {
 		return fmt.Errorf("get client from config: %w", err)
 	}
 
-	if _, err = kargoSvcCli.ApproveFreight(
-		ctx,
-		connect.NewRequest(
-			&v1alpha1.ApproveFreightRequest{
-				Project: o.Project,
-				Name:    o.FreightName,
-				Alias:   o.FreightAlias,
-				Stage:   o.Stage,
-			},
-		),
-	); err != nil {
+	req := &v1alpha1.ApproveFreightRequest{
+		Project: o.Project,
+		Name:    o.FreightName,
+		Alias:   o.FreightAlias,
+		Stage:   o.Stage,
+	}
+	if o.ValidFor > 0 {
+		req.ValidFor = o.ValidFor.String()
+	}
+	if _, err = kargoSvcCli.ApproveFreight(ctx, connect.NewRequest(req)); err != nil {
 		return fmt.Errorf("approve freight: %w", err)
 	}
 	return nil
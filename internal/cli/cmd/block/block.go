@@ -0,0 +1,125 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/config"
+	"github.com/akuity/kargo/internal/cli/option"
+	"github.com/akuity/kargo/internal/cli/templates"
+	v1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+type blockOptions struct {
+	Config        config.CLIConfig
+	ClientOptions client.Options
+
+	Project      string
+	FreightName  string
+	FreightAlias string
+	Reason       string
+}
+
+func NewCommand(cfg config.CLIConfig) *cobra.Command {
+	cmdOpts := &blockOptions{
+		Config: cfg,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "block [--project=project] (--freight=freight | --freight-alias=alias) --reason=reason",
+		Short: "Block a piece of freight from being promoted to any stage",
+		Args:  option.NoArgs,
+		Example: templates.Example(`
+# Block a piece of freight specified by name
+kargo block --project=my-project --freight=abc1234 --reason="Causes data loss"
+
+# Block a piece of freight specified by alias
+kargo block --project=my-project --freight-alias=wonky-wombat --reason="Causes data loss"
+
+# Block a piece of freight specified by name in the default project
+kargo config set-project my-project
+kargo block --freight=abc1234 --reason="Causes data loss"
+`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := cmdOpts.validate(); err != nil {
+				return err
+			}
+
+			return cmdOpts.run(cmd.Context())
+		},
+	}
+
+	// Register the option flags on the command.
+	cmdOpts.addFlags(cmd)
+
+	return cmd
+}
+
+// addFlags adds the flags for the block options to the provided command.
+func (o *blockOptions) addFlags(cmd *cobra.Command) {
+	o.ClientOptions.AddFlags(cmd.PersistentFlags())
+
+	option.Project(
+		cmd.Flags(), &o.Project, o.Config.Project,
+		"The project the freight belongs to. If not set, the default project will be used.",
+	)
+	option.Freight(cmd.Flags(), &o.FreightName, "The name of the freight to block.")
+	option.FreightAlias(cmd.Flags(), &o.FreightAlias, "The alias of the freight to block.")
+	option.Reason(cmd.Flags(), &o.Reason, "The reason the freight is being blocked.")
+
+	if err := cmd.MarkFlagRequired(option.ReasonFlag); err != nil {
+		panic(fmt.Errorf("could not mark %s flag as required: %w", option.ReasonFlag, err))
+	}
+
+	cmd.MarkFlagsOneRequired(option.FreightFlag, option.FreightAliasFlag)
+	cmd.MarkFlagsMutuallyExclusive(option.FreightFlag, option.FreightAliasFlag)
+}
+
+// validate performs validation of the options. If the options are invalid, an
+// error is returned.
+func (o *blockOptions) validate() error {
+	var errs []error
+	// While the flags are marked as required, a user could still provide an empty
+	// string. This is a check to ensure that the flags are not empty.
+	if o.Project == "" {
+		errs = append(errs, fmt.Errorf("%s is required", option.ProjectFlag))
+	}
+	if o.FreightName == "" && o.FreightAlias == "" {
+		errs = append(
+			errs,
+			fmt.Errorf("either %s or %s is required", option.FreightFlag, option.FreightAliasFlag),
+		)
+	}
+	if o.Reason == "" {
+		errs = append(errs, fmt.Errorf("%s is required", option.ReasonFlag))
+	}
+	return errors.Join(errs...)
+}
+
+// run blocks a piece of freight based on the options.
+func (o *blockOptions) run(ctx context.Context) error {
+	kargoSvcCli, err := client.GetClientFromConfig(ctx, o.Config, o.ClientOptions)
+	if err != nil {
+		return fmt.Errorf("get client from config: %w", err)
+	}
+
+	if _, err = kargoSvcCli.BlockFreight(
+		ctx,
+		connect.NewRequest(
+			&v1alpha1.BlockFreightRequest{
+				Project: o.Project,
+				Name:    o.FreightName,
+				Alias:   o.FreightAlias,
+				Reason:  o.Reason,
+			},
+		),
+	); err != nil {
+		return fmt.Errorf("block freight: %w", err)
+	}
+	return nil
+}
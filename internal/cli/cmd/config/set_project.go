@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/akuity/kargo/internal/cli/completion"
 	"github.com/akuity/kargo/internal/cli/config"
 	"github.com/akuity/kargo/internal/cli/option"
 	"github.com/akuity/kargo/internal/cli/templates"
@@ -36,6 +37,7 @@ kargo config set-project ""
 
 			return cmdOpts.run()
 		},
+		ValidArgsFunction: completion.FirstArg(completion.ProjectNames(cfg, nil)),
 	}
 	return cmd
 }
@@ -34,6 +34,7 @@ type createCredentialsOptions struct {
 	Git         bool
 	Helm        bool
 	Image       bool
+	Package     bool
 	Type        string
 	RepoURL     string
 	Regex       bool
@@ -50,7 +51,7 @@ func newCredentialsCommand(cfg config.CLIConfig, streams genericiooptions.IOStre
 
 	cmd := &cobra.Command{
 		Use: `credentials [--project=project] NAME \
-    (--git | --helm | --image) \
+    (--git | --helm | --image | --package) \
     [--description=description] \
     --repo-url=repo-url [--regex] \
     -username=username \
@@ -126,6 +127,7 @@ func (o *createCredentialsOptions) addFlags(cmd *cobra.Command) {
 	option.Git(cmd.Flags(), &o.Git, "Create credentials for a Git repository.")
 	option.Helm(cmd.Flags(), &o.Helm, "Create credentials for a Helm chart repository.")
 	option.Image(cmd.Flags(), &o.Image, "Create credentials for a container image repository.")
+	option.Package(cmd.Flags(), &o.Package, "Create credentials for a package registry.")
 	option.Type(cmd.Flags(), &o.Type, "Type of repository the credentials are for.")
 	option.RepoURL(cmd.Flags(), &o.RepoURL, "URL of the repository the credentials are for.")
 	option.Regex(
@@ -138,8 +140,8 @@ func (o *createCredentialsOptions) addFlags(cmd *cobra.Command) {
 	option.Username(cmd.Flags(), &o.Username, "Username for the credentials.")
 	option.Password(cmd.Flags(), &o.Password, "Password for the credentials.")
 
-	cmd.MarkFlagsOneRequired(option.GitFlag, option.HelmFlag, option.ImageFlag, option.TypeFlag)
-	cmd.MarkFlagsMutuallyExclusive(option.GitFlag, option.HelmFlag, option.ImageFlag, option.TypeFlag)
+	cmd.MarkFlagsOneRequired(option.GitFlag, option.HelmFlag, option.ImageFlag, option.PackageFlag, option.TypeFlag)
+	cmd.MarkFlagsMutuallyExclusive(option.GitFlag, option.HelmFlag, option.ImageFlag, option.PackageFlag, option.TypeFlag)
 
 	if err := cmd.MarkFlagRequired(option.RepoURLFlag); err != nil {
 		panic(
@@ -210,6 +212,8 @@ func (o *createCredentialsOptions) run(ctx context.Context) error {
 		o.Type = credentials.TypeHelm.String()
 	} else if o.Image {
 		o.Type = credentials.TypeImage.String()
+	} else if o.Package {
+		o.Type = credentials.TypePackage.String()
 	}
 
 	resp, err := kargoSvcCli.CreateCredentials(
@@ -15,6 +15,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/completion"
 	"github.com/akuity/kargo/internal/cli/config"
 	"github.com/akuity/kargo/internal/cli/io"
 	"github.com/akuity/kargo/internal/cli/kubernetes"
@@ -32,9 +33,13 @@ type getFreightOptions struct {
 	Config        config.CLIConfig
 	ClientOptions client.Options
 
-	Project string
-	Names   []string
-	Aliases []string
+	Project     string
+	Names       []string
+	Aliases     []string
+	Origin      string
+	VerifiedIn  string
+	ApprovedFor string
+	Artifact    string
 }
 
 func newGetFreightCommand(
@@ -51,7 +56,9 @@ func newGetFreightCommand(
 	}
 
 	cmd := &cobra.Command{
-		Use:   "freight [--project=project] [--name=name | --alias=alias] [--no-headers]",
+		Use: "freight [--project=project] [--name=name | --alias=alias] " +
+			"[--origin=warehouse] [--verified-in=stage] [--approved-for=stage] " +
+			"[--artifact=substring] [--no-headers]",
 		Short: "Display one or many pieces of freight",
 		Args:  option.NoArgs,
 		Example: templates.Example(`
@@ -67,6 +74,16 @@ kargo get freight --project=my-project --name=abc1234
 # Get a single piece of freight by alias
 kargo get freight --project=my-project --alias=wonky-wombat
 
+# List all freight in my-project originating from the warehouse named my-warehouse
+kargo get freight --project=my-project --origin=my-warehouse
+
+# List all freight in my-project that has been verified in the uat stage and
+# references an artifact with a tag, version, etc. containing 1.42.
+kargo get freight --project=my-project --verified-in=uat --artifact=1.42.
+
+# List all freight in my-project that has been approved for the prod stage
+kargo get freight --project=my-project --approved-for=prod
+
 # List all freight in the default project
 kargo config set-project my-project
 kargo get freight
@@ -108,6 +125,44 @@ func (o *getFreightOptions) addFlags(cmd *cobra.Command) {
 	)
 	option.Names(cmd.Flags(), &o.Names, "The name of a piece of freight to get.")
 	option.Aliases(cmd.Flags(), &o.Aliases, "The alias of a piece of freight to get.")
+	option.Origin(
+		cmd.Flags(), &o.Origin,
+		"Only list freight originating from the warehouse with this name.",
+	)
+	option.VerifiedIn(
+		cmd.Flags(), &o.VerifiedIn,
+		"Only list freight that has been verified in the stage with this name.",
+	)
+	option.ApprovedFor(
+		cmd.Flags(), &o.ApprovedFor,
+		"Only list freight that has been approved for the stage with this name.",
+	)
+	option.Artifact(
+		cmd.Flags(), &o.Artifact,
+		"Only list freight referencing an image, commit, or chart whose repository URL, "+
+			"name, tag, digest, commit ID, or version contains this substring.",
+	)
+
+	// Filters only apply when listing freight.
+	for _, filterFlag := range []string{
+		option.OriginFlag,
+		option.VerifiedInFlag,
+		option.ApprovedForFlag,
+		option.ArtifactFlag,
+	} {
+		cmd.MarkFlagsMutuallyExclusive(option.NameFlag, filterFlag)
+		cmd.MarkFlagsMutuallyExclusive(option.AliasFlag, filterFlag)
+	}
+
+	completion.RegisterProjectFlag(cmd, o.Config, &o.ClientOptions)
+	completion.RegisterFreightFlags(
+		cmd, o.Config, &o.ClientOptions, &o.Project,
+		option.NameFlag, option.AliasFlag,
+	)
+	completion.RegisterStageFlags(
+		cmd, o.Config, &o.ClientOptions, &o.Project,
+		option.VerifiedInFlag, option.ApprovedForFlag,
+	)
 }
 
 // validate performs validation of the options. If the options are invalid, an
@@ -134,7 +189,11 @@ func (o *getFreightOptions) run(ctx context.Context) error {
 			ctx,
 			connect.NewRequest(
 				&v1alpha1.QueryFreightRequest{
-					Project: o.Project,
+					Project:     o.Project,
+					Origin:      o.Origin,
+					VerifiedIn:  o.VerifiedIn,
+					ApprovedFor: o.ApprovedFor,
+					Artifact:    o.Artifact,
 				},
 			),
 		); err != nil {
@@ -196,10 +255,18 @@ func newFreightTable(list *metav1.List) *metav1.Table {
 		if freight.Labels != nil {
 			alias = freight.Labels[kargoapi.AliasLabelKey]
 		}
+		var blocked string
+		if freight.IsBlocked() {
+			blocked = freight.Status.Blocked.Reason
+			if blocked == "" {
+				blocked = "Blocked"
+			}
+		}
 		rows[i] = metav1.TableRow{
 			Cells: []any{
 				freight.Name,
 				alias,
+				blocked,
 				duration.HumanDuration(time.Since(freight.CreationTimestamp.Time)),
 			},
 			Object: list.Items[i],
@@ -209,6 +276,7 @@ func newFreightTable(list *metav1.List) *metav1.Table {
 		ColumnDefinitions: []metav1.TableColumnDefinition{
 			{Name: "Name", Type: "string"},
 			{Name: "Alias", Type: "string"},
+			{Name: "Blocked", Type: "string"},
 			{Name: "Age", Type: "string"},
 		},
 		Rows: rows,
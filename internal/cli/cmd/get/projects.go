@@ -16,6 +16,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/completion"
 	"github.com/akuity/kargo/internal/cli/config"
 	"github.com/akuity/kargo/internal/cli/io"
 	"github.com/akuity/kargo/internal/cli/kubernetes"
@@ -81,6 +82,8 @@ kargo get project my-project
 func (o *getProjectsOptions) addFlags(cmd *cobra.Command) {
 	o.ClientOptions.AddFlags(cmd.PersistentFlags())
 	o.PrintFlags.AddFlags(cmd)
+
+	cmd.ValidArgsFunction = completion.ProjectNames(o.Config, &o.ClientOptions)
 }
 
 // complete sets the options from the command arguments.
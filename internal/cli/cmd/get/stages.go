@@ -16,6 +16,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/completion"
 	"github.com/akuity/kargo/internal/cli/config"
 	"github.com/akuity/kargo/internal/cli/io"
 	"github.com/akuity/kargo/internal/cli/kubernetes"
@@ -100,6 +101,9 @@ func (o *getStagesOptions) addFlags(cmd *cobra.Command) {
 		cmd.Flags(), &o.Project, o.Config.Project,
 		"The project for which to list stages. If not set, the default project will be used.",
 	)
+
+	completion.RegisterProjectFlag(cmd, o.Config, &o.ClientOptions)
+	cmd.ValidArgsFunction = completion.StageNames(o.Config, &o.ClientOptions, &o.Project)
 }
 
 // complete sets the options from the command arguments.
@@ -83,6 +83,9 @@ kargo grant --project=my-project --role=my-role \
 	// Set the input/output streams for the command.
 	io.SetIOStreams(cmd, cmdOpts.IOStreams)
 
+	// Register subcommands.
+	cmd.AddCommand(newRoleCommand(cfg, streams))
+
 	return cmd
 }
 
@@ -0,0 +1,151 @@
+package grant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
+	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/config"
+	"github.com/akuity/kargo/internal/cli/io"
+	"github.com/akuity/kargo/internal/cli/kubernetes"
+	"github.com/akuity/kargo/internal/cli/option"
+	"github.com/akuity/kargo/internal/cli/templates"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+type grantRoleOptions struct {
+	genericiooptions.IOStreams
+	*genericclioptions.PrintFlags
+
+	Config        config.CLIConfig
+	ClientOptions client.Options
+
+	Project  string
+	Role     string
+	Subjects []string
+
+	userClaims *rbacapi.UserClaims
+}
+
+func newRoleCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra.Command {
+	cmdOpts := &grantRoleOptions{
+		Config:     cfg,
+		IOStreams:  streams,
+		PrintFlags: genericclioptions.NewPrintFlags("updated").WithTypeSetter(kubernetes.GetScheme()),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "role [--project=project] NAME --to=KIND:VALUE [--to=KIND:VALUE]...",
+		Short: "Grant a role to users identified by their sub claim, email address, or group",
+		Args:  option.ExactArgs(1),
+		Example: templates.Example(`
+# Grant my-role to members of the platform-team group
+kargo grant role my-role --project=my-project --to=group:platform-team
+
+# Grant my-role to users with specific email addresses and sub claims
+kargo grant role my-role --project=my-project \
+  --to=email:alice@example.com --to=sub:1234567890
+
+# Grant my-role in the default project to members of the platform-team group
+kargo config set-project my-project
+kargo grant role my-role --to=group:platform-team
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdOpts.complete(args)
+
+			if err := cmdOpts.validate(); err != nil {
+				return err
+			}
+
+			return cmdOpts.run(cmd.Context())
+		},
+	}
+
+	// Register the option flags on the command.
+	cmdOpts.addFlags(cmd)
+
+	// Set the input/output streams for the command.
+	io.SetIOStreams(cmd, cmdOpts.IOStreams)
+
+	return cmd
+}
+
+// addFlags adds the flags for the grant role options to the provided command.
+func (o *grantRoleOptions) addFlags(cmd *cobra.Command) {
+	o.ClientOptions.AddFlags(cmd.PersistentFlags())
+	o.PrintFlags.AddFlags(cmd)
+
+	option.Project(
+		cmd.Flags(), &o.Project, o.Config.Project,
+		"The project in which to grant the role. If not set, the default project will be used.",
+	)
+	option.To(
+		cmd.Flags(), &o.Subjects,
+		"A subject to be granted the role, of the form KIND:VALUE, where KIND is one of sub, email, or group.",
+	)
+
+	if err := cmd.MarkFlagRequired(option.ToFlag); err != nil {
+		panic(fmt.Errorf("could not mark %s flag as required: %w", option.ToFlag, err))
+	}
+}
+
+// complete sets the options from the command arguments.
+func (o *grantRoleOptions) complete(args []string) {
+	o.Role = strings.TrimSpace(args[0])
+}
+
+// validate performs validation of the options. If the options are invalid, an
+// error is returned.
+func (o *grantRoleOptions) validate() error {
+	var errs []error
+	// While the flags are marked as required, a user could still provide an empty
+	// string. This is a check to ensure that the flags are not empty.
+	if o.Project == "" {
+		errs = append(errs, fmt.Errorf("%s is required", option.ProjectFlag))
+	}
+	if o.Role == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	var err error
+	if o.userClaims, err = option.ParseSubjects(o.Subjects); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// run grants a role to the users identified by the subjects.
+func (o *grantRoleOptions) run(ctx context.Context) error {
+	kargoSvcCli, err := client.GetClientFromConfig(ctx, o.Config, o.ClientOptions)
+	if err != nil {
+		return fmt.Errorf("get client from config: %w", err)
+	}
+
+	resp, err := kargoSvcCli.Grant(
+		ctx,
+		connect.NewRequest(&svcv1alpha1.GrantRequest{
+			Project: o.Project,
+			Role:    o.Role,
+			Request: &svcv1alpha1.GrantRequest_UserClaims{
+				UserClaims: o.userClaims,
+			},
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("grant: %w", err)
+	}
+
+	printer, err := o.PrintFlags.ToPrinter()
+	if err != nil {
+		return fmt.Errorf("new printer: %w", err)
+	}
+
+	return printer.PrintObj(resp.Msg.Role, o.IOStreams.Out)
+}
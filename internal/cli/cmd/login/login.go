@@ -21,11 +21,14 @@ import (
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/spf13/cobra"
 	"golang.org/x/oauth2"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
 	"k8s.io/utils/strings/slices"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/cli/client"
 	libConfig "github.com/akuity/kargo/internal/cli/config"
+	"github.com/akuity/kargo/internal/cli/io"
 	"github.com/akuity/kargo/internal/cli/option"
 	"github.com/akuity/kargo/internal/cli/templates"
 	"github.com/akuity/kargo/internal/kubeclient"
@@ -38,6 +41,8 @@ const defaultRandStringCharSet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRST
 var assets embed.FS
 
 type loginOptions struct {
+	genericiooptions.IOStreams
+
 	Config        libConfig.CLIConfig
 	InsecureTLS   bool
 	UseAdmin      bool
@@ -50,9 +55,11 @@ type loginOptions struct {
 
 func NewCommand(
 	cfg libConfig.CLIConfig,
+	streams genericiooptions.IOStreams,
 ) *cobra.Command {
 	cmdOpts := &loginOptions{
-		Config: cfg,
+		Config:    cfg,
+		IOStreams: streams,
 	}
 
 	cmd := &cobra.Command{
@@ -89,6 +96,9 @@ kargo login https://kargo.example.com --kubeconfig --insecure-tls
 	// Register the option flags on the command.
 	cmdOpts.addFlags(cmd)
 
+	// Set the input/output streams for the command.
+	io.SetIOStreams(cmd, cmdOpts.IOStreams)
+
 	return cmd
 }
 
@@ -187,9 +197,35 @@ func (o *loginOptions) run(ctx context.Context) error {
 	); err != nil {
 		return fmt.Errorf("error persisting configuration: %w", err)
 	}
+
+	o.printAnnouncements(ctx, bearerToken)
 	return nil
 }
 
+// printAnnouncements prints any announcements published by the operators of
+// the Kargo API server, including those targeting the default project. Since
+// announcements are informational, failure to retrieve them (e.g. from a
+// server that predates them) does not fail the login.
+func (o *loginOptions) printAnnouncements(ctx context.Context, bearerToken string) {
+	kargoClient := client.GetClient(o.ServerAddress, bearerToken, o.InsecureTLS)
+	res, err := kargoClient.ListAnnouncements(
+		ctx,
+		connect.NewRequest(&v1alpha1.ListAnnouncementsRequest{
+			Project: o.Config.Project,
+		}),
+	)
+	if err != nil {
+		return
+	}
+	for _, a := range res.Msg.GetAnnouncements() {
+		severity := a.Severity
+		if severity == "" {
+			severity = kargoapi.AnnouncementSeverityInfo
+		}
+		_, _ = fmt.Fprintf(o.Out, "[%s] %s\n", severity, a.Message)
+	}
+}
+
 func adminLogin(
 	ctx context.Context,
 	serverAddress string,
@@ -0,0 +1,27 @@
+package logs
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"github.com/akuity/kargo/internal/cli/config"
+	"github.com/akuity/kargo/internal/cli/option"
+	"github.com/akuity/kargo/internal/cli/templates"
+)
+
+func NewCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs SUBCOMMAND",
+		Short: "Print logs",
+		Args:  option.NoArgs,
+		Example: templates.Example(`
+# Print the logs of the verification of a stage's current freight
+kargo logs verification --project=my-project my-stage
+`),
+	}
+
+	// Register subcommands.
+	cmd.AddCommand(newVerificationCommand(cfg, streams))
+
+	return cmd
+}
@@ -0,0 +1,208 @@
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/config"
+	"github.com/akuity/kargo/internal/cli/io"
+	"github.com/akuity/kargo/internal/cli/option"
+	"github.com/akuity/kargo/internal/cli/templates"
+	v1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+type verificationOptions struct {
+	genericiooptions.IOStreams
+
+	Config        config.CLIConfig
+	ClientOptions client.Options
+
+	Project   string
+	Stage     string
+	ID        string
+	Metric    string
+	Container string
+}
+
+func newVerificationCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra.Command {
+	cmdOpts := &verificationOptions{
+		Config:    cfg,
+		IOStreams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "verification [--project=project] STAGE [--id=id] [--metric=metric] [--container=container]",
+		Short: "Print the logs of the verification of the stage's freight",
+		Args:  option.ExactArgs(1),
+		Example: templates.Example(`
+# Print the logs of the verification of the stage's current freight
+kargo logs verification --project=my-project my-stage
+
+# Print the logs of a previous verification of the stage's current freight
+kargo logs verification --project=my-project my-stage --id=abc123
+
+# Print the logs of a single metric of the verification
+kargo logs verification --project=my-project my-stage --metric=integration-test
+
+# Print the logs of the verification of a stage in the default project
+kargo config set-project my-project
+kargo logs verification my-stage
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdOpts.complete(args)
+
+			if err := cmdOpts.validate(); err != nil {
+				return err
+			}
+
+			return cmdOpts.run(cmd.Context())
+		},
+	}
+
+	// Register the option flags on the command.
+	cmdOpts.addFlags(cmd)
+
+	// Set the input/output streams for the command.
+	io.SetIOStreams(cmd, cmdOpts.IOStreams)
+
+	return cmd
+}
+
+// addFlags adds the flags for the verification options to the provided
+// command.
+func (o *verificationOptions) addFlags(cmd *cobra.Command) {
+	o.ClientOptions.AddFlags(cmd.PersistentFlags())
+
+	option.Project(
+		cmd.Flags(), &o.Project, o.Config.Project,
+		"The project the stage belongs to. If not set, the default project will be used.",
+	)
+	cmd.Flags().StringVar(
+		&o.ID, "id", "",
+		"The ID of the verification to print the logs of. If not set, the logs "+
+			"of the most recent verification of the stage's current freight are "+
+			"printed.",
+	)
+	cmd.Flags().StringVar(
+		&o.Metric, "metric", "",
+		"The name of the metric to print the logs of. If not set, the logs of "+
+			"all metrics are printed.",
+	)
+	cmd.Flags().StringVar(
+		&o.Container, "container", "",
+		"The name of the container to print the logs of. If not set, the logs "+
+			"of all containers are printed.",
+	)
+}
+
+// complete sets the options from the command arguments.
+func (o *verificationOptions) complete(args []string) {
+	o.Stage = strings.TrimSpace(strings.ToLower(args[0]))
+}
+
+// validate performs validation of the options. If the options are invalid, an
+// error is returned.
+func (o *verificationOptions) validate() error {
+	var errs []error
+	// While the flags are marked as required, a user could still provide an empty
+	// string. This is a check to ensure that the flags are not empty.
+	if o.Project == "" {
+		errs = append(errs, fmt.Errorf("%s is required", option.ProjectFlag))
+	}
+	if o.Stage == "" {
+		errs = append(errs, errors.New("stage is required"))
+	}
+	return errors.Join(errs...)
+}
+
+// run prints the logs of the verification of the stage's current freight.
+func (o *verificationOptions) run(ctx context.Context) error {
+	kargoSvcCli, err := client.GetClientFromConfig(ctx, o.Config, o.ClientOptions)
+	if err != nil {
+		return fmt.Errorf("get client from config: %w", err)
+	}
+
+	stageRes, err := kargoSvcCli.GetStage(
+		ctx,
+		connect.NewRequest(&v1alpha1.GetStageRequest{
+			Project: o.Project,
+			Name:    o.Stage,
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("get stage: %w", err)
+	}
+
+	verification, err := findVerification(stageRes.Msg.GetStage(), o.ID)
+	if err != nil {
+		return err
+	}
+
+	res, err := kargoSvcCli.GetAnalysisRunLogs(
+		ctx,
+		connect.NewRequest(&v1alpha1.GetAnalysisRunLogsRequest{
+			Namespace:     verification.AnalysisRun.Namespace,
+			Name:          verification.AnalysisRun.Name,
+			MetricName:    o.Metric,
+			ContainerName: o.Container,
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("get verification logs: %w", err)
+	}
+	defer res.Close()
+	for res.Receive() {
+		msg := res.Msg()
+		_, _ = fmt.Fprintf(
+			o.Out,
+			"[%s/%s/%s] %s\n",
+			msg.GetMetricName(),
+			msg.GetPodName(),
+			msg.GetContainerName(),
+			msg.GetChunk(),
+		)
+	}
+	if err = res.Err(); err != nil {
+		return fmt.Errorf("get verification logs: %w", err)
+	}
+	return nil
+}
+
+// findVerification returns the verification of the provided Stage's current
+// Freight with the provided ID or, if the ID is empty, the most recent
+// verification of the Stage's current Freight. An error is returned if no such
+// verification is found or if it has no associated AnalysisRun.
+func findVerification(stage *kargoapi.Stage, id string) (*kargoapi.VerificationInfo, error) {
+	if stage == nil || stage.Status.CurrentFreight == nil {
+		return nil, errors.New("stage has no current freight")
+	}
+	history := stage.Status.CurrentFreight.VerificationHistory
+	var verification *kargoapi.VerificationInfo
+	if id == "" {
+		verification = history.Current()
+	} else {
+		for i := range history {
+			if history[i].ID == id {
+				verification = &history[i]
+				break
+			}
+		}
+	}
+	switch {
+	case verification == nil && id == "":
+		return nil, errors.New("stage's current freight has not been verified")
+	case verification == nil:
+		return nil, fmt.Errorf("stage's current freight has no verification with ID %q", id)
+	case !verification.HasAnalysisRun():
+		return nil, fmt.Errorf("verification %q has no associated AnalysisRun", verification.ID)
+	}
+	return verification, nil
+}
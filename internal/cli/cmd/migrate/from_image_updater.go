@@ -0,0 +1,224 @@
+package migrate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"github.com/akuity/kargo/internal/cli/config"
+	cliio "github.com/akuity/kargo/internal/cli/io"
+	"github.com/akuity/kargo/internal/cli/kubernetes"
+	"github.com/akuity/kargo/internal/cli/option"
+	"github.com/akuity/kargo/internal/cli/templates"
+	argocd "github.com/akuity/kargo/internal/controller/argocd/api/v1alpha1"
+)
+
+type fromImageUpdaterOptions struct {
+	genericiooptions.IOStreams
+	*genericclioptions.PrintFlags
+
+	Config config.CLIConfig
+
+	Project   string
+	Filenames []string
+	Recursive bool
+}
+
+func newFromImageUpdaterCommand(
+	cfg config.CLIConfig,
+	streams genericiooptions.IOStreams,
+) *cobra.Command {
+	cmdOpts := &fromImageUpdaterOptions{
+		Config:    cfg,
+		IOStreams: streams,
+		PrintFlags: genericclioptions.NewPrintFlags("").WithDefaultOutput("yaml").
+			WithTypeSetter(kubernetes.GetScheme()),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "from-image-updater [--project=project] -f FILENAME",
+		Short: "Generate warehouses and stages from Argo CD Image Updater annotations",
+		Args:  option.NoArgs,
+		Example: templates.Example(`
+# Generate a warehouse and a stage for each Argo CD application managed by
+# Argo CD Image Updater
+kubectl get applications -n argocd -o yaml > applications.yaml
+kargo migrate from-image-updater --project=my-project -f applications.yaml
+
+# Generate and apply them
+kargo migrate from-image-updater --project=my-project -f applications.yaml > kargo.yaml
+kargo apply -f kargo.yaml
+
+# Generate them in the default project
+kargo config set-project my-project
+kargo migrate from-image-updater -f applications.yaml
+`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := cmdOpts.validate(); err != nil {
+				return err
+			}
+
+			return cmdOpts.run()
+		},
+	}
+
+	// Register the option flags on the command.
+	cmdOpts.addFlags(cmd)
+
+	// Set the input/output streams for the command.
+	cliio.SetIOStreams(cmd, cmdOpts.IOStreams)
+
+	return cmd
+}
+
+// addFlags adds the flags for the from-image-updater options to the provided
+// command.
+func (o *fromImageUpdaterOptions) addFlags(cmd *cobra.Command) {
+	o.PrintFlags.AddFlags(cmd)
+
+	option.Project(
+		cmd.Flags(), &o.Project, o.Config.Project,
+		"The project to generate the warehouses and stages in. If not set, the "+
+			"default project will be used.",
+	)
+	option.Filenames(
+		cmd.Flags(), &o.Filenames,
+		"Filename or directory to read the Argo CD application(s) from",
+	)
+	option.Recursive(cmd.Flags(), &o.Recursive)
+
+	if err := cmd.MarkFlagRequired(option.FilenameFlag); err != nil {
+		panic(fmt.Errorf("could not mark filename flag as required: %w", err))
+	}
+	if err := cmd.MarkFlagFilename(option.FilenameFlag, ".yaml", ".yml"); err != nil {
+		panic(fmt.Errorf("could not mark filename flag as filename: %w", err))
+	}
+	if err := cmd.MarkFlagDirname(option.FilenameFlag); err != nil {
+		panic(fmt.Errorf("could not mark filename flag as dirname: %w", err))
+	}
+}
+
+// validate performs validation of the options. If the options are invalid, an
+// error is returned.
+func (o *fromImageUpdaterOptions) validate() error {
+	var errs []error
+	if o.Project == "" {
+		errs = append(errs, fmt.Errorf("%s is required", option.ProjectFlag))
+	}
+	if len(o.Filenames) == 0 {
+		errs = append(errs, fmt.Errorf("%s is required", option.FilenameFlag))
+	}
+	return errors.Join(errs...)
+}
+
+// run prints a warehouse and a stage for each Argo CD Application managed by
+// Argo CD Image Updater, and any warnings about their migration.
+func (o *fromImageUpdaterOptions) run() error {
+	manifest, err := option.ReadManifests(o.Recursive, o.Filenames...)
+	if err != nil {
+		return fmt.Errorf("read manifests: %w", err)
+	}
+	apps, err := decodeApplications(manifest)
+	if err != nil {
+		return err
+	}
+
+	printer, err := o.PrintFlags.ToPrinter()
+	if err != nil {
+		return fmt.Errorf("new printer: %w", err)
+	}
+
+	var migrated int
+	for _, app := range apps {
+		if !isImageUpdaterApplication(app) {
+			continue
+		}
+		m, err := migrateImageUpdaterApplication(app, o.Project)
+		if err != nil {
+			return fmt.Errorf("migrate application %q: %w", app.Name, err)
+		}
+		for _, warning := range m.warnings {
+			_, _ = fmt.Fprintf(o.ErrOut, "Warning: application %q: %s\n", app.Name, warning)
+		}
+		if err = printer.PrintObj(m.warehouse, o.Out); err != nil {
+			return fmt.Errorf("print warehouse %q: %w", m.warehouse.Name, err)
+		}
+		if err = printer.PrintObj(m.stage, o.Out); err != nil {
+			return fmt.Errorf("print stage %q: %w", m.stage.Name, err)
+		}
+		migrated++
+	}
+	if migrated == 0 {
+		return errors.New("no Argo CD applications managed by Argo CD Image Updater found")
+	}
+	return nil
+}
+
+// decodeApplications returns the Argo CD Applications contained in the
+// provided YAML manifest, including those contained in Lists, as output by
+// kubectl. Resources of any other kind are ignored.
+func decodeApplications(manifest []byte) ([]*argocd.Application, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), 4096)
+	var apps []*argocd.Application
+	for {
+		ext := runtime.RawExtension{}
+		if err := decoder.Decode(&ext); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("error decoding manifest: %w", err)
+		}
+		ext.Raw = bytes.TrimSpace(ext.Raw)
+		if len(ext.Raw) == 0 || bytes.Equal(ext.Raw, []byte("null")) {
+			continue
+		}
+		decoded, err := decodeApplication(ext.Raw)
+		if err != nil {
+			return nil, err
+		}
+		apps = append(apps, decoded...)
+	}
+	return apps, nil
+}
+
+// decodeApplication returns the Argo CD Application contained in the provided
+// JSON document or, if the document is a List, the Applications among its
+// items.
+func decodeApplication(doc []byte) ([]*argocd.Application, error) {
+	var typeMeta struct {
+		APIVersion string                 `json:"apiVersion"`
+		Kind       string                 `json:"kind"`
+		Items      []runtime.RawExtension `json:"items"`
+	}
+	if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+		return nil, fmt.Errorf("error unmarshaling manifest: %w", err)
+	}
+	switch {
+	case typeMeta.Kind == "List":
+		var apps []*argocd.Application
+		for _, item := range typeMeta.Items {
+			decoded, err := decodeApplication(item.Raw)
+			if err != nil {
+				return nil, err
+			}
+			apps = append(apps, decoded...)
+		}
+		return apps, nil
+	case typeMeta.APIVersion == argocd.GroupVersion.String() &&
+		typeMeta.Kind == "Application":
+		app := &argocd.Application{}
+		if err := yaml.Unmarshal(doc, app); err != nil {
+			return nil, fmt.Errorf("error unmarshaling manifest: %w", err)
+		}
+		return []*argocd.Application{app}, nil
+	default:
+		return nil, nil
+	}
+}
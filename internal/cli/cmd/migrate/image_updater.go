@@ -0,0 +1,556 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	argocd "github.com/akuity/kargo/internal/controller/argocd/api/v1alpha1"
+)
+
+const (
+	imageUpdaterAnnotationPrefix = "argocd-image-updater.argoproj.io/"
+
+	imageUpdaterImageListAnnotationKey       = imageUpdaterAnnotationPrefix + "image-list"
+	imageUpdaterWriteBackMethodAnnotationKey = imageUpdaterAnnotationPrefix + "write-back-method"
+	imageUpdaterWriteBackTargetAnnotationKey = imageUpdaterAnnotationPrefix + "write-back-target"
+	imageUpdaterGitBranchAnnotationKey       = imageUpdaterAnnotationPrefix + "git-branch"
+	imageUpdaterGitRepositoryAnnotationKey   = imageUpdaterAnnotationPrefix + "git-repository"
+
+	// Per-image annotations are keyed by the image's alias, e.g.
+	// argocd-image-updater.argoproj.io/<alias>.update-strategy.
+	imageUpdaterUpdateStrategyOption     = "update-strategy"
+	imageUpdaterAllowTagsOption          = "allow-tags"
+	imageUpdaterIgnoreTagsOption         = "ignore-tags"
+	imageUpdaterPlatformsOption          = "platforms"
+	imageUpdaterPullSecretOption         = "pull-secret"
+	imageUpdaterHelmImageNameOption      = "helm.image-name"
+	imageUpdaterHelmImageTagOption       = "helm.image-tag"
+	imageUpdaterHelmImageSpecOption      = "helm.image-spec"
+	imageUpdaterKustomizeImageNameOption = "kustomize.image-name"
+
+	// Defaults applied by Argo CD Image Updater to Helm-based Applications when
+	// no Helm parameters are specified for an image.
+	defaultHelmImageTagParameter = "image.tag"
+
+	// defaultHelmValuesFile is the values file Argo CD Image Updater writes to
+	// when the write-back target is "helmvalues" without a path.
+	defaultHelmValuesFile = "values.yaml"
+
+	authorizedStageAnnotationKey = "kargo.akuity.io/authorized-stage"
+)
+
+// imageUpdaterImage is an image listed in the image-list annotation of an Argo
+// CD Application managed by Argo CD Image Updater.
+type imageUpdaterImage struct {
+	// alias is the alias under which per-image options are specified. It is
+	// empty if the image was listed without one.
+	alias string
+	// repoURL is the image's repository, without tag or digest.
+	repoURL string
+	// constraint is the version constraint (or, for the digest strategy, the
+	// tag) the image was listed with. It is empty if there was none.
+	constraint string
+}
+
+// imageUpdaterMigration holds the Kargo resources equivalent to an Argo CD
+// Application managed by Argo CD Image Updater, along with warnings about any
+// configuration that could not be migrated faithfully.
+type imageUpdaterMigration struct {
+	warehouse *kargoapi.Warehouse
+	stage     *kargoapi.Stage
+	warnings  []string
+}
+
+// isImageUpdaterApplication returns true if the provided Argo CD Application
+// is managed by Argo CD Image Updater.
+func isImageUpdaterApplication(app *argocd.Application) bool {
+	return strings.TrimSpace(app.Annotations[imageUpdaterImageListAnnotationKey]) != ""
+}
+
+// migrateImageUpdaterApplication returns a Warehouse subscribing to the images
+// that the provided Argo CD Application has Argo CD Image Updater keep up to
+// date, and a Stage that subscribes to the Warehouse and updates the
+// Application the way Argo CD Image Updater would have. Both are named after
+// the Application and belong to the specified project.
+func migrateImageUpdaterApplication(
+	app *argocd.Application,
+	project string,
+) (*imageUpdaterMigration, error) {
+	images, err := parseImageList(app.Annotations[imageUpdaterImageListAnnotationKey])
+	if err != nil {
+		return nil, err
+	}
+	if len(images) == 0 {
+		return nil, errors.New("no images are listed in the image-list annotation")
+	}
+
+	source := app.Spec.Source
+	if source == nil && len(app.Spec.Sources) == 1 {
+		source = &app.Spec.Sources[0]
+	}
+	if source == nil {
+		return nil, errors.New(
+			"only Applications with exactly one source can be migrated",
+		)
+	}
+
+	m := &imageUpdaterMigration{}
+	opts := imageUpdaterOptions(app.Annotations)
+
+	subs := make([]kargoapi.RepoSubscription, 0, len(images))
+	for _, img := range images {
+		sub, err := m.imageSubscription(img, opts(img.alias))
+		if err != nil {
+			return nil, fmt.Errorf("image %q: %w", img.repoURL, err)
+		}
+		subs = append(subs, kargoapi.RepoSubscription{Image: sub})
+	}
+
+	promoMechs, err := m.promotionMechanisms(app, *source, images, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	m.warehouse = &kargoapi.Warehouse{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kargoapi.GroupVersion.String(),
+			Kind:       "Warehouse",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: project,
+			Name:      app.Name,
+		},
+		Spec: kargoapi.WarehouseSpec{
+			FreightCreationPolicy: kargoapi.FreightCreationPolicyAutomatic,
+			Subscriptions:         subs,
+		},
+	}
+	m.stage = &kargoapi.Stage{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kargoapi.GroupVersion.String(),
+			Kind:       "Stage",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: project,
+			Name:      app.Name,
+		},
+		Spec: kargoapi.StageSpec{
+			Subscriptions: kargoapi.Subscriptions{
+				Warehouse: app.Name,
+			},
+			PromotionMechanisms: promoMechs,
+		},
+	}
+
+	m.warn(
+		"the Application must be annotated with %s: %s:%s to permit the Stage "+
+			"to update it, and its Argo CD Image Updater annotations should be "+
+			"removed once the Stage is in place",
+		authorizedStageAnnotationKey,
+		project,
+		app.Name,
+	)
+	return m, nil
+}
+
+// parseImageList parses the value of Argo CD Image Updater's image-list
+// annotation, a comma-delimited list of entries of the form
+// [<alias>=]<image>[:<constraint>].
+func parseImageList(list string) ([]imageUpdaterImage, error) {
+	var images []imageUpdaterImage
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		original := entry
+		var img imageUpdaterImage
+		if alias, ref, ok := strings.Cut(entry, "="); ok {
+			img.alias = strings.TrimSpace(alias)
+			entry = strings.TrimSpace(ref)
+		}
+		// A digest pins the image and is of no use for discovering new
+		// versions.
+		entry, _, _ = strings.Cut(entry, "@")
+		// A colon after the last slash separates the constraint from the image.
+		// Any other colon separates a registry host from its port.
+		if i := strings.LastIndex(entry, ":"); i > strings.LastIndex(entry, "/") {
+			img.constraint = entry[i+1:]
+			entry = entry[:i]
+		}
+		if entry == "" {
+			return nil, fmt.Errorf("invalid image-list entry %q", original)
+		}
+		img.repoURL = entry
+		images = append(images, img)
+	}
+	return images, nil
+}
+
+// imageUpdaterOptions returns a function that looks up per-image options in
+// the provided annotations by the alias of the image. Images listed without an
+// alias have no options.
+func imageUpdaterOptions(
+	annotations map[string]string,
+) func(alias string) func(option string) string {
+	return func(alias string) func(string) string {
+		return func(option string) string {
+			if alias == "" {
+				return ""
+			}
+			return strings.TrimSpace(
+				annotations[fmt.Sprintf("%s%s.%s", imageUpdaterAnnotationPrefix, alias, option)],
+			)
+		}
+	}
+}
+
+// imageSubscription returns an image subscription equivalent to the provided
+// image and its options.
+func (m *imageUpdaterMigration) imageSubscription(
+	img imageUpdaterImage,
+	opt func(string) string,
+) (*kargoapi.ImageSubscription, error) {
+	sub := &kargoapi.ImageSubscription{
+		RepoURL: img.repoURL,
+	}
+
+	strategy, err := imageSelectionStrategy(opt(imageUpdaterUpdateStrategyOption))
+	if err != nil {
+		return nil, err
+	}
+	sub.ImageSelectionStrategy = strategy
+	switch strategy {
+	case kargoapi.ImageSelectionStrategySemVer:
+		sub.SemverConstraint = img.constraint
+	case kargoapi.ImageSelectionStrategyDigest:
+		// For the digest strategy, the constraint is the tag to track, which
+		// is what Kargo expects in the SemverConstraint field.
+		if img.constraint == "" {
+			return nil, errors.New("the digest update strategy requires a tag")
+		}
+		sub.SemverConstraint = img.constraint
+	}
+
+	switch allowTags := opt(imageUpdaterAllowTagsOption); {
+	case allowTags == "", allowTags == "any":
+	case strings.HasPrefix(allowTags, "regexp:"):
+		sub.AllowTags = strings.TrimPrefix(allowTags, "regexp:")
+	default:
+		m.warn(
+			"image %q: unsupported allow-tags value %q was ignored",
+			img.repoURL,
+			allowTags,
+		)
+	}
+
+	for _, tag := range strings.Split(opt(imageUpdaterIgnoreTagsOption), ",") {
+		if tag = strings.TrimSpace(tag); tag == "" {
+			continue
+		}
+		if strings.ContainsAny(tag, "*?[") {
+			m.warn(
+				"image %q: ignore-tags pattern %q was ignored, as Kargo can only "+
+					"ignore specific tags",
+				img.repoURL,
+				tag,
+			)
+			continue
+		}
+		sub.IgnoreTags = append(sub.IgnoreTags, tag)
+	}
+
+	sub.Platform = opt(imageUpdaterPlatformsOption)
+
+	if opt(imageUpdaterPullSecretOption) != "" {
+		m.warn(
+			"image %q: the pull secret was not migrated; credentials for the "+
+				"image repository must be added to the project",
+			img.repoURL,
+		)
+	}
+
+	return sub, nil
+}
+
+// imageSelectionStrategy returns the image selection strategy equivalent to
+// the provided Argo CD Image Updater update strategy.
+func imageSelectionStrategy(updateStrategy string) (kargoapi.ImageSelectionStrategy, error) {
+	switch updateStrategy {
+	case "", "semver":
+		return kargoapi.ImageSelectionStrategySemVer, nil
+	case "latest", "newest-build":
+		return kargoapi.ImageSelectionStrategyNewestBuild, nil
+	case "name", "alphabetical":
+		return kargoapi.ImageSelectionStrategyLexical, nil
+	case "digest":
+		return kargoapi.ImageSelectionStrategyDigest, nil
+	default:
+		return "", fmt.Errorf("unsupported update strategy %q", updateStrategy)
+	}
+}
+
+// promotionMechanisms returns promotion mechanisms that update the provided
+// Application's source with new versions of the provided images the way its
+// write-back method would have.
+func (m *imageUpdaterMigration) promotionMechanisms(
+	app *argocd.Application,
+	source argocd.ApplicationSource,
+	images []imageUpdaterImage,
+	opts func(string) func(string) string,
+) (*kargoapi.PromotionMechanisms, error) {
+	isHelm := source.Chart != "" || source.Helm != nil
+	for _, img := range images {
+		opt := opts(img.alias)
+		if opt(imageUpdaterHelmImageNameOption) != "" ||
+			opt(imageUpdaterHelmImageTagOption) != "" ||
+			opt(imageUpdaterHelmImageSpecOption) != "" {
+			isHelm = true
+		}
+	}
+
+	appUpdate := kargoapi.ArgoCDAppUpdate{
+		AppName:      app.Name,
+		AppNamespace: app.Namespace,
+	}
+
+	method := strings.TrimSpace(app.Annotations[imageUpdaterWriteBackMethodAnnotationKey])
+	switch {
+	case method == "" || method == "argocd":
+	case method == "git" || strings.HasPrefix(method, "git:"):
+		if method != "git" {
+			m.warn(
+				"the git write-back credentials were not migrated; credentials " +
+					"for the git repository must be added to the project",
+			)
+		}
+		gitUpdate, ok, err := m.gitRepoUpdate(app, source, images, opts, isHelm)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			// The Application only needs to be synced after the git repository
+			// has been updated.
+			return &kargoapi.PromotionMechanisms{
+				GitRepoUpdates:   []kargoapi.GitRepoUpdate{*gitUpdate},
+				ArgoCDAppUpdates: []kargoapi.ArgoCDAppUpdate{appUpdate},
+			}, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported write-back method %q", method)
+	}
+
+	sourceUpdate := kargoapi.ArgoCDSourceUpdate{
+		RepoURL: source.RepoURL,
+		Chart:   source.Chart,
+	}
+	for _, img := range images {
+		strategy, _ := imageSelectionStrategy(opts(img.alias)(imageUpdaterUpdateStrategyOption))
+		useDigest := strategy == kargoapi.ImageSelectionStrategyDigest
+		if isHelm {
+			if sourceUpdate.Helm == nil {
+				sourceUpdate.Helm = &kargoapi.ArgoCDHelm{}
+			}
+			for _, p := range helmImageParameters(opts(img.alias), useDigest) {
+				sourceUpdate.Helm.Images = append(
+					sourceUpdate.Helm.Images,
+					kargoapi.ArgoCDHelmImageUpdate{
+						Image: img.repoURL,
+						Key:   p.key,
+						Value: p.value,
+					},
+				)
+			}
+			continue
+		}
+		m.checkKustomizeImageName(img, opts(img.alias))
+		if sourceUpdate.Kustomize == nil {
+			sourceUpdate.Kustomize = &kargoapi.ArgoCDKustomize{}
+		}
+		sourceUpdate.Kustomize.Images = append(
+			sourceUpdate.Kustomize.Images,
+			kargoapi.ArgoCDKustomizeImageUpdate{
+				Image:     img.repoURL,
+				UseDigest: useDigest,
+			},
+		)
+	}
+	appUpdate.SourceUpdates = []kargoapi.ArgoCDSourceUpdate{sourceUpdate}
+	return &kargoapi.PromotionMechanisms{
+		ArgoCDAppUpdates: []kargoapi.ArgoCDAppUpdate{appUpdate},
+	}, nil
+}
+
+// gitRepoUpdate returns an update of the provided Application's git repository
+// equivalent to the git write-back method. If the Application's write-back
+// target is a file Kargo does not know how to update, false is returned and
+// the Application's parameters should be updated instead.
+func (m *imageUpdaterMigration) gitRepoUpdate(
+	app *argocd.Application,
+	source argocd.ApplicationSource,
+	images []imageUpdaterImage,
+	opts func(string) func(string) string,
+	isHelm bool,
+) (*kargoapi.GitRepoUpdate, bool, error) {
+	target := strings.TrimSpace(app.Annotations[imageUpdaterWriteBackTargetAnnotationKey])
+	kind, targetPath, _ := strings.Cut(target, ":")
+	switch kind {
+	case "kustomization", "helmvalues":
+	case "":
+		m.warn(
+			"Kargo cannot write Argo CD parameter override files, so the " +
+				"Application's parameters are updated directly instead; set the " +
+				"write-back-target annotation to migrate to updates of a " +
+				"kustomization or Helm values file",
+		)
+		return nil, false, nil
+	default:
+		return nil, false, fmt.Errorf("unsupported write-back target %q", target)
+	}
+
+	if kind == "helmvalues" && targetPath == "" {
+		targetPath = defaultHelmValuesFile
+	}
+	// Paths are relative to the Application's source path unless they are
+	// absolute, in which case they are relative to the root of the repository.
+	if strings.HasPrefix(targetPath, "/") {
+		targetPath = path.Clean(strings.TrimPrefix(targetPath, "/"))
+	} else {
+		targetPath = path.Join(source.Path, targetPath)
+	}
+	if targetPath == "" {
+		targetPath = "."
+	}
+
+	update := &kargoapi.GitRepoUpdate{
+		RepoURL: source.RepoURL,
+	}
+	if repoURL := strings.TrimSpace(
+		app.Annotations[imageUpdaterGitRepositoryAnnotationKey],
+	); repoURL != "" {
+		update.RepoURL = repoURL
+	}
+
+	readBranch, writeBranch, _ := strings.Cut(
+		strings.TrimSpace(app.Annotations[imageUpdaterGitBranchAnnotationKey]),
+		":",
+	)
+	if readBranch == "" && source.TargetRevision != "HEAD" {
+		readBranch = source.TargetRevision
+	}
+	if readBranch == "" {
+		return nil, false, errors.New(
+			"unable to determine the branch to write back to; set the " +
+				"git-branch annotation",
+		)
+	}
+	if strings.Contains(writeBranch, "{{") {
+		m.warn(
+			"the templated write branch %q is not supported; the branch %q is "+
+				"written to instead",
+			writeBranch,
+			readBranch,
+		)
+		writeBranch = ""
+	}
+	if writeBranch == "" {
+		update.WriteBranch = readBranch
+	} else {
+		update.ReadBranch = readBranch
+		update.WriteBranch = writeBranch
+	}
+
+	if kind == "kustomization" {
+		update.Kustomize = &kargoapi.KustomizePromotionMechanism{}
+		for _, img := range images {
+			m.checkKustomizeImageName(img, opts(img.alias))
+			strategy, _ := imageSelectionStrategy(opts(img.alias)(imageUpdaterUpdateStrategyOption))
+			update.Kustomize.Images = append(
+				update.Kustomize.Images,
+				kargoapi.KustomizeImageUpdate{
+					Image:     img.repoURL,
+					Path:      targetPath,
+					UseDigest: strategy == kargoapi.ImageSelectionStrategyDigest,
+				},
+			)
+		}
+		return update, true, nil
+	}
+
+	if !isHelm {
+		m.warn("a Helm values file is written back to, but the Application does not use Helm")
+	}
+	update.Helm = &kargoapi.HelmPromotionMechanism{}
+	for _, img := range images {
+		strategy, _ := imageSelectionStrategy(opts(img.alias)(imageUpdaterUpdateStrategyOption))
+		useDigest := strategy == kargoapi.ImageSelectionStrategyDigest
+		for _, p := range helmImageParameters(opts(img.alias), useDigest) {
+			update.Helm.Images = append(
+				update.Helm.Images,
+				kargoapi.HelmImageUpdate{
+					Image:          img.repoURL,
+					ValuesFilePath: targetPath,
+					Key:            p.key,
+					Value:          p.value,
+				},
+			)
+		}
+	}
+	return update, true, nil
+}
+
+// helmImageParameter is a Helm parameter that is to be updated with a new
+// version of an image.
+type helmImageParameter struct {
+	key   string
+	value kargoapi.ImageUpdateValueType
+}
+
+// helmImageParameters returns the Helm parameters that Argo CD Image Updater
+// would update with new versions of an image having the provided options. The
+// parameter holding the image's name, if any, is not among them, as the name
+// of the image never changes.
+func helmImageParameters(opt func(string) string, useDigest bool) []helmImageParameter {
+	if spec := opt(imageUpdaterHelmImageSpecOption); spec != "" {
+		value := kargoapi.ImageUpdateValueTypeImageAndTag
+		if useDigest {
+			value = kargoapi.ImageUpdateValueTypeImageAndDigest
+		}
+		return []helmImageParameter{{key: spec, value: value}}
+	}
+	key := opt(imageUpdaterHelmImageTagOption)
+	if key == "" {
+		key = defaultHelmImageTagParameter
+	}
+	value := kargoapi.ImageUpdateValueTypeTag
+	if useDigest {
+		value = kargoapi.ImageUpdateValueTypeDigest
+	}
+	return []helmImageParameter{{key: key, value: value}}
+}
+
+// checkKustomizeImageName adds a warning if the provided image is configured
+// to replace an image by a different name, which Kargo does not support.
+func (m *imageUpdaterMigration) checkKustomizeImageName(
+	img imageUpdaterImage,
+	opt func(string) string,
+) {
+	if name := opt(imageUpdaterKustomizeImageNameOption); name != "" && name != img.repoURL {
+		m.warn(
+			"image %q: Kargo cannot replace the image %q by a different name; "+
+				"the Kustomize images must reference %q instead",
+			img.repoURL,
+			name,
+			img.repoURL,
+		)
+	}
+}
+
+func (m *imageUpdaterMigration) warn(format string, args ...any) {
+	m.warnings = append(m.warnings, fmt.Sprintf(format, args...))
+}
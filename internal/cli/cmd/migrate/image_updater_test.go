@@ -0,0 +1,410 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	argocd "github.com/akuity/kargo/internal/controller/argocd/api/v1alpha1"
+)
+
+func TestParseImageList(t *testing.T) {
+	testCases := []struct {
+		name       string
+		list       string
+		assertions func(t *testing.T, images []imageUpdaterImage, err error)
+	}{
+		{
+			name: "empty",
+			list: " , ",
+			assertions: func(t *testing.T, images []imageUpdaterImage, err error) {
+				require.NoError(t, err)
+				require.Empty(t, images)
+			},
+		},
+		{
+			name: "invalid entry",
+			list: "app=:1.x",
+			assertions: func(t *testing.T, _ []imageUpdaterImage, err error) {
+				require.ErrorContains(t, err, `invalid image-list entry "app=:1.x"`)
+			},
+		},
+		{
+			name: "success",
+			list: "nginx, app=registry.example.com:5000/acme/app:~1.2 ,redis=redis:7@sha256:abc",
+			assertions: func(t *testing.T, images []imageUpdaterImage, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]imageUpdaterImage{
+						{repoURL: "nginx"},
+						{
+							alias:      "app",
+							repoURL:    "registry.example.com:5000/acme/app",
+							constraint: "~1.2",
+						},
+						{
+							alias:      "redis",
+							repoURL:    "redis",
+							constraint: "7",
+						},
+					},
+					images,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			images, err := parseImageList(testCase.list)
+			testCase.assertions(t, images, err)
+		})
+	}
+}
+
+func TestMigrateImageUpdaterApplication(t *testing.T) {
+	newApp := func(
+		annotations map[string]string,
+		source argocd.ApplicationSource,
+	) *argocd.Application {
+		return &argocd.Application{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "argocd",
+				Name:        "guestbook",
+				Annotations: annotations,
+			},
+			Spec: argocd.ApplicationSpec{
+				Source: &source,
+			},
+		}
+	}
+	kustomizeSource := argocd.ApplicationSource{
+		RepoURL:        "https://github.com/acme/deploy.git",
+		Path:           "envs/dev",
+		TargetRevision: "main",
+	}
+	helmSource := argocd.ApplicationSource{
+		RepoURL:        "https://charts.example.com",
+		Chart:          "guestbook",
+		TargetRevision: "1.0.0",
+	}
+
+	testCases := []struct {
+		name       string
+		app        *argocd.Application
+		assertions func(t *testing.T, m *imageUpdaterMigration, err error)
+	}{
+		{
+			name: "multiple sources",
+			app: &argocd.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "guestbook",
+					Annotations: map[string]string{
+						imageUpdaterImageListAnnotationKey: "nginx",
+					},
+				},
+				Spec: argocd.ApplicationSpec{
+					Sources: argocd.ApplicationSources{kustomizeSource, helmSource},
+				},
+			},
+			assertions: func(t *testing.T, _ *imageUpdaterMigration, err error) {
+				require.ErrorContains(t, err, "exactly one source")
+			},
+		},
+		{
+			name: "unsupported update strategy",
+			app: newApp(
+				map[string]string{
+					imageUpdaterImageListAnnotationKey:                   "app=nginx",
+					imageUpdaterAnnotationPrefix + "app.update-strategy": "most-popular",
+				},
+				kustomizeSource,
+			),
+			assertions: func(t *testing.T, _ *imageUpdaterMigration, err error) {
+				require.ErrorContains(t, err, `unsupported update strategy "most-popular"`)
+			},
+		},
+		{
+			name: "digest update strategy without tag",
+			app: newApp(
+				map[string]string{
+					imageUpdaterImageListAnnotationKey:                   "app=nginx",
+					imageUpdaterAnnotationPrefix + "app.update-strategy": "digest",
+				},
+				kustomizeSource,
+			),
+			assertions: func(t *testing.T, _ *imageUpdaterMigration, err error) {
+				require.ErrorContains(t, err, "requires a tag")
+			},
+		},
+		{
+			name: "unsupported write-back method",
+			app: newApp(
+				map[string]string{
+					imageUpdaterImageListAnnotationKey:       "nginx",
+					imageUpdaterWriteBackMethodAnnotationKey: "carrier-pigeon",
+				},
+				kustomizeSource,
+			),
+			assertions: func(t *testing.T, _ *imageUpdaterMigration, err error) {
+				require.ErrorContains(t, err, `unsupported write-back method "carrier-pigeon"`)
+			},
+		},
+		{
+			name: "kustomize application updated by Argo CD",
+			app: newApp(
+				map[string]string{
+					imageUpdaterImageListAnnotationKey:                          "app=ghcr.io/acme/app:^1.0,redis=redis:latest",
+					imageUpdaterAnnotationPrefix + "app.allow-tags":             "regexp:^1\\.",
+					imageUpdaterAnnotationPrefix + "app.ignore-tags":            "1.0.1, 1.1.*",
+					imageUpdaterAnnotationPrefix + "app.platforms":              "linux/amd64,linux/arm64",
+					imageUpdaterAnnotationPrefix + "app.pull-secret":            "secret:argocd/ghcr",
+					imageUpdaterAnnotationPrefix + "redis.update-strategy":      "digest",
+					imageUpdaterAnnotationPrefix + "redis.kustomize.image-name": "docker.io/library/redis",
+				},
+				kustomizeSource,
+			),
+			assertions: func(t *testing.T, m *imageUpdaterMigration, err error) {
+				require.NoError(t, err)
+
+				require.Equal(t, "kargo-demo", m.warehouse.Namespace)
+				require.Equal(t, "guestbook", m.warehouse.Name)
+				require.Equal(
+					t,
+					[]kargoapi.RepoSubscription{
+						{
+							Image: &kargoapi.ImageSubscription{
+								RepoURL:                "ghcr.io/acme/app",
+								ImageSelectionStrategy: kargoapi.ImageSelectionStrategySemVer,
+								SemverConstraint:       "^1.0",
+								AllowTags:              "^1\\.",
+								IgnoreTags:             []string{"1.0.1"},
+								Platform:               "linux/amd64,linux/arm64",
+							},
+						},
+						{
+							Image: &kargoapi.ImageSubscription{
+								RepoURL:                "redis",
+								ImageSelectionStrategy: kargoapi.ImageSelectionStrategyDigest,
+								SemverConstraint:       "latest",
+							},
+						},
+					},
+					m.warehouse.Spec.Subscriptions,
+				)
+
+				require.Equal(t, "kargo-demo", m.stage.Namespace)
+				require.Equal(t, "guestbook", m.stage.Name)
+				require.Equal(t, "guestbook", m.stage.Spec.Subscriptions.Warehouse)
+				require.Equal(
+					t,
+					&kargoapi.PromotionMechanisms{
+						ArgoCDAppUpdates: []kargoapi.ArgoCDAppUpdate{{
+							AppName:      "guestbook",
+							AppNamespace: "argocd",
+							SourceUpdates: []kargoapi.ArgoCDSourceUpdate{{
+								RepoURL: "https://github.com/acme/deploy.git",
+								Kustomize: &kargoapi.ArgoCDKustomize{
+									Images: []kargoapi.ArgoCDKustomizeImageUpdate{
+										{Image: "ghcr.io/acme/app"},
+										{Image: "redis", UseDigest: true},
+									},
+								},
+							}},
+						}},
+					},
+					m.stage.Spec.PromotionMechanisms,
+				)
+
+				require.Len(t, m.warnings, 4)
+				require.Contains(t, m.warnings[0], `ignore-tags pattern "1.1.*"`)
+				require.Contains(t, m.warnings[1], "pull secret")
+				require.Contains(t, m.warnings[2], `replace the image "docker.io/library/redis"`)
+				require.Contains(t, m.warnings[3], "kargo.akuity.io/authorized-stage: kargo-demo:guestbook")
+			},
+		},
+		{
+			name: "helm application updated by Argo CD",
+			app: newApp(
+				map[string]string{
+					imageUpdaterImageListAnnotationKey:                   "nginx,app=ghcr.io/acme/app,web=ghcr.io/acme/web:latest",
+					imageUpdaterAnnotationPrefix + "app.helm.image-tag":  "app.image.tag",
+					imageUpdaterAnnotationPrefix + "web.helm.image-spec": "web.image",
+					imageUpdaterAnnotationPrefix + "web.update-strategy": "digest",
+				},
+				helmSource,
+			),
+			assertions: func(t *testing.T, m *imageUpdaterMigration, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]kargoapi.ArgoCDSourceUpdate{{
+						RepoURL: "https://charts.example.com",
+						Chart:   "guestbook",
+						Helm: &kargoapi.ArgoCDHelm{
+							Images: []kargoapi.ArgoCDHelmImageUpdate{
+								{
+									Image: "nginx",
+									Key:   "image.tag",
+									Value: kargoapi.ImageUpdateValueTypeTag,
+								},
+								{
+									Image: "ghcr.io/acme/app",
+									Key:   "app.image.tag",
+									Value: kargoapi.ImageUpdateValueTypeTag,
+								},
+								{
+									Image: "ghcr.io/acme/web",
+									Key:   "web.image",
+									Value: kargoapi.ImageUpdateValueTypeImageAndDigest,
+								},
+							},
+						},
+					}},
+					m.stage.Spec.PromotionMechanisms.ArgoCDAppUpdates[0].SourceUpdates,
+				)
+				require.Len(t, m.warnings, 1)
+			},
+		},
+		{
+			name: "git write-back without target",
+			app: newApp(
+				map[string]string{
+					imageUpdaterImageListAnnotationKey:       "nginx",
+					imageUpdaterWriteBackMethodAnnotationKey: "git",
+				},
+				kustomizeSource,
+			),
+			assertions: func(t *testing.T, m *imageUpdaterMigration, err error) {
+				require.NoError(t, err)
+				require.Empty(t, m.stage.Spec.PromotionMechanisms.GitRepoUpdates)
+				require.Len(t, m.stage.Spec.PromotionMechanisms.ArgoCDAppUpdates[0].SourceUpdates, 1)
+				require.Len(t, m.warnings, 2)
+				require.Contains(t, m.warnings[0], "parameter override files")
+			},
+		},
+		{
+			name: "git write-back without branch",
+			app: newApp(
+				map[string]string{
+					imageUpdaterImageListAnnotationKey:       "nginx",
+					imageUpdaterWriteBackMethodAnnotationKey: "git",
+					imageUpdaterWriteBackTargetAnnotationKey: "kustomization",
+				},
+				argocd.ApplicationSource{
+					RepoURL:        "https://github.com/acme/deploy.git",
+					TargetRevision: "HEAD",
+				},
+			),
+			assertions: func(t *testing.T, _ *imageUpdaterMigration, err error) {
+				require.ErrorContains(t, err, "set the git-branch annotation")
+			},
+		},
+		{
+			name: "git write-back to kustomization",
+			app: newApp(
+				map[string]string{
+					imageUpdaterImageListAnnotationKey:       "app=ghcr.io/acme/app",
+					imageUpdaterWriteBackMethodAnnotationKey: "git:secret:argocd/git-creds",
+					imageUpdaterWriteBackTargetAnnotationKey: "kustomization:../base",
+					imageUpdaterGitBranchAnnotationKey:       "main:image-updates",
+					imageUpdaterGitRepositoryAnnotationKey:   "https://github.com/acme/other.git",
+				},
+				kustomizeSource,
+			),
+			assertions: func(t *testing.T, m *imageUpdaterMigration, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					&kargoapi.PromotionMechanisms{
+						GitRepoUpdates: []kargoapi.GitRepoUpdate{{
+							RepoURL:     "https://github.com/acme/other.git",
+							ReadBranch:  "main",
+							WriteBranch: "image-updates",
+							Kustomize: &kargoapi.KustomizePromotionMechanism{
+								Images: []kargoapi.KustomizeImageUpdate{{
+									Image: "ghcr.io/acme/app",
+									Path:  "envs/base",
+								}},
+							},
+						}},
+						ArgoCDAppUpdates: []kargoapi.ArgoCDAppUpdate{{
+							AppName:      "guestbook",
+							AppNamespace: "argocd",
+						}},
+					},
+					m.stage.Spec.PromotionMechanisms,
+				)
+				require.Len(t, m.warnings, 2)
+				require.Contains(t, m.warnings[0], "git write-back credentials")
+			},
+		},
+		{
+			name: "git write-back to helm values",
+			app: newApp(
+				map[string]string{
+					imageUpdaterImageListAnnotationKey:                  "app=ghcr.io/acme/app",
+					imageUpdaterAnnotationPrefix + "app.helm.image-tag": "image.version",
+					imageUpdaterWriteBackMethodAnnotationKey:            "git",
+					imageUpdaterWriteBackTargetAnnotationKey:            "helmvalues:/charts/app/values-dev.yaml",
+					imageUpdaterGitBranchAnnotationKey:                  "main:image-updater-{{.SHA256}}",
+				},
+				kustomizeSource,
+			),
+			assertions: func(t *testing.T, m *imageUpdaterMigration, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]kargoapi.GitRepoUpdate{{
+						RepoURL:     "https://github.com/acme/deploy.git",
+						WriteBranch: "main",
+						Helm: &kargoapi.HelmPromotionMechanism{
+							Images: []kargoapi.HelmImageUpdate{{
+								Image:          "ghcr.io/acme/app",
+								ValuesFilePath: "charts/app/values-dev.yaml",
+								Key:            "image.version",
+								Value:          kargoapi.ImageUpdateValueTypeTag,
+							}},
+						},
+					}},
+					m.stage.Spec.PromotionMechanisms.GitRepoUpdates,
+				)
+				require.Len(t, m.warnings, 2)
+				require.Contains(t, m.warnings[0], "templated write branch")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			m, err := migrateImageUpdaterApplication(testCase.app, "kargo-demo")
+			testCase.assertions(t, m, err)
+		})
+	}
+}
+
+func TestDecodeApplications(t *testing.T) {
+	const manifest = `apiVersion: v1
+kind: List
+items:
+- apiVersion: argoproj.io/v1alpha1
+  kind: Application
+  metadata:
+    name: app-1
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: not-an-app
+---
+apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: app-2
+`
+	apps, err := decodeApplications([]byte(manifest))
+	require.NoError(t, err)
+	require.Len(t, apps, 2)
+	require.Equal(t, "app-1", apps[0].Name)
+	require.Equal(t, "app-2", apps[1].Name)
+}
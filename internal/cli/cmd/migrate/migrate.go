@@ -0,0 +1,28 @@
+package migrate
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"github.com/akuity/kargo/internal/cli/config"
+	"github.com/akuity/kargo/internal/cli/option"
+	"github.com/akuity/kargo/internal/cli/templates"
+)
+
+func NewCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate SUBCOMMAND",
+		Short: "Generate Kargo resources from the configuration of other tools",
+		Args:  option.NoArgs,
+		Example: templates.Example(`
+# Generate warehouses and stages from Argo CD Image Updater annotations
+kubectl get applications -n argocd -o yaml > applications.yaml
+kargo migrate from-image-updater --project=my-project -f applications.yaml
+`),
+	}
+
+	// Register subcommands.
+	cmd.AddCommand(newFromImageUpdaterCommand(cfg, streams))
+
+	return cmd
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/spf13/cobra"
@@ -13,6 +14,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/completion"
 	"github.com/akuity/kargo/internal/cli/config"
 	"github.com/akuity/kargo/internal/cli/io"
 	"github.com/akuity/kargo/internal/cli/kubernetes"
@@ -22,6 +24,16 @@ import (
 	"github.com/akuity/kargo/pkg/api/service/v1alpha1/svcv1alpha1connect"
 )
 
+// throughFlag is the flag name for the through flag.
+const throughFlag = "through"
+
+// idempotencyKeyFlag is the flag name for the idempotency-key flag.
+const idempotencyKeyFlag = "idempotency-key"
+
+// promotionPlanPollInterval is the interval at which the progress of a
+// PromotionPlan is checked when waiting for it to complete.
+const promotionPlanPollInterval = 5 * time.Second
+
 type promotionOptions struct {
 	genericiooptions.IOStreams
 	*genericclioptions.PrintFlags
@@ -34,7 +46,10 @@ type promotionOptions struct {
 	FreightAlias  string
 	Stage         string
 	SubscribersOf string
+	Through       bool
 	Wait          bool
+
+	IdempotencyKey string
 }
 
 func NewCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra.Command {
@@ -46,7 +61,7 @@ func NewCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra
 
 	cmd := &cobra.Command{
 		Use: "promote [--project=project] (--freight=freight | --freight-alias=alias) " +
-			"(--stage=stage | --subscribers-of=stage)",
+			"(--stage=stage [--through] | --subscribers-of=stage)",
 		Short: "Promote a piece of freight",
 		Args:  option.NoArgs,
 		Example: templates.Example(`
@@ -56,9 +71,18 @@ kargo promote --project=my-project --freight=abc123 --stage=qa
 # Promote a piece of freight specified by alias to the QA stage
 kargo promote --project=my-project --freight-alias=wonky-wombat --stage=qa
 
+# Promote a piece of freight specified by name to the QA stage, such that
+# retrying the command (e.g. in CI) does not create another promotion
+kargo promote --project=my-project --freight=abc123 --stage=qa \
+  --idempotency-key=build-1234
+
 # Promote a piece of freight specified by name to subscribers of the QA stage
 kargo promote --project=my-project --freight=abc123 --subscribers-of=qa
 
+# Promote a piece of freight specified by name all the way to the prod stage,
+# through each of the stages between the stages it is verified in and prod
+kargo promote --project=my-project --freight=abc123 --stage=prod --through --wait
+
 # Promote a piece of freight specified by alias to subscribers of the QA stage
 kargo promote --project=my-project --freight-alias=wonky-wombat --subscribers-of=qa
 
@@ -121,6 +145,23 @@ func (o *promotionOptions) addFlags(cmd *cobra.Command) {
 			option.StageFlag,
 		),
 	)
+	cmd.Flags().BoolVar(
+		&o.Through, throughFlag, false,
+		fmt.Sprintf(
+			"Promote the freight to the stage specified by --%s through each intermediate stage in "+
+				"turn, waiting for the freight to be verified in each one.",
+			option.StageFlag,
+		),
+	)
+	cmd.Flags().StringVar(
+		&o.IdempotencyKey, idempotencyKeyFlag, "",
+		fmt.Sprintf(
+			"A key identifying the promotion to the stage specified by --%s. If a promotion "+
+				"of the same freight to the same stage was already requested using this key, "+
+				"that promotion is returned instead of a new one being created.",
+			option.StageFlag,
+		),
+	)
 	option.Wait(cmd.Flags(), &o.Wait, false, "Wait for the promotion(s) to complete.")
 
 	cmd.MarkFlagsOneRequired(option.FreightFlag, option.FreightAliasFlag)
@@ -128,6 +169,19 @@ func (o *promotionOptions) addFlags(cmd *cobra.Command) {
 
 	cmd.MarkFlagsOneRequired(option.StageFlag, option.SubscribersOfFlag)
 	cmd.MarkFlagsMutuallyExclusive(option.StageFlag, option.SubscribersOfFlag)
+	cmd.MarkFlagsMutuallyExclusive(throughFlag, option.SubscribersOfFlag)
+	cmd.MarkFlagsMutuallyExclusive(idempotencyKeyFlag, option.SubscribersOfFlag)
+	cmd.MarkFlagsMutuallyExclusive(idempotencyKeyFlag, throughFlag)
+
+	completion.RegisterProjectFlag(cmd, o.Config, &o.ClientOptions)
+	completion.RegisterFreightFlags(
+		cmd, o.Config, &o.ClientOptions, &o.Project,
+		option.FreightFlag, option.FreightAliasFlag,
+	)
+	completion.RegisterStageFlags(
+		cmd, o.Config, &o.ClientOptions, &o.Project,
+		option.StageFlag, option.SubscribersOfFlag,
+	)
 }
 
 // validate performs validation of the options. If the options are invalid, an
@@ -151,6 +205,9 @@ func (o *promotionOptions) validate() error {
 			fmt.Errorf("either %s or %s is required", option.StageFlag, option.SubscribersOfFlag),
 		)
 	}
+	if o.Through && o.Stage == "" {
+		errs = append(errs, fmt.Errorf("%s is required with %s", option.StageFlag, throughFlag))
+	}
 	return errors.Join(errs...)
 }
 
@@ -167,11 +224,11 @@ func (o *promotionOptions) run(ctx context.Context) error {
 	}
 
 	switch {
-	case o.Stage != "":
-		res, err := kargoSvcCli.PromoteToStage(
+	case o.Stage != "" && o.Through:
+		res, err := kargoSvcCli.PromoteThroughStages(
 			ctx,
 			connect.NewRequest(
-				&v1alpha1.PromoteToStageRequest{
+				&v1alpha1.PromoteThroughStagesRequest{
 					Project:      o.Project,
 					Freight:      o.FreightName,
 					FreightAlias: o.FreightAlias,
@@ -179,6 +236,30 @@ func (o *promotionOptions) run(ctx context.Context) error {
 				},
 			),
 		)
+		if err != nil {
+			return fmt.Errorf("promote through stages: %w", err)
+		}
+		plan := res.Msg.GetPromotionPlan()
+		if o.Wait {
+			if plan, err = waitForPromotionPlan(ctx, kargoSvcCli, plan); err != nil {
+				return fmt.Errorf("wait for promotion plan: %w", err)
+			}
+		}
+		_ = printer.PrintObj(plan, o.IOStreams.Out)
+		return nil
+	case o.Stage != "":
+		res, err := kargoSvcCli.PromoteToStage(
+			ctx,
+			connect.NewRequest(
+				&v1alpha1.PromoteToStageRequest{
+					Project:        o.Project,
+					Freight:        o.FreightName,
+					FreightAlias:   o.FreightAlias,
+					Stage:          o.Stage,
+					IdempotencyKey: o.IdempotencyKey,
+				},
+			),
+		)
 		if err != nil {
 			return fmt.Errorf("promote stage: %w", err)
 		}
@@ -267,3 +348,30 @@ func waitForPromotion(
 		}
 	}
 }
+
+// waitForPromotionPlan polls the provided PromotionPlan until it reaches a
+// terminal phase, and returns it as it was last observed.
+func waitForPromotionPlan(
+	ctx context.Context,
+	kargoSvcCli svcv1alpha1connect.KargoServiceClient,
+	p *kargoapi.PromotionPlan,
+) (*kargoapi.PromotionPlan, error) {
+	ticker := time.NewTicker(promotionPlanPollInterval)
+	defer ticker.Stop()
+	for p != nil && !p.Status.Phase.IsTerminal() {
+		select {
+		case <-ctx.Done():
+			return p, ctx.Err()
+		case <-ticker.C:
+		}
+		res, err := kargoSvcCli.GetPromotionPlan(ctx, connect.NewRequest(&v1alpha1.GetPromotionPlanRequest{
+			Project: p.Namespace,
+			Name:    p.Name,
+		}))
+		if err != nil {
+			return p, fmt.Errorf("get promotion plan: %w", err)
+		}
+		p = res.Msg.GetPromotionPlan()
+	}
+	return p, nil
+}
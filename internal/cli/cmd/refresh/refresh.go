@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/completion"
 	"github.com/akuity/kargo/internal/cli/config"
 	"github.com/akuity/kargo/internal/cli/option"
 	"github.com/akuity/kargo/internal/cli/templates"
@@ -59,6 +60,8 @@ func (o *refreshOptions) addFlags(cmd *cobra.Command) {
 	option.Project(cmd.Flags(), &o.Project, o.Config.Project,
 		"The Project the resource belongs to. If not set, the default project will be used.")
 	option.Wait(cmd.Flags(), &o.Wait, false, "Wait for the refresh to complete.")
+
+	completion.RegisterProjectFlag(cmd, o.Config, &o.ClientOptions)
 }
 
 // complete sets the resource type for the refresh options, and further parses
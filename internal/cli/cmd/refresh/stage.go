@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/cli/completion"
 	"github.com/akuity/kargo/internal/cli/config"
 	"github.com/akuity/kargo/internal/cli/option"
 	"github.com/akuity/kargo/internal/cli/templates"
@@ -49,6 +50,10 @@ kargo refresh stage my-stage
 	// Register the option flags on the command.
 	cmdOpts.addFlags(cmd)
 
+	cmd.ValidArgsFunction = completion.FirstArg(
+		completion.StageNames(cmdOpts.Config, &cmdOpts.ClientOptions, &cmdOpts.Project),
+	)
+
 	return cmd
 }
 
@@ -0,0 +1,151 @@
+package revoke
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
+	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/config"
+	"github.com/akuity/kargo/internal/cli/io"
+	"github.com/akuity/kargo/internal/cli/kubernetes"
+	"github.com/akuity/kargo/internal/cli/option"
+	"github.com/akuity/kargo/internal/cli/templates"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+type revokeRoleOptions struct {
+	genericiooptions.IOStreams
+	*genericclioptions.PrintFlags
+
+	Config        config.CLIConfig
+	ClientOptions client.Options
+
+	Project  string
+	Role     string
+	Subjects []string
+
+	userClaims *rbacapi.UserClaims
+}
+
+func newRoleCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra.Command {
+	cmdOpts := &revokeRoleOptions{
+		Config:     cfg,
+		IOStreams:  streams,
+		PrintFlags: genericclioptions.NewPrintFlags("updated").WithTypeSetter(kubernetes.GetScheme()),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "role [--project=project] NAME --from=KIND:VALUE [--from=KIND:VALUE]...",
+		Short: "Revoke a role from users identified by their sub claim, email address, or group",
+		Args:  option.ExactArgs(1),
+		Example: templates.Example(`
+# Revoke my-role from members of the platform-team group
+kargo revoke role my-role --project=my-project --from=group:platform-team
+
+# Revoke my-role from users with specific email addresses and sub claims
+kargo revoke role my-role --project=my-project \
+  --from=email:alice@example.com --from=sub:1234567890
+
+# Revoke my-role in the default project from members of the platform-team group
+kargo config set-project my-project
+kargo revoke role my-role --from=group:platform-team
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdOpts.complete(args)
+
+			if err := cmdOpts.validate(); err != nil {
+				return err
+			}
+
+			return cmdOpts.run(cmd.Context())
+		},
+	}
+
+	// Register the option flags on the command.
+	cmdOpts.addFlags(cmd)
+
+	// Set the input/output streams for the command.
+	io.SetIOStreams(cmd, cmdOpts.IOStreams)
+
+	return cmd
+}
+
+// addFlags adds the flags for the revoke role options to the provided command.
+func (o *revokeRoleOptions) addFlags(cmd *cobra.Command) {
+	o.ClientOptions.AddFlags(cmd.PersistentFlags())
+	o.PrintFlags.AddFlags(cmd)
+
+	option.Project(
+		cmd.Flags(), &o.Project, o.Config.Project,
+		"The project in which to revoke the role. If not set, the default project will be used.",
+	)
+	option.From(
+		cmd.Flags(), &o.Subjects,
+		"A subject to have the role revoked, of the form KIND:VALUE, where KIND is one of sub, email, or group.",
+	)
+
+	if err := cmd.MarkFlagRequired(option.FromFlag); err != nil {
+		panic(fmt.Errorf("could not mark %s flag as required: %w", option.FromFlag, err))
+	}
+}
+
+// complete sets the options from the command arguments.
+func (o *revokeRoleOptions) complete(args []string) {
+	o.Role = strings.TrimSpace(args[0])
+}
+
+// validate performs validation of the options. If the options are invalid, an
+// error is returned.
+func (o *revokeRoleOptions) validate() error {
+	var errs []error
+	// While the flags are marked as required, a user could still provide an empty
+	// string. This is a check to ensure that the flags are not empty.
+	if o.Project == "" {
+		errs = append(errs, fmt.Errorf("%s is required", option.ProjectFlag))
+	}
+	if o.Role == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	var err error
+	if o.userClaims, err = option.ParseSubjects(o.Subjects); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// run revokes a role from the users identified by the subjects.
+func (o *revokeRoleOptions) run(ctx context.Context) error {
+	kargoSvcCli, err := client.GetClientFromConfig(ctx, o.Config, o.ClientOptions)
+	if err != nil {
+		return fmt.Errorf("get client from config: %w", err)
+	}
+
+	resp, err := kargoSvcCli.Revoke(
+		ctx,
+		connect.NewRequest(&svcv1alpha1.RevokeRequest{
+			Project: o.Project,
+			Role:    o.Role,
+			Request: &svcv1alpha1.RevokeRequest_UserClaims{
+				UserClaims: o.userClaims,
+			},
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("revoke: %w", err)
+	}
+
+	printer, err := o.PrintFlags.ToPrinter()
+	if err != nil {
+		return fmt.Errorf("new printer: %w", err)
+	}
+
+	return printer.PrintObj(resp.Msg.Role, o.IOStreams.Out)
+}
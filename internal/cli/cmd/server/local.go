@@ -0,0 +1,272 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kelseyhightower/envconfig"
+	"golang.org/x/sync/errgroup"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/controller"
+	"github.com/akuity/kargo/internal/controller/clusterconfigs"
+	"github.com/akuity/kargo/internal/controller/management/namespaces"
+	"github.com/akuity/kargo/internal/controller/management/projectroles"
+	"github.com/akuity/kargo/internal/controller/management/projects"
+	"github.com/akuity/kargo/internal/controller/promotionplans"
+	"github.com/akuity/kargo/internal/controller/promotions"
+	"github.com/akuity/kargo/internal/controller/stages"
+	"github.com/akuity/kargo/internal/controller/warehouses"
+	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// localKargoNamespace is the namespace Kargo is presumed to be installed to
+// when running locally. Since the controllers run with the user's own
+// credentials rather than as a ServiceAccount in this namespace, nothing
+// needs to exist in it.
+const localKargoNamespace = "kargo"
+
+// localConfig holds the configuration of the reconcilers run locally.
+type localConfig struct {
+	credentials credentials.KubernetesDatabaseConfig
+	promotions  promotions.ReconcilerConfig
+	stages      stages.ReconcilerConfig
+	warehouses  warehouses.ReconcilerConfig
+}
+
+// localConfigFromEnv returns the configuration of the reconcilers run locally.
+// As when Kargo is installed to a cluster, each reconciler's defaults apply
+// unless overridden by environment variables. Integrations that are not
+// supported locally are disabled regardless.
+func localConfigFromEnv() (localConfig, error) {
+	var cfg localConfig
+	for _, spec := range []any{
+		&cfg.credentials,
+		&cfg.promotions,
+		&cfg.stages,
+		&cfg.warehouses,
+	} {
+		if err := envconfig.Process("", spec); err != nil {
+			return cfg, fmt.Errorf("error loading configuration: %w", err)
+		}
+	}
+	cfg.stages.RolloutsIntegrationEnabled = false
+	return cfg, nil
+}
+
+// runLocalControllers runs the management controller and the Kargo controller
+// in-process against the cluster described by the provided REST config. It
+// blocks until the provided context is canceled or a controller fails.
+//
+// Only what is needed to demo and develop promotion pipelines is run. Argo CD
+// and Argo Rollouts integrations are disabled, and since no webhook server is
+// run, no defaulting or validation of resources by webhooks takes place.
+// Reconciliation requests are queued in memory by each controller, exactly as
+// they are when Kargo is installed to a cluster, so no external queue is
+// required; pending requests are simply lost when the process exits and are
+// recreated by the initial sync of the next run.
+func runLocalControllers(ctx context.Context, restCfg *rest.Config) error {
+	logger := logging.LoggerFromContext(ctx)
+
+	restCfg = rest.CopyConfig(restCfg)
+	restCfg.ContentType = runtime.ContentTypeJSON
+
+	if err := checkKargoCRDsExist(ctx, restCfg); err != nil {
+		return err
+	}
+
+	cfg, err := localConfigFromEnv()
+	if err != nil {
+		return err
+	}
+
+	mgmtMgr, err := newLocalManagementManager(restCfg)
+	if err != nil {
+		return fmt.Errorf("error initializing management controller manager: %w", err)
+	}
+	kargoMgr, err := newLocalKargoManager(restCfg)
+	if err != nil {
+		return fmt.Errorf("error initializing Kargo controller manager: %w", err)
+	}
+	if err = setupLocalReconcilers(ctx, mgmtMgr, kargoMgr, cfg); err != nil {
+		return err
+	}
+
+	logger.Info("Starting local Kargo controllers")
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		if err := mgmtMgr.Start(ctx); err != nil {
+			return fmt.Errorf("error starting management controller manager: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		if err := kargoMgr.Start(ctx); err != nil {
+			return fmt.Errorf("error starting Kargo controller manager: %w", err)
+		}
+		return nil
+	})
+	return g.Wait()
+}
+
+// setupLocalReconcilers registers the management controller's reconcilers with
+// the provided management controller manager and the Kargo controller's
+// reconcilers with the provided Kargo controller manager.
+func setupLocalReconcilers(
+	ctx context.Context,
+	mgmtMgr manager.Manager,
+	kargoMgr manager.Manager,
+	cfg localConfig,
+) error {
+	if err := namespaces.SetupReconcilerWithManager(mgmtMgr); err != nil {
+		return fmt.Errorf("error setting up Namespaces reconciler: %w", err)
+	}
+	if err := projects.SetupReconcilerWithManager(
+		mgmtMgr,
+		projects.ReconcilerConfig{KargoNamespace: localKargoNamespace},
+	); err != nil {
+		return fmt.Errorf("error setting up Projects reconciler: %w", err)
+	}
+	if err := projectroles.SetupReconcilerWithManager(mgmtMgr); err != nil {
+		return fmt.Errorf("error setting up ProjectRoles reconciler: %w", err)
+	}
+
+	credentialsDB := credentials.NewKubernetesDatabase(
+		kargoMgr.GetClient(),
+		cfg.credentials,
+	)
+	if err := promotions.SetupReconcilerWithManager(
+		ctx,
+		kargoMgr,
+		nil, // Argo CD integration is disabled
+		credentialsDB,
+		cfg.promotions,
+	); err != nil {
+		return fmt.Errorf("error setting up Promotions reconciler: %w", err)
+	}
+	if err := clusterconfigs.SetupReconcilerWithManager(kargoMgr); err != nil {
+		return fmt.Errorf("error setting up ClusterConfig reconciler: %w", err)
+	}
+	if err := stages.SetupReconcilerWithManager(
+		ctx,
+		kargoMgr,
+		nil, // Argo CD integration is disabled
+		credentialsDB,
+		cfg.stages,
+	); err != nil {
+		return fmt.Errorf("error setting up Stages reconciler: %w", err)
+	}
+	if err := promotionplans.SetupReconcilerWithManager(ctx, kargoMgr, ""); err != nil {
+		return fmt.Errorf("error setting up PromotionPlans reconciler: %w", err)
+	}
+	if err := warehouses.SetupReconcilerWithManager(
+		ctx,
+		kargoMgr,
+		credentialsDB,
+		cfg.warehouses,
+	); err != nil {
+		return fmt.Errorf("error setting up Warehouses reconciler: %w", err)
+	}
+	return nil
+}
+
+// checkKargoCRDsExist returns an error explaining how to proceed if Kargo's
+// CRDs are not installed in the cluster described by the provided REST config.
+func checkKargoCRDsExist(ctx context.Context, restCfg *rest.Config) error {
+	dynamicClient, err := dynamic.NewForConfig(restCfg)
+	if err != nil {
+		return fmt.Errorf("error creating dynamic client: %w", err)
+	}
+	if _, err = dynamicClient.Resource(
+		kargoapi.GroupVersion.WithResource("stages"),
+	).List(ctx, metav1.ListOptions{Limit: 1}); err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			return errors.New(
+				"Kargo CRDs were not found in the cluster; install them before " +
+					"running Kargo locally",
+			)
+		}
+		return fmt.Errorf("error checking for Kargo CRDs: %w", err)
+	}
+	return nil
+}
+
+// newLocalManagementManager returns a manager for the management controller's
+// reconcilers.
+func newLocalManagementManager(restCfg *rest.Config) (manager.Manager, error) {
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		corev1.AddToScheme,
+		rbacv1.AddToScheme,
+		appsv1.AddToScheme,
+		networkingv1.AddToScheme,
+		kargoapi.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			return nil, fmt.Errorf("error building management controller manager scheme: %w", err)
+		}
+	}
+	return ctrl.NewManager(
+		restCfg,
+		ctrl.Options{
+			Scheme: scheme,
+			Metrics: server.Options{
+				BindAddress: "0",
+			},
+		},
+	)
+}
+
+// newLocalKargoManager returns a manager for the Kargo controller's
+// reconcilers.
+func newLocalKargoManager(restCfg *rest.Config) (manager.Manager, error) {
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		corev1.AddToScheme,
+		kargoapi.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			return nil, fmt.Errorf("error building Kargo controller manager scheme: %w", err)
+		}
+	}
+	secretReq, err := controller.GetCredentialsRequirement()
+	if err != nil {
+		return nil, fmt.Errorf("error getting label requirement for credentials Secrets: %w", err)
+	}
+	return ctrl.NewManager(
+		restCfg,
+		ctrl.Options{
+			Scheme: scheme,
+			Metrics: server.Options{
+				BindAddress: "0",
+			},
+			Cache: cache.Options{
+				ByObject: map[client.Object]cache.ByObject{
+					// Only watch Secrets matching the label requirements for
+					// credentials.
+					&corev1.Secret{}: {
+						Label: labels.NewSelector().Add(*secretReq),
+					},
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,296 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestLocalConfigFromEnv(t *testing.T) {
+	testCases := []struct {
+		name       string
+		env        map[string]string
+		assertions func(*testing.T, localConfig, error)
+	}{
+		{
+			name: "defaults",
+			assertions: func(t *testing.T, cfg localConfig, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "10Gi", cfg.warehouses.GitCloneCacheMaxSize)
+				require.Equal(t, 24*time.Hour, cfg.warehouses.GitCloneCacheMaxIdle)
+			},
+		},
+		{
+			name: "overridden by environment",
+			env: map[string]string{
+				"GIT_CLONE_CACHE_MAX_SIZE": "1Gi",
+				"SHARD_NAME":               "fake-shard",
+			},
+			assertions: func(t *testing.T, cfg localConfig, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "1Gi", cfg.warehouses.GitCloneCacheMaxSize)
+				require.Equal(t, "fake-shard", cfg.promotions.ShardName)
+				require.Equal(t, "fake-shard", cfg.stages.ShardName)
+				require.Equal(t, "fake-shard", cfg.warehouses.ShardName)
+			},
+		},
+		{
+			name: "Argo Rollouts integration is always disabled",
+			env: map[string]string{
+				"ROLLOUTS_INTEGRATION_ENABLED": "true",
+			},
+			assertions: func(t *testing.T, cfg localConfig, err error) {
+				require.NoError(t, err)
+				require.False(t, cfg.stages.RolloutsIntegrationEnabled)
+			},
+		},
+		{
+			name: "invalid environment",
+			env: map[string]string{
+				"GIT_CLONE_CACHE_MAX_IDLE": "whenever",
+			},
+			assertions: func(t *testing.T, _ localConfig, err error) {
+				require.ErrorContains(t, err, "error loading configuration")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			for k, v := range testCase.env {
+				t.Setenv(k, v)
+			}
+			cfg, err := localConfigFromEnv()
+			testCase.assertions(t, cfg, err)
+		})
+	}
+}
+
+func TestCheckKargoCRDsExist(t *testing.T) {
+	testCases := []struct {
+		name       string
+		handler    http.HandlerFunc
+		assertions func(*testing.T, error)
+	}{
+		{
+			name: "CRDs not installed",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "Kargo CRDs were not found")
+			},
+		},
+		{
+			name: "error listing Stages",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "error checking for Kargo CRDs")
+			},
+		},
+		{
+			name: "CRDs installed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/apis/kargo.akuity.io/v1alpha1/stages" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(
+					`{"apiVersion":"kargo.akuity.io/v1alpha1","kind":"StageList","items":[]}`,
+				))
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			srv := httptest.NewServer(testCase.handler)
+			t.Cleanup(srv.Close)
+			testCase.assertions(
+				t,
+				checkKargoCRDsExist(context.Background(), &rest.Config{Host: srv.URL}),
+			)
+		})
+	}
+}
+
+func TestNewLocalManagers(t *testing.T) {
+	restCfg := newFakeRESTConfig(t)
+	testCases := []struct {
+		name         string
+		newManager   func(*rest.Config) (manager.Manager, error)
+		recognized   []schema.GroupVersionKind
+		unrecognized []schema.GroupVersionKind
+	}{
+		{
+			name:       "management controller manager",
+			newManager: newLocalManagementManager,
+			recognized: []schema.GroupVersionKind{
+				{Version: "v1", Kind: "Namespace"},
+				{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "Role"},
+				{Group: "networking.k8s.io", Version: "v1", Kind: "NetworkPolicy"},
+				kargoapi.GroupVersion.WithKind("Project"),
+			},
+		},
+		{
+			name:       "Kargo controller manager",
+			newManager: newLocalKargoManager,
+			recognized: []schema.GroupVersionKind{
+				{Version: "v1", Kind: "Secret"},
+				kargoapi.GroupVersion.WithKind("Stage"),
+				kargoapi.GroupVersion.WithKind("Warehouse"),
+			},
+			unrecognized: []schema.GroupVersionKind{
+				{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "Role"},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mgr, err := testCase.newManager(restCfg)
+			require.NoError(t, err)
+			for _, gvk := range testCase.recognized {
+				require.True(t, mgr.GetScheme().Recognizes(gvk), gvk.String())
+			}
+			for _, gvk := range testCase.unrecognized {
+				require.False(t, mgr.GetScheme().Recognizes(gvk), gvk.String())
+			}
+		})
+	}
+}
+
+func TestSetupLocalReconcilers(t *testing.T) {
+	restCfg := newFakeRESTConfig(t)
+
+	mgmtMgr, err := newLocalManagementManager(restCfg)
+	require.NoError(t, err)
+	kargoMgr, err := newLocalKargoManager(restCfg)
+	require.NoError(t, err)
+
+	cfg, err := localConfigFromEnv()
+	require.NoError(t, err)
+
+	require.NoError(
+		t,
+		setupLocalReconcilers(context.Background(), mgmtMgr, kargoMgr, cfg),
+	)
+}
+
+// newFakeRESTConfig returns a REST config for a fake API server that serves
+// discovery for every kind the local managers know about.
+func newFakeRESTConfig(t *testing.T) *rest.Config {
+	// The management controller manager's scheme is a superset of the Kargo
+	// controller manager's, and building it requires no API server.
+	mgmtMgr, err := newLocalManagementManager(&rest.Config{Host: "https://127.0.0.1:1"})
+	require.NoError(t, err)
+	srv := newFakeDiscoveryServer(t, mgmtMgr.GetScheme())
+	return &rest.Config{
+		Host: srv.URL,
+		ContentConfig: rest.ContentConfig{
+			ContentType: runtime.ContentTypeJSON,
+		},
+	}
+}
+
+// newFakeDiscoveryServer returns a test server that serves just enough of the
+// Kubernetes API discovery endpoints for controllers to be registered with a
+// manager for every kind known to the provided scheme. Informers are never
+// started, so nothing else is served.
+func newFakeDiscoveryServer(t *testing.T, scheme *runtime.Scheme) *httptest.Server {
+	clusterScoped := map[string]bool{
+		"Namespace":          true,
+		"ClusterRole":        true,
+		"ClusterRoleBinding": true,
+		"Project":            true,
+		"ClusterConfig":      true,
+	}
+	resources := map[schema.GroupVersion][]metav1.APIResource{}
+	for gvk := range scheme.AllKnownTypes() {
+		if strings.HasSuffix(gvk.Kind, "List") || strings.HasSuffix(gvk.Kind, "Options") ||
+			gvk.Kind == "WatchEvent" || gvk.Kind == "Status" {
+			continue
+		}
+		plural, _ := meta.UnsafeGuessKindToResource(gvk)
+		resources[gvk.GroupVersion()] = append(resources[gvk.GroupVersion()], metav1.APIResource{
+			Name:       plural.Resource,
+			Kind:       gvk.Kind,
+			Namespaced: !clusterScoped[gvk.Kind],
+			Verbs:      metav1.Verbs{"get", "list", "watch", "create", "update", "patch", "delete"},
+		})
+	}
+
+	groups := map[string]*metav1.APIGroup{}
+	for gv := range resources {
+		if gv.Group == "" {
+			continue
+		}
+		group, ok := groups[gv.Group]
+		if !ok {
+			group = &metav1.APIGroup{Name: gv.Group}
+			groups[gv.Group] = group
+		}
+		version := metav1.GroupVersionForDiscovery{
+			GroupVersion: gv.String(),
+			Version:      gv.Version,
+		}
+		group.Versions = append(group.Versions, version)
+		group.PreferredVersion = version
+	}
+	groupList := &metav1.APIGroupList{}
+	for _, group := range groups {
+		groupList.Groups = append(groupList.Groups, *group)
+	}
+
+	writeJSON := func(w http.ResponseWriter, obj any) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(obj)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch path := strings.Trim(r.URL.Path, "/"); {
+		case path == "api":
+			writeJSON(w, &metav1.APIVersions{Versions: []string{"v1"}})
+		case path == "apis":
+			writeJSON(w, groupList)
+		default:
+			var gv schema.GroupVersion
+			switch parts := strings.Split(path, "/"); {
+			case len(parts) == 2 && parts[0] == "api":
+				gv = schema.GroupVersion{Version: parts[1]}
+			case len(parts) == 3 && parts[0] == "apis":
+				gv = schema.GroupVersion{Group: parts[1], Version: parts[2]}
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			res, ok := resources[gv]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writeJSON(w, &metav1.APIResourceList{
+				GroupVersion: gv.String(),
+				APIResources: res,
+			})
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
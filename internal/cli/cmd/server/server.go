@@ -5,8 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+	k8s "k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	"github.com/akuity/kargo/internal/api"
@@ -15,11 +20,13 @@ import (
 	"github.com/akuity/kargo/internal/api/rbac"
 	"github.com/akuity/kargo/internal/cli/option"
 	"github.com/akuity/kargo/internal/cli/templates"
+	"github.com/akuity/kargo/internal/credentials"
 	fakeevent "github.com/akuity/kargo/internal/kubernetes/event/fake"
 )
 
 type serverOptions struct {
 	address string
+	local   bool
 }
 
 func NewCommand() *cobra.Command {
@@ -35,6 +42,10 @@ kargo server
 
 # Start a local Kargo API server on a specific address
 kargo server --address=127.0.0.1:3000
+
+# Start a local Kargo API server along with Kargo's controllers, for evaluating
+# Kargo using a local (e.g. kind or k3d) cluster
+kargo server --local --address=127.0.0.1:3000
 `),
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if err := cmdOpts.validate(); err != nil {
@@ -54,6 +65,9 @@ kargo server --address=127.0.0.1:3000
 func (o *serverOptions) addFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&o.address, "address", "127.0.0.1:0",
 		"Address to bind the server to. Defaults to binding to a random port on localhost.")
+	cmd.Flags().BoolVar(&o.local, "local", false,
+		"Also run Kargo's controllers in-process against the cluster of the current "+
+			"kubeconfig context. Intended for evaluating Kargo using a local cluster.")
 }
 
 // validate performs validation of the options. If the options are invalid, an
@@ -89,23 +103,52 @@ func (o *serverOptions) run(ctx context.Context) error {
 		return fmt.Errorf("error creating Kubernetes client: %w", err)
 	}
 
+	clientset, err := k8s.NewForConfig(restCfg)
+	if err != nil {
+		return fmt.Errorf("error creating Kubernetes clientset: %w", err)
+	}
+
+	credentialsCfg, err := credentials.KubernetesDatabaseConfigFromEnv()
+	if err != nil {
+		return fmt.Errorf("error initializing credentials database: %w", err)
+	}
+
 	l, err := net.Listen("tcp", o.address)
 	if err != nil {
 		return fmt.Errorf("start local server: %w", err)
 	}
 	defer l.Close() // nolint: errcheck
 
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	srv := api.NewServer(
 		apiconfig.ServerConfig{
 			LocalMode: true,
 		},
 		client,
 		client,
+		clientset.CoreV1(),
 		rbac.NewKubernetesRolesDatabase(client),
 		&fakeevent.EventRecorder{},
+		credentialsCfg,
 	)
-	if err := srv.Serve(ctx, l); err != nil {
-		return fmt.Errorf("serve error: %w", err)
+	if !o.local {
+		if err := srv.Serve(ctx, l); err != nil {
+			return fmt.Errorf("serve error: %w", err)
+		}
+		return nil
 	}
-	return nil
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		if err := srv.Serve(ctx, l); err != nil {
+			return fmt.Errorf("serve error: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		return runLocalControllers(ctx, restCfg)
+	})
+	return g.Wait()
 }
@@ -0,0 +1,115 @@
+package unblock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/config"
+	"github.com/akuity/kargo/internal/cli/option"
+	"github.com/akuity/kargo/internal/cli/templates"
+	v1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+type unblockOptions struct {
+	Config        config.CLIConfig
+	ClientOptions client.Options
+
+	Project      string
+	FreightName  string
+	FreightAlias string
+}
+
+func NewCommand(cfg config.CLIConfig) *cobra.Command {
+	cmdOpts := &unblockOptions{
+		Config: cfg,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "unblock [--project=project] (--freight=freight | --freight-alias=alias)",
+		Short: "Unblock a previously blocked piece of freight",
+		Args:  option.NoArgs,
+		Example: templates.Example(`
+# Unblock a piece of freight specified by name
+kargo unblock --project=my-project --freight=abc1234
+
+# Unblock a piece of freight specified by alias
+kargo unblock --project=my-project --freight-alias=wonky-wombat
+
+# Unblock a piece of freight specified by name in the default project
+kargo config set-project my-project
+kargo unblock --freight=abc1234
+`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := cmdOpts.validate(); err != nil {
+				return err
+			}
+
+			return cmdOpts.run(cmd.Context())
+		},
+	}
+
+	// Register the option flags on the command.
+	cmdOpts.addFlags(cmd)
+
+	return cmd
+}
+
+// addFlags adds the flags for the unblock options to the provided command.
+func (o *unblockOptions) addFlags(cmd *cobra.Command) {
+	o.ClientOptions.AddFlags(cmd.PersistentFlags())
+
+	option.Project(
+		cmd.Flags(), &o.Project, o.Config.Project,
+		"The project the freight belongs to. If not set, the default project will be used.",
+	)
+	option.Freight(cmd.Flags(), &o.FreightName, "The name of the freight to unblock.")
+	option.FreightAlias(cmd.Flags(), &o.FreightAlias, "The alias of the freight to unblock.")
+
+	cmd.MarkFlagsOneRequired(option.FreightFlag, option.FreightAliasFlag)
+	cmd.MarkFlagsMutuallyExclusive(option.FreightFlag, option.FreightAliasFlag)
+}
+
+// validate performs validation of the options. If the options are invalid, an
+// error is returned.
+func (o *unblockOptions) validate() error {
+	var errs []error
+	// While the flags are marked as required, a user could still provide an empty
+	// string. This is a check to ensure that the flags are not empty.
+	if o.Project == "" {
+		errs = append(errs, fmt.Errorf("%s is required", option.ProjectFlag))
+	}
+	if o.FreightName == "" && o.FreightAlias == "" {
+		errs = append(
+			errs,
+			fmt.Errorf("either %s or %s is required", option.FreightFlag, option.FreightAliasFlag),
+		)
+	}
+	return errors.Join(errs...)
+}
+
+// run unblocks a piece of freight based on the options.
+func (o *unblockOptions) run(ctx context.Context) error {
+	kargoSvcCli, err := client.GetClientFromConfig(ctx, o.Config, o.ClientOptions)
+	if err != nil {
+		return fmt.Errorf("get client from config: %w", err)
+	}
+
+	if _, err = kargoSvcCli.UnblockFreight(
+		ctx,
+		connect.NewRequest(
+			&v1alpha1.UnblockFreightRequest{
+				Project: o.Project,
+				Name:    o.FreightName,
+				Alias:   o.FreightAlias,
+			},
+		),
+	); err != nil {
+		return fmt.Errorf("unblock freight: %w", err)
+	}
+	return nil
+}
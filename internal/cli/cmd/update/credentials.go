@@ -34,6 +34,7 @@ type updateCredentialsOptions struct {
 	Git                         bool
 	Helm                        bool
 	Image                       bool
+	Package                     bool
 	Type                        string
 	RepoURL                     string
 	Regex                       bool
@@ -51,7 +52,7 @@ func newUpdateCredentialsCommand(cfg config.CLIConfig, streams genericiooptions.
 
 	cmd := &cobra.Command{
 		Use: `credentials [--project=project] NAME \
-    [--git | --helm | --image] \
+    [--git | --helm | --image | --package] \
     [--description=description] \
     [--repo-url=repo-url [--regex]] \
     [--username=username] \
@@ -114,6 +115,7 @@ func (o *updateCredentialsOptions) addFlags(cmd *cobra.Command) {
 	option.Git(cmd.Flags(), &o.Git, "Change the credentials to be for a Git repository.")
 	option.Helm(cmd.Flags(), &o.Helm, "Change the credentials to be for a Helm chart repository.")
 	option.Image(cmd.Flags(), &o.Image, "Change the credentials to be for a container image repository.")
+	option.Package(cmd.Flags(), &o.Package, "Change the credentials to be for a package registry.")
 	option.Type(cmd.Flags(), &o.Type, "Type of repository the credentials are for.")
 	option.RepoURL(cmd.Flags(), &o.RepoURL, "URL of the repository the credentials are for.")
 	option.Regex(
@@ -131,7 +133,7 @@ func (o *updateCredentialsOptions) addFlags(cmd *cobra.Command) {
 		"Change the password in the credentials interactively.",
 	)
 
-	cmd.MarkFlagsMutuallyExclusive(option.GitFlag, option.HelmFlag, option.ImageFlag, option.TypeFlag)
+	cmd.MarkFlagsMutuallyExclusive(option.GitFlag, option.HelmFlag, option.ImageFlag, option.PackageFlag, option.TypeFlag)
 
 	cmd.MarkFlagsMutuallyExclusive(option.PasswordFlag, option.InteractivePasswordFlag)
 }
@@ -184,6 +186,8 @@ func (o *updateCredentialsOptions) run(ctx context.Context) error {
 		o.Type = credentials.TypeHelm.String()
 	} else if o.Image {
 		o.Type = credentials.TypeImage.String()
+	} else if o.Package {
+		o.Type = credentials.TypePackage.String()
 	}
 
 	resp, err := kargoSvcCli.UpdateCredentials(
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/completion"
 	"github.com/akuity/kargo/internal/cli/config"
 	"github.com/akuity/kargo/internal/cli/option"
 	"github.com/akuity/kargo/internal/cli/templates"
@@ -83,6 +84,12 @@ func (o *updateFreightAliasOptions) addFlags(cmd *cobra.Command) {
 
 	cmd.MarkFlagsOneRequired(option.NameFlag, option.OldAliasFlag)
 	cmd.MarkFlagsMutuallyExclusive(option.NameFlag, option.OldAliasFlag)
+
+	completion.RegisterProjectFlag(cmd, o.Config, &o.ClientOptions)
+	completion.RegisterFreightFlags(
+		cmd, o.Config, &o.ClientOptions, &o.Project,
+		option.NameFlag, option.OldAliasFlag,
+	)
 }
 
 // validate performs validation of the options. If the options are invalid, an
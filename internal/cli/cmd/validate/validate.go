@@ -0,0 +1,30 @@
+package validate
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"github.com/akuity/kargo/internal/cli/config"
+	"github.com/akuity/kargo/internal/cli/option"
+	"github.com/akuity/kargo/internal/cli/templates"
+)
+
+func NewCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate SUBCOMMAND",
+		Short: "Validate resources",
+		Args:  option.NoArgs,
+		Example: templates.Example(`
+# Validate a warehouse and check that its repositories can be reached
+kargo validate warehouse --project=my-project my-warehouse
+
+# Validate the warehouses in warehouse.yaml without applying them
+kargo validate warehouse -f warehouse.yaml
+`),
+	}
+
+	// Register subcommands.
+	cmd.AddCommand(newWarehouseCommand(cfg, streams))
+
+	return cmd
+}
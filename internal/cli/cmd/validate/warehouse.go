@@ -0,0 +1,214 @@
+package validate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/config"
+	cliio "github.com/akuity/kargo/internal/cli/io"
+	"github.com/akuity/kargo/internal/cli/option"
+	"github.com/akuity/kargo/internal/cli/templates"
+	v1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+type warehouseOptions struct {
+	genericiooptions.IOStreams
+
+	Config        config.CLIConfig
+	ClientOptions client.Options
+
+	Project   string
+	Name      string
+	Filenames []string
+	Recursive bool
+}
+
+func newWarehouseCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra.Command {
+	cmdOpts := &warehouseOptions{
+		Config:    cfg,
+		IOStreams: streams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "warehouse [--project=project] (NAME | -f FILENAME)",
+		Short: "Validate a warehouse and check that its repositories can be reached",
+		Args:  option.MaximumNArgs(1),
+		Example: templates.Example(`
+# Validate a warehouse
+kargo validate warehouse --project=my-project my-warehouse
+
+# Validate the warehouses in warehouse.yaml without applying them
+kargo validate warehouse -f warehouse.yaml
+
+# Validate a warehouse in the default project
+kargo config set-project my-project
+kargo validate warehouse my-warehouse
+`),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmdOpts.complete(args)
+
+			if err := cmdOpts.validate(); err != nil {
+				return err
+			}
+
+			return cmdOpts.run(cmd.Context())
+		},
+	}
+
+	// Register the option flags on the command.
+	cmdOpts.addFlags(cmd)
+
+	// Set the input/output streams for the command.
+	cliio.SetIOStreams(cmd, cmdOpts.IOStreams)
+
+	return cmd
+}
+
+// addFlags adds the flags for the warehouse options to the provided command.
+func (o *warehouseOptions) addFlags(cmd *cobra.Command) {
+	o.ClientOptions.AddFlags(cmd.PersistentFlags())
+
+	option.Project(
+		cmd.Flags(), &o.Project, o.Config.Project,
+		"The project the warehouse belongs to. If not set, the default project "+
+			"will be used. Ignored for warehouses read from a file that specify "+
+			"a namespace.",
+	)
+	option.Filenames(cmd.Flags(), &o.Filenames, "Filename or directory to read the warehouse(s) from")
+	option.Recursive(cmd.Flags(), &o.Recursive)
+
+	if err := cmd.MarkFlagFilename(option.FilenameFlag, ".yaml", ".yml"); err != nil {
+		panic(fmt.Errorf("could not mark filename flag as filename: %w", err))
+	}
+	if err := cmd.MarkFlagDirname(option.FilenameFlag); err != nil {
+		panic(fmt.Errorf("could not mark filename flag as dirname: %w", err))
+	}
+}
+
+// complete sets the options from the command arguments.
+func (o *warehouseOptions) complete(args []string) {
+	if len(args) > 0 {
+		o.Name = strings.TrimSpace(args[0])
+	}
+}
+
+// validate performs validation of the options. If the options are invalid, an
+// error is returned.
+func (o *warehouseOptions) validate() error {
+	switch {
+	case o.Name == "" && len(o.Filenames) == 0:
+		return fmt.Errorf("either name or %s is required", option.FilenameFlag)
+	case o.Name != "" && len(o.Filenames) > 0:
+		return fmt.Errorf("name and %s are mutually exclusive", option.FilenameFlag)
+	case o.Name != "" && o.Project == "":
+		return fmt.Errorf("%s is required", option.ProjectFlag)
+	}
+	return nil
+}
+
+// run validates the warehouse(s) and prints any warnings.
+func (o *warehouseOptions) run(ctx context.Context) error {
+	var reqs []*v1alpha1.ValidateWarehouseRequest
+	if o.Name != "" {
+		reqs = append(reqs, &v1alpha1.ValidateWarehouseRequest{
+			Project: o.Project,
+			Name:    o.Name,
+		})
+	} else {
+		manifest, err := option.ReadManifests(o.Recursive, o.Filenames...)
+		if err != nil {
+			return fmt.Errorf("read manifests: %w", err)
+		}
+		warehouses, err := decodeWarehouses(manifest)
+		if err != nil {
+			return err
+		}
+		if len(warehouses) == 0 {
+			return errors.New("no warehouses found")
+		}
+		for _, warehouse := range warehouses {
+			project := warehouse.Namespace
+			if project == "" {
+				project = o.Project
+			}
+			if project == "" {
+				return fmt.Errorf(
+					"%s is required for warehouse %q, which does not specify a namespace",
+					option.ProjectFlag,
+					warehouse.Name,
+				)
+			}
+			reqs = append(reqs, &v1alpha1.ValidateWarehouseRequest{
+				Project: project,
+				Name:    warehouse.Name,
+				Spec:    &warehouse.Spec,
+			})
+		}
+	}
+
+	kargoSvcCli, err := client.GetClientFromConfig(ctx, o.Config, o.ClientOptions)
+	if err != nil {
+		return fmt.Errorf("get client from config: %w", err)
+	}
+
+	var warnings int
+	for _, req := range reqs {
+		res, err := kargoSvcCli.ValidateWarehouse(ctx, connect.NewRequest(req))
+		if err != nil {
+			return fmt.Errorf("validate warehouse %q: %w", req.GetName(), err)
+		}
+		for _, warning := range res.Msg.GetWarnings() {
+			_, _ = fmt.Fprintf(o.ErrOut, "Warning: warehouse %q: %s\n", req.GetName(), warning)
+		}
+		warnings += len(res.Msg.GetWarnings())
+		if len(res.Msg.GetWarnings()) == 0 {
+			_, _ = fmt.Fprintf(o.Out, "warehouse.kargo.akuity.io/%s validated\n", req.GetName())
+		}
+	}
+	if warnings > 0 {
+		return fmt.Errorf("validation produced %d warning(s)", warnings)
+	}
+	return nil
+}
+
+// decodeWarehouses returns the Warehouses contained in the provided YAML
+// manifest. Resources of any other kind are ignored.
+func decodeWarehouses(manifest []byte) ([]*kargoapi.Warehouse, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), 4096)
+	var warehouses []*kargoapi.Warehouse
+	for {
+		ext := runtime.RawExtension{}
+		if err := decoder.Decode(&ext); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("error decoding manifest: %w", err)
+		}
+		ext.Raw = bytes.TrimSpace(ext.Raw)
+		if len(ext.Raw) == 0 || bytes.Equal(ext.Raw, []byte("null")) {
+			continue
+		}
+		warehouse := &kargoapi.Warehouse{}
+		if err := yaml.Unmarshal(ext.Raw, warehouse); err != nil {
+			return nil, fmt.Errorf("error unmarshaling manifest: %w", err)
+		}
+		if warehouse.GroupVersionKind().Group != kargoapi.GroupVersion.Group ||
+			warehouse.Kind != "Warehouse" {
+			continue
+		}
+		warehouses = append(warehouses, warehouse)
+	}
+	return warehouses, nil
+}
@@ -5,39 +5,58 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/spf13/cobra"
 
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/completion"
 	"github.com/akuity/kargo/internal/cli/config"
 	"github.com/akuity/kargo/internal/cli/option"
 	"github.com/akuity/kargo/internal/cli/templates"
 	v1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+	"github.com/akuity/kargo/pkg/api/service/v1alpha1/svcv1alpha1connect"
 )
 
+// defaultAbortTimeout is how long to wait, by default, for an in-progress
+// verification to be aborted before a fresh one is started.
+const defaultAbortTimeout = 2 * time.Minute
+
 type verifyStageOptions struct {
 	Config        config.CLIConfig
 	ClientOptions client.Options
 
 	Project string
 	Name    string
+	Again   bool
 	Abort   bool
+
+	// abortTimeout is how long to wait for an in-progress verification to be
+	// aborted when Again is set.
+	abortTimeout time.Duration
 }
 
 func newVerifyStageCommand(cfg config.CLIConfig) *cobra.Command {
 	cmdOpts := &verifyStageOptions{
-		Config: cfg,
+		Config:       cfg,
+		abortTimeout: defaultAbortTimeout,
 	}
 
 	cmd := &cobra.Command{
-		Use:   "stage [--project=project] (NAME) [--abort]",
+		Use:   "stage [--project=project] (NAME) [--again|--abort]",
 		Short: "(Re)run or abort the verification of the stage's current freight",
 		Args:  option.ExactArgs(1),
 		Example: templates.Example(`
 # Rerun the verification of the stage's current freight
 kargo verify stage --project=my-project my-stage
 
+# Start a fresh verification of the stage's current freight, aborting any
+# verification that is still in progress. The results of previous
+# verifications are retained in the stage's history
+kargo verify stage --project=my-project my-stage --again
+
 # Rerun the verification of a stage in the default project
 kargo config set-project my-project
 kargo verify stage my-stage
@@ -74,7 +93,19 @@ func (o *verifyStageOptions) addFlags(cmd *cobra.Command) {
 		cmd.Flags(), &o.Project, o.Config.Project,
 		"The project the stage belongs to. If not set, the default project will be used.",
 	)
+	cmd.Flags().BoolVar(
+		&o.Again, "again", false,
+		"If set, any verification of the stage's current freight that is still "+
+			"in progress will be aborted before a fresh one is started. Without "+
+			"this flag, a rerun is refused while a verification is in progress.",
+	)
 	cmd.Flags().BoolVar(&o.Abort, "abort", false, "If set, the verification will be aborted.")
+	cmd.MarkFlagsMutuallyExclusive("again", "abort")
+
+	completion.RegisterProjectFlag(cmd, o.Config, &o.ClientOptions)
+	cmd.ValidArgsFunction = completion.FirstArg(
+		completion.StageNames(o.Config, &o.ClientOptions, &o.Project),
+	)
 }
 
 // complete sets the options from the command arguments.
@@ -119,6 +150,12 @@ func (o *verifyStageOptions) run(ctx context.Context) error {
 		return nil
 	}
 
+	if o.Again {
+		if err := o.abortInProgressVerification(ctx, kargoSvcCli); err != nil {
+			return err
+		}
+	}
+
 	if _, err := kargoSvcCli.Reverify(
 		ctx,
 		connect.NewRequest(
@@ -133,3 +170,92 @@ func (o *verifyStageOptions) run(ctx context.Context) error {
 
 	return nil
 }
+
+// abortInProgressVerification aborts the verification of the stage's current
+// freight if it is still in progress and waits for the abort to take effect,
+// so that a subsequent rerun request is not refused.
+func (o *verifyStageOptions) abortInProgressVerification(
+	ctx context.Context,
+	kargoSvcCli svcv1alpha1connect.KargoServiceClient,
+) error {
+	res, err := kargoSvcCli.GetStage(
+		ctx,
+		connect.NewRequest(
+			&v1alpha1.GetStageRequest{
+				Project: o.Project,
+				Name:    o.Name,
+			},
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("get stage: %w", err)
+	}
+	if !isVerificationInProgress(res.Msg.GetStage()) {
+		return nil
+	}
+
+	if _, err = kargoSvcCli.AbortVerification(
+		ctx,
+		connect.NewRequest(
+			&v1alpha1.AbortVerificationRequest{
+				Project: o.Project,
+				Stage:   o.Name,
+			},
+		),
+	); err != nil {
+		return fmt.Errorf("abort verification: %w", err)
+	}
+
+	watchCtx, cancel := context.WithTimeout(ctx, o.abortTimeout)
+	defer cancel()
+	watch, err := kargoSvcCli.WatchStages(
+		watchCtx,
+		connect.NewRequest(
+			&v1alpha1.WatchStagesRequest{
+				Project: o.Project,
+				Name:    o.Name,
+			},
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("watch stage: %w", err)
+	}
+	defer func() {
+		if conn, connErr := watch.Conn(); connErr == nil {
+			_ = conn.CloseRequest()
+		}
+	}()
+	for {
+		if !watch.Receive() {
+			err = watch.Err()
+			if errors.Is(watchCtx.Err(), context.DeadlineExceeded) ||
+				connect.CodeOf(err) == connect.CodeDeadlineExceeded {
+				return fmt.Errorf(
+					"timed out after %s waiting for the verification to be aborted",
+					o.abortTimeout,
+				)
+			}
+			if err != nil {
+				return fmt.Errorf("watch stage: %w", err)
+			}
+			return errors.New("unexpected end of watch stream")
+		}
+		msg := watch.Msg()
+		if msg == nil || msg.Stage == nil {
+			return errors.New("unexpected response")
+		}
+		if !isVerificationInProgress(msg.Stage) {
+			return nil
+		}
+	}
+}
+
+// isVerificationInProgress returns true if the verification of the provided
+// stage's current freight has not yet reached a terminal phase.
+func isVerificationInProgress(stage *kargoapi.Stage) bool {
+	if stage == nil || stage.Status.CurrentFreight == nil {
+		return false
+	}
+	info := stage.Status.CurrentFreight.VerificationInfo
+	return info != nil && !info.Phase.IsTerminal()
+}
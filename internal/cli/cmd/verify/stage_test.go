@@ -0,0 +1,166 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	v1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+	"github.com/akuity/kargo/pkg/api/service/v1alpha1/svcv1alpha1connect"
+)
+
+// fakeVerificationServer is a KargoServiceHandler that serves a single Stage
+// whose verification is in the phase reported by stagePhase, and that serves
+// each of watchPhases in turn to watchers of the Stage.
+type fakeVerificationServer struct {
+	svcv1alpha1connect.UnimplementedKargoServiceHandler
+
+	stagePhase  kargoapi.VerificationPhase
+	abortErr    error
+	watchPhases []kargoapi.VerificationPhase
+
+	aborted bool
+}
+
+func (f *fakeVerificationServer) GetStage(
+	context.Context,
+	*connect.Request[v1alpha1.GetStageRequest],
+) (*connect.Response[v1alpha1.GetStageResponse], error) {
+	return connect.NewResponse(&v1alpha1.GetStageResponse{
+		Result: &v1alpha1.GetStageResponse_Stage{
+			Stage: newVerifyingStage(f.stagePhase),
+		},
+	}), nil
+}
+
+func (f *fakeVerificationServer) AbortVerification(
+	context.Context,
+	*connect.Request[v1alpha1.AbortVerificationRequest],
+) (*connect.Response[v1alpha1.AbortVerificationResponse], error) {
+	if f.abortErr != nil {
+		return nil, f.abortErr
+	}
+	f.aborted = true
+	return connect.NewResponse(&v1alpha1.AbortVerificationResponse{}), nil
+}
+
+func (f *fakeVerificationServer) WatchStages(
+	ctx context.Context,
+	_ *connect.Request[v1alpha1.WatchStagesRequest],
+	stream *connect.ServerStream[v1alpha1.WatchStagesResponse],
+) error {
+	for _, phase := range f.watchPhases {
+		if err := stream.Send(&v1alpha1.WatchStagesResponse{
+			Stage: newVerifyingStage(phase),
+		}); err != nil {
+			return err
+		}
+	}
+	// Keep the stream open, as a real watch would, until the client gives up
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func newVerifyingStage(phase kargoapi.VerificationPhase) *kargoapi.Stage {
+	stage := &kargoapi.Stage{}
+	if phase != "" {
+		stage.Status.CurrentFreight = &kargoapi.FreightReference{
+			VerificationInfo: &kargoapi.VerificationInfo{Phase: phase},
+		}
+	}
+	return stage
+}
+
+func TestAbortInProgressVerification(t *testing.T) {
+	testCases := []struct {
+		name       string
+		server     *fakeVerificationServer
+		assertions func(*testing.T, *fakeVerificationServer, error)
+	}{
+		{
+			name: "no verification",
+			server: &fakeVerificationServer{
+				abortErr: errors.New("should not be called"),
+			},
+			assertions: func(t *testing.T, _ *fakeVerificationServer, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "verification not in progress",
+			server: &fakeVerificationServer{
+				stagePhase: kargoapi.VerificationPhaseSuccessful,
+				abortErr:   errors.New("should not be called"),
+			},
+			assertions: func(t *testing.T, _ *fakeVerificationServer, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "verification in progress",
+			server: &fakeVerificationServer{
+				stagePhase: kargoapi.VerificationPhaseRunning,
+				watchPhases: []kargoapi.VerificationPhase{
+					kargoapi.VerificationPhaseRunning,
+					kargoapi.VerificationPhaseAborted,
+				},
+			},
+			assertions: func(t *testing.T, srv *fakeVerificationServer, err error) {
+				require.NoError(t, err)
+				require.True(t, srv.aborted)
+			},
+		},
+		{
+			name: "error aborting verification",
+			server: &fakeVerificationServer{
+				stagePhase: kargoapi.VerificationPhaseRunning,
+				abortErr:   connect.NewError(connect.CodeInternal, errors.New("something went wrong")),
+			},
+			assertions: func(t *testing.T, _ *fakeVerificationServer, err error) {
+				require.ErrorContains(t, err, "abort verification")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "timed out waiting for abort",
+			server: &fakeVerificationServer{
+				stagePhase: kargoapi.VerificationPhaseRunning,
+				watchPhases: []kargoapi.VerificationPhase{
+					kargoapi.VerificationPhaseRunning,
+				},
+			},
+			assertions: func(t *testing.T, srv *fakeVerificationServer, err error) {
+				require.True(t, srv.aborted)
+				require.ErrorContains(t, err, "timed out")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, handler := svcv1alpha1connect.NewKargoServiceHandler(testCase.server)
+			srv := httptest.NewServer(handler)
+			t.Cleanup(srv.Close)
+
+			opts := &verifyStageOptions{
+				Project:      "fake-project",
+				Name:         "fake-stage",
+				Again:        true,
+				abortTimeout: 100 * time.Millisecond,
+			}
+			testCase.assertions(
+				t,
+				testCase.server,
+				opts.abortInProgressVerification(
+					context.Background(),
+					svcv1alpha1connect.NewKargoServiceClient(srv.Client(), srv.URL),
+				),
+			)
+		})
+	}
+}
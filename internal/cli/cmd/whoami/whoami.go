@@ -0,0 +1,179 @@
+package whoami
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/config"
+	"github.com/akuity/kargo/internal/cli/io"
+	"github.com/akuity/kargo/internal/cli/kubernetes"
+	"github.com/akuity/kargo/internal/cli/option"
+	"github.com/akuity/kargo/internal/cli/templates"
+	svcv1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+)
+
+type whoAmIOptions struct {
+	genericiooptions.IOStreams
+	*genericclioptions.PrintFlags
+
+	Config        config.CLIConfig
+	ClientOptions client.Options
+
+	Project     string
+	Permissions bool
+}
+
+func NewCommand(cfg config.CLIConfig, streams genericiooptions.IOStreams) *cobra.Command {
+	cmdOpts := &whoAmIOptions{
+		Config:     cfg,
+		IOStreams:  streams,
+		PrintFlags: genericclioptions.NewPrintFlags("").WithTypeSetter(kubernetes.GetScheme()),
+	}
+
+	cmd := &cobra.Command{
+		Use:   "whoami [--permissions] [--project=project]",
+		Short: "Show information about the current user",
+		Args:  option.NoArgs,
+		Example: templates.Example(`
+# Show information about the current user
+kargo whoami
+
+# Show the current user's effective permissions in all projects
+kargo whoami --permissions
+
+# Show the current user's effective permissions in a single project
+kargo whoami --permissions --project=my-project
+`),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmdOpts.run(cmd.Context())
+		},
+	}
+
+	// Register the option flags on the command.
+	cmdOpts.addFlags(cmd)
+
+	// Set the input/output streams for the command.
+	io.SetIOStreams(cmd, cmdOpts.IOStreams)
+
+	return cmd
+}
+
+// addFlags adds the flags for the whoami options to the provided command.
+func (o *whoAmIOptions) addFlags(cmd *cobra.Command) {
+	o.ClientOptions.AddFlags(cmd.PersistentFlags())
+	o.PrintFlags.AddFlags(cmd)
+
+	option.Project(
+		cmd.Flags(), &o.Project, "",
+		"The project for which to show permissions. If not set, permissions "+
+			"are shown for all projects.",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.Permissions, "permissions", o.Permissions,
+		"If true, shows the current user's effective permissions to promote, "+
+			"approve, and manage credentials",
+	)
+}
+
+// run prints information about the current user.
+func (o *whoAmIOptions) run(ctx context.Context) error {
+	kargoSvcCli, err := client.GetClientFromConfig(ctx, o.Config, o.ClientOptions)
+	if err != nil {
+		return fmt.Errorf("get client from config: %w", err)
+	}
+
+	resp, err := kargoSvcCli.WhoAmI(
+		ctx,
+		connect.NewRequest(&svcv1alpha1.WhoAmIRequest{
+			Project:     o.Project,
+			Permissions: o.Permissions || o.Project != "",
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("who am i: %w", err)
+	}
+
+	if o.PrintFlags.OutputFlagSpecified != nil && o.PrintFlags.OutputFlagSpecified() {
+		printer, err := o.PrintFlags.ToPrinter()
+		if err != nil {
+			return fmt.Errorf("new printer: %w", err)
+		}
+		obj, err := whoAmIToRuntimeObject(resp.Msg)
+		if err != nil {
+			return fmt.Errorf("map user information to runtime object: %w", err)
+		}
+		return printer.PrintObj(obj, o.IOStreams.Out)
+	}
+
+	return printWhoAmI(resp.Msg, o.IOStreams)
+}
+
+func printWhoAmI(res *svcv1alpha1.WhoAmIResponse, streams genericiooptions.IOStreams) error {
+	_, _ = fmt.Fprintln(streams.Out, "Subject:", res.GetSubject())
+	if res.GetEmail() != "" {
+		_, _ = fmt.Fprintln(streams.Out, "Email:", res.GetEmail())
+	}
+	if len(res.GetGroups()) > 0 {
+		_, _ = fmt.Fprintln(streams.Out, "Groups:", strings.Join(res.GetGroups(), ", "))
+	}
+	_, _ = fmt.Fprintln(streams.Out, "Admin:", res.GetAdmin())
+	if len(res.GetRoles()) > 0 {
+		_, _ = fmt.Fprintln(streams.Out, "Roles:", strings.Join(res.GetRoles(), ", "))
+	}
+
+	if len(res.GetProjectPermissions()) == 0 {
+		return nil
+	}
+	_, _ = fmt.Fprintln(streams.Out)
+	w := printers.GetNewTabWriter(streams.Out)
+	_, _ = fmt.Fprintln(w, "PROJECT\tVERB\tRESOURCE\tNAME\tALLOWED")
+	for _, projectPermissions := range res.GetProjectPermissions() {
+		for _, permission := range projectPermissions.GetPermissions() {
+			name := permission.GetName()
+			if name == "" {
+				name = "*"
+			}
+			_, _ = fmt.Fprintf(
+				w,
+				"%s\t%s\t%s\t%s\t%s\n",
+				projectPermissions.GetProject(),
+				permission.GetVerb(),
+				permission.GetResource(),
+				name,
+				strconv.FormatBool(permission.GetAllowed()),
+			)
+		}
+	}
+	return w.Flush()
+}
+
+func whoAmIToRuntimeObject(res *svcv1alpha1.WhoAmIResponse) (runtime.Object, error) {
+	data, err := protojson.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("marshal user information: %w", err)
+	}
+	var content map[string]any
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("unmarshal user information: %w", err)
+	}
+	u := &unstructured.Unstructured{}
+	u.SetUnstructuredContent(content)
+	u.SetAPIVersion(kargoapi.GroupVersion.String())
+	u.SetKind("WhoAmI")
+	return u, nil
+}
@@ -0,0 +1,79 @@
+package completion
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+// defaultCacheTTL is the amount of time for which the names of resources
+// retrieved from the Kargo API server are reused when completing subsequent
+// command lines.
+const defaultCacheTTL = time.Minute
+
+// cacheEntry is the representation of a cached list of resource names on disk.
+type cacheEntry struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Names     []string  `json:"names"`
+}
+
+// cache is a file-based cache of lists of resource names. Shell completion is
+// performed by a new process each time the user presses <TAB>, so the cache
+// must outlive the process to be of any use.
+type cache struct {
+	dir   string
+	ttl   time.Duration
+	nowFn func() time.Time
+}
+
+// newCache returns a cache that stores its entries in Kargo's directory in the
+// user's XDG cache home.
+func newCache() *cache {
+	return &cache{
+		dir:   filepath.Join(xdg.CacheHome, "kargo", "completion"),
+		ttl:   defaultCacheTTL,
+		nowFn: time.Now,
+	}
+}
+
+// get returns the names cached under the provided key if they were cached less
+// than the cache's TTL ago. Otherwise, it calls the provided function to
+// retrieve the names and caches them before returning them. Failure to read or
+// write the cache is not an error; the names are simply retrieved afresh.
+func (c *cache) get(key string, fetch func() ([]string, error)) ([]string, error) {
+	path := c.path(key)
+	if data, err := os.ReadFile(path); err == nil {
+		entry := cacheEntry{}
+		if err = json.Unmarshal(data, &entry); err == nil &&
+			c.nowFn().Sub(entry.FetchedAt) < c.ttl {
+			return entry.Names, nil
+		}
+	}
+
+	names, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(cacheEntry{
+		FetchedAt: c.nowFn(),
+		Names:     names,
+	}); err == nil {
+		if err = os.MkdirAll(c.dir, 0o700); err == nil {
+			_ = os.WriteFile(path, data, 0o600)
+		}
+	}
+	return names, nil
+}
+
+// path returns the path of the file in which the entry with the provided key
+// is stored. Keys are hashed because they may contain characters that are not
+// permitted in file names, such as the slashes of the API server's URL.
+func (c *cache) path(key string) string {
+	return filepath.Join(c.dir, fmt.Sprintf("%x.json", sha256.Sum256([]byte(key))))
+}
@@ -0,0 +1,113 @@
+package completion
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheGet(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		setup      func(*testing.T, *cache)
+		fetch      func() ([]string, error)
+		assertions func(*testing.T, *cache, []string, error)
+	}{
+		{
+			name: "cache miss",
+			fetch: func() ([]string, error) {
+				return []string{"fresh"}, nil
+			},
+			assertions: func(t *testing.T, c *cache, names []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"fresh"}, names)
+				// The names should have been cached
+				_, err = os.Stat(c.path("fake-key"))
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "cache hit",
+			setup: func(t *testing.T, c *cache) {
+				_, err := c.get("fake-key", func() ([]string, error) {
+					return []string{"cached"}, nil
+				})
+				require.NoError(t, err)
+			},
+			fetch: func() ([]string, error) {
+				return nil, errors.New("names should have been read from the cache")
+			},
+			assertions: func(t *testing.T, _ *cache, names []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"cached"}, names)
+			},
+		},
+		{
+			name: "cache entry expired",
+			setup: func(t *testing.T, c *cache) {
+				nowFn := c.nowFn
+				c.nowFn = func() time.Time {
+					return now.Add(-2 * defaultCacheTTL)
+				}
+				_, err := c.get("fake-key", func() ([]string, error) {
+					return []string{"stale"}, nil
+				})
+				require.NoError(t, err)
+				c.nowFn = nowFn
+			},
+			fetch: func() ([]string, error) {
+				return []string{"fresh"}, nil
+			},
+			assertions: func(t *testing.T, _ *cache, names []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"fresh"}, names)
+			},
+		},
+		{
+			name: "corrupt cache entry",
+			setup: func(t *testing.T, c *cache) {
+				require.NoError(t, os.WriteFile(c.path("fake-key"), []byte("garbage"), 0o600))
+			},
+			fetch: func() ([]string, error) {
+				return []string{"fresh"}, nil
+			},
+			assertions: func(t *testing.T, _ *cache, names []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"fresh"}, names)
+			},
+		},
+		{
+			name: "error fetching names",
+			fetch: func() ([]string, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, c *cache, _ []string, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+				// Nothing should have been cached
+				_, err = os.Stat(c.path("fake-key"))
+				require.True(t, os.IsNotExist(err))
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := &cache{
+				dir: t.TempDir(),
+				ttl: defaultCacheTTL,
+				nowFn: func() time.Time {
+					return now
+				},
+			}
+			if testCase.setup != nil {
+				testCase.setup(t, c)
+			}
+			names, err := c.get("fake-key", testCase.fetch)
+			testCase.assertions(t, c, names, err)
+		})
+	}
+}
@@ -0,0 +1,272 @@
+// Package completion provides shell completion of the names of resources for
+// the Kargo CLI. Names are retrieved from the Kargo API server and cached
+// briefly, so that completing a command line does not require a round trip to
+// the server on every keystroke.
+package completion
+
+import (
+	"context"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/config"
+	"github.com/akuity/kargo/internal/cli/option"
+	v1alpha1 "github.com/akuity/kargo/pkg/api/service/v1alpha1"
+	"github.com/akuity/kargo/pkg/api/service/v1alpha1/svcv1alpha1connect"
+)
+
+// Func is the signature of functions that complete arguments or flag values
+// of a cobra.Command.
+type Func func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective)
+
+// lister retrieves the names of a kind of resource in the provided Project
+// from the Kargo API server.
+type lister func(
+	ctx context.Context,
+	kargoSvcCli svcv1alpha1connect.KargoServiceClient,
+	project string,
+) ([]string, error)
+
+// ProjectNames returns a Func that completes the names of Projects.
+func ProjectNames(cfg config.CLIConfig, clientOpts *client.Options) Func {
+	return newFunc(cfg, clientOpts, nil, "projects", listProjectNames)
+}
+
+// StageNames returns a Func that completes the names of Stages in the Project
+// referenced by the provided pointer. The pointer is dereferenced only when
+// completing, after the command's flags have been parsed.
+func StageNames(cfg config.CLIConfig, clientOpts *client.Options, project *string) Func {
+	return newFunc(cfg, clientOpts, project, "stages", listStageNames)
+}
+
+// FreightNames returns a Func that completes the names of Freight in the
+// Project referenced by the provided pointer.
+func FreightNames(cfg config.CLIConfig, clientOpts *client.Options, project *string) Func {
+	return newFunc(cfg, clientOpts, project, "freight-names", listFreightNames)
+}
+
+// FreightAliases returns a Func that completes the aliases of Freight in the
+// Project referenced by the provided pointer.
+func FreightAliases(cfg config.CLIConfig, clientOpts *client.Options, project *string) Func {
+	return newFunc(cfg, clientOpts, project, "freight-aliases", listFreightAliases)
+}
+
+// FirstArg returns a Func that uses the provided Func to complete only the
+// first argument of a command, for commands that accept a single argument.
+func FirstArg(fn Func) Func {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return fn(cmd, args, toComplete)
+	}
+}
+
+// RegisterProjectFlag registers completion of Project names for the project
+// flag of the provided command.
+func RegisterProjectFlag(cmd *cobra.Command, cfg config.CLIConfig, clientOpts *client.Options) {
+	registerFlag(cmd, option.ProjectFlag, ProjectNames(cfg, clientOpts))
+}
+
+// RegisterStageFlags registers completion of Stage names for those of the
+// flags with the provided names that are defined by the provided command.
+func RegisterStageFlags(
+	cmd *cobra.Command,
+	cfg config.CLIConfig,
+	clientOpts *client.Options,
+	project *string,
+	flagNames ...string,
+) {
+	fn := StageNames(cfg, clientOpts, project)
+	for _, flagName := range flagNames {
+		registerFlag(cmd, flagName, fn)
+	}
+}
+
+// RegisterFreightFlags registers completion of Freight names and aliases for
+// the provided command's flags with the provided names, if it defines them.
+func RegisterFreightFlags(
+	cmd *cobra.Command,
+	cfg config.CLIConfig,
+	clientOpts *client.Options,
+	project *string,
+	nameFlag string,
+	aliasFlag string,
+) {
+	registerFlag(cmd, nameFlag, FreightNames(cfg, clientOpts, project))
+	registerFlag(cmd, aliasFlag, FreightAliases(cfg, clientOpts, project))
+}
+
+// registerFlag registers the provided Func for the flag with the provided name
+// if the provided command defines it.
+func registerFlag(cmd *cobra.Command, flagName string, fn Func) {
+	if cmd.Flags().Lookup(flagName) == nil {
+		return
+	}
+	// The only possible errors are that the flag does not exist, which was
+	// ruled out above, or that a function was already registered for it.
+	_ = cmd.RegisterFlagCompletionFunc(flagName, fn)
+}
+
+func newFunc(
+	cfg config.CLIConfig,
+	clientOpts *client.Options,
+	project *string,
+	kind string,
+	list lister,
+) Func {
+	return func(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var opts client.Options
+		if clientOpts != nil {
+			opts = *clientOpts
+		}
+		var projectName string
+		if project != nil {
+			if projectName = *project; projectName == "" {
+				// Nothing can be listed without knowing the Project.
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+		}
+		names, err := complete(
+			cmd.Context(),
+			newCache(),
+			cfg,
+			opts,
+			kind,
+			projectName,
+			list,
+			toComplete,
+		)
+		if err != nil {
+			// Completion must not print errors to the terminal, so log them where
+			// `kargo __complete` debugging output goes instead.
+			cobra.CompDebugln(err.Error(), true)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// complete returns the names of the provided kind of resource in the provided
+// Project that start with toComplete.
+func complete(
+	ctx context.Context,
+	c *cache,
+	cfg config.CLIConfig,
+	clientOpts client.Options,
+	kind string,
+	project string,
+	list lister,
+	toComplete string,
+) ([]string, error) {
+	names, err := c.get(
+		strings.Join([]string{cfg.APIAddress, kind, project}, "\n"),
+		func() ([]string, error) {
+			kargoSvcCli, err := client.GetClientFromConfig(ctx, cfg, clientOpts)
+			if err != nil {
+				return nil, err
+			}
+			return list(ctx, kargoSvcCli, project)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return filterByPrefix(names, toComplete), nil
+}
+
+// filterByPrefix returns those of the provided names that start with the
+// provided prefix.
+func filterByPrefix(names []string, prefix string) []string {
+	filtered := make([]string, 0, len(names))
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			filtered = append(filtered, name)
+		}
+	}
+	return filtered
+}
+
+func listProjectNames(
+	ctx context.Context,
+	kargoSvcCli svcv1alpha1connect.KargoServiceClient,
+	_ string,
+) ([]string, error) {
+	res, err := kargoSvcCli.ListProjects(ctx, connect.NewRequest(&v1alpha1.ListProjectsRequest{}))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(res.Msg.GetProjects()))
+	for _, p := range res.Msg.GetProjects() {
+		names = append(names, p.Name)
+	}
+	return names, nil
+}
+
+func listStageNames(
+	ctx context.Context,
+	kargoSvcCli svcv1alpha1connect.KargoServiceClient,
+	project string,
+) ([]string, error) {
+	res, err := kargoSvcCli.ListStages(
+		ctx,
+		connect.NewRequest(&v1alpha1.ListStagesRequest{Project: project}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(res.Msg.GetStages()))
+	for _, s := range res.Msg.GetStages() {
+		names = append(names, s.Name)
+	}
+	return names, nil
+}
+
+func listFreightNames(
+	ctx context.Context,
+	kargoSvcCli svcv1alpha1connect.KargoServiceClient,
+	project string,
+) ([]string, error) {
+	return listFreight(ctx, kargoSvcCli, project, false)
+}
+
+func listFreightAliases(
+	ctx context.Context,
+	kargoSvcCli svcv1alpha1connect.KargoServiceClient,
+	project string,
+) ([]string, error) {
+	return listFreight(ctx, kargoSvcCli, project, true)
+}
+
+// listFreight returns the names, or the aliases if aliases is true, of all
+// Freight in the provided Project.
+func listFreight(
+	ctx context.Context,
+	kargoSvcCli svcv1alpha1connect.KargoServiceClient,
+	project string,
+	aliases bool,
+) ([]string, error) {
+	res, err := kargoSvcCli.QueryFreight(
+		ctx,
+		connect.NewRequest(&v1alpha1.QueryFreightRequest{Project: project}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	// We didn't specify any groupBy, so there should be one group with an
+	// empty key
+	freight := res.Msg.GetGroups()[""].GetFreight()
+	names := make([]string, 0, len(freight))
+	for _, f := range freight {
+		name := f.Name
+		if aliases {
+			name = f.Alias
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
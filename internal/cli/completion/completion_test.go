@@ -0,0 +1,88 @@
+package completion
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/kargo/internal/cli/client"
+	"github.com/akuity/kargo/internal/cli/config"
+	"github.com/akuity/kargo/pkg/api/service/v1alpha1/svcv1alpha1connect"
+)
+
+func TestComplete(t *testing.T) {
+	listStages := func(
+		context.Context,
+		svcv1alpha1connect.KargoServiceClient,
+		string,
+	) ([]string, error) {
+		return nil, errors.New("names should have been read from the cache")
+	}
+
+	testCases := []struct {
+		name       string
+		cfg        config.CLIConfig
+		project    string
+		toComplete string
+		assertions func(*testing.T, []string, error)
+	}{
+		{
+			name:       "names filtered by prefix",
+			cfg:        config.CLIConfig{APIAddress: "https://kargo.example.com"},
+			project:    "fake-project",
+			toComplete: "pr",
+			assertions: func(t *testing.T, names []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"prod-east", "prod-west"}, names)
+			},
+		},
+		{
+			name:    "entries are not shared across Projects",
+			cfg:     config.CLIConfig{APIAddress: "https://kargo.example.com"},
+			project: "other-project",
+			assertions: func(t *testing.T, _ []string, err error) {
+				// Nothing was cached for this Project, so a client is needed, but
+				// there are no credentials to create one with.
+				require.ErrorContains(t, err, "not logged in")
+			},
+		},
+		{
+			name:    "entries are not shared across API servers",
+			cfg:     config.CLIConfig{APIAddress: "https://other.example.com"},
+			project: "fake-project",
+			assertions: func(t *testing.T, _ []string, err error) {
+				require.ErrorContains(t, err, "not logged in")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := &cache{
+				dir:   t.TempDir(),
+				ttl:   defaultCacheTTL,
+				nowFn: time.Now,
+			}
+			_, err := c.get(
+				"https://kargo.example.com\nstages\nfake-project",
+				func() ([]string, error) {
+					return []string{"prod-east", "prod-west", "test", "uat"}, nil
+				},
+			)
+			require.NoError(t, err)
+			names, err := complete(
+				context.Background(),
+				c,
+				testCase.cfg,
+				client.Options{},
+				"stages",
+				testCase.project,
+				listStages,
+				testCase.toComplete,
+			)
+			testCase.assertions(t, names, err)
+		})
+	}
+}
@@ -12,6 +12,12 @@ const (
 	// AliasShortFlag is the short flag name for the alias flag.
 	AliasShortFlag = "a"
 
+	// ApprovedForFlag is the flag name for the approved-for flag.
+	ApprovedForFlag = "approved-for"
+
+	// ArtifactFlag is the flag name for the artifact flag.
+	ArtifactFlag = "artifact"
+
 	// AsKubernetesResourcesFlag is the flag name for the as-kubernetes-resources
 	// flag.
 	AsKubernetesResourcesFlag = "as-kubernetes-resources"
@@ -19,6 +25,12 @@ const (
 	// as-kubernetes-resources flag.
 	AsKubernetesResourcesShortFlag = "k"
 
+	// DiffFlag is the flag name for the diff flag.
+	DiffFlag = "diff"
+
+	// DryRunFlag is the flag name for the dry-run flag.
+	DryRunFlag = "dry-run"
+
 	// EmailFlag is the flag name for the email flag.
 	EmailFlag = "email"
 
@@ -33,6 +45,9 @@ const (
 	// FreightAliasFlag is the flag name for the freight-alias flag.
 	FreightAliasFlag = "freight-alias"
 
+	// FromFlag is the flag name for the from flag.
+	FromFlag = "from"
+
 	// GitFlag is the flag name for the git flag.
 	GitFlag = string(credentials.TypeGit)
 
@@ -66,6 +81,12 @@ const (
 	// OldAliasFlag is the flag name for the old-alias flag.
 	OldAliasFlag = "old-alias"
 
+	// OriginFlag is the flag name for the origin flag.
+	OriginFlag = "origin"
+
+	// PackageFlag is the flag name for the package flag.
+	PackageFlag = string(credentials.TypePackage)
+
 	// PasswordFlag is the flag name for the password flag.
 	PasswordFlag = "password"
 
@@ -79,6 +100,9 @@ const (
 	// RecursiveShortFlag is the short flag name for the recursive flag.
 	RecursiveShortFlag = "R"
 
+	// ReasonFlag is the flag name for the reason flag.
+	ReasonFlag = "reason"
+
 	// Regex is the flag name for the regex flag.
 	RegexFlag = "regex"
 
@@ -103,12 +127,18 @@ const (
 	// SubscribersOfFlag is the flag name for the subscribers-of flag.
 	SubscribersOfFlag = "subscribers-of"
 
+	// ToFlag is the flag name for the to flag.
+	ToFlag = "to"
+
 	// TypeFlag is the flag name for the type flag.
 	TypeFlag = "type"
 
 	// UsernameFlag is the flag name for the username flag.
 	UsernameFlag = "username"
 
+	// VerifiedInFlag is the flag name for the verified-in flag.
+	VerifiedInFlag = "verified-in"
+
 	// VerbFlag is the flag name for the verb flag.
 	VerbFlag = "verb"
 
@@ -126,6 +156,16 @@ func Aliases(fs *pflag.FlagSet, stage *[]string, usage string) {
 	fs.StringArrayVar(stage, AliasFlag, nil, usage)
 }
 
+// ApprovedFor adds the ApprovedForFlag to the provided flag set.
+func ApprovedFor(fs *pflag.FlagSet, stage *string, usage string) {
+	fs.StringVar(stage, ApprovedForFlag, "", usage)
+}
+
+// Artifact adds the ArtifactFlag to the provided flag set.
+func Artifact(fs *pflag.FlagSet, artifact *string, usage string) {
+	fs.StringVar(artifact, ArtifactFlag, "", usage)
+}
+
 // AsKubernetesResources adds the AsKubernetesResourcesFlag and
 // AsKubernetesResourcesShortFlag to the provided flag set.
 func AsKubernetesResources(fs *pflag.FlagSet, asKubernetesResources *bool, usage string) {
@@ -143,6 +183,16 @@ func Description(fs *pflag.FlagSet, stage *string, usage string) {
 	fs.StringVar(stage, DescriptionFlag, "", usage)
 }
 
+// Diff adds the DiffFlag to the provided flag set.
+func Diff(fs *pflag.FlagSet, diff *bool, usage string) {
+	fs.BoolVar(diff, DiffFlag, false, usage)
+}
+
+// DryRun adds the DryRunFlag to the provided flag set.
+func DryRun(fs *pflag.FlagSet, dryRun *bool, usage string) {
+	fs.BoolVar(dryRun, DryRunFlag, false, usage)
+}
+
 // Emails adds a multi-value EmailFlag to the provided flag set.
 func Emails(fs *pflag.FlagSet, emails *[]string, usage string) {
 	fs.StringSliceVar(emails, EmailFlag, nil, usage)
@@ -163,6 +213,11 @@ func FreightAlias(fs *pflag.FlagSet, stage *string, usage string) {
 	fs.StringVar(stage, FreightAliasFlag, "", usage)
 }
 
+// From adds a multi-value FromFlag to the provided flag set.
+func From(fs *pflag.FlagSet, from *[]string, usage string) {
+	fs.StringSliceVar(from, FromFlag, nil, usage)
+}
+
 // Git adds the GitFlag to the provided flag set.
 func Git(fs *pflag.FlagSet, git *bool, usage string) {
 	fs.BoolVar(git, GitFlag, false, usage)
@@ -223,6 +278,16 @@ func OldAlias(fs *pflag.FlagSet, stage *string, usage string) {
 	fs.StringVar(stage, OldAliasFlag, "", usage)
 }
 
+// Origin adds the OriginFlag to the provided flag set.
+func Origin(fs *pflag.FlagSet, warehouse *string, usage string) {
+	fs.StringVar(warehouse, OriginFlag, "", usage)
+}
+
+// Package adds the PackageFlag to the provided flag set.
+func Package(fs *pflag.FlagSet, pkg *bool, usage string) {
+	fs.BoolVar(pkg, PackageFlag, false, usage)
+}
+
 // Password adds the PasswordFlag to the provided flag set.
 func Password(fs *pflag.FlagSet, password *string, usage string) {
 	fs.StringVar(password, PasswordFlag, "", usage)
@@ -242,6 +307,11 @@ func Recursive(fs *pflag.FlagSet, recursive *bool) {
 	)
 }
 
+// Reason adds the ReasonFlag to the provided flag set.
+func Reason(fs *pflag.FlagSet, reason *string, usage string) {
+	fs.StringVar(reason, ReasonFlag, "", usage)
+}
+
 // RepoURL adds the RepoURLFlag to the provided flag set.
 func RepoURL(fs *pflag.FlagSet, repoURL *string, usage string) {
 	fs.StringVar(repoURL, RepoURLFlag, "", usage)
@@ -282,6 +352,11 @@ func SubscribersOf(fs *pflag.FlagSet, subscribersOf *string, usage string) {
 	fs.StringVar(subscribersOf, SubscribersOfFlag, "", usage)
 }
 
+// To adds a multi-value ToFlag to the provided flag set.
+func To(fs *pflag.FlagSet, to *[]string, usage string) {
+	fs.StringSliceVar(to, ToFlag, nil, usage)
+}
+
 // Type adds the TypeFlag to the provided flag set.
 func Type(fs *pflag.FlagSet, repoType *string, usage string) {
 	fs.StringVar(repoType, TypeFlag, "", usage)
@@ -292,6 +367,11 @@ func Username(fs *pflag.FlagSet, username *string, usage string) {
 	fs.StringVar(username, UsernameFlag, "", usage)
 }
 
+// VerifiedIn adds the VerifiedInFlag to the provided flag set.
+func VerifiedIn(fs *pflag.FlagSet, stage *string, usage string) {
+	fs.StringVar(stage, VerifiedInFlag, "", usage)
+}
+
 // Verbs adds a multi-value VerbFlag to the provided flag set.
 func Verbs(fs *pflag.FlagSet, verbs *[]string, usage string) {
 	fs.StringSliceVar(verbs, VerbFlag, nil, usage)
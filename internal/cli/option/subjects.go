@@ -0,0 +1,58 @@
+package option
+
+import (
+	"fmt"
+	"strings"
+
+	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
+)
+
+const (
+	// SubjectKindSub is the kind of subject identifying users by their sub
+	// claim.
+	SubjectKindSub = "sub"
+	// SubjectKindEmail is the kind of subject identifying users by their email
+	// address.
+	SubjectKindEmail = "email"
+	// SubjectKindGroup is the kind of subject identifying users by a group
+	// they are a member of.
+	SubjectKindGroup = "group"
+)
+
+// ParseSubjects parses the provided subjects, each of the form KIND:VALUE,
+// where KIND is one of "sub", "email", or "group", into UserClaims. An error
+// is returned if any subject is malformed.
+func ParseSubjects(subjects []string) (*rbacapi.UserClaims, error) {
+	claims := &rbacapi.UserClaims{}
+	for _, subject := range subjects {
+		kind, value, ok := strings.Cut(strings.TrimSpace(subject), ":")
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, fmt.Errorf(
+				"invalid subject %q: expected the form KIND:VALUE, where KIND is one of %q, %q, or %q",
+				subject,
+				SubjectKindSub,
+				SubjectKindEmail,
+				SubjectKindGroup,
+			)
+		}
+		switch kind {
+		case SubjectKindSub:
+			claims.Subs = append(claims.Subs, value)
+		case SubjectKindEmail:
+			claims.Emails = append(claims.Emails, value)
+		case SubjectKindGroup:
+			claims.Groups = append(claims.Groups, value)
+		default:
+			return nil, fmt.Errorf(
+				"invalid subject %q: unknown kind %q; expected one of %q, %q, or %q",
+				subject,
+				kind,
+				SubjectKindSub,
+				SubjectKindEmail,
+				SubjectKindGroup,
+			)
+		}
+	}
+	return claims, nil
+}
@@ -0,0 +1,82 @@
+package option
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
+)
+
+func TestParseSubjects(t *testing.T) {
+	testCases := []struct {
+		name       string
+		subjects   []string
+		assertions func(*testing.T, *rbacapi.UserClaims, error)
+	}{
+		{
+			name:     "no subjects",
+			subjects: nil,
+			assertions: func(t *testing.T, claims *rbacapi.UserClaims, err error) {
+				require.NoError(t, err)
+				require.Equal(t, &rbacapi.UserClaims{}, claims)
+			},
+		},
+		{
+			name: "subjects of every kind",
+			subjects: []string{
+				"group:platform-team",
+				"email:alice@example.com",
+				"sub:1234567890",
+				" group: admins ",
+			},
+			assertions: func(t *testing.T, claims *rbacapi.UserClaims, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					&rbacapi.UserClaims{
+						Subs:   []string{"1234567890"},
+						Emails: []string{"alice@example.com"},
+						Groups: []string{"platform-team", "admins"},
+					},
+					claims,
+				)
+			},
+		},
+		{
+			name:     "value containing a colon",
+			subjects: []string{"sub:oidc:1234567890"},
+			assertions: func(t *testing.T, claims *rbacapi.UserClaims, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"oidc:1234567890"}, claims.Subs)
+			},
+		},
+		{
+			name:     "subject without kind",
+			subjects: []string{"platform-team"},
+			assertions: func(t *testing.T, _ *rbacapi.UserClaims, err error) {
+				require.ErrorContains(t, err, "expected the form KIND:VALUE")
+			},
+		},
+		{
+			name:     "subject without value",
+			subjects: []string{"group:"},
+			assertions: func(t *testing.T, _ *rbacapi.UserClaims, err error) {
+				require.ErrorContains(t, err, "expected the form KIND:VALUE")
+			},
+		},
+		{
+			name:     "unknown kind",
+			subjects: []string{"team:platform"},
+			assertions: func(t *testing.T, _ *rbacapi.UserClaims, err error) {
+				require.ErrorContains(t, err, `unknown kind "team"`)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			claims, err := ParseSubjects(testCase.subjects)
+			testCase.assertions(t, claims, err)
+		})
+	}
+}
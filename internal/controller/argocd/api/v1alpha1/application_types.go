@@ -24,6 +24,7 @@ type ApplicationSpec struct {
 type ApplicationSource struct {
 	RepoURL        string                      `json:"repoURL"`
 	TargetRevision string                      `json:"targetRevision,omitempty"`
+	Path           string                      `json:"path,omitempty"`
 	Helm           *ApplicationSourceHelm      `json:"helm,omitempty"`
 	Kustomize      *ApplicationSourceKustomize `json:"kustomize,omitempty"`
 	Chart          string                      `json:"chart,omitempty"`
@@ -55,10 +56,21 @@ type ApplicationSourceKustomize struct {
 }
 
 type ApplicationStatus struct {
+	Resources      []ResourceStatus       `json:"resources,omitempty"`
 	Health         HealthStatus           `json:"health,omitempty"`
 	Sync           SyncStatus             `json:"sync,omitempty"`
 	Conditions     []ApplicationCondition `json:"conditions,omitempty"`
 	OperationState *OperationState        `json:"operationState,omitempty"`
+	History        RevisionHistories      `json:"history,omitempty"`
+}
+
+type RevisionHistories []RevisionHistory
+
+type RevisionHistory struct {
+	ID              int64        `json:"id"`
+	Revision        string       `json:"revision,omitempty"`
+	DeployStartedAt *metav1.Time `json:"deployStartedAt,omitempty"`
+	DeployedAt      metav1.Time  `json:"deployedAt"`
 }
 
 type OperationInitiator struct {
@@ -74,8 +86,31 @@ type Operation struct {
 }
 
 type SyncOperation struct {
-	SyncOptions SyncOptions `json:"syncOptions,omitempty"`
-	Revisions   []string    `json:"revisions,omitempty"`
+	Prune        bool                    `json:"prune,omitempty"`
+	SyncStrategy *SyncStrategy           `json:"syncStrategy,omitempty"`
+	Resources    []SyncOperationResource `json:"resources,omitempty"`
+	SyncOptions  SyncOptions             `json:"syncOptions,omitempty"`
+	Revisions    []string                `json:"revisions,omitempty"`
+}
+
+type SyncStrategy struct {
+	Apply *SyncStrategyApply `json:"apply,omitempty"`
+	Hook  *SyncStrategyHook  `json:"hook,omitempty"`
+}
+
+type SyncStrategyApply struct {
+	Force bool `json:"force,omitempty"`
+}
+
+type SyncStrategyHook struct {
+	SyncStrategyApply `json:",inline"`
+}
+
+type SyncOperationResource struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
 }
 
 type Info struct {
@@ -121,6 +156,16 @@ type SyncStatus struct {
 	Revisions []string       `json:"revisions,omitempty"`
 }
 
+type ResourceStatus struct {
+	Group     string         `json:"group,omitempty"`
+	Version   string         `json:"version,omitempty"`
+	Kind      string         `json:"kind,omitempty"`
+	Namespace string         `json:"namespace,omitempty"`
+	Name      string         `json:"name,omitempty"`
+	Status    SyncStatusCode `json:"status,omitempty"`
+	Health    *HealthStatus  `json:"health,omitempty"`
+}
+
 type HealthStatus struct {
 	Status  HealthStatusCode `json:"status,omitempty"`
 	Message string           `json:"message,omitempty"`
@@ -144,6 +189,8 @@ type OperationState struct {
 	Phase      OperationPhase       `json:"phase,omitempty"`
 	Message    string               `json:"message,omitempty"`
 	SyncResult *SyncOperationResult `json:"syncResult,omitempty"`
+	StartedAt  metav1.Time          `json:"startedAt"`
+	FinishedAt *metav1.Time         `json:"finishedAt,omitempty"`
 }
 
 type SyncOperationResult struct {
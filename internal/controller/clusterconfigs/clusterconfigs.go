@@ -0,0 +1,103 @@
+package clusterconfigs
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/controller"
+	"github.com/akuity/kargo/internal/controller/git"
+	"github.com/akuity/kargo/internal/image"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// reconciler reconciles the ClusterConfig resource by applying the per-host
+// settings it declares to the image and git packages.
+type reconciler struct {
+	client client.Client
+
+	// The following behaviors are overridable for testing purposes:
+
+	configureImageHostsFn func(map[string]image.HostOptions)
+
+	configureGitCABundlesFn func(map[string][]byte)
+}
+
+// SetupReconcilerWithManager initializes a reconciler for the ClusterConfig
+// resource and registers it with the provided Manager.
+func SetupReconcilerWithManager(mgr manager.Manager) error {
+	if err := ctrl.NewControllerManagedBy(mgr).
+		For(&kargoapi.ClusterConfig{}).
+		WithEventFilter(
+			predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return obj.GetName() == kargoapi.ClusterConfigName
+			}),
+		).
+		WithOptions(controller.CommonOptions()).
+		Complete(newReconciler(mgr.GetClient())); err != nil {
+		return fmt.Errorf("error building ClusterConfig reconciler: %w", err)
+	}
+	return nil
+}
+
+func newReconciler(kubeClient client.Client) *reconciler {
+	return &reconciler{
+		client:                  kubeClient,
+		configureImageHostsFn:   image.ConfigureHosts,
+		configureGitCABundlesFn: git.ConfigureCABundles,
+	}
+}
+
+// Reconcile is part of the main Kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *reconciler) Reconcile(
+	ctx context.Context,
+	req ctrl.Request,
+) (ctrl.Result, error) {
+	logger := logging.LoggerFromContext(ctx).WithField("clusterConfig", req.Name)
+	ctx = logging.ContextWithLogger(ctx, logger)
+	logger.Debug("reconciling ClusterConfig")
+
+	clusterCfg := &kargoapi.ClusterConfig{}
+	if err := r.client.Get(
+		ctx,
+		types.NamespacedName{Name: req.Name},
+		clusterCfg,
+	); err != nil {
+		if err = client.IgnoreNotFound(err); err != nil {
+			return ctrl.Result{}, fmt.Errorf("error getting ClusterConfig: %w", err)
+		}
+		// The ClusterConfig was deleted, so all settings revert to defaults.
+		clusterCfg = &kargoapi.ClusterConfig{}
+	}
+
+	r.applyHostConfigs(clusterCfg.Spec.Hosts)
+
+	logger.Debug("done reconciling ClusterConfig")
+	return ctrl.Result{}, nil
+}
+
+// applyHostConfigs applies the provided per-host settings to the image and git
+// packages, replacing any settings previously applied.
+func (r *reconciler) applyHostConfigs(hosts []kargoapi.HostConfig) {
+	imageHosts := make(map[string]image.HostOptions, len(hosts))
+	caBundles := make(map[string][]byte, len(hosts))
+	for _, host := range hosts {
+		imageHosts[host.Host] = image.HostOptions{
+			RateLimit: int(host.RateLimit),
+			CABundle:  []byte(host.CABundle),
+			Mirror:    host.Mirror,
+		}
+		if host.CABundle != "" {
+			caBundles[host.Host] = []byte(host.CABundle)
+		}
+	}
+	r.configureImageHostsFn(imageHosts)
+	r.configureGitCABundlesFn(caBundles)
+}
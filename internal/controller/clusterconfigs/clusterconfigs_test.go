@@ -0,0 +1,128 @@
+package clusterconfigs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/image"
+)
+
+func TestNewReconciler(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().Build()
+	r := newReconciler(kubeClient)
+	require.NotNil(t, r.client)
+	// Assert that all overridable behaviors were initialized to a default:
+	require.NotNil(t, r.configureImageHostsFn)
+	require.NotNil(t, r.configureGitCABundlesFn)
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, kargoapi.AddToScheme(scheme))
+
+	testCases := []struct {
+		name       string
+		objects    []client.Object
+		assertions func(*testing.T, map[string]image.HostOptions, map[string][]byte, error)
+	}{
+		{
+			name: "ClusterConfig not found",
+			assertions: func(
+				t *testing.T,
+				imageHosts map[string]image.HostOptions,
+				caBundles map[string][]byte,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Empty(t, imageHosts)
+				require.Empty(t, caBundles)
+			},
+		},
+		{
+			name: "ClusterConfig found",
+			objects: []client.Object{
+				&kargoapi.ClusterConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: kargoapi.ClusterConfigName},
+					Spec: kargoapi.ClusterConfigSpec{
+						Hosts: []kargoapi.HostConfig{
+							{
+								Host:      "registry.example.com",
+								RateLimit: 5,
+								CABundle:  "fake-ca-bundle",
+								Mirror:    "mirror.example.com",
+							},
+							{
+								Host:      "ghcr.io",
+								RateLimit: 20,
+							},
+						},
+					},
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				imageHosts map[string]image.HostOptions,
+				caBundles map[string][]byte,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					map[string]image.HostOptions{
+						"registry.example.com": {
+							RateLimit: 5,
+							CABundle:  []byte("fake-ca-bundle"),
+							Mirror:    "mirror.example.com",
+						},
+						"ghcr.io": {
+							RateLimit: 20,
+							CABundle:  []byte{},
+						},
+					},
+					imageHosts,
+				)
+				require.Equal(
+					t,
+					map[string][]byte{
+						"registry.example.com": []byte("fake-ca-bundle"),
+					},
+					caBundles,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var imageHosts map[string]image.HostOptions
+			var caBundles map[string][]byte
+			r := &reconciler{
+				client: fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(testCase.objects...).
+					Build(),
+				configureImageHostsFn: func(opts map[string]image.HostOptions) {
+					imageHosts = opts
+				},
+				configureGitCABundlesFn: func(bundles map[string][]byte) {
+					caBundles = bundles
+				},
+			}
+			_, err := r.Reconcile(
+				context.Background(),
+				ctrl.Request{
+					NamespacedName: types.NamespacedName{Name: kargoapi.ClusterConfigName},
+				},
+			)
+			testCase.assertions(t, imageHosts, caBundles, err)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+// SetReconcilingCondition sets a Ready condition with a status of Unknown in
+// the provided conditions, indicating that the controller has begun, but not
+// yet finished, reconciling the provided generation of a resource's spec.
+func SetReconcilingCondition(conditions *[]metav1.Condition, generation int64) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               kargoapi.ConditionTypeReady,
+		Status:             metav1.ConditionUnknown,
+		Reason:             kargoapi.ConditionReasonReconciling,
+		Message:            fmt.Sprintf("Reconciling generation %d", generation),
+		ObservedGeneration: generation,
+	})
+}
+
+// SetReadyCondition sets a Ready condition in the provided conditions that
+// reflects the outcome, described by the provided error, of reconciling the
+// provided generation of a resource's spec.
+func SetReadyCondition(conditions *[]metav1.Condition, generation int64, err error) {
+	if err != nil {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               kargoapi.ConditionTypeReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             kargoapi.ConditionReasonSyncFailed,
+			Message:            err.Error(),
+			ObservedGeneration: generation,
+		})
+		return
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               kargoapi.ConditionTypeReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             kargoapi.ConditionReasonSynced,
+		Message:            fmt.Sprintf("Generation %d has been reconciled", generation),
+		ObservedGeneration: generation,
+	})
+}
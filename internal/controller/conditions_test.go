@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestSetReconcilingCondition(t *testing.T) {
+	conditions := []metav1.Condition{{
+		Type:               kargoapi.ConditionTypeReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             kargoapi.ConditionReasonSynced,
+		ObservedGeneration: 1,
+	}}
+	SetReconcilingCondition(&conditions, 2)
+	cond := meta.FindStatusCondition(conditions, kargoapi.ConditionTypeReady)
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionUnknown, cond.Status)
+	require.Equal(t, kargoapi.ConditionReasonReconciling, cond.Reason)
+	require.Equal(t, "Reconciling generation 2", cond.Message)
+	require.Equal(t, int64(2), cond.ObservedGeneration)
+}
+
+func TestSetReadyCondition(t *testing.T) {
+	testCases := []struct {
+		name       string
+		err        error
+		assertions func(*testing.T, *metav1.Condition)
+	}{
+		{
+			name: "reconciled",
+			assertions: func(t *testing.T, cond *metav1.Condition) {
+				require.Equal(t, metav1.ConditionTrue, cond.Status)
+				require.Equal(t, kargoapi.ConditionReasonSynced, cond.Reason)
+				require.Equal(t, "Generation 2 has been reconciled", cond.Message)
+			},
+		},
+		{
+			name: "reconciled and failed",
+			err:  errors.New("something went wrong"),
+			assertions: func(t *testing.T, cond *metav1.Condition) {
+				require.Equal(t, metav1.ConditionFalse, cond.Status)
+				require.Equal(t, kargoapi.ConditionReasonSyncFailed, cond.Reason)
+				require.Equal(t, "something went wrong", cond.Message)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var conditions []metav1.Condition
+			SetReconcilingCondition(&conditions, 2)
+			SetReadyCondition(&conditions, 2, testCase.err)
+			require.Len(t, conditions, 1)
+			require.Equal(t, int64(2), conditions[0].ObservedGeneration)
+			testCase.assertions(t, &conditions[0])
+		})
+	}
+}
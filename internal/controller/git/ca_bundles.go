@@ -0,0 +1,73 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// systemCABundlePaths are the locations at which common Linux distributions
+// store the system's bundle of trusted CA certificates.
+var systemCABundlePaths = []string{
+	"/etc/ssl/certs/ca-certificates.crt", // Debian, Ubuntu, Alpine, etc.
+	"/etc/pki/tls/certs/ca-bundle.crt",   // Fedora, RHEL, etc.
+	"/etc/ssl/ca-bundle.pem",             // OpenSUSE
+}
+
+var (
+	// caBundles is a map of PEM-encoded CA bundles indexed by host.
+	caBundles map[string][]byte
+	// caBundlesMu is for preventing concurrent access to the caBundles map.
+	caBundlesMu sync.RWMutex
+)
+
+// ConfigureCABundles replaces all previously configured CA bundles with the
+// provided ones, which are indexed by host (optionally including a port). When
+// a repository hosted on one of these hosts is cloned over HTTPS, the
+// corresponding CA certificates are trusted in addition to the system's
+// trusted CAs.
+func ConfigureCABundles(bundles map[string][]byte) {
+	caBundlesMu.Lock()
+	defer caBundlesMu.Unlock()
+	caBundles = bundles
+}
+
+// getCABundle returns the CA bundle configured for the host of the provided
+// repository URL, if any.
+func getCABundle(repoURL string) []byte {
+	u, err := url.Parse(repoURL)
+	if err != nil || u.Scheme != "https" {
+		return nil
+	}
+	caBundlesMu.RLock()
+	defer caBundlesMu.RUnlock()
+	if bundle, ok := caBundles[u.Host]; ok {
+		return bundle
+	}
+	return caBundles[u.Hostname()]
+}
+
+// writeCABundle writes the provided CA bundle, preceded by the system's bundle
+// of trusted CA certificates (if it can be found), to a file in the specified
+// directory and returns the path to that file. Git does not support trusting
+// additional CAs, only replacing the trusted CAs, so the system's bundle must
+// be included to retain trust in publicly trusted CAs.
+func writeCABundle(dir string, bundle []byte) (string, error) {
+	var buf bytes.Buffer
+	for _, path := range systemCABundlePaths {
+		if systemBundle, err := os.ReadFile(path); err == nil {
+			buf.Write(systemBundle)
+			buf.WriteString("\n")
+			break
+		}
+	}
+	buf.Write(bundle)
+	path := filepath.Join(dir, "ca-bundle.pem")
+	if err := os.WriteFile(path, buf.Bytes(), 0600); err != nil {
+		return "", fmt.Errorf("error writing CA bundle to %q: %w", path, err)
+	}
+	return path, nil
+}
@@ -0,0 +1,319 @@
+package git
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	libExec "github.com/akuity/kargo/internal/exec"
+	libGit "github.com/akuity/kargo/internal/git"
+)
+
+var (
+	cloneCacheRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kargo_git_clone_cache_requests_total",
+			Help: "Number of clones served by the Git clone cache, by whether " +
+				"the repository was already cached",
+		},
+		[]string{"result"},
+	)
+	cloneCacheEvictionsTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "kargo_git_clone_cache_evictions_total",
+			Help: "Number of repositories evicted from the Git clone cache",
+		},
+	)
+	cloneCacheSizeBytes = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "kargo_git_clone_cache_size_bytes",
+			Help: "Disk space used by the repositories in the Git clone cache",
+		},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		cloneCacheRequestsTotal,
+		cloneCacheEvictionsTotal,
+		cloneCacheSizeBytes,
+	)
+}
+
+// CloneCache maintains a persistent bare mirror of each remote repository it
+// clones. Each clone first brings the mirror up to date using an incremental
+// fetch and then clones the remote repository using the mirror as a
+// reference, so that objects that were already fetched once are never fetched
+// again. Both steps authenticate to the remote repository, so the cache never
+// grants access to a repository that would otherwise be inaccessible.
+//
+// Mirrors are blobless partial clones, so that the cache holds only the
+// commits and trees needed for discovery and not the content of every file in
+// the history of every cached repository. Consequently, clones made through
+// the cache are always blobless as well and fetch the content of files on
+// demand.
+//
+// Mirrors that have not been used for longer than the maximum idle time are
+// evicted, as are the least recently used mirrors whenever the cache exceeds
+// its maximum size. Mirrors referenced by a Repo that has not been closed yet
+// are never evicted.
+type CloneCache struct {
+	dir      string
+	maxBytes int64
+	maxIdle  time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+// cacheEntry is a single mirror in a CloneCache.
+type cacheEntry struct {
+	dir      string
+	lastUsed time.Time
+	size     int64
+	users    int
+	// fetchMu serializes updates of the mirror.
+	fetchMu sync.Mutex
+}
+
+// NewCloneCache returns a CloneCache that keeps its mirrors in the specified
+// directory. Mirrors already present in the directory, for instance from
+// before a restart, are reused. A maxBytes or maxIdle of zero disables
+// eviction by size or idle time, respectively.
+func NewCloneCache(dir string, maxBytes int64, maxIdle time.Duration) (*CloneCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("error creating Git clone cache directory %q: %w", dir, err)
+	}
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Git clone cache directory %q: %w", dir, err)
+	}
+	c := &CloneCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		maxIdle:  maxIdle,
+		entries:  make(map[string]*cacheEntry, len(dirEntries)),
+	}
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("error reading Git clone cache directory %q: %w", dir, err)
+		}
+		entryDir := filepath.Join(dir, dirEntry.Name())
+		c.entries[dirEntry.Name()] = &cacheEntry{
+			dir:      entryDir,
+			lastUsed: info.ModTime(),
+			size:     dirSize(entryDir),
+		}
+	}
+	c.evict()
+	return c, nil
+}
+
+// Clone has the same semantics as the package-level Clone function, but
+// fetches objects through the cache's mirror of the remote repository. The
+// returned Repo must be closed once it is no longer needed, so that the mirror
+// becomes eligible for eviction.
+func (c *CloneCache) Clone(
+	repoURL string,
+	clientOpts *ClientOptions,
+	cloneOpts *CloneOptions,
+) (Repo, error) {
+	if cloneOpts == nil {
+		cloneOpts = &CloneOptions{}
+	}
+	if cloneOpts.Filter == "" {
+		// A clone that references a blobless mirror must itself be able to fetch
+		// missing blobs on demand, or its checkout would fail.
+		opts := *cloneOpts
+		opts.Filter = FilterBlobless
+		cloneOpts = &opts
+	}
+	r, err := newRepo(repoURL, clientOpts, cloneOpts)
+	if err != nil {
+		return nil, err
+	}
+	entry := c.acquire(repoURL)
+	release := func() {
+		c.release(entry)
+	}
+	if err = c.update(r, entry); err != nil {
+		release()
+		_ = r.Close()
+		return nil, err
+	}
+	r.referenceDir = entry.dir
+	if err = r.clone(cloneOpts); err != nil {
+		release()
+		_ = r.Close()
+		return nil, err
+	}
+	return &cachedRepo{repo: r, release: release}, nil
+}
+
+// acquire returns the cache entry for the specified repository, creating it
+// if necessary, and marks it as in use.
+func (c *CloneCache) acquire(repoURL string) *cacheEntry {
+	sum := sha256.Sum256([]byte(libGit.NormalizeURL(repoURL)))
+	key := hex.EncodeToString(sum[:])
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &cacheEntry{dir: filepath.Join(c.dir, key)}
+		c.entries[key] = entry
+	}
+	entry.users++
+	entry.lastUsed = time.Now()
+	return entry
+}
+
+// release marks the provided cache entry as no longer in use by one of its
+// users and evicts entries from the cache as necessary.
+func (c *CloneCache) release(entry *cacheEntry) {
+	c.mu.Lock()
+	entry.users--
+	entry.lastUsed = time.Now()
+	c.mu.Unlock()
+	c.evict()
+}
+
+// update brings the mirror of the provided cache entry up to date with the
+// remote repository of the provided repo, creating the mirror if it does not
+// exist yet.
+func (c *CloneCache) update(r *repo, entry *cacheEntry) error {
+	entry.fetchMu.Lock()
+	defer entry.fetchMu.Unlock()
+
+	if _, err := os.Stat(filepath.Join(entry.dir, "HEAD")); err == nil {
+		cmd := r.buildGitCommand("fetch", "--prune", "--filter="+FilterBlobless, "origin")
+		cmd.Dir = entry.dir
+		if _, err = libExec.Exec(cmd); err != nil {
+			return fmt.Errorf("error fetching repo %q into cache: %w", r.url, err)
+		}
+		cloneCacheRequestsTotal.WithLabelValues("hit").Inc()
+	} else {
+		// Remove any remains of an earlier, failed attempt.
+		if err = os.RemoveAll(entry.dir); err != nil {
+			return fmt.Errorf("error cleaning cache directory %q: %w", entry.dir, err)
+		}
+		cmd := r.buildGitCommand(
+			"clone", "--bare", "--filter="+FilterBlobless, r.url, entry.dir,
+		)
+		cmd.Dir = r.homeDir // Override the cmd.Dir that's set by r.buildGitCommand()
+		if _, err = libExec.Exec(cmd); err != nil {
+			_ = os.RemoveAll(entry.dir)
+			return fmt.Errorf("error cloning repo %q into cache: %w", r.url, err)
+		}
+		// Bare clones are not configured to fetch anything. Only branches and
+		// tags are of interest. Other refs, like those of pull requests, would
+		// needlessly bloat the mirror.
+		for _, refspec := range []string{
+			"+refs/heads/*:refs/heads/*",
+			"+refs/tags/*:refs/tags/*",
+		} {
+			cmd = r.buildGitCommand("config", "--add", "remote.origin.fetch", refspec)
+			cmd.Dir = entry.dir
+			if _, err = libExec.Exec(cmd); err != nil {
+				_ = os.RemoveAll(entry.dir)
+				return fmt.Errorf("error configuring cached repo %q: %w", r.url, err)
+			}
+		}
+		cloneCacheRequestsTotal.WithLabelValues("miss").Inc()
+	}
+
+	size := dirSize(entry.dir)
+	c.mu.Lock()
+	entry.size = size
+	c.mu.Unlock()
+	return nil
+}
+
+// evict removes mirrors that are not in use from the cache, first those that
+// have been idle for too long and then the least recently used ones until the
+// cache no longer exceeds its maximum size.
+func (c *CloneCache) evict() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	var total int64
+	candidates := make([]string, 0, len(c.entries))
+	for key, entry := range c.entries {
+		if entry.users > 0 {
+			total += entry.size
+			continue
+		}
+		if c.maxIdle > 0 && now.Sub(entry.lastUsed) > c.maxIdle {
+			c.removeLocked(key)
+			continue
+		}
+		total += entry.size
+		candidates = append(candidates, key)
+	}
+	if c.maxBytes > 0 && total > c.maxBytes {
+		slices.SortFunc(candidates, func(a, b string) int {
+			return c.entries[a].lastUsed.Compare(c.entries[b].lastUsed)
+		})
+		for _, key := range candidates {
+			if total <= c.maxBytes {
+				break
+			}
+			total -= c.entries[key].size
+			c.removeLocked(key)
+		}
+	}
+	cloneCacheSizeBytes.Set(float64(total))
+}
+
+// removeLocked removes the mirror with the specified key from the cache. The
+// caller must hold c.mu.
+func (c *CloneCache) removeLocked(key string) {
+	_ = os.RemoveAll(c.entries[key].dir)
+	delete(c.entries, key)
+	cloneCacheEvictionsTotal.Inc()
+}
+
+// dirSize returns the total size of the regular files in the specified
+// directory, ignoring any errors.
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // nolint: nilerr
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+// cachedRepo is a Repo cloned through a CloneCache. Closing it marks the
+// mirror it references as no longer in use.
+type cachedRepo struct {
+	*repo
+	release     func()
+	releaseOnce sync.Once
+}
+
+func (c *cachedRepo) Close() error {
+	err := c.repo.Close()
+	c.releaseOnce.Do(c.release)
+	return err
+}
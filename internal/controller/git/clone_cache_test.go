@@ -0,0 +1,135 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneCache(t *testing.T) {
+	repoDir := filepath.Join(t.TempDir(), "repo")
+	commit := func() {
+		out, err := exec.Command(
+			"git", "-C", repoDir,
+			"-c", "user.name=test", "-c", "user.email=test@example.com",
+			"commit", "--allow-empty", "-m", "commit",
+		).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	out, err := exec.Command("git", "init", "--initial-branch", "main", repoDir).CombinedOutput()
+	require.NoError(t, err, string(out))
+	commit()
+
+	cacheDir := t.TempDir()
+	cache, err := NewCloneCache(cacheDir, 0, 0)
+	require.NoError(t, err)
+
+	// The first clone populates the cache.
+	repo, err := cache.Clone(repoDir, nil, &CloneOptions{Branch: "main"})
+	require.NoError(t, err)
+	require.Len(t, cache.entries, 1)
+	firstID, err := repo.LastCommitID()
+	require.NoError(t, err)
+	require.NoError(t, repo.Close())
+	_, err = os.Stat(repo.HomeDir())
+	require.True(t, os.IsNotExist(err))
+
+	// Subsequent clones see new commits.
+	commit()
+	repo, err = cache.Clone(repoDir, nil, &CloneOptions{Branch: "main"})
+	require.NoError(t, err)
+	secondID, err := repo.LastCommitID()
+	require.NoError(t, err)
+	require.NotEqual(t, firstID, secondID)
+	for _, entry := range cache.entries {
+		require.Equal(t, 1, entry.users)
+		require.Positive(t, entry.size)
+	}
+
+	// Mirrors in use are never evicted.
+	cache.maxBytes = 1
+	cache.evict()
+	require.Len(t, cache.entries, 1)
+
+	// Mirrors exceeding the maximum size are evicted once they are released.
+	require.NoError(t, repo.Close())
+	require.Empty(t, cache.entries)
+	dirEntries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	require.Empty(t, dirEntries)
+
+	// Mirrors are reused across cache instances.
+	cache.maxBytes = 0
+	repo, err = cache.Clone(repoDir, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Close())
+	cache, err = NewCloneCache(cacheDir, 0, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, cache.entries, 1)
+
+	// Idle mirrors are evicted.
+	for _, entry := range cache.entries {
+		entry.lastUsed = time.Now().Add(-2 * time.Hour)
+	}
+	cache.evict()
+	require.Empty(t, cache.entries)
+}
+
+func TestCloneCacheBlobless(t *testing.T) {
+	repoDir := filepath.Join(t.TempDir(), "repo")
+	for _, args := range [][]string{
+		{"init", "--initial-branch", "main", repoDir},
+		// Filters are only honored by servers that allow them.
+		{"-C", repoDir, "config", "uploadpack.allowFilter", "true"},
+		{"-C", repoDir, "config", "uploadpack.allowAnySHA1InWant", "true"},
+	} {
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "file"), []byte("content"), 0o600))
+	for _, args := range [][]string{
+		{"-C", repoDir, "add", "file"},
+		{
+			"-C", repoDir,
+			"-c", "user.name=test", "-c", "user.email=test@example.com",
+			"commit", "-m", "commit",
+		},
+	} {
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	cache, err := NewCloneCache(t.TempDir(), 0, 0)
+	require.NoError(t, err)
+
+	// Clones through the cache check out file content even though the mirror
+	// holds none of it.
+	repo, err := cache.Clone("file://"+repoDir, nil, nil)
+	require.NoError(t, err)
+	defer repo.Close()
+	content, err := os.ReadFile(filepath.Join(repo.WorkingDir(), "file"))
+	require.NoError(t, err)
+	require.Equal(t, "content", string(content))
+	for _, entry := range cache.entries {
+		out, err := exec.Command(
+			"git", "-C", entry.dir,
+			"rev-list", "--objects", "--missing=print", "--all",
+		).CombinedOutput()
+		require.NoError(t, err, string(out))
+		require.Contains(t, string(out), "?")
+	}
+}
+
+func TestCloneCacheCloneError(t *testing.T) {
+	cache, err := NewCloneCache(t.TempDir(), 0, 0)
+	require.NoError(t, err)
+	_, err = cache.Clone(filepath.Join(t.TempDir(), "nonexistent"), nil, nil)
+	require.ErrorContains(t, err, "error cloning repo")
+	for _, entry := range cache.entries {
+		require.Zero(t, entry.users)
+	}
+}
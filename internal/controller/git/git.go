@@ -31,10 +31,46 @@ type RepoCredentials struct {
 	Password string `json:"password,omitempty"`
 }
 
+// ErrBranchProtected is returned (wrapped) by Repo.Push when the remote
+// repository rejects the push because the branch being pushed to is protected.
+var ErrBranchProtected = errors.New("branch is protected")
+
+// ProtectionRuleError is returned (wrapped) by Repo.Push when the remote
+// repository rejects the push because of a protection rule. It carries the
+// explanation given by the Git hosting provider and wraps ErrBranchProtected.
+type ProtectionRuleError struct {
+	// Branch is the name of the branch that could not be pushed.
+	Branch string
+	// Details are the messages with which the Git hosting provider explained
+	// the rejection, e.g. the protection rules that were violated.
+	Details []string
+}
+
+func (e *ProtectionRuleError) Error() string {
+	if len(e.Details) == 0 {
+		return ErrBranchProtected.Error()
+	}
+	return fmt.Sprintf(
+		"%s: %s",
+		ErrBranchProtected.Error(),
+		strings.Join(e.Details, "; "),
+	)
+}
+
+func (e *ProtectionRuleError) Unwrap() error {
+	return ErrBranchProtected
+}
+
+// ErrPushConflict is returned (wrapped) by Repo.Push when the remote
+// repository rejects the push because the remote branch contains commits that
+// the local branch does not.
+var ErrPushConflict = errors.New("remote branch contains conflicting changes")
+
 type SigningKeyType string
 
 const (
 	SigningKeyTypeGPG SigningKeyType = "gpg"
+	SigningKeyTypeSSH SigningKeyType = "ssh"
 )
 
 // User represents the user contributing to a git repository.
@@ -48,6 +84,10 @@ type User struct {
 	// SigningKeyPath is an optional path referencing a signing key for
 	// signing git objects.
 	SigningKeyPath string
+	// SigningKey is an optional signing key for signing git objects. It is an
+	// alternative to SigningKeyPath for keys that do not exist on the file
+	// system. If both are specified, SigningKey takes precedence.
+	SigningKey string
 }
 
 // CommitOptions represents options for committing changes to a git repository.
@@ -74,6 +114,10 @@ type TagMetadata struct {
 	// Subject is the subject (first line) of the commit message associated
 	// with the tag.
 	Subject string
+	// Trailers are the trailers of the commit message associated with the tag,
+	// indexed by key. A key may have multiple values if the trailer occurs more
+	// than once.
+	Trailers map[string][]string
 }
 
 type CommitMetadata struct {
@@ -88,6 +132,12 @@ type CommitMetadata struct {
 	Committer string
 	// Subject is the subject (first line) of the commit message.
 	Subject string
+	// Trailers are the trailers of the commit message, indexed by key. A key
+	// may have multiple values if the trailer occurs more than once.
+	Trailers map[string][]string
+	// ParentCount is the number of parents of the commit. Merge commits have
+	// more than one parent.
+	ParentCount int
 }
 
 // Repo is an interface for interacting with a git repository.
@@ -117,16 +167,28 @@ type Repo interface {
 	CurrentBranch() string
 	// DeleteBranch deletes the specified branch
 	DeleteBranch(branch string) error
+	// DeleteRemoteBranch deletes the specified branch from the remote
+	// repository.
+	DeleteRemoteBranch(branch string) error
 	// HasDiffs returns a bool indicating whether the working directory currently
 	// contains any differences from what's already at the head of the current
 	// branch.
 	HasDiffs() (bool, error)
+	// HasCommit returns a bool indicating whether the commit with the given ID
+	// exists in the local copy of the repository. Since clones may be limited to
+	// a single branch, this is also an indication of whether the commit is
+	// reachable from that branch.
+	HasCommit(commitID string) (bool, error)
 	// GetDiffPathsForCommitID returns a string slice indicating the paths,
 	// relative to the root of the repository, of any files that are new or
 	// modified in the commit with the given ID.
 	GetDiffPathsForCommitID(commitID string) ([]string, error)
 	// IsAncestor returns true if parent branch is an ancestor of child
 	IsAncestor(parent string, child string) (bool, error)
+	// ListBranches returns the names of all branches of the remote repository
+	// that were fetched when it was cloned. Only a clone that is not a
+	// single-branch clone includes all branches of the remote repository.
+	ListBranches() ([]string, error)
 	// LastCommitID returns the ID (sha) of the most recent commit to the current
 	// branch.
 	LastCommitID() (string, error)
@@ -134,13 +196,27 @@ type Repo interface {
 	// commit ID, creator date, and subject.
 	ListTags() ([]TagMetadata, error)
 	// ListCommits returns a slice of commits in the current branch with
-	// metadata such as commit ID, commit date, and subject.
-	ListCommits(limit, skip uint) ([]CommitMetadata, error)
+	// metadata such as commit ID, commit date, and subject. If any pathspecs are
+	// provided, only commits touching matching paths are returned. Merge commits
+	// are returned unless they are identical to all of their parents with
+	// respect to those paths.
+	ListCommits(limit, skip uint, pathspecs ...string) ([]CommitMetadata, error)
 	// CommitMessage returns the text of the most recent commit message associated
 	// with the specified commit ID.
 	CommitMessage(id string) (string, error)
+	// CommitBody returns the body of the commit message associated with the
+	// specified commit ID, i.e. the commit message without its subject.
+	CommitBody(id string) (string, error)
 	// Push pushes from the current branch to a remote branch by the same name.
+	// If the push is rejected because the remote branch is protected, the
+	// returned error wraps ErrBranchProtected. If the push is rejected because
+	// the remote branch contains commits that the current branch does not, the
+	// returned error wraps ErrPushConflict.
 	Push(force bool) error
+	// PullRebase fetches the remote branch by the same name as the current
+	// branch and rebases the current branch's local commits onto it. If the
+	// rebase results in conflicts, it is aborted and an error is returned.
+	PullRebase() error
 	// RefsHaveDiffs returns whether there is a diff between two commits/branches
 	RefsHaveDiffs(commit1 string, commit2 string) (bool, error)
 	// RemoteBranchExists returns a bool indicating if the specified branch exists
@@ -148,8 +224,21 @@ type Repo interface {
 	RemoteBranchExists(branch string) (bool, error)
 	// ResetHard performs a hard reset.
 	ResetHard() error
+	// TrustSigningKeys configures the repository to trust signatures made with
+	// any of the provided public keys when verifying commits and tags. gpgKeys
+	// may contain any number of ASCII-armored GPG public keys. sshKeys may
+	// contain any number of SSH public keys, one per line, either bare or in the
+	// allowed signers format understood by ssh-keygen. Either may be empty.
+	TrustSigningKeys(gpgKeys, sshKeys string) error
 	// URL returns the remote URL of the repository.
 	URL() string
+	// VerifyCommitSignature returns true if the commit with the given ID bears
+	// a valid signature made with a key trusted by way of TrustSigningKeys.
+	VerifyCommitSignature(commitID string) (bool, error)
+	// VerifyTagSignature returns true if the given tag is an annotated tag
+	// bearing a valid signature made with a key trusted by way of
+	// TrustSigningKeys.
+	VerifyTagSignature(tag string) (bool, error)
 	// WorkingDir returns an absolute path to the repository's working tree.
 	WorkingDir() string
 	// HomeDir returns an absolute path to the home directory of the system user
@@ -165,6 +254,8 @@ type repo struct {
 	dir                   string
 	currentBranch         string
 	insecureSkipTLSVerify bool
+	caBundlePath          string
+	referenceDir          string
 }
 
 // ClientOptions represents options for the git client. Commonly, the
@@ -221,6 +312,10 @@ type CloneOptions struct {
 	// should be ignored when cloning the repository. The setting will be
 	// remembered for subsequent interactions with the remote repository.
 	InsecureSkipTLSVerify bool
+	// InsecureHTTP specifies whether the repository may be cloned over plain
+	// HTTP. This is only honored if the repository's host was also configured
+	// using ConfigureInsecureHTTPHosts.
+	InsecureHTTP bool
 }
 
 // Clone produces a local clone of the remote git repository at the specified
@@ -233,6 +328,63 @@ func Clone(
 	clientOpts *ClientOptions,
 	cloneOpts *CloneOptions,
 ) (Repo, error) {
+	if cloneOpts == nil {
+		cloneOpts = &CloneOptions{}
+	}
+	r, err := newRepo(repoURL, clientOpts, cloneOpts)
+	if err != nil {
+		return nil, err
+	}
+	return r, r.clone(cloneOpts)
+}
+
+// CheckRemote verifies, without cloning it, that the remote repository can be
+// reached and read using the provided options. If cloneOpts specifies a
+// Branch, that branch must also exist in the remote repository. This is a
+// lightweight means of detecting, for instance, a mistyped repository URL or
+// missing credentials.
+func CheckRemote(
+	repoURL string,
+	clientOpts *ClientOptions,
+	cloneOpts *CloneOptions,
+) error {
+	if cloneOpts == nil {
+		cloneOpts = &CloneOptions{}
+	}
+	r, err := newRepo(repoURL, clientOpts, cloneOpts)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	args := []string{"ls-remote", "--heads"}
+	if cloneOpts.Branch != "" {
+		args = append(args, "--exit-code", r.url, cloneOpts.Branch)
+	} else {
+		args = append(args, r.url)
+	}
+	cmd := r.buildGitCommand(args...)
+	cmd.Dir = r.homeDir // Override the cmd.Dir that's set by r.buildGitCommand()
+	_, err = libExec.Exec(cmd)
+	var exitErr *libExec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode == 2 {
+		return fmt.Errorf("branch %q does not exist in repo %q", cloneOpts.Branch, r.url)
+	}
+	if err != nil {
+		return fmt.Errorf("error reading from repo %q: %w", r.url, err)
+	}
+	return nil
+}
+
+// newRepo prepares a home directory and client configuration for interacting
+// with the specified remote repository, but does not clone it.
+func newRepo(
+	repoURL string,
+	clientOpts *ClientOptions,
+	cloneOpts *CloneOptions,
+) (*repo, error) {
+	if err := validateInsecureHTTP(repoURL, cloneOpts.InsecureHTTP); err != nil {
+		return nil, err
+	}
 	homeDir, err := os.MkdirTemp("", "repo-")
 	if err != nil {
 		return nil, fmt.Errorf("error creating home directory for repo %q: %w", repoURL, err)
@@ -243,10 +395,15 @@ func Clone(
 		dir:                   filepath.Join(homeDir, "repo"),
 		insecureSkipTLSVerify: cloneOpts.InsecureSkipTLSVerify,
 	}
+	if bundle := getCABundle(repoURL); bundle != nil {
+		if r.caBundlePath, err = writeCABundle(homeDir, bundle); err != nil {
+			return nil, err
+		}
+	}
 	if err = r.setupClient(clientOpts); err != nil {
 		return nil, err
 	}
-	return r, r.clone(cloneOpts)
+	return r, nil
 }
 
 func (r *repo) AddAll() error {
@@ -284,6 +441,18 @@ func (r *repo) clone(opts *CloneOptions) error {
 	}
 	if opts.Depth > 0 {
 		args = append(args, "--depth", fmt.Sprint(opts.Depth))
+		if !opts.SingleBranch {
+			// --depth implies --single-branch unless told otherwise.
+			args = append(args, "--no-single-branch")
+		}
+	}
+	if opts.Filter != "" {
+		args = append(args, "--filter", opts.Filter)
+	}
+	if r.referenceDir != "" {
+		// Reuse the objects of a local repository, so that only those missing
+		// from it are fetched from the remote repository.
+		args = append(args, "--reference", r.referenceDir)
 	}
 	args = append(args, r.url, r.dir)
 	cmd := r.buildGitCommand(args...)
@@ -400,6 +569,23 @@ func (r *repo) DeleteBranch(branch string) error {
 	return nil
 }
 
+func (r *repo) DeleteRemoteBranch(branch string) error {
+	if _, err := libExec.Exec(r.buildGitCommand(
+		"push",
+		"origin",
+		"--delete",
+		branch,
+	)); err != nil {
+		return fmt.Errorf(
+			"error deleting branch %q from remote repo %q: %w",
+			branch,
+			r.url,
+			err,
+		)
+	}
+	return nil
+}
+
 func (r *repo) HasDiffs() (bool, error) {
 	resBytes, err := libExec.Exec(r.buildGitCommand("status", "-s"))
 	if err != nil {
@@ -408,6 +594,22 @@ func (r *repo) HasDiffs() (bool, error) {
 	return len(resBytes) > 0, nil
 }
 
+func (r *repo) HasCommit(commitID string) (bool, error) {
+	_, err := libExec.Exec(
+		r.buildGitCommand("rev-parse", "--verify", "--quiet", commitID+"^{commit}"),
+	)
+	if err == nil {
+		return true, nil
+	}
+	var execErr *libExec.ExitError
+	if errors.As(err, &execErr) {
+		if execErr.ExitCode == 1 {
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("error looking up commit %q: %w", commitID, err)
+}
+
 func (r *repo) GetDiffPathsForCommitID(commitID string) ([]string, error) {
 	resBytes, err := libExec.Exec(r.buildGitCommand("diff", "--name-only", commitID+"^", commitID))
 	if err != nil {
@@ -462,6 +664,7 @@ func (r *repo) ListTags() ([]TagMetadata, error) {
 	// - author name and email
 	// - committer name and email
 	// - creator date
+	// - trailers, separated by the unit separator character (%x1f)
 	//
 	// The `if`/`then`/`else` logic is used to ensure that we get the commit ID
 	// and subject of the tag, regardless of whether it's an annotated or
@@ -469,8 +672,8 @@ func (r *repo) ListTags() ([]TagMetadata, error) {
 	//
 	// nolint: lll
 	const (
-		formatAnnotatedTag   = `%(refname:short)|*|%(*objectname)|*|%(*contents:subject)|*|%(*authorname) %(*authoremail)|*|%(*committername) %(*committeremail)|*|%(*creatordate:iso8601)`
-		formatLightweightTag = `%(refname:short)|*|%(objectname)|*|%(contents:subject)|*|%(authorname) %(authoremail)|*|%(committername) %(committeremail)|*|%(creatordate:iso8601)`
+		formatAnnotatedTag   = `%(refname:short)|*|%(*objectname)|*|%(*contents:subject)|*|%(*authorname) %(*authoremail)|*|%(*committername) %(*committeremail)|*|%(*creatordate:iso8601)|*|%(*contents:trailers:only,unfold,separator=%x1f)`
+		formatLightweightTag = `%(refname:short)|*|%(objectname)|*|%(contents:subject)|*|%(authorname) %(authoremail)|*|%(committername) %(committeremail)|*|%(creatordate:iso8601)|*|%(contents:trailers:only,unfold,separator=%x1f)`
 		tagFormat            = `%(if)%(*objectname)%(then)` + formatAnnotatedTag + `%(else)` + formatLightweightTag + `%(end)`
 	)
 
@@ -488,8 +691,8 @@ func (r *repo) ListTags() ([]TagMetadata, error) {
 	scanner := bufio.NewScanner(bytes.NewReader(tagsBytes))
 	for scanner.Scan() {
 		line := scanner.Bytes()
-		parts := bytes.SplitN(scanner.Bytes(), []byte("|*|"), 6)
-		if len(parts) != 6 {
+		parts := bytes.SplitN(scanner.Bytes(), []byte("|*|"), 7)
+		if len(parts) != 7 {
 			return nil, fmt.Errorf("unexpected number of fields: %q", line)
 		}
 
@@ -505,13 +708,36 @@ func (r *repo) ListTags() ([]TagMetadata, error) {
 			Author:      string(parts[3]),
 			Committer:   string(parts[4]),
 			CreatorDate: creatorDate,
+			Trailers:    parseTrailers(parts[6]),
 		})
 	}
 
 	return tags, nil
 }
 
-func (r *repo) ListCommits(limit, skip uint) ([]CommitMetadata, error) {
+func (r *repo) ListBranches() ([]string, error) {
+	branchesBytes, err := libExec.Exec(r.buildGitCommand(
+		"for-each-ref",
+		// Strip the refs/remotes/origin/ prefix.
+		"--format=%(refname:lstrip=3)",
+		"refs/remotes/origin/",
+	))
+	if err != nil {
+		return nil, fmt.Errorf("error listing branches for repo %q: %w", r.url, err)
+	}
+	var branches []string
+	scanner := bufio.NewScanner(bytes.NewReader(branchesBytes))
+	for scanner.Scan() {
+		// origin/HEAD is a symbolic reference to the default branch rather
+		// than a branch in its own right.
+		if branch := strings.TrimSpace(scanner.Text()); branch != "" && branch != "HEAD" {
+			branches = append(branches, branch)
+		}
+	}
+	return branches, nil
+}
+
+func (r *repo) ListCommits(limit, skip uint, pathspecs ...string) ([]CommitMetadata, error) {
 	args := []string{
 		"log",
 		// This format is designed to output the following fields, separated by
@@ -521,8 +747,11 @@ func (r *repo) ListCommits(limit, skip uint) ([]CommitMetadata, error) {
 		// - commit date
 		// - author name and email
 		// - committer name and email
+		// - parent commit IDs, separated by spaces
+		// - trailers, separated by the unit separator character (%x1f)
 		// - subject
-		"--pretty=format:%H%x09%ci%x09%an <%ae>%x09%cn <%ce>%x09%s",
+		"--pretty=format:%H%x09%ci%x09%an <%ae>%x09%cn <%ce>%x09%P%x09" +
+			"%(trailers:only,unfold,separator=%x1f)%x09%s",
 	}
 	if limit > 0 {
 		args = append(args, fmt.Sprintf("--max-count=%d", limit))
@@ -530,6 +759,12 @@ func (r *repo) ListCommits(limit, skip uint) ([]CommitMetadata, error) {
 	if skip > 0 {
 		args = append(args, fmt.Sprintf("--skip=%d", skip))
 	}
+	if len(pathspecs) > 0 {
+		// Without --full-history, Git would simplify history by omitting merge
+		// commits that are identical to any one of their parents.
+		args = append(args, "--full-history", "--")
+		args = append(args, pathspecs...)
+	}
 
 	commitsBytes, err := libExec.Exec(r.buildGitCommand(args...))
 	if err != nil {
@@ -540,8 +775,8 @@ func (r *repo) ListCommits(limit, skip uint) ([]CommitMetadata, error) {
 	scanner := bufio.NewScanner(bytes.NewReader(commitsBytes))
 	for scanner.Scan() {
 		line := scanner.Bytes()
-		parts := bytes.SplitN(scanner.Bytes(), []byte("\t"), 5)
-		if len(parts) != 5 {
+		parts := bytes.SplitN(scanner.Bytes(), []byte("\t"), 7)
+		if len(parts) != 7 {
 			return nil, fmt.Errorf("unexpected number of fields: %q", line)
 		}
 
@@ -551,17 +786,39 @@ func (r *repo) ListCommits(limit, skip uint) ([]CommitMetadata, error) {
 		}
 
 		commits = append(commits, CommitMetadata{
-			ID:         string(parts[0]),
-			CommitDate: commitDate,
-			Author:     string(parts[2]),
-			Committer:  string(parts[3]),
-			Subject:    string(parts[4]),
+			ID:          string(parts[0]),
+			CommitDate:  commitDate,
+			Author:      string(parts[2]),
+			Committer:   string(parts[3]),
+			ParentCount: len(bytes.Fields(parts[4])),
+			Trailers:    parseTrailers(parts[5]),
+			Subject:     string(parts[6]),
 		})
 	}
 
 	return commits, nil
 }
 
+// parseTrailers parses commit message trailers, as output by Git when
+// formatting them with the "only", "unfold", and "separator=%x1f" options,
+// into a map of trailer values indexed by key. If there are no trailers, nil
+// is returned.
+func parseTrailers(b []byte) map[string][]string {
+	if len(b) == 0 {
+		return nil
+	}
+	trailers := map[string][]string{}
+	for _, trailer := range strings.Split(string(b), "\x1f") {
+		key, value, ok := strings.Cut(trailer, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		trailers[key] = append(trailers[key], strings.TrimSpace(value))
+	}
+	return trailers
+}
+
 func (r *repo) CommitMessage(id string) (string, error) {
 	msgBytes, err := libExec.Exec(
 		r.buildGitCommand("log", "-n", "1", "--pretty=format:%s", id),
@@ -572,17 +829,145 @@ func (r *repo) CommitMessage(id string) (string, error) {
 	return string(msgBytes), nil
 }
 
+func (r *repo) CommitBody(id string) (string, error) {
+	bodyBytes, err := libExec.Exec(
+		r.buildGitCommand("log", "-n", "1", "--pretty=format:%b", id),
+	)
+	if err != nil {
+		return "", fmt.Errorf("error obtaining commit message body for commit %q: %w", id, err)
+	}
+	return strings.TrimSpace(string(bodyBytes)), nil
+}
+
 func (r *repo) Push(force bool) error {
 	args := []string{"push", "origin", r.currentBranch}
 	if force {
 		args = append(args, "--force")
 	}
 	if _, err := libExec.Exec(r.buildGitCommand(args...)); err != nil {
+		if isBranchProtectedError(err) {
+			return fmt.Errorf(
+				"error pushing branch %q: %w",
+				r.currentBranch,
+				&ProtectionRuleError{
+					Branch:  r.currentBranch,
+					Details: getProtectionRuleDetails(err),
+				},
+			)
+		}
+		if isPushConflictError(err) {
+			return fmt.Errorf(
+				"error pushing branch %q: %w: %w",
+				r.currentBranch,
+				ErrPushConflict,
+				err,
+			)
+		}
 		return fmt.Errorf("error pushing branch %q: %w", r.currentBranch, err)
 	}
 	return nil
 }
 
+// protectedBranchErrorPatterns are (lowercase) substrings of the messages with
+// which well-known Git hosting providers reject pushes to protected branches.
+var protectedBranchErrorPatterns = []string{
+	// GitHub, GitLab, and Gitea
+	"protected branch",
+	// GitHub repository rulesets
+	"repository rule violations",
+	// Bitbucket
+	"can only be modified through pull requests",
+	// Azure DevOps
+	"tf402455",
+}
+
+// isBranchProtectedError returns a bool indicating whether the provided error
+// resulted from a push having been rejected because the branch being pushed to
+// is protected.
+func isBranchProtectedError(err error) bool {
+	var execErr *libExec.ExitError
+	if !errors.As(err, &execErr) {
+		return false
+	}
+	output := strings.ToLower(string(execErr.Output))
+	for _, pattern := range protectedBranchErrorPatterns {
+		if strings.Contains(output, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// getProtectionRuleDetails returns the messages with which the Git hosting
+// provider explained why the push that resulted in the provided error was
+// rejected. These are the non-empty lines of the push's output that were sent
+// by the remote. If there are none, the lines in which Git reports the rejected
+// refs are returned instead.
+func getProtectionRuleDetails(err error) []string {
+	var execErr *libExec.ExitError
+	if !errors.As(err, &execErr) {
+		return nil
+	}
+	var details, rejections []string
+	for _, line := range strings.Split(string(execErr.Output), "\n") {
+		line = strings.TrimSpace(line)
+		if remoteMsg, ok := strings.CutPrefix(line, "remote:"); ok {
+			if remoteMsg = strings.TrimSpace(remoteMsg); remoteMsg != "" {
+				details = append(details, remoteMsg)
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "! [remote rejected]") {
+			rejections = append(rejections, line)
+		}
+	}
+	if len(details) == 0 {
+		return rejections
+	}
+	return details
+}
+
+// pushConflictErrorPatterns are (lowercase) substrings of the messages with
+// which Git rejects pushes because the remote branch contains commits that the
+// local branch does not.
+var pushConflictErrorPatterns = []string{
+	"(fetch first)",
+	"(non-fast-forward)",
+	"cannot lock ref",
+}
+
+// isPushConflictError returns a bool indicating whether the provided error
+// resulted from a push having been rejected because the remote branch contains
+// commits that the local branch does not.
+func isPushConflictError(err error) bool {
+	var execErr *libExec.ExitError
+	if !errors.As(err, &execErr) {
+		return false
+	}
+	output := strings.ToLower(string(execErr.Output))
+	for _, pattern := range pushConflictErrorPatterns {
+		if strings.Contains(output, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *repo) PullRebase() error {
+	if _, err := libExec.Exec(
+		r.buildGitCommand("pull", "--rebase", "origin", r.currentBranch),
+	); err != nil {
+		// Leave the working tree as it was before the attempt
+		_, _ = libExec.Exec(r.buildGitCommand("rebase", "--abort"))
+		return fmt.Errorf(
+			"error rebasing branch %q onto remote branch: %w",
+			r.currentBranch,
+			err,
+		)
+	}
+	return nil
+}
+
 func (r *repo) RemoteBranchExists(branch string) (bool, error) {
 	_, err := libExec.Exec(r.buildGitCommand(
 		"ls-remote",
@@ -614,6 +999,60 @@ func (r *repo) ResetHard() error {
 	return nil
 }
 
+func (r *repo) TrustSigningKeys(gpgKeys, sshKeys string) error {
+	if gpgKeys != "" {
+		keysPath := filepath.Join(r.homeDir, "trusted-gpg-keys.asc")
+		if err := os.WriteFile(keysPath, []byte(gpgKeys), 0600); err != nil {
+			return fmt.Errorf("error writing trusted gpg keys to %q: %w", keysPath, err)
+		}
+		cmd := r.buildCommand("gpg", "--batch", "--import", keysPath)
+		cmd.Dir = r.homeDir // Override the cmd.Dir that's set by r.buildCommand()
+		if _, err := libExec.Exec(cmd); err != nil {
+			return fmt.Errorf("error importing trusted gpg keys: %w", err)
+		}
+	}
+	if sshKeys != "" {
+		allowedSignersPath := filepath.Join(r.homeDir, "allowed_signers")
+		if err := os.WriteFile(
+			allowedSignersPath,
+			[]byte(toAllowedSigners(sshKeys)),
+			0600,
+		); err != nil {
+			return fmt.Errorf(
+				"error writing trusted ssh keys to %q: %w",
+				allowedSignersPath,
+				err,
+			)
+		}
+		cmd := r.buildGitCommand(
+			"config", "--global", "gpg.ssh.allowedSignersFile", allowedSignersPath,
+		)
+		cmd.Dir = r.homeDir // Override the cmd.Dir that's set by r.buildGitCommand()
+		if _, err := libExec.Exec(cmd); err != nil {
+			return fmt.Errorf("error configuring trusted ssh keys: %w", err)
+		}
+	}
+	return nil
+}
+
+// toAllowedSigners converts the provided SSH public keys, one per line, to the
+// allowed signers format understood by ssh-keygen. Bare keys are permitted to
+// sign on behalf of any principal. Lines already in the allowed signers format,
+// blank lines, and comments are left as-is.
+func toAllowedSigners(sshKeys string) string {
+	lines := strings.Split(strings.TrimSpace(sshKeys), "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "ssh-") ||
+			strings.HasPrefix(line, "ecdsa-") ||
+			strings.HasPrefix(line, "sk-") {
+			line = "* " + line
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
 func (r *repo) URL() string {
 	return r.url
 }
@@ -622,6 +1061,31 @@ func (r *repo) HomeDir() string {
 	return r.homeDir
 }
 
+func (r *repo) VerifyCommitSignature(commitID string) (bool, error) {
+	return r.verifySignature("verify-commit", commitID)
+}
+
+func (r *repo) VerifyTagSignature(tag string) (bool, error) {
+	return r.verifySignature("verify-tag", tag)
+}
+
+// verifySignature uses the provided git verification command to verify the
+// signature of the provided object. Git exits with a non-zero code for any
+// object that is unsigned, bears an invalid or untrusted signature, or does not
+// exist, so all of these are treated as the signature not having been
+// verified.
+func (r *repo) verifySignature(command string, object string) (bool, error) {
+	_, err := libExec.Exec(r.buildGitCommand(command, object))
+	if err == nil {
+		return true, nil
+	}
+	var execErr *libExec.ExitError
+	if errors.As(err, &execErr) {
+		return false, nil
+	}
+	return false, fmt.Errorf("error verifying signature of %q: %w", object, err)
+}
+
 func (r *repo) WorkingDir() string {
 	return r.dir
 }
@@ -672,7 +1136,19 @@ func (r *repo) setupAuthor(author User) error {
 		return fmt.Errorf("error configuring git user email: %w", err)
 	}
 
-	if author.SigningKeyPath != "" && author.SigningKeyType == SigningKeyTypeGPG {
+	if author.SigningKey != "" {
+		author.SigningKeyPath = filepath.Join(r.homeDir, "signing-key")
+		if err := os.WriteFile(author.SigningKeyPath, []byte(author.SigningKey), 0600); err != nil {
+			return fmt.Errorf("error writing signing key to %q: %w", author.SigningKeyPath, err)
+		}
+	}
+
+	if author.SigningKeyPath == "" {
+		return nil
+	}
+
+	switch author.SigningKeyType {
+	case SigningKeyTypeGPG:
 		cmd = r.buildGitCommand("config", "--global", "commit.gpgsign", "true")
 		cmd.Dir = r.homeDir // Override the cmd.Dir that's set by r.buildGitCommand()
 		if _, err := libExec.Exec(cmd); err != nil {
@@ -684,6 +1160,18 @@ func (r *repo) setupAuthor(author User) error {
 		if _, err := libExec.Exec(cmd); err != nil {
 			return fmt.Errorf("error importing gpg key %q: %w", author.SigningKeyPath, err)
 		}
+	case SigningKeyTypeSSH:
+		for _, kv := range [][2]string{
+			{"gpg.format", "ssh"},
+			{"user.signingkey", author.SigningKeyPath},
+			{"commit.gpgsign", "true"},
+		} {
+			cmd = r.buildGitCommand("config", "--global", kv[0], kv[1])
+			cmd.Dir = r.homeDir // Override the cmd.Dir that's set by r.buildGitCommand()
+			if _, err := libExec.Exec(cmd); err != nil {
+				return fmt.Errorf("error configuring commit ssh signing: %w", err)
+			}
+		}
 	}
 
 	return nil
@@ -761,5 +1249,8 @@ func (r *repo) buildGitCommand(arg ...string) *exec.Cmd {
 	if r.insecureSkipTLSVerify {
 		cmd.Env = append(cmd.Env, "GIT_SSL_NO_VERIFY=true")
 	}
+	if r.caBundlePath != "" {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("GIT_SSL_CAINFO=%s", r.caBundlePath))
+	}
 	return cmd
 }
@@ -0,0 +1,384 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libExec "github.com/akuity/kargo/internal/exec"
+)
+
+func TestIsBranchProtectedError(t *testing.T) {
+	testCases := []struct {
+		name      string
+		err       error
+		protected bool
+	}{
+		{
+			name: "not an exit error",
+			err:  errors.New("protected branch"),
+		},
+		{
+			name: "unrelated push failure",
+			err: &libExec.ExitError{
+				Output: []byte("fatal: unable to access 'https://example.com/repo.git/'"),
+			},
+		},
+		{
+			name: "GitHub",
+			err: &libExec.ExitError{
+				Output: []byte(
+					"remote: error: GH006: Protected branch update failed for refs/heads/main.",
+				),
+			},
+			protected: true,
+		},
+		{
+			name: "GitHub repository rulesets",
+			err: &libExec.ExitError{
+				Output: []byte(
+					"remote: error: GH013: Repository rule violations found for refs/heads/main.",
+				),
+			},
+			protected: true,
+		},
+		{
+			name: "GitLab",
+			err: &libExec.ExitError{
+				Output: []byte(
+					"remote: GitLab: You are not allowed to push code to protected branches on this project.",
+				),
+			},
+			protected: true,
+		},
+		{
+			name: "Bitbucket",
+			err: &libExec.ExitError{
+				Output: []byte(
+					"remote: Branch refs/heads/main can only be modified through pull requests.",
+				),
+			},
+			protected: true,
+		},
+		{
+			name: "wrapped",
+			err: fmt.Errorf("something went wrong: %w", &libExec.ExitError{
+				Output: []byte("remote: error: GH006: Protected branch update failed"),
+			}),
+			protected: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.protected, isBranchProtectedError(testCase.err))
+		})
+	}
+}
+
+func TestGetProtectionRuleDetails(t *testing.T) {
+	testCases := []struct {
+		name    string
+		err     error
+		details []string
+	}{
+		{
+			name: "not an exit error",
+			err:  errors.New("something went wrong"),
+		},
+		{
+			name: "GitHub repository rulesets",
+			err: fmt.Errorf("something went wrong: %w", &libExec.ExitError{
+				Output: []byte(`remote: error: GH013: Repository rule violations found for refs/heads/main.
+remote: Review all repository rules at https://github.com/example/repo/rules?ref=refs%2Fheads%2Fmain
+remote:
+remote: - Changes must be made through a pull request.
+remote:
+To https://github.com/example/repo.git
+ ! [remote rejected] main -> main (push declined due to repository rule violations)
+error: failed to push some refs to 'https://github.com/example/repo.git'
+`),
+			}),
+			details: []string{
+				"error: GH013: Repository rule violations found for refs/heads/main.",
+				"Review all repository rules at https://github.com/example/repo/rules?ref=refs%2Fheads%2Fmain",
+				"- Changes must be made through a pull request.",
+			},
+		},
+		{
+			name: "no remote messages",
+			err: &libExec.ExitError{
+				Output: []byte(`To https://example.com/repo.git
+ ! [remote rejected] main -> main (protected branch hook declined)
+error: failed to push some refs to 'https://example.com/repo.git'
+`),
+			},
+			details: []string{
+				"! [remote rejected] main -> main (protected branch hook declined)",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.details, getProtectionRuleDetails(testCase.err))
+		})
+	}
+}
+
+func TestProtectionRuleError(t *testing.T) {
+	err := fmt.Errorf("error pushing branch %q: %w", "main", &ProtectionRuleError{
+		Branch:  "main",
+		Details: []string{"Changes must be made through a pull request."},
+	})
+	require.ErrorIs(t, err, ErrBranchProtected)
+	require.EqualError(
+		t,
+		err,
+		`error pushing branch "main": branch is protected: `+
+			"Changes must be made through a pull request.",
+	)
+	var ruleErr *ProtectionRuleError
+	require.ErrorAs(t, err, &ruleErr)
+	require.Equal(t, "main", ruleErr.Branch)
+
+	require.EqualError(t, &ProtectionRuleError{}, "branch is protected")
+}
+
+func TestIsPushConflictError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		conflict bool
+	}{
+		{
+			name: "not an exit error",
+			err:  errors.New("(fetch first)"),
+		},
+		{
+			name: "unrelated push failure",
+			err: &libExec.ExitError{
+				Output: []byte("fatal: unable to access 'https://example.com/repo.git/'"),
+			},
+		},
+		{
+			name: "remote contains work the local branch does not",
+			err: &libExec.ExitError{
+				Output: []byte(
+					" ! [rejected]        main -> main (fetch first)\n" +
+						"error: failed to push some refs to 'https://example.com/repo.git'",
+				),
+			},
+			conflict: true,
+		},
+		{
+			name: "local branch is behind",
+			err: &libExec.ExitError{
+				Output: []byte(" ! [rejected]        main -> main (non-fast-forward)"),
+			},
+			conflict: true,
+		},
+		{
+			name: "concurrent update of the remote ref",
+			err: fmt.Errorf("something went wrong: %w", &libExec.ExitError{
+				Output: []byte(
+					" ! [remote rejected] main -> main (cannot lock ref 'refs/heads/main')",
+				),
+			}),
+			conflict: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.conflict, isPushConflictError(testCase.err))
+		})
+	}
+}
+
+func TestCheckRemote(t *testing.T) {
+	repoDir := filepath.Join(t.TempDir(), "repo")
+	for _, args := range [][]string{
+		{"init", "--initial-branch", "main", repoDir},
+		{
+			"-C", repoDir,
+			"-c", "user.name=test", "-c", "user.email=test@example.com",
+			"commit", "--allow-empty", "-m", "initial commit",
+		},
+	} {
+		out, err := exec.Command("git", args...).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	testCases := []struct {
+		name       string
+		repoURL    string
+		branch     string
+		assertions func(*testing.T, error)
+	}{
+		{
+			name:    "repo does not exist",
+			repoURL: filepath.Join(t.TempDir(), "nonexistent"),
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "error reading from repo")
+			},
+		},
+		{
+			name:    "branch does not exist",
+			repoURL: repoDir,
+			branch:  "nonexistent",
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, `branch "nonexistent" does not exist`)
+			},
+		},
+		{
+			name:    "success without branch",
+			repoURL: repoDir,
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:    "success with branch",
+			repoURL: repoDir,
+			branch:  "main",
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				t,
+				CheckRemote(testCase.repoURL, nil, &CloneOptions{Branch: testCase.branch}),
+			)
+		})
+	}
+}
+
+func TestParseTrailers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected map[string][]string
+	}{
+		{
+			name: "no trailers",
+		},
+		{
+			name:  "single trailer",
+			input: "Change-Id: I123",
+			expected: map[string][]string{
+				"Change-Id": {"I123"},
+			},
+		},
+		{
+			name:  "multiple trailers",
+			input: "Change-Id: I123\x1fTicket: ABC-1\x1fTicket: ABC-2\x1fSigned-off-by: Jane <jane@example.com>",
+			expected: map[string][]string{
+				"Change-Id":     {"I123"},
+				"Ticket":        {"ABC-1", "ABC-2"},
+				"Signed-off-by": {"Jane <jane@example.com>"},
+			},
+		},
+		{
+			name:  "malformed trailer is ignored",
+			input: "malformed\x1fTicket: ABC-1",
+			expected: map[string][]string{
+				"Ticket": {"ABC-1"},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, parseTrailers([]byte(testCase.input)))
+		})
+	}
+}
+
+func TestVerifySignatures(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "signing-key")
+	out, err := exec.Command(
+		"ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", keyPath,
+	).CombinedOutput()
+	require.NoError(t, err, string(out))
+	publicKey, err := os.ReadFile(keyPath + ".pub")
+	require.NoError(t, err)
+
+	repoDir := filepath.Join(t.TempDir(), "repo")
+	out, err = exec.Command("git", "init", "--initial-branch", "main", repoDir).CombinedOutput()
+	require.NoError(t, err, string(out))
+	for _, args := range [][]string{
+		{"commit", "--allow-empty", "-S", "-m", "signed commit"},
+		{"tag", "-s", "-m", "signed tag", "signed"},
+		{"tag", "unsigned"},
+		{"commit", "--allow-empty", "--no-gpg-sign", "-m", "unsigned commit"},
+	} {
+		out, err = exec.Command(
+			"git",
+			append(
+				[]string{
+					"-C", repoDir,
+					"-c", "user.name=test", "-c", "user.email=test@example.com",
+					"-c", "gpg.format=ssh", "-c", "user.signingkey=" + keyPath,
+				},
+				args...,
+			)...,
+		).CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	repo, err := Clone(repoDir, nil, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = repo.Close()
+	})
+
+	// Nothing is verified before any keys are trusted
+	verified, err := repo.VerifyCommitSignature("HEAD~1")
+	require.NoError(t, err)
+	require.False(t, verified)
+
+	require.NoError(t, repo.TrustSigningKeys("", string(publicKey)))
+
+	verified, err = repo.VerifyCommitSignature("HEAD~1")
+	require.NoError(t, err)
+	require.True(t, verified)
+
+	verified, err = repo.VerifyCommitSignature("HEAD")
+	require.NoError(t, err)
+	require.False(t, verified)
+
+	// Tags are only fetched when they are listed
+	_, err = repo.ListTags()
+	require.NoError(t, err)
+
+	verified, err = repo.VerifyTagSignature("signed")
+	require.NoError(t, err)
+	require.True(t, verified)
+
+	verified, err = repo.VerifyTagSignature("unsigned")
+	require.NoError(t, err)
+	require.False(t, verified)
+}
+
+func TestToAllowedSigners(t *testing.T) {
+	require.Equal(
+		t,
+		"* ssh-ed25519 AAAA1 alice\n"+
+			"bob@example.com ssh-ed25519 AAAA2\n"+
+			"\n"+
+			"# comment\n"+
+			"* ecdsa-sha2-nistp256 AAAA3\n",
+		toAllowedSigners(
+			"ssh-ed25519 AAAA1 alice\n"+
+				"  bob@example.com ssh-ed25519 AAAA2\n"+
+				"\n"+
+				"# comment\n"+
+				"ecdsa-sha2-nistp256 AAAA3\n",
+		),
+	)
+}
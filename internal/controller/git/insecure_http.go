@@ -0,0 +1,54 @@
+package git
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	libGit "github.com/akuity/kargo/internal/git"
+)
+
+var (
+	// insecureHTTPHosts are the hosts whose repositories may be accessed over
+	// plain HTTP.
+	insecureHTTPHosts []string
+	// insecureHTTPHostsMu is for preventing concurrent access to the
+	// insecureHTTPHosts slice.
+	insecureHTTPHostsMu sync.RWMutex
+)
+
+// ConfigureInsecureHTTPHosts replaces all previously configured insecure HTTP
+// hosts with the provided ones (optionally including a port). Repositories
+// hosted on one of these hosts may be cloned over plain HTTP, but only when
+// this is explicitly requested using CloneOptions.InsecureHTTP.
+func ConfigureInsecureHTTPHosts(hosts []string) {
+	insecureHTTPHostsMu.Lock()
+	defer insecureHTTPHostsMu.Unlock()
+	insecureHTTPHosts = slices.Clone(hosts)
+}
+
+// validateInsecureHTTP returns an error if the provided repository URL uses
+// plain HTTP and either insecureHTTP is false or the repository's host is not
+// one of the configured insecure HTTP hosts.
+func validateInsecureHTTP(repoURL string, insecureHTTP bool) error {
+	if !libGit.IsInsecureHTTPURL(repoURL) {
+		return nil
+	}
+	if !insecureHTTP {
+		return fmt.Errorf(
+			"git repo %q can only be accessed over plain HTTP, which must be "+
+				"explicitly permitted by enabling insecureHTTP",
+			repoURL,
+		)
+	}
+	insecureHTTPHostsMu.RLock()
+	defer insecureHTTPHostsMu.RUnlock()
+	if !libGit.IsInsecureHTTPHost(repoURL, insecureHTTPHosts) {
+		return fmt.Errorf(
+			"the host of git repo %q is not permitted to be accessed over plain "+
+				"HTTP by this installation of Kargo",
+			repoURL,
+		)
+	}
+	return nil
+}
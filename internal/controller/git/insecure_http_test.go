@@ -0,0 +1,67 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateInsecureHTTP(t *testing.T) {
+	ConfigureInsecureHTTPHosts([]string{"git.example.com", " "})
+	t.Cleanup(func() {
+		ConfigureInsecureHTTPHosts(nil)
+	})
+
+	testCases := []struct {
+		name         string
+		repoURL      string
+		insecureHTTP bool
+		assertions   func(*testing.T, error)
+	}{
+		{
+			name:    "HTTPS",
+			repoURL: "https://github.com/akuity/kargo.git",
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:    "SSH",
+			repoURL: "ssh://git@github.com/akuity/kargo.git",
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:    "HTTP without opt-in",
+			repoURL: "http://git.example.com/repo.git",
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "must be explicitly permitted")
+			},
+		},
+		{
+			name:         "HTTP with opt-in, but host not permitted",
+			repoURL:      "http://git.example.org/repo.git",
+			insecureHTTP: true,
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "not permitted to be accessed over plain HTTP")
+			},
+		},
+		{
+			name:         "HTTP with opt-in and permitted host",
+			repoURL:      "HTTP://git.example.com/repo.git",
+			insecureHTTP: true,
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				t,
+				validateInsecureHTTP(testCase.repoURL, testCase.insecureHTTP),
+			)
+		})
+	}
+}
@@ -89,6 +89,13 @@ func TestGetCredentialsRequirement(t *testing.T) {
 			},
 			matches: true,
 		},
+		{
+			name: "credential type label set to package",
+			labels: labels.Set{
+				kargoapi.CredentialTypeLabelKey: credentials.TypePackage.String(),
+			},
+			matches: true,
+		},
 		{
 			name: "credential type label set to unknown type",
 			labels: labels.Set{
@@ -0,0 +1,275 @@
+package projectroles
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/api/rbac"
+	"github.com/akuity/kargo/internal/controller"
+	"github.com/akuity/kargo/internal/kubeclient"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// reconciler reconciles ProjectRole resources into the ServiceAccount, Role,
+// and RoleBinding that underlie a Kargo Role.
+type reconciler struct {
+	client client.Client
+
+	// The following behaviors are overridable for testing purposes:
+
+	syncProjectRoleFn func(context.Context, *kargoapi.ProjectRole) error
+
+	patchProjectRoleStatusFn func(
+		context.Context,
+		*kargoapi.ProjectRole,
+		kargoapi.ProjectRoleStatus,
+	) error
+}
+
+// SetupReconcilerWithManager initializes a reconciler for ProjectRole resources
+// and registers it with the provided Manager.
+func SetupReconcilerWithManager(kargoMgr manager.Manager) error {
+	return ctrl.NewControllerManagedBy(kargoMgr).
+		For(&kargoapi.ProjectRole{}).
+		// Changes made to the underlying resources by any other means are
+		// reverted.
+		Owns(&corev1.ServiceAccount{}).
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{}).
+		WithOptions(controller.CommonOptions()).
+		Complete(newReconciler(kargoMgr.GetClient()))
+}
+
+func newReconciler(kubeClient client.Client) *reconciler {
+	r := &reconciler{
+		client: kubeClient,
+	}
+	r.syncProjectRoleFn = r.syncProjectRole
+	r.patchProjectRoleStatusFn = r.patchProjectRoleStatus
+	return r
+}
+
+// Reconcile is part of the main Kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *reconciler) Reconcile(
+	ctx context.Context,
+	req ctrl.Request,
+) (ctrl.Result, error) {
+	logger := logging.LoggerFromContext(ctx).WithFields(log.Fields{
+		"namespace":   req.NamespacedName.Namespace,
+		"projectRole": req.NamespacedName.Name,
+	})
+	ctx = logging.ContextWithLogger(ctx, logger)
+	logger.Debug("reconciling ProjectRole")
+
+	projectRole := &kargoapi.ProjectRole{}
+	if err := r.client.Get(ctx, req.NamespacedName, projectRole); err != nil {
+		// Ignore if not found. The underlying resources are owned by the
+		// ProjectRole and will be garbage collected along with it.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if projectRole.DeletionTimestamp != nil {
+		logger.Debug("ProjectRole is being deleted; nothing to do")
+		return ctrl.Result{}, nil
+	}
+
+	newStatus := *projectRole.Status.DeepCopy()
+	newStatus.ObservedGeneration = projectRole.Generation
+	err := r.syncProjectRoleFn(ctx, projectRole)
+	if err != nil {
+		logger.Errorf("error syncing ProjectRole: %s", err)
+		meta.SetStatusCondition(&newStatus.Conditions, metav1.Condition{
+			Type:               kargoapi.ProjectRoleConditionTypeReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             kargoapi.ProjectRoleConditionReasonSyncFailed,
+			Message:            err.Error(),
+			ObservedGeneration: projectRole.Generation,
+		})
+	} else {
+		meta.SetStatusCondition(&newStatus.Conditions, metav1.Condition{
+			Type:               kargoapi.ProjectRoleConditionTypeReady,
+			Status:             metav1.ConditionTrue,
+			Reason:             kargoapi.ProjectRoleConditionReasonSynced,
+			Message:            "ServiceAccount, Role, and RoleBinding are in sync",
+			ObservedGeneration: projectRole.Generation,
+		})
+	}
+
+	patchErr := r.patchProjectRoleStatusFn(ctx, projectRole, newStatus)
+	if patchErr != nil {
+		logger.Errorf("error updating ProjectRole status: %s", patchErr)
+	}
+
+	// If we had no error, but couldn't patch, then we DO have an error. But we
+	// do it this way so that a failure to patch is never counted as THE failure
+	// when something else more serious occurred first.
+	if err == nil {
+		err = patchErr
+	}
+	logger.Debug("done reconciling ProjectRole")
+
+	// Controller runtime automatically gives us a progressive backoff if err is
+	// not nil
+	return ctrl.Result{}, err
+}
+
+// syncProjectRole creates or updates the ServiceAccount, Role, and RoleBinding
+// underlying the provided ProjectRole so that they reflect its spec. It will
+// return an error if any of those resources already exist and are not
+// controlled by the ProjectRole.
+func (r *reconciler) syncProjectRole(
+	ctx context.Context,
+	projectRole *kargoapi.ProjectRole,
+) error {
+	logger := logging.LoggerFromContext(ctx)
+
+	// Note: The rules are validated and normalized in the course of this
+	// conversion. Only resource types that Kargo Roles support are permitted,
+	// which prevents a ProjectRole from granting anything beyond what a Kargo
+	// Role could.
+	desiredSA, desiredRole, desiredRB, err := rbac.RoleToResources(&rbacapi.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: projectRole.Namespace,
+			Name:      projectRole.Name,
+		},
+		Subs:   projectRole.Spec.Subjects.Subs,
+		Emails: projectRole.Spec.Subjects.Emails,
+		Groups: projectRole.Spec.Subjects.Groups,
+		Rules:  projectRole.Spec.Rules,
+	})
+	if err != nil {
+		return err
+	}
+	if description, ok := projectRole.Annotations[kargoapi.AnnotationKeyDescription]; ok {
+		desiredSA.Annotations[kargoapi.AnnotationKeyDescription] = description
+	}
+
+	ownerRef := metav1.NewControllerRef(
+		projectRole,
+		kargoapi.GroupVersion.WithKind("ProjectRole"),
+	)
+	ownerRef.BlockOwnerDeletion = ptr.To(false)
+
+	sa := &corev1.ServiceAccount{ObjectMeta: objectMetaFor(desiredSA.ObjectMeta)}
+	if err = r.ensureControlled(ctx, projectRole, ownerRef, "ServiceAccount", sa, func() {
+		syncAnnotations(sa, desiredSA.Annotations)
+	}); err != nil {
+		return err
+	}
+
+	role := &rbacv1.Role{ObjectMeta: objectMetaFor(desiredRole.ObjectMeta)}
+	if err = r.ensureControlled(ctx, projectRole, ownerRef, "Role", role, func() {
+		syncAnnotations(role, desiredRole.Annotations)
+		role.Rules = desiredRole.Rules
+	}); err != nil {
+		return err
+	}
+
+	rb := &rbacv1.RoleBinding{ObjectMeta: objectMetaFor(desiredRB.ObjectMeta)}
+	if err = r.ensureControlled(ctx, projectRole, ownerRef, "RoleBinding", rb, func() {
+		syncAnnotations(rb, desiredRB.Annotations)
+		rb.Subjects = desiredRB.Subjects
+		rb.RoleRef = desiredRB.RoleRef
+	}); err != nil {
+		return err
+	}
+
+	logger.Debug("ServiceAccount, Role, and RoleBinding are in sync")
+	return nil
+}
+
+// ensureControlled creates the provided object or updates it if it already
+// exists, in either case applying the provided mutation and making the provided
+// ProjectRole its controller. It will return an error if the object already
+// exists and is not controlled by the ProjectRole.
+func (r *reconciler) ensureControlled(
+	ctx context.Context,
+	projectRole *kargoapi.ProjectRole,
+	ownerRef *metav1.OwnerReference,
+	kind string,
+	obj client.Object,
+	mutate func(),
+) error {
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.client, obj, func() error {
+		if obj.GetResourceVersion() != "" && !metav1.IsControlledBy(obj, projectRole) {
+			return fmt.Errorf(
+				"%s %q in namespace %q already exists and is not managed by this ProjectRole",
+				kind,
+				obj.GetName(),
+				obj.GetNamespace(),
+			)
+		}
+		mutate()
+		if obj.GetResourceVersion() == "" {
+			obj.SetOwnerReferences([]metav1.OwnerReference{*ownerRef})
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf(
+			"error syncing %s %q in namespace %q: %w",
+			kind,
+			obj.GetName(),
+			obj.GetNamespace(),
+			err,
+		)
+	}
+	return nil
+}
+
+// syncAnnotations sets the provided annotations on the provided object. The
+// Kargo-specific annotations that are absent from the provided annotations are
+// removed from the object. All other annotations are left untouched.
+func syncAnnotations(obj client.Object, desired map[string]string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, len(desired))
+	}
+	for _, key := range []string{
+		kargoapi.AnnotationKeyDescription,
+		rbacapi.AnnotationKeyOIDCSubjects,
+		rbacapi.AnnotationKeyOIDCEmails,
+		rbacapi.AnnotationKeyOIDCGroups,
+	} {
+		delete(annotations, key)
+	}
+	for key, value := range desired {
+		annotations[key] = value
+	}
+	obj.SetAnnotations(annotations)
+}
+
+func objectMetaFor(desired metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: desired.Namespace,
+		Name:      desired.Name,
+	}
+}
+
+func (r *reconciler) patchProjectRoleStatus(
+	ctx context.Context,
+	projectRole *kargoapi.ProjectRole,
+	status kargoapi.ProjectRoleStatus,
+) error {
+	return kubeclient.PatchStatus(
+		ctx,
+		r.client,
+		projectRole,
+		func(s *kargoapi.ProjectRoleStatus) {
+			*s = status
+		},
+	)
+}
@@ -0,0 +1,289 @@
+package projectroles
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestNewReconciler(t *testing.T) {
+	r := newReconciler(fake.NewClientBuilder().Build())
+	require.NotNil(t, r.client)
+	// Assert that all overridable behaviors were initialized to a default:
+	require.NotNil(t, r.syncProjectRoleFn)
+	require.NotNil(t, r.patchProjectRoleStatusFn)
+}
+
+func TestReconcile(t *testing.T) {
+	testProjectRole := &kargoapi.ProjectRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "fake-project",
+			Name:       "fake-role",
+			Generation: 2,
+		},
+	}
+	testCases := []struct {
+		name              string
+		objects           []client.Object
+		syncProjectRoleFn func(context.Context, *kargoapi.ProjectRole) error
+		assertions        func(*testing.T, client.Client, error)
+	}{
+		{
+			name: "ProjectRole not found",
+			assertions: func(t *testing.T, _ client.Client, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:    "error syncing ProjectRole",
+			objects: []client.Object{testProjectRole.DeepCopy()},
+			syncProjectRoleFn: func(context.Context, *kargoapi.ProjectRole) error {
+				return errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, c client.Client, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+				projectRole := &kargoapi.ProjectRole{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(testProjectRole), projectRole))
+				require.Equal(t, int64(2), projectRole.Status.ObservedGeneration)
+				cond := meta.FindStatusCondition(
+					projectRole.Status.Conditions,
+					kargoapi.ProjectRoleConditionTypeReady,
+				)
+				require.NotNil(t, cond)
+				require.Equal(t, metav1.ConditionFalse, cond.Status)
+				require.Equal(t, kargoapi.ProjectRoleConditionReasonSyncFailed, cond.Reason)
+				require.Equal(t, "something went wrong", cond.Message)
+			},
+		},
+		{
+			name:    "success",
+			objects: []client.Object{testProjectRole.DeepCopy()},
+			syncProjectRoleFn: func(context.Context, *kargoapi.ProjectRole) error {
+				return nil
+			},
+			assertions: func(t *testing.T, c client.Client, err error) {
+				require.NoError(t, err)
+				projectRole := &kargoapi.ProjectRole{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(testProjectRole), projectRole))
+				require.True(
+					t,
+					meta.IsStatusConditionTrue(
+						projectRole.Status.Conditions,
+						kargoapi.ProjectRoleConditionTypeReady,
+					),
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := newFakeClient(t, testCase.objects...)
+			r := newReconciler(c)
+			r.syncProjectRoleFn = testCase.syncProjectRoleFn
+			_, err := r.Reconcile(
+				context.Background(),
+				ctrl.Request{NamespacedName: client.ObjectKeyFromObject(testProjectRole)},
+			)
+			testCase.assertions(t, c, err)
+		})
+	}
+}
+
+func TestSyncProjectRole(t *testing.T) {
+	testProjectRole := &kargoapi.ProjectRole{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-project",
+			Name:      "fake-role",
+			UID:       "fake-uid",
+			Annotations: map[string]string{
+				kargoapi.AnnotationKeyDescription: "fake description",
+			},
+		},
+		Spec: kargoapi.ProjectRoleSpec{
+			Subjects: kargoapi.ProjectRoleSubjects{
+				Emails: []string{"bar@example.com", "foo@example.com"},
+				Groups: []string{"devs"},
+			},
+			Rules: []rbacv1.PolicyRule{{
+				Resources:     []string{"stages"},
+				ResourceNames: []string{"uat"},
+				Verbs:         []string{"get", "promote"},
+			}},
+		},
+	}
+	objKey := client.ObjectKeyFromObject(testProjectRole)
+	ownerRef := metav1.OwnerReference{
+		APIVersion:         kargoapi.GroupVersion.String(),
+		Kind:               "ProjectRole",
+		Name:               testProjectRole.Name,
+		UID:                testProjectRole.UID,
+		Controller:         ptr.To(true),
+		BlockOwnerDeletion: ptr.To(false),
+	}
+	testCases := []struct {
+		name        string
+		projectRole *kargoapi.ProjectRole
+		objects     []client.Object
+		assertions  func(*testing.T, client.Client, error)
+	}{
+		{
+			name: "invalid rules",
+			projectRole: &kargoapi.ProjectRole{
+				ObjectMeta: testProjectRole.ObjectMeta,
+				Spec: kargoapi.ProjectRoleSpec{
+					Rules: []rbacv1.PolicyRule{{
+						Resources: []string{"pods"},
+						Verbs:     []string{"*"},
+					}},
+				},
+			},
+			assertions: func(t *testing.T, c client.Client, err error) {
+				require.ErrorContains(t, err, `unrecognized resource type "pods"`)
+				err = c.Get(context.Background(), objKey, &corev1.ServiceAccount{})
+				require.Error(t, err)
+			},
+		},
+		{
+			name:        "ServiceAccount exists and is not controlled by ProjectRole",
+			projectRole: testProjectRole,
+			objects: []client.Object{
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: objKey.Namespace,
+						Name:      objKey.Name,
+					},
+				},
+			},
+			assertions: func(t *testing.T, _ client.Client, err error) {
+				require.ErrorContains(t, err, "already exists and is not managed by this ProjectRole")
+			},
+		},
+		{
+			name:        "resources do not exist",
+			projectRole: testProjectRole,
+			assertions: func(t *testing.T, c client.Client, err error) {
+				require.NoError(t, err)
+
+				sa := &corev1.ServiceAccount{}
+				require.NoError(t, c.Get(context.Background(), objKey, sa))
+				require.Equal(t, []metav1.OwnerReference{ownerRef}, sa.OwnerReferences)
+				require.Equal(t, rbacapi.AnnotationValueTrue, sa.Annotations[rbacapi.AnnotationKeyManaged])
+				require.Equal(
+					t,
+					"bar@example.com,foo@example.com",
+					sa.Annotations[rbacapi.AnnotationKeyOIDCEmails],
+				)
+				require.Equal(t, "devs", sa.Annotations[rbacapi.AnnotationKeyOIDCGroups])
+				require.Equal(t, "fake description", sa.Annotations[kargoapi.AnnotationKeyDescription])
+
+				role := &rbacv1.Role{}
+				require.NoError(t, c.Get(context.Background(), objKey, role))
+				require.Equal(t, []metav1.OwnerReference{ownerRef}, role.OwnerReferences)
+				require.Equal(
+					t,
+					[]rbacv1.PolicyRule{{
+						APIGroups:     []string{kargoapi.GroupVersion.Group},
+						Resources:     []string{"stages"},
+						ResourceNames: []string{"uat"},
+						Verbs:         []string{"get", "promote"},
+					}},
+					role.Rules,
+				)
+
+				rb := &rbacv1.RoleBinding{}
+				require.NoError(t, c.Get(context.Background(), objKey, rb))
+				require.Equal(t, []metav1.OwnerReference{ownerRef}, rb.OwnerReferences)
+				require.Equal(
+					t,
+					[]rbacv1.Subject{{
+						Kind:      rbacv1.ServiceAccountKind,
+						Namespace: objKey.Namespace,
+						Name:      objKey.Name,
+					}},
+					rb.Subjects,
+				)
+				require.Equal(t, objKey.Name, rb.RoleRef.Name)
+			},
+		},
+		{
+			name:        "resources exist and have drifted",
+			projectRole: testProjectRole,
+			objects: []client.Object{
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:       objKey.Namespace,
+						Name:            objKey.Name,
+						OwnerReferences: []metav1.OwnerReference{ownerRef},
+						Annotations: map[string]string{
+							rbacapi.AnnotationKeyManaged:      rbacapi.AnnotationValueTrue,
+							rbacapi.AnnotationKeyOIDCSubjects: "intruder",
+							"unrelated":                       "value",
+						},
+					},
+				},
+				&rbacv1.Role{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:       objKey.Namespace,
+						Name:            objKey.Name,
+						OwnerReferences: []metav1.OwnerReference{ownerRef},
+					},
+					Rules: []rbacv1.PolicyRule{{
+						APIGroups: []string{""},
+						Resources: []string{"secrets"},
+						Verbs:     []string{"*"},
+					}},
+				},
+			},
+			assertions: func(t *testing.T, c client.Client, err error) {
+				require.NoError(t, err)
+
+				sa := &corev1.ServiceAccount{}
+				require.NoError(t, c.Get(context.Background(), objKey, sa))
+				require.Empty(t, sa.Annotations[rbacapi.AnnotationKeyOIDCSubjects])
+				require.Equal(t, "devs", sa.Annotations[rbacapi.AnnotationKeyOIDCGroups])
+				require.Equal(t, "value", sa.Annotations["unrelated"])
+
+				role := &rbacv1.Role{}
+				require.NoError(t, c.Get(context.Background(), objKey, role))
+				require.Len(t, role.Rules, 1)
+				require.Equal(t, []string{"stages"}, role.Rules[0].Resources)
+
+				require.NoError(t, c.Get(context.Background(), objKey, &rbacv1.RoleBinding{}))
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := newFakeClient(t, testCase.objects...)
+			r := newReconciler(c)
+			err := r.syncProjectRole(context.Background(), testCase.projectRole)
+			testCase.assertions(t, c, err)
+		})
+	}
+}
+
+func newFakeClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, rbacv1.AddToScheme(scheme))
+	require.NoError(t, kargoapi.AddToScheme(scheme))
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&kargoapi.ProjectRole{}).
+		Build()
+}
@@ -13,15 +13,18 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	rbacapi "github.com/akuity/kargo/api/rbac/v1alpha1"
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/controller"
+	argocd "github.com/akuity/kargo/internal/controller/argocd/api/v1alpha1"
 	rolloutsapi "github.com/akuity/kargo/internal/controller/rollouts/api/v1alpha1"
 	"github.com/akuity/kargo/internal/kubeclient"
 	"github.com/akuity/kargo/internal/logging"
@@ -29,6 +32,14 @@ import (
 
 type ReconcilerConfig struct {
 	KargoNamespace string `envconfig:"KARGO_NAMESPACE" required:"true"`
+	// PromotionRunnerImage is the image used by promotion runners dedicated to
+	// Projects. If it is empty, no Project can have a dedicated promotion
+	// runner.
+	PromotionRunnerImage string `envconfig:"PROMOTION_RUNNER_IMAGE"`
+	// ArgoCDNamespace is the namespace in which Argo CD Applications updated by
+	// promotion runners reside. If it is empty, Argo CD integration is disabled
+	// for promotion runners.
+	ArgoCDNamespace string `envconfig:"ARGOCD_NAMESPACE"`
 }
 
 func ReconcilerConfigFromEnv() ReconcilerConfig {
@@ -89,6 +100,31 @@ type reconciler struct {
 
 	ensureDefaultProjectRolesFn func(context.Context, *kargoapi.Project) error
 
+	ensurePromotionRunnerFn func(context.Context, *kargoapi.Project) error
+
+	getAuthorizedArgoCDAppsFn func(context.Context, *kargoapi.Project) ([]string, error)
+
+	listArgoCDAppsFn func(
+		context.Context,
+		client.ObjectList,
+		...client.ListOption,
+	) error
+
+	applyObjectFn func(context.Context, client.Object) error
+
+	getObjectFn func(
+		context.Context,
+		types.NamespacedName,
+		client.Object,
+		...client.GetOption,
+	) error
+
+	deleteObjectFn func(
+		context.Context,
+		client.Object,
+		...client.DeleteOption,
+	) error
+
 	createServiceAccountFn func(
 		context.Context,
 		client.Object,
@@ -114,18 +150,32 @@ func SetupReconcilerWithManager(
 	kargoMgr manager.Manager,
 	cfg ReconcilerConfig,
 ) error {
-	return ctrl.NewControllerManagedBy(kargoMgr).
-		For(&kargoapi.Project{}).
-		WithEventFilter(
-			predicate.Funcs{
-				DeleteFunc: func(event.DeleteEvent) bool {
-					// We're not interested in any deletes
-					return false
+	r := newReconciler(kargoMgr.GetClient(), cfg)
+	b := ctrl.NewControllerManagedBy(kargoMgr).
+		For(
+			&kargoapi.Project{},
+			builder.WithPredicates(
+				predicate.Funcs{
+					DeleteFunc: func(event.DeleteEvent) bool {
+						// We're not interested in any deletes
+						return false
+					},
 				},
-			},
+			),
 		).
-		WithOptions(controller.CommonOptions()).
-		Complete(newReconciler(kargoMgr.GetClient(), cfg))
+		WithOptions(controller.CommonOptions())
+	if cfg.PromotionRunnerImage != "" && cfg.ArgoCDNamespace != "" {
+		// Promotion runners may only update the Argo CD Applications that permit
+		// mutation by their Projects, so their permissions must follow the
+		// creation and deletion of Applications and changes to their
+		// annotations.
+		b = b.Watches(
+			&argocd.Application{},
+			handler.EnqueueRequestsFromMapFunc(r.projectsWithDedicatedRunners),
+			builder.WithPredicates(predicate.AnnotationChangedPredicate{}),
+		)
+	}
+	return b.Complete(r)
 }
 
 func newReconciler(kubeClient client.Client, cfg ReconcilerConfig) *reconciler {
@@ -142,6 +192,12 @@ func newReconciler(kubeClient client.Client, cfg ReconcilerConfig) *reconciler {
 	r.updateNamespaceFn = r.client.Update
 	r.ensureAPIAdminPermissionsFn = r.ensureAPIAdminPermissions
 	r.ensureDefaultProjectRolesFn = r.ensureDefaultProjectRoles
+	r.ensurePromotionRunnerFn = r.ensurePromotionRunner
+	r.getAuthorizedArgoCDAppsFn = r.getAuthorizedArgoCDApps
+	r.listArgoCDAppsFn = r.client.List
+	r.applyObjectFn = r.applyObject
+	r.getObjectFn = r.client.Get
+	r.deleteObjectFn = r.client.Delete
 	r.createServiceAccountFn = r.client.Create
 	r.createRoleFn = r.client.Create
 	r.createRoleBindingFn = r.client.Create
@@ -176,12 +232,18 @@ func (r *reconciler) Reconcile(
 		return ctrl.Result{}, nil
 	}
 
-	if project.Status.Phase.IsTerminal() {
+	var newStatus kargoapi.ProjectStatus
+	if project.Status.Phase == kargoapi.ProjectPhaseReady {
+		// Unlike everything else that is taken care of when a Project is
+		// initialized, a Project's isolation may change at any time.
+		newStatus = *project.Status.DeepCopy()
+		err = r.ensurePromotionRunnerFn(ctx, project)
+	} else if project.Status.Phase.IsTerminal() {
 		logger.Debugf("Project is %s; nothing to do", project.Status.Phase)
 		return ctrl.Result{}, nil
+	} else {
+		newStatus, err = r.syncProjectFn(ctx, project)
 	}
-
-	newStatus, err := r.syncProjectFn(ctx, project)
 	if err != nil {
 		newStatus.Message = err.Error()
 		logger.Errorf("error syncing Project: %s", err)
@@ -226,6 +288,10 @@ func (r *reconciler) syncProject(
 		return status, fmt.Errorf("error ensuring default project roles: %w", err)
 	}
 
+	if err = r.ensurePromotionRunnerFn(ctx, project); err != nil {
+		return status, err
+	}
+
 	status.Phase = kargoapi.ProjectPhaseReady
 	return status, nil
 }
@@ -432,7 +498,7 @@ func (r *reconciler) ensureDefaultProjectRoles(
 				},
 				{ // Full access to all mutable Kargo resource types
 					APIGroups: []string{kargoapi.GroupVersion.Group},
-					Resources: []string{"freights", "stages", "warehouses"},
+					Resources: []string{"freights", "projectroles", "stages", "warehouses"},
 					Verbs:     []string{"*"},
 				},
 				{ // Promote permission on all stages
@@ -442,10 +508,10 @@ func (r *reconciler) ensureDefaultProjectRoles(
 				},
 				{ // Nearly full access to all Promotions, but they are immutable
 					APIGroups: []string{kargoapi.GroupVersion.Group},
-					Resources: []string{"promotions"},
+					Resources: []string{"promotionplans", "promotions"},
 					Verbs:     []string{"create", "delete", "get", "list", "watch"},
 				},
-				{ // Manual approvals involve patching Freight status
+				{ // Manual approvals and blocking involve patching Freight status
 					APIGroups: []string{kargoapi.GroupVersion.Group},
 					Resources: []string{"freights/status"},
 					Verbs:     []string{"patch"},
@@ -484,8 +550,15 @@ func (r *reconciler) ensureDefaultProjectRoles(
 				},
 				{
 					APIGroups: []string{kargoapi.GroupVersion.Group},
-					Resources: []string{"freights", "promotions", "stages", "warehouses"},
-					Verbs:     []string{"get", "list", "watch"},
+					Resources: []string{
+						"freights",
+						"projectroles",
+						"promotionplans",
+						"promotions",
+						"stages",
+						"warehouses",
+					},
+					Verbs: []string{"get", "list", "watch"},
 				},
 				{
 					APIGroups: []string{rolloutsapi.GroupVersion.Group},
@@ -32,6 +32,12 @@ func TestNewReconciler(t *testing.T) {
 	require.NotNil(t, r.updateNamespaceFn)
 	require.NotNil(t, r.ensureAPIAdminPermissionsFn)
 	require.NotNil(t, r.ensureDefaultProjectRolesFn)
+	require.NotNil(t, r.ensurePromotionRunnerFn)
+	require.NotNil(t, r.getAuthorizedArgoCDAppsFn)
+	require.NotNil(t, r.listArgoCDAppsFn)
+	require.NotNil(t, r.applyObjectFn)
+	require.NotNil(t, r.getObjectFn)
+	require.NotNil(t, r.deleteObjectFn)
 	require.NotNil(t, r.createServiceAccountFn)
 	require.NotNil(t, r.createRoleFn)
 	require.NotNil(t, r.createRoleBindingFn)
@@ -106,6 +112,47 @@ func TestReconcile(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "error ensuring promotion runner of ready project",
+			reconciler: &reconciler{
+				getProjectFn: func(
+					context.Context,
+					client.Client,
+					string,
+				) (*kargoapi.Project, error) {
+					return &kargoapi.Project{
+						Status: kargoapi.ProjectStatus{
+							Phase: kargoapi.ProjectPhaseReady,
+						},
+					}, nil
+				},
+				syncProjectFn: func(
+					context.Context,
+					*kargoapi.Project,
+				) (kargoapi.ProjectStatus, error) {
+					require.Fail(t, "ready project should not be synced")
+					return kargoapi.ProjectStatus{}, nil
+				},
+				ensurePromotionRunnerFn: func(
+					context.Context,
+					*kargoapi.Project,
+				) error {
+					return errors.New("something went wrong")
+				},
+				patchProjectStatusFn: func(
+					_ context.Context,
+					_ *kargoapi.Project,
+					status kargoapi.ProjectStatus,
+				) error {
+					require.Equal(t, kargoapi.ProjectPhaseReady, status.Phase)
+					require.Equal(t, "something went wrong", status.Message)
+					return nil
+				},
+			},
+			assertions: func(t *testing.T, _ ctrl.Result, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
 		{
 			name: "error syncing project",
 			reconciler: &reconciler{
@@ -267,6 +314,40 @@ func TestSyncProject(t *testing.T) {
 				require.Equal(t, kargoapi.ProjectPhaseInitializing, status.Phase)
 			},
 		},
+		{
+			name: "error ensuring promotion runner",
+			reconciler: &reconciler{
+				ensureNamespaceFn: func(
+					_ context.Context,
+					project *kargoapi.Project,
+				) (kargoapi.ProjectStatus, error) {
+					return *project.Status.DeepCopy(), nil
+				},
+				ensureAPIAdminPermissionsFn: func(
+					context.Context,
+					*kargoapi.Project,
+				) error {
+					return nil
+				},
+				ensureDefaultProjectRolesFn: func(
+					context.Context,
+					*kargoapi.Project,
+				) error {
+					return nil
+				},
+				ensurePromotionRunnerFn: func(
+					context.Context,
+					*kargoapi.Project,
+				) error {
+					return errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, status kargoapi.ProjectStatus, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+				// Still initializing because retry could succeed
+				require.Equal(t, kargoapi.ProjectPhaseInitializing, status.Phase)
+			},
+		},
 		{
 			name: "success",
 			reconciler: &reconciler{
@@ -288,6 +369,12 @@ func TestSyncProject(t *testing.T) {
 				) error {
 					return nil
 				},
+				ensurePromotionRunnerFn: func(
+					context.Context,
+					*kargoapi.Project,
+				) error {
+					return nil
+				},
 			},
 			assertions: func(t *testing.T, status kargoapi.ProjectStatus, err error) {
 				require.NoError(t, err)
@@ -0,0 +1,415 @@
+package projects
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gobwas/glob"
+	log "github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kubeerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	argocd "github.com/akuity/kargo/internal/controller/argocd/api/v1alpha1"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+const (
+	// promotionRunnerName is the name of the Deployment, ServiceAccount,
+	// RoleBinding, and NetworkPolicy that make up a promotion runner in the
+	// namespace of the Project to which it is dedicated.
+	promotionRunnerName = "kargo-promotion-runner"
+	// promotionRunnerAppLabelValue is the value of the app.kubernetes.io/name
+	// label of promotion runner Pods.
+	promotionRunnerAppLabelValue = "kargo-promotion-runner"
+	// promotionRunnerClusterRoleName is the name of the ClusterRole, installed
+	// by the Kargo Helm chart, that is bound to promotion runners within the
+	// namespaces of the Projects they are dedicated to.
+	promotionRunnerClusterRoleName = "kargo-promotion-runner"
+	// promotionRunnerProjectReaderClusterRoleName is the name of the
+	// ClusterRole, installed by the Kargo Helm chart, that permits promotion
+	// runners to read Projects.
+	promotionRunnerProjectReaderClusterRoleName = "kargo-promotion-runner-project-reader"
+
+	authorizedStageAnnotationKey = "kargo.akuity.io/authorized-stage"
+)
+
+// ensurePromotionRunner ensures that the provided Project has a promotion
+// runner dedicated to it if, and only if, its isolation calls for one.
+func (r *reconciler) ensurePromotionRunner(
+	ctx context.Context,
+	project *kargoapi.Project,
+) error {
+	logger := logging.LoggerFromContext(ctx).WithFields(log.Fields{
+		"project":   project.Name,
+		"namespace": project.Name,
+	})
+
+	if !project.HasDedicatedPromotionRunner() {
+		return r.removePromotionRunner(ctx, project)
+	}
+
+	if r.cfg.PromotionRunnerImage == "" {
+		return errors.New(
+			"Project requires a dedicated promotion runner, but dedicated " +
+				"promotion runners are not supported by this installation of Kargo",
+		)
+	}
+
+	var argoCDApps []string
+	if r.cfg.ArgoCDNamespace != "" {
+		var err error
+		if argoCDApps, err = r.getAuthorizedArgoCDAppsFn(ctx, project); err != nil {
+			return fmt.Errorf(
+				"error finding Argo CD Applications Project %q is authorized to update: %w",
+				project.Name,
+				err,
+			)
+		}
+	}
+
+	for _, obj := range r.promotionRunnerObjects(project, argoCDApps) {
+		if err := r.applyObjectFn(ctx, obj); err != nil {
+			return fmt.Errorf(
+				"error ensuring %T %q in namespace %q of promotion runner: %w",
+				obj,
+				obj.GetName(),
+				obj.GetNamespace(),
+				err,
+			)
+		}
+	}
+	logger.Debug("ensured dedicated promotion runner")
+
+	return nil
+}
+
+// removePromotionRunner removes the promotion runner dedicated to the provided
+// Project, if it has one.
+func (r *reconciler) removePromotionRunner(
+	ctx context.Context,
+	project *kargoapi.Project,
+) error {
+	// The runner's Deployment is the first thing to be created and the last
+	// thing to be removed, so if it does not exist, there is nothing to remove.
+	if err := r.getObjectFn(
+		ctx,
+		types.NamespacedName{Namespace: project.Name, Name: promotionRunnerName},
+		&appsv1.Deployment{},
+	); err != nil {
+		if kubeerr.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf(
+			"error getting Deployment %q in namespace %q: %w",
+			promotionRunnerName,
+			project.Name,
+			err,
+		)
+	}
+
+	objs := r.promotionRunnerObjects(project, nil)
+	// Remove the runner's Deployment last, so that removal is retried if
+	// removing anything else fails.
+	for i := len(objs) - 1; i >= 0; i-- {
+		if err := r.deleteObjectFn(ctx, objs[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf(
+				"error removing %T %q in namespace %q of promotion runner: %w",
+				objs[i],
+				objs[i].GetName(),
+				objs[i].GetNamespace(),
+				err,
+			)
+		}
+	}
+	logging.LoggerFromContext(ctx).WithField("project", project.Name).
+		Debug("removed dedicated promotion runner")
+
+	return nil
+}
+
+// promotionRunnerObjects returns all the resources that make up the promotion
+// runner dedicated to the provided Project. The runner is only permitted to
+// update the named Argo CD Applications. The runner's Deployment is always
+// first.
+//
+// The runner's permissions are defined by ClusterRoles installed by the Kargo
+// Helm chart, so that the management controller only ever needs to bind them
+// and never needs to create ClusterRoles of its own.
+func (r *reconciler) promotionRunnerObjects(
+	project *kargoapi.Project,
+	argoCDApps []string,
+) []client.Object {
+	// Cluster-scoped resources, and resources in other namespaces, are not
+	// garbage collected along with the Project's namespace, so their names must
+	// be unique to the Project.
+	projectScopedName := fmt.Sprintf("%s-%s", promotionRunnerName, project.Name)
+	labels := map[string]string{
+		"app.kubernetes.io/name":    promotionRunnerAppLabelValue,
+		kargoapi.ProjectLabelKey:    project.Name,
+		"app.kubernetes.io/part-of": "kargo",
+	}
+	subjects := []rbacv1.Subject{{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      promotionRunnerName,
+		Namespace: project.Name,
+	}}
+
+	objs := []client.Object{
+		r.promotionRunnerDeployment(project, labels),
+		&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      promotionRunnerName,
+				Namespace: project.Name,
+				Labels:    labels,
+			},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      promotionRunnerName,
+				Namespace: project.Name,
+				Labels:    labels,
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     promotionRunnerClusterRoleName,
+			},
+			Subjects: subjects,
+		},
+		&networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      promotionRunnerName,
+				Namespace: project.Name,
+				Labels:    labels,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"app.kubernetes.io/name": promotionRunnerAppLabelValue,
+					},
+				},
+				// With no ingress rules, all inbound traffic is denied.
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   projectScopedName,
+				Labels: labels,
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     promotionRunnerProjectReaderClusterRoleName,
+			},
+			Subjects: subjects,
+		},
+	}
+
+	if r.cfg.ArgoCDNamespace != "" {
+		rules := []rbacv1.PolicyRule{{
+			APIGroups: []string{"argoproj.io"},
+			Resources: []string{"applications"},
+			Verbs:     []string{"get", "list", "watch"},
+		}}
+		// A rule without resource names would apply to all Applications.
+		if len(argoCDApps) > 0 {
+			rules = append(rules, rbacv1.PolicyRule{
+				APIGroups:     []string{"argoproj.io"},
+				Resources:     []string{"applications"},
+				ResourceNames: argoCDApps,
+				Verbs:         []string{"patch"},
+			})
+		}
+		objs = append(
+			objs,
+			&rbacv1.Role{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      projectScopedName,
+					Namespace: r.cfg.ArgoCDNamespace,
+					Labels:    labels,
+				},
+				Rules: rules,
+			},
+			&rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      projectScopedName,
+					Namespace: r.cfg.ArgoCDNamespace,
+					Labels:    labels,
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: rbacv1.GroupName,
+					Kind:     "Role",
+					Name:     projectScopedName,
+				},
+				Subjects: subjects,
+			},
+		)
+	}
+
+	return objs
+}
+
+// getAuthorizedArgoCDApps returns the sorted names of the Argo CD Applications
+// in the Argo CD namespace that permit mutation by at least one of the provided
+// Project's Stages.
+func (r *reconciler) getAuthorizedArgoCDApps(
+	ctx context.Context,
+	project *kargoapi.Project,
+) ([]string, error) {
+	apps := argocd.ApplicationList{}
+	if err := r.listArgoCDAppsFn(
+		ctx,
+		&apps,
+		client.InNamespace(r.cfg.ArgoCDNamespace),
+	); err != nil {
+		return nil, fmt.Errorf(
+			"error listing Argo CD Applications in namespace %q: %w",
+			r.cfg.ArgoCDNamespace,
+			err,
+		)
+	}
+	var names []string
+	for _, app := range apps.Items {
+		if isArgoCDAppAuthorizedForProject(app.ObjectMeta, project.Name) {
+			names = append(names, app.Name)
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// isArgoCDAppAuthorizedForProject returns true if the provided Argo CD
+// Application's authorized Stage annotation permits mutation by Stages in the
+// namespace of the named Project. Which of those Stages may mutate the
+// Application is still decided at the time of promotion.
+func isArgoCDAppAuthorizedForProject(appMeta metav1.ObjectMeta, project string) bool {
+	allowedStage, ok := appMeta.Annotations[authorizedStageAnnotationKey]
+	if !ok {
+		return false
+	}
+	tokens := strings.SplitN(allowedStage, ":", 2)
+	if len(tokens) != 2 {
+		return false
+	}
+	allowedNamespaceGlob, err := glob.Compile(tokens[0])
+	if err != nil {
+		return false
+	}
+	return allowedNamespaceGlob.Match(project)
+}
+
+// projectsWithDedicatedRunners returns reconcile requests for all Projects
+// with a dedicated promotion runner.
+func (r *reconciler) projectsWithDedicatedRunners(
+	ctx context.Context,
+	_ client.Object,
+) []reconcile.Request {
+	projects := kargoapi.ProjectList{}
+	if err := r.client.List(ctx, &projects); err != nil {
+		logging.LoggerFromContext(ctx).Errorf("error listing Projects: %s", err)
+		return nil
+	}
+	var reqs []reconcile.Request
+	for _, project := range projects.Items {
+		if project.HasDedicatedPromotionRunner() {
+			reqs = append(reqs, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: project.Name},
+			})
+		}
+	}
+	return reqs
+}
+
+// promotionRunnerDeployment returns the Deployment of the promotion runner
+// dedicated to the provided Project.
+func (r *reconciler) promotionRunnerDeployment(
+	project *kargoapi.Project,
+	labels map[string]string,
+) *appsv1.Deployment {
+	env := []corev1.EnvVar{
+		{Name: "PROMOTION_RUNNER_PROJECT", Value: project.Name},
+		{Name: "ARGOCD_INTEGRATION_ENABLED", Value: "false"},
+		{Name: "ROLLOUTS_INTEGRATION_ENABLED", Value: "false"},
+	}
+	if r.cfg.ArgoCDNamespace != "" {
+		env[1].Value = "true"
+		env = append(env, corev1.EnvVar{
+			Name:  "ARGOCD_NAMESPACE",
+			Value: r.cfg.ArgoCDNamespace,
+		})
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      promotionRunnerName,
+			Namespace: project.Name,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Strategy: appsv1.DeploymentStrategy{
+				// Two runners must never execute the same Promotions at once
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name": promotionRunnerAppLabelValue,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: promotionRunnerName,
+					Containers: []corev1.Container{{
+						Name:    "promotion-runner",
+						Image:   r.cfg.PromotionRunnerImage,
+						Command: []string{"/usr/local/bin/kargo", "controller"},
+						Env:     env,
+						SecurityContext: &corev1.SecurityContext{
+							AllowPrivilegeEscalation: ptr.To(false),
+							Capabilities: &corev1.Capabilities{
+								Drop: []corev1.Capability{"ALL"},
+							},
+							RunAsNonRoot: ptr.To(true),
+							SeccompProfile: &corev1.SeccompProfile{
+								Type: corev1.SeccompProfileTypeRuntimeDefault,
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// applyObject creates the provided object or, if it already exists, updates
+// it to match the provided object.
+func (r *reconciler) applyObject(ctx context.Context, obj client.Object) error {
+	err := r.client.Create(ctx, obj)
+	if !kubeerr.IsAlreadyExists(err) {
+		return err
+	}
+	existing, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected type %T", obj)
+	}
+	if err = r.client.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		return err
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return r.client.Update(ctx, obj)
+}
@@ -0,0 +1,358 @@
+package projects
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kubeerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	argocd "github.com/akuity/kargo/internal/controller/argocd/api/v1alpha1"
+)
+
+func TestEnsurePromotionRunner(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, appsv1.AddToScheme(scheme))
+	require.NoError(t, networkingv1.AddToScheme(scheme))
+	require.NoError(t, rbacv1.AddToScheme(scheme))
+	require.NoError(t, argocd.AddToScheme(scheme))
+
+	isolatedProject := &kargoapi.Project{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake-project"},
+		Spec: &kargoapi.ProjectSpec{
+			Isolation: &kargoapi.ProjectIsolation{
+				DedicatedPromotionRunner: true,
+			},
+		},
+	}
+	sharedProject := &kargoapi.Project{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake-project"},
+	}
+
+	testCases := []struct {
+		name       string
+		cfg        ReconcilerConfig
+		project    *kargoapi.Project
+		objects    []client.Object
+		assertions func(*testing.T, client.Client, error)
+	}{
+		{
+			name:    "Project without dedicated runner",
+			project: sharedProject,
+			assertions: func(t *testing.T, c client.Client, err error) {
+				require.NoError(t, err)
+				err = c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-project", Name: promotionRunnerName},
+					&appsv1.Deployment{},
+				)
+				require.True(t, kubeerr.IsNotFound(err))
+			},
+		},
+		{
+			name: "Project no longer has dedicated runner",
+			cfg: ReconcilerConfig{
+				PromotionRunnerImage: "kargo:fake",
+				ArgoCDNamespace:      "argocd",
+			},
+			project: sharedProject,
+			objects: (&reconciler{
+				cfg: ReconcilerConfig{
+					PromotionRunnerImage: "kargo:fake",
+					ArgoCDNamespace:      "argocd",
+				},
+			}).promotionRunnerObjects(isolatedProject, []string{"fake-app"}),
+			assertions: func(t *testing.T, c client.Client, err error) {
+				require.NoError(t, err)
+				err = c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-project", Name: promotionRunnerName},
+					&appsv1.Deployment{},
+				)
+				require.True(t, kubeerr.IsNotFound(err))
+				err = c.Get(
+					context.Background(),
+					types.NamespacedName{Name: "kargo-promotion-runner-fake-project"},
+					&rbacv1.ClusterRoleBinding{},
+				)
+				require.True(t, kubeerr.IsNotFound(err))
+				err = c.Get(
+					context.Background(),
+					types.NamespacedName{
+						Namespace: "argocd",
+						Name:      "kargo-promotion-runner-fake-project",
+					},
+					&rbacv1.RoleBinding{},
+				)
+				require.True(t, kubeerr.IsNotFound(err))
+			},
+		},
+		{
+			name:    "dedicated runners not supported",
+			project: isolatedProject,
+			assertions: func(t *testing.T, _ client.Client, err error) {
+				require.ErrorContains(t, err, "not supported by this installation")
+			},
+		},
+		{
+			name: "Project with dedicated runner",
+			cfg: ReconcilerConfig{
+				PromotionRunnerImage: "kargo:fake",
+			},
+			project: isolatedProject,
+			assertions: func(t *testing.T, c client.Client, err error) {
+				require.NoError(t, err)
+
+				deployment := &appsv1.Deployment{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-project", Name: promotionRunnerName},
+					deployment,
+				))
+				require.Equal(
+					t,
+					promotionRunnerName,
+					deployment.Spec.Template.Spec.ServiceAccountName,
+				)
+				container := deployment.Spec.Template.Spec.Containers[0]
+				require.Equal(t, "kargo:fake", container.Image)
+				require.Contains(
+					t,
+					container.Env,
+					corev1.EnvVar{Name: "PROMOTION_RUNNER_PROJECT", Value: "fake-project"},
+				)
+				require.Contains(
+					t,
+					container.Env,
+					corev1.EnvVar{Name: "ARGOCD_INTEGRATION_ENABLED", Value: "false"},
+				)
+
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-project", Name: promotionRunnerName},
+					&corev1.ServiceAccount{},
+				))
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-project", Name: promotionRunnerName},
+					&networkingv1.NetworkPolicy{},
+				))
+				roleBinding := &rbacv1.RoleBinding{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-project", Name: promotionRunnerName},
+					roleBinding,
+				))
+				require.Equal(
+					t,
+					rbacv1.RoleRef{
+						APIGroup: rbacv1.GroupName,
+						Kind:     "ClusterRole",
+						Name:     promotionRunnerClusterRoleName,
+					},
+					roleBinding.RoleRef,
+				)
+				clusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Name: "kargo-promotion-runner-fake-project"},
+					clusterRoleBinding,
+				))
+				require.Equal(
+					t,
+					promotionRunnerProjectReaderClusterRoleName,
+					clusterRoleBinding.RoleRef.Name,
+				)
+				// No ClusterRoles are created by the management controller
+				clusterRoles := &rbacv1.ClusterRoleList{}
+				require.NoError(t, c.List(context.Background(), clusterRoles))
+				require.Empty(t, clusterRoles.Items)
+			},
+		},
+		{
+			name: "Project with dedicated runner already exists",
+			cfg: ReconcilerConfig{
+				PromotionRunnerImage: "kargo:new",
+				ArgoCDNamespace:      "argocd",
+			},
+			project: isolatedProject,
+			objects: append(
+				(&reconciler{
+					cfg: ReconcilerConfig{PromotionRunnerImage: "kargo:old"},
+				}).promotionRunnerObjects(isolatedProject, nil),
+				&argocd.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "argocd",
+						Name:      "authorized-app",
+						Annotations: map[string]string{
+							authorizedStageAnnotationKey: "fake-project:fake-stage",
+						},
+					},
+				},
+				&argocd.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "argocd",
+						Name:      "glob-authorized-app",
+						Annotations: map[string]string{
+							authorizedStageAnnotationKey: "fake-*:*",
+						},
+					},
+				},
+				&argocd.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "argocd",
+						Name:      "other-project-app",
+						Annotations: map[string]string{
+							authorizedStageAnnotationKey: "other-project:fake-stage",
+						},
+					},
+				},
+				&argocd.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "argocd",
+						Name:      "unannotated-app",
+					},
+				},
+			),
+			assertions: func(t *testing.T, c client.Client, err error) {
+				require.NoError(t, err)
+
+				deployment := &appsv1.Deployment{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-project", Name: promotionRunnerName},
+					deployment,
+				))
+				container := deployment.Spec.Template.Spec.Containers[0]
+				require.Equal(t, "kargo:new", container.Image)
+				require.Contains(
+					t,
+					container.Env,
+					corev1.EnvVar{Name: "ARGOCD_NAMESPACE", Value: "argocd"},
+				)
+
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{
+						Namespace: "argocd",
+						Name:      "kargo-promotion-runner-fake-project",
+					},
+					&rbacv1.RoleBinding{},
+				))
+				role := &rbacv1.Role{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{
+						Namespace: "argocd",
+						Name:      "kargo-promotion-runner-fake-project",
+					},
+					role,
+				))
+				require.Len(t, role.Rules, 2)
+				require.Empty(t, role.Rules[0].ResourceNames)
+				require.NotContains(t, role.Rules[0].Verbs, "patch")
+				require.Equal(
+					t,
+					[]string{"authorized-app", "glob-authorized-app"},
+					role.Rules[1].ResourceNames,
+				)
+				require.Equal(t, []string{"patch"}, role.Rules[1].Verbs)
+			},
+		},
+		{
+			name: "no authorized Argo CD Applications",
+			cfg: ReconcilerConfig{
+				PromotionRunnerImage: "kargo:fake",
+				ArgoCDNamespace:      "argocd",
+			},
+			project: isolatedProject,
+			assertions: func(t *testing.T, c client.Client, err error) {
+				require.NoError(t, err)
+				role := &rbacv1.Role{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{
+						Namespace: "argocd",
+						Name:      "kargo-promotion-runner-fake-project",
+					},
+					role,
+				))
+				// A patch rule without resource names would permit patching all
+				// Applications.
+				require.Len(t, role.Rules, 1)
+				require.NotContains(t, role.Rules[0].Verbs, "patch")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(testCase.objects...).
+				Build()
+			r := newReconciler(c, testCase.cfg)
+			err := r.ensurePromotionRunner(context.Background(), testCase.project)
+			testCase.assertions(t, c, err)
+		})
+	}
+}
+
+func TestIsArgoCDAppAuthorizedForProject(t *testing.T) {
+	testCases := []struct {
+		name       string
+		annotation string
+		authorized bool
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name:       "malformed annotation",
+			annotation: "fake-project",
+		},
+		{
+			name:       "invalid glob",
+			annotation: "[:fake-stage",
+		},
+		{
+			name:       "other Project",
+			annotation: "other-project:fake-stage",
+		},
+		{
+			name:       "exact match",
+			annotation: "fake-project:fake-stage",
+			authorized: true,
+		},
+		{
+			name:       "glob match",
+			annotation: "fake-*:*",
+			authorized: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			appMeta := metav1.ObjectMeta{}
+			if testCase.annotation != "" {
+				appMeta.Annotations = map[string]string{
+					authorizedStageAnnotationKey: testCase.annotation,
+				}
+			}
+			require.Equal(
+				t,
+				testCase.authorized,
+				isArgoCDAppAuthorizedForProject(appMeta, "fake-project"),
+			)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+const (
+	// LeadTimeMilestonePromoted is the value of the milestone label for the lead
+	// time of Freight's first successful promotion to a Stage.
+	LeadTimeMilestonePromoted = "promoted"
+	// LeadTimeMilestoneVerified is the value of the milestone label for the lead
+	// time of Freight's first verification in a Stage.
+	LeadTimeMilestoneVerified = "verified"
+)
+
+var freightLeadTimeSeconds = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name: "kargo_freight_lead_time_seconds",
+		Help: "Time elapsed between the creation of Freight and it reaching a " +
+			"milestone of its lifecycle in a Stage for the first time",
+		// 1m to ~11d
+		Buckets: prometheus.ExponentialBuckets(60, 3, 10),
+	},
+	[]string{"project", "stage", "milestone"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(freightLeadTimeSeconds)
+}
+
+// ObserveFreightLeadTime records the time elapsed between the creation of the
+// provided Freight and the provided time, at which it reached the specified
+// milestone in the specified Stage for the first time.
+func ObserveFreightLeadTime(
+	freight *kargoapi.Freight,
+	stage string,
+	milestone string,
+	reachedAt time.Time,
+) {
+	freightLeadTimeSeconds.WithLabelValues(
+		freight.Namespace,
+		stage,
+		milestone,
+	).Observe(reachedAt.Sub(freight.CreationTimestamp.Time).Seconds())
+}
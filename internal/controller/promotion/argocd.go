@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"path"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -160,8 +161,32 @@ func (a *argoCDMechanism) Promote(
 		)
 	}
 
+	newStatus := promo.Status.WithPhase(aggregatedPhase)
+	if aggregatedPhase != kargoapi.PromotionPhaseRunning {
+		// Record the outcome of the sync operations so that it can be inspected
+		// once the Promotion has concluded.
+		for _, update := range updates {
+			namespace := update.AppNamespace
+			if namespace == "" {
+				namespace = libargocd.Namespace()
+			}
+			app, err := a.getArgoCDAppFn(ctx, namespace, update.AppName)
+			if err != nil || app == nil {
+				logger.Warnf(
+					"unable to record outcome of sync operation of Argo CD Application %q "+
+						"in namespace %q: %v",
+					update.AppName,
+					namespace,
+					err,
+				)
+				continue
+			}
+			newStatus.Metadata = setSyncResultMetadata(newStatus.Metadata, app)
+		}
+	}
+
 	logger.Debug("done executing Argo CD-based promotion mechanisms")
-	return promo.Status.WithPhase(aggregatedPhase), newFreight, nil
+	return newStatus, newFreight, nil
 }
 
 func (a *argoCDMechanism) mustPerformUpdate(
@@ -327,6 +352,9 @@ func (a *argoCDMechanism) doSingleUpdate(
 			app.Operation.Sync.SyncOptions = app.Spec.SyncPolicy.SyncOptions
 		}
 	}
+	if update.SyncOptions != nil {
+		applyArgoCDSyncOptions(app.Operation.Sync, *update.SyncOptions)
+	}
 	if app.Spec.Source != nil {
 		app.Operation.Sync.Revisions = []string{app.Spec.Source.TargetRevision}
 	}
@@ -361,6 +389,101 @@ func (a *argoCDMechanism) doSingleUpdate(
 	return nil
 }
 
+// applyArgoCDSyncOptions applies the provided ArgoCDSyncOptions to the provided
+// Argo CD SyncOperation. Options that are expressed as Argo CD sync options
+// take precedence over any sync options of the same name already present in
+// the SyncOperation, e.g. because they were inherited from the Application's
+// sync policy.
+func applyArgoCDSyncOptions(
+	sync *argocd.SyncOperation,
+	opts kargoapi.ArgoCDSyncOptions,
+) {
+	sync.Prune = opts.Prune
+	if opts.Force {
+		// The hook strategy is Argo CD's default. Using it here ensures that
+		// forcing a sync does not also disable the Application's hooks.
+		sync.SyncStrategy = &argocd.SyncStrategy{
+			Hook: &argocd.SyncStrategyHook{
+				SyncStrategyApply: argocd.SyncStrategyApply{Force: true},
+			},
+		}
+	}
+	if len(opts.Resources) > 0 {
+		sync.Resources = make([]argocd.SyncOperationResource, len(opts.Resources))
+		for i, resource := range opts.Resources {
+			sync.Resources[i] = argocd.SyncOperationResource{
+				Group:     resource.Group,
+				Kind:      resource.Kind,
+				Name:      resource.Name,
+				Namespace: resource.Namespace,
+			}
+		}
+	}
+	overrides := map[string]bool{
+		"ApplyOutOfSyncOnly": opts.ApplyOutOfSyncOnly,
+		"Replace":            opts.Replace,
+	}
+	// Copy the existing sync options, as they may be shared with the
+	// Application's sync policy.
+	syncOpts := make(argocd.SyncOptions, 0, len(sync.SyncOptions)+len(overrides))
+	for _, opt := range sync.SyncOptions {
+		name, _, _ := strings.Cut(opt, "=")
+		if enabled, ok := overrides[name]; ok && enabled {
+			continue
+		}
+		syncOpts = append(syncOpts, opt)
+	}
+	for _, name := range []string{"ApplyOutOfSyncOnly", "Replace"} {
+		if overrides[name] {
+			syncOpts = append(syncOpts, name+"=true")
+		}
+	}
+	sync.SyncOptions = syncOpts
+}
+
+const (
+	// argoCDOperationIDMetadataKeyPrefix is the prefix of the keys used to store
+	// the IDs of the Argo CD history entries resulting from sync operations in
+	// the metadata map.
+	argoCDOperationIDMetadataKeyPrefix = "argocd-operation-id:"
+	// argoCDOperationPhaseMetadataKeyPrefix is the prefix of the keys used to
+	// store the phases of completed sync operations in the metadata map.
+	argoCDOperationPhaseMetadataKeyPrefix = "argocd-operation-phase:"
+	// argoCDSyncStatusMetadataKeyPrefix is the prefix of the keys used to store
+	// the sync statuses of Argo CD Applications following completed sync
+	// operations in the metadata map.
+	argoCDSyncStatusMetadataKeyPrefix = "argocd-sync-status:"
+)
+
+// setSyncResultMetadata records the outcome of the most recent sync operation
+// of the provided Argo CD Application in the metadata map, provided the
+// operation was initiated by Kargo and has completed. The ID of the history
+// entry Argo CD recorded for the operation, if any, serves as the operation's
+// ID. The metadata map is returned.
+func setSyncResultMetadata(
+	metadata map[string]string,
+	app *argocd.Application,
+) map[string]string {
+	state := app.Status.OperationState
+	if state == nil || !state.Phase.Completed() ||
+		state.Operation.InitiatedBy.Username != applicationOperationInitiator {
+		return metadata
+	}
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	appKey := app.Namespace + "/" + app.Name
+	metadata[argoCDOperationPhaseMetadataKeyPrefix+appKey] = string(state.Phase)
+	metadata[argoCDSyncStatusMetadataKeyPrefix+appKey] = string(app.Status.Sync.Status)
+	for _, entry := range app.Status.History {
+		if entry.DeployStartedAt != nil && entry.DeployStartedAt.Equal(&state.StartedAt) {
+			metadata[argoCDOperationIDMetadataKeyPrefix+appKey] = strconv.FormatInt(entry.ID, 10)
+			break
+		}
+	}
+	return metadata
+}
+
 func (a *argoCDMechanism) logAppEvent(ctx context.Context, app *argocd.Application, user, reason, message string) {
 	logger := logging.LoggerFromContext(ctx).WithField("app", app.Name)
 
@@ -428,48 +551,63 @@ func getApplicationFn(
 func authorizeArgoCDAppUpdate(
 	stageMeta metav1.ObjectMeta,
 	appMeta metav1.ObjectMeta,
+) error {
+	return authorizeResourceUpdate("Argo CD Application", stageMeta, appMeta)
+}
+
+// authorizeResourceUpdate returns an error if the resource of the specified
+// kind represented by objMeta does not explicitly permit mutation by the Kargo
+// Stage represented by stageMeta.
+func authorizeResourceUpdate(
+	kind string,
+	stageMeta metav1.ObjectMeta,
+	objMeta metav1.ObjectMeta,
 ) error {
 	permErr := fmt.Errorf(
-		"Argo CD Application %q in namespace %q does not permit mutation by "+
+		"%s %q in namespace %q does not permit mutation by "+
 			"Kargo Stage %s in namespace %s",
-		appMeta.Name,
-		appMeta.Namespace,
+		kind,
+		objMeta.Name,
+		objMeta.Namespace,
 		stageMeta.Name,
 		stageMeta.Namespace,
 	)
-	if appMeta.Annotations == nil {
+	if objMeta.Annotations == nil {
 		return permErr
 	}
-	allowedStage, ok := appMeta.Annotations[authorizedStageAnnotationKey]
+	allowedStage, ok := objMeta.Annotations[authorizedStageAnnotationKey]
 	if !ok {
 		return permErr
 	}
 	tokens := strings.SplitN(allowedStage, ":", 2)
 	if len(tokens) != 2 {
 		return fmt.Errorf(
-			"unable to parse value of annotation %q (%q) on Argo CD Application "+
+			"unable to parse value of annotation %q (%q) on %s "+
 				"%q in namespace %q",
 			authorizedStageAnnotationKey,
 			allowedStage,
-			appMeta.Name,
-			appMeta.Namespace,
+			kind,
+			objMeta.Name,
+			objMeta.Namespace,
 		)
 	}
 	allowedNamespaceGlob, err := glob.Compile(tokens[0])
 	if err != nil {
 		return fmt.Errorf(
-			"Argo CD Application %q in namespace %q has invalid glob expression: %q",
-			appMeta.Name,
-			appMeta.Namespace,
+			"%s %q in namespace %q has invalid glob expression: %q",
+			kind,
+			objMeta.Name,
+			objMeta.Namespace,
 			tokens[0],
 		)
 	}
 	allowedNameGlob, err := glob.Compile(tokens[1])
 	if err != nil {
 		return fmt.Errorf(
-			"Argo CD Application %q in namespace %q has invalid glob expression: %q",
-			appMeta.Name,
-			appMeta.Namespace,
+			"%s %q in namespace %q has invalid glob expression: %q",
+			kind,
+			objMeta.Name,
+			objMeta.Namespace,
 			tokens[1],
 		)
 	}
@@ -624,7 +762,9 @@ func buildHelmParamChangesForArgoCDAppSource(
 		case kargoapi.ImageUpdateValueTypeImageAndTag,
 			kargoapi.ImageUpdateValueTypeTag,
 			kargoapi.ImageUpdateValueTypeImageAndDigest,
-			kargoapi.ImageUpdateValueTypeDigest:
+			kargoapi.ImageUpdateValueTypeDigest,
+			kargoapi.ImageUpdateValueTypeImageAndTagAndDigest,
+			kargoapi.ImageUpdateValueTypeTagAndDigest:
 		default:
 			// This really shouldn't happen, so we'll ignore it.
 			continue
@@ -644,6 +784,10 @@ func buildHelmParamChangesForArgoCDAppSource(
 			changes[imageUpdate.Key] = fmt.Sprintf("%s@%s", imageUpdate.Image, digest)
 		case kargoapi.ImageUpdateValueTypeDigest:
 			changes[imageUpdate.Key] = digest
+		case kargoapi.ImageUpdateValueTypeImageAndTagAndDigest:
+			changes[imageUpdate.Key] = fmt.Sprintf("%s:%s@%s", imageUpdate.Image, tag, digest)
+		case kargoapi.ImageUpdateValueTypeTagAndDigest:
+			changes[imageUpdate.Key] = fmt.Sprintf("%s@%s", tag, digest)
 		}
 	}
 	return changes
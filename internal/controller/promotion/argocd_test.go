@@ -12,6 +12,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
@@ -308,6 +309,13 @@ func TestArgoCDPromote(t *testing.T) {
 				) error {
 					return nil
 				},
+				getArgoCDAppFn: func(
+					context.Context,
+					string,
+					string,
+				) (*argocd.Application, error) {
+					return nil, errors.New("something went wrong")
+				},
 			},
 			stage: &kargoapi.Stage{
 				Spec: kargoapi.StageSpec{
@@ -374,12 +382,51 @@ func TestArgoCDPromote(t *testing.T) {
 				) (argocd.OperationPhase, bool, error) {
 					return argocd.OperationSucceeded, false, nil
 				},
+				getArgoCDAppFn: func(
+					_ context.Context,
+					namespace string,
+					name string,
+				) (*argocd.Application, error) {
+					return &argocd.Application{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: namespace,
+							Name:      name,
+						},
+						Status: argocd.ApplicationStatus{
+							Sync: argocd.SyncStatus{
+								Status: argocd.SyncStatusCodeSynced,
+							},
+							OperationState: &argocd.OperationState{
+								Operation: argocd.Operation{
+									InitiatedBy: argocd.OperationInitiator{
+										Username: applicationOperationInitiator,
+									},
+								},
+								Phase:     argocd.OperationSucceeded,
+								StartedAt: metav1.Unix(1700000000, 0),
+							},
+							History: argocd.RevisionHistories{
+								{
+									ID:              4,
+									DeployStartedAt: ptr.To(metav1.Unix(1600000000, 0)),
+								},
+								{
+									ID:              5,
+									DeployStartedAt: ptr.To(metav1.Unix(1700000000, 0)),
+								},
+							},
+						},
+					}, nil
+				},
 			},
 			stage: &kargoapi.Stage{
 				Spec: kargoapi.StageSpec{
 					PromotionMechanisms: &kargoapi.PromotionMechanisms{
 						ArgoCDAppUpdates: []kargoapi.ArgoCDAppUpdate{
-							{},
+							{
+								AppName:      "fake-app",
+								AppNamespace: "fake-namespace",
+							},
 						},
 					},
 				},
@@ -394,6 +441,15 @@ func TestArgoCDPromote(t *testing.T) {
 				require.NoError(t, err)
 				require.Equal(t, kargoapi.PromotionPhaseSucceeded, status.Phase)
 				require.Equal(t, newFreightIn, newFreightOut)
+				require.Equal(
+					t,
+					map[string]string{
+						"argocd-operation-id:fake-namespace/fake-app":    "5",
+						"argocd-operation-phase:fake-namespace/fake-app": "Succeeded",
+						"argocd-sync-status:fake-namespace/fake-app":     "Synced",
+					},
+					status.Metadata,
+				)
 			},
 		},
 	}
@@ -885,6 +941,67 @@ func TestArgoCDDoSingleUpdate(t *testing.T) {
 				require.NoError(t, err)
 			},
 		},
+		{
+			name: "success with sync options",
+			promoMech: &argoCDMechanism{
+				getArgoCDAppFn: func(
+					context.Context,
+					string,
+					string,
+				) (*argocd.Application, error) {
+					return &argocd.Application{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "fake-name",
+							Namespace: "fake-namespace",
+							Annotations: map[string]string{
+								authorizedStageAnnotationKey: "fake-namespace:fake-name",
+							},
+						},
+						Spec: argocd.ApplicationSpec{
+							SyncPolicy: &argocd.SyncPolicy{
+								SyncOptions: argocd.SyncOptions{"CreateNamespace=true"},
+							},
+						},
+					}, nil
+				},
+				argoCDAppPatchFn: func(
+					_ context.Context,
+					obj client.Object,
+					_ client.Patch,
+					_ ...client.PatchOption,
+				) error {
+					app, ok := obj.(*argocd.Application)
+					require.True(t, ok)
+					require.True(t, app.Operation.Sync.Prune)
+					require.Equal(
+						t,
+						argocd.SyncOptions{"CreateNamespace=true", "ApplyOutOfSyncOnly=true"},
+						app.Operation.Sync.SyncOptions,
+					)
+					// The Application's sync policy must not have been modified
+					require.Equal(
+						t,
+						argocd.SyncOptions{"CreateNamespace=true"},
+						app.Spec.SyncPolicy.SyncOptions,
+					)
+					return nil
+				},
+				logAppEventFn: func(context.Context, *argocd.Application, string, string, string) {},
+			},
+			stageMeta: metav1.ObjectMeta{
+				Name:      "fake-name",
+				Namespace: "fake-namespace",
+			},
+			update: kargoapi.ArgoCDAppUpdate{
+				SyncOptions: &kargoapi.ArgoCDSyncOptions{
+					Prune:              true,
+					ApplyOutOfSyncOnly: true,
+				},
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -901,6 +1018,174 @@ func TestArgoCDDoSingleUpdate(t *testing.T) {
 	}
 }
 
+func TestApplyArgoCDSyncOptions(t *testing.T) {
+	testCases := []struct {
+		name       string
+		sync       argocd.SyncOperation
+		opts       kargoapi.ArgoCDSyncOptions
+		assertions func(*testing.T, argocd.SyncOperation)
+	}{
+		{
+			name: "no options",
+			sync: argocd.SyncOperation{
+				SyncOptions: argocd.SyncOptions{"Replace=false"},
+			},
+			assertions: func(t *testing.T, sync argocd.SyncOperation) {
+				require.False(t, sync.Prune)
+				require.Nil(t, sync.SyncStrategy)
+				require.Nil(t, sync.Resources)
+				require.Equal(t, argocd.SyncOptions{"Replace=false"}, sync.SyncOptions)
+			},
+		},
+		{
+			name: "all options",
+			sync: argocd.SyncOperation{
+				SyncOptions: argocd.SyncOptions{
+					"CreateNamespace=true",
+					"Replace=false",
+					"ApplyOutOfSyncOnly=false",
+				},
+			},
+			opts: kargoapi.ArgoCDSyncOptions{
+				Prune:              true,
+				Force:              true,
+				ApplyOutOfSyncOnly: true,
+				Replace:            true,
+				Resources: []kargoapi.ArgoCDSyncResource{
+					{
+						Group: "apps",
+						Kind:  "Deployment",
+						Name:  "fake-deployment",
+					},
+				},
+			},
+			assertions: func(t *testing.T, sync argocd.SyncOperation) {
+				require.True(t, sync.Prune)
+				require.Equal(
+					t,
+					&argocd.SyncStrategy{
+						Hook: &argocd.SyncStrategyHook{
+							SyncStrategyApply: argocd.SyncStrategyApply{Force: true},
+						},
+					},
+					sync.SyncStrategy,
+				)
+				require.Equal(
+					t,
+					[]argocd.SyncOperationResource{
+						{
+							Group: "apps",
+							Kind:  "Deployment",
+							Name:  "fake-deployment",
+						},
+					},
+					sync.Resources,
+				)
+				require.Equal(
+					t,
+					argocd.SyncOptions{
+						"CreateNamespace=true",
+						"ApplyOutOfSyncOnly=true",
+						"Replace=true",
+					},
+					sync.SyncOptions,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			applyArgoCDSyncOptions(&testCase.sync, testCase.opts)
+			testCase.assertions(t, testCase.sync)
+		})
+	}
+}
+
+func TestSetSyncResultMetadata(t *testing.T) {
+	testCases := []struct {
+		name     string
+		app      *argocd.Application
+		expected map[string]string
+	}{
+		{
+			name: "no operation",
+			app:  &argocd.Application{},
+		},
+		{
+			name: "operation initiated by another user",
+			app: &argocd.Application{
+				Status: argocd.ApplicationStatus{
+					OperationState: &argocd.OperationState{
+						Operation: argocd.Operation{
+							InitiatedBy: argocd.OperationInitiator{
+								Username: "someone-else",
+							},
+						},
+						Phase: argocd.OperationSucceeded,
+					},
+				},
+			},
+		},
+		{
+			name: "operation still running",
+			app: &argocd.Application{
+				Status: argocd.ApplicationStatus{
+					OperationState: &argocd.OperationState{
+						Operation: argocd.Operation{
+							InitiatedBy: argocd.OperationInitiator{
+								Username: applicationOperationInitiator,
+							},
+						},
+						Phase: argocd.OperationRunning,
+					},
+				},
+			},
+		},
+		{
+			name: "failed operation without history entry",
+			app: &argocd.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "fake-namespace",
+					Name:      "fake-app",
+				},
+				Status: argocd.ApplicationStatus{
+					Sync: argocd.SyncStatus{
+						Status: "OutOfSync",
+					},
+					OperationState: &argocd.OperationState{
+						Operation: argocd.Operation{
+							InitiatedBy: argocd.OperationInitiator{
+								Username: applicationOperationInitiator,
+							},
+						},
+						Phase:     argocd.OperationFailed,
+						StartedAt: metav1.Unix(1700000000, 0),
+					},
+					History: argocd.RevisionHistories{
+						{
+							ID:              4,
+							DeployStartedAt: ptr.To(metav1.Unix(1600000000, 0)),
+						},
+					},
+				},
+			},
+			expected: map[string]string{
+				"argocd-operation-phase:fake-namespace/fake-app": "Failed",
+				"argocd-sync-status:fake-namespace/fake-app":     "OutOfSync",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				setSyncResultMetadata(nil, testCase.app),
+			)
+		})
+	}
+}
+
 func TestLogAppEvent(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -1412,6 +1697,16 @@ func TestBuildHelmParamChangesForArgoCDAppSource(t *testing.T) {
 			Tag:     "fourth-fake-tag",
 			Digest:  "fourth-fake-digest",
 		},
+		{
+			RepoURL: "fifth-fake-url",
+			Tag:     "fifth-fake-tag",
+			Digest:  "fifth-fake-digest",
+		},
+		{
+			RepoURL: "sixth-fake-url",
+			Tag:     "sixth-fake-tag",
+			Digest:  "sixth-fake-digest",
+		},
 	}
 	imageUpdates := []kargoapi.ArgoCDHelmImageUpdate{
 		{
@@ -1434,6 +1729,16 @@ func TestBuildHelmParamChangesForArgoCDAppSource(t *testing.T) {
 			Key:   "fourth-fake-key",
 			Value: kargoapi.ImageUpdateValueTypeDigest,
 		},
+		{
+			Image: "fifth-fake-url",
+			Key:   "fifth-fake-key",
+			Value: kargoapi.ImageUpdateValueTypeImageAndTagAndDigest,
+		},
+		{
+			Image: "sixth-fake-url",
+			Key:   "sixth-fake-key",
+			Value: kargoapi.ImageUpdateValueTypeTagAndDigest,
+		},
 		{
 			Image: "image-that-is-not-in-list",
 			Key:   "fake-key",
@@ -1448,6 +1753,8 @@ func TestBuildHelmParamChangesForArgoCDAppSource(t *testing.T) {
 			"second-fake-key": "second-fake-tag",
 			"third-fake-key":  "third-fake-url@third-fake-digest",
 			"fourth-fake-key": "fourth-fake-digest",
+			"fifth-fake-key":  "fifth-fake-url:fifth-fake-tag@fifth-fake-digest",
+			"sixth-fake-key":  "sixth-fake-tag@sixth-fake-digest",
 		},
 		result,
 	)
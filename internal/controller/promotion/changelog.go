@@ -0,0 +1,174 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/expressions"
+)
+
+// getChangelogEntry returns the entry describing the provided Promotion that
+// is to be prepended to the changelog file specified by the provided update.
+// Unless the changelog specifies a template of its own, the entry lists the
+// artifacts referenced by the new Freight and, for each commit, the range of
+// commits between it and the commit from the same repository that was
+// previously promoted to the Stage.
+func (g *gitMechanism) getChangelogEntry(
+	ctx context.Context,
+	update kargoapi.GitRepoUpdate,
+	newFreight kargoapi.FreightReference,
+	promo *kargoapi.Promotion,
+	vars map[string]string,
+	changes []string,
+) (string, error) {
+	stage, err := g.getStageFn(
+		ctx,
+		g.kargoClient,
+		types.NamespacedName{
+			Namespace: promo.Namespace,
+			Name:      promo.Spec.Stage,
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf(
+			"error finding Stage %q in namespace %q: %w",
+			promo.Spec.Stage,
+			promo.Namespace,
+			err,
+		)
+	}
+	var prevFreight *kargoapi.FreightReference
+	if stage != nil && stage.Status.CurrentFreight != nil &&
+		stage.Status.CurrentFreight.Name != newFreight.Name {
+		prevFreight = stage.Status.CurrentFreight
+	}
+
+	if update.Changelog.Template == "" {
+		return buildChangelogEntry(promo, newFreight, prevFreight), nil
+	}
+
+	env, err := commitMessageEnv(newFreight, promo, vars, changes, buildCommitMessage(changes))
+	if err != nil {
+		return "", err
+	}
+	env["previousFreight"] = nil
+	if prevFreight != nil {
+		if env["previousFreight"], err = freightExprValue(*prevFreight); err != nil {
+			return "", err
+		}
+	}
+	env["date"] = changelogDate(promo)
+	entry, err := expressions.EvaluateTemplateToString(update.Changelog.Template, env)
+	if err != nil {
+		return "", fmt.Errorf("error evaluating changelog template: %w", err)
+	}
+	return entry, nil
+}
+
+// changelogDate returns the date, formatted as per RFC 3339, on which the
+// provided Promotion was created. Using the Promotion's creation time rather
+// than the current time ensures the entry does not change if the Promotion is
+// retried.
+func changelogDate(promo *kargoapi.Promotion) string {
+	return promo.CreationTimestamp.UTC().Format(time.RFC3339)
+}
+
+// buildChangelogEntry builds a Markdown changelog entry describing the
+// promotion of the provided new Freight by the provided Promotion. If the
+// provided previous Freight is non-nil, the entry includes the ranges of
+// commits between it and the new Freight.
+func buildChangelogEntry(
+	promo *kargoapi.Promotion,
+	newFreight kargoapi.FreightReference,
+	prevFreight *kargoapi.FreightReference,
+) string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(
+		&sb,
+		"## %s: promoted Freight %s to Stage %s\n\n",
+		changelogDate(promo),
+		newFreight.Name,
+		promo.Spec.Stage,
+	)
+	_, _ = fmt.Fprintf(&sb, "Promotion: %s\n\n", promo.Name)
+	for _, commit := range newFreight.Commits {
+		ref := commit.ID
+		if prevFreight != nil {
+			for _, prevCommit := range prevFreight.Commits {
+				if prevCommit.RepoURL == commit.RepoURL &&
+					prevCommit.ID != "" && prevCommit.ID != commit.ID {
+					ref = fmt.Sprintf("%s..%s", prevCommit.ID, commit.ID)
+					break
+				}
+			}
+		}
+		if ref == "" {
+			ref = commit.Tag
+		}
+		_, _ = fmt.Fprintf(&sb, "- commit %s %s\n", commit.RepoURL, ref)
+	}
+	for _, image := range newFreight.Images {
+		ref := image.Tag
+		if image.Digest != "" {
+			ref = fmt.Sprintf("%s@%s", ref, image.Digest)
+		}
+		_, _ = fmt.Fprintf(&sb, "- image %s:%s\n", image.RepoURL, ref)
+	}
+	for _, chart := range newFreight.Charts {
+		_, _ = fmt.Fprintf(&sb, "- chart %s %s %s\n", chart.RepoURL, chart.Name, chart.Version)
+	}
+	for _, pkg := range newFreight.Packages {
+		_, _ = fmt.Fprintf(&sb, "- package %s %s %s %s\n", pkg.Type, pkg.RepoURL, pkg.Name, pkg.Version)
+	}
+	return sb.String()
+}
+
+// changelogFilePath returns the absolute path of the changelog file at the
+// provided path relative to the provided working directory. It returns an error
+// if the path does not refer to a location within the working directory.
+func changelogFilePath(workingDir, path string) (string, error) {
+	if !filepath.IsLocal(path) {
+		return "", fmt.Errorf(
+			"changelog path %q does not refer to a location within the repository",
+			path,
+		)
+	}
+	return filepath.Join(workingDir, path), nil
+}
+
+// readChangelog returns the contents of the changelog file at the provided
+// path. It returns no contents and no error if the file does not exist.
+func readChangelog(path string) ([]byte, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error reading changelog %q: %w", path, err)
+	}
+	return contents, nil
+}
+
+// writeChangelog writes the provided entry, followed by the provided existing
+// contents, to the changelog file at the provided path, creating the file and
+// its parent directories if necessary. A blank line is inserted between the
+// entry and the existing contents.
+func writeChangelog(path, entry string, existing []byte) error {
+	entry = strings.TrimRight(entry, "\n") + "\n"
+	if len(existing) > 0 {
+		entry += "\n"
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error creating directory for changelog %q: %w", path, err)
+	}
+	// nolint: gosec
+	if err := os.WriteFile(path, append([]byte(entry), existing...), 0o644); err != nil {
+		return fmt.Errorf("error writing changelog %q: %w", path, err)
+	}
+	return nil
+}
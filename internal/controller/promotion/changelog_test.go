@@ -0,0 +1,218 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestGetChangelogEntry(t *testing.T) {
+	testFreight := kargoapi.FreightReference{
+		Name: "new-freight",
+		Commits: []kargoapi.GitCommit{
+			{
+				RepoURL: "https://github.com/example/app.git",
+				ID:      "new-commit",
+			},
+			{
+				RepoURL: "https://github.com/example/other.git",
+				Tag:     "v2.0.0",
+			},
+		},
+		Images: []kargoapi.Image{{
+			RepoURL: "example/image",
+			Tag:     "v1.2.3",
+			Digest:  "sha256:abc",
+		}},
+		Charts: []kargoapi.Chart{{
+			RepoURL: "oci://example.com/charts",
+			Name:    "app",
+			Version: "1.0.0",
+		}},
+		Packages: []kargoapi.Package{{
+			Type:    kargoapi.PackageTypeNPM,
+			RepoURL: "https://registry.npmjs.org",
+			Name:    "@example/lib",
+			Version: "2.1.0",
+		}},
+	}
+	testPromo := &kargoapi.Promotion{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "fake-project",
+			Name:              "fake-promotion",
+			CreationTimestamp: metav1.NewTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)),
+		},
+		Spec: kargoapi.PromotionSpec{
+			Stage: "fake-stage",
+		},
+	}
+	stageWithFreight := func(freight *kargoapi.FreightReference) func(
+		context.Context,
+		client.Client,
+		types.NamespacedName,
+	) (*kargoapi.Stage, error) {
+		return func(context.Context, client.Client, types.NamespacedName) (*kargoapi.Stage, error) {
+			return &kargoapi.Stage{
+				Status: kargoapi.StageStatus{
+					CurrentFreight: freight,
+				},
+			}, nil
+		}
+	}
+	testCases := []struct {
+		name       string
+		changelog  kargoapi.GitRepoChangelog
+		getStageFn func(context.Context, client.Client, types.NamespacedName) (*kargoapi.Stage, error)
+		assertions func(*testing.T, string, error)
+	}{
+		{
+			name:      "error getting Stage",
+			changelog: kargoapi.GitRepoChangelog{Path: "CHANGELOG.md"},
+			getStageFn: func(context.Context, client.Client, types.NamespacedName) (*kargoapi.Stage, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, "error finding Stage")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name:       "default entry without previous Freight",
+			changelog:  kargoapi.GitRepoChangelog{Path: "CHANGELOG.md"},
+			getStageFn: stageWithFreight(nil),
+			assertions: func(t *testing.T, entry string, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					"## 2024-05-01T12:00:00Z: promoted Freight new-freight to Stage fake-stage\n\n"+
+						"Promotion: fake-promotion\n\n"+
+						"- commit https://github.com/example/app.git new-commit\n"+
+						"- commit https://github.com/example/other.git v2.0.0\n"+
+						"- image example/image:v1.2.3@sha256:abc\n"+
+						"- chart oci://example.com/charts app 1.0.0\n"+
+						"- package npm https://registry.npmjs.org @example/lib 2.1.0\n",
+					entry,
+				)
+			},
+		},
+		{
+			name:      "default entry with commit ranges",
+			changelog: kargoapi.GitRepoChangelog{Path: "CHANGELOG.md"},
+			getStageFn: stageWithFreight(&kargoapi.FreightReference{
+				Name: "old-freight",
+				Commits: []kargoapi.GitCommit{{
+					RepoURL: "https://github.com/example/app.git",
+					ID:      "old-commit",
+				}},
+			}),
+			assertions: func(t *testing.T, entry string, err error) {
+				require.NoError(t, err)
+				require.Contains(
+					t,
+					entry,
+					"- commit https://github.com/example/app.git old-commit..new-commit\n",
+				)
+			},
+		},
+		{
+			name:      "Freight already current in Stage",
+			changelog: kargoapi.GitRepoChangelog{Path: "CHANGELOG.md"},
+			getStageFn: stageWithFreight(&kargoapi.FreightReference{
+				Name: "new-freight",
+				Commits: []kargoapi.GitCommit{{
+					RepoURL: "https://github.com/example/app.git",
+					ID:      "old-commit",
+				}},
+			}),
+			assertions: func(t *testing.T, entry string, err error) {
+				require.NoError(t, err)
+				require.Contains(
+					t,
+					entry,
+					"- commit https://github.com/example/app.git new-commit\n",
+				)
+			},
+		},
+		{
+			name: "custom template",
+			changelog: kargoapi.GitRepoChangelog{
+				Path: "CHANGELOG.md",
+				Template: "* ${{ date }} ${{ ctx.stage }}: " +
+					"${{ previousFreight.name }} -> ${{ freight.name }}",
+			},
+			getStageFn: stageWithFreight(&kargoapi.FreightReference{Name: "old-freight"}),
+			assertions: func(t *testing.T, entry string, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					"* 2024-05-01T12:00:00Z fake-stage: old-freight -> new-freight",
+					entry,
+				)
+			},
+		},
+		{
+			name: "invalid template",
+			changelog: kargoapi.GitRepoChangelog{
+				Path:     "CHANGELOG.md",
+				Template: "${{ freight.nonexistent.field }}",
+			},
+			getStageFn: stageWithFreight(nil),
+			assertions: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, "error evaluating changelog template")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := &gitMechanism{getStageFn: testCase.getStageFn}
+			entry, err := g.getChangelogEntry(
+				context.Background(),
+				kargoapi.GitRepoUpdate{Changelog: &testCase.changelog},
+				testFreight,
+				testPromo,
+				nil,
+				nil,
+			)
+			testCase.assertions(t, entry, err)
+		})
+	}
+}
+
+func TestChangelogFilePath(t *testing.T) {
+	path, err := changelogFilePath("/repo", "docs/CHANGELOG.md")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join("/repo", "docs", "CHANGELOG.md"), path)
+
+	_, err = changelogFilePath("/repo", "../CHANGELOG.md")
+	require.ErrorContains(t, err, "does not refer to a location within the repository")
+}
+
+func TestReadAndWriteChangelog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs", "CHANGELOG.md")
+
+	existing, err := readChangelog(path)
+	require.NoError(t, err)
+	require.Empty(t, existing)
+
+	require.NoError(t, writeChangelog(path, "## first\n\n- one\n\n", existing))
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "## first\n\n- one\n", string(contents))
+
+	existing, err = readChangelog(path)
+	require.NoError(t, err)
+	require.NoError(t, writeChangelog(path, "## second", existing))
+	contents, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "## second\n\n## first\n\n- one\n", string(contents))
+}
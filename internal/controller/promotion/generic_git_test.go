@@ -10,7 +10,7 @@ import (
 )
 
 func TestNewGenericGitMechanism(t *testing.T) {
-	pm := newGenericGitMechanism(&credentials.FakeDB{})
+	pm := newGenericGitMechanism(nil, &credentials.FakeDB{})
 	ggpm, ok := pm.(*gitMechanism)
 	require.True(t, ok)
 	require.NotNil(t, ggpm.selectUpdatesFn)
@@ -2,17 +2,24 @@ package promotion
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/kelseyhightower/envconfig"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/controller/git"
 	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/expressions"
 	libGit "github.com/akuity/kargo/internal/git"
+	"github.com/akuity/kargo/internal/kubeclient"
 	"github.com/akuity/kargo/internal/logging"
 )
 
@@ -35,8 +42,9 @@ func GitConfigFromEnv() GitConfig {
 // update configuration in a repository. It is easily configured to support
 // different types of configuration management tools.
 type gitMechanism struct {
-	name string
-	cfg  GitConfig
+	name        string
+	cfg         GitConfig
+	kargoClient client.Client
 	// Overridable behaviors:
 	selectUpdatesFn  func([]kargoapi.GitRepoUpdate) []kargoapi.GitRepoUpdate
 	doSingleUpdateFn func(
@@ -49,7 +57,15 @@ type gitMechanism struct {
 		update kargoapi.GitRepoUpdate,
 		commits []kargoapi.GitCommit,
 	) (string, int, error)
-	getAuthorFn      func() (*git.User, error)
+	getAuthorFn     func(ctx context.Context, namespace string) (*git.User, error)
+	getProjectFn    func(context.Context, client.Client, string) (*kargoapi.Project, error)
+	getStageFn      func(context.Context, client.Client, types.NamespacedName) (*kargoapi.Stage, error)
+	getVarsFn       func(ctx context.Context, promo *kargoapi.Promotion) (map[string]string, error)
+	getSigningKeyFn func(
+		ctx context.Context,
+		namespace string,
+		secretName string,
+	) (string, error)
 	getCredentialsFn func(
 		ctx context.Context,
 		namespace string,
@@ -59,22 +75,44 @@ type gitMechanism struct {
 		ctx context.Context,
 		update kargoapi.GitRepoUpdate,
 		newFreight kargoapi.FreightReference,
-		namespace string,
+		promo *kargoapi.Promotion,
 		readRef string,
 		writeBranch string,
 		repo git.Repo,
 		repoCreds git.RepoCredentials,
 	) (string, error)
+	getCommitMessageFn func(
+		ctx context.Context,
+		update kargoapi.GitRepoUpdate,
+		newFreight kargoapi.FreightReference,
+		promo *kargoapi.Promotion,
+		vars map[string]string,
+		changes []string,
+	) (string, error)
+	getChangelogEntryFn func(
+		ctx context.Context,
+		update kargoapi.GitRepoUpdate,
+		newFreight kargoapi.FreightReference,
+		promo *kargoapi.Promotion,
+		vars map[string]string,
+		changes []string,
+	) (string, error)
 	applyConfigManagementFn func(
 		ctx context.Context,
 		update kargoapi.GitRepoUpdate,
 		newFreight kargoapi.FreightReference,
 		namespace string,
+		vars map[string]string,
 		sourceCommit string,
 		homeDir string,
 		workingDir string,
 		repoCreds git.RepoCredentials,
 	) ([]string, error)
+	checkpointFn func(
+		ctx context.Context,
+		promo *kargoapi.Promotion,
+		metadata map[string]string,
+	)
 }
 
 // newGitMechanism returns an implementation of the Mechanism interface that
@@ -83,6 +121,7 @@ type gitMechanism struct {
 // functions that select and carry out the relevant subset of updates.
 func newGitMechanism(
 	name string,
+	kargoClient client.Client,
 	credentialsDB credentials.Database,
 	selectUpdatesFn func([]kargoapi.GitRepoUpdate) []kargoapi.GitRepoUpdate,
 	applyConfigManagementFn func(
@@ -90,6 +129,7 @@ func newGitMechanism(
 		update kargoapi.GitRepoUpdate,
 		newFreight kargoapi.FreightReference,
 		namespace string,
+		vars map[string]string,
 		sourceCommit string,
 		homeDir string,
 		workingDir string,
@@ -97,7 +137,8 @@ func newGitMechanism(
 	) ([]string, error),
 ) Mechanism {
 	g := &gitMechanism{
-		name: name,
+		name:        name,
+		kargoClient: kargoClient,
 	}
 	g.cfg = GitConfigFromEnv()
 	g.selectUpdatesFn = selectUpdatesFn
@@ -105,8 +146,15 @@ func newGitMechanism(
 	g.getReadRefFn = getReadRef
 	g.getCredentialsFn = getRepoCredentialsFn(credentialsDB)
 	g.getAuthorFn = g.getAuthor
+	g.getProjectFn = kargoapi.GetProject
+	g.getStageFn = kargoapi.GetStage
+	g.getVarsFn = g.getVars
+	g.getSigningKeyFn = g.getSigningKey
 	g.gitCommitFn = g.gitCommit
+	g.getCommitMessageFn = g.getCommitMessage
+	g.getChangelogEntryFn = g.getChangelogEntry
 	g.applyConfigManagementFn = applyConfigManagementFn
+	g.checkpointFn = g.checkpoint
 	return g
 }
 
@@ -168,7 +216,25 @@ func (g *gitMechanism) doSingleUpdate(
 		return nil, newFreight, err
 	}
 
-	author, err := g.getAuthorFn()
+	// If this update was already completed by an earlier reconciliation of the
+	// Promotion, e.g. one that was interrupted by a controller restart, it must
+	// not be repeated. Pick up where that reconciliation left off instead.
+	if commitID := getCommitFromMetadata(
+		promo.Status.Metadata,
+		update.RepoURL,
+		update.WriteBranch,
+	); commitID != "" {
+		logging.LoggerFromContext(ctx).WithField("repo", update.RepoURL).Debugf(
+			"update of branch %q was already completed; skipping",
+			update.WriteBranch,
+		)
+		if commitIndex > -1 {
+			newFreight.Commits[commitIndex].HealthCheckCommit = commitID
+		}
+		return promo.Status.WithPhase(kargoapi.PromotionPhaseSucceeded), newFreight, nil
+	}
+
+	author, err := g.getAuthorFn(ctx, promo.Namespace)
 	if err != nil {
 		return nil, newFreight, err
 	}
@@ -186,71 +252,337 @@ func (g *gitMechanism) doSingleUpdate(
 	if creds == nil {
 		creds = &git.RepoCredentials{}
 	}
-	repo, err := git.Clone(
-		update.RepoURL,
-		&git.ClientOptions{
-			User:        author,
-			Credentials: creds,
-		},
-		&git.CloneOptions{
-			InsecureSkipTLSVerify: update.InsecureSkipTLSVerify,
-		},
-	)
+	cloneRepo := func() (git.Repo, error) {
+		repo, err := git.Clone(
+			update.RepoURL,
+			&git.ClientOptions{
+				User:        author,
+				Credentials: creds,
+			},
+			&git.CloneOptions{
+				InsecureSkipTLSVerify: update.InsecureSkipTLSVerify,
+				InsecureHTTP:          update.InsecureHTTP,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error cloning git repo %q: %w", update.RepoURL, err)
+		}
+		return repo, nil
+	}
+	repo, err := cloneRepo()
 	if err != nil {
-		return nil, newFreight, fmt.Errorf("error cloning git repo %q: %w", update.RepoURL, err)
+		return nil, newFreight, err
 	}
 	defer repo.Close()
 
-	commitBranch := update.WriteBranch
-	if update.PullRequest != nil {
+	var commitID string
+	usePullRequest := update.PullRequest != nil
+	if usePullRequest && update.PullRequest.FallbackOnProtectedBranch &&
+		getPullRequestNumberFromMetadata(promo.Status.Metadata, update.RepoURL) == -1 {
+		// Attempt to commit to writeBranch directly and only fall back to a PR
+		// if the push is rejected because writeBranch is protected.
+		commitID, err = g.commitToWriteBranch(
+			ctx,
+			update,
+			newFreight,
+			promo,
+			readRef,
+			repo,
+			cloneRepo,
+			*creds,
+		)
+		if err == nil {
+			usePullRequest = false
+		} else {
+			if !errors.Is(err, git.ErrBranchProtected) {
+				return nil, newFreight, err
+			}
+			logging.LoggerFromContext(ctx).WithField("repo", update.RepoURL).Debugf(
+				"branch %q is protected; falling back to a pull request",
+				update.WriteBranch,
+			)
+			// The rejected commit remains in the local copy of writeBranch, which
+			// would keep it from being compared to the PR branch. Start over with a
+			// fresh clone.
+			if repo, err = cloneRepo(); err != nil {
+				return nil, newFreight, err
+			}
+			defer repo.Close()
+		}
+	}
+
+	if usePullRequest {
 		// When doing a PR promotion, instead of committing to writeBranch directly,
 		// we commit to a temporary, PR branch, which is a child of writeBranch.
-		commitBranch = pullRequestBranchName(promo.Namespace, promo.Spec.Stage)
+		commitBranch := pullRequestBranchName(promo.Namespace, promo.Spec.Stage)
 
+		// Once the PR has been created, the commit it was created for must not
+		// be repeated. All that remains is to wait for the PR to be closed.
 		if getPullRequestNumberFromMetadata(promo.Status.Metadata, update.RepoURL) == -1 {
 			// PR was never created. Prepare the branch for the commit
 			if err = preparePullRequestBranch(repo, commitBranch, update.WriteBranch); err != nil {
 				return nil, newFreight, fmt.Errorf("error preparing PR branch %q: %w", update.RepoURL, err)
 			}
+			if _, err = g.gitCommitFn(
+				ctx,
+				update,
+				newFreight,
+				promo,
+				readRef,
+				commitBranch,
+				repo,
+				*creds,
+			); err != nil {
+				return nil, newFreight, err
+			}
 		}
-	}
-
-	commitID, err := g.gitCommitFn(
-		ctx,
-		update,
-		newFreight,
-		promo.Namespace,
-		readRef,
-		commitBranch,
-		repo,
-		*creds,
-	)
-	if err != nil {
-		return nil, newFreight, err
-	}
 
-	newStatus := promo.Status.DeepCopy()
-	if update.PullRequest != nil {
 		gpClient, err := newGitProvider(update.RepoURL, update.PullRequest, creds)
 		if err != nil {
 			return nil, newFreight, err
 		}
+		var newStatus *kargoapi.PromotionStatus
 		commitID, newStatus, err = reconcilePullRequest(ctx, promo.Status, repo, gpClient, commitBranch, update.WriteBranch)
 		if err != nil {
 			return nil, newFreight, err
 		}
-	} else {
-		// For git commit promotions, promotion is successful as soon as the commit is pushed.
-		newStatus.Phase = kargoapi.PromotionPhaseSucceeded
+		if shouldDeletePullRequestBranch(update.PullRequest.BranchCleanup, newStatus.Phase) {
+			// Failing to clean up after a pull request that has already been
+			// closed should not change the outcome of the Promotion. The branch is
+			// reused by the Stage's next Promotion regardless.
+			if newStatus.Metadata, err = deletePullRequestBranch(
+				repo,
+				commitBranch,
+				newStatus.Metadata,
+			); err != nil {
+				logging.LoggerFromContext(ctx).WithField("repo", update.RepoURL).
+					Errorf("error deleting PR branch %q: %s", commitBranch, err)
+			}
+		}
+		if newStatus.Phase == kargoapi.PromotionPhaseSucceeded && commitID != "" {
+			if commitIndex > -1 {
+				newFreight.Commits[commitIndex].HealthCheckCommit = commitID
+			}
+			newStatus.Metadata = setCommitMetadata(
+				newStatus.Metadata,
+				update.RepoURL,
+				update.WriteBranch,
+				commitID,
+			)
+		}
+		g.checkpointFn(ctx, promo, newStatus.Metadata)
+		return newStatus, newFreight, nil
 	}
 
-	if commitIndex > -1 && newStatus.Phase == kargoapi.PromotionPhaseSucceeded {
-		newFreight.Commits[commitIndex].HealthCheckCommit = commitID
+	if commitID == "" {
+		if commitID, err = g.commitToWriteBranch(
+			ctx,
+			update,
+			newFreight,
+			promo,
+			readRef,
+			repo,
+			cloneRepo,
+			*creds,
+		); err != nil {
+			if errors.Is(err, git.ErrBranchProtected) {
+				return nil, newFreight, fmt.Errorf(
+					"%w; to open a pull request whenever the branch is protected, "+
+						"set pullRequest.fallbackOnProtectedBranch to true for this "+
+						"repository",
+					err,
+				)
+			}
+			return nil, newFreight, err
+		}
 	}
 
+	// For git commit promotions, promotion is successful as soon as the commit is
+	// pushed.
+	newStatus := promo.Status.DeepCopy()
+	newStatus.Phase = kargoapi.PromotionPhaseSucceeded
+	newStatus.Metadata = setCommitMetadata(
+		newStatus.Metadata,
+		update.RepoURL,
+		update.WriteBranch,
+		commitID,
+	)
+	g.checkpointFn(ctx, promo, newStatus.Metadata)
+	if commitIndex > -1 {
+		newFreight.Commits[commitIndex].HealthCheckCommit = commitID
+	}
 	return newStatus, newFreight, nil
 }
 
+// commitToWriteBranch commits changes to the update's write branch using
+// gitCommitFn. If pushing the commit is rejected because the remote branch was
+// updated after the repository was cloned, and the update's push conflict
+// strategy is Rerender, the repository is cloned again using the provided
+// function and the changes are made afresh, up to the maximum number of
+// attempts.
+func (g *gitMechanism) commitToWriteBranch(
+	ctx context.Context,
+	update kargoapi.GitRepoUpdate,
+	newFreight kargoapi.FreightReference,
+	promo *kargoapi.Promotion,
+	readRef string,
+	repo git.Repo,
+	cloneRepo func() (git.Repo, error),
+	creds git.RepoCredentials,
+) (string, error) {
+	strategy, maxAttempts := getPushConflictHandling(update)
+	// freshRepo is the most recent of any repositories cloned here. It is
+	// closed upon return. The provided repository is the caller's to close.
+	var freshRepo git.Repo
+	defer func() {
+		if freshRepo != nil {
+			freshRepo.Close()
+		}
+	}()
+	for attempt := int32(1); ; attempt++ {
+		commitID, err := g.gitCommitFn(
+			ctx,
+			update,
+			newFreight,
+			promo,
+			readRef,
+			update.WriteBranch,
+			repo,
+			creds,
+		)
+		if err == nil || !errors.Is(err, git.ErrPushConflict) ||
+			strategy != kargoapi.GitPushConflictStrategyRerender || attempt >= maxAttempts {
+			return commitID, err
+		}
+		logging.LoggerFromContext(ctx).WithField("repo", update.RepoURL).Debugf(
+			"branch %q was updated concurrently; making changes again on a fresh clone "+
+				"(attempt %d of %d)",
+			update.WriteBranch,
+			attempt+1,
+			maxAttempts,
+		)
+		if freshRepo != nil {
+			freshRepo.Close()
+		}
+		if freshRepo, err = cloneRepo(); err != nil {
+			return "", err
+		}
+		repo = freshRepo
+	}
+}
+
+// pushWithRetries pushes the current branch of the provided repository. If the
+// push is rejected because the remote branch was updated after the repository
+// was cloned, and the update's push conflict strategy is Rebase, local commits
+// are rebased onto the remote branch and the push is retried, up to the
+// maximum number of attempts.
+func pushWithRetries(
+	ctx context.Context,
+	update kargoapi.GitRepoUpdate,
+	repo git.Repo,
+) error {
+	strategy, maxAttempts := getPushConflictHandling(update)
+	for attempt := int32(1); ; attempt++ {
+		err := repo.Push(false)
+		if err == nil || !errors.Is(err, git.ErrPushConflict) ||
+			strategy != kargoapi.GitPushConflictStrategyRebase || attempt >= maxAttempts {
+			return err
+		}
+		logging.LoggerFromContext(ctx).WithField("repo", update.RepoURL).Debugf(
+			"branch %q was updated concurrently; rebasing and pushing again "+
+				"(attempt %d of %d)",
+			update.WriteBranch,
+			attempt+1,
+			maxAttempts,
+		)
+		if err = repo.PullRebase(); err != nil {
+			return err
+		}
+	}
+}
+
+// getPushConflictHandling returns the strategy for handling rejected pushes
+// and the maximum number of push attempts for the provided update, applying
+// defaults for anything left unspecified.
+func getPushConflictHandling(
+	update kargoapi.GitRepoUpdate,
+) (kargoapi.GitPushConflictStrategy, int32) {
+	strategy := kargoapi.GitPushConflictStrategyFail
+	maxAttempts := int32(3)
+	if update.PushConflicts != nil {
+		if update.PushConflicts.Strategy != "" {
+			strategy = update.PushConflicts.Strategy
+		}
+		if update.PushConflicts.MaxAttempts > 0 {
+			maxAttempts = update.PushConflicts.MaxAttempts
+		}
+	}
+	return strategy, maxAttempts
+}
+
+// commitMetadataKey returns the key used to store the ID of the commit that
+// completed an update of the specified branch of the specified repository in
+// the metadata map.
+func commitMetadataKey(repoURL, branch string) string {
+	return fmt.Sprintf("commit:%s:%s", repoURL, branch)
+}
+
+// setCommitMetadata records in the metadata map that an update of the specified
+// branch of the specified repository was completed by the commit with the
+// provided ID.
+func setCommitMetadata(
+	metadata map[string]string,
+	repoURL string,
+	branch string,
+	commitID string,
+) map[string]string {
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	metadata[commitMetadataKey(repoURL, branch)] = commitID
+	return metadata
+}
+
+// getCommitFromMetadata returns the ID of the commit that completed an update
+// of the specified branch of the specified repository from the metadata map.
+// If the update was not completed, an empty string is returned.
+func getCommitFromMetadata(metadata map[string]string, repoURL, branch string) string {
+	return metadata[commitMetadataKey(repoURL, branch)]
+}
+
+// checkpoint immediately persists the provided metadata to the status of the
+// provided Promotion, so that the side effects it records, such as pushed
+// commits, are not repeated if the controller is restarted before the
+// Promotion's status is otherwise updated. Failure to persist the metadata is
+// logged but not otherwise treated as an error, as it will still be persisted
+// along with the rest of the Promotion's status.
+func (g *gitMechanism) checkpoint(
+	ctx context.Context,
+	promo *kargoapi.Promotion,
+	metadata map[string]string,
+) {
+	if err := kubeclient.PatchStatus(
+		ctx,
+		g.kargoClient,
+		promo,
+		func(status *kargoapi.PromotionStatus) {
+			if status.Metadata == nil {
+				status.Metadata = make(map[string]string, len(metadata))
+			}
+			for k, v := range metadata {
+				status.Metadata[k] = v
+			}
+		},
+	); err != nil {
+		logging.LoggerFromContext(ctx).Warnf(
+			"error checkpointing status of Promotion %q in namespace %q: %s",
+			promo.Name,
+			promo.Namespace,
+			err,
+		)
+	}
+}
+
 // getReadRef steps through the provided slice of commits to determine if any of
 // them are from the same repository referenced by the provided update. If so,
 // it returns the commit ID and index of the commit in the slice. If not, it
@@ -265,7 +597,8 @@ func getReadRef(
 	updateRepoURL := libGit.NormalizeURL(update.RepoURL)
 	for i, commit := range commits {
 		if libGit.NormalizeURL(commit.RepoURL) == updateRepoURL {
-			if update.WriteBranch == commit.Branch && update.PullRequest == nil {
+			if update.WriteBranch == commit.Branch &&
+				(update.PullRequest == nil || update.PullRequest.FallbackOnProtectedBranch) {
 				return "", -1, fmt.Errorf(
 					"invalid update specified; cannot write to branch %q of repo %q "+
 						"because it will form a subscription loop",
@@ -319,7 +652,11 @@ func getRepoCredentialsFn(
 	}
 }
 
-func (g *gitMechanism) getAuthor() (*git.User, error) {
+// getAuthor returns the identity, and optional signing key, to be used for
+// commits made on behalf of the Project with the provided namespace. The
+// Project's own Git config, if any, takes precedence over the controller-wide
+// defaults.
+func (g *gitMechanism) getAuthor(ctx context.Context, namespace string) (*git.User, error) {
 	author := git.User{
 		Name:  g.cfg.Name,
 		Email: g.cfg.Email,
@@ -328,6 +665,8 @@ func (g *gitMechanism) getAuthor() (*git.User, error) {
 	switch strings.ToLower(g.cfg.SigningKeyType) {
 	case "gpg", "":
 		author.SigningKeyType = git.SigningKeyTypeGPG
+	case "ssh":
+		author.SigningKeyType = git.SigningKeyTypeSSH
 	default:
 		return nil, fmt.Errorf(
 			"unsupported signing key type: %q",
@@ -346,9 +685,124 @@ func (g *gitMechanism) getAuthor() (*git.User, error) {
 		author.SigningKeyPath = g.cfg.SigningKeyPath
 	}
 
+	project, err := g.getProjectFn(ctx, g.kargoClient, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error finding Project %q: %w", namespace, err)
+	}
+	if project == nil || project.Spec == nil || project.Spec.GitConfig == nil {
+		return &author, nil
+	}
+	gitCfg := project.Spec.GitConfig
+
+	if gitCfg.Name != "" {
+		author.Name = gitCfg.Name
+	}
+	if gitCfg.Email != "" {
+		author.Email = gitCfg.Email
+	}
+
+	// The controller-wide signing key is tied to the controller-wide identity,
+	// so it is never used on behalf of a Project with its own Git config.
+	author.SigningKeyPath = ""
+	if gitCfg.SigningKey == nil {
+		return &author, nil
+	}
+	switch gitCfg.SigningKey.Type {
+	case kargoapi.GitSigningKeyTypeGPG, "":
+		author.SigningKeyType = git.SigningKeyTypeGPG
+	case kargoapi.GitSigningKeyTypeSSH:
+		author.SigningKeyType = git.SigningKeyTypeSSH
+	default:
+		return nil, fmt.Errorf(
+			"unsupported signing key type: %q",
+			gitCfg.SigningKey.Type,
+		)
+	}
+	if author.SigningKey, err = g.getSigningKeyFn(
+		ctx,
+		namespace,
+		gitCfg.SigningKey.SecretName,
+	); err != nil {
+		return nil, err
+	}
+
 	return &author, nil
 }
 
+// getSigningKey returns the private signing key stored in the specified
+// Secret.
+func (g *gitMechanism) getSigningKey(
+	ctx context.Context,
+	namespace string,
+	secretName string,
+) (string, error) {
+	secret := corev1.Secret{}
+	if err := g.kargoClient.Get(
+		ctx,
+		types.NamespacedName{
+			Namespace: namespace,
+			Name:      secretName,
+		},
+		&secret,
+	); err != nil {
+		return "", fmt.Errorf(
+			"error getting signing key Secret %q in namespace %q: %w",
+			secretName,
+			namespace,
+			err,
+		)
+	}
+	key, ok := secret.Data[kargoapi.GitSigningKeySecretKey]
+	if !ok || len(key) == 0 {
+		return "", fmt.Errorf(
+			"Secret %q in namespace %q has no %q key",
+			secretName,
+			namespace,
+			kargoapi.GitSigningKeySecretKey,
+		)
+	}
+	return string(key), nil
+}
+
+// getVars returns the variables available to expressions evaluated during the
+// provided Promotion, as defined by the Project and Stage it belongs to.
+func (g *gitMechanism) getVars(
+	ctx context.Context,
+	promo *kargoapi.Promotion,
+) (map[string]string, error) {
+	project, err := g.getProjectFn(ctx, g.kargoClient, promo.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error finding Project %q: %w", promo.Namespace, err)
+	}
+	stage, err := g.getStageFn(
+		ctx,
+		g.kargoClient,
+		types.NamespacedName{
+			Namespace: promo.Namespace,
+			Name:      promo.Spec.Stage,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error finding Stage %q in namespace %q: %w",
+			promo.Spec.Stage,
+			promo.Namespace,
+			err,
+		)
+	}
+	return kargoapi.ResolveVars(project, stage), nil
+}
+
+// varsExprValue converts the provided variables into the representation by
+// which expressions refer to them.
+func varsExprValue(vars map[string]string) map[string]any {
+	res := make(map[string]any, len(vars))
+	for k, v := range vars {
+		res[k] = v
+	}
+	return res
+}
+
 // gitCommit checks out the specified readRef (if non-empty), applies
 // the provided update function to the cloned repository, and then commits and
 // pushes any changes to the specified writeBranch. The function returns the
@@ -358,7 +812,7 @@ func (g *gitMechanism) gitCommit(
 	ctx context.Context,
 	update kargoapi.GitRepoUpdate,
 	newFreight kargoapi.FreightReference,
-	namespace string,
+	promo *kargoapi.Promotion,
 	readRef string,
 	writeBranch string,
 	repo git.Repo,
@@ -378,13 +832,25 @@ func (g *gitMechanism) gitCommit(
 		return "", err // TODO: Wrap this
 	}
 
+	vars, err := g.getVarsFn(ctx, promo)
+	if err != nil {
+		return "", err
+	}
+
 	var changes []string
 	if g.applyConfigManagementFn != nil {
+		var snapshot map[string]fileAttributes
+		if update.Preserve != nil {
+			if snapshot, err = snapshotFileAttributes(repo.WorkingDir(), *update.Preserve); err != nil {
+				return "", err
+			}
+		}
 		if changes, err = g.applyConfigManagementFn(
 			ctx,
 			update,
 			newFreight,
-			namespace,
+			promo.Namespace,
+			vars,
 			sourceCommitID,
 			repo.HomeDir(),
 			repo.WorkingDir(),
@@ -392,8 +858,37 @@ func (g *gitMechanism) gitCommit(
 		); err != nil {
 			return "", err
 		}
+		if update.Preserve != nil {
+			if err = restoreFileAttributes(repo.WorkingDir(), snapshot, *update.Preserve); err != nil {
+				return "", err
+			}
+		}
+	}
+	commitMsg, err := g.getCommitMessageFn(ctx, update, newFreight, promo, vars, changes)
+	if err != nil {
+		return "", err
+	}
+
+	var changelogPath, changelogEntry string
+	var changelog []byte
+	if update.Changelog != nil {
+		if changelogPath, err = changelogFilePath(repo.WorkingDir(), update.Changelog.Path); err != nil {
+			return "", err
+		}
+		if changelogEntry, err = g.getChangelogEntryFn(
+			ctx,
+			update,
+			newFreight,
+			promo,
+			vars,
+			changes,
+		); err != nil {
+			return "", err
+		}
+		if changelog, err = readChangelog(changelogPath); err != nil {
+			return "", err
+		}
 	}
-	commitMsg := buildCommitMessage(changes)
 
 	// Sometimes we don't write to the same branch we read from...
 	if readRef != writeBranch {
@@ -440,6 +935,16 @@ func (g *gitMechanism) gitCommit(
 			}
 		}
 
+		// The changelog accumulates entries on the branch it is written to, so
+		// its existing contents are taken from that branch rather than from the
+		// one that was read from.
+		changelog = nil
+		if update.Changelog != nil && branchExists {
+			if changelog, err = readChangelog(changelogPath); err != nil {
+				return "", err
+			}
+		}
+
 		if err = deleteRepoContents(repo.WorkingDir()); err != nil {
 			return "", fmt.Errorf("error clearing contents from repository working tree: %w", err)
 		}
@@ -449,6 +954,12 @@ func (g *gitMechanism) gitCommit(
 		}
 	}
 
+	if update.Changelog != nil {
+		if err = writeChangelog(changelogPath, changelogEntry, changelog); err != nil {
+			return "", err
+		}
+	}
+
 	hasDiffs, err := repo.HasDiffs()
 	if err != nil {
 		return "", fmt.Errorf("error checking for diffs in git repo %q: %w", update.RepoURL, err)
@@ -458,7 +969,7 @@ func (g *gitMechanism) gitCommit(
 		if err = repo.AddAllAndCommit(commitMsg); err != nil {
 			return "", fmt.Errorf("error committing updates to git repo %q: %w", update.RepoURL, err)
 		}
-		if err = repo.Push(false); err != nil {
+		if err = pushWithRetries(ctx, update, repo); err != nil {
 			return "", fmt.Errorf("error pushing updates to git repo %q: %w", update.RepoURL, err)
 		}
 	}
@@ -509,6 +1020,140 @@ func deleteRepoContents(dir string) error {
 	return nil
 }
 
+// getCommitMessage returns the message for a commit of the provided changes. If
+// the provided update references one of the Project's CommitMessageTemplates,
+// the message is composed by evaluating that template. Otherwise, a message is
+// composed from the changes themselves.
+func (g *gitMechanism) getCommitMessage(
+	ctx context.Context,
+	update kargoapi.GitRepoUpdate,
+	newFreight kargoapi.FreightReference,
+	promo *kargoapi.Promotion,
+	vars map[string]string,
+	changes []string,
+) (string, error) {
+	summary := buildCommitMessage(changes)
+	if update.CommitMessageTemplate == "" {
+		return summary, nil
+	}
+	project, err := g.getProjectFn(ctx, g.kargoClient, promo.Namespace)
+	if err != nil {
+		return "", fmt.Errorf("error finding Project %q: %w", promo.Namespace, err)
+	}
+	var tmpl *kargoapi.CommitMessageTemplate
+	if project != nil {
+		tmpl = project.GetCommitMessageTemplate(update.CommitMessageTemplate)
+	}
+	if tmpl == nil {
+		return "", fmt.Errorf(
+			"commit message template %q not found in Project %q",
+			update.CommitMessageTemplate,
+			promo.Namespace,
+		)
+	}
+	env, err := commitMessageEnv(newFreight, promo, vars, changes, summary)
+	if err != nil {
+		return "", err
+	}
+	msg, err := expressions.EvaluateTemplateToString(tmpl.Template, env)
+	if err != nil {
+		return "", fmt.Errorf(
+			"error evaluating commit message template %q: %w",
+			update.CommitMessageTemplate,
+			err,
+		)
+	}
+	return msg, nil
+}
+
+// commitMessageEnv builds the environment against which expressions in commit
+// message templates are evaluated.
+func commitMessageEnv(
+	newFreight kargoapi.FreightReference,
+	promo *kargoapi.Promotion,
+	vars map[string]string,
+	changes []string,
+	summary string,
+) (map[string]any, error) {
+	freight, err := freightExprValue(newFreight)
+	if err != nil {
+		return nil, err
+	}
+	changeList := make([]any, len(changes))
+	for i, change := range changes {
+		changeList[i] = change
+	}
+	outputs := map[string]any{}
+	pullRequests := map[string]any{}
+	for k, v := range promo.Status.Metadata {
+		outputs[k] = v
+		if repoURL, ok := strings.CutPrefix(k, pullRequestURLMetadataKeyPrefix); ok {
+			pullRequests[repoURL] = v
+		}
+	}
+	return map[string]any{
+		"ctx": map[string]any{
+			"project":   promo.Namespace,
+			"stage":     promo.Spec.Stage,
+			"promotion": promo.Name,
+		},
+		"freight":      freight,
+		"changes":      changeList,
+		"summary":      summary,
+		"outputs":      outputs,
+		"pullRequests": pullRequests,
+		"vars":         varsExprValue(vars),
+	}, nil
+}
+
+// freightExprValue converts the provided FreightReference into the
+// representation by which expressions refer to it.
+func freightExprValue(newFreight kargoapi.FreightReference) (map[string]any, error) {
+	commits, err := toExprValue(newFreight.Commits)
+	if err != nil {
+		return nil, fmt.Errorf("error converting Freight commits: %w", err)
+	}
+	images, err := toExprValue(newFreight.Images)
+	if err != nil {
+		return nil, fmt.Errorf("error converting Freight images: %w", err)
+	}
+	charts, err := toExprValue(newFreight.Charts)
+	if err != nil {
+		return nil, fmt.Errorf("error converting Freight charts: %w", err)
+	}
+	packages, err := toExprValue(newFreight.Packages)
+	if err != nil {
+		return nil, fmt.Errorf("error converting Freight packages: %w", err)
+	}
+	return map[string]any{
+		"name":      newFreight.Name,
+		"warehouse": newFreight.Warehouse,
+		"commits":   commits,
+		"images":    images,
+		"charts":    charts,
+		"packages":  packages,
+	}, nil
+}
+
+// toExprValue converts the provided value into its generic JSON
+// representation so that its fields can be accessed by expressions using the
+// same names by which they are known in the Kargo API.
+func toExprValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var res any
+	if err = json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	if res == nil {
+		// Nil slices should be represented as empty lists
+		return []any{}, nil
+	}
+	return res, nil
+}
+
 // buildCommitMessage constructs a commit message from the provided change
 // summary. If the change summary is empty, then a generic message is returned.
 // If the change summary contains only one entry, then that entry is returned as
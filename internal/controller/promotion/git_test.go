@@ -10,7 +10,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/controller/git"
@@ -20,6 +25,7 @@ import (
 func TestNewGitMechanism(t *testing.T) {
 	pm := newGitMechanism(
 		"fake-name",
+		nil,
 		&credentials.FakeDB{},
 		func([]kargoapi.GitRepoUpdate) []kargoapi.GitRepoUpdate {
 			return nil
@@ -28,7 +34,9 @@ func TestNewGitMechanism(t *testing.T) {
 			context.Context,
 			kargoapi.GitRepoUpdate,
 			kargoapi.FreightReference,
-			string, string, string, string,
+			string,
+			map[string]string,
+			string, string, string,
 			git.RepoCredentials,
 		) ([]string, error) {
 			return nil, nil
@@ -41,14 +49,20 @@ func TestNewGitMechanism(t *testing.T) {
 	require.NotNil(t, gpm.doSingleUpdateFn)
 	require.NotNil(t, gpm.getReadRefFn)
 	require.NotNil(t, gpm.getAuthorFn)
+	require.NotNil(t, gpm.getProjectFn)
+	require.NotNil(t, gpm.getStageFn)
+	require.NotNil(t, gpm.getVarsFn)
+	require.NotNil(t, gpm.getSigningKeyFn)
 	require.NotNil(t, gpm.getCredentialsFn)
 	require.NotNil(t, gpm.gitCommitFn)
+	require.NotNil(t, gpm.getCommitMessageFn)
 	require.NotNil(t, gpm.applyConfigManagementFn)
+	require.NotNil(t, gpm.checkpointFn)
 }
 
 func TestGitGetName(t *testing.T) {
 	const testName = "fake name"
-	pm := newGitMechanism(testName, nil, nil, nil)
+	pm := newGitMechanism(testName, nil, nil, nil, nil)
 	require.Equal(t, testName, pm.GetName())
 }
 
@@ -157,9 +171,10 @@ func TestGitPromote(t *testing.T) {
 func TestGitDoSingleUpdate(t *testing.T) {
 	const testRef = "fake-ref"
 	testCases := []struct {
-		name       string
-		promoMech  *gitMechanism
-		assertions func(
+		name        string
+		promoStatus kargoapi.PromotionStatus
+		promoMech   *gitMechanism
+		assertions  func(
 			t *testing.T,
 			status *kargoapi.PromotionStatus,
 			newFreightIn kargoapi.FreightReference,
@@ -189,6 +204,42 @@ func TestGitDoSingleUpdate(t *testing.T) {
 				require.Equal(t, newFreightIn, newFreightOut)
 			},
 		},
+		{
+			name: "update already completed",
+			promoStatus: kargoapi.PromotionStatus{
+				Phase: kargoapi.PromotionPhaseRunning,
+				Metadata: map[string]string{
+					commitMetadataKey("https://github.com/akuity/kargo", "main"): "fake-commit-id",
+				},
+			},
+			promoMech: &gitMechanism{
+				getReadRefFn: func(
+					kargoapi.GitRepoUpdate,
+					[]kargoapi.GitCommit,
+				) (string, int, error) {
+					return testRef, 0, nil
+				},
+				// The update must not be repeated, so nothing else may be invoked
+			},
+			assertions: func(
+				t *testing.T,
+				status *kargoapi.PromotionStatus,
+				newFreightIn kargoapi.FreightReference,
+				newFreightOut kargoapi.FreightReference,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionPhaseSucceeded, status.Phase)
+				require.Equal(
+					t,
+					"fake-commit-id",
+					newFreightOut.Commits[0].HealthCheckCommit,
+				)
+				// The newFreight is otherwise unaltered
+				newFreightIn.Commits[0].HealthCheckCommit = ""
+				require.Equal(t, newFreightIn, newFreightOut)
+			},
+		},
 		{
 			name: "error getting repo credentials",
 			promoMech: &gitMechanism{
@@ -198,7 +249,7 @@ func TestGitDoSingleUpdate(t *testing.T) {
 				) (string, int, error) {
 					return testRef, 0, nil
 				},
-				getAuthorFn: func() (*git.User, error) {
+				getAuthorFn: func(context.Context, string) (*git.User, error) {
 					return nil, nil
 				},
 				getCredentialsFn: func(
@@ -230,7 +281,7 @@ func TestGitDoSingleUpdate(t *testing.T) {
 				) (string, int, error) {
 					return testRef, 0, nil
 				},
-				getAuthorFn: func() (*git.User, error) {
+				getAuthorFn: func(context.Context, string) (*git.User, error) {
 					return nil, errors.New("something went wrong")
 				},
 				getCredentialsFn: func(
@@ -262,7 +313,7 @@ func TestGitDoSingleUpdate(t *testing.T) {
 				) (string, int, error) {
 					return testRef, 0, nil
 				},
-				getAuthorFn: func() (*git.User, error) {
+				getAuthorFn: func(context.Context, string) (*git.User, error) {
 					return nil, nil
 				},
 				getCredentialsFn: func(
@@ -276,7 +327,7 @@ func TestGitDoSingleUpdate(t *testing.T) {
 					context.Context,
 					kargoapi.GitRepoUpdate,
 					kargoapi.FreightReference,
-					string,
+					*kargoapi.Promotion,
 					string,
 					string,
 					git.Repo,
@@ -306,7 +357,7 @@ func TestGitDoSingleUpdate(t *testing.T) {
 				) (string, int, error) {
 					return testRef, 0, nil
 				},
-				getAuthorFn: func() (*git.User, error) {
+				getAuthorFn: func(context.Context, string) (*git.User, error) {
 					return nil, nil
 				},
 				getCredentialsFn: func(
@@ -320,7 +371,7 @@ func TestGitDoSingleUpdate(t *testing.T) {
 					context.Context,
 					kargoapi.GitRepoUpdate,
 					kargoapi.FreightReference,
-					string,
+					*kargoapi.Promotion,
 					string,
 					string,
 					git.Repo,
@@ -328,15 +379,21 @@ func TestGitDoSingleUpdate(t *testing.T) {
 				) (string, error) {
 					return "fake-commit-id", nil
 				},
+				checkpointFn: func(context.Context, *kargoapi.Promotion, map[string]string) {},
 			},
 			assertions: func(
 				t *testing.T,
-				_ *kargoapi.PromotionStatus,
+				status *kargoapi.PromotionStatus,
 				newFreightIn kargoapi.FreightReference,
 				newFreightOut kargoapi.FreightReference,
 				err error,
 			) {
 				require.NoError(t, err)
+				require.Equal(
+					t,
+					"fake-commit-id",
+					getCommitFromMetadata(status.Metadata, "https://github.com/akuity/kargo", "main"),
+				)
 				require.Equal(
 					t,
 					"fake-commit-id",
@@ -357,8 +414,12 @@ func TestGitDoSingleUpdate(t *testing.T) {
 				context.Background(),
 				&kargoapi.Promotion{
 					ObjectMeta: metav1.ObjectMeta{Namespace: "fake-namespace"},
+					Status:     testCase.promoStatus,
+				},
+				kargoapi.GitRepoUpdate{
+					RepoURL:     "https://github.com/akuity/kargo",
+					WriteBranch: "main",
 				},
-				kargoapi.GitRepoUpdate{RepoURL: "https://github.com/akuity/kargo"},
 				newFreightIn,
 			)
 			testCase.assertions(t, status, newFreightIn, newFreightOut, err)
@@ -366,6 +427,157 @@ func TestGitDoSingleUpdate(t *testing.T) {
 	}
 }
 
+func TestGitGetAuthor(t *testing.T) {
+	const testNamespace = "fake-namespace"
+
+	testCases := []struct {
+		name       string
+		cfg        GitConfig
+		project    *kargoapi.Project
+		secret     *corev1.Secret
+		assertions func(*testing.T, *git.User, error)
+	}{
+		{
+			name: "unsupported controller-wide signing key type",
+			cfg:  GitConfig{SigningKeyType: "fake-type"},
+			assertions: func(t *testing.T, _ *git.User, err error) {
+				require.ErrorContains(t, err, "unsupported signing key type")
+			},
+		},
+		{
+			name: "Project without Git config",
+			cfg:  GitConfig{Name: "Kargo", Email: "kargo@example.com"},
+			project: &kargoapi.Project{
+				ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+			},
+			assertions: func(t *testing.T, author *git.User, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					&git.User{
+						Name:           "Kargo",
+						Email:          "kargo@example.com",
+						SigningKeyType: git.SigningKeyTypeGPG,
+					},
+					author,
+				)
+			},
+		},
+		{
+			name: "Project Git config overrides defaults",
+			cfg:  GitConfig{Name: "Kargo", Email: "kargo@example.com"},
+			project: &kargoapi.Project{
+				ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+				Spec: &kargoapi.ProjectSpec{
+					GitConfig: &kargoapi.ProjectGitConfig{
+						Email: "team@example.com",
+					},
+				},
+			},
+			assertions: func(t *testing.T, author *git.User, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "Kargo", author.Name)
+				require.Equal(t, "team@example.com", author.Email)
+				require.Empty(t, author.SigningKey)
+			},
+		},
+		{
+			name: "signing key Secret not found",
+			project: &kargoapi.Project{
+				ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+				Spec: &kargoapi.ProjectSpec{
+					GitConfig: &kargoapi.ProjectGitConfig{
+						SigningKey: &kargoapi.GitSigningKey{SecretName: "fake-secret"},
+					},
+				},
+			},
+			assertions: func(t *testing.T, _ *git.User, err error) {
+				require.ErrorContains(t, err, "error getting signing key Secret")
+			},
+		},
+		{
+			name: "signing key Secret has no signing key",
+			project: &kargoapi.Project{
+				ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+				Spec: &kargoapi.ProjectSpec{
+					GitConfig: &kargoapi.ProjectGitConfig{
+						SigningKey: &kargoapi.GitSigningKey{SecretName: "fake-secret"},
+					},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      "fake-secret",
+				},
+			},
+			assertions: func(t *testing.T, _ *git.User, err error) {
+				require.ErrorContains(t, err, `has no "signingKey" key`)
+			},
+		},
+		{
+			name: "Project SSH signing key",
+			project: &kargoapi.Project{
+				ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+				Spec: &kargoapi.ProjectSpec{
+					GitConfig: &kargoapi.ProjectGitConfig{
+						Name:  "Team",
+						Email: "team@example.com",
+						SigningKey: &kargoapi.GitSigningKey{
+							Type:       kargoapi.GitSigningKeyTypeSSH,
+							SecretName: "fake-secret",
+						},
+					},
+				},
+			},
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      "fake-secret",
+				},
+				Data: map[string][]byte{
+					kargoapi.GitSigningKeySecretKey: []byte("fake-key"),
+				},
+			},
+			assertions: func(t *testing.T, author *git.User, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					&git.User{
+						Name:           "Team",
+						Email:          "team@example.com",
+						SigningKeyType: git.SigningKeyTypeSSH,
+						SigningKey:     "fake-key",
+					},
+					author,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(scheme))
+			require.NoError(t, kargoapi.AddToScheme(scheme))
+			kargoClient := fake.NewClientBuilder().WithScheme(scheme)
+			if testCase.project != nil {
+				kargoClient = kargoClient.WithObjects(testCase.project)
+			}
+			if testCase.secret != nil {
+				kargoClient = kargoClient.WithObjects(testCase.secret)
+			}
+			g := &gitMechanism{
+				cfg:          testCase.cfg,
+				kargoClient:  kargoClient.Build(),
+				getProjectFn: kargoapi.GetProject,
+			}
+			g.getSigningKeyFn = g.getSigningKey
+			author, err := g.getAuthor(context.Background(), testNamespace)
+			testCase.assertions(t, author, err)
+		})
+	}
+}
+
 func TestGetReadRef(t *testing.T) {
 	const testBranch = "fake-branch"
 	testCases := []struct {
@@ -402,6 +614,25 @@ func TestGetReadRef(t *testing.T) {
 				require.ErrorContains(t, err, "because it will form a subscription loop")
 			},
 		},
+		{
+			name: "subscription-loop avoided with pull request fallback",
+			update: kargoapi.GitRepoUpdate{
+				RepoURL:     "fake-url",
+				WriteBranch: testBranch,
+				PullRequest: &kargoapi.PullRequestPromotionMechanism{
+					FallbackOnProtectedBranch: true,
+				},
+			},
+			commits: []kargoapi.GitCommit{
+				{
+					RepoURL: "fake-url",
+					Branch:  testBranch,
+				},
+			},
+			assertions: func(t *testing.T, _ string, _ int, err error) {
+				require.ErrorContains(t, err, "because it will form a subscription loop")
+			},
+		},
 		{
 			name: "success",
 			update: kargoapi.GitRepoUpdate{
@@ -562,6 +793,217 @@ func TestDeleteRepoContents(t *testing.T) {
 	require.Len(t, dirEntries, 1)
 }
 
+func TestGitGetVars(t *testing.T) {
+	testPromo := &kargoapi.Promotion{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-project",
+			Name:      "fake-promotion",
+		},
+		Spec: kargoapi.PromotionSpec{
+			Stage: "fake-stage",
+		},
+	}
+	testCases := []struct {
+		name         string
+		getProjectFn func(context.Context, client.Client, string) (*kargoapi.Project, error)
+		getStageFn   func(context.Context, client.Client, types.NamespacedName) (*kargoapi.Stage, error)
+		assertions   func(*testing.T, map[string]string, error)
+	}{
+		{
+			name: "error getting Project",
+			getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, _ map[string]string, err error) {
+				require.ErrorContains(t, err, "error finding Project")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "error getting Stage",
+			getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+				return nil, nil
+			},
+			getStageFn: func(context.Context, client.Client, types.NamespacedName) (*kargoapi.Stage, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, _ map[string]string, err error) {
+				require.ErrorContains(t, err, "error finding Stage")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "success",
+			getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+				return &kargoapi.Project{
+					Spec: &kargoapi.ProjectSpec{
+						Vars: []kargoapi.ExpressionVariable{
+							{Name: "registry", Value: "registry.example.com"},
+							{Name: "team", Value: "platform"},
+						},
+					},
+				}, nil
+			},
+			getStageFn: func(
+				_ context.Context,
+				_ client.Client,
+				namespacedName types.NamespacedName,
+			) (*kargoapi.Stage, error) {
+				if namespacedName.Namespace != "fake-project" || namespacedName.Name != "fake-stage" {
+					return nil, nil
+				}
+				return &kargoapi.Stage{
+					Spec: kargoapi.StageSpec{
+						Vars: []kargoapi.ExpressionVariable{
+							{Name: "registry", Value: "prod-registry.example.com"},
+						},
+					},
+				}, nil
+			},
+			assertions: func(t *testing.T, vars map[string]string, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					map[string]string{
+						"registry": "prod-registry.example.com",
+						"team":     "platform",
+					},
+					vars,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := &gitMechanism{
+				getProjectFn: testCase.getProjectFn,
+				getStageFn:   testCase.getStageFn,
+			}
+			vars, err := g.getVars(context.Background(), testPromo)
+			testCase.assertions(t, vars, err)
+		})
+	}
+}
+
+func TestGetCommitMessage(t *testing.T) {
+	testFreight := kargoapi.FreightReference{
+		Name: "fake-freight",
+		Images: []kargoapi.Image{{
+			RepoURL: "fake-image",
+			Tag:     "v1.2.3",
+		}},
+	}
+	testPromo := &kargoapi.Promotion{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-project",
+			Name:      "fake-promotion",
+		},
+		Spec: kargoapi.PromotionSpec{
+			Stage: "fake-stage",
+		},
+		Status: kargoapi.PromotionStatus{
+			Metadata: setPullRequestMetadata(nil, "fake-repo", 42, "https://fake-pr"),
+		},
+	}
+	testProject := &kargoapi.Project{
+		Spec: &kargoapi.ProjectSpec{
+			CommitMessageTemplates: []kargoapi.CommitMessageTemplate{
+				{
+					Name: "conventional",
+					Template: "chore(${{ ctx.stage }}): promote ${{ freight.name }}" +
+						"\n\nimage: ${{ freight.images[0].tag }}" +
+						"\nchanges: ${{ len(changes) }}" +
+						"\nteam: ${{ vars.team }}" +
+						"\npr: ${{ pullRequests['fake-repo'] }}" +
+						"\n\n${{ summary }}",
+				},
+				{
+					Name:     "invalid",
+					Template: "${{ freight.nonexistent.field }}",
+				},
+			},
+		},
+	}
+	getProjectFn := func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+		return testProject, nil
+	}
+	testCases := []struct {
+		name         string
+		template     string
+		getProjectFn func(context.Context, client.Client, string) (*kargoapi.Project, error)
+		assertions   func(*testing.T, string, error)
+	}{
+		{
+			name: "no template referenced",
+			assertions: func(t *testing.T, msg string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "fake-change", msg)
+			},
+		},
+		{
+			name:     "error getting Project",
+			template: "conventional",
+			getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, "error finding Project")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name:         "template not found",
+			template:     "nonexistent",
+			getProjectFn: getProjectFn,
+			assertions: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(
+					t,
+					err,
+					`commit message template "nonexistent" not found in Project "fake-project"`,
+				)
+			},
+		},
+		{
+			name:         "error evaluating template",
+			template:     "invalid",
+			getProjectFn: getProjectFn,
+			assertions: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, `error evaluating commit message template "invalid"`)
+			},
+		},
+		{
+			name:         "success",
+			template:     "conventional",
+			getProjectFn: getProjectFn,
+			assertions: func(t *testing.T, msg string, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					"chore(fake-stage): promote fake-freight\n\nimage: v1.2.3"+
+						"\nchanges: 1\nteam: fake-team\npr: https://fake-pr\n\nfake-change",
+					msg,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			g := &gitMechanism{
+				getProjectFn: testCase.getProjectFn,
+			}
+			msg, err := g.getCommitMessage(
+				context.Background(),
+				kargoapi.GitRepoUpdate{CommitMessageTemplate: testCase.template},
+				testFreight,
+				testPromo,
+				map[string]string{"team": "fake-team"},
+				[]string{"fake-change"},
+			)
+			testCase.assertions(t, msg, err)
+		})
+	}
+}
+
 func TestBuildCommitMessage(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -653,3 +1095,111 @@ func createDummyRepoDir(t *testing.T, dirCount, fileCount int) (string, error) {
 	}
 	return dir, nil
 }
+
+func TestCommitMetadata(t *testing.T) {
+	const testRepoURL = "https://github.com/akuity/kargo"
+	require.Empty(t, getCommitFromMetadata(nil, testRepoURL, "main"))
+	metadata := setCommitMetadata(nil, testRepoURL, "main", "fake-commit-id")
+	require.Equal(t, "fake-commit-id", getCommitFromMetadata(metadata, testRepoURL, "main"))
+	// Updates of other branches of the same repository are tracked separately
+	require.Empty(t, getCommitFromMetadata(metadata, testRepoURL, "stage/test"))
+}
+
+func TestCommitToWriteBranch(t *testing.T) {
+	testCases := []struct {
+		name               string
+		pushConflicts      *kargoapi.GitPushConflictHandling
+		failures           int
+		expectedCommits    int
+		expectedClones     int
+		expectPushConflict bool
+	}{
+		{
+			name:            "no conflict",
+			expectedCommits: 1,
+		},
+		{
+			name:               "conflict with default handling",
+			failures:           1,
+			expectedCommits:    1,
+			expectPushConflict: true,
+		},
+		{
+			name: "conflict with Rebase strategy",
+			pushConflicts: &kargoapi.GitPushConflictHandling{
+				Strategy: kargoapi.GitPushConflictStrategyRebase,
+			},
+			failures:           1,
+			expectedCommits:    1,
+			expectPushConflict: true,
+		},
+		{
+			name: "conflict resolved by rerendering",
+			pushConflicts: &kargoapi.GitPushConflictHandling{
+				Strategy: kargoapi.GitPushConflictStrategyRerender,
+			},
+			failures:        2,
+			expectedCommits: 3,
+			expectedClones:  2,
+		},
+		{
+			name: "conflict persists after max attempts",
+			pushConflicts: &kargoapi.GitPushConflictHandling{
+				Strategy:    kargoapi.GitPushConflictStrategyRerender,
+				MaxAttempts: 2,
+			},
+			failures:           5,
+			expectedCommits:    2,
+			expectedClones:     1,
+			expectPushConflict: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var commits, clones int
+			g := &gitMechanism{
+				gitCommitFn: func(
+					context.Context,
+					kargoapi.GitRepoUpdate,
+					kargoapi.FreightReference,
+					*kargoapi.Promotion,
+					string,
+					string,
+					git.Repo,
+					git.RepoCredentials,
+				) (string, error) {
+					commits++
+					if commits <= testCase.failures {
+						return "", fmt.Errorf("something went wrong: %w", git.ErrPushConflict)
+					}
+					return "fake-commit-id", nil
+				},
+			}
+			commitID, err := g.commitToWriteBranch(
+				context.Background(),
+				kargoapi.GitRepoUpdate{
+					RepoURL:       "https://github.com/akuity/kargo",
+					WriteBranch:   "main",
+					PushConflicts: testCase.pushConflicts,
+				},
+				kargoapi.FreightReference{},
+				&kargoapi.Promotion{},
+				"",
+				nil,
+				func() (git.Repo, error) {
+					clones++
+					return nil, nil
+				},
+				git.RepoCredentials{},
+			)
+			require.Equal(t, testCase.expectedCommits, commits)
+			require.Equal(t, testCase.expectedClones, clones)
+			if testCase.expectPushConflict {
+				require.ErrorIs(t, err, git.ErrPushConflict)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "fake-commit-id", commitID)
+		})
+	}
+}
@@ -10,9 +10,13 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/controller/git"
 	"github.com/akuity/kargo/internal/credentials"
+	libExec "github.com/akuity/kargo/internal/exec"
+	"github.com/akuity/kargo/internal/expressions"
 	"github.com/akuity/kargo/internal/helm"
 	libYAML "github.com/akuity/kargo/internal/yaml"
 )
@@ -20,10 +24,13 @@ import (
 // newGenericGitMechanism returns a gitMechanism that only only selects and
 // performs updates that involve Helm.
 func newHelmMechanism(
+	kargoClient client.Client,
 	credentialsDB credentials.Database,
+	limits libExec.Limits,
 ) Mechanism {
 	return newGitMechanism(
 		"Helm promotion mechanism",
+		kargoClient,
 		credentialsDB,
 		selectHelmUpdates,
 		(&helmer{
@@ -32,6 +39,8 @@ func newHelmMechanism(
 			setStringsInYAMLFileFn:         libYAML.SetStringsInFile,
 			prepareDependencyCredentialsFn: prepareDependencyCredentialsFn(credentialsDB),
 			updateChartDependenciesFn:      helm.UpdateChartDependencies,
+			renderChartFn:                  helm.RenderChart,
+			limits:                         limits,
 		}).apply,
 	)
 }
@@ -61,7 +70,14 @@ type helmer struct {
 	) (map[string]map[string]string, []string, error)
 	setStringsInYAMLFileFn         func(file string, changes map[string]string) error
 	prepareDependencyCredentialsFn func(ctx context.Context, homePath, chartPath, namespace string) error
-	updateChartDependenciesFn      func(homeDir, chartPath string) error
+	updateChartDependenciesFn      func(homeDir, chartPath string, limits libExec.Limits) error
+	renderChartFn                  func(
+		homeDir string,
+		chartPath string,
+		opts helm.TemplateOptions,
+		limits libExec.Limits,
+	) ([]byte, error)
+	limits libExec.Limits
 }
 
 // apply uses Helm to carry out the provided update in the specified working
@@ -71,6 +87,7 @@ func (h *helmer) apply(
 	update kargoapi.GitRepoUpdate,
 	newFreight kargoapi.FreightReference,
 	namespace string,
+	vars map[string]string,
 	_ string, // TODO: sourceCommit would be a nice addition to the commit message
 	homeDir string,
 	workingDir string,
@@ -106,12 +123,138 @@ func (h *helmer) apply(
 		if err = h.prepareDependencyCredentialsFn(ctx, homeDir, chartYAMLPath, namespace); err != nil {
 			return nil, fmt.Errorf("preparing credentials for chart dependencies %q: :%w", chart, err)
 		}
-		if err = h.updateChartDependenciesFn(homeDir, chartPath); err != nil {
+		if err = h.updateChartDependenciesFn(homeDir, chartPath, h.limits); err != nil {
 			return nil, fmt.Errorf("updating dependencies for chart %q: %w", chart, err)
 		}
 	}
 
-	return append(imageChangeSummary, subchartChangeSummary...), nil
+	changeSummary := append(imageChangeSummary, subchartChangeSummary...)
+
+	// Rendering
+	if update.Helm.Template != nil {
+		if err = h.renderTemplate(
+			update.Helm.Template,
+			newFreight,
+			namespace,
+			vars,
+			homeDir,
+			workingDir,
+		); err != nil {
+			return nil, fmt.Errorf("rendering chart %q: %w", update.Helm.Template.ChartPath, err)
+		}
+		changeSummary = append(
+			changeSummary,
+			fmt.Sprintf(
+				"rendered chart %q to %q",
+				update.Helm.Template.ChartPath,
+				update.Helm.Template.OutPath,
+			),
+		)
+	}
+
+	return changeSummary, nil
+}
+
+// renderTemplate renders the chart described by the provided HelmTemplate and
+// writes the resulting manifests to the HelmTemplate's OutPath. Values files
+// are merged in the order listed, followed by inline values, followed by set
+// values in the order listed. Expressions in set values are evaluated against
+// the provided Freight and variables.
+func (h *helmer) renderTemplate(
+	tmpl *kargoapi.HelmTemplate,
+	newFreight kargoapi.FreightReference,
+	namespace string,
+	vars map[string]string,
+	homeDir string,
+	workingDir string,
+) error {
+	opts := helm.TemplateOptions{
+		ReleaseName: tmpl.ReleaseName,
+		Namespace:   tmpl.Namespace,
+		ValuesFiles: make([]string, 0, len(tmpl.ValuesFilePaths)+1),
+		SetValues:   make([]string, 0, len(tmpl.SetValues)),
+	}
+	if opts.ReleaseName == "" {
+		opts.ReleaseName = filepath.Base(tmpl.ChartPath)
+	}
+	for _, valuesFile := range tmpl.ValuesFilePaths {
+		opts.ValuesFiles = append(opts.ValuesFiles, filepath.Join(workingDir, valuesFile))
+	}
+	if tmpl.Values != "" {
+		valuesFile, err := writeInlineValues(homeDir, tmpl.Values)
+		if err != nil {
+			return err
+		}
+		opts.ValuesFiles = append(opts.ValuesFiles, valuesFile)
+	}
+	if len(tmpl.SetValues) > 0 {
+		env, err := helmTemplateEnv(newFreight, namespace, vars)
+		if err != nil {
+			return err
+		}
+		for _, setValue := range tmpl.SetValues {
+			value, err := expressions.EvaluateTemplateToString(setValue.Value, env)
+			if err != nil {
+				return fmt.Errorf("error evaluating value of key %q: %w", setValue.Key, err)
+			}
+			// Helm splits --set arguments on unescaped commas
+			opts.SetValues = append(
+				opts.SetValues,
+				setValue.Key+"="+strings.ReplaceAll(value, ",", `\,`),
+			)
+		}
+	}
+
+	manifests, err := h.renderChartFn(homeDir, filepath.Join(workingDir, tmpl.ChartPath), opts, h.limits)
+	if err != nil {
+		return err
+	}
+	outPath := filepath.Join(workingDir, tmpl.OutPath)
+	if err = os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+		return fmt.Errorf("error creating directory for %q: %w", tmpl.OutPath, err)
+	}
+	if err = os.WriteFile(outPath, manifests, 0644); err != nil { // nolint: gosec
+		return fmt.Errorf("error writing rendered manifests to %q: %w", tmpl.OutPath, err)
+	}
+	return nil
+}
+
+// writeInlineValues validates the provided YAML document and writes it to a
+// temporary values file in the provided directory, returning the file's path.
+func writeInlineValues(dir, values string) (string, error) {
+	var parsed map[string]any
+	if err := yaml.Unmarshal([]byte(values), &parsed); err != nil {
+		return "", fmt.Errorf("error parsing inline values: %w", err)
+	}
+	file, err := os.CreateTemp(dir, "values-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("error creating inline values file: %w", err)
+	}
+	defer file.Close()
+	if _, err = file.WriteString(values); err != nil {
+		return "", fmt.Errorf("error writing inline values file: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// helmTemplateEnv builds the environment against which expressions in the set
+// values of a HelmTemplate are evaluated.
+func helmTemplateEnv(
+	newFreight kargoapi.FreightReference,
+	namespace string,
+	vars map[string]string,
+) (map[string]any, error) {
+	freight, err := freightExprValue(newFreight)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"ctx": map[string]any{
+			"project": namespace,
+		},
+		"freight": freight,
+		"vars":    varsExprValue(vars),
+	}, nil
 }
 
 // buildValuesFilesChanges takes a list of images and a list of instructions
@@ -135,7 +278,9 @@ func buildValuesFilesChanges(
 		case kargoapi.ImageUpdateValueTypeImageAndTag,
 			kargoapi.ImageUpdateValueTypeTag,
 			kargoapi.ImageUpdateValueTypeImageAndDigest,
-			kargoapi.ImageUpdateValueTypeDigest:
+			kargoapi.ImageUpdateValueTypeDigest,
+			kargoapi.ImageUpdateValueTypeImageAndTagAndDigest,
+			kargoapi.ImageUpdateValueTypeTagAndDigest:
 		default:
 			// This really shouldn't happen, so we'll ignore it.
 			continue
@@ -166,6 +311,14 @@ func buildValuesFilesChanges(
 		case kargoapi.ImageUpdateValueTypeDigest:
 			changesByFile[imageUpdate.ValuesFilePath][imageUpdate.Key] = digest
 			fqImageRef = fmt.Sprintf("%s@%s", imageUpdate.Image, digest)
+		case kargoapi.ImageUpdateValueTypeImageAndTagAndDigest:
+			changesByFile[imageUpdate.ValuesFilePath][imageUpdate.Key] =
+				fmt.Sprintf("%s:%s@%s", imageUpdate.Image, tag, digest)
+			fqImageRef = fmt.Sprintf("%s:%s@%s", imageUpdate.Image, tag, digest)
+		case kargoapi.ImageUpdateValueTypeTagAndDigest:
+			changesByFile[imageUpdate.ValuesFilePath][imageUpdate.Key] =
+				fmt.Sprintf("%s@%s", tag, digest)
+			fqImageRef = fmt.Sprintf("%s:%s@%s", imageUpdate.Image, tag, digest)
 		}
 		changeSummary = append(
 			changeSummary,
@@ -13,10 +13,12 @@ import (
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/controller/git"
 	"github.com/akuity/kargo/internal/credentials"
+	libExec "github.com/akuity/kargo/internal/exec"
+	"github.com/akuity/kargo/internal/helm"
 )
 
 func TestNewHelmMechanism(t *testing.T) {
-	pm := newHelmMechanism(&credentials.FakeDB{})
+	pm := newHelmMechanism(nil, &credentials.FakeDB{}, libExec.Limits{})
 	hpm, ok := pm.(*gitMechanism)
 	require.True(t, ok)
 	require.NotNil(t, hpm.selectUpdatesFn)
@@ -221,7 +223,7 @@ func TestHelmerApply(t *testing.T) {
 				setStringsInYAMLFileFn: func(string, map[string]string) error {
 					return nil
 				},
-				updateChartDependenciesFn: func(string, string) error {
+				updateChartDependenciesFn: func(string, string, libExec.Limits) error {
 					return errors.New("something went wrong")
 				},
 			},
@@ -260,7 +262,7 @@ func TestHelmerApply(t *testing.T) {
 				prepareDependencyCredentialsFn: func(context.Context, string, string, string) error {
 					return nil
 				},
-				updateChartDependenciesFn: func(string, string) error {
+				updateChartDependenciesFn: func(string, string, libExec.Limits) error {
 					return nil
 				},
 			},
@@ -279,6 +281,7 @@ func TestHelmerApply(t *testing.T) {
 				},
 				kargoapi.FreightReference{}, // The way the tests are structured, this value doesn't matter
 				"",
+				nil,
 				"",
 				"",
 				"",
@@ -289,6 +292,134 @@ func TestHelmerApply(t *testing.T) {
 	}
 }
 
+func TestHelmerRenderTemplate(t *testing.T) {
+	testFreight := kargoapi.FreightReference{
+		Images: []kargoapi.Image{{
+			RepoURL: "fake-url",
+			Tag:     "fake-tag",
+		}},
+	}
+	testCases := []struct {
+		name       string
+		tmpl       *kargoapi.HelmTemplate
+		renderFn   func(string, string, helm.TemplateOptions, libExec.Limits) ([]byte, error)
+		assertions func(t *testing.T, homeDir, workDir string, err error)
+	}{
+		{
+			name: "error parsing inline values",
+			tmpl: &kargoapi.HelmTemplate{
+				ChartPath: "charts/app",
+				Values:    "not: [valid",
+				OutPath:   "out/manifests.yaml",
+			},
+			assertions: func(t *testing.T, _, _ string, err error) {
+				require.ErrorContains(t, err, "error parsing inline values")
+			},
+		},
+		{
+			name: "error evaluating set value",
+			tmpl: &kargoapi.HelmTemplate{
+				ChartPath: "charts/app",
+				SetValues: []kargoapi.HelmSetValue{{
+					Key:   "image.tag",
+					Value: "${{ freight.images[ }}",
+				}},
+				OutPath: "out/manifests.yaml",
+			},
+			assertions: func(t *testing.T, _, _ string, err error) {
+				require.ErrorContains(t, err, `error evaluating value of key "image.tag"`)
+			},
+		},
+		{
+			name: "error rendering chart",
+			tmpl: &kargoapi.HelmTemplate{
+				ChartPath: "charts/app",
+				OutPath:   "out/manifests.yaml",
+			},
+			renderFn: func(string, string, helm.TemplateOptions, libExec.Limits) ([]byte, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, _, _ string, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "success",
+			tmpl: &kargoapi.HelmTemplate{
+				ChartPath:       "charts/app",
+				Namespace:       "fake-namespace",
+				ValuesFilePaths: []string{"values.yaml", "envs/prod.yaml"},
+				Values:          "replicas: 3\n",
+				SetValues: []kargoapi.HelmSetValue{
+					{
+						Key:   "image.tag",
+						Value: "${{ freight.images[0].tag }}",
+					},
+					{
+						Key:   "annotations.project",
+						Value: "${{ ctx.project }},extra",
+					},
+					{
+						Key:   "registry",
+						Value: "${{ vars.registry }}",
+					},
+				},
+				OutPath: "out/manifests.yaml",
+			},
+			renderFn: func(homeDir, chartPath string, opts helm.TemplateOptions, _ libExec.Limits) ([]byte, error) {
+				workDir := filepath.Dir(filepath.Dir(chartPath))
+				if chartPath != filepath.Join(workDir, "charts", "app") {
+					return nil, fmt.Errorf("unexpected chart path %q", chartPath)
+				}
+				if opts.ReleaseName != "app" || opts.Namespace != "fake-namespace" {
+					return nil, fmt.Errorf("unexpected options %+v", opts)
+				}
+				if len(opts.ValuesFiles) != 3 ||
+					opts.ValuesFiles[0] != filepath.Join(workDir, "values.yaml") ||
+					opts.ValuesFiles[1] != filepath.Join(workDir, "envs", "prod.yaml") ||
+					filepath.Dir(opts.ValuesFiles[2]) != homeDir {
+					return nil, fmt.Errorf("unexpected values files %v", opts.ValuesFiles)
+				}
+				inline, err := os.ReadFile(opts.ValuesFiles[2])
+				if err != nil {
+					return nil, err
+				}
+				if string(inline) != "replicas: 3\n" {
+					return nil, fmt.Errorf("unexpected inline values %q", inline)
+				}
+				if len(opts.SetValues) != 3 ||
+					opts.SetValues[0] != "image.tag=fake-tag" ||
+					opts.SetValues[1] != `annotations.project=fake-project\,extra` ||
+					opts.SetValues[2] != "registry=fake-registry" {
+					return nil, fmt.Errorf("unexpected set values %v", opts.SetValues)
+				}
+				return []byte("kind: Deployment\n"), nil
+			},
+			assertions: func(t *testing.T, _, workDir string, err error) {
+				require.NoError(t, err)
+				manifests, err := os.ReadFile(filepath.Join(workDir, "out", "manifests.yaml"))
+				require.NoError(t, err)
+				require.Equal(t, "kind: Deployment\n", string(manifests))
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			homeDir := t.TempDir()
+			workDir := t.TempDir()
+			err := (&helmer{renderChartFn: testCase.renderFn}).renderTemplate(
+				testCase.tmpl,
+				testFreight,
+				"fake-project",
+				map[string]string{"registry": "fake-registry"},
+				homeDir,
+				workDir,
+			)
+			testCase.assertions(t, homeDir, workDir, err)
+		})
+	}
+}
+
 func TestBuildValuesFilesChanges(t *testing.T) {
 	images := []kargoapi.Image{
 		{
@@ -311,6 +442,16 @@ func TestBuildValuesFilesChanges(t *testing.T) {
 			Tag:     "fourth-fake-tag",
 			Digest:  "fourth-fake-digest",
 		},
+		{
+			RepoURL: "fifth-fake-url",
+			Tag:     "fifth-fake-tag",
+			Digest:  "fifth-fake-digest",
+		},
+		{
+			RepoURL: "sixth-fake-url",
+			Tag:     "sixth-fake-tag",
+			Digest:  "sixth-fake-digest",
+		},
 	}
 	imageUpdates := []kargoapi.HelmImageUpdate{
 		{
@@ -337,6 +478,18 @@ func TestBuildValuesFilesChanges(t *testing.T) {
 			Key:            "fourth-fake-key",
 			Value:          kargoapi.ImageUpdateValueTypeDigest,
 		},
+		{
+			ValuesFilePath: "pinned-fake-values.yaml",
+			Image:          "fifth-fake-url",
+			Key:            "fifth-fake-key",
+			Value:          kargoapi.ImageUpdateValueTypeImageAndTagAndDigest,
+		},
+		{
+			ValuesFilePath: "pinned-fake-values.yaml",
+			Image:          "sixth-fake-url",
+			Key:            "sixth-fake-key",
+			Value:          kargoapi.ImageUpdateValueTypeTagAndDigest,
+		},
 		{
 			ValuesFilePath: "yet-another-fake-values.yaml",
 			Image:          "image-that-is-not-in-list",
@@ -356,6 +509,10 @@ func TestBuildValuesFilesChanges(t *testing.T) {
 				"third-fake-key":  "third-fake-url@third-fake-digest",
 				"fourth-fake-key": "fourth-fake-digest",
 			},
+			"pinned-fake-values.yaml": {
+				"fifth-fake-key": "fifth-fake-url:fifth-fake-tag@fifth-fake-digest",
+				"sixth-fake-key": "sixth-fake-tag@sixth-fake-digest",
+			},
 		},
 		result,
 	)
@@ -366,6 +523,10 @@ func TestBuildValuesFilesChanges(t *testing.T) {
 			"updated fake-values.yaml to use image second-fake-url:second-fake-tag",
 			"updated another-fake-values.yaml to use image third-fake-url@third-fake-digest",
 			"updated another-fake-values.yaml to use image fourth-fake-url@fourth-fake-digest",
+			"updated pinned-fake-values.yaml to use image " +
+				"fifth-fake-url:fifth-fake-tag@fifth-fake-digest",
+			"updated pinned-fake-values.yaml to use image " +
+				"sixth-fake-url:sixth-fake-tag@sixth-fake-digest",
 		},
 		changeSummary,
 	)
@@ -0,0 +1,277 @@
+package promotion
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/expressions"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// defaultHTTPPromotionHookTimeout is the maximum amount of time to wait for a
+// response to the request sent by an HTTPPromotionHook that does not specify
+// a timeout.
+const defaultHTTPPromotionHookTimeout = 10 * time.Second
+
+// HookRunner executes the PromotionHooks of Stages.
+type HookRunner interface {
+	// RunHooks executes the provided PromotionHooks of the provided Stage, in
+	// order, as part of the provided Promotion of the provided Freight. It
+	// stops at the first hook that fails, unless that hook's ContinueOnFailure
+	// field is true and continueOnFailure is also true. The outcome of every
+	// executed hook is returned. An error is only returned if the hooks could
+	// not be executed at all.
+	RunHooks(
+		ctx context.Context,
+		stage *kargoapi.Stage,
+		promo *kargoapi.Promotion,
+		newFreight kargoapi.FreightReference,
+		hooks []kargoapi.PromotionHook,
+		continueOnFailure bool,
+	) ([]kargoapi.PromotionHookStatus, error)
+}
+
+type hookRunner struct {
+	kargoClient client.Client
+
+	// The following behaviors are overridable for testing purposes:
+
+	getProjectFn func(context.Context, client.Client, string) (*kargoapi.Project, error)
+
+	sendHTTPRequestFn func(
+		context.Context,
+		kargoapi.HTTPPromotionHook,
+		map[string]any,
+	) error
+
+	getSSHCredentialsFn func(
+		ctx context.Context,
+		namespace string,
+		secretName string,
+	) (*sshCredentials, error)
+
+	runSSHCommandFn func(
+		context.Context,
+		kargoapi.SSHPromotionHook,
+		sshCredentials,
+		map[string]any,
+	) (string, error)
+}
+
+// NewHookRunner returns an implementation of the HookRunner interface.
+func NewHookRunner(kargoClient client.Client) HookRunner {
+	h := &hookRunner{
+		kargoClient:  kargoClient,
+		getProjectFn: kargoapi.GetProject,
+	}
+	h.sendHTTPRequestFn = sendHTTPRequest
+	h.getSSHCredentialsFn = h.getSSHCredentials
+	h.runSSHCommandFn = runSSHCommand
+	return h
+}
+
+// RunHooks implements the HookRunner interface.
+func (h *hookRunner) RunHooks(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	promo *kargoapi.Promotion,
+	newFreight kargoapi.FreightReference,
+	hooks []kargoapi.PromotionHook,
+	continueOnFailure bool,
+) ([]kargoapi.PromotionHookStatus, error) {
+	if len(hooks) == 0 {
+		return nil, nil
+	}
+	logger := logging.LoggerFromContext(ctx)
+
+	project, err := h.getProjectFn(ctx, h.kargoClient, promo.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error finding Project %q: %w", promo.Namespace, err)
+	}
+	if project == nil {
+		return nil, fmt.Errorf("Project %q not found", promo.Namespace)
+	}
+	env, err := hookEnv(newFreight, promo, kargoapi.ResolveVars(project, stage))
+	if err != nil {
+		return nil, fmt.Errorf("error building environment for expressions: %w", err)
+	}
+
+	statuses := make([]kargoapi.PromotionHookStatus, 0, len(hooks))
+	for _, hook := range hooks {
+		status := h.runHook(ctx, project, hook, env)
+		statuses = append(statuses, status)
+		if status.Succeeded {
+			logger.WithField("hook", status.Name).Debug("promotion hook succeeded")
+			continue
+		}
+		logger.WithField("hook", status.Name).
+			Infof("promotion hook failed: %s", status.Message)
+		if !continueOnFailure || !hook.ContinueOnFailure {
+			break
+		}
+	}
+	return statuses, nil
+}
+
+// runHook executes a single PromotionHook and returns its outcome.
+func (h *hookRunner) runHook(
+	ctx context.Context,
+	project *kargoapi.Project,
+	hook kargoapi.PromotionHook,
+	env map[string]any,
+) kargoapi.PromotionHookStatus {
+	httpHook := hook.HTTP
+	var status kargoapi.PromotionHookStatus
+	if hook.ProjectHook != "" {
+		status.Name = hook.ProjectHook
+		projectHook := project.GetPromotionHook(hook.ProjectHook)
+		if projectHook == nil {
+			status.Message = fmt.Sprintf(
+				"Project %q has no promotion hook named %q",
+				project.Name,
+				hook.ProjectHook,
+			)
+			return status
+		}
+		httpHook = &projectHook.HTTP
+	} else if hook.SSH != nil {
+		return h.runSSHHook(ctx, project, *hook.SSH, env)
+	}
+	if httpHook == nil {
+		status.Message = "hook specifies no action"
+		return status
+	}
+	if status.Name == "" {
+		status.Name = httpHook.URL
+	}
+	if err := h.sendHTTPRequestFn(ctx, *httpHook, env); err != nil {
+		status.Message = err.Error()
+		return status
+	}
+	status.Succeeded = true
+	return status
+}
+
+// runSSHHook executes the command described by a single SSHPromotionHook and
+// returns its outcome.
+func (h *hookRunner) runSSHHook(
+	ctx context.Context,
+	project *kargoapi.Project,
+	hook kargoapi.SSHPromotionHook,
+	env map[string]any,
+) kargoapi.PromotionHookStatus {
+	status := kargoapi.PromotionHookStatus{Name: hook.Host}
+	creds, err := h.getSSHCredentialsFn(ctx, project.Name, hook.SecretName)
+	if err != nil {
+		status.Message = err.Error()
+		return status
+	}
+	if status.Output, err = h.runSSHCommandFn(ctx, hook, *creds, env); err != nil {
+		status.Message = err.Error()
+		return status
+	}
+	status.Succeeded = true
+	return status
+}
+
+// sendHTTPRequest sends the request described by the provided
+// HTTPPromotionHook, evaluating any expressions it contains against the
+// provided environment. An error is returned if the request could not be sent
+// or was responded to with an unexpected status code.
+func sendHTTPRequest(
+	ctx context.Context,
+	hook kargoapi.HTTPPromotionHook,
+	env map[string]any,
+) error {
+	url, err := expressions.EvaluateTemplateToString(hook.URL, env)
+	if err != nil {
+		return fmt.Errorf("error evaluating URL %q: %w", hook.URL, err)
+	}
+	body, err := expressions.EvaluateTemplateToString(hook.Body, env)
+	if err != nil {
+		return fmt.Errorf("error evaluating body of request to %q: %w", url, err)
+	}
+
+	method := hook.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	timeout := defaultHTTPPromotionHookTimeout
+	if hook.Timeout != nil {
+		timeout = hook.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating request to %q: %w", url, err)
+	}
+	for _, header := range hook.Headers {
+		value, err := expressions.EvaluateTemplateToString(header.Value, env)
+		if err != nil {
+			return fmt.Errorf("error evaluating value of header %q: %w", header.Name, err)
+		}
+		req.Header.Add(header.Name, value)
+	}
+
+	httpTransport := cleanhttp.DefaultTransport()
+	if hook.InsecureSkipTLSVerify {
+		httpTransport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true, // nolint: gosec
+		}
+	}
+	httpClient := &http.Client{Transport: httpTransport}
+	defer httpClient.CloseIdleConnections()
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending %s request to %q: %w", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	expected := resp.StatusCode >= 200 && resp.StatusCode < 300
+	if len(hook.ExpectedStatusCodes) > 0 {
+		expected = slices.Contains(hook.ExpectedStatusCodes, int32(resp.StatusCode))
+	}
+	if !expected {
+		return fmt.Errorf(
+			"%s request to %q was responded to with unexpected status code %d",
+			method,
+			url,
+			resp.StatusCode,
+		)
+	}
+	return nil
+}
+
+// hookEnv returns the environment against which the expressions of
+// PromotionHooks are evaluated.
+func hookEnv(
+	newFreight kargoapi.FreightReference,
+	promo *kargoapi.Promotion,
+	vars map[string]string,
+) (map[string]any, error) {
+	freight, err := freightExprValue(newFreight)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"ctx": map[string]any{
+			"project":   promo.Namespace,
+			"stage":     promo.Spec.Stage,
+			"promotion": promo.Name,
+		},
+		"freight": freight,
+		"vars":    varsExprValue(vars),
+	}, nil
+}
+
@@ -0,0 +1,373 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestRunHooks(t *testing.T) {
+	testProject := &kargoapi.Project{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake-project"},
+		Spec: &kargoapi.ProjectSpec{
+			PromotionHooks: []kargoapi.ProjectPromotionHook{{
+				Name: "warm-caches",
+				HTTP: kargoapi.HTTPPromotionHook{URL: "https://example.com/warm"},
+			}},
+		},
+	}
+	testCases := []struct {
+		name              string
+		hooks             []kargoapi.PromotionHook
+		continueOnFailure bool
+		runner            *hookRunner
+		assertions        func(*testing.T, []kargoapi.PromotionHookStatus, error)
+	}{
+		{
+			name:  "error getting project",
+			hooks: []kargoapi.PromotionHook{{ProjectHook: "warm-caches"}},
+			runner: &hookRunner{
+				getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, _ []kargoapi.PromotionHookStatus, err error) {
+				require.ErrorContains(t, err, "error finding Project")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "all hooks succeed",
+			hooks: []kargoapi.PromotionHook{
+				{HTTP: &kargoapi.HTTPPromotionHook{URL: "https://example.com/scale-down"}},
+				{ProjectHook: "warm-caches"},
+			},
+			runner: &hookRunner{
+				getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+					return testProject, nil
+				},
+				sendHTTPRequestFn: func(
+					_ context.Context,
+					_ kargoapi.HTTPPromotionHook,
+					env map[string]any,
+				) error {
+					if env["vars"].(map[string]any)["foo"] != "bar" { // nolint: forcetypeassert
+						return errors.New("vars not in environment")
+					}
+					return nil
+				},
+			},
+			assertions: func(t *testing.T, statuses []kargoapi.PromotionHookStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]kargoapi.PromotionHookStatus{
+						{Name: "https://example.com/scale-down", Succeeded: true},
+						{Name: "warm-caches", Succeeded: true},
+					},
+					statuses,
+				)
+			},
+		},
+		{
+			name: "unknown project hook",
+			hooks: []kargoapi.PromotionHook{
+				{ProjectHook: "nonexistent"},
+				{ProjectHook: "warm-caches"},
+			},
+			runner: &hookRunner{
+				getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+					return testProject, nil
+				},
+			},
+			assertions: func(t *testing.T, statuses []kargoapi.PromotionHookStatus, err error) {
+				require.NoError(t, err)
+				require.Len(t, statuses, 1)
+				require.False(t, statuses[0].Succeeded)
+				require.Contains(t, statuses[0].Message, "has no promotion hook named")
+			},
+		},
+		{
+			name: "failure stops execution",
+			hooks: []kargoapi.PromotionHook{
+				{
+					HTTP:              &kargoapi.HTTPPromotionHook{URL: "https://example.com/a"},
+					ContinueOnFailure: true,
+				},
+				{HTTP: &kargoapi.HTTPPromotionHook{URL: "https://example.com/b"}},
+			},
+			runner: &hookRunner{
+				getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+					return testProject, nil
+				},
+				sendHTTPRequestFn: func(context.Context, kargoapi.HTTPPromotionHook, map[string]any) error {
+					return errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, statuses []kargoapi.PromotionHookStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]kargoapi.PromotionHookStatus{{
+						Name:    "https://example.com/a",
+						Message: "something went wrong",
+					}},
+					statuses,
+				)
+			},
+		},
+		{
+			name: "failure of hook that allows continuing",
+			hooks: []kargoapi.PromotionHook{
+				{
+					HTTP:              &kargoapi.HTTPPromotionHook{URL: "https://example.com/a"},
+					ContinueOnFailure: true,
+				},
+				{HTTP: &kargoapi.HTTPPromotionHook{URL: "https://example.com/b"}},
+			},
+			continueOnFailure: true,
+			runner: &hookRunner{
+				getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+					return testProject, nil
+				},
+				sendHTTPRequestFn: func(
+					_ context.Context,
+					hook kargoapi.HTTPPromotionHook,
+					_ map[string]any,
+				) error {
+					if hook.URL == "https://example.com/a" {
+						return errors.New("something went wrong")
+					}
+					return nil
+				},
+			},
+			assertions: func(t *testing.T, statuses []kargoapi.PromotionHookStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]kargoapi.PromotionHookStatus{
+						{Name: "https://example.com/a", Message: "something went wrong"},
+						{Name: "https://example.com/b", Succeeded: true},
+					},
+					statuses,
+				)
+			},
+		},
+		{
+			name: "error getting SSH credentials",
+			hooks: []kargoapi.PromotionHook{{
+				SSH: &kargoapi.SSHPromotionHook{
+					Host:       "vm.example.com",
+					SecretName: "vm-creds",
+					Command:    "systemctl restart app",
+				},
+			}},
+			runner: &hookRunner{
+				getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+					return testProject, nil
+				},
+				getSSHCredentialsFn: func(context.Context, string, string) (*sshCredentials, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, statuses []kargoapi.PromotionHookStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]kargoapi.PromotionHookStatus{{
+						Name:    "vm.example.com",
+						Message: "something went wrong",
+					}},
+					statuses,
+				)
+			},
+		},
+		{
+			name: "SSH command fails",
+			hooks: []kargoapi.PromotionHook{{
+				SSH: &kargoapi.SSHPromotionHook{
+					Host:       "vm.example.com",
+					SecretName: "vm-creds",
+					Command:    "systemctl restart app",
+				},
+			}},
+			runner: &hookRunner{
+				getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+					return testProject, nil
+				},
+				getSSHCredentialsFn: func(context.Context, string, string) (*sshCredentials, error) {
+					return &sshCredentials{}, nil
+				},
+				runSSHCommandFn: func(
+					context.Context,
+					kargoapi.SSHPromotionHook,
+					sshCredentials,
+					map[string]any,
+				) (string, error) {
+					return "unit not found", errors.New("command exited with status 5")
+				},
+			},
+			assertions: func(t *testing.T, statuses []kargoapi.PromotionHookStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]kargoapi.PromotionHookStatus{{
+						Name:    "vm.example.com",
+						Message: "command exited with status 5",
+						Output:  "unit not found",
+					}},
+					statuses,
+				)
+			},
+		},
+		{
+			name: "SSH command succeeds",
+			hooks: []kargoapi.PromotionHook{{
+				SSH: &kargoapi.SSHPromotionHook{
+					Host:       "vm.example.com",
+					SecretName: "vm-creds",
+					Command:    "systemctl restart app",
+				},
+			}},
+			runner: &hookRunner{
+				getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+					return testProject, nil
+				},
+				getSSHCredentialsFn: func(
+					_ context.Context,
+					namespace string,
+					secretName string,
+				) (*sshCredentials, error) {
+					if namespace != "fake-project" || secretName != "vm-creds" {
+						return nil, errors.New("unexpected Secret")
+					}
+					return &sshCredentials{username: "deployer"}, nil
+				},
+				runSSHCommandFn: func(
+					_ context.Context,
+					_ kargoapi.SSHPromotionHook,
+					creds sshCredentials,
+					_ map[string]any,
+				) (string, error) {
+					return "restarted as " + creds.username, nil
+				},
+			},
+			assertions: func(t *testing.T, statuses []kargoapi.PromotionHookStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]kargoapi.PromotionHookStatus{{
+						Name:      "vm.example.com",
+						Succeeded: true,
+						Output:    "restarted as deployer",
+					}},
+					statuses,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			statuses, err := testCase.runner.RunHooks(
+				context.Background(),
+				&kargoapi.Stage{
+					Spec: kargoapi.StageSpec{
+						Vars: []kargoapi.ExpressionVariable{{Name: "foo", Value: "bar"}},
+					},
+				},
+				&kargoapi.Promotion{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "fake-project",
+						Name:      "fake-promotion",
+					},
+				},
+				kargoapi.FreightReference{},
+				testCase.hooks,
+				testCase.continueOnFailure,
+			)
+			testCase.assertions(t, statuses, err)
+		})
+	}
+}
+
+func TestSendHTTPRequest(t *testing.T) {
+	env := map[string]any{
+		"ctx":  map[string]any{"stage": "test"},
+		"vars": map[string]any{"token": "fake-token"},
+	}
+	testCases := []struct {
+		name       string
+		hook       kargoapi.HTTPPromotionHook
+		handler    http.HandlerFunc
+		assertions func(*testing.T, error)
+	}{
+		{
+			name: "success",
+			hook: kargoapi.HTTPPromotionHook{
+				URL:     "/stages/${{ ctx.stage }}",
+				Headers: []kargoapi.HTTPHeader{{Name: "Authorization", Value: "Bearer ${{ vars.token }}"}},
+				Body:    `{"stage":"${{ ctx.stage }}"}`,
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if r.Method != http.MethodPost ||
+					r.URL.Path != "/stages/test" ||
+					r.Header.Get("Authorization") != "Bearer fake-token" ||
+					string(body) != `{"stage":"test"}` {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "unexpected status code",
+			hook: kargoapi.HTTPPromotionHook{URL: "/"},
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "unexpected status code 500")
+			},
+		},
+		{
+			name: "expected status code",
+			hook: kargoapi.HTTPPromotionHook{
+				URL:                 "/",
+				Method:              http.MethodDelete,
+				ExpectedStatusCodes: []int32{http.StatusNotFound},
+			},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			srv := httptest.NewServer(testCase.handler)
+			t.Cleanup(srv.Close)
+			hook := testCase.hook
+			hook.URL = srv.URL + hook.URL
+			testCase.assertions(t, sendHTTPRequest(context.Background(), hook, env))
+		})
+	}
+}
@@ -4,24 +4,36 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/controller/git"
 	"github.com/akuity/kargo/internal/credentials"
+	libExec "github.com/akuity/kargo/internal/exec"
 	"github.com/akuity/kargo/internal/kustomize"
 )
 
 // newKustomizeMechanism returns a gitMechanism that only only selects and
 // performs updates that involve Kustomize.
 func newKustomizeMechanism(
+	kargoClient client.Client,
 	credentialsDB credentials.Database,
+	limits libExec.Limits,
 ) Mechanism {
 	return newGitMechanism(
 		"Kustomize promotion mechanism",
+		kargoClient,
 		credentialsDB,
 		selectKustomizeUpdates,
 		(&kustomizer{
-			setImageFn: kustomize.SetImage,
+			setImageFn:        kustomize.SetImage,
+			addResourcesFn:    kustomize.AddResources,
+			removeResourcesFn: kustomize.RemoveResources,
+			addPatchFn:        kustomize.AddPatch,
+			removePatchFn:     kustomize.RemovePatch,
+			limits:            limits,
 		}).apply,
 	)
 }
@@ -41,7 +53,12 @@ func selectKustomizeUpdates(updates []kargoapi.GitRepoUpdate) []kargoapi.GitRepo
 // kustomizer is a helper struct whose sole purpose is to close over several
 // other functions that are used in the implementation of the apply() function.
 type kustomizer struct {
-	setImageFn func(dir, fqImageRef string) error
+	setImageFn        func(dir, fqImageRef string, limits libExec.Limits) error
+	addResourcesFn    func(dir string, resources []string, limits libExec.Limits) error
+	removeResourcesFn func(dir string, resources []string, limits libExec.Limits) error
+	addPatchFn        func(dir string, patch kustomize.Patch, limits libExec.Limits) error
+	removePatchFn     func(dir string, patch kustomize.Patch, limits libExec.Limits) error
+	limits            libExec.Limits
 }
 
 // apply uses Kustomize to carry out the provided update in the specified
@@ -51,12 +68,21 @@ func (k *kustomizer) apply(
 	update kargoapi.GitRepoUpdate,
 	newFreight kargoapi.FreightReference,
 	_ string,
+	_ map[string]string,
 	_ string, // TODO: sourceCommit would be a nice addition to the commit message
 	_ string,
 	workingDir string,
 	_ git.RepoCredentials,
 ) ([]string, error) {
-	changeSummary := make([]string, 0, len(update.Kustomize.Images))
+	changeSummary, err := k.applyResources(update.Kustomize.Resources, workingDir)
+	if err != nil {
+		return nil, err
+	}
+	patchChanges, err := k.applyPatches(update.Kustomize.Patches, workingDir)
+	if err != nil {
+		return nil, err
+	}
+	changeSummary = append(changeSummary, patchChanges...)
 	for _, imgUpdate := range update.Kustomize.Images {
 		var fqImageRef string // Fully-qualified image reference
 		for _, img := range newFreight.Images {
@@ -74,7 +100,7 @@ func (k *kustomizer) apply(
 			continue
 		}
 		dir := filepath.Join(workingDir, imgUpdate.Path)
-		if err := k.setImageFn(dir, fqImageRef); err != nil {
+		if err := k.setImageFn(dir, fqImageRef, k.limits); err != nil {
 			return nil, fmt.Errorf(
 				"error updating image %q to %q using Kustomize: %w",
 				imgUpdate.Image,
@@ -93,3 +119,114 @@ func (k *kustomizer) apply(
 	}
 	return changeSummary, nil
 }
+
+// applyResources uses Kustomize to add resources to and remove resources from
+// kustomizations in the specified working directory.
+func (k *kustomizer) applyResources(
+	updates []kargoapi.KustomizeResourcesUpdate,
+	workingDir string,
+) ([]string, error) {
+	var changeSummary []string
+	for _, resUpdate := range updates {
+		dir := filepath.Join(workingDir, resUpdate.Path)
+		if len(resUpdate.Add) > 0 {
+			if err := k.addResourcesFn(dir, resUpdate.Add, k.limits); err != nil {
+				return nil, fmt.Errorf(
+					"error adding resources to %s/kustomization.yaml using Kustomize: %w",
+					resUpdate.Path,
+					err,
+				)
+			}
+			changeSummary = append(
+				changeSummary,
+				fmt.Sprintf(
+					"added resources %s to %s/kustomization.yaml",
+					strings.Join(resUpdate.Add, ", "),
+					resUpdate.Path,
+				),
+			)
+		}
+		if len(resUpdate.Remove) > 0 {
+			if err := k.removeResourcesFn(dir, resUpdate.Remove, k.limits); err != nil {
+				return nil, fmt.Errorf(
+					"error removing resources from %s/kustomization.yaml using Kustomize: %w",
+					resUpdate.Path,
+					err,
+				)
+			}
+			changeSummary = append(
+				changeSummary,
+				fmt.Sprintf(
+					"removed resources %s from %s/kustomization.yaml",
+					strings.Join(resUpdate.Remove, ", "),
+					resUpdate.Path,
+				),
+			)
+		}
+	}
+	return changeSummary, nil
+}
+
+// applyPatches uses Kustomize to add patches to and remove patches from
+// kustomizations in the specified working directory.
+func (k *kustomizer) applyPatches(
+	updates []kargoapi.KustomizePatchesUpdate,
+	workingDir string,
+) ([]string, error) {
+	var changeSummary []string
+	for _, patchUpdate := range updates {
+		dir := filepath.Join(workingDir, patchUpdate.Path)
+		for _, patch := range patchUpdate.Add {
+			if err := k.addPatchFn(dir, toKustomizePatch(patch), k.limits); err != nil {
+				return nil, fmt.Errorf(
+					"error adding patch %q to %s/kustomization.yaml using Kustomize: %w",
+					patch.Path,
+					patchUpdate.Path,
+					err,
+				)
+			}
+			changeSummary = append(
+				changeSummary,
+				fmt.Sprintf(
+					"added patch %s to %s/kustomization.yaml",
+					patch.Path,
+					patchUpdate.Path,
+				),
+			)
+		}
+		for _, patch := range patchUpdate.Remove {
+			if err := k.removePatchFn(dir, toKustomizePatch(patch), k.limits); err != nil {
+				return nil, fmt.Errorf(
+					"error removing patch %q from %s/kustomization.yaml using Kustomize: %w",
+					patch.Path,
+					patchUpdate.Path,
+					err,
+				)
+			}
+			changeSummary = append(
+				changeSummary,
+				fmt.Sprintf(
+					"removed patch %s from %s/kustomization.yaml",
+					patch.Path,
+					patchUpdate.Path,
+				),
+			)
+		}
+	}
+	return changeSummary, nil
+}
+
+// toKustomizePatch converts the provided KustomizePatch to a kustomize.Patch.
+func toKustomizePatch(patch kargoapi.KustomizePatch) kustomize.Patch {
+	p := kustomize.Patch{Path: patch.Path}
+	if t := patch.Target; t != nil {
+		p.Group = t.Group
+		p.Version = t.Version
+		p.Kind = t.Kind
+		p.Name = t.Name
+		p.Namespace = t.Namespace
+		p.LabelSelector = t.LabelSelector
+		p.AnnotationSelector = t.AnnotationSelector
+	}
+	return p
+}
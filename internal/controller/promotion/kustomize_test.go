@@ -10,10 +10,12 @@ import (
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/controller/git"
 	"github.com/akuity/kargo/internal/credentials"
+	libExec "github.com/akuity/kargo/internal/exec"
+	"github.com/akuity/kargo/internal/kustomize"
 )
 
 func TestNewKustomizeMechanism(t *testing.T) {
-	pm := newKustomizeMechanism(&credentials.FakeDB{})
+	pm := newKustomizeMechanism(nil, &credentials.FakeDB{}, libExec.Limits{})
 	kpm, ok := pm.(*gitMechanism)
 	require.True(t, ok)
 	require.NotNil(t, kpm.selectUpdatesFn)
@@ -87,7 +89,7 @@ func TestKustomizerApply(t *testing.T) {
 				},
 			},
 			kustomizer: &kustomizer{
-				setImageFn: func(string, string) error {
+				setImageFn: func(string, string, libExec.Limits) error {
 					return errors.New("something went wrong")
 				},
 			},
@@ -109,7 +111,7 @@ func TestKustomizerApply(t *testing.T) {
 				},
 			},
 			kustomizer: &kustomizer{
-				setImageFn: func(string, string) error {
+				setImageFn: func(string, string, libExec.Limits) error {
 					return nil
 				},
 			},
@@ -138,7 +140,7 @@ func TestKustomizerApply(t *testing.T) {
 				},
 			},
 			kustomizer: &kustomizer{
-				setImageFn: func(string, string) error {
+				setImageFn: func(string, string, libExec.Limits) error {
 					return nil
 				},
 			},
@@ -153,6 +155,114 @@ func TestKustomizerApply(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "error adding resources",
+			update: kargoapi.GitRepoUpdate{
+				Kustomize: &kargoapi.KustomizePromotionMechanism{
+					Resources: []kargoapi.KustomizeResourcesUpdate{{
+						Path: "fake-path",
+						Add:  []string{"fake-resource.yaml"},
+					}},
+				},
+			},
+			kustomizer: &kustomizer{
+				addResourcesFn: func(string, []string, libExec.Limits) error {
+					return errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, _ []string, err error) {
+				require.ErrorContains(t, err, "error adding resources")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "error removing patch",
+			update: kargoapi.GitRepoUpdate{
+				Kustomize: &kargoapi.KustomizePromotionMechanism{
+					Patches: []kargoapi.KustomizePatchesUpdate{{
+						Path:   "fake-path",
+						Remove: []kargoapi.KustomizePatch{{Path: "fake-patch.yaml"}},
+					}},
+				},
+			},
+			kustomizer: &kustomizer{
+				removePatchFn: func(string, kustomize.Patch, libExec.Limits) error {
+					return errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, _ []string, err error) {
+				require.ErrorContains(t, err, `error removing patch "fake-patch.yaml"`)
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "success with resources, patches, and images",
+			update: kargoapi.GitRepoUpdate{
+				Kustomize: &kargoapi.KustomizePromotionMechanism{
+					Images: []kargoapi.KustomizeImageUpdate{{
+						Image: "fake-image",
+						Path:  "fake-path",
+					}},
+					Resources: []kargoapi.KustomizeResourcesUpdate{{
+						Path:   "fake-path",
+						Add:    []string{"a.yaml", "b.yaml"},
+						Remove: []string{"c.yaml"},
+					}},
+					Patches: []kargoapi.KustomizePatchesUpdate{{
+						Path: "fake-path",
+						Add: []kargoapi.KustomizePatch{{
+							Path: "replicas.yaml",
+							Target: &kargoapi.KustomizePatchTarget{
+								Kind: "Deployment",
+								Name: "fake-deployment",
+							},
+						}},
+						Remove: []kargoapi.KustomizePatch{{Path: "debug.yaml"}},
+					}},
+				},
+			},
+			kustomizer: &kustomizer{
+				setImageFn: func(string, string, libExec.Limits) error {
+					return nil
+				},
+				addResourcesFn: func(dir string, resources []string, _ libExec.Limits) error {
+					if dir != "fake-path" || len(resources) != 2 {
+						return errors.New("unexpected arguments")
+					}
+					return nil
+				},
+				removeResourcesFn: func(string, []string, libExec.Limits) error {
+					return nil
+				},
+				addPatchFn: func(_ string, patch kustomize.Patch, _ libExec.Limits) error {
+					if patch != (kustomize.Patch{
+						Path: "replicas.yaml",
+						Kind: "Deployment",
+						Name: "fake-deployment",
+					}) {
+						return errors.New("unexpected patch")
+					}
+					return nil
+				},
+				removePatchFn: func(string, kustomize.Patch, libExec.Limits) error {
+					return nil
+				},
+			},
+			assertions: func(t *testing.T, changes []string, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]string{
+						"added resources a.yaml, b.yaml to fake-path/kustomization.yaml",
+						"removed resources c.yaml from fake-path/kustomization.yaml",
+						"added patch replicas.yaml to fake-path/kustomization.yaml",
+						"removed patch debug.yaml from fake-path/kustomization.yaml",
+						"updated fake-path/kustomization.yaml to use image fake-image:fake-tag",
+					},
+					changes,
+				)
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -169,6 +279,7 @@ func TestKustomizerApply(t *testing.T) {
 					},
 				},
 				"",
+				nil,
 				"",
 				"",
 				"",
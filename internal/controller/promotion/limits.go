@@ -0,0 +1,54 @@
+package promotion
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	libExec "github.com/akuity/kargo/internal/exec"
+)
+
+// LimitsConfig represents configuration for the resource limits that apply to
+// each of the processes spawned by promotion mechanisms, e.g. to render
+// manifests. Limiting those processes keeps a single misbehaving Promotion from
+// exhausting the resources of the controller and, with it, disrupting the
+// Promotions of all Projects.
+type LimitsConfig struct {
+	// CPUTime is the maximum amount of CPU time each process may consume.
+	CPUTime time.Duration `envconfig:"PROMOTION_CPU_TIME_LIMIT"`
+	// Memory is the maximum amount of memory each process may allocate,
+	// expressed as a Kubernetes quantity, e.g. "512Mi".
+	Memory string `envconfig:"PROMOTION_MEMORY_LIMIT"`
+	// FileSize is the maximum size of any single file each process may write,
+	// expressed as a Kubernetes quantity, e.g. "100Mi".
+	FileSize string `envconfig:"PROMOTION_FILE_SIZE_LIMIT"`
+}
+
+func LimitsConfigFromEnv() LimitsConfig {
+	var cfg LimitsConfig
+	envconfig.MustProcess("", &cfg)
+	return cfg
+}
+
+// Limits returns the libExec.Limits described by the LimitsConfig. It returns
+// an error if the LimitsConfig contains a quantity that cannot be parsed.
+func (c LimitsConfig) Limits() (libExec.Limits, error) {
+	limits := libExec.Limits{CPUTime: c.CPUTime}
+	if c.Memory != "" {
+		memory, err := resource.ParseQuantity(c.Memory)
+		if err != nil {
+			return limits, fmt.Errorf("error parsing memory limit %q: %w", c.Memory, err)
+		}
+		limits.Memory = memory.Value()
+	}
+	if c.FileSize != "" {
+		fileSize, err := resource.ParseQuantity(c.FileSize)
+		if err != nil {
+			return limits, fmt.Errorf("error parsing file size limit %q: %w", c.FileSize, err)
+		}
+		limits.FileSize = fileSize.Value()
+	}
+	return limits, nil
+}
@@ -0,0 +1,66 @@
+package promotion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	libExec "github.com/akuity/kargo/internal/exec"
+)
+
+func TestLimitsConfigLimits(t *testing.T) {
+	testCases := []struct {
+		name       string
+		cfg        LimitsConfig
+		assertions func(*testing.T, libExec.Limits, error)
+	}{
+		{
+			name: "no limits",
+			assertions: func(t *testing.T, limits libExec.Limits, err error) {
+				require.NoError(t, err)
+				require.True(t, limits.IsZero())
+			},
+		},
+		{
+			name: "all limits",
+			cfg: LimitsConfig{
+				CPUTime:  time.Minute,
+				Memory:   "512Mi",
+				FileSize: "1G",
+			},
+			assertions: func(t *testing.T, limits libExec.Limits, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					libExec.Limits{
+						CPUTime:  time.Minute,
+						Memory:   512 * 1024 * 1024,
+						FileSize: 1000 * 1000 * 1000,
+					},
+					limits,
+				)
+			},
+		},
+		{
+			name: "invalid memory limit",
+			cfg:  LimitsConfig{Memory: "lots"},
+			assertions: func(t *testing.T, _ libExec.Limits, err error) {
+				require.ErrorContains(t, err, `error parsing memory limit "lots"`)
+			},
+		},
+		{
+			name: "invalid file size limit",
+			cfg:  LimitsConfig{FileSize: "huge"},
+			assertions: func(t *testing.T, _ libExec.Limits, err error) {
+				require.ErrorContains(t, err, `error parsing file size limit "huge"`)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			limits, err := testCase.cfg.Limits()
+			testCase.assertions(t, limits, err)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package promotion
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+// fileAttributes represents the attributes of a file that may be preserved
+// when the file is updated.
+type fileAttributes struct {
+	// mode is the file's mode.
+	mode fs.FileMode
+	// crlf indicates whether the file uses CRLF line endings.
+	crlf bool
+	// linkTarget is the target of the file if it is a symbolic link.
+	linkTarget string
+}
+
+// snapshotFileAttributes walks the specified directory, EXCEPT for its .git
+// subdirectory, and returns the attributes of each file within it that are to
+// be preserved according to the provided GitFilePreservation, indexed by the
+// file's path relative to the directory.
+func snapshotFileAttributes(
+	dir string,
+	preserve kargoapi.GitFilePreservation,
+) (map[string]fileAttributes, error) {
+	snapshot := map[string]fileAttributes{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		attrs := fileAttributes{mode: info.Mode()}
+		switch {
+		case info.Mode()&fs.ModeSymlink != 0:
+			if !preserve.Symlinks {
+				return nil
+			}
+			if attrs.linkTarget, err = os.Readlink(path); err != nil {
+				return err
+			}
+		case info.Mode().IsRegular():
+			if preserve.LineEndings {
+				content, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				attrs.crlf = bytes.Contains(content, []byte("\r\n"))
+			}
+		default:
+			return nil
+		}
+		snapshot[relPath] = attrs
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error recording attributes of files in %q: %w", dir, err)
+	}
+	return snapshot, nil
+}
+
+// restoreFileAttributes restores the attributes recorded in the provided
+// snapshot, as preserved according to the provided GitFilePreservation, of
+// those files within the specified directory that still exist.
+func restoreFileAttributes(
+	dir string,
+	snapshot map[string]fileAttributes,
+	preserve kargoapi.GitFilePreservation,
+) error {
+	// Symbolic links are restored first because doing so may update the files
+	// they point to, which may themselves have attributes to restore.
+	if preserve.Symlinks {
+		for relPath, attrs := range snapshot {
+			if attrs.linkTarget == "" {
+				continue
+			}
+			if err := restoreSymlink(dir, relPath, attrs.linkTarget); err != nil {
+				return err
+			}
+		}
+	}
+	for relPath, attrs := range snapshot {
+		if !attrs.mode.IsRegular() {
+			continue
+		}
+		path := filepath.Join(dir, relPath)
+		info, err := os.Lstat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("error reading attributes of file %q: %w", relPath, err)
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		if preserve.LineEndings && attrs.crlf {
+			if err = restoreCRLF(path, info.Mode().Perm()); err != nil {
+				return fmt.Errorf("error restoring line endings of file %q: %w", relPath, err)
+			}
+		}
+		if preserve.FileModes && info.Mode().Perm() != attrs.mode.Perm() {
+			if err = os.Chmod(path, attrs.mode.Perm()); err != nil {
+				return fmt.Errorf("error restoring mode of file %q: %w", relPath, err)
+			}
+		}
+	}
+	return nil
+}
+
+// restoreSymlink restores the symbolic link at the provided path, relative to
+// the specified directory, if it has been replaced by a regular file. The
+// content of the regular file is written to the file the symbolic link pointed
+// to, which must be within the specified directory.
+func restoreSymlink(dir, relPath, linkTarget string) error {
+	path := filepath.Join(dir, relPath)
+	info, err := os.Lstat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("error reading attributes of file %q: %w", relPath, err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+	targetPath := linkTarget
+	if !filepath.IsAbs(targetPath) {
+		targetPath = filepath.Join(filepath.Dir(path), targetPath)
+	}
+	relTargetPath, err := filepath.Rel(dir, targetPath)
+	if err != nil || relTargetPath == ".." ||
+		strings.HasPrefix(relTargetPath, ".."+string(filepath.Separator)) {
+		return fmt.Errorf(
+			"cannot restore symbolic link %q because its target %q is outside the repository",
+			relPath,
+			linkTarget,
+		)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading file %q: %w", relPath, err)
+	}
+	targetMode := info.Mode().Perm()
+	if targetInfo, err := os.Stat(targetPath); err == nil {
+		targetMode = targetInfo.Mode().Perm()
+	}
+	if err = os.WriteFile(targetPath, content, targetMode); err != nil {
+		return fmt.Errorf("error writing target %q of symbolic link %q: %w", linkTarget, relPath, err)
+	}
+	if err = os.Remove(path); err != nil {
+		return fmt.Errorf("error removing file %q: %w", relPath, err)
+	}
+	if err = os.Symlink(linkTarget, path); err != nil {
+		return fmt.Errorf("error restoring symbolic link %q: %w", relPath, err)
+	}
+	return nil
+}
+
+// restoreCRLF rewrites the file at the specified path so that all of its lines
+// end with CRLF.
+func restoreCRLF(path string, perm fs.FileMode) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	crlfContent := bytes.ReplaceAll(
+		bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n")),
+		[]byte("\n"),
+		[]byte("\r\n"),
+	)
+	if bytes.Equal(content, crlfContent) {
+		return nil
+	}
+	return os.WriteFile(path, crlfContent, perm)
+}
@@ -0,0 +1,154 @@
+package promotion
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestSnapshotAndRestoreFileAttributes(t *testing.T) {
+	testCases := []struct {
+		name       string
+		preserve   kargoapi.GitFilePreservation
+		setup      func(t *testing.T, dir string)
+		update     func(t *testing.T, dir string)
+		assertions func(t *testing.T, dir string, err error)
+	}{
+		{
+			name:     "line endings restored",
+			preserve: kargoapi.GitFilePreservation{LineEndings: true},
+			setup: func(t *testing.T, dir string) {
+				writeTestFile(t, dir, "values.yaml", "a: 1\r\nb: 2\r\n", 0644)
+				writeTestFile(t, dir, "unix.yaml", "a: 1\n", 0644)
+			},
+			update: func(t *testing.T, dir string) {
+				writeTestFile(t, dir, "values.yaml", "a: 1\nb: 3\n", 0644)
+				writeTestFile(t, dir, "unix.yaml", "a: 2\n", 0644)
+			},
+			assertions: func(t *testing.T, dir string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "a: 1\r\nb: 3\r\n", readTestFile(t, dir, "values.yaml"))
+				require.Equal(t, "a: 2\n", readTestFile(t, dir, "unix.yaml"))
+			},
+		},
+		{
+			name: "line endings not preserved",
+			setup: func(t *testing.T, dir string) {
+				writeTestFile(t, dir, "values.yaml", "a: 1\r\n", 0644)
+			},
+			update: func(t *testing.T, dir string) {
+				writeTestFile(t, dir, "values.yaml", "a: 2\n", 0644)
+			},
+			assertions: func(t *testing.T, dir string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "a: 2\n", readTestFile(t, dir, "values.yaml"))
+			},
+		},
+		{
+			name:     "file modes restored",
+			preserve: kargoapi.GitFilePreservation{FileModes: true},
+			setup: func(t *testing.T, dir string) {
+				writeTestFile(t, dir, "run.sh", "echo foo\n", 0755)
+			},
+			update: func(t *testing.T, dir string) {
+				require.NoError(t, os.Remove(filepath.Join(dir, "run.sh")))
+				writeTestFile(t, dir, "run.sh", "echo bar\n", 0644)
+			},
+			assertions: func(t *testing.T, dir string, err error) {
+				require.NoError(t, err)
+				info, err := os.Stat(filepath.Join(dir, "run.sh"))
+				require.NoError(t, err)
+				require.Equal(t, os.FileMode(0755), info.Mode().Perm())
+				require.Equal(t, "echo bar\n", readTestFile(t, dir, "run.sh"))
+			},
+		},
+		{
+			name:     "symlinks restored",
+			preserve: kargoapi.GitFilePreservation{Symlinks: true},
+			setup: func(t *testing.T, dir string) {
+				writeTestFile(t, dir, "base/values.yaml", "a: 1\n", 0644)
+				require.NoError(t, os.MkdirAll(filepath.Join(dir, "stage"), 0755))
+				require.NoError(
+					t,
+					os.Symlink("../base/values.yaml", filepath.Join(dir, "stage", "values.yaml")),
+				)
+			},
+			update: func(t *testing.T, dir string) {
+				require.NoError(t, os.Remove(filepath.Join(dir, "stage", "values.yaml")))
+				writeTestFile(t, dir, "stage/values.yaml", "a: 2\n", 0600)
+			},
+			assertions: func(t *testing.T, dir string, err error) {
+				require.NoError(t, err)
+				target, err := os.Readlink(filepath.Join(dir, "stage", "values.yaml"))
+				require.NoError(t, err)
+				require.Equal(t, "../base/values.yaml", target)
+				require.Equal(t, "a: 2\n", readTestFile(t, dir, "base/values.yaml"))
+				info, err := os.Stat(filepath.Join(dir, "base", "values.yaml"))
+				require.NoError(t, err)
+				require.Equal(t, os.FileMode(0644), info.Mode().Perm())
+			},
+		},
+		{
+			name:     "symlink target outside of repository",
+			preserve: kargoapi.GitFilePreservation{Symlinks: true},
+			setup: func(t *testing.T, dir string) {
+				require.NoError(t, os.Symlink("../outside.yaml", filepath.Join(dir, "values.yaml")))
+			},
+			update: func(t *testing.T, dir string) {
+				require.NoError(t, os.Remove(filepath.Join(dir, "values.yaml")))
+				writeTestFile(t, dir, "values.yaml", "a: 2\n", 0644)
+			},
+			assertions: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, "is outside the repository")
+			},
+		},
+		{
+			name: ".git directory ignored",
+			preserve: kargoapi.GitFilePreservation{
+				LineEndings: true,
+				FileModes:   true,
+			},
+			setup: func(t *testing.T, dir string) {
+				writeTestFile(t, dir, ".git/config", "[core]\r\n", 0755)
+			},
+			update: func(t *testing.T, dir string) {
+				writeTestFile(t, dir, ".git/config", "[user]\n", 0644)
+				require.NoError(t, os.Chmod(filepath.Join(dir, ".git", "config"), 0644))
+			},
+			assertions: func(t *testing.T, dir string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "[user]\n", readTestFile(t, dir, ".git/config"))
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dir := t.TempDir()
+			testCase.setup(t, dir)
+			snapshot, err := snapshotFileAttributes(dir, testCase.preserve)
+			require.NoError(t, err)
+			testCase.update(t, dir)
+			testCase.assertions(
+				t,
+				dir,
+				restoreFileAttributes(dir, snapshot, testCase.preserve),
+			)
+		})
+	}
+}
+
+func writeTestFile(t *testing.T, dir, relPath, content string, perm os.FileMode) {
+	path := filepath.Join(dir, relPath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), perm))
+}
+
+func readTestFile(t *testing.T, dir, relPath string) string {
+	content, err := os.ReadFile(filepath.Join(dir, relPath))
+	require.NoError(t, err)
+	return string(content)
+}
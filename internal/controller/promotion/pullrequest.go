@@ -191,6 +191,62 @@ func reconcilePullRequest(
 	return mergeCommitSHA, newStatus, nil
 }
 
+// shouldDeletePullRequestBranch returns a bool indicating whether, according to
+// the provided PullRequestBranchCleanup, the branch from which a pull request
+// was opened is to be deleted given the provided phase of the Promotion that
+// opened it. Only Promotions that have completed have closed their pull
+// requests.
+func shouldDeletePullRequestBranch(
+	cleanup *kargoapi.PullRequestBranchCleanup,
+	phase kargoapi.PromotionPhase,
+) bool {
+	if cleanup == nil {
+		return false
+	}
+	switch phase {
+	case kargoapi.PromotionPhaseSucceeded:
+		return true
+	case kargoapi.PromotionPhaseFailed:
+		return cleanup.Policy == kargoapi.PullRequestBranchCleanupPolicyAlways
+	default:
+		return false
+	}
+}
+
+// deletePullRequestBranch deletes the specified pull request branch from the
+// remote repository and records its deletion in the provided metadata map. A
+// branch that no longer exists, for instance because the Git hosting provider
+// deleted it when the pull request was merged, is not considered an error.
+func deletePullRequestBranch(
+	repo git.Repo,
+	prBranch string,
+	metadata map[string]string,
+) (map[string]string, error) {
+	exists, err := repo.RemoteBranchExists(prBranch)
+	if err != nil {
+		return metadata, err
+	}
+	if exists {
+		if err = repo.DeleteRemoteBranch(prBranch); err != nil {
+			return metadata, err
+		}
+	}
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	metadata[pullRequestBranchDeletedMetadataKeyPrefix+repo.URL()] = prBranch
+	return metadata, nil
+}
+
+// pullRequestBranchDeletedMetadataKeyPrefix is the prefix of the keys used to
+// record, in the metadata map, the deletion of the branches from which pull
+// requests were opened.
+const pullRequestBranchDeletedMetadataKeyPrefix = "pr-branch-deleted:"
+
+// pullRequestURLMetadataKeyPrefix is the prefix of the keys used to store pull
+// request URLs in the metadata map.
+const pullRequestURLMetadataKeyPrefix = "pr-url:"
+
 // pullRequestMetadataKey returns the key used to store the pull request number in the metadata map.
 func pullRequestMetadataKey(repoURL string) string {
 	return fmt.Sprintf("pr:%s", repoURL)
@@ -202,8 +258,9 @@ func setPullRequestMetadata(metadata map[string]string, repoURL string, number i
 		metadata = make(map[string]string)
 	}
 	metadata[pullRequestMetadataKey(repoURL)] = strconv.FormatInt(number, 10)
-	// we only set url for UI purposes so there is no helper function for key
-	metadata[fmt.Sprintf("pr-url:%s", repoURL)] = url
+	// we only set url for UI purposes and commit message templates so there is
+	// no helper function for key
+	metadata[pullRequestURLMetadataKeyPrefix+repoURL] = url
 	return metadata
 }
 
@@ -0,0 +1,146 @@
+package promotion
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/controller/git"
+)
+
+// fakeRemoteBranchRepo is a fake implementation of the subset of git.Repo
+// used to delete branches from the remote repository.
+type fakeRemoteBranchRepo struct {
+	git.Repo
+	branchExists    bool
+	branchExistsErr error
+	deleteErr       error
+	deleted         []string
+}
+
+func (f *fakeRemoteBranchRepo) URL() string {
+	return "https://github.com/example/repo.git"
+}
+
+func (f *fakeRemoteBranchRepo) RemoteBranchExists(string) (bool, error) {
+	return f.branchExists, f.branchExistsErr
+}
+
+func (f *fakeRemoteBranchRepo) DeleteRemoteBranch(branch string) error {
+	if f.deleteErr != nil {
+		return f.deleteErr
+	}
+	f.deleted = append(f.deleted, branch)
+	return nil
+}
+
+func TestShouldDeletePullRequestBranch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cleanup  *kargoapi.PullRequestBranchCleanup
+		phase    kargoapi.PromotionPhase
+		expected bool
+	}{
+		{
+			name:     "no cleanup",
+			phase:    kargoapi.PromotionPhaseSucceeded,
+			expected: false,
+		},
+		{
+			name:     "pull request still open",
+			cleanup:  &kargoapi.PullRequestBranchCleanup{Policy: kargoapi.PullRequestBranchCleanupPolicyAlways},
+			phase:    kargoapi.PromotionPhaseRunning,
+			expected: false,
+		},
+		{
+			name:     "merged with OnMerge policy",
+			cleanup:  &kargoapi.PullRequestBranchCleanup{Policy: kargoapi.PullRequestBranchCleanupPolicyOnMerge},
+			phase:    kargoapi.PromotionPhaseSucceeded,
+			expected: true,
+		},
+		{
+			name:     "closed with OnMerge policy",
+			cleanup:  &kargoapi.PullRequestBranchCleanup{Policy: kargoapi.PullRequestBranchCleanupPolicyOnMerge},
+			phase:    kargoapi.PromotionPhaseFailed,
+			expected: false,
+		},
+		{
+			name:     "closed with Always policy",
+			cleanup:  &kargoapi.PullRequestBranchCleanup{Policy: kargoapi.PullRequestBranchCleanupPolicyAlways},
+			phase:    kargoapi.PromotionPhaseFailed,
+			expected: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				shouldDeletePullRequestBranch(testCase.cleanup, testCase.phase),
+			)
+		})
+	}
+}
+
+func TestDeletePullRequestBranch(t *testing.T) {
+	const testBranch = "kargo/fake-project/fake-stage/promotion"
+	testCases := []struct {
+		name       string
+		repo       *fakeRemoteBranchRepo
+		assertions func(*testing.T, *fakeRemoteBranchRepo, map[string]string, error)
+	}{
+		{
+			name: "error checking for branch",
+			repo: &fakeRemoteBranchRepo{branchExistsErr: errors.New("something went wrong")},
+			assertions: func(t *testing.T, _ *fakeRemoteBranchRepo, md map[string]string, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+				require.Empty(t, md)
+			},
+		},
+		{
+			name: "error deleting branch",
+			repo: &fakeRemoteBranchRepo{
+				branchExists: true,
+				deleteErr:    errors.New("something went wrong"),
+			},
+			assertions: func(t *testing.T, _ *fakeRemoteBranchRepo, md map[string]string, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+				require.Empty(t, md)
+			},
+		},
+		{
+			name: "branch already deleted",
+			repo: &fakeRemoteBranchRepo{},
+			assertions: func(t *testing.T, repo *fakeRemoteBranchRepo, md map[string]string, err error) {
+				require.NoError(t, err)
+				require.Empty(t, repo.deleted)
+				require.Equal(
+					t,
+					testBranch,
+					md[pullRequestBranchDeletedMetadataKeyPrefix+repo.URL()],
+				)
+			},
+		},
+		{
+			name: "branch deleted",
+			repo: &fakeRemoteBranchRepo{branchExists: true},
+			assertions: func(t *testing.T, repo *fakeRemoteBranchRepo, md map[string]string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{testBranch}, repo.deleted)
+				require.Equal(
+					t,
+					testBranch,
+					md[pullRequestBranchDeletedMetadataKeyPrefix+repo.URL()],
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			md, err := deletePullRequestBranch(testCase.repo, testBranch, nil)
+			testCase.assertions(t, testCase.repo, md, err)
+		})
+	}
+}
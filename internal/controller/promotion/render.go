@@ -7,23 +7,30 @@ import (
 	"path/filepath"
 	"sort"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/controller/git"
 	"github.com/akuity/kargo/internal/credentials"
+	libExec "github.com/akuity/kargo/internal/exec"
 	render "github.com/akuity/kargo/internal/kargo-render"
 )
 
 // newKargoRenderMechanism returns a gitMechanism that only only selects and
 // performs updates that involve Kargo Render.
 func newKargoRenderMechanism(
+	kargoClient client.Client,
 	credentialsDB credentials.Database,
+	limits libExec.Limits,
 ) Mechanism {
 	return newGitMechanism(
 		"Kargo Render promotion mechanism",
+		kargoClient,
 		credentialsDB,
 		selectKargoRenderUpdates,
 		(&renderer{
 			renderManifestsFn: render.RenderManifests,
+			limits:            limits,
 		}).apply,
 	)
 }
@@ -44,6 +51,7 @@ func selectKargoRenderUpdates(updates []kargoapi.GitRepoUpdate) []kargoapi.GitRe
 // other functions that are used in the implementation of the apply() function.
 type renderer struct {
 	renderManifestsFn func(req render.Request) error
+	limits            libExec.Limits
 }
 
 // apply uses Kargo Render to carry out the provided update in the specified
@@ -53,6 +61,7 @@ func (r *renderer) apply(
 	update kargoapi.GitRepoUpdate,
 	newFreight kargoapi.FreightReference,
 	_ string,
+	_ map[string]string,
 	sourceCommit string,
 	_ string,
 	workingDir string,
@@ -103,6 +112,7 @@ func (r *renderer) apply(
 		LocalInPath:  workingDir,
 		LocalOutPath: writeDir,
 		RepoCreds:    repoCreds,
+		Limits:       r.limits,
 	}
 
 	if err = r.renderManifestsFn(req); err != nil {
@@ -13,11 +13,12 @@ import (
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/controller/git"
 	"github.com/akuity/kargo/internal/credentials"
+	libExec "github.com/akuity/kargo/internal/exec"
 	render "github.com/akuity/kargo/internal/kargo-render"
 )
 
 func TestNewKargoRenderMechanism(t *testing.T) {
-	pm := newKargoRenderMechanism(&credentials.FakeDB{})
+	pm := newKargoRenderMechanism(nil, &credentials.FakeDB{}, libExec.Limits{})
 	kpm, ok := pm.(*gitMechanism)
 	require.True(t, ok)
 	require.NotNil(t, kpm.selectUpdatesFn)
@@ -219,6 +220,7 @@ func TestKargoRenderApply(t *testing.T) {
 				testCase.update,
 				testCase.newFreight,
 				"",
+				nil,
 				testSourceCommitID,
 				"", // Home directory is not used by this implementation
 				testWorkDir,
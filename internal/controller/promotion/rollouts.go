@@ -0,0 +1,419 @@
+package promotion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	libargocd "github.com/akuity/kargo/internal/argocd"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// rolloutGVK is the GroupVersionKind of Argo Rollouts Rollout resources. These
+// are handled as unstructured objects so that Kargo need not depend upon the
+// Argo Rollouts API.
+var rolloutGVK = schema.GroupVersionKind{
+	Group:   "argoproj.io",
+	Version: "v1alpha1",
+	Kind:    "Rollout",
+}
+
+// Phases of an Argo Rollouts Rollout.
+const (
+	rolloutPhaseHealthy  = "Healthy"
+	rolloutPhasePaused   = "Paused"
+	rolloutPhaseDegraded = "Degraded"
+)
+
+// rolloutGenerationMetadataKeyPrefix is the prefix of the keys used to store,
+// in the metadata map, the generation of each Rollout as of when it was
+// updated. This serves both to record that the update has been applied, so
+// that it is never applied twice, and to ignore any status the Rollout
+// reported before it observed the update.
+const rolloutGenerationMetadataKeyPrefix = "rollout-generation:"
+
+// argoRolloutsMechanism is an implementation of the Mechanism interface that
+// updates Argo Rollouts Rollout resources.
+type argoRolloutsMechanism struct {
+	// These behaviors are overridable for testing purposes:
+	getClusterClientFn func(
+		ctx context.Context,
+		cluster string,
+	) (client.Client, error)
+	getRolloutFn func(
+		ctx context.Context,
+		c client.Client,
+		namespace string,
+		name string,
+	) (*unstructured.Unstructured, error)
+	doSingleUpdateFn func(
+		ctx context.Context,
+		c client.Client,
+		stageMeta metav1.ObjectMeta,
+		update kargoapi.ArgoRolloutUpdate,
+		rollout *unstructured.Unstructured,
+	) error
+}
+
+// newArgoRolloutsMechanism returns an implementation of the Mechanism
+// interface that updates Argo Rollouts Rollout resources.
+func newArgoRolloutsMechanism(
+	kargoClient client.Client,
+	argocdClient client.Client,
+) Mechanism {
+	r := &argoRolloutsMechanism{}
+	r.getClusterClientFn = libargocd.GetClusterClientFn(kargoClient, argocdClient)
+	r.getRolloutFn = getRollout
+	r.doSingleUpdateFn = r.doSingleUpdate
+	return r
+}
+
+// GetName implements the Mechanism interface.
+func (*argoRolloutsMechanism) GetName() string {
+	return "Argo Rollouts promotion mechanism"
+}
+
+// Promote implements the Mechanism interface.
+func (r *argoRolloutsMechanism) Promote(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	promo *kargoapi.Promotion,
+	newFreight kargoapi.FreightReference,
+) (*kargoapi.PromotionStatus, kargoapi.FreightReference, error) {
+	updates := stage.Spec.PromotionMechanisms.ArgoRolloutUpdates
+
+	if len(updates) == 0 {
+		return promo.Status.WithPhase(kargoapi.PromotionPhaseSucceeded), newFreight, nil
+	}
+
+	logger := logging.LoggerFromContext(ctx)
+	logger.Debug("executing Argo Rollouts-based promotion mechanisms")
+
+	newStatus := promo.Status.DeepCopy()
+	newStatus.Phase = kargoapi.PromotionPhaseSucceeded
+	for _, update := range updates {
+		c, err := r.getClusterClientFn(ctx, update.Cluster)
+		if err != nil {
+			return nil, newFreight, fmt.Errorf(
+				"error getting client for cluster %q: %w",
+				update.Cluster,
+				err,
+			)
+		}
+		rollout, err := r.getRolloutFn(ctx, c, update.Namespace, update.Name)
+		if err != nil {
+			return nil, newFreight, fmt.Errorf(
+				"error finding Argo Rollouts Rollout %q in namespace %q: %w",
+				update.Name,
+				update.Namespace,
+				err,
+			)
+		}
+		if rollout == nil {
+			return nil, newFreight, fmt.Errorf(
+				"unable to find Argo Rollouts Rollout %q in namespace %q",
+				update.Name,
+				update.Namespace,
+			)
+		}
+
+		generation, updated := getRolloutGenerationFromMetadata(
+			promo.Status.Metadata,
+			update.Namespace,
+			update.Name,
+		)
+		if !updated {
+			if err = r.doSingleUpdateFn(ctx, c, stage.ObjectMeta, update, rollout); err != nil {
+				return nil, newFreight, err
+			}
+			newStatus.Metadata = setRolloutGenerationMetadata(
+				newStatus.Metadata,
+				update.Namespace,
+				update.Name,
+				rollout.GetGeneration(),
+			)
+			// The Rollout has yet to react to the update, so we must wait for it.
+			newStatus.Phase = mostSeverePromotionPhase(
+				newStatus.Phase,
+				kargoapi.PromotionPhaseRunning,
+			)
+			continue
+		}
+
+		phase, message := rolloutStatusToPromotionPhase(rollout, update.Action, generation)
+		newStatus.Phase = mostSeverePromotionPhase(newStatus.Phase, phase)
+		if message != "" && phase == kargoapi.PromotionPhaseFailed {
+			newStatus.Message = fmt.Sprintf(
+				"Argo Rollouts Rollout %q in namespace %q: %s",
+				update.Name,
+				update.Namespace,
+				message,
+			)
+		}
+	}
+
+	logger.Debug("done executing Argo Rollouts-based promotion mechanisms")
+	return newStatus, newFreight, nil
+}
+
+// doSingleUpdate applies the provided ArgoRolloutUpdate to the provided
+// Rollout, using the provided client, and the Rollout is updated in place to
+// reflect the result.
+func (r *argoRolloutsMechanism) doSingleUpdate(
+	ctx context.Context,
+	c client.Client,
+	stageMeta metav1.ObjectMeta,
+	update kargoapi.ArgoRolloutUpdate,
+	rollout *unstructured.Unstructured,
+) error {
+	objMeta := metav1.ObjectMeta{
+		Namespace:   rollout.GetNamespace(),
+		Name:        rollout.GetName(),
+		Annotations: rollout.GetAnnotations(),
+	}
+	// Make sure this is allowed!
+	if err := authorizeResourceUpdate("Argo Rollouts Rollout", stageMeta, objMeta); err != nil {
+		return err
+	}
+
+	specPatch, statusPatch, err := buildRolloutPatches(rollout, update)
+	if err != nil {
+		return fmt.Errorf(
+			"error updating Argo Rollouts Rollout %q in namespace %q: %w",
+			rollout.GetName(),
+			rollout.GetNamespace(),
+			err,
+		)
+	}
+	if specPatch != nil {
+		if err = c.Patch(
+			ctx,
+			rollout,
+			client.RawPatch(types.MergePatchType, specPatch),
+		); err != nil {
+			return fmt.Errorf("error patching Argo Rollouts Rollout %q: %w", rollout.GetName(), err)
+		}
+	}
+	if statusPatch != nil {
+		if err = c.Status().Patch(
+			ctx,
+			rollout,
+			client.RawPatch(types.MergePatchType, statusPatch),
+		); err != nil {
+			return fmt.Errorf(
+				"error patching status of Argo Rollouts Rollout %q: %w",
+				rollout.GetName(),
+				err,
+			)
+		}
+	}
+	logging.LoggerFromContext(ctx).WithField("rollout", rollout.GetName()).
+		Debug("patched Argo Rollouts Rollout")
+	return nil
+}
+
+// buildRolloutPatches returns JSON merge patches to the spec and status of the
+// provided Rollout that apply the provided ArgoRolloutUpdate. Either patch is
+// nil if no changes to the corresponding part of the Rollout are required.
+// These mimic the patches applied by the Argo Rollouts kubectl plugin.
+func buildRolloutPatches(
+	rollout *unstructured.Unstructured,
+	update kargoapi.ArgoRolloutUpdate,
+) ([]byte, []byte, error) {
+	spec := map[string]any{}
+	status := map[string]any{}
+
+	if len(update.CanarySteps) > 0 {
+		if _, found, _ := unstructured.NestedMap(
+			rollout.Object, "spec", "strategy", "canary",
+		); !found {
+			return nil, nil, errors.New("Rollout does not use a canary strategy")
+		}
+		steps := make([]any, len(update.CanarySteps))
+		for i, step := range update.CanarySteps {
+			switch {
+			case step.SetWeight != nil && step.Pause == nil:
+				steps[i] = map[string]any{"setWeight": *step.SetWeight}
+			case step.Pause != nil && step.SetWeight == nil:
+				pause := map[string]any{}
+				if step.Pause.Duration != nil {
+					pause["duration"] = step.Pause.Duration.Duration.String()
+				}
+				steps[i] = map[string]any{"pause": pause}
+			default:
+				return nil, nil, fmt.Errorf(
+					"canary step %d must specify exactly one of setWeight or pause", i,
+				)
+			}
+		}
+		spec["strategy"] = map[string]any{
+			"canary": map[string]any{"steps": steps},
+		}
+	}
+
+	switch update.Action {
+	case kargoapi.ArgoRolloutActionPromote, kargoapi.ArgoRolloutActionPromoteFull:
+		if paused, _, _ := unstructured.NestedBool(rollout.Object, "spec", "paused"); paused {
+			spec["paused"] = false
+		}
+		if update.Action == kargoapi.ArgoRolloutActionPromoteFull {
+			status["promoteFull"] = true
+		} else {
+			status["pauseConditions"] = nil
+		}
+	case kargoapi.ArgoRolloutActionAbort:
+		status["abort"] = true
+	case "":
+	default:
+		return nil, nil, fmt.Errorf("unknown action %q", update.Action)
+	}
+
+	var specPatch, statusPatch []byte
+	var err error
+	if len(spec) > 0 {
+		if specPatch, err = json.Marshal(map[string]any{"spec": spec}); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(status) > 0 {
+		if statusPatch, err = json.Marshal(map[string]any{"status": status}); err != nil {
+			return nil, nil, err
+		}
+	}
+	return specPatch, statusPatch, nil
+}
+
+// rolloutStatusToPromotionPhase returns the PromotionPhase reflected by the
+// status of the provided Rollout following an update that took the specified
+// action and left the Rollout at the specified generation. If the Rollout has
+// failed, a message explaining why is also returned.
+func rolloutStatusToPromotionPhase(
+	rollout *unstructured.Unstructured,
+	action kargoapi.ArgoRolloutAction,
+	generation int64,
+) (kargoapi.PromotionPhase, string) {
+	// Argo Rollouts records the observed generation as a string
+	observedGenerationStr, _, _ := unstructured.NestedString(
+		rollout.Object, "status", "observedGeneration",
+	)
+	if observedGeneration, err := strconv.ParseInt(observedGenerationStr, 10, 64); err == nil &&
+		observedGeneration < generation {
+		// The Rollout has yet to observe the update
+		return kargoapi.PromotionPhaseRunning, ""
+	}
+	phase, _, _ := unstructured.NestedString(rollout.Object, "status", "phase")
+	message, _, _ := unstructured.NestedString(rollout.Object, "status", "message")
+	aborted, _, _ := unstructured.NestedBool(rollout.Object, "status", "abort")
+
+	if action == kargoapi.ArgoRolloutActionAbort {
+		// An aborted Rollout is Degraded once it has shifted all traffic back to
+		// its stable version.
+		if aborted && phase == rolloutPhaseDegraded {
+			return kargoapi.PromotionPhaseSucceeded, ""
+		}
+		return kargoapi.PromotionPhaseRunning, ""
+	}
+	if aborted {
+		return kargoapi.PromotionPhaseFailed, "Rollout was aborted"
+	}
+	switch phase {
+	case rolloutPhaseHealthy, rolloutPhasePaused:
+		// A paused Rollout has completed the steps it can complete without
+		// being promoted again.
+		return kargoapi.PromotionPhaseSucceeded, ""
+	case rolloutPhaseDegraded:
+		return kargoapi.PromotionPhaseFailed, message
+	default:
+		return kargoapi.PromotionPhaseRunning, ""
+	}
+}
+
+// mostSeverePromotionPhase returns the more severe of the two provided
+// PromotionPhases. In order of precedence: Errored, Failed, Running, Succeeded.
+func mostSeverePromotionPhase(a, b kargoapi.PromotionPhase) kargoapi.PromotionPhase {
+	severity := map[kargoapi.PromotionPhase]int{
+		kargoapi.PromotionPhaseSucceeded: 0,
+		kargoapi.PromotionPhaseRunning:   1,
+		kargoapi.PromotionPhaseFailed:    2,
+		kargoapi.PromotionPhaseErrored:   3,
+	}
+	if severity[b] > severity[a] {
+		return b
+	}
+	return a
+}
+
+// rolloutGenerationMetadataKey returns the key used to store the generation of
+// the specified Rollout as of when it was updated in the metadata map.
+func rolloutGenerationMetadataKey(namespace, name string) string {
+	return rolloutGenerationMetadataKeyPrefix + namespace + "/" + name
+}
+
+// setRolloutGenerationMetadata records in the metadata map that the specified
+// Rollout was updated and was at the specified generation as a result. The
+// metadata map is returned.
+func setRolloutGenerationMetadata(
+	metadata map[string]string,
+	namespace string,
+	name string,
+	generation int64,
+) map[string]string {
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	metadata[rolloutGenerationMetadataKey(namespace, name)] =
+		strconv.FormatInt(generation, 10)
+	return metadata
+}
+
+// getRolloutGenerationFromMetadata returns the generation the specified
+// Rollout was at as a result of being updated, as recorded in the metadata
+// map, and whether the Rollout has been updated at all.
+func getRolloutGenerationFromMetadata(
+	metadata map[string]string,
+	namespace string,
+	name string,
+) (int64, bool) {
+	value, ok := metadata[rolloutGenerationMetadataKey(namespace, name)]
+	if !ok {
+		return 0, false
+	}
+	generation, _ := strconv.ParseInt(value, 10, 64)
+	return generation, true
+}
+
+// getRollout returns the specified Rollout using the provided client. If the
+// Rollout does not exist, nil is returned.
+func getRollout(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	name string,
+) (*unstructured.Unstructured, error) {
+	rollout := &unstructured.Unstructured{}
+	rollout.SetGroupVersionKind(rolloutGVK)
+	if err := c.Get(
+		ctx,
+		types.NamespacedName{
+			Namespace: namespace,
+			Name:      name,
+		},
+		rollout,
+	); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rollout, nil
+}
@@ -0,0 +1,675 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestNewArgoRolloutsMechanism(t *testing.T) {
+	pm := newArgoRolloutsMechanism(
+		fake.NewClientBuilder().Build(),
+		fake.NewClientBuilder().Build(),
+	)
+	rpm, ok := pm.(*argoRolloutsMechanism)
+	require.True(t, ok)
+	require.NotNil(t, rpm.getClusterClientFn)
+	require.NotNil(t, rpm.getRolloutFn)
+	require.NotNil(t, rpm.doSingleUpdateFn)
+}
+
+func TestArgoRolloutsGetName(t *testing.T) {
+	require.NotEmpty(t, (&argoRolloutsMechanism{}).GetName())
+}
+
+func TestArgoRolloutsPromote(t *testing.T) {
+	testStage := &kargoapi.Stage{
+		Spec: kargoapi.StageSpec{
+			PromotionMechanisms: &kargoapi.PromotionMechanisms{
+				ArgoRolloutUpdates: []kargoapi.ArgoRolloutUpdate{{
+					Namespace: "fake-namespace",
+					Name:      "fake-rollout",
+					Action:    kargoapi.ArgoRolloutActionPromote,
+				}},
+			},
+		},
+	}
+	testCases := []struct {
+		name       string
+		promoMech  *argoRolloutsMechanism
+		stage      *kargoapi.Stage
+		promo      *kargoapi.Promotion
+		assertions func(*testing.T, *kargoapi.PromotionStatus, error)
+	}{
+		{
+			name:      "no updates",
+			promoMech: &argoRolloutsMechanism{},
+			stage: &kargoapi.Stage{
+				Spec: kargoapi.StageSpec{
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
+				},
+			},
+			promo: &kargoapi.Promotion{},
+			assertions: func(t *testing.T, status *kargoapi.PromotionStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionPhaseSucceeded, status.Phase)
+			},
+		},
+		{
+			name: "error getting cluster client",
+			promoMech: &argoRolloutsMechanism{
+				getClusterClientFn: func(context.Context, string) (client.Client, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			stage: testStage,
+			promo: &kargoapi.Promotion{},
+			assertions: func(t *testing.T, _ *kargoapi.PromotionStatus, err error) {
+				require.ErrorContains(t, err, "error getting client for cluster")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "error getting Rollout",
+			promoMech: &argoRolloutsMechanism{
+				getClusterClientFn: getTestClusterClient,
+				getRolloutFn: func(
+					context.Context,
+					client.Client,
+					string,
+					string,
+				) (*unstructured.Unstructured, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			stage: testStage,
+			promo: &kargoapi.Promotion{},
+			assertions: func(t *testing.T, _ *kargoapi.PromotionStatus, err error) {
+				require.ErrorContains(t, err, "error finding Argo Rollouts Rollout")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "Rollout not found",
+			promoMech: &argoRolloutsMechanism{
+				getClusterClientFn: getTestClusterClient,
+				getRolloutFn: func(
+					context.Context,
+					client.Client,
+					string,
+					string,
+				) (*unstructured.Unstructured, error) {
+					return nil, nil
+				},
+			},
+			stage: testStage,
+			promo: &kargoapi.Promotion{},
+			assertions: func(t *testing.T, _ *kargoapi.PromotionStatus, err error) {
+				require.ErrorContains(t, err, "unable to find Argo Rollouts Rollout")
+			},
+		},
+		{
+			name: "error applying update",
+			promoMech: &argoRolloutsMechanism{
+				getClusterClientFn: getTestClusterClient,
+				getRolloutFn: func(
+					context.Context,
+					client.Client,
+					string,
+					string,
+				) (*unstructured.Unstructured, error) {
+					return newTestRollout(nil), nil
+				},
+				doSingleUpdateFn: func(
+					context.Context,
+					client.Client,
+					metav1.ObjectMeta,
+					kargoapi.ArgoRolloutUpdate,
+					*unstructured.Unstructured,
+				) error {
+					return errors.New("something went wrong")
+				},
+			},
+			stage: testStage,
+			promo: &kargoapi.Promotion{},
+			assertions: func(t *testing.T, _ *kargoapi.PromotionStatus, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "update applied",
+			promoMech: &argoRolloutsMechanism{
+				getClusterClientFn: getTestClusterClient,
+				getRolloutFn: func(
+					context.Context,
+					client.Client,
+					string,
+					string,
+				) (*unstructured.Unstructured, error) {
+					return newTestRollout(nil), nil
+				},
+				doSingleUpdateFn: func(
+					_ context.Context,
+					_ client.Client,
+					_ metav1.ObjectMeta,
+					_ kargoapi.ArgoRolloutUpdate,
+					rollout *unstructured.Unstructured,
+				) error {
+					rollout.SetGeneration(5)
+					return nil
+				},
+			},
+			stage: testStage,
+			promo: &kargoapi.Promotion{},
+			assertions: func(t *testing.T, status *kargoapi.PromotionStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionPhaseRunning, status.Phase)
+				require.Equal(
+					t,
+					map[string]string{"rollout-generation:fake-namespace/fake-rollout": "5"},
+					status.Metadata,
+				)
+			},
+		},
+		{
+			name: "update already applied and Rollout progressing",
+			promoMech: &argoRolloutsMechanism{
+				getClusterClientFn: getTestClusterClient,
+				getRolloutFn: func(
+					context.Context,
+					client.Client,
+					string,
+					string,
+				) (*unstructured.Unstructured, error) {
+					return newTestRollout(map[string]any{
+						"observedGeneration": "5",
+						"phase":              "Progressing",
+					}), nil
+				},
+			},
+			stage: testStage,
+			promo: &kargoapi.Promotion{
+				Status: kargoapi.PromotionStatus{
+					Metadata: map[string]string{
+						"rollout-generation:fake-namespace/fake-rollout": "5",
+					},
+				},
+			},
+			assertions: func(t *testing.T, status *kargoapi.PromotionStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionPhaseRunning, status.Phase)
+			},
+		},
+		{
+			name: "update already applied and Rollout degraded",
+			promoMech: &argoRolloutsMechanism{
+				getClusterClientFn: getTestClusterClient,
+				getRolloutFn: func(
+					context.Context,
+					client.Client,
+					string,
+					string,
+				) (*unstructured.Unstructured, error) {
+					return newTestRollout(map[string]any{
+						"observedGeneration": "5",
+						"phase":              "Degraded",
+						"message":            "ProgressDeadlineExceeded",
+					}), nil
+				},
+			},
+			stage: testStage,
+			promo: &kargoapi.Promotion{
+				Status: kargoapi.PromotionStatus{
+					Metadata: map[string]string{
+						"rollout-generation:fake-namespace/fake-rollout": "5",
+					},
+				},
+			},
+			assertions: func(t *testing.T, status *kargoapi.PromotionStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionPhaseFailed, status.Phase)
+				require.Contains(t, status.Message, "ProgressDeadlineExceeded")
+			},
+		},
+		{
+			name: "update already applied and Rollout healthy",
+			promoMech: &argoRolloutsMechanism{
+				getClusterClientFn: getTestClusterClient,
+				getRolloutFn: func(
+					context.Context,
+					client.Client,
+					string,
+					string,
+				) (*unstructured.Unstructured, error) {
+					return newTestRollout(map[string]any{
+						"observedGeneration": "5",
+						"phase":              "Healthy",
+					}), nil
+				},
+			},
+			stage: testStage,
+			promo: &kargoapi.Promotion{
+				Status: kargoapi.PromotionStatus{
+					Metadata: map[string]string{
+						"rollout-generation:fake-namespace/fake-rollout": "5",
+					},
+				},
+			},
+			assertions: func(t *testing.T, status *kargoapi.PromotionStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionPhaseSucceeded, status.Phase)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			newStatus, _, err := testCase.promoMech.Promote(
+				context.Background(),
+				testCase.stage,
+				testCase.promo,
+				kargoapi.FreightReference{},
+			)
+			testCase.assertions(t, newStatus, err)
+		})
+	}
+}
+
+func TestArgoRolloutsDoSingleUpdate(t *testing.T) {
+	stageMeta := metav1.ObjectMeta{
+		Namespace: "fake-project",
+		Name:      "fake-stage",
+	}
+	testCases := []struct {
+		name         string
+		interceptors interceptor.Funcs
+		rollout      *unstructured.Unstructured
+		update       kargoapi.ArgoRolloutUpdate
+		assertions   func(*testing.T, error)
+	}{
+		{
+			name: "update not authorized",
+			rollout: func() *unstructured.Unstructured {
+				rollout := newTestRollout(nil)
+				rollout.SetAnnotations(nil)
+				return rollout
+			}(),
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(
+					t, err, "Argo Rollouts Rollout \"fake-rollout\" in namespace "+
+						"\"fake-namespace\" does not permit mutation",
+				)
+			},
+		},
+		{
+			name:    "invalid update",
+			rollout: newTestRollout(nil),
+			update: kargoapi.ArgoRolloutUpdate{
+				CanarySteps: []kargoapi.ArgoRolloutCanaryStep{{}},
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "must specify exactly one of setWeight or pause")
+			},
+		},
+		{
+			name: "error patching Rollout",
+			interceptors: interceptor.Funcs{
+				Patch: func(
+					context.Context,
+					client.WithWatch,
+					client.Object,
+					client.Patch,
+					...client.PatchOption,
+				) error {
+					return errors.New("something went wrong")
+				},
+			},
+			rollout: newTestRollout(nil),
+			update: kargoapi.ArgoRolloutUpdate{
+				CanarySteps: []kargoapi.ArgoRolloutCanaryStep{{SetWeight: ptr.To[int32](20)}},
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "error patching Argo Rollouts Rollout")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "error patching Rollout status",
+			interceptors: interceptor.Funcs{
+				SubResourcePatch: func(
+					context.Context,
+					client.Client,
+					string,
+					client.Object,
+					client.Patch,
+					...client.SubResourcePatchOption,
+				) error {
+					return errors.New("something went wrong")
+				},
+			},
+			rollout: newTestRollout(nil),
+			update: kargoapi.ArgoRolloutUpdate{
+				Action: kargoapi.ArgoRolloutActionAbort,
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "error patching status of Argo Rollouts Rollout")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "success",
+			interceptors: interceptor.Funcs{
+				Patch: func(
+					_ context.Context,
+					_ client.WithWatch,
+					_ client.Object,
+					patch client.Patch,
+					_ ...client.PatchOption,
+				) error {
+					data, err := patch.Data(nil)
+					require.NoError(t, err)
+					require.JSONEq(
+						t,
+						`{"spec":{"strategy":{"canary":{"steps":[{"setWeight":20}]}}}}`,
+						string(data),
+					)
+					return nil
+				},
+				SubResourcePatch: func(
+					_ context.Context,
+					_ client.Client,
+					_ string,
+					_ client.Object,
+					patch client.Patch,
+					_ ...client.SubResourcePatchOption,
+				) error {
+					data, err := patch.Data(nil)
+					require.NoError(t, err)
+					require.JSONEq(t, `{"status":{"pauseConditions":null}}`, string(data))
+					return nil
+				},
+			},
+			rollout: newTestRollout(nil),
+			update: kargoapi.ArgoRolloutUpdate{
+				CanarySteps: []kargoapi.ArgoRolloutCanaryStep{{SetWeight: ptr.To[int32](20)}},
+				Action:      kargoapi.ArgoRolloutActionPromote,
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithInterceptorFuncs(testCase.interceptors).Build()
+			testCase.assertions(
+				t,
+				(&argoRolloutsMechanism{}).doSingleUpdate(
+					context.Background(),
+					c,
+					stageMeta,
+					testCase.update,
+					testCase.rollout,
+				),
+			)
+		})
+	}
+}
+
+func TestBuildRolloutPatches(t *testing.T) {
+	testCases := []struct {
+		name       string
+		rollout    *unstructured.Unstructured
+		update     kargoapi.ArgoRolloutUpdate
+		assertions func(t *testing.T, specPatch, statusPatch []byte, err error)
+	}{
+		{
+			name:    "no changes",
+			rollout: newTestRollout(nil),
+			assertions: func(t *testing.T, specPatch, statusPatch []byte, err error) {
+				require.NoError(t, err)
+				require.Nil(t, specPatch)
+				require.Nil(t, statusPatch)
+			},
+		},
+		{
+			name: "Rollout without canary strategy",
+			rollout: func() *unstructured.Unstructured {
+				rollout := newTestRollout(nil)
+				unstructured.RemoveNestedField(rollout.Object, "spec", "strategy", "canary")
+				return rollout
+			}(),
+			update: kargoapi.ArgoRolloutUpdate{
+				CanarySteps: []kargoapi.ArgoRolloutCanaryStep{{SetWeight: ptr.To[int32](20)}},
+			},
+			assertions: func(t *testing.T, _, _ []byte, err error) {
+				require.ErrorContains(t, err, "does not use a canary strategy")
+			},
+		},
+		{
+			name:    "canary steps",
+			rollout: newTestRollout(nil),
+			update: kargoapi.ArgoRolloutUpdate{
+				CanarySteps: []kargoapi.ArgoRolloutCanaryStep{
+					{SetWeight: ptr.To[int32](20)},
+					{Pause: &kargoapi.ArgoRolloutPause{
+						Duration: &metav1.Duration{Duration: 30 * time.Second},
+					}},
+					{SetWeight: ptr.To[int32](50)},
+					{Pause: &kargoapi.ArgoRolloutPause{}},
+				},
+			},
+			assertions: func(t *testing.T, specPatch, statusPatch []byte, err error) {
+				require.NoError(t, err)
+				require.JSONEq(
+					t,
+					`{"spec":{"strategy":{"canary":{"steps":[`+
+						`{"setWeight":20},{"pause":{"duration":"30s"}},`+
+						`{"setWeight":50},{"pause":{}}]}}}}`,
+					string(specPatch),
+				)
+				require.Nil(t, statusPatch)
+			},
+		},
+		{
+			name: "promote paused Rollout",
+			rollout: func() *unstructured.Unstructured {
+				rollout := newTestRollout(nil)
+				require.NoError(t, unstructured.SetNestedField(rollout.Object, true, "spec", "paused"))
+				return rollout
+			}(),
+			update: kargoapi.ArgoRolloutUpdate{
+				Action: kargoapi.ArgoRolloutActionPromote,
+			},
+			assertions: func(t *testing.T, specPatch, statusPatch []byte, err error) {
+				require.NoError(t, err)
+				require.JSONEq(t, `{"spec":{"paused":false}}`, string(specPatch))
+				require.JSONEq(t, `{"status":{"pauseConditions":null}}`, string(statusPatch))
+			},
+		},
+		{
+			name:    "promote full",
+			rollout: newTestRollout(nil),
+			update: kargoapi.ArgoRolloutUpdate{
+				Action: kargoapi.ArgoRolloutActionPromoteFull,
+			},
+			assertions: func(t *testing.T, specPatch, statusPatch []byte, err error) {
+				require.NoError(t, err)
+				require.Nil(t, specPatch)
+				require.JSONEq(t, `{"status":{"promoteFull":true}}`, string(statusPatch))
+			},
+		},
+		{
+			name:    "abort",
+			rollout: newTestRollout(nil),
+			update: kargoapi.ArgoRolloutUpdate{
+				Action: kargoapi.ArgoRolloutActionAbort,
+			},
+			assertions: func(t *testing.T, specPatch, statusPatch []byte, err error) {
+				require.NoError(t, err)
+				require.Nil(t, specPatch)
+				require.JSONEq(t, `{"status":{"abort":true}}`, string(statusPatch))
+			},
+		},
+		{
+			name:    "unknown action",
+			rollout: newTestRollout(nil),
+			update: kargoapi.ArgoRolloutUpdate{
+				Action: "Bogus",
+			},
+			assertions: func(t *testing.T, _, _ []byte, err error) {
+				require.ErrorContains(t, err, "unknown action")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			specPatch, statusPatch, err := buildRolloutPatches(testCase.rollout, testCase.update)
+			testCase.assertions(t, specPatch, statusPatch, err)
+		})
+	}
+}
+
+func TestRolloutStatusToPromotionPhase(t *testing.T) {
+	testCases := []struct {
+		name          string
+		status        map[string]any
+		action        kargoapi.ArgoRolloutAction
+		expectedPhase kargoapi.PromotionPhase
+	}{
+		{
+			name: "update not yet observed",
+			status: map[string]any{
+				"observedGeneration": "4",
+				"phase":              "Healthy",
+			},
+			expectedPhase: kargoapi.PromotionPhaseRunning,
+		},
+		{
+			name: "progressing",
+			status: map[string]any{
+				"observedGeneration": "5",
+				"phase":              "Progressing",
+			},
+			expectedPhase: kargoapi.PromotionPhaseRunning,
+		},
+		{
+			name: "paused",
+			status: map[string]any{
+				"observedGeneration": "5",
+				"phase":              "Paused",
+			},
+			expectedPhase: kargoapi.PromotionPhaseSucceeded,
+		},
+		{
+			name: "healthy",
+			status: map[string]any{
+				"observedGeneration": "5",
+				"phase":              "Healthy",
+			},
+			expectedPhase: kargoapi.PromotionPhaseSucceeded,
+		},
+		{
+			name: "degraded",
+			status: map[string]any{
+				"observedGeneration": "5",
+				"phase":              "Degraded",
+			},
+			expectedPhase: kargoapi.PromotionPhaseFailed,
+		},
+		{
+			name: "aborted by someone else",
+			status: map[string]any{
+				"observedGeneration": "5",
+				"phase":              "Degraded",
+				"abort":              true,
+			},
+			action:        kargoapi.ArgoRolloutActionPromote,
+			expectedPhase: kargoapi.PromotionPhaseFailed,
+		},
+		{
+			name: "abort in progress",
+			status: map[string]any{
+				"observedGeneration": "5",
+				"phase":              "Progressing",
+				"abort":              true,
+			},
+			action:        kargoapi.ArgoRolloutActionAbort,
+			expectedPhase: kargoapi.PromotionPhaseRunning,
+		},
+		{
+			name: "abort completed",
+			status: map[string]any{
+				"observedGeneration": "5",
+				"phase":              "Degraded",
+				"abort":              true,
+			},
+			action:        kargoapi.ArgoRolloutActionAbort,
+			expectedPhase: kargoapi.PromotionPhaseSucceeded,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			phase, _ := rolloutStatusToPromotionPhase(
+				newTestRollout(testCase.status),
+				testCase.action,
+				5,
+			)
+			require.Equal(t, testCase.expectedPhase, phase)
+		})
+	}
+}
+
+func TestGetRollout(t *testing.T) {
+	rollout := newTestRollout(nil)
+	c := fake.NewClientBuilder().WithObjects(rollout).Build()
+
+	res, err := getRollout(context.Background(), c, "fake-namespace", "fake-rollout")
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, "fake-rollout", res.GetName())
+
+	res, err = getRollout(context.Background(), c, "fake-namespace", "nonexistent")
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
+
+// getTestClusterClient is a stand-in for argoRolloutsMechanism's
+// getClusterClientFn that returns no client at all.
+func getTestClusterClient(context.Context, string) (client.Client, error) {
+	return nil, nil
+}
+
+// newTestRollout returns an unstructured Rollout using a canary strategy that
+// permits mutation by the Stage "fake-stage" in the Project "fake-project".
+func newTestRollout(status map[string]any) *unstructured.Unstructured {
+	rollout := &unstructured.Unstructured{
+		Object: map[string]any{
+			"spec": map[string]any{
+				"strategy": map[string]any{
+					"canary": map[string]any{},
+				},
+			},
+		},
+	}
+	if status != nil {
+		rollout.Object["status"] = status
+	}
+	rollout.SetGroupVersionKind(rolloutGVK)
+	rollout.SetNamespace("fake-namespace")
+	rollout.SetName("fake-rollout")
+	rollout.SetAnnotations(map[string]string{
+		authorizedStageAnnotationKey: "fake-project:fake-stage",
+	})
+	return rollout
+}
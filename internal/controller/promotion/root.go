@@ -7,6 +7,7 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	libExec "github.com/akuity/kargo/internal/exec"
 )
 
 // Mechanism provides a consistent interface for all promotion mechanisms.
@@ -25,20 +26,24 @@ type Mechanism interface {
 }
 
 // NewMechanisms returns the entrypoint to a hierarchical tree of promotion
+// mechanisms. The provided limits apply to the processes spawned by those
 // mechanisms.
 func NewMechanisms(
+	kargoClient client.Client,
 	argocdClient client.Client,
 	credentialsDB credentials.Database,
+	limits libExec.Limits,
 ) Mechanism {
 	return newCompositeMechanism(
 		"promotion mechanisms",
 		newCompositeMechanism(
 			"Git-based promotion mechanisms",
-			newGenericGitMechanism(credentialsDB),
-			newKargoRenderMechanism(credentialsDB),
-			newKustomizeMechanism(credentialsDB),
-			newHelmMechanism(credentialsDB),
+			newGenericGitMechanism(kargoClient, credentialsDB),
+			newKargoRenderMechanism(kargoClient, credentialsDB, limits),
+			newKustomizeMechanism(kargoClient, credentialsDB, limits),
+			newHelmMechanism(kargoClient, credentialsDB, limits),
 		),
 		newArgoCDMechanism(argocdClient),
+		newArgoRolloutsMechanism(kargoClient, argocdClient),
 	)
 }
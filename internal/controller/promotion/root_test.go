@@ -9,12 +9,15 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	libExec "github.com/akuity/kargo/internal/exec"
 )
 
 func TestNewMechanisms(t *testing.T) {
 	promoMechs := NewMechanisms(
+		fake.NewClientBuilder().Build(),
 		fake.NewClientBuilder().Build(),
 		credentials.NewKubernetesDatabase(nil, credentials.KubernetesDatabaseConfig{}),
+		libExec.Limits{},
 	)
 	require.IsType(t, &compositeMechanism{}, promoMechs)
 }
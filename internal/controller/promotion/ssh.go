@@ -0,0 +1,227 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/expressions"
+)
+
+// defaultSSHPromotionHookTimeout is the maximum amount of time to wait for the
+// command executed by an SSHPromotionHook that does not specify a timeout to
+// complete.
+const defaultSSHPromotionHookTimeout = time.Minute
+
+// maxSSHPromotionHookOutput is the number of bytes of the output of the command
+// executed by an SSHPromotionHook that are retained.
+const maxSSHPromotionHookOutput = 4 << 10 // 4 KiB
+
+// sshCredentials are the credentials used to connect to the host of an
+// SSHPromotionHook.
+type sshCredentials struct {
+	username   string
+	privateKey []byte
+	knownHosts []byte
+}
+
+// getSSHCredentials returns the credentials stored in the specified Secret for
+// use by an SSHPromotionHook.
+func (h *hookRunner) getSSHCredentials(
+	ctx context.Context,
+	namespace string,
+	secretName string,
+) (*sshCredentials, error) {
+	secret := corev1.Secret{}
+	if err := h.kargoClient.Get(
+		ctx,
+		types.NamespacedName{
+			Namespace: namespace,
+			Name:      secretName,
+		},
+		&secret,
+	); err != nil {
+		return nil, fmt.Errorf(
+			"error getting SSH credentials Secret %q in namespace %q: %w",
+			secretName,
+			namespace,
+			err,
+		)
+	}
+	for _, key := range []string{
+		kargoapi.SSHPromotionHookUsernameSecretKey,
+		kargoapi.SSHPromotionHookPrivateKeySecretKey,
+		kargoapi.SSHPromotionHookKnownHostsSecretKey,
+	} {
+		if len(secret.Data[key]) == 0 {
+			return nil, fmt.Errorf(
+				"Secret %q in namespace %q has no %q key",
+				secretName,
+				namespace,
+				key,
+			)
+		}
+	}
+	return &sshCredentials{
+		username:   string(secret.Data[kargoapi.SSHPromotionHookUsernameSecretKey]),
+		privateKey: secret.Data[kargoapi.SSHPromotionHookPrivateKeySecretKey],
+		knownHosts: secret.Data[kargoapi.SSHPromotionHookKnownHostsSecretKey],
+	}, nil
+}
+
+// runSSHCommand executes the command described by the provided
+// SSHPromotionHook, evaluating any expressions it contains against the
+// provided environment, on the hook's host using the provided credentials. It
+// returns the last maxSSHPromotionHookOutput bytes of the command's combined
+// standard output and standard error. An error is returned if the command could
+// not be executed, did not complete in time, or exited with a non-zero status.
+func runSSHCommand(
+	ctx context.Context,
+	hook kargoapi.SSHPromotionHook,
+	creds sshCredentials,
+	env map[string]any,
+) (string, error) {
+	cmd, err := expressions.EvaluateTemplateToString(hook.Command, env)
+	if err != nil {
+		return "", fmt.Errorf("error evaluating command %q: %w", hook.Command, err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(creds.privateKey)
+	if err != nil {
+		return "", fmt.Errorf("error parsing SSH private key: %w", err)
+	}
+	hostKeyCallback, err := knownHostsCallback(creds.knownHosts)
+	if err != nil {
+		return "", err
+	}
+
+	addr := hook.Host
+	if _, _, err = net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "22")
+	}
+
+	timeout := defaultSSHPromotionHookTimeout
+	if hook.Timeout != nil {
+		timeout = hook.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("error connecting to %q: %w", addr, err)
+	}
+	// Closing the connection when the context is done interrupts the handshake
+	// and the command alike.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(
+		conn,
+		addr,
+		&ssh.ClientConfig{
+			User:            creds.username,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			HostKeyCallback: hostKeyCallback,
+		},
+	)
+	if err != nil {
+		_ = conn.Close()
+		return "", fmt.Errorf("error establishing SSH connection to %q: %w", addr, withContextErr(ctx, err))
+	}
+	client := ssh.NewClient(sshConn, chans, reqs)
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return "", fmt.Errorf("error opening SSH session with %q: %w", addr, withContextErr(ctx, err))
+	}
+	defer session.Close()
+
+	output := &tailBuffer{max: maxSSHPromotionHookOutput}
+	session.Stdout = output
+	session.Stderr = output
+	if err = session.Run(cmd); err != nil {
+		var exitErr *ssh.ExitError
+		if errors.As(err, &exitErr) {
+			return output.String(), fmt.Errorf(
+				"command executed on %q exited with status %d",
+				addr,
+				exitErr.ExitStatus(),
+			)
+		}
+		return output.String(), fmt.Errorf(
+			"error executing command on %q: %w",
+			addr,
+			withContextErr(ctx, err),
+		)
+	}
+	return output.String(), nil
+}
+
+// withContextErr returns the provided context's error, if any, in place of the
+// provided error, which is then likely to be a consequence of the former.
+func withContextErr(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// knownHostsCallback returns an ssh.HostKeyCallback that only accepts the host
+// keys listed in the provided contents of an OpenSSH known_hosts file.
+func knownHostsCallback(knownHosts []byte) (ssh.HostKeyCallback, error) {
+	// The knownhosts package only reads known_hosts files from disk.
+	dir, err := os.MkdirTemp("", "known-hosts-")
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "known_hosts")
+	if err = os.WriteFile(path, knownHosts, 0o600); err != nil {
+		return nil, fmt.Errorf("error writing known hosts: %w", err)
+	}
+	callback, err := knownhosts.New(path)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing known hosts: %w", err)
+	}
+	return callback, nil
+}
+
+// tailBuffer is an io.Writer that retains only the last max bytes written to
+// it. It is safe for concurrent use, so that a command's standard output and
+// standard error may both be written to it.
+type tailBuffer struct {
+	mu  sync.Mutex
+	max int
+	buf []byte
+}
+
+// Write implements io.Writer.
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.buf = append(t.buf, p...)
+	if excess := len(t.buf) - t.max; excess > 0 {
+		t.buf = append(t.buf[:0], t.buf[excess:]...)
+	}
+	return len(p), nil
+}
+
+// String returns the retained bytes as a string.
+func (t *tailBuffer) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return string(t.buf)
+}
@@ -0,0 +1,288 @@
+package promotion
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestGetSSHCredentials(t *testing.T) {
+	newSecret := func(data map[string]string) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "fake-project",
+				Name:      "vm-creds",
+			},
+			Data: map[string][]byte{},
+		}
+		for k, v := range data {
+			secret.Data[k] = []byte(v)
+		}
+		return secret
+	}
+	testCases := []struct {
+		name       string
+		secret     *corev1.Secret
+		assertions func(*testing.T, *sshCredentials, error)
+	}{
+		{
+			name: "Secret not found",
+			assertions: func(t *testing.T, _ *sshCredentials, err error) {
+				require.ErrorContains(t, err, "error getting SSH credentials Secret")
+			},
+		},
+		{
+			name: "known hosts missing",
+			secret: newSecret(map[string]string{
+				kargoapi.SSHPromotionHookUsernameSecretKey:   "deployer",
+				kargoapi.SSHPromotionHookPrivateKeySecretKey: "fake-key",
+			}),
+			assertions: func(t *testing.T, _ *sshCredentials, err error) {
+				require.ErrorContains(t, err, `has no "knownHosts" key`)
+			},
+		},
+		{
+			name: "success",
+			secret: newSecret(map[string]string{
+				kargoapi.SSHPromotionHookUsernameSecretKey:   "deployer",
+				kargoapi.SSHPromotionHookPrivateKeySecretKey: "fake-key",
+				kargoapi.SSHPromotionHookKnownHostsSecretKey: "fake-known-hosts",
+			}),
+			assertions: func(t *testing.T, creds *sshCredentials, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					&sshCredentials{
+						username:   "deployer",
+						privateKey: []byte("fake-key"),
+						knownHosts: []byte("fake-known-hosts"),
+					},
+					creds,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			clientBuilder := fake.NewClientBuilder()
+			if testCase.secret != nil {
+				clientBuilder = clientBuilder.WithObjects(testCase.secret)
+			}
+			h := &hookRunner{kargoClient: clientBuilder.Build()}
+			creds, err := h.getSSHCredentials(context.Background(), "fake-project", "vm-creds")
+			testCase.assertions(t, creds, err)
+		})
+	}
+}
+
+func TestRunSSHCommand(t *testing.T) {
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostKey)
+	require.NoError(t, err)
+	_, clientKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	clientSigner, err := ssh.NewSignerFromKey(clientKey)
+	require.NoError(t, err)
+	clientKeyPEM, err := ssh.MarshalPrivateKey(clientKey, "")
+	require.NoError(t, err)
+
+	addr := startTestSSHServer(t, hostSigner, clientSigner.PublicKey())
+
+	_, otherHostKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherHostSigner, err := ssh.NewSignerFromKey(otherHostKey)
+	require.NoError(t, err)
+
+	testCreds := sshCredentials{
+		username:   "deployer",
+		privateKey: pem.EncodeToMemory(clientKeyPEM),
+		knownHosts: []byte(knownhosts.Line([]string{addr}, hostSigner.PublicKey()) + "\n"),
+	}
+
+	testCases := []struct {
+		name       string
+		hook       kargoapi.SSHPromotionHook
+		creds      sshCredentials
+		assertions func(*testing.T, string, error)
+	}{
+		{
+			name: "success",
+			hook: kargoapi.SSHPromotionHook{
+				Host:    addr,
+				Command: "echo ${{ freight.name }}",
+			},
+			creds: testCreds,
+			assertions: func(t *testing.T, output string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "deployer ran: echo fake-freight\n", output)
+			},
+		},
+		{
+			name: "invalid command template",
+			hook: kargoapi.SSHPromotionHook{
+				Host:    addr,
+				Command: "echo ${{ freight.nonexistent.field }}",
+			},
+			creds: testCreds,
+			assertions: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, "error evaluating command")
+			},
+		},
+		{
+			name: "non-zero exit status",
+			hook: kargoapi.SSHPromotionHook{
+				Host:    addr,
+				Command: "fail",
+			},
+			creds: testCreds,
+			assertions: func(t *testing.T, output string, err error) {
+				require.ErrorContains(t, err, "exited with status 3")
+				require.Equal(t, "deployer ran: fail\n", output)
+			},
+		},
+		{
+			name: "unknown host key",
+			hook: kargoapi.SSHPromotionHook{
+				Host:    addr,
+				Command: "echo hello",
+			},
+			creds: sshCredentials{
+				username:   testCreds.username,
+				privateKey: testCreds.privateKey,
+				knownHosts: []byte(
+					knownhosts.Line([]string{addr}, otherHostSigner.PublicKey()) + "\n",
+				),
+			},
+			assertions: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, "error establishing SSH connection")
+				require.ErrorContains(t, err, "key mismatch")
+			},
+		},
+		{
+			name: "timeout",
+			hook: kargoapi.SSHPromotionHook{
+				Host:    addr,
+				Command: "hang",
+				Timeout: &metav1.Duration{Duration: 100 * time.Millisecond},
+			},
+			creds: testCreds,
+			assertions: func(t *testing.T, _ string, err error) {
+				require.ErrorIs(t, err, context.DeadlineExceeded)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			output, err := runSSHCommand(
+				context.Background(),
+				testCase.hook,
+				testCase.creds,
+				map[string]any{
+					"freight": map[string]any{"name": "fake-freight"},
+				},
+			)
+			testCase.assertions(t, output, err)
+		})
+	}
+}
+
+func TestTailBuffer(t *testing.T) {
+	buf := &tailBuffer{max: 8}
+	_, err := buf.Write([]byte("hello "))
+	require.NoError(t, err)
+	require.Equal(t, "hello ", buf.String())
+	_, err = buf.Write([]byte("world"))
+	require.NoError(t, err)
+	require.Equal(t, "lo world", buf.String())
+}
+
+// startTestSSHServer starts an SSH server that accepts connections from
+// clients authenticating with the provided public key and returns its
+// address. The server echoes back the name of the user and the command it was
+// asked to execute. It fails the command "fail" with exit status 3 and never
+// completes the command "hang".
+func startTestSSHServer(t *testing.T, hostSigner ssh.Signer, clientKey ssh.PublicKey) string {
+	cfg := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(clientKey.Marshal()) {
+				return nil, ssh.ErrNoAuth
+			}
+			return nil, nil
+		},
+	}
+	cfg.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestSSHConn(conn, cfg)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func serveTestSSHConn(conn net.Conn, cfg *ssh.ServerConfig) {
+	defer conn.Close()
+	sshConn, chans, reqs, err := ssh.NewServerConn(conn, cfg)
+	if err != nil {
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(reqs)
+	for newChan := range chans {
+		if newChan.ChannelType() != "session" {
+			_ = newChan.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		ch, chReqs, err := newChan.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer ch.Close()
+			for req := range chReqs {
+				if req.Type != "exec" || len(req.Payload) < 4 {
+					_ = req.Reply(false, nil)
+					continue
+				}
+				_ = req.Reply(true, nil)
+				cmd := string(req.Payload[4:])
+				if cmd == "hang" {
+					continue
+				}
+				_, _ = ch.Write([]byte(sshConn.User() + " ran: " + cmd + "\n"))
+				var status uint32
+				if strings.HasPrefix(cmd, "fail") {
+					status = 3
+				}
+				payload := make([]byte, 4)
+				binary.BigEndian.PutUint32(payload, status)
+				_, _ = ch.SendRequest("exit-status", false, payload)
+				return
+			}
+		}()
+	}
+}
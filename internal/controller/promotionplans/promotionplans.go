@@ -0,0 +1,605 @@
+package promotionplans
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/controller"
+	"github.com/akuity/kargo/internal/kargo"
+	"github.com/akuity/kargo/internal/kubeclient"
+	libEvent "github.com/akuity/kargo/internal/kubernetes/event"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+const (
+	// maintenanceRequeueInterval is how long to wait before checking again
+	// whether a Project's maintenance mode, which pauses PromotionPlans, has
+	// ended.
+	maintenanceRequeueInterval = time.Minute
+	// maxStageNamePrefixLength is the maximum length of the Stage name used as
+	// the prefix of the name of a Promotion created by a PromotionPlan:
+	// 253 - 1 (.) - 40 (sha1) - 1 (.) - 7 (sha) = 204
+	maxStageNamePrefixLength = 204
+)
+
+// reconciler reconciles PromotionPlan resources by promoting their Freight to
+// each of their Stages in turn.
+type reconciler struct {
+	kargoClient client.Client
+	recorder    record.EventRecorder
+
+	controllerName string
+
+	// The following behaviors are overridable for testing purposes:
+
+	nowFn func() time.Time
+
+	getFreightFn func(
+		context.Context,
+		client.Client,
+		types.NamespacedName,
+	) (*kargoapi.Freight, error)
+
+	getStageFn func(
+		context.Context,
+		client.Client,
+		types.NamespacedName,
+	) (*kargoapi.Stage, error)
+
+	listStagesFn func(context.Context, client.ObjectList, ...client.ListOption) error
+
+	getProjectFn func(context.Context, client.Client, string) (*kargoapi.Project, error)
+
+	getPromotionFn func(
+		context.Context,
+		client.Client,
+		types.NamespacedName,
+	) (*kargoapi.Promotion, error)
+
+	listPromosFn func(context.Context, client.ObjectList, ...client.ListOption) error
+
+	createPromotionFn func(context.Context, client.Object, ...client.CreateOption) error
+}
+
+// SetupReconcilerWithManager initializes a reconciler for PromotionPlan
+// resources and registers it with the provided Manager. It relies on the
+// Stages reconciler having been registered with the same Manager first, as
+// that reconciler indexes Promotions by Stage and Freight.
+func SetupReconcilerWithManager(
+	ctx context.Context,
+	mgr manager.Manager,
+	shardName string,
+) error {
+	controllerName := "promotion-plan-controller"
+	if shardName != "" {
+		controllerName += "-" + shardName
+	}
+
+	shardPredicate, err := controller.GetShardPredicate(shardName)
+	if err != nil {
+		return fmt.Errorf("error creating shard selector predicate: %w", err)
+	}
+
+	r := newReconciler(
+		mgr.GetClient(),
+		libEvent.NewRecorder(ctx, mgr.GetScheme(), mgr.GetClient(), controllerName),
+		controllerName,
+	)
+
+	if err = ctrl.NewControllerManagedBy(mgr).
+		For(
+			&kargoapi.PromotionPlan{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}, shardPredicate),
+		).
+		// Changes to the Promotions, Freight, and Stages a PromotionPlan
+		// depends on may permit it to progress.
+		Watches(
+			&kargoapi.Promotion{},
+			handler.EnqueueRequestsFromMapFunc(r.activePlansInNamespace),
+		).
+		Watches(
+			&kargoapi.Freight{},
+			handler.EnqueueRequestsFromMapFunc(r.activePlansInNamespace),
+		).
+		Watches(
+			&kargoapi.Stage{},
+			handler.EnqueueRequestsFromMapFunc(r.activePlansInNamespace),
+		).
+		WithOptions(controller.CommonOptions()).
+		Complete(r); err != nil {
+		return fmt.Errorf("error building PromotionPlan reconciler: %w", err)
+	}
+	return nil
+}
+
+func newReconciler(
+	kargoClient client.Client,
+	recorder record.EventRecorder,
+	controllerName string,
+) *reconciler {
+	return &reconciler{
+		kargoClient:       kargoClient,
+		recorder:          recorder,
+		controllerName:    controllerName,
+		nowFn:             time.Now,
+		getFreightFn:      kargoapi.GetFreight,
+		getStageFn:        kargoapi.GetStage,
+		listStagesFn:      kargoClient.List,
+		getProjectFn:      kargoapi.GetProject,
+		getPromotionFn:    kargoapi.GetPromotion,
+		listPromosFn:      kargoClient.List,
+		createPromotionFn: kargoClient.Create,
+	}
+}
+
+// activePlansInNamespace returns reconcile requests for all PromotionPlans in
+// the namespace of the provided object that are not yet in a terminal phase.
+func (r *reconciler) activePlansInNamespace(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	plans := kargoapi.PromotionPlanList{}
+	if err := r.kargoClient.List(
+		ctx,
+		&plans,
+		client.InNamespace(obj.GetNamespace()),
+	); err != nil {
+		logging.LoggerFromContext(ctx).Errorf(
+			"error listing PromotionPlans in namespace %q: %s",
+			obj.GetNamespace(),
+			err,
+		)
+		return nil
+	}
+	var reqs []reconcile.Request
+	for _, plan := range plans.Items {
+		if plan.Status.Phase.IsTerminal() {
+			continue
+		}
+		reqs = append(reqs, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: plan.Namespace,
+				Name:      plan.Name,
+			},
+		})
+	}
+	return reqs
+}
+
+// Reconcile is part of the main Kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *reconciler) Reconcile(
+	ctx context.Context,
+	req reconcile.Request,
+) (reconcile.Result, error) {
+	logger := logging.LoggerFromContext(ctx).WithFields(log.Fields{
+		"namespace":     req.NamespacedName.Namespace,
+		"promotionPlan": req.NamespacedName.Name,
+	})
+	ctx = logging.ContextWithLogger(ctx, logger)
+	logger.Debug("reconciling PromotionPlan")
+
+	plan := &kargoapi.PromotionPlan{}
+	if err := r.kargoClient.Get(ctx, req.NamespacedName, plan); err != nil {
+		// Ignore if not found. This can happen if the PromotionPlan was deleted
+		// after the current reconciliation request was issued.
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	if plan.Status.Phase.IsTerminal() {
+		logger.Debug("PromotionPlan is already in a terminal phase")
+		return reconcile.Result{}, nil
+	}
+
+	newStatus, requeueAfter, err := r.syncPlan(ctx, plan)
+	if err != nil {
+		newStatus.Message = err.Error()
+		logger.Errorf("error syncing PromotionPlan: %s", err)
+	}
+
+	updateErr := kubeclient.PatchStatus(
+		ctx,
+		r.kargoClient,
+		plan,
+		func(status *kargoapi.PromotionPlanStatus) {
+			*status = newStatus
+		},
+	)
+	if updateErr != nil {
+		logger.Errorf("error updating PromotionPlan status: %s", updateErr)
+	}
+	if err == nil {
+		err = updateErr
+	}
+	logger.Debug("done reconciling PromotionPlan")
+
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if newStatus.Phase.IsTerminal() {
+		return reconcile.Result{}, nil
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// syncPlan determines the Stages of the provided PromotionPlan, if that has
+// not already been done, and advances the first of its steps that has not yet
+// succeeded. It returns the PromotionPlan's new status and how long to wait
+// before checking on its progress again if no relevant change is observed in
+// the meantime.
+func (r *reconciler) syncPlan(
+	ctx context.Context,
+	plan *kargoapi.PromotionPlan,
+) (kargoapi.PromotionPlanStatus, time.Duration, error) {
+	status := *plan.Status.DeepCopy()
+	if status.Phase == "" {
+		status.Phase = kargoapi.PromotionPlanPhasePending
+	}
+	requeueAfter := 5 * time.Minute
+
+	freight, err := r.getFreightFn(
+		ctx,
+		r.kargoClient,
+		types.NamespacedName{
+			Namespace: plan.Namespace,
+			Name:      plan.Spec.Freight,
+		},
+	)
+	if err != nil {
+		return status, requeueAfter, err
+	}
+	if freight == nil {
+		r.fail(&status, "Freight %q not found", plan.Spec.Freight)
+		return status, requeueAfter, nil
+	}
+	if freight.IsBlocked() {
+		r.fail(
+			&status,
+			"Freight %q is blocked: %s",
+			freight.Name,
+			freight.Status.Blocked.Reason,
+		)
+		return status, requeueAfter, nil
+	}
+
+	if len(status.Steps) == 0 {
+		stages := kargoapi.StageList{}
+		if err = r.listStagesFn(
+			ctx,
+			&stages,
+			client.InNamespace(plan.Namespace),
+		); err != nil {
+			return status, requeueAfter, fmt.Errorf(
+				"error listing Stages in namespace %q: %w",
+				plan.Namespace,
+				err,
+			)
+		}
+		path, err := kargo.PromotionPlanStages(freight, stages.Items, plan.Spec.Stage)
+		if err != nil {
+			r.fail(&status, "%s", err)
+			return status, requeueAfter, nil
+		}
+		status.Steps = make([]kargoapi.PromotionPlanStep, len(path))
+		for i, stage := range path {
+			status.Steps[i] = kargoapi.PromotionPlanStep{
+				Stage: stage,
+				Phase: kargoapi.PromotionPlanStepPhasePending,
+			}
+		}
+		status.Phase = kargoapi.PromotionPlanPhaseRunning
+	}
+
+	for i := range status.Steps {
+		step := &status.Steps[i]
+		if step.Phase == kargoapi.PromotionPlanStepPhaseSucceeded {
+			continue
+		}
+		if step.Phase == kargoapi.PromotionPlanStepPhasePending {
+			project, err := r.getProjectFn(ctx, r.kargoClient, plan.Namespace)
+			if err != nil {
+				return status, requeueAfter, fmt.Errorf(
+					"error finding Project %q: %w",
+					plan.Namespace,
+					err,
+				)
+			}
+			if project != nil {
+				if m := project.ActiveMaintenanceMode(r.nowFn()); m != nil {
+					status.Message = fmt.Sprintf("PromotionPlan is paused: %s", m.Describe())
+					return status, maintenanceRequeueInterval, nil
+				}
+			}
+		}
+		message, err := r.syncStep(ctx, plan, freight, step)
+		if err != nil {
+			return status, requeueAfter, err
+		}
+		if step.Phase == kargoapi.PromotionPlanStepPhaseFailed {
+			r.fail(&status, "%s", message)
+			return status, requeueAfter, nil
+		}
+		if step.Phase != kargoapi.PromotionPlanStepPhaseSucceeded {
+			status.Message = message
+			return status, requeueAfter, nil
+		}
+	}
+
+	status.Phase = kargoapi.PromotionPlanPhaseSucceeded
+	status.Message = fmt.Sprintf(
+		"Freight %q was promoted to and verified in Stage %q",
+		freight.Name,
+		plan.Spec.Stage,
+	)
+	status.FinishedAt = &metav1.Time{Time: r.nowFn()}
+	return status, requeueAfter, nil
+}
+
+// syncStep advances the provided step of the provided PromotionPlan as far as
+// currently possible by updating it in place. It returns a message describing
+// the step's progress.
+func (r *reconciler) syncStep(
+	ctx context.Context,
+	plan *kargoapi.PromotionPlan,
+	freight *kargoapi.Freight,
+	step *kargoapi.PromotionPlanStep,
+) (string, error) {
+	stage, err := r.getStageFn(
+		ctx,
+		r.kargoClient,
+		types.NamespacedName{
+			Namespace: plan.Namespace,
+			Name:      step.Stage,
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	if stage == nil {
+		step.Phase = kargoapi.PromotionPlanStepPhaseFailed
+		return fmt.Sprintf("Stage %q not found", step.Stage), nil
+	}
+	_, verified := freight.Status.VerifiedIn[stage.Name]
+
+	if step.Phase == kargoapi.PromotionPlanStepPhasePending {
+		switch {
+		case stage.Spec.PromotionMechanisms == nil:
+			// Freight is verified in a control flow Stage as soon as it is
+			// verified in one of the Stage's upstream Stages, so there is
+			// nothing to promote.
+			step.Phase = kargoapi.PromotionPlanStepPhaseVerifying
+		case verified && stage.Status.CurrentFreight != nil &&
+			stage.Status.CurrentFreight.Name == freight.Name:
+			step.Phase = kargoapi.PromotionPlanStepPhaseSucceeded
+			return fmt.Sprintf(
+				"Stage %q already has Freight %q",
+				stage.Name,
+				freight.Name,
+			), nil
+		default:
+			if err = r.startPromotion(ctx, plan, freight, stage, step); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if step.Phase == kargoapi.PromotionPlanStepPhasePromoting {
+		promo, err := r.getPromotionFn(
+			ctx,
+			r.kargoClient,
+			types.NamespacedName{
+				Namespace: plan.Namespace,
+				Name:      step.Promotion,
+			},
+		)
+		if err != nil {
+			return "", err
+		}
+		if promo == nil {
+			step.Phase = kargoapi.PromotionPlanStepPhaseFailed
+			return fmt.Sprintf("Promotion %q not found", step.Promotion), nil
+		}
+		switch promo.Status.Phase {
+		case kargoapi.PromotionPhaseSucceeded:
+			step.Phase = kargoapi.PromotionPlanStepPhaseVerifying
+		case kargoapi.PromotionPhaseFailed, kargoapi.PromotionPhaseErrored:
+			step.Phase = kargoapi.PromotionPlanStepPhaseFailed
+			return fmt.Sprintf(
+				"Promotion %q to Stage %q %s: %s",
+				promo.Name,
+				stage.Name,
+				strings.ToLower(string(promo.Status.Phase)),
+				promo.Status.Message,
+			), nil
+		default:
+			return fmt.Sprintf(
+				"promoting Freight %q to Stage %q",
+				freight.Name,
+				stage.Name,
+			), nil
+		}
+	}
+
+	// The step is verifying.
+	if stage.Spec.PromotionMechanisms != nil {
+		current := stage.Status.CurrentFreight
+		if current == nil || current.Name != freight.Name {
+			step.Phase = kargoapi.PromotionPlanStepPhaseFailed
+			return fmt.Sprintf(
+				"Freight %q was replaced in Stage %q before it could be verified",
+				freight.Name,
+				stage.Name,
+			), nil
+		}
+		if info := current.VerificationInfo; info != nil {
+			switch info.Phase {
+			case kargoapi.VerificationPhaseFailed,
+				kargoapi.VerificationPhaseError,
+				kargoapi.VerificationPhaseAborted:
+				step.Phase = kargoapi.PromotionPlanStepPhaseFailed
+				return fmt.Sprintf(
+					"verification of Freight %q in Stage %q %s: %s",
+					freight.Name,
+					stage.Name,
+					strings.ToLower(string(info.Phase)),
+					info.Message,
+				), nil
+			}
+		}
+	}
+	if verified {
+		step.Phase = kargoapi.PromotionPlanStepPhaseSucceeded
+		return fmt.Sprintf(
+			"Freight %q was verified in Stage %q",
+			freight.Name,
+			stage.Name,
+		), nil
+	}
+	return fmt.Sprintf(
+		"waiting for Freight %q to be verified in Stage %q",
+		freight.Name,
+		stage.Name,
+	), nil
+}
+
+// startPromotion promotes the provided Freight to the provided Stage and
+// records the Promotion in the provided step. If a Promotion of the Freight to
+// the Stage is already in progress, e.g. because the Stage auto-promoted the
+// Freight, that Promotion is used instead of creating a new one.
+func (r *reconciler) startPromotion(
+	ctx context.Context,
+	plan *kargoapi.PromotionPlan,
+	freight *kargoapi.Freight,
+	stage *kargoapi.Stage,
+	step *kargoapi.PromotionPlanStep,
+) error {
+	logger := logging.LoggerFromContext(ctx).WithField("stage", stage.Name)
+
+	promos := kargoapi.PromotionList{}
+	if err := r.listPromosFn(
+		ctx,
+		&promos,
+		&client.ListOptions{
+			Namespace: stage.Namespace,
+			FieldSelector: fields.Set(
+				map[string]string{
+					kubeclient.PromotionsByStageAndFreightIndexField: kubeclient.
+						StageAndFreightKey(stage.Name, freight.Name),
+				},
+			).AsSelector(),
+		},
+	); err != nil {
+		return fmt.Errorf(
+			"error listing existing Promotions for Freight %q in namespace %q: %w",
+			freight.Name,
+			stage.Namespace,
+			err,
+		)
+	}
+	for _, promo := range promos.Items {
+		if !promo.Status.Phase.IsTerminal() {
+			logger.WithField("promotion", promo.Name).
+				Debug("Promotion of Freight is already in progress")
+			step.Promotion = promo.Name
+			step.Phase = kargoapi.PromotionPlanStepPhasePromoting
+			return nil
+		}
+	}
+
+	promo := kargo.NewPromotion(ctx, *stage, freight.Name)
+	// The Promotion is named deterministically, so that if recording it in the
+	// PromotionPlan's status fails, the next attempt to start this step finds
+	// the Promotion that was already created instead of creating another one.
+	promo.Name = promotionName(plan, stage.Name, freight.Name)
+	// Attribute the Promotion to whoever requested the PromotionPlan.
+	actor := kargoapi.FormatEventControllerActor(r.controllerName)
+	if createActor, ok := plan.Annotations[kargoapi.AnnotationKeyCreateActor]; ok {
+		promo.Annotations[kargoapi.AnnotationKeyCreateActor] = createActor
+		actor = createActor
+	}
+	if err := r.createPromotionFn(ctx, &promo); apierrors.IsAlreadyExists(err) {
+		logger.WithField("promotion", promo.Name).
+			Debug("Promotion was already created for this step")
+		step.Promotion = promo.Name
+		step.Phase = kargoapi.PromotionPlanStepPhasePromoting
+		return nil
+	} else if err != nil {
+		return fmt.Errorf(
+			"error creating Promotion of Stage %q in namespace %q to Freight %q: %w",
+			stage.Name,
+			stage.Namespace,
+			freight.Name,
+			err,
+		)
+	}
+
+	r.recorder.AnnotatedEventf(
+		&promo,
+		kargoapi.NewPromotionEventAnnotations(ctx, actor, &promo, freight),
+		corev1.EventTypeNormal,
+		kargoapi.EventReasonPromotionCreated,
+		"Promoted Freight for Stage %q as part of PromotionPlan %q",
+		promo.Spec.Stage,
+		plan.Name,
+	)
+
+	logger.WithField("promotion", promo.Name).Debug("created Promotion")
+	step.Promotion = promo.Name
+	step.Phase = kargoapi.PromotionPlanStepPhasePromoting
+	return nil
+}
+
+// promotionName returns the name of the Promotion of the specified Freight to
+// the specified Stage that is created by the provided PromotionPlan. The name
+// is derived from the PromotionPlan's UID and the Stage's name, so that it is
+// the same every time the corresponding step is started.
+func promotionName(
+	plan *kargoapi.PromotionPlan,
+	stage string,
+	freight string,
+) string {
+	shortHash := freight
+	if len(shortHash) > 7 {
+		shortHash = freight[0:7]
+	}
+	shortStageName := stage
+	if len(shortStageName) > maxStageNamePrefixLength {
+		shortStageName = shortStageName[0:maxStageNamePrefixLength]
+	}
+	return strings.ToLower(fmt.Sprintf(
+		"%s.%x.%s",
+		shortStageName,
+		sha1.Sum([]byte(string(plan.UID)+"/"+stage)),
+		shortHash,
+	))
+}
+
+// fail marks the provided PromotionPlan status as failed with the provided
+// message.
+func (r *reconciler) fail(
+	status *kargoapi.PromotionPlanStatus,
+	format string,
+	args ...any,
+) {
+	status.Phase = kargoapi.PromotionPlanPhaseFailed
+	status.Message = fmt.Sprintf(format, args...)
+	status.FinishedAt = &metav1.Time{Time: r.nowFn()}
+}
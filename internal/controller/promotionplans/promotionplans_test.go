@@ -0,0 +1,535 @@
+package promotionplans
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/kubeclient"
+	fakeevent "github.com/akuity/kargo/internal/kubernetes/event/fake"
+)
+
+const testNamespace = "fake-namespace"
+
+func TestNewReconciler(t *testing.T) {
+	kargoClient := fake.NewClientBuilder().Build()
+	r := newReconciler(kargoClient, &fakeevent.EventRecorder{}, "fake-controller")
+	require.NotNil(t, r.kargoClient)
+	require.NotNil(t, r.recorder)
+	require.Equal(t, "fake-controller", r.controllerName)
+	require.NotNil(t, r.nowFn)
+	require.NotNil(t, r.getFreightFn)
+	require.NotNil(t, r.getStageFn)
+	require.NotNil(t, r.listStagesFn)
+	require.NotNil(t, r.getProjectFn)
+	require.NotNil(t, r.getPromotionFn)
+	require.NotNil(t, r.listPromosFn)
+	require.NotNil(t, r.createPromotionFn)
+}
+
+func TestReconcile(t *testing.T) {
+	testPlan := &kargoapi.PromotionPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "fake-plan",
+			Annotations: map[string]string{
+				kargoapi.AnnotationKeyCreateActor: "email:alice@example.com",
+			},
+		},
+		Spec: kargoapi.PromotionPlanSpec{
+			Freight: "fake-freight",
+			Stage:   "prod",
+		},
+	}
+	withStatus := func(status kargoapi.PromotionPlanStatus) *kargoapi.PromotionPlan {
+		plan := testPlan.DeepCopy()
+		plan.Status = status
+		return plan
+	}
+	newFreight := func(verifiedIn ...string) *kargoapi.Freight {
+		freight := &kargoapi.Freight{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      "fake-freight",
+			},
+			Warehouse: "fake-warehouse",
+		}
+		if len(verifiedIn) > 0 {
+			freight.Status.VerifiedIn = map[string]kargoapi.VerifiedStage{}
+			for _, stage := range verifiedIn {
+				freight.Status.VerifiedIn[stage] = kargoapi.VerifiedStage{}
+			}
+		}
+		return freight
+	}
+	newStage := func(
+		name string,
+		subs kargoapi.Subscriptions,
+		current *kargoapi.FreightReference,
+	) *kargoapi.Stage {
+		return &kargoapi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      name,
+			},
+			Spec: kargoapi.StageSpec{
+				Subscriptions:       subs,
+				PromotionMechanisms: &kargoapi.PromotionMechanisms{},
+			},
+			Status: kargoapi.StageStatus{
+				CurrentFreight: current,
+			},
+		}
+	}
+	testStage := newStage("test", kargoapi.Subscriptions{Warehouse: "fake-warehouse"}, nil)
+	prodStage := newStage(
+		"prod",
+		kargoapi.Subscriptions{
+			UpstreamStages: []kargoapi.StageSubscription{{Name: "test"}},
+		},
+		nil,
+	)
+	newPromotion := func(stage string, phase kargoapi.PromotionPhase) *kargoapi.Promotion {
+		return &kargoapi.Promotion{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      stage + "-promotion",
+			},
+			Spec: kargoapi.PromotionSpec{
+				Stage:   stage,
+				Freight: "fake-freight",
+			},
+			Status: kargoapi.PromotionStatus{
+				Phase:   phase,
+				Message: "something went wrong",
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		objects    []client.Object
+		assertions func(*testing.T, client.Client, reconcile.Result, error)
+	}{
+		{
+			name: "Freight not found",
+			objects: []client.Object{
+				testPlan.DeepCopy(),
+			},
+			assertions: func(t *testing.T, c client.Client, _ reconcile.Result, err error) {
+				require.NoError(t, err)
+				plan := getPlan(t, c)
+				require.Equal(t, kargoapi.PromotionPlanPhaseFailed, plan.Status.Phase)
+				require.Contains(t, plan.Status.Message, "not found")
+				require.NotNil(t, plan.Status.FinishedAt)
+			},
+		},
+		{
+			name: "Freight cannot reach Stage",
+			objects: []client.Object{
+				testPlan.DeepCopy(),
+				newFreight(),
+				prodStage.DeepCopy(),
+			},
+			assertions: func(t *testing.T, c client.Client, _ reconcile.Result, err error) {
+				require.NoError(t, err)
+				plan := getPlan(t, c)
+				require.Equal(t, kargoapi.PromotionPlanPhaseFailed, plan.Status.Phase)
+				require.Contains(t, plan.Status.Message, "cannot reach Stage")
+			},
+		},
+		{
+			name: "first step is started",
+			objects: []client.Object{
+				testPlan.DeepCopy(),
+				newFreight(),
+				testStage.DeepCopy(),
+				prodStage.DeepCopy(),
+			},
+			assertions: func(t *testing.T, c client.Client, res reconcile.Result, err error) {
+				require.NoError(t, err)
+				require.NotZero(t, res.RequeueAfter)
+				plan := getPlan(t, c)
+				require.Equal(t, kargoapi.PromotionPlanPhaseRunning, plan.Status.Phase)
+				require.Len(t, plan.Status.Steps, 2)
+				require.Equal(t, "test", plan.Status.Steps[0].Stage)
+				require.Equal(
+					t,
+					kargoapi.PromotionPlanStepPhasePromoting,
+					plan.Status.Steps[0].Phase,
+				)
+				require.NotEmpty(t, plan.Status.Steps[0].Promotion)
+				require.Equal(t, "prod", plan.Status.Steps[1].Stage)
+				require.Equal(
+					t,
+					kargoapi.PromotionPlanStepPhasePending,
+					plan.Status.Steps[1].Phase,
+				)
+
+				promo := &kargoapi.Promotion{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{
+						Namespace: testNamespace,
+						Name:      plan.Status.Steps[0].Promotion,
+					},
+					promo,
+				))
+				require.Equal(t, "test", promo.Spec.Stage)
+				require.Equal(t, "fake-freight", promo.Spec.Freight)
+				require.Equal(
+					t,
+					"email:alice@example.com",
+					promo.Annotations[kargoapi.AnnotationKeyCreateActor],
+				)
+			},
+		},
+		{
+			name: "existing Promotion is adopted",
+			objects: []client.Object{
+				testPlan.DeepCopy(),
+				newFreight(),
+				testStage.DeepCopy(),
+				prodStage.DeepCopy(),
+				newPromotion("test", kargoapi.PromotionPhaseRunning),
+			},
+			assertions: func(t *testing.T, c client.Client, _ reconcile.Result, err error) {
+				require.NoError(t, err)
+				plan := getPlan(t, c)
+				require.Equal(t, "test-promotion", plan.Status.Steps[0].Promotion)
+				promos := kargoapi.PromotionList{}
+				require.NoError(t, c.List(context.Background(), &promos))
+				require.Len(t, promos.Items, 1)
+			},
+		},
+		{
+			name: "Promotion created by an earlier attempt is reused",
+			objects: []client.Object{
+				testPlan.DeepCopy(),
+				newFreight(),
+				newStage(
+					"test",
+					kargoapi.Subscriptions{Warehouse: "fake-warehouse"},
+					&kargoapi.FreightReference{Name: "fake-freight"},
+				),
+				prodStage.DeepCopy(),
+				func() *kargoapi.Promotion {
+					// The Promotion has already succeeded, so it is not found to
+					// be in progress.
+					promo := newPromotion("test", kargoapi.PromotionPhaseSucceeded)
+					promo.Name = promotionName(testPlan, "test", "fake-freight")
+					return promo
+				}(),
+			},
+			assertions: func(t *testing.T, c client.Client, _ reconcile.Result, err error) {
+				require.NoError(t, err)
+				plan := getPlan(t, c)
+				require.Equal(
+					t,
+					promotionName(testPlan, "test", "fake-freight"),
+					plan.Status.Steps[0].Promotion,
+				)
+				require.Equal(
+					t,
+					kargoapi.PromotionPlanStepPhaseVerifying,
+					plan.Status.Steps[0].Phase,
+				)
+				promos := kargoapi.PromotionList{}
+				require.NoError(t, c.List(context.Background(), &promos))
+				require.Len(t, promos.Items, 1)
+			},
+		},
+		{
+			name: "Promotion failed",
+			objects: []client.Object{
+				withStatus(kargoapi.PromotionPlanStatus{
+					Phase: kargoapi.PromotionPlanPhaseRunning,
+					Steps: []kargoapi.PromotionPlanStep{
+						{
+							Stage:     "test",
+							Phase:     kargoapi.PromotionPlanStepPhasePromoting,
+							Promotion: "test-promotion",
+						},
+						{Stage: "prod", Phase: kargoapi.PromotionPlanStepPhasePending},
+					},
+				}),
+				newFreight(),
+				testStage.DeepCopy(),
+				prodStage.DeepCopy(),
+				newPromotion("test", kargoapi.PromotionPhaseFailed),
+			},
+			assertions: func(t *testing.T, c client.Client, res reconcile.Result, err error) {
+				require.NoError(t, err)
+				require.Zero(t, res.RequeueAfter)
+				plan := getPlan(t, c)
+				require.Equal(t, kargoapi.PromotionPlanPhaseFailed, plan.Status.Phase)
+				require.Equal(
+					t,
+					kargoapi.PromotionPlanStepPhaseFailed,
+					plan.Status.Steps[0].Phase,
+				)
+				require.Contains(t, plan.Status.Message, "something went wrong")
+			},
+		},
+		{
+			name: "Promotion succeeded but Freight not yet verified",
+			objects: []client.Object{
+				withStatus(kargoapi.PromotionPlanStatus{
+					Phase: kargoapi.PromotionPlanPhaseRunning,
+					Steps: []kargoapi.PromotionPlanStep{
+						{
+							Stage:     "test",
+							Phase:     kargoapi.PromotionPlanStepPhasePromoting,
+							Promotion: "test-promotion",
+						},
+						{Stage: "prod", Phase: kargoapi.PromotionPlanStepPhasePending},
+					},
+				}),
+				newFreight(),
+				newStage(
+					"test",
+					kargoapi.Subscriptions{Warehouse: "fake-warehouse"},
+					&kargoapi.FreightReference{Name: "fake-freight"},
+				),
+				prodStage.DeepCopy(),
+				newPromotion("test", kargoapi.PromotionPhaseSucceeded),
+			},
+			assertions: func(t *testing.T, c client.Client, _ reconcile.Result, err error) {
+				require.NoError(t, err)
+				plan := getPlan(t, c)
+				require.Equal(t, kargoapi.PromotionPlanPhaseRunning, plan.Status.Phase)
+				require.Equal(
+					t,
+					kargoapi.PromotionPlanStepPhaseVerifying,
+					plan.Status.Steps[0].Phase,
+				)
+				require.Contains(t, plan.Status.Message, "waiting for Freight")
+			},
+		},
+		{
+			name: "verification failed",
+			objects: []client.Object{
+				withStatus(kargoapi.PromotionPlanStatus{
+					Phase: kargoapi.PromotionPlanPhaseRunning,
+					Steps: []kargoapi.PromotionPlanStep{
+						{
+							Stage:     "test",
+							Phase:     kargoapi.PromotionPlanStepPhaseVerifying,
+							Promotion: "test-promotion",
+						},
+						{Stage: "prod", Phase: kargoapi.PromotionPlanStepPhasePending},
+					},
+				}),
+				newFreight(),
+				newStage(
+					"test",
+					kargoapi.Subscriptions{Warehouse: "fake-warehouse"},
+					&kargoapi.FreightReference{
+						Name: "fake-freight",
+						VerificationInfo: &kargoapi.VerificationInfo{
+							Phase:   kargoapi.VerificationPhaseFailed,
+							Message: "analysis failed",
+						},
+					},
+				),
+				prodStage.DeepCopy(),
+			},
+			assertions: func(t *testing.T, c client.Client, _ reconcile.Result, err error) {
+				require.NoError(t, err)
+				plan := getPlan(t, c)
+				require.Equal(t, kargoapi.PromotionPlanPhaseFailed, plan.Status.Phase)
+				require.Contains(t, plan.Status.Message, "analysis failed")
+			},
+		},
+		{
+			name: "Freight replaced before verification",
+			objects: []client.Object{
+				withStatus(kargoapi.PromotionPlanStatus{
+					Phase: kargoapi.PromotionPlanPhaseRunning,
+					Steps: []kargoapi.PromotionPlanStep{
+						{
+							Stage:     "test",
+							Phase:     kargoapi.PromotionPlanStepPhaseVerifying,
+							Promotion: "test-promotion",
+						},
+						{Stage: "prod", Phase: kargoapi.PromotionPlanStepPhasePending},
+					},
+				}),
+				newFreight(),
+				newStage(
+					"test",
+					kargoapi.Subscriptions{Warehouse: "fake-warehouse"},
+					&kargoapi.FreightReference{Name: "other-freight"},
+				),
+				prodStage.DeepCopy(),
+			},
+			assertions: func(t *testing.T, c client.Client, _ reconcile.Result, err error) {
+				require.NoError(t, err)
+				plan := getPlan(t, c)
+				require.Equal(t, kargoapi.PromotionPlanPhaseFailed, plan.Status.Phase)
+				require.Contains(t, plan.Status.Message, "was replaced")
+			},
+		},
+		{
+			name: "verified Freight moves on to the next step",
+			objects: []client.Object{
+				withStatus(kargoapi.PromotionPlanStatus{
+					Phase: kargoapi.PromotionPlanPhaseRunning,
+					Steps: []kargoapi.PromotionPlanStep{
+						{
+							Stage:     "test",
+							Phase:     kargoapi.PromotionPlanStepPhaseVerifying,
+							Promotion: "test-promotion",
+						},
+						{Stage: "prod", Phase: kargoapi.PromotionPlanStepPhasePending},
+					},
+				}),
+				newFreight("test"),
+				newStage(
+					"test",
+					kargoapi.Subscriptions{Warehouse: "fake-warehouse"},
+					&kargoapi.FreightReference{Name: "fake-freight"},
+				),
+				prodStage.DeepCopy(),
+			},
+			assertions: func(t *testing.T, c client.Client, _ reconcile.Result, err error) {
+				require.NoError(t, err)
+				plan := getPlan(t, c)
+				require.Equal(t, kargoapi.PromotionPlanPhaseRunning, plan.Status.Phase)
+				require.Equal(
+					t,
+					kargoapi.PromotionPlanStepPhaseSucceeded,
+					plan.Status.Steps[0].Phase,
+				)
+				require.Equal(
+					t,
+					kargoapi.PromotionPlanStepPhasePromoting,
+					plan.Status.Steps[1].Phase,
+				)
+				require.NotEmpty(t, plan.Status.Steps[1].Promotion)
+			},
+		},
+		{
+			name: "next step paused by maintenance mode",
+			objects: []client.Object{
+				withStatus(kargoapi.PromotionPlanStatus{
+					Phase: kargoapi.PromotionPlanPhaseRunning,
+					Steps: []kargoapi.PromotionPlanStep{
+						{Stage: "test", Phase: kargoapi.PromotionPlanStepPhaseSucceeded},
+						{Stage: "prod", Phase: kargoapi.PromotionPlanStepPhasePending},
+					},
+				}),
+				newFreight("test"),
+				testStage.DeepCopy(),
+				prodStage.DeepCopy(),
+				&kargoapi.Project{
+					ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+					Spec: &kargoapi.ProjectSpec{
+						MaintenanceMode: &kargoapi.MaintenanceMode{Enabled: true},
+					},
+				},
+			},
+			assertions: func(t *testing.T, c client.Client, res reconcile.Result, err error) {
+				require.NoError(t, err)
+				require.Equal(t, maintenanceRequeueInterval, res.RequeueAfter)
+				plan := getPlan(t, c)
+				require.Equal(t, kargoapi.PromotionPlanPhaseRunning, plan.Status.Phase)
+				require.Equal(
+					t,
+					kargoapi.PromotionPlanStepPhasePending,
+					plan.Status.Steps[1].Phase,
+				)
+				require.Contains(t, plan.Status.Message, "maintenance mode")
+			},
+		},
+		{
+			name: "all steps succeeded",
+			objects: []client.Object{
+				withStatus(kargoapi.PromotionPlanStatus{
+					Phase: kargoapi.PromotionPlanPhaseRunning,
+					Steps: []kargoapi.PromotionPlanStep{
+						{Stage: "test", Phase: kargoapi.PromotionPlanStepPhaseSucceeded},
+						{
+							Stage:     "prod",
+							Phase:     kargoapi.PromotionPlanStepPhaseVerifying,
+							Promotion: "prod-promotion",
+						},
+					},
+				}),
+				newFreight("test", "prod"),
+				testStage.DeepCopy(),
+				newStage(
+					"prod",
+					kargoapi.Subscriptions{
+						UpstreamStages: []kargoapi.StageSubscription{{Name: "test"}},
+					},
+					&kargoapi.FreightReference{Name: "fake-freight"},
+				),
+			},
+			assertions: func(t *testing.T, c client.Client, res reconcile.Result, err error) {
+				require.NoError(t, err)
+				require.Zero(t, res.RequeueAfter)
+				plan := getPlan(t, c)
+				require.Equal(t, kargoapi.PromotionPlanPhaseSucceeded, plan.Status.Phase)
+				require.NotNil(t, plan.Status.FinishedAt)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, kargoapi.AddToScheme(scheme))
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tc.objects...).
+				WithStatusSubresource(&kargoapi.PromotionPlan{}).
+				WithIndex(
+					&kargoapi.Promotion{},
+					kubeclient.PromotionsByStageAndFreightIndexField,
+					func(obj client.Object) []string {
+						promo := obj.(*kargoapi.Promotion) // nolint: forcetypeassert
+						return []string{
+							kubeclient.StageAndFreightKey(promo.Spec.Stage, promo.Spec.Freight),
+						}
+					},
+				).
+				Build()
+			r := newReconciler(c, fakeevent.NewEventRecorder(1), "fake-controller")
+			r.nowFn = func() time.Time {
+				return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			}
+			res, err := r.Reconcile(
+				context.Background(),
+				reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: testNamespace,
+						Name:      testPlan.Name,
+					},
+				},
+			)
+			tc.assertions(t, c, res, err)
+		})
+	}
+}
+
+func getPlan(t *testing.T, c client.Client) *kargoapi.PromotionPlan {
+	plan := &kargoapi.PromotionPlan{}
+	require.NoError(t, c.Get(
+		context.Background(),
+		types.NamespacedName{
+			Namespace: testNamespace,
+			Name:      "fake-plan",
+		},
+		plan,
+	))
+	return plan
+}
@@ -0,0 +1,126 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	libargocd "github.com/akuity/kargo/internal/argocd"
+	argocd "github.com/akuity/kargo/internal/controller/argocd/api/v1alpha1"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// annotateArgoCDApps records details of the provided successful Promotion of
+// the provided Freight as annotations on every Argo CD Application that was
+// updated by the provided Stage's promotion mechanisms, so that it is possible
+// to trace what Kargo last did to an Application from Argo CD alone. An
+// attempt is made to annotate every Application, even if some fail, and any
+// errors are returned together.
+func (r *reconciler) annotateArgoCDApps(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	promo *kargoapi.Promotion,
+	freight *kargoapi.Freight,
+) error {
+	if stage.Spec.PromotionMechanisms == nil ||
+		len(stage.Spec.PromotionMechanisms.ArgoCDAppUpdates) == 0 {
+		return nil
+	}
+	if r.argocdClient == nil {
+		// Argo CD integration is disabled. The Promotion could not have
+		// succeeded had it actually updated any Applications.
+		return nil
+	}
+
+	annotations := map[string]string{
+		kargoapi.AnnotationKeyPromotion:        promo.Name,
+		kargoapi.AnnotationKeyFreight:          freight.Name,
+		kargoapi.AnnotationKeyFreightAlias:     freight.Alias,
+		kargoapi.AnnotationKeyFreightArtifacts: freightArtifactsSummary(freight),
+	}
+
+	var errs []error
+	for _, update := range stage.Spec.PromotionMechanisms.ArgoCDAppUpdates {
+		namespace := update.AppNamespace
+		if namespace == "" {
+			namespace = libargocd.Namespace()
+		}
+		logger := logging.LoggerFromContext(ctx).WithField("app", update.AppName).
+			WithField("appNamespace", namespace)
+		app, err := argocd.GetApplication(ctx, r.argocdClient, namespace, update.AppName)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if app == nil {
+			errs = append(errs, fmt.Errorf(
+				"unable to find Argo CD Application %q in namespace %q",
+				update.AppName,
+				namespace,
+			))
+			continue
+		}
+		patch := client.MergeFrom(app.DeepCopy())
+		if app.Annotations == nil {
+			app.Annotations = make(map[string]string, len(annotations))
+		}
+		for key, value := range annotations {
+			if value == "" {
+				// Do not leave behind a stale value from an earlier Promotion.
+				delete(app.Annotations, key)
+				continue
+			}
+			app.Annotations[key] = value
+		}
+		if err = r.argocdClient.Patch(ctx, app, patch); err != nil {
+			errs = append(errs, fmt.Errorf(
+				"error annotating Argo CD Application %q in namespace %q: %w",
+				update.AppName,
+				namespace,
+				err,
+			))
+			continue
+		}
+		logger.Debug("annotated Argo CD Application")
+	}
+	return errors.Join(errs...)
+}
+
+// freightArtifactsSummary returns a comma-separated summary of the versions of
+// all artifacts referenced by the provided Freight.
+func freightArtifactsSummary(freight *kargoapi.Freight) string {
+	artifacts := make(
+		[]string,
+		0,
+		len(freight.Commits)+len(freight.Images)+len(freight.Charts)+len(freight.Packages),
+	)
+	for _, commit := range freight.Commits {
+		version := commit.ID
+		if commit.Tag != "" {
+			version = commit.Tag
+		}
+		artifacts = append(artifacts, fmt.Sprintf("%s@%s", commit.RepoURL, version))
+	}
+	for _, image := range freight.Images {
+		if image.Tag != "" {
+			artifacts = append(artifacts, fmt.Sprintf("%s:%s", image.RepoURL, image.Tag))
+		} else {
+			artifacts = append(artifacts, fmt.Sprintf("%s@%s", image.RepoURL, image.Digest))
+		}
+	}
+	for _, chart := range freight.Charts {
+		repoURL := chart.RepoURL
+		if chart.Name != "" {
+			repoURL = fmt.Sprintf("%s/%s", strings.TrimSuffix(repoURL, "/"), chart.Name)
+		}
+		artifacts = append(artifacts, fmt.Sprintf("%s:%s", repoURL, chart.Version))
+	}
+	for _, pkg := range freight.Packages {
+		artifacts = append(artifacts, fmt.Sprintf("%s/%s@%s", pkg.RepoURL, pkg.Name, pkg.Version))
+	}
+	return strings.Join(artifacts, ",")
+}
@@ -0,0 +1,200 @@
+package promotions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	argocd "github.com/akuity/kargo/internal/controller/argocd/api/v1alpha1"
+)
+
+func TestAnnotateArgoCDApps(t *testing.T) {
+	testPromo := &kargoapi.Promotion{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake-promotion"},
+	}
+	testFreight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake-freight"},
+		Alias:      "fake-alias",
+		Images: []kargoapi.Image{{
+			RepoURL: "fake-image",
+			Tag:     "v1.0.0",
+		}},
+	}
+	testStage := &kargoapi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-stage",
+			Namespace: "fake-project",
+		},
+		Spec: kargoapi.StageSpec{
+			PromotionMechanisms: &kargoapi.PromotionMechanisms{
+				ArgoCDAppUpdates: []kargoapi.ArgoCDAppUpdate{{
+					AppName:      "fake-app",
+					AppNamespace: "argocd",
+				}},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		stage      *kargoapi.Stage
+		freight    *kargoapi.Freight
+		noClient   bool
+		objects    []client.Object
+		assertions func(*testing.T, client.Client, error)
+	}{
+		{
+			name: "no Argo CD Application updates",
+			stage: &kargoapi.Stage{
+				Spec: kargoapi.StageSpec{
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
+				},
+			},
+			freight: testFreight,
+			assertions: func(t *testing.T, _ client.Client, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:     "Argo CD integration disabled",
+			stage:    testStage,
+			freight:  testFreight,
+			noClient: true,
+			assertions: func(t *testing.T, _ client.Client, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:    "Application not found",
+			stage:   testStage,
+			freight: testFreight,
+			assertions: func(t *testing.T, _ client.Client, err error) {
+				require.ErrorContains(t, err, "unable to find Argo CD Application")
+			},
+		},
+		{
+			name:    "success",
+			stage:   testStage,
+			freight: testFreight,
+			objects: []client.Object{
+				&argocd.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fake-app",
+						Namespace: "argocd",
+						Annotations: map[string]string{
+							"kargo.akuity.io/authorized-stage": "fake-project:fake-stage",
+						},
+					},
+				},
+			},
+			assertions: func(t *testing.T, c client.Client, err error) {
+				require.NoError(t, err)
+				app, err := argocd.GetApplication(context.Background(), c, "argocd", "fake-app")
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					map[string]string{
+						"kargo.akuity.io/authorized-stage":     "fake-project:fake-stage",
+						kargoapi.AnnotationKeyPromotion:        "fake-promotion",
+						kargoapi.AnnotationKeyFreight:          "fake-freight",
+						kargoapi.AnnotationKeyFreightAlias:     "fake-alias",
+						kargoapi.AnnotationKeyFreightArtifacts: "fake-image:v1.0.0",
+					},
+					app.Annotations,
+				)
+			},
+		},
+		{
+			name:  "stale annotations are removed",
+			stage: testStage,
+			freight: &kargoapi.Freight{
+				ObjectMeta: metav1.ObjectMeta{Name: "another-fake-freight"},
+			},
+			objects: []client.Object{
+				&argocd.Application{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "fake-app",
+						Namespace: "argocd",
+						Annotations: map[string]string{
+							kargoapi.AnnotationKeyFreight:          "fake-freight",
+							kargoapi.AnnotationKeyFreightAlias:     "fake-alias",
+							kargoapi.AnnotationKeyFreightArtifacts: "fake-image:v1.0.0",
+						},
+					},
+				},
+			},
+			assertions: func(t *testing.T, c client.Client, err error) {
+				require.NoError(t, err)
+				app, err := argocd.GetApplication(context.Background(), c, "argocd", "fake-app")
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					map[string]string{
+						kargoapi.AnnotationKeyPromotion: "fake-promotion",
+						kargoapi.AnnotationKeyFreight:   "another-fake-freight",
+					},
+					app.Annotations,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			scheme := k8sruntime.NewScheme()
+			require.NoError(t, argocd.AddToScheme(scheme))
+			argocdClient := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(testCase.objects...).Build()
+			r := &reconciler{}
+			if !testCase.noClient {
+				r.argocdClient = argocdClient
+			}
+			err := r.annotateArgoCDApps(
+				context.Background(),
+				testCase.stage,
+				testPromo,
+				testCase.freight,
+			)
+			testCase.assertions(t, argocdClient, err)
+		})
+	}
+}
+
+func TestFreightArtifactsSummary(t *testing.T) {
+	require.Empty(t, freightArtifactsSummary(&kargoapi.Freight{}))
+	require.Equal(
+		t,
+		"https://github.com/example/repo.git@abc123,"+
+			"https://github.com/example/repo.git@v1.0.0,"+
+			"nginx:1.25.0,"+
+			"nginx@sha256:deadbeef,"+
+			"https://charts.example.com/fake-chart:1.0.0,"+
+			"oci://ghcr.io/example/fake-chart:2.0.0,"+
+			"https://registry.npmjs.org/fake-package@3.0.0",
+		freightArtifactsSummary(&kargoapi.Freight{
+			Commits: []kargoapi.GitCommit{
+				{RepoURL: "https://github.com/example/repo.git", ID: "abc123"},
+				{RepoURL: "https://github.com/example/repo.git", ID: "def456", Tag: "v1.0.0"},
+			},
+			Images: []kargoapi.Image{
+				{RepoURL: "nginx", Tag: "1.25.0"},
+				{RepoURL: "nginx", Digest: "sha256:deadbeef"},
+			},
+			Charts: []kargoapi.Chart{
+				{RepoURL: "https://charts.example.com/", Name: "fake-chart", Version: "1.0.0"},
+				{RepoURL: "oci://ghcr.io/example/fake-chart", Version: "2.0.0"},
+			},
+			Packages: []kargoapi.Package{{
+				Type:    kargoapi.PackageTypeNPM,
+				RepoURL: "https://registry.npmjs.org",
+				Name:    "fake-package",
+				Version: "3.0.0",
+			}},
+		}),
+	)
+}
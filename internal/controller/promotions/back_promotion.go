@@ -0,0 +1,230 @@
+package promotions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/kargo"
+	"github.com/akuity/kargo/internal/kubeclient"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// backPromote creates Promotions of the provided Freight to any Stages that
+// were bypassed on its way to the provided Stage, provided the Project has
+// enabled back-promotion for those Stages. A Stage is considered to have been
+// bypassed if it is upstream from the provided Stage and the Freight has not
+// been verified in it or in any Stage between it and the provided Stage.
+func (r *reconciler) backPromote(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	freight *kargoapi.Freight,
+) error {
+	logger := logging.LoggerFromContext(ctx)
+
+	project, err := r.getProjectFn(ctx, r.kargoClient, stage.Namespace)
+	if err != nil {
+		return fmt.Errorf("error finding Project %q: %w", stage.Namespace, err)
+	}
+	if project == nil || project.Spec == nil {
+		return nil
+	}
+	if m := project.ActiveMaintenanceMode(time.Now()); m != nil {
+		logger.Debugf("back-promotion is paused: %s", m.Describe())
+		return nil
+	}
+	backPromotionEnabled := map[string]struct{}{}
+	for _, policy := range project.Spec.PromotionPolicies {
+		if policy.BackPromotionEnabled {
+			backPromotionEnabled[policy.Stage] = struct{}{}
+		}
+	}
+	if len(backPromotionEnabled) == 0 {
+		return nil
+	}
+
+	bypassedStages, err := r.getBypassedStages(ctx, stage, freight)
+	if err != nil {
+		return err
+	}
+	for i := range bypassedStages {
+		bypassedStage := &bypassedStages[i]
+		if _, ok := backPromotionEnabled[bypassedStage.Name]; !ok {
+			logger.WithField("bypassedStage", bypassedStage.Name).
+				Debug("back-promotion is not enabled for bypassed Stage")
+			continue
+		}
+		if err = r.backPromoteToStage(ctx, bypassedStage, freight); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getBypassedStages walks the pipeline upstream from the provided Stage and
+// returns all Stages in which the provided Freight has not been verified. The
+// walk stops at any Stage in which the Freight has been verified, since the
+// Freight must have legitimately reached that Stage and everything upstream of
+// it.
+func (r *reconciler) getBypassedStages(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	freight *kargoapi.Freight,
+) ([]kargoapi.Stage, error) {
+	var bypassed []kargoapi.Stage
+	visited := map[string]struct{}{stage.Name: {}}
+	queue := stage.Spec.Subscriptions.UpstreamStages
+	for len(queue) > 0 {
+		upstream := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[upstream.Name]; ok {
+			continue
+		}
+		visited[upstream.Name] = struct{}{}
+		if _, ok := freight.Status.VerifiedIn[upstream.Name]; ok {
+			continue
+		}
+		upstreamStage, err := r.getStageFn(
+			ctx,
+			r.kargoClient,
+			types.NamespacedName{
+				Namespace: stage.Namespace,
+				Name:      upstream.Name,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error finding Stage %q in namespace %q: %w",
+				upstream.Name,
+				stage.Namespace,
+				err,
+			)
+		}
+		if upstreamStage == nil {
+			continue
+		}
+		bypassed = append(bypassed, *upstreamStage)
+		queue = append(queue, upstreamStage.Spec.Subscriptions.UpstreamStages...)
+	}
+	return bypassed, nil
+}
+
+// backPromoteToStage creates a Promotion of the provided Freight to the
+// provided bypassed Stage, approving the Freight for that Stage first if
+// necessary. Nothing is done if the Stage already has the Freight or if a
+// Promotion of the Freight to the Stage already exists.
+func (r *reconciler) backPromoteToStage(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	freight *kargoapi.Freight,
+) error {
+	logger := logging.LoggerFromContext(ctx).WithField("bypassedStage", stage.Name)
+
+	if stage.Status.CurrentFreight != nil && stage.Status.CurrentFreight.Name == freight.Name {
+		logger.Debug("bypassed Stage already has Freight")
+		return nil
+	}
+	if stage.Spec.PromotionMechanisms == nil {
+		logger.Debug("bypassed Stage has no PromotionMechanisms")
+		return nil
+	}
+
+	promos := kargoapi.PromotionList{}
+	if err := r.listPromosFn(
+		ctx,
+		&promos,
+		&client.ListOptions{
+			Namespace: stage.Namespace,
+			FieldSelector: fields.Set(
+				map[string]string{
+					kubeclient.PromotionsByStageAndFreightIndexField: kubeclient.
+						StageAndFreightKey(stage.Name, freight.Name),
+				},
+			).AsSelector(),
+		},
+	); err != nil {
+		return fmt.Errorf(
+			"error listing existing Promotions for Freight %q in namespace %q: %w",
+			freight.Name,
+			stage.Namespace,
+			err,
+		)
+	}
+	if len(promos.Items) > 0 {
+		logger.Debug("Promotion already exists for Freight")
+		return nil
+	}
+
+	upstreamStages := make([]string, len(stage.Spec.Subscriptions.UpstreamStages))
+	for i, upstreamStage := range stage.Spec.Subscriptions.UpstreamStages {
+		upstreamStages[i] = upstreamStage.Name
+	}
+	if !kargoapi.IsFreightAvailable(freight, stage.Name, upstreamStages) {
+		// The Freight will usually not have been verified upstream from the
+		// bypassed Stage, so it must be approved for the Stage before it can be
+		// promoted to it.
+		if err := r.approveFreightFn(ctx, freight, stage.Name); err != nil {
+			return fmt.Errorf(
+				"error approving Freight %q for Stage %q in namespace %q: %w",
+				freight.Name,
+				stage.Name,
+				stage.Namespace,
+				err,
+			)
+		}
+	}
+
+	promo := kargo.NewPromotion(ctx, *stage, freight.Name)
+	if err := r.createPromotionFn(ctx, &promo); err != nil {
+		return fmt.Errorf(
+			"error creating Promotion of Stage %q in namespace %q to Freight %q: %w",
+			stage.Name,
+			stage.Namespace,
+			freight.Name,
+			err,
+		)
+	}
+
+	r.recorder.AnnotatedEventf(
+		&promo,
+		kargoapi.NewPromotionEventAnnotations(
+			ctx,
+			kargoapi.FormatEventControllerActor(r.cfg.Name()),
+			&promo,
+			freight,
+		),
+		corev1.EventTypeNormal,
+		kargoapi.EventReasonPromotionCreated,
+		"Automatically back-promoted Freight for bypassed Stage %q",
+		promo.Spec.Stage,
+	)
+
+	logger.WithField("promotion", promo.Name).Debug("created back-promotion")
+	return nil
+}
+
+// approveFreight marks the provided Freight as approved for the specified
+// Stage.
+func (r *reconciler) approveFreight(
+	ctx context.Context,
+	freight *kargoapi.Freight,
+	stageName string,
+) error {
+	return kubeclient.PatchStatus(
+		ctx,
+		r.kargoClient,
+		freight,
+		func(status *kargoapi.FreightStatus) {
+			if status.ApprovedFor == nil {
+				status.ApprovedFor = map[string]kargoapi.ApprovedStage{}
+			}
+			status.ApprovedFor[stageName] = kargoapi.ApprovedStage{}
+		},
+	)
+}
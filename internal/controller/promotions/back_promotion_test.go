@@ -0,0 +1,223 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	fakeevent "github.com/akuity/kargo/internal/kubernetes/event/fake"
+)
+
+func TestBackPromote(t *testing.T) {
+	const testNamespace = "fake-namespace"
+
+	newStage := func(name string, upstreams ...string) *kargoapi.Stage {
+		stage := &kargoapi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      name,
+			},
+			Spec: kargoapi.StageSpec{
+				PromotionMechanisms: &kargoapi.PromotionMechanisms{},
+			},
+		}
+		if len(upstreams) == 0 {
+			stage.Spec.Subscriptions = kargoapi.Subscriptions{Warehouse: "fake-warehouse"}
+			return stage
+		}
+		stage.Spec.Subscriptions = kargoapi.Subscriptions{}
+		for _, upstream := range upstreams {
+			stage.Spec.Subscriptions.UpstreamStages = append(
+				stage.Spec.Subscriptions.UpstreamStages,
+				kargoapi.StageSubscription{Name: upstream},
+			)
+		}
+		return stage
+	}
+
+	newProject := func(policies ...kargoapi.PromotionPolicy) *kargoapi.Project {
+		return &kargoapi.Project{
+			ObjectMeta: metav1.ObjectMeta{Name: testNamespace},
+			Spec:       &kargoapi.ProjectSpec{PromotionPolicies: policies},
+		}
+	}
+
+	newFreight := func(verifiedIn ...string) *kargoapi.Freight {
+		freight := &kargoapi.Freight{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      "fake-freight",
+			},
+			Status: kargoapi.FreightStatus{
+				ApprovedFor: map[string]kargoapi.ApprovedStage{"prod": {}},
+			},
+		}
+		if len(verifiedIn) > 0 {
+			freight.Status.VerifiedIn = map[string]kargoapi.VerifiedStage{}
+			for _, stage := range verifiedIn {
+				freight.Status.VerifiedIn[stage] = kargoapi.VerifiedStage{}
+			}
+		}
+		return freight
+	}
+
+	testCases := []struct {
+		name          string
+		project       *kargoapi.Project
+		freight       *kargoapi.Freight
+		existingPromo bool
+		assertions    func(*testing.T, client.Client, *kargoapi.Freight, error)
+	}{
+		{
+			name: "back-promotion not enabled",
+			project: newProject(
+				kargoapi.PromotionPolicy{Stage: "test", AutoPromotionEnabled: true},
+			),
+			freight: newFreight(),
+			assertions: func(t *testing.T, c client.Client, _ *kargoapi.Freight, err error) {
+				require.NoError(t, err)
+				promos := kargoapi.PromotionList{}
+				require.NoError(t, c.List(context.Background(), &promos))
+				require.Empty(t, promos.Items)
+			},
+		},
+		{
+			name: "no Stages bypassed",
+			project: newProject(
+				kargoapi.PromotionPolicy{Stage: "test", BackPromotionEnabled: true},
+				kargoapi.PromotionPolicy{Stage: "uat", BackPromotionEnabled: true},
+			),
+			freight: newFreight("uat"),
+			assertions: func(t *testing.T, c client.Client, _ *kargoapi.Freight, err error) {
+				require.NoError(t, err)
+				promos := kargoapi.PromotionList{}
+				require.NoError(t, c.List(context.Background(), &promos))
+				require.Empty(t, promos.Items)
+			},
+		},
+		{
+			name: "Promotion already exists",
+			project: newProject(
+				kargoapi.PromotionPolicy{Stage: "test", BackPromotionEnabled: true},
+				kargoapi.PromotionPolicy{Stage: "uat", BackPromotionEnabled: true},
+			),
+			freight:       newFreight(),
+			existingPromo: true,
+			assertions: func(t *testing.T, c client.Client, _ *kargoapi.Freight, err error) {
+				require.NoError(t, err)
+				promos := kargoapi.PromotionList{}
+				require.NoError(t, c.List(context.Background(), &promos))
+				require.Empty(t, promos.Items)
+			},
+		},
+		{
+			name: "bypassed Stages are back-promoted",
+			project: newProject(
+				kargoapi.PromotionPolicy{Stage: "test", BackPromotionEnabled: true},
+				kargoapi.PromotionPolicy{Stage: "uat", BackPromotionEnabled: true},
+			),
+			freight: newFreight(),
+			assertions: func(t *testing.T, c client.Client, freight *kargoapi.Freight, err error) {
+				require.NoError(t, err)
+				promos := kargoapi.PromotionList{}
+				require.NoError(t, c.List(context.Background(), &promos))
+				require.Len(t, promos.Items, 2)
+				stages := []string{promos.Items[0].Spec.Stage, promos.Items[1].Spec.Stage}
+				require.ElementsMatch(t, []string{"test", "uat"}, stages)
+				for _, promo := range promos.Items {
+					require.Equal(t, freight.Name, promo.Spec.Freight)
+				}
+				// The Freight must have been approved for uat, since it was not
+				// verified in test. test subscribes directly to a Warehouse and
+				// requires no approval.
+				updated := kargoapi.Freight{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(freight), &updated))
+				require.Contains(t, updated.Status.ApprovedFor, "uat")
+				require.NotContains(t, updated.Status.ApprovedFor, "test")
+			},
+		},
+		{
+			name: "paused by maintenance mode",
+			project: func() *kargoapi.Project {
+				project := newProject(
+					kargoapi.PromotionPolicy{Stage: "test", BackPromotionEnabled: true},
+					kargoapi.PromotionPolicy{Stage: "uat", BackPromotionEnabled: true},
+				)
+				project.Spec.MaintenanceMode = &kargoapi.MaintenanceMode{Enabled: true}
+				return project
+			}(),
+			freight: newFreight(),
+			assertions: func(t *testing.T, c client.Client, _ *kargoapi.Freight, err error) {
+				require.NoError(t, err)
+				promos := kargoapi.PromotionList{}
+				require.NoError(t, c.List(context.Background(), &promos))
+				require.Empty(t, promos.Items)
+			},
+		},
+		{
+			name: "only Stages with back-promotion enabled are back-promoted",
+			project: newProject(
+				kargoapi.PromotionPolicy{Stage: "test", BackPromotionEnabled: true},
+			),
+			freight: newFreight(),
+			assertions: func(t *testing.T, c client.Client, _ *kargoapi.Freight, err error) {
+				require.NoError(t, err)
+				promos := kargoapi.PromotionList{}
+				require.NoError(t, c.List(context.Background(), &promos))
+				require.Len(t, promos.Items, 1)
+				require.Equal(t, "test", promos.Items[0].Spec.Stage)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			test := newStage("test")
+			uat := newStage("uat", "test")
+			prod := newStage("prod", "uat")
+			r := newFakeReconciler(
+				t,
+				fakeevent.NewEventRecorder(2),
+				testCase.project,
+				test,
+				uat,
+				prod,
+				testCase.freight,
+			)
+			// The fake client has no field indices, so existing Promotions are
+			// simulated here
+			r.listPromosFn = func(
+				_ context.Context,
+				list client.ObjectList,
+				_ ...client.ListOption,
+			) error {
+				if testCase.existingPromo {
+					promos := list.(*kargoapi.PromotionList) // nolint: forcetypeassert
+					promos.Items = []kargoapi.Promotion{{}}
+				}
+				return nil
+			}
+			err := r.backPromote(context.Background(), prod, testCase.freight)
+			testCase.assertions(t, r.kargoClient, testCase.freight, err)
+		})
+	}
+}
+
+func TestBackPromoteProjectError(t *testing.T) {
+	r := newFakeReconciler(t, fakeevent.NewEventRecorder(1))
+	r.getProjectFn = func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+		return nil, errors.New("something went wrong")
+	}
+	err := r.backPromote(
+		context.Background(),
+		&kargoapi.Stage{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "fake-namespace"},
+		},
+		&kargoapi.Freight{},
+	)
+	require.ErrorContains(t, err, "something went wrong")
+}
@@ -0,0 +1,148 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/gitprovider"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// notifyGitProviders notifies the Git hosting providers of the repositories
+// whose commits are referenced by the provided Freight of the outcome of the
+// provided Promotion, as called for by the provided Stage's
+// GitProviderNotifications. Deployment statuses are recorded for Promotions
+// that Succeeded, Failed, or Errored, while pull requests are only commented
+// on for Promotions that Succeeded. An attempt is made to send every
+// notification, even if some fail, and any errors are returned together.
+func (r *reconciler) notifyGitProviders(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	promo *kargoapi.Promotion,
+	freight *kargoapi.Freight,
+	phase kargoapi.PromotionPhase,
+) error {
+	notifications := stage.Spec.GitProviderNotifications
+	if notifications == nil ||
+		(!notifications.DeploymentStatuses && !notifications.PullRequestComments) {
+		return nil
+	}
+
+	environment := notifications.Environment
+	if environment == "" {
+		environment = stage.Name
+	}
+	state := gitprovider.DeploymentStateSuccess
+	if phase != kargoapi.PromotionPhaseSucceeded {
+		state = gitprovider.DeploymentStateFailure
+	}
+	description := fmt.Sprintf(
+		"Promotion %q of Freight %q to Stage %q in Project %q %s",
+		promo.Name,
+		freightDisplayName(freight),
+		stage.Name,
+		stage.Namespace,
+		phase,
+	)
+
+	var errs []error
+	for _, commit := range freight.Commits {
+		if len(notifications.RepoURLs) > 0 &&
+			!slices.Contains(notifications.RepoURLs, commit.RepoURL) {
+			continue
+		}
+		logger := logging.LoggerFromContext(ctx).WithField("repo", commit.RepoURL)
+		gpClient, err := r.getGitProviderFn(ctx, stage.Namespace, commit.RepoURL)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if notifications.DeploymentStatuses {
+			ref, tag := commit.Branch, false
+			if commit.Tag != "" {
+				ref, tag = commit.Tag, true
+			}
+			if err = gpClient.CreateDeploymentStatus(
+				ctx,
+				commit.RepoURL,
+				gitprovider.CreateDeploymentStatusOpts{
+					Environment: environment,
+					SHA:         commit.ID,
+					Ref:         ref,
+					Tag:         tag,
+					State:       state,
+					Description: description,
+				},
+			); err != nil {
+				errs = append(errs, fmt.Errorf(
+					"error recording deployment status of commit %q in git repo %q: %w",
+					commit.ID,
+					commit.RepoURL,
+					err,
+				))
+			} else {
+				logger.Debug("recorded deployment status")
+			}
+		}
+		if notifications.PullRequestComments && phase == kargoapi.PromotionPhaseSucceeded {
+			if err = commentOnPullRequestsWithCommit(
+				ctx,
+				gpClient,
+				commit,
+				description,
+			); err != nil {
+				errs = append(errs, err)
+			} else {
+				logger.Debug("commented on pull requests")
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// commentOnPullRequestsWithCommit posts the provided comment to every pull
+// request containing the provided commit.
+func commentOnPullRequestsWithCommit(
+	ctx context.Context,
+	gpClient gitprovider.GitProviderService,
+	commit kargoapi.GitCommit,
+	comment string,
+) error {
+	prs, err := gpClient.ListPullRequestsWithCommit(ctx, commit.RepoURL, commit.ID)
+	if err != nil {
+		return fmt.Errorf(
+			"error listing pull requests containing commit %q in git repo %q: %w",
+			commit.ID,
+			commit.RepoURL,
+			err,
+		)
+	}
+	for _, pr := range prs {
+		if err = gpClient.CreatePullRequestComment(
+			ctx,
+			commit.RepoURL,
+			pr.Number,
+			comment,
+		); err != nil {
+			return fmt.Errorf(
+				"error commenting on pull request %d in git repo %q: %w",
+				pr.Number,
+				commit.RepoURL,
+				err,
+			)
+		}
+	}
+	return nil
+}
+
+// freightDisplayName returns the provided Freight's alias, if it has one, or
+// its name otherwise.
+func freightDisplayName(freight *kargoapi.Freight) string {
+	if freight.Alias != "" {
+		return freight.Alias
+	}
+	return freight.Name
+}
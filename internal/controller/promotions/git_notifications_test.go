@@ -0,0 +1,224 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/gitprovider"
+)
+
+// fakeGitProvider is a fake implementation of gitprovider.GitProviderService
+// that records the notifications it is sent.
+type fakeGitProvider struct {
+	gitprovider.GitProviderService
+
+	deploymentStatuses []gitprovider.CreateDeploymentStatusOpts
+	comments           map[int64]string
+	prs                []*gitprovider.PullRequest
+	err                error
+}
+
+func (f *fakeGitProvider) CreateDeploymentStatus(
+	_ context.Context,
+	_ string,
+	opts gitprovider.CreateDeploymentStatusOpts,
+) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.deploymentStatuses = append(f.deploymentStatuses, opts)
+	return nil
+}
+
+func (f *fakeGitProvider) ListPullRequestsWithCommit(
+	context.Context,
+	string,
+	string,
+) ([]*gitprovider.PullRequest, error) {
+	return f.prs, f.err
+}
+
+func (f *fakeGitProvider) CreatePullRequestComment(
+	_ context.Context,
+	_ string,
+	number int64,
+	body string,
+) error {
+	if f.comments == nil {
+		f.comments = map[int64]string{}
+	}
+	f.comments[number] = body
+	return nil
+}
+
+func TestNotifyGitProviders(t *testing.T) {
+	testFreight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-project",
+			Name:      "fake-freight",
+		},
+		Alias: "fake-alias",
+		Commits: []kargoapi.GitCommit{
+			{
+				RepoURL: "https://github.com/example/app",
+				ID:      "fake-commit",
+				Branch:  "main",
+			},
+			{
+				RepoURL: "https://github.com/example/config",
+				ID:      "fake-tagged-commit",
+				Tag:     "v1.0.0",
+			},
+		},
+	}
+	testPromo := &kargoapi.Promotion{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-project",
+			Name:      "fake-promotion",
+		},
+	}
+	newStage := func(notifications *kargoapi.GitProviderNotifications) *kargoapi.Stage {
+		return &kargoapi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "fake-project",
+				Name:      "fake-stage",
+			},
+			Spec: kargoapi.StageSpec{
+				GitProviderNotifications: notifications,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		stage      *kargoapi.Stage
+		phase      kargoapi.PromotionPhase
+		provider   *fakeGitProvider
+		getErr     error
+		assertions func(*testing.T, *fakeGitProvider, error)
+	}{
+		{
+			name:     "notifications not enabled",
+			stage:    newStage(nil),
+			phase:    kargoapi.PromotionPhaseSucceeded,
+			provider: &fakeGitProvider{},
+			assertions: func(t *testing.T, provider *fakeGitProvider, err error) {
+				require.NoError(t, err)
+				require.Empty(t, provider.deploymentStatuses)
+				require.Empty(t, provider.comments)
+			},
+		},
+		{
+			name: "error getting Git provider",
+			stage: newStage(&kargoapi.GitProviderNotifications{
+				DeploymentStatuses: true,
+			}),
+			phase:    kargoapi.PromotionPhaseSucceeded,
+			provider: &fakeGitProvider{},
+			getErr:   errors.New("something went wrong"),
+			assertions: func(t *testing.T, _ *fakeGitProvider, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "error recording deployment status",
+			stage: newStage(&kargoapi.GitProviderNotifications{
+				DeploymentStatuses: true,
+			}),
+			phase:    kargoapi.PromotionPhaseSucceeded,
+			provider: &fakeGitProvider{err: errors.New("something went wrong")},
+			assertions: func(t *testing.T, _ *fakeGitProvider, err error) {
+				require.ErrorContains(t, err, "error recording deployment status")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "deployment statuses for successful Promotion",
+			stage: newStage(&kargoapi.GitProviderNotifications{
+				DeploymentStatuses: true,
+			}),
+			phase:    kargoapi.PromotionPhaseSucceeded,
+			provider: &fakeGitProvider{},
+			assertions: func(t *testing.T, provider *fakeGitProvider, err error) {
+				require.NoError(t, err)
+				require.Len(t, provider.deploymentStatuses, 2)
+				status := provider.deploymentStatuses[0]
+				require.Equal(t, "fake-stage", status.Environment)
+				require.Equal(t, "fake-commit", status.SHA)
+				require.Equal(t, "main", status.Ref)
+				require.False(t, status.Tag)
+				require.Equal(t, gitprovider.DeploymentStateSuccess, status.State)
+				require.Contains(t, status.Description, "fake-alias")
+				status = provider.deploymentStatuses[1]
+				require.Equal(t, "v1.0.0", status.Ref)
+				require.True(t, status.Tag)
+			},
+		},
+		{
+			name: "deployment statuses for failed Promotion",
+			stage: newStage(&kargoapi.GitProviderNotifications{
+				Environment:         "fake-environment",
+				DeploymentStatuses:  true,
+				PullRequestComments: true,
+				RepoURLs:            []string{"https://github.com/example/app"},
+			}),
+			phase: kargoapi.PromotionPhaseFailed,
+			provider: &fakeGitProvider{
+				prs: []*gitprovider.PullRequest{{Number: 42}},
+			},
+			assertions: func(t *testing.T, provider *fakeGitProvider, err error) {
+				require.NoError(t, err)
+				require.Len(t, provider.deploymentStatuses, 1)
+				status := provider.deploymentStatuses[0]
+				require.Equal(t, "fake-environment", status.Environment)
+				require.Equal(t, gitprovider.DeploymentStateFailure, status.State)
+				// Pull requests are only commented on for successful Promotions
+				require.Empty(t, provider.comments)
+			},
+		},
+		{
+			name: "pull request comments",
+			stage: newStage(&kargoapi.GitProviderNotifications{
+				PullRequestComments: true,
+				RepoURLs:            []string{"https://github.com/example/app"},
+			}),
+			phase: kargoapi.PromotionPhaseSucceeded,
+			provider: &fakeGitProvider{
+				prs: []*gitprovider.PullRequest{{Number: 42}, {Number: 43}},
+			},
+			assertions: func(t *testing.T, provider *fakeGitProvider, err error) {
+				require.NoError(t, err)
+				require.Empty(t, provider.deploymentStatuses)
+				require.Len(t, provider.comments, 2)
+				require.Contains(t, provider.comments[42], "fake-stage")
+				require.Contains(t, provider.comments[43], "Succeeded")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := &reconciler{
+				getGitProviderFn: func(
+					context.Context,
+					string,
+					string,
+				) (gitprovider.GitProviderService, error) {
+					return testCase.provider, testCase.getErr
+				},
+			}
+			err := r.notifyGitProviders(
+				context.Background(),
+				testCase.stage,
+				testPromo,
+				testFreight,
+				testCase.phase,
+			)
+			testCase.assertions(t, testCase.provider, err)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package promotions
+
+import (
+	"context"
+	"fmt"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+// promoteWithHooks executes the provided Stage's pre-promotion hooks, its
+// other promotion mechanisms, and its post-promotion hooks, in that order. Each
+// is only executed if everything before it succeeded. Pre-promotion hooks are
+// executed only once per Promotion, no matter how many times the other
+// promotion mechanisms must be executed before they succeed.
+func (r *reconciler) promoteWithHooks(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	promo *kargoapi.Promotion,
+	freight kargoapi.FreightReference,
+) (*kargoapi.PromotionStatus, kargoapi.FreightReference, error) {
+	var preHooks, postHooks []kargoapi.PromotionHook
+	if mechs := stage.Spec.PromotionMechanisms; mechs != nil {
+		preHooks, postHooks = mechs.PrePromotionHooks, mechs.PostPromotionHooks
+	}
+
+	// The outcomes of the pre-promotion hooks are recorded in the Promotion's
+	// status the first time they are executed.
+	preStatuses := promo.Status.PrePromotionHooks
+	if len(preHooks) > 0 && len(preStatuses) == 0 {
+		var err error
+		if preStatuses, err = r.hookRunner.RunHooks(
+			ctx,
+			stage,
+			promo,
+			freight,
+			preHooks,
+			false,
+		); err != nil {
+			return nil, freight, fmt.Errorf("error executing pre-promotion hooks: %w", err)
+		}
+	}
+	for _, status := range preStatuses {
+		if !status.Succeeded {
+			return &kargoapi.PromotionStatus{
+				Phase: kargoapi.PromotionPhaseFailed,
+				Message: fmt.Sprintf(
+					"pre-promotion hook %q failed: %s",
+					status.Name,
+					status.Message,
+				),
+				PrePromotionHooks: preStatuses,
+			}, freight, nil
+		}
+	}
+
+	newStatus, nextFreight, err := r.promoMechanisms.Promote(ctx, stage, promo, freight)
+	if err != nil {
+		return nil, nextFreight, err
+	}
+	newStatus.PrePromotionHooks = preStatuses
+	if newStatus.Phase != kargoapi.PromotionPhaseSucceeded || len(postHooks) == 0 {
+		return newStatus, nextFreight, nil
+	}
+
+	postStatuses, err := r.hookRunner.RunHooks(
+		ctx,
+		stage,
+		promo,
+		nextFreight,
+		postHooks,
+		true,
+	)
+	if err != nil {
+		return nil, nextFreight, fmt.Errorf("error executing post-promotion hooks: %w", err)
+	}
+	newStatus.PostPromotionHooks = postStatuses
+	for i, status := range postStatuses {
+		if !status.Succeeded && !postHooks[i].ContinueOnFailure {
+			newStatus.Phase = kargoapi.PromotionPhaseFailed
+			newStatus.Message = fmt.Sprintf(
+				"post-promotion hook %q failed: %s",
+				status.Name,
+				status.Message,
+			)
+			break
+		}
+	}
+	return newStatus, nextFreight, nil
+}
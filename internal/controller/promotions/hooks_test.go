@@ -0,0 +1,288 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+type fakeMechanism struct {
+	promoteFn func() (*kargoapi.PromotionStatus, error)
+	calls     int
+}
+
+func (f *fakeMechanism) GetName() string {
+	return "fake"
+}
+
+func (f *fakeMechanism) Promote(
+	_ context.Context,
+	_ *kargoapi.Stage,
+	_ *kargoapi.Promotion,
+	freight kargoapi.FreightReference,
+) (*kargoapi.PromotionStatus, kargoapi.FreightReference, error) {
+	f.calls++
+	status, err := f.promoteFn()
+	return status, freight, err
+}
+
+type fakeHookRunner struct {
+	runHooksFn func([]kargoapi.PromotionHook) ([]kargoapi.PromotionHookStatus, error)
+	calls      int
+}
+
+func (f *fakeHookRunner) RunHooks(
+	_ context.Context,
+	_ *kargoapi.Stage,
+	_ *kargoapi.Promotion,
+	_ kargoapi.FreightReference,
+	hooks []kargoapi.PromotionHook,
+	_ bool,
+) ([]kargoapi.PromotionHookStatus, error) {
+	f.calls++
+	return f.runHooksFn(hooks)
+}
+
+func TestPromoteWithHooks(t *testing.T) {
+	preHook := kargoapi.PromotionHook{ProjectHook: "pre"}
+	postHook := kargoapi.PromotionHook{ProjectHook: "post"}
+	succeeded := func() (*kargoapi.PromotionStatus, error) {
+		return &kargoapi.PromotionStatus{Phase: kargoapi.PromotionPhaseSucceeded}, nil
+	}
+	succeedAll := func(hooks []kargoapi.PromotionHook) ([]kargoapi.PromotionHookStatus, error) {
+		statuses := make([]kargoapi.PromotionHookStatus, len(hooks))
+		for i, hook := range hooks {
+			statuses[i] = kargoapi.PromotionHookStatus{Name: hook.ProjectHook, Succeeded: true}
+		}
+		return statuses, nil
+	}
+	testCases := []struct {
+		name        string
+		mechs       *kargoapi.PromotionMechanisms
+		promoStatus kargoapi.PromotionStatus
+		mechanism   *fakeMechanism
+		hookRunner  *fakeHookRunner
+		assertions  func(*testing.T, *kargoapi.PromotionStatus, *fakeMechanism, *fakeHookRunner, error)
+	}{
+		{
+			name:       "no hooks",
+			mechs:      &kargoapi.PromotionMechanisms{},
+			mechanism:  &fakeMechanism{promoteFn: succeeded},
+			hookRunner: &fakeHookRunner{},
+			assertions: func(
+				t *testing.T,
+				status *kargoapi.PromotionStatus,
+				mech *fakeMechanism,
+				runner *fakeHookRunner,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionPhaseSucceeded, status.Phase)
+				require.Equal(t, 1, mech.calls)
+				require.Zero(t, runner.calls)
+			},
+		},
+		{
+			name: "error running pre-promotion hooks",
+			mechs: &kargoapi.PromotionMechanisms{
+				PrePromotionHooks: []kargoapi.PromotionHook{preHook},
+			},
+			mechanism: &fakeMechanism{promoteFn: succeeded},
+			hookRunner: &fakeHookRunner{
+				runHooksFn: func([]kargoapi.PromotionHook) ([]kargoapi.PromotionHookStatus, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				_ *kargoapi.PromotionStatus,
+				mech *fakeMechanism,
+				_ *fakeHookRunner,
+				err error,
+			) {
+				require.ErrorContains(t, err, "error executing pre-promotion hooks")
+				require.ErrorContains(t, err, "something went wrong")
+				require.Zero(t, mech.calls)
+			},
+		},
+		{
+			name: "pre-promotion hook fails",
+			mechs: &kargoapi.PromotionMechanisms{
+				PrePromotionHooks: []kargoapi.PromotionHook{preHook},
+			},
+			mechanism: &fakeMechanism{promoteFn: succeeded},
+			hookRunner: &fakeHookRunner{
+				runHooksFn: func([]kargoapi.PromotionHook) ([]kargoapi.PromotionHookStatus, error) {
+					return []kargoapi.PromotionHookStatus{
+						{Name: "pre", Message: "something went wrong"},
+					}, nil
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				status *kargoapi.PromotionStatus,
+				mech *fakeMechanism,
+				_ *fakeHookRunner,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionPhaseFailed, status.Phase)
+				require.Equal(t, `pre-promotion hook "pre" failed: something went wrong`, status.Message)
+				require.Len(t, status.PrePromotionHooks, 1)
+				require.Zero(t, mech.calls)
+			},
+		},
+		{
+			name: "pre-promotion hooks are not executed again",
+			mechs: &kargoapi.PromotionMechanisms{
+				PrePromotionHooks: []kargoapi.PromotionHook{preHook},
+			},
+			promoStatus: kargoapi.PromotionStatus{
+				PrePromotionHooks: []kargoapi.PromotionHookStatus{{Name: "pre", Succeeded: true}},
+			},
+			mechanism: &fakeMechanism{
+				promoteFn: func() (*kargoapi.PromotionStatus, error) {
+					return &kargoapi.PromotionStatus{Phase: kargoapi.PromotionPhaseRunning}, nil
+				},
+			},
+			hookRunner: &fakeHookRunner{},
+			assertions: func(
+				t *testing.T,
+				status *kargoapi.PromotionStatus,
+				mech *fakeMechanism,
+				runner *fakeHookRunner,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionPhaseRunning, status.Phase)
+				require.Equal(
+					t,
+					[]kargoapi.PromotionHookStatus{{Name: "pre", Succeeded: true}},
+					status.PrePromotionHooks,
+				)
+				require.Equal(t, 1, mech.calls)
+				require.Zero(t, runner.calls)
+			},
+		},
+		{
+			name: "post-promotion hooks not executed before success",
+			mechs: &kargoapi.PromotionMechanisms{
+				PostPromotionHooks: []kargoapi.PromotionHook{postHook},
+			},
+			mechanism: &fakeMechanism{
+				promoteFn: func() (*kargoapi.PromotionStatus, error) {
+					return &kargoapi.PromotionStatus{Phase: kargoapi.PromotionPhaseRunning}, nil
+				},
+			},
+			hookRunner: &fakeHookRunner{},
+			assertions: func(
+				t *testing.T,
+				status *kargoapi.PromotionStatus,
+				_ *fakeMechanism,
+				runner *fakeHookRunner,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionPhaseRunning, status.Phase)
+				require.Zero(t, runner.calls)
+			},
+		},
+		{
+			name: "all hooks succeed",
+			mechs: &kargoapi.PromotionMechanisms{
+				PrePromotionHooks:  []kargoapi.PromotionHook{preHook},
+				PostPromotionHooks: []kargoapi.PromotionHook{postHook},
+			},
+			mechanism:  &fakeMechanism{promoteFn: succeeded},
+			hookRunner: &fakeHookRunner{runHooksFn: succeedAll},
+			assertions: func(
+				t *testing.T,
+				status *kargoapi.PromotionStatus,
+				_ *fakeMechanism,
+				runner *fakeHookRunner,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionPhaseSucceeded, status.Phase)
+				require.Len(t, status.PrePromotionHooks, 1)
+				require.Len(t, status.PostPromotionHooks, 1)
+				require.Equal(t, 2, runner.calls)
+			},
+		},
+		{
+			name: "post-promotion hook fails",
+			mechs: &kargoapi.PromotionMechanisms{
+				PostPromotionHooks: []kargoapi.PromotionHook{postHook},
+			},
+			mechanism: &fakeMechanism{promoteFn: succeeded},
+			hookRunner: &fakeHookRunner{
+				runHooksFn: func([]kargoapi.PromotionHook) ([]kargoapi.PromotionHookStatus, error) {
+					return []kargoapi.PromotionHookStatus{
+						{Name: "post", Message: "something went wrong"},
+					}, nil
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				status *kargoapi.PromotionStatus,
+				_ *fakeMechanism,
+				_ *fakeHookRunner,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionPhaseFailed, status.Phase)
+				require.Equal(t, `post-promotion hook "post" failed: something went wrong`, status.Message)
+			},
+		},
+		{
+			name: "post-promotion hook that allows continuing fails",
+			mechs: &kargoapi.PromotionMechanisms{
+				PostPromotionHooks: []kargoapi.PromotionHook{{
+					ProjectHook:       "post",
+					ContinueOnFailure: true,
+				}},
+			},
+			mechanism: &fakeMechanism{promoteFn: succeeded},
+			hookRunner: &fakeHookRunner{
+				runHooksFn: func([]kargoapi.PromotionHook) ([]kargoapi.PromotionHookStatus, error) {
+					return []kargoapi.PromotionHookStatus{
+						{Name: "post", Message: "something went wrong"},
+					}, nil
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				status *kargoapi.PromotionStatus,
+				_ *fakeMechanism,
+				_ *fakeHookRunner,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.PromotionPhaseSucceeded, status.Phase)
+				require.Len(t, status.PostPromotionHooks, 1)
+				require.False(t, status.PostPromotionHooks[0].Succeeded)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := &reconciler{
+				promoMechanisms: testCase.mechanism,
+				hookRunner:      testCase.hookRunner,
+			}
+			status, _, err := r.promoteWithHooks(
+				context.Background(),
+				&kargoapi.Stage{
+					Spec: kargoapi.StageSpec{PromotionMechanisms: testCase.mechs},
+				},
+				&kargoapi.Promotion{Status: testCase.promoStatus},
+				kargoapi.FreightReference{},
+			)
+			testCase.assertions(t, status, testCase.mechanism, testCase.hookRunner, err)
+		})
+	}
+}
@@ -10,9 +10,11 @@ import (
 	"github.com/kelseyhightower/envconfig"
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -25,19 +27,29 @@ import (
 	"github.com/akuity/kargo/internal/controller/promotion"
 	"github.com/akuity/kargo/internal/controller/runtime"
 	"github.com/akuity/kargo/internal/credentials"
+	libExec "github.com/akuity/kargo/internal/exec"
+	"github.com/akuity/kargo/internal/gitprovider"
 	"github.com/akuity/kargo/internal/kargo"
 	"github.com/akuity/kargo/internal/kubeclient"
 	libEvent "github.com/akuity/kargo/internal/kubernetes/event"
 	"github.com/akuity/kargo/internal/logging"
+	"github.com/akuity/kargo/internal/redact"
 )
 
 // ReconcilerConfig represents configuration for the promotion reconciler.
 type ReconcilerConfig struct {
 	ShardName string `envconfig:"SHARD_NAME"`
+	// PromotionRunnerProject is the name of the Project whose Promotions are
+	// exclusively executed by this reconciler. It is only set when the
+	// reconciler is part of a promotion runner dedicated to that Project.
+	PromotionRunnerProject string `envconfig:"PROMOTION_RUNNER_PROJECT"`
 }
 
 func (c ReconcilerConfig) Name() string {
 	name := "promotion-controller"
+	if c.PromotionRunnerProject != "" {
+		return "promotion-runner-" + c.PromotionRunnerProject
+	}
 	if c.ShardName != "" {
 		return name + "-" + c.ShardName
 	}
@@ -53,7 +65,9 @@ func ReconcilerConfigFromEnv() ReconcilerConfig {
 // reconciler reconciles Promotion resources.
 type reconciler struct {
 	kargoClient     client.Client
+	argocdClient    client.Client
 	promoMechanisms promotion.Mechanism
+	hookRunner      promotion.HookRunner
 
 	cfg ReconcilerConfig
 
@@ -71,6 +85,59 @@ type reconciler struct {
 	) (*kargoapi.Stage, error)
 
 	promoteFn func(context.Context, kargoapi.Promotion, *kargoapi.Freight) (*kargoapi.PromotionStatus, error)
+
+	// Back-promotion:
+
+	backPromoteFn func(context.Context, *kargoapi.Stage, *kargoapi.Freight) error
+
+	getProjectFn func(
+		context.Context,
+		client.Client,
+		string,
+	) (*kargoapi.Project, error)
+
+	listPromosFn func(
+		context.Context,
+		client.ObjectList,
+		...client.ListOption,
+	) error
+
+	createPromotionFn func(
+		context.Context,
+		client.Object,
+		...client.CreateOption,
+	) error
+
+	approveFreightFn func(context.Context, *kargoapi.Freight, string) error
+
+	// Lead time tracking:
+
+	markFreightPromotedFn func(context.Context, *kargoapi.Freight, string) error
+
+	// Git provider notifications:
+
+	notifyGitProvidersFn func(
+		context.Context,
+		*kargoapi.Stage,
+		*kargoapi.Promotion,
+		*kargoapi.Freight,
+		kargoapi.PromotionPhase,
+	) error
+
+	getGitProviderFn func(
+		ctx context.Context,
+		namespace string,
+		repoURL string,
+	) (gitprovider.GitProviderService, error)
+
+	// Argo CD Application annotations:
+
+	annotateArgoCDAppsFn func(
+		context.Context,
+		*kargoapi.Stage,
+		*kargoapi.Promotion,
+		*kargoapi.Freight,
+	) error
 }
 
 // SetupReconcilerWithManager initializes a reconciler for Promotion resources
@@ -97,6 +164,11 @@ func SetupReconcilerWithManager(
 	}
 	shardSelector := labels.NewSelector().Add(*shardRequirement)
 
+	limits, err := promotion.LimitsConfigFromEnv().Limits()
+	if err != nil {
+		return fmt.Errorf("error parsing promotion limits: %w", err)
+	}
+
 	var argocdClient client.Client
 	if argocdMgr != nil {
 		argocdClient = argocdMgr.GetClient()
@@ -107,6 +179,7 @@ func SetupReconcilerWithManager(
 		argocdClient,
 		libEvent.NewRecorder(ctx, kargoMgr.GetScheme(), kargoMgr.GetClient(), cfg.Name()),
 		credentialsDB,
+		limits,
 		cfg,
 	)
 
@@ -171,6 +244,7 @@ func newReconciler(
 	argocdClient client.Client,
 	recorder record.EventRecorder,
 	credentialsDB credentials.Database,
+	limits libExec.Limits,
 	cfg ReconcilerConfig,
 ) *reconciler {
 	pqs := promoQueues{
@@ -178,17 +252,30 @@ func newReconciler(
 		pendingPromoQueuesByStage: map[types.NamespacedName]runtime.PriorityQueue{},
 	}
 	r := &reconciler{
-		kargoClient: kargoClient,
-		recorder:    recorder,
-		cfg:         cfg,
-		pqs:         &pqs,
+		kargoClient:  kargoClient,
+		argocdClient: argocdClient,
+		recorder:     recorder,
+		cfg:          cfg,
+		pqs:          &pqs,
 		promoMechanisms: promotion.NewMechanisms(
+			kargoClient,
 			argocdClient,
 			credentialsDB,
+			limits,
 		),
+		hookRunner: promotion.NewHookRunner(kargoClient),
 	}
 	r.getStageFn = kargoapi.GetStage
 	r.promoteFn = r.promote
+	r.backPromoteFn = r.backPromote
+	r.getProjectFn = kargoapi.GetProject
+	r.listPromosFn = kargoClient.List
+	r.createPromotionFn = kargoClient.Create
+	r.approveFreightFn = r.approveFreight
+	r.markFreightPromotedFn = r.markFreightPromoted
+	r.notifyGitProvidersFn = r.notifyGitProviders
+	r.getGitProviderFn = gitprovider.NewGitProviderServiceFn(credentialsDB)
+	r.annotateArgoCDAppsFn = r.annotateArgoCDApps
 	return r
 }
 
@@ -235,6 +322,12 @@ func (r *reconciler) Reconcile(
 		// Promotion was deleted after the current reconciliation request was issued.
 		return ctrl.Result{}, nil
 	}
+	if executes, err := r.executesPromotionsOf(ctx, promo.Namespace); err != nil {
+		return ctrl.Result{}, err
+	} else if !executes {
+		logger.Debug("Promotion is executed by another promotion runner; ignoring")
+		return ctrl.Result{}, nil
+	}
 	// Find the Freight
 	freight, err := kargoapi.GetFreight(ctx, r.kargoClient, types.NamespacedName{
 		Namespace: promo.Namespace,
@@ -284,7 +377,12 @@ func (r *reconciler) Reconcile(
 		}
 	}
 
-	promoCtx := logging.ContextWithLogger(ctx, logger)
+	// Any secrets resolved while executing the Promotion, such as the passwords
+	// of repository credentials, are masked in its status and in logs.
+	secrets := &redact.Secrets{}
+	promoCtx := redact.ContextWithSecrets(ctx, secrets)
+	logger = logger.WithContext(promoCtx)
+	promoCtx = logging.ContextWithLogger(promoCtx, logger)
 
 	newStatus := promo.Status.DeepCopy()
 
@@ -296,7 +394,7 @@ func (r *reconciler) Reconcile(
 			if err := recover(); err != nil {
 				logger.Errorf("Promotion panic: %v", err)
 				newStatus.Phase = kargoapi.PromotionPhaseErrored
-				newStatus.Message = fmt.Sprintf("%v", err)
+				newStatus.Message = secrets.Redact(fmt.Sprintf("%v", err))
 			}
 		}()
 		otherStatus, promoteErr := r.promoteFn(
@@ -306,7 +404,7 @@ func (r *reconciler) Reconcile(
 		)
 		if promoteErr != nil {
 			newStatus.Phase = kargoapi.PromotionPhaseErrored
-			newStatus.Message = promoteErr.Error()
+			newStatus.Message = secrets.Redact(promoteErr.Error())
 			logger.Errorf("error executing Promotion: %s", promoteErr)
 		} else {
 			newStatus = otherStatus
@@ -315,6 +413,9 @@ func (r *reconciler) Reconcile(
 
 	if newStatus.Phase.IsTerminal() {
 		logger.Infof("promotion %s", newStatus.Phase)
+		if newStatus.FinishedAt == nil {
+			newStatus.FinishedAt = ptr.To(metav1.Now())
+		}
 	}
 
 	// Record the current refresh token as having been handled.
@@ -374,6 +475,46 @@ func (r *reconciler) Reconcile(
 				strconv.FormatBool(stage.Spec.Verification != nil)
 		}
 		r.recorder.AnnotatedEventf(promo, eventAnnotations, corev1.EventTypeNormal, reason, msg)
+
+		if newStatus.Phase == kargoapi.PromotionPhaseSucceeded && freight != nil {
+			if markErr := r.markFreightPromotedFn(ctx, freight, stage.Name); markErr != nil {
+				// Log the error, but don't let failure to record the milestone affect
+				// the outcome of this Promotion.
+				logger.Errorf("error marking Freight as promoted: %s", markErr)
+			}
+		}
+
+		// If the Freight bypassed any upstream Stages on its way to this one,
+		// promote it to those Stages as well if the Project calls for that.
+		if newStatus.Phase == kargoapi.PromotionPhaseSucceeded && freight != nil {
+			if backPromoteErr := r.backPromoteFn(ctx, stage, freight); backPromoteErr != nil {
+				// Log the error, but don't let failure to back-promote affect the
+				// outcome of this Promotion.
+				logger.Errorf("error back-promoting Freight: %s", backPromoteErr)
+			}
+		}
+
+		if freight != nil {
+			if notifyErr := r.notifyGitProvidersFn(
+				ctx,
+				stage,
+				promo,
+				freight,
+				newStatus.Phase,
+			); notifyErr != nil {
+				// Log the error, but don't let failure to notify Git providers affect
+				// the outcome of this Promotion.
+				logger.Errorf("error notifying Git providers: %s", notifyErr)
+			}
+		}
+
+		if newStatus.Phase == kargoapi.PromotionPhaseSucceeded && freight != nil {
+			if annotateErr := r.annotateArgoCDAppsFn(ctx, stage, promo, freight); annotateErr != nil {
+				// Log the error, but don't let failure to annotate Argo CD
+				// Applications affect the outcome of this Promotion.
+				logger.Errorf("error annotating Argo CD Applications: %s", annotateErr)
+			}
+		}
 	}
 
 	if err != nil {
@@ -392,6 +533,48 @@ func (r *reconciler) Reconcile(
 	return ctrl.Result{}, nil
 }
 
+// markFreightPromoted records the time at which the provided Freight was first
+// successfully promoted to the specified Stage, unless that has already been
+// recorded.
+func (r *reconciler) markFreightPromoted(
+	ctx context.Context,
+	freight *kargoapi.Freight,
+	stageName string,
+) error {
+	if _, promoted := freight.Status.PromotedTo[stageName]; promoted {
+		return nil
+	}
+	promotedAt := time.Now()
+	if err := kubeclient.PatchStatus(
+		ctx,
+		r.kargoClient,
+		freight,
+		func(status *kargoapi.FreightStatus) {
+			if status.PromotedTo == nil {
+				status.PromotedTo = map[string]kargoapi.PromotedStage{}
+			}
+			status.PromotedTo[stageName] = kargoapi.PromotedStage{
+				PromotedAt: ptr.To(metav1.NewTime(promotedAt)),
+			}
+		},
+	); err != nil {
+		return fmt.Errorf(
+			"error marking Freight %q in namespace %q as promoted to Stage %q: %w",
+			freight.Name,
+			freight.Namespace,
+			stageName,
+			err,
+		)
+	}
+	controller.ObserveFreightLeadTime(
+		freight,
+		stageName,
+		controller.LeadTimeMilestonePromoted,
+		promotedAt,
+	)
+	return nil
+}
+
 func (r *reconciler) promote(
 	ctx context.Context,
 	promo kargoapi.Promotion,
@@ -420,6 +603,14 @@ func (r *reconciler) promote(
 	if targetFreight == nil {
 		return nil, fmt.Errorf("Freight %q not found in namespace %q", promo.Spec.Freight, promo.Namespace)
 	}
+	if targetFreight.IsBlocked() {
+		return nil, fmt.Errorf(
+			"Freight %q in namespace %q is blocked: %s",
+			targetFreight.Name,
+			promo.Namespace,
+			targetFreight.Status.Blocked.Reason,
+		)
+	}
 	upstreamStages := make([]string, len(stage.Spec.Subscriptions.UpstreamStages))
 	for i, upstreamStage := range stage.Spec.Subscriptions.UpstreamStages {
 		upstreamStages[i] = upstreamStage.Name
@@ -440,6 +631,7 @@ func (r *reconciler) promote(
 		Commits:   targetFreight.Commits,
 		Images:    targetFreight.Images,
 		Charts:    targetFreight.Charts,
+		Packages:  targetFreight.Packages,
 		Warehouse: targetFreight.Warehouse,
 	}
 	err = kubeclient.PatchStatus(ctx, r.kargoClient, stage, func(status *kargoapi.StageStatus) {
@@ -453,11 +645,14 @@ func (r *reconciler) promote(
 		return nil, err
 	}
 
-	newStatus, nextFreight, err := r.promoMechanisms.Promote(ctx, stage, &promo, targetFreightRef)
+	newStatus, nextFreight, err := r.promoteWithHooks(ctx, stage, &promo, targetFreightRef)
 	if err != nil {
 		return nil, err
 	}
 	newStatus.Freight = &nextFreight
+	newStatus.PromotionMechanisms = stage.Spec.PromotionMechanisms.DeepCopy()
+	newStatus.PromotionMechanismsRevision = stage.Spec.PromotionMechanisms.Revision()
+	redactPromotionStatus(redact.SecretsFromContext(ctx), newStatus)
 
 	logger.Debugf("promotion %s", newStatus.Phase)
 
@@ -468,6 +663,7 @@ func (r *reconciler) promote(
 		if err = kubeclient.PatchStatus(ctx, r.kargoClient, stage, func(status *kargoapi.StageStatus) {
 			status.LastPromotion = status.CurrentPromotion
 			status.LastPromotion.Status = newStatus
+			status.LastPromotion.FinishedAt = ptr.To(metav1.Now())
 			if newStatus.Phase == kargoapi.PromotionPhaseSucceeded {
 				// Handle specific things that need to happen on success.
 				// 1. Trigger re-verification for re-promotions.
@@ -506,3 +702,25 @@ func (r *reconciler) promote(
 
 	return newStatus, nil
 }
+
+// redactPromotionStatus masks the provided secrets wherever they occur in the
+// free-form text of the provided PromotionStatus, i.e. in its message, its
+// metadata, and the names and messages of its promotion hooks.
+func redactPromotionStatus(secrets *redact.Secrets, status *kargoapi.PromotionStatus) {
+	if secrets == nil || status == nil {
+		return
+	}
+	status.Message = secrets.Redact(status.Message)
+	for k, v := range status.Metadata {
+		status.Metadata[k] = secrets.Redact(v)
+	}
+	for _, hooks := range [][]kargoapi.PromotionHookStatus{
+		status.PrePromotionHooks,
+		status.PostPromotionHooks,
+	} {
+		for i := range hooks {
+			hooks[i].Name = secrets.Redact(hooks[i].Name)
+			hooks[i].Message = secrets.Redact(hooks[i].Message)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package promotions
+
+import (
+	"context"
+	"fmt"
+)
+
+// executesPromotionsOf returns true if this reconciler is responsible for
+// executing the Promotions of the specified Project. A reconciler that is part
+// of a promotion runner dedicated to a Project executes only that Project's
+// Promotions. Any other reconciler executes the Promotions of every Project
+// that does not have a dedicated promotion runner.
+func (r *reconciler) executesPromotionsOf(
+	ctx context.Context,
+	projectName string,
+) (bool, error) {
+	if r.cfg.PromotionRunnerProject != "" {
+		return projectName == r.cfg.PromotionRunnerProject, nil
+	}
+	project, err := r.getProjectFn(ctx, r.kargoClient, projectName)
+	if err != nil {
+		return false, fmt.Errorf("error finding Project %q: %w", projectName, err)
+	}
+	return project == nil || !project.HasDedicatedPromotionRunner(), nil
+}
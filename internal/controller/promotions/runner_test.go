@@ -0,0 +1,104 @@
+package promotions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestExecutesPromotionsOf(t *testing.T) {
+	testCases := []struct {
+		name       string
+		reconciler *reconciler
+		assertions func(*testing.T, bool, error)
+	}{
+		{
+			name: "dedicated runner of the Project",
+			reconciler: &reconciler{
+				cfg: ReconcilerConfig{PromotionRunnerProject: "fake-project"},
+			},
+			assertions: func(t *testing.T, executes bool, err error) {
+				require.NoError(t, err)
+				require.True(t, executes)
+			},
+		},
+		{
+			name: "dedicated runner of another Project",
+			reconciler: &reconciler{
+				cfg: ReconcilerConfig{PromotionRunnerProject: "other-project"},
+			},
+			assertions: func(t *testing.T, executes bool, err error) {
+				require.NoError(t, err)
+				require.False(t, executes)
+			},
+		},
+		{
+			name: "error getting Project",
+			reconciler: &reconciler{
+				getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, _ bool, err error) {
+				require.ErrorContains(t, err, "error finding Project")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "Project not found",
+			reconciler: &reconciler{
+				getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+					return nil, nil
+				},
+			},
+			assertions: func(t *testing.T, executes bool, err error) {
+				require.NoError(t, err)
+				require.True(t, executes)
+			},
+		},
+		{
+			name: "Project without dedicated runner",
+			reconciler: &reconciler{
+				getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+					return &kargoapi.Project{}, nil
+				},
+			},
+			assertions: func(t *testing.T, executes bool, err error) {
+				require.NoError(t, err)
+				require.True(t, executes)
+			},
+		},
+		{
+			name: "Project with dedicated runner",
+			reconciler: &reconciler{
+				getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+					return &kargoapi.Project{
+						Spec: &kargoapi.ProjectSpec{
+							Isolation: &kargoapi.ProjectIsolation{
+								DedicatedPromotionRunner: true,
+							},
+						},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, executes bool, err error) {
+				require.NoError(t, err)
+				require.False(t, executes)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			executes, err := testCase.reconciler.executesPromotionsOf(
+				context.Background(),
+				"fake-project",
+			)
+			testCase.assertions(t, executes, err)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package stages
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// syncArtifactsUnavailableCondition reflects, in the ArtifactsUnavailable
+// condition of the provided StageStatus, whether the Stage's current Freight or
+// any Freight in its history has been found by its Warehouse to reference
+// artifacts that are no longer available upstream. This makes it known, before
+// it is needed, that rolling back to such Freight is likely to fail.
+func (r *reconciler) syncArtifactsUnavailableCondition(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	status *kargoapi.StageStatus,
+) {
+	logger := logging.LoggerFromContext(ctx)
+
+	var affected []string
+	checked := map[string]struct{}{}
+	refs := make([]kargoapi.FreightReference, 0, len(status.History)+1)
+	if status.CurrentFreight != nil {
+		refs = append(refs, *status.CurrentFreight)
+	}
+	refs = append(refs, status.History...)
+	for _, ref := range refs {
+		if _, ok := checked[ref.Name]; ok {
+			continue
+		}
+		checked[ref.Name] = struct{}{}
+		freight, err := r.getFreightFn(
+			ctx,
+			r.kargoClient,
+			types.NamespacedName{
+				Namespace: stage.Namespace,
+				Name:      ref.Name,
+			},
+		)
+		if err != nil {
+			// Leave the condition as it was rather than report something we
+			// could not confirm.
+			logger.Errorf("error finding Freight %q: %s", ref.Name, err)
+			return
+		}
+		if freight == nil || !freight.HasUnavailableArtifacts() {
+			continue
+		}
+		if status.CurrentFreight != nil && ref.Name == status.CurrentFreight.Name {
+			affected = append(affected, fmt.Sprintf("%q (current)", ref.Name))
+		} else {
+			affected = append(affected, fmt.Sprintf("%q (history)", ref.Name))
+		}
+	}
+
+	if len(affected) == 0 {
+		meta.RemoveStatusCondition(&status.Conditions, kargoapi.StageConditionTypeArtifactsUnavailable)
+		return
+	}
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:   kargoapi.StageConditionTypeArtifactsUnavailable,
+		Status: metav1.ConditionTrue,
+		Reason: kargoapi.StageConditionReasonFreightArtifactsNotFound,
+		Message: fmt.Sprintf(
+			"Freight references artifacts that are no longer available upstream: %s",
+			strings.Join(affected, ", "),
+		),
+		ObservedGeneration: stage.Generation,
+	})
+}
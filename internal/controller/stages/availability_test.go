@@ -0,0 +1,109 @@
+package stages
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestSyncArtifactsUnavailableCondition(t *testing.T) {
+	unavailableConditions := []metav1.Condition{{
+		Type:   kargoapi.FreightConditionTypeArtifactsUnavailable,
+		Status: metav1.ConditionTrue,
+	}}
+
+	stage := &kargoapi.Stage{
+		Status: kargoapi.StageStatus{
+			CurrentFreight: &kargoapi.FreightReference{Name: "current"},
+			History: kargoapi.FreightReferenceStack{
+				{Name: "current"},
+				{Name: "previous"},
+				{Name: "older"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		existing     []metav1.Condition
+		getFreightFn func(context.Context, client.Client, types.NamespacedName) (*kargoapi.Freight, error)
+		assertions   func(*testing.T, kargoapi.StageStatus)
+	}{
+		{
+			name: "error getting Freight",
+			existing: []metav1.Condition{{
+				Type:   kargoapi.StageConditionTypeArtifactsUnavailable,
+				Status: metav1.ConditionTrue,
+			}},
+			getFreightFn: func(context.Context, client.Client, types.NamespacedName) (*kargoapi.Freight, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, status kargoapi.StageStatus) {
+				// The condition should have been left alone
+				require.True(
+					t,
+					meta.IsStatusConditionTrue(status.Conditions, kargoapi.StageConditionTypeArtifactsUnavailable),
+				)
+			},
+		},
+		{
+			name: "all artifacts available",
+			existing: []metav1.Condition{{
+				Type:   kargoapi.StageConditionTypeArtifactsUnavailable,
+				Status: metav1.ConditionTrue,
+			}},
+			getFreightFn: func(context.Context, client.Client, types.NamespacedName) (*kargoapi.Freight, error) {
+				return &kargoapi.Freight{}, nil
+			},
+			assertions: func(t *testing.T, status kargoapi.StageStatus) {
+				require.Empty(t, status.Conditions)
+			},
+		},
+		{
+			name: "artifacts unavailable",
+			getFreightFn: func(
+				_ context.Context,
+				_ client.Client,
+				key types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				freight := &kargoapi.Freight{}
+				if key.Name != "previous" {
+					freight.Status.Conditions = unavailableConditions
+				}
+				return freight, nil
+			},
+			assertions: func(t *testing.T, status kargoapi.StageStatus) {
+				condition := meta.FindStatusCondition(
+					status.Conditions,
+					kargoapi.StageConditionTypeArtifactsUnavailable,
+				)
+				require.NotNil(t, condition)
+				require.Equal(t, metav1.ConditionTrue, condition.Status)
+				require.Equal(t, kargoapi.StageConditionReasonFreightArtifactsNotFound, condition.Reason)
+				require.Equal(
+					t,
+					`Freight references artifacts that are no longer available `+
+						`upstream: "current" (current), "older" (history)`,
+					condition.Message,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := &reconciler{getFreightFn: testCase.getFreightFn}
+			status := *stage.Status.DeepCopy()
+			status.Conditions = testCase.existing
+			r.syncArtifactsUnavailableCondition(context.Background(), stage, &status)
+			testCase.assertions(t, status)
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package stages
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	libargocd "github.com/akuity/kargo/internal/argocd"
+	argocd "github.com/akuity/kargo/internal/controller/argocd/api/v1alpha1"
+	"github.com/akuity/kargo/internal/controller/git"
+	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
+	"github.com/akuity/kargo/internal/kargo"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// syncDriftCondition detects drift between what the Stage's last successful
+// Promotion wrote and the current state of the targets of the Stage's
+// promotion mechanisms and reflects the result in the Drifted condition of the
+// provided StageStatus. It returns a bool indicating whether drift was
+// detected.
+func (r *reconciler) syncDriftCondition(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	status *kargoapi.StageStatus,
+) bool {
+	logger := logging.LoggerFromContext(ctx)
+
+	if stage.Spec.DriftDetection == nil || !stage.Spec.DriftDetection.Enabled {
+		meta.RemoveStatusCondition(&status.Conditions, kargoapi.StageConditionTypeDrifted)
+		return false
+	}
+
+	promotedFreight := lastPromotedFreight(stage)
+	if promotedFreight == nil {
+		// Without knowing what the last successful Promotion wrote, there is
+		// nothing to compare against.
+		meta.RemoveStatusCondition(&status.Conditions, kargoapi.StageConditionTypeDrifted)
+		return false
+	}
+
+	drift, err := r.detectDriftFn(ctx, stage, *promotedFreight)
+	if err != nil {
+		logger.Errorf("error detecting drift: %s", err)
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               kargoapi.StageConditionTypeDrifted,
+			Status:             metav1.ConditionUnknown,
+			Reason:             kargoapi.StageConditionReasonDriftDetectionFailed,
+			Message:            err.Error(),
+			ObservedGeneration: stage.Generation,
+		})
+		return false
+	}
+
+	if len(drift) == 0 {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               kargoapi.StageConditionTypeDrifted,
+			Status:             metav1.ConditionFalse,
+			Reason:             kargoapi.StageConditionReasonNoDrift,
+			Message:            "No drift detected since the last Promotion",
+			ObservedGeneration: stage.Generation,
+		})
+		return false
+	}
+
+	logger.WithField("drift", drift).Debug("drift detected")
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               kargoapi.StageConditionTypeDrifted,
+		Status:             metav1.ConditionTrue,
+		Reason:             kargoapi.StageConditionReasonDriftDetected,
+		Message:            strings.Join(drift, "; "),
+		ObservedGeneration: stage.Generation,
+	})
+	return true
+}
+
+// lastPromotedFreight returns the FreightReference recorded by the Stage's last
+// Promotion if that Promotion succeeded and promoted the Stage's current
+// Freight. Otherwise, it returns nil. Unlike the Stage's current Freight, this
+// reflects any commits written by the most recent re-promotion of that
+// Freight.
+func lastPromotedFreight(stage *kargoapi.Stage) *kargoapi.FreightReference {
+	lastPromo := stage.Status.LastPromotion
+	if lastPromo == nil || lastPromo.Status == nil || lastPromo.Status.Freight == nil ||
+		lastPromo.Status.Phase != kargoapi.PromotionPhaseSucceeded {
+		return nil
+	}
+	if stage.Status.CurrentFreight == nil ||
+		stage.Status.CurrentFreight.Name != lastPromo.Status.Freight.Name {
+		return nil
+	}
+	return lastPromo.Status.Freight
+}
+
+// detectDrift compares the targets of the provided Stage's promotion mechanisms
+// to what was written to them when the provided Freight was promoted. It
+// returns a description of each discrepancy found.
+func (r *reconciler) detectDrift(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	freight kargoapi.FreightReference,
+) ([]string, error) {
+	var drift []string
+
+	for _, update := range stage.Spec.PromotionMechanisms.GitRepoUpdates {
+		updateRepoURL := libGit.NormalizeURL(update.RepoURL)
+		var written string
+		for _, commit := range freight.Commits {
+			if libGit.NormalizeURL(commit.RepoURL) == updateRepoURL {
+				written = commit.HealthCheckCommit
+				break
+			}
+		}
+		if written == "" {
+			// We don't know what commit the Promotion wrote to this repository.
+			continue
+		}
+		head, err := r.getGitBranchHeadFn(ctx, stage.Namespace, update)
+		if err != nil {
+			return nil, err
+		}
+		if head != written {
+			drift = append(drift, fmt.Sprintf(
+				"branch %q of Git repository %q is at commit %q, but the last Promotion wrote commit %q",
+				update.WriteBranch,
+				update.RepoURL,
+				head,
+				written,
+			))
+		}
+	}
+
+	if r.argocdClient == nil {
+		return drift, nil
+	}
+	for _, update := range stage.Spec.PromotionMechanisms.ArgoCDAppUpdates {
+		namespace := update.AppNamespace
+		if namespace == "" {
+			namespace = libargocd.Namespace()
+		}
+		app, err := argocd.GetApplication(ctx, r.argocdClient, namespace, update.AppName)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error finding Argo CD Application %q in namespace %q: %w",
+				update.AppName,
+				namespace,
+				err,
+			)
+		}
+		if app == nil {
+			continue
+		}
+		sources := app.Spec.Sources
+		if app.Spec.Source != nil {
+			sources = append([]argocd.ApplicationSource{*app.Spec.Source}, sources...)
+		}
+		for _, srcUpdate := range update.SourceUpdates {
+			if !srcUpdate.UpdateTargetRevision {
+				continue
+			}
+			for _, source := range sources {
+				expected := expectedTargetRevision(source, srcUpdate, freight)
+				if expected == "" || source.TargetRevision == expected {
+					continue
+				}
+				drift = append(drift, fmt.Sprintf(
+					"source %q of Argo CD Application %q in namespace %q targets "+
+						"revision %q, but the last Promotion set it to %q",
+					source.RepoURL,
+					update.AppName,
+					namespace,
+					source.TargetRevision,
+					expected,
+				))
+			}
+		}
+	}
+
+	return drift, nil
+}
+
+// expectedTargetRevision returns the target revision that promotion of the
+// provided Freight would have set on the provided Argo CD Application source
+// by way of the provided update. If the update does not apply to the source,
+// or the Freight does not reference an applicable artifact, an empty string is
+// returned.
+func expectedTargetRevision(
+	source argocd.ApplicationSource,
+	update kargoapi.ArgoCDSourceUpdate,
+	freight kargoapi.FreightReference,
+) string {
+	if source.Chart != "" || update.Chart != "" {
+		// Kargo uses the "oci://" prefix, but Argo CD does not.
+		if source.RepoURL != strings.TrimPrefix(update.RepoURL, "oci://") || source.Chart != update.Chart {
+			return ""
+		}
+		for _, chart := range freight.Charts {
+			if path.Join(strings.TrimPrefix(chart.RepoURL, "oci://"), chart.Name) ==
+				path.Join(source.RepoURL, source.Chart) {
+				return chart.Version
+			}
+		}
+		return ""
+	}
+	sourceRepoURL := libGit.NormalizeURL(source.RepoURL)
+	if sourceRepoURL != libGit.NormalizeURL(update.RepoURL) {
+		return ""
+	}
+	for _, commit := range freight.Commits {
+		if libGit.NormalizeURL(commit.RepoURL) == sourceRepoURL {
+			if commit.Tag != "" {
+				return commit.Tag
+			}
+			return commit.ID
+		}
+	}
+	return ""
+}
+
+// getGitBranchHead returns the ID of the commit at the head of the branch
+// written to by the provided GitRepoUpdate.
+func (r *reconciler) getGitBranchHead(
+	ctx context.Context,
+	namespace string,
+	update kargoapi.GitRepoUpdate,
+) (string, error) {
+	var repoCreds *git.RepoCredentials
+	creds, ok, err := r.credentialsDB.Get(ctx, namespace, credentials.TypeGit, update.RepoURL)
+	if err != nil {
+		return "", fmt.Errorf("error obtaining credentials for git repo %q: %w", update.RepoURL, err)
+	}
+	if ok {
+		repoCreds = &git.RepoCredentials{
+			Username:      creds.Username,
+			Password:      creds.Password,
+			SSHPrivateKey: creds.SSHPrivateKey,
+		}
+	}
+	repo, err := git.Clone(
+		update.RepoURL,
+		&git.ClientOptions{Credentials: repoCreds},
+		&git.CloneOptions{
+			Branch:                update.WriteBranch,
+			SingleBranch:          true,
+			Depth:                 1,
+			InsecureSkipTLSVerify: update.InsecureSkipTLSVerify,
+			InsecureHTTP:          update.InsecureHTTP,
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("error cloning git repo %q: %w", update.RepoURL, err)
+	}
+	defer repo.Close()
+	head, err := repo.LastCommitID()
+	if err != nil {
+		return "", fmt.Errorf(
+			"error determining head of branch %q of git repo %q: %w",
+			update.WriteBranch,
+			update.RepoURL,
+			err,
+		)
+	}
+	return head, nil
+}
+
+// remediateDrift re-promotes the provided Stage to its current Freight, thereby
+// overwriting any changes made outside of Kargo, if the Project permits it.
+func (r *reconciler) remediateDrift(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+) (bool, error) {
+	logger := logging.LoggerFromContext(ctx)
+
+	project, err := r.getProjectFn(ctx, r.kargoClient, stage.Namespace)
+	if err != nil {
+		return false, fmt.Errorf("error finding Project %q: %w", stage.Namespace, err)
+	}
+	if project == nil {
+		return false, fmt.Errorf("Project %q not found", stage.Namespace)
+	}
+	if m := project.ActiveMaintenanceMode(r.nowFn()); m != nil {
+		logger.Debugf("drift remediation is paused: %s", m.Describe())
+		return false, nil
+	}
+	var permitted bool
+	if project.Spec != nil {
+		for _, policy := range project.Spec.PromotionPolicies {
+			if policy.Stage == stage.Name {
+				permitted = policy.DriftRemediationEnabled
+				break
+			}
+		}
+	}
+	if !permitted {
+		logger.Debug("drift remediation is not permitted for the Stage")
+		return false, nil
+	}
+
+	freight, err := r.getFreightFn(
+		ctx,
+		r.kargoClient,
+		types.NamespacedName{
+			Namespace: stage.Namespace,
+			Name:      stage.Status.CurrentFreight.Name,
+		},
+	)
+	if err != nil {
+		return false, fmt.Errorf(
+			"error finding Freight %q in namespace %q: %w",
+			stage.Status.CurrentFreight.Name,
+			stage.Namespace,
+			err,
+		)
+	}
+	if freight == nil {
+		return false, fmt.Errorf(
+			"Freight %q not found in namespace %q",
+			stage.Status.CurrentFreight.Name,
+			stage.Namespace,
+		)
+	}
+	if freight.IsBlocked() {
+		logger.Debug("current Freight is blocked; not remediating drift")
+		return false, nil
+	}
+
+	promo := kargo.NewPromotion(ctx, *stage, freight.Name)
+	if err = r.createPromotionFn(ctx, &promo); err != nil {
+		return false, fmt.Errorf(
+			"error creating Promotion of Stage %q in namespace %q to Freight %q: %w",
+			stage.Name,
+			stage.Namespace,
+			freight.Name,
+			err,
+		)
+	}
+
+	r.recorder.AnnotatedEventf(
+		&promo,
+		kargoapi.NewPromotionEventAnnotations(
+			ctx,
+			kargoapi.FormatEventControllerActor(r.cfg.Name()),
+			&promo,
+			freight,
+		),
+		corev1.EventTypeNormal,
+		kargoapi.EventReasonPromotionCreated,
+		"Automatically re-promoted Freight for Stage %q to remediate drift",
+		promo.Spec.Stage,
+	)
+
+	logger.WithField("promotion", promo.Name).Debug("created Promotion resource to remediate drift")
+	return true, nil
+}
@@ -0,0 +1,439 @@
+package stages
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	argocd "github.com/akuity/kargo/internal/controller/argocd/api/v1alpha1"
+	fakeevent "github.com/akuity/kargo/internal/kubernetes/event/fake"
+)
+
+func TestSyncDriftCondition(t *testing.T) {
+	promotedStatus := kargoapi.StageStatus{
+		CurrentFreight: &kargoapi.FreightReference{Name: "fake-freight"},
+		LastPromotion: &kargoapi.PromotionInfo{
+			Name: "fake-promo",
+			Status: &kargoapi.PromotionStatus{
+				Phase:   kargoapi.PromotionPhaseSucceeded,
+				Freight: &kargoapi.FreightReference{Name: "fake-freight"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		stage         *kargoapi.Stage
+		detectDriftFn func(
+			context.Context,
+			*kargoapi.Stage,
+			kargoapi.FreightReference,
+		) ([]string, error)
+		assertions func(*testing.T, kargoapi.StageStatus, bool)
+	}{
+		{
+			name: "drift detection not enabled",
+			stage: &kargoapi.Stage{
+				Status: kargoapi.StageStatus{
+					Conditions: []metav1.Condition{{
+						Type:   kargoapi.StageConditionTypeDrifted,
+						Status: metav1.ConditionTrue,
+					}},
+				},
+			},
+			assertions: func(t *testing.T, status kargoapi.StageStatus, drifted bool) {
+				require.False(t, drifted)
+				require.Empty(t, status.Conditions)
+			},
+		},
+		{
+			name: "no successful Promotion of current Freight",
+			stage: &kargoapi.Stage{
+				Spec: kargoapi.StageSpec{
+					DriftDetection: &kargoapi.DriftDetection{Enabled: true},
+				},
+				Status: kargoapi.StageStatus{
+					CurrentFreight: &kargoapi.FreightReference{Name: "fake-freight"},
+				},
+			},
+			assertions: func(t *testing.T, status kargoapi.StageStatus, drifted bool) {
+				require.False(t, drifted)
+				require.Empty(t, status.Conditions)
+			},
+		},
+		{
+			name: "error detecting drift",
+			stage: &kargoapi.Stage{
+				Spec: kargoapi.StageSpec{
+					DriftDetection: &kargoapi.DriftDetection{Enabled: true},
+				},
+				Status: *promotedStatus.DeepCopy(),
+			},
+			detectDriftFn: func(
+				context.Context,
+				*kargoapi.Stage,
+				kargoapi.FreightReference,
+			) ([]string, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, status kargoapi.StageStatus, drifted bool) {
+				require.False(t, drifted)
+				condition := meta.FindStatusCondition(status.Conditions, kargoapi.StageConditionTypeDrifted)
+				require.NotNil(t, condition)
+				require.Equal(t, metav1.ConditionUnknown, condition.Status)
+				require.Equal(t, kargoapi.StageConditionReasonDriftDetectionFailed, condition.Reason)
+				require.Equal(t, "something went wrong", condition.Message)
+			},
+		},
+		{
+			name: "no drift",
+			stage: &kargoapi.Stage{
+				Spec: kargoapi.StageSpec{
+					DriftDetection: &kargoapi.DriftDetection{Enabled: true},
+				},
+				Status: *promotedStatus.DeepCopy(),
+			},
+			detectDriftFn: func(
+				context.Context,
+				*kargoapi.Stage,
+				kargoapi.FreightReference,
+			) ([]string, error) {
+				return nil, nil
+			},
+			assertions: func(t *testing.T, status kargoapi.StageStatus, drifted bool) {
+				require.False(t, drifted)
+				condition := meta.FindStatusCondition(status.Conditions, kargoapi.StageConditionTypeDrifted)
+				require.NotNil(t, condition)
+				require.Equal(t, metav1.ConditionFalse, condition.Status)
+				require.Equal(t, kargoapi.StageConditionReasonNoDrift, condition.Reason)
+			},
+		},
+		{
+			name: "drift",
+			stage: &kargoapi.Stage{
+				Spec: kargoapi.StageSpec{
+					DriftDetection: &kargoapi.DriftDetection{Enabled: true},
+				},
+				Status: *promotedStatus.DeepCopy(),
+			},
+			detectDriftFn: func(
+				context.Context,
+				*kargoapi.Stage,
+				kargoapi.FreightReference,
+			) ([]string, error) {
+				return []string{"fake-drift-1", "fake-drift-2"}, nil
+			},
+			assertions: func(t *testing.T, status kargoapi.StageStatus, drifted bool) {
+				require.True(t, drifted)
+				condition := meta.FindStatusCondition(status.Conditions, kargoapi.StageConditionTypeDrifted)
+				require.NotNil(t, condition)
+				require.Equal(t, metav1.ConditionTrue, condition.Status)
+				require.Equal(t, kargoapi.StageConditionReasonDriftDetected, condition.Reason)
+				require.Equal(t, "fake-drift-1; fake-drift-2", condition.Message)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := &reconciler{detectDriftFn: testCase.detectDriftFn}
+			status := *testCase.stage.Status.DeepCopy()
+			drifted := r.syncDriftCondition(context.Background(), testCase.stage, &status)
+			testCase.assertions(t, status, drifted)
+		})
+	}
+}
+
+func TestDetectDrift(t *testing.T) {
+	freight := kargoapi.FreightReference{
+		Name: "fake-freight",
+		Commits: []kargoapi.GitCommit{{
+			RepoURL:           "https://github.com/example/repo.git",
+			ID:                "fake-commit",
+			HealthCheckCommit: "fake-written-commit",
+		}},
+		Charts: []kargoapi.Chart{{
+			RepoURL: "oci://example.com/charts/chart",
+			Version: "1.0.0",
+		}},
+	}
+
+	testCases := []struct {
+		name               string
+		stage              *kargoapi.Stage
+		app                *argocd.Application
+		getGitBranchHeadFn func(context.Context, string, kargoapi.GitRepoUpdate) (string, error)
+		assertions         func(*testing.T, []string, error)
+	}{
+		{
+			name: "error getting Git branch head",
+			stage: &kargoapi.Stage{
+				Spec: kargoapi.StageSpec{
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{
+						GitRepoUpdates: []kargoapi.GitRepoUpdate{{
+							RepoURL:     "https://github.com/example/repo",
+							WriteBranch: "stage/test",
+						}},
+					},
+				},
+			},
+			getGitBranchHeadFn: func(context.Context, string, kargoapi.GitRepoUpdate) (string, error) {
+				return "", errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, _ []string, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "no drift",
+			stage: &kargoapi.Stage{
+				Spec: kargoapi.StageSpec{
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{
+						GitRepoUpdates: []kargoapi.GitRepoUpdate{{
+							RepoURL:     "https://github.com/example/repo",
+							WriteBranch: "stage/test",
+						}},
+						ArgoCDAppUpdates: []kargoapi.ArgoCDAppUpdate{{
+							AppName:      "fake-app",
+							AppNamespace: "argocd",
+							SourceUpdates: []kargoapi.ArgoCDSourceUpdate{{
+								RepoURL:              "oci://example.com/charts",
+								Chart:                "chart",
+								UpdateTargetRevision: true,
+							}},
+						}},
+					},
+				},
+			},
+			app: &argocd.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "argocd",
+					Name:      "fake-app",
+				},
+				Spec: argocd.ApplicationSpec{
+					Source: &argocd.ApplicationSource{
+						RepoURL:        "example.com/charts",
+						Chart:          "chart",
+						TargetRevision: "1.0.0",
+					},
+				},
+			},
+			getGitBranchHeadFn: func(context.Context, string, kargoapi.GitRepoUpdate) (string, error) {
+				return "fake-written-commit", nil
+			},
+			assertions: func(t *testing.T, drift []string, err error) {
+				require.NoError(t, err)
+				require.Empty(t, drift)
+			},
+		},
+		{
+			name: "drift",
+			stage: &kargoapi.Stage{
+				Spec: kargoapi.StageSpec{
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{
+						GitRepoUpdates: []kargoapi.GitRepoUpdate{{
+							RepoURL:     "https://github.com/example/repo",
+							WriteBranch: "stage/test",
+						}},
+						ArgoCDAppUpdates: []kargoapi.ArgoCDAppUpdate{{
+							AppName:      "fake-app",
+							AppNamespace: "argocd",
+							SourceUpdates: []kargoapi.ArgoCDSourceUpdate{{
+								RepoURL:              "oci://example.com/charts",
+								Chart:                "chart",
+								UpdateTargetRevision: true,
+							}},
+						}},
+					},
+				},
+			},
+			app: &argocd.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "argocd",
+					Name:      "fake-app",
+				},
+				Spec: argocd.ApplicationSpec{
+					Source: &argocd.ApplicationSource{
+						RepoURL:        "example.com/charts",
+						Chart:          "chart",
+						TargetRevision: "0.9.0",
+					},
+				},
+			},
+			getGitBranchHeadFn: func(context.Context, string, kargoapi.GitRepoUpdate) (string, error) {
+				return "fake-manual-commit", nil
+			},
+			assertions: func(t *testing.T, drift []string, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]string{
+						`branch "stage/test" of Git repository "https://github.com/example/repo" ` +
+							`is at commit "fake-manual-commit", but the last Promotion wrote ` +
+							`commit "fake-written-commit"`,
+						`source "example.com/charts" of Argo CD Application "fake-app" ` +
+							`in namespace "argocd" targets revision "0.9.0", but the last ` +
+							`Promotion set it to "1.0.0"`,
+					},
+					drift,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, argocd.AddToScheme(scheme))
+			argocdClient := fake.NewClientBuilder().WithScheme(scheme)
+			if testCase.app != nil {
+				argocdClient = argocdClient.WithObjects(testCase.app)
+			}
+			r := &reconciler{
+				argocdClient:       argocdClient.Build(),
+				getGitBranchHeadFn: testCase.getGitBranchHeadFn,
+			}
+			drift, err := r.detectDrift(context.Background(), testCase.stage, freight)
+			testCase.assertions(t, drift, err)
+		})
+	}
+}
+
+func TestRemediateDrift(t *testing.T) {
+	stage := &kargoapi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-namespace",
+			Name:      "fake-stage",
+		},
+		Status: kargoapi.StageStatus{
+			CurrentFreight: &kargoapi.FreightReference{Name: "fake-freight"},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		project      *kargoapi.Project
+		freight      *kargoapi.Freight
+		assertions   func(*testing.T, bool, error)
+		expectCreate bool
+	}{
+		{
+			name: "drift remediation not permitted",
+			project: &kargoapi.Project{
+				Spec: &kargoapi.ProjectSpec{
+					PromotionPolicies: []kargoapi.PromotionPolicy{{
+						Stage:                "fake-stage",
+						AutoPromotionEnabled: true,
+					}},
+				},
+			},
+			assertions: func(t *testing.T, remediating bool, err error) {
+				require.NoError(t, err)
+				require.False(t, remediating)
+			},
+		},
+		{
+			name: "paused by maintenance mode",
+			project: &kargoapi.Project{
+				Spec: &kargoapi.ProjectSpec{
+					PromotionPolicies: []kargoapi.PromotionPolicy{{
+						Stage:                   "fake-stage",
+						DriftRemediationEnabled: true,
+					}},
+					MaintenanceMode: &kargoapi.MaintenanceMode{Enabled: true},
+				},
+			},
+			freight: &kargoapi.Freight{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake-freight"},
+			},
+			assertions: func(t *testing.T, remediating bool, err error) {
+				require.NoError(t, err)
+				require.False(t, remediating)
+			},
+		},
+		{
+			name: "current Freight is blocked",
+			project: &kargoapi.Project{
+				Spec: &kargoapi.ProjectSpec{
+					PromotionPolicies: []kargoapi.PromotionPolicy{{
+						Stage:                   "fake-stage",
+						DriftRemediationEnabled: true,
+					}},
+				},
+			},
+			freight: &kargoapi.Freight{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake-freight"},
+				Status: kargoapi.FreightStatus{
+					Blocked: &kargoapi.FreightBlock{Reason: "fake-reason"},
+				},
+			},
+			assertions: func(t *testing.T, remediating bool, err error) {
+				require.NoError(t, err)
+				require.False(t, remediating)
+			},
+		},
+		{
+			name: "success",
+			project: &kargoapi.Project{
+				Spec: &kargoapi.ProjectSpec{
+					PromotionPolicies: []kargoapi.PromotionPolicy{{
+						Stage:                   "fake-stage",
+						DriftRemediationEnabled: true,
+					}},
+				},
+			},
+			freight: &kargoapi.Freight{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake-freight"},
+			},
+			expectCreate: true,
+			assertions: func(t *testing.T, remediating bool, err error) {
+				require.NoError(t, err)
+				require.True(t, remediating)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var created *kargoapi.Promotion
+			r := &reconciler{
+				recorder: fakeevent.NewEventRecorder(1),
+				nowFn:    time.Now,
+				getProjectFn: func(context.Context, client.Client, string) (*kargoapi.Project, error) {
+					return testCase.project, nil
+				},
+				getFreightFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Freight, error) {
+					return testCase.freight, nil
+				},
+				createPromotionFn: func(
+					_ context.Context,
+					obj client.Object,
+					_ ...client.CreateOption,
+				) error {
+					created = obj.(*kargoapi.Promotion) // nolint: forcetypeassert
+					return nil
+				},
+			}
+			remediating, err := r.remediateDrift(context.Background(), stage)
+			testCase.assertions(t, remediating, err)
+			if testCase.expectCreate {
+				require.NotNil(t, created)
+				require.Equal(t, "fake-stage", created.Spec.Stage)
+				require.Equal(t, "fake-freight", created.Spec.Freight)
+			} else {
+				require.Nil(t, created)
+			}
+		})
+	}
+}
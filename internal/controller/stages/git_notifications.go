@@ -0,0 +1,83 @@
+package stages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/gitprovider"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// notifyGitProviders records the outcome of the provided Freight's
+// verification in the provided Stage as a commit status on every commit the
+// Freight references, as called for by the Stage's GitProviderNotifications.
+// An attempt is made to record every status, even if some fail, and any errors
+// are returned together.
+func (r *reconciler) notifyGitProviders(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	freight *kargoapi.Freight,
+	vi *kargoapi.VerificationInfo,
+) error {
+	notifications := stage.Spec.GitProviderNotifications
+	if notifications == nil || !notifications.CommitStatuses || vi == nil {
+		return nil
+	}
+
+	environment := notifications.Environment
+	if environment == "" {
+		environment = stage.Name
+	}
+	state := gitprovider.CommitStateSuccess
+	if vi.Phase != kargoapi.VerificationPhaseSuccessful {
+		state = gitprovider.CommitStateFailure
+	}
+	freightName := freight.Alias
+	if freightName == "" {
+		freightName = freight.Name
+	}
+	description := fmt.Sprintf(
+		"Verification of Freight %q in Stage %q in Project %q %s",
+		freightName,
+		stage.Name,
+		stage.Namespace,
+		vi.Phase,
+	)
+
+	var errs []error
+	for _, commit := range freight.Commits {
+		if len(notifications.RepoURLs) > 0 &&
+			!slices.Contains(notifications.RepoURLs, commit.RepoURL) {
+			continue
+		}
+		gpClient, err := r.getGitProviderFn(ctx, stage.Namespace, commit.RepoURL)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err = gpClient.CreateCommitStatus(
+			ctx,
+			commit.RepoURL,
+			gitprovider.CreateCommitStatusOpts{
+				SHA:         commit.ID,
+				Context:     "kargo/" + environment,
+				State:       state,
+				Description: description,
+			},
+		); err != nil {
+			errs = append(errs, fmt.Errorf(
+				"error recording status of commit %q in git repo %q: %w",
+				commit.ID,
+				commit.RepoURL,
+				err,
+			))
+			continue
+		}
+		logging.LoggerFromContext(ctx).WithField("repo", commit.RepoURL).
+			Debug("recorded commit status")
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,177 @@
+package stages
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/gitprovider"
+)
+
+// fakeGitProvider is a fake implementation of gitprovider.GitProviderService
+// that records the commit statuses it is sent.
+type fakeGitProvider struct {
+	gitprovider.GitProviderService
+
+	commitStatuses []gitprovider.CreateCommitStatusOpts
+	err            error
+}
+
+func (f *fakeGitProvider) CreateCommitStatus(
+	_ context.Context,
+	_ string,
+	opts gitprovider.CreateCommitStatusOpts,
+) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.commitStatuses = append(f.commitStatuses, opts)
+	return nil
+}
+
+func TestNotifyGitProviders(t *testing.T) {
+	testFreight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-project",
+			Name:      "fake-freight",
+		},
+		Alias: "fake-alias",
+		Commits: []kargoapi.GitCommit{
+			{
+				RepoURL: "https://github.com/example/app",
+				ID:      "fake-commit",
+			},
+			{
+				RepoURL: "https://github.com/example/config",
+				ID:      "fake-other-commit",
+			},
+		},
+	}
+	newStage := func(notifications *kargoapi.GitProviderNotifications) *kargoapi.Stage {
+		return &kargoapi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "fake-project",
+				Name:      "fake-stage",
+			},
+			Spec: kargoapi.StageSpec{
+				GitProviderNotifications: notifications,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		stage      *kargoapi.Stage
+		phase      kargoapi.VerificationPhase
+		provider   *fakeGitProvider
+		getErr     error
+		assertions func(*testing.T, *fakeGitProvider, error)
+	}{
+		{
+			name:     "notifications not enabled",
+			stage:    newStage(nil),
+			phase:    kargoapi.VerificationPhaseSuccessful,
+			provider: &fakeGitProvider{},
+			assertions: func(t *testing.T, provider *fakeGitProvider, err error) {
+				require.NoError(t, err)
+				require.Empty(t, provider.commitStatuses)
+			},
+		},
+		{
+			name: "commit statuses not enabled",
+			stage: newStage(&kargoapi.GitProviderNotifications{
+				DeploymentStatuses: true,
+			}),
+			phase:    kargoapi.VerificationPhaseSuccessful,
+			provider: &fakeGitProvider{},
+			assertions: func(t *testing.T, provider *fakeGitProvider, err error) {
+				require.NoError(t, err)
+				require.Empty(t, provider.commitStatuses)
+			},
+		},
+		{
+			name: "error getting Git provider",
+			stage: newStage(&kargoapi.GitProviderNotifications{
+				CommitStatuses: true,
+			}),
+			phase:    kargoapi.VerificationPhaseSuccessful,
+			provider: &fakeGitProvider{},
+			getErr:   errors.New("something went wrong"),
+			assertions: func(t *testing.T, _ *fakeGitProvider, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "error recording commit status",
+			stage: newStage(&kargoapi.GitProviderNotifications{
+				CommitStatuses: true,
+			}),
+			phase:    kargoapi.VerificationPhaseSuccessful,
+			provider: &fakeGitProvider{err: errors.New("something went wrong")},
+			assertions: func(t *testing.T, _ *fakeGitProvider, err error) {
+				require.ErrorContains(t, err, "error recording status of commit")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "commit statuses for successful verification",
+			stage: newStage(&kargoapi.GitProviderNotifications{
+				CommitStatuses: true,
+			}),
+			phase:    kargoapi.VerificationPhaseSuccessful,
+			provider: &fakeGitProvider{},
+			assertions: func(t *testing.T, provider *fakeGitProvider, err error) {
+				require.NoError(t, err)
+				require.Len(t, provider.commitStatuses, 2)
+				status := provider.commitStatuses[0]
+				require.Equal(t, "fake-commit", status.SHA)
+				require.Equal(t, "kargo/fake-stage", status.Context)
+				require.Equal(t, gitprovider.CommitStateSuccess, status.State)
+				require.Contains(t, status.Description, "fake-alias")
+				require.Equal(t, "fake-other-commit", provider.commitStatuses[1].SHA)
+			},
+		},
+		{
+			name: "commit statuses for failed verification",
+			stage: newStage(&kargoapi.GitProviderNotifications{
+				Environment:    "fake-environment",
+				CommitStatuses: true,
+				RepoURLs:       []string{"https://github.com/example/app"},
+			}),
+			phase:    kargoapi.VerificationPhaseFailed,
+			provider: &fakeGitProvider{},
+			assertions: func(t *testing.T, provider *fakeGitProvider, err error) {
+				require.NoError(t, err)
+				require.Len(t, provider.commitStatuses, 1)
+				status := provider.commitStatuses[0]
+				require.Equal(t, "kargo/fake-environment", status.Context)
+				require.Equal(t, gitprovider.CommitStateFailure, status.State)
+				require.Contains(t, status.Description, "Failed")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := &reconciler{
+				getGitProviderFn: func(
+					context.Context,
+					string,
+					string,
+				) (gitprovider.GitProviderService, error) {
+					return testCase.provider, testCase.getErr
+				},
+			}
+			err := r.notifyGitProviders(
+				context.Background(),
+				testCase.stage,
+				testFreight,
+				&kargoapi.VerificationInfo{Phase: testCase.phase},
+			)
+			testCase.assertions(t, testCase.provider, err)
+		})
+	}
+}
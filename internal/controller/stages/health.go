@@ -0,0 +1,68 @@
+package stages
+
+import (
+	"fmt"
+	"time"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+// applyHealthChecks updates the provided Health, which reflects the outcome of
+// the most recent health check, to account for any tolerance of failed health
+// checks described by the provided HealthChecks. The provided lastHealth and
+// lastPromo are the Stage's previously observed Health and its last completed
+// Promotion, respectively. A failed health check is tolerated if it occurred
+// within the GracePeriod following the last successful Promotion or if fewer
+// consecutive health checks than the FailureThreshold have failed. A tolerated
+// failure is reflected by a Health with a status of Progressing instead of
+// Unhealthy.
+func applyHealthChecks(
+	checks *kargoapi.HealthChecks,
+	health *kargoapi.Health,
+	lastHealth *kargoapi.Health,
+	lastPromo *kargoapi.PromotionInfo,
+	now time.Time,
+) {
+	if health == nil || health.Status != kargoapi.HealthStateUnhealthy {
+		return
+	}
+
+	health.ConsecutiveFailures = 1
+	if lastHealth != nil {
+		health.ConsecutiveFailures += lastHealth.ConsecutiveFailures
+	}
+
+	if checks == nil {
+		return
+	}
+
+	if checks.GracePeriod != nil && lastPromo != nil && lastPromo.FinishedAt != nil &&
+		lastPromo.Status != nil && lastPromo.Status.Phase == kargoapi.PromotionPhaseSucceeded {
+		if gracePeriodEnd := lastPromo.FinishedAt.Add(checks.GracePeriod.Duration); now.Before(gracePeriodEnd) {
+			health.Status = kargoapi.HealthStateProgressing
+			health.Issues = append(
+				health.Issues,
+				fmt.Sprintf(
+					"failed health checks are tolerated until the grace period following "+
+						"Promotion %q ends at %s",
+					lastPromo.Name,
+					gracePeriodEnd.UTC().Format(time.RFC3339),
+				),
+			)
+			return
+		}
+	}
+
+	if health.ConsecutiveFailures < checks.FailureThreshold {
+		health.Status = kargoapi.HealthStateProgressing
+		health.Issues = append(
+			health.Issues,
+			fmt.Sprintf(
+				"%d of %d consecutive failed health checks required to mark the Stage "+
+					"Unhealthy have occurred",
+				health.ConsecutiveFailures,
+				checks.FailureThreshold,
+			),
+		)
+	}
+}
@@ -2,6 +2,11 @@ package stages
 
 import (
 	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 )
@@ -17,3 +22,159 @@ func (m *mockAppHealthEvaluator) EvaluateHealth(
 ) *kargoapi.Health {
 	return m.Health
 }
+
+func TestApplyHealthChecks(t *testing.T) {
+	now := time.Now()
+	succeededPromo := &kargoapi.PromotionInfo{
+		Name: "fake-promotion",
+		Status: &kargoapi.PromotionStatus{
+			Phase: kargoapi.PromotionPhaseSucceeded,
+		},
+		FinishedAt: &metav1.Time{Time: now.Add(-time.Minute)},
+	}
+	testCases := []struct {
+		name       string
+		checks     *kargoapi.HealthChecks
+		health     *kargoapi.Health
+		lastHealth *kargoapi.Health
+		lastPromo  *kargoapi.PromotionInfo
+		assertions func(*testing.T, *kargoapi.Health)
+	}{
+		{
+			name: "healthy",
+			checks: &kargoapi.HealthChecks{
+				FailureThreshold: 3,
+			},
+			health: &kargoapi.Health{Status: kargoapi.HealthStateHealthy},
+			lastHealth: &kargoapi.Health{
+				Status:              kargoapi.HealthStateProgressing,
+				ConsecutiveFailures: 2,
+			},
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.Equal(t, kargoapi.HealthStateHealthy, health.Status)
+				require.Zero(t, health.ConsecutiveFailures)
+			},
+		},
+		{
+			name: "unhealthy without health checks",
+			health: &kargoapi.Health{
+				Status: kargoapi.HealthStateUnhealthy,
+				Issues: []string{"something went wrong"},
+			},
+			lastHealth: &kargoapi.Health{
+				Status:              kargoapi.HealthStateUnhealthy,
+				ConsecutiveFailures: 1,
+			},
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.Equal(t, kargoapi.HealthStateUnhealthy, health.Status)
+				require.Equal(t, int32(2), health.ConsecutiveFailures)
+				require.Equal(t, []string{"something went wrong"}, health.Issues)
+			},
+		},
+		{
+			name: "unhealthy within grace period",
+			checks: &kargoapi.HealthChecks{
+				GracePeriod: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			health:    &kargoapi.Health{Status: kargoapi.HealthStateUnhealthy},
+			lastPromo: succeededPromo,
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.Equal(t, kargoapi.HealthStateProgressing, health.Status)
+				require.Equal(t, int32(1), health.ConsecutiveFailures)
+				require.Len(t, health.Issues, 1)
+				require.Contains(t, health.Issues[0], "grace period")
+			},
+		},
+		{
+			name: "unhealthy after grace period",
+			checks: &kargoapi.HealthChecks{
+				GracePeriod: &metav1.Duration{Duration: 30 * time.Second},
+			},
+			health:    &kargoapi.Health{Status: kargoapi.HealthStateUnhealthy},
+			lastPromo: succeededPromo,
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.Equal(t, kargoapi.HealthStateUnhealthy, health.Status)
+			},
+		},
+		{
+			name: "unhealthy within grace period of failed Promotion",
+			checks: &kargoapi.HealthChecks{
+				GracePeriod: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			health: &kargoapi.Health{Status: kargoapi.HealthStateUnhealthy},
+			lastPromo: &kargoapi.PromotionInfo{
+				Name: "fake-promotion",
+				Status: &kargoapi.PromotionStatus{
+					Phase: kargoapi.PromotionPhaseFailed,
+				},
+				FinishedAt: &metav1.Time{Time: now.Add(-time.Minute)},
+			},
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.Equal(t, kargoapi.HealthStateUnhealthy, health.Status)
+			},
+		},
+		{
+			name: "unhealthy below failure threshold",
+			checks: &kargoapi.HealthChecks{
+				FailureThreshold: 3,
+			},
+			health: &kargoapi.Health{Status: kargoapi.HealthStateUnhealthy},
+			lastHealth: &kargoapi.Health{
+				Status:              kargoapi.HealthStateProgressing,
+				ConsecutiveFailures: 1,
+			},
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.Equal(t, kargoapi.HealthStateProgressing, health.Status)
+				require.Equal(t, int32(2), health.ConsecutiveFailures)
+				require.Equal(
+					t,
+					[]string{
+						"2 of 3 consecutive failed health checks required to mark the Stage " +
+							"Unhealthy have occurred",
+					},
+					health.Issues,
+				)
+			},
+		},
+		{
+			name: "unhealthy at failure threshold",
+			checks: &kargoapi.HealthChecks{
+				FailureThreshold: 3,
+			},
+			health: &kargoapi.Health{Status: kargoapi.HealthStateUnhealthy},
+			lastHealth: &kargoapi.Health{
+				Status:              kargoapi.HealthStateProgressing,
+				ConsecutiveFailures: 2,
+			},
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.Equal(t, kargoapi.HealthStateUnhealthy, health.Status)
+				require.Equal(t, int32(3), health.ConsecutiveFailures)
+				require.Empty(t, health.Issues)
+			},
+		},
+		{
+			name: "failures are reset by successful health check",
+			checks: &kargoapi.HealthChecks{
+				FailureThreshold: 2,
+			},
+			health:     &kargoapi.Health{Status: kargoapi.HealthStateUnhealthy},
+			lastHealth: &kargoapi.Health{Status: kargoapi.HealthStateHealthy},
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.Equal(t, kargoapi.HealthStateProgressing, health.Status)
+				require.Equal(t, int32(1), health.ConsecutiveFailures)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			applyHealthChecks(
+				testCase.checks,
+				testCase.health,
+				testCase.lastHealth,
+				testCase.lastPromo,
+				now,
+			)
+			testCase.assertions(t, testCase.health)
+		})
+	}
+}
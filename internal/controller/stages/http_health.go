@@ -0,0 +1,200 @@
+package stages
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/hashicorp/go-cleanhttp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/expressions"
+)
+
+// defaultHTTPHealthCheckTimeout is the maximum amount of time to wait for an
+// HTTP endpoint to respond when an HTTPHealthCheck does not specify a timeout.
+const defaultHTTPHealthCheckTimeout = 10 * time.Second
+
+// maxHTTPHealthCheckBodySize is the maximum number of bytes of an HTTP
+// endpoint's response body that will be read and matched against an
+// HTTPHealthCheck's ExpectedBody.
+const maxHTTPHealthCheckBodySize = 1 << 20 // 1 MiB
+
+// evaluateHTTPHealth assesses the health of the HTTP endpoints described by the
+// provided Stage's HealthChecks. Expressions in the URL and ExpectedBody of
+// each HTTPHealthCheck are evaluated against the Stage and the provided
+// Freight. If the Stage describes no HTTP endpoints, nil is returned.
+func evaluateHTTPHealth(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	freightRef kargoapi.FreightReference,
+) *kargoapi.Health {
+	if stage.Spec.HealthChecks == nil || len(stage.Spec.HealthChecks.HTTP) == 0 {
+		return nil
+	}
+
+	health := &kargoapi.Health{
+		Status:        kargoapi.HealthStateHealthy,
+		HTTPEndpoints: make([]kargoapi.HTTPEndpointStatus, len(stage.Spec.HealthChecks.HTTP)),
+		Issues:        make([]string, 0),
+	}
+
+	freight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: stage.Namespace,
+			Name:      freightRef.Name,
+		},
+		Commits:   freightRef.Commits,
+		Images:    freightRef.Images,
+		Charts:    freightRef.Charts,
+		Packages:  freightRef.Packages,
+		Warehouse: freightRef.Warehouse,
+	}
+	env, err := verificationArgsEnv(stage, freight)
+	if err != nil {
+		health.Status = kargoapi.HealthStateUnknown
+		health.Issues = append(
+			health.Issues,
+			fmt.Sprintf("error building environment for expressions: %s", err),
+		)
+		return health
+	}
+	exprOpts := freightFunctions(freight)
+
+	for i, check := range stage.Spec.HealthChecks.HTTP {
+		endpointStatus, err := checkHTTPEndpoint(ctx, check, env, exprOpts)
+		health.HTTPEndpoints[i] = endpointStatus
+		health.Status = health.Status.Merge(endpointStatus.Status)
+		if err != nil {
+			health.Issues = append(health.Issues, err.Error())
+		}
+	}
+
+	return health
+}
+
+// checkHTTPEndpoint checks the HTTP endpoint described by the provided
+// HTTPHealthCheck and returns its status. If the endpoint is not healthy, or
+// its health cannot be determined, an error explaining why is also returned.
+func checkHTTPEndpoint(
+	ctx context.Context,
+	check kargoapi.HTTPHealthCheck,
+	env map[string]any,
+	exprOpts []expr.Option,
+) (kargoapi.HTTPEndpointStatus, error) {
+	status := kargoapi.HTTPEndpointStatus{
+		URL:    check.URL,
+		Status: kargoapi.HealthStateUnknown,
+	}
+
+	url, err := expressions.EvaluateTemplateToString(check.URL, env, exprOpts...)
+	if err != nil {
+		return status, fmt.Errorf("error evaluating URL %q: %w", check.URL, err)
+	}
+	status.URL = url
+
+	var expectedBody *regexp.Regexp
+	if check.ExpectedBody != "" {
+		pattern, err := expressions.EvaluateTemplateToString(check.ExpectedBody, env, exprOpts...)
+		if err != nil {
+			return status, fmt.Errorf(
+				"error evaluating expected body of HTTP endpoint %q: %w", url, err,
+			)
+		}
+		if expectedBody, err = regexp.Compile(pattern); err != nil {
+			return status, fmt.Errorf(
+				"error compiling expected body %q of HTTP endpoint %q: %w", pattern, url, err,
+			)
+		}
+	}
+
+	method := check.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	timeout := defaultHTTPHealthCheckTimeout
+	if check.Timeout != nil {
+		timeout = check.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return status, fmt.Errorf("error creating request to HTTP endpoint %q: %w", url, err)
+	}
+
+	httpTransport := cleanhttp.DefaultTransport()
+	if check.InsecureSkipTLSVerify {
+		httpTransport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true, // nolint: gosec
+		}
+	}
+	httpClient := &http.Client{Transport: httpTransport}
+	defer httpClient.CloseIdleConnections()
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		status.Status = kargoapi.HealthStateUnhealthy
+		return status, fmt.Errorf("error checking HTTP endpoint %q: %w", url, err)
+	}
+	defer resp.Body.Close()
+	status.StatusCode = int32(resp.StatusCode)
+
+	status.Status = kargoapi.HealthStateUnhealthy
+	if !isExpectedStatusCode(check.ExpectedStatusCodes, resp.StatusCode) {
+		return status, fmt.Errorf(
+			"HTTP endpoint %q responded with unexpected status code %d",
+			url, resp.StatusCode,
+		)
+	}
+	if expectedBody != nil {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPHealthCheckBodySize))
+		if err != nil {
+			return status, fmt.Errorf(
+				"error reading response body of HTTP endpoint %q: %w", url, err,
+			)
+		}
+		if !expectedBody.Match(body) {
+			return status, fmt.Errorf(
+				"response body of HTTP endpoint %q does not match %q",
+				url, expectedBody.String(),
+			)
+		}
+	}
+
+	status.Status = kargoapi.HealthStateHealthy
+	return status, nil
+}
+
+// isExpectedStatusCode returns true if the provided status code is one of the
+// expected status codes or, if no status codes are expected, if it is a 2xx
+// status code.
+func isExpectedStatusCode(expected []int32, statusCode int) bool {
+	if len(expected) == 0 {
+		return statusCode >= 200 && statusCode < 300
+	}
+	return slices.Contains(expected, int32(statusCode))
+}
+
+// mergeHealth merges the provided Health assessments into one. Either may be
+// nil, in which case the other is returned.
+func mergeHealth(a, b *kargoapi.Health) *kargoapi.Health {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	a.Status = a.Status.Merge(b.Status)
+	a.Issues = append(a.Issues, b.Issues...)
+	a.ArgoCDApps = append(a.ArgoCDApps, b.ArgoCDApps...)
+	a.HTTPEndpoints = append(a.HTTPEndpoints, b.HTTPEndpoints...)
+	return a
+}
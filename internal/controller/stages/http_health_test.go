@@ -0,0 +1,238 @@
+package stages
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestEvaluateHTTPHealth(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			w.WriteHeader(http.StatusOK)
+		case "/version":
+			_, _ = fmt.Fprint(w, `{"version":"v1.2.3"}`)
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/slow":
+			time.Sleep(500 * time.Millisecond)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	freight := kargoapi.FreightReference{
+		Name: "fake-freight",
+		Images: []kargoapi.Image{{
+			RepoURL: "fake-image",
+			Tag:     "v1.2.3",
+		}},
+	}
+
+	testCases := []struct {
+		name       string
+		checks     *kargoapi.HealthChecks
+		assertions func(*testing.T, *kargoapi.Health)
+	}{
+		{
+			name: "no HTTP health checks",
+			checks: &kargoapi.HealthChecks{
+				FailureThreshold: 3,
+			},
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.Nil(t, health)
+			},
+		},
+		{
+			name: "healthy",
+			checks: &kargoapi.HealthChecks{
+				HTTP: []kargoapi.HTTPHealthCheck{
+					{URL: srv.URL + "/healthz"},
+					{
+						URL:          srv.URL + "/version",
+						ExpectedBody: `"version":"${{ imageFrom("fake-image").tag }}"`,
+					},
+				},
+			},
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.NotNil(t, health)
+				require.Equal(t, kargoapi.HealthStateHealthy, health.Status)
+				require.Empty(t, health.Issues)
+				require.Equal(
+					t,
+					[]kargoapi.HTTPEndpointStatus{
+						{
+							URL:        srv.URL + "/healthz",
+							StatusCode: http.StatusOK,
+							Status:     kargoapi.HealthStateHealthy,
+						},
+						{
+							URL:        srv.URL + "/version",
+							StatusCode: http.StatusOK,
+							Status:     kargoapi.HealthStateHealthy,
+						},
+					},
+					health.HTTPEndpoints,
+				)
+			},
+		},
+		{
+			name: "URL expression",
+			checks: &kargoapi.HealthChecks{
+				HTTP: []kargoapi.HTTPHealthCheck{{
+					URL: srv.URL + "/${{ ctx.stage == 'fake-stage' ? 'healthz' : 'unavailable' }}",
+				}},
+			},
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.NotNil(t, health)
+				require.Equal(t, kargoapi.HealthStateHealthy, health.Status)
+				require.Equal(t, srv.URL+"/healthz", health.HTTPEndpoints[0].URL)
+			},
+		},
+		{
+			name: "unexpected status code",
+			checks: &kargoapi.HealthChecks{
+				HTTP: []kargoapi.HTTPHealthCheck{
+					{URL: srv.URL + "/healthz"},
+					{URL: srv.URL + "/unavailable"},
+				},
+			},
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.NotNil(t, health)
+				require.Equal(t, kargoapi.HealthStateUnhealthy, health.Status)
+				require.Len(t, health.Issues, 1)
+				require.Contains(t, health.Issues[0], "unexpected status code 503")
+				require.Equal(
+					t,
+					kargoapi.HealthStateUnhealthy,
+					health.HTTPEndpoints[1].Status,
+				)
+			},
+		},
+		{
+			name: "expected status code",
+			checks: &kargoapi.HealthChecks{
+				HTTP: []kargoapi.HTTPHealthCheck{{
+					URL:                 srv.URL + "/unavailable",
+					Method:              http.MethodHead,
+					ExpectedStatusCodes: []int32{http.StatusServiceUnavailable},
+				}},
+			},
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.NotNil(t, health)
+				require.Equal(t, kargoapi.HealthStateHealthy, health.Status)
+			},
+		},
+		{
+			name: "unexpected body",
+			checks: &kargoapi.HealthChecks{
+				HTTP: []kargoapi.HTTPHealthCheck{{
+					URL:          srv.URL + "/version",
+					ExpectedBody: `"version":"v2\.0\.0"`,
+				}},
+			},
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.NotNil(t, health)
+				require.Equal(t, kargoapi.HealthStateUnhealthy, health.Status)
+				require.Len(t, health.Issues, 1)
+				require.Contains(t, health.Issues[0], "does not match")
+			},
+		},
+		{
+			name: "timeout",
+			checks: &kargoapi.HealthChecks{
+				HTTP: []kargoapi.HTTPHealthCheck{{
+					URL:     srv.URL + "/slow",
+					Timeout: &metav1.Duration{Duration: 50 * time.Millisecond},
+				}},
+			},
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.NotNil(t, health)
+				require.Equal(t, kargoapi.HealthStateUnhealthy, health.Status)
+				require.Len(t, health.Issues, 1)
+				require.Contains(t, health.Issues[0], "error checking HTTP endpoint")
+				require.Zero(t, health.HTTPEndpoints[0].StatusCode)
+			},
+		},
+		{
+			name: "invalid expression",
+			checks: &kargoapi.HealthChecks{
+				HTTP: []kargoapi.HTTPHealthCheck{{
+					URL: srv.URL + `/${{ imageFrom("nonexistent").tag }}`,
+				}},
+			},
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.NotNil(t, health)
+				require.Equal(t, kargoapi.HealthStateUnknown, health.Status)
+				require.Len(t, health.Issues, 1)
+				require.Contains(t, health.Issues[0], "error evaluating URL")
+			},
+		},
+		{
+			name: "invalid expected body",
+			checks: &kargoapi.HealthChecks{
+				HTTP: []kargoapi.HTTPHealthCheck{{
+					URL:          srv.URL + "/version",
+					ExpectedBody: "(",
+				}},
+			},
+			assertions: func(t *testing.T, health *kargoapi.Health) {
+				require.NotNil(t, health)
+				require.Equal(t, kargoapi.HealthStateUnknown, health.Status)
+				require.Len(t, health.Issues, 1)
+				require.Contains(t, health.Issues[0], "error compiling expected body")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				t,
+				evaluateHTTPHealth(
+					context.Background(),
+					&kargoapi.Stage{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "fake-project",
+							Name:      "fake-stage",
+						},
+						Spec: kargoapi.StageSpec{
+							HealthChecks: testCase.checks,
+						},
+					},
+					freight,
+				),
+			)
+		})
+	}
+}
+
+func TestMergeHealth(t *testing.T) {
+	appHealth := &kargoapi.Health{
+		Status:     kargoapi.HealthStateHealthy,
+		ArgoCDApps: []kargoapi.ArgoCDAppStatus{{Name: "fake-app"}},
+	}
+	httpHealth := &kargoapi.Health{
+		Status: kargoapi.HealthStateUnhealthy,
+		Issues: []string{"something went wrong"},
+		HTTPEndpoints: []kargoapi.HTTPEndpointStatus{{
+			URL:    "https://example.com",
+			Status: kargoapi.HealthStateUnhealthy,
+		}},
+	}
+	require.Nil(t, mergeHealth(nil, nil))
+	require.Same(t, appHealth, mergeHealth(appHealth, nil))
+	require.Same(t, httpHealth, mergeHealth(nil, httpHealth))
+	merged := mergeHealth(appHealth, httpHealth)
+	require.Equal(t, kargoapi.HealthStateUnhealthy, merged.Status)
+	require.Equal(t, []string{"something went wrong"}, merged.Issues)
+	require.Len(t, merged.ArgoCDApps, 1)
+	require.Len(t, merged.HTTPEndpoints, 1)
+}
@@ -0,0 +1,487 @@
+package stages
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// dockerHubConfigKey is the key under which credentials for Docker Hub must
+// be stored in a .dockerconfigjson for the kubelet to use them.
+const dockerHubConfigKey = "https://index.docker.io/v1/"
+
+// dockerConfigJSON mirrors the structure of a .dockerconfigjson file.
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+// dockerConfigEntry mirrors a single entry in a .dockerconfigjson file.
+type dockerConfigEntry struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Auth     string `json:"auth"`
+}
+
+// syncImagePullSecretsCondition brings the image pull Secrets described by the
+// Stage's ImagePullSecrets field in line with the credentials for the images
+// referenced by the provided StageStatus' current Freight, removes any
+// previously maintained Secrets that are no longer described by that field,
+// and reflects the outcome in the ImagePullSecretsSynced condition and the
+// ImagePullSecrets field of that StageStatus.
+func (r *reconciler) syncImagePullSecretsCondition(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	status *kargoapi.StageStatus,
+) {
+	logger := logging.LoggerFromContext(ctx)
+
+	if len(stage.Spec.ImagePullSecrets) == 0 &&
+		(len(status.ImagePullSecrets) == 0 || !r.cfg.ImagePullSecretsEnabled) {
+		meta.RemoveStatusCondition(
+			&status.Conditions,
+			kargoapi.StageConditionTypeImagePullSecretsSynced,
+		)
+		return
+	}
+
+	if !r.cfg.ImagePullSecretsEnabled {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:   kargoapi.StageConditionTypeImagePullSecretsSynced,
+			Status: metav1.ConditionFalse,
+			Reason: kargoapi.StageConditionReasonImagePullSecretsDisabled,
+			Message: "Maintenance of image pull Secrets is disabled on this " +
+				"Kargo controller",
+			ObservedGeneration: stage.Generation,
+		})
+		return
+	}
+
+	var images []kargoapi.Image
+	if status.CurrentFreight != nil {
+		images = status.CurrentFreight.Images
+	}
+	maintained, err := r.syncImagePullSecretsFn(ctx, stage, images)
+	status.ImagePullSecrets = maintained
+	if err != nil {
+		logger.Errorf("error syncing image pull Secrets: %s", err)
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               kargoapi.StageConditionTypeImagePullSecretsSynced,
+			Status:             metav1.ConditionFalse,
+			Reason:             kargoapi.StageConditionReasonImagePullSecretsSyncFailed,
+			Message:            err.Error(),
+			ObservedGeneration: stage.Generation,
+		})
+		return
+	}
+
+	if len(stage.Spec.ImagePullSecrets) == 0 {
+		meta.RemoveStatusCondition(
+			&status.Conditions,
+			kargoapi.StageConditionTypeImagePullSecretsSynced,
+		)
+		return
+	}
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:   kargoapi.StageConditionTypeImagePullSecretsSynced,
+		Status: metav1.ConditionTrue,
+		Reason: kargoapi.StageConditionReasonImagePullSecretsSynced,
+		Message: fmt.Sprintf(
+			"%d image pull Secret(s) are up to date",
+			len(stage.Spec.ImagePullSecrets),
+		),
+		ObservedGeneration: stage.Generation,
+	})
+}
+
+// syncImagePullSecrets builds a .dockerconfigjson from the credentials for the
+// provided images and writes it to every image pull Secret described by the
+// Stage's ImagePullSecrets field that the Stage's Project permits, attaching
+// each Secret to the ServiceAccounts that should reference it. Secrets listed
+// in the Stage's status that are no longer described by that field, or are no
+// longer permitted by the Project, are deleted, and ServiceAccounts that should
+// no longer reference a Secret are detached from it. It returns the image pull
+// Secrets that are maintained on behalf of the Stage afterwards, which include
+// any that could not be removed so that removal is retried. Errors for
+// individual Secrets do not prevent the remaining Secrets from being synced;
+// they are joined and returned together.
+func (r *reconciler) syncImagePullSecrets(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	images []kargoapi.Image,
+) ([]kargoapi.ImagePullSecret, error) {
+	project, err := r.getProjectFn(ctx, r.kargoClient, stage.Namespace)
+	if err != nil {
+		return stage.Status.ImagePullSecrets,
+			fmt.Errorf("error finding Project %q: %w", stage.Namespace, err)
+	}
+	if project == nil {
+		return stage.Status.ImagePullSecrets,
+			fmt.Errorf("Project %q not found", stage.Namespace)
+	}
+
+	var maintained []kargoapi.ImagePullSecret
+	var errs []error
+
+	var desired []kargoapi.ImagePullSecret
+	for _, ips := range stage.Spec.ImagePullSecrets {
+		if !project.AllowsImagePullSecret(ips) {
+			errs = append(errs, fmt.Errorf(
+				"namespace %q in cluster %q is not among the imagePullSecretTargets "+
+					"of Project %q",
+				ips.Namespace, ips.Cluster, project.Name,
+			))
+			continue
+		}
+		desired = append(desired, ips)
+	}
+
+	if len(desired) > 0 {
+		dockerConfig, err := r.buildDockerConfigJSON(ctx, stage.Namespace, images)
+		if err != nil {
+			return stage.Status.ImagePullSecrets, err
+		}
+		for _, ips := range desired {
+			// Record the Secret as maintained even if syncing it fails, since it
+			// may have been created or attached to some of its ServiceAccounts.
+			maintained = append(maintained, ips)
+			c, err := r.getClusterClientFn(ctx, ips.Cluster)
+			if err != nil {
+				errs = append(errs, fmt.Errorf(
+					"error getting client for cluster %q: %w", ips.Cluster, err,
+				))
+				continue
+			}
+			if err = syncImagePullSecret(ctx, c, stage, ips, dockerConfig); err != nil {
+				errs = append(errs, fmt.Errorf(
+					"error syncing Secret %q in namespace %q: %w",
+					ips.Name, ips.Namespace, err,
+				))
+			}
+		}
+	}
+
+	for _, prev := range stage.Status.ImagePullSecrets {
+		stale := prev
+		deleteSecret := true
+		if cur := findImagePullSecret(desired, prev); cur != nil {
+			deleteSecret = false
+			stale.ServiceAccounts = nil
+			for _, saName := range prev.ServiceAccounts {
+				if !slices.Contains(cur.ServiceAccounts, saName) {
+					stale.ServiceAccounts = append(stale.ServiceAccounts, saName)
+				}
+			}
+			if len(stale.ServiceAccounts) == 0 {
+				continue
+			}
+		}
+		if err = r.removeImagePullSecret(ctx, stage, stale, deleteSecret); err != nil {
+			errs = append(errs, err)
+			// Keep track of what could not be removed so that removal is retried
+			if cur := findImagePullSecret(maintained, stale); cur != nil {
+				cur.ServiceAccounts = append(cur.ServiceAccounts, stale.ServiceAccounts...)
+			} else {
+				maintained = append(maintained, stale)
+			}
+		}
+	}
+
+	return maintained, errors.Join(errs...)
+}
+
+// clearImagePullSecrets deletes all image pull Secrets maintained on behalf of
+// the Stage, as recorded in its status, and detaches them from the
+// ServiceAccounts they were attached to.
+func (r *reconciler) clearImagePullSecrets(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+) error {
+	if !r.cfg.ImagePullSecretsEnabled {
+		logging.LoggerFromContext(ctx).Info(
+			"maintenance of image pull Secrets is disabled; leaving image pull " +
+				"Secrets of deleted Stage in place",
+		)
+		return nil
+	}
+	var errs []error
+	for _, ips := range stage.Status.ImagePullSecrets {
+		if err := r.removeImagePullSecret(ctx, stage, ips, true); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// removeImagePullSecret detaches the Secret described by the provided
+// ImagePullSecret from its ServiceAccounts and, if deleteSecret is true,
+// deletes it. A Secret that exists but was not created by Kargo on behalf of
+// the Stage is left untouched, as are the ServiceAccounts that reference it.
+func (r *reconciler) removeImagePullSecret(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	ips kargoapi.ImagePullSecret,
+	deleteSecret bool,
+) error {
+	c, err := r.getClusterClientFn(ctx, ips.Cluster)
+	if err != nil {
+		return fmt.Errorf(
+			"error getting client for cluster %q: %w", ips.Cluster, err,
+		)
+	}
+	secret := &corev1.Secret{}
+	if err = c.Get(
+		ctx,
+		types.NamespacedName{Namespace: ips.Namespace, Name: ips.Name},
+		secret,
+	); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf(
+				"error getting Secret %q in namespace %q: %w",
+				ips.Name, ips.Namespace, err,
+			)
+		}
+		secret = nil
+	}
+	if secret != nil && !isManagedByStage(secret, stage) {
+		return nil
+	}
+	for _, saName := range ips.ServiceAccounts {
+		if err = detachImagePullSecret(ctx, c, ips.Namespace, saName, ips.Name); err != nil {
+			return fmt.Errorf(
+				"error removing Secret %q from ServiceAccount %q in namespace %q: %w",
+				ips.Name, saName, ips.Namespace, err,
+			)
+		}
+	}
+	if !deleteSecret || secret == nil {
+		return nil
+	}
+	if err = c.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf(
+			"error deleting Secret %q in namespace %q: %w",
+			ips.Name, ips.Namespace, err,
+		)
+	}
+	return nil
+}
+
+// findImagePullSecret returns a pointer to the element of the provided slice
+// describing the same Secret as the provided ImagePullSecret, or nil if there
+// is none.
+func findImagePullSecret(
+	secrets []kargoapi.ImagePullSecret,
+	ips kargoapi.ImagePullSecret,
+) *kargoapi.ImagePullSecret {
+	for i := range secrets {
+		if secrets[i].Cluster == ips.Cluster &&
+			secrets[i].Namespace == ips.Namespace &&
+			secrets[i].Name == ips.Name {
+			return &secrets[i]
+		}
+	}
+	return nil
+}
+
+// isManagedByStage returns true if the provided Secret was created by Kargo on
+// behalf of the provided Stage.
+func isManagedByStage(secret *corev1.Secret, stage *kargoapi.Stage) bool {
+	return secret.Labels[kargoapi.ProjectLabelKey] == stage.Namespace &&
+		secret.Labels[kargoapi.StageLabelKey] == stage.Name
+}
+
+// buildDockerConfigJSON returns the serialized .dockerconfigjson containing
+// the credentials the Kargo controller has for the registries hosting the
+// provided images. Images for which no credentials are found are skipped.
+func (r *reconciler) buildDockerConfigJSON(
+	ctx context.Context,
+	namespace string,
+	images []kargoapi.Image,
+) ([]byte, error) {
+	cfg := dockerConfigJSON{Auths: map[string]dockerConfigEntry{}}
+	for _, image := range images {
+		host, err := registryConfigKey(image.RepoURL)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := cfg.Auths[host]; ok {
+			continue
+		}
+		creds, ok, err := r.credentialsDB.Get(
+			ctx,
+			namespace,
+			credentials.TypeImage,
+			image.RepoURL,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error obtaining credentials for image repo %q: %w",
+				image.RepoURL, err,
+			)
+		}
+		if !ok {
+			continue
+		}
+		cfg.Auths[host] = dockerConfigEntry{
+			Username: creds.Username,
+			Password: creds.Password,
+			Auth: base64.StdEncoding.EncodeToString(
+				[]byte(creds.Username + ":" + creds.Password),
+			),
+		}
+	}
+	return json.Marshal(cfg)
+}
+
+// registryConfigKey returns the key under which credentials for the registry
+// hosting the provided image repository are stored in a .dockerconfigjson.
+func registryConfigKey(repoURL string) (string, error) {
+	repo, err := name.NewRepository(repoURL)
+	if err != nil {
+		return "", fmt.Errorf("error parsing image repo URL %q: %w", repoURL, err)
+	}
+	if reg := repo.RegistryStr(); reg != name.DefaultRegistry {
+		return reg, nil
+	}
+	return dockerHubConfigKey, nil
+}
+
+// syncImagePullSecret creates or updates the Secret described by the provided
+// ImagePullSecret so that it contains the provided .dockerconfigjson and then
+// adds the Secret to the imagePullSecrets of the ServiceAccounts that should
+// reference it. An existing Secret that was not created by Kargo on behalf of
+// the Stage is never modified.
+func syncImagePullSecret(
+	ctx context.Context,
+	c client.Client,
+	stage *kargoapi.Stage,
+	ips kargoapi.ImagePullSecret,
+	dockerConfig []byte,
+) error {
+	secret := &corev1.Secret{}
+	err := c.Get(
+		ctx,
+		types.NamespacedName{Namespace: ips.Namespace, Name: ips.Name},
+		secret,
+	)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ips.Namespace,
+				Name:      ips.Name,
+				Labels: map[string]string{
+					kargoapi.ProjectLabelKey: stage.Namespace,
+					kargoapi.StageLabelKey:   stage.Name,
+				},
+			},
+			Type: corev1.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				corev1.DockerConfigJsonKey: dockerConfig,
+			},
+		}
+		if err = c.Create(ctx, secret); err != nil {
+			return err
+		}
+	} else {
+		if !isManagedByStage(secret, stage) {
+			return errors.New("Secret exists and is not managed by this Stage")
+		}
+		if string(secret.Data[corev1.DockerConfigJsonKey]) != string(dockerConfig) {
+			secret.Data = map[string][]byte{
+				corev1.DockerConfigJsonKey: dockerConfig,
+			}
+			if err = c.Update(ctx, secret); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, saName := range ips.ServiceAccounts {
+		if err = attachImagePullSecret(ctx, c, ips.Namespace, saName, ips.Name); err != nil {
+			return fmt.Errorf(
+				"error adding Secret to ServiceAccount %q: %w", saName, err,
+			)
+		}
+	}
+	return nil
+}
+
+// attachImagePullSecret adds the named Secret to the imagePullSecrets of the
+// specified ServiceAccount if it is not already present.
+func attachImagePullSecret(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	saName string,
+	secretName string,
+) error {
+	sa := &corev1.ServiceAccount{}
+	if err := c.Get(
+		ctx,
+		types.NamespacedName{Namespace: namespace, Name: saName},
+		sa,
+	); err != nil {
+		return err
+	}
+	for _, ref := range sa.ImagePullSecrets {
+		if ref.Name == secretName {
+			return nil
+		}
+	}
+	patch := client.MergeFrom(sa.DeepCopy())
+	sa.ImagePullSecrets = append(
+		sa.ImagePullSecrets,
+		corev1.LocalObjectReference{Name: secretName},
+	)
+	return c.Patch(ctx, sa, patch)
+}
+
+// detachImagePullSecret removes the named Secret from the imagePullSecrets of
+// the specified ServiceAccount if it is present. A ServiceAccount that does not
+// exist is not an error.
+func detachImagePullSecret(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	saName string,
+	secretName string,
+) error {
+	sa := &corev1.ServiceAccount{}
+	if err := c.Get(
+		ctx,
+		types.NamespacedName{Namespace: namespace, Name: saName},
+		sa,
+	); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	refs := slices.DeleteFunc(
+		slices.Clone(sa.ImagePullSecrets),
+		func(ref corev1.LocalObjectReference) bool {
+			return ref.Name == secretName
+		},
+	)
+	if len(refs) == len(sa.ImagePullSecrets) {
+		return nil
+	}
+	patch := client.MergeFrom(sa.DeepCopy())
+	sa.ImagePullSecrets = refs
+	return c.Patch(ctx, sa, patch)
+}
@@ -0,0 +1,752 @@
+package stages
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/credentials"
+)
+
+func TestSyncImagePullSecretsCondition(t *testing.T) {
+	testStage := &kargoapi.Stage{
+		Spec: kargoapi.StageSpec{
+			ImagePullSecrets: []kargoapi.ImagePullSecret{{
+				Namespace: "fake-namespace",
+				Name:      "fake-secret",
+			}},
+		},
+	}
+
+	testCases := []struct {
+		name                   string
+		stage                  *kargoapi.Stage
+		enabled                bool
+		syncImagePullSecretsFn func(
+			context.Context,
+			*kargoapi.Stage,
+			[]kargoapi.Image,
+		) ([]kargoapi.ImagePullSecret, error)
+		assertions func(*testing.T, kargoapi.StageStatus)
+	}{
+		{
+			name: "no image pull Secrets",
+			stage: &kargoapi.Stage{
+				Status: kargoapi.StageStatus{
+					Conditions: []metav1.Condition{{
+						Type:   kargoapi.StageConditionTypeImagePullSecretsSynced,
+						Status: metav1.ConditionTrue,
+					}},
+				},
+			},
+			assertions: func(t *testing.T, status kargoapi.StageStatus) {
+				require.Empty(t, status.Conditions)
+			},
+		},
+		{
+			name:  "feature disabled",
+			stage: testStage,
+			assertions: func(t *testing.T, status kargoapi.StageStatus) {
+				cond := meta.FindStatusCondition(
+					status.Conditions,
+					kargoapi.StageConditionTypeImagePullSecretsSynced,
+				)
+				require.NotNil(t, cond)
+				require.Equal(t, metav1.ConditionFalse, cond.Status)
+				require.Equal(
+					t,
+					kargoapi.StageConditionReasonImagePullSecretsDisabled,
+					cond.Reason,
+				)
+			},
+		},
+		{
+			name:    "error syncing",
+			stage:   testStage,
+			enabled: true,
+			syncImagePullSecretsFn: func(
+				_ context.Context,
+				stage *kargoapi.Stage,
+				_ []kargoapi.Image,
+			) ([]kargoapi.ImagePullSecret, error) {
+				return stage.Spec.ImagePullSecrets, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, status kargoapi.StageStatus) {
+				cond := meta.FindStatusCondition(
+					status.Conditions,
+					kargoapi.StageConditionTypeImagePullSecretsSynced,
+				)
+				require.NotNil(t, cond)
+				require.Equal(t, metav1.ConditionFalse, cond.Status)
+				require.Equal(
+					t,
+					kargoapi.StageConditionReasonImagePullSecretsSyncFailed,
+					cond.Reason,
+				)
+				require.Equal(t, "something went wrong", cond.Message)
+				require.Equal(t, testStage.Spec.ImagePullSecrets, status.ImagePullSecrets)
+			},
+		},
+		{
+			name:    "success",
+			stage:   testStage,
+			enabled: true,
+			syncImagePullSecretsFn: func(
+				_ context.Context,
+				stage *kargoapi.Stage,
+				_ []kargoapi.Image,
+			) ([]kargoapi.ImagePullSecret, error) {
+				return stage.Spec.ImagePullSecrets, nil
+			},
+			assertions: func(t *testing.T, status kargoapi.StageStatus) {
+				cond := meta.FindStatusCondition(
+					status.Conditions,
+					kargoapi.StageConditionTypeImagePullSecretsSynced,
+				)
+				require.NotNil(t, cond)
+				require.Equal(t, metav1.ConditionTrue, cond.Status)
+				require.Equal(
+					t,
+					kargoapi.StageConditionReasonImagePullSecretsSynced,
+					cond.Reason,
+				)
+				require.Equal(t, testStage.Spec.ImagePullSecrets, status.ImagePullSecrets)
+			},
+		},
+		{
+			name: "image pull Secrets removed from spec",
+			stage: &kargoapi.Stage{
+				Status: kargoapi.StageStatus{
+					Conditions: []metav1.Condition{{
+						Type:   kargoapi.StageConditionTypeImagePullSecretsSynced,
+						Status: metav1.ConditionTrue,
+					}},
+					ImagePullSecrets: testStage.Spec.ImagePullSecrets,
+				},
+			},
+			enabled: true,
+			syncImagePullSecretsFn: func(
+				context.Context,
+				*kargoapi.Stage,
+				[]kargoapi.Image,
+			) ([]kargoapi.ImagePullSecret, error) {
+				return nil, nil
+			},
+			assertions: func(t *testing.T, status kargoapi.StageStatus) {
+				require.Empty(t, status.Conditions)
+				require.Empty(t, status.ImagePullSecrets)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := &reconciler{
+				cfg: ReconcilerConfig{
+					ImagePullSecretsEnabled: testCase.enabled,
+				},
+				syncImagePullSecretsFn: testCase.syncImagePullSecretsFn,
+			}
+			status := *testCase.stage.Status.DeepCopy()
+			r.syncImagePullSecretsCondition(context.Background(), testCase.stage, &status)
+			testCase.assertions(t, status)
+		})
+	}
+}
+
+func TestSyncImagePullSecrets(t *testing.T) {
+	testStage := &kargoapi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-project",
+			Name:      "fake-stage",
+		},
+		Spec: kargoapi.StageSpec{
+			ImagePullSecrets: []kargoapi.ImagePullSecret{{
+				Namespace:       "fake-namespace",
+				Name:            "fake-secret",
+				ServiceAccounts: []string{"default"},
+			}},
+		},
+	}
+	testImages := []kargoapi.Image{
+		{RepoURL: "ghcr.io/example/app"},
+		{RepoURL: "nginx"},
+		{RepoURL: "registry.example.com/no-creds"},
+	}
+	testCredsDB := &credentials.FakeDB{
+		GetFn: func(
+			_ context.Context,
+			_ string,
+			_ credentials.Type,
+			repo string,
+		) (credentials.Credentials, bool, error) {
+			switch repo {
+			case "ghcr.io/example/app":
+				return credentials.Credentials{Username: "gh", Password: "gh-token"}, true, nil
+			case "nginx":
+				return credentials.Credentials{Username: "hub", Password: "hub-token"}, true, nil
+			}
+			return credentials.Credentials{}, false, nil
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	testProject := &kargoapi.Project{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake-project"},
+		Spec: &kargoapi.ProjectSpec{
+			ImagePullSecretTargets: []kargoapi.ImagePullSecretTarget{{
+				Namespace: "fake-namespace",
+			}},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		project        *kargoapi.Project
+		projectMissing bool
+		stage          *kargoapi.Stage
+		objects        []client.Object
+		assertions     func(*testing.T, client.Client, []kargoapi.ImagePullSecret, error)
+	}{
+		{
+			name:           "Project not found",
+			projectMissing: true,
+			assertions: func(
+				t *testing.T,
+				_ client.Client,
+				maintained []kargoapi.ImagePullSecret,
+				err error,
+			) {
+				require.ErrorContains(t, err, "not found")
+				require.Empty(t, maintained)
+			},
+		},
+		{
+			name:    "namespace not permitted by Project",
+			project: &kargoapi.Project{Spec: &kargoapi.ProjectSpec{}},
+			objects: []client.Object{
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "fake-namespace",
+						Name:      "default",
+					},
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				c client.Client,
+				maintained []kargoapi.ImagePullSecret,
+				err error,
+			) {
+				require.ErrorContains(t, err, "not among the imagePullSecretTargets")
+				require.Empty(t, maintained)
+
+				secret := &corev1.Secret{}
+				err = c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "fake-secret"},
+					secret,
+				)
+				require.True(t, apierrors.IsNotFound(err))
+			},
+		},
+		{
+			name: "Secret does not exist",
+			objects: []client.Object{
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "fake-namespace",
+						Name:      "default",
+					},
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				c client.Client,
+				maintained []kargoapi.ImagePullSecret,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, testStage.Spec.ImagePullSecrets, maintained)
+
+				secret := &corev1.Secret{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "fake-secret"},
+					secret,
+				))
+				require.Equal(t, corev1.SecretTypeDockerConfigJson, secret.Type)
+				require.Equal(t, "fake-project", secret.Labels[kargoapi.ProjectLabelKey])
+				require.Equal(t, "fake-stage", secret.Labels[kargoapi.StageLabelKey])
+
+				cfg := dockerConfigJSON{}
+				require.NoError(t, json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &cfg))
+				require.Len(t, cfg.Auths, 2)
+				require.Equal(t, "gh-token", cfg.Auths["ghcr.io"].Password)
+				require.Equal(t, "hub-token", cfg.Auths[dockerHubConfigKey].Password)
+
+				sa := &corev1.ServiceAccount{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "default"},
+					sa,
+				))
+				require.Equal(
+					t,
+					[]corev1.LocalObjectReference{{Name: "fake-secret"}},
+					sa.ImagePullSecrets,
+				)
+			},
+		},
+		{
+			name: "Secret exists and is managed by the Stage",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "fake-namespace",
+						Name:      "fake-secret",
+						Labels: map[string]string{
+							kargoapi.ProjectLabelKey: "fake-project",
+							kargoapi.StageLabelKey:   "fake-stage",
+						},
+					},
+					Type: corev1.SecretTypeDockerConfigJson,
+					Data: map[string][]byte{
+						corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`),
+					},
+				},
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "fake-namespace",
+						Name:      "default",
+					},
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "fake-secret"}},
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				c client.Client,
+				maintained []kargoapi.ImagePullSecret,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, testStage.Spec.ImagePullSecrets, maintained)
+
+				secret := &corev1.Secret{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "fake-secret"},
+					secret,
+				))
+				cfg := dockerConfigJSON{}
+				require.NoError(t, json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &cfg))
+				require.Len(t, cfg.Auths, 2)
+
+				sa := &corev1.ServiceAccount{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "default"},
+					sa,
+				))
+				require.Len(t, sa.ImagePullSecrets, 1)
+			},
+		},
+		{
+			name: "Secret exists and is not managed by the Stage",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "fake-namespace",
+						Name:      "fake-secret",
+					},
+					Data: map[string][]byte{"foo": []byte("bar")},
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				c client.Client,
+				_ []kargoapi.ImagePullSecret,
+				err error,
+			) {
+				require.ErrorContains(t, err, "not managed by this Stage")
+
+				secret := &corev1.Secret{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "fake-secret"},
+					secret,
+				))
+				require.Equal(t, []byte("bar"), secret.Data["foo"])
+			},
+		},
+		{
+			name: "ServiceAccount does not exist",
+			assertions: func(
+				t *testing.T,
+				_ client.Client,
+				maintained []kargoapi.ImagePullSecret,
+				err error,
+			) {
+				require.ErrorContains(t, err, "error adding Secret to ServiceAccount")
+				// The Secret was created, so it must be recorded for later cleanup
+				require.Equal(t, testStage.Spec.ImagePullSecrets, maintained)
+			},
+		},
+		{
+			name: "Secret removed from spec",
+			stage: &kargoapi.Stage{
+				ObjectMeta: testStage.ObjectMeta,
+				Status: kargoapi.StageStatus{
+					ImagePullSecrets: []kargoapi.ImagePullSecret{{
+						Namespace:       "fake-namespace",
+						Name:            "old-secret",
+						ServiceAccounts: []string{"default", "deleted"},
+					}},
+				},
+			},
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "fake-namespace",
+						Name:      "old-secret",
+						Labels: map[string]string{
+							kargoapi.ProjectLabelKey: "fake-project",
+							kargoapi.StageLabelKey:   "fake-stage",
+						},
+					},
+				},
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "fake-namespace",
+						Name:      "default",
+					},
+					ImagePullSecrets: []corev1.LocalObjectReference{
+						{Name: "other-secret"},
+						{Name: "old-secret"},
+					},
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				c client.Client,
+				maintained []kargoapi.ImagePullSecret,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Empty(t, maintained)
+
+				secret := &corev1.Secret{}
+				err = c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "old-secret"},
+					secret,
+				)
+				require.True(t, apierrors.IsNotFound(err))
+
+				sa := &corev1.ServiceAccount{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "default"},
+					sa,
+				))
+				require.Equal(
+					t,
+					[]corev1.LocalObjectReference{{Name: "other-secret"}},
+					sa.ImagePullSecrets,
+				)
+			},
+		},
+		{
+			name: "ServiceAccount removed from Secret",
+			stage: &kargoapi.Stage{
+				ObjectMeta: testStage.ObjectMeta,
+				Spec:       testStage.Spec,
+				Status: kargoapi.StageStatus{
+					ImagePullSecrets: []kargoapi.ImagePullSecret{{
+						Namespace:       "fake-namespace",
+						Name:            "fake-secret",
+						ServiceAccounts: []string{"default", "builder"},
+					}},
+				},
+			},
+			objects: []client.Object{
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "fake-namespace",
+						Name:      "default",
+					},
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "fake-secret"}},
+				},
+				&corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "fake-namespace",
+						Name:      "builder",
+					},
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "fake-secret"}},
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				c client.Client,
+				maintained []kargoapi.ImagePullSecret,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, testStage.Spec.ImagePullSecrets, maintained)
+
+				secret := &corev1.Secret{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "fake-secret"},
+					secret,
+				))
+
+				sa := &corev1.ServiceAccount{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "default"},
+					sa,
+				))
+				require.Len(t, sa.ImagePullSecrets, 1)
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "builder"},
+					sa,
+				))
+				require.Empty(t, sa.ImagePullSecrets)
+			},
+		},
+		{
+			name: "Secret no longer permitted by Project",
+			project: &kargoapi.Project{
+				ObjectMeta: metav1.ObjectMeta{Name: "fake-project"},
+				Spec:       &kargoapi.ProjectSpec{},
+			},
+			stage: &kargoapi.Stage{
+				ObjectMeta: testStage.ObjectMeta,
+				Spec:       testStage.Spec,
+				Status: kargoapi.StageStatus{
+					ImagePullSecrets: testStage.Spec.ImagePullSecrets,
+				},
+			},
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "fake-namespace",
+						Name:      "fake-secret",
+						Labels: map[string]string{
+							kargoapi.ProjectLabelKey: "fake-project",
+							kargoapi.StageLabelKey:   "fake-stage",
+						},
+					},
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				c client.Client,
+				maintained []kargoapi.ImagePullSecret,
+				err error,
+			) {
+				require.ErrorContains(t, err, "not among the imagePullSecretTargets")
+				require.Empty(t, maintained)
+
+				secret := &corev1.Secret{}
+				err = c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "fake-secret"},
+					secret,
+				)
+				require.True(t, apierrors.IsNotFound(err))
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(testCase.objects...).
+				Build()
+			project := testProject
+			if testCase.projectMissing {
+				project = nil
+			} else if testCase.project != nil {
+				project = testCase.project
+			}
+			stage := testStage
+			if testCase.stage != nil {
+				stage = testCase.stage
+			}
+			r := &reconciler{
+				credentialsDB: testCredsDB,
+				getProjectFn: func(
+					context.Context,
+					client.Client,
+					string,
+				) (*kargoapi.Project, error) {
+					return project, nil
+				},
+				getClusterClientFn: func(context.Context, string) (client.Client, error) {
+					return c, nil
+				},
+			}
+			maintained, err := r.syncImagePullSecrets(context.Background(), stage, testImages)
+			testCase.assertions(t, c, maintained, err)
+		})
+	}
+}
+
+func TestClearImagePullSecrets(t *testing.T) {
+	testStage := &kargoapi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-project",
+			Name:      "fake-stage",
+		},
+		Status: kargoapi.StageStatus{
+			ImagePullSecrets: []kargoapi.ImagePullSecret{
+				{
+					Namespace:       "fake-namespace",
+					Name:            "managed-secret",
+					ServiceAccounts: []string{"default"},
+				},
+				{
+					Namespace:       "fake-namespace",
+					Name:            "unmanaged-secret",
+					ServiceAccounts: []string{"default"},
+				},
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	testCases := []struct {
+		name       string
+		enabled    bool
+		assertions func(*testing.T, client.Client, error)
+	}{
+		{
+			name: "feature disabled",
+			assertions: func(t *testing.T, c client.Client, err error) {
+				require.NoError(t, err)
+
+				secret := &corev1.Secret{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "managed-secret"},
+					secret,
+				))
+			},
+		},
+		{
+			name:    "feature enabled",
+			enabled: true,
+			assertions: func(t *testing.T, c client.Client, err error) {
+				require.NoError(t, err)
+
+				secret := &corev1.Secret{}
+				err = c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "managed-secret"},
+					secret,
+				)
+				require.True(t, apierrors.IsNotFound(err))
+
+				// Secrets not created on behalf of the Stage are left untouched
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "unmanaged-secret"},
+					secret,
+				))
+
+				sa := &corev1.ServiceAccount{}
+				require.NoError(t, c.Get(
+					context.Background(),
+					types.NamespacedName{Namespace: "fake-namespace", Name: "default"},
+					sa,
+				))
+				require.Equal(
+					t,
+					[]corev1.LocalObjectReference{{Name: "unmanaged-secret"}},
+					sa.ImagePullSecrets,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "fake-namespace",
+							Name:      "managed-secret",
+							Labels: map[string]string{
+								kargoapi.ProjectLabelKey: "fake-project",
+								kargoapi.StageLabelKey:   "fake-stage",
+							},
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "fake-namespace",
+							Name:      "unmanaged-secret",
+						},
+					},
+					&corev1.ServiceAccount{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "fake-namespace",
+							Name:      "default",
+						},
+						ImagePullSecrets: []corev1.LocalObjectReference{
+							{Name: "managed-secret"},
+							{Name: "unmanaged-secret"},
+						},
+					},
+				).
+				Build()
+			r := &reconciler{
+				cfg: ReconcilerConfig{
+					ImagePullSecretsEnabled: testCase.enabled,
+				},
+				getClusterClientFn: func(context.Context, string) (client.Client, error) {
+					return c, nil
+				},
+			}
+			testCase.assertions(t, c, r.clearImagePullSecrets(context.Background(), testStage))
+		})
+	}
+}
+
+func TestRegistryConfigKey(t *testing.T) {
+	testCases := []struct {
+		repoURL  string
+		expected string
+	}{
+		{"nginx", dockerHubConfigKey},
+		{"docker.io/library/nginx", dockerHubConfigKey},
+		{"ghcr.io/example/app", "ghcr.io"},
+		{"localhost:5000/app", "localhost:5000"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.repoURL, func(t *testing.T) {
+			key, err := registryConfigKey(testCase.repoURL)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, key)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package stages
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// defaultQualificationHookTimeout is the maximum amount of time to wait for a
+// QualificationHook to respond when it does not specify a timeout.
+const defaultQualificationHookTimeout = 10 * time.Second
+
+// maxQualificationResponseSize is the maximum number of bytes of a
+// QualificationHook's response body that will be read.
+const maxQualificationResponseSize = 1 << 20 // 1 MiB
+
+// qualificationRequest is the body of the request sent to a QualificationHook.
+type qualificationRequest struct {
+	// Stage identifies the Stage in which the Freight is to be marked as
+	// verified.
+	Stage qualificationStage `json:"stage"`
+	// Freight is the Freight that is to be marked as verified.
+	Freight *kargoapi.Freight `json:"freight"`
+	// Verification is the outcome of the Freight's verification in the Stage.
+	// It is nil if the Stage does not define a verification process.
+	Verification *kargoapi.VerificationInfo `json:"verification,omitempty"`
+}
+
+// qualificationStage identifies a Stage in a qualificationRequest.
+type qualificationStage struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// qualificationResponse is the body of the response expected from a
+// QualificationHook.
+type qualificationResponse struct {
+	// Allowed indicates whether the Freight may be marked as verified.
+	Allowed bool `json:"allowed"`
+	// Reason optionally explains the decision.
+	Reason string `json:"reason,omitempty"`
+}
+
+// syncQualifiedCondition consults the provided Stage's QualificationHook, if
+// any, about whether the Stage's current Freight may be marked as verified in
+// the Stage and reflects the outcome in the Qualified condition of the
+// provided StageStatus. It returns a bool indicating whether the Freight may be
+// marked as verified. Freight that has already been marked as verified in the
+// Stage is not submitted to the hook again.
+func (r *reconciler) syncQualifiedCondition(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+	status *kargoapi.StageStatus,
+) (bool, error) {
+	logger := logging.LoggerFromContext(ctx)
+
+	hook := stage.Spec.QualificationHook
+	if hook == nil {
+		meta.RemoveStatusCondition(&status.Conditions, kargoapi.StageConditionTypeQualified)
+		return true, nil
+	}
+
+	freight, err := r.getFreightFn(
+		ctx,
+		r.kargoClient,
+		types.NamespacedName{
+			Namespace: stage.Namespace,
+			Name:      status.CurrentFreight.Name,
+		},
+	)
+	if err != nil {
+		return false, fmt.Errorf(
+			"error getting Freight %q in namespace %q: %w",
+			status.CurrentFreight.Name,
+			stage.Namespace,
+			err,
+		)
+	}
+	if freight == nil {
+		// Leave it to the caller to deal with the missing Freight
+		return true, nil
+	}
+	if _, verified := freight.Status.VerifiedIn[stage.Name]; verified {
+		return true, nil
+	}
+
+	res, err := r.callQualificationHookFn(
+		ctx,
+		*hook,
+		qualificationRequest{
+			Stage: qualificationStage{
+				Namespace: stage.Namespace,
+				Name:      stage.Name,
+			},
+			Freight:      freight,
+			Verification: status.CurrentFreight.VerificationInfo,
+		},
+	)
+	if err != nil {
+		logger.Errorf("error calling qualification hook: %s", err)
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               kargoapi.StageConditionTypeQualified,
+			Status:             metav1.ConditionUnknown,
+			Reason:             kargoapi.StageConditionReasonQualificationFailed,
+			Message:            err.Error(),
+			ObservedGeneration: stage.Generation,
+		})
+		return false, nil
+	}
+
+	if !res.Allowed {
+		logger.WithField("reason", res.Reason).
+			Debug("qualification hook denied marking Freight as verified")
+		message := fmt.Sprintf(
+			"Qualification hook denied marking Freight %q as verified", freight.Name,
+		)
+		if res.Reason != "" {
+			message = fmt.Sprintf("%s: %s", message, res.Reason)
+		}
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               kargoapi.StageConditionTypeQualified,
+			Status:             metav1.ConditionFalse,
+			Reason:             kargoapi.StageConditionReasonQualificationDenied,
+			Message:            message,
+			ObservedGeneration: stage.Generation,
+		})
+		return false, nil
+	}
+
+	message := fmt.Sprintf(
+		"Qualification hook allowed marking Freight %q as verified", freight.Name,
+	)
+	if res.Reason != "" {
+		message = fmt.Sprintf("%s: %s", message, res.Reason)
+	}
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               kargoapi.StageConditionTypeQualified,
+		Status:             metav1.ConditionTrue,
+		Reason:             kargoapi.StageConditionReasonQualificationAllowed,
+		Message:            message,
+		ObservedGeneration: stage.Generation,
+	})
+	return true, nil
+}
+
+// callQualificationHook sends the provided qualificationRequest to the service
+// described by the provided QualificationHook and returns its response.
+func callQualificationHook(
+	ctx context.Context,
+	hook kargoapi.QualificationHook,
+	qualReq qualificationRequest,
+) (*qualificationResponse, error) {
+	body, err := json.Marshal(qualReq)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling qualification request: %w", err)
+	}
+
+	timeout := defaultQualificationHookTimeout
+	if hook.Timeout != nil {
+		timeout = hook.Timeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request to qualification hook %q: %w", hook.URL, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpTransport := cleanhttp.DefaultTransport()
+	if hook.InsecureSkipTLSVerify {
+		httpTransport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true, // nolint: gosec
+		}
+	}
+	httpClient := &http.Client{Transport: httpTransport}
+	defer httpClient.CloseIdleConnections()
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error calling qualification hook %q: %w", hook.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf(
+			"qualification hook %q responded with unexpected status code %d",
+			hook.URL, resp.StatusCode,
+		)
+	}
+
+	resBody, err := io.ReadAll(io.LimitReader(resp.Body, maxQualificationResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error reading response body of qualification hook %q: %w", hook.URL, err,
+		)
+	}
+	res := &qualificationResponse{}
+	if err = json.Unmarshal(resBody, res); err != nil {
+		return nil, fmt.Errorf(
+			"error unmarshaling response body of qualification hook %q: %w", hook.URL, err,
+		)
+	}
+	return res, nil
+}
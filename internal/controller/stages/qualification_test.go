@@ -0,0 +1,301 @@
+package stages
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestSyncQualifiedCondition(t *testing.T) {
+	testStage := func(hook *kargoapi.QualificationHook) *kargoapi.Stage {
+		return &kargoapi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "fake-namespace",
+				Name:      "fake-stage",
+			},
+			Spec: kargoapi.StageSpec{
+				QualificationHook: hook,
+			},
+		}
+	}
+	testFreight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-namespace",
+			Name:      "fake-freight",
+		},
+	}
+	getTestFreight := func(
+		context.Context,
+		client.Client,
+		types.NamespacedName,
+	) (*kargoapi.Freight, error) {
+		return testFreight.DeepCopy(), nil
+	}
+
+	testCases := []struct {
+		name       string
+		stage      *kargoapi.Stage
+		reconciler *reconciler
+		assertions func(*testing.T, kargoapi.StageStatus, bool, error)
+	}{
+		{
+			name:       "no qualification hook",
+			stage:      testStage(nil),
+			reconciler: &reconciler{},
+			assertions: func(t *testing.T, status kargoapi.StageStatus, qualified bool, err error) {
+				require.NoError(t, err)
+				require.True(t, qualified)
+				require.Nil(
+					t,
+					meta.FindStatusCondition(status.Conditions, kargoapi.StageConditionTypeQualified),
+				)
+			},
+		},
+		{
+			name:  "error getting Freight",
+			stage: testStage(&kargoapi.QualificationHook{URL: "https://example.com"}),
+			reconciler: &reconciler{
+				getFreightFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Freight, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, _ kargoapi.StageStatus, qualified bool, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+				require.False(t, qualified)
+			},
+		},
+		{
+			name:  "Freight already verified in Stage",
+			stage: testStage(&kargoapi.QualificationHook{URL: "https://example.com"}),
+			reconciler: &reconciler{
+				getFreightFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Freight, error) {
+					freight := testFreight.DeepCopy()
+					freight.Status.VerifiedIn = map[string]kargoapi.VerifiedStage{
+						"fake-stage": {},
+					}
+					return freight, nil
+				},
+				callQualificationHookFn: func(
+					context.Context,
+					kargoapi.QualificationHook,
+					qualificationRequest,
+				) (*qualificationResponse, error) {
+					return nil, errors.New("hook should not have been called")
+				},
+			},
+			assertions: func(t *testing.T, _ kargoapi.StageStatus, qualified bool, err error) {
+				require.NoError(t, err)
+				require.True(t, qualified)
+			},
+		},
+		{
+			name:  "error calling qualification hook",
+			stage: testStage(&kargoapi.QualificationHook{URL: "https://example.com"}),
+			reconciler: &reconciler{
+				getFreightFn: getTestFreight,
+				callQualificationHookFn: func(
+					context.Context,
+					kargoapi.QualificationHook,
+					qualificationRequest,
+				) (*qualificationResponse, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, status kargoapi.StageStatus, qualified bool, err error) {
+				require.NoError(t, err)
+				require.False(t, qualified)
+				cond := meta.FindStatusCondition(
+					status.Conditions,
+					kargoapi.StageConditionTypeQualified,
+				)
+				require.NotNil(t, cond)
+				require.Equal(t, metav1.ConditionUnknown, cond.Status)
+				require.Equal(t, kargoapi.StageConditionReasonQualificationFailed, cond.Reason)
+				require.Equal(t, "something went wrong", cond.Message)
+			},
+		},
+		{
+			name:  "qualification denied",
+			stage: testStage(&kargoapi.QualificationHook{URL: "https://example.com"}),
+			reconciler: &reconciler{
+				getFreightFn: getTestFreight,
+				callQualificationHookFn: func(
+					_ context.Context,
+					_ kargoapi.QualificationHook,
+					req qualificationRequest,
+				) (*qualificationResponse, error) {
+					require.Equal(t, "fake-namespace", req.Stage.Namespace)
+					require.Equal(t, "fake-stage", req.Stage.Name)
+					require.Equal(t, "fake-freight", req.Freight.Name)
+					require.Equal(t, kargoapi.VerificationPhaseSuccessful, req.Verification.Phase)
+					return &qualificationResponse{
+						Allowed: false,
+						Reason:  "quality gate failed",
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, status kargoapi.StageStatus, qualified bool, err error) {
+				require.NoError(t, err)
+				require.False(t, qualified)
+				cond := meta.FindStatusCondition(
+					status.Conditions,
+					kargoapi.StageConditionTypeQualified,
+				)
+				require.NotNil(t, cond)
+				require.Equal(t, metav1.ConditionFalse, cond.Status)
+				require.Equal(t, kargoapi.StageConditionReasonQualificationDenied, cond.Reason)
+				require.Contains(t, cond.Message, "quality gate failed")
+			},
+		},
+		{
+			name:  "qualification allowed",
+			stage: testStage(&kargoapi.QualificationHook{URL: "https://example.com"}),
+			reconciler: &reconciler{
+				getFreightFn: getTestFreight,
+				callQualificationHookFn: func(
+					context.Context,
+					kargoapi.QualificationHook,
+					qualificationRequest,
+				) (*qualificationResponse, error) {
+					return &qualificationResponse{Allowed: true}, nil
+				},
+			},
+			assertions: func(t *testing.T, status kargoapi.StageStatus, qualified bool, err error) {
+				require.NoError(t, err)
+				require.True(t, qualified)
+				cond := meta.FindStatusCondition(
+					status.Conditions,
+					kargoapi.StageConditionTypeQualified,
+				)
+				require.NotNil(t, cond)
+				require.Equal(t, metav1.ConditionTrue, cond.Status)
+				require.Equal(t, kargoapi.StageConditionReasonQualificationAllowed, cond.Reason)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			status := kargoapi.StageStatus{
+				CurrentFreight: &kargoapi.FreightReference{
+					Name: "fake-freight",
+					VerificationInfo: &kargoapi.VerificationInfo{
+						Phase: kargoapi.VerificationPhaseSuccessful,
+					},
+				},
+				Conditions: []metav1.Condition{{
+					Type:   kargoapi.StageConditionTypeQualified,
+					Status: metav1.ConditionTrue,
+					Reason: kargoapi.StageConditionReasonQualificationAllowed,
+				}},
+			}
+			qualified, err := testCase.reconciler.syncQualifiedCondition(
+				context.Background(),
+				testCase.stage,
+				&status,
+			)
+			testCase.assertions(t, status, qualified, err)
+		})
+	}
+}
+
+func TestCallQualificationHook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/qualify":
+			req := qualificationRequest{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = fmt.Fprintf(
+				w,
+				`{"allowed":false,"reason":"%s is not approved"}`,
+				req.Freight.Name,
+			)
+		case "/garbage":
+			_, _ = fmt.Fprint(w, "not json")
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	testReq := qualificationRequest{
+		Stage: qualificationStage{
+			Namespace: "fake-namespace",
+			Name:      "fake-stage",
+		},
+		Freight: &kargoapi.Freight{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "fake-namespace",
+				Name:      "fake-freight",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		path       string
+		assertions func(*testing.T, *qualificationResponse, error)
+	}{
+		{
+			name: "success",
+			path: "/qualify",
+			assertions: func(t *testing.T, res *qualificationResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					&qualificationResponse{
+						Allowed: false,
+						Reason:  "fake-freight is not approved",
+					},
+					res,
+				)
+			},
+		},
+		{
+			name: "unexpected status code",
+			path: "/unavailable",
+			assertions: func(t *testing.T, _ *qualificationResponse, err error) {
+				require.ErrorContains(t, err, "unexpected status code 503")
+			},
+		},
+		{
+			name: "invalid response body",
+			path: "/garbage",
+			assertions: func(t *testing.T, _ *qualificationResponse, err error) {
+				require.ErrorContains(t, err, "error unmarshaling response body")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			res, err := callQualificationHook(
+				context.Background(),
+				kargoapi.QualificationHook{URL: srv.URL + testCase.path},
+				testReq,
+			)
+			testCase.assertions(t, res, err)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package stages
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"time"
 
@@ -29,6 +30,8 @@ import (
 	"github.com/akuity/kargo/internal/controller"
 	argocd "github.com/akuity/kargo/internal/controller/argocd/api/v1alpha1"
 	rollouts "github.com/akuity/kargo/internal/controller/rollouts/api/v1alpha1"
+	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/gitprovider"
 	"github.com/akuity/kargo/internal/kargo"
 	"github.com/akuity/kargo/internal/kubeclient"
 	libEvent "github.com/akuity/kargo/internal/kubernetes/event"
@@ -40,6 +43,7 @@ type ReconcilerConfig struct {
 	ShardName                    string `envconfig:"SHARD_NAME"`
 	RolloutsIntegrationEnabled   bool   `envconfig:"ROLLOUTS_INTEGRATION_ENABLED"`
 	RolloutsControllerInstanceID string `envconfig:"ROLLOUTS_CONTROLLER_INSTANCE_ID"`
+	ImagePullSecretsEnabled      bool   `envconfig:"IMAGE_PULL_SECRETS_ENABLED"`
 }
 
 func (c ReconcilerConfig) Name() string {
@@ -58,8 +62,9 @@ func ReconcilerConfigFromEnv() ReconcilerConfig {
 
 // reconciler reconciles Stage resources.
 type reconciler struct {
-	kargoClient  client.Client
-	argocdClient client.Client
+	kargoClient   client.Client
+	argocdClient  client.Client
+	credentialsDB credentials.Database
 
 	recorder record.EventRecorder
 
@@ -160,13 +165,62 @@ type reconciler struct {
 		newStatus kargoapi.FreightStatus,
 	) error
 
+	callQualificationHookFn func(
+		context.Context,
+		kargoapi.QualificationHook,
+		qualificationRequest,
+	) (*qualificationResponse, error)
+
+	// Git provider notifications:
+
+	getGitProviderFn func(
+		ctx context.Context,
+		namespace string,
+		repoURL string,
+	) (gitprovider.GitProviderService, error)
+
+	// Drift detection:
+
+	detectDriftFn func(
+		context.Context,
+		*kargoapi.Stage,
+		kargoapi.FreightReference,
+	) ([]string, error)
+
+	getGitBranchHeadFn func(
+		ctx context.Context,
+		namespace string,
+		update kargoapi.GitRepoUpdate,
+	) (string, error)
+
+	remediateDriftFn func(context.Context, *kargoapi.Stage) (bool, error)
+
+	// Image pull Secrets:
+
+	syncImagePullSecretsFn func(
+		context.Context,
+		*kargoapi.Stage,
+		[]kargoapi.Image,
+	) ([]kargoapi.ImagePullSecret, error)
+
+	getClusterClientFn func(
+		ctx context.Context,
+		cluster string,
+	) (client.Client, error)
+
 	// Auto-promotion:
 
 	isAutoPromotionPermittedFn func(
 		ctx context.Context,
-		namespace string,
+		project *kargoapi.Project,
 		stageName string,
-	) (bool, error)
+	) bool
+
+	getAutoPromotionThrottleFn func(
+		context.Context,
+		*kargoapi.Project,
+		*kargoapi.Stage,
+	) *time.Time
 
 	getProjectFn func(
 		context.Context,
@@ -192,6 +246,7 @@ type reconciler struct {
 		ctx context.Context,
 		namespace string,
 		warehouse string,
+		service string,
 	) (*kargoapi.Freight, error)
 
 	getAllVerifiedFreightFn func(
@@ -218,6 +273,10 @@ type reconciler struct {
 		...client.ListOption,
 	) error
 
+	// Approval expiry:
+
+	expireApprovalsFn func(context.Context, *kargoapi.Stage) (*time.Time, error)
+
 	// Stage deletion:
 
 	clearVerificationsFn func(context.Context, *kargoapi.Stage) error
@@ -226,6 +285,8 @@ type reconciler struct {
 
 	clearAnalysisRunsFn func(context.Context, *kargoapi.Stage) error
 
+	clearImagePullSecretsFn func(context.Context, *kargoapi.Stage) error
+
 	shardRequirement *labels.Requirement
 }
 
@@ -235,6 +296,7 @@ func SetupReconcilerWithManager(
 	ctx context.Context,
 	kargoMgr manager.Manager,
 	argocdMgr manager.Manager,
+	credentialsDB credentials.Database,
 	cfg ReconcilerConfig,
 ) error {
 	// Index Promotions in non-terminal states by Stage
@@ -300,6 +362,28 @@ func SetupReconcilerWithManager(
 		argocdClient = argocdMgr.GetClient()
 	}
 
+	r := newReconciler(
+		kargoMgr.GetClient(),
+		argocdClient,
+		credentialsDB,
+		libEvent.NewRecorder(ctx, kargoMgr.GetScheme(), kargoMgr.GetClient(), cfg.Name()),
+		cfg,
+		shardRequirement,
+	)
+	if cfg.ImagePullSecretsEnabled {
+		// Image pull Secrets and ServiceAccounts may live in any namespace. Use
+		// an uncached client for them so the manager does not start informers
+		// for every Secret in the cluster.
+		localClient, err := client.New(
+			kargoMgr.GetConfig(),
+			client.Options{Scheme: kargoMgr.GetScheme()},
+		)
+		if err != nil {
+			return fmt.Errorf("error creating client for image pull Secrets: %w", err)
+		}
+		r.getClusterClientFn = libargocd.GetClusterClientFn(localClient, argocdClient)
+	}
+
 	c, err := ctrl.NewControllerManagedBy(kargoMgr).
 		For(&kargoapi.Stage{}).
 		WithEventFilter(
@@ -322,15 +406,7 @@ func SetupReconcilerWithManager(
 		).
 		WithEventFilter(shardPredicate).
 		WithOptions(controller.CommonOptions()).
-		Build(
-			newReconciler(
-				kargoMgr.GetClient(),
-				argocdClient,
-				libEvent.NewRecorder(ctx, kargoMgr.GetScheme(), kargoMgr.GetClient(), cfg.Name()),
-				cfg,
-				shardRequirement,
-			),
-		)
+		Build(r)
 	if err != nil {
 		return fmt.Errorf("error building Stage reconciler: %w", err)
 	}
@@ -439,6 +515,7 @@ func SetupReconcilerWithManager(
 func newReconciler(
 	kargoClient client.Client,
 	argocdClient client.Client,
+	credentialsDB credentials.Database,
 	recorder record.EventRecorder,
 	cfg ReconcilerConfig,
 	shardRequirement *labels.Requirement,
@@ -446,6 +523,7 @@ func newReconciler(
 	r := &reconciler{
 		kargoClient:      kargoClient,
 		argocdClient:     argocdClient,
+		credentialsDB:    credentialsDB,
 		recorder:         recorder,
 		cfg:              cfg,
 		appHealth:        libargocd.NewApplicationHealthEvaluator(argocdClient),
@@ -469,8 +547,17 @@ func newReconciler(
 	r.getFreightFn = kargoapi.GetFreight
 	r.verifyFreightInStageFn = r.verifyFreightInStage
 	r.patchFreightStatusFn = r.patchFreightStatus
+	r.callQualificationHookFn = callQualificationHook
+	// Drift detection:
+	r.detectDriftFn = r.detectDrift
+	r.getGitBranchHeadFn = r.getGitBranchHead
+	r.remediateDriftFn = r.remediateDrift
+	// Image pull Secrets:
+	r.syncImagePullSecretsFn = r.syncImagePullSecrets
+	r.getClusterClientFn = libargocd.GetClusterClientFn(kargoClient, argocdClient)
 	// Auto-promotion:
 	r.isAutoPromotionPermittedFn = r.isAutoPromotionPermitted
+	r.getAutoPromotionThrottleFn = r.getAutoPromotionThrottle
 	r.getProjectFn = kargoapi.GetProject
 	r.createPromotionFn = kargoClient.Create
 	// Discovering latest Freight:
@@ -480,10 +567,15 @@ func newReconciler(
 	r.getLatestVerifiedFreightFn = r.getLatestVerifiedFreight
 	r.getLatestApprovedFreightFn = r.getLatestApprovedFreight
 	r.listFreightFn = r.kargoClient.List
+	// Approval expiry:
+	r.expireApprovalsFn = r.expireApprovals
+	// Git provider notifications:
+	r.getGitProviderFn = gitprovider.NewGitProviderServiceFn(credentialsDB)
 	// Stage deletion:
 	r.clearVerificationsFn = r.clearVerifications
 	r.clearApprovalsFn = r.clearApprovals
 	r.clearAnalysisRunsFn = r.clearAnalysisRuns
+	r.clearImagePullSecretsFn = r.clearImagePullSecrets
 	return r
 }
 
@@ -517,7 +609,18 @@ func (r *reconciler) Reconcile(
 	}
 	logger.Debug("found Stage")
 
+	if stage.DeletionTimestamp == nil && stage.Generation != stage.Status.ObservedGeneration {
+		// Let clients waiting for the Stage to converge know that changes to its
+		// spec are being reconciled.
+		if err = kubeclient.PatchStatus(ctx, r.kargoClient, stage, func(status *kargoapi.StageStatus) {
+			controller.SetReconcilingCondition(&status.Conditions, stage.Generation)
+		}); err != nil {
+			logger.Errorf("error updating Stage status: %s", err)
+		}
+	}
+
 	var newStatus kargoapi.StageStatus
+	var nextApprovalExpiry, nextAutoPromotion *time.Time
 	if stage.DeletionTimestamp != nil {
 		newStatus, err = r.syncStageDelete(ctx, stage)
 		if err == nil && controllerutil.RemoveFinalizer(stage, kargoapi.FinalizerName) {
@@ -527,16 +630,23 @@ func (r *reconciler) Reconcile(
 		}
 	} else {
 		err = kargoapi.AddFinalizer(ctx, r.kargoClient, stage)
+		if err == nil {
+			nextApprovalExpiry, err = r.expireApprovalsFn(ctx, stage)
+		}
 		if err != nil {
-			newStatus = stage.Status
+			newStatus = *stage.Status.DeepCopy()
 		} else {
 			if stage.Spec.PromotionMechanisms == nil {
 				newStatus, err = r.syncControlFlowStage(ctx, stage)
 			} else {
-				newStatus, err = r.syncNormalStage(ctx, stage)
+				newStatus, nextAutoPromotion, err = r.syncNormalStage(ctx, stage)
 			}
 		}
 	}
+	// Whether or not it succeeded, the current generation of the Stage's spec
+	// has been reconciled.
+	newStatus.ObservedGeneration = stage.Generation
+	controller.SetReadyCondition(&newStatus.Conditions, stage.Generation, err)
 	if err != nil {
 		newStatus.Message = err.Error()
 		logger.Errorf("error syncing Stage: %s", stage.Status.Message)
@@ -575,7 +685,18 @@ func (r *reconciler) Reconcile(
 	// Everything succeeded, look for new changes on the defined interval.
 	//
 	// TODO: Make this configurable
-	return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+	requeueAfter := 5 * time.Minute
+	// If an approval of Freight for this Stage will expire sooner than that,
+	// come back in time to revoke it. Likewise, if auto-promotion is throttled,
+	// come back as soon as the throttle lifts.
+	for _, next := range []*time.Time{nextApprovalExpiry, nextAutoPromotion} {
+		if next != nil {
+			if untilNext := next.Sub(r.nowFn()); untilNext < requeueAfter {
+				requeueAfter = max(untilNext, time.Second)
+			}
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
 func (r *reconciler) syncControlFlowStage(
@@ -622,7 +743,10 @@ func (r *reconciler) syncControlFlowStage(
 				err,
 			)
 		}
-		availableFreight = freight.Items
+		availableFreight = kargoapi.FilterFreightByService(
+			freight.Items,
+			stage.Spec.Subscriptions.Service,
+		)
 	} else {
 		// Get all Freight verified in upstream Stages. Merely being approved for an
 		// upstream Stage is not enough. If Freight is only approved for a Stage,
@@ -654,7 +778,9 @@ func (r *reconciler) syncControlFlowStage(
 			if newStatus.VerifiedIn == nil {
 				newStatus.VerifiedIn = map[string]kargoapi.VerifiedStage{}
 			}
-			newStatus.VerifiedIn[stage.Name] = kargoapi.VerifiedStage{}
+			newStatus.VerifiedIn[stage.Name] = kargoapi.VerifiedStage{
+				VerifiedAt: ptr.To(metav1.NewTime(finishTime)),
+			}
 			if err := r.patchFreightStatusFn(ctx, &af, newStatus); err != nil {
 				return status, fmt.Errorf(
 					"error marking Freight %q in namespace %q as verified in Stage %q: %w",
@@ -664,6 +790,12 @@ func (r *reconciler) syncControlFlowStage(
 					err,
 				)
 			}
+			controller.ObserveFreightLeadTime(
+				&af,
+				stage.Name,
+				controller.LeadTimeMilestoneVerified,
+				finishTime,
+			)
 
 			r.recordFreightVerificationEvent(
 				stage,
@@ -683,7 +815,7 @@ func (r *reconciler) syncControlFlowStage(
 func (r *reconciler) syncNormalStage(
 	ctx context.Context,
 	stage *kargoapi.Stage,
-) (kargoapi.StageStatus, error) {
+) (kargoapi.StageStatus, *time.Time, error) {
 	startTime := r.nowFn()
 	status := *stage.Status.DeepCopy()
 
@@ -698,13 +830,13 @@ func (r *reconciler) syncNormalStage(
 		stage.Namespace,
 		stage.Name,
 	); err != nil {
-		return status, err
+		return status, nil, err
 	} else if hasNonTerminalPromos {
 		logger.Debug(
 			"Stage has one or more Promotions in a non-terminal phase; skipping " +
 				"this reconciliation loop",
 		)
-		return status, nil
+		return status, nil, nil
 	}
 
 	status.ObservedGeneration = stage.Generation
@@ -719,6 +851,15 @@ func (r *reconciler) syncNormalStage(
 	} else {
 		freightLogger := logger.WithField("freight", status.CurrentFreight.Name)
 		shouldRecordFreightVerificationEvent := false
+		var autoQualifiedInfo *kargoapi.VerificationInfo
+
+		// Optional verification without any AnalysisTemplates requires nothing
+		// beyond the Stage being healthy, so it is treated the same as no
+		// verification at all.
+		verification := stage.Spec.Verification
+		if verification.IsOptional() && len(verification.AnalysisTemplates) == 0 {
+			verification = nil
+		}
 
 		// Push the latest state of the current Freight to the history at the
 		// end of each reconciliation loop.
@@ -727,26 +868,57 @@ func (r *reconciler) syncNormalStage(
 		}()
 
 		// Check health
-		if status.Health = r.appHealth.EvaluateHealth(
-			ctx,
-			*status.CurrentFreight,
-			stage.Spec.PromotionMechanisms.ArgoCDAppUpdates,
+		if status.Health = mergeHealth(
+			r.appHealth.EvaluateHealth(
+				ctx,
+				*status.CurrentFreight,
+				stage.Spec.PromotionMechanisms.ArgoCDAppUpdates,
+			),
+			evaluateHTTPHealth(ctx, stage, *status.CurrentFreight),
 		); status.Health != nil {
+			applyHealthChecks(
+				stage.Spec.HealthChecks,
+				status.Health,
+				stage.Status.Health,
+				stage.Status.LastPromotion,
+				r.nowFn(),
+			)
 			freightLogger.WithField("health", status.Health.Status).
 				Debug("Stage health assessed")
 		} else {
 			freightLogger.Debug("Stage health deemed not applicable")
 		}
 
+		// Make known whether it is still possible to roll back to Freight in
+		// the Stage's history
+		r.syncArtifactsUnavailableCondition(ctx, stage, &status)
+
+		// Keep image pull Secrets in target clusters populated with the
+		// credentials for the images in the current Freight
+		r.syncImagePullSecretsCondition(ctx, stage, &status)
+
+		// Check for drift and, if permitted, re-promote the current Freight to
+		// remediate it
+		if r.syncDriftCondition(ctx, stage, &status) {
+			remediating, err := r.remediateDriftFn(ctx, stage)
+			if err != nil {
+				return status, nil, fmt.Errorf("error remediating drift: %w", err)
+			}
+			if remediating {
+				// The Promotion we just created will take it from here
+				return status, nil, nil
+			}
+		}
+
 		// If the Stage is healthy and no verification process is defined, then the
 		// Stage should transition to the Steady phase.
 		if (status.Health == nil || status.Health.Status == kargoapi.HealthStateHealthy) &&
-			stage.Spec.Verification == nil && status.Phase == kargoapi.StagePhaseVerifying {
+			verification == nil && status.Phase == kargoapi.StagePhaseVerifying {
 			status.Phase = kargoapi.StagePhaseSteady
 		}
 
 		// Initiate or follow-up on verification if required
-		if stage.Spec.Verification != nil {
+		if verification != nil {
 			// Update the verification history with the current verification info.
 			// NOTE: We do this regardless of the phase of the verification process
 			// and before potentially creating a new AnalysisRun to ensure we add
@@ -783,7 +955,7 @@ func (r *reconciler) syncNormalStage(
 							status.CurrentFreight.VerificationHistory.UpdateOrPush(
 								*status.CurrentFreight.VerificationInfo,
 							)
-							return status, fmt.Errorf("error starting verification: %w", err)
+							return status, nil, fmt.Errorf("error starting verification: %w", err)
 						}
 					}
 				} else {
@@ -796,7 +968,7 @@ func (r *reconciler) syncNormalStage(
 						status.CurrentFreight.VerificationHistory.UpdateOrPush(
 							*status.CurrentFreight.VerificationInfo,
 						)
-						return status, fmt.Errorf("error getting verification info: %w", err)
+						return status, nil, fmt.Errorf("error getting verification info: %w", err)
 					}
 
 					// Abort the verification if it's still running and the Stage has
@@ -831,13 +1003,27 @@ func (r *reconciler) syncNormalStage(
 
 		// If health is not applicable or healthy
 		// AND
-		// Verification is not applicable or successful
+		// Verification is not applicable, successful, or optional and the
+		// Freight has been auto-qualified
+		// AND
+		// The Stage's qualification hook, if any, allows it
 		// THEN
 		// Mark the Freight as verified in this Stage
+		verified := verification == nil ||
+			(status.CurrentFreight.VerificationInfo != nil &&
+				status.CurrentFreight.VerificationInfo.Phase == kargoapi.VerificationPhaseSuccessful)
+		autoQualified := !verified &&
+			isAutoQualified(verification, status.CurrentFreight.VerificationInfo, r.nowFn())
+		qualified := false
 		if (status.Health == nil || status.Health.Status == kargoapi.HealthStateHealthy) &&
-			(stage.Spec.Verification == nil ||
-				(status.CurrentFreight.VerificationInfo != nil &&
-					status.CurrentFreight.VerificationInfo.Phase == kargoapi.VerificationPhaseSuccessful)) {
+			(verified || autoQualified) {
+			// Give the Stage's qualification hook, if any, the final say
+			var err error
+			if qualified, err = r.syncQualifiedCondition(ctx, stage, &status); err != nil {
+				return status, nil, fmt.Errorf("error consulting qualification hook: %w", err)
+			}
+		}
+		if qualified {
 			updated, err := r.verifyFreightInStageFn(
 				ctx,
 				stage.Namespace,
@@ -845,7 +1031,7 @@ func (r *reconciler) syncNormalStage(
 				stage.Name,
 			)
 			if err != nil {
-				return status, fmt.Errorf(
+				return status, nil, fmt.Errorf(
 					"error marking Freight %q in namespace %q as verified in Stage %q: %w",
 					status.CurrentFreight.Name,
 					stage.Namespace,
@@ -857,6 +1043,14 @@ func (r *reconciler) syncNormalStage(
 			// Always record verification event when the Freight is marked as verified
 			if updated {
 				shouldRecordFreightVerificationEvent = true
+				if autoQualified {
+					freightLogger.Debug("Freight auto-qualified in Stage")
+					autoQualifiedInfo = &kargoapi.VerificationInfo{
+						StartTime:  ptr.To(metav1.NewTime(startTime)),
+						FinishTime: ptr.To(metav1.NewTime(r.nowFn())),
+						Phase:      kargoapi.VerificationPhaseSuccessful,
+					}
+				}
 			}
 		}
 
@@ -865,12 +1059,14 @@ func (r *reconciler) syncNormalStage(
 		// Record freight verification event only if the freight is newly verified
 		if shouldRecordFreightVerificationEvent {
 			vi := status.CurrentFreight.VerificationInfo
-			if stage.Spec.Verification == nil {
+			if verification == nil {
 				vi = &kargoapi.VerificationInfo{
 					StartTime:  ptr.To(metav1.NewTime(startTime)),
 					FinishTime: ptr.To(metav1.NewTime(finishTime)),
 					Phase:      kargoapi.VerificationPhaseSuccessful,
 				}
+			} else if autoQualifiedInfo != nil {
+				vi = autoQualifiedInfo
 			}
 
 			var ar *rollouts.AnalysisRun
@@ -885,7 +1081,7 @@ func (r *reconciler) syncNormalStage(
 					},
 				)
 				if err != nil {
-					return status, fmt.Errorf("get analysisRun: %w", err)
+					return status, nil, fmt.Errorf("get analysisRun: %w", err)
 				}
 			}
 
@@ -898,10 +1094,15 @@ func (r *reconciler) syncNormalStage(
 				},
 			)
 			if err != nil {
-				return status, fmt.Errorf("get freight: %w", err)
+				return status, nil, fmt.Errorf("get freight: %w", err)
 			}
 			if fr != nil {
 				r.recordFreightVerificationEvent(stage, fr, vi, ar)
+				if err = r.notifyGitProviders(ctx, stage, fr, vi); err != nil {
+					// Log the error, but don't let failure to notify Git providers
+					// affect the reconciliation of the Stage.
+					freightLogger.Errorf("error notifying Git providers: %s", err)
+				}
 			}
 		}
 	}
@@ -912,21 +1113,26 @@ func (r *reconciler) syncNormalStage(
 			"Stage has no subscriptions. This may indicate an issue with resource" +
 				"validation logic.",
 		)
-		return status, nil
+		return status, nil, nil
+	}
+
+	project, err := r.getProjectFn(ctx, r.kargoClient, stage.Namespace)
+	if err != nil {
+		return status, nil, fmt.Errorf("error finding Project %q: %w", stage.Namespace, err)
+	}
+	if project == nil {
+		return status, nil, fmt.Errorf("Project %q not found", stage.Namespace)
 	}
 
 	logger.Debug("checking if auto-promotion is permitted...")
-	if permitted, err :=
-		r.isAutoPromotionPermittedFn(ctx, stage.Namespace, stage.Name); err != nil {
-		return status, fmt.Errorf(
-			"error checking if auto-promotion is permitted for Stage %q in namespace %q: %w",
-			stage.Name,
-			stage.Namespace,
-			err,
-		)
-	} else if !permitted {
+	if !r.isAutoPromotionPermittedFn(ctx, project, stage.Name) {
 		logger.Debug("auto-promotion is not permitted for the Stage")
-		return status, nil
+		return status, nil, nil
+	}
+
+	if throttledUntil := r.getAutoPromotionThrottleFn(ctx, project, stage); throttledUntil != nil {
+		logger.Debug("auto-promotion is throttled for the Stage")
+		return status, throttledUntil, nil
 	}
 
 	// If we get to here, auto-promotion is permitted. Time to go looking for new
@@ -935,7 +1141,7 @@ func (r *reconciler) syncNormalStage(
 	latestFreight, err :=
 		r.getLatestAvailableFreightFn(ctx, stage.Namespace, stage)
 	if err != nil {
-		return status, fmt.Errorf(
+		return status, nil, fmt.Errorf(
 			"error finding latest Freight for Stage %q in namespace %q: %w",
 			stage.Name,
 			stage.Namespace,
@@ -945,7 +1151,7 @@ func (r *reconciler) syncNormalStage(
 
 	if latestFreight == nil {
 		logger.Debug("no Freight found")
-		return status, nil
+		return status, nil, nil
 	}
 
 	logger = logger.WithField("freight", latestFreight.Name)
@@ -954,7 +1160,18 @@ func (r *reconciler) syncNormalStage(
 	if stage.Status.CurrentFreight != nil &&
 		stage.Status.CurrentFreight.Name == latestFreight.Name {
 		logger.Debug("Stage already has latest available Freight")
-		return status, nil
+		return status, nil, nil
+	}
+
+	// Only proceed if nextFreight changes any of the artifacts the Stage
+	// consumes
+	if stage.Status.CurrentFreight != nil && !changesConsumedArtifacts(
+		stage.Spec.Subscriptions.Artifacts,
+		*stage.Status.CurrentFreight,
+		latestFreight,
+	) {
+		logger.Debug("latest available Freight changes no artifacts consumed by the Stage")
+		return status, nil, nil
 	}
 
 	// If a promotion already exists for this Stage + Freight, then we're
@@ -973,7 +1190,7 @@ func (r *reconciler) syncNormalStage(
 			).AsSelector(),
 		},
 	); err != nil {
-		return status, fmt.Errorf(
+		return status, nil, fmt.Errorf(
 			"error listing existing Promotions for Freight %q in namespace %q: %w",
 			latestFreight.Name,
 			stage.Namespace,
@@ -983,7 +1200,7 @@ func (r *reconciler) syncNormalStage(
 
 	if len(promos.Items) > 0 {
 		logger.Debug("Promotion already exists for Freight")
-		return status, nil
+		return status, nil, nil
 	}
 
 	logger.Debug("auto-promotion will proceed")
@@ -991,7 +1208,7 @@ func (r *reconciler) syncNormalStage(
 	promo := kargo.NewPromotion(ctx, *stage, latestFreight.Name)
 	if err :=
 		r.createPromotionFn(ctx, &promo); err != nil {
-		return status, fmt.Errorf(
+		return status, nil, fmt.Errorf(
 			"error creating Promotion of Stage %q in namespace %q to Freight %q: %w",
 			stage.Name,
 			stage.Namespace,
@@ -1016,7 +1233,7 @@ func (r *reconciler) syncNormalStage(
 
 	logger.WithField("promotion", promo.Name).Debug("created Promotion resource")
 
-	return status, nil
+	return status, nil, nil
 }
 
 func (r *reconciler) syncStageDelete(
@@ -1052,6 +1269,16 @@ func (r *reconciler) syncStageDelete(
 			err,
 		)
 	}
+	if len(stage.Status.ImagePullSecrets) > 0 {
+		if err := r.clearImagePullSecretsFn(ctx, stage); err != nil {
+			return status, fmt.Errorf(
+				"error clearing image pull Secrets for Stage %q in namespace %q: %w",
+				stage.Name,
+				stage.Namespace,
+				err,
+			)
+		}
+	}
 	return status, nil
 }
 
@@ -1139,6 +1366,89 @@ func (r *reconciler) clearApprovals(
 	return nil
 }
 
+// expireApprovals revokes all approvals of Freight for the provided Stage that
+// have expired without the Freight having been promoted to the Stage. Approvals
+// that have not yet expired, but whose Freight has since been promoted to the
+// Stage, are fulfilled and no longer expire. The time at which the next of the
+// remaining approvals will expire, if any, is returned.
+func (r *reconciler) expireApprovals(
+	ctx context.Context,
+	stage *kargoapi.Stage,
+) (*time.Time, error) {
+	logger := logging.LoggerFromContext(ctx)
+	approved := kargoapi.FreightList{}
+	if err := r.listFreightFn(
+		ctx,
+		&approved,
+		&client.ListOptions{
+			Namespace: stage.Namespace,
+			FieldSelector: fields.OneTermEqualSelector(
+				kubeclient.FreightApprovedForStagesIndexField,
+				stage.Name,
+			),
+		},
+	); err != nil {
+		return nil, fmt.Errorf(
+			"error listing Freight approved for Stage %q in namespace %q: %w",
+			stage.Name,
+			stage.Namespace,
+			err,
+		)
+	}
+	now := r.nowFn()
+	var nextExpiry *time.Time
+	for _, f := range approved.Items {
+		freight := f // Avoid implicit memory aliasing
+		approval, ok := freight.Status.ApprovedFor[stage.Name]
+		if !ok || approval.ExpiresAt == nil {
+			continue
+		}
+		newStatus := *freight.Status.DeepCopy()
+		var expired bool
+		if promoted, ok := freight.Status.PromotedTo[stage.Name]; ok &&
+			promoted.PromotedAt != nil &&
+			(approval.ApprovedAt == nil || !promoted.PromotedAt.Before(approval.ApprovedAt)) {
+			approval.ExpiresAt = nil
+			newStatus.ApprovedFor[stage.Name] = approval
+		} else if approval.HasExpired(now) {
+			delete(newStatus.ApprovedFor, stage.Name)
+			expired = true
+		} else {
+			if nextExpiry == nil || approval.ExpiresAt.Time.Before(*nextExpiry) {
+				nextExpiry = &approval.ExpiresAt.Time
+			}
+			continue
+		}
+		if err := r.patchFreightStatusFn(ctx, &freight, newStatus); err != nil {
+			return nil, fmt.Errorf(
+				"error patching status of Freight %q in namespace %q: %w",
+				freight.Name,
+				freight.Namespace,
+				err,
+			)
+		}
+		if !expired {
+			continue
+		}
+		logger.WithField("freight", freight.Name).Debug("revoked expired approval of Freight")
+		r.recorder.AnnotatedEventf(
+			&freight,
+			kargoapi.NewFreightApprovedEventAnnotations(
+				kargoapi.FormatEventControllerActor(r.cfg.Name()),
+				&freight,
+				stage.Name,
+			),
+			corev1.EventTypeNormal,
+			kargoapi.EventReasonFreightApprovalExpired,
+			fmt.Sprintf(
+				"Approval of Freight for Stage %q expired without it having been promoted",
+				stage.Name,
+			),
+		)
+	}
+	return nextExpiry, nil
+}
+
 func (r *reconciler) clearAnalysisRuns(
 	ctx context.Context,
 	stage *kargoapi.Stage,
@@ -1237,10 +1547,19 @@ func (r *reconciler) verifyFreightInStage(
 		return false, nil
 	}
 
-	newStatus.VerifiedIn[stageName] = kargoapi.VerifiedStage{}
+	verifiedAt := r.nowFn()
+	newStatus.VerifiedIn[stageName] = kargoapi.VerifiedStage{
+		VerifiedAt: ptr.To(metav1.NewTime(verifiedAt)),
+	}
 	if err = r.patchFreightStatusFn(ctx, freight, newStatus); err != nil {
 		return false, err
 	}
+	controller.ObserveFreightLeadTime(
+		freight,
+		stageName,
+		controller.LeadTimeMilestoneVerified,
+		verifiedAt,
+	)
 
 	logger.Debug("marked Freight as verified in Stage")
 	return true, nil
@@ -1271,29 +1590,73 @@ func (r *reconciler) patchFreightStatus(
 
 func (r *reconciler) isAutoPromotionPermitted(
 	ctx context.Context,
-	namespace string,
+	project *kargoapi.Project,
 	stageName string,
-) (bool, error) {
+) bool {
 	logger := logging.LoggerFromContext(ctx)
-	project, err := r.getProjectFn(ctx, r.kargoClient, namespace)
-	if err != nil {
-		return false, fmt.Errorf("error finding Project %q: %w", namespace, err)
-	}
-	if project == nil {
-		return false, fmt.Errorf("Project %q not found", namespace)
+	if m := project.ActiveMaintenanceMode(r.nowFn()); m != nil {
+		logger.Debugf("auto-promotion is paused: %s", m.Describe())
+		return false
 	}
 	if project.Spec == nil || len(project.Spec.PromotionPolicies) == 0 {
 		logger.Debug("found no PromotionPolicy associated with the Stage")
-		return false, nil
+		return false
 	}
 	for _, policy := range project.Spec.PromotionPolicies {
 		if policy.Stage == stageName {
 			logger.WithField("autoPromotionEnabled", policy.AutoPromotionEnabled).
 				Debug("found PromotionPolicy associated with the Stage")
-			return policy.AutoPromotionEnabled, nil
+			return policy.AutoPromotionEnabled
 		}
 	}
-	return false, nil
+	return false
+}
+
+// getAutoPromotionThrottle returns the earliest time at which the provided
+// Stage may next be auto-promoted if its PromotionPolicy specifies a minimum
+// interval between auto-promotions and either a Promotion to the Stage is in
+// progress or that interval has not yet elapsed since the Stage's last
+// Promotion concluded. Otherwise, it returns nil. Deferring auto-promotion in
+// either case ensures that, once the interval has elapsed, only the newest
+// available Freight is promoted.
+func (r *reconciler) getAutoPromotionThrottle(
+	ctx context.Context,
+	project *kargoapi.Project,
+	stage *kargoapi.Stage,
+) *time.Time {
+	logger := logging.LoggerFromContext(ctx)
+	if project.Spec == nil {
+		return nil
+	}
+	var minInterval time.Duration
+	for _, policy := range project.Spec.PromotionPolicies {
+		if policy.Stage == stage.Name && policy.AutoPromotionMinInterval != nil {
+			minInterval = policy.AutoPromotionMinInterval.Duration
+			break
+		}
+	}
+	if minInterval <= 0 {
+		return nil
+	}
+	now := r.nowFn()
+	if stage.Status.CurrentPromotion != nil {
+		logger.WithField("promotion", stage.Status.CurrentPromotion.Name).
+			Debug("Promotion is in progress")
+		// The in-progress Promotion has yet to conclude, so the interval cannot
+		// elapse any sooner than this.
+		next := now.Add(minInterval)
+		return &next
+	}
+	lastPromo := stage.Status.LastPromotion
+	if lastPromo == nil || lastPromo.FinishedAt == nil {
+		return nil
+	}
+	if next := lastPromo.FinishedAt.Add(minInterval); now.Before(next) {
+		logger.WithField("remaining", next.Sub(now).String()).
+			Debug("minimum interval between auto-promotions has not elapsed")
+		return &next
+	}
+	return nil
 }
 
 func (r *reconciler) getLatestAvailableFreight(
@@ -1308,6 +1671,7 @@ func (r *reconciler) getLatestAvailableFreight(
 			ctx,
 			namespace,
 			stage.Spec.Subscriptions.Warehouse,
+			stage.Spec.Subscriptions.Service,
 		)
 		if err != nil {
 			return nil, fmt.Errorf(
@@ -1374,10 +1738,74 @@ func (r *reconciler) getLatestAvailableFreight(
 	return latestApprovedFreight, nil
 }
 
+// changesConsumedArtifacts returns true if the provided Freight differs from
+// the provided current Freight in any artifact of the provided kinds. If no
+// kinds are provided, the Stage consumes artifacts of all kinds and any
+// Freight is considered a change, even one with identical artifacts.
+func changesConsumedArtifacts(
+	kinds []kargoapi.ArtifactKind,
+	current kargoapi.FreightReference,
+	freight *kargoapi.Freight,
+) bool {
+	if len(kinds) == 0 {
+		return true
+	}
+	consumes := func(kind kargoapi.ArtifactKind) bool {
+		return slices.Contains(kinds, kind)
+	}
+	if consumes(kargoapi.ArtifactKindGit) && !sameArtifacts(
+		current.Commits,
+		freight.Commits,
+		func(c kargoapi.GitCommit) string { return c.RepoURL + "@" + c.ID },
+	) {
+		return true
+	}
+	if consumes(kargoapi.ArtifactKindImage) && !sameArtifacts(
+		current.Images,
+		freight.Images,
+		func(i kargoapi.Image) string { return i.RepoURL + ":" + i.Tag + "@" + i.Digest },
+	) {
+		return true
+	}
+	if consumes(kargoapi.ArtifactKindChart) && !sameArtifacts(
+		current.Charts,
+		freight.Charts,
+		func(c kargoapi.Chart) string { return c.RepoURL + "/" + c.Name + ":" + c.Version },
+	) {
+		return true
+	}
+	return consumes(kargoapi.ArtifactKindPackage) && !sameArtifacts(
+		current.Packages,
+		freight.Packages,
+		func(p kargoapi.Package) string {
+			return string(p.Type) + "/" + p.RepoURL + "/" + p.Name + ":" + p.Version
+		},
+	)
+}
+
+// sameArtifacts returns true if the two provided lists of artifacts contain
+// the same artifacts, as identified by the provided key function, regardless
+// of their order.
+func sameArtifacts[T any](a, b []T, key func(T) string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	keys := func(artifacts []T) []string {
+		k := make([]string, len(artifacts))
+		for i, artifact := range artifacts {
+			k[i] = key(artifact)
+		}
+		slices.Sort(k)
+		return k
+	}
+	return slices.Equal(keys(a), keys(b))
+}
+
 func (r *reconciler) getLatestFreightFromWarehouse(
 	ctx context.Context,
 	namespace string,
 	warehouse string,
+	service string,
 ) (*kargoapi.Freight, error) {
 	var freight kargoapi.FreightList
 	if err := r.listFreightFn(
@@ -1398,15 +1826,11 @@ func (r *reconciler) getLatestFreightFromWarehouse(
 			err,
 		)
 	}
+	freight.Items = kargoapi.FilterFreightByService(freight.Items, service)
 	if len(freight.Items) == 0 {
 		return nil, nil
 	}
-	// Sort by creation timestamp, descending
-	sort.SliceStable(freight.Items, func(i, j int) bool {
-		return freight.Items[j].CreationTimestamp.
-			Before(&freight.Items[i].CreationTimestamp)
-	})
-	return &freight.Items[0], nil
+	return latestUnblockedFreight(freight.Items), nil
 }
 
 func (r *reconciler) getAllVerifiedFreight(
@@ -1467,12 +1891,7 @@ func (r *reconciler) getLatestVerifiedFreight(
 	if len(verifiedFreight) == 0 {
 		return nil, nil
 	}
-	// Sort the list by creation timestamp, descending
-	sort.SliceStable(verifiedFreight, func(i, j int) bool {
-		return verifiedFreight[j].CreationTimestamp.
-			Before(&verifiedFreight[i].CreationTimestamp)
-	})
-	return &verifiedFreight[0], nil
+	return latestUnblockedFreight(verifiedFreight), nil
 }
 
 func (r *reconciler) getLatestApprovedFreight(
@@ -1502,12 +1921,22 @@ func (r *reconciler) getLatestApprovedFreight(
 	if len(freight.Items) == 0 {
 		return nil, nil
 	}
-	// Sort the list by creation timestamp, descending
-	sort.SliceStable(freight.Items, func(i, j int) bool {
-		return freight.Items[j].CreationTimestamp.
-			Before(&freight.Items[i].CreationTimestamp)
+	return latestUnblockedFreight(freight.Items), nil
+}
+
+// latestUnblockedFreight returns the most recently created Freight from the
+// provided list that has not been blocked, or nil if there is no such Freight.
+// The list is sorted in place by creation timestamp, descending.
+func latestUnblockedFreight(freight []kargoapi.Freight) *kargoapi.Freight {
+	sort.SliceStable(freight, func(i, j int) bool {
+		return freight[j].CreationTimestamp.Before(&freight[i].CreationTimestamp)
 	})
-	return &freight.Items[0], nil
+	for i := range freight {
+		if !freight[i].IsBlocked() {
+			return &freight[i]
+		}
+	}
+	return nil
 }
 
 func (r *reconciler) recordFreightVerificationEvent(
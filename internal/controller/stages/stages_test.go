@@ -16,6 +16,7 @@ import (
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/controller"
 	rollouts "github.com/akuity/kargo/internal/controller/rollouts/api/v1alpha1"
+	"github.com/akuity/kargo/internal/credentials"
 	fakeevent "github.com/akuity/kargo/internal/kubernetes/event/fake"
 )
 
@@ -34,6 +35,7 @@ func TestNewReconciler(t *testing.T) {
 	r := newReconciler(
 		kubeClient,
 		kubeClient,
+		&credentials.FakeDB{},
 		recorder,
 		testCfg,
 		requirement,
@@ -41,6 +43,7 @@ func TestNewReconciler(t *testing.T) {
 	require.Equal(t, testCfg, r.cfg)
 	require.NotNil(t, r.kargoClient)
 	require.NotNil(t, r.argocdClient)
+	require.NotNil(t, r.credentialsDB)
 	require.NotNil(t, r.recorder)
 	require.NotNil(t, r.appHealth)
 	// Assert that all overridable behaviors were initialized to a default:
@@ -59,8 +62,17 @@ func TestNewReconciler(t *testing.T) {
 	require.NotNil(t, r.getFreightFn)
 	require.NotNil(t, r.verifyFreightInStageFn)
 	require.NotNil(t, r.patchFreightStatusFn)
+	require.NotNil(t, r.callQualificationHookFn)
+	// Drift detection:
+	require.NotNil(t, r.detectDriftFn)
+	require.NotNil(t, r.getGitBranchHeadFn)
+	require.NotNil(t, r.remediateDriftFn)
+	// Image pull Secrets:
+	require.NotNil(t, r.syncImagePullSecretsFn)
+	require.NotNil(t, r.getClusterClientFn)
 	// Auto-promotion:
 	require.NotNil(t, r.isAutoPromotionPermittedFn)
+	require.NotNil(t, r.getAutoPromotionThrottleFn)
 	require.NotNil(t, r.getProjectFn)
 	require.NotNil(t, r.createPromotionFn)
 	// Discovering latest Freight:
@@ -70,10 +82,14 @@ func TestNewReconciler(t *testing.T) {
 	require.NotNil(t, r.getLatestVerifiedFreightFn)
 	require.NotNil(t, r.getLatestApprovedFreightFn)
 	require.NotNil(t, r.listFreightFn)
+	// Approval expiry:
+	require.NotNil(t, r.expireApprovalsFn)
 	// Stage deletion:
+	require.NotNil(t, r.getGitProviderFn)
 	require.NotNil(t, r.clearVerificationsFn)
 	require.NotNil(t, r.clearApprovalsFn)
 	require.NotNil(t, r.clearAnalysisRunsFn)
+	require.NotNil(t, r.clearImagePullSecretsFn)
 }
 
 func TestSyncControlFlowStage(t *testing.T) {
@@ -294,6 +310,13 @@ func TestSyncNormalStage(t *testing.T) {
 	) (bool, error) {
 		return false, nil
 	}
+	fakeProjectFn := func(
+		context.Context,
+		client.Client,
+		string,
+	) (*kargoapi.Project, error) {
+		return &kargoapi.Project{}, nil
+	}
 
 	testCases := []struct {
 		name       string
@@ -304,6 +327,7 @@ func TestSyncNormalStage(t *testing.T) {
 			recorder *fakeevent.EventRecorder,
 			initialStatus kargoapi.StageStatus,
 			newStatus kargoapi.StageStatus,
+			nextAutoPromotion *time.Time,
 			err error,
 		)
 	}{
@@ -324,6 +348,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				initialStatus kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.Error(t, err)
@@ -353,6 +378,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				initialStatus kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.NoError(t, err)
@@ -411,6 +437,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				_ kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.NoError(t, err)
@@ -482,6 +509,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				initialStatus kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.NoError(t, err)
@@ -531,6 +559,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				initialStatus kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.NoError(t, err)
@@ -604,6 +633,7 @@ func TestSyncNormalStage(t *testing.T) {
 				_ *fakeevent.EventRecorder,
 				initialStatus kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.ErrorContains(t, err, "retryable error")
@@ -678,6 +708,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				initialStatus kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.NoError(t, err)
@@ -752,6 +783,7 @@ func TestSyncNormalStage(t *testing.T) {
 				_ *fakeevent.EventRecorder,
 				initialStatus kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.ErrorContains(t, err, "retryable error")
@@ -844,6 +876,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				_ kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.NoError(t, err)
@@ -876,6 +909,128 @@ func TestSyncNormalStage(t *testing.T) {
 			},
 		},
 
+		{
+			name: "optional verification auto-qualifies Freight",
+			stage: &kargoapi.Stage{
+				Spec: kargoapi.StageSpec{
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
+					Verification: &kargoapi.Verification{
+						AnalysisTemplates: []kargoapi.AnalysisTemplateReference{{
+							Name: "fake-template",
+						}},
+						Policy:           kargoapi.VerificationPolicyOptional,
+						AutoQualifyAfter: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+				Status: kargoapi.StageStatus{
+					Phase: kargoapi.StagePhaseVerifying,
+					CurrentFreight: &kargoapi.FreightReference{
+						VerificationInfo: &kargoapi.VerificationInfo{
+							ID:        "fake-id",
+							StartTime: ptr.To(metav1.NewTime(fakeTime.Add(-15 * time.Minute))),
+							Phase:     kargoapi.VerificationPhaseRunning,
+							AnalysisRun: &kargoapi.AnalysisRunReference{
+								Name: "fake-analysis-run",
+							},
+						},
+					},
+				},
+			},
+			reconciler: &reconciler{
+				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				appHealth:                  &mockAppHealthEvaluator{},
+				getVerificationInfoFn: func(
+					_ context.Context,
+					s *kargoapi.Stage,
+				) (*kargoapi.VerificationInfo, error) {
+					return s.Status.CurrentFreight.VerificationInfo, nil
+				},
+				verifyFreightInStageFn: func(context.Context, string, string, string) (bool, error) {
+					return true, nil
+				},
+				getFreightFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{}, nil
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				recorder *fakeevent.EventRecorder,
+				_ kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				_ *time.Time,
+				err error,
+			) {
+				require.NoError(t, err)
+				// Verification is still running
+				require.Equal(t, kargoapi.StagePhaseVerifying, newStatus.Phase)
+				require.Equal(
+					t,
+					kargoapi.VerificationPhaseRunning,
+					newStatus.CurrentFreight.VerificationInfo.Phase,
+				)
+
+				require.Len(t, recorder.Events, 1)
+				event := <-recorder.Events
+				require.Equal(t, kargoapi.EventReasonFreightVerificationSucceeded, event.Reason)
+			},
+		},
+
+		{
+			name: "optional verification without AnalysisTemplates",
+			stage: &kargoapi.Stage{
+				Spec: kargoapi.StageSpec{
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
+					Verification: &kargoapi.Verification{
+						Policy: kargoapi.VerificationPolicyOptional,
+					},
+				},
+				Status: kargoapi.StageStatus{
+					Phase:          kargoapi.StagePhaseVerifying,
+					CurrentFreight: &kargoapi.FreightReference{},
+				},
+			},
+			reconciler: &reconciler{
+				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				appHealth:                  &mockAppHealthEvaluator{},
+				startVerificationFn: func(
+					context.Context,
+					*kargoapi.Stage,
+				) (*kargoapi.VerificationInfo, error) {
+					return nil, errors.New("verification should not have been started")
+				},
+				verifyFreightInStageFn: func(context.Context, string, string, string) (bool, error) {
+					return true, nil
+				},
+				getFreightFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{}, nil
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				recorder *fakeevent.EventRecorder,
+				_ kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				_ *time.Time,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Equal(t, kargoapi.StagePhaseSteady, newStatus.Phase)
+				require.Nil(t, newStatus.CurrentFreight.VerificationInfo)
+
+				require.Len(t, recorder.Events, 1)
+				event := <-recorder.Events
+				require.Equal(t, kargoapi.EventReasonFreightVerificationSucceeded, event.Reason)
+			},
+		},
+
 		{
 			name: "verification abort conditions not met",
 			stage: &kargoapi.Stage{
@@ -949,6 +1104,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				_ kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.NoError(t, err)
@@ -992,6 +1148,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				initialStatus kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.ErrorContains(t, err, "something went wrong")
@@ -1009,7 +1166,7 @@ func TestSyncNormalStage(t *testing.T) {
 		},
 
 		{
-			name: "error checking if auto-promotion is permitted",
+			name: "error finding Project",
 			stage: &kargoapi.Stage{
 				Spec: kargoapi.StageSpec{
 					Subscriptions: kargoapi.Subscriptions{
@@ -1027,12 +1184,12 @@ func TestSyncNormalStage(t *testing.T) {
 				verifyFreightInStageFn: func(context.Context, string, string, string) (bool, error) {
 					return true, nil
 				},
-				isAutoPromotionPermittedFn: func(
+				getProjectFn: func(
 					context.Context,
+					client.Client,
 					string,
-					string,
-				) (bool, error) {
-					return false, errors.New("something went wrong")
+				) (*kargoapi.Project, error) {
+					return nil, errors.New("something went wrong")
 				},
 				getFreightFn: func(
 					context.Context,
@@ -1047,6 +1204,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				initialStatus kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				// Verification should be done before auto-promotion
@@ -1063,7 +1221,68 @@ func TestSyncNormalStage(t *testing.T) {
 				)
 
 				require.ErrorContains(t, err, "something went wrong")
-				require.ErrorContains(t, err, "error checking if auto-promotion is permitted")
+				require.ErrorContains(t, err, "error finding Project")
+				// Status should be returned unchanged
+				require.Equal(t, initialStatus, newStatus)
+			},
+		},
+
+		{
+			name: "Project not found",
+			stage: &kargoapi.Stage{
+				Spec: kargoapi.StageSpec{
+					Subscriptions: kargoapi.Subscriptions{
+						Warehouse: "fake-warehouse",
+					},
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
+				},
+				Status: kargoapi.StageStatus{
+					CurrentFreight: &kargoapi.FreightReference{},
+				},
+			},
+			reconciler: &reconciler{
+				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				appHealth:                  &mockAppHealthEvaluator{},
+				verifyFreightInStageFn: func(context.Context, string, string, string) (bool, error) {
+					return true, nil
+				},
+				getProjectFn: func(
+					context.Context,
+					client.Client,
+					string,
+				) (*kargoapi.Project, error) {
+					return nil, nil
+				},
+				getFreightFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{}, nil
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				recorder *fakeevent.EventRecorder,
+				initialStatus kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				_ *time.Time,
+				err error,
+			) {
+				// Verification should be done before auto-promotion
+				require.Len(t, recorder.Events, 1)
+				event := <-recorder.Events
+				require.Equal(t, kargoapi.EventReasonFreightVerificationSucceeded, event.Reason)
+				require.Equal(t,
+					fakeTime.Format(time.RFC3339),
+					event.Annotations[kargoapi.AnnotationKeyEventVerificationStartTime],
+				)
+				require.Equal(t,
+					fakeTime.Format(time.RFC3339),
+					event.Annotations[kargoapi.AnnotationKeyEventVerificationFinishTime],
+				)
+
+				require.ErrorContains(t, err, "not found")
 				// Status should be returned unchanged
 				require.Equal(t, initialStatus, newStatus)
 			},
@@ -1088,12 +1307,13 @@ func TestSyncNormalStage(t *testing.T) {
 				verifyFreightInStageFn: func(context.Context, string, string, string) (bool, error) {
 					return true, nil
 				},
+				getProjectFn: fakeProjectFn,
 				isAutoPromotionPermittedFn: func(
 					context.Context,
+					*kargoapi.Project,
 					string,
-					string,
-				) (bool, error) {
-					return false, nil
+				) bool {
+					return false
 				},
 				getFreightFn: func(
 					context.Context,
@@ -1108,6 +1328,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				initialStatus kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				// Verification should be done before auto-promotion
@@ -1130,7 +1351,7 @@ func TestSyncNormalStage(t *testing.T) {
 		},
 
 		{
-			name: "error getting latest Freight",
+			name: "auto-promotion is throttled",
 			stage: &kargoapi.Stage{
 				Spec: kargoapi.StageSpec{
 					Subscriptions: kargoapi.Subscriptions{
@@ -1148,13 +1369,84 @@ func TestSyncNormalStage(t *testing.T) {
 				verifyFreightInStageFn: func(context.Context, string, string, string) (bool, error) {
 					return true, nil
 				},
+				getProjectFn: fakeProjectFn,
 				isAutoPromotionPermittedFn: func(
 					context.Context,
+					*kargoapi.Project,
 					string,
-					string,
-				) (bool, error) {
+				) bool {
+					return true
+				},
+				getAutoPromotionThrottleFn: func(
+					context.Context,
+					*kargoapi.Project,
+					*kargoapi.Stage,
+				) *time.Time {
+					return ptr.To(fakeTime.Add(time.Minute))
+				},
+				getFreightFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{}, nil
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				recorder *fakeevent.EventRecorder,
+				initialStatus kargoapi.StageStatus,
+				newStatus kargoapi.StageStatus,
+				nextAutoPromotion *time.Time,
+				err error,
+			) {
+				// Only the verification event should have been recorded
+				require.Len(t, recorder.Events, 1)
+				event := <-recorder.Events
+				require.Equal(t, kargoapi.EventReasonFreightVerificationSucceeded, event.Reason)
+
+				require.NoError(t, err)
+				// The Stage should be reconciled again once the throttle lifts
+				require.Equal(t, ptr.To(fakeTime.Add(time.Minute)), nextAutoPromotion)
+				// Status should be returned unchanged
+				require.Equal(t, initialStatus, newStatus)
+			},
+		},
+
+		{
+			name: "error getting latest Freight",
+			stage: &kargoapi.Stage{
+				Spec: kargoapi.StageSpec{
+					Subscriptions: kargoapi.Subscriptions{
+						Warehouse: "fake-warehouse",
+					},
+					PromotionMechanisms: &kargoapi.PromotionMechanisms{},
+				},
+				Status: kargoapi.StageStatus{
+					CurrentFreight: &kargoapi.FreightReference{},
+				},
+			},
+			reconciler: &reconciler{
+				hasNonTerminalPromotionsFn: noNonTerminalPromotionsFn,
+				appHealth:                  &mockAppHealthEvaluator{},
+				verifyFreightInStageFn: func(context.Context, string, string, string) (bool, error) {
 					return true, nil
 				},
+				getProjectFn: fakeProjectFn,
+				isAutoPromotionPermittedFn: func(
+					context.Context,
+					*kargoapi.Project,
+					string,
+				) bool {
+					return true
+				},
+				getAutoPromotionThrottleFn: func(
+					context.Context,
+					*kargoapi.Project,
+					*kargoapi.Stage,
+				) *time.Time {
+					return nil
+				},
 				getFreightFn: func(
 					context.Context,
 					client.Client,
@@ -1175,6 +1467,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				initialStatus kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				// Verification should be done before auto-promotion
@@ -1223,12 +1516,20 @@ func TestSyncNormalStage(t *testing.T) {
 				verifyFreightInStageFn: func(context.Context, string, string, string) (bool, error) {
 					return true, nil
 				},
+				getProjectFn: fakeProjectFn,
 				isAutoPromotionPermittedFn: func(
 					context.Context,
+					*kargoapi.Project,
 					string,
-					string,
-				) (bool, error) {
-					return true, nil
+				) bool {
+					return true
+				},
+				getAutoPromotionThrottleFn: func(
+					context.Context,
+					*kargoapi.Project,
+					*kargoapi.Stage,
+				) *time.Time {
+					return nil
 				},
 				getLatestAvailableFreightFn: func(
 					context.Context,
@@ -1243,6 +1544,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				initialStatus kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.NoError(t, err)
@@ -1275,12 +1577,20 @@ func TestSyncNormalStage(t *testing.T) {
 				verifyFreightInStageFn: func(context.Context, string, string, string) (bool, error) {
 					return true, nil
 				},
+				getProjectFn: fakeProjectFn,
 				isAutoPromotionPermittedFn: func(
 					context.Context,
+					*kargoapi.Project,
 					string,
-					string,
-				) (bool, error) {
-					return true, nil
+				) bool {
+					return true
+				},
+				getAutoPromotionThrottleFn: func(
+					context.Context,
+					*kargoapi.Project,
+					*kargoapi.Stage,
+				) *time.Time {
+					return nil
 				},
 				getFreightFn: func(
 					context.Context,
@@ -1306,6 +1616,7 @@ func TestSyncNormalStage(t *testing.T) {
 				_ *fakeevent.EventRecorder,
 				initialStatus kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.NoError(t, err)
@@ -1333,12 +1644,20 @@ func TestSyncNormalStage(t *testing.T) {
 				verifyFreightInStageFn: func(context.Context, string, string, string) (bool, error) {
 					return true, nil
 				},
+				getProjectFn: fakeProjectFn,
 				isAutoPromotionPermittedFn: func(
 					context.Context,
+					*kargoapi.Project,
 					string,
-					string,
-				) (bool, error) {
-					return true, nil
+				) bool {
+					return true
+				},
+				getAutoPromotionThrottleFn: func(
+					context.Context,
+					*kargoapi.Project,
+					*kargoapi.Stage,
+				) *time.Time {
+					return nil
 				},
 				getFreightFn: func(
 					context.Context,
@@ -1378,6 +1697,7 @@ func TestSyncNormalStage(t *testing.T) {
 				_ *fakeevent.EventRecorder,
 				initialStatus kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.NoError(t, err)
@@ -1405,12 +1725,20 @@ func TestSyncNormalStage(t *testing.T) {
 				verifyFreightInStageFn: func(context.Context, string, string, string) (bool, error) {
 					return true, nil
 				},
+				getProjectFn: fakeProjectFn,
 				isAutoPromotionPermittedFn: func(
 					context.Context,
+					*kargoapi.Project,
 					string,
-					string,
-				) (bool, error) {
-					return true, nil
+				) bool {
+					return true
+				},
+				getAutoPromotionThrottleFn: func(
+					context.Context,
+					*kargoapi.Project,
+					*kargoapi.Stage,
+				) *time.Time {
+					return nil
 				},
 				getFreightFn: func(
 					context.Context,
@@ -1454,6 +1782,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				initialStatus kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				// Verification should be done before promotion
@@ -1529,12 +1858,13 @@ func TestSyncNormalStage(t *testing.T) {
 					// No updates are performed
 					return false, nil
 				},
+				getProjectFn: fakeProjectFn,
 				isAutoPromotionPermittedFn: func(
 					context.Context,
+					*kargoapi.Project,
 					string,
-					string,
-				) (bool, error) {
-					return false, nil
+				) bool {
+					return false
 				},
 				getFreightFn: func(
 					context.Context,
@@ -1583,6 +1913,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				_ kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.NoError(t, err)
@@ -1673,12 +2004,20 @@ func TestSyncNormalStage(t *testing.T) {
 				verifyFreightInStageFn: func(context.Context, string, string, string) (bool, error) {
 					return true, nil
 				},
+				getProjectFn: fakeProjectFn,
 				isAutoPromotionPermittedFn: func(
 					context.Context,
+					*kargoapi.Project,
 					string,
-					string,
-				) (bool, error) {
-					return true, nil
+				) bool {
+					return true
+				},
+				getAutoPromotionThrottleFn: func(
+					context.Context,
+					*kargoapi.Project,
+					*kargoapi.Stage,
+				) *time.Time {
+					return nil
 				},
 				getFreightFn: func(
 					context.Context,
@@ -1722,6 +2061,7 @@ func TestSyncNormalStage(t *testing.T) {
 				recorder *fakeevent.EventRecorder,
 				_ kargoapi.StageStatus,
 				newStatus kargoapi.StageStatus,
+				_ *time.Time,
 				err error,
 			) {
 				require.NoError(t, err)
@@ -1768,11 +2108,23 @@ func TestSyncNormalStage(t *testing.T) {
 			recorder := fakeevent.NewEventRecorder(2)
 			testCase.reconciler.nowFn = fakeNow
 			testCase.reconciler.recorder = recorder
-			newStatus, err := testCase.reconciler.syncNormalStage(
+			if testCase.reconciler.getFreightFn == nil {
+				// Most test cases don't care about Freight, but the availability
+				// of the artifacts of Freight in the Stage's history is always
+				// checked
+				testCase.reconciler.getFreightFn = func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Freight, error) {
+					return nil, nil
+				}
+			}
+			newStatus, nextAutoPromotion, err := testCase.reconciler.syncNormalStage(
 				context.Background(),
 				testCase.stage,
 			)
-			testCase.assertions(t, recorder, testCase.stage.Status, newStatus, err)
+			testCase.assertions(t, recorder, testCase.stage.Status, newStatus, nextAutoPromotion, err)
 		})
 	}
 }
@@ -1780,6 +2132,7 @@ func TestSyncNormalStage(t *testing.T) {
 func TestSyncStageDelete(t *testing.T) {
 	testCases := []struct {
 		name       string
+		stage      *kargoapi.Stage
 		reconciler *reconciler
 		assertions func(
 			t *testing.T,
@@ -1835,6 +2188,31 @@ func TestSyncStageDelete(t *testing.T) {
 				require.Equal(t, initialStatus, newStatus)
 			},
 		},
+		{
+			name: "error clearing image pull Secrets",
+			stage: &kargoapi.Stage{
+				Status: kargoapi.StageStatus{
+					ImagePullSecrets: []kargoapi.ImagePullSecret{{
+						Namespace: "fake-namespace",
+						Name:      "fake-secret",
+					}},
+				},
+			},
+			reconciler: &reconciler{
+				clearVerificationsFn: func(context.Context, *kargoapi.Stage) error { return nil },
+				clearApprovalsFn:     func(context.Context, *kargoapi.Stage) error { return nil },
+				clearAnalysisRunsFn:  func(context.Context, *kargoapi.Stage) error { return nil },
+				clearImagePullSecretsFn: func(context.Context, *kargoapi.Stage) error {
+					return errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, initialStatus, newStatus kargoapi.StageStatus, err error) {
+				require.ErrorContains(t, err, "error clearing image pull Secrets for Stage")
+				require.ErrorContains(t, err, "something went wrong")
+				// Status should be returned unchanged
+				require.Equal(t, initialStatus, newStatus)
+			},
+		},
 		{
 			name: "success",
 			reconciler: &reconciler{
@@ -1855,11 +2233,11 @@ func TestSyncStageDelete(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			testStage := &kargoapi.Stage{
-				ObjectMeta: metav1.ObjectMeta{
-					Finalizers: []string{kargoapi.FinalizerName},
-				},
+			testStage := &kargoapi.Stage{}
+			if testCase.stage != nil {
+				testStage = testCase.stage
 			}
+			testStage.Finalizers = []string{kargoapi.FinalizerName}
 			newStatus, err := testCase.reconciler.syncStageDelete(context.Background(), testStage)
 			testCase.assertions(t, testStage.Status, newStatus, err)
 		})
@@ -2056,6 +2434,165 @@ func TestClearApprovals(t *testing.T) {
 	}
 }
 
+func TestExpireApprovals(t *testing.T) {
+	const testStage = "fake-stage"
+	approvedAt := fakeTime.Add(-time.Hour)
+	newFreight := func(name string, approval kargoapi.ApprovedStage) kargoapi.Freight {
+		return kargoapi.Freight{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: kargoapi.FreightStatus{
+				ApprovedFor: map[string]kargoapi.ApprovedStage{testStage: approval},
+			},
+		}
+	}
+	expired := newFreight("expired", kargoapi.ApprovedStage{
+		ApprovedAt: &metav1.Time{Time: approvedAt},
+		ExpiresAt:  &metav1.Time{Time: fakeTime.Add(-time.Minute)},
+	})
+	expiringSoon := newFreight("expiring-soon", kargoapi.ApprovedStage{
+		ApprovedAt: &metav1.Time{Time: approvedAt},
+		ExpiresAt:  &metav1.Time{Time: fakeTime.Add(time.Minute)},
+	})
+	expiringLater := newFreight("expiring-later", kargoapi.ApprovedStage{
+		ApprovedAt: &metav1.Time{Time: approvedAt},
+		ExpiresAt:  &metav1.Time{Time: fakeTime.Add(time.Hour)},
+	})
+	neverExpiring := newFreight("never-expiring", kargoapi.ApprovedStage{
+		ApprovedAt: &metav1.Time{Time: approvedAt},
+	})
+	promoted := newFreight("promoted", kargoapi.ApprovedStage{
+		ApprovedAt: &metav1.Time{Time: approvedAt},
+		ExpiresAt:  &metav1.Time{Time: fakeTime.Add(-time.Minute)},
+	})
+	promoted.Status.PromotedTo = map[string]kargoapi.PromotedStage{
+		testStage: {PromotedAt: &metav1.Time{Time: approvedAt.Add(time.Minute)}},
+	}
+	promotedBeforeApproval := newFreight("promoted-before-approval", kargoapi.ApprovedStage{
+		ApprovedAt: &metav1.Time{Time: approvedAt},
+		ExpiresAt:  &metav1.Time{Time: fakeTime.Add(-time.Minute)},
+	})
+	promotedBeforeApproval.Status.PromotedTo = map[string]kargoapi.PromotedStage{
+		testStage: {PromotedAt: &metav1.Time{Time: approvedAt.Add(-time.Minute)}},
+	}
+
+	testCases := []struct {
+		name       string
+		freight    []kargoapi.Freight
+		listErr    error
+		patchErr   error
+		assertions func(
+			*testing.T,
+			*fakeevent.EventRecorder,
+			map[string]kargoapi.FreightStatus,
+			*time.Time,
+			error,
+		)
+	}{
+		{
+			name:    "error listing approved Freight",
+			listErr: errors.New("something went wrong"),
+			assertions: func(
+				t *testing.T,
+				_ *fakeevent.EventRecorder,
+				_ map[string]kargoapi.FreightStatus,
+				_ *time.Time,
+				err error,
+			) {
+				require.ErrorContains(t, err, "error listing Freight approved for Stage")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name:     "error patching Freight status",
+			freight:  []kargoapi.Freight{expired},
+			patchErr: errors.New("something went wrong"),
+			assertions: func(
+				t *testing.T,
+				_ *fakeevent.EventRecorder,
+				_ map[string]kargoapi.FreightStatus,
+				_ *time.Time,
+				err error,
+			) {
+				require.ErrorContains(t, err, "error patching status of Freight")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "success",
+			freight: []kargoapi.Freight{
+				expired,
+				expiringLater,
+				expiringSoon,
+				neverExpiring,
+				promoted,
+				promotedBeforeApproval,
+			},
+			assertions: func(
+				t *testing.T,
+				recorder *fakeevent.EventRecorder,
+				patched map[string]kargoapi.FreightStatus,
+				nextExpiry *time.Time,
+				err error,
+			) {
+				require.NoError(t, err)
+				require.Len(t, patched, 3)
+				// Expired approvals are revoked
+				require.NotContains(t, patched["expired"].ApprovedFor, testStage)
+				require.NotContains(t, patched["promoted-before-approval"].ApprovedFor, testStage)
+				// Approvals of promoted Freight no longer expire
+				approval, ok := patched["promoted"].ApprovedFor[testStage]
+				require.True(t, ok)
+				require.Nil(t, approval.ExpiresAt)
+				require.NotNil(t, approval.ApprovedAt)
+				// The soonest expiration of the remaining approvals is returned
+				require.NotNil(t, nextExpiry)
+				require.Equal(t, fakeTime.Add(time.Minute), *nextExpiry)
+				require.Len(t, recorder.Events, 2)
+				event := <-recorder.Events
+				require.Equal(t, kargoapi.EventReasonFreightApprovalExpired, event.Reason)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			recorder := fakeevent.NewEventRecorder(10)
+			patched := map[string]kargoapi.FreightStatus{}
+			r := &reconciler{
+				recorder: recorder,
+				nowFn:    func() time.Time { return fakeTime },
+				listFreightFn: func(
+					_ context.Context,
+					objList client.ObjectList,
+					_ ...client.ListOption,
+				) error {
+					freight, ok := objList.(*kargoapi.FreightList)
+					require.True(t, ok)
+					for _, f := range testCase.freight {
+						freight.Items = append(freight.Items, *f.DeepCopy())
+					}
+					return testCase.listErr
+				},
+				patchFreightStatusFn: func(
+					_ context.Context,
+					freight *kargoapi.Freight,
+					status kargoapi.FreightStatus,
+				) error {
+					if testCase.patchErr != nil {
+						return testCase.patchErr
+					}
+					patched[freight.Name] = status
+					return nil
+				},
+			}
+			nextExpiry, err := r.expireApprovals(
+				context.Background(),
+				&kargoapi.Stage{ObjectMeta: metav1.ObjectMeta{Name: testStage}},
+			)
+			testCase.assertions(t, recorder, patched, nextExpiry, err)
+		})
+	}
+}
+
 func TestHasNonTerminalPromotions(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -2238,6 +2775,7 @@ func TestVerifyFreightInStage(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
+			testCase.reconciler.nowFn = time.Now
 			updated, err := testCase.reconciler.verifyFreightInStage(
 				context.Background(),
 				"fake-namespace",
@@ -2252,106 +2790,194 @@ func TestVerifyFreightInStage(t *testing.T) {
 func TestIsAutoPromotionPermitted(t *testing.T) {
 	testCases := []struct {
 		name       string
-		reconciler *reconciler
-		assertions func(*testing.T, bool, error)
+		project    *kargoapi.Project
+		assertions func(*testing.T, bool)
 	}{
 		{
-			name: "error getting Project",
-			reconciler: &reconciler{
-				getProjectFn: func(
-					context.Context,
-					client.Client,
-					string,
-				) (*kargoapi.Project, error) {
-					return nil, errors.New("something went wrong")
-				},
-			},
-			assertions: func(t *testing.T, allowed bool, err error) {
-				require.ErrorContains(t, err, "something went wrong")
-				require.ErrorContains(t, err, "error finding Project")
-				require.False(t, allowed)
+			name:    "defaults to not permitted",
+			project: &kargoapi.Project{},
+			assertions: func(t *testing.T, result bool) {
+				require.False(t, result)
 			},
 		},
 		{
-			name: "no Project found",
-			reconciler: &reconciler{
-				getProjectFn: func(
-					context.Context,
-					client.Client,
-					string,
-				) (*kargoapi.Project, error) {
-					return nil, nil
+			name: "explicitly not permitted",
+			project: &kargoapi.Project{
+				Spec: &kargoapi.ProjectSpec{
+					PromotionPolicies: []kargoapi.PromotionPolicy{
+						{
+							Stage:                "fake-stage",
+							AutoPromotionEnabled: false,
+						},
+					},
 				},
 			},
-			assertions: func(t *testing.T, allowed bool, err error) {
-				require.ErrorContains(t, err, "Project")
-				require.ErrorContains(t, err, "not found")
-				require.False(t, allowed)
+			assertions: func(t *testing.T, result bool) {
+				require.False(t, result)
 			},
 		},
 		{
-			name: "defaults to not permitted",
-			reconciler: &reconciler{
-				getProjectFn: func(_ context.Context, _ client.Client, _ string) (*kargoapi.Project, error) {
-					return &kargoapi.Project{}, nil
+			name: "paused by maintenance mode",
+			project: &kargoapi.Project{
+				Spec: &kargoapi.ProjectSpec{
+					PromotionPolicies: []kargoapi.PromotionPolicy{
+						{
+							Stage:                "fake-stage",
+							AutoPromotionEnabled: true,
+						},
+					},
+					MaintenanceMode: &kargoapi.MaintenanceMode{Enabled: true},
 				},
 			},
-			assertions: func(t *testing.T, result bool, err error) {
-				require.NoError(t, err)
+			assertions: func(t *testing.T, result bool) {
 				require.False(t, result)
 			},
 		},
 		{
-			name: "explicitly not permitted",
-			reconciler: &reconciler{
-				getProjectFn: func(_ context.Context, _ client.Client, _ string) (*kargoapi.Project, error) {
-					return &kargoapi.Project{
-						Spec: &kargoapi.ProjectSpec{
-							PromotionPolicies: []kargoapi.PromotionPolicy{
-								{
-									Stage:                "fake-stage",
-									AutoPromotionEnabled: false,
-								},
-							},
+			name: "permitted after maintenance mode has ended",
+			project: &kargoapi.Project{
+				Spec: &kargoapi.ProjectSpec{
+					PromotionPolicies: []kargoapi.PromotionPolicy{
+						{
+							Stage:                "fake-stage",
+							AutoPromotionEnabled: true,
 						},
-					}, nil
+					},
+					MaintenanceMode: &kargoapi.MaintenanceMode{
+						Enabled: true,
+						EndTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+					},
 				},
 			},
-			assertions: func(t *testing.T, result bool, err error) {
-				require.NoError(t, err)
-				require.False(t, result)
+			assertions: func(t *testing.T, result bool) {
+				require.True(t, result)
 			},
 		},
 		{
 			name: "permitted",
-			reconciler: &reconciler{
-				getProjectFn: func(_ context.Context, _ client.Client, _ string) (*kargoapi.Project, error) {
-					return &kargoapi.Project{
-						Spec: &kargoapi.ProjectSpec{
-							PromotionPolicies: []kargoapi.PromotionPolicy{
-								{
-									Stage:                "fake-stage",
-									AutoPromotionEnabled: true,
-								},
-							},
+			project: &kargoapi.Project{
+				Spec: &kargoapi.ProjectSpec{
+					PromotionPolicies: []kargoapi.PromotionPolicy{
+						{
+							Stage:                "fake-stage",
+							AutoPromotionEnabled: true,
 						},
-					}, nil
+					},
 				},
 			},
-			assertions: func(t *testing.T, result bool, err error) {
-				require.NoError(t, err)
+			assertions: func(t *testing.T, result bool) {
 				require.True(t, result)
 			},
 		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			res, err := testCase.reconciler.isAutoPromotionPermitted(
+			r := &reconciler{nowFn: time.Now}
+			res := r.isAutoPromotionPermitted(
 				context.Background(),
-				"fake-namespace",
+				testCase.project,
 				"fake-stage",
 			)
-			testCase.assertions(t, res, err)
+			testCase.assertions(t, res)
+		})
+	}
+}
+
+func TestGetAutoPromotionThrottle(t *testing.T) {
+	newProject := func(minInterval *metav1.Duration) *kargoapi.Project {
+		return &kargoapi.Project{
+			Spec: &kargoapi.ProjectSpec{
+				PromotionPolicies: []kargoapi.PromotionPolicy{
+					{
+						Stage:                    "fake-stage",
+						AutoPromotionEnabled:     true,
+						AutoPromotionMinInterval: minInterval,
+					},
+				},
+			},
+		}
+	}
+	newStage := func(status kargoapi.StageStatus) *kargoapi.Stage {
+		return &kargoapi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "fake-namespace",
+				Name:      "fake-stage",
+			},
+			Status: status,
+		}
+	}
+	testCases := []struct {
+		name       string
+		project    *kargoapi.Project
+		stage      *kargoapi.Stage
+		assertions func(*testing.T, *time.Time)
+	}{
+		{
+			name:    "no minimum interval",
+			project: newProject(nil),
+			stage: newStage(kargoapi.StageStatus{
+				CurrentPromotion: &kargoapi.PromotionInfo{Name: "fake-promotion"},
+			}),
+			assertions: func(t *testing.T, throttledUntil *time.Time) {
+				require.Nil(t, throttledUntil)
+			},
+		},
+		{
+			name:    "Promotion in progress",
+			project: newProject(&metav1.Duration{Duration: 30 * time.Minute}),
+			stage: newStage(kargoapi.StageStatus{
+				CurrentPromotion: &kargoapi.PromotionInfo{Name: "fake-promotion"},
+			}),
+			assertions: func(t *testing.T, throttledUntil *time.Time) {
+				require.Equal(t, ptr.To(fakeTime.Add(30*time.Minute)), throttledUntil)
+			},
+		},
+		{
+			name:    "no previous Promotion",
+			project: newProject(&metav1.Duration{Duration: 30 * time.Minute}),
+			stage:   newStage(kargoapi.StageStatus{}),
+			assertions: func(t *testing.T, throttledUntil *time.Time) {
+				require.Nil(t, throttledUntil)
+			},
+		},
+		{
+			name:    "minimum interval not elapsed",
+			project: newProject(&metav1.Duration{Duration: 30 * time.Minute}),
+			stage: newStage(kargoapi.StageStatus{
+				LastPromotion: &kargoapi.PromotionInfo{
+					Name:       "fake-promotion",
+					FinishedAt: ptr.To(metav1.NewTime(fakeTime.Add(-10 * time.Minute))),
+				},
+			}),
+			assertions: func(t *testing.T, throttledUntil *time.Time) {
+				require.Equal(t, ptr.To(fakeTime.Add(20*time.Minute)), throttledUntil)
+			},
+		},
+		{
+			name:    "minimum interval elapsed",
+			project: newProject(&metav1.Duration{Duration: 30 * time.Minute}),
+			stage: newStage(kargoapi.StageStatus{
+				LastPromotion: &kargoapi.PromotionInfo{
+					Name:       "fake-promotion",
+					FinishedAt: ptr.To(metav1.NewTime(fakeTime.Add(-time.Hour))),
+				},
+			}),
+			assertions: func(t *testing.T, throttledUntil *time.Time) {
+				require.Nil(t, throttledUntil)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := &reconciler{
+				nowFn: func() time.Time {
+					return fakeTime
+				},
+			}
+			testCase.assertions(
+				t,
+				r.getAutoPromotionThrottle(context.Background(), testCase.project, testCase.stage),
+			)
 		})
 	}
 }
@@ -2374,6 +3000,7 @@ func TestGetLatestAvailableFreight(t *testing.T) {
 					context.Context,
 					string,
 					string,
+					string,
 				) (*kargoapi.Freight, error) {
 					return nil, errors.New("something went wrong")
 				},
@@ -2393,6 +3020,7 @@ func TestGetLatestAvailableFreight(t *testing.T) {
 					context.Context,
 					string,
 					string,
+					string,
 				) (*kargoapi.Freight, error) {
 					return nil, nil
 				},
@@ -2412,6 +3040,7 @@ func TestGetLatestAvailableFreight(t *testing.T) {
 					context.Context,
 					string,
 					string,
+					string,
 				) (*kargoapi.Freight, error) {
 					return &kargoapi.Freight{}, nil
 				},
@@ -2645,9 +3274,67 @@ func TestGetLatestAvailableFreight(t *testing.T) {
 	}
 }
 
+func TestChangesConsumedArtifacts(t *testing.T) {
+	current := kargoapi.FreightReference{
+		Commits: []kargoapi.GitCommit{{RepoURL: "fake-repo", ID: "abc"}},
+		Images: []kargoapi.Image{
+			{RepoURL: "fake-image-1", Tag: "v1.0.0"},
+			{RepoURL: "fake-image-2", Tag: "v1.0.0"},
+		},
+		Charts: []kargoapi.Chart{{RepoURL: "fake-chart-repo", Name: "fake-chart", Version: "1.0.0"}},
+	}
+	chartOnly := &kargoapi.Freight{
+		Commits: current.Commits,
+		// Same images, in a different order
+		Images: []kargoapi.Image{current.Images[1], current.Images[0]},
+		Charts: []kargoapi.Chart{{RepoURL: "fake-chart-repo", Name: "fake-chart", Version: "1.1.0"}},
+	}
+	testCases := []struct {
+		name     string
+		kinds    []kargoapi.ArtifactKind
+		freight  *kargoapi.Freight
+		expected bool
+	}{
+		{
+			name:     "all kinds consumed",
+			freight:  &kargoapi.Freight{Commits: current.Commits, Images: current.Images, Charts: current.Charts},
+			expected: true,
+		},
+		{
+			name:    "changed kind not consumed",
+			kinds:   []kargoapi.ArtifactKind{kargoapi.ArtifactKindGit, kargoapi.ArtifactKindImage},
+			freight: chartOnly,
+		},
+		{
+			name:     "changed kind consumed",
+			kinds:    []kargoapi.ArtifactKind{kargoapi.ArtifactKindImage, kargoapi.ArtifactKindChart},
+			freight:  chartOnly,
+			expected: true,
+		},
+		{
+			name:  "artifact of consumed kind added",
+			kinds: []kargoapi.ArtifactKind{kargoapi.ArtifactKindPackage},
+			freight: &kargoapi.Freight{
+				Packages: []kargoapi.Package{{Type: kargoapi.PackageTypeNPM, Name: "fake-package", Version: "1.0.0"}},
+			},
+			expected: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				changesConsumedArtifacts(testCase.kinds, current, testCase.freight),
+			)
+		})
+	}
+}
+
 func TestGetLatestFreightFromWarehouse(t *testing.T) {
 	testCases := []struct {
 		name       string
+		service    string
 		reconciler *reconciler
 		assertions func(*testing.T, *kargoapi.Freight, error)
 	}{
@@ -2720,6 +3407,113 @@ func TestGetLatestFreightFromWarehouse(t *testing.T) {
 				require.Equal(t, "newer-freight", freight.Name)
 			},
 		},
+		{
+			name: "latest Freight is blocked",
+			reconciler: &reconciler{
+				listFreightFn: func(
+					_ context.Context,
+					objList client.ObjectList,
+					_ ...client.ListOption,
+				) error {
+					freight, ok := objList.(*kargoapi.FreightList)
+					require.True(t, ok)
+					freight.Items = []kargoapi.Freight{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "newer-freight",
+								CreationTimestamp: metav1.Time{
+									Time: time.Now(),
+								},
+							},
+							Status: kargoapi.FreightStatus{
+								Blocked: &kargoapi.FreightBlock{Reason: "bad release"},
+							},
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "older-freight",
+								CreationTimestamp: metav1.Time{
+									Time: time.Now().Add(-time.Hour),
+								},
+							},
+						},
+					}
+					return nil
+				},
+			},
+			assertions: func(t *testing.T, freight *kargoapi.Freight, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, freight)
+				// Be sure the blocked Freight was skipped
+				require.Equal(t, "older-freight", freight.Name)
+			},
+		},
+		{
+			name:    "success getting latest Freight for service",
+			service: "fake-service",
+			reconciler: &reconciler{
+				listFreightFn: func(
+					_ context.Context,
+					objList client.ObjectList,
+					_ ...client.ListOption,
+				) error {
+					freight, ok := objList.(*kargoapi.FreightList)
+					require.True(t, ok)
+					freight.Items = []kargoapi.Freight{
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "newer-freight",
+								CreationTimestamp: metav1.Time{
+									Time: time.Now(),
+								},
+							},
+							Service: "another-fake-service",
+						},
+						{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: "older-freight",
+								CreationTimestamp: metav1.Time{
+									Time: time.Now().Add(-time.Hour),
+								},
+							},
+							Service: "fake-service",
+						},
+					}
+					return nil
+				},
+			},
+			assertions: func(t *testing.T, freight *kargoapi.Freight, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, freight)
+				// Be sure Freight for other services was skipped
+				require.Equal(t, "older-freight", freight.Name)
+			},
+		},
+		{
+			name:    "found no Freight for service",
+			service: "fake-service",
+			reconciler: &reconciler{
+				listFreightFn: func(
+					_ context.Context,
+					objList client.ObjectList,
+					_ ...client.ListOption,
+				) error {
+					freight, ok := objList.(*kargoapi.FreightList)
+					require.True(t, ok)
+					freight.Items = []kargoapi.Freight{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "fake-freight"},
+							Service:    "another-fake-service",
+						},
+					}
+					return nil
+				},
+			},
+			assertions: func(t *testing.T, freight *kargoapi.Freight, err error) {
+				require.NoError(t, err)
+				require.Nil(t, freight)
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -2727,6 +3521,7 @@ func TestGetLatestFreightFromWarehouse(t *testing.T) {
 				context.Background(),
 				"fake-namespace",
 				"fake-warehouse",
+				testCase.service,
 			)
 			testCase.assertions(t, freight, err)
 		})
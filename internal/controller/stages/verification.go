@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/oklog/ulid/v2"
@@ -350,6 +351,32 @@ func (r *reconciler) abortVerification(
 	return newInfo
 }
 
+// isAutoQualified returns true if the provided optional Verification permits
+// Freight to be marked as verified in a Stage without having been successfully
+// verified, given the provided VerificationInfo and the current time. If no
+// AutoQualifyAfter duration is specified, this is always the case. Otherwise,
+// it is only the case if that duration has elapsed since verification started
+// and verification has not failed by then.
+func isAutoQualified(
+	ver *kargoapi.Verification,
+	info *kargoapi.VerificationInfo,
+	now time.Time,
+) bool {
+	if !ver.IsOptional() {
+		return false
+	}
+	if ver.AutoQualifyAfter == nil {
+		return true
+	}
+	if info == nil || info.StartTime == nil {
+		return false
+	}
+	if info.Phase.IsTerminal() && info.Phase != kargoapi.VerificationPhaseSuccessful {
+		return false
+	}
+	return !now.Before(info.StartTime.Add(ver.AutoQualifyAfter.Duration))
+}
+
 func (r *reconciler) buildAnalysisRun(
 	stage *kargoapi.Stage,
 	freight *kargoapi.Freight,
@@ -414,9 +441,15 @@ func (r *reconciler) buildAnalysisRun(
 		return nil, fmt.Errorf("error flattening templates: %w", err)
 	}
 
+	// Evaluate any expressions in the args from the Stage
+	args, err := evaluateVerificationArgs(stage, freight, stage.Spec.Verification.Args)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating arguments: %w", err)
+	}
+
 	// Merge the args from the template with the args from the Stage
-	rolloutsArgs := make([]rollouts.Argument, len(stage.Spec.Verification.Args))
-	for i, argument := range stage.Spec.Verification.Args {
+	rolloutsArgs := make([]rollouts.Argument, len(args))
+	for i, argument := range args {
 		arg := argument // Avoid implicit memory aliasing
 		rolloutsArgs[i] = rollouts.Argument{
 			Name:  arg.Name,
@@ -0,0 +1,195 @@
+package stages
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/expr-lang/expr"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/expressions"
+)
+
+// evaluateVerificationArgs returns a copy of the provided verification
+// arguments with any expressions in their values evaluated against the
+// provided Stage and the Freight that is being verified.
+//
+// Expressions have access to the following:
+//
+//   - ctx: an object with project and stage fields.
+//   - freight: the Freight being verified, with name, alias, warehouse,
+//     commits, images, charts, and packages fields.
+//   - outputs: the metadata recorded by the Stage's last Promotion, if any.
+//   - imageFrom(repoURL): the image from the specified repository.
+//   - commitFrom(repoURL): the commit from the specified Git repository.
+//   - chartFrom(repoURL[, name]): the chart from the specified repository.
+//   - packageFrom(name[, repoURL]): the package with the specified name.
+func evaluateVerificationArgs(
+	stage *kargoapi.Stage,
+	freight *kargoapi.Freight,
+	args []kargoapi.AnalysisRunArgument,
+) ([]kargoapi.AnalysisRunArgument, error) {
+	evaluated := make([]kargoapi.AnalysisRunArgument, len(args))
+	var env map[string]any
+	var exprOpts []expr.Option
+	for i, arg := range args {
+		evaluated[i] = arg
+		if !expressions.IsTemplate(arg.Value) {
+			continue
+		}
+		if env == nil {
+			var err error
+			if env, err = verificationArgsEnv(stage, freight); err != nil {
+				return nil, err
+			}
+			exprOpts = freightFunctions(freight)
+		}
+		value, err := expressions.EvaluateTemplateToString(arg.Value, env, exprOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating value of argument %q: %w", arg.Name, err)
+		}
+		evaluated[i].Value = value
+	}
+	return evaluated, nil
+}
+
+// verificationArgsEnv builds the environment against which expressions in
+// verification arguments are evaluated.
+func verificationArgsEnv(
+	stage *kargoapi.Stage,
+	freight *kargoapi.Freight,
+) (map[string]any, error) {
+	commits, err := toExprValue(freight.Commits)
+	if err != nil {
+		return nil, fmt.Errorf("error converting Freight commits: %w", err)
+	}
+	images, err := toExprValue(freight.Images)
+	if err != nil {
+		return nil, fmt.Errorf("error converting Freight images: %w", err)
+	}
+	charts, err := toExprValue(freight.Charts)
+	if err != nil {
+		return nil, fmt.Errorf("error converting Freight charts: %w", err)
+	}
+	packages, err := toExprValue(freight.Packages)
+	if err != nil {
+		return nil, fmt.Errorf("error converting Freight packages: %w", err)
+	}
+	outputs := map[string]any{}
+	if lastPromo := stage.Status.LastPromotion; lastPromo != nil && lastPromo.Status != nil {
+		for k, v := range lastPromo.Status.Metadata {
+			outputs[k] = v
+		}
+	}
+	return map[string]any{
+		"ctx": map[string]any{
+			"project": stage.Namespace,
+			"stage":   stage.Name,
+		},
+		"freight": map[string]any{
+			"name":      freight.Name,
+			"alias":     freight.Alias,
+			"warehouse": freight.Warehouse,
+			"commits":   commits,
+			"images":    images,
+			"charts":    charts,
+			"packages":  packages,
+		},
+		"outputs": outputs,
+	}, nil
+}
+
+// freightFunctions returns expression functions for looking up specific
+// artifacts in the provided Freight by repository URL.
+func freightFunctions(freight *kargoapi.Freight) []expr.Option {
+	return []expr.Option{
+		expr.Function(
+			"imageFrom",
+			func(params ...any) (any, error) {
+				repoURL := params[0].(string) // nolint: forcetypeassert
+				for _, image := range freight.Images {
+					if image.RepoURL == repoURL {
+						return toExprValue(image)
+					}
+				}
+				return nil, fmt.Errorf("no image from repository %q found in Freight", repoURL)
+			},
+			new(func(string) map[string]any),
+		),
+		expr.Function(
+			"commitFrom",
+			func(params ...any) (any, error) {
+				repoURL := params[0].(string) // nolint: forcetypeassert
+				for _, commit := range freight.Commits {
+					if commit.RepoURL == repoURL {
+						return toExprValue(commit)
+					}
+				}
+				return nil, fmt.Errorf("no commit from repository %q found in Freight", repoURL)
+			},
+			new(func(string) map[string]any),
+		),
+		expr.Function(
+			"chartFrom",
+			func(params ...any) (any, error) {
+				repoURL := params[0].(string) // nolint: forcetypeassert
+				var name string
+				if len(params) > 1 {
+					name = params[1].(string) // nolint: forcetypeassert
+				}
+				for _, chart := range freight.Charts {
+					if chart.RepoURL == repoURL && chart.Name == name {
+						return toExprValue(chart)
+					}
+				}
+				return nil, fmt.Errorf(
+					"no chart %q from repository %q found in Freight", name, repoURL,
+				)
+			},
+			new(func(string) map[string]any),
+			new(func(string, string) map[string]any),
+		),
+		expr.Function(
+			"packageFrom",
+			func(params ...any) (any, error) {
+				name := params[0].(string) // nolint: forcetypeassert
+				var repoURL string
+				if len(params) > 1 {
+					repoURL = params[1].(string) // nolint: forcetypeassert
+				}
+				for _, pkg := range freight.Packages {
+					if pkg.Name == name && (repoURL == "" || pkg.RepoURL == repoURL) {
+						return toExprValue(pkg)
+					}
+				}
+				if repoURL == "" {
+					return nil, fmt.Errorf("no package %q found in Freight", name)
+				}
+				return nil, fmt.Errorf(
+					"no package %q from registry %q found in Freight", name, repoURL,
+				)
+			},
+			new(func(string) map[string]any),
+			new(func(string, string) map[string]any),
+		),
+	}
+}
+
+// toExprValue converts the provided value into its generic JSON
+// representation so that its fields can be accessed by expressions using the
+// same names by which they are known in the Kargo API.
+func toExprValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var res any
+	if err = json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	if res == nil {
+		// Nil slices should be represented as empty lists
+		return []any{}, nil
+	}
+	return res, nil
+}
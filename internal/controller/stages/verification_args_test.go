@@ -0,0 +1,173 @@
+package stages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestEvaluateVerificationArgs(t *testing.T) {
+	stage := &kargoapi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-stage",
+			Namespace: "fake-namespace",
+		},
+		Status: kargoapi.StageStatus{
+			LastPromotion: &kargoapi.PromotionInfo{
+				Name: "fake-promotion",
+				Status: &kargoapi.PromotionStatus{
+					Metadata: map[string]string{
+						"fake-key": "fake-value",
+					},
+				},
+			},
+		},
+	}
+	freight := &kargoapi.Freight{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-freight",
+			Namespace: "fake-namespace",
+		},
+		Alias: "fake-alias",
+		Commits: []kargoapi.GitCommit{{
+			RepoURL: "https://github.com/example/repo.git",
+			ID:      "fake-commit",
+		}},
+		Images: []kargoapi.Image{{
+			RepoURL: "example/image",
+			Tag:     "v1.2.3",
+			Digest:  "sha256:fake-digest",
+		}},
+		Charts: []kargoapi.Chart{
+			{
+				RepoURL: "oci://example.com/charts/chart",
+				Version: "1.0.0",
+			},
+			{
+				RepoURL: "https://charts.example.com",
+				Name:    "other-chart",
+				Version: "2.0.0",
+			},
+		},
+		Packages: []kargoapi.Package{{
+			Type:    kargoapi.PackageTypeMaven,
+			RepoURL: "https://repo.maven.apache.org/maven2",
+			Name:    "com.example:lib",
+			Version: "3.0.0",
+		}},
+	}
+
+	testCases := []struct {
+		name       string
+		args       []kargoapi.AnalysisRunArgument
+		assertions func(*testing.T, []kargoapi.AnalysisRunArgument, error)
+	}{
+		{
+			name: "no expressions",
+			args: []kargoapi.AnalysisRunArgument{{
+				Name:  "plain",
+				Value: "plain-value",
+			}},
+			assertions: func(t *testing.T, args []kargoapi.AnalysisRunArgument, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]kargoapi.AnalysisRunArgument{{
+						Name:  "plain",
+						Value: "plain-value",
+					}},
+					args,
+				)
+			},
+		},
+		{
+			name: "expressions are evaluated",
+			args: []kargoapi.AnalysisRunArgument{
+				{
+					Name:  "context",
+					Value: "${{ ctx.project }}/${{ ctx.stage }}",
+				},
+				{
+					Name:  "freight",
+					Value: "${{ freight.name }} (${{ freight.alias }})",
+				},
+				{
+					Name:  "image",
+					Value: "${{ freight.images[0].repoURL }}:${{ imageFrom('example/image').tag }}",
+				},
+				{
+					Name:  "commit",
+					Value: "${{ commitFrom('https://github.com/example/repo.git').id }}",
+				},
+				{
+					Name:  "oci-chart",
+					Value: "${{ chartFrom('oci://example.com/charts/chart').version }}",
+				},
+				{
+					Name:  "chart",
+					Value: "${{ chartFrom('https://charts.example.com', 'other-chart').version }}",
+				},
+				{
+					Name:  "package",
+					Value: "${{ packageFrom('com.example:lib').version }}",
+				},
+				{
+					Name:  "output",
+					Value: "${{ outputs['fake-key'] }}",
+				},
+				{
+					Name:  "count",
+					Value: "${{ len(freight.charts) }}",
+				},
+			},
+			assertions: func(t *testing.T, args []kargoapi.AnalysisRunArgument, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]kargoapi.AnalysisRunArgument{
+						{Name: "context", Value: "fake-namespace/fake-stage"},
+						{Name: "freight", Value: "fake-freight (fake-alias)"},
+						{Name: "image", Value: "example/image:v1.2.3"},
+						{Name: "commit", Value: "fake-commit"},
+						{Name: "oci-chart", Value: "1.0.0"},
+						{Name: "chart", Value: "2.0.0"},
+						{Name: "package", Value: "3.0.0"},
+						{Name: "output", Value: "fake-value"},
+						{Name: "count", Value: "2"},
+					},
+					args,
+				)
+			},
+		},
+		{
+			name: "artifact not found",
+			args: []kargoapi.AnalysisRunArgument{{
+				Name:  "image",
+				Value: "${{ imageFrom('example/other-image').tag }}",
+			}},
+			assertions: func(t *testing.T, _ []kargoapi.AnalysisRunArgument, err error) {
+				require.ErrorContains(t, err, `error evaluating value of argument "image"`)
+				require.ErrorContains(t, err, `no image from repository "example/other-image"`)
+			},
+		},
+		{
+			name: "invalid expression",
+			args: []kargoapi.AnalysisRunArgument{{
+				Name:  "invalid",
+				Value: "${{ freight.( }}",
+			}},
+			assertions: func(t *testing.T, _ []kargoapi.AnalysisRunArgument, err error) {
+				require.ErrorContains(t, err, `error evaluating value of argument "invalid"`)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			args, err := evaluateVerificationArgs(stage, freight, testCase.args)
+			testCase.assertions(t, args, err)
+		})
+	}
+}
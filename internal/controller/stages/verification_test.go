@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -1670,3 +1671,79 @@ func TestMergeArgs(t *testing.T) {
 		require.Equal(t, "my-value", *args[0].Value)
 	}
 }
+
+func TestIsAutoQualified(t *testing.T) {
+	now := time.Date(2024, time.April, 10, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name     string
+		ver      *kargoapi.Verification
+		info     *kargoapi.VerificationInfo
+		expected bool
+	}{
+		{
+			name:     "verification is required",
+			ver:      &kargoapi.Verification{},
+			expected: false,
+		},
+		{
+			name: "verification is optional with no timeout",
+			ver: &kargoapi.Verification{
+				Policy: kargoapi.VerificationPolicyOptional,
+			},
+			expected: true,
+		},
+		{
+			name: "verification has not started",
+			ver: &kargoapi.Verification{
+				Policy:           kargoapi.VerificationPolicyOptional,
+				AutoQualifyAfter: &metav1.Duration{Duration: time.Minute},
+			},
+			expected: false,
+		},
+		{
+			name: "timeout has not elapsed",
+			ver: &kargoapi.Verification{
+				Policy:           kargoapi.VerificationPolicyOptional,
+				AutoQualifyAfter: &metav1.Duration{Duration: time.Hour},
+			},
+			info: &kargoapi.VerificationInfo{
+				StartTime: ptr.To(metav1.NewTime(now.Add(-time.Minute))),
+				Phase:     kargoapi.VerificationPhaseRunning,
+			},
+			expected: false,
+		},
+		{
+			name: "verification failed",
+			ver: &kargoapi.Verification{
+				Policy:           kargoapi.VerificationPolicyOptional,
+				AutoQualifyAfter: &metav1.Duration{Duration: time.Minute},
+			},
+			info: &kargoapi.VerificationInfo{
+				StartTime: ptr.To(metav1.NewTime(now.Add(-time.Hour))),
+				Phase:     kargoapi.VerificationPhaseFailed,
+			},
+			expected: false,
+		},
+		{
+			name: "timeout has elapsed",
+			ver: &kargoapi.Verification{
+				Policy:           kargoapi.VerificationPolicyOptional,
+				AutoQualifyAfter: &metav1.Duration{Duration: time.Minute},
+			},
+			info: &kargoapi.VerificationInfo{
+				StartTime: ptr.To(metav1.NewTime(now.Add(-time.Hour))),
+				Phase:     kargoapi.VerificationPhaseRunning,
+			},
+			expected: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				isAutoQualified(testCase.ver, testCase.info, now),
+			)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package warehouses
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/kubeclient"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// syncFreightAvailability determines whether the artifacts referenced by
+// Freight from the provided Warehouse that any Stage currently uses, or has
+// recently used, are still available upstream and reflects the result in the
+// ArtifactsUnavailable condition of each such Freight. When Freight is newly
+// found to reference unavailable artifacts, warning events are recorded for the
+// Freight and for every Stage using it, so that users learn a rollback to that
+// Freight is likely to fail before they need one.
+func (r *reconciler) syncFreightAvailability(
+	ctx context.Context,
+	warehouse *kargoapi.Warehouse,
+	discovered *kargoapi.DiscoveredArtifacts,
+) error {
+	logger := logging.LoggerFromContext(ctx)
+
+	stages := kargoapi.StageList{}
+	if err := r.client.List(ctx, &stages, client.InNamespace(warehouse.Namespace)); err != nil {
+		return fmt.Errorf("error listing Stages in namespace %q: %w", warehouse.Namespace, err)
+	}
+	stagesByFreight := map[string][]string{}
+	for _, stage := range stages.Items {
+		refs := stage.Status.History
+		if stage.Status.CurrentFreight != nil {
+			refs = append(refs, *stage.Status.CurrentFreight)
+		}
+		for _, ref := range refs {
+			if ref.Warehouse != warehouse.Name ||
+				slices.Contains(stagesByFreight[ref.Name], stage.Name) {
+				continue
+			}
+			stagesByFreight[ref.Name] = append(stagesByFreight[ref.Name], stage.Name)
+		}
+	}
+	freightNames := make([]string, 0, len(stagesByFreight))
+	for freightName := range stagesByFreight {
+		freightNames = append(freightNames, freightName)
+	}
+	sort.Strings(freightNames)
+
+	for _, freightName := range freightNames {
+		freightLogger := logger.WithField("freight", freightName)
+		freight, err := kargoapi.GetFreight(
+			ctx,
+			r.client,
+			types.NamespacedName{
+				Namespace: warehouse.Namespace,
+				Name:      freightName,
+			},
+		)
+		if err != nil {
+			return fmt.Errorf(
+				"error finding Freight %q in namespace %q: %w",
+				freightName,
+				warehouse.Namespace,
+				err,
+			)
+		}
+		if freight == nil {
+			continue
+		}
+
+		unavailable, err := r.findUnavailableArtifactsFn(ctx, warehouse, freight, discovered)
+		if err != nil {
+			// Don't let one unreachable repository prevent checking the rest of
+			// the Freight. The condition is left as it was.
+			freightLogger.Errorf("error checking availability of artifacts: %s", err)
+			continue
+		}
+
+		wasUnavailable := freight.HasUnavailableArtifacts()
+		newStatus := *freight.Status.DeepCopy()
+		var changed bool
+		if len(unavailable) == 0 {
+			changed = meta.RemoveStatusCondition(
+				&newStatus.Conditions,
+				kargoapi.FreightConditionTypeArtifactsUnavailable,
+			)
+		} else {
+			changed = meta.SetStatusCondition(&newStatus.Conditions, metav1.Condition{
+				Type:    kargoapi.FreightConditionTypeArtifactsUnavailable,
+				Status:  metav1.ConditionTrue,
+				Reason:  kargoapi.FreightConditionReasonArtifactsNotFound,
+				Message: strings.Join(unavailable, "; "),
+			})
+		}
+		if changed {
+			if err = kubeclient.PatchStatus(
+				ctx,
+				r.client,
+				freight,
+				func(status *kargoapi.FreightStatus) {
+					*status = newStatus
+				},
+			); err != nil {
+				return fmt.Errorf(
+					"error updating status of Freight %q in namespace %q: %w",
+					freight.Name,
+					freight.Namespace,
+					err,
+				)
+			}
+		}
+
+		if len(unavailable) > 0 && !wasUnavailable {
+			freightLogger.WithField("unavailable", unavailable).
+				Debug("Freight references artifacts that are no longer available")
+			r.recordArtifactsUnavailableEvents(freight, stagesByFreight[freightName], unavailable)
+		}
+	}
+
+	return nil
+}
+
+// recordArtifactsUnavailableEvents records warning events for the provided
+// Freight and for each of the named Stages that use it, indicating that the
+// Freight references artifacts that are no longer available upstream.
+func (r *reconciler) recordArtifactsUnavailableEvents(
+	freight *kargoapi.Freight,
+	stageNames []string,
+	unavailable []string,
+) {
+	actor := kargoapi.FormatEventControllerActor(r.controllerName)
+	r.recorder.AnnotatedEventf(
+		freight,
+		kargoapi.NewFreightEventAnnotations(actor, freight),
+		corev1.EventTypeWarning,
+		kargoapi.EventReasonFreightArtifactsUnavailable,
+		"Freight references artifacts that are no longer available: %s",
+		strings.Join(unavailable, "; "),
+	)
+	for _, stageName := range stageNames {
+		stage := &kargoapi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: freight.Namespace,
+				Name:      stageName,
+			},
+		}
+		annotations := kargoapi.NewFreightEventAnnotations(actor, freight)
+		annotations[kargoapi.AnnotationKeyEventStageName] = stageName
+		r.recorder.AnnotatedEventf(
+			stage,
+			annotations,
+			corev1.EventTypeWarning,
+			kargoapi.EventReasonFreightArtifactsUnavailable,
+			"Freight %q used by this Stage references artifacts that are no "+
+				"longer available; rolling back to it is likely to fail",
+			freight.Name,
+		)
+	}
+}
@@ -0,0 +1,246 @@
+package warehouses
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	fakeevent "github.com/akuity/kargo/internal/kubernetes/event/fake"
+)
+
+func TestSyncFreightAvailability(t *testing.T) {
+	const testNamespace = "fake-namespace"
+
+	warehouse := &kargoapi.Warehouse{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      "fake-warehouse",
+		},
+	}
+
+	newStage := func(name string, current string, history ...string) *kargoapi.Stage {
+		stage := &kargoapi.Stage{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      name,
+			},
+		}
+		if current != "" {
+			stage.Status.CurrentFreight = &kargoapi.FreightReference{
+				Name:      current,
+				Warehouse: warehouse.Name,
+			}
+		}
+		for _, freight := range history {
+			stage.Status.History = append(stage.Status.History, kargoapi.FreightReference{
+				Name:      freight,
+				Warehouse: warehouse.Name,
+			})
+		}
+		return stage
+	}
+
+	newFreight := func(name string, unavailable bool) *kargoapi.Freight {
+		freight := &kargoapi.Freight{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      name,
+			},
+		}
+		if unavailable {
+			freight.Status.Conditions = []metav1.Condition{{
+				Type:    kargoapi.FreightConditionTypeArtifactsUnavailable,
+				Status:  metav1.ConditionTrue,
+				Reason:  kargoapi.FreightConditionReasonArtifactsNotFound,
+				Message: "fake-artifact",
+			}}
+		}
+		return freight
+	}
+
+	testCases := []struct {
+		name                       string
+		objects                    []client.Object
+		findUnavailableArtifactsFn func(
+			context.Context,
+			*kargoapi.Warehouse,
+			*kargoapi.Freight,
+			*kargoapi.DiscoveredArtifacts,
+		) ([]string, error)
+		assertions func(*testing.T, client.Client, *fakeevent.EventRecorder, error)
+	}{
+		{
+			name: "Freight not used by any Stage is not checked",
+			objects: []client.Object{
+				newStage("test", ""),
+				newFreight("fake-freight", false),
+			},
+			findUnavailableArtifactsFn: func(
+				context.Context,
+				*kargoapi.Warehouse,
+				*kargoapi.Freight,
+				*kargoapi.DiscoveredArtifacts,
+			) ([]string, error) {
+				return nil, errors.New("should not be called")
+			},
+			assertions: func(t *testing.T, _ client.Client, recorder *fakeevent.EventRecorder, err error) {
+				require.NoError(t, err)
+				require.Empty(t, recorder.Events)
+			},
+		},
+		{
+			name: "error checking artifacts is not fatal",
+			objects: []client.Object{
+				newStage("test", "fake-freight"),
+				newFreight("fake-freight", true),
+			},
+			findUnavailableArtifactsFn: func(
+				context.Context,
+				*kargoapi.Warehouse,
+				*kargoapi.Freight,
+				*kargoapi.DiscoveredArtifacts,
+			) ([]string, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, c client.Client, recorder *fakeevent.EventRecorder, err error) {
+				require.NoError(t, err)
+				require.Empty(t, recorder.Events)
+				// The condition should have been left alone
+				freight := &kargoapi.Freight{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{
+					Namespace: testNamespace,
+					Name:      "fake-freight",
+				}, freight))
+				require.True(t, freight.HasUnavailableArtifacts())
+			},
+		},
+		{
+			name: "artifacts newly unavailable",
+			objects: []client.Object{
+				newStage("test", "new-freight", "new-freight", "fake-freight"),
+				newStage("uat", "fake-freight"),
+				newFreight("fake-freight", false),
+				newFreight("new-freight", false),
+			},
+			findUnavailableArtifactsFn: func(
+				_ context.Context,
+				_ *kargoapi.Warehouse,
+				freight *kargoapi.Freight,
+				_ *kargoapi.DiscoveredArtifacts,
+			) ([]string, error) {
+				if freight.Name == "fake-freight" {
+					return []string{"fake-artifact-1", "fake-artifact-2"}, nil
+				}
+				return nil, nil
+			},
+			assertions: func(t *testing.T, c client.Client, recorder *fakeevent.EventRecorder, err error) {
+				require.NoError(t, err)
+
+				freight := &kargoapi.Freight{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{
+					Namespace: testNamespace,
+					Name:      "fake-freight",
+				}, freight))
+				condition := meta.FindStatusCondition(
+					freight.Status.Conditions,
+					kargoapi.FreightConditionTypeArtifactsUnavailable,
+				)
+				require.NotNil(t, condition)
+				require.Equal(t, metav1.ConditionTrue, condition.Status)
+				require.Equal(t, "fake-artifact-1; fake-artifact-2", condition.Message)
+
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{
+					Namespace: testNamespace,
+					Name:      "new-freight",
+				}, freight))
+				require.False(t, freight.HasUnavailableArtifacts())
+
+				// One event for the Freight and one for each Stage using it
+				require.Len(t, recorder.Events, 3)
+				stages := map[string]struct{}{}
+				for range 3 {
+					event := <-recorder.Events
+					require.Equal(t, corev1.EventTypeWarning, event.EventType)
+					require.Equal(t, kargoapi.EventReasonFreightArtifactsUnavailable, event.Reason)
+					if stage, ok := event.Annotations[kargoapi.AnnotationKeyEventStageName]; ok {
+						stages[stage] = struct{}{}
+					}
+				}
+				require.Equal(t, map[string]struct{}{"test": {}, "uat": {}}, stages)
+			},
+		},
+		{
+			name: "artifacts still unavailable",
+			objects: []client.Object{
+				newStage("test", "fake-freight"),
+				newFreight("fake-freight", true),
+			},
+			findUnavailableArtifactsFn: func(
+				context.Context,
+				*kargoapi.Warehouse,
+				*kargoapi.Freight,
+				*kargoapi.DiscoveredArtifacts,
+			) ([]string, error) {
+				return []string{"fake-artifact"}, nil
+			},
+			assertions: func(t *testing.T, _ client.Client, recorder *fakeevent.EventRecorder, err error) {
+				require.NoError(t, err)
+				// Events are only recorded when artifacts first become unavailable
+				require.Empty(t, recorder.Events)
+			},
+		},
+		{
+			name: "artifacts available again",
+			objects: []client.Object{
+				newStage("test", "fake-freight"),
+				newFreight("fake-freight", true),
+			},
+			findUnavailableArtifactsFn: func(
+				context.Context,
+				*kargoapi.Warehouse,
+				*kargoapi.Freight,
+				*kargoapi.DiscoveredArtifacts,
+			) ([]string, error) {
+				return nil, nil
+			},
+			assertions: func(t *testing.T, c client.Client, recorder *fakeevent.EventRecorder, err error) {
+				require.NoError(t, err)
+				require.Empty(t, recorder.Events)
+				freight := &kargoapi.Freight{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{
+					Namespace: testNamespace,
+					Name:      "fake-freight",
+				}, freight))
+				require.Empty(t, freight.Status.Conditions)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			require.NoError(t, kargoapi.AddToScheme(scheme))
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(testCase.objects...).
+				WithStatusSubresource(&kargoapi.Freight{}).
+				Build()
+			recorder := fakeevent.NewEventRecorder(3)
+			r := &reconciler{
+				client:                     c,
+				recorder:                   recorder,
+				findUnavailableArtifactsFn: testCase.findUnavailableArtifactsFn,
+			}
+			err := r.syncFreightAvailability(context.Background(), warehouse, nil)
+			testCase.assertions(t, c, recorder, err)
+		})
+	}
+}
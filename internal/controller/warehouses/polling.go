@@ -0,0 +1,103 @@
+package warehouses
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+const (
+	// defaultPollInterval is the interval at which a Warehouse polls its
+	// subscriptions when its polling configuration does not specify one. In
+	// Adaptive mode, it is the longest interval.
+	defaultPollInterval = 5 * time.Minute
+	// defaultMinPollInterval is the shortest interval at which a Warehouse in
+	// Adaptive polling mode polls its subscriptions when its polling
+	// configuration does not specify one.
+	defaultMinPollInterval = time.Minute
+)
+
+// nextPollInterval returns the interval after which a Warehouse with the
+// provided polling configuration should next poll its subscriptions. In
+// Adaptive mode, the shortest interval is returned if new artifacts were just
+// discovered. Otherwise, the previous interval is doubled, within the bounds of
+// the configuration.
+func nextPollInterval(
+	polling *kargoapi.WarehousePolling,
+	previous *metav1.Duration,
+	discoveredNew bool,
+) time.Duration {
+	maxInterval := defaultPollInterval
+	if polling != nil && polling.Interval != nil && polling.Interval.Duration > 0 {
+		maxInterval = polling.Interval.Duration
+	}
+	if polling == nil || polling.Mode != kargoapi.WarehousePollingModeAdaptive {
+		return maxInterval
+	}
+
+	minInterval := defaultMinPollInterval
+	if polling.MinInterval != nil && polling.MinInterval.Duration > 0 {
+		minInterval = polling.MinInterval.Duration
+	}
+	minInterval = min(minInterval, maxInterval)
+
+	if discoveredNew {
+		return minInterval
+	}
+	if previous == nil {
+		return maxInterval
+	}
+	return min(max(2*previous.Duration, minInterval), maxInterval)
+}
+
+// discoveredNewArtifacts returns true if the latest artifact discovered for any
+// subscription differs from the latest artifact previously discovered for it.
+func discoveredNewArtifacts(previous, current *kargoapi.DiscoveredArtifacts) bool {
+	if current == nil {
+		return false
+	}
+	latest := latestArtifacts(previous)
+	for key, artifact := range latestArtifacts(current) {
+		if latest[key] != artifact {
+			return true
+		}
+	}
+	return false
+}
+
+// latestArtifacts returns an identifier of the latest artifact discovered for
+// each subscription in the provided DiscoveredArtifacts, indexed by a key
+// identifying the subscription. Subscriptions for which no artifacts were
+// discovered are omitted.
+func latestArtifacts(artifacts *kargoapi.DiscoveredArtifacts) map[string]string {
+	if artifacts == nil {
+		return nil
+	}
+	latest := make(
+		map[string]string,
+		len(artifacts.Git)+len(artifacts.Images)+len(artifacts.Charts)+len(artifacts.Packages),
+	)
+	for _, result := range artifacts.Git {
+		if len(result.Commits) > 0 {
+			latest["git:"+result.RepoURL+":"+result.Service+":"+result.Branch] = result.Commits[0].ID
+		}
+	}
+	for _, result := range artifacts.Images {
+		if len(result.References) > 0 {
+			latest["image:"+result.RepoURL+":"+result.Platform] = result.References[0].Digest
+		}
+	}
+	for _, result := range artifacts.Charts {
+		if len(result.Versions) > 0 {
+			latest["chart:"+result.RepoURL+":"+result.Name] = result.Versions[0]
+		}
+	}
+	for _, result := range artifacts.Packages {
+		if len(result.Versions) > 0 {
+			latest["package:"+string(result.Type)+":"+result.RepoURL+":"+result.Name] = result.Versions[0]
+		}
+	}
+	return latest
+}
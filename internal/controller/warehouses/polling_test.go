@@ -0,0 +1,193 @@
+package warehouses
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestNextPollInterval(t *testing.T) {
+	adaptive := &kargoapi.WarehousePolling{
+		Mode:        kargoapi.WarehousePollingModeAdaptive,
+		Interval:    &metav1.Duration{Duration: 10 * time.Minute},
+		MinInterval: &metav1.Duration{Duration: 30 * time.Second},
+	}
+	testCases := []struct {
+		name          string
+		polling       *kargoapi.WarehousePolling
+		previous      *metav1.Duration
+		discoveredNew bool
+		expected      time.Duration
+	}{
+		{
+			name:          "no polling configuration",
+			discoveredNew: true,
+			expected:      defaultPollInterval,
+		},
+		{
+			name: "fixed mode",
+			polling: &kargoapi.WarehousePolling{
+				Interval: &metav1.Duration{Duration: 2 * time.Minute},
+			},
+			previous:      &metav1.Duration{Duration: time.Minute},
+			discoveredNew: true,
+			expected:      2 * time.Minute,
+		},
+		{
+			name:          "adaptive mode with new artifacts",
+			polling:       adaptive,
+			previous:      &metav1.Duration{Duration: 8 * time.Minute},
+			discoveredNew: true,
+			expected:      30 * time.Second,
+		},
+		{
+			name:     "adaptive mode without previous interval",
+			polling:  adaptive,
+			expected: 10 * time.Minute,
+		},
+		{
+			name:     "adaptive mode backs off",
+			polling:  adaptive,
+			previous: &metav1.Duration{Duration: 30 * time.Second},
+			expected: time.Minute,
+		},
+		{
+			name:     "adaptive mode backs off no further than interval",
+			polling:  adaptive,
+			previous: &metav1.Duration{Duration: 8 * time.Minute},
+			expected: 10 * time.Minute,
+		},
+		{
+			name:     "adaptive mode after min interval was raised",
+			polling:  adaptive,
+			previous: &metav1.Duration{Duration: 10 * time.Second},
+			expected: 30 * time.Second,
+		},
+		{
+			name: "adaptive mode with defaults",
+			polling: &kargoapi.WarehousePolling{
+				Mode: kargoapi.WarehousePollingModeAdaptive,
+			},
+			discoveredNew: true,
+			expected:      defaultMinPollInterval,
+		},
+		{
+			name: "adaptive mode with min interval exceeding interval",
+			polling: &kargoapi.WarehousePolling{
+				Mode:        kargoapi.WarehousePollingModeAdaptive,
+				MinInterval: &metav1.Duration{Duration: time.Hour},
+			},
+			discoveredNew: true,
+			expected:      defaultPollInterval,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				nextPollInterval(testCase.polling, testCase.previous, testCase.discoveredNew),
+			)
+		})
+	}
+}
+
+func TestDiscoveredNewArtifacts(t *testing.T) {
+	artifacts := &kargoapi.DiscoveredArtifacts{
+		Git: []kargoapi.GitDiscoveryResult{{
+			RepoURL: "https://github.com/example/repo.git",
+			Commits: []kargoapi.DiscoveredCommit{{ID: "abc"}, {ID: "def"}},
+		}},
+		Images: []kargoapi.ImageDiscoveryResult{{
+			RepoURL:    "example/image",
+			References: []kargoapi.DiscoveredImageReference{{Tag: "v1.0.0", Digest: "sha256:abc"}},
+		}},
+		Charts: []kargoapi.ChartDiscoveryResult{{
+			RepoURL:  "oci://example.com/charts",
+			Name:     "app",
+			Versions: []string{"1.0.0"},
+		}},
+	}
+	testCases := []struct {
+		name     string
+		previous *kargoapi.DiscoveredArtifacts
+		current  *kargoapi.DiscoveredArtifacts
+		expected bool
+	}{
+		{
+			name:     "nothing discovered",
+			previous: artifacts,
+			expected: false,
+		},
+		{
+			name:     "first discovery",
+			current:  artifacts,
+			expected: true,
+		},
+		{
+			name:     "unchanged",
+			previous: artifacts,
+			current:  artifacts.DeepCopy(),
+			expected: false,
+		},
+		{
+			name:     "new commit",
+			previous: artifacts,
+			current: func() *kargoapi.DiscoveredArtifacts {
+				current := artifacts.DeepCopy()
+				current.Git[0].Commits = append(
+					[]kargoapi.DiscoveredCommit{{ID: "123"}},
+					current.Git[0].Commits...,
+				)
+				return current
+			}(),
+			expected: true,
+		},
+		{
+			name:     "new image",
+			previous: artifacts,
+			current: func() *kargoapi.DiscoveredArtifacts {
+				current := artifacts.DeepCopy()
+				current.Images[0].References[0].Digest = "sha256:def"
+				return current
+			}(),
+			expected: true,
+		},
+		{
+			name:     "new chart version",
+			previous: artifacts,
+			current: func() *kargoapi.DiscoveredArtifacts {
+				current := artifacts.DeepCopy()
+				current.Charts[0].Versions = []string{"1.1.0", "1.0.0"}
+				return current
+			}(),
+			expected: true,
+		},
+		{
+			name:     "subscription without results",
+			previous: artifacts,
+			current: func() *kargoapi.DiscoveredArtifacts {
+				current := artifacts.DeepCopy()
+				current.Charts = append(
+					current.Charts,
+					kargoapi.ChartDiscoveryResult{RepoURL: "oci://example.com/other"},
+				)
+				return current
+			}(),
+			expected: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				discoveredNewArtifacts(testCase.previous, testCase.current),
+			)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package warehouses
+
+import (
+	"fmt"
+	"strings"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/git"
+	"github.com/akuity/kargo/internal/image"
+)
+
+// checkImageRevisions verifies that, for each of the provided subscriptions
+// that specifies a RevisionCheck, the newest image among the provided
+// artifacts was built from the newest commit discovered from the specified Git
+// repository. For a subscription whose RepoURL is a pattern, this is verified
+// for every matching repository. An error describing the first mismatch found,
+// if any, is returned.
+func checkImageRevisions(
+	subs []kargoapi.RepoSubscription,
+	artifacts *kargoapi.DiscoveredArtifacts,
+) error {
+	if artifacts == nil {
+		return nil
+	}
+	for _, s := range subs {
+		if s.Image == nil || s.Image.RevisionCheck == nil {
+			continue
+		}
+		for _, result := range artifacts.Images {
+			if !image.RepoURLMatches(s.Image.RepoURL, result.RepoURL) ||
+				len(result.References) == 0 {
+				continue
+			}
+			if err := checkImageRevision(
+				*s.Image.RevisionCheck,
+				result.RepoURL,
+				result.References[0],
+				artifacts.Git,
+			); err != nil {
+				return err
+			}
+			if !image.IsRepoURLPattern(s.Image.RepoURL) {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// checkImageRevision verifies that the provided image from the specified
+// repository was built from the newest commit among the provided Git discovery
+// results that was discovered from the Git repository specified by the
+// provided ImageRevisionCheck.
+func checkImageRevision(
+	check kargoapi.ImageRevisionCheck,
+	repoURL string,
+	latestImage kargoapi.DiscoveredImageReference,
+	gitResults []kargoapi.GitDiscoveryResult,
+) error {
+	var latestCommit *kargoapi.DiscoveredCommit
+	for _, result := range gitResults {
+		if git.NormalizeURL(result.RepoURL) == git.NormalizeURL(check.GitRepoURL) &&
+			len(result.Commits) > 0 {
+			latestCommit = &result.Commits[0]
+			break
+		}
+	}
+	if latestCommit == nil {
+		return fmt.Errorf(
+			"no commits discovered from git repo %q to check the revision of "+
+				"image %s:%s against",
+			check.GitRepoURL,
+			repoURL,
+			latestImage.Tag,
+		)
+	}
+	revision := latestImage.Revision
+	if check.BuildMetadataField != "" {
+		revision = latestImage.BuildMetadata[check.BuildMetadataField]
+	}
+	if revision == "" {
+		return fmt.Errorf(
+			"could not determine the revision image %s:%s was built from",
+			repoURL,
+			latestImage.Tag,
+		)
+	}
+	if !revisionMatchesCommit(revision, latestCommit.ID) {
+		return fmt.Errorf(
+			"image %s:%s was built from revision %q rather than from commit %q "+
+				"of git repo %q",
+			repoURL,
+			latestImage.Tag,
+			revision,
+			latestCommit.ID,
+			check.GitRepoURL,
+		)
+	}
+	return nil
+}
+
+// revisionMatchesCommit returns true if the provided revision identifies the
+// commit with the provided ID. The revision may be abbreviated, but must then
+// be at least minAbbreviatedRevisionLength characters long.
+func revisionMatchesCommit(revision, commitID string) bool {
+	if revision == commitID {
+		return true
+	}
+	return len(revision) >= minAbbreviatedRevisionLength &&
+		strings.HasPrefix(commitID, revision)
+}
+
+// minAbbreviatedRevisionLength is the minimum length of an abbreviated commit
+// ID that is accepted as identifying a commit. This is the length of the
+// abbreviated commit IDs displayed by Git by default.
+const minAbbreviatedRevisionLength = 7
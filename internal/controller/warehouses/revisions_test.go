@@ -0,0 +1,194 @@
+package warehouses
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestCheckImageRevisions(t *testing.T) {
+	subs := []kargoapi.RepoSubscription{
+		{
+			Git: &kargoapi.GitSubscription{
+				RepoURL: "https://github.com/example/repo",
+			},
+		},
+		{
+			Image: &kargoapi.ImageSubscription{
+				RepoURL: "example/image",
+				RevisionCheck: &kargoapi.ImageRevisionCheck{
+					GitRepoURL: "https://github.com/example/repo.git",
+				},
+			},
+		},
+	}
+	gitResults := []kargoapi.GitDiscoveryResult{{
+		RepoURL: "https://github.com/example/repo",
+		Commits: []kargoapi.DiscoveredCommit{
+			{ID: "1c3d5e7f9a1c3d5e7f9a1c3d5e7f9a1c3d5e7f9a"},
+		},
+	}}
+	testCases := []struct {
+		name       string
+		subs       []kargoapi.RepoSubscription
+		artifacts  *kargoapi.DiscoveredArtifacts
+		assertions func(*testing.T, error)
+	}{
+		{
+			name: "no revision checks",
+			subs: []kargoapi.RepoSubscription{
+				{Image: &kargoapi.ImageSubscription{RepoURL: "example/image"}},
+			},
+			artifacts: &kargoapi.DiscoveredArtifacts{
+				Images: []kargoapi.ImageDiscoveryResult{{
+					RepoURL:    "example/image",
+					References: []kargoapi.DiscoveredImageReference{{Tag: "v1.0.0"}},
+				}},
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "no commits discovered",
+			subs: subs,
+			artifacts: &kargoapi.DiscoveredArtifacts{
+				Images: []kargoapi.ImageDiscoveryResult{{
+					RepoURL:    "example/image",
+					References: []kargoapi.DiscoveredImageReference{{Tag: "v1.0.0"}},
+				}},
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "no commits discovered from git repo")
+			},
+		},
+		{
+			name: "revision unknown",
+			subs: subs,
+			artifacts: &kargoapi.DiscoveredArtifacts{
+				Git: gitResults,
+				Images: []kargoapi.ImageDiscoveryResult{{
+					RepoURL:    "example/image",
+					References: []kargoapi.DiscoveredImageReference{{Tag: "v1.0.0"}},
+				}},
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "could not determine the revision")
+			},
+		},
+		{
+			name: "revision mismatch",
+			subs: subs,
+			artifacts: &kargoapi.DiscoveredArtifacts{
+				Git: gitResults,
+				Images: []kargoapi.ImageDiscoveryResult{{
+					RepoURL: "example/image",
+					References: []kargoapi.DiscoveredImageReference{{
+						Tag:      "v1.0.0",
+						Revision: "2b4d6f8a0c",
+					}},
+				}},
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "was built from revision \"2b4d6f8a0c\"")
+			},
+		},
+		{
+			name: "revision matches",
+			subs: subs,
+			artifacts: &kargoapi.DiscoveredArtifacts{
+				Git: gitResults,
+				Images: []kargoapi.ImageDiscoveryResult{{
+					RepoURL: "example/image",
+					References: []kargoapi.DiscoveredImageReference{{
+						Tag:      "v1.0.0",
+						Revision: "1c3d5e7f9a1c3d5e7f9a1c3d5e7f9a1c3d5e7f9a",
+					}},
+				}},
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "build metadata revision matches",
+			subs: []kargoapi.RepoSubscription{
+				subs[0],
+				{
+					Image: &kargoapi.ImageSubscription{
+						RepoURL: "example/image",
+						RevisionCheck: &kargoapi.ImageRevisionCheck{
+							GitRepoURL:         "https://github.com/example/repo",
+							BuildMetadataField: "commit",
+						},
+					},
+				},
+			},
+			artifacts: &kargoapi.DiscoveredArtifacts{
+				Git: gitResults,
+				Images: []kargoapi.ImageDiscoveryResult{{
+					RepoURL: "example/image",
+					References: []kargoapi.DiscoveredImageReference{{
+						Tag:           "v1.0.0",
+						Revision:      "2b4d6f8a0c",
+						BuildMetadata: map[string]string{"commit": "1c3d5e7"},
+					}},
+				}},
+			},
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "revision mismatch in repository matching pattern",
+			subs: []kargoapi.RepoSubscription{
+				subs[0],
+				{
+					Image: &kargoapi.ImageSubscription{
+						RepoURL: "example/image-*",
+						RevisionCheck: &kargoapi.ImageRevisionCheck{
+							GitRepoURL: "https://github.com/example/repo",
+						},
+					},
+				},
+			},
+			artifacts: &kargoapi.DiscoveredArtifacts{
+				Git: gitResults,
+				Images: []kargoapi.ImageDiscoveryResult{
+					{
+						RepoURL: "example/image-a",
+						References: []kargoapi.DiscoveredImageReference{{
+							Tag:      "v1.0.0",
+							Revision: "1c3d5e7f9a1c3d5e7f9a1c3d5e7f9a1c3d5e7f9a",
+						}},
+					},
+					{
+						RepoURL: "example/image-b",
+						References: []kargoapi.DiscoveredImageReference{{
+							Tag:      "v1.0.0",
+							Revision: "2b4d6f8a0c",
+						}},
+					},
+				},
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "image example/image-b:v1.0.0 was built from revision")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(t, checkImageRevisions(testCase.subs, testCase.artifacts))
+		})
+	}
+}
+
+func TestRevisionMatchesCommit(t *testing.T) {
+	const commitID = "1c3d5e7f9a1c3d5e7f9a1c3d5e7f9a1c3d5e7f9a"
+	require.True(t, revisionMatchesCommit(commitID, commitID))
+	require.True(t, revisionMatchesCommit("1c3d5e7", commitID))
+	require.False(t, revisionMatchesCommit("1c3d", commitID))
+	require.False(t, revisionMatchesCommit("2b4d6f8a0c", commitID))
+}
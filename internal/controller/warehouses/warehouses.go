@@ -2,11 +2,17 @@ package warehouses
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
 	"time"
 
+	"github.com/kelseyhightower/envconfig"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -17,63 +23,128 @@ import (
 	"github.com/akuity/kargo/internal/controller"
 	"github.com/akuity/kargo/internal/controller/git"
 	"github.com/akuity/kargo/internal/credentials"
-	"github.com/akuity/kargo/internal/helm"
-	"github.com/akuity/kargo/internal/image"
+	"github.com/akuity/kargo/internal/discovery"
 	"github.com/akuity/kargo/internal/kargo"
 	"github.com/akuity/kargo/internal/kubeclient"
+	libEvent "github.com/akuity/kargo/internal/kubernetes/event"
 	"github.com/akuity/kargo/internal/logging"
 )
 
-// reconciler reconciles Warehouse resources.
-type reconciler struct {
-	client                     client.Client
-	credentialsDB              credentials.Database
-	imageSourceURLFnsByBaseURL map[string]func(string, string) string
-
-	// The following behaviors are overridable for testing purposes:
+const (
+	// maxDiscoveredArtifactsBytes is the maximum size of the JSON serialization
+	// of the discovered artifacts kept in a Warehouse's status. It leaves ample
+	// room for the remainder of the Warehouse within the maximum size of a
+	// resource. It is exceeded only if the Warehouse has so many subscriptions
+	// that a single artifact for each exceeds it.
+	maxDiscoveredArtifactsBytes = 512 << 10
+)
 
-	discoverArtifactsFn func(context.Context, *kargoapi.Warehouse) (*kargoapi.DiscoveredArtifacts, error)
+// ReconcilerConfig represents configuration for the Warehouse reconciler.
+type ReconcilerConfig struct {
+	ShardName string `envconfig:"SHARD_NAME"`
+	// MaxConcurrentDiscoveries is the maximum number of a Warehouse's
+	// subscriptions of any one kind from which artifacts are discovered
+	// concurrently. If not greater than zero, a default applies.
+	MaxConcurrentDiscoveries int `envconfig:"MAX_CONCURRENT_WAREHOUSE_DISCOVERIES"`
+	// GitCloneCacheDir is the directory in which persistent mirrors of Git
+	// repositories are kept, so that discovery only needs to fetch what has
+	// changed since the last reconciliation. If empty, repositories are cloned
+	// from scratch every time.
+	GitCloneCacheDir string `envconfig:"GIT_CLONE_CACHE_DIR"`
+	// GitCloneCacheMaxSize is the maximum amount of disk space, expressed as a
+	// Kubernetes quantity (e.g. "10Gi"), used by the mirrors in
+	// GitCloneCacheDir. The least recently used mirrors are evicted when it is
+	// exceeded. A value of "0" means no limit.
+	GitCloneCacheMaxSize string `envconfig:"GIT_CLONE_CACHE_MAX_SIZE" default:"10Gi"`
+	// GitCloneCacheMaxIdle is the time after which mirrors in GitCloneCacheDir
+	// that have not been used are evicted. A value of zero means no limit.
+	GitCloneCacheMaxIdle time.Duration `envconfig:"GIT_CLONE_CACHE_MAX_IDLE" default:"24h"`
+	// GitMirrorDirs are the directories beneath which the mirrors and bundles
+	// referenced by file:// mirror URLs of Git subscriptions must lie. If
+	// empty, file:// mirror URLs are not permitted.
+	GitMirrorDirs []string `envconfig:"GIT_MIRROR_DIRS" default:""`
+}
 
-	discoverCommitsFn func(context.Context, string, []kargoapi.RepoSubscription) ([]kargoapi.GitDiscoveryResult, error)
+func (c ReconcilerConfig) Name() string {
+	name := "warehouse-controller"
+	if c.ShardName != "" {
+		return name + "-" + c.ShardName
+	}
+	return name
+}
 
-	discoverImagesFn func(context.Context, string, []kargoapi.RepoSubscription) ([]kargoapi.ImageDiscoveryResult, error)
+func ReconcilerConfigFromEnv() ReconcilerConfig {
+	cfg := ReconcilerConfig{}
+	envconfig.MustProcess("", &cfg)
+	return cfg
+}
 
-	discoverImageRefsFn func(context.Context, kargoapi.ImageSubscription, *image.Credentials) ([]image.Image, error)
+// reconciler reconciles Warehouse resources.
+type reconciler struct {
+	client         client.Client
+	recorder       record.EventRecorder
+	controllerName string
 
-	discoverChartsFn func(context.Context, string, []kargoapi.RepoSubscription) ([]kargoapi.ChartDiscoveryResult, error)
+	// The following behaviors are overridable for testing purposes:
 
-	discoverChartVersionsFn func(context.Context, string, string, string, *helm.Credentials) ([]string, error)
+	discoverArtifactsFn func(context.Context, *kargoapi.Warehouse) (*kargoapi.DiscoveredArtifacts, error)
 
 	buildFreightFromLatestArtifactsFn func(string, *kargoapi.DiscoveredArtifacts) (*kargoapi.Freight, error)
 
-	gitCloneFn func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error)
-
-	listCommitsFn func(repo git.Repo, limit, skip uint) ([]git.CommitMetadata, error)
-
-	listTagsFn func(repo git.Repo) ([]git.TagMetadata, error)
-
-	discoverBranchHistoryFn func(repo git.Repo, sub kargoapi.GitSubscription) ([]git.CommitMetadata, error)
-
-	discoverTagsFn func(repo git.Repo, sub kargoapi.GitSubscription) ([]git.TagMetadata, error)
+	createFreightFn func(context.Context, client.Object, ...client.CreateOption) error
 
-	getDiffPathsForCommitIDFn func(repo git.Repo, commitID string) ([]string, error)
+	syncFreightAvailabilityFn func(context.Context, *kargoapi.Warehouse, *kargoapi.DiscoveredArtifacts) error
 
-	createFreightFn func(context.Context, client.Object, ...client.CreateOption) error
+	findUnavailableArtifactsFn func(
+		context.Context,
+		*kargoapi.Warehouse,
+		*kargoapi.Freight,
+		*kargoapi.DiscoveredArtifacts,
+	) ([]string, error)
 }
 
 // SetupReconcilerWithManager initializes a reconciler for Warehouse resources
 // and registers it with the provided Manager.
 func SetupReconcilerWithManager(
+	ctx context.Context,
 	mgr manager.Manager,
 	credentialsDB credentials.Database,
-	shardName string,
+	cfg ReconcilerConfig,
 ) error {
-
-	shardPredicate, err := controller.GetShardPredicate(shardName)
+	shardPredicate, err := controller.GetShardPredicate(cfg.ShardName)
 	if err != nil {
 		return fmt.Errorf("error creating shard selector predicate: %w", err)
 	}
 
+	discoveryCfg := discovery.Config{
+		MaxConcurrentDiscoveries: cfg.MaxConcurrentDiscoveries,
+		GitMirrorDirs:            cfg.GitMirrorDirs,
+	}
+	if cfg.GitCloneCacheDir != "" {
+		maxSize, err := resource.ParseQuantity(cfg.GitCloneCacheMaxSize)
+		if err != nil {
+			return fmt.Errorf(
+				"error parsing Git clone cache maximum size %q: %w",
+				cfg.GitCloneCacheMaxSize,
+				err,
+			)
+		}
+		if discoveryCfg.GitCloneCache, err = git.NewCloneCache(
+			cfg.GitCloneCacheDir,
+			maxSize.Value(),
+			cfg.GitCloneCacheMaxIdle,
+		); err != nil {
+			return fmt.Errorf("error initializing Git clone cache: %w", err)
+		}
+	}
+
+	r := newReconciler(
+		mgr.GetClient(),
+		libEvent.NewRecorder(ctx, mgr.GetScheme(), mgr.GetClient(), cfg.Name()),
+		discovery.NewDiscoverer(mgr.GetClient(), credentialsDB, discoveryCfg),
+		cfg,
+	)
+
 	if err := ctrl.NewControllerManagedBy(mgr).
 		For(&kargoapi.Warehouse{}).
 		WithEventFilter(
@@ -92,7 +163,7 @@ func SetupReconcilerWithManager(
 		).
 		WithEventFilter(shardPredicate).
 		WithOptions(controller.CommonOptions()).
-		Complete(newReconciler(mgr.GetClient(), credentialsDB)); err != nil {
+		Complete(r); err != nil {
 		return fmt.Errorf("error building Warehouse reconciler: %w", err)
 	}
 	return nil
@@ -100,30 +171,20 @@ func SetupReconcilerWithManager(
 
 func newReconciler(
 	kubeClient client.Client,
-	credentialsDB credentials.Database,
+	recorder record.EventRecorder,
+	discoverer *discovery.Discoverer,
+	cfg ReconcilerConfig,
 ) *reconciler {
 	r := &reconciler{
-		client:                  kubeClient,
-		credentialsDB:           credentialsDB,
-		gitCloneFn:              git.Clone,
-		discoverChartVersionsFn: helm.DiscoverChartVersions,
-		imageSourceURLFnsByBaseURL: map[string]func(string, string) string{
-			githubURLPrefix: getGithubImageSourceURL,
-		},
-		createFreightFn: kubeClient.Create,
+		client:                     kubeClient,
+		recorder:                   recorder,
+		controllerName:             cfg.Name(),
+		discoverArtifactsFn:        discoverer.DiscoverArtifacts,
+		createFreightFn:            kubeClient.Create,
+		findUnavailableArtifactsFn: discoverer.FindUnavailableArtifacts,
 	}
-
-	r.discoverArtifactsFn = r.discoverArtifacts
-	r.discoverCommitsFn = r.discoverCommits
-	r.discoverImagesFn = r.discoverImages
-	r.discoverImageRefsFn = r.discoverImageRefs
-	r.discoverChartsFn = r.discoverCharts
 	r.buildFreightFromLatestArtifactsFn = r.buildFreightFromLatestArtifacts
-	r.listCommitsFn = r.listCommits
-	r.listTagsFn = r.listTags
-	r.discoverBranchHistoryFn = r.discoverBranchHistory
-	r.discoverTagsFn = r.discoverTags
-	r.getDiffPathsForCommitIDFn = r.getDiffPathsForCommitID
+	r.syncFreightAvailabilityFn = r.syncFreightAvailability
 	return r
 }
 
@@ -153,11 +214,51 @@ func (r *reconciler) Reconcile(
 		return ctrl.Result{}, nil
 	}
 
+	if warehouse.Generation != warehouse.Status.ObservedGeneration {
+		// Discovery may take a while. Let clients waiting for the Warehouse to
+		// converge know that changes to its spec are being reconciled.
+		if err = kubeclient.PatchStatus(
+			ctx,
+			r.client,
+			warehouse,
+			func(status *kargoapi.WarehouseStatus) {
+				controller.SetReconcilingCondition(&status.Conditions, warehouse.Generation)
+			},
+		); err != nil {
+			logger.Errorf("error updating Warehouse status: %s", err)
+		}
+	}
+
 	newStatus, err := r.syncWarehouse(ctx, warehouse)
+	controller.SetReadyCondition(&newStatus.Conditions, warehouse.Generation, err)
 	if err != nil {
 		newStatus.Message = err.Error()
 		logger.Errorf("error syncing Warehouse: %s", err)
+	} else if availabilityErr := r.syncFreightAvailabilityFn(
+		ctx,
+		warehouse,
+		newStatus.DiscoveredArtifacts,
+	); availabilityErr != nil {
+		// This is not treated as a failure to sync the Warehouse. Discovery
+		// itself succeeded, and availability will be checked again next time.
+		logger.Errorf("error checking availability of Freight artifacts: %s", availabilityErr)
 	}
+	if err == nil {
+		newStatus.PollInterval = &metav1.Duration{
+			Duration: nextPollInterval(
+				warehouse.Spec.Polling,
+				warehouse.Status.PollInterval,
+				discoveredNewArtifacts(
+					warehouse.Status.DiscoveredArtifacts,
+					newStatus.DiscoveredArtifacts,
+				),
+			),
+		}
+	}
+	// Only a bounded summary of the discovered artifacts is kept in the status
+	// to prevent Warehouses with many subscriptions from growing beyond the
+	// maximum size of a resource.
+	newStatus.DiscoveredArtifacts = summarizeDiscoveredArtifacts(newStatus.DiscoveredArtifacts)
 
 	updateErr := kubeclient.PatchStatus(
 		ctx,
@@ -185,10 +286,9 @@ func (r *reconciler) Reconcile(
 		return ctrl.Result{}, err
 	}
 
-	// Everything succeeded, look for new changes on the defined interval.
-	//
-	// TODO: Make this configurable
-	return ctrl.Result{RequeueAfter: 5 * time.Minute}, nil
+	// Everything succeeded, look for new changes on the interval determined by
+	// the Warehouse's polling configuration.
+	return ctrl.Result{RequeueAfter: newStatus.PollInterval.Duration}, nil
 }
 
 func (r *reconciler) syncWarehouse(
@@ -212,57 +312,186 @@ func (r *reconciler) syncWarehouse(
 		return status, fmt.Errorf("error discovering artifacts: %w", err)
 	}
 	logger.Debug("discovered latest artifacts")
+	if warehouse.Status.DiscoveredArtifacts != nil && discoveredArtifacts != nil {
+		for repoURL, change := range changedDefaultBranches(
+			warehouse.Status.DiscoveredArtifacts.Git,
+			discoveredArtifacts.Git,
+		) {
+			logger.WithField("repo", repoURL).
+				Infof("default branch of git repo changed from %s", change)
+		}
+	}
 	status.DiscoveredArtifacts = discoveredArtifacts
 
 	// Automatically create a Freight from the latest discovered artifacts
-	// if the Warehouse is configured to do so.
+	// if the Warehouse is configured to do so. If the Warehouse subscribes to
+	// the services of a monorepo or to a pattern of branches, a distinct
+	// Freight is created for each service or branch.
 	if pol := warehouse.Spec.FreightCreationPolicy; pol == kargoapi.FreightCreationPolicyAutomatic || pol == "" {
-		freight, err := r.buildFreightFromLatestArtifactsFn(warehouse.Namespace, discoveredArtifacts)
-		if err != nil {
-			return status, fmt.Errorf("failed to build Freight from latest artifacts: %w", err)
+		for _, artifacts := range splitDiscoveredArtifacts(discoveredArtifacts) {
+			// Freight is not produced from images that were not built from the
+			// commits they would be paired with. This is not an error, as the
+			// expected images will usually appear once they have been built.
+			if err = checkImageRevisions(warehouse.Spec.Subscriptions, artifacts); err != nil {
+				logger.Debugf("not creating Freight: %s", err)
+				status.Message = fmt.Sprintf("Freight not created: %s", err)
+				continue
+			}
+
+			freight, err := r.buildFreightFromLatestArtifactsFn(warehouse.Namespace, artifacts)
+			if err != nil {
+				return status, fmt.Errorf("failed to build Freight from latest artifacts: %w", err)
+			}
+			freight.Warehouse = warehouse.Name
+
+			if err = r.createFreightFn(ctx, freight); client.IgnoreAlreadyExists(err) != nil {
+				return status, fmt.Errorf(
+					"error creating Freight %q in namespace %q: %w",
+					freight.Name,
+					freight.Namespace,
+					err,
+				)
+			} else if err == nil {
+				log.Debugf("created Freight %q in namespace %q", freight.Name, freight.Namespace)
+			}
+
+			status.LastFreightID = freight.Name
 		}
-		freight.Warehouse = warehouse.Name
+	}
+	return status, nil
+}
 
-		if err = r.createFreightFn(ctx, freight); client.IgnoreAlreadyExists(err) != nil {
-			return status, fmt.Errorf(
-				"error creating Freight %q in namespace %q: %w",
-				freight.Name,
-				freight.Namespace,
-				err,
-			)
-		} else if err == nil {
-			log.Debugf("created Freight %q in namespace %q", freight.Name, freight.Namespace)
+// splitDiscoveredArtifacts splits the provided DiscoveredArtifacts into one
+// set of DiscoveredArtifacts for each service or branch for which commits were
+// discovered. Each set contains the Git discovery result for its service or
+// branch along with all discovery results that do not belong to any service or
+// branch. If no commits were discovered for any service or branch, the
+// provided DiscoveredArtifacts are returned as the only set.
+func splitDiscoveredArtifacts(
+	artifacts *kargoapi.DiscoveredArtifacts,
+) []*kargoapi.DiscoveredArtifacts {
+	if artifacts == nil {
+		return []*kargoapi.DiscoveredArtifacts{nil}
+	}
+	var shared, services []kargoapi.GitDiscoveryResult
+	for _, result := range artifacts.Git {
+		if result.Service == "" && result.Branch == "" {
+			shared = append(shared, result)
+		} else {
+			services = append(services, result)
 		}
-
-		status.LastFreightID = freight.Name
 	}
-	return status, nil
+	if len(services) == 0 {
+		return []*kargoapi.DiscoveredArtifacts{artifacts}
+	}
+	split := make([]*kargoapi.DiscoveredArtifacts, len(services))
+	for i, result := range services {
+		split[i] = &kargoapi.DiscoveredArtifacts{
+			Git:       append(slices.Clone(shared), result),
+			Images:    artifacts.Images,
+			Charts:    artifacts.Charts,
+			Packages:  artifacts.Packages,
+			Truncated: artifacts.Truncated,
+		}
+	}
+	return split
 }
 
-func (r *reconciler) discoverArtifacts(
-	ctx context.Context,
-	warehouse *kargoapi.Warehouse,
-) (*kargoapi.DiscoveredArtifacts, error) {
-	commits, err := r.discoverCommitsFn(ctx, warehouse.Namespace, warehouse.Spec.Subscriptions)
-	if err != nil {
-		return nil, fmt.Errorf("error discovering commits: %w", err)
+// summarizeDiscoveredArtifacts returns a copy of the provided
+// DiscoveredArtifacts. If the serialized artifacts exceed
+// maxDiscoveredArtifactsBytes, the copy is truncated to the largest number of
+// artifacts per subscription that fits, but never below one. Subscriptions
+// are therefore only truncated below their own discovery limits when
+// necessary to keep the Warehouse within the maximum size of a resource. The
+// most recently discovered artifacts for each subscription are always
+// retained.
+func summarizeDiscoveredArtifacts(
+	artifacts *kargoapi.DiscoveredArtifacts,
+) *kargoapi.DiscoveredArtifacts {
+	if artifacts == nil {
+		return nil
 	}
+	summary := artifacts.DeepCopy()
 
-	images, err := r.discoverImagesFn(ctx, warehouse.Namespace, warehouse.Spec.Subscriptions)
-	if err != nil {
-		return nil, fmt.Errorf("error discovering images: %w", err)
+	// sizes holds the serialized size of every artifact of every result.
+	sizes := make(
+		[][]int,
+		0,
+		len(summary.Git)+len(summary.Images)+len(summary.Charts)+len(summary.Packages),
+	)
+	for _, result := range summary.Git {
+		sizes = append(sizes, artifactSizes(result.Commits))
+	}
+	for _, result := range summary.Images {
+		sizes = append(sizes, artifactSizes(result.References))
+	}
+	for _, result := range summary.Charts {
+		sizes = append(sizes, artifactSizes(result.Versions))
+	}
+	for _, result := range summary.Packages {
+		sizes = append(sizes, artifactSizes(result.Versions))
 	}
+	// sizeWithLimit returns the size of the artifacts when each result is
+	// truncated to the specified number of artifacts.
+	sizeWithLimit := func(limit int) int {
+		var size int
+		for _, resultSizes := range sizes {
+			for _, artifactSize := range resultSizes[:min(limit, len(resultSizes))] {
+				size += artifactSize
+			}
+		}
+		return size
+	}
+	var maxLen int
+	for _, resultSizes := range sizes {
+		maxLen = max(maxLen, len(resultSizes))
+	}
+	if sizeWithLimit(maxLen) <= maxDiscoveredArtifactsBytes {
+		return summary
+	}
+	limit := max(1, sort.Search(maxLen, func(i int) bool {
+		return sizeWithLimit(i+1) > maxDiscoveredArtifactsBytes
+	}))
 
-	charts, err := r.discoverChartsFn(ctx, warehouse.Namespace, warehouse.Spec.Subscriptions)
-	if err != nil {
-		return nil, fmt.Errorf("error discovering charts: %w", err)
+	for i := range summary.Git {
+		summary.Git[i].Commits = truncateArtifacts(summary, summary.Git[i].Commits, limit)
+	}
+	for i := range summary.Images {
+		summary.Images[i].References = truncateArtifacts(summary, summary.Images[i].References, limit)
 	}
+	for i := range summary.Charts {
+		summary.Charts[i].Versions = truncateArtifacts(summary, summary.Charts[i].Versions, limit)
+	}
+	for i := range summary.Packages {
+		summary.Packages[i].Versions = truncateArtifacts(summary, summary.Packages[i].Versions, limit)
+	}
+	return summary
+}
 
-	return &kargoapi.DiscoveredArtifacts{
-		Git:    commits,
-		Images: images,
-		Charts: charts,
-	}, nil
+// artifactSizes returns the serialized size of each of the provided artifacts,
+// including the separator preceding it in a JSON array.
+func artifactSizes[T any](artifacts []T) []int {
+	sizes := make([]int, len(artifacts))
+	for i, artifact := range artifacts {
+		// Discovered artifacts always serialize successfully.
+		b, _ := json.Marshal(artifact)
+		sizes[i] = len(b) + 1
+	}
+	return sizes
+}
+
+// truncateArtifacts returns the provided artifacts truncated to the specified
+// limit, recording in the provided summary whether anything was truncated.
+func truncateArtifacts[T any](
+	summary *kargoapi.DiscoveredArtifacts,
+	artifacts []T,
+	limit int,
+) []T {
+	if len(artifacts) <= limit {
+		return artifacts
+	}
+	summary.Truncated = true
+	return artifacts[:limit]
 }
 
 func (r *reconciler) buildFreightFromLatestArtifacts(
@@ -285,14 +514,19 @@ func (r *reconciler) buildFreightFromLatestArtifacts(
 		}
 		latestCommit := result.Commits[0]
 		freight.Commits = append(freight.Commits, kargoapi.GitCommit{
-			RepoURL:   result.RepoURL,
-			ID:        latestCommit.ID,
-			Branch:    latestCommit.Branch,
-			Tag:       latestCommit.Tag,
-			Message:   latestCommit.Subject,
-			Author:    latestCommit.Author,
-			Committer: latestCommit.Committer,
+			RepoURL:         result.RepoURL,
+			ID:              latestCommit.ID,
+			Branch:          latestCommit.Branch,
+			Tag:             latestCommit.Tag,
+			Message:         latestCommit.Subject,
+			Author:          latestCommit.Author,
+			Committer:       latestCommit.Committer,
+			Trailers:        latestCommit.Trailers,
+			SignatureStatus: latestCommit.SignatureStatus,
 		})
+		if result.Service != "" {
+			freight.Service = result.Service
+		}
 	}
 
 	for _, result := range artifacts.Images {
@@ -301,10 +535,11 @@ func (r *reconciler) buildFreightFromLatestArtifacts(
 		}
 		latestImage := result.References[0]
 		freight.Images = append(freight.Images, kargoapi.Image{
-			RepoURL:    result.RepoURL,
-			GitRepoURL: latestImage.GitRepoURL,
-			Tag:        latestImage.Tag,
-			Digest:     latestImage.Digest,
+			RepoURL:       result.RepoURL,
+			GitRepoURL:    latestImage.GitRepoURL,
+			Tag:           latestImage.Tag,
+			Digest:        latestImage.Digest,
+			BuildMetadata: latestImage.BuildMetadata,
 		})
 	}
 
@@ -324,8 +559,49 @@ func (r *reconciler) buildFreightFromLatestArtifacts(
 		})
 	}
 
+	for _, result := range artifacts.Packages {
+		if len(result.Versions) == 0 {
+			return nil, fmt.Errorf(
+				"no versions discovered for package %q from registry %q",
+				result.Name,
+				result.RepoURL,
+			)
+		}
+		freight.Packages = append(freight.Packages, kargoapi.Package{
+			Type:    result.Type,
+			RepoURL: result.RepoURL,
+			Name:    result.Name,
+			Version: result.Versions[0],
+		})
+	}
+
 	// Generate a unique ID for the Freight based on its contents.
 	freight.Name = freight.GenerateID()
 
 	return freight, nil
 }
+
+// changedDefaultBranches compares the default branches recorded in the
+// provided previous and current Git discovery results and returns a
+// description of each change, indexed by the URL of the repository whose
+// default branch changed.
+func changedDefaultBranches(previous, current []kargoapi.GitDiscoveryResult) map[string]string {
+	defaultBranches := make(map[string]string, len(previous))
+	for _, result := range previous {
+		if result.DefaultBranch != "" {
+			defaultBranches[result.RepoURL] = result.DefaultBranch
+		}
+	}
+	var changes map[string]string
+	for _, result := range current {
+		prev, ok := defaultBranches[result.RepoURL]
+		if !ok || result.DefaultBranch == "" || result.DefaultBranch == prev {
+			continue
+		}
+		if changes == nil {
+			changes = map[string]string{}
+		}
+		changes[result.RepoURL] = fmt.Sprintf("%q to %q", prev, result.DefaultBranch)
+	}
+	return changes
+}
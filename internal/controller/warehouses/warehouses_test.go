@@ -2,7 +2,10 @@ package warehouses
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -14,30 +17,30 @@ import (
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
 	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/discovery"
+	fakeevent "github.com/akuity/kargo/internal/kubernetes/event/fake"
 )
 
 func TestNewReconciler(t *testing.T) {
 	kubeClient := fake.NewClientBuilder().Build()
 	e := newReconciler(
 		kubeClient,
-		&credentials.FakeDB{},
+		fakeevent.NewEventRecorder(1),
+		discovery.NewDiscoverer(kubeClient, &credentials.FakeDB{}, discovery.Config{}),
+		ReconcilerConfig{
+			ShardName: "fake-shard",
+		},
 	)
 	require.NotNil(t, e.client)
-	require.NotNil(t, e.credentialsDB)
-	require.NotEmpty(t, e.imageSourceURLFnsByBaseURL)
+	require.NotNil(t, e.recorder)
+	require.Equal(t, "warehouse-controller-fake-shard", e.controllerName)
 
 	// Assert that all overridable behaviors were initialized to a default:
 	require.NotNil(t, e.discoverArtifactsFn)
-	require.NotNil(t, e.discoverCommitsFn)
-	require.NotNil(t, e.discoverImagesFn)
-	require.NotNil(t, e.discoverChartsFn)
 	require.NotNil(t, e.buildFreightFromLatestArtifactsFn)
-	require.NotNil(t, e.listCommitsFn)
-	require.NotNil(t, e.listTagsFn)
-	require.NotNil(t, e.discoverBranchHistoryFn)
-	require.NotNil(t, e.discoverTagsFn)
-	require.NotNil(t, e.getDiffPathsForCommitIDFn)
 	require.NotNil(t, e.createFreightFn)
+	require.NotNil(t, e.syncFreightAvailabilityFn)
+	require.NotNil(t, e.findUnavailableArtifactsFn)
 }
 
 func TestSyncWarehouse(t *testing.T) {
@@ -92,6 +95,61 @@ func TestSyncWarehouse(t *testing.T) {
 			},
 		},
 
+		{
+			name: "image not built from latest commit",
+			reconciler: &reconciler{
+				discoverArtifactsFn: func(
+					context.Context,
+					*kargoapi.Warehouse,
+				) (*kargoapi.DiscoveredArtifacts, error) {
+					return &kargoapi.DiscoveredArtifacts{
+						Git: []kargoapi.GitDiscoveryResult{{
+							RepoURL: "https://github.com/example/repo",
+							Commits: []kargoapi.DiscoveredCommit{{ID: "new-commit-id"}},
+						}},
+						Images: []kargoapi.ImageDiscoveryResult{{
+							RepoURL: "example/image",
+							References: []kargoapi.DiscoveredImageReference{{
+								Tag:      "v1.0.0",
+								Revision: "old-commit-id",
+							}},
+						}},
+					}, nil
+				},
+				buildFreightFromLatestArtifactsFn: func(
+					string,
+					*kargoapi.DiscoveredArtifacts,
+				) (*kargoapi.Freight, error) {
+					return nil, errors.New("should not be called")
+				},
+			},
+			warehouse: &kargoapi.Warehouse{
+				Spec: kargoapi.WarehouseSpec{
+					Subscriptions: []kargoapi.RepoSubscription{
+						{
+							Git: &kargoapi.GitSubscription{
+								RepoURL: "https://github.com/example/repo",
+							},
+						},
+						{
+							Image: &kargoapi.ImageSubscription{
+								RepoURL: "example/image",
+								RevisionCheck: &kargoapi.ImageRevisionCheck{
+									GitRepoURL: "https://github.com/example/repo",
+								},
+							},
+						},
+					},
+				},
+			},
+			assertions: func(t *testing.T, status kargoapi.WarehouseStatus, err error) {
+				require.NoError(t, err)
+				require.Contains(t, status.Message, "Freight not created")
+				require.Contains(t, status.Message, "old-commit-id")
+				require.Empty(t, status.LastFreightID)
+			},
+		},
+
 		{
 			name: "Freight for latest artifacts already exists",
 			reconciler: &reconciler{
@@ -213,6 +271,65 @@ func TestSyncWarehouse(t *testing.T) {
 			},
 		},
 
+		{
+			name: "automatic Freight creation for services",
+			reconciler: func() *reconciler {
+				var created []string
+				r := &reconciler{
+					discoverArtifactsFn: func(context.Context, *kargoapi.Warehouse) (*kargoapi.DiscoveredArtifacts, error) {
+						return &kargoapi.DiscoveredArtifacts{
+							Git: []kargoapi.GitDiscoveryResult{
+								{
+									RepoURL: "fake-repo",
+									Service: "foo",
+									Commits: []kargoapi.DiscoveredCommit{{ID: "fake-commit"}},
+								},
+								{
+									RepoURL: "fake-repo",
+									Service: "bar",
+									Commits: []kargoapi.DiscoveredCommit{{ID: "fake-commit"}},
+								},
+							},
+						}, nil
+					},
+					createFreightFn: func(
+						_ context.Context,
+						obj client.Object,
+						_ ...client.CreateOption,
+					) error {
+						freight := obj.(*kargoapi.Freight) // nolint: forcetypeassert
+						created = append(created, freight.Service+"/"+freight.Name)
+						if len(created) == 2 {
+							// Both services' latest commit is the same, but the
+							// Freight for each must be distinct.
+							if created[0] == created[1] {
+								return errors.New("Freight for services collided")
+							}
+						}
+						return nil
+					},
+				}
+				r.buildFreightFromLatestArtifactsFn = r.buildFreightFromLatestArtifacts
+				return r
+			}(),
+			warehouse: &kargoapi.Warehouse{
+				Spec: kargoapi.WarehouseSpec{
+					FreightCreationPolicy: kargoapi.FreightCreationPolicyAutomatic,
+				},
+			},
+			assertions: func(t *testing.T, status kargoapi.WarehouseStatus, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					(&kargoapi.Freight{
+						Service: "bar",
+						Commits: []kargoapi.GitCommit{{RepoURL: "fake-repo", ID: "fake-commit"}},
+					}).GenerateID(),
+					status.LastFreightID,
+				)
+			},
+		},
+
 		{
 			name: "manual Freight creation",
 			reconciler: &reconciler{
@@ -312,128 +429,103 @@ func TestSyncWarehouse(t *testing.T) {
 	}
 }
 
-func TestDiscoverArtifacts(t *testing.T) {
+func TestSummarizeDiscoveredArtifacts(t *testing.T) {
+	// Each commit is serialized to a little over 1KiB.
+	subject := strings.Repeat("x", 1<<10)
+	newCommits := func(n int) []kargoapi.DiscoveredCommit {
+		commits := make([]kargoapi.DiscoveredCommit, n)
+		for i := range commits {
+			commits[i] = kargoapi.DiscoveredCommit{
+				ID:      fmt.Sprintf("commit-%d", i),
+				Subject: subject,
+			}
+		}
+		return commits
+	}
+	newGitResults := func(results, commits int) []kargoapi.GitDiscoveryResult {
+		gitResults := make([]kargoapi.GitDiscoveryResult, results)
+		for i := range gitResults {
+			gitResults[i] = kargoapi.GitDiscoveryResult{
+				RepoURL: fmt.Sprintf("https://example.com/repo-%d.git", i),
+				Commits: newCommits(commits),
+			}
+		}
+		return gitResults
+	}
+
 	testCases := []struct {
 		name       string
-		reconciler *reconciler
-		assertions func(*testing.T, *kargoapi.DiscoveredArtifacts, error)
+		artifacts  *kargoapi.DiscoveredArtifacts
+		assertions func(*testing.T, *kargoapi.DiscoveredArtifacts, *kargoapi.DiscoveredArtifacts)
 	}{
 		{
-			name: "error discovering commits",
-			reconciler: &reconciler{
-				discoverCommitsFn: func(
-					context.Context, string,
-					[]kargoapi.RepoSubscription,
-				) ([]kargoapi.GitDiscoveryResult, error) {
-					return nil, errors.New("something went wrong")
-				},
-			},
-			assertions: func(t *testing.T, discoveredArtifacts *kargoapi.DiscoveredArtifacts, err error) {
-				require.ErrorContains(t, err, "something went wrong")
-				require.ErrorContains(t, err, "error discovering commits")
-				require.Nil(t, discoveredArtifacts)
+			name: "nil",
+			assertions: func(t *testing.T, _, summary *kargoapi.DiscoveredArtifacts) {
+				require.Nil(t, summary)
 			},
 		},
 		{
-			name: "error discovering images",
-			reconciler: &reconciler{
-				discoverCommitsFn: func(
-					context.Context, string,
-					[]kargoapi.RepoSubscription,
-				) ([]kargoapi.GitDiscoveryResult, error) {
-					return []kargoapi.GitDiscoveryResult{}, nil
-				},
-				discoverImagesFn: func(
-					context.Context, string,
-					[]kargoapi.RepoSubscription,
-				) ([]kargoapi.ImageDiscoveryResult, error) {
-					return nil, errors.New("something went wrong")
-				},
-			},
-			assertions: func(t *testing.T, discoveredArtifacts *kargoapi.DiscoveredArtifacts, err error) {
-				require.ErrorContains(t, err, "something went wrong")
-				require.ErrorContains(t, err, "error discovering images")
-				require.Nil(t, discoveredArtifacts)
+			name: "within size limit",
+			artifacts: &kargoapi.DiscoveredArtifacts{
+				// The maximum discovery limit of a subscription is never cut short
+				// unless necessary.
+				Git: newGitResults(2, 100),
+				Images: []kargoapi.ImageDiscoveryResult{{
+					RepoURL:    "fake-repo",
+					References: []kargoapi.DiscoveredImageReference{},
+				}},
+			},
+			assertions: func(t *testing.T, artifacts, summary *kargoapi.DiscoveredArtifacts) {
+				require.Equal(t, artifacts, summary)
+				require.False(t, summary.Truncated)
 			},
 		},
 		{
-			name: "error discovering charts",
-			reconciler: &reconciler{
-				discoverCommitsFn: func(
-					context.Context, string,
-					[]kargoapi.RepoSubscription,
-				) ([]kargoapi.GitDiscoveryResult, error) {
-					return []kargoapi.GitDiscoveryResult{}, nil
-				},
-				discoverImagesFn: func(
-					context.Context, string,
-					[]kargoapi.RepoSubscription,
-				) ([]kargoapi.ImageDiscoveryResult, error) {
-					return []kargoapi.ImageDiscoveryResult{}, nil
-				},
-				discoverChartsFn: func(
-					context.Context, string,
-					[]kargoapi.RepoSubscription,
-				) ([]kargoapi.ChartDiscoveryResult, error) {
-					return nil, errors.New("something went wrong")
-				},
-			},
-			assertions: func(t *testing.T, discoveredArtifacts *kargoapi.DiscoveredArtifacts, err error) {
-				require.ErrorContains(t, err, "something went wrong")
-				require.ErrorContains(t, err, "error discovering charts")
-				require.Nil(t, discoveredArtifacts)
+			name: "exceeding size limit",
+			artifacts: &kargoapi.DiscoveredArtifacts{
+				Git: newGitResults(100, 20),
+				Charts: []kargoapi.ChartDiscoveryResult{{
+					RepoURL:  "fake-repo",
+					Versions: []string{"1.0.0"},
+				}},
+			},
+			assertions: func(t *testing.T, artifacts, summary *kargoapi.DiscoveredArtifacts) {
+				require.True(t, summary.Truncated)
+				limit := len(summary.Git[0].Commits)
+				require.Greater(t, limit, 1)
+				require.Less(t, limit, 20)
+				for _, result := range summary.Git {
+					require.Equal(t, artifacts.Git[0].Commits[:limit], result.Commits)
+				}
+				require.Equal(t, []string{"1.0.0"}, summary.Charts[0].Versions)
+				b, err := json.Marshal(summary)
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(b), maxDiscoveredArtifactsBytes+(64<<10))
+				// The original must not have been modified
+				require.Len(t, artifacts.Git[0].Commits, 20)
 			},
 		},
 		{
-			name: "success",
-			reconciler: &reconciler{
-				discoverCommitsFn: func(
-					context.Context, string,
-					[]kargoapi.RepoSubscription,
-				) ([]kargoapi.GitDiscoveryResult, error) {
-					return []kargoapi.GitDiscoveryResult{
-						{RepoURL: "fake-repo", Commits: []kargoapi.DiscoveredCommit{
-							{ID: "fake-commit"},
-						}},
-					}, nil
-				},
-				discoverImagesFn: func(
-					context.Context, string,
-					[]kargoapi.RepoSubscription,
-				) ([]kargoapi.ImageDiscoveryResult, error) {
-					return []kargoapi.ImageDiscoveryResult{
-						{RepoURL: "fake-repo", References: []kargoapi.DiscoveredImageReference{
-							{Tag: "fake-tag"},
-						}},
-					}, nil
-				},
-				discoverChartsFn: func(
-					context.Context, string,
-					[]kargoapi.RepoSubscription,
-				) ([]kargoapi.ChartDiscoveryResult, error) {
-					return []kargoapi.ChartDiscoveryResult{
-						{RepoURL: "fake-repo", Versions: []string{
-							"fake-version",
-						}},
-					}, nil
-				},
+			name: "more subscriptions than fit within size limit",
+			artifacts: &kargoapi.DiscoveredArtifacts{
+				Git: newGitResults(600, 2),
 			},
-			assertions: func(t *testing.T, discoveredArtifacts *kargoapi.DiscoveredArtifacts, err error) {
-				require.NoError(t, err)
-				require.Len(t, discoveredArtifacts.Git, 1)
-				require.Len(t, discoveredArtifacts.Images, 1)
-				require.Len(t, discoveredArtifacts.Charts, 1)
+			assertions: func(t *testing.T, artifacts, summary *kargoapi.DiscoveredArtifacts) {
+				require.True(t, summary.Truncated)
+				require.Len(t, summary.Git, 600)
+				for _, result := range summary.Git {
+					require.Equal(t, artifacts.Git[0].Commits[:1], result.Commits)
+				}
 			},
 		},
 	}
-
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			discoveredArtifacts, err := testCase.reconciler.discoverArtifacts(
-				context.TODO(),
-				&kargoapi.Warehouse{},
+			testCase.assertions(
+				t,
+				testCase.artifacts,
+				summarizeDiscoveredArtifacts(testCase.artifacts),
 			)
-			testCase.assertions(t, discoveredArtifacts, err)
 		})
 	}
 }
@@ -497,6 +589,23 @@ func TestBuildFreightFromLatestArtifacts(t *testing.T) {
 				require.Nil(t, freight)
 			},
 		},
+		{
+			name: "no package versions discovered",
+			artifacts: &kargoapi.DiscoveredArtifacts{
+				Packages: []kargoapi.PackageDiscoveryResult{
+					{
+						Type:     kargoapi.PackageTypeNPM,
+						RepoURL:  "fake-registry",
+						Name:     "fake-package",
+						Versions: []string{},
+					},
+				},
+			},
+			assertions: func(t *testing.T, freight *kargoapi.Freight, err error) {
+				require.ErrorContains(t, err, "no versions discovered for package")
+				require.Nil(t, freight)
+			},
+		},
 		{
 			name: "success",
 			artifacts: &kargoapi.DiscoveredArtifacts{
@@ -512,6 +621,14 @@ func TestBuildFreightFromLatestArtifacts(t *testing.T) {
 					{RepoURL: "fake-repo", Versions: []string{"fake-version"}},
 					{RepoURL: "fake-repo", Versions: []string{"fake-version"}},
 				},
+				Packages: []kargoapi.PackageDiscoveryResult{
+					{
+						Type:     kargoapi.PackageTypeNPM,
+						RepoURL:  "fake-registry",
+						Name:     "fake-package",
+						Versions: []string{"1.0.0"},
+					},
+				},
 			},
 			assertions: func(t *testing.T, freight *kargoapi.Freight, err error) {
 				require.NoError(t, err)
@@ -519,6 +636,31 @@ func TestBuildFreightFromLatestArtifacts(t *testing.T) {
 				require.Len(t, freight.Commits, 2)
 				require.Len(t, freight.Images, 2)
 				require.Len(t, freight.Charts, 2)
+				require.Equal(t, []kargoapi.Package{{
+					Type:    kargoapi.PackageTypeNPM,
+					RepoURL: "fake-registry",
+					Name:    "fake-package",
+					Version: "1.0.0",
+				}}, freight.Packages)
+				require.Empty(t, freight.Service)
+			},
+		},
+		{
+			name: "success for service",
+			artifacts: &kargoapi.DiscoveredArtifacts{
+				Git: []kargoapi.GitDiscoveryResult{
+					{
+						RepoURL: "fake-repo",
+						Service: "fake-service",
+						Commits: []kargoapi.DiscoveredCommit{{ID: "fake-commit"}},
+					},
+				},
+			},
+			assertions: func(t *testing.T, freight *kargoapi.Freight, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, freight)
+				require.Equal(t, "fake-service", freight.Service)
+				require.Equal(t, freight.GenerateID(), freight.Name)
 			},
 		},
 	}
@@ -533,3 +675,164 @@ func TestBuildFreightFromLatestArtifacts(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitDiscoveredArtifacts(t *testing.T) {
+	sharedCommits := kargoapi.GitDiscoveryResult{
+		RepoURL: "fake-repo",
+		Commits: []kargoapi.DiscoveredCommit{{ID: "fake-commit"}},
+	}
+	images := []kargoapi.ImageDiscoveryResult{{
+		RepoURL:    "fake-image-repo",
+		References: []kargoapi.DiscoveredImageReference{{Tag: "fake-tag"}},
+	}}
+	pkgs := []kargoapi.PackageDiscoveryResult{{
+		Type:     kargoapi.PackageTypePyPI,
+		RepoURL:  "fake-registry",
+		Name:     "fake-package",
+		Versions: []string{"1.0.0"},
+	}}
+	fooCommits := kargoapi.GitDiscoveryResult{
+		RepoURL: "fake-monorepo",
+		Service: "foo",
+		Commits: []kargoapi.DiscoveredCommit{{ID: "fake-foo-commit"}},
+	}
+	barCommits := kargoapi.GitDiscoveryResult{
+		RepoURL: "fake-monorepo",
+		Service: "bar",
+		Commits: []kargoapi.DiscoveredCommit{{ID: "fake-bar-commit"}},
+	}
+	releaseCommits := kargoapi.GitDiscoveryResult{
+		RepoURL: "fake-branched-repo",
+		Branch:  "release/1.0",
+		Commits: []kargoapi.DiscoveredCommit{{ID: "fake-release-commit", Branch: "release/1.0"}},
+	}
+	mainCommits := kargoapi.GitDiscoveryResult{
+		RepoURL: "fake-branched-repo",
+		Branch:  "main",
+		Commits: []kargoapi.DiscoveredCommit{{ID: "fake-main-commit", Branch: "main"}},
+	}
+	testCases := []struct {
+		name      string
+		artifacts *kargoapi.DiscoveredArtifacts
+		expected  []*kargoapi.DiscoveredArtifacts
+	}{
+		{
+			name:     "nil",
+			expected: []*kargoapi.DiscoveredArtifacts{nil},
+		},
+		{
+			name: "no services",
+			artifacts: &kargoapi.DiscoveredArtifacts{
+				Git:    []kargoapi.GitDiscoveryResult{sharedCommits},
+				Images: images,
+			},
+			expected: []*kargoapi.DiscoveredArtifacts{{
+				Git:    []kargoapi.GitDiscoveryResult{sharedCommits},
+				Images: images,
+			}},
+		},
+		{
+			name: "services",
+			artifacts: &kargoapi.DiscoveredArtifacts{
+				Git:      []kargoapi.GitDiscoveryResult{fooCommits, sharedCommits, barCommits},
+				Images:   images,
+				Packages: pkgs,
+			},
+			expected: []*kargoapi.DiscoveredArtifacts{
+				{
+					Git:      []kargoapi.GitDiscoveryResult{sharedCommits, fooCommits},
+					Images:   images,
+					Packages: pkgs,
+				},
+				{
+					Git:      []kargoapi.GitDiscoveryResult{sharedCommits, barCommits},
+					Images:   images,
+					Packages: pkgs,
+				},
+			},
+		},
+		{
+			name: "branches",
+			artifacts: &kargoapi.DiscoveredArtifacts{
+				Git:    []kargoapi.GitDiscoveryResult{releaseCommits, mainCommits, sharedCommits},
+				Images: images,
+			},
+			expected: []*kargoapi.DiscoveredArtifacts{
+				{
+					Git:    []kargoapi.GitDiscoveryResult{sharedCommits, releaseCommits},
+					Images: images,
+				},
+				{
+					Git:    []kargoapi.GitDiscoveryResult{sharedCommits, mainCommits},
+					Images: images,
+				},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				splitDiscoveredArtifacts(testCase.artifacts),
+			)
+		})
+	}
+}
+
+func TestChangedDefaultBranches(t *testing.T) {
+	testCases := []struct {
+		name     string
+		previous []kargoapi.GitDiscoveryResult
+		current  []kargoapi.GitDiscoveryResult
+		expected map[string]string
+	}{
+		{
+			name: "no previous results",
+			current: []kargoapi.GitDiscoveryResult{
+				{RepoURL: "fake-repo", DefaultBranch: "main"},
+			},
+		},
+		{
+			name: "default branch unchanged",
+			previous: []kargoapi.GitDiscoveryResult{
+				{RepoURL: "fake-repo", DefaultBranch: "main"},
+			},
+			current: []kargoapi.GitDiscoveryResult{
+				{RepoURL: "fake-repo", DefaultBranch: "main"},
+			},
+		},
+		{
+			name: "branch specified by subscription",
+			previous: []kargoapi.GitDiscoveryResult{
+				{RepoURL: "fake-repo", DefaultBranch: "master"},
+			},
+			current: []kargoapi.GitDiscoveryResult{
+				{RepoURL: "fake-repo"},
+			},
+		},
+		{
+			name: "default branch changed",
+			previous: []kargoapi.GitDiscoveryResult{
+				{RepoURL: "fake-repo", DefaultBranch: "master"},
+				{RepoURL: "other-repo", DefaultBranch: "main"},
+			},
+			current: []kargoapi.GitDiscoveryResult{
+				{RepoURL: "fake-repo", DefaultBranch: "main"},
+				{RepoURL: "other-repo", DefaultBranch: "main"},
+			},
+			expected: map[string]string{
+				"fake-repo": `"master" to "main"`,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				changedDefaultBranches(testCase.previous, testCase.current),
+			)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package credentials
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"regexp"
 	"sort"
 	"strings"
@@ -9,13 +11,17 @@ import (
 	"github.com/kelseyhightower/envconfig"
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/credentials/kms"
 	"github.com/akuity/kargo/internal/git"
 	"github.com/akuity/kargo/internal/helm"
 	"github.com/akuity/kargo/internal/logging"
+	"github.com/akuity/kargo/internal/redact"
 )
 
 const (
@@ -23,6 +29,7 @@ const (
 	FieldRepoURLIsRegex = "repoURLIsRegex"
 	FieldUsername       = "username"
 	FieldPassword       = "password"
+	FieldSSHPrivateKey  = "sshPrivateKey"
 )
 
 // Type is a string type used to represent a type of Credentials.
@@ -40,6 +47,8 @@ const (
 	TypeHelm Type = "helm"
 	// TypeImage represents credentials for an image repository.
 	TypeImage Type = "image"
+	// TypePackage represents credentials for a package registry.
+	TypePackage Type = "package"
 )
 
 // Credentials generically represents any type of repository credential.
@@ -77,13 +86,29 @@ type kubernetesDatabase struct {
 // implementation of the Database interface.
 type KubernetesDatabaseConfig struct {
 	GlobalCredentialsNamespaces []string `envconfig:"GLOBAL_CREDENTIALS_NAMESPACES" default:""`
+	// GitInsecureHTTPHosts are the hosts whose git repositories may be accessed
+	// over plain HTTP. Credentials are only ever returned for git repositories
+	// with plain HTTP URLs if they are hosted on one of these hosts.
+	GitInsecureHTTPHosts []string `envconfig:"GIT_INSECURE_HTTP_HOSTS" default:""`
+	// KMS is the key management service used to decrypt credentials Secrets
+	// that were envelope-encrypted by the Kargo API server. It is nil if no
+	// key management service is configured.
+	KMS kms.Service `ignored:"true"`
 }
 
-func KubernetesDatabaseConfigFromEnv() KubernetesDatabaseConfig {
+// KubernetesDatabaseConfigFromEnv returns a KubernetesDatabaseConfig populated
+// from environment variables. It returns an error if the key management
+// service those variables configure cannot be initialized.
+func KubernetesDatabaseConfigFromEnv() (KubernetesDatabaseConfig, error) {
 	cfg := KubernetesDatabaseConfig{}
 	envconfig.MustProcess("", &cfg)
 	sort.StringSlice(cfg.GlobalCredentialsNamespaces).Sort()
-	return cfg
+	kmsSvc, err := kms.NewService(kms.ConfigFromEnv())
+	if err != nil {
+		return cfg, fmt.Errorf("error initializing key management service for credentials: %w", err)
+	}
+	cfg.KMS = kms.NewCachingService(kmsSvc)
+	return cfg, nil
 }
 
 // NewKubernetesDatabase initializes and returns an implementation of the
@@ -108,8 +133,10 @@ func (k *kubernetesDatabase) Get(
 	creds := Credentials{}
 
 	// If we are dealing with an insecure HTTP endpoint (of any type),
-	// refuse to return any credentials
-	if strings.HasPrefix(repoURL, "http://") {
+	// refuse to return any credentials, unless it is a git repository on a host
+	// that has explicitly been permitted to be accessed over plain HTTP
+	if strings.HasPrefix(repoURL, "http://") &&
+		(credType != TypeGit || !git.IsInsecureHTTPHost(repoURL, k.cfg.GitInsecureHTTPHosts)) {
 		logger := logging.LoggerFromContext(ctx).WithField("repoURL", repoURL)
 		logger.Warnf("refused to get credentials for insecure HTTP endpoint")
 		return creds, false, nil
@@ -145,11 +172,125 @@ func (k *kubernetesDatabase) Get(
 		}
 	}
 
+	if secret == nil {
+		// Fall back to default credentials for the repository's host, if any
+		// have been declared in the ClusterConfig
+		if secret, err = k.getHostDefaultCredentialsSecret(
+			ctx,
+			credType,
+			repoURL,
+		); err != nil {
+			return creds, false, err
+		}
+	}
+
 	if secret == nil {
 		return creds, false, nil
 	}
 
-	return secretToCreds(secret), true, nil
+	if err = DecryptSecret(ctx, k.cfg.KMS, secret); err != nil {
+		return creds, false, fmt.Errorf(
+			"error decrypting credentials Secret %q in namespace %q: %w",
+			secret.Name,
+			secret.Namespace,
+			err,
+		)
+	}
+
+	creds = secretToCreds(secret)
+	// Make sure the credentials are never disclosed by anything done with them
+	// on behalf of the caller.
+	redact.AddSecrets(ctx, creds.Password, creds.SSHPrivateKey)
+	return creds, true, nil
+}
+
+// getHostDefaultCredentialsSecret returns the Secret referenced as the default
+// credentials for the host of the provided repository URL by the
+// ClusterConfig, if any.
+func (k *kubernetesDatabase) getHostDefaultCredentialsSecret(
+	ctx context.Context,
+	credType Type,
+	repoURL string,
+) (*corev1.Secret, error) {
+	host := repoHost(credType, repoURL)
+	if host == "" {
+		return nil, nil
+	}
+
+	clusterCfg := &kargoapi.ClusterConfig{}
+	if err := k.kargoClient.Get(
+		ctx,
+		client.ObjectKey{Name: kargoapi.ClusterConfigName},
+		clusterCfg,
+	); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error getting ClusterConfig: %w", err)
+	}
+
+	hostCfg := clusterCfg.Spec.GetHostConfig(host)
+	if hostCfg == nil && host == dockerHubHost {
+		hostCfg = clusterCfg.Spec.GetHostConfig("docker.io")
+	}
+	if hostCfg == nil || hostCfg.CredentialsSecretRef == nil {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := k.kargoClient.Get(
+		ctx,
+		client.ObjectKey{
+			Namespace: hostCfg.CredentialsSecretRef.Namespace,
+			Name:      hostCfg.CredentialsSecretRef.Name,
+		},
+		secret,
+	); err != nil {
+		if apierrors.IsNotFound(err) {
+			logging.LoggerFromContext(ctx).WithFields(log.Fields{
+				"host":      host,
+				"namespace": hostCfg.CredentialsSecretRef.Namespace,
+				"secret":    hostCfg.CredentialsSecretRef.Name,
+			}).Warn("default credentials Secret for host not found")
+			return nil, nil
+		}
+		return nil, fmt.Errorf(
+			"error getting default credentials Secret for host %q: %w",
+			host,
+			err,
+		)
+	}
+	return secret, nil
+}
+
+// dockerHubHost is the registry host of Docker Hub.
+const dockerHubHost = "index.docker.io"
+
+// repoHost returns the host (including port, if any) of the provided
+// repository URL, or an empty string if it cannot be determined. Image
+// repository URLs without an explicit registry refer to Docker Hub.
+func repoHost(credType Type, repoURL string) string {
+	if strings.Contains(repoURL, "://") {
+		u, err := url.Parse(repoURL)
+		if err != nil {
+			return ""
+		}
+		return u.Host
+	}
+	if credType == TypeImage {
+		first, _, found := strings.Cut(repoURL, "/")
+		if !found ||
+			(!strings.ContainsAny(first, ".:") && first != "localhost") {
+			return dockerHubHost
+		}
+		return first
+	}
+	// SCP-style Git URLs, e.g. git@github.com:akuity/kargo.git
+	if _, rest, found := strings.Cut(repoURL, "@"); found {
+		host, _, _ := strings.Cut(rest, ":")
+		return host
+	}
+	return ""
 }
 
 func (k *kubernetesDatabase) getCredentialsSecret(
@@ -223,8 +364,8 @@ func (k *kubernetesDatabase) getCredentialsSecret(
 
 func secretToCreds(secret *corev1.Secret) Credentials {
 	return Credentials{
-		Username:      string(secret.Data["username"]),
-		Password:      string(secret.Data["password"]),
-		SSHPrivateKey: string(secret.Data["sshPrivateKey"]),
+		Username:      string(secret.Data[FieldUsername]),
+		Password:      string(secret.Data[FieldPassword]),
+		SSHPrivateKey: string(secret.Data[FieldSSHPrivateKey]),
 	}
 }
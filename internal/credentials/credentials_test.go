@@ -7,10 +7,13 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/credentials/kms"
+	"github.com/akuity/kargo/internal/redact"
 )
 
 func TestNewKubernetesDatabase(t *testing.T) {
@@ -116,10 +119,11 @@ func TestGet(t *testing.T) {
 	}
 
 	testCases := []struct {
-		name     string
-		secrets  []client.Object
-		repoURL  string
-		expected *corev1.Secret
+		name              string
+		secrets           []client.Object
+		repoURL           string
+		insecureHTTPHosts []string
+		expected          *corev1.Secret
 	}{
 		{
 			name:     "exact match in project namespace",
@@ -190,17 +194,26 @@ func TestGet(t *testing.T) {
 			repoURL:  insecureTestURL,
 			expected: nil,
 		},
+		{
+			name:              "insecure HTTP endpoint on permitted host",
+			secrets:           []client.Object{projectCredentialWithInsecureRepoURL},
+			repoURL:           insecureTestURL,
+			insecureHTTPHosts: []string{"github.com"},
+			expected:          projectCredentialWithInsecureRepoURL,
+		},
 	}
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
+			secrets := &redact.Secrets{}
 			creds, found, err := NewKubernetesDatabase(
 				fake.NewClientBuilder().WithObjects(testCase.secrets...).Build(),
 				KubernetesDatabaseConfig{
 					GlobalCredentialsNamespaces: []string{testGlobalNamespace},
+					GitInsecureHTTPHosts:        testCase.insecureHTTPHosts,
 				},
 			).Get(
-				context.Background(),
+				redact.ContextWithSecrets(context.Background(), secrets),
 				testProjectNamespace,
 				testCredType,
 				testCase.repoURL,
@@ -219,6 +232,183 @@ func TestGet(t *testing.T) {
 				string(testCase.expected.Data["username"]),
 				creds.Username,
 			)
+			// The password must have been registered as a secret
+			require.Equal(t, redact.Mask, secrets.Redact(creds.Password))
+		})
+	}
+}
+
+func TestGetEncrypted(t *testing.T) {
+	kmsSvc := &kms.FakeService{}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fake-secret",
+			Namespace: "fake-namespace",
+			Labels: map[string]string{
+				kargoapi.CredentialTypeLabelKey: TypeGit.String(),
+			},
+		},
+		Data: map[string][]byte{
+			FieldRepoURL:  []byte("https://github.com/akuity/kargo"),
+			FieldUsername: []byte("fake-username"),
+			FieldPassword: []byte("fake-password"),
+		},
+	}
+	require.NoError(t, EncryptSecret(context.Background(), kmsSvc, secret))
+	kubeClient := fake.NewClientBuilder().WithObjects(secret).Build()
+
+	creds, found, err := NewKubernetesDatabase(
+		kubeClient,
+		KubernetesDatabaseConfig{KMS: kmsSvc},
+	).Get(context.Background(), "fake-namespace", TypeGit, "https://github.com/akuity/kargo")
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "fake-username", creds.Username)
+	require.Equal(t, "fake-password", creds.Password)
+
+	_, _, err = NewKubernetesDatabase(
+		kubeClient,
+		KubernetesDatabaseConfig{},
+	).Get(context.Background(), "fake-namespace", TypeGit, "https://github.com/akuity/kargo")
+	require.ErrorContains(t, err, "error decrypting credentials Secret")
+	require.ErrorContains(t, err, "no key management service is configured")
+}
+
+func TestGetHostDefaultCredentials(t *testing.T) {
+	const testProjectNamespace = "fake-namespace"
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, kargoapi.AddToScheme(scheme))
+
+	defaultCredentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default-credentials",
+			Namespace: "kargo",
+			Labels: map[string]string{
+				kargoapi.CredentialTypeLabelKey: TypeImage.String(),
+			},
+		},
+		Data: map[string][]byte{
+			FieldUsername: []byte("host-default"),
+			FieldPassword: []byte("fake-password"),
+		},
+	}
+
+	clusterConfig := &kargoapi.ClusterConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: kargoapi.ClusterConfigName},
+		Spec: kargoapi.ClusterConfigSpec{
+			Hosts: []kargoapi.HostConfig{
+				{
+					Host: "ghcr.io",
+					CredentialsSecretRef: &kargoapi.SecretReference{
+						Namespace: "kargo",
+						Name:      "default-credentials",
+					},
+				},
+				{
+					Host: "docker.io",
+					CredentialsSecretRef: &kargoapi.SecretReference{
+						Namespace: "kargo",
+						Name:      "default-credentials",
+					},
+				},
+				{
+					Host: "quay.io",
+					CredentialsSecretRef: &kargoapi.SecretReference{
+						Namespace: "kargo",
+						Name:      "missing",
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		objects  []client.Object
+		repoURL  string
+		expected string
+	}{
+		{
+			name:    "no ClusterConfig",
+			objects: []client.Object{defaultCredentials},
+			repoURL: "ghcr.io/akuity/kargo",
+		},
+		{
+			name:     "host with default credentials",
+			objects:  []client.Object{clusterConfig, defaultCredentials},
+			repoURL:  "ghcr.io/akuity/kargo",
+			expected: "host-default",
+		},
+		{
+			name:     "implicit Docker Hub host",
+			objects:  []client.Object{clusterConfig, defaultCredentials},
+			repoURL:  "nginx",
+			expected: "host-default",
+		},
+		{
+			name:    "host without settings",
+			objects: []client.Object{clusterConfig, defaultCredentials},
+			repoURL: "registry.example.com/akuity/kargo",
+		},
+		{
+			name:    "referenced Secret not found",
+			objects: []client.Object{clusterConfig, defaultCredentials},
+			repoURL: "quay.io/akuity/kargo",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			creds, found, err := NewKubernetesDatabase(
+				fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(testCase.objects...).
+					Build(),
+				KubernetesDatabaseConfig{},
+			).Get(
+				context.Background(),
+				testProjectNamespace,
+				TypeImage,
+				testCase.repoURL,
+			)
+			require.NoError(t, err)
+			if testCase.expected == "" {
+				require.False(t, found)
+				require.Empty(t, creds)
+				return
+			}
+			require.True(t, found)
+			require.Equal(t, testCase.expected, creds.Username)
+		})
+	}
+}
+
+func TestRepoHost(t *testing.T) {
+	testCases := []struct {
+		credType Type
+		repoURL  string
+		expected string
+	}{
+		{TypeGit, "https://github.com/akuity/kargo.git", "github.com"},
+		{TypeGit, "https://git.example.com:8443/akuity/kargo.git", "git.example.com:8443"},
+		{TypeGit, "git@github.com:akuity/kargo.git", "github.com"},
+		{TypeGit, "not a url", ""},
+		{TypeImage, "nginx", "index.docker.io"},
+		{TypeImage, "library/nginx", "index.docker.io"},
+		{TypeImage, "ghcr.io/akuity/kargo", "ghcr.io"},
+		{TypeImage, "localhost/kargo", "localhost"},
+		{TypeImage, "registry:5000/kargo", "registry:5000"},
+		{TypeHelm, "oci://ghcr.io/akuity/kargo-charts/kargo", "ghcr.io"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.repoURL, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				repoHost(testCase.credType, testCase.repoURL),
+			)
 		})
 	}
 }
@@ -0,0 +1,125 @@
+package credentials
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/credentials/kms"
+)
+
+// encryptedFields are the fields of a credentials Secret that are encrypted
+// by EncryptSecret. Other fields, such as the repository URL, must remain in
+// plaintext so that the Secret can be matched to a repository.
+var encryptedFields = []string{FieldPassword, FieldSSHPrivateKey}
+
+// IsEncrypted returns a bool indicating whether the sensitive fields of the
+// provided credentials Secret have been encrypted by EncryptSecret.
+func IsEncrypted(secret *corev1.Secret) bool {
+	_, ok := secret.Annotations[kargoapi.AnnotationKeyEncryptedDataKey]
+	return ok
+}
+
+// EncryptSecret envelope-encrypts the sensitive fields of the provided
+// credentials Secret in place. Each field is encrypted with AES-256-GCM using
+// a freshly generated data encryption key, which is in turn encrypted using
+// the provided key management service and recorded in the
+// AnnotationKeyEncryptedDataKey annotation. It returns an error if the Secret
+// is already encrypted.
+func EncryptSecret(ctx context.Context, kmsSvc kms.Service, secret *corev1.Secret) error {
+	if IsEncrypted(secret) {
+		return errors.New("secret is already encrypted")
+	}
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return fmt.Errorf("error generating data encryption key: %w", err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return err
+	}
+	encryptedDataKey, err := kmsSvc.Encrypt(ctx, dataKey)
+	if err != nil {
+		return fmt.Errorf("error encrypting data encryption key: %w", err)
+	}
+	for _, field := range encryptedFields {
+		value, ok := secret.Data[field]
+		if !ok {
+			continue
+		}
+		nonce := make([]byte, aead.NonceSize())
+		if _, err = rand.Read(nonce); err != nil {
+			return fmt.Errorf("error generating nonce: %w", err)
+		}
+		// The name of the field is authenticated, so that encrypted values
+		// cannot be swapped between fields.
+		secret.Data[field] = aead.Seal(nonce, nonce, value, []byte(field))
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string, 1)
+	}
+	secret.Annotations[kargoapi.AnnotationKeyEncryptedDataKey] =
+		base64.StdEncoding.EncodeToString(encryptedDataKey)
+	return nil
+}
+
+// DecryptSecret decrypts the sensitive fields of the provided credentials
+// Secret in place, using the provided key management service to decrypt the
+// data encryption key, and removes the AnnotationKeyEncryptedDataKey
+// annotation. It is a no-op if the Secret is not encrypted.
+func DecryptSecret(ctx context.Context, kmsSvc kms.Service, secret *corev1.Secret) error {
+	if !IsEncrypted(secret) {
+		return nil
+	}
+	if kmsSvc == nil {
+		return errors.New("secret is encrypted, but no key management service is configured")
+	}
+	encryptedDataKey, err := base64.StdEncoding.DecodeString(
+		secret.Annotations[kargoapi.AnnotationKeyEncryptedDataKey],
+	)
+	if err != nil {
+		return fmt.Errorf("error decoding data encryption key: %w", err)
+	}
+	dataKey, err := kmsSvc.Decrypt(ctx, encryptedDataKey)
+	if err != nil {
+		return fmt.Errorf("error decrypting data encryption key: %w", err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return err
+	}
+	for _, field := range encryptedFields {
+		value, ok := secret.Data[field]
+		if !ok {
+			continue
+		}
+		if len(value) < aead.NonceSize() {
+			return fmt.Errorf("encrypted value of field %q is too short", field)
+		}
+		nonce, ciphertext := value[:aead.NonceSize()], value[aead.NonceSize():]
+		if secret.Data[field], err = aead.Open(nil, nonce, ciphertext, []byte(field)); err != nil {
+			return fmt.Errorf("error decrypting field %q: %w", field, err)
+		}
+	}
+	delete(secret.Annotations, kargoapi.AnnotationKeyEncryptedDataKey)
+	return nil
+}
+
+func newAEAD(dataKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing cipher: %w", err)
+	}
+	return aead, nil
+}
@@ -0,0 +1,78 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/credentials/kms"
+)
+
+func TestEncryptAndDecryptSecret(t *testing.T) {
+	kmsSvc := &kms.FakeService{
+		EncryptFn: func(_ context.Context, plaintext []byte) ([]byte, error) {
+			return append([]byte("wrapped:"), plaintext...), nil
+		},
+		DecryptFn: func(_ context.Context, ciphertext []byte) ([]byte, error) {
+			return ciphertext[len("wrapped:"):], nil
+		},
+	}
+	original := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "fake-secret",
+			Annotations: map[string]string{kargoapi.AnnotationKeyDescription: "fake"},
+		},
+		Data: map[string][]byte{
+			FieldRepoURL:       []byte("https://github.com/example/repo"),
+			FieldUsername:      []byte("fake-username"),
+			FieldPassword:      []byte("fake-password"),
+			FieldSSHPrivateKey: []byte("fake-key"),
+		},
+	}
+
+	secret := original.DeepCopy()
+	require.NoError(t, EncryptSecret(context.Background(), kmsSvc, secret))
+	require.True(t, IsEncrypted(secret))
+	require.Contains(t, secret.Annotations, kargoapi.AnnotationKeyEncryptedDataKey)
+	require.Equal(t, original.Data[FieldRepoURL], secret.Data[FieldRepoURL])
+	require.Equal(t, original.Data[FieldUsername], secret.Data[FieldUsername])
+	require.NotContains(t, string(secret.Data[FieldPassword]), "fake-password")
+	require.NotContains(t, string(secret.Data[FieldSSHPrivateKey]), "fake-key")
+
+	err := EncryptSecret(context.Background(), kmsSvc, secret)
+	require.ErrorContains(t, err, "already encrypted")
+
+	// Encrypted values cannot be swapped between fields
+	swapped := secret.DeepCopy()
+	swapped.Data[FieldPassword], swapped.Data[FieldSSHPrivateKey] =
+		swapped.Data[FieldSSHPrivateKey], swapped.Data[FieldPassword]
+	err = DecryptSecret(context.Background(), kmsSvc, swapped)
+	require.ErrorContains(t, err, "error decrypting field")
+
+	err = DecryptSecret(context.Background(), nil, secret.DeepCopy())
+	require.ErrorContains(t, err, "no key management service is configured")
+
+	err = DecryptSecret(
+		context.Background(),
+		&kms.FakeService{
+			DecryptFn: func(context.Context, []byte) ([]byte, error) {
+				return nil, errors.New("something went wrong")
+			},
+		},
+		secret.DeepCopy(),
+	)
+	require.ErrorContains(t, err, "error decrypting data encryption key")
+	require.ErrorContains(t, err, "something went wrong")
+
+	require.NoError(t, DecryptSecret(context.Background(), kmsSvc, secret))
+	require.Equal(t, original, secret)
+
+	// Decrypting a Secret that is not encrypted is a no-op
+	require.NoError(t, DecryptSecret(context.Background(), nil, secret))
+	require.Equal(t, original, secret)
+}
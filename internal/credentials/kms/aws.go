@@ -0,0 +1,178 @@
+package kms
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/akuity/kargo/internal/os"
+)
+
+// awsService is an implementation of the Service interface backed by AWS Key
+// Management Service. Requests are authenticated using the static credentials
+// found in the standard AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, and
+// (optionally) AWS_SESSION_TOKEN environment variables.
+type awsService struct {
+	client   *http.Client
+	endpoint string
+	region   string
+	keyID    string
+	nowFn    func() time.Time
+	getEnvFn func(string, string) string
+}
+
+func newAWSService(cfg Config) (Service, error) {
+	region := cfg.AWSRegion
+	if region == "" {
+		region = os.GetEnv("AWS_REGION", "")
+	}
+	if region == "" {
+		return nil, errors.New("no AWS region was specified for AWS key management service")
+	}
+	return &awsService{
+		client:   http.DefaultClient,
+		endpoint: fmt.Sprintf("https://kms.%s.amazonaws.com/", region),
+		region:   region,
+		keyID:    cfg.KeyID,
+		nowFn:    time.Now,
+		getEnvFn: os.GetEnv,
+	}, nil
+}
+
+// Encrypt implements Service.
+func (a *awsService) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	res := struct {
+		CiphertextBlob []byte `json:"CiphertextBlob"`
+	}{}
+	if err := a.call(
+		ctx,
+		"TrentService.Encrypt",
+		map[string]any{"KeyId": a.keyID, "Plaintext": plaintext},
+		&res,
+	); err != nil {
+		return nil, fmt.Errorf("error encrypting data using AWS KMS key %q: %w", a.keyID, err)
+	}
+	return res.CiphertextBlob, nil
+}
+
+// Decrypt implements Service.
+func (a *awsService) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	res := struct {
+		Plaintext []byte `json:"Plaintext"`
+	}{}
+	if err := a.call(
+		ctx,
+		"TrentService.Decrypt",
+		map[string]any{"KeyId": a.keyID, "CiphertextBlob": ciphertext},
+		&res,
+	); err != nil {
+		return nil, fmt.Errorf("error decrypting data using AWS KMS key %q: %w", a.keyID, err)
+	}
+	return res.Plaintext, nil
+}
+
+// call invokes the specified operation of the AWS KMS API.
+func (a *awsService) call(ctx context.Context, target string, reqBody, resBody any) error {
+	return postJSON(
+		ctx,
+		a.client,
+		a.endpoint,
+		"application/x-amz-json-1.1",
+		reqBody,
+		resBody,
+		func(req *http.Request, body []byte) error {
+			req.Header.Set("X-Amz-Target", target)
+			return a.sign(req, body)
+		},
+	)
+}
+
+// sign signs the provided request using AWS Signature Version 4.
+func (a *awsService) sign(req *http.Request, body []byte) error {
+	accessKeyID := a.getEnvFn("AWS_ACCESS_KEY_ID", "")
+	secretAccessKey := a.getEnvFn("AWS_SECRET_ACCESS_KEY", "")
+	if accessKeyID == "" || secretAccessKey == "" {
+		return errors.New(
+			"AWS credentials not found in AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY",
+		)
+	}
+
+	now := a.nowFn().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := fmt.Sprintf("%s/%s/kms/aws4_request", now.Format("20060102"), a.region)
+	req.Header.Set("X-Amz-Date", amzDate)
+	if sessionToken := a.getEnvFn("AWS_SESSION_TOKEN", ""); sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for key, values := range req.Header {
+		headers[strings.ToLower(key)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join(
+		[]string{
+			req.Method,
+			path,
+			req.URL.RawQuery,
+			canonicalHeaders.String(),
+			signedHeaders,
+			hashHex(body),
+		},
+		"\n",
+	)
+	stringToSign := strings.Join(
+		[]string{"AWS4-HMAC-SHA256", amzDate, scope, hashHex([]byte(canonicalRequest))},
+		"\n",
+	)
+
+	key := []byte("AWS4" + secretAccessKey)
+	for _, part := range []string{now.Format("20060102"), a.region, "kms", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set(
+		"Authorization",
+		fmt.Sprintf(
+			"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+			accessKeyID,
+			scope,
+			signedHeaders,
+			signature,
+		),
+	)
+	return nil
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,78 @@
+package kms
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAWSService(t *testing.T) {
+	var target, auth, sessionToken string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target = r.Header.Get("X-Amz-Target")
+		auth = r.Header.Get("Authorization")
+		sessionToken = r.Header.Get("X-Amz-Security-Token")
+		body, _ := io.ReadAll(r.Body)
+		req := map[string][]byte{}
+		_ = json.Unmarshal(body, &req)
+		switch target {
+		case "TrentService.Encrypt":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"CiphertextBlob": append([]byte("encrypted:"), req["Plaintext"]...),
+			})
+		case "TrentService.Decrypt":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"Plaintext": req["CiphertextBlob"][len("encrypted:"):],
+			})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"UnknownOperationException"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	env := map[string]string{
+		"AWS_ACCESS_KEY_ID":     "AKIDEXAMPLE",
+		"AWS_SECRET_ACCESS_KEY": "fake-secret",
+		"AWS_SESSION_TOKEN":     "fake-session-token",
+	}
+	svc := &awsService{
+		client:   srv.Client(),
+		endpoint: srv.URL + "/",
+		region:   "us-east-1",
+		keyID:    "alias/kargo",
+		nowFn: func() time.Time {
+			return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		},
+		getEnvFn: func(key, _ string) string {
+			return env[key]
+		},
+	}
+
+	ciphertext, err := svc.Encrypt(context.Background(), []byte("data key"))
+	require.NoError(t, err)
+	require.Equal(t, "TrentService.Encrypt", target)
+	require.Equal(t, []byte("encrypted:data key"), ciphertext)
+	require.Contains(
+		t,
+		auth,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240102/us-east-1/kms/aws4_request, "+
+			"SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target, Signature=",
+	)
+	require.Equal(t, "fake-session-token", sessionToken)
+
+	plaintext, err := svc.Decrypt(context.Background(), ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, "TrentService.Decrypt", target)
+	require.Equal(t, []byte("data key"), plaintext)
+
+	delete(env, "AWS_SECRET_ACCESS_KEY")
+	_, err = svc.Encrypt(context.Background(), []byte("data key"))
+	require.ErrorContains(t, err, "AWS credentials not found")
+}
@@ -0,0 +1,24 @@
+package kms
+
+import "context"
+
+// FakeService is a mock implementation of the Service interface that is used
+// to facilitate unit testing.
+type FakeService struct {
+	EncryptFn func(ctx context.Context, plaintext []byte) ([]byte, error)
+	DecryptFn func(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+func (f *FakeService) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	if f.EncryptFn == nil {
+		return plaintext, nil
+	}
+	return f.EncryptFn(ctx, plaintext)
+}
+
+func (f *FakeService) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	if f.DecryptFn == nil {
+		return ciphertext, nil
+	}
+	return f.DecryptFn(ctx, ciphertext)
+}
@@ -0,0 +1,73 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// gcpScope is the OAuth2 scope required to use Google Cloud Key Management
+// Service.
+const gcpScope = "https://www.googleapis.com/auth/cloudkms"
+
+// gcpService is an implementation of the Service interface backed by Google
+// Cloud Key Management Service. Requests are authenticated using Application
+// Default Credentials, e.g. those of a workload identity.
+type gcpService struct {
+	client   *http.Client
+	endpoint string
+	keyName  string
+}
+
+func newGCPService(cfg Config) (Service, error) {
+	tokenSource, err := google.DefaultTokenSource(context.Background(), gcpScope)
+	if err != nil {
+		return nil, fmt.Errorf("error finding Google Cloud credentials: %w", err)
+	}
+	return &gcpService{
+		client:   oauth2.NewClient(context.Background(), tokenSource),
+		endpoint: "https://cloudkms.googleapis.com",
+		keyName:  cfg.KeyID,
+	}, nil
+}
+
+// Encrypt implements Service.
+func (g *gcpService) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	res := struct {
+		Ciphertext []byte `json:"ciphertext"`
+	}{}
+	if err := postJSON(
+		ctx,
+		g.client,
+		fmt.Sprintf("%s/v1/%s:encrypt", g.endpoint, g.keyName),
+		"application/json",
+		map[string]any{"plaintext": plaintext},
+		&res,
+		nil,
+	); err != nil {
+		return nil, fmt.Errorf("error encrypting data using Google Cloud KMS key %q: %w", g.keyName, err)
+	}
+	return res.Ciphertext, nil
+}
+
+// Decrypt implements Service.
+func (g *gcpService) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	res := struct {
+		Plaintext []byte `json:"plaintext"`
+	}{}
+	if err := postJSON(
+		ctx,
+		g.client,
+		fmt.Sprintf("%s/v1/%s:decrypt", g.endpoint, g.keyName),
+		"application/json",
+		map[string]any{"ciphertext": ciphertext},
+		&res,
+		nil,
+	); err != nil {
+		return nil, fmt.Errorf("error decrypting data using Google Cloud KMS key %q: %w", g.keyName, err)
+	}
+	return res.Plaintext, nil
+}
@@ -0,0 +1,55 @@
+package kms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGCPService(t *testing.T) {
+	const keyName = "projects/p/locations/global/keyRings/r/cryptoKeys/k"
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		req := map[string][]byte{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch r.URL.Path {
+		case "/v1/" + keyName + ":encrypt":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"ciphertext": append([]byte("encrypted:"), req["plaintext"]...),
+			})
+		case "/v1/" + keyName + ":decrypt":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"plaintext": req["ciphertext"][len("encrypted:"):],
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404}}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc := &gcpService{
+		client:   srv.Client(),
+		endpoint: srv.URL,
+		keyName:  keyName,
+	}
+
+	ciphertext, err := svc.Encrypt(context.Background(), []byte("data key"))
+	require.NoError(t, err)
+	require.Equal(t, "/v1/"+keyName+":encrypt", path)
+	require.Equal(t, []byte("encrypted:data key"), ciphertext)
+
+	plaintext, err := svc.Decrypt(context.Background(), ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, "/v1/"+keyName+":decrypt", path)
+	require.Equal(t, []byte("data key"), plaintext)
+
+	svc.keyName = "bogus"
+	_, err = svc.Decrypt(context.Background(), ciphertext)
+	require.ErrorContains(t, err, "received unexpected HTTP 404")
+}
@@ -0,0 +1,251 @@
+package kms
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+// Provider is a string type used to represent a key management service
+// provider.
+type Provider string
+
+const (
+	// ProviderAWS represents AWS Key Management Service.
+	ProviderAWS Provider = "aws"
+	// ProviderGCP represents Google Cloud Key Management Service.
+	ProviderGCP Provider = "gcp"
+	// ProviderVault represents the transit secrets engine of HashiCorp Vault.
+	ProviderVault Provider = "vault"
+)
+
+// Service is an interface for a key management service that encrypts and
+// decrypts small amounts of data, such as data encryption keys, using a key
+// that never leaves the service.
+type Service interface {
+	// Encrypt encrypts the provided plaintext.
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+	// Decrypt decrypts the provided ciphertext, which must have been returned
+	// by Encrypt.
+	Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error)
+}
+
+// Config represents configuration for a key management service.
+type Config struct {
+	// Provider is the key management service provider. When empty, no key
+	// management service is used.
+	Provider Provider `envconfig:"CREDENTIALS_KMS_PROVIDER" default:""`
+	// KeyID identifies the key used to encrypt and decrypt data. For AWS, this
+	// is the ID, ARN, or alias of a key. For GCP, this is the resource name of
+	// a key, e.g. projects/p/locations/l/keyRings/r/cryptoKeys/k. For Vault,
+	// this is the name of a key in the transit secrets engine.
+	KeyID string `envconfig:"CREDENTIALS_KMS_KEY_ID" default:""`
+	// AWSRegion is the AWS region of the key. When empty, the value of the
+	// AWS_REGION environment variable is used.
+	AWSRegion string `envconfig:"CREDENTIALS_KMS_AWS_REGION" default:""`
+	// VaultAddress is the address of the Vault server, e.g.
+	// https://vault.example.com:8200.
+	VaultAddress string `envconfig:"CREDENTIALS_KMS_VAULT_ADDRESS" default:""`
+	// VaultToken is the token used to authenticate to the Vault server.
+	VaultToken string `envconfig:"CREDENTIALS_KMS_VAULT_TOKEN" default:""`
+	// VaultTransitMount is the path at which the transit secrets engine is
+	// mounted in Vault.
+	VaultTransitMount string `envconfig:"CREDENTIALS_KMS_VAULT_TRANSIT_MOUNT" default:"transit"`
+}
+
+// ConfigFromEnv returns a Config populated from environment variables.
+func ConfigFromEnv() Config {
+	cfg := Config{}
+	envconfig.MustProcess("", &cfg)
+	return cfg
+}
+
+// NewService returns a Service for the provider specified by the provided
+// Config. It returns nil if the Config does not specify a provider.
+func NewService(cfg Config) (Service, error) {
+	if cfg.Provider == "" {
+		return nil, nil
+	}
+	if cfg.KeyID == "" {
+		return nil, fmt.Errorf("no key ID was specified for %s key management service", cfg.Provider)
+	}
+	switch cfg.Provider {
+	case ProviderAWS:
+		return newAWSService(cfg)
+	case ProviderGCP:
+		return newGCPService(cfg)
+	case ProviderVault:
+		return newVaultService(cfg)
+	default:
+		return nil, fmt.Errorf("key management service provider %q is unsupported", cfg.Provider)
+	}
+}
+
+// postJSON sends the provided request body, encoded as JSON, to the provided
+// URL and decodes the JSON response into the provided response body. The
+// prepare function, if non-nil, is invoked to set any headers of the request
+// before it is sent.
+func postJSON(
+	ctx context.Context,
+	client *http.Client,
+	url string,
+	contentType string,
+	reqBody any,
+	resBody any,
+	prepare func(req *http.Request, body []byte) error,
+) error {
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return fmt.Errorf("error encoding request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error preparing HTTP/S request to %q: %w", url, err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if prepare != nil {
+		if err = prepare(req, body); err != nil {
+			return err
+		}
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request to %q: %w", url, err)
+	}
+	defer res.Body.Close()
+	resBytes, err := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("error reading response from %q: %w", url, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf(
+			"received unexpected HTTP %d from %q: %s",
+			res.StatusCode,
+			url,
+			bytes.TrimSpace(resBytes),
+		)
+	}
+	if err = json.Unmarshal(resBytes, resBody); err != nil {
+		return fmt.Errorf("error decoding response from %q: %w", url, err)
+	}
+	return nil
+}
+
+const (
+	// maxCachedPlaintexts is the maximum number of plaintexts a cachingService
+	// retains. The least recently used plaintext is evicted once it is
+	// exceeded.
+	maxCachedPlaintexts = 1024
+	// cachedPlaintextTTL is how long a cachingService retains a plaintext, so
+	// that data encryption keys do not linger in memory indefinitely and that
+	// revoking access to a key in the key management service takes effect
+	// eventually.
+	cachedPlaintextTTL = time.Hour
+)
+
+// cachingService is an implementation of the Service interface that wraps
+// another Service and remembers the plaintext of what it has recently
+// decrypted, so that frequently decrypted data, such as data encryption keys,
+// does not require a round trip to the key management service every time. It
+// retains at most maxEntries plaintexts, each for no longer than ttl.
+type cachingService struct {
+	Service
+	maxEntries int
+	ttl        time.Duration
+	nowFn      func() time.Time
+
+	mu sync.Mutex
+	// entries indexes the elements of lru by ciphertext.
+	entries map[string]*list.Element
+	// lru holds a *cachedPlaintext for each entry, from the most to the least
+	// recently used.
+	lru *list.List
+}
+
+// cachedPlaintext is a plaintext retained by a cachingService.
+type cachedPlaintext struct {
+	ciphertext string
+	plaintext  []byte
+	expiresAt  time.Time
+}
+
+// NewCachingService returns a Service that decrypts using the provided
+// Service, but caches the results in memory. It returns nil if the provided
+// Service is nil.
+func NewCachingService(svc Service) Service {
+	if svc == nil {
+		return nil
+	}
+	return &cachingService{
+		Service:    svc,
+		maxEntries: maxCachedPlaintexts,
+		ttl:        cachedPlaintextTTL,
+		nowFn:      time.Now,
+		entries:    map[string]*list.Element{},
+		lru:        list.New(),
+	}
+}
+
+// Decrypt implements Service.
+func (c *cachingService) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	if plaintext, ok := c.get(string(ciphertext)); ok {
+		return plaintext, nil
+	}
+	plaintext, err := c.Service.Decrypt(ctx, ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	c.add(string(ciphertext), plaintext)
+	return plaintext, nil
+}
+
+// get returns the cached plaintext of the provided ciphertext, if there is one
+// that has not expired, and marks it as the most recently used.
+func (c *cachingService) get(ciphertext string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[ciphertext]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cachedPlaintext) // nolint: forcetypeassert
+	if !c.nowFn().Before(entry.expiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return entry.plaintext, true
+}
+
+// add caches the provided plaintext of the provided ciphertext, evicting the
+// least recently used plaintexts if the cache is full.
+func (c *cachingService) add(ciphertext string, plaintext []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[ciphertext]; ok {
+		c.remove(elem)
+	}
+	c.entries[ciphertext] = c.lru.PushFront(&cachedPlaintext{
+		ciphertext: ciphertext,
+		plaintext:  plaintext,
+		expiresAt:  c.nowFn().Add(c.ttl),
+	})
+	for c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove removes the provided element from the cache. The caller must hold
+// c.mu.
+func (c *cachingService) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*cachedPlaintext).ciphertext) // nolint: forcetypeassert
+}
@@ -0,0 +1,167 @@
+package kms
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewService(t *testing.T) {
+	testCases := []struct {
+		name       string
+		cfg        Config
+		assertions func(*testing.T, Service, error)
+	}{
+		{
+			name: "no provider",
+			assertions: func(t *testing.T, svc Service, err error) {
+				require.NoError(t, err)
+				require.Nil(t, svc)
+			},
+		},
+		{
+			name: "no key ID",
+			cfg:  Config{Provider: ProviderVault},
+			assertions: func(t *testing.T, _ Service, err error) {
+				require.ErrorContains(t, err, "no key ID was specified")
+			},
+		},
+		{
+			name: "unsupported provider",
+			cfg:  Config{Provider: "bogus", KeyID: "fake-key"},
+			assertions: func(t *testing.T, _ Service, err error) {
+				require.ErrorContains(t, err, `provider "bogus" is unsupported`)
+			},
+		},
+		{
+			name: "AWS without region",
+			cfg:  Config{Provider: ProviderAWS, KeyID: "fake-key"},
+			assertions: func(t *testing.T, _ Service, err error) {
+				require.ErrorContains(t, err, "no AWS region was specified")
+			},
+		},
+		{
+			name: "AWS",
+			cfg:  Config{Provider: ProviderAWS, KeyID: "fake-key", AWSRegion: "us-west-2"},
+			assertions: func(t *testing.T, svc Service, err error) {
+				require.NoError(t, err)
+				require.IsType(t, &awsService{}, svc)
+				require.Equal(t, "https://kms.us-west-2.amazonaws.com/", svc.(*awsService).endpoint) // nolint: forcetypeassert
+			},
+		},
+		{
+			name: "Vault without address",
+			cfg:  Config{Provider: ProviderVault, KeyID: "fake-key", VaultToken: "fake-token"},
+			assertions: func(t *testing.T, _ Service, err error) {
+				require.ErrorContains(t, err, "no Vault address was specified")
+			},
+		},
+		{
+			name: "Vault without token",
+			cfg: Config{
+				Provider:     ProviderVault,
+				KeyID:        "fake-key",
+				VaultAddress: "https://vault.example.com",
+			},
+			assertions: func(t *testing.T, _ Service, err error) {
+				require.ErrorContains(t, err, "no Vault token was specified")
+			},
+		},
+		{
+			name: "Vault",
+			cfg: Config{
+				Provider:          ProviderVault,
+				KeyID:             "fake-key",
+				VaultAddress:      "https://vault.example.com/",
+				VaultToken:        "fake-token",
+				VaultTransitMount: "transit",
+			},
+			assertions: func(t *testing.T, svc Service, err error) {
+				require.NoError(t, err)
+				require.IsType(t, &vaultService{}, svc)
+				require.Equal(t, "https://vault.example.com", svc.(*vaultService).address) // nolint: forcetypeassert
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Setenv("AWS_REGION", "")
+			svc, err := NewService(testCase.cfg)
+			testCase.assertions(t, svc, err)
+		})
+	}
+}
+
+func TestCachingService(t *testing.T) {
+	require.Nil(t, NewCachingService(nil))
+
+	var calls int
+	svc := NewCachingService(&FakeService{
+		DecryptFn: func(_ context.Context, ciphertext []byte) ([]byte, error) {
+			calls++
+			if string(ciphertext) == "bogus" {
+				return nil, errors.New("something went wrong")
+			}
+			return append([]byte("decrypted:"), ciphertext...), nil
+		},
+	})
+	for range 2 {
+		plaintext, err := svc.Decrypt(context.Background(), []byte("foo"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("decrypted:foo"), plaintext)
+	}
+	require.Equal(t, 1, calls)
+
+	// Errors are not cached
+	for range 2 {
+		_, err := svc.Decrypt(context.Background(), []byte("bogus"))
+		require.ErrorContains(t, err, "something went wrong")
+	}
+	require.Equal(t, 3, calls)
+
+	// Encryption is not cached
+	ciphertext, err := svc.Encrypt(context.Background(), []byte("foo"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("foo"), ciphertext)
+}
+
+func TestCachingServiceBounds(t *testing.T) {
+	var calls int
+	svc := NewCachingService(&FakeService{
+		DecryptFn: func(_ context.Context, ciphertext []byte) ([]byte, error) {
+			calls++
+			return ciphertext, nil
+		},
+	}).(*cachingService) // nolint: forcetypeassert
+	now := time.Now()
+	svc.nowFn = func() time.Time {
+		return now
+	}
+	svc.maxEntries = 2
+	decrypt := func(ciphertext string) {
+		t.Helper()
+		_, err := svc.Decrypt(context.Background(), []byte(ciphertext))
+		require.NoError(t, err)
+	}
+
+	// The least recently used plaintext is evicted when the cache is full
+	decrypt("a")
+	decrypt("b")
+	decrypt("a")
+	decrypt("c")
+	require.Equal(t, 3, calls)
+	require.Len(t, svc.entries, 2)
+	decrypt("a")
+	require.Equal(t, 3, calls)
+	decrypt("b")
+	require.Equal(t, 4, calls)
+
+	// Plaintexts expire after the TTL
+	now = now.Add(svc.ttl)
+	decrypt("b")
+	require.Equal(t, 5, calls)
+	require.Equal(t, svc.lru.Len(), len(svc.entries))
+}
@@ -0,0 +1,96 @@
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// vaultService is an implementation of the Service interface backed by the
+// transit secrets engine of HashiCorp Vault.
+type vaultService struct {
+	client  *http.Client
+	address string
+	token   string
+	mount   string
+	keyName string
+}
+
+func newVaultService(cfg Config) (Service, error) {
+	if cfg.VaultAddress == "" {
+		return nil, errors.New("no Vault address was specified for Vault key management service")
+	}
+	if cfg.VaultToken == "" {
+		return nil, errors.New("no Vault token was specified for Vault key management service")
+	}
+	return &vaultService{
+		client:  http.DefaultClient,
+		address: strings.TrimSuffix(cfg.VaultAddress, "/"),
+		token:   cfg.VaultToken,
+		mount:   strings.Trim(cfg.VaultTransitMount, "/"),
+		keyName: cfg.KeyID,
+	}, nil
+}
+
+// Encrypt implements Service. The ciphertext returned is the one produced by
+// Vault, e.g. vault:v1:<base64-encoded data>, which includes the version of
+// the key used, so that data encrypted before the key was rotated can still be
+// decrypted.
+func (v *vaultService) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	res := struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}{}
+	if err := v.call(
+		ctx,
+		"encrypt",
+		map[string]any{"plaintext": base64.StdEncoding.EncodeToString(plaintext)},
+		&res,
+	); err != nil {
+		return nil, fmt.Errorf("error encrypting data using Vault transit key %q: %w", v.keyName, err)
+	}
+	return []byte(res.Data.Ciphertext), nil
+}
+
+// Decrypt implements Service.
+func (v *vaultService) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	res := struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}{}
+	if err := v.call(
+		ctx,
+		"decrypt",
+		map[string]any{"ciphertext": string(ciphertext)},
+		&res,
+	); err != nil {
+		return nil, fmt.Errorf("error decrypting data using Vault transit key %q: %w", v.keyName, err)
+	}
+	plaintext, err := base64.StdEncoding.DecodeString(res.Data.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding plaintext returned by Vault: %w", err)
+	}
+	return plaintext, nil
+}
+
+// call invokes the specified operation of the transit secrets engine using
+// the configured key.
+func (v *vaultService) call(ctx context.Context, operation string, reqBody, resBody any) error {
+	return postJSON(
+		ctx,
+		v.client,
+		fmt.Sprintf("%s/v1/%s/%s/%s", v.address, v.mount, operation, v.keyName),
+		"application/json",
+		reqBody,
+		resBody,
+		func(req *http.Request, _ []byte) error {
+			req.Header.Set("X-Vault-Token", v.token)
+			return nil
+		},
+	)
+}
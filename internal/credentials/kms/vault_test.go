@@ -0,0 +1,62 @@
+package kms
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultService(t *testing.T) {
+	var path, token string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		token = r.Header.Get("X-Vault-Token")
+		req := map[string]string{}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/kargo":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]string{"ciphertext": "vault:v1:" + req["plaintext"]},
+			})
+		case "/v1/transit/decrypt/kargo":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]string{
+					"plaintext": strings.TrimPrefix(req["ciphertext"], "vault:v1:"),
+				},
+			})
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	svc := &vaultService{
+		client:  srv.Client(),
+		address: srv.URL,
+		token:   "fake-token",
+		mount:   "transit",
+		keyName: "kargo",
+	}
+
+	ciphertext, err := svc.Encrypt(context.Background(), []byte("data key"))
+	require.NoError(t, err)
+	require.Equal(t, "/v1/transit/encrypt/kargo", path)
+	require.Equal(t, "fake-token", token)
+	require.Equal(t, "vault:v1:ZGF0YSBrZXk=", string(ciphertext))
+
+	plaintext, err := svc.Decrypt(context.Background(), ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, "/v1/transit/decrypt/kargo", path)
+	require.Equal(t, []byte("data key"), plaintext)
+
+	svc.keyName = "bogus"
+	_, err = svc.Encrypt(context.Background(), []byte("data key"))
+	require.ErrorContains(t, err, "received unexpected HTTP 403")
+	require.ErrorContains(t, err, "permission denied")
+}
@@ -0,0 +1,336 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/controller/git"
+	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
+	"github.com/akuity/kargo/internal/helm"
+	"github.com/akuity/kargo/internal/image"
+)
+
+// FindUnavailableArtifacts returns a description of each artifact referenced
+// by the provided Freight that can no longer be found upstream. Artifacts that
+// were just discovered are known to be available and are not checked again.
+// Artifacts from repositories the Warehouse no longer subscribes to are not
+// checked at all.
+func (d *Discoverer) FindUnavailableArtifacts(
+	ctx context.Context,
+	warehouse *kargoapi.Warehouse,
+	freight *kargoapi.Freight,
+	discovered *kargoapi.DiscoveredArtifacts,
+) ([]string, error) {
+	if discovered == nil {
+		discovered = &kargoapi.DiscoveredArtifacts{}
+	}
+	var unavailable []string
+
+	for _, commit := range freight.Commits {
+		sub := getGitSubscription(warehouse, commit.RepoURL)
+		if sub == nil || isCommitDiscovered(discovered, commit) {
+			continue
+		}
+		available, err := d.isCommitAvailableFn(ctx, warehouse.Namespace, *sub, commit)
+		if err != nil {
+			return nil, err
+		}
+		if available {
+			continue
+		}
+		if commit.Tag != "" {
+			unavailable = append(unavailable, fmt.Sprintf(
+				"tag %q of Git repository %q no longer exists or no longer references commit %q",
+				commit.Tag,
+				commit.RepoURL,
+				commit.ID,
+			))
+		} else {
+			unavailable = append(unavailable, fmt.Sprintf(
+				"commit %q is no longer reachable in Git repository %q",
+				commit.ID,
+				commit.RepoURL,
+			))
+		}
+	}
+
+	for _, img := range freight.Images {
+		sub := getImageSubscription(warehouse, img.RepoURL)
+		if sub == nil || isImageDiscovered(discovered, img) {
+			continue
+		}
+		available, err := d.isImageAvailableFn(ctx, warehouse.Namespace, *sub, img)
+		if err != nil {
+			return nil, err
+		}
+		if !available {
+			ref := img.RepoURL
+			if img.Tag != "" {
+				ref += ":" + img.Tag
+			}
+			if img.Digest != "" {
+				ref += "@" + img.Digest
+			}
+			unavailable = append(unavailable, fmt.Sprintf("image %q is no longer available", ref))
+		}
+	}
+
+	for _, chart := range freight.Charts {
+		sub := getChartSubscription(warehouse, chart.RepoURL, chart.Name)
+		if sub == nil || isChartDiscovered(discovered, chart) {
+			continue
+		}
+		available, err := d.isChartAvailableFn(ctx, warehouse.Namespace, *sub, chart)
+		if err != nil {
+			return nil, err
+		}
+		if !available {
+			unavailable = append(unavailable, fmt.Sprintf(
+				"version %q of chart %q from repository %q is no longer available",
+				chart.Version,
+				chart.Name,
+				chart.RepoURL,
+			))
+		}
+	}
+
+	return unavailable, nil
+}
+
+func getGitSubscription(
+	warehouse *kargoapi.Warehouse,
+	repoURL string,
+) *kargoapi.GitSubscription {
+	for _, sub := range warehouse.Spec.Subscriptions {
+		if sub.Git != nil && sub.Git.RepoURL == repoURL {
+			return sub.Git
+		}
+	}
+	return nil
+}
+
+// getImageSubscription returns the provided Warehouse's subscription to the
+// specified image repository. A subscription whose RepoURL is a pattern
+// matching the repository is returned as if it subscribed to that repository
+// alone.
+func getImageSubscription(
+	warehouse *kargoapi.Warehouse,
+	repoURL string,
+) *kargoapi.ImageSubscription {
+	for _, sub := range warehouse.Spec.Subscriptions {
+		if sub.Image != nil && image.RepoURLMatches(sub.Image.RepoURL, repoURL) {
+			imageSub := *sub.Image
+			imageSub.RepoURL = repoURL
+			return &imageSub
+		}
+	}
+	return nil
+}
+
+func getChartSubscription(
+	warehouse *kargoapi.Warehouse,
+	repoURL string,
+	name string,
+) *kargoapi.ChartSubscription {
+	for _, sub := range warehouse.Spec.Subscriptions {
+		if sub.Chart != nil && sub.Chart.RepoURL == repoURL && sub.Chart.Name == name {
+			return sub.Chart
+		}
+	}
+	return nil
+}
+
+func isCommitDiscovered(discovered *kargoapi.DiscoveredArtifacts, commit kargoapi.GitCommit) bool {
+	for _, result := range discovered.Git {
+		if result.RepoURL != commit.RepoURL {
+			continue
+		}
+		for _, c := range result.Commits {
+			if c.ID == commit.ID && c.Tag == commit.Tag {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isImageDiscovered(discovered *kargoapi.DiscoveredArtifacts, img kargoapi.Image) bool {
+	for _, result := range discovered.Images {
+		if result.RepoURL != img.RepoURL {
+			continue
+		}
+		for _, ref := range result.References {
+			if ref.Tag == img.Tag && ref.Digest == img.Digest {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isChartDiscovered(discovered *kargoapi.DiscoveredArtifacts, chart kargoapi.Chart) bool {
+	for _, result := range discovered.Charts {
+		if result.RepoURL == chart.RepoURL && result.Name == chart.Name &&
+			slices.Contains(result.Versions, chart.Version) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCommitAvailable returns a bool indicating whether the provided commit can
+// still be found in the Git repository of the provided subscription. A commit
+// that was selected by tag is only considered available if that tag still
+// exists and still references the commit. Any other commit is only considered
+// available if it is still reachable from the subscribed branch.
+func (d *Discoverer) isCommitAvailable(
+	ctx context.Context,
+	namespace string,
+	sub kargoapi.GitSubscription,
+	commit kargoapi.GitCommit,
+) (bool, error) {
+	var repoCreds *git.RepoCredentials
+	creds, ok, err := d.credentialsDB.Get(ctx, namespace, credentials.TypeGit, sub.RepoURL)
+	if err != nil {
+		return false, fmt.Errorf(
+			"error obtaining credentials for git repo %q: %w",
+			sub.RepoURL,
+			err,
+		)
+	}
+	if ok {
+		repoCreds = &git.RepoCredentials{
+			Username:      creds.Username,
+			Password:      creds.Password,
+			SSHPrivateKey: creds.SSHPrivateKey,
+		}
+	}
+	branch := sub.Branch
+	if libGit.IsBranchPattern(branch) {
+		// The commit must still be reachable from the matching branch it was
+		// discovered on.
+		branch = commit.Branch
+	}
+	repo, err := d.gitCloneFn(
+		sub.RepoURL,
+		&git.ClientOptions{Credentials: repoCreds},
+		&git.CloneOptions{
+			Branch:                branch,
+			SingleBranch:          true,
+			Filter:                git.FilterBlobless,
+			InsecureSkipTLSVerify: sub.InsecureSkipTLSVerify,
+			InsecureHTTP:          sub.InsecureHTTP,
+		},
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to clone git repo %q: %w", sub.RepoURL, err)
+	}
+	defer repo.Close()
+
+	if commit.Tag != "" {
+		tags, err := d.listTagsFn(repo)
+		if err != nil {
+			return false, fmt.Errorf("error listing tags from git repo %q: %w", sub.RepoURL, err)
+		}
+		for _, tag := range tags {
+			if tag.Tag == commit.Tag {
+				return tag.CommitID == commit.ID, nil
+			}
+		}
+		return false, nil
+	}
+
+	available, err := repo.HasCommit(commit.ID)
+	if err != nil {
+		return false, fmt.Errorf(
+			"error looking up commit %q in git repo %q: %w",
+			commit.ID,
+			sub.RepoURL,
+			err,
+		)
+	}
+	return available, nil
+}
+
+// isImageAvailable returns a bool indicating whether the provided image can
+// still be found in the image repository of the provided subscription.
+func (d *Discoverer) isImageAvailable(
+	ctx context.Context,
+	namespace string,
+	sub kargoapi.ImageSubscription,
+	img kargoapi.Image,
+) (bool, error) {
+	var regCreds *image.Credentials
+	creds, ok, err := d.credentialsDB.Get(ctx, namespace, credentials.TypeImage, sub.RepoURL)
+	if err != nil {
+		return false, fmt.Errorf(
+			"error obtaining credentials for image repo %q: %w",
+			sub.RepoURL,
+			err,
+		)
+	}
+	if ok {
+		regCreds = &image.Credentials{
+			Username: creds.Username,
+			Password: creds.Password,
+		}
+	}
+	available, err := image.IsAvailable(
+		ctx,
+		sub.RepoURL,
+		img.Tag,
+		img.Digest,
+		sub.InsecureSkipTLSVerify,
+		regCreds,
+	)
+	if err != nil {
+		return false, fmt.Errorf(
+			"error checking availability of image from repo %q: %w",
+			sub.RepoURL,
+			err,
+		)
+	}
+	return available, nil
+}
+
+// isChartAvailable returns a bool indicating whether the provided version of a
+// chart can still be found in the chart repository of the provided
+// subscription.
+func (d *Discoverer) isChartAvailable(
+	ctx context.Context,
+	namespace string,
+	sub kargoapi.ChartSubscription,
+	chart kargoapi.Chart,
+) (bool, error) {
+	var helmCreds *helm.Credentials
+	creds, ok, err := d.credentialsDB.Get(ctx, namespace, credentials.TypeHelm, sub.RepoURL)
+	if err != nil {
+		return false, fmt.Errorf(
+			"error obtaining credentials for chart repository %q: %w",
+			sub.RepoURL,
+			err,
+		)
+	}
+	if ok {
+		helmCreds = &helm.Credentials{
+			Username: creds.Username,
+			Password: creds.Password,
+		}
+	}
+	// The subscription's semver constraint is deliberately not applied. The
+	// constraint may have changed since the Freight was created, but that does
+	// not make the chart version any less available.
+	versions, err := d.discoverChartVersionsFn(ctx, sub.RepoURL, sub.Name, "", helmCreds)
+	if err != nil {
+		return false, fmt.Errorf(
+			"error listing versions of chart %q from repository %q: %w",
+			sub.Name,
+			sub.RepoURL,
+			err,
+		)
+	}
+	return slices.Contains(versions, chart.Version), nil
+}
@@ -0,0 +1,150 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestFindUnavailableArtifacts(t *testing.T) {
+	warehouse := &kargoapi.Warehouse{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-namespace",
+			Name:      "fake-warehouse",
+		},
+		Spec: kargoapi.WarehouseSpec{
+			Subscriptions: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{RepoURL: "https://github.com/example/repo"}},
+				{Image: &kargoapi.ImageSubscription{RepoURL: "example/image"}},
+				{Chart: &kargoapi.ChartSubscription{RepoURL: "oci://example.com/chart"}},
+			},
+		},
+	}
+
+	freight := &kargoapi.Freight{
+		Commits: []kargoapi.GitCommit{
+			{RepoURL: "https://github.com/example/repo", ID: "fake-commit"},
+			{RepoURL: "https://github.com/example/unsubscribed", ID: "fake-commit"},
+		},
+		Images: []kargoapi.Image{
+			{RepoURL: "example/image", Tag: "v1.0.0", Digest: "sha256:fake"},
+		},
+		Charts: []kargoapi.Chart{
+			{RepoURL: "oci://example.com/chart", Version: "1.0.0"},
+		},
+	}
+
+	testCases := []struct {
+		name                string
+		discovered          *kargoapi.DiscoveredArtifacts
+		isCommitAvailableFn func(context.Context, string, kargoapi.GitSubscription, kargoapi.GitCommit) (bool, error)
+		isImageAvailableFn  func(context.Context, string, kargoapi.ImageSubscription, kargoapi.Image) (bool, error)
+		isChartAvailableFn  func(context.Context, string, kargoapi.ChartSubscription, kargoapi.Chart) (bool, error)
+		assertions          func(*testing.T, []string, error)
+	}{
+		{
+			name: "error checking commit",
+			isCommitAvailableFn: func(
+				context.Context,
+				string,
+				kargoapi.GitSubscription,
+				kargoapi.GitCommit,
+			) (bool, error) {
+				return false, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, _ []string, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "all artifacts discovered",
+			discovered: &kargoapi.DiscoveredArtifacts{
+				Git: []kargoapi.GitDiscoveryResult{{
+					RepoURL: "https://github.com/example/repo",
+					Commits: []kargoapi.DiscoveredCommit{{ID: "fake-commit"}},
+				}},
+				Images: []kargoapi.ImageDiscoveryResult{{
+					RepoURL: "example/image",
+					References: []kargoapi.DiscoveredImageReference{{
+						Tag:    "v1.0.0",
+						Digest: "sha256:fake",
+					}},
+				}},
+				Charts: []kargoapi.ChartDiscoveryResult{{
+					RepoURL:  "oci://example.com/chart",
+					Versions: []string{"1.0.0"},
+				}},
+			},
+			// No explicit checks should be necessary
+			assertions: func(t *testing.T, unavailable []string, err error) {
+				require.NoError(t, err)
+				require.Empty(t, unavailable)
+			},
+		},
+		{
+			name: "artifacts unavailable",
+			isCommitAvailableFn: func(
+				_ context.Context,
+				_ string,
+				_ kargoapi.GitSubscription,
+				commit kargoapi.GitCommit,
+			) (bool, error) {
+				if commit.RepoURL != "https://github.com/example/repo" {
+					return false, errors.New("unsubscribed repository should not be checked")
+				}
+				return false, nil
+			},
+			isImageAvailableFn: func(
+				context.Context,
+				string,
+				kargoapi.ImageSubscription,
+				kargoapi.Image,
+			) (bool, error) {
+				return false, nil
+			},
+			isChartAvailableFn: func(
+				context.Context,
+				string,
+				kargoapi.ChartSubscription,
+				kargoapi.Chart,
+			) (bool, error) {
+				return false, nil
+			},
+			assertions: func(t *testing.T, unavailable []string, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]string{
+						`commit "fake-commit" is no longer reachable in Git repository ` +
+							`"https://github.com/example/repo"`,
+						`image "example/image:v1.0.0@sha256:fake" is no longer available`,
+						`version "1.0.0" of chart "" from repository ` +
+							`"oci://example.com/chart" is no longer available`,
+					},
+					unavailable,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := &Discoverer{
+				isCommitAvailableFn: testCase.isCommitAvailableFn,
+				isImageAvailableFn:  testCase.isImageAvailableFn,
+				isChartAvailableFn:  testCase.isChartAvailableFn,
+			}
+			unavailable, err := r.FindUnavailableArtifacts(
+				context.Background(),
+				warehouse,
+				freight,
+				testCase.discovered,
+			)
+			testCase.assertions(t, unavailable, err)
+		})
+	}
+}
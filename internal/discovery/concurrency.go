@@ -0,0 +1,53 @@
+package discovery
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+// discoverConcurrently calls the provided function for each of the provided
+// subscriptions, with at most limit calls in progress at any one time, and
+// returns the concatenation of the results of all calls in the order of the
+// subscriptions they were made for. If any call returns an error, the context
+// passed to the calls still in progress is canceled, no further calls are
+// made, and the first error is returned. A limit that is not greater than zero
+// is treated as one, i.e. the calls are made sequentially.
+func discoverConcurrently[T any](
+	ctx context.Context,
+	limit int,
+	subs []kargoapi.RepoSubscription,
+	discover func(context.Context, kargoapi.RepoSubscription) ([]T, error),
+) ([]T, error) {
+	resultsBySub := make([][]T, len(subs))
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(limit, 1))
+	for i, sub := range subs {
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				// Another call already failed
+				return err
+			}
+			results, err := discover(ctx, sub)
+			if err != nil {
+				return err
+			}
+			resultsBySub[i] = results
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	var count int
+	for _, results := range resultsBySub {
+		count += len(results)
+	}
+	results := make([]T, 0, count)
+	for _, subResults := range resultsBySub {
+		results = append(results, subResults...)
+	}
+	return results, nil
+}
@@ -0,0 +1,127 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestDiscoverConcurrently(t *testing.T) {
+	subs := []kargoapi.RepoSubscription{
+		{Image: &kargoapi.ImageSubscription{RepoURL: "fake-repo-1"}},
+		{Chart: &kargoapi.ChartSubscription{RepoURL: "fake-repo-2"}},
+		{Image: &kargoapi.ImageSubscription{RepoURL: "fake-repo-3"}},
+		{Image: &kargoapi.ImageSubscription{RepoURL: "fake-repo-4"}},
+	}
+
+	testCases := []struct {
+		name       string
+		limit      int
+		discover   func(context.Context, kargoapi.RepoSubscription) ([]string, error)
+		assertions func(*testing.T, []string, error)
+	}{
+		{
+			name:  "results are in the order of the subscriptions",
+			limit: len(subs),
+			discover: func(_ context.Context, sub kargoapi.RepoSubscription) ([]string, error) {
+				if sub.Image == nil {
+					return nil, nil
+				}
+				// Make earlier subscriptions finish last
+				switch sub.Image.RepoURL {
+				case "fake-repo-1":
+					time.Sleep(20 * time.Millisecond)
+				case "fake-repo-3":
+					time.Sleep(10 * time.Millisecond)
+				}
+				return []string{sub.Image.RepoURL + "-a", sub.Image.RepoURL + "-b"}, nil
+			},
+			assertions: func(t *testing.T, results []string, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					[]string{
+						"fake-repo-1-a", "fake-repo-1-b",
+						"fake-repo-3-a", "fake-repo-3-b",
+						"fake-repo-4-a", "fake-repo-4-b",
+					},
+					results,
+				)
+			},
+		},
+		{
+			name:  "error discovering",
+			limit: len(subs),
+			discover: func(_ context.Context, sub kargoapi.RepoSubscription) ([]string, error) {
+				if sub.Chart != nil {
+					return nil, errors.New("something went wrong")
+				}
+				return []string{"fake-result"}, nil
+			},
+			assertions: func(t *testing.T, _ []string, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name:  "limit is respected",
+			limit: 2,
+			discover: func() func(context.Context, kargoapi.RepoSubscription) ([]string, error) {
+				var inProgress, maxInProgress atomic.Int32
+				return func(context.Context, kargoapi.RepoSubscription) ([]string, error) {
+					n := inProgress.Add(1)
+					defer inProgress.Add(-1)
+					for {
+						m := maxInProgress.Load()
+						if n <= m || maxInProgress.CompareAndSwap(m, n) {
+							break
+						}
+					}
+					time.Sleep(10 * time.Millisecond)
+					if maxInProgress.Load() > 2 {
+						return nil, errors.New("limit exceeded")
+					}
+					return nil, nil
+				}
+			}(),
+			assertions: func(t *testing.T, results []string, err error) {
+				require.NoError(t, err)
+				require.Empty(t, results)
+			},
+		},
+		{
+			name:  "limit not greater than zero",
+			limit: 0,
+			discover: func() func(context.Context, kargoapi.RepoSubscription) ([]string, error) {
+				var inProgress atomic.Int32
+				return func(context.Context, kargoapi.RepoSubscription) ([]string, error) {
+					defer inProgress.Add(-1)
+					if inProgress.Add(1) > 1 {
+						return nil, errors.New("discovery was not sequential")
+					}
+					time.Sleep(time.Millisecond)
+					return nil, nil
+				}
+			}(),
+			assertions: func(t *testing.T, _ []string, err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			results, err := discoverConcurrently(
+				context.Background(),
+				testCase.limit,
+				subs,
+				testCase.discover,
+			)
+			testCase.assertions(t, results, err)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/controller/git"
+	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
+	"github.com/akuity/kargo/internal/helm"
+	"github.com/akuity/kargo/internal/image"
+)
+
+// noCredentialsHint is appended to warnings about repositories that could not
+// be reached and for which no credentials were found, as missing credentials
+// are a likely cause.
+const noCredentialsHint = " (no credentials were found for this repository)"
+
+// CheckConnectivity attempts to resolve credentials for, and to reach, the
+// repository of every subscription of the provided Warehouse. It returns a
+// warning for each subscription whose repository could not be reached. The
+// Warehouse need not exist in the cluster, which makes this useful for catching
+// problems such as mistyped repository URLs before a Warehouse is applied.
+func (d *Discoverer) CheckConnectivity(
+	ctx context.Context,
+	warehouse *kargoapi.Warehouse,
+) []string {
+	f := field.NewPath("spec", "subscriptions")
+	var warnings []string
+	for i, sub := range warehouse.Spec.Subscriptions {
+		var warning string
+		switch {
+		case sub.Git != nil:
+			warning = d.checkGitConnectivity(ctx, warehouse.Namespace, *sub.Git)
+		case sub.Image != nil:
+			warning = d.checkImageConnectivity(ctx, warehouse.Namespace, *sub.Image)
+		case sub.Chart != nil:
+			warning = d.checkChartConnectivity(ctx, warehouse.Namespace, *sub.Chart)
+		}
+		if warning != "" {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", f.Index(i), warning))
+		}
+	}
+	return warnings
+}
+
+// checkGitConnectivity returns a warning if the Git repository of the provided
+// subscription, or the subscription's branch, cannot be reached. An empty
+// string is returned otherwise.
+func (d *Discoverer) checkGitConnectivity(
+	ctx context.Context,
+	namespace string,
+	sub kargoapi.GitSubscription,
+) string {
+	creds, ok, err := d.credentialsDB.Get(ctx, namespace, credentials.TypeGit, sub.RepoURL)
+	if err != nil {
+		return fmt.Sprintf("error obtaining credentials for git repo %q: %s", sub.RepoURL, err)
+	}
+	var repoCreds *git.RepoCredentials
+	if ok {
+		repoCreds = &git.RepoCredentials{
+			Username:      creds.Username,
+			Password:      creds.Password,
+			SSHPrivateKey: creds.SSHPrivateKey,
+		}
+	}
+	branch := sub.Branch
+	if libGit.IsBranchPattern(branch) {
+		// A pattern may legitimately match no branches at all, so only the
+		// repository itself is checked.
+		branch = ""
+	}
+	if err = d.checkGitRemoteFn(
+		sub.RepoURL,
+		&git.ClientOptions{Credentials: repoCreds},
+		&git.CloneOptions{
+			Branch:                branch,
+			InsecureSkipTLSVerify: sub.InsecureSkipTLSVerify,
+			InsecureHTTP:          sub.InsecureHTTP,
+		},
+	); err != nil {
+		warning := fmt.Sprintf("unable to access git repo %q: %s", sub.RepoURL, err)
+		if !ok {
+			warning += noCredentialsHint
+		}
+		return warning
+	}
+	return ""
+}
+
+// checkImageConnectivity returns a warning if the image repository of the
+// provided subscription cannot be reached or, if the subscription's RepoURL is
+// a pattern, if the matching repositories cannot be listed or there are none.
+// An empty string is returned otherwise.
+func (d *Discoverer) checkImageConnectivity(
+	ctx context.Context,
+	namespace string,
+	sub kargoapi.ImageSubscription,
+) string {
+	creds, ok, err := d.credentialsDB.Get(ctx, namespace, credentials.TypeImage, sub.RepoURL)
+	if err != nil {
+		return fmt.Sprintf("error obtaining credentials for image repo %q: %s", sub.RepoURL, err)
+	}
+	var regCreds *image.Credentials
+	if ok {
+		regCreds = &image.Credentials{
+			Username: creds.Username,
+			Password: creds.Password,
+		}
+	}
+	if image.IsRepoURLPattern(sub.RepoURL) {
+		var repoURLs []string
+		if repoURLs, err = d.listImageRepositoriesFn(
+			ctx,
+			sub.RepoURL,
+			sub.InsecureSkipTLSVerify,
+			regCreds,
+		); err == nil && len(repoURLs) == 0 {
+			return fmt.Sprintf("no image repos matching %q were found", sub.RepoURL)
+		}
+	} else {
+		err = d.checkImageRepositoryFn(
+			ctx,
+			sub.RepoURL,
+			sub.InsecureSkipTLSVerify,
+			regCreds,
+		)
+	}
+	if err != nil {
+		warning := fmt.Sprintf("unable to access image repo %q: %s", sub.RepoURL, err)
+		if !ok {
+			warning += noCredentialsHint
+		}
+		return warning
+	}
+	return ""
+}
+
+// checkChartConnectivity returns a warning if the chart repository of the
+// provided subscription cannot be reached or contains no versions of the
+// subscribed chart satisfying the subscription's semver constraint. An empty
+// string is returned otherwise.
+func (d *Discoverer) checkChartConnectivity(
+	ctx context.Context,
+	namespace string,
+	sub kargoapi.ChartSubscription,
+) string {
+	creds, ok, err := d.credentialsDB.Get(ctx, namespace, credentials.TypeHelm, sub.RepoURL)
+	if err != nil {
+		return fmt.Sprintf(
+			"error obtaining credentials for chart repository %q: %s",
+			sub.RepoURL,
+			err,
+		)
+	}
+	var helmCreds *helm.Credentials
+	if ok {
+		helmCreds = &helm.Credentials{
+			Username: creds.Username,
+			Password: creds.Password,
+		}
+	}
+	versions, err := d.discoverChartVersionsFn(
+		ctx,
+		sub.RepoURL,
+		sub.Name,
+		sub.SemverConstraint,
+		helmCreds,
+	)
+	if err != nil {
+		warning := fmt.Sprintf("unable to access chart repository %q: %s", sub.RepoURL, err)
+		if !ok {
+			warning += noCredentialsHint
+		}
+		return warning
+	}
+	if len(versions) == 0 {
+		return fmt.Sprintf(
+			"no versions of chart %q satisfying the semver constraint were found "+
+				"in repository %q",
+			sub.Name,
+			sub.RepoURL,
+		)
+	}
+	return ""
+}
@@ -0,0 +1,233 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/controller/git"
+	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/helm"
+	"github.com/akuity/kargo/internal/image"
+)
+
+func TestCheckConnectivity(t *testing.T) {
+	testWarehouse := &kargoapi.Warehouse{
+		Spec: kargoapi.WarehouseSpec{
+			Subscriptions: []kargoapi.RepoSubscription{
+				{
+					Git: &kargoapi.GitSubscription{
+						RepoURL: "https://github.com/example/repo.git",
+						Branch:  "main",
+					},
+				},
+				{
+					Image: &kargoapi.ImageSubscription{
+						RepoURL: "example/image",
+					},
+				},
+				{
+					Chart: &kargoapi.ChartSubscription{
+						RepoURL:          "https://charts.example.com",
+						Name:             "fake-chart",
+						SemverConstraint: "^1.0.0",
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		discoverer *Discoverer
+		assertions func(*testing.T, []string)
+	}{
+		{
+			name: "error obtaining credentials",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{
+					GetFn: func(
+						context.Context,
+						string,
+						credentials.Type,
+						string,
+					) (credentials.Credentials, bool, error) {
+						return credentials.Credentials{}, false, errors.New("something went wrong")
+					},
+				},
+			},
+			assertions: func(t *testing.T, warnings []string) {
+				require.Len(t, warnings, 3)
+				for _, warning := range warnings {
+					require.Contains(t, warning, "error obtaining credentials")
+					require.Contains(t, warning, "something went wrong")
+				}
+			},
+		},
+		{
+			name: "repositories cannot be reached",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{
+					GetFn: func(
+						_ context.Context,
+						_ string,
+						credType credentials.Type,
+						_ string,
+					) (credentials.Credentials, bool, error) {
+						// Only Git credentials exist
+						return credentials.Credentials{}, credType == credentials.TypeGit, nil
+					},
+				},
+				checkGitRemoteFn: func(string, *git.ClientOptions, *git.CloneOptions) error {
+					return errors.New("repository not found")
+				},
+				checkImageRepositoryFn: func(context.Context, string, bool, *image.Credentials) error {
+					return errors.New("unauthorized")
+				},
+				discoverChartVersionsFn: func(
+					context.Context,
+					string,
+					string,
+					string,
+					*helm.Credentials,
+				) ([]string, error) {
+					return nil, errors.New("not found")
+				},
+			},
+			assertions: func(t *testing.T, warnings []string) {
+				require.Equal(
+					t,
+					[]string{
+						`spec.subscriptions[0]: unable to access git repo ` +
+							`"https://github.com/example/repo.git": repository not found`,
+						`spec.subscriptions[1]: unable to access image repo ` +
+							`"example/image": unauthorized` + noCredentialsHint,
+						`spec.subscriptions[2]: unable to access chart repository ` +
+							`"https://charts.example.com": not found` + noCredentialsHint,
+					},
+					warnings,
+				)
+			},
+		},
+		{
+			name: "no chart versions satisfy constraint",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				checkGitRemoteFn: func(string, *git.ClientOptions, *git.CloneOptions) error {
+					return nil
+				},
+				checkImageRepositoryFn: func(context.Context, string, bool, *image.Credentials) error {
+					return nil
+				},
+				discoverChartVersionsFn: func(
+					context.Context,
+					string,
+					string,
+					string,
+					*helm.Credentials,
+				) ([]string, error) {
+					return nil, nil
+				},
+			},
+			assertions: func(t *testing.T, warnings []string) {
+				require.Len(t, warnings, 1)
+				require.Contains(t, warnings[0], "spec.subscriptions[2]: no versions of chart")
+			},
+		},
+		{
+			name: "all repositories can be reached",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				checkGitRemoteFn: func(
+					_ string,
+					_ *git.ClientOptions,
+					cloneOpts *git.CloneOptions,
+				) error {
+					if cloneOpts.Branch != "main" {
+						return errors.New("unexpected branch")
+					}
+					return nil
+				},
+				checkImageRepositoryFn: func(context.Context, string, bool, *image.Credentials) error {
+					return nil
+				},
+				discoverChartVersionsFn: func(
+					context.Context,
+					string,
+					string,
+					string,
+					*helm.Credentials,
+				) ([]string, error) {
+					return []string{"1.0.0"}, nil
+				},
+			},
+			assertions: func(t *testing.T, warnings []string) {
+				require.Empty(t, warnings)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				t,
+				testCase.discoverer.CheckConnectivity(context.Background(), testWarehouse),
+			)
+		})
+	}
+}
+
+func TestCheckImageConnectivityWithPattern(t *testing.T) {
+	testSub := kargoapi.ImageSubscription{
+		RepoURL: "ghcr.io/acme/service-*",
+	}
+	testCases := []struct {
+		name                    string
+		listImageRepositoriesFn func(context.Context, string, bool, *image.Credentials) ([]string, error)
+		assertions              func(*testing.T, string)
+	}{
+		{
+			name: "error listing repositories",
+			listImageRepositoriesFn: func(context.Context, string, bool, *image.Credentials) ([]string, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, warning string) {
+				require.Contains(t, warning, "unable to access image repo")
+				require.Contains(t, warning, "something went wrong")
+				require.Contains(t, warning, noCredentialsHint)
+			},
+		},
+		{
+			name: "no matching repositories",
+			listImageRepositoriesFn: func(context.Context, string, bool, *image.Credentials) ([]string, error) {
+				return nil, nil
+			},
+			assertions: func(t *testing.T, warning string) {
+				require.Equal(t, `no image repos matching "ghcr.io/acme/service-*" were found`, warning)
+			},
+		},
+		{
+			name: "matching repositories",
+			listImageRepositoriesFn: func(context.Context, string, bool, *image.Credentials) ([]string, error) {
+				return []string{"ghcr.io/acme/service-a"}, nil
+			},
+			assertions: func(t *testing.T, warning string) {
+				require.Empty(t, warning)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := &Discoverer{
+				credentialsDB:           &credentials.FakeDB{},
+				listImageRepositoriesFn: testCase.listImageRepositoriesFn,
+			}
+			testCase.assertions(
+				t,
+				r.checkImageConnectivity(context.Background(), "fake-namespace", testSub),
+			)
+		})
+	}
+}
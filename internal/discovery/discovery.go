@@ -0,0 +1,220 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/controller/git"
+	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/helm"
+	"github.com/akuity/kargo/internal/image"
+	"github.com/akuity/kargo/internal/packages"
+)
+
+// defaultMaxConcurrentDiscoveries is the maximum number of a Warehouse's
+// subscriptions of any one kind from which artifacts are discovered
+// concurrently when Config does not specify one.
+const defaultMaxConcurrentDiscoveries = 4
+
+// Config represents configuration for a Discoverer.
+type Config struct {
+	// MaxConcurrentDiscoveries is the maximum number of a Warehouse's
+	// subscriptions of any one kind from which artifacts are discovered
+	// concurrently. If not greater than zero, defaultMaxConcurrentDiscoveries
+	// applies.
+	MaxConcurrentDiscoveries int
+	// GitCloneCache, if not nil, is used to clone Git repositories, so that
+	// only what has changed since a repository was last cloned needs to be
+	// fetched.
+	GitCloneCache *git.CloneCache
+	// GitMirrorDirs are the directories beneath which the mirrors and bundles
+	// referenced by file:// mirror URLs of Git subscriptions must lie. If
+	// empty, file:// mirror URLs are not permitted.
+	GitMirrorDirs []string
+}
+
+// Discoverer discovers the artifacts available from the repositories a
+// Warehouse subscribes to. It neither produces Freight nor updates the
+// Warehouse, so it may be used both by the Warehouse reconciler and to preview
+// the effect of changes to a Warehouse's spec before they are applied.
+type Discoverer struct {
+	client                     client.Client
+	credentialsDB              credentials.Database
+	imageSourceURLFnsByBaseURL map[string]func(string, string) string
+	maxConcurrentDiscoveries   int
+	gitMirrorDirs              []string
+
+	// The following behaviors are overridable for testing purposes:
+
+	discoverCommitsFn func(context.Context, string, []kargoapi.RepoSubscription) ([]kargoapi.GitDiscoveryResult, error)
+
+	discoverImagesFn func(context.Context, string, []kargoapi.RepoSubscription) ([]kargoapi.ImageDiscoveryResult, error)
+
+	discoverImageRefsFn func(context.Context, kargoapi.ImageSubscription, *image.Credentials) ([]image.Image, error)
+
+	listImageRepositoriesFn func(context.Context, string, bool, *image.Credentials) ([]string, error)
+
+	getImageReferrerFn func(
+		ctx context.Context,
+		repoURL string,
+		digest string,
+		artifactType string,
+		insecureSkipTLSVerify bool,
+		creds *image.Credentials,
+	) (*image.Referrer, error)
+
+	discoverChartsFn func(context.Context, string, []kargoapi.RepoSubscription) ([]kargoapi.ChartDiscoveryResult, error)
+
+	discoverChartVersionsFn func(context.Context, string, string, string, *helm.Credentials) ([]string, error)
+
+	discoverPackagesFn func(
+		context.Context,
+		string,
+		[]kargoapi.RepoSubscription,
+	) ([]kargoapi.PackageDiscoveryResult, error)
+
+	discoverPackageVersionsFn func(
+		ctx context.Context,
+		pkgType kargoapi.PackageType,
+		repoURL string,
+		name string,
+		semverConstraint string,
+		creds *packages.Credentials,
+	) ([]string, error)
+
+	gitCloneFn func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error)
+
+	newAPIRepoFn func(
+		ctx context.Context,
+		repoURL string,
+		branch string,
+		creds *git.RepoCredentials,
+	) (git.Repo, error)
+
+	listCommitsFn func(repo git.Repo, limit, skip uint, pathspecs []string) ([]git.CommitMetadata, error)
+
+	listTagsFn func(repo git.Repo) ([]git.TagMetadata, error)
+
+	listBranchesFn func(repo git.Repo) ([]string, error)
+
+	checkoutBranchFn func(repo git.Repo, branch string) error
+
+	discoverBranchHistoryFn func(repo git.Repo, sub kargoapi.GitSubscription) ([]git.CommitMetadata, error)
+
+	discoverTagsFn func(repo git.Repo, sub kargoapi.GitSubscription) ([]git.TagMetadata, error)
+
+	getDiffPathsForCommitIDFn func(repo git.Repo, commitID string) ([]string, error)
+
+	getCommitBodyFn func(repo git.Repo, commitID string) (string, error)
+
+	isReachableFromBranchFn func(repo git.Repo, commitID string) (bool, error)
+
+	trustSigningKeysFn func(repo git.Repo, gpgKeys, sshKeys string) error
+
+	verifyCommitSignatureFn func(repo git.Repo, commitID string) (bool, error)
+
+	verifyTagSignatureFn func(repo git.Repo, tag string) (bool, error)
+
+	isCommitAvailableFn func(context.Context, string, kargoapi.GitSubscription, kargoapi.GitCommit) (bool, error)
+
+	isImageAvailableFn func(context.Context, string, kargoapi.ImageSubscription, kargoapi.Image) (bool, error)
+
+	isChartAvailableFn func(context.Context, string, kargoapi.ChartSubscription, kargoapi.Chart) (bool, error)
+
+	checkGitRemoteFn func(string, *git.ClientOptions, *git.CloneOptions) error
+
+	checkImageRepositoryFn func(context.Context, string, bool, *image.Credentials) error
+}
+
+// NewDiscoverer returns a Discoverer that uses the provided client and
+// credentials database to look up the credentials for, and other resources
+// referenced by, a Warehouse's subscriptions.
+func NewDiscoverer(
+	kubeClient client.Client,
+	credentialsDB credentials.Database,
+	cfg Config,
+) *Discoverer {
+	d := &Discoverer{
+		client:                    kubeClient,
+		credentialsDB:             credentialsDB,
+		gitCloneFn:                git.Clone,
+		newAPIRepoFn:              newAPIRepo,
+		discoverChartVersionsFn:   helm.DiscoverChartVersions,
+		discoverPackageVersionsFn: packages.DiscoverVersions,
+		getImageReferrerFn:        image.GetReferrer,
+		listImageRepositoriesFn:   image.ListRepositories,
+		imageSourceURLFnsByBaseURL: map[string]func(string, string) string{
+			githubURLPrefix: getGithubImageSourceURL,
+		},
+		gitMirrorDirs: cfg.GitMirrorDirs,
+	}
+	if cfg.GitCloneCache != nil {
+		d.gitCloneFn = cfg.GitCloneCache.Clone
+	}
+
+	d.maxConcurrentDiscoveries = cfg.MaxConcurrentDiscoveries
+	if d.maxConcurrentDiscoveries <= 0 {
+		d.maxConcurrentDiscoveries = defaultMaxConcurrentDiscoveries
+	}
+
+	d.discoverCommitsFn = d.discoverCommits
+	d.discoverImagesFn = d.discoverImages
+	d.discoverImageRefsFn = d.discoverImageRefs
+	d.discoverChartsFn = d.discoverCharts
+	d.discoverPackagesFn = d.discoverPackages
+	d.listCommitsFn = d.listCommits
+	d.listTagsFn = d.listTags
+	d.listBranchesFn = d.listBranches
+	d.checkoutBranchFn = d.checkoutBranch
+	d.discoverBranchHistoryFn = d.discoverBranchHistory
+	d.discoverTagsFn = d.discoverTags
+	d.getDiffPathsForCommitIDFn = d.getDiffPathsForCommitID
+	d.getCommitBodyFn = d.getCommitBody
+	d.isReachableFromBranchFn = d.isReachableFromBranch
+	d.trustSigningKeysFn = d.trustSigningKeys
+	d.verifyCommitSignatureFn = d.verifyCommitSignature
+	d.verifyTagSignatureFn = d.verifyTagSignature
+	d.isCommitAvailableFn = d.isCommitAvailable
+	d.isImageAvailableFn = d.isImageAvailable
+	d.isChartAvailableFn = d.isChartAvailable
+	d.checkGitRemoteFn = git.CheckRemote
+	d.checkImageRepositoryFn = image.CheckRepository
+	return d
+}
+
+// DiscoverArtifacts discovers the latest artifacts for all subscriptions of
+// the provided Warehouse. The Warehouse need not exist in the cluster.
+func (d *Discoverer) DiscoverArtifacts(
+	ctx context.Context,
+	warehouse *kargoapi.Warehouse,
+) (*kargoapi.DiscoveredArtifacts, error) {
+	commits, err := d.discoverCommitsFn(ctx, warehouse.Namespace, warehouse.Spec.Subscriptions)
+	if err != nil {
+		return nil, fmt.Errorf("error discovering commits: %w", err)
+	}
+
+	images, err := d.discoverImagesFn(ctx, warehouse.Namespace, warehouse.Spec.Subscriptions)
+	if err != nil {
+		return nil, fmt.Errorf("error discovering images: %w", err)
+	}
+
+	charts, err := d.discoverChartsFn(ctx, warehouse.Namespace, warehouse.Spec.Subscriptions)
+	if err != nil {
+		return nil, fmt.Errorf("error discovering charts: %w", err)
+	}
+
+	pkgs, err := d.discoverPackagesFn(ctx, warehouse.Namespace, warehouse.Spec.Subscriptions)
+	if err != nil {
+		return nil, fmt.Errorf("error discovering packages: %w", err)
+	}
+
+	return &kargoapi.DiscoveredArtifacts{
+		Git:      commits,
+		Images:   images,
+		Charts:   charts,
+		Packages: pkgs,
+	}, nil
+}
@@ -0,0 +1,226 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/credentials"
+)
+
+func TestNewDiscoverer(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().Build()
+	d := NewDiscoverer(
+		kubeClient,
+		&credentials.FakeDB{},
+		Config{
+			MaxConcurrentDiscoveries: 2,
+		},
+	)
+	require.NotNil(t, d.client)
+	require.NotNil(t, d.credentialsDB)
+	require.Equal(t, 2, d.maxConcurrentDiscoveries)
+	require.NotEmpty(t, d.imageSourceURLFnsByBaseURL)
+
+	// Assert that all overridable behaviors were initialized to a default:
+	require.NotNil(t, d.discoverCommitsFn)
+	require.NotNil(t, d.discoverImagesFn)
+	require.NotNil(t, d.getImageReferrerFn)
+	require.NotNil(t, d.discoverChartsFn)
+	require.NotNil(t, d.discoverChartVersionsFn)
+	require.NotNil(t, d.discoverPackagesFn)
+	require.NotNil(t, d.discoverPackageVersionsFn)
+	require.NotNil(t, d.gitCloneFn)
+	require.NotNil(t, d.newAPIRepoFn)
+	require.NotNil(t, d.listCommitsFn)
+	require.NotNil(t, d.listTagsFn)
+	require.NotNil(t, d.discoverBranchHistoryFn)
+	require.NotNil(t, d.discoverTagsFn)
+	require.NotNil(t, d.getDiffPathsForCommitIDFn)
+	require.NotNil(t, d.getCommitBodyFn)
+	require.NotNil(t, d.isCommitAvailableFn)
+	require.NotNil(t, d.isImageAvailableFn)
+	require.NotNil(t, d.isChartAvailableFn)
+	require.NotNil(t, d.checkGitRemoteFn)
+	require.NotNil(t, d.checkImageRepositoryFn)
+}
+
+func TestNewDiscovererDefaultConcurrency(t *testing.T) {
+	d := NewDiscoverer(nil, &credentials.FakeDB{}, Config{})
+	require.Equal(t, defaultMaxConcurrentDiscoveries, d.maxConcurrentDiscoveries)
+}
+
+func TestDiscoverArtifacts(t *testing.T) {
+	testCases := []struct {
+		name       string
+		discoverer *Discoverer
+		assertions func(*testing.T, *kargoapi.DiscoveredArtifacts, error)
+	}{
+		{
+			name: "error discovering commits",
+			discoverer: &Discoverer{
+				discoverCommitsFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+				) ([]kargoapi.GitDiscoveryResult, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, discoveredArtifacts *kargoapi.DiscoveredArtifacts, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+				require.ErrorContains(t, err, "error discovering commits")
+				require.Nil(t, discoveredArtifacts)
+			},
+		},
+		{
+			name: "error discovering images",
+			discoverer: &Discoverer{
+				discoverCommitsFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+				) ([]kargoapi.GitDiscoveryResult, error) {
+					return []kargoapi.GitDiscoveryResult{}, nil
+				},
+				discoverImagesFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+				) ([]kargoapi.ImageDiscoveryResult, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, discoveredArtifacts *kargoapi.DiscoveredArtifacts, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+				require.ErrorContains(t, err, "error discovering images")
+				require.Nil(t, discoveredArtifacts)
+			},
+		},
+		{
+			name: "error discovering charts",
+			discoverer: &Discoverer{
+				discoverCommitsFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+				) ([]kargoapi.GitDiscoveryResult, error) {
+					return []kargoapi.GitDiscoveryResult{}, nil
+				},
+				discoverImagesFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+				) ([]kargoapi.ImageDiscoveryResult, error) {
+					return []kargoapi.ImageDiscoveryResult{}, nil
+				},
+				discoverChartsFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+				) ([]kargoapi.ChartDiscoveryResult, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, discoveredArtifacts *kargoapi.DiscoveredArtifacts, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+				require.ErrorContains(t, err, "error discovering charts")
+				require.Nil(t, discoveredArtifacts)
+			},
+		},
+		{
+			name: "error discovering packages",
+			discoverer: &Discoverer{
+				discoverCommitsFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+				) ([]kargoapi.GitDiscoveryResult, error) {
+					return []kargoapi.GitDiscoveryResult{}, nil
+				},
+				discoverImagesFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+				) ([]kargoapi.ImageDiscoveryResult, error) {
+					return []kargoapi.ImageDiscoveryResult{}, nil
+				},
+				discoverChartsFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+				) ([]kargoapi.ChartDiscoveryResult, error) {
+					return []kargoapi.ChartDiscoveryResult{}, nil
+				},
+				discoverPackagesFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+				) ([]kargoapi.PackageDiscoveryResult, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, discoveredArtifacts *kargoapi.DiscoveredArtifacts, err error) {
+				require.ErrorContains(t, err, "something went wrong")
+				require.ErrorContains(t, err, "error discovering packages")
+				require.Nil(t, discoveredArtifacts)
+			},
+		},
+		{
+			name: "success",
+			discoverer: &Discoverer{
+				discoverCommitsFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+				) ([]kargoapi.GitDiscoveryResult, error) {
+					return []kargoapi.GitDiscoveryResult{
+						{RepoURL: "fake-repo", Commits: []kargoapi.DiscoveredCommit{
+							{ID: "fake-commit"},
+						}},
+					}, nil
+				},
+				discoverImagesFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+				) ([]kargoapi.ImageDiscoveryResult, error) {
+					return []kargoapi.ImageDiscoveryResult{
+						{RepoURL: "fake-repo", References: []kargoapi.DiscoveredImageReference{
+							{Tag: "fake-tag"},
+						}},
+					}, nil
+				},
+				discoverChartsFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+				) ([]kargoapi.ChartDiscoveryResult, error) {
+					return []kargoapi.ChartDiscoveryResult{
+						{RepoURL: "fake-repo", Versions: []string{
+							"fake-version",
+						}},
+					}, nil
+				},
+				discoverPackagesFn: func(
+					context.Context, string,
+					[]kargoapi.RepoSubscription,
+				) ([]kargoapi.PackageDiscoveryResult, error) {
+					return []kargoapi.PackageDiscoveryResult{
+						{Type: kargoapi.PackageTypeNPM, RepoURL: "fake-registry", Name: "fake-package", Versions: []string{
+							"fake-version",
+						}},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, discoveredArtifacts *kargoapi.DiscoveredArtifacts, err error) {
+				require.NoError(t, err)
+				require.Len(t, discoveredArtifacts.Git, 1)
+				require.Len(t, discoveredArtifacts.Images, 1)
+				require.Len(t, discoveredArtifacts.Charts, 1)
+				require.Len(t, discoveredArtifacts.Packages, 1)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			discoveredArtifacts, err := testCase.discoverer.DiscoverArtifacts(
+				context.TODO(),
+				&kargoapi.Warehouse{},
+			)
+			testCase.assertions(t, discoveredArtifacts, err)
+		})
+	}
+}
@@ -0,0 +1,1222 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/bmatcuk/doublestar/v4"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/controller/git"
+	"github.com/akuity/kargo/internal/credentials"
+	libGit "github.com/akuity/kargo/internal/git"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+const (
+	regexpPrefix = "regexp:"
+	regexPrefix  = "regex:"
+	globPrefix   = "glob:"
+)
+
+type pathSelector func(path string) (bool, error)
+
+func (d *Discoverer) discoverCommits(
+	ctx context.Context,
+	namespace string,
+	subs []kargoapi.RepoSubscription,
+) ([]kargoapi.GitDiscoveryResult, error) {
+	return discoverConcurrently(
+		ctx,
+		d.maxConcurrentDiscoveries,
+		subs,
+		func(ctx context.Context, s kargoapi.RepoSubscription) ([]kargoapi.GitDiscoveryResult, error) {
+			if s.Git == nil {
+				return nil, nil
+			}
+			return d.discoverCommitsFromSubscription(ctx, namespace, *s.Git)
+		},
+	)
+}
+
+// discoverCommitsFromSubscription discovers the commits of interest in the
+// repository of the provided GitSubscription. A result is returned for each of
+// the subscription's services or, if it has none, a single result is returned.
+// If the subscription's Branch is a pattern, this is repeated for each matching
+// branch.
+func (d *Discoverer) discoverCommitsFromSubscription(
+	ctx context.Context,
+	namespace string,
+	sub kargoapi.GitSubscription,
+) ([]kargoapi.GitDiscoveryResult, error) {
+	logger := logging.LoggerFromContext(ctx).WithField("repo", sub.RepoURL)
+
+	// Credentials are looked up for the location the repository is actually
+	// cloned from, which may be a mirror requiring credentials of its own.
+	credsURL := sub.RepoURL
+	if sub.MirrorURL != "" {
+		credsURL = sub.MirrorURL
+	}
+	creds, ok, err := d.credentialsDB.Get(ctx, namespace, credentials.TypeGit, credsURL)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error obtaining credentials for git repo %q: %w",
+			credsURL,
+			err,
+		)
+	}
+	var repoCreds *git.RepoCredentials
+	if ok {
+		repoCreds = &git.RepoCredentials{
+			Username:      creds.Username,
+			Password:      creds.Password,
+			SSHPrivateKey: creds.SSHPrivateKey,
+		}
+		logger.Debug("obtained credentials for git repo")
+	} else {
+		logger.Debug("found no credentials for git repo")
+	}
+
+	isBranchPattern := libGit.IsBranchPattern(sub.Branch)
+	var repo git.Repo
+	if sub.DiscoveryMode == kargoapi.GitDiscoveryModeProviderAPI {
+		branch := sub.Branch
+		if isBranchPattern {
+			branch = ""
+		}
+		if repo, err = d.newAPIRepoFn(ctx, sub.RepoURL, branch, repoCreds); err != nil {
+			return nil, fmt.Errorf(
+				"error accessing git repo %q through its provider's API: %w",
+				sub.RepoURL,
+				err,
+			)
+		}
+	} else {
+		cloneOpts := &git.CloneOptions{
+			Branch:                sub.Branch,
+			SingleBranch:          true,
+			Depth:                 uint(sub.CloneDepth),
+			Filter:                git.FilterBlobless,
+			InsecureSkipTLSVerify: sub.InsecureSkipTLSVerify,
+			InsecureHTTP:          sub.InsecureHTTP,
+		}
+		if isBranchPattern {
+			// All branches are needed to find those matching the pattern.
+			cloneOpts.Branch = ""
+			cloneOpts.SingleBranch = false
+		}
+		var cloneURL string
+		if cloneURL, err = d.getCloneURL(sub); err != nil {
+			return nil, err
+		}
+		if repo, err = d.gitCloneFn(
+			cloneURL,
+			&git.ClientOptions{
+				Credentials: repoCreds,
+			},
+			cloneOpts,
+		); err != nil {
+			return nil, fmt.Errorf("failed to clone git repo %q: %w", cloneURL, err)
+		}
+	}
+	defer repo.Close()
+
+	if sub.VerifySignatures != nil {
+		if err = d.configureTrustedKeys(
+			ctx,
+			namespace,
+			repo,
+			sub.VerifySignatures.SecretName,
+		); err != nil {
+			return nil, fmt.Errorf(
+				"error configuring trusted keys for git repo %q: %w",
+				sub.RepoURL,
+				err,
+			)
+		}
+	}
+
+	if !isBranchPattern {
+		return d.discoverCommitsFromClone(repo, sub)
+	}
+	return d.discoverCommitsFromBranches(repo, sub)
+}
+
+// getCloneURL returns the URL from which the repository of the provided
+// GitSubscription is cloned. This is the subscription's MirrorURL, if it has
+// one, or else its RepoURL. A file:// MirrorURL must refer to a path beneath
+// one of the Discoverer's mirror directories. If that path is a bundle rather
+// than a repository, the path itself is returned, since bundles cannot be
+// cloned through the file:// transport.
+func (d *Discoverer) getCloneURL(sub kargoapi.GitSubscription) (string, error) {
+	if sub.MirrorURL == "" {
+		return sub.RepoURL, nil
+	}
+	u, err := url.Parse(sub.MirrorURL)
+	if err != nil {
+		return "", fmt.Errorf("error parsing mirror URL %q: %w", sub.MirrorURL, err)
+	}
+	if u.Scheme != "file" {
+		return sub.MirrorURL, nil
+	}
+	if u.Host != "" && u.Host != "localhost" {
+		return "", fmt.Errorf("mirror URL %q does not refer to a local path", sub.MirrorURL)
+	}
+	// Symlinks are resolved first, so that they cannot point outside of the
+	// mirror directories.
+	path, err := filepath.EvalSymlinks(u.Path)
+	if err != nil {
+		return "", fmt.Errorf("error resolving mirror %q: %w", sub.MirrorURL, err)
+	}
+	if !slices.ContainsFunc(d.gitMirrorDirs, func(dir string) bool {
+		if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+			dir = resolved
+		}
+		rel, err := filepath.Rel(dir, path)
+		return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+	}) {
+		return "", fmt.Errorf(
+			"mirror %q does not lie beneath a directory permitted for mirrors "+
+				"by this installation of Kargo",
+			sub.MirrorURL,
+		)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading mirror %q: %w", sub.MirrorURL, err)
+	}
+	if info.Mode().IsRegular() {
+		return path, nil
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String(), nil
+}
+
+// configureTrustedKeys configures the provided repository to trust the public
+// keys stored in the specified Secret when verifying signatures.
+func (d *Discoverer) configureTrustedKeys(
+	ctx context.Context,
+	namespace string,
+	repo git.Repo,
+	secretName string,
+) error {
+	secret := corev1.Secret{}
+	if err := d.client.Get(
+		ctx,
+		types.NamespacedName{
+			Namespace: namespace,
+			Name:      secretName,
+		},
+		&secret,
+	); err != nil {
+		return fmt.Errorf(
+			"error getting trusted keys Secret %q in namespace %q: %w",
+			secretName,
+			namespace,
+			err,
+		)
+	}
+	gpgKeys := string(secret.Data[kargoapi.GitSignatureVerificationGPGKeysSecretKey])
+	sshKeys := string(secret.Data[kargoapi.GitSignatureVerificationSSHKeysSecretKey])
+	if gpgKeys == "" && sshKeys == "" {
+		return fmt.Errorf(
+			"Secret %q in namespace %q has neither a %q nor a %q key",
+			secretName,
+			namespace,
+			kargoapi.GitSignatureVerificationGPGKeysSecretKey,
+			kargoapi.GitSignatureVerificationSSHKeysSecretKey,
+		)
+	}
+	return d.trustSigningKeysFn(repo, gpgKeys, sshKeys)
+}
+
+// discoverCommitsFromBranches discovers the commits of interest in every
+// branch of the provided repository that matches the branch pattern of the
+// provided GitSubscription. Each result is labeled with the branch it was
+// discovered from. If no branch matches the pattern, a single result without
+// any commits is returned.
+func (d *Discoverer) discoverCommitsFromBranches(
+	repo git.Repo,
+	sub kargoapi.GitSubscription,
+) ([]kargoapi.GitDiscoveryResult, error) {
+	matcher, err := libGit.NewBranchMatcher(sub.Branch)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing branch pattern: %w", err)
+	}
+	branches, err := d.listBranchesFn(repo)
+	if err != nil {
+		return nil, fmt.Errorf("error listing branches from git repo %q: %w", sub.RepoURL, err)
+	}
+	var results []kargoapi.GitDiscoveryResult
+	for _, branch := range branches {
+		if !matcher(branch) {
+			continue
+		}
+		if err = d.checkoutBranchFn(repo, branch); err != nil {
+			return nil, fmt.Errorf(
+				"error checking out branch %q of git repo %q: %w",
+				branch,
+				sub.RepoURL,
+				err,
+			)
+		}
+		branchSub := sub
+		branchSub.Branch = branch
+		branchResults, err := d.discoverCommitsFromClone(repo, branchSub)
+		if err != nil {
+			return nil, fmt.Errorf("error discovering commits from branch %q: %w", branch, err)
+		}
+		for i := range branchResults {
+			branchResults[i].Branch = branch
+		}
+		results = append(results, branchResults...)
+	}
+	if len(results) == 0 {
+		return []kargoapi.GitDiscoveryResult{{RepoURL: sub.RepoURL}}, nil
+	}
+	return results, nil
+}
+
+// discoverCommitsFromClone discovers the commits of interest in the branch
+// checked out in the provided repository. A result is returned for each of the
+// provided GitSubscription's services or, if it has none, a single result is
+// returned. If the GitSubscription does not specify a branch, the branch that
+// is checked out is recorded in each result as the repository's default
+// branch.
+func (d *Discoverer) discoverCommitsFromClone(
+	repo git.Repo,
+	sub kargoapi.GitSubscription,
+) ([]kargoapi.GitDiscoveryResult, error) {
+	// Rather than any particular branch name being assumed, the default branch
+	// is whichever branch the remote's HEAD referred to when the repository
+	// was cloned. Recording it in the discovered commits, and thereby in
+	// Freight, makes it explicit which branch they were discovered on.
+	var defaultBranch string
+	if sub.Branch == "" {
+		defaultBranch = repo.CurrentBranch()
+		sub.Branch = defaultBranch
+	}
+
+	if len(sub.Services) == 0 {
+		discovered, err := d.discoverCommitsFromRepo(repo, sub)
+		if err != nil {
+			return nil, err
+		}
+		return []kargoapi.GitDiscoveryResult{{
+			RepoURL:       sub.RepoURL,
+			DefaultBranch: defaultBranch,
+			Commits:       discovered,
+		}}, nil
+	}
+
+	// Each service is discovered independently of the others, as if the
+	// subscription's IncludePaths were set to the service's path. The
+	// repository only needs to be cloned once for all of them.
+	results := make([]kargoapi.GitDiscoveryResult, 0, len(sub.Services))
+	for _, svc := range sub.Services {
+		svcSub := sub
+		svcSub.IncludePaths = []string{svc.Path}
+		discovered, err := d.discoverCommitsFromRepo(repo, svcSub)
+		if err != nil {
+			return nil, fmt.Errorf("error discovering commits for service %q: %w", svc.Name, err)
+		}
+		results = append(results, kargoapi.GitDiscoveryResult{
+			RepoURL:       sub.RepoURL,
+			Service:       svc.Name,
+			DefaultBranch: defaultBranch,
+			Commits:       discovered,
+		})
+	}
+	return results, nil
+}
+
+// discoverCommitsFromRepo discovers the commits of interest in the provided
+// repository according to the provided subscription's CommitSelectionStrategy.
+func (d *Discoverer) discoverCommitsFromRepo(
+	repo git.Repo,
+	sub kargoapi.GitSubscription,
+) ([]kargoapi.DiscoveredCommit, error) {
+	var discovered []kargoapi.DiscoveredCommit
+	switch sub.CommitSelectionStrategy {
+	case kargoapi.CommitSelectionStrategyCalVer,
+		kargoapi.CommitSelectionStrategyLexical,
+		kargoapi.CommitSelectionStrategyNewestTag,
+		kargoapi.CommitSelectionStrategySemVer:
+		tags, err := d.discoverTagsFn(repo, sub)
+		if err != nil {
+			return nil, fmt.Errorf("error listing tags from git repo %q: %w", sub.RepoURL, err)
+		}
+
+		resolver, resolve := repo.(tagMetadataResolver)
+		for _, meta := range tags {
+			if resolve {
+				if err = resolver.resolveTagMetadata(&meta); err != nil {
+					return nil, fmt.Errorf(
+						"error getting metadata of tag %q from git repo %q: %w",
+						meta.Tag,
+						sub.RepoURL,
+						err,
+					)
+				}
+			}
+			signatureStatus, err := d.getSignatureStatus(repo, sub, meta.CommitID, meta.Tag)
+			if err != nil {
+				return nil, err
+			}
+			discovered = append(discovered, kargoapi.DiscoveredCommit{
+				ID:              meta.CommitID,
+				Tag:             meta.Tag,
+				Subject:         meta.Subject,
+				Author:          meta.Author,
+				Committer:       meta.Committer,
+				CreatorDate:     &metav1.Time{Time: meta.CreatorDate},
+				Trailers:        selectTrailers(meta.Trailers, sub.Trailers),
+				SignatureStatus: signatureStatus,
+			})
+		}
+	default:
+		commits, err := d.discoverBranchHistoryFn(repo, sub)
+		if err != nil {
+			return nil, fmt.Errorf("error listing commits from git repo %q: %w", sub.RepoURL, err)
+		}
+
+		for _, meta := range commits {
+			signatureStatus, err := d.getSignatureStatus(repo, sub, meta.ID, "")
+			if err != nil {
+				return nil, err
+			}
+			discovered = append(discovered, kargoapi.DiscoveredCommit{
+				ID:              meta.ID,
+				Branch:          sub.Branch,
+				Subject:         meta.Subject,
+				Author:          meta.Author,
+				Committer:       meta.Committer,
+				CreatorDate:     &metav1.Time{Time: meta.CommitDate},
+				Trailers:        selectTrailers(meta.Trailers, sub.Trailers),
+				SignatureStatus: signatureStatus,
+			})
+		}
+	}
+	return discovered, nil
+}
+
+// skipsUnverifiedCommits returns true if the provided subscription requires
+// commits whose signatures cannot be verified to be excluded from discovery.
+func skipsUnverifiedCommits(sub kargoapi.GitSubscription) bool {
+	return sub.VerifySignatures != nil &&
+		sub.VerifySignatures.UnverifiedPolicy != kargoapi.UnverifiedCommitPolicyMark
+}
+
+// getSignatureStatus returns the signature status to be recorded for the
+// provided commit, which was discovered through the provided subscription,
+// optionally by way of the provided tag. If the subscription does not call for
+// signatures to be verified, an empty status is returned. If it calls for
+// unverified commits to be skipped, every commit that was discovered has
+// already been verified.
+func (d *Discoverer) getSignatureStatus(
+	repo git.Repo,
+	sub kargoapi.GitSubscription,
+	commitID string,
+	tag string,
+) (kargoapi.GitSignatureStatus, error) {
+	if sub.VerifySignatures == nil {
+		return "", nil
+	}
+	if skipsUnverifiedCommits(sub) {
+		return kargoapi.GitSignatureStatusVerified, nil
+	}
+	verified, err := d.isSignatureVerified(repo, sub, commitID, tag)
+	if err != nil {
+		return "", err
+	}
+	if !verified {
+		return kargoapi.GitSignatureStatusUnverified, nil
+	}
+	return kargoapi.GitSignatureStatusVerified, nil
+}
+
+// isSignatureVerified returns true if the commit with the provided ID bears a
+// valid signature made with a trusted key or, if a tag is provided, if either
+// the commit or the tag does.
+func (d *Discoverer) isSignatureVerified(
+	repo git.Repo,
+	sub kargoapi.GitSubscription,
+	commitID string,
+	tag string,
+) (bool, error) {
+	if tag != "" {
+		verified, err := d.verifyTagSignatureFn(repo, tag)
+		if err != nil {
+			return false, fmt.Errorf(
+				"error verifying signature of tag %q in git repo %q: %w",
+				tag,
+				sub.RepoURL,
+				err,
+			)
+		}
+		if verified {
+			return true, nil
+		}
+	}
+	verified, err := d.verifyCommitSignatureFn(repo, commitID)
+	if err != nil {
+		return false, fmt.Errorf(
+			"error verifying signature of commit %q in git repo %q: %w",
+			commitID,
+			sub.RepoURL,
+			err,
+		)
+	}
+	return verified, nil
+}
+
+// selectTrailers returns the values of those of the provided commit trailers
+// whose keys case-insensitively match any of the provided keys, indexed by the
+// matching key as it was provided. Multiple values of the same trailer are
+// joined by commas. If no trailers are selected, nil is returned.
+func selectTrailers(trailers map[string][]string, keys []string) map[string]string {
+	if len(trailers) == 0 || len(keys) == 0 {
+		return nil
+	}
+	var selected map[string]string
+	for _, key := range keys {
+		var values []string
+		for trailerKey, trailerValues := range trailers {
+			if strings.EqualFold(trailerKey, key) {
+				values = append(values, trailerValues...)
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		if selected == nil {
+			selected = make(map[string]string, len(keys))
+		}
+		selected[key] = strings.Join(values, ",")
+	}
+	return selected
+}
+
+func (d *Discoverer) discoverBranchHistory(repo git.Repo, sub kargoapi.GitSubscription) ([]git.CommitMetadata, error) {
+	limit := gitDiscoveryLimit(sub)
+
+	filter, err := libGit.NewCommitFilter(sub.ExpressionFilter)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing expression filter: %w", err)
+	}
+
+	// If no include or exclude paths, merge commit filters, or expression filter
+	// are specified and unverified commits needn't be skipped, return the first
+	// commits up to the limit, unless any of them carries a skip marker.
+	hasPathsFilters := sub.IncludePaths != nil || sub.ExcludePaths != nil
+	hasMergeFilter := sub.ExcludeMergeCommits || sub.OnlyMergeCommits
+	skipUnverified := skipsUnverifiedCommits(sub)
+	markers := getSkipMarkers(sub)
+	if !hasPathsFilters && !hasMergeFilter && filter == nil && !skipUnverified {
+		commits, err := d.listCommitsFn(repo, uint(limit), 0, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error listing commits from git repo %q: %w", sub.RepoURL, err)
+		}
+		// Commits carrying a skip marker are rare, so they are only filtered out
+		// the hard way if there are any among the first commits.
+		if !slices.ContainsFunc(commits, func(meta git.CommitMetadata) bool {
+			return hasSkipMarker(markers, meta)
+		}) {
+			return commits, nil
+		}
+	}
+
+	// Compile include and exclude path selectors.
+	includeSelectors, err := getPathSelectors(sub.IncludePaths)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing include selector: %w", err)
+	}
+	excludeSelectors, err := getPathSelectors(sub.ExcludePaths)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing exclude selector: %w", err)
+	}
+
+	// Where possible, have Git narrow the commits down to those touching the
+	// included paths, so that only those need to be checked against the
+	// selectors. On large repositories where matching commits are rare, this is
+	// considerably faster than checking every commit.
+	pathspecs := getPathspecs(sub.IncludePaths, sub.ExcludePaths)
+
+	var filteredCommits = make([]git.CommitMetadata, 0, limit)
+	for skip := uint(0); ; skip += uint(limit) {
+		commits, err := d.listCommitsFn(repo, uint(limit), skip, pathspecs)
+		if err != nil {
+			return nil, fmt.Errorf("error listing commits from git repo %q: %w", sub.RepoURL, err)
+		}
+
+		// Filter commits based on skip markers, whether they are merge commits,
+		// include and exclude paths, the expression filter, and their signatures.
+		for _, meta := range commits {
+			if hasSkipMarker(markers, meta) {
+				continue
+			}
+			if !matchesMergeFilters(sub, meta) {
+				continue
+			}
+			var diffPaths []string
+			if hasPathsFilters || filter.UsesPaths() {
+				if diffPaths, err = d.getDiffPathsForCommitIDFn(repo, meta.ID); err != nil {
+					return nil, fmt.Errorf(
+						"error getting diff paths for commit %q in git repo %q: %w",
+						meta.ID,
+						sub.RepoURL,
+						err,
+					)
+				}
+			}
+			if hasPathsFilters {
+				match, err := matchesPathsFilters(includeSelectors, excludeSelectors, diffPaths)
+				if err != nil {
+					return nil, fmt.Errorf(
+						"error checking includePaths/excludePaths match for commit %q for git repo %q: %w",
+						meta.ID,
+						sub.RepoURL,
+						err,
+					)
+				}
+				if !match {
+					continue
+				}
+			}
+			var body string
+			if filter.UsesBody() {
+				if body, err = d.getCommitBodyFn(repo, meta.ID); err != nil {
+					return nil, fmt.Errorf(
+						"error getting message body of commit %q in git repo %q: %w",
+						meta.ID,
+						sub.RepoURL,
+						err,
+					)
+				}
+			}
+			match, err := filter.Matches(libGit.CommitInfo{
+				ID:        meta.ID,
+				Author:    meta.Author,
+				Committer: meta.Committer,
+				Subject:   meta.Subject,
+				Body:      body,
+				Date:      meta.CommitDate,
+				Trailers:  meta.Trailers,
+				Paths:     diffPaths,
+			})
+			if err != nil {
+				return nil, fmt.Errorf(
+					"error checking expression filter match for commit %q for git repo %q: %w",
+					meta.ID,
+					sub.RepoURL,
+					err,
+				)
+			}
+			if !match {
+				continue
+			}
+			if skipUnverified {
+				verified, err := d.isSignatureVerified(repo, sub, meta.ID, "")
+				if err != nil {
+					return nil, err
+				}
+				if !verified {
+					continue
+				}
+			}
+
+			filteredCommits = append(filteredCommits, meta)
+			if len(filteredCommits) >= limit {
+				return trimSlice(filteredCommits, limit), nil
+			}
+		}
+
+		// If there are no more commits to list, break the loop.
+		if len(commits) == 0 {
+			break
+		}
+	}
+
+	return trimSlice(filteredCommits, limit), nil
+}
+
+// defaultSkipMarkers are the markers that cause commits to be skipped when a
+// GitSubscription does not specify any.
+var defaultSkipMarkers = kargoapi.GitCommitSkipMarkers{
+	Subject: "[kargo skip]",
+	Trailer: "Kargo-Skip: true",
+}
+
+// getSkipMarkers returns the markers that cause commits to be skipped when
+// discovering commits from the history of a branch through the provided
+// subscription.
+func getSkipMarkers(sub kargoapi.GitSubscription) kargoapi.GitCommitSkipMarkers {
+	if sub.SkipMarkers == nil {
+		return defaultSkipMarkers
+	}
+	return *sub.SkipMarkers
+}
+
+// hasSkipMarker returns true if the subject of the provided commit contains
+// the provided subject marker or its message has the provided trailer. Trailer
+// keys and values are compared case-insensitively.
+func hasSkipMarker(markers kargoapi.GitCommitSkipMarkers, meta git.CommitMetadata) bool {
+	if markers.Subject != "" && strings.Contains(meta.Subject, markers.Subject) {
+		return true
+	}
+	key, value, ok := strings.Cut(markers.Trailer, ":")
+	if !ok {
+		return false
+	}
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	for k, values := range meta.Trailers {
+		if strings.EqualFold(k, key) && slices.ContainsFunc(values, func(v string) bool {
+			return strings.EqualFold(v, value)
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMergeFilters returns true if the provided commit satisfies the
+// provided subscription's merge commit filters. It returns false otherwise.
+func matchesMergeFilters(sub kargoapi.GitSubscription, meta git.CommitMetadata) bool {
+	isMerge := meta.ParentCount > 1
+	switch {
+	case sub.ExcludeMergeCommits:
+		return !isMerge
+	case sub.OnlyMergeCommits:
+		return isMerge
+	default:
+		return true
+	}
+}
+
+// discoverTags returns a list of tags from the given Git repository that match
+// the given subscription's tag selection criteria. It returns the list of tags
+// that match the criteria, sorted in descending order. If the list contains
+// more tags than the subscription's discovery limit, it is clipped to that
+// many of the most recent tags.
+func (d *Discoverer) discoverTags(repo git.Repo, sub kargoapi.GitSubscription) ([]git.TagMetadata, error) {
+	tags, err := d.listTagsFn(repo)
+	if err != nil {
+		return nil, fmt.Errorf("error listing tags from git repo %q: %w", sub.RepoURL, err)
+	}
+
+	if tags, err = filterTags(tags, sub.IgnoreTags, sub.AllowTags); err != nil {
+		return nil, fmt.Errorf("failed to filter tags: %w", err)
+	}
+
+	if sub.RestrictTagsToBranch {
+		if tags, err = d.filterTagsByBranch(repo, tags); err != nil {
+			return nil, fmt.Errorf("failed to filter tags by branch: %w", err)
+		}
+	}
+
+	switch sub.CommitSelectionStrategy {
+	case kargoapi.CommitSelectionStrategySemVer:
+		if tags, err = selectSemVerTags(
+			tags,
+			sub.SemverConstraint,
+			sub.SemverPrefix,
+			sub.StrictSemvers,
+		); err != nil {
+			return nil, fmt.Errorf("failed to select semver tags: %w", err)
+		}
+	case kargoapi.CommitSelectionStrategyCalVer:
+		if tags, err = selectCalVerTags(tags, sub.CalVerLayout); err != nil {
+			return nil, fmt.Errorf("failed to select calver tags: %w", err)
+		}
+	case kargoapi.CommitSelectionStrategyLexical:
+		slices.SortFunc(tags, func(i, j git.TagMetadata) int {
+			// Sort in reverse lexicographic order
+			return strings.Compare(j.Tag, i.Tag)
+		})
+	default:
+		// No additional filtering or sorting required, as the tags are already
+		// ordered by creation date.
+	}
+
+	filter, err := libGit.NewCommitFilter(sub.ExpressionFilter)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing expression filter: %w", err)
+	}
+
+	// If no include or exclude paths or expression filter are specified and
+	// unverified tags needn't be skipped, return the first tags up to the limit.
+	limit := gitDiscoveryLimit(sub)
+	hasPathsFilters := sub.IncludePaths != nil || sub.ExcludePaths != nil
+	skipUnverified := skipsUnverifiedCommits(sub)
+	if len(tags) == 0 || (!hasPathsFilters && filter == nil && !skipUnverified) {
+		return trimSlice(tags, limit), nil
+	}
+
+	// Compile include and exclude path selectors.
+	includeSelectors, err := getPathSelectors(sub.IncludePaths)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing include selector: %w", err)
+	}
+	excludeSelectors, err := getPathSelectors(sub.ExcludePaths)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing exclude selector: %w", err)
+	}
+
+	// Filter tags based on include and exclude paths, the expression filter, and
+	// their signatures.
+	var filteredTags = make([]git.TagMetadata, 0, limit)
+	for _, meta := range tags {
+		var diffPaths []string
+		if hasPathsFilters || filter.UsesPaths() {
+			if diffPaths, err = d.getDiffPathsForCommitIDFn(repo, meta.CommitID); err != nil {
+				return nil, fmt.Errorf(
+					"error getting diff paths for tag %q in git repo %q: %w",
+					meta.Tag,
+					sub.RepoURL,
+					err,
+				)
+			}
+		}
+		if hasPathsFilters {
+			match, err := matchesPathsFilters(includeSelectors, excludeSelectors, diffPaths)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"error checking includePaths/excludePaths match for tag %q for git repo %q: %w",
+					meta.Tag,
+					sub.RepoURL,
+					err,
+				)
+			}
+			if !match {
+				continue
+			}
+		}
+		var body string
+		if filter.UsesBody() {
+			if body, err = d.getCommitBodyFn(repo, meta.CommitID); err != nil {
+				return nil, fmt.Errorf(
+					"error getting message body of commit for tag %q in git repo %q: %w",
+					meta.Tag,
+					sub.RepoURL,
+					err,
+				)
+			}
+		}
+		match, err := filter.Matches(libGit.CommitInfo{
+			ID:        meta.CommitID,
+			Tag:       meta.Tag,
+			Author:    meta.Author,
+			Committer: meta.Committer,
+			Subject:   meta.Subject,
+			Body:      body,
+			Date:      meta.CreatorDate,
+			Trailers:  meta.Trailers,
+			Paths:     diffPaths,
+		})
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error checking expression filter match for tag %q for git repo %q: %w",
+				meta.Tag,
+				sub.RepoURL,
+				err,
+			)
+		}
+		if !match {
+			continue
+		}
+		if skipUnverified {
+			verified, err := d.isSignatureVerified(repo, sub, meta.CommitID, meta.Tag)
+			if err != nil {
+				return nil, err
+			}
+			if !verified {
+				continue
+			}
+		}
+
+		filteredTags = append(filteredTags, meta)
+		if len(filteredTags) >= limit {
+			break
+		}
+	}
+	return trimSlice(filteredTags, limit), nil
+}
+
+// gitDiscoveryLimit returns the maximum number of commits or tags that may be
+// discovered for the provided subscription. Subscriptions created before the
+// limit was configurable may not specify one, in which case the default of 20
+// applies.
+func gitDiscoveryLimit(sub kargoapi.GitSubscription) int {
+	if sub.DiscoveryLimit <= 0 {
+		return 20
+	}
+	return int(sub.DiscoveryLimit)
+}
+
+// filterTagsByBranch filters the given list of tags down to those whose
+// commits are reachable from the branch checked out in the given repository.
+func (d *Discoverer) filterTagsByBranch(
+	repo git.Repo,
+	tags []git.TagMetadata,
+) ([]git.TagMetadata, error) {
+	filteredTags := make([]git.TagMetadata, 0, len(tags))
+	for _, meta := range tags {
+		reachable, err := d.isReachableFromBranchFn(repo, meta.CommitID)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error determining if commit %q of tag %q is reachable from branch: %w",
+				meta.CommitID,
+				meta.Tag,
+				err,
+			)
+		}
+		if reachable {
+			filteredTags = append(filteredTags, meta)
+		}
+	}
+	return filteredTags, nil
+}
+
+// filterTags filters the given list of tag names based on the given allow and
+// ignore criteria. It returns the filtered list of tag names.
+func filterTags(tags []git.TagMetadata, ignoreTags []string, allow string) ([]git.TagMetadata, error) {
+	allowRegex, err := regexp.Compile(allow)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling regular expression %q: %w", allow, err)
+	}
+	filteredTags := make([]git.TagMetadata, 0, len(tags))
+	for _, tag := range tags {
+		if ignores(tag.Tag, ignoreTags) || !allows(tag.Tag, allowRegex) {
+			continue
+		}
+		filteredTags = append(filteredTags, tag)
+	}
+	return slices.Clip(filteredTags), nil
+}
+
+// allows returns true if the given tag name matches the given regular
+// expression or if the regular expression is nil. It returns false otherwise.
+func allows(tagName string, allowRegex *regexp.Regexp) bool {
+	if allowRegex == nil {
+		return true
+	}
+	return allowRegex.MatchString(tagName)
+}
+
+// ignores returns true if the given tag name is in the given list of ignored
+// tag names. It returns false otherwise.
+func ignores(tagName string, ignore []string) bool {
+	for _, i := range ignore {
+		if i == tagName {
+			return true
+		}
+	}
+	return false
+}
+
+func getPathSelectors(selectorStrs []string) ([]pathSelector, error) {
+	selectors := make([]pathSelector, len(selectorStrs))
+	for i, selectorStr := range selectorStrs {
+		switch {
+		case strings.HasPrefix(selectorStr, regexpPrefix):
+			regex, err := regexp.Compile(strings.TrimPrefix(selectorStr, regexpPrefix))
+			if err != nil {
+				return nil, err
+			}
+			selectors[i] = func(path string) (bool, error) {
+				return regex.MatchString(path), nil
+			}
+		case strings.HasPrefix(selectorStr, regexPrefix):
+			regex, err := regexp.Compile(strings.TrimPrefix(selectorStr, regexPrefix))
+			if err != nil {
+				return nil, err
+			}
+			selectors[i] = func(path string) (bool, error) {
+				return regex.MatchString(path), nil
+			}
+		case strings.HasPrefix(selectorStr, globPrefix):
+			// Glob patterns are matched using doublestar so that "**" can be
+			// used to match any number of directories, e.g.
+			// "glob:apps/**/overlays/prod/**".
+			pattern := strings.TrimPrefix(selectorStr, globPrefix)
+			selectors[i] = func(path string) (bool, error) {
+				return doublestar.Match(pattern, path)
+			}
+		default:
+			basePath := selectorStr
+			selectors[i] = func(path string) (bool, error) {
+				relPath, err := filepath.Rel(basePath, path)
+				if err != nil {
+					return false, err
+				}
+				return !strings.Contains(relPath, ".."), nil
+			}
+		}
+	}
+	return selectors, nil
+}
+
+// getPathspecs translates the provided include and exclude path selectors into
+// Git pathspecs matching at least every path matched by the selectors. Commits
+// found using these pathspecs are therefore a superset of the commits matching
+// the selectors. Regular expressions cannot be translated, so if any include
+// selector is a regular expression, nil is returned and no commits may be ruled
+// out by Git. Exclude selectors that cannot be translated exactly are omitted.
+func getPathspecs(includeSelectors, excludeSelectors []string) []string {
+	pathspecs := make([]string, 0, len(includeSelectors)+len(excludeSelectors))
+	for _, selectorStr := range includeSelectors {
+		pathspec, ok := getPathspec(selectorStr)
+		if !ok {
+			return nil
+		}
+		if pathspec == "" {
+			// The selector matches all paths
+			pathspecs = pathspecs[:0]
+			break
+		}
+		pathspecs = append(pathspecs, pathspec)
+	}
+	includeAll := len(pathspecs) == 0
+	for _, selectorStr := range excludeSelectors {
+		pathspec, ok := getPathspec(selectorStr)
+		if !ok || pathspec == "" {
+			continue
+		}
+		pathspecs = append(pathspecs, ":(exclude,"+strings.TrimPrefix(pathspec, ":("))
+	}
+	if includeAll && len(pathspecs) == 0 {
+		return nil
+	}
+	return pathspecs
+}
+
+// getPathspec translates the provided path selector into a Git pathspec. It
+// returns false if the selector cannot be translated. An empty pathspec is
+// returned if the selector matches all paths.
+func getPathspec(selectorStr string) (string, bool) {
+	switch {
+	case strings.HasPrefix(selectorStr, regexpPrefix),
+		strings.HasPrefix(selectorStr, regexPrefix):
+		return "", false
+	case strings.HasPrefix(selectorStr, globPrefix):
+		pattern := strings.TrimPrefix(selectorStr, globPrefix)
+		// Git's glob magic treats "**" the same way doublestar does, but has no
+		// notion of brace expansion.
+		if strings.ContainsAny(pattern, "{}") {
+			return "", false
+		}
+		return ":(top,glob)" + pattern, true
+	default:
+		basePath := filepath.Clean(selectorStr)
+		if filepath.IsAbs(basePath) || strings.HasPrefix(basePath, "..") {
+			return "", false
+		}
+		if basePath == "." {
+			return "", true
+		}
+		return ":(top,literal)" + basePath, true
+	}
+}
+
+func matchesPathsFilters(includeSelectors, excludeSelectors []pathSelector, diffs []string) (bool, error) {
+pathLoop:
+	for _, path := range diffs {
+		if len(includeSelectors) > 0 {
+			var selected bool
+			var err error
+			for _, selector := range includeSelectors {
+				if selected, err = selector(path); err != nil {
+					return false, err
+				}
+				if selected {
+					// Path was explicitly included, so we can move on to checking if
+					// it should be excluded
+					break
+				}
+			}
+			if !selected {
+				// Path was not explicitly included, so we can move on to the next path
+				continue pathLoop
+			}
+		}
+		// If we reach this point, the path was either implicitly or explicitly
+		// included. Now check if it should be excluded.
+		for _, selector := range excludeSelectors {
+			selected, err := selector(path)
+			if err != nil {
+				return false, err
+			}
+			if selected {
+				// Path was explicitly excluded, so we can move on to the next path
+				continue pathLoop
+			}
+		}
+		// If we reach this point, the path was not explicitly excluded
+		return true, nil
+	}
+	return false, nil
+}
+
+// selectSemVerTags returns the tags that are semantic versions satisfying the
+// provided constraint, sorted in descending order. If a prefix is provided, it
+// is stripped from each tag before the tag is parsed and tags lacking it are
+// ignored. If strict is true, only tags that are complete semantic versions
+// are considered.
+func selectSemVerTags(
+	tags []git.TagMetadata,
+	constraint string,
+	prefix string,
+	strict bool,
+) ([]git.TagMetadata, error) {
+	var svConstraint *semver.Constraints
+	if constraint != "" {
+		var err error
+		if svConstraint, err = semver.NewConstraint(constraint); err != nil {
+			return nil, fmt.Errorf("error parsing semver constraint %q: %w", constraint, err)
+		}
+	}
+
+	type semVerTag struct {
+		git.TagMetadata
+		*semver.Version
+	}
+
+	var svs []semVerTag
+	for _, meta := range tags {
+		version, ok := strings.CutPrefix(meta.Tag, prefix)
+		if !ok {
+			continue
+		}
+		sv, err := parseSemVer(version, strict)
+		if err != nil {
+			continue
+		}
+		if svConstraint == nil || svConstraint.Check(sv) {
+			svs = append(svs, semVerTag{
+				TagMetadata: meta,
+				Version:     sv,
+			})
+		}
+	}
+
+	slices.SortFunc(svs, func(i, j semVerTag) int {
+		if comp := j.Compare(i.Version); comp != 0 {
+			return comp
+		}
+		// If the semvers tie, break the tie lexically using the tags from which
+		// the semvers were parsed. This ensures a deterministic comparison of
+		// equivalent semvers, e.g., 1.0 and 1.0.0.
+		return strings.Compare(j.Tag, i.Tag)
+	})
+
+	var semverTags []git.TagMetadata
+	for _, sv := range svs {
+		semverTags = append(semverTags, sv.TagMetadata)
+	}
+	return semverTags, nil
+}
+
+// parseSemVer parses the provided version as a semantic version. If strict is
+// true, the version must be of the form MAJOR.MINOR.PATCH, optionally preceded
+// by a "v".
+func parseSemVer(version string, strict bool) (*semver.Version, error) {
+	if strict {
+		return semver.StrictNewVersion(strings.TrimPrefix(version, "v"))
+	}
+	return semver.NewVersion(version)
+}
+
+// selectCalVerTags returns the tags that conform to the provided calendar
+// versioning layout, sorted in descending order by the versions they
+// represent.
+func selectCalVerTags(tags []git.TagMetadata, layout string) ([]git.TagMetadata, error) {
+	cvLayout, err := libGit.NewCalVerLayout(layout)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing calver layout: %w", err)
+	}
+
+	type calVerTag struct {
+		git.TagMetadata
+		libGit.CalVer
+	}
+
+	var cvs []calVerTag
+	for _, meta := range tags {
+		if cv, ok := cvLayout.Parse(meta.Tag); ok {
+			cvs = append(cvs, calVerTag{
+				TagMetadata: meta,
+				CalVer:      cv,
+			})
+		}
+	}
+
+	slices.SortFunc(cvs, func(i, j calVerTag) int {
+		if comp := j.Compare(i.CalVer); comp != 0 {
+			return comp
+		}
+		// If the versions tie, break the tie lexically. This ensures a
+		// deterministic comparison of equivalent versions, e.g., 2024.1.01 and
+		// 2024.1.1 when parsed against the layout YYYY.MM.MICRO.
+		return strings.Compare(j.Tag, i.Tag)
+	})
+
+	var calverTags []git.TagMetadata
+	for _, cv := range cvs {
+		calverTags = append(calverTags, cv.TagMetadata)
+	}
+	return calverTags, nil
+}
+
+func (d *Discoverer) listCommits(
+	repo git.Repo,
+	limit uint,
+	skip uint,
+	pathspecs []string,
+) ([]git.CommitMetadata, error) {
+	return repo.ListCommits(limit, skip, pathspecs...)
+}
+
+func (d *Discoverer) listTags(repo git.Repo) ([]git.TagMetadata, error) {
+	return repo.ListTags()
+}
+
+func (d *Discoverer) listBranches(repo git.Repo) ([]string, error) {
+	return repo.ListBranches()
+}
+
+func (d *Discoverer) checkoutBranch(repo git.Repo, branch string) error {
+	return repo.Checkout(branch)
+}
+
+func (d *Discoverer) getDiffPathsForCommitID(repo git.Repo, commitID string) ([]string, error) {
+	return repo.GetDiffPathsForCommitID(commitID)
+}
+
+func (d *Discoverer) getCommitBody(repo git.Repo, commitID string) (string, error) {
+	return repo.CommitBody(commitID)
+}
+
+func (d *Discoverer) trustSigningKeys(repo git.Repo, gpgKeys, sshKeys string) error {
+	return repo.TrustSigningKeys(gpgKeys, sshKeys)
+}
+
+func (d *Discoverer) verifyCommitSignature(repo git.Repo, commitID string) (bool, error) {
+	return repo.VerifyCommitSignature(commitID)
+}
+
+func (d *Discoverer) verifyTagSignature(repo git.Repo, tag string) (bool, error) {
+	return repo.VerifyTagSignature(tag)
+}
+
+func (d *Discoverer) isReachableFromBranch(repo git.Repo, commitID string) (bool, error) {
+	return repo.IsAncestor(commitID, repo.CurrentBranch())
+}
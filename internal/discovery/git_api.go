@@ -0,0 +1,336 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/akuity/kargo/internal/controller/git"
+	"github.com/akuity/kargo/internal/gitprovider"
+)
+
+// trailerRegex matches a single line of a commit message's trailer block.
+var trailerRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*)\s*:\s*(.*)$`)
+
+// tagMetadataResolver is implemented by git.Repo implementations whose
+// ListTags method does not populate the metadata of the commits the tags point
+// to because doing so is expensive. The metadata is then resolved only for the
+// tags that are actually discovered.
+type tagMetadataResolver interface {
+	resolveTagMetadata(meta *git.TagMetadata) error
+}
+
+// apiRepo is an implementation of git.Repo that is backed by a git provider's
+// API instead of a local clone. It supports only the subset of operations
+// that are needed for discovering branches, commits, and tags. All other
+// operations return an error.
+type apiRepo struct {
+	ctx     context.Context
+	url     string
+	branch  string
+	browser gitprovider.RepoBrowser
+	commits map[string]*gitprovider.Commit
+}
+
+// newAPIRepo returns a git.Repo for the specified repository and branch that
+// is backed by the API of the repository's git provider. If no branch is
+// specified, the repository's default branch is used, just as it would be
+// checked out by a clone. If credentials are provided, their password is used
+// as the token to authenticate to the API.
+func newAPIRepo(
+	ctx context.Context,
+	repoURL string,
+	branch string,
+	creds *git.RepoCredentials,
+) (git.Repo, error) {
+	var token string
+	if creds != nil {
+		token = creds.Password
+	}
+	browser, err := gitprovider.NewRepoBrowser(repoURL, token)
+	if err != nil {
+		return nil, err
+	}
+	if branch == "" {
+		if branch, err = browser.GetDefaultBranch(ctx, repoURL); err != nil {
+			return nil, fmt.Errorf("error getting default branch: %w", err)
+		}
+	}
+	return &apiRepo{
+		ctx:     ctx,
+		url:     repoURL,
+		branch:  branch,
+		browser: browser,
+		commits: map[string]*gitprovider.Commit{},
+	}, nil
+}
+
+func (a *apiRepo) Checkout(branch string) error {
+	a.branch = branch
+	return nil
+}
+
+func (a *apiRepo) CurrentBranch() string {
+	return a.branch
+}
+
+func (a *apiRepo) ListBranches() ([]string, error) {
+	return a.browser.ListBranches(a.ctx, a.url)
+}
+
+// ListCommits lists commits from the current branch. Because git providers
+// paginate their APIs, skip must be a multiple of limit. Pathspecs are ignored,
+// since they only serve to narrow down the commits that are subsequently
+// matched against the subscription's path filters anyway.
+func (a *apiRepo) ListCommits(limit, skip uint, _ ...string) ([]git.CommitMetadata, error) {
+	if limit == 0 || skip%limit != 0 {
+		return nil, fmt.Errorf(
+			"cannot list %d commits after skipping %d using the git provider's API",
+			limit, skip,
+		)
+	}
+	commits, err := a.browser.ListCommits(
+		a.ctx,
+		a.url,
+		a.branch,
+		int(skip/limit)+1,
+		int(limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+	metas := make([]git.CommitMetadata, len(commits))
+	for i, commit := range commits {
+		subject, _ := splitCommitMessage(commit.Message)
+		metas[i] = git.CommitMetadata{
+			ID:          commit.ID,
+			CommitDate:  commit.CommitDate,
+			Author:      commit.Author,
+			Committer:   commit.Committer,
+			Subject:     subject,
+			Trailers:    parseMessageTrailers(commit.Message),
+			ParentCount: commit.ParentCount,
+		}
+	}
+	return metas, nil
+}
+
+// ListTags lists the repository's tags. Only the names of the tags and the IDs
+// of the commits they point to are populated. The remaining metadata can be
+// obtained using resolveTagMetadata.
+func (a *apiRepo) ListTags() ([]git.TagMetadata, error) {
+	tags, err := a.browser.ListTags(a.ctx, a.url)
+	if err != nil {
+		return nil, err
+	}
+	metas := make([]git.TagMetadata, len(tags))
+	for i, tag := range tags {
+		metas[i] = git.TagMetadata{
+			Tag:      tag.Name,
+			CommitID: tag.CommitID,
+		}
+	}
+	return metas, nil
+}
+
+func (a *apiRepo) resolveTagMetadata(meta *git.TagMetadata) error {
+	commit, err := a.getCommit(meta.CommitID)
+	if err != nil {
+		return err
+	}
+	meta.Subject, _ = splitCommitMessage(commit.Message)
+	meta.Author = commit.Author
+	meta.Committer = commit.Committer
+	meta.CreatorDate = commit.CommitDate
+	meta.Trailers = parseMessageTrailers(commit.Message)
+	return nil
+}
+
+func (a *apiRepo) GetDiffPathsForCommitID(commitID string) ([]string, error) {
+	commit, err := a.getCommit(commitID)
+	if err != nil {
+		return nil, err
+	}
+	return commit.Paths, nil
+}
+
+func (a *apiRepo) CommitMessage(id string) (string, error) {
+	commit, err := a.getCommit(id)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(commit.Message), nil
+}
+
+func (a *apiRepo) CommitBody(id string) (string, error) {
+	commit, err := a.getCommit(id)
+	if err != nil {
+		return "", err
+	}
+	_, body := splitCommitMessage(commit.Message)
+	return body, nil
+}
+
+func (a *apiRepo) HasCommit(commitID string) (bool, error) {
+	if _, err := a.getCommit(commitID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// getCommit gets the specified commit, including the paths it changed, from
+// the git provider's API. Commits are cached for the lifetime of the apiRepo,
+// since both the paths and the body of a commit may be needed.
+func (a *apiRepo) getCommit(id string) (*gitprovider.Commit, error) {
+	if commit, ok := a.commits[id]; ok {
+		return commit, nil
+	}
+	commit, err := a.browser.GetCommit(a.ctx, a.url, id)
+	if err != nil {
+		return nil, err
+	}
+	a.commits[id] = commit
+	return commit, nil
+}
+
+func (a *apiRepo) URL() string {
+	return a.url
+}
+
+func (a *apiRepo) WorkingDir() string {
+	return ""
+}
+
+func (a *apiRepo) HomeDir() string {
+	return ""
+}
+
+func (a *apiRepo) Close() error {
+	return nil
+}
+
+func (a *apiRepo) AddAll() error {
+	return errUnsupportedByAPIRepo("adding changes")
+}
+
+func (a *apiRepo) AddAllAndCommit(string) error {
+	return errUnsupportedByAPIRepo("committing changes")
+}
+
+func (a *apiRepo) Clean() error {
+	return errUnsupportedByAPIRepo("cleaning the working tree")
+}
+
+func (a *apiRepo) Commit(string, *git.CommitOptions) error {
+	return errUnsupportedByAPIRepo("committing changes")
+}
+
+func (a *apiRepo) CreateChildBranch(string) error {
+	return errUnsupportedByAPIRepo("creating branches")
+}
+
+func (a *apiRepo) CreateOrphanedBranch(string) error {
+	return errUnsupportedByAPIRepo("creating branches")
+}
+
+func (a *apiRepo) DeleteBranch(string) error {
+	return errUnsupportedByAPIRepo("deleting branches")
+}
+
+func (a *apiRepo) DeleteRemoteBranch(string) error {
+	return errUnsupportedByAPIRepo("deleting branches")
+}
+
+func (a *apiRepo) HasDiffs() (bool, error) {
+	return false, errUnsupportedByAPIRepo("inspecting the working tree")
+}
+
+func (a *apiRepo) IsAncestor(string, string) (bool, error) {
+	return false, errUnsupportedByAPIRepo("determining commit ancestry")
+}
+
+func (a *apiRepo) LastCommitID() (string, error) {
+	return "", errUnsupportedByAPIRepo("determining the last commit")
+}
+
+func (a *apiRepo) Push(bool) error {
+	return errUnsupportedByAPIRepo("pushing")
+}
+
+func (a *apiRepo) PullRebase() error {
+	return errUnsupportedByAPIRepo("pulling")
+}
+
+func (a *apiRepo) RefsHaveDiffs(string, string) (bool, error) {
+	return false, errUnsupportedByAPIRepo("comparing refs")
+}
+
+func (a *apiRepo) RemoteBranchExists(string) (bool, error) {
+	return false, errUnsupportedByAPIRepo("looking up remote branches")
+}
+
+func (a *apiRepo) ResetHard() error {
+	return errUnsupportedByAPIRepo("resetting the working tree")
+}
+
+func (a *apiRepo) TrustSigningKeys(string, string) error {
+	return errUnsupportedByAPIRepo("verifying signatures")
+}
+
+func (a *apiRepo) VerifyCommitSignature(string) (bool, error) {
+	return false, errUnsupportedByAPIRepo("verifying signatures")
+}
+
+func (a *apiRepo) VerifyTagSignature(string) (bool, error) {
+	return false, errUnsupportedByAPIRepo("verifying signatures")
+}
+
+func errUnsupportedByAPIRepo(operation string) error {
+	return fmt.Errorf(
+		"%s is not supported when using the git provider's API for discovery",
+		operation,
+	)
+}
+
+// splitCommitMessage splits the provided commit message into its subject and
+// body the way Git does: the subject is the first paragraph, with its lines
+// joined by spaces, and the body is the remainder of the message.
+func splitCommitMessage(message string) (string, string) {
+	message = strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n"))
+	subject, body, _ := strings.Cut(message, "\n\n")
+	return strings.Join(strings.Fields(subject), " "), strings.TrimSpace(body)
+}
+
+// parseMessageTrailers parses the trailers from the last paragraph of the
+// provided commit message into a map of trailer values indexed by key. The
+// last paragraph is only considered to be a trailer block if it is not the
+// subject and if every line in it is either a trailer or the continuation of
+// one. If there are no trailers, nil is returned.
+func parseMessageTrailers(message string) map[string][]string {
+	_, body := splitCommitMessage(message)
+	if body == "" {
+		return nil
+	}
+	paragraphs := strings.Split(body, "\n\n")
+	lines := strings.Split(strings.TrimSpace(paragraphs[len(paragraphs)-1]), "\n")
+	var keys, values []string
+	for _, line := range lines {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(values) > 0 {
+			// Continuation of the previous trailer's value
+			values[len(values)-1] += " " + strings.TrimSpace(line)
+			continue
+		}
+		match := trailerRegex.FindStringSubmatch(line)
+		if match == nil {
+			return nil
+		}
+		keys = append(keys, match[1])
+		values = append(values, strings.TrimSpace(match[2]))
+	}
+	trailers := make(map[string][]string, len(keys))
+	for i, key := range keys {
+		trailers[key] = append(trailers[key], values[i])
+	}
+	return trailers
+}
@@ -0,0 +1,205 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/akuity/kargo/internal/controller/git"
+	"github.com/akuity/kargo/internal/gitprovider"
+)
+
+// fakeRepoBrowser is a gitprovider.RepoBrowser that serves a fixed set of
+// branches, commits, and tags.
+type fakeRepoBrowser struct {
+	branches []string
+	commits  []gitprovider.Commit
+	tags     []gitprovider.Tag
+}
+
+func (f *fakeRepoBrowser) ListBranches(context.Context, string) ([]string, error) {
+	return f.branches, nil
+}
+
+func (f *fakeRepoBrowser) GetDefaultBranch(context.Context, string) (string, error) {
+	return "main", nil
+}
+
+func (f *fakeRepoBrowser) ListCommits(
+	_ context.Context,
+	_ string,
+	_ string,
+	page int,
+	perPage int,
+) ([]gitprovider.Commit, error) {
+	start := (page - 1) * perPage
+	if start >= len(f.commits) {
+		return nil, nil
+	}
+	return f.commits[start:min(start+perPage, len(f.commits))], nil
+}
+
+func (f *fakeRepoBrowser) GetCommit(
+	_ context.Context,
+	_ string,
+	id string,
+) (*gitprovider.Commit, error) {
+	for _, commit := range f.commits {
+		if commit.ID == id {
+			return &commit, nil
+		}
+	}
+	return nil, fmt.Errorf("commit %q not found", id)
+}
+
+func (f *fakeRepoBrowser) ListTags(context.Context, string) ([]gitprovider.Tag, error) {
+	return f.tags, nil
+}
+
+func TestAPIRepoListCommits(t *testing.T) {
+	commitDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &apiRepo{
+		browser: &fakeRepoBrowser{
+			commits: []gitprovider.Commit{
+				{
+					ID:          "abc",
+					Message:     "first\n\nbody\n\nTicket: 123",
+					Author:      "author <author@example.com>",
+					Committer:   "committer <committer@example.com>",
+					CommitDate:  commitDate,
+					ParentCount: 2,
+				},
+				{ID: "def", Message: "second"},
+				{ID: "xyz", Message: "third"},
+			},
+		},
+	}
+
+	commits, err := repo.ListCommits(2, 0)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]git.CommitMetadata{
+			{
+				ID:          "abc",
+				CommitDate:  commitDate,
+				Author:      "author <author@example.com>",
+				Committer:   "committer <committer@example.com>",
+				Subject:     "first",
+				Trailers:    map[string][]string{"Ticket": {"123"}},
+				ParentCount: 2,
+			},
+			{ID: "def", Subject: "second"},
+		},
+		commits,
+	)
+
+	commits, err = repo.ListCommits(2, 2)
+	require.NoError(t, err)
+	require.Equal(t, []git.CommitMetadata{{ID: "xyz", Subject: "third"}}, commits)
+
+	_, err = repo.ListCommits(2, 1)
+	require.ErrorContains(t, err, "cannot list 2 commits after skipping 1")
+}
+
+func TestAPIRepoGetCommit(t *testing.T) {
+	repo := &apiRepo{
+		browser: &fakeRepoBrowser{
+			commits: []gitprovider.Commit{{
+				ID:      "abc",
+				Message: "subject\n\nbody",
+				Paths:   []string{"a.txt"},
+			}},
+		},
+		commits: map[string]*gitprovider.Commit{},
+	}
+
+	paths, err := repo.GetDiffPathsForCommitID("abc")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a.txt"}, paths)
+
+	body, err := repo.CommitBody("abc")
+	require.NoError(t, err)
+	require.Equal(t, "body", body)
+
+	meta := git.TagMetadata{Tag: "v1.0.0", CommitID: "abc"}
+	require.NoError(t, repo.resolveTagMetadata(&meta))
+	require.Equal(t, "subject", meta.Subject)
+
+	_, err = repo.GetDiffPathsForCommitID("xyz")
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestSplitCommitMessage(t *testing.T) {
+	testCases := []struct {
+		name    string
+		message string
+		subject string
+		body    string
+	}{
+		{
+			name:    "subject only",
+			message: "subject\n",
+			subject: "subject",
+		},
+		{
+			name:    "multi-line subject",
+			message: "first line\nsecond line\n\nbody",
+			subject: "first line second line",
+			body:    "body",
+		},
+		{
+			name:    "CRLF line endings",
+			message: "subject\r\n\r\nbody\r\n",
+			subject: "subject",
+			body:    "body",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			subject, body := splitCommitMessage(testCase.message)
+			require.Equal(t, testCase.subject, subject)
+			require.Equal(t, testCase.body, body)
+		})
+	}
+}
+
+func TestParseMessageTrailers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		message  string
+		expected map[string][]string
+	}{
+		{
+			name:    "subject only",
+			message: "Ticket: 123",
+		},
+		{
+			name:    "last paragraph is not a trailer block",
+			message: "subject\n\nTicket: 123\nnot a trailer",
+		},
+		{
+			name:    "trailers",
+			message: "subject\n\nbody\n\nTicket: 123\nSigned-off-by: a\nSigned-off-by: b",
+			expected: map[string][]string{
+				"Ticket":        {"123"},
+				"Signed-off-by": {"a", "b"},
+			},
+		},
+		{
+			name:    "folded trailer",
+			message: "subject\n\nNote: first\n  second",
+			expected: map[string][]string{
+				"Note": {"first second"},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, parseMessageTrailers(testCase.message))
+		})
+	}
+}
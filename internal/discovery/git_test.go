@@ -0,0 +1,2319 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/controller/git"
+	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/gitprovider"
+)
+
+// fakeRepo is a git.Repo that only supports being closed.
+type fakeRepo struct {
+	git.Repo
+	branch string
+}
+
+func (f *fakeRepo) CurrentBranch() string {
+	return f.branch
+}
+
+func (f *fakeRepo) Close() error {
+	return nil
+}
+
+func TestDiscoverCommits(t *testing.T) {
+	testCases := []struct {
+		name       string
+		discoverer *Discoverer
+		subs       []kargoapi.RepoSubscription
+		assertions func(*testing.T, []kargoapi.GitDiscoveryResult, error)
+	}{
+		{
+			name: "error cloning repository",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{}},
+			},
+			assertions: func(t *testing.T, _ []kargoapi.GitDiscoveryResult, err error) {
+				require.ErrorContains(t, err, "failed to clone git repo")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "error accessing repository through provider API",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				newAPIRepoFn: func(context.Context, string, string, *git.RepoCredentials) (git.Repo, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					DiscoveryMode: kargoapi.GitDiscoveryModeProviderAPI,
+				}},
+			},
+			assertions: func(t *testing.T, _ []kargoapi.GitDiscoveryResult, err error) {
+				require.ErrorContains(t, err, "through its provider's API")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "discovers tags through provider API",
+			discoverer: func() *Discoverer {
+				r := &Discoverer{
+					credentialsDB: &credentials.FakeDB{},
+					newAPIRepoFn: func(
+						_ context.Context,
+						repoURL string,
+						branch string,
+						_ *git.RepoCredentials,
+					) (git.Repo, error) {
+						return &apiRepo{
+							url:    repoURL,
+							branch: branch,
+							browser: &fakeRepoBrowser{
+								tags: []gitprovider.Tag{
+									{Name: "v1.0.0", CommitID: "abc"},
+									{Name: "v2.0.0", CommitID: "xyz"},
+								},
+								commits: []gitprovider.Commit{
+									{ID: "abc", Message: "first"},
+									{ID: "xyz", Message: "second"},
+								},
+							},
+							commits: map[string]*gitprovider.Commit{},
+						}, nil
+					},
+				}
+				r.listTagsFn = r.listTags
+				r.discoverTagsFn = r.discoverTags
+				return r
+			}(),
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					RepoURL:                 "fake-repo",
+					CommitSelectionStrategy: kargoapi.CommitSelectionStrategySemVer,
+					DiscoveryMode:           kargoapi.GitDiscoveryModeProviderAPI,
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.GitDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.GitDiscoveryResult{{
+					RepoURL: "fake-repo",
+					Commits: []kargoapi.DiscoveredCommit{
+						{ID: "xyz", Tag: "v2.0.0", Subject: "second", CreatorDate: &metav1.Time{}},
+						{ID: "abc", Tag: "v1.0.0", Subject: "first", CreatorDate: &metav1.Time{}},
+					},
+				}}, results)
+			},
+		},
+		{
+			name: "error obtaining credentials",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{
+					GetFn: func(
+						context.Context,
+						string,
+						credentials.Type,
+						string,
+					) (credentials.Credentials, bool, error) {
+						return credentials.Credentials{}, false, errors.New("something went wrong")
+					},
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{}},
+			},
+			assertions: func(t *testing.T, _ []kargoapi.GitDiscoveryResult, err error) {
+				require.ErrorContains(t, err, "error obtaining credentials for git repo")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "discovers tags",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error) {
+					return &fakeRepo{}, nil
+				},
+				discoverTagsFn: func(git.Repo, kargoapi.GitSubscription) ([]git.TagMetadata, error) {
+					return []git.TagMetadata{
+						{Tag: "v2.0.0"},
+						{Tag: "v1.0.0"},
+					}, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					RepoURL:                 "fake-repo",
+					CommitSelectionStrategy: kargoapi.CommitSelectionStrategySemVer,
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.GitDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.GitDiscoveryResult{
+					{
+						RepoURL: "fake-repo",
+						Commits: []kargoapi.DiscoveredCommit{
+							{Tag: "v2.0.0", CreatorDate: &metav1.Time{}},
+							{Tag: "v1.0.0", CreatorDate: &metav1.Time{}},
+						},
+					},
+				}, results)
+			},
+		},
+		{
+			name: "error discovering tags",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error) {
+					return &fakeRepo{}, nil
+				},
+				discoverTagsFn: func(git.Repo, kargoapi.GitSubscription) ([]git.TagMetadata, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					CommitSelectionStrategy: kargoapi.CommitSelectionStrategySemVer,
+				}},
+			},
+			assertions: func(t *testing.T, _ []kargoapi.GitDiscoveryResult, err error) {
+				require.ErrorContains(t, err, "error listing tags from git repo")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "discovers branch history",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error) {
+					return &fakeRepo{branch: "trunk"}, nil
+				},
+				discoverBranchHistoryFn: func(git.Repo, kargoapi.GitSubscription) ([]git.CommitMetadata, error) {
+					return []git.CommitMetadata{
+						{ID: "abc"},
+						{ID: "xyz"},
+					}, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					RepoURL:                 "fake-repo",
+					CommitSelectionStrategy: kargoapi.CommitSelectionStrategyNewestFromBranch,
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.GitDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.GitDiscoveryResult{
+					{
+						RepoURL:       "fake-repo",
+						DefaultBranch: "trunk",
+						Commits: []kargoapi.DiscoveredCommit{
+							{ID: "abc", Branch: "trunk", CreatorDate: &metav1.Time{}},
+							{ID: "xyz", Branch: "trunk", CreatorDate: &metav1.Time{}},
+						},
+					},
+				}, results)
+			},
+		},
+		{
+			name: "discovers selected trailers",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error) {
+					return &fakeRepo{}, nil
+				},
+				discoverBranchHistoryFn: func(git.Repo, kargoapi.GitSubscription) ([]git.CommitMetadata, error) {
+					return []git.CommitMetadata{
+						{
+							ID: "abc",
+							Trailers: map[string][]string{
+								"Change-Id":     {"I123"},
+								"Signed-off-by": {"Jane <jane@example.com>"},
+							},
+						},
+					}, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					RepoURL:                 "fake-repo",
+					CommitSelectionStrategy: kargoapi.CommitSelectionStrategyNewestFromBranch,
+					Trailers:                []string{"change-id", "Ticket"},
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.GitDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.GitDiscoveryResult{
+					{
+						RepoURL: "fake-repo",
+						Commits: []kargoapi.DiscoveredCommit{
+							{
+								ID:          "abc",
+								CreatorDate: &metav1.Time{},
+								Trailers:    map[string]string{"change-id": "I123"},
+							},
+						},
+					},
+				}, results)
+			},
+		},
+		{
+			name: "error discovering branch history",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error) {
+					return &fakeRepo{}, nil
+				},
+				discoverBranchHistoryFn: func(git.Repo, kargoapi.GitSubscription) ([]git.CommitMetadata, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					CommitSelectionStrategy: kargoapi.CommitSelectionStrategyNewestFromBranch,
+				}},
+			},
+			assertions: func(t *testing.T, _ []kargoapi.GitDiscoveryResult, err error) {
+				require.ErrorContains(t, err, "error listing commits from git repo")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "discovers for each service",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error) {
+					return &fakeRepo{}, nil
+				},
+				discoverBranchHistoryFn: func(
+					_ git.Repo,
+					sub kargoapi.GitSubscription,
+				) ([]git.CommitMetadata, error) {
+					switch sub.IncludePaths[0] {
+					case "services/foo":
+						return []git.CommitMetadata{{ID: "abc"}}, nil
+					case "services/bar":
+						return []git.CommitMetadata{{ID: "xyz"}}, nil
+					}
+					return nil, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					RepoURL:                 "fake-repo",
+					CommitSelectionStrategy: kargoapi.CommitSelectionStrategyNewestFromBranch,
+					Services: []kargoapi.GitService{
+						{Name: "foo", Path: "services/foo"},
+						{Name: "bar", Path: "services/bar"},
+					},
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.GitDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.GitDiscoveryResult{
+					{
+						RepoURL: "fake-repo",
+						Service: "foo",
+						Commits: []kargoapi.DiscoveredCommit{
+							{ID: "abc", CreatorDate: &metav1.Time{}},
+						},
+					},
+					{
+						RepoURL: "fake-repo",
+						Service: "bar",
+						Commits: []kargoapi.DiscoveredCommit{
+							{ID: "xyz", CreatorDate: &metav1.Time{}},
+						},
+					},
+				}, results)
+			},
+		},
+		{
+			name: "error discovering for service",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error) {
+					return &fakeRepo{}, nil
+				},
+				discoverBranchHistoryFn: func(git.Repo, kargoapi.GitSubscription) ([]git.CommitMetadata, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					Services: []kargoapi.GitService{{Name: "foo", Path: "services/foo"}},
+				}},
+			},
+			assertions: func(t *testing.T, _ []kargoapi.GitDiscoveryResult, err error) {
+				require.ErrorContains(t, err, `error discovering commits for service "foo"`)
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "clones shallowly",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(_ string, _ *git.ClientOptions, opts *git.CloneOptions) (git.Repo, error) {
+					if opts.Depth != 5 {
+						return nil, fmt.Errorf("expected a clone of depth 5, got %d", opts.Depth)
+					}
+					return &fakeRepo{}, nil
+				},
+				discoverBranchHistoryFn: func(git.Repo, kargoapi.GitSubscription) ([]git.CommitMetadata, error) {
+					return []git.CommitMetadata{{ID: "abc"}}, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					RepoURL:    "fake-repo",
+					Branch:     "main",
+					CloneDepth: 5,
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.GitDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Len(t, results, 1)
+				require.Len(t, results[0].Commits, 1)
+			},
+		},
+		{
+			name: "discovers from branches matching pattern",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(_ string, _ *git.ClientOptions, opts *git.CloneOptions) (git.Repo, error) {
+					if opts.Branch != "" || opts.SingleBranch {
+						return nil, errors.New("expected a clone of all branches")
+					}
+					return &fakeRepo{}, nil
+				},
+				listBranchesFn: func(git.Repo) ([]string, error) {
+					return []string{"main", "release/1.0", "release/2.0"}, nil
+				},
+				checkoutBranchFn: func(git.Repo, string) error {
+					return nil
+				},
+				discoverBranchHistoryFn: func(_ git.Repo, sub kargoapi.GitSubscription) ([]git.CommitMetadata, error) {
+					return []git.CommitMetadata{{ID: sub.Branch + "-head"}}, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					RepoURL: "fake-repo",
+					Branch:  "glob:release/*",
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.GitDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.GitDiscoveryResult{
+					{
+						RepoURL: "fake-repo",
+						Branch:  "release/1.0",
+						Commits: []kargoapi.DiscoveredCommit{
+							{ID: "release/1.0-head", Branch: "release/1.0", CreatorDate: &metav1.Time{}},
+						},
+					},
+					{
+						RepoURL: "fake-repo",
+						Branch:  "release/2.0",
+						Commits: []kargoapi.DiscoveredCommit{
+							{ID: "release/2.0-head", Branch: "release/2.0", CreatorDate: &metav1.Time{}},
+						},
+					},
+				}, results)
+			},
+		},
+		{
+			name: "no branches matching pattern",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error) {
+					return &fakeRepo{}, nil
+				},
+				listBranchesFn: func(git.Repo) ([]string, error) {
+					return []string{"main"}, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					RepoURL: "fake-repo",
+					Branch:  `regexp:^release/\d+$`,
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.GitDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.GitDiscoveryResult{{RepoURL: "fake-repo"}}, results)
+			},
+		},
+		{
+			name: "error checking out branch matching pattern",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error) {
+					return &fakeRepo{}, nil
+				},
+				listBranchesFn: func(git.Repo) ([]string, error) {
+					return []string{"release/1.0"}, nil
+				},
+				checkoutBranchFn: func(git.Repo, string) error {
+					return errors.New("something went wrong")
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					RepoURL: "fake-repo",
+					Branch:  "glob:release/*",
+				}},
+			},
+			assertions: func(t *testing.T, _ []kargoapi.GitDiscoveryResult, err error) {
+				require.ErrorContains(t, err, `error checking out branch "release/1.0"`)
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "error getting trusted keys",
+			discoverer: &Discoverer{
+				client:        fake.NewClientBuilder().Build(),
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error) {
+					return &fakeRepo{}, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					VerifySignatures: &kargoapi.GitSignatureVerification{
+						SecretName: "fake-secret",
+					},
+				}},
+			},
+			assertions: func(t *testing.T, _ []kargoapi.GitDiscoveryResult, err error) {
+				require.ErrorContains(t, err, "error configuring trusted keys for git repo")
+				require.ErrorContains(t, err, `error getting trusted keys Secret "fake-secret"`)
+			},
+		},
+		{
+			name: "trusted keys Secret without keys",
+			discoverer: &Discoverer{
+				client: fake.NewClientBuilder().WithObjects(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "fake-ns",
+						Name:      "fake-secret",
+					},
+				}).Build(),
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error) {
+					return &fakeRepo{}, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					VerifySignatures: &kargoapi.GitSignatureVerification{
+						SecretName: "fake-secret",
+					},
+				}},
+			},
+			assertions: func(t *testing.T, _ []kargoapi.GitDiscoveryResult, err error) {
+				require.ErrorContains(t, err, `has neither a "gpgKeys" nor a "sshKeys" key`)
+			},
+		},
+		{
+			name: "marks unverified commits",
+			discoverer: &Discoverer{
+				client: fake.NewClientBuilder().WithObjects(&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "fake-ns",
+						Name:      "fake-secret",
+					},
+					Data: map[string][]byte{
+						kargoapi.GitSignatureVerificationSSHKeysSecretKey: []byte("fake-ssh-key"),
+					},
+				}).Build(),
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error) {
+					return &fakeRepo{}, nil
+				},
+				trustSigningKeysFn: func(_ git.Repo, gpgKeys, sshKeys string) error {
+					if gpgKeys != "" || sshKeys != "fake-ssh-key" {
+						return errors.New("unexpected keys")
+					}
+					return nil
+				},
+				discoverBranchHistoryFn: func(git.Repo, kargoapi.GitSubscription) ([]git.CommitMetadata, error) {
+					return []git.CommitMetadata{
+						{ID: "abc"},
+						{ID: "xyz"},
+					}, nil
+				},
+				verifyCommitSignatureFn: func(_ git.Repo, id string) (bool, error) {
+					return id == "abc", nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					RepoURL: "fake-repo",
+					VerifySignatures: &kargoapi.GitSignatureVerification{
+						SecretName:       "fake-secret",
+						UnverifiedPolicy: kargoapi.UnverifiedCommitPolicyMark,
+					},
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.GitDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.GitDiscoveryResult{
+					{
+						RepoURL: "fake-repo",
+						Commits: []kargoapi.DiscoveredCommit{
+							{
+								ID:              "abc",
+								CreatorDate:     &metav1.Time{},
+								SignatureStatus: kargoapi.GitSignatureStatusVerified,
+							},
+							{
+								ID:              "xyz",
+								CreatorDate:     &metav1.Time{},
+								SignatureStatus: kargoapi.GitSignatureStatusUnverified,
+							},
+						},
+					},
+				}, results)
+			},
+		},
+		{
+			name: "discovers for multiple subscriptions",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				gitCloneFn: func(string, *git.ClientOptions, *git.CloneOptions) (git.Repo, error) {
+					return &fakeRepo{}, nil
+				},
+				discoverTagsFn: func(git.Repo, kargoapi.GitSubscription) ([]git.TagMetadata, error) {
+					return []git.TagMetadata{
+						{Tag: "v2.0.0"},
+						{Tag: "v1.0.0"},
+					}, nil
+				},
+				discoverBranchHistoryFn: func(git.Repo, kargoapi.GitSubscription) ([]git.CommitMetadata, error) {
+					return []git.CommitMetadata{
+						{ID: "abc"},
+						{ID: "xyz"},
+					}, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{
+					RepoURL:                 "fake-repo-1",
+					CommitSelectionStrategy: kargoapi.CommitSelectionStrategyNewestTag,
+				}},
+				{Image: &kargoapi.ImageSubscription{}}, // Should be ignored
+				{Git: &kargoapi.GitSubscription{
+					RepoURL:                 "fake-repo-2",
+					CommitSelectionStrategy: kargoapi.CommitSelectionStrategyNewestFromBranch,
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.GitDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.GitDiscoveryResult{
+					{
+						RepoURL: "fake-repo-1",
+						Commits: []kargoapi.DiscoveredCommit{
+							{Tag: "v2.0.0", CreatorDate: &metav1.Time{}},
+							{Tag: "v1.0.0", CreatorDate: &metav1.Time{}},
+						},
+					},
+					{
+						RepoURL: "fake-repo-2",
+						Commits: []kargoapi.DiscoveredCommit{
+							{ID: "abc", CreatorDate: &metav1.Time{}},
+							{ID: "xyz", CreatorDate: &metav1.Time{}},
+						},
+					},
+				}, results)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			results, err := testCase.discoverer.discoverCommits(context.TODO(), "fake-ns", testCase.subs)
+			testCase.assertions(t, results, err)
+		})
+	}
+}
+
+func TestGetCloneURL(t *testing.T) {
+	mirrorsDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(mirrorsDir, "repo.git"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(mirrorsDir, "repo.bundle"), nil, 0o600))
+	otherDir := t.TempDir()
+	require.NoError(t, os.Symlink(otherDir, filepath.Join(mirrorsDir, "escape")))
+
+	r := &Discoverer{gitMirrorDirs: []string{mirrorsDir}}
+
+	testCases := []struct {
+		name       string
+		mirrorURL  string
+		assertions func(*testing.T, string, error)
+	}{
+		{
+			name: "no mirror",
+			assertions: func(t *testing.T, cloneURL string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "https://github.com/example/repo", cloneURL)
+			},
+		},
+		{
+			name:      "remote mirror",
+			mirrorURL: "https://mirror.example.com/repo",
+			assertions: func(t *testing.T, cloneURL string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "https://mirror.example.com/repo", cloneURL)
+			},
+		},
+		{
+			name:      "local mirror",
+			mirrorURL: "file://" + filepath.Join(mirrorsDir, "repo.git"),
+			assertions: func(t *testing.T, cloneURL string, err error) {
+				require.NoError(t, err)
+				require.True(t, strings.HasPrefix(cloneURL, "file://"))
+				require.True(t, strings.HasSuffix(cloneURL, "/repo.git"))
+			},
+		},
+		{
+			name:      "bundle",
+			mirrorURL: "file://" + filepath.Join(mirrorsDir, "repo.bundle"),
+			assertions: func(t *testing.T, cloneURL string, err error) {
+				require.NoError(t, err)
+				require.True(t, filepath.IsAbs(cloneURL))
+				require.True(t, strings.HasSuffix(cloneURL, "/repo.bundle"))
+			},
+		},
+		{
+			name:      "mirror does not exist",
+			mirrorURL: "file://" + filepath.Join(mirrorsDir, "missing.git"),
+			assertions: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, "error resolving mirror")
+			},
+		},
+		{
+			name:      "mirror outside of mirror directories",
+			mirrorURL: "file://" + otherDir,
+			assertions: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, "does not lie beneath a directory permitted for mirrors")
+			},
+		},
+		{
+			name:      "symlink out of mirror directories",
+			mirrorURL: "file://" + filepath.Join(mirrorsDir, "escape"),
+			assertions: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, "does not lie beneath a directory permitted for mirrors")
+			},
+		},
+		{
+			name:      "remote host",
+			mirrorURL: "file://example.com/repo.git",
+			assertions: func(t *testing.T, _ string, err error) {
+				require.ErrorContains(t, err, "does not refer to a local path")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cloneURL, err := r.getCloneURL(kargoapi.GitSubscription{
+				RepoURL:   "https://github.com/example/repo",
+				MirrorURL: testCase.mirrorURL,
+			})
+			testCase.assertions(t, cloneURL, err)
+		})
+	}
+
+	t.Run("no mirror directories", func(t *testing.T) {
+		_, err := (&Discoverer{}).getCloneURL(kargoapi.GitSubscription{
+			RepoURL:   "https://github.com/example/repo",
+			MirrorURL: "file://" + filepath.Join(mirrorsDir, "repo.git"),
+		})
+		require.ErrorContains(t, err, "does not lie beneath a directory permitted for mirrors")
+	})
+}
+
+func TestSelectTrailers(t *testing.T) {
+	testTrailers := map[string][]string{
+		"Change-Id": {"I123"},
+		"ticket":    {"ABC-1", "ABC-2"},
+	}
+	testCases := []struct {
+		name     string
+		trailers map[string][]string
+		keys     []string
+		expected map[string]string
+	}{
+		{
+			name:     "no trailers",
+			keys:     []string{"Change-Id"},
+			expected: nil,
+		},
+		{
+			name:     "no keys",
+			trailers: testTrailers,
+			expected: nil,
+		},
+		{
+			name:     "no matching trailers",
+			trailers: testTrailers,
+			keys:     []string{"Reviewed-by"},
+			expected: nil,
+		},
+		{
+			name:     "matching trailers",
+			trailers: testTrailers,
+			keys:     []string{"Change-Id", "Ticket", "Reviewed-by"},
+			expected: map[string]string{
+				"Change-Id": "I123",
+				"Ticket":    "ABC-1,ABC-2",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				selectTrailers(testCase.trailers, testCase.keys),
+			)
+		})
+	}
+}
+
+func TestDiscoverBranchHistory(t *testing.T) {
+	testCases := []struct {
+		name       string
+		sub        kargoapi.GitSubscription
+		discoverer *Discoverer
+		assertions func(*testing.T, []git.CommitMetadata, error)
+	}{
+		{
+			name: "error listing commits",
+			discoverer: &Discoverer{
+				listCommitsFn: func(git.Repo, uint, uint, []string) ([]git.CommitMetadata, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, _ []git.CommitMetadata, err error) {
+				require.ErrorContains(t, err, "error listing commits")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "without path filters",
+			discoverer: &Discoverer{
+				listCommitsFn: func(git.Repo, uint, uint, []string) ([]git.CommitMetadata, error) {
+					return []git.CommitMetadata{
+						{ID: "abc"},
+						{ID: "xyz"},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, commits []git.CommitMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.CommitMetadata{
+					{ID: "abc"},
+					{ID: "xyz"},
+				}, commits)
+			},
+		},
+		{
+			name: "with configured limit",
+			sub: kargoapi.GitSubscription{
+				DiscoveryLimit: 50,
+			},
+			discoverer: &Discoverer{
+				listCommitsFn: func(_ git.Repo, limit, _ uint, _ []string) ([]git.CommitMetadata, error) {
+					if limit != 50 {
+						return nil, fmt.Errorf("unexpected limit %d", limit)
+					}
+					return []git.CommitMetadata{{ID: "abc"}}, nil
+				},
+			},
+			assertions: func(t *testing.T, commits []git.CommitMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.CommitMetadata{{ID: "abc"}}, commits)
+			},
+		},
+		{
+			name: "skipping commits with default markers",
+			discoverer: &Discoverer{
+				listCommitsFn: func(_ git.Repo, _, skip uint, _ []string) ([]git.CommitMetadata, error) {
+					if skip > 0 {
+						return nil, nil
+					}
+					return []git.CommitMetadata{
+						{ID: "abc", Subject: "chore: bump version [kargo skip]"},
+						{ID: "def", Trailers: map[string][]string{"kargo-skip": {"True"}}},
+						{ID: "ghi", Trailers: map[string][]string{"Kargo-Skip": {"false"}}},
+						{ID: "xyz", Subject: "feat: add feature"},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, commits []git.CommitMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.CommitMetadata{
+					{ID: "ghi", Trailers: map[string][]string{"Kargo-Skip": {"false"}}},
+					{ID: "xyz", Subject: "feat: add feature"},
+				}, commits)
+			},
+		},
+		{
+			name: "skipping commits with configured markers",
+			sub: kargoapi.GitSubscription{
+				SkipMarkers: &kargoapi.GitCommitSkipMarkers{Trailer: "Skipped-By: bot"},
+			},
+			discoverer: &Discoverer{
+				listCommitsFn: func(_ git.Repo, _, skip uint, _ []string) ([]git.CommitMetadata, error) {
+					if skip > 0 {
+						return nil, nil
+					}
+					return []git.CommitMetadata{
+						{ID: "abc", Subject: "chore: bump version [kargo skip]"},
+						{ID: "def", Trailers: map[string][]string{"Skipped-By": {"bot"}}},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, commits []git.CommitMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.CommitMetadata{
+					{ID: "abc", Subject: "chore: bump version [kargo skip]"},
+				}, commits)
+			},
+		},
+		{
+			name: "excluding merge commits",
+			sub: kargoapi.GitSubscription{
+				ExcludeMergeCommits: true,
+			},
+			discoverer: &Discoverer{
+				listCommitsFn: func(_ git.Repo, _, skip uint, _ []string) ([]git.CommitMetadata, error) {
+					if skip > 0 {
+						return nil, nil
+					}
+					return []git.CommitMetadata{
+						{ID: "abc", ParentCount: 2},
+						{ID: "def", ParentCount: 1},
+						{ID: "xyz", ParentCount: 0},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, commits []git.CommitMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.CommitMetadata{
+					{ID: "def", ParentCount: 1},
+					{ID: "xyz", ParentCount: 0},
+				}, commits)
+			},
+		},
+		{
+			name: "only merge commits",
+			sub: kargoapi.GitSubscription{
+				OnlyMergeCommits: true,
+			},
+			discoverer: &Discoverer{
+				listCommitsFn: func(_ git.Repo, _, skip uint, _ []string) ([]git.CommitMetadata, error) {
+					if skip > 0 {
+						return nil, nil
+					}
+					return []git.CommitMetadata{
+						{ID: "abc", ParentCount: 2},
+						{ID: "def", ParentCount: 1},
+						{ID: "xyz", ParentCount: 3},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, commits []git.CommitMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.CommitMetadata{
+					{ID: "abc", ParentCount: 2},
+					{ID: "xyz", ParentCount: 3},
+				}, commits)
+			},
+		},
+		{
+			name: "with configured limit and path filters",
+			sub: kargoapi.GitSubscription{
+				IncludePaths:   []string{"src"},
+				DiscoveryLimit: 2,
+			},
+			discoverer: &Discoverer{
+				listCommitsFn: func(_ git.Repo, limit, skip uint, _ []string) ([]git.CommitMetadata, error) {
+					if skip > 0 {
+						return nil, nil
+					}
+					commits := make([]git.CommitMetadata, limit)
+					for i := range commits {
+						commits[i] = git.CommitMetadata{ID: fmt.Sprintf("commit-%d", i)}
+					}
+					return commits, nil
+				},
+				getDiffPathsForCommitIDFn: func(git.Repo, string) ([]string, error) {
+					return []string{"src/main.go"}, nil
+				},
+			},
+			assertions: func(t *testing.T, commits []git.CommitMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.CommitMetadata{{ID: "commit-0"}, {ID: "commit-1"}}, commits)
+			},
+		},
+		{
+			name: "error getting diff path",
+			sub: kargoapi.GitSubscription{
+				IncludePaths: []string{regexpPrefix + "^.*third_path_to_a/file$"},
+			},
+			discoverer: &Discoverer{
+				listCommitsFn: func(git.Repo, uint, uint, []string) ([]git.CommitMetadata, error) {
+					return []git.CommitMetadata{
+						{ID: "abc"},
+						{ID: "xyz"},
+					}, nil
+				},
+				getDiffPathsForCommitIDFn: func(_ git.Repo, _ string) ([]string, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, _ []git.CommitMetadata, err error) {
+				require.ErrorContains(t, err, "error getting diff paths for commit")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "with translatable path filters",
+			sub: kargoapi.GitSubscription{
+				IncludePaths: []string{"apps/foo"},
+				ExcludePaths: []string{globPrefix + "apps/foo/*.md"},
+			},
+			discoverer: &Discoverer{
+				listCommitsFn: func(_ git.Repo, _ uint, skip uint, pathspecs []string) ([]git.CommitMetadata, error) {
+					if !slices.Equal(
+						[]string{":(top,literal)apps/foo", ":(exclude,top,glob)apps/foo/*.md"},
+						pathspecs,
+					) {
+						return nil, errors.New("unexpected pathspecs")
+					}
+					if skip > 0 {
+						return nil, nil
+					}
+					return []git.CommitMetadata{
+						{ID: "abc"},
+						{ID: "xyz"},
+					}, nil
+				},
+				getDiffPathsForCommitIDFn: func(_ git.Repo, id string) ([]string, error) {
+					if id == "abc" {
+						return []string{"apps/foo/values.yaml"}, nil
+					}
+					// Git may return merge commits that do not match
+					return []string{"apps/bar/values.yaml"}, nil
+				},
+			},
+			assertions: func(t *testing.T, commits []git.CommitMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.CommitMetadata{
+					{ID: "abc"},
+				}, commits)
+			},
+		},
+		{
+			name: "with path filters",
+			sub: kargoapi.GitSubscription{
+				IncludePaths: []string{regexpPrefix + "^.*third_path_to_a/file$"},
+			},
+			discoverer: &Discoverer{
+				listCommitsFn: func(_ git.Repo, _ uint, skip uint, _ []string) ([]git.CommitMetadata, error) {
+					if skip > 0 {
+						return nil, nil
+					}
+					return []git.CommitMetadata{
+						{ID: "abc"},
+						{ID: "xyz"},
+					}, nil
+				},
+				getDiffPathsForCommitIDFn: func(_ git.Repo, id string) ([]string, error) {
+					if id == "abc" {
+						return []string{"third_path_to_a/file"}, nil
+					}
+					return []string{"first_path_to_a/file", "second_path_to_a/file"}, nil
+				},
+			},
+			assertions: func(t *testing.T, commits []git.CommitMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.CommitMetadata{
+					{ID: "abc"},
+				}, commits)
+			},
+		},
+		{
+			name: "with expression filter",
+			sub: kargoapi.GitSubscription{
+				ExpressionFilter: `author contains "release-bot" && !(message startsWith "chore")`,
+			},
+			discoverer: &Discoverer{
+				listCommitsFn: func(_ git.Repo, _ uint, skip uint, _ []string) ([]git.CommitMetadata, error) {
+					if skip > 0 {
+						return nil, nil
+					}
+					return []git.CommitMetadata{
+						{ID: "abc", Author: "Jane Doe <jane@example.com>", Subject: "feat: foo"},
+						{ID: "def", Author: "Release Bot <release-bot@example.com>", Subject: "chore: bar"},
+						{ID: "xyz", Author: "Release Bot <release-bot@example.com>", Subject: "feat: baz"},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, commits []git.CommitMetadata, err error) {
+				require.NoError(t, err)
+				require.Len(t, commits, 1)
+				require.Equal(t, "xyz", commits[0].ID)
+			},
+		},
+		{
+			name: "with expression filter using paths",
+			sub: kargoapi.GitSubscription{
+				ExpressionFilter: `all(paths, # endsWith ".md")`,
+			},
+			discoverer: &Discoverer{
+				listCommitsFn: func(_ git.Repo, _ uint, skip uint, _ []string) ([]git.CommitMetadata, error) {
+					if skip > 0 {
+						return nil, nil
+					}
+					return []git.CommitMetadata{
+						{ID: "abc"},
+						{ID: "xyz"},
+					}, nil
+				},
+				getDiffPathsForCommitIDFn: func(_ git.Repo, id string) ([]string, error) {
+					if id == "abc" {
+						return []string{"README.md"}, nil
+					}
+					return []string{"README.md", "main.go"}, nil
+				},
+			},
+			assertions: func(t *testing.T, commits []git.CommitMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.CommitMetadata{
+					{ID: "abc"},
+				}, commits)
+			},
+		},
+		{
+			name: "with expression filter using commit body",
+			sub: kargoapi.GitSubscription{
+				ExpressionFilter: `commit.Author contains "release-bot" && !(commit.Body contains "[skip]")`,
+			},
+			discoverer: &Discoverer{
+				listCommitsFn: func(_ git.Repo, _ uint, skip uint, _ []string) ([]git.CommitMetadata, error) {
+					if skip > 0 {
+						return nil, nil
+					}
+					return []git.CommitMetadata{
+						{ID: "abc", Author: "Release Bot <release-bot@example.com>"},
+						{ID: "xyz", Author: "Release Bot <release-bot@example.com>"},
+					}, nil
+				},
+				getCommitBodyFn: func(_ git.Repo, id string) (string, error) {
+					if id == "abc" {
+						return "Bump dependencies\n\n[skip]", nil
+					}
+					return "Bump dependencies", nil
+				},
+			},
+			assertions: func(t *testing.T, commits []git.CommitMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.CommitMetadata{
+					{ID: "xyz", Author: "Release Bot <release-bot@example.com>"},
+				}, commits)
+			},
+		},
+		{
+			name: "error getting commit body",
+			sub: kargoapi.GitSubscription{
+				ExpressionFilter: `body != ""`,
+			},
+			discoverer: &Discoverer{
+				listCommitsFn: func(git.Repo, uint, uint, []string) ([]git.CommitMetadata, error) {
+					return []git.CommitMetadata{{ID: "abc"}}, nil
+				},
+				getCommitBodyFn: func(git.Repo, string) (string, error) {
+					return "", errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, _ []git.CommitMetadata, err error) {
+				require.ErrorContains(t, err, `error getting message body of commit "abc"`)
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "with invalid expression filter",
+			sub: kargoapi.GitSubscription{
+				ExpressionFilter: "author ==",
+			},
+			discoverer: &Discoverer{},
+			assertions: func(t *testing.T, _ []git.CommitMetadata, err error) {
+				require.ErrorContains(t, err, "error parsing expression filter")
+			},
+		},
+		{
+			name: "skipping unverified commits",
+			sub: kargoapi.GitSubscription{
+				DiscoveryLimit: 2,
+				VerifySignatures: &kargoapi.GitSignatureVerification{
+					SecretName: "fake-secret",
+				},
+			},
+			discoverer: &Discoverer{
+				listCommitsFn: func(_ git.Repo, _, skip uint, _ []string) ([]git.CommitMetadata, error) {
+					if skip > 0 {
+						return []git.CommitMetadata{{ID: "ghi"}}, nil
+					}
+					return []git.CommitMetadata{{ID: "abc"}, {ID: "def"}}, nil
+				},
+				verifyCommitSignatureFn: func(_ git.Repo, id string) (bool, error) {
+					return id != "def", nil
+				},
+			},
+			assertions: func(t *testing.T, commits []git.CommitMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.CommitMetadata{
+					{ID: "abc"},
+					{ID: "ghi"},
+				}, commits)
+			},
+		},
+		{
+			name: "error verifying commit signature",
+			sub: kargoapi.GitSubscription{
+				VerifySignatures: &kargoapi.GitSignatureVerification{
+					SecretName: "fake-secret",
+				},
+			},
+			discoverer: &Discoverer{
+				listCommitsFn: func(git.Repo, uint, uint, []string) ([]git.CommitMetadata, error) {
+					return []git.CommitMetadata{{ID: "abc"}}, nil
+				},
+				verifyCommitSignatureFn: func(git.Repo, string) (bool, error) {
+					return false, errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, _ []git.CommitMetadata, err error) {
+				require.ErrorContains(t, err, `error verifying signature of commit "abc"`)
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tags, err := testCase.discoverer.discoverBranchHistory(nil, testCase.sub)
+			testCase.assertions(t, tags, err)
+		})
+	}
+}
+
+func TestDiscoverTags(t *testing.T) {
+	testCases := []struct {
+		name       string
+		sub        kargoapi.GitSubscription
+		discoverer *Discoverer
+		assertions func(*testing.T, []git.TagMetadata, error)
+	}{
+		{
+			name: "error listing tags",
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, _ []git.TagMetadata, err error) {
+				require.ErrorContains(t, err, "error listing tags")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "error determining if tag is reachable from branch",
+			sub: kargoapi.GitSubscription{
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategyNewestTag,
+				RestrictTagsToBranch:    true,
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return []git.TagMetadata{
+						{Tag: "v1.0.0", CommitID: "fake-commit-id"},
+					}, nil
+				},
+				isReachableFromBranchFn: func(git.Repo, string) (bool, error) {
+					return false, errors.New("something went wrong")
+				},
+			},
+			assertions: func(t *testing.T, _ []git.TagMetadata, err error) {
+				require.ErrorContains(t, err, "failed to filter tags by branch")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "restrict tags to branch",
+			sub: kargoapi.GitSubscription{
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategySemVer,
+				RestrictTagsToBranch:    true,
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return []git.TagMetadata{
+						{Tag: "v1.0.1", CommitID: "hotfix-commit-id"},
+						{Tag: "v2.0.0", CommitID: "main-commit-id"},
+						{Tag: "v1.0.0", CommitID: "old-main-commit-id"},
+					}, nil
+				},
+				isReachableFromBranchFn: func(_ git.Repo, commitID string) (bool, error) {
+					return commitID != "hotfix-commit-id", nil
+				},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "v2.0.0", CommitID: "main-commit-id"},
+					{Tag: "v1.0.0", CommitID: "old-main-commit-id"},
+				}, tags)
+			},
+		},
+		{
+			name: "ignore tags",
+			sub: kargoapi.GitSubscription{
+				IgnoreTags:              []string{"abc"},
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategyNewestTag,
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return []git.TagMetadata{
+						{Tag: "abc"},
+						{Tag: "xyz"},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{{Tag: "xyz"}}, tags)
+			},
+		},
+		{
+			name: "allow tags compile error",
+			sub: kargoapi.GitSubscription{
+				AllowTags: "[",
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return nil, nil
+				},
+			},
+			assertions: func(t *testing.T, _ []git.TagMetadata, err error) {
+				require.ErrorContains(t, err, "failed to filter tags")
+				require.ErrorContains(t, err, "error compiling regular expression")
+			},
+		},
+		{
+			name: "SemVer commit selection strategy",
+			sub: kargoapi.GitSubscription{
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategySemVer,
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return []git.TagMetadata{
+						{Tag: "v1.0.0"},
+						{Tag: "abc"},
+						{Tag: "v2.0.0"},
+						{Tag: "xyz"},
+						{Tag: "v1.2.3"},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "v2.0.0"},
+					{Tag: "v1.2.3"},
+					{Tag: "v1.0.0"},
+				}, tags)
+			},
+		},
+		{
+			name: "SemVer commit selection strategy with constraint",
+			sub: kargoapi.GitSubscription{
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategySemVer,
+				SemverConstraint:        ">=2.0.0",
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return []git.TagMetadata{
+						{Tag: "v1.0.0"},
+						{Tag: "v2.0.0"},
+						{Tag: "v1.2.3"},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "v2.0.0"},
+				}, tags)
+			},
+		},
+		{
+			name: "SemVer commit selection strategy with invalid constraint",
+			sub: kargoapi.GitSubscription{
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategySemVer,
+				SemverConstraint:        "invalid",
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return nil, nil
+				},
+			},
+			assertions: func(t *testing.T, _ []git.TagMetadata, err error) {
+				require.ErrorContains(t, err, "failed to select semver tags")
+				require.ErrorContains(t, err, "error parsing semver constraint")
+			},
+		},
+		{
+			name: "CalVer commit selection strategy",
+			sub: kargoapi.GitSubscription{
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategyCalVer,
+				CalVerLayout:            "YYYY.0M.MICRO",
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return []git.TagMetadata{
+						{Tag: "2024.09.1"},
+						{Tag: "v1.0.0"},
+						{Tag: "2024.10.0"},
+						{Tag: "2023.12.7"},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "2024.10.0"},
+					{Tag: "2024.09.1"},
+					{Tag: "2023.12.7"},
+				}, tags)
+			},
+		},
+		{
+			name: "CalVer commit selection strategy without layout",
+			sub: kargoapi.GitSubscription{
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategyCalVer,
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return nil, nil
+				},
+			},
+			assertions: func(t *testing.T, _ []git.TagMetadata, err error) {
+				require.ErrorContains(t, err, "failed to select calver tags")
+				require.ErrorContains(t, err, "layout is empty")
+			},
+		},
+		{
+			name: "lexicographical commit selection strategy",
+			sub: kargoapi.GitSubscription{
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategyLexical,
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return []git.TagMetadata{
+						{Tag: "123"},
+						{Tag: "abc"},
+						{Tag: "xyz"},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "xyz"},
+					{Tag: "abc"},
+					{Tag: "123"},
+				}, tags)
+			},
+		},
+		{
+			name: "more tags than limit",
+			sub: kargoapi.GitSubscription{
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategyNewestTag,
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return []git.TagMetadata{
+						{Tag: "a"}, {Tag: "b"}, {Tag: "c"}, {Tag: "d"}, {Tag: "e"},
+						{Tag: "f"}, {Tag: "g"}, {Tag: "h"}, {Tag: "i"}, {Tag: "j"},
+						{Tag: "k"}, {Tag: "l"}, {Tag: "m"}, {Tag: "n"}, {Tag: "o"},
+						{Tag: "p"}, {Tag: "q"}, {Tag: "r"}, {Tag: "s"}, {Tag: "t"},
+						{Tag: "u"}, {Tag: "v"}, {Tag: "w"}, {Tag: "x"}, {Tag: "y"},
+						{Tag: "z"},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Len(t, tags, 20)
+			},
+		},
+		{
+			name: "more tags than configured limit",
+			sub: kargoapi.GitSubscription{
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategyNewestTag,
+				DiscoveryLimit:          3,
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return []git.TagMetadata{
+						{Tag: "a"}, {Tag: "b"}, {Tag: "c"}, {Tag: "d"}, {Tag: "e"},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{{Tag: "a"}, {Tag: "b"}, {Tag: "c"}}, tags)
+			},
+		},
+		{
+			name: "with path filters",
+			sub: kargoapi.GitSubscription{
+				IncludePaths: []string{regexpPrefix + "^.*third_path_to_a/file$"},
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return []git.TagMetadata{
+						{Tag: "v1.0.0"},
+						{Tag: "abc", CommitID: "fake-commit-id"},
+						{Tag: "v2.0.0"},
+						{Tag: "xyz"},
+						{Tag: "v1.2.3"},
+					}, nil
+				},
+				getDiffPathsForCommitIDFn: func(_ git.Repo, id string) ([]string, error) {
+					if id == "fake-commit-id" {
+						return []string{"third_path_to_a/file"}, nil
+					}
+					return []string{"first_path_to_a/file", "second_path_to_a/file"}, nil
+				},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "abc", CommitID: "fake-commit-id"},
+				}, tags)
+			},
+		},
+		{
+			name: "with expression filter",
+			sub: kargoapi.GitSubscription{
+				ExpressionFilter: `tag matches "^v[0-9]+\\.[0-9]+\\.[0-9]+$" && author contains "release-bot"`,
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return []git.TagMetadata{
+						{Tag: "v1.0.0", Author: "Jane Doe <jane@example.com>"},
+						{Tag: "v1.1.0-rc.1", Author: "Release Bot <release-bot@example.com>"},
+						{Tag: "v1.1.0", Author: "Release Bot <release-bot@example.com>"},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "v1.1.0", Author: "Release Bot <release-bot@example.com>"},
+				}, tags)
+			},
+		},
+		{
+			name: "with expression filter using commit body",
+			sub: kargoapi.GitSubscription{
+				ExpressionFilter: `!(commit.Subject startsWith "chore:") && body contains "Approved"`,
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return []git.TagMetadata{
+						{Tag: "v1.2.0", CommitID: "ghi", Subject: "chore: release"},
+						{Tag: "v1.1.0", CommitID: "def", Subject: "feat: bar"},
+						{Tag: "v1.0.0", CommitID: "abc", Subject: "feat: foo"},
+					}, nil
+				},
+				getCommitBodyFn: func(_ git.Repo, id string) (string, error) {
+					if id == "def" {
+						return "", nil
+					}
+					return "Approved-by: Jane Doe", nil
+				},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "v1.0.0", CommitID: "abc", Subject: "feat: foo"},
+				}, tags)
+			},
+		},
+		{
+			name: "skipping unverified tags",
+			sub: kargoapi.GitSubscription{
+				VerifySignatures: &kargoapi.GitSignatureVerification{
+					SecretName: "fake-secret",
+				},
+			},
+			discoverer: &Discoverer{
+				listTagsFn: func(git.Repo) ([]git.TagMetadata, error) {
+					return []git.TagMetadata{
+						{Tag: "v1.2.0", CommitID: "ghi"},
+						{Tag: "v1.1.0", CommitID: "def"},
+						{Tag: "v1.0.0", CommitID: "abc"},
+					}, nil
+				},
+				verifyTagSignatureFn: func(_ git.Repo, tag string) (bool, error) {
+					return tag == "v1.2.0", nil
+				},
+				verifyCommitSignatureFn: func(_ git.Repo, id string) (bool, error) {
+					return id == "abc", nil
+				},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "v1.2.0", CommitID: "ghi"},
+					{Tag: "v1.0.0", CommitID: "abc"},
+				}, tags)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tags, err := testCase.discoverer.discoverTags(
+				nil,
+				testCase.sub,
+			)
+			testCase.assertions(t, tags, err)
+		})
+	}
+}
+
+func TestFilterTags(t *testing.T) {
+	testCases := []struct {
+		name       string
+		tags       []git.TagMetadata
+		ignoreTags []string
+		allow      string
+		assertions func(*testing.T, []git.TagMetadata, error)
+	}{
+		{
+			name: "no tags",
+			tags: nil,
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Empty(t, tags)
+			},
+		},
+		{
+			name:  "invalid regular expression",
+			allow: "[",
+			assertions: func(t *testing.T, _ []git.TagMetadata, err error) {
+				require.ErrorContains(t, err, "error compiling regular expression")
+			},
+		},
+		{
+			name: "without ignore tags or allow regex",
+			tags: []git.TagMetadata{
+				{Tag: "v1.0.0"},
+				{Tag: "xyz"},
+				{Tag: "foo"},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "v1.0.0"},
+					{Tag: "xyz"},
+					{Tag: "foo"},
+				}, tags)
+			},
+		},
+		{
+			name: "with ignore tags",
+			tags: []git.TagMetadata{
+				{Tag: "v1.0.0"},
+				{Tag: "xyz"},
+				{Tag: "foo"},
+			},
+			ignoreTags: []string{"v1.0.0", "foo"},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "xyz"},
+				}, tags)
+			},
+		},
+		{
+			name: "with allow regex",
+			tags: []git.TagMetadata{
+				{Tag: "v1.0.0"},
+				{Tag: "xyz"},
+				{Tag: "foo"},
+			},
+			allow: "v.*",
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "v1.0.0"},
+				}, tags)
+			},
+		},
+		{
+			name: "with ignore tags and allow regex",
+			tags: []git.TagMetadata{
+				{Tag: "v1.0.0"},
+				{Tag: "xyz"},
+				{Tag: "foo"},
+				{Tag: "v2.0.0"},
+			},
+			ignoreTags: []string{"v1.0.0"},
+			allow:      "v.*",
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "v2.0.0"},
+				}, tags)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tags, err := filterTags(testCase.tags, testCase.ignoreTags, testCase.allow)
+			testCase.assertions(t, tags, err)
+		})
+	}
+}
+
+func TestAllows(t *testing.T) {
+	testCases := []struct {
+		name    string
+		regex   *regexp.Regexp
+		tag     string
+		allowed bool
+	}{
+		{
+			name:    "no regex specified",
+			tag:     "abc",
+			allowed: true,
+		},
+		{
+			name:    "allowed",
+			regex:   regexp.MustCompile("[a-z]+"),
+			tag:     "abc",
+			allowed: true,
+		},
+		{
+			name:    "not allowed",
+			regex:   regexp.MustCompile("[a-z]+"),
+			tag:     "123",
+			allowed: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.allowed,
+				allows(testCase.tag, testCase.regex),
+			)
+		})
+	}
+}
+
+func TestIgnores(t *testing.T) {
+	testCases := []struct {
+		name    string
+		ignore  []string
+		tag     string
+		ignored bool
+	}{
+		{
+			name:    "ignored",
+			ignore:  []string{"abc"},
+			tag:     "abc",
+			ignored: true,
+		},
+		{
+			name:    "not ignored",
+			ignore:  []string{"abc"},
+			tag:     "123",
+			ignored: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.ignored,
+				ignores(testCase.tag, testCase.ignore),
+			)
+		})
+	}
+}
+
+func TestSelectSemVerTags(t *testing.T) {
+	testCases := []struct {
+		name       string
+		constraint string
+		prefix     string
+		strict     bool
+		tags       []git.TagMetadata
+		assertions func(*testing.T, []git.TagMetadata, error)
+	}{
+		{
+			name:       "error parsing constraint",
+			constraint: "invalid",
+			tags:       nil,
+			assertions: func(t *testing.T, _ []git.TagMetadata, err error) {
+				require.ErrorContains(t, err, "error parsing semver constraint")
+			},
+		},
+		{
+			name: "empty/nil tag list",
+			tags: nil,
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Empty(t, tags)
+			},
+		},
+		{
+			name: "no semantic tags in tag list",
+			tags: []git.TagMetadata{
+				{Tag: "abc"},
+				{Tag: "xyz"},
+				{Tag: "foo"},
+				{Tag: "bar"},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Empty(t, tags)
+			},
+		},
+		{
+			name:       "no constraint matches",
+			constraint: ">=2.0.0",
+			tags: []git.TagMetadata{
+				{Tag: "v1.0.0"},
+				{Tag: "v1.2.3"},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Empty(t, tags)
+			},
+		},
+		{
+			name: "success with no constraint",
+			tags: []git.TagMetadata{
+				{Tag: "v1.0.0"},
+				{Tag: "v2.1.3"},
+				{Tag: "v1.2.3"},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "v2.1.3"},
+					{Tag: "v1.2.3"},
+					{Tag: "v1.0.0"},
+				}, tags)
+			},
+		},
+		{
+			name:       "success with constraint",
+			constraint: "<2.0.0",
+			tags: []git.TagMetadata{
+				{Tag: "v1.0.0"},
+				{Tag: "v2.1.3"},
+				{Tag: "v1.2.3"},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "v1.2.3"},
+					{Tag: "v1.0.0"},
+				}, tags)
+			},
+		},
+		{
+			name: "success with equivalent versions",
+			tags: []git.TagMetadata{
+				{Tag: "1.0"},
+				{Tag: "1.0.0"},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "1.0.0"},
+					{Tag: "1.0"},
+				}, tags)
+			},
+		},
+		{
+			name:       "success with prefix",
+			constraint: "<2.0.0",
+			prefix:     "service-a/v",
+			tags: []git.TagMetadata{
+				{Tag: "service-a/v1.0.0"},
+				{Tag: "service-b/v1.5.0"},
+				{Tag: "service-a/v1.2.3"},
+				{Tag: "service-a/v2.0.0"},
+				{Tag: "v1.9.0"},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "service-a/v1.2.3"},
+					{Tag: "service-a/v1.0.0"},
+				}, tags)
+			},
+		},
+		{
+			name:   "success with strict semvers",
+			strict: true,
+			tags: []git.TagMetadata{
+				{Tag: "1.0"},
+				{Tag: "v1.2.3"},
+				{Tag: "v2"},
+				{Tag: "1.1.0"},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "v1.2.3"},
+					{Tag: "1.1.0"},
+				}, tags)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tags, err := selectSemVerTags(
+				testCase.tags,
+				testCase.constraint,
+				testCase.prefix,
+				testCase.strict,
+			)
+			testCase.assertions(t, tags, err)
+		})
+	}
+}
+
+func TestSelectCalVerTags(t *testing.T) {
+	testCases := []struct {
+		name       string
+		layout     string
+		tags       []git.TagMetadata
+		assertions func(*testing.T, []git.TagMetadata, error)
+	}{
+		{
+			name:   "error parsing layout",
+			layout: "MAJOR.MINOR",
+			assertions: func(t *testing.T, _ []git.TagMetadata, err error) {
+				require.ErrorContains(t, err, "error parsing calver layout")
+			},
+		},
+		{
+			name:   "no tags conform to layout",
+			layout: "YYYY.0M.MICRO",
+			tags: []git.TagMetadata{
+				{Tag: "v1.0.0"},
+				{Tag: "2024.3.0"},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Empty(t, tags)
+			},
+		},
+		{
+			name:   "success",
+			layout: "vYYYY.MM.MICRO",
+			tags: []git.TagMetadata{
+				{Tag: "v2024.9.1"},
+				{Tag: "v2024.10.0"},
+				{Tag: "latest"},
+				{Tag: "v2023.12.5"},
+				{Tag: "v2024.9.10"},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "v2024.10.0"},
+					{Tag: "v2024.9.10"},
+					{Tag: "v2024.9.1"},
+					{Tag: "v2023.12.5"},
+				}, tags)
+			},
+		},
+		{
+			name:   "success with equivalent versions",
+			layout: "YYYY.MM.MICRO",
+			tags: []git.TagMetadata{
+				{Tag: "2024.1.1"},
+				{Tag: "2024.1.01"},
+			},
+			assertions: func(t *testing.T, tags []git.TagMetadata, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []git.TagMetadata{
+					{Tag: "2024.1.1"},
+					{Tag: "2024.1.01"},
+				}, tags)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tags, err := selectCalVerTags(testCase.tags, testCase.layout)
+			testCase.assertions(t, tags, err)
+		})
+	}
+}
+
+func TestGetPathspecs(t *testing.T) {
+	testCases := []struct {
+		name      string
+		include   []string
+		exclude   []string
+		pathspecs []string
+	}{
+		{
+			name: "no selectors",
+		},
+		{
+			name:    "paths and globs",
+			include: []string{"apps/foo/", "./apps/bar", globPrefix + "charts/*/Chart.yaml"},
+			exclude: []string{"apps/foo/docs"},
+			pathspecs: []string{
+				":(top,literal)apps/foo",
+				":(top,literal)apps/bar",
+				":(top,glob)charts/*/Chart.yaml",
+				":(exclude,top,literal)apps/foo/docs",
+			},
+		},
+		{
+			name:    "regular expression include",
+			include: []string{"apps/foo", regexpPrefix + "^apps/.*/values.yaml$"},
+			exclude: []string{"apps/foo/docs"},
+		},
+		{
+			name:      "include all",
+			include:   []string{"apps/foo", "."},
+			pathspecs: nil,
+		},
+		{
+			name:    "untranslatable excludes are omitted",
+			include: []string{"apps"},
+			exclude: []string{
+				regexPrefix + "^apps/.*/README.md$",
+				globPrefix + "apps/*/{README,CHANGELOG}.md",
+				"apps/bar",
+			},
+			pathspecs: []string{":(top,literal)apps", ":(exclude,top,literal)apps/bar"},
+		},
+		{
+			name:    "doublestar globs",
+			include: []string{globPrefix + "apps/**/overlays/prod/**"},
+			exclude: []string{globPrefix + "apps/**/*.md"},
+			pathspecs: []string{
+				":(top,glob)apps/**/overlays/prod/**",
+				":(exclude,top,glob)apps/**/*.md",
+			},
+		},
+		{
+			name:    "brace expansion include",
+			include: []string{globPrefix + "apps/{foo,bar}/**"},
+		},
+		{
+			name:      "excludes only",
+			exclude:   []string{"docs"},
+			pathspecs: []string{":(exclude,top,literal)docs"},
+		},
+		{
+			name:    "path outside the repository",
+			include: []string{"../foo"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.pathspecs,
+				getPathspecs(testCase.include, testCase.exclude),
+			)
+		})
+	}
+}
+
+func TestMatchesPathsFilters(t *testing.T) {
+	testCases := []struct {
+		name         string
+		includePaths []string
+		excludePaths []string
+		diffs        []string
+		assertions   func(*testing.T, bool, error)
+	}{
+		{
+			name:         "success with no includePaths configured",
+			excludePaths: []string{regexpPrefix + "nonexistent"},
+			diffs:        []string{"path1/values.yaml", "path2/_helpers.tpl"},
+			assertions: func(t *testing.T, matchFound bool, err error) {
+				require.NoError(t, err)
+				require.Equal(t, true, matchFound)
+			},
+		},
+		{
+			name:         "success with a matching regexp filters configuration",
+			includePaths: []string{regexpPrefix + "values\\.ya?ml$"},
+			excludePaths: []string{regexPrefix + "nonexistent"},
+			diffs:        []string{"path1/values.yaml", "path2/_helpers.tpl"},
+			assertions: func(t *testing.T, matchFound bool, err error) {
+				require.NoError(t, err)
+				require.Equal(t, true, matchFound)
+			},
+		},
+		{
+			name:         "success with unmatching regexp filters configuration",
+			includePaths: []string{regexpPrefix + "values\\.ya?ml$"},
+			excludePaths: []string{regexPrefix + "nonexistent", regexpPrefix + ".*val.*"},
+			diffs:        []string{"path1/values.yaml", "path2/_helpers.tpl"},
+			assertions: func(t *testing.T, matchFound bool, err error) {
+				require.NoError(t, err)
+				require.Equal(t, false, matchFound)
+			},
+		},
+		{
+			name:         "success with matching glob filters configuration",
+			includePaths: []string{"glob:path2/*.tpl"},
+			excludePaths: []string{"nonexistent"},
+			diffs:        []string{"path1/values.yaml", "path2/_helpers.tpl"},
+			assertions: func(t *testing.T, matchFound bool, err error) {
+				require.NoError(t, err)
+				require.Equal(t, true, matchFound)
+			},
+		},
+		{
+			name:         "success with unmatching glob filters configuration",
+			includePaths: []string{"path2/*.tpl"},
+			excludePaths: []string{regexPrefix + "nonexistent", "*/?helpers.tpl"},
+			diffs:        []string{"path1/values.yaml", "path2/_helpers.tpl"},
+			assertions: func(t *testing.T, matchFound bool, err error) {
+				require.NoError(t, err)
+				require.Equal(t, false, matchFound)
+			},
+		},
+		{
+			name:         "success with matching prefix filters configuration",
+			includePaths: []string{"path1/"},
+			excludePaths: []string{"nonexistent"},
+			diffs:        []string{"path1/values.yaml", "path2/_helpers.tpl"},
+			assertions: func(t *testing.T, matchFound bool, err error) {
+				require.NoError(t, err)
+				require.Equal(t, true, matchFound)
+			},
+		},
+		{
+			name:         "success with unmatching prefix filters configuration",
+			includePaths: []string{"path3/"},
+			excludePaths: []string{regexPrefix + "nonexistent", "*/?helpers.tpl"},
+			diffs:        []string{"path1/values.yaml", "path2/_helpers.tpl"},
+			assertions: func(t *testing.T, matchFound bool, err error) {
+				require.NoError(t, err)
+				require.Equal(t, false, matchFound)
+			},
+		},
+		{
+			name:         "success with matching doublestar glob filters configuration",
+			includePaths: []string{globPrefix + "apps/**/overlays/prod/**"},
+			excludePaths: []string{globPrefix + "**/*.md"},
+			diffs: []string{
+				"apps/guestbook/overlays/prod/README.md",
+				"apps/team-a/guestbook/overlays/prod/kustomization.yaml",
+			},
+			assertions: func(t *testing.T, matchFound bool, err error) {
+				require.NoError(t, err)
+				require.Equal(t, true, matchFound)
+			},
+		},
+		{
+			name:         "success with unmatching doublestar glob filters configuration",
+			includePaths: []string{globPrefix + "apps/**/overlays/prod/**"},
+			excludePaths: []string{globPrefix + "**/*.md"},
+			diffs: []string{
+				"apps/guestbook/overlays/prod/README.md",
+				"apps/guestbook/overlays/test/kustomization.yaml",
+			},
+			assertions: func(t *testing.T, matchFound bool, err error) {
+				require.NoError(t, err)
+				require.Equal(t, false, matchFound)
+			},
+		},
+		{
+			name:         "error with invalid glob syntax",
+			includePaths: []string{"glob:path2/*.tpl["},
+			diffs:        []string{"path1/values.yaml", "path2/_helpers.tpl"},
+			assertions: func(t *testing.T, _ bool, err error) {
+				require.Error(t, err)
+				require.ErrorContains(t, err, "syntax error in pattern")
+			},
+		},
+		{
+			name:         "success unmatching mix1",
+			includePaths: []string{"path3", regexPrefix + "nonexistent", globPrefix + "nonexistent"},
+			excludePaths: []string{regexPrefix + "nonexistent", "*/?helpers.tpl", globPrefix + "nonexistent"},
+			diffs:        []string{"path1/values.yaml", "path2/_helpers.tpl"},
+			assertions: func(t *testing.T, matchFound bool, err error) {
+				require.NoError(t, err)
+				require.Equal(t, false, matchFound)
+			},
+		},
+		{
+			name:         "success unmatching mix2",
+			includePaths: []string{"path1", regexPrefix + "[_-]", globPrefix + "nonexistent"},
+			excludePaths: []string{
+				regexPrefix + "nonexistent",
+				"path1",
+				"path1",
+				globPrefix + "*.tpl",
+				globPrefix + "*/*.tpl",
+				globPrefix + "*.tpl",
+				"path1",
+			},
+			diffs: []string{"path1/values.yaml", "path2/_helpers.tpl", "path2/ingress.yaml"},
+			assertions: func(t *testing.T, matchFound bool, err error) {
+				require.NoError(t, err)
+				require.Equal(t, false, matchFound)
+			},
+		},
+		{
+			name: "success unmatching mix3",
+			includePaths: []string{
+				"path1/f",
+				regexpPrefix + "path[1-3]",
+				globPrefix + "file*",
+			},
+			excludePaths: []string{
+				regexPrefix + "\\d",
+				"yaml",
+				globPrefix + "*.tpl",
+				globPrefix + "*.tpl",
+				"nonexistent",
+			},
+			diffs: []string{"path1/file1", "path2/file2", "path3/file3"},
+			assertions: func(t *testing.T, matchFound bool, err error) {
+				require.NoError(t, err)
+				require.Equal(t, false, matchFound)
+			},
+		},
+		{
+			name:         "success unmatching mix2; no config",
+			includePaths: []string{},
+			excludePaths: []string{},
+			diffs:        []string{},
+			assertions: func(t *testing.T, matchFound bool, err error) {
+				require.NoError(t, err)
+				require.Equal(t, false, matchFound)
+			},
+		},
+		{
+			name: "success matching mix1",
+			includePaths: []string{
+				"path1",
+				regexPrefix + "[_-]",
+				globPrefix + "nonexistent",
+				regexPrefix + "no",
+				globPrefix + "*/*/*/abe/*",
+			},
+			excludePaths: []string{
+				regexPrefix + "nonexistent",
+				"path1",
+				"path1",
+				globPrefix + "*.tpl",
+				globPrefix + "*/*.tpl",
+				globPrefix + "*.tpl",
+				regexpPrefix + ".*q",
+			},
+			diffs: []string{
+				"path1/values.yaml",
+				"path2/_helpers.tpl",
+				"abc",
+				"abb",
+				"aba",
+				"abz",
+				"aby",
+				"abx",
+				"abw",
+				"abv",
+				"abu",
+				"path3/abc/abd/abe/deployment.yaml",
+				"path4.txt",
+			},
+			assertions: func(t *testing.T, matchFound bool, err error) {
+				require.NoError(t, err)
+				require.Equal(t, true, matchFound)
+			},
+		},
+		{
+			name: "success matching mix3; no includePaths",
+			excludePaths: []string{
+				regexpPrefix + "ab[cbazxwvu]",
+				"helpers.tpl",
+				globPrefix + "path*/*"},
+			diffs: []string{
+				"path1/values.yaml",
+				"path2/_helpers.tpl",
+				"abc",
+				"abb",
+				"aba",
+				"abz",
+				"aby",
+				"abx",
+				"abw",
+				"abv",
+				"abu",
+			},
+			assertions: func(t *testing.T, matchFound bool, err error) {
+				require.NoError(t, err)
+				require.Equal(t, true, matchFound)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			includeSelectors, err := getPathSelectors(testCase.includePaths)
+			require.NoError(t, err)
+			excludeSelectors, err := getPathSelectors(testCase.excludePaths)
+			require.NoError(t, err)
+
+			matchFound, err := matchesPathsFilters(includeSelectors, excludeSelectors, testCase.diffs)
+			testCase.assertions(t, matchFound, err)
+		})
+	}
+}
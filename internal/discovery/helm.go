@@ -0,0 +1,128 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/helm"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+func (d *Discoverer) discoverCharts(
+	ctx context.Context,
+	namespace string,
+	subs []kargoapi.RepoSubscription,
+) ([]kargoapi.ChartDiscoveryResult, error) {
+	return discoverConcurrently(
+		ctx,
+		d.maxConcurrentDiscoveries,
+		subs,
+		func(ctx context.Context, s kargoapi.RepoSubscription) ([]kargoapi.ChartDiscoveryResult, error) {
+			if s.Chart == nil {
+				return nil, nil
+			}
+			result, err := d.discoverChartVersions(ctx, namespace, s.Chart)
+			if err != nil {
+				return nil, err
+			}
+			return []kargoapi.ChartDiscoveryResult{result}, nil
+		},
+	)
+}
+
+// discoverChartVersions discovers the latest suitable versions of the chart
+// described by the provided ChartSubscription.
+func (d *Discoverer) discoverChartVersions(
+	ctx context.Context,
+	namespace string,
+	sub *kargoapi.ChartSubscription,
+) (kargoapi.ChartDiscoveryResult, error) {
+	logger := logging.LoggerFromContext(ctx).WithField("repoURL", sub.RepoURL)
+	if sub.Name != "" {
+		logger = logger.WithField("chart", sub.Name)
+	}
+
+	creds, ok, err := d.credentialsDB.Get(ctx, namespace, credentials.TypeHelm, sub.RepoURL)
+	if err != nil {
+		return kargoapi.ChartDiscoveryResult{}, fmt.Errorf(
+			"error obtaining credentials for chart repository %q: %w",
+			sub.RepoURL,
+			err,
+		)
+	}
+	var helmCreds *helm.Credentials
+	if ok {
+		helmCreds = &helm.Credentials{
+			Username: creds.Username,
+			Password: creds.Password,
+		}
+		logger.Debug("obtained credentials for chart repo")
+	} else {
+		logger.Debug("found no credentials for chart repo")
+	}
+
+	versions, err := d.discoverChartVersionsFn(ctx, sub.RepoURL, sub.Name, sub.SemverConstraint, helmCreds)
+	if err != nil {
+		if sub.Name == "" {
+			return kargoapi.ChartDiscoveryResult{}, fmt.Errorf(
+				"error discovering latest suitable chart versions in repository %q: %w",
+				sub.RepoURL,
+				err,
+			)
+		}
+		return kargoapi.ChartDiscoveryResult{}, fmt.Errorf(
+			"error discovering latest suitable chart versions for chart %q in repository %q: %w",
+			sub.Name,
+			sub.RepoURL,
+			err,
+		)
+	}
+
+	if versions, err = helm.ExcludeVersions(versions, sub.ExcludeVersions); err != nil {
+		return kargoapi.ChartDiscoveryResult{}, fmt.Errorf(
+			"error excluding chart versions from repository %q: %w",
+			sub.RepoURL,
+			err,
+		)
+	}
+
+	if len(versions) == 0 {
+		logger.Debug("discovered no suitable chart versions")
+		return kargoapi.ChartDiscoveryResult{
+			RepoURL:          sub.RepoURL,
+			Name:             sub.Name,
+			SemverConstraint: sub.SemverConstraint,
+		}, nil
+	}
+
+	logger.Debugf("discovered %d suitable chart versions", len(versions))
+	return kargoapi.ChartDiscoveryResult{
+		RepoURL:          sub.RepoURL,
+		Name:             sub.Name,
+		SemverConstraint: sub.SemverConstraint,
+		Versions:         trimSlice(versions, chartDiscoveryLimit(sub)),
+	}, nil
+}
+
+// chartDiscoveryLimit returns the maximum number of versions that may be
+// discovered for the provided subscription. Subscriptions created before the
+// limit was configurable may not specify one, in which case the default of 20
+// applies.
+func chartDiscoveryLimit(sub *kargoapi.ChartSubscription) int {
+	if sub.DiscoveryLimit <= 0 {
+		return 20
+	}
+	return int(sub.DiscoveryLimit)
+}
+
+// trimSlice returns a slice of any type with a maximum length of limit.
+// If the input slice is shorter than limit or limit is less than or equal to
+// zero, the input slice is returned unmodified.
+func trimSlice[T any](slice []T, limit int) []T {
+	if limit <= 0 || len(slice) <= limit {
+		return slice
+	}
+	return slice[:limit]
+}
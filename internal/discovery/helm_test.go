@@ -1,4 +1,4 @@
-package warehouses
+package discovery
 
 import (
 	"context"
@@ -15,13 +15,13 @@ import (
 func TestDiscoverCharts(t *testing.T) {
 	testCases := []struct {
 		name       string
-		reconciler *reconciler
+		discoverer *Discoverer
 		subs       []kargoapi.RepoSubscription
 		assertions func(*testing.T, []kargoapi.ChartDiscoveryResult, error)
 	}{
 		{
 			name:       "no chart subscription",
-			reconciler: &reconciler{},
+			discoverer: &Discoverer{},
 			subs: []kargoapi.RepoSubscription{
 				{Git: &kargoapi.GitSubscription{}},
 			},
@@ -32,7 +32,7 @@ func TestDiscoverCharts(t *testing.T) {
 		},
 		{
 			name: "error obtaining credentials",
-			reconciler: &reconciler{
+			discoverer: &Discoverer{
 				credentialsDB: &credentials.FakeDB{
 					GetFn: func(
 						context.Context,
@@ -54,7 +54,7 @@ func TestDiscoverCharts(t *testing.T) {
 		},
 		{
 			name: "discovers chart versions",
-			reconciler: &reconciler{
+			discoverer: &Discoverer{
 				credentialsDB: &credentials.FakeDB{},
 				discoverChartVersionsFn: func(
 					context.Context,
@@ -83,9 +83,42 @@ func TestDiscoverCharts(t *testing.T) {
 				}, results)
 			},
 		},
+		{
+			name: "excludes chart versions and applies discovery limit",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				discoverChartVersionsFn: func(
+					context.Context,
+					string,
+					string,
+					string,
+					*helm.Credentials,
+				) ([]string, error) {
+					return []string{"2.0.0", "1.2.0", "1.1.1", "1.1.0", "1.0.0"}, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Chart: &kargoapi.ChartSubscription{
+					RepoURL:         "https://example.com",
+					Name:            "fake-chart",
+					ExcludeVersions: []string{"2.0.0", ">=1.1.0 <1.2.0"},
+					DiscoveryLimit:  1,
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.ChartDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.ChartDiscoveryResult{
+					{
+						RepoURL:  "https://example.com",
+						Name:     "fake-chart",
+						Versions: []string{"1.2.0"},
+					},
+				}, results)
+			},
+		},
 		{
 			name: "no chart versions discovered",
-			reconciler: &reconciler{
+			discoverer: &Discoverer{
 				credentialsDB: &credentials.FakeDB{},
 				discoverChartVersionsFn: func(
 					context.Context,
@@ -115,7 +148,7 @@ func TestDiscoverCharts(t *testing.T) {
 		},
 		{
 			name: "error discovering chart versions",
-			reconciler: &reconciler{
+			discoverer: &Discoverer{
 				credentialsDB: &credentials.FakeDB{},
 				discoverChartVersionsFn: func(
 					context.Context,
@@ -138,7 +171,7 @@ func TestDiscoverCharts(t *testing.T) {
 		},
 		{
 			name: "error discovering chart versions with chart name",
-			reconciler: &reconciler{
+			discoverer: &Discoverer{
 				credentialsDB: &credentials.FakeDB{},
 				discoverChartVersionsFn: func(
 					context.Context,
@@ -165,7 +198,7 @@ func TestDiscoverCharts(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			results, err := testCase.reconciler.discoverCharts(
+			results, err := testCase.discoverer.discoverCharts(
 				context.TODO(),
 				"fake-namespace",
 				testCase.subs,
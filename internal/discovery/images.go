@@ -0,0 +1,329 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/expressions"
+	"github.com/akuity/kargo/internal/git"
+	"github.com/akuity/kargo/internal/image"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// maxImageRepositoriesPerPattern is the maximum number of image repositories
+// matched by the RepoURL pattern of a single ImageSubscription from which
+// images are discovered.
+const maxImageRepositoriesPerPattern = 100
+
+func (d *Discoverer) discoverImages(
+	ctx context.Context,
+	namespace string,
+	subs []kargoapi.RepoSubscription,
+) ([]kargoapi.ImageDiscoveryResult, error) {
+	return discoverConcurrently(
+		ctx,
+		d.maxConcurrentDiscoveries,
+		subs,
+		func(ctx context.Context, s kargoapi.RepoSubscription) ([]kargoapi.ImageDiscoveryResult, error) {
+			if s.Image == nil {
+				return nil, nil
+			}
+			sub := s.Image
+
+			if image.IsRepoURLPattern(sub.RepoURL) {
+				return d.discoverImagesByPattern(ctx, namespace, *sub)
+			}
+
+			result, err := d.discoverImagesFromRepo(ctx, namespace, *sub)
+			if err != nil {
+				return nil, err
+			}
+			return []kargoapi.ImageDiscoveryResult{result}, nil
+		},
+	)
+}
+
+// discoverImagesByPattern discovers images from every repository matched by the
+// RepoURL pattern of the provided ImageSubscription. Each matched repository
+// is treated as if it were subscribed to individually, except that repositories
+// from which no suitable images are discovered are omitted from the results.
+// This permits new repositories to be matched before any images have been
+// pushed to them without preventing the production of Freight.
+func (d *Discoverer) discoverImagesByPattern(
+	ctx context.Context,
+	namespace string,
+	sub kargoapi.ImageSubscription,
+) ([]kargoapi.ImageDiscoveryResult, error) {
+	logger := logging.LoggerFromContext(ctx).WithField("repoPattern", sub.RepoURL)
+
+	regCreds, err := d.getImageCredentials(ctx, namespace, sub.RepoURL)
+	if err != nil {
+		return nil, err
+	}
+	repoURLs, err := d.listImageRepositoriesFn(
+		ctx,
+		sub.RepoURL,
+		sub.InsecureSkipTLSVerify,
+		regCreds,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error discovering image repositories matching %q: %w",
+			sub.RepoURL,
+			err,
+		)
+	}
+	logger.Debugf("discovered %d matching image repositories", len(repoURLs))
+	if len(repoURLs) > maxImageRepositoriesPerPattern {
+		logger.Warnf(
+			"only discovering images from the first %d of %d matching image repositories",
+			maxImageRepositoriesPerPattern,
+			len(repoURLs),
+		)
+		repoURLs = repoURLs[:maxImageRepositoriesPerPattern]
+	}
+
+	var results []kargoapi.ImageDiscoveryResult
+	for _, repoURL := range repoURLs {
+		repoSub := sub
+		repoSub.RepoURL = repoURL
+		result, err := d.discoverImagesFromRepo(ctx, namespace, repoSub)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.References) == 0 {
+			continue
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// discoverImagesFromRepo discovers images from the repository of the provided
+// ImageSubscription.
+func (d *Discoverer) discoverImagesFromRepo(
+	ctx context.Context,
+	namespace string,
+	sub kargoapi.ImageSubscription,
+) (kargoapi.ImageDiscoveryResult, error) {
+	logger := logging.LoggerFromContext(ctx).WithField("repo", sub.RepoURL)
+
+	regCreds, err := d.getImageCredentials(ctx, namespace, sub.RepoURL)
+	if err != nil {
+		return kargoapi.ImageDiscoveryResult{}, err
+	}
+
+	images, err := d.discoverImageRefsFn(ctx, sub, regCreds)
+	if err != nil {
+		return kargoapi.ImageDiscoveryResult{}, fmt.Errorf(
+			"error discovering latest suitable images %q: %w",
+			sub.RepoURL,
+			err,
+		)
+	}
+	if len(images) == 0 {
+		logger.Debug("discovered no suitable images")
+		return kargoapi.ImageDiscoveryResult{
+			RepoURL:  sub.RepoURL,
+			Platform: sub.Platform,
+		}, nil
+	}
+
+	logger.Debugf("discovered %d suitable images", len(images))
+	discoveredImages := make([]kargoapi.DiscoveredImageReference, 0, len(images))
+	for _, img := range images {
+		if sub.RequireDigest && img.Digest == "" {
+			logger.WithField("tag", img.Tag).
+				Warn("disregarding discovered image whose digest could not be resolved")
+			continue
+		}
+		discovery := kargoapi.DiscoveredImageReference{
+			Tag:        img.Tag,
+			Digest:     img.Digest,
+			GitRepoURL: d.getImageSourceURL(sub.GitRepoURL, img.Tag),
+			Revision:   img.Revision,
+		}
+		if img.CreatedAt != nil {
+			discovery.CreatedAt = &metav1.Time{Time: *img.CreatedAt}
+		}
+		if sub.BuildMetadata != nil {
+			if discovery.BuildMetadata, err = d.getImageBuildMetadata(
+				ctx,
+				sub,
+				regCreds,
+				img.Digest,
+			); err != nil {
+				return kargoapi.ImageDiscoveryResult{}, err
+			}
+		}
+		discoveredImages = append(discoveredImages, discovery)
+	}
+	return kargoapi.ImageDiscoveryResult{
+		RepoURL:    sub.RepoURL,
+		Platform:   sub.Platform,
+		References: discoveredImages,
+	}, nil
+}
+
+// getImageCredentials returns the credentials for the specified image
+// repository, or nil if there are none.
+func (d *Discoverer) getImageCredentials(
+	ctx context.Context,
+	namespace string,
+	repoURL string,
+) (*image.Credentials, error) {
+	logger := logging.LoggerFromContext(ctx).WithField("repo", repoURL)
+	creds, ok, err := d.credentialsDB.Get(ctx, namespace, credentials.TypeImage, repoURL)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error obtaining credentials for image repo %q: %w",
+			repoURL,
+			err,
+		)
+	}
+	if !ok {
+		logger.Debug("found no credentials for image repo")
+		return nil, nil
+	}
+	logger.Debug("obtained credentials for image repo")
+	return &image.Credentials{
+		Username: creds.Username,
+		Password: creds.Password,
+	}, nil
+}
+
+func (d *Discoverer) discoverImageRefs(
+	ctx context.Context,
+	sub kargoapi.ImageSubscription,
+	creds *image.Credentials,
+) ([]image.Image, error) {
+	imageSelector, err := imageSelectorForSubscription(sub, creds)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error creating image selector for image %q: %w",
+			sub.RepoURL,
+			err,
+		)
+	}
+
+	images, err := imageSelector.Select(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error discovering newest applicable images %q: %w",
+			sub.RepoURL,
+			err,
+		)
+	}
+	return images, nil
+}
+
+// getImageBuildMetadata extracts the fields of build metadata specified by the
+// provided ImageSubscription from the artifact of the specified type that
+// refers to the image with the provided digest. If there is no such artifact,
+// nil is returned.
+func (d *Discoverer) getImageBuildMetadata(
+	ctx context.Context,
+	sub kargoapi.ImageSubscription,
+	creds *image.Credentials,
+	digest string,
+) (map[string]string, error) {
+	referrer, err := d.getImageReferrerFn(
+		ctx,
+		sub.RepoURL,
+		digest,
+		sub.BuildMetadata.ArtifactType,
+		sub.InsecureSkipTLSVerify,
+		creds,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error getting %q artifact referring to image %q with digest %q: %w",
+			sub.BuildMetadata.ArtifactType,
+			sub.RepoURL,
+			digest,
+			err,
+		)
+	}
+	if referrer == nil {
+		logging.LoggerFromContext(ctx).WithField("repo", sub.RepoURL).Debugf(
+			"found no %q artifact referring to image with digest %q",
+			sub.BuildMetadata.ArtifactType,
+			digest,
+		)
+		return nil, nil
+	}
+	annotations := make(map[string]any, len(referrer.Annotations))
+	for k, v := range referrer.Annotations {
+		annotations[k] = v
+	}
+	env := map[string]any{
+		"content":     referrer.Content,
+		"annotations": annotations,
+	}
+	var metadata map[string]string
+	for _, field := range sub.BuildMetadata.Fields {
+		value, err := expressions.EvaluateTemplateToString(field.Value, env)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error evaluating value of build metadata field %q for image %q "+
+					"with digest %q: %w",
+				field.Name,
+				sub.RepoURL,
+				digest,
+				err,
+			)
+		}
+		if value == "" {
+			continue
+		}
+		if metadata == nil {
+			metadata = make(map[string]string, len(sub.BuildMetadata.Fields))
+		}
+		metadata[field.Name] = value
+	}
+	return metadata, nil
+}
+
+const (
+	githubURLPrefix = "https://github.com"
+)
+
+func (d *Discoverer) getImageSourceURL(gitRepoURL, tag string) string {
+	for baseUrl, fn := range d.imageSourceURLFnsByBaseURL {
+		if strings.HasPrefix(gitRepoURL, baseUrl) {
+			return fn(gitRepoURL, tag)
+		}
+	}
+	return ""
+}
+
+func imageSelectorForSubscription(
+	sub kargoapi.ImageSubscription,
+	creds *image.Credentials,
+) (image.Selector, error) {
+	return image.NewSelector(
+		sub.RepoURL,
+		image.SelectionStrategy(sub.ImageSelectionStrategy),
+		&image.SelectorOptions{
+			Constraint:              sub.SemverConstraint,
+			SortExpression:          sub.SortExpression,
+			AllowRegex:              sub.AllowTags,
+			Ignore:                  sub.IgnoreTags,
+			Platform:                sub.Platform,
+			Creds:                   creds,
+			InsecureSkipTLSVerify:   sub.InsecureSkipTLSVerify,
+			PreferCreatedAnnotation: sub.PreferCreatedAnnotation,
+			CacheResponses:          sub.CacheRegistryResponses,
+			DiscoveryLimit:          20,
+		},
+	)
+}
+
+func getGithubImageSourceURL(gitRepoURL, tag string) string {
+	return fmt.Sprintf("%s/tree/%s", git.NormalizeURL(gitRepoURL), tag)
+}
@@ -0,0 +1,522 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/image"
+)
+
+func TestDiscoverImages(t *testing.T) {
+	testCases := []struct {
+		name       string
+		discoverer *Discoverer
+		subs       []kargoapi.RepoSubscription
+		assertions func(*testing.T, []kargoapi.ImageDiscoveryResult, error)
+	}{
+		{
+			name:       "no image subscription",
+			discoverer: &Discoverer{},
+			subs: []kargoapi.RepoSubscription{
+				{Git: &kargoapi.GitSubscription{}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.ImageDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Empty(t, results)
+			},
+		},
+		{
+			name: "error obtaining credentials",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{
+					GetFn: func(
+						context.Context,
+						string,
+						credentials.Type,
+						string,
+					) (credentials.Credentials, bool, error) {
+						return credentials.Credentials{}, false, fmt.Errorf("something went wrong")
+					},
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Image: &kargoapi.ImageSubscription{}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.ImageDiscoveryResult, err error) {
+				require.Error(t, err)
+				require.Empty(t, results)
+			},
+		},
+		{
+			name: "discovers image references",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				discoverImageRefsFn: func(
+					context.Context,
+					kargoapi.ImageSubscription,
+					*image.Credentials,
+				) ([]image.Image, error) {
+					return []image.Image{
+						{Tag: "xyz"},
+						{Tag: "abc"},
+					}, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Image: &kargoapi.ImageSubscription{
+					RepoURL: "fake-repo",
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.ImageDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.ImageDiscoveryResult{
+					{
+						RepoURL: "fake-repo",
+						References: []kargoapi.DiscoveredImageReference{
+							{Tag: "xyz"},
+							{Tag: "abc"},
+						},
+					},
+				}, results)
+			},
+		},
+		{
+			name: "disregards image references without digests",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				discoverImageRefsFn: func(
+					context.Context,
+					kargoapi.ImageSubscription,
+					*image.Credentials,
+				) ([]image.Image, error) {
+					return []image.Image{
+						{Tag: "xyz"},
+						{Tag: "abc", Digest: "sha256:abc"},
+					}, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Image: &kargoapi.ImageSubscription{
+					RepoURL:       "fake-repo",
+					RequireDigest: true,
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.ImageDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.ImageDiscoveryResult{
+					{
+						RepoURL: "fake-repo",
+						References: []kargoapi.DiscoveredImageReference{
+							{Tag: "abc", Digest: "sha256:abc"},
+						},
+					},
+				}, results)
+			},
+		},
+		{
+			name: "discovers image references with build metadata",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				discoverImageRefsFn: func(
+					context.Context,
+					kargoapi.ImageSubscription,
+					*image.Credentials,
+				) ([]image.Image, error) {
+					return []image.Image{
+						{Tag: "xyz", Digest: "fake-digest"},
+					}, nil
+				},
+				getImageReferrerFn: func(
+					context.Context,
+					string,
+					string,
+					string,
+					bool,
+					*image.Credentials,
+				) (*image.Referrer, error) {
+					return &image.Referrer{
+						Content: map[string]any{"commit": "abc123"},
+					}, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Image: &kargoapi.ImageSubscription{
+					RepoURL: "fake-repo",
+					BuildMetadata: &kargoapi.ImageBuildMetadataSource{
+						ArtifactType: "fake-artifact-type",
+						Fields: []kargoapi.ImageBuildMetadataField{{
+							Name:  "commit",
+							Value: "${{ content.commit }}",
+						}},
+					},
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.ImageDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.ImageDiscoveryResult{
+					{
+						RepoURL: "fake-repo",
+						References: []kargoapi.DiscoveredImageReference{
+							{
+								Tag:           "xyz",
+								Digest:        "fake-digest",
+								BuildMetadata: map[string]string{"commit": "abc123"},
+							},
+						},
+					},
+				}, results)
+			},
+		},
+		{
+			name: "error discovering image references",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				discoverImageRefsFn: func(
+					context.Context,
+					kargoapi.ImageSubscription,
+					*image.Credentials,
+				) ([]image.Image, error) {
+					return nil, fmt.Errorf("something went wrong")
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Image: &kargoapi.ImageSubscription{}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.ImageDiscoveryResult, err error) {
+				require.Error(t, err)
+				require.Empty(t, results)
+			},
+		},
+		{
+			name: "no suitable images discovered",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				discoverImageRefsFn: func(
+					context.Context,
+					kargoapi.ImageSubscription,
+					*image.Credentials,
+				) ([]image.Image, error) {
+					return nil, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Image: &kargoapi.ImageSubscription{
+					RepoURL: "fake-repo",
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.ImageDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.ImageDiscoveryResult{
+					{
+						RepoURL: "fake-repo",
+					},
+				}, results)
+			},
+		},
+		{
+			name: "error listing repositories matching pattern",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				listImageRepositoriesFn: func(
+					context.Context,
+					string,
+					bool,
+					*image.Credentials,
+				) ([]string, error) {
+					return nil, fmt.Errorf("something went wrong")
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Image: &kargoapi.ImageSubscription{
+					RepoURL: "ghcr.io/acme/service-*",
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.ImageDiscoveryResult, err error) {
+				require.ErrorContains(t, err, "error discovering image repositories matching")
+				require.ErrorContains(t, err, "something went wrong")
+				require.Empty(t, results)
+			},
+		},
+		{
+			name: "discovers image references from repositories matching pattern",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				listImageRepositoriesFn: func(
+					_ context.Context,
+					pattern string,
+					_ bool,
+					_ *image.Credentials,
+				) ([]string, error) {
+					if pattern != "ghcr.io/acme/service-*" {
+						return nil, fmt.Errorf("unexpected pattern %q", pattern)
+					}
+					return []string{
+						"ghcr.io/acme/service-a",
+						"ghcr.io/acme/service-b",
+					}, nil
+				},
+				discoverImageRefsFn: func(
+					_ context.Context,
+					sub kargoapi.ImageSubscription,
+					_ *image.Credentials,
+				) ([]image.Image, error) {
+					if sub.RepoURL == "ghcr.io/acme/service-b" {
+						// No images have been pushed yet
+						return nil, nil
+					}
+					return []image.Image{{Tag: "v1.0.0", Digest: "sha256:abc"}}, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Image: &kargoapi.ImageSubscription{
+					RepoURL:  "ghcr.io/acme/service-*",
+					Platform: "linux/amd64",
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.ImageDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.ImageDiscoveryResult{
+					{
+						RepoURL:  "ghcr.io/acme/service-a",
+						Platform: "linux/amd64",
+						References: []kargoapi.DiscoveredImageReference{{
+							Tag:    "v1.0.0",
+							Digest: "sha256:abc",
+						}},
+					},
+				}, results)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			results, err := testCase.discoverer.discoverImages(
+				context.TODO(),
+				"fake-namespace",
+				testCase.subs,
+			)
+			testCase.assertions(t, results, err)
+		})
+	}
+}
+
+func TestGetImageBuildMetadata(t *testing.T) {
+	testSub := kargoapi.ImageSubscription{
+		RepoURL: "fake-repo",
+		BuildMetadata: &kargoapi.ImageBuildMetadataSource{
+			ArtifactType: "application/vnd.in-toto+json",
+			Fields: []kargoapi.ImageBuildMetadataField{
+				{
+					Name:  "buildURL",
+					Value: "${{ content.predicate.runDetails.metadata.invocationId }}",
+				},
+				{
+					Name:  "commit",
+					Value: "${{ content.predicate.buildDefinition?.resolvedDependencies?.[0]?.digest?.gitCommit }}",
+				},
+				{
+					Name:  "source",
+					Value: "${{ annotations['org.opencontainers.image.source'] }}",
+				},
+			},
+		},
+	}
+	testCases := []struct {
+		name               string
+		sub                kargoapi.ImageSubscription
+		getImageReferrerFn func(
+			context.Context,
+			string,
+			string,
+			string,
+			bool,
+			*image.Credentials,
+		) (*image.Referrer, error)
+		assertions func(*testing.T, map[string]string, error)
+	}{
+		{
+			name: "error getting referrer",
+			sub:  testSub,
+			getImageReferrerFn: func(
+				context.Context,
+				string,
+				string,
+				string,
+				bool,
+				*image.Credentials,
+			) (*image.Referrer, error) {
+				return nil, fmt.Errorf("something went wrong")
+			},
+			assertions: func(t *testing.T, _ map[string]string, err error) {
+				require.ErrorContains(t, err, "error getting \"application/vnd.in-toto+json\" artifact")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "no referrer",
+			sub:  testSub,
+			getImageReferrerFn: func(
+				context.Context,
+				string,
+				string,
+				string,
+				bool,
+				*image.Credentials,
+			) (*image.Referrer, error) {
+				return nil, nil
+			},
+			assertions: func(t *testing.T, metadata map[string]string, err error) {
+				require.NoError(t, err)
+				require.Nil(t, metadata)
+			},
+		},
+		{
+			name: "error evaluating field",
+			sub: kargoapi.ImageSubscription{
+				BuildMetadata: &kargoapi.ImageBuildMetadataSource{
+					Fields: []kargoapi.ImageBuildMetadataField{{
+						Name:  "invalid",
+						Value: "${{ content.foo( }}",
+					}},
+				},
+			},
+			getImageReferrerFn: func(
+				context.Context,
+				string,
+				string,
+				string,
+				bool,
+				*image.Credentials,
+			) (*image.Referrer, error) {
+				return &image.Referrer{Content: map[string]any{}}, nil
+			},
+			assertions: func(t *testing.T, _ map[string]string, err error) {
+				require.ErrorContains(t, err, "error evaluating value of build metadata field \"invalid\"")
+			},
+		},
+		{
+			name: "success",
+			sub:  testSub,
+			getImageReferrerFn: func(
+				context.Context,
+				string,
+				string,
+				string,
+				bool,
+				*image.Credentials,
+			) (*image.Referrer, error) {
+				return &image.Referrer{
+					Annotations: map[string]string{
+						"org.opencontainers.image.source": "https://github.com/example/repo",
+					},
+					Content: map[string]any{
+						"predicate": map[string]any{
+							"runDetails": map[string]any{
+								"metadata": map[string]any{
+									"invocationId": "https://ci.example.com/runs/42",
+								},
+							},
+						},
+					},
+				}, nil
+			},
+			assertions: func(t *testing.T, metadata map[string]string, err error) {
+				require.NoError(t, err)
+				// The commit field evaluates to an empty string and is omitted
+				require.Equal(
+					t,
+					map[string]string{
+						"buildURL": "https://ci.example.com/runs/42",
+						"source":   "https://github.com/example/repo",
+					},
+					metadata,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := &Discoverer{
+				getImageReferrerFn: testCase.getImageReferrerFn,
+			}
+			metadata, err := r.getImageBuildMetadata(
+				context.Background(),
+				testCase.sub,
+				nil,
+				"fake-digest",
+			)
+			testCase.assertions(t, metadata, err)
+		})
+	}
+}
+
+func TestGetImageSourceURL(t *testing.T) {
+	const testURLPrefix = "fake-url-prefix"
+	testCases := []struct {
+		name        string
+		discoverer  *Discoverer
+		expectedURL string
+	}{
+		{
+			name:        "no image source URL function found",
+			discoverer:  &Discoverer{},
+			expectedURL: "",
+		},
+		{
+			name: "image source URL function found",
+			discoverer: &Discoverer{
+				imageSourceURLFnsByBaseURL: map[string]func(string, string) string{
+					testURLPrefix: func(string, string) string {
+						return "fake-url"
+					},
+				},
+			},
+			expectedURL: "fake-url",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expectedURL,
+				testCase.discoverer.getImageSourceURL(testURLPrefix, "fake-tag"),
+			)
+		})
+	}
+}
+
+func TestGetGithubImageSourceURL(t *testing.T) {
+	const testTag = "fake-tag"
+	testCases := []struct {
+		name    string
+		baseURL string
+	}{
+		{
+			name:    "with .git suffix",
+			baseURL: "https://github.com/akuity/kargo.git",
+		},
+		{
+			name:    "without .git suffix",
+			baseURL: "https://github.com/akuity/kargo",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				fmt.Sprintf(
+					"%s/tree/%s",
+					"https://github.com/akuity/kargo",
+					testTag,
+				),
+				getGithubImageSourceURL(testCase.baseURL, testTag),
+			)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/logging"
+	"github.com/akuity/kargo/internal/packages"
+)
+
+func (d *Discoverer) discoverPackages(
+	ctx context.Context,
+	namespace string,
+	subs []kargoapi.RepoSubscription,
+) ([]kargoapi.PackageDiscoveryResult, error) {
+	return discoverConcurrently(
+		ctx,
+		d.maxConcurrentDiscoveries,
+		subs,
+		func(ctx context.Context, s kargoapi.RepoSubscription) ([]kargoapi.PackageDiscoveryResult, error) {
+			if s.Package == nil {
+				return nil, nil
+			}
+			result, err := d.discoverPackage(ctx, namespace, s.Package)
+			if err != nil {
+				return nil, err
+			}
+			return []kargoapi.PackageDiscoveryResult{result}, nil
+		},
+	)
+}
+
+// discoverPackage discovers the latest suitable versions of the package
+// described by the provided PackageSubscription.
+func (d *Discoverer) discoverPackage(
+	ctx context.Context,
+	namespace string,
+	sub *kargoapi.PackageSubscription,
+) (kargoapi.PackageDiscoveryResult, error) {
+	repoURL := packages.RepoURL(sub.Type, sub.RepoURL)
+	logger := logging.LoggerFromContext(ctx).WithField("repoURL", repoURL).
+		WithField("package", sub.Name)
+
+	creds, ok, err := d.credentialsDB.Get(ctx, namespace, credentials.TypePackage, repoURL)
+	if err != nil {
+		return kargoapi.PackageDiscoveryResult{}, fmt.Errorf(
+			"error obtaining credentials for package registry %q: %w",
+			repoURL,
+			err,
+		)
+	}
+	var pkgCreds *packages.Credentials
+	if ok {
+		pkgCreds = &packages.Credentials{
+			Username: creds.Username,
+			Password: creds.Password,
+		}
+		logger.Debug("obtained credentials for package registry")
+	} else {
+		logger.Debug("found no credentials for package registry")
+	}
+
+	versions, err := d.discoverPackageVersionsFn(
+		ctx,
+		sub.Type,
+		repoURL,
+		sub.Name,
+		sub.SemverConstraint,
+		pkgCreds,
+	)
+	if err != nil {
+		return kargoapi.PackageDiscoveryResult{}, fmt.Errorf(
+			"error discovering latest suitable versions of %s package %q in registry %q: %w",
+			sub.Type,
+			sub.Name,
+			repoURL,
+			err,
+		)
+	}
+
+	if len(versions) == 0 {
+		logger.Debug("discovered no suitable package versions")
+	} else {
+		logger.Debugf("discovered %d suitable package versions", len(versions))
+	}
+	return kargoapi.PackageDiscoveryResult{
+		Type:             sub.Type,
+		RepoURL:          repoURL,
+		Name:             sub.Name,
+		SemverConstraint: sub.SemverConstraint,
+		Versions:         trimSlice(versions, packageDiscoveryLimit(sub)),
+	}, nil
+}
+
+// packageDiscoveryLimit returns the maximum number of versions that may be
+// discovered for the provided subscription, falling back to the default of 20
+// if it does not specify one.
+func packageDiscoveryLimit(sub *kargoapi.PackageSubscription) int {
+	if sub.DiscoveryLimit <= 0 {
+		return 20
+	}
+	return int(sub.DiscoveryLimit)
+}
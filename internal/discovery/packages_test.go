@@ -0,0 +1,149 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/credentials"
+	"github.com/akuity/kargo/internal/packages"
+)
+
+func TestDiscoverPackages(t *testing.T) {
+	testCases := []struct {
+		name       string
+		discoverer *Discoverer
+		subs       []kargoapi.RepoSubscription
+		assertions func(*testing.T, []kargoapi.PackageDiscoveryResult, error)
+	}{
+		{
+			name:       "no package subscription",
+			discoverer: &Discoverer{},
+			subs: []kargoapi.RepoSubscription{
+				{Chart: &kargoapi.ChartSubscription{}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.PackageDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Empty(t, results)
+			},
+		},
+		{
+			name: "error obtaining credentials",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{
+					GetFn: func(
+						context.Context,
+						string,
+						credentials.Type,
+						string,
+					) (credentials.Credentials, bool, error) {
+						return credentials.Credentials{}, false, fmt.Errorf("something went wrong")
+					},
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Package: &kargoapi.PackageSubscription{Type: kargoapi.PackageTypeNPM}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.PackageDiscoveryResult, err error) {
+				require.ErrorContains(t, err, "error obtaining credentials for package registry")
+				require.Empty(t, results)
+			},
+		},
+		{
+			name: "discovers package versions from default registry with credentials",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{
+					GetFn: func(
+						_ context.Context,
+						_ string,
+						credType credentials.Type,
+						repoURL string,
+					) (credentials.Credentials, bool, error) {
+						if credType != credentials.TypePackage || repoURL != packages.DefaultNPMRepoURL {
+							return credentials.Credentials{}, false, nil
+						}
+						return credentials.Credentials{Password: "fake-token"}, true, nil
+					},
+				},
+				discoverPackageVersionsFn: func(
+					_ context.Context,
+					_ kargoapi.PackageType,
+					_ string,
+					_ string,
+					_ string,
+					creds *packages.Credentials,
+				) ([]string, error) {
+					if creds == nil || creds.Password != "fake-token" {
+						return nil, fmt.Errorf("missing credentials")
+					}
+					return []string{"1.2.0", "1.1.0", "1.0.0"}, nil
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Package: &kargoapi.PackageSubscription{
+					Type:             kargoapi.PackageTypeNPM,
+					Name:             "fake-package",
+					SemverConstraint: "^1.0.0",
+					DiscoveryLimit:   2,
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.PackageDiscoveryResult, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []kargoapi.PackageDiscoveryResult{
+					{
+						Type:             kargoapi.PackageTypeNPM,
+						RepoURL:          packages.DefaultNPMRepoURL,
+						Name:             "fake-package",
+						SemverConstraint: "^1.0.0",
+						Versions:         []string{"1.2.0", "1.1.0"},
+					},
+				}, results)
+			},
+		},
+		{
+			name: "error discovering package versions",
+			discoverer: &Discoverer{
+				credentialsDB: &credentials.FakeDB{},
+				discoverPackageVersionsFn: func(
+					context.Context,
+					kargoapi.PackageType,
+					string,
+					string,
+					string,
+					*packages.Credentials,
+				) ([]string, error) {
+					return nil, fmt.Errorf("something went wrong")
+				},
+			},
+			subs: []kargoapi.RepoSubscription{
+				{Package: &kargoapi.PackageSubscription{
+					Type:    kargoapi.PackageTypeMaven,
+					RepoURL: "https://maven.example.com/",
+					Name:    "com.example:lib",
+				}},
+			},
+			assertions: func(t *testing.T, results []kargoapi.PackageDiscoveryResult, err error) {
+				require.ErrorContains(
+					t, err, `error discovering latest suitable versions of Maven package "com.example:lib"`,
+				)
+				require.ErrorContains(t, err, `"https://maven.example.com"`)
+				require.ErrorContains(t, err, "something went wrong")
+				require.Empty(t, results)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			results, err := testCase.discoverer.discoverPackages(
+				context.TODO(),
+				"fake-namespace",
+				testCase.subs,
+			)
+			testCase.assertions(t, results, err)
+		})
+	}
+}
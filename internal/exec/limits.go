@@ -0,0 +1,95 @@
+package exec
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+// SandboxCommand is the name of the Kargo command that applies Limits to its
+// own process before replacing itself with the command that is to be limited.
+const SandboxCommand = "sandbox"
+
+// Limits represents limits on the resources that may be consumed by a command.
+// A zero value for any of its fields means the corresponding resource is not
+// limited.
+type Limits struct {
+	// CPUTime is the maximum amount of CPU time the command may consume. A
+	// command that exceeds it is killed.
+	CPUTime time.Duration
+	// Memory is the maximum number of bytes of memory the command may allocate.
+	// Allocations beyond it fail.
+	Memory int64
+	// FileSize is the maximum size, in bytes, of any single file the command may
+	// write. A command that exceeds it is killed.
+	FileSize int64
+}
+
+// IsZero returns true if no resources are limited by the Limits.
+func (l Limits) IsZero() bool {
+	return l == Limits{}
+}
+
+// Args returns the flags of the SandboxCommand that correspond to the Limits.
+func (l Limits) Args() []string {
+	var args []string
+	if l.CPUTime > 0 {
+		args = append(args, "--cpu-time="+l.CPUTime.String())
+	}
+	if l.Memory > 0 {
+		args = append(args, "--memory="+strconv.FormatInt(l.Memory, 10))
+	}
+	if l.FileSize > 0 {
+		args = append(args, "--file-size="+strconv.FormatInt(l.FileSize, 10))
+	}
+	return args
+}
+
+// ExecWithLimits is like Exec(), but executes the provided command in a
+// separate process to which the provided Limits apply. This ensures a command
+// that misbehaves, e.g. by attempting to consume all available memory, is
+// stopped before it can affect the process that executed it. If no resources
+// are limited by the provided Limits, this is equivalent to calling Exec().
+func ExecWithLimits(cmd *exec.Cmd, limits Limits) ([]byte, error) {
+	if limits.IsZero() {
+		return Exec(cmd)
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("error finding current executable: %w", err)
+	}
+	return Exec(sandbox(self, cmd, limits))
+}
+
+// OutputWithLimits is like ExecWithLimits(), but only returns the command's
+// standard output, like cmd.Output(). Anything the command writes to its
+// standard error is written to the provided command's Stderr, if set.
+func OutputWithLimits(cmd *exec.Cmd, limits Limits) ([]byte, error) {
+	if limits.IsZero() {
+		return cmd.Output()
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("error finding current executable: %w", err)
+	}
+	return sandbox(self, cmd, limits).Output()
+}
+
+// sandbox returns a command that uses the SandboxCommand of the provided Kargo
+// executable to execute the provided command subject to the provided Limits.
+func sandbox(self string, cmd *exec.Cmd, limits Limits) *exec.Cmd {
+	args := append([]string{SandboxCommand}, limits.Args()...)
+	args = append(args, "--", cmd.Path)
+	if len(cmd.Args) > 1 {
+		args = append(args, cmd.Args[1:]...)
+	}
+	sandboxed := exec.Command(self, args...) // nolint: gosec
+	sandboxed.Env = cmd.Env
+	sandboxed.Dir = cmd.Dir
+	// The sandboxed command replaces the sandbox process, so it writes to the
+	// same standard error.
+	sandboxed.Stderr = cmd.Stderr
+	return sandboxed
+}
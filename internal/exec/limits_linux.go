@@ -0,0 +1,42 @@
+package exec
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// ApplyLimits applies the provided Limits to the current process and then
+// replaces the current process with the command specified by the provided
+// arguments. It only returns if either of those fails.
+func ApplyLimits(limits Limits, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no command specified")
+	}
+	for _, l := range []struct {
+		resource int
+		name     string
+		value    uint64
+	}{
+		{resource: syscall.RLIMIT_CPU, name: "CPU time", value: uint64(math.Ceil(limits.CPUTime.Seconds()))},
+		{resource: syscall.RLIMIT_DATA, name: "memory", value: uint64(limits.Memory)},
+		{resource: syscall.RLIMIT_FSIZE, name: "file size", value: uint64(limits.FileSize)},
+	} {
+		if l.value == 0 {
+			continue
+		}
+		if err := syscall.Setrlimit(
+			l.resource,
+			&syscall.Rlimit{Cur: l.value, Max: l.value},
+		); err != nil {
+			return fmt.Errorf("error limiting %s: %w", l.name, err)
+		}
+	}
+	path, err := exec.LookPath(args[0])
+	if err != nil {
+		return fmt.Errorf("error finding command %q: %w", args[0], err)
+	}
+	return syscall.Exec(path, args, os.Environ()) // nolint: gosec
+}
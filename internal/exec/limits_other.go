@@ -0,0 +1,13 @@
+//go:build !linux
+
+package exec
+
+import "errors"
+
+// ApplyLimits applies the provided Limits to the current process and then
+// replaces the current process with the command specified by the provided
+// arguments. Limits are only supported on Linux, so on all other operating
+// systems, it always returns an error.
+func ApplyLimits(Limits, []string) error {
+	return errors.New("resource limits are only supported on Linux")
+}
@@ -0,0 +1,94 @@
+package exec
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitsArgs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		limits   Limits
+		expected []string
+	}{
+		{
+			name:   "no limits",
+			limits: Limits{},
+		},
+		{
+			name: "all limits",
+			limits: Limits{
+				CPUTime:  time.Minute,
+				Memory:   512 * 1024 * 1024,
+				FileSize: 1024,
+			},
+			expected: []string{
+				"--cpu-time=1m0s",
+				"--memory=536870912",
+				"--file-size=1024",
+			},
+		},
+		{
+			name:     "some limits",
+			limits:   Limits{Memory: 1024},
+			expected: []string{"--memory=1024"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, testCase.limits.Args())
+			require.Equal(t, testCase.expected == nil, testCase.limits.IsZero())
+		})
+	}
+}
+
+func TestSandbox(t *testing.T) {
+	cmd := exec.Command("echo", "hello", "world")
+	cmd.Env = []string{"FOO=bar"}
+	cmd.Dir = "/fake/dir"
+	cmd.Stderr = &strings.Builder{}
+	sandboxed := sandbox("/usr/local/bin/kargo", cmd, Limits{Memory: 1024})
+	require.Equal(t, "/usr/local/bin/kargo", sandboxed.Path)
+	require.Equal(
+		t,
+		[]string{
+			"/usr/local/bin/kargo",
+			SandboxCommand,
+			"--memory=1024",
+			"--",
+			cmd.Path,
+			"hello",
+			"world",
+		},
+		sandboxed.Args,
+	)
+	require.Equal(t, cmd.Env, sandboxed.Env)
+	require.Equal(t, cmd.Dir, sandboxed.Dir)
+	require.Equal(t, cmd.Stderr, sandboxed.Stderr)
+}
+
+func TestExecWithLimits(t *testing.T) {
+	// Without any limits, the command is executed directly
+	res, err := ExecWithLimits(exec.Command("echo", "foo"), Limits{})
+	require.NoError(t, err)
+	require.Equal(t, "foo\n", string(res))
+}
+
+func TestOutputWithLimits(t *testing.T) {
+	// Without any limits, the command is executed directly
+	cmd := exec.Command("sh", "-c", "echo foo; echo bar >&2")
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	res, err := OutputWithLimits(cmd, Limits{})
+	require.NoError(t, err)
+	require.Equal(t, "foo\n", string(res))
+	require.Equal(t, "bar\n", stderr.String())
+}
+
+func TestApplyLimits(t *testing.T) {
+	require.Error(t, ApplyLimits(Limits{}, nil))
+}
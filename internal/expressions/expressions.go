@@ -0,0 +1,150 @@
+package expressions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+)
+
+const (
+	exprStart = "${{"
+	exprEnd   = "}}"
+)
+
+// EvaluateTemplate evaluates the provided template string using the provided
+// environment. Templates may contain any number of expressions, each enclosed
+// in ${{ and }}. Expressions have access to all values in the provided
+// environment and to the built-in function library (see Functions). If the
+// template consists of nothing but a single expression, the result of that
+// expression is returned as-is, preserving its type. In all other cases, the
+// results of all expressions are converted to strings and interpolated into
+// the template, which is then returned as a string.
+func EvaluateTemplate(
+	template string,
+	env map[string]any,
+	exprOpts ...expr.Option,
+) (any, error) {
+	segments, err := parseTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+	opts := make([]expr.Option, 0, len(exprOpts)+len(functionOptions)+1)
+	opts = append(opts, expr.Env(env))
+	opts = append(opts, functionOptions...)
+	opts = append(opts, exprOpts...)
+	if len(segments) == 1 && segments[0].isExpr {
+		return evaluate(segments[0].value, env, opts)
+	}
+	var sb strings.Builder
+	for _, seg := range segments {
+		if !seg.isExpr {
+			sb.WriteString(seg.value)
+			continue
+		}
+		res, err := evaluate(seg.value, env, opts)
+		if err != nil {
+			return nil, err
+		}
+		if res != nil {
+			sb.WriteString(fmt.Sprint(res))
+		}
+	}
+	return sb.String(), nil
+}
+
+// EvaluateTemplateToString evaluates the provided template string using the
+// provided environment and returns the result as a string. It is a
+// convenience wrapper around EvaluateTemplate for callers that are only
+// interested in string results.
+func EvaluateTemplateToString(
+	template string,
+	env map[string]any,
+	exprOpts ...expr.Option,
+) (string, error) {
+	res, err := EvaluateTemplate(template, env, exprOpts...)
+	if err != nil {
+		return "", err
+	}
+	if res == nil {
+		return "", nil
+	}
+	if s, ok := res.(string); ok {
+		return s, nil
+	}
+	return fmt.Sprint(res), nil
+}
+
+// CompileExpression compiles the provided expression, which must NOT be
+// enclosed in ${{ and }}, into a program that has access to the built-in
+// function library. Callers that need to evaluate the same expression many
+// times (e.g. once per image tag) should compile it once using this function
+// and then run the resulting program using expr.Run.
+func CompileExpression(expression string, exprOpts ...expr.Option) (*vm.Program, error) {
+	opts := make([]expr.Option, 0, len(exprOpts)+len(functionOptions))
+	opts = append(opts, functionOptions...)
+	opts = append(opts, exprOpts...)
+	program, err := expr.Compile(expression, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling expression %q: %w", expression, err)
+	}
+	return program, nil
+}
+
+// IsTemplate returns true if the provided string contains at least one
+// expression enclosed in ${{ and }}. It returns false otherwise.
+func IsTemplate(s string) bool {
+	start := strings.Index(s, exprStart)
+	return start >= 0 && strings.Contains(s[start+len(exprStart):], exprEnd)
+}
+
+// evaluate compiles and runs a single expression.
+func evaluate(expression string, env map[string]any, opts []expr.Option) (any, error) {
+	program, err := expr.Compile(expression, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling expression %q: %w", expression, err)
+	}
+	res, err := expr.Run(program, env)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating expression %q: %w", expression, err)
+	}
+	return res, nil
+}
+
+// segment is a portion of a template. It is either a literal string or an
+// expression.
+type segment struct {
+	value  string
+	isExpr bool
+}
+
+// parseTemplate splits the provided template into literal and expression
+// segments. It returns an error if an expression is not terminated.
+func parseTemplate(template string) ([]segment, error) {
+	var segments []segment
+	remaining := template
+	for {
+		start := strings.Index(remaining, exprStart)
+		if start < 0 {
+			if remaining != "" || len(segments) == 0 {
+				segments = append(segments, segment{value: remaining})
+			}
+			return segments, nil
+		}
+		if start > 0 {
+			segments = append(segments, segment{value: remaining[:start]})
+		}
+		remaining = remaining[start+len(exprStart):]
+		end := strings.Index(remaining, exprEnd)
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated expression in template %q", template)
+		}
+		expression := strings.TrimSpace(remaining[:end])
+		if expression == "" {
+			return nil, fmt.Errorf("empty expression in template %q", template)
+		}
+		segments = append(segments, segment{value: expression, isExpr: true})
+		remaining = remaining[end+len(exprEnd):]
+	}
+}
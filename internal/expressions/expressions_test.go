@@ -0,0 +1,125 @@
+package expressions
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateTemplate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		template   string
+		env        map[string]any
+		assertions func(t *testing.T, result any, err error)
+	}{
+		{
+			name:     "no expressions",
+			template: "just a string",
+			assertions: func(t *testing.T, result any, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "just a string", result)
+			},
+		},
+		{
+			name:     "empty template",
+			template: "",
+			assertions: func(t *testing.T, result any, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "", result)
+			},
+		},
+		{
+			name:     "single expression preserves type",
+			template: "${{ 40 + 2 }}",
+			assertions: func(t *testing.T, result any, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 42, result)
+			},
+		},
+		{
+			name:     "expressions are interpolated",
+			template: "image: ${{ image.repoURL }}:${{ image.tag }}",
+			env: map[string]any{
+				"image": map[string]any{
+					"repoURL": "example.com/foo",
+					"tag":     "v1.2.3",
+				},
+			},
+			assertions: func(t *testing.T, result any, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "image: example.com/foo:v1.2.3", result)
+			},
+		},
+		{
+			name:     "built-in functions are available",
+			template: `${{ semverParse("v1.2.3").minor }}`,
+			assertions: func(t *testing.T, result any, err error) {
+				require.NoError(t, err)
+				require.Equal(t, 2, result)
+			},
+		},
+		{
+			name:     "unterminated expression",
+			template: "${{ 1 + 1",
+			assertions: func(t *testing.T, _ any, err error) {
+				require.ErrorContains(t, err, "unterminated expression")
+			},
+		},
+		{
+			name:     "empty expression",
+			template: "foo ${{ }}",
+			assertions: func(t *testing.T, _ any, err error) {
+				require.ErrorContains(t, err, "empty expression")
+			},
+		},
+		{
+			name:     "invalid expression",
+			template: "${{ 1 + }}",
+			assertions: func(t *testing.T, _ any, err error) {
+				require.ErrorContains(t, err, "error compiling expression")
+			},
+		},
+		{
+			name:     "error evaluating expression",
+			template: `${{ semverCompare("foo", "1.0.0") }}`,
+			assertions: func(t *testing.T, _ any, err error) {
+				require.ErrorContains(t, err, "error evaluating expression")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := EvaluateTemplate(testCase.template, testCase.env)
+			testCase.assertions(t, result, err)
+		})
+	}
+}
+
+func TestEvaluateTemplateToString(t *testing.T) {
+	result, err := EvaluateTemplateToString("${{ 40 + 2 }}", nil)
+	require.NoError(t, err)
+	require.Equal(t, "42", result)
+}
+
+func TestCompileExpression(t *testing.T) {
+	program, err := CompileExpression(
+		`int(regexpCapture("build-(\\d+)", tag)[1])`,
+		expr.Env(map[string]any{"tag": ""}),
+	)
+	require.NoError(t, err)
+	result, err := expr.Run(program, map[string]any{"tag": "main-build-42"})
+	require.NoError(t, err)
+	require.Equal(t, 42, result)
+
+	_, err = CompileExpression("1 +")
+	require.ErrorContains(t, err, "error compiling expression")
+}
+
+func TestIsTemplate(t *testing.T) {
+	require.True(t, IsTemplate("foo ${{ bar }}"))
+	require.False(t, IsTemplate("foo"))
+	require.False(t, IsTemplate("foo ${{ bar"))
+	require.False(t, IsTemplate("foo }} ${{"))
+}
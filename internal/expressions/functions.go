@@ -0,0 +1,279 @@
+package expressions
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/expr-lang/expr"
+)
+
+// functionOptions is the built-in function library that is made available to
+// all expressions.
+var functionOptions = []expr.Option{
+	// quote(value) wraps the string representation of any value in double
+	// quotes, escaping as necessary.
+	expr.Function("quote", quote, new(func(any) string)),
+
+	// semverParse(version) parses a semantic version and returns a map with
+	// major, minor, patch, prerelease, metadata, and original keys.
+	expr.Function("semverParse", semverParse, new(func(string) map[string]any)),
+	// semverCompare(a, b) returns -1, 0, or 1 depending on whether semantic
+	// version a is less than, equal to, or greater than semantic version b.
+	expr.Function("semverCompare", semverCompare, new(func(string, string) int)),
+	// semverSatisfies(version, constraint) returns true if the semantic version
+	// satisfies the constraint.
+	expr.Function("semverSatisfies", semverSatisfies, new(func(string, string) bool)),
+
+	// regexpMatch(pattern, s) returns true if s matches the regular
+	// expression.
+	expr.Function("regexpMatch", regexpMatch, new(func(string, string) bool)),
+	// regexpCapture(pattern, s) returns the full match and all submatches of
+	// the first match of the regular expression in s, or an empty list.
+	expr.Function("regexpCapture", regexpCapture, new(func(string, string) []any)),
+	// regexpCaptureNamed(pattern, s) returns a map of named submatches of the
+	// first match of the regular expression in s, or an empty map.
+	expr.Function(
+		"regexpCaptureNamed",
+		regexpCaptureNamed,
+		new(func(string, string) map[string]any),
+	),
+	// regexpReplace(pattern, s, replacement) replaces all matches of the
+	// regular expression in s with the replacement.
+	expr.Function(
+		"regexpReplace",
+		regexpReplace,
+		new(func(string, string, string) string),
+	),
+
+	// urlParse(s) parses a URL and returns a map with scheme, user, host,
+	// hostname, port, path, rawQuery, query, and fragment keys.
+	expr.Function("urlParse", urlParse, new(func(string) map[string]any)),
+
+	// timeFormat(t, layout) formats a time using a Go time layout. t may be a
+	// time or an RFC 3339 formatted string.
+	expr.Function("timeFormat", timeFormat, new(func(any, string) string)),
+	// timeParse(layout, s) parses a time using a Go time layout.
+	expr.Function("timeParse", timeParse, new(func(string, string) time.Time)),
+	// timeUnix(t) returns the number of seconds since the Unix epoch. t may be
+	// a time or an RFC 3339 formatted string.
+	expr.Function("timeUnix", timeUnix, new(func(any) int)),
+
+	// imageRef(image) returns a reference to an image of the form
+	// repoURL:tag@digest, omitting the tag or digest if the image has none. The
+	// image is a map with repoURL, tag, and digest keys, such as an image from
+	// Freight.
+	expr.Function("imageRef", imageRef, new(func(map[string]any) string)),
+
+	// fromJSON(s) parses a JSON document.
+	expr.Function("fromJSON", fromJSON, new(func(string) any)),
+	// toJSON(value) serializes any value as JSON.
+	expr.Function("toJSON", toJSON, new(func(any) string)),
+}
+
+func quote(params ...any) (any, error) {
+	return strconv.Quote(fmt.Sprint(params[0])), nil
+}
+
+func semverParse(params ...any) (any, error) {
+	sv, err := semver.NewVersion(params[0].(string)) // nolint: forcetypeassert
+	if err != nil {
+		return nil, fmt.Errorf("error parsing semantic version %q: %w", params[0], err)
+	}
+	return map[string]any{
+		"major":      int(sv.Major()),
+		"minor":      int(sv.Minor()),
+		"patch":      int(sv.Patch()),
+		"prerelease": sv.Prerelease(),
+		"metadata":   sv.Metadata(),
+		"original":   sv.Original(),
+	}, nil
+}
+
+func semverCompare(params ...any) (any, error) {
+	a, err := semver.NewVersion(params[0].(string)) // nolint: forcetypeassert
+	if err != nil {
+		return nil, fmt.Errorf("error parsing semantic version %q: %w", params[0], err)
+	}
+	b, err := semver.NewVersion(params[1].(string)) // nolint: forcetypeassert
+	if err != nil {
+		return nil, fmt.Errorf("error parsing semantic version %q: %w", params[1], err)
+	}
+	return a.Compare(b), nil
+}
+
+func semverSatisfies(params ...any) (any, error) {
+	sv, err := semver.NewVersion(params[0].(string)) // nolint: forcetypeassert
+	if err != nil {
+		return nil, fmt.Errorf("error parsing semantic version %q: %w", params[0], err)
+	}
+	constraint, err := semver.NewConstraint(params[1].(string)) // nolint: forcetypeassert
+	if err != nil {
+		return nil, fmt.Errorf("error parsing semver constraint %q: %w", params[1], err)
+	}
+	return constraint.Check(sv), nil
+}
+
+func regexpMatch(params ...any) (any, error) {
+	re, err := compileRegexp(params[0].(string)) // nolint: forcetypeassert
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString(params[1].(string)), nil // nolint: forcetypeassert
+}
+
+func regexpCapture(params ...any) (any, error) {
+	re, err := compileRegexp(params[0].(string)) // nolint: forcetypeassert
+	if err != nil {
+		return nil, err
+	}
+	matches := re.FindStringSubmatch(params[1].(string)) // nolint: forcetypeassert
+	res := make([]any, len(matches))
+	for i, match := range matches {
+		res[i] = match
+	}
+	return res, nil
+}
+
+func regexpCaptureNamed(params ...any) (any, error) {
+	re, err := compileRegexp(params[0].(string)) // nolint: forcetypeassert
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]any{}
+	matches := re.FindStringSubmatch(params[1].(string)) // nolint: forcetypeassert
+	if matches == nil {
+		return res, nil
+	}
+	for i, name := range re.SubexpNames() {
+		if i > 0 && name != "" {
+			res[name] = matches[i]
+		}
+	}
+	return res, nil
+}
+
+func regexpReplace(params ...any) (any, error) {
+	re, err := compileRegexp(params[0].(string)) // nolint: forcetypeassert
+	if err != nil {
+		return nil, err
+	}
+	// nolint: forcetypeassert
+	return re.ReplaceAllString(params[1].(string), params[2].(string)), nil
+}
+
+func compileRegexp(pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling regular expression %q: %w", pattern, err)
+	}
+	return re, nil
+}
+
+func urlParse(params ...any) (any, error) {
+	u, err := url.Parse(params[0].(string)) // nolint: forcetypeassert
+	if err != nil {
+		return nil, fmt.Errorf("error parsing URL %q: %w", params[0], err)
+	}
+	query := map[string]any{}
+	for k, v := range u.Query() {
+		if len(v) > 0 {
+			query[k] = v[0]
+		}
+	}
+	return map[string]any{
+		"scheme":   u.Scheme,
+		"user":     u.User.Username(),
+		"host":     u.Host,
+		"hostname": u.Hostname(),
+		"port":     u.Port(),
+		"path":     u.Path,
+		"rawQuery": u.RawQuery,
+		"query":    query,
+		"fragment": u.Fragment,
+	}, nil
+}
+
+func timeFormat(params ...any) (any, error) {
+	t, err := toTime(params[0])
+	if err != nil {
+		return nil, err
+	}
+	return t.Format(params[1].(string)), nil // nolint: forcetypeassert
+}
+
+func timeParse(params ...any) (any, error) {
+	// nolint: forcetypeassert
+	t, err := time.Parse(params[0].(string), params[1].(string))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing time %q: %w", params[1], err)
+	}
+	return t, nil
+}
+
+func timeUnix(params ...any) (any, error) {
+	t, err := toTime(params[0])
+	if err != nil {
+		return nil, err
+	}
+	return int(t.Unix()), nil
+}
+
+// toTime converts the provided value to a time.Time. The value may be a
+// time.Time, a pointer to one, or an RFC 3339 formatted string.
+func toTime(v any) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case *time.Time:
+		if t == nil {
+			return time.Time{}, fmt.Errorf("time is nil")
+		}
+		return *t, nil
+	case string:
+		parsed, err := time.Parse(time.RFC3339, t)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("error parsing time %q: %w", t, err)
+		}
+		return parsed, nil
+	default:
+		return time.Time{}, fmt.Errorf("cannot convert %T to a time", v)
+	}
+}
+
+func imageRef(params ...any) (any, error) {
+	image := params[0].(map[string]any) // nolint: forcetypeassert
+	repoURL, _ := image["repoURL"].(string)
+	if repoURL == "" {
+		return nil, fmt.Errorf("image has no repoURL")
+	}
+	ref := repoURL
+	if tag, _ := image["tag"].(string); tag != "" {
+		ref += ":" + tag
+	}
+	if digest, _ := image["digest"].(string); digest != "" {
+		ref += "@" + digest
+	}
+	return ref, nil
+}
+
+func fromJSON(params ...any) (any, error) {
+	var res any
+	data := []byte(params[0].(string)) // nolint: forcetypeassert
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("error parsing JSON: %w", err)
+	}
+	return res, nil
+}
+
+func toJSON(params ...any) (any, error) {
+	b, err := json.Marshal(params[0])
+	if err != nil {
+		return nil, fmt.Errorf("error serializing value to JSON: %w", err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,178 @@
+package expressions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunctions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		template    string
+		env         map[string]any
+		expectError bool
+		expected    any
+	}{
+		{
+			name:     "quote",
+			template: `${{ quote(42) }}`,
+			expected: `"42"`,
+		},
+		{
+			name:     "semverParse",
+			template: `${{ semverParse("v1.2.3-rc.1+build.5") }}`,
+			expected: map[string]any{
+				"major":      1,
+				"minor":      2,
+				"patch":      3,
+				"prerelease": "rc.1",
+				"metadata":   "build.5",
+				"original":   "v1.2.3-rc.1+build.5",
+			},
+		},
+		{
+			name:        "semverParse with invalid version",
+			template:    `${{ semverParse("foo") }}`,
+			expectError: true,
+		},
+		{
+			name:     "semverCompare",
+			template: `${{ semverCompare("1.10.0", "1.9.0") }}`,
+			expected: 1,
+		},
+		{
+			name:     "semverSatisfies",
+			template: `${{ semverSatisfies("1.2.3", "^1.0.0") }}`,
+			expected: true,
+		},
+		{
+			name:        "semverSatisfies with invalid constraint",
+			template:    `${{ semverSatisfies("1.2.3", "not a constraint") }}`,
+			expectError: true,
+		},
+		{
+			name:     "regexpMatch",
+			template: `${{ regexpMatch("^v\\d+$", "v42") }}`,
+			expected: true,
+		},
+		{
+			name:        "regexpMatch with invalid pattern",
+			template:    `${{ regexpMatch("(", "v42") }}`,
+			expectError: true,
+		},
+		{
+			name:     "regexpCapture",
+			template: `${{ regexpCapture("build-(\\d+)", tag)[1] }}`,
+			env:      map[string]any{"tag": "main-build-1234"},
+			expected: "1234",
+		},
+		{
+			name:     "regexpCapture without match",
+			template: `${{ len(regexpCapture("build-(\\d+)", "main")) }}`,
+			expected: 0,
+		},
+		{
+			name:     "regexpCaptureNamed",
+			template: `${{ regexpCaptureNamed("(?P<branch>\\w+)-(?P<build>\\d+)", "main-42").build }}`,
+			expected: "42",
+		},
+		{
+			name:     "regexpReplace",
+			template: `${{ regexpReplace("[^a-z0-9]+", "Feature/Foo_Bar", "-") }}`,
+			expected: "-eature-oo-ar",
+		},
+		{
+			name:     "urlParse",
+			template: `${{ urlParse("https://user@example.com:8443/foo/bar?baz=qux#frag") }}`,
+			expected: map[string]any{
+				"scheme":   "https",
+				"user":     "user",
+				"host":     "example.com:8443",
+				"hostname": "example.com",
+				"port":     "8443",
+				"path":     "/foo/bar",
+				"rawQuery": "baz=qux",
+				"query":    map[string]any{"baz": "qux"},
+				"fragment": "frag",
+			},
+		},
+		{
+			name:     "timeFormat with time",
+			template: `${{ timeFormat(created, "2006-01-02") }}`,
+			env: map[string]any{
+				"created": time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+			},
+			expected: "2024-05-06",
+		},
+		{
+			name:     "timeFormat with string",
+			template: `${{ timeFormat("2024-05-06T07:08:09Z", "15:04") }}`,
+			expected: "07:08",
+		},
+		{
+			name:        "timeFormat with invalid string",
+			template:    `${{ timeFormat("yesterday", "15:04") }}`,
+			expectError: true,
+		},
+		{
+			name:     "timeParse",
+			template: `${{ timeParse("2006-01-02", "2024-05-06") }}`,
+			expected: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "timeUnix",
+			template: `${{ timeUnix("1970-01-01T00:01:00Z") }}`,
+			expected: 60,
+		},
+		{
+			name:     "imageRef",
+			template: `${{ imageRef(image) }}`,
+			env: map[string]any{
+				"image": map[string]any{
+					"repoURL": "nginx",
+					"tag":     "1.25.0",
+					"digest":  "sha256:abc",
+				},
+			},
+			expected: "nginx:1.25.0@sha256:abc",
+		},
+		{
+			name:     "imageRef without digest",
+			template: `${{ imageRef({"repoURL": "nginx", "tag": "1.25.0"}) }}`,
+			expected: "nginx:1.25.0",
+		},
+		{
+			name:        "imageRef without repoURL",
+			template:    `${{ imageRef({"tag": "1.25.0"}) }}`,
+			expectError: true,
+		},
+		{
+			name:     "fromJSON",
+			template: `${{ fromJSON("{\"foo\": [1, 2]}").foo[1] }}`,
+			expected: float64(2),
+		},
+		{
+			name:        "fromJSON with invalid JSON",
+			template:    `${{ fromJSON("{") }}`,
+			expectError: true,
+		},
+		{
+			name:     "toJSON",
+			template: `${{ toJSON({"foo": "bar"}) }}`,
+			expected: `{"foo":"bar"}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := EvaluateTemplate(testCase.template, testCase.env)
+			if testCase.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, result)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package git
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	branchPatternGlobPrefix   = "glob:"
+	branchPatternRegexpPrefix = "regexp:"
+)
+
+// BranchMatcher decides whether a branch name matches a branch pattern.
+type BranchMatcher func(branch string) bool
+
+// IsBranchPattern returns a bool indicating whether the provided value of a
+// GitSubscription's Branch field is a pattern matching any number of branches,
+// rather than the name of a single branch. Patterns are either glob patterns,
+// prefixed with "glob:", or regular expressions, prefixed with "regexp:".
+func IsBranchPattern(branch string) bool {
+	return strings.HasPrefix(branch, branchPatternGlobPrefix) ||
+		strings.HasPrefix(branch, branchPatternRegexpPrefix)
+}
+
+// NewBranchMatcher compiles the provided branch pattern into a BranchMatcher.
+// The pattern must be prefixed with either "glob:" or "regexp:". In glob
+// patterns, wildcards do not match the slashes that separate the components of
+// a branch name (e.g. "glob:release/*" matches release/1.0 but not
+// release/1.0/hotfix).
+func NewBranchMatcher(pattern string) (BranchMatcher, error) {
+	switch {
+	case strings.HasPrefix(pattern, branchPatternGlobPrefix):
+		glob := strings.TrimPrefix(pattern, branchPatternGlobPrefix)
+		// Match against an arbitrary name to detect a malformed pattern early.
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, fmt.Errorf("error parsing glob pattern %q: %w", glob, err)
+		}
+		return func(branch string) bool {
+			match, _ := path.Match(glob, branch)
+			return match
+		}, nil
+	case strings.HasPrefix(pattern, branchPatternRegexpPrefix):
+		regex, err := regexp.Compile(strings.TrimPrefix(pattern, branchPatternRegexpPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing regular expression: %w", err)
+		}
+		return regex.MatchString, nil
+	default:
+		return nil, fmt.Errorf("%q is not a branch pattern", pattern)
+	}
+}
@@ -0,0 +1,70 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsBranchPattern(t *testing.T) {
+	require.False(t, IsBranchPattern(""))
+	require.False(t, IsBranchPattern("main"))
+	require.False(t, IsBranchPattern("release/1.0"))
+	require.True(t, IsBranchPattern("glob:release/*"))
+	require.True(t, IsBranchPattern("regexp:^release/.*$"))
+}
+
+func TestNewBranchMatcher(t *testing.T) {
+	testCases := []struct {
+		name       string
+		pattern    string
+		assertions func(*testing.T, BranchMatcher, error)
+	}{
+		{
+			name:    "not a pattern",
+			pattern: "main",
+			assertions: func(t *testing.T, _ BranchMatcher, err error) {
+				require.ErrorContains(t, err, "is not a branch pattern")
+			},
+		},
+		{
+			name:    "invalid glob",
+			pattern: "glob:release/[",
+			assertions: func(t *testing.T, _ BranchMatcher, err error) {
+				require.ErrorContains(t, err, "error parsing glob pattern")
+			},
+		},
+		{
+			name:    "invalid regular expression",
+			pattern: "regexp:(",
+			assertions: func(t *testing.T, _ BranchMatcher, err error) {
+				require.ErrorContains(t, err, "error parsing regular expression")
+			},
+		},
+		{
+			name:    "glob",
+			pattern: "glob:release/*",
+			assertions: func(t *testing.T, matcher BranchMatcher, err error) {
+				require.NoError(t, err)
+				require.True(t, matcher("release/1.0"))
+				require.False(t, matcher("release/1.0/hotfix"))
+				require.False(t, matcher("main"))
+			},
+		},
+		{
+			name:    "regular expression",
+			pattern: `regexp:^release/\d+\.\d+$`,
+			assertions: func(t *testing.T, matcher BranchMatcher, err error) {
+				require.NoError(t, err)
+				require.True(t, matcher("release/1.0"))
+				require.False(t, matcher("release/next"))
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			matcher, err := NewBranchMatcher(testCase.pattern)
+			testCase.assertions(t, matcher, err)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// calVerTokens maps each of the conventional calendar versioning tokens (see
+// https://calver.org) that may appear in a CalVerLayout to a regular
+// expression matching its values. Tokens are listed such that no token is
+// preceded by another token that is a prefix of it.
+var calVerTokens = []struct {
+	name   string
+	regex  string
+	isYear bool
+}{
+	{name: "YYYY", regex: `\d{4}`, isYear: true},
+	{name: "YY", regex: `[1-9]\d{0,2}|0`, isYear: true},
+	{name: "0Y", regex: `\d{2,3}`, isYear: true},
+	{name: "MM", regex: `1[0-2]|[1-9]`},
+	{name: "0M", regex: `0[1-9]|1[0-2]`},
+	{name: "WW", regex: `5[0-3]|[1-4]\d|[1-9]|0`},
+	{name: "0W", regex: `5[0-3]|[0-4]\d`},
+	{name: "DD", regex: `3[01]|[12]\d|[1-9]`},
+	{name: "0D", regex: `3[01]|[12]\d|0[1-9]`},
+	{name: "MAJOR", regex: `\d+`},
+	{name: "MINOR", regex: `\d+`},
+	{name: "MICRO", regex: `\d+`},
+}
+
+// CalVerLayout is a compiled calendar versioning layout, such as
+// YYYY.0M.MICRO, that versions can be parsed against.
+type CalVerLayout struct {
+	regex *regexp.Regexp
+}
+
+// CalVer is a calendar version parsed against a CalVerLayout. It consists of
+// the numeric values of the layout's tokens, in the order they appear in the
+// layout.
+type CalVer []int
+
+// NewCalVerLayout compiles the provided calendar versioning layout. A layout
+// is composed of the tokens YYYY, YY, 0Y, MM, 0M, WW, 0W, DD, 0D, MAJOR, MINOR,
+// and MICRO, any of which may be separated or surrounded by literal text (e.g.
+// vYYYY.0M.MICRO). A layout must contain exactly one year token and its tokens
+// are expected to appear in order of decreasing significance, as is the case
+// for all conventional layouts.
+func NewCalVerLayout(layout string) (*CalVerLayout, error) {
+	if layout == "" {
+		return nil, errors.New("calendar versioning layout is empty")
+	}
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	var years int
+	var literal strings.Builder
+	flushLiteral := func() {
+		pattern.WriteString(regexp.QuoteMeta(literal.String()))
+		literal.Reset()
+	}
+	for rest := layout; rest != ""; {
+		matched := false
+		for _, token := range calVerTokens {
+			if strings.HasPrefix(rest, token.name) {
+				flushLiteral()
+				pattern.WriteString("(" + token.regex + ")")
+				if token.isYear {
+					years++
+				}
+				rest = rest[len(token.name):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			literal.WriteByte(rest[0])
+			rest = rest[1:]
+		}
+	}
+	flushLiteral()
+	pattern.WriteString("$")
+	if years != 1 {
+		return nil, fmt.Errorf(
+			"calendar versioning layout %q must contain exactly one of the tokens YYYY, YY, or 0Y",
+			layout,
+		)
+	}
+	return &CalVerLayout{regex: regexp.MustCompile(pattern.String())}, nil
+}
+
+// Parse parses the provided version against the layout. It returns false if
+// the version does not conform to the layout.
+func (l *CalVerLayout) Parse(version string) (CalVer, bool) {
+	matches := l.regex.FindStringSubmatch(version)
+	if matches == nil {
+		return nil, false
+	}
+	cv := make(CalVer, len(matches)-1)
+	for i, match := range matches[1:] {
+		var err error
+		if cv[i], err = strconv.Atoi(match); err != nil {
+			return nil, false
+		}
+	}
+	return cv, true
+}
+
+// Compare returns -1, 0, or +1 depending on whether the version is older than,
+// the same as, or newer than the other version. Both versions must have been
+// parsed against the same layout.
+func (c CalVer) Compare(other CalVer) int {
+	for i := range min(len(c), len(other)) {
+		switch {
+		case c[i] < other[i]:
+			return -1
+		case c[i] > other[i]:
+			return 1
+		}
+	}
+	return 0
+}
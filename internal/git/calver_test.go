@@ -0,0 +1,91 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCalVerLayout(t *testing.T) {
+	testCases := []struct {
+		name       string
+		layout     string
+		assertions func(*testing.T, *CalVerLayout, error)
+	}{
+		{
+			name: "empty",
+			assertions: func(t *testing.T, _ *CalVerLayout, err error) {
+				require.ErrorContains(t, err, "layout is empty")
+			},
+		},
+		{
+			name:   "no year",
+			layout: "MAJOR.MINOR",
+			assertions: func(t *testing.T, _ *CalVerLayout, err error) {
+				require.ErrorContains(t, err, "must contain exactly one of the tokens")
+			},
+		},
+		{
+			name:   "multiple years",
+			layout: "YYYY.YY",
+			assertions: func(t *testing.T, _ *CalVerLayout, err error) {
+				require.ErrorContains(t, err, "must contain exactly one of the tokens")
+			},
+		},
+		{
+			name:   "full year, zero-padded month, and micro",
+			layout: "YYYY.0M.MICRO",
+			assertions: func(t *testing.T, layout *CalVerLayout, err error) {
+				require.NoError(t, err)
+				cv, ok := layout.Parse("2024.03.12")
+				require.True(t, ok)
+				require.Equal(t, CalVer{2024, 3, 12}, cv)
+				_, ok = layout.Parse("2024.3.12")
+				require.False(t, ok)
+				_, ok = layout.Parse("2024.13.0")
+				require.False(t, ok)
+				_, ok = layout.Parse("v2024.03.12")
+				require.False(t, ok)
+			},
+		},
+		{
+			name:   "literal text",
+			layout: "vYY.MM.DD-rcMICRO",
+			assertions: func(t *testing.T, layout *CalVerLayout, err error) {
+				require.NoError(t, err)
+				cv, ok := layout.Parse("v24.1.31-rc2")
+				require.True(t, ok)
+				require.Equal(t, CalVer{24, 1, 31, 2}, cv)
+				_, ok = layout.Parse("v24.1.32-rc2")
+				require.False(t, ok)
+				_, ok = layout.Parse("v24x1.31-rc2")
+				require.False(t, ok)
+			},
+		},
+		{
+			name:   "zero-padded year and week",
+			layout: "0Y.0W",
+			assertions: func(t *testing.T, layout *CalVerLayout, err error) {
+				require.NoError(t, err)
+				cv, ok := layout.Parse("06.09")
+				require.True(t, ok)
+				require.Equal(t, CalVer{6, 9}, cv)
+				_, ok = layout.Parse("6.9")
+				require.False(t, ok)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			layout, err := NewCalVerLayout(testCase.layout)
+			testCase.assertions(t, layout, err)
+		})
+	}
+}
+
+func TestCalVerCompare(t *testing.T) {
+	require.Equal(t, 0, CalVer{2024, 3, 1}.Compare(CalVer{2024, 3, 1}))
+	require.Equal(t, -1, CalVer{2024, 3, 1}.Compare(CalVer{2024, 10, 0}))
+	require.Equal(t, 1, CalVer{2025, 1, 0}.Compare(CalVer{2024, 12, 9}))
+	require.Equal(t, 1, CalVer{2024, 3, 10}.Compare(CalVer{2024, 3, 9}))
+}
@@ -0,0 +1,171 @@
+package git
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/vm"
+
+	"github.com/akuity/kargo/internal/expressions"
+)
+
+// CommitInfo is the metadata of a commit (or of the commit referenced by a
+// tag) against which a CommitFilter is evaluated.
+type CommitInfo struct {
+	// ID is the ID (sha) of the commit.
+	ID string
+	// Tag is the name of the tag referencing the commit. It is empty when
+	// commits are selected from a branch's history.
+	Tag string
+	// Author is the author of the commit, in the format "Name <email>".
+	Author string
+	// Committer is the person who committed the commit, in the format
+	// "Name <email>".
+	Committer string
+	// Subject is the subject (first line) of the commit message.
+	Subject string
+	// Body is the commit message without its subject. It is only populated
+	// when the CommitFilter's UsesBody method returns true.
+	Body string
+	// Date is the date of the commit, or the creation date of an annotated tag.
+	Date time.Time
+	// Trailers are the trailers of the commit message, indexed by key.
+	Trailers map[string][]string
+	// Paths are the paths changed by the commit. These are only populated when
+	// the CommitFilter's UsesPaths method returns true.
+	Paths []string
+}
+
+// CommitFilter is a compiled expression that decides whether a commit is of
+// interest based on its metadata.
+type CommitFilter struct {
+	program   *vm.Program
+	usesPaths bool
+	usesBody  bool
+}
+
+// NewCommitFilter compiles the provided expression into a CommitFilter. The
+// expression must evaluate to a boolean and has access to the variables id,
+// tag, author, committer, subject (or message), body, date, trailers, and
+// paths. The same metadata is also available as the fields of the commit
+// variable (e.g. commit.Subject). If the provided expression is empty, nil is
+// returned.
+func NewCommitFilter(expression string) (*CommitFilter, error) {
+	if expression == "" {
+		return nil, nil
+	}
+	detector := &usageDetector{}
+	program, err := expressions.CompileExpression(
+		expression,
+		expr.Env(commitFilterEnv(CommitInfo{})),
+		expr.AsBool(),
+		expr.Patch(detector),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &CommitFilter{
+		program:   program,
+		usesPaths: detector.paths,
+		usesBody:  detector.body,
+	}, nil
+}
+
+// ValidateCommitFilter returns an error if the provided expression cannot be
+// compiled into a CommitFilter.
+func ValidateCommitFilter(expression string) error {
+	_, err := NewCommitFilter(expression)
+	return err
+}
+
+// UsesPaths returns true if the filter references the paths changed by a
+// commit. Since determining those paths is comparatively expensive, callers
+// need only populate CommitInfo.Paths when this returns true.
+func (c *CommitFilter) UsesPaths() bool {
+	return c != nil && c.usesPaths
+}
+
+// UsesBody returns true if the filter references the body of a commit's
+// message. Since obtaining it requires an additional Git command per commit,
+// callers need only populate CommitInfo.Body when this returns true.
+func (c *CommitFilter) UsesBody() bool {
+	return c != nil && c.usesBody
+}
+
+// Matches returns true if the provided commit satisfies the filter. A nil
+// CommitFilter matches all commits.
+func (c *CommitFilter) Matches(info CommitInfo) (bool, error) {
+	if c == nil {
+		return true, nil
+	}
+	res, err := expr.Run(c.program, commitFilterEnv(info))
+	if err != nil {
+		return false, fmt.Errorf("error evaluating commit filter: %w", err)
+	}
+	match, _ := res.(bool)
+	return match, nil
+}
+
+// commitFilterEnv returns the environment in which a commit filter is
+// evaluated for the provided commit.
+func commitFilterEnv(info CommitInfo) map[string]any {
+	trailers := info.Trailers
+	if trailers == nil {
+		trailers = map[string][]string{}
+	}
+	paths := info.Paths
+	if paths == nil {
+		paths = []string{}
+	}
+	info.Trailers = trailers
+	info.Paths = paths
+	return map[string]any{
+		"id":        info.ID,
+		"tag":       info.Tag,
+		"author":    info.Author,
+		"committer": info.Committer,
+		"subject":   info.Subject,
+		"message":   info.Subject,
+		"body":      info.Body,
+		"date":      info.Date,
+		"trailers":  trailers,
+		"paths":     paths,
+		"commit":    info,
+	}
+}
+
+// usageDetector is an ast.Visitor that records whether an expression
+// references the paths changed by a commit or the body of its message, either
+// directly or as a field of the commit variable.
+type usageDetector struct {
+	paths bool
+	body  bool
+}
+
+// Visit implements the ast.Visitor interface.
+func (u *usageDetector) Visit(node *ast.Node) {
+	switch n := (*node).(type) {
+	case *ast.IdentifierNode:
+		switch n.Value {
+		case "paths":
+			u.paths = true
+		case "body":
+			u.body = true
+		}
+	case *ast.MemberNode:
+		ident, ok := n.Node.(*ast.IdentifierNode)
+		if !ok || ident.Value != "commit" {
+			return
+		}
+		if prop, ok := n.Property.(*ast.StringNode); ok {
+			switch prop.Value {
+			case "Paths":
+				u.paths = true
+			case "Body":
+				u.body = true
+			}
+		}
+	}
+}
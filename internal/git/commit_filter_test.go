@@ -0,0 +1,197 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCommitFilter(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		assertions func(*testing.T, *CommitFilter, error)
+	}{
+		{
+			name:       "empty expression",
+			expression: "",
+			assertions: func(t *testing.T, filter *CommitFilter, err error) {
+				require.NoError(t, err)
+				require.Nil(t, filter)
+			},
+		},
+		{
+			name:       "invalid expression",
+			expression: "author ==",
+			assertions: func(t *testing.T, _ *CommitFilter, err error) {
+				require.ErrorContains(t, err, "error compiling expression")
+			},
+		},
+		{
+			name:       "unknown variable",
+			expression: `bogus == "foo"`,
+			assertions: func(t *testing.T, _ *CommitFilter, err error) {
+				require.ErrorContains(t, err, "error compiling expression")
+			},
+		},
+		{
+			name:       "non-boolean expression",
+			expression: "author",
+			assertions: func(t *testing.T, _ *CommitFilter, err error) {
+				require.ErrorContains(t, err, "error compiling expression")
+			},
+		},
+		{
+			name:       "expression not using paths",
+			expression: `author contains "release-bot"`,
+			assertions: func(t *testing.T, filter *CommitFilter, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, filter)
+				require.False(t, filter.UsesPaths())
+			},
+		},
+		{
+			name:       "expression using paths",
+			expression: `any(paths, # startsWith "charts/")`,
+			assertions: func(t *testing.T, filter *CommitFilter, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, filter)
+				require.True(t, filter.UsesPaths())
+				require.False(t, filter.UsesBody())
+			},
+		},
+		{
+			name:       "expression using body",
+			expression: `body contains "BREAKING CHANGE"`,
+			assertions: func(t *testing.T, filter *CommitFilter, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, filter)
+				require.False(t, filter.UsesPaths())
+				require.True(t, filter.UsesBody())
+			},
+		},
+		{
+			name:       "expression using fields of commit",
+			expression: `commit.Body != "" && len(commit["Paths"]) > 0 && commit.Subject != ""`,
+			assertions: func(t *testing.T, filter *CommitFilter, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, filter)
+				require.True(t, filter.UsesPaths())
+				require.True(t, filter.UsesBody())
+			},
+		},
+		{
+			name:       "unknown field of commit",
+			expression: `commit.Bogus == "foo"`,
+			assertions: func(t *testing.T, _ *CommitFilter, err error) {
+				require.ErrorContains(t, err, "error compiling expression")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			filter, err := NewCommitFilter(testCase.expression)
+			testCase.assertions(t, filter, err)
+		})
+	}
+}
+
+func TestCommitFilterMatches(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		info       CommitInfo
+		assertions func(*testing.T, bool, error)
+	}{
+		{
+			name:       "nil filter",
+			expression: "",
+			assertions: func(t *testing.T, match bool, err error) {
+				require.NoError(t, err)
+				require.True(t, match)
+			},
+		},
+		{
+			name:       "tag and author match",
+			expression: `tag matches "^v[0-9]+\\.[0-9]+\\.[0-9]+$" && author contains "release-bot"`,
+			info: CommitInfo{
+				Tag:    "v1.2.3",
+				Author: "Release Bot <release-bot@example.com>",
+			},
+			assertions: func(t *testing.T, match bool, err error) {
+				require.NoError(t, err)
+				require.True(t, match)
+			},
+		},
+		{
+			name:       "tag and author do not match",
+			expression: `tag matches "^v[0-9]+\\.[0-9]+\\.[0-9]+$" && author contains "release-bot"`,
+			info: CommitInfo{
+				Tag:    "v1.2.3-rc.1",
+				Author: "Release Bot <release-bot@example.com>",
+			},
+			assertions: func(t *testing.T, match bool, err error) {
+				require.NoError(t, err)
+				require.False(t, match)
+			},
+		},
+		{
+			name:       "message, trailers, and paths",
+			expression: `!(message startsWith "chore") && "PROJ-1" in trailers["Ticket"] && "charts/foo" in paths`,
+			info: CommitInfo{
+				Subject:  "feat: add foo",
+				Trailers: map[string][]string{"Ticket": {"PROJ-1"}},
+				Paths:    []string{"charts/foo"},
+			},
+			assertions: func(t *testing.T, match bool, err error) {
+				require.NoError(t, err)
+				require.True(t, match)
+			},
+		},
+		{
+			name: "fields of commit",
+			expression: `commit.Author contains "release-bot" && !(commit.Subject startsWith "chore:") && ` +
+				`commit.Body contains "Reviewed" && commit.Date.Year() == 2024`,
+			info: CommitInfo{
+				Author:  "Release Bot <release-bot@example.com>",
+				Subject: "feat: add foo",
+				Body:    "Reviewed-by: Jane Doe <jane@example.com>",
+				Date:    time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+			},
+			assertions: func(t *testing.T, match bool, err error) {
+				require.NoError(t, err)
+				require.True(t, match)
+			},
+		},
+		{
+			name:       "subject and body",
+			expression: `subject == message && body contains "BREAKING CHANGE"`,
+			info: CommitInfo{
+				Subject: "feat: remove foo",
+				Body:    "BREAKING CHANGE: foo is gone",
+			},
+			assertions: func(t *testing.T, match bool, err error) {
+				require.NoError(t, err)
+				require.True(t, match)
+			},
+		},
+		{
+			name:       "missing trailer",
+			expression: `len(trailers["Ticket"]) > 0`,
+			info:       CommitInfo{},
+			assertions: func(t *testing.T, match bool, err error) {
+				require.NoError(t, err)
+				require.False(t, match)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			filter, err := NewCommitFilter(testCase.expression)
+			require.NoError(t, err)
+			match, err := filter.Matches(testCase.info)
+			testCase.assertions(t, match, err)
+		})
+	}
+}
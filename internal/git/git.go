@@ -76,3 +76,27 @@ func NormalizeURL(repo string) string {
 	}
 	return fmt.Sprintf("ssh://%s/%s", userHost, pathURL.String())
 }
+
+// IsInsecureHTTPURL returns a bool indicating whether the provided repository
+// URL uses plain, unencrypted HTTP.
+func IsInsecureHTTPURL(repoURL string) bool {
+	return strings.HasPrefix(strings.ToLower(repoURL), "http://")
+}
+
+// IsInsecureHTTPHost returns a bool indicating whether the host of the
+// provided repository URL is one whose repositories may be accessed over plain
+// HTTP. The hosts are matched with and without their port.
+func IsInsecureHTTPHost(repoURL string, hosts []string) bool {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return false
+	}
+	for _, host := range hosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host != "" &&
+			(host == strings.ToLower(u.Host) || host == strings.ToLower(u.Hostname())) {
+			return true
+		}
+	}
+	return false
+}
@@ -88,3 +88,60 @@ func TestNormalizeURL(t *testing.T) {
 		})
 	}
 }
+
+func TestIsInsecureHTTPHost(t *testing.T) {
+	testCases := []struct {
+		name     string
+		repoURL  string
+		hosts    []string
+		expected bool
+	}{
+		{
+			name:    "no hosts",
+			repoURL: "http://git.example.com/repo.git",
+		},
+		{
+			name:    "host does not match",
+			repoURL: "http://git.example.com/repo.git",
+			hosts:   []string{"git.example.org"},
+		},
+		{
+			name:     "host matches",
+			repoURL:  "http://git.example.com/repo.git",
+			hosts:    []string{"git.example.com"},
+			expected: true,
+		},
+		{
+			name:     "host matches case-insensitively",
+			repoURL:  "http://Git.Example.com/repo.git",
+			hosts:    []string{"git.example.COM"},
+			expected: true,
+		},
+		{
+			name:     "host matches without port",
+			repoURL:  "http://git.example.com:8080/repo.git",
+			hosts:    []string{"git.example.com"},
+			expected: true,
+		},
+		{
+			name:     "host matches with port",
+			repoURL:  "http://git.example.com:8080/repo.git",
+			hosts:    []string{"git.example.com:8080"},
+			expected: true,
+		},
+		{
+			name:    "port does not match",
+			repoURL: "http://git.example.com:8080/repo.git",
+			hosts:   []string{"git.example.com:9090"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				IsInsecureHTTPHost(testCase.repoURL, testCase.hosts),
+			)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RepoBrowser is implemented by git provider services that can list the
+// branches, commits, and tags of a single repository through the provider's
+// API, i.e. without the repository having to be cloned.
+type RepoBrowser interface {
+	// ListBranches lists the names of all branches of the repository.
+	ListBranches(ctx context.Context, repoURL string) ([]string, error)
+
+	// GetDefaultBranch gets the name of the repository's default branch.
+	GetDefaultBranch(ctx context.Context, repoURL string) (string, error)
+
+	// ListCommits lists one page of the history of the specified branch,
+	// newest first. Pages are numbered from 1. An empty branch refers to the
+	// repository's default branch.
+	ListCommits(
+		ctx context.Context,
+		repoURL string,
+		branch string,
+		page int,
+		perPage int,
+	) ([]Commit, error)
+
+	// GetCommit gets the commit with the specified ID, including the paths it
+	// changed.
+	GetCommit(ctx context.Context, repoURL string, id string) (*Commit, error)
+
+	// ListTags lists all tags of the repository along with the IDs of the
+	// commits they point to. Other details of those commits are not included.
+	ListTags(ctx context.Context, repoURL string) ([]Tag, error)
+}
+
+// Commit describes a commit as reported by a git provider's API.
+type Commit struct {
+	// ID is the ID (sha) of the commit.
+	ID string
+	// Message is the full commit message.
+	Message string
+	// Author is the author of the commit, in the format "Name <email>".
+	Author string
+	// Committer is the person who committed the commit, in the format
+	// "Name <email>".
+	Committer string
+	// CommitDate is the date of the commit.
+	CommitDate time.Time
+	// ParentCount is the number of parents of the commit.
+	ParentCount int
+	// Paths are the paths changed by the commit. They are only populated by
+	// RepoBrowser.GetCommit.
+	Paths []string
+}
+
+// Tag describes a tag as reported by a git provider's API.
+type Tag struct {
+	// Name is the name of the tag.
+	Name string
+	// CommitID is the ID (sha) of the commit the tag points to.
+	CommitID string
+}
+
+// NewRepoBrowser returns a RepoBrowser for the specified repository,
+// authenticated using the provided token, if any. An error is returned if the
+// repository's git provider cannot be inferred from its URL or if the provider
+// does not support browsing repositories.
+func NewRepoBrowser(repoURL string, token string) (RepoBrowser, error) {
+	svc, err := NewGitProviderServiceFromURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		if svc, err = svc.WithAuthToken(token); err != nil {
+			return nil, err
+		}
+	}
+	browser, ok := svc.(RepoBrowser)
+	if !ok {
+		return nil, fmt.Errorf(
+			"the git provider of repository %q does not support listing its contents",
+			repoURL,
+		)
+	}
+	return browser, nil
+}
+
+// FormatSignature formats the provided name and email address the way Git
+// does when identifying the author or committer of a commit.
+func FormatSignature(name, email string) string {
+	return fmt.Sprintf("%s <%s>", name, email)
+}
@@ -0,0 +1,43 @@
+package gitprovider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/akuity/kargo/internal/credentials"
+)
+
+// NewGitProviderServiceFn returns a function that closes over the provided
+// credentials database and, when invoked, returns a git provider service for
+// the specified repository, authenticated using the repository's credentials
+// from the specified namespace, if any are found.
+func NewGitProviderServiceFn(
+	credentialsDB credentials.Database,
+) func(
+	ctx context.Context,
+	namespace string,
+	repoURL string,
+) (GitProviderService, error) {
+	return func(
+		ctx context.Context,
+		namespace string,
+		repoURL string,
+	) (GitProviderService, error) {
+		gpClient, err := NewGitProviderServiceFromURL(repoURL)
+		if err != nil {
+			return nil, err
+		}
+		creds, ok, err := credentialsDB.Get(ctx, namespace, credentials.TypeGit, repoURL)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error obtaining credentials for git repo %q: %w",
+				repoURL,
+				err,
+			)
+		}
+		if !ok {
+			return gpClient, nil
+		}
+		return gpClient.WithAuthToken(creds.Password)
+	}
+}
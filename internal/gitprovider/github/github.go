@@ -167,3 +167,271 @@ func (g *GitHubProvider) IsPullRequestMerged(ctx context.Context, repoURL string
 	}
 	return merged, nil
 }
+
+func (g *GitHubProvider) ListPullRequestsWithCommit(
+	ctx context.Context,
+	repoURL string,
+	sha string,
+) ([]*gitprovider.PullRequest, error) {
+	owner, repo, err := parseGitHubURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	ghPRs, _, err := g.client.PullRequests.ListPullRequestsWithCommit(ctx, owner, repo, sha, nil)
+	if err != nil {
+		return nil, err
+	}
+	prs := make([]*gitprovider.PullRequest, len(ghPRs))
+	for i, ghPR := range ghPRs {
+		prs[i] = convertGithubPR(ghPR)
+	}
+	return prs, nil
+}
+
+func (g *GitHubProvider) CreatePullRequestComment(
+	ctx context.Context,
+	repoURL string,
+	id int64,
+	body string,
+) error {
+	owner, repo, err := parseGitHubURL(repoURL)
+	if err != nil {
+		return err
+	}
+	// Pull request comments are issue comments as far as the GitHub API is
+	// concerned.
+	_, _, err = g.client.Issues.CreateComment(
+		ctx,
+		owner,
+		repo,
+		int(id),
+		&github.IssueComment{Body: &body},
+	)
+	return err
+}
+
+func (g *GitHubProvider) CreateDeploymentStatus(
+	ctx context.Context,
+	repoURL string,
+	opts gitprovider.CreateDeploymentStatusOpts,
+) error {
+	owner, repo, err := parseGitHubURL(repoURL)
+	if err != nil {
+		return err
+	}
+	// https://docs.github.com/en/rest/deployments/deployments?apiVersion=2022-11-28#create-a-deployment
+	deployment, _, err := g.client.Repositories.CreateDeployment(
+		ctx,
+		owner,
+		repo,
+		&github.DeploymentRequest{
+			Ref:         &opts.SHA,
+			Environment: &opts.Environment,
+			Description: &opts.Description,
+			AutoMerge:   github.Bool(false),
+			// The commit has already been deployed, so there is no sense in
+			// requiring any status checks to have passed.
+			RequiredContexts: &[]string{},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	state := "success"
+	if opts.State == gitprovider.DeploymentStateFailure {
+		state = "failure"
+	}
+	_, _, err = g.client.Repositories.CreateDeploymentStatus(
+		ctx,
+		owner,
+		repo,
+		deployment.GetID(),
+		&github.DeploymentStatusRequest{
+			State:       &state,
+			Description: &opts.Description,
+			Environment: &opts.Environment,
+		},
+	)
+	return err
+}
+
+func (g *GitHubProvider) CreateCommitStatus(
+	ctx context.Context,
+	repoURL string,
+	opts gitprovider.CreateCommitStatusOpts,
+) error {
+	owner, repo, err := parseGitHubURL(repoURL)
+	if err != nil {
+		return err
+	}
+	state := "success"
+	switch opts.State {
+	case gitprovider.CommitStatePending:
+		state = "pending"
+	case gitprovider.CommitStateFailure:
+		state = "failure"
+	}
+	// https://docs.github.com/en/rest/commits/statuses?apiVersion=2022-11-28#create-a-commit-status
+	_, _, err = g.client.Repositories.CreateStatus(
+		ctx,
+		owner,
+		repo,
+		opts.SHA,
+		&github.RepoStatus{
+			State:       &state,
+			Context:     &opts.Context,
+			Description: &opts.Description,
+		},
+	)
+	return err
+}
+
+// githubMaxPerPage is the maximum page size supported by the GitHub API.
+const githubMaxPerPage = 100
+
+func (g *GitHubProvider) ListBranches(
+	ctx context.Context,
+	repoURL string,
+) ([]string, error) {
+	owner, repo, err := parseGitHubURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	// https://docs.github.com/en/rest/branches/branches?apiVersion=2022-11-28#list-branches
+	opts := &github.BranchListOptions{
+		ListOptions: github.ListOptions{PerPage: githubMaxPerPage},
+	}
+	var branches []string
+	for {
+		ghBranches, res, err := g.client.Repositories.ListBranches(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, ghBranch := range ghBranches {
+			branches = append(branches, ghBranch.GetName())
+		}
+		if res == nil || res.NextPage == 0 {
+			return branches, nil
+		}
+		opts.Page = res.NextPage
+	}
+}
+
+func (g *GitHubProvider) GetDefaultBranch(
+	ctx context.Context,
+	repoURL string,
+) (string, error) {
+	owner, repo, err := parseGitHubURL(repoURL)
+	if err != nil {
+		return "", err
+	}
+	// https://docs.github.com/en/rest/repos/repos?apiVersion=2022-11-28#get-a-repository
+	ghRepo, _, err := g.client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return "", err
+	}
+	return ghRepo.GetDefaultBranch(), nil
+}
+
+func (g *GitHubProvider) ListCommits(
+	ctx context.Context,
+	repoURL string,
+	branch string,
+	page int,
+	perPage int,
+) ([]gitprovider.Commit, error) {
+	owner, repo, err := parseGitHubURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	// https://docs.github.com/en/rest/commits/commits?apiVersion=2022-11-28#list-commits
+	ghCommits, _, err := g.client.Repositories.ListCommits(
+		ctx,
+		owner,
+		repo,
+		&github.CommitsListOptions{
+			SHA:         branch,
+			ListOptions: github.ListOptions{Page: page, PerPage: perPage},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	commits := make([]gitprovider.Commit, len(ghCommits))
+	for i, ghCommit := range ghCommits {
+		commits[i] = convertGithubCommit(ghCommit)
+	}
+	return commits, nil
+}
+
+func (g *GitHubProvider) GetCommit(
+	ctx context.Context,
+	repoURL string,
+	id string,
+) (*gitprovider.Commit, error) {
+	owner, repo, err := parseGitHubURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	// https://docs.github.com/en/rest/commits/commits?apiVersion=2022-11-28#get-a-commit
+	ghCommit, _, err := g.client.Repositories.GetCommit(ctx, owner, repo, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	commit := convertGithubCommit(ghCommit)
+	for _, file := range ghCommit.Files {
+		commit.Paths = append(commit.Paths, file.GetFilename())
+		// Renames change the old path as well.
+		if prev := file.GetPreviousFilename(); prev != "" {
+			commit.Paths = append(commit.Paths, prev)
+		}
+	}
+	return &commit, nil
+}
+
+func (g *GitHubProvider) ListTags(
+	ctx context.Context,
+	repoURL string,
+) ([]gitprovider.Tag, error) {
+	owner, repo, err := parseGitHubURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	// https://docs.github.com/en/rest/repos/repos?apiVersion=2022-11-28#list-repository-tags
+	opts := &github.ListOptions{PerPage: githubMaxPerPage}
+	var tags []gitprovider.Tag
+	for {
+		ghTags, res, err := g.client.Repositories.ListTags(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, ghTag := range ghTags {
+			tags = append(tags, gitprovider.Tag{
+				Name:     ghTag.GetName(),
+				CommitID: ghTag.GetCommit().GetSHA(),
+			})
+		}
+		if res == nil || res.NextPage == 0 {
+			return tags, nil
+		}
+		opts.Page = res.NextPage
+	}
+}
+
+func convertGithubCommit(ghCommit *github.RepositoryCommit) gitprovider.Commit {
+	commit := ghCommit.GetCommit()
+	return gitprovider.Commit{
+		ID:      ghCommit.GetSHA(),
+		Message: commit.GetMessage(),
+		Author: gitprovider.FormatSignature(
+			commit.GetAuthor().GetName(),
+			commit.GetAuthor().GetEmail(),
+		),
+		Committer: gitprovider.FormatSignature(
+			commit.GetCommitter().GetName(),
+			commit.GetCommitter().GetEmail(),
+		),
+		CommitDate:  commit.GetCommitter().GetDate().Time,
+		ParentCount: len(ghCommit.Parents),
+	}
+}
@@ -58,8 +58,101 @@ type MergeRequestClient interface {
 	) (*gitlab.MergeRequest, *gitlab.Response, error)
 }
 
+type CommitClient interface {
+	ListMergeRequestsByCommit(
+		pid any,
+		sha string,
+		options ...gitlab.RequestOptionFunc,
+	) ([]*gitlab.MergeRequest, *gitlab.Response, error)
+
+	SetCommitStatus(
+		pid any,
+		sha string,
+		opt *gitlab.SetCommitStatusOptions,
+		options ...gitlab.RequestOptionFunc,
+	) (*gitlab.CommitStatus, *gitlab.Response, error)
+
+	ListCommits(
+		pid any,
+		opt *gitlab.ListCommitsOptions,
+		options ...gitlab.RequestOptionFunc,
+	) ([]*gitlab.Commit, *gitlab.Response, error)
+
+	GetCommit(
+		pid any,
+		sha string,
+		options ...gitlab.RequestOptionFunc,
+	) (*gitlab.Commit, *gitlab.Response, error)
+
+	GetCommitDiff(
+		pid any,
+		sha string,
+		opt *gitlab.GetCommitDiffOptions,
+		options ...gitlab.RequestOptionFunc,
+	) ([]*gitlab.Diff, *gitlab.Response, error)
+}
+
+type BranchClient interface {
+	ListBranches(
+		pid any,
+		opts *gitlab.ListBranchesOptions,
+		options ...gitlab.RequestOptionFunc,
+	) ([]*gitlab.Branch, *gitlab.Response, error)
+}
+
+type ProjectClient interface {
+	GetProject(
+		pid any,
+		opt *gitlab.GetProjectOptions,
+		options ...gitlab.RequestOptionFunc,
+	) (*gitlab.Project, *gitlab.Response, error)
+}
+
+type TagClient interface {
+	ListTags(
+		pid any,
+		opt *gitlab.ListTagsOptions,
+		options ...gitlab.RequestOptionFunc,
+	) ([]*gitlab.Tag, *gitlab.Response, error)
+}
+
+type NoteClient interface {
+	CreateMergeRequestNote(
+		pid any,
+		mergeRequest int,
+		opt *gitlab.CreateMergeRequestNoteOptions,
+		options ...gitlab.RequestOptionFunc,
+	) (*gitlab.Note, *gitlab.Response, error)
+}
+
+type DeploymentClient interface {
+	CreateProjectDeployment(
+		pid any,
+		opt *gitlab.CreateProjectDeploymentOptions,
+		options ...gitlab.RequestOptionFunc,
+	) (*gitlab.Deployment, *gitlab.Response, error)
+}
+
 type GitLabClient struct { // nolint: revive
 	MergeRequests MergeRequestClient
+	Commits       CommitClient
+	Notes         NoteClient
+	Deployments   DeploymentClient
+	Branches      BranchClient
+	Tags          TagClient
+	Projects      ProjectClient
+}
+
+func newGitLabClient(client *gitlab.Client) *GitLabClient {
+	return &GitLabClient{
+		MergeRequests: client.MergeRequests,
+		Commits:       client.Commits,
+		Notes:         client.Notes,
+		Deployments:   client.Deployments,
+		Branches:      client.Branches,
+		Tags:          client.Tags,
+		Projects:      client.Projects,
+	}
 }
 
 type GitLabProvider struct { // nolint: revive
@@ -72,7 +165,7 @@ func NewGitLabProvider() (gitprovider.GitProviderService, error) {
 		return nil, err
 	}
 	return &GitLabProvider{
-		client: &GitLabClient{MergeRequests: client.MergeRequests},
+		client: newGitLabClient(client),
 	}, nil
 }
 
@@ -81,7 +174,7 @@ func (g *GitLabProvider) WithAuthToken(token string) (gitprovider.GitProviderSer
 	if err != nil {
 		return nil, err
 	}
-	g.client = &GitLabClient{MergeRequests: client.MergeRequests}
+	g.client = newGitLabClient(client)
 	return g, nil
 }
 
@@ -154,6 +247,266 @@ func (g *GitLabProvider) IsPullRequestMerged(_ context.Context, repoURL string,
 	return glMR.State == "merged", nil
 }
 
+func (g *GitLabProvider) ListPullRequestsWithCommit(
+	_ context.Context,
+	repoURL string,
+	sha string,
+) ([]*gitprovider.PullRequest, error) {
+	projectName, err := getProjectNameFromUrl(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	glMRs, _, err := g.client.Commits.ListMergeRequestsByCommit(projectName, sha)
+	if err != nil {
+		return nil, err
+	}
+	prs := make([]*gitprovider.PullRequest, len(glMRs))
+	for i, glMR := range glMRs {
+		prs[i] = convertGitlabMR(glMR)
+	}
+	return prs, nil
+}
+
+func (g *GitLabProvider) CreatePullRequestComment(
+	_ context.Context,
+	repoURL string,
+	id int64,
+	body string,
+) error {
+	projectName, err := getProjectNameFromUrl(repoURL)
+	if err != nil {
+		return err
+	}
+	_, _, err = g.client.Notes.CreateMergeRequestNote(
+		projectName,
+		int(id),
+		&gitlab.CreateMergeRequestNoteOptions{Body: &body},
+	)
+	return err
+}
+
+func (g *GitLabProvider) CreateDeploymentStatus(
+	_ context.Context,
+	repoURL string,
+	opts gitprovider.CreateDeploymentStatusOpts,
+) error {
+	projectName, err := getProjectNameFromUrl(repoURL)
+	if err != nil {
+		return err
+	}
+	status := gitlab.DeploymentStatusSuccess
+	if opts.State == gitprovider.DeploymentStateFailure {
+		status = gitlab.DeploymentStatusFailed
+	}
+	// GitLab requires a ref. Fall back to the commit ID if the branch or tag
+	// the commit was found on is unknown.
+	ref := opts.Ref
+	if ref == "" {
+		ref = opts.SHA
+	}
+	// https://docs.gitlab.com/ee/api/deployments.html#create-a-deployment
+	_, _, err = g.client.Deployments.CreateProjectDeployment(
+		projectName,
+		&gitlab.CreateProjectDeploymentOptions{
+			Environment: &opts.Environment,
+			Ref:         &ref,
+			SHA:         &opts.SHA,
+			Tag:         &opts.Tag,
+			Status:      &status,
+		},
+	)
+	return err
+}
+
+func (g *GitLabProvider) CreateCommitStatus(
+	_ context.Context,
+	repoURL string,
+	opts gitprovider.CreateCommitStatusOpts,
+) error {
+	projectName, err := getProjectNameFromUrl(repoURL)
+	if err != nil {
+		return err
+	}
+	state := gitlab.Success
+	switch opts.State {
+	case gitprovider.CommitStatePending:
+		state = gitlab.Running
+	case gitprovider.CommitStateFailure:
+		state = gitlab.Failed
+	}
+	// https://docs.gitlab.com/ee/api/commits.html#set-the-pipeline-status-of-a-commit
+	_, _, err = g.client.Commits.SetCommitStatus(
+		projectName,
+		opts.SHA,
+		&gitlab.SetCommitStatusOptions{
+			State:       state,
+			Name:        &opts.Context,
+			Description: &opts.Description,
+		},
+	)
+	return err
+}
+
+// gitlabMaxPerPage is the maximum page size supported by the GitLab API.
+const gitlabMaxPerPage = 100
+
+func (g *GitLabProvider) ListBranches(
+	_ context.Context,
+	repoURL string,
+) ([]string, error) {
+	projectName, err := getProjectNameFromUrl(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	// https://docs.gitlab.com/ee/api/branches.html#list-repository-branches
+	opts := &gitlab.ListBranchesOptions{
+		ListOptions: gitlab.ListOptions{PerPage: gitlabMaxPerPage},
+	}
+	var branches []string
+	for {
+		glBranches, res, err := g.client.Branches.ListBranches(projectName, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, glBranch := range glBranches {
+			branches = append(branches, glBranch.Name)
+		}
+		if res == nil || res.NextPage == 0 {
+			return branches, nil
+		}
+		opts.Page = res.NextPage
+	}
+}
+
+func (g *GitLabProvider) GetDefaultBranch(
+	_ context.Context,
+	repoURL string,
+) (string, error) {
+	projectName, err := getProjectNameFromUrl(repoURL)
+	if err != nil {
+		return "", err
+	}
+	// https://docs.gitlab.com/ee/api/projects.html#get-single-project
+	project, _, err := g.client.Projects.GetProject(projectName, nil)
+	if err != nil {
+		return "", err
+	}
+	return project.DefaultBranch, nil
+}
+
+func (g *GitLabProvider) ListCommits(
+	_ context.Context,
+	repoURL string,
+	branch string,
+	page int,
+	perPage int,
+) ([]gitprovider.Commit, error) {
+	projectName, err := getProjectNameFromUrl(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	opts := &gitlab.ListCommitsOptions{
+		ListOptions: gitlab.ListOptions{Page: page, PerPage: perPage},
+	}
+	if branch != "" {
+		opts.RefName = &branch
+	}
+	// https://docs.gitlab.com/ee/api/commits.html#list-repository-commits
+	glCommits, _, err := g.client.Commits.ListCommits(projectName, opts)
+	if err != nil {
+		return nil, err
+	}
+	commits := make([]gitprovider.Commit, len(glCommits))
+	for i, glCommit := range glCommits {
+		commits[i] = convertGitlabCommit(glCommit)
+	}
+	return commits, nil
+}
+
+func (g *GitLabProvider) GetCommit(
+	_ context.Context,
+	repoURL string,
+	id string,
+) (*gitprovider.Commit, error) {
+	projectName, err := getProjectNameFromUrl(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	// https://docs.gitlab.com/ee/api/commits.html#get-a-single-commit
+	glCommit, _, err := g.client.Commits.GetCommit(projectName, id)
+	if err != nil {
+		return nil, err
+	}
+	commit := convertGitlabCommit(glCommit)
+	// https://docs.gitlab.com/ee/api/commits.html#get-the-diff-of-a-commit
+	opts := &gitlab.GetCommitDiffOptions{
+		ListOptions: gitlab.ListOptions{PerPage: gitlabMaxPerPage},
+	}
+	for {
+		diffs, res, err := g.client.Commits.GetCommitDiff(projectName, id, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, diff := range diffs {
+			commit.Paths = append(commit.Paths, diff.NewPath)
+			// Renames change the old path as well.
+			if diff.OldPath != "" && diff.OldPath != diff.NewPath {
+				commit.Paths = append(commit.Paths, diff.OldPath)
+			}
+		}
+		if res == nil || res.NextPage == 0 {
+			return &commit, nil
+		}
+		opts.Page = res.NextPage
+	}
+}
+
+func (g *GitLabProvider) ListTags(
+	_ context.Context,
+	repoURL string,
+) ([]gitprovider.Tag, error) {
+	projectName, err := getProjectNameFromUrl(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	// https://docs.gitlab.com/ee/api/tags.html#list-project-repository-tags
+	opts := &gitlab.ListTagsOptions{
+		ListOptions: gitlab.ListOptions{PerPage: gitlabMaxPerPage},
+	}
+	var tags []gitprovider.Tag
+	for {
+		glTags, res, err := g.client.Tags.ListTags(projectName, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, glTag := range glTags {
+			tag := gitprovider.Tag{Name: glTag.Name}
+			if glTag.Commit != nil {
+				tag.CommitID = glTag.Commit.ID
+			}
+			tags = append(tags, tag)
+		}
+		if res == nil || res.NextPage == 0 {
+			return tags, nil
+		}
+		opts.Page = res.NextPage
+	}
+}
+
+func convertGitlabCommit(glCommit *gitlab.Commit) gitprovider.Commit {
+	commit := gitprovider.Commit{
+		ID:          glCommit.ID,
+		Message:     glCommit.Message,
+		Author:      gitprovider.FormatSignature(glCommit.AuthorName, glCommit.AuthorEmail),
+		Committer:   gitprovider.FormatSignature(glCommit.CommitterName, glCommit.CommitterEmail),
+		ParentCount: len(glCommit.ParentIDs),
+	}
+	if glCommit.CommittedDate != nil {
+		commit.CommitDate = *glCommit.CommittedDate
+	}
+	return commit
+}
+
 func convertGitlabMR(glMR *gitlab.MergeRequest) *gitprovider.PullRequest {
 	var prState gitprovider.PullRequestState
 	if isMROpen(glMR) {
@@ -3,6 +3,7 @@ package gitlab
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/xanzy/go-gitlab"
@@ -11,10 +12,21 @@ import (
 )
 
 type MockGitLabClient struct {
-	mr         *gitlab.MergeRequest
-	createOpts *gitlab.CreateMergeRequestOptions
-	listOpts   *gitlab.ListProjectMergeRequestsOptions
-	pid        any
+	mr             *gitlab.MergeRequest
+	createOpts     *gitlab.CreateMergeRequestOptions
+	listOpts       *gitlab.ListProjectMergeRequestsOptions
+	noteOpts       *gitlab.CreateMergeRequestNoteOptions
+	deploymentOpts *gitlab.CreateProjectDeploymentOptions
+	statusOpts     *gitlab.SetCommitStatusOptions
+	commits        []*gitlab.Commit
+	diffs          []*gitlab.Diff
+	branches       []*gitlab.Branch
+	tags           []*gitlab.Tag
+	project        *gitlab.Project
+	commitsOpts    *gitlab.ListCommitsOptions
+	pid            any
+	sha            string
+	mrIID          int
 }
 
 func (m *MockGitLabClient) CreateMergeRequest(
@@ -47,6 +59,108 @@ func (m *MockGitLabClient) GetMergeRequest(
 	return m.mr, nil, nil
 }
 
+func (m *MockGitLabClient) ListMergeRequestsByCommit(
+	pid any,
+	sha string,
+	_ ...gitlab.RequestOptionFunc,
+) ([]*gitlab.MergeRequest, *gitlab.Response, error) {
+	m.pid = pid
+	m.sha = sha
+	return []*gitlab.MergeRequest{m.mr}, nil, nil
+}
+
+func (m *MockGitLabClient) SetCommitStatus(
+	pid any,
+	sha string,
+	opt *gitlab.SetCommitStatusOptions,
+	_ ...gitlab.RequestOptionFunc,
+) (*gitlab.CommitStatus, *gitlab.Response, error) {
+	m.pid = pid
+	m.sha = sha
+	m.statusOpts = opt
+	return &gitlab.CommitStatus{}, nil, nil
+}
+
+func (m *MockGitLabClient) ListCommits(
+	pid any,
+	opt *gitlab.ListCommitsOptions,
+	_ ...gitlab.RequestOptionFunc,
+) ([]*gitlab.Commit, *gitlab.Response, error) {
+	m.pid = pid
+	m.commitsOpts = opt
+	return m.commits, nil, nil
+}
+
+func (m *MockGitLabClient) GetCommit(
+	pid any,
+	sha string,
+	_ ...gitlab.RequestOptionFunc,
+) (*gitlab.Commit, *gitlab.Response, error) {
+	m.pid = pid
+	m.sha = sha
+	return m.commits[0], nil, nil
+}
+
+func (m *MockGitLabClient) GetCommitDiff(
+	pid any,
+	sha string,
+	_ *gitlab.GetCommitDiffOptions,
+	_ ...gitlab.RequestOptionFunc,
+) ([]*gitlab.Diff, *gitlab.Response, error) {
+	m.pid = pid
+	m.sha = sha
+	return m.diffs, nil, nil
+}
+
+func (m *MockGitLabClient) ListBranches(
+	pid any,
+	_ *gitlab.ListBranchesOptions,
+	_ ...gitlab.RequestOptionFunc,
+) ([]*gitlab.Branch, *gitlab.Response, error) {
+	m.pid = pid
+	return m.branches, nil, nil
+}
+
+func (m *MockGitLabClient) ListTags(
+	pid any,
+	_ *gitlab.ListTagsOptions,
+	_ ...gitlab.RequestOptionFunc,
+) ([]*gitlab.Tag, *gitlab.Response, error) {
+	m.pid = pid
+	return m.tags, nil, nil
+}
+
+func (m *MockGitLabClient) GetProject(
+	pid any,
+	_ *gitlab.GetProjectOptions,
+	_ ...gitlab.RequestOptionFunc,
+) (*gitlab.Project, *gitlab.Response, error) {
+	m.pid = pid
+	return m.project, nil, nil
+}
+
+func (m *MockGitLabClient) CreateMergeRequestNote(
+	pid any,
+	mergeRequest int,
+	opt *gitlab.CreateMergeRequestNoteOptions,
+	_ ...gitlab.RequestOptionFunc,
+) (*gitlab.Note, *gitlab.Response, error) {
+	m.pid = pid
+	m.mrIID = mergeRequest
+	m.noteOpts = opt
+	return &gitlab.Note{}, nil, nil
+}
+
+func (m *MockGitLabClient) CreateProjectDeployment(
+	pid any,
+	opt *gitlab.CreateProjectDeploymentOptions,
+	_ ...gitlab.RequestOptionFunc,
+) (*gitlab.Deployment, *gitlab.Response, error) {
+	m.pid = pid
+	m.deploymentOpts = opt
+	return &gitlab.Deployment{}, nil, nil
+}
+
 func TestCreatePullRequest(t *testing.T) {
 	mockClient := &MockGitLabClient{
 		mr: &gitlab.MergeRequest{
@@ -148,3 +262,262 @@ func isPullRequestMerged(state string) bool {
 	res, _ := g.IsPullRequestMerged(context.Background(), "https://gitlab.com/group/project.git", 1)
 	return res
 }
+
+func TestListPullRequestsWithCommit(t *testing.T) {
+	mockClient := &MockGitLabClient{
+		mr: &gitlab.MergeRequest{
+			IID:            1,
+			MergeCommitSHA: "sha",
+			State:          "merged",
+			WebURL:         "url",
+		},
+	}
+	g := GitLabProvider{client: &GitLabClient{Commits: mockClient}}
+
+	prs, err := g.ListPullRequestsWithCommit(
+		context.Background(),
+		"https://gitlab.com/group/project.git",
+		"fake-sha",
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, "group/project", mockClient.pid)
+	require.Equal(t, "fake-sha", mockClient.sha)
+	require.Len(t, prs, 1)
+	require.Equal(t, int64(mockClient.mr.IID), prs[0].Number)
+	require.Equal(t, mockClient.mr.WebURL, prs[0].URL)
+}
+
+func TestCreatePullRequestComment(t *testing.T) {
+	mockClient := &MockGitLabClient{}
+	g := GitLabProvider{client: &GitLabClient{Notes: mockClient}}
+
+	err := g.CreatePullRequestComment(
+		context.Background(),
+		"https://gitlab.com/group/project.git",
+		1,
+		"comment",
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, "group/project", mockClient.pid)
+	require.Equal(t, 1, mockClient.mrIID)
+	require.Equal(t, "comment", *mockClient.noteOpts.Body)
+}
+
+func TestCreateDeploymentStatus(t *testing.T) {
+	testCases := []struct {
+		name           string
+		opts           gitprovider.CreateDeploymentStatusOpts
+		expectedRef    string
+		expectedStatus gitlab.DeploymentStatusValue
+	}{
+		{
+			name: "successful deployment of a branch",
+			opts: gitprovider.CreateDeploymentStatusOpts{
+				Environment: "test",
+				SHA:         "fake-sha",
+				Ref:         "main",
+				State:       gitprovider.DeploymentStateSuccess,
+			},
+			expectedRef:    "main",
+			expectedStatus: gitlab.DeploymentStatusSuccess,
+		},
+		{
+			name: "failed deployment of unknown ref",
+			opts: gitprovider.CreateDeploymentStatusOpts{
+				Environment: "test",
+				SHA:         "fake-sha",
+				State:       gitprovider.DeploymentStateFailure,
+			},
+			expectedRef:    "fake-sha",
+			expectedStatus: gitlab.DeploymentStatusFailed,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockClient := &MockGitLabClient{}
+			g := GitLabProvider{client: &GitLabClient{Deployments: mockClient}}
+
+			err := g.CreateDeploymentStatus(
+				context.Background(),
+				"https://gitlab.com/group/project.git",
+				testCase.opts,
+			)
+
+			require.NoError(t, err)
+			require.Equal(t, "group/project", mockClient.pid)
+			require.Equal(t, testCase.opts.Environment, *mockClient.deploymentOpts.Environment)
+			require.Equal(t, testCase.opts.SHA, *mockClient.deploymentOpts.SHA)
+			require.Equal(t, testCase.expectedRef, *mockClient.deploymentOpts.Ref)
+			require.Equal(t, testCase.expectedStatus, *mockClient.deploymentOpts.Status)
+		})
+	}
+}
+
+func TestCreateCommitStatus(t *testing.T) {
+	testCases := []struct {
+		name          string
+		state         gitprovider.CommitState
+		expectedState gitlab.BuildStateValue
+	}{
+		{
+			name:          "pending",
+			state:         gitprovider.CommitStatePending,
+			expectedState: gitlab.Running,
+		},
+		{
+			name:          "success",
+			state:         gitprovider.CommitStateSuccess,
+			expectedState: gitlab.Success,
+		},
+		{
+			name:          "failure",
+			state:         gitprovider.CommitStateFailure,
+			expectedState: gitlab.Failed,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockClient := &MockGitLabClient{}
+			g := GitLabProvider{client: &GitLabClient{Commits: mockClient}}
+
+			err := g.CreateCommitStatus(
+				context.Background(),
+				"https://gitlab.com/group/project.git",
+				gitprovider.CreateCommitStatusOpts{
+					SHA:         "fake-sha",
+					Context:     "kargo/test",
+					State:       testCase.state,
+					Description: "fake description",
+				},
+			)
+
+			require.NoError(t, err)
+			require.Equal(t, "group/project", mockClient.pid)
+			require.Equal(t, "fake-sha", mockClient.sha)
+			require.Equal(t, testCase.expectedState, mockClient.statusOpts.State)
+			require.Equal(t, "kargo/test", *mockClient.statusOpts.Name)
+			require.Equal(t, "fake description", *mockClient.statusOpts.Description)
+		})
+	}
+}
+
+func TestListBranches(t *testing.T) {
+	mockClient := &MockGitLabClient{
+		branches: []*gitlab.Branch{{Name: "main"}, {Name: "dev"}},
+	}
+	g := GitLabProvider{client: &GitLabClient{Branches: mockClient}}
+
+	branches, err := g.ListBranches(
+		context.Background(),
+		"https://gitlab.com/group/project.git",
+	)
+	require.NoError(t, err)
+	require.Equal(t, "group/project", mockClient.pid)
+	require.Equal(t, []string{"main", "dev"}, branches)
+}
+
+func TestGetDefaultBranch(t *testing.T) {
+	mockClient := &MockGitLabClient{
+		project: &gitlab.Project{DefaultBranch: "trunk"},
+	}
+	g := GitLabProvider{client: &GitLabClient{Projects: mockClient}}
+
+	branch, err := g.GetDefaultBranch(
+		context.Background(),
+		"https://gitlab.com/group/project.git",
+	)
+	require.NoError(t, err)
+	require.Equal(t, "group/project", mockClient.pid)
+	require.Equal(t, "trunk", branch)
+}
+
+func TestListCommits(t *testing.T) {
+	committedDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockClient := &MockGitLabClient{
+		commits: []*gitlab.Commit{{
+			ID:             "sha",
+			Message:        "message",
+			AuthorName:     "author",
+			AuthorEmail:    "author@example.com",
+			CommitterName:  "committer",
+			CommitterEmail: "committer@example.com",
+			CommittedDate:  &committedDate,
+			ParentIDs:      []string{"parent1", "parent2"},
+		}},
+	}
+	g := GitLabProvider{client: &GitLabClient{Commits: mockClient}}
+
+	commits, err := g.ListCommits(
+		context.Background(),
+		"https://gitlab.com/group/project.git",
+		"main",
+		2,
+		10,
+	)
+	require.NoError(t, err)
+	require.Equal(t, "group/project", mockClient.pid)
+	require.Equal(t, "main", *mockClient.commitsOpts.RefName)
+	require.Equal(t, 2, mockClient.commitsOpts.Page)
+	require.Equal(t, 10, mockClient.commitsOpts.PerPage)
+	require.Equal(
+		t,
+		[]gitprovider.Commit{{
+			ID:          "sha",
+			Message:     "message",
+			Author:      "author <author@example.com>",
+			Committer:   "committer <committer@example.com>",
+			CommitDate:  committedDate,
+			ParentCount: 2,
+		}},
+		commits,
+	)
+}
+
+func TestGetCommit(t *testing.T) {
+	mockClient := &MockGitLabClient{
+		commits: []*gitlab.Commit{{ID: "sha"}},
+		diffs: []*gitlab.Diff{
+			{OldPath: "a.txt", NewPath: "a.txt"},
+			{OldPath: "old.txt", NewPath: "new.txt"},
+		},
+	}
+	g := GitLabProvider{client: &GitLabClient{Commits: mockClient}}
+
+	commit, err := g.GetCommit(
+		context.Background(),
+		"https://gitlab.com/group/project.git",
+		"sha",
+	)
+	require.NoError(t, err)
+	require.Equal(t, "group/project", mockClient.pid)
+	require.Equal(t, "sha", mockClient.sha)
+	require.Equal(t, "sha", commit.ID)
+	require.Equal(t, []string{"a.txt", "new.txt", "old.txt"}, commit.Paths)
+}
+
+func TestListTags(t *testing.T) {
+	mockClient := &MockGitLabClient{
+		tags: []*gitlab.Tag{
+			{Name: "v1.0.0", Commit: &gitlab.Commit{ID: "sha1"}},
+			{Name: "v1.1.0", Commit: &gitlab.Commit{ID: "sha2"}},
+		},
+	}
+	g := GitLabProvider{client: &GitLabClient{Tags: mockClient}}
+
+	tags, err := g.ListTags(
+		context.Background(),
+		"https://gitlab.com/group/project.git",
+	)
+	require.NoError(t, err)
+	require.Equal(t, "group/project", mockClient.pid)
+	require.Equal(
+		t,
+		[]gitprovider.Tag{
+			{Name: "v1.0.0", CommitID: "sha1"},
+			{Name: "v1.1.0", CommitID: "sha2"},
+		},
+		tags,
+	)
+}
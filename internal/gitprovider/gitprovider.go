@@ -21,6 +21,20 @@ type GitProviderService interface { // nolint: revive
 
 	// IsPullRequestMerged returns whether or not the pull request was merged
 	IsPullRequestMerged(ctx context.Context, repoURL string, number int64) (bool, error)
+
+	// ListPullRequestsWithCommit lists pull requests that contain the given commit
+	ListPullRequestsWithCommit(ctx context.Context, repoURL string, sha string) ([]*PullRequest, error)
+
+	// CreatePullRequestComment comments on an existing pull request
+	CreatePullRequestComment(ctx context.Context, repoURL string, number int64, body string) error
+
+	// CreateDeploymentStatus records the status of a deployment of a commit to
+	// an environment
+	CreateDeploymentStatus(ctx context.Context, repoURL string, opts CreateDeploymentStatusOpts) error
+
+	// CreateCommitStatus records the status of a check (e.g. verification in
+	// an environment) against a commit
+	CreateCommitStatus(ctx context.Context, repoURL string, opts CreateCommitStatusOpts) error
 }
 
 type CreatePullRequestOpts struct {
@@ -37,6 +51,49 @@ type ListPullRequestOpts struct {
 	Base  string
 }
 
+type CreateDeploymentStatusOpts struct {
+	// Environment is the name of the environment the commit was deployed to
+	Environment string
+	// SHA is the ID of the deployed commit
+	SHA string
+	// Ref is the branch or tag the deployed commit was found on. Some providers
+	// require it.
+	Ref string
+	// Tag indicates whether Ref is a tag rather than a branch
+	Tag bool
+	// State is the deployment state (one of: Success, Failure)
+	State DeploymentState
+	// Description is a short description of the deployment status
+	Description string
+}
+
+type CreateCommitStatusOpts struct {
+	// SHA is the ID of the commit
+	SHA string
+	// Context is the name that distinguishes this status from statuses of
+	// other checks against the same commit
+	Context string
+	// State is the commit state (one of: Pending, Success, Failure)
+	State CommitState
+	// Description is a short description of the status
+	Description string
+}
+
+type CommitState string
+
+const (
+	CommitStatePending CommitState = "Pending"
+	CommitStateSuccess CommitState = "Success"
+	CommitStateFailure CommitState = "Failure"
+)
+
+type DeploymentState string
+
+const (
+	DeploymentStateSuccess DeploymentState = "Success"
+	DeploymentStateFailure DeploymentState = "Failure"
+)
+
 type PullRequestState string
 
 const (
@@ -0,0 +1,123 @@
+package gitwebhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// bitbucketCloud receives webhook requests from Bitbucket Cloud.
+//
+// See https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Push
+type bitbucketCloud struct{}
+
+func (bitbucketCloud) handles(req *http.Request) bool {
+	return req.Header.Get("X-Event-Key") != "" && req.Header.Get("X-Hook-UUID") != ""
+}
+
+func (bitbucketCloud) authenticate(req *http.Request, body []byte, secret []byte) error {
+	return verifyHMACSHA256(
+		secret,
+		body,
+		strings.TrimPrefix(req.Header.Get("X-Hub-Signature"), "sha256="),
+	)
+}
+
+func (bitbucketCloud) parse(req *http.Request, body []byte) (*pushEvent, error) {
+	if req.Header.Get("X-Event-Key") != "repo:push" {
+		return nil, nil
+	}
+	payload := struct {
+		Push struct {
+			Changes []struct {
+				// New is null if the branch or tag was deleted.
+				New *struct {
+					Type string `json:"type"`
+					Name string `json:"name"`
+				} `json:"new"`
+			} `json:"changes"`
+		} `json:"push"`
+		Repository struct {
+			FullName string `json:"full_name"`
+			Links    struct {
+				HTML struct {
+					Href string `json:"href"`
+				} `json:"html"`
+			} `json:"links"`
+		} `json:"repository"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	event := &pushEvent{
+		repoURLs: []string{
+			payload.Repository.Links.HTML.Href,
+			"git@bitbucket.org:" + payload.Repository.FullName,
+		},
+	}
+	for _, change := range payload.Push.Changes {
+		if change.New == nil {
+			continue
+		}
+		switch change.New.Type {
+		case "branch":
+			event.branches = append(event.branches, change.New.Name)
+		case "tag":
+			event.tags = append(event.tags, change.New.Name)
+		}
+	}
+	return event, nil
+}
+
+// bitbucketServer receives webhook requests from Bitbucket Server and
+// Bitbucket Data Center. Unlike Bitbucket Cloud, it does not send an
+// X-Hook-UUID header.
+//
+// See https://confluence.atlassian.com/bitbucketserver/event-payload-938025882.html#Eventpayload-Push
+type bitbucketServer struct{}
+
+func (bitbucketServer) handles(req *http.Request) bool {
+	return req.Header.Get("X-Event-Key") != "" && req.Header.Get("X-Hook-UUID") == ""
+}
+
+func (bitbucketServer) authenticate(req *http.Request, body []byte, secret []byte) error {
+	return verifyHMACSHA256(
+		secret,
+		body,
+		strings.TrimPrefix(req.Header.Get("X-Hub-Signature"), "sha256="),
+	)
+}
+
+func (bitbucketServer) parse(req *http.Request, body []byte) (*pushEvent, error) {
+	if req.Header.Get("X-Event-Key") != "repo:refs_changed" {
+		return nil, nil
+	}
+	payload := struct {
+		Changes []struct {
+			Ref struct {
+				ID string `json:"id"`
+			} `json:"ref"`
+			Type string `json:"type"`
+		} `json:"changes"`
+		Repository struct {
+			Links struct {
+				Clone []struct {
+					Href string `json:"href"`
+				} `json:"clone"`
+			} `json:"links"`
+		} `json:"repository"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	event := &pushEvent{}
+	for _, link := range payload.Repository.Links.Clone {
+		event.repoURLs = append(event.repoURLs, link.Href)
+	}
+	for _, change := range payload.Changes {
+		if change.Type != "DELETE" {
+			event.addRef(change.Ref.ID)
+		}
+	}
+	return event, nil
+}
@@ -0,0 +1,105 @@
+package gitwebhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitbucketCloud(t *testing.T) {
+	p := bitbucketCloud{}
+	const payload = `{
+		"push": {
+			"changes": [
+				{"new": {"type": "branch", "name": "main"}},
+				{"new": {"type": "tag", "name": "v1.0.0"}},
+				{"new": null}
+			]
+		},
+		"repository": {
+			"full_name": "example/repo",
+			"links": {"html": {"href": "https://bitbucket.org/example/repo"}}
+		}
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, Path+"fake-project", nil)
+	req.Header.Set("X-Event-Key", "repo:push")
+	require.False(t, p.handles(req))
+	req.Header.Set("X-Hook-UUID", "fake-uuid")
+	require.True(t, p.handles(req))
+
+	require.Error(t, p.authenticate(req, []byte(payload), []byte("fake-secret")))
+	req.Header.Set("X-Hub-Signature", "sha256="+sign("fake-secret", payload))
+	require.NoError(t, p.authenticate(req, []byte(payload), []byte("fake-secret")))
+
+	event, err := p.parse(req, []byte(payload))
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&pushEvent{
+			repoURLs: []string{
+				"https://bitbucket.org/example/repo",
+				"git@bitbucket.org:example/repo",
+			},
+			branches: []string{"main"},
+			tags:     []string{"v1.0.0"},
+		},
+		event,
+	)
+
+	req.Header.Set("X-Event-Key", "diagnostics:ping")
+	event, err = p.parse(req, []byte(payload))
+	require.NoError(t, err)
+	require.Nil(t, event)
+}
+
+func TestBitbucketServer(t *testing.T) {
+	p := bitbucketServer{}
+	const payload = `{
+		"changes": [
+			{"ref": {"id": "refs/heads/main"}, "type": "UPDATE"},
+			{"ref": {"id": "refs/tags/v1.0.0"}, "type": "ADD"},
+			{"ref": {"id": "refs/heads/old"}, "type": "DELETE"}
+		],
+		"repository": {
+			"links": {
+				"clone": [
+					{"href": "ssh://git@bitbucket.example.com:7999/prj/repo.git"},
+					{"href": "https://bitbucket.example.com/scm/prj/repo.git"}
+				]
+			}
+		}
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, Path+"fake-project", nil)
+	require.False(t, p.handles(req))
+	req.Header.Set("X-Event-Key", "repo:refs_changed")
+	require.True(t, p.handles(req))
+	require.Equal(t, p, providerFor(req))
+
+	require.Error(t, p.authenticate(req, []byte(payload), []byte("fake-secret")))
+	req.Header.Set("X-Hub-Signature", "sha256="+sign("fake-secret", payload))
+	require.NoError(t, p.authenticate(req, []byte(payload), []byte("fake-secret")))
+
+	event, err := p.parse(req, []byte(payload))
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&pushEvent{
+			repoURLs: []string{
+				"ssh://git@bitbucket.example.com:7999/prj/repo.git",
+				"https://bitbucket.example.com/scm/prj/repo.git",
+			},
+			branches: []string{"main"},
+			tags:     []string{"v1.0.0"},
+		},
+		event,
+	)
+
+	req.Header.Set("X-Event-Key", "diagnostics:ping")
+	event, err = p.parse(req, []byte(payload))
+	require.NoError(t, err)
+	require.Nil(t, event)
+}
@@ -0,0 +1,122 @@
+package gitwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// snsHostRegex matches the hosts from which Amazon SNS may be asked to confirm
+// a subscription.
+var snsHostRegex = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// codeCommit receives notifications of AWS CodeCommit triggers, which are
+// delivered through an Amazon SNS topic with an HTTPS subscription. SNS cannot
+// sign requests using a shared secret. Instead, the secret must be included
+// as the password in the subscription's endpoint URL, e.g.
+// https://kargo:<secret>@kargo.example.com/webhooks/git/kargo-demo, which SNS
+// then sends using basic authentication.
+//
+// See https://docs.aws.amazon.com/codecommit/latest/userguide/how-to-notify-sns.html
+// and https://docs.aws.amazon.com/sns/latest/dg/sns-http-https-endpoint-as-subscriber.html
+type codeCommit struct {
+	// confirmSubscriptionFn confirms the SNS subscription with the provided
+	// confirmation URL.
+	confirmSubscriptionFn func(ctx context.Context, subscribeURL string) error
+}
+
+func (codeCommit) handles(req *http.Request) bool {
+	return req.Header.Get("X-Amz-Sns-Message-Type") != ""
+}
+
+func (codeCommit) authenticate(req *http.Request, _ []byte, secret []byte) error {
+	_, password, ok := req.BasicAuth()
+	if !ok {
+		return errors.New("missing basic authentication credentials")
+	}
+	return verifyToken(secret, password)
+}
+
+func (c codeCommit) parse(req *http.Request, body []byte) (*pushEvent, error) {
+	msg := struct {
+		Message      string `json:"Message"`
+		SubscribeURL string `json:"SubscribeURL"`
+	}{}
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, err
+	}
+	switch req.Header.Get("X-Amz-Sns-Message-Type") {
+	case "SubscriptionConfirmation":
+		// The request has already been authenticated, so the subscription was
+		// requested by someone who knows the Project's secret.
+		return nil, c.confirmSubscriptionFn(req.Context(), msg.SubscribeURL)
+	case "Notification":
+	default:
+		return nil, nil
+	}
+	trigger := struct {
+		Records []struct {
+			AWSRegion      string `json:"awsRegion"`
+			EventSourceARN string `json:"eventSourceARN"`
+			CodeCommit     struct {
+				References []struct {
+					Ref     string `json:"ref"`
+					Deleted bool   `json:"deleted"`
+				} `json:"references"`
+			} `json:"codecommit"`
+		} `json:"Records"`
+	}{}
+	if err := json.Unmarshal([]byte(msg.Message), &trigger); err != nil {
+		return nil, fmt.Errorf("error parsing CodeCommit trigger event: %w", err)
+	}
+	event := &pushEvent{}
+	for _, record := range trigger.Records {
+		// The ARN of a repository is arn:<partition>:codecommit:<region>:<account>:<name>.
+		arn := strings.Split(record.EventSourceARN, ":")
+		if len(arn) != 6 || arn[2] != "codecommit" {
+			continue
+		}
+		host := fmt.Sprintf("git-codecommit.%s.amazonaws.com", record.AWSRegion)
+		event.repoURLs = append(
+			event.repoURLs,
+			fmt.Sprintf("https://%s/v1/repos/%s", host, arn[5]),
+			fmt.Sprintf("ssh://%s/v1/repos/%s", host, arn[5]),
+		)
+		for _, ref := range record.CodeCommit.References {
+			if !ref.Deleted {
+				event.addRef(ref.Ref)
+			}
+		}
+	}
+	return event, nil
+}
+
+// confirmSNSSubscription confirms an Amazon SNS subscription by requesting the
+// provided confirmation URL, which must point to SNS itself.
+func confirmSNSSubscription(ctx context.Context, subscribeURL string) error {
+	u, err := url.Parse(subscribeURL)
+	if err != nil || u.Scheme != "https" || !snsHostRegex.MatchString(u.Hostname()) {
+		return fmt.Errorf("%q is not an Amazon SNS subscription confirmation URL", subscribeURL)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error confirming SNS subscription: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("error confirming SNS subscription: %s", res.Status)
+	}
+	return nil
+}
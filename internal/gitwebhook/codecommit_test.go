@@ -0,0 +1,84 @@
+package gitwebhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeCommit(t *testing.T) {
+	var confirmed string
+	p := codeCommit{
+		confirmSubscriptionFn: func(_ context.Context, subscribeURL string) error {
+			confirmed = subscribeURL
+			return nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, Path+"fake-project", nil)
+	require.False(t, p.handles(req))
+	req.Header.Set("X-Amz-Sns-Message-Type", "SubscriptionConfirmation")
+	require.True(t, p.handles(req))
+
+	require.Error(t, p.authenticate(req, nil, []byte("fake-secret")))
+	req.SetBasicAuth("kargo", "wrong-secret")
+	require.Error(t, p.authenticate(req, nil, []byte("fake-secret")))
+	req.SetBasicAuth("kargo", "fake-secret")
+	require.NoError(t, p.authenticate(req, nil, []byte("fake-secret")))
+
+	const subscribeURL = "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription"
+	event, err := p.parse(req, []byte(`{"SubscribeURL": "`+subscribeURL+`"}`))
+	require.NoError(t, err)
+	require.Nil(t, event)
+	require.Equal(t, subscribeURL, confirmed)
+
+	message, err := json.Marshal(map[string]any{
+		"Message": `{
+			"Records": [{
+				"awsRegion": "us-east-1",
+				"eventSourceARN": "arn:aws:codecommit:us-east-1:123456789012:repo",
+				"codecommit": {
+					"references": [
+						{"ref": "refs/heads/main"},
+						{"ref": "refs/tags/v1.0.0"},
+						{"ref": "refs/heads/old", "deleted": true}
+					]
+				}
+			}]
+		}`,
+	})
+	require.NoError(t, err)
+	req.Header.Set("X-Amz-Sns-Message-Type", "Notification")
+	event, err = p.parse(req, message)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&pushEvent{
+			repoURLs: []string{
+				"https://git-codecommit.us-east-1.amazonaws.com/v1/repos/repo",
+				"ssh://git-codecommit.us-east-1.amazonaws.com/v1/repos/repo",
+			},
+			branches: []string{"main"},
+			tags:     []string{"v1.0.0"},
+		},
+		event,
+	)
+}
+
+func TestConfirmSNSSubscription(t *testing.T) {
+	for _, subscribeURL := range []string{
+		"http://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription",
+		"https://example.com/?Action=ConfirmSubscription",
+		"https://sns.us-east-1.amazonaws.com.example.com/",
+	} {
+		require.ErrorContains(
+			t,
+			confirmSNSSubscription(context.Background(), subscribeURL),
+			"is not an Amazon SNS subscription confirmation URL",
+		)
+	}
+}
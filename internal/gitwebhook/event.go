@@ -0,0 +1,160 @@
+package gitwebhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	libGit "github.com/akuity/kargo/internal/git"
+)
+
+const (
+	branchRefPrefix = "refs/heads/"
+	tagRefPrefix    = "refs/tags/"
+)
+
+// pushEvent describes branches and/or tags having been pushed to a repository.
+type pushEvent struct {
+	// repoURLs are URLs of the repository that was pushed to. Git providers
+	// usually report more than one (e.g. an HTTPS and an SSH clone URL), any
+	// of which may be the one a GitSubscription refers to.
+	repoURLs []string
+	// branches are the names of the branches that were pushed to.
+	branches []string
+	// tags are the names of the tags that were pushed.
+	tags []string
+}
+
+// addRef records the provided fully qualified ref as having been pushed.
+// Refs other than branches and tags are ignored.
+func (e *pushEvent) addRef(ref string) {
+	switch {
+	case strings.HasPrefix(ref, branchRefPrefix):
+		e.branches = append(e.branches, strings.TrimPrefix(ref, branchRefPrefix))
+	case strings.HasPrefix(ref, tagRefPrefix):
+		e.tags = append(e.tags, strings.TrimPrefix(ref, tagRefPrefix))
+	}
+}
+
+// empty returns true if the event describes no pushed branches or tags.
+func (e *pushEvent) empty() bool {
+	return len(e.branches) == 0 && len(e.tags) == 0
+}
+
+// provider receives webhook requests sent by a single kind of git provider.
+type provider interface {
+	// handles returns true if the provided request was sent by the provider.
+	handles(req *http.Request) bool
+	// authenticate returns an error if the provided request was not signed
+	// using, or does not carry, the provided secret.
+	authenticate(req *http.Request, body []byte, secret []byte) error
+	// parse returns the push event described by the provided request. If the
+	// request describes an event that is of no interest (e.g. a ping or the
+	// deletion of a branch), nil is returned.
+	parse(req *http.Request, body []byte) (*pushEvent, error)
+}
+
+// providers are all supported git providers. Gitea is listed before GitHub,
+// because Gitea also sends GitHub's headers for the sake of compatibility.
+var providers = []provider{
+	gitea{},
+	github{},
+	gitee{nowFn: time.Now},
+	gitlab{},
+	bitbucketCloud{},
+	bitbucketServer{},
+	codeCommit{confirmSubscriptionFn: confirmSNSSubscription},
+}
+
+// providerFor returns the provider that sent the provided request or nil if
+// the request was not sent by any supported provider.
+func providerFor(req *http.Request) provider {
+	for _, p := range providers {
+		if p.handles(req) {
+			return p
+		}
+	}
+	return nil
+}
+
+var errInvalidSignature = errors.New("invalid signature")
+
+// verifyHMACSHA256 returns an error if the provided hex-encoded signature is
+// not the HMAC-SHA256 of the provided body keyed with the provided secret.
+func verifyHMACSHA256(secret, body []byte, signature string) error {
+	sig, err := hex.DecodeString(signature)
+	if err != nil || len(sig) == 0 {
+		return errInvalidSignature
+	}
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(body)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return errInvalidSignature
+	}
+	return nil
+}
+
+// verifyToken returns an error if the provided token, sent by a git provider
+// that does not sign its requests, does not equal the provided secret.
+func verifyToken(secret []byte, token string) error {
+	if token == "" || subtle.ConstantTimeCompare(secret, []byte(token)) != 1 {
+		return errors.New("invalid token")
+	}
+	return nil
+}
+
+// subscribesTo returns true if the provided GitSubscription may discover new
+// commits as a result of the provided push event.
+func subscribesTo(sub kargoapi.GitSubscription, event *pushEvent) bool {
+	if !slices.ContainsFunc(event.repoURLs, func(repoURL string) bool {
+		return repoURL != "" && sameRepo(repoURL, sub.RepoURL)
+	}) {
+		return false
+	}
+	switch sub.CommitSelectionStrategy {
+	case kargoapi.CommitSelectionStrategyCalVer,
+		kargoapi.CommitSelectionStrategyLexical,
+		kargoapi.CommitSelectionStrategyNewestTag,
+		kargoapi.CommitSelectionStrategySemVer:
+		return len(event.tags) > 0
+	}
+	switch {
+	case sub.Branch == "":
+		// Most providers do not report the repository's default branch, so
+		// any push to a branch is assumed to possibly be a push to it.
+		return len(event.branches) > 0
+	case libGit.IsBranchPattern(sub.Branch):
+		matcher, err := libGit.NewBranchMatcher(sub.Branch)
+		if err != nil {
+			return false
+		}
+		return slices.ContainsFunc(event.branches, matcher)
+	default:
+		return slices.Contains(event.branches, sub.Branch)
+	}
+}
+
+// sameRepo returns true if the provided URLs refer to the same repository,
+// regardless of whether either is an HTTP(S) or SSH URL.
+func sameRepo(a, b string) bool {
+	return repoKey(a) == repoKey(b)
+}
+
+// repoKey reduces the provided repository URL to the host and path of the
+// repository it refers to.
+func repoKey(repoURL string) string {
+	normalized := libGit.NormalizeURL(repoURL)
+	u, err := url.Parse(normalized)
+	if err != nil || u.Host == "" {
+		return normalized
+	}
+	return u.Hostname() + strings.TrimSuffix(u.Path, "/")
+}
@@ -0,0 +1,98 @@
+package gitwebhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestVerifyHMACSHA256(t *testing.T) {
+	require.NoError(t, verifyHMACSHA256([]byte("secret"), []byte("body"), sign("secret", "body")))
+	require.Error(t, verifyHMACSHA256([]byte("secret"), []byte("body"), sign("other", "body")))
+	require.Error(t, verifyHMACSHA256([]byte("secret"), []byte("body"), "not-hex"))
+	require.Error(t, verifyHMACSHA256([]byte("secret"), []byte("body"), ""))
+}
+
+func TestVerifyToken(t *testing.T) {
+	require.NoError(t, verifyToken([]byte("secret"), "secret"))
+	require.Error(t, verifyToken([]byte("secret"), "other"))
+	require.Error(t, verifyToken([]byte("secret"), ""))
+}
+
+func TestSubscribesTo(t *testing.T) {
+	const repoURL = "https://github.com/example/repo"
+	const semVer = kargoapi.CommitSelectionStrategySemVer
+	branchPush := &pushEvent{
+		repoURLs: []string{"git@github.com:example/repo.git"},
+		branches: []string{"release/1.0"},
+	}
+	tagPush := &pushEvent{
+		repoURLs: []string{"https://github.com/example/repo.git"},
+		tags:     []string{"v1.0.0"},
+	}
+
+	testCases := []struct {
+		name     string
+		sub      kargoapi.GitSubscription
+		event    *pushEvent
+		expected bool
+	}{
+		{
+			name:  "different repository",
+			sub:   kargoapi.GitSubscription{RepoURL: "https://github.com/example/other"},
+			event: branchPush,
+		},
+		{
+			name:     "default branch",
+			sub:      kargoapi.GitSubscription{RepoURL: repoURL},
+			event:    branchPush,
+			expected: true,
+		},
+		{
+			name:     "matching branch",
+			sub:      kargoapi.GitSubscription{RepoURL: repoURL, Branch: "release/1.0"},
+			event:    branchPush,
+			expected: true,
+		},
+		{
+			name:  "other branch",
+			sub:   kargoapi.GitSubscription{RepoURL: repoURL, Branch: "main"},
+			event: branchPush,
+		},
+		{
+			name:     "matching branch pattern",
+			sub:      kargoapi.GitSubscription{RepoURL: repoURL, Branch: "glob:release/*"},
+			event:    branchPush,
+			expected: true,
+		},
+		{
+			name:  "branch pushed to tag subscription",
+			sub:   kargoapi.GitSubscription{RepoURL: repoURL, CommitSelectionStrategy: semVer},
+			event: branchPush,
+		},
+		{
+			name:     "tag pushed to tag subscription",
+			sub:      kargoapi.GitSubscription{RepoURL: repoURL, CommitSelectionStrategy: semVer},
+			event:    tagPush,
+			expected: true,
+		},
+		{
+			name:  "tag pushed to branch subscription",
+			sub:   kargoapi.GitSubscription{RepoURL: repoURL},
+			event: tagPush,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, subscribesTo(testCase.sub, testCase.event))
+		})
+	}
+}
+
+func TestSameRepo(t *testing.T) {
+	require.True(t, sameRepo("https://github.com/example/repo", "git@github.com:example/repo.git"))
+	require.True(t, sameRepo("https://GitHub.com/example/repo/", "ssh://git@github.com/example/repo"))
+	require.False(t, sameRepo("https://github.com/example/repo", "https://github.com/example/other"))
+}
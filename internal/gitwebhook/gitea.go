@@ -0,0 +1,24 @@
+package gitwebhook
+
+import "net/http"
+
+// gitea receives webhook requests from Gitea. Its push events use the same
+// payload as GitHub's, but are signed differently.
+//
+// See https://docs.gitea.com/usage/webhooks
+type gitea struct{}
+
+func (gitea) handles(req *http.Request) bool {
+	return req.Header.Get("X-Gitea-Event") != ""
+}
+
+func (gitea) authenticate(req *http.Request, body []byte, secret []byte) error {
+	return verifyHMACSHA256(secret, body, req.Header.Get("X-Gitea-Signature"))
+}
+
+func (gitea) parse(req *http.Request, body []byte) (*pushEvent, error) {
+	if req.Header.Get("X-Gitea-Event") != "push" {
+		return nil, nil
+	}
+	return parseRefPush(body)
+}
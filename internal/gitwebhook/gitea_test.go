@@ -0,0 +1,39 @@
+package gitwebhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitea(t *testing.T) {
+	p := gitea{}
+
+	req := httptest.NewRequest(http.MethodPost, Path+"fake-project", nil)
+	req.Header.Set("X-GitHub-Event", "push")
+	require.False(t, p.handles(req))
+	req.Header.Set("X-Gitea-Event", "push")
+	require.True(t, p.handles(req))
+	// Gitea's requests also carry GitHub's headers, but must be handled as
+	// Gitea's.
+	require.Equal(t, p, providerFor(req))
+
+	req.Header.Set("X-Gitea-Signature", sign("fake-secret", testPushPayload))
+	require.NoError(t, p.authenticate(req, []byte(testPushPayload), []byte("fake-secret")))
+	require.Error(t, p.authenticate(req, []byte(testPushPayload), []byte("wrong-secret")))
+
+	event, err := p.parse(req, []byte(`{
+		"ref": "refs/tags/v1.0.0",
+		"repository": {"clone_url": "https://gitea.example.com/example/repo.git"}
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"v1.0.0"}, event.tags)
+	require.Contains(t, event.repoURLs, "https://gitea.example.com/example/repo.git")
+
+	req.Header.Set("X-Gitea-Event", "create")
+	event, err = p.parse(req, []byte(testPushPayload))
+	require.NoError(t, err)
+	require.Nil(t, event)
+}
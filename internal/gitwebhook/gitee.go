@@ -0,0 +1,86 @@
+package gitwebhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// gitee receives webhook requests from Gitee. Gitee either signs the
+// timestamp of a request, together with the secret, or, if the webhook is
+// configured with a password rather than a signing key, sends the secret
+// verbatim. The body of a request is never signed.
+//
+// See https://help.gitee.com/webhook/gitee-webhook-push-data-format
+type gitee struct {
+	// nowFn returns the current time, against which the timestamps of signed
+	// requests are checked.
+	nowFn func() time.Time
+}
+
+// maxGiteeTimestampSkew is how far the timestamp of a signed request from
+// Gitee may be from the current time. Because the body of a request is not
+// signed, a captured signature could otherwise be replayed indefinitely.
+const maxGiteeTimestampSkew = time.Hour
+
+func (gitee) handles(req *http.Request) bool {
+	return req.Header.Get("X-Gitee-Event") != ""
+}
+
+func (g gitee) authenticate(req *http.Request, _ []byte, secret []byte) error {
+	token := req.Header.Get("X-Gitee-Token")
+	if timestamp := req.Header.Get("X-Gitee-Timestamp"); timestamp != "" {
+		mac := hmac.New(sha256.New, secret)
+		_, _ = mac.Write([]byte(timestamp + "\n" + string(secret)))
+		if sig, err := base64.StdEncoding.DecodeString(token); err == nil &&
+			hmac.Equal(sig, mac.Sum(nil)) {
+			millis, err := strconv.ParseInt(timestamp, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid timestamp %q", timestamp)
+			}
+			skew := g.nowFn().Sub(time.UnixMilli(millis))
+			if skew > maxGiteeTimestampSkew || skew < -maxGiteeTimestampSkew {
+				return fmt.Errorf("timestamp %q is not recent", timestamp)
+			}
+			return nil
+		}
+	}
+	return verifyToken(secret, token)
+}
+
+func (gitee) parse(req *http.Request, body []byte) (*pushEvent, error) {
+	switch req.Header.Get("X-Gitee-Event") {
+	case "Push Hook", "Tag Push Hook":
+	default:
+		return nil, nil
+	}
+	payload := struct {
+		Ref        string `json:"ref"`
+		Deleted    bool   `json:"deleted"`
+		Repository struct {
+			HTMLURL    string `json:"html_url"`
+			GitHTTPURL string `json:"git_http_url"`
+			GitSSHURL  string `json:"git_ssh_url"`
+		} `json:"repository"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	if payload.Deleted {
+		return nil, nil
+	}
+	event := &pushEvent{
+		repoURLs: []string{
+			payload.Repository.HTMLURL,
+			payload.Repository.GitHTTPURL,
+			payload.Repository.GitSSHURL,
+		},
+	}
+	event.addRef(payload.Ref)
+	return event, nil
+}
@@ -0,0 +1,81 @@
+package gitwebhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitee(t *testing.T) {
+	const timestamp = "1700000000000"
+	now := time.UnixMilli(1700000000000)
+	p := gitee{
+		nowFn: func() time.Time {
+			return now
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, Path+"fake-project", nil)
+	require.False(t, p.handles(req))
+	req.Header.Set("X-Gitee-Event", "Push Hook")
+	require.True(t, p.handles(req))
+
+	// Password
+	require.Error(t, p.authenticate(req, nil, []byte("fake-secret")))
+	req.Header.Set("X-Gitee-Token", "fake-secret")
+	require.NoError(t, p.authenticate(req, nil, []byte("fake-secret")))
+
+	// Signature
+	mac := hmac.New(sha256.New, []byte("fake-secret"))
+	_, _ = mac.Write([]byte(timestamp + "\nfake-secret"))
+	req.Header.Set("X-Gitee-Timestamp", timestamp)
+	req.Header.Set("X-Gitee-Token", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	require.NoError(t, p.authenticate(req, nil, []byte("fake-secret")))
+	require.Error(t, p.authenticate(req, nil, []byte("wrong-secret")))
+
+	// Signatures of timestamps that are not recent are rejected
+	now = now.Add(59 * time.Minute)
+	require.NoError(t, p.authenticate(req, nil, []byte("fake-secret")))
+	now = now.Add(2 * time.Minute)
+	require.ErrorContains(t, p.authenticate(req, nil, []byte("fake-secret")), "not recent")
+	now = time.UnixMilli(1700000000000).Add(-61 * time.Minute)
+	require.ErrorContains(t, p.authenticate(req, nil, []byte("fake-secret")), "not recent")
+	now = time.UnixMilli(1700000000000)
+
+	event, err := p.parse(req, []byte(`{
+		"ref": "refs/heads/master",
+		"repository": {
+			"html_url": "https://gitee.com/example/repo",
+			"git_http_url": "https://gitee.com/example/repo.git",
+			"git_ssh_url": "git@gitee.com:example/repo.git"
+		}
+	}`))
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&pushEvent{
+			repoURLs: []string{
+				"https://gitee.com/example/repo",
+				"https://gitee.com/example/repo.git",
+				"git@gitee.com:example/repo.git",
+			},
+			branches: []string{"master"},
+		},
+		event,
+	)
+
+	event, err = p.parse(req, []byte(`{"ref": "refs/heads/master", "deleted": true}`))
+	require.NoError(t, err)
+	require.Nil(t, event)
+
+	req.Header.Set("X-Gitee-Event", "Issue Hook")
+	event, err = p.parse(req, []byte(`{}`))
+	require.NoError(t, err)
+	require.Nil(t, event)
+}
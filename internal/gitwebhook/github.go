@@ -0,0 +1,64 @@
+package gitwebhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// github receives webhook requests from GitHub.
+//
+// See https://docs.github.com/en/webhooks/webhook-events-and-payloads#push
+type github struct{}
+
+func (github) handles(req *http.Request) bool {
+	return req.Header.Get("X-GitHub-Event") != ""
+}
+
+func (github) authenticate(req *http.Request, body []byte, secret []byte) error {
+	return verifyHMACSHA256(
+		secret,
+		body,
+		strings.TrimPrefix(req.Header.Get("X-Hub-Signature-256"), "sha256="),
+	)
+}
+
+func (github) parse(req *http.Request, body []byte) (*pushEvent, error) {
+	if req.Header.Get("X-GitHub-Event") != "push" {
+		return nil, nil
+	}
+	return parseRefPush(body)
+}
+
+// refPushPayload is the payload of a push event as sent by GitHub and by the
+// providers that mimic it.
+type refPushPayload struct {
+	Ref        string `json:"ref"`
+	Deleted    bool   `json:"deleted"`
+	Repository struct {
+		HTMLURL  string `json:"html_url"`
+		CloneURL string `json:"clone_url"`
+		SSHURL   string `json:"ssh_url"`
+	} `json:"repository"`
+}
+
+// parseRefPush parses a push event payload in the format used by GitHub.
+// Pushes that delete a branch or tag are ignored.
+func parseRefPush(body []byte) (*pushEvent, error) {
+	payload := refPushPayload{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	if payload.Deleted {
+		return nil, nil
+	}
+	event := &pushEvent{
+		repoURLs: []string{
+			payload.Repository.HTMLURL,
+			payload.Repository.CloneURL,
+			payload.Repository.SSHURL,
+		},
+	}
+	event.addRef(payload.Ref)
+	return event, nil
+}
@@ -0,0 +1,46 @@
+package gitwebhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHub(t *testing.T) {
+	p := github{}
+
+	req := httptest.NewRequest(http.MethodPost, Path+"fake-project", nil)
+	require.False(t, p.handles(req))
+	req.Header.Set("X-GitHub-Event", "push")
+	require.True(t, p.handles(req))
+
+	require.Error(t, p.authenticate(req, []byte(testPushPayload), []byte("fake-secret")))
+	req.Header.Set("X-Hub-Signature-256", "sha256="+sign("fake-secret", testPushPayload))
+	require.NoError(t, p.authenticate(req, []byte(testPushPayload), []byte("fake-secret")))
+
+	event, err := p.parse(req, []byte(testPushPayload))
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&pushEvent{
+			repoURLs: []string{
+				"https://github.com/example/repo",
+				"https://github.com/example/repo.git",
+				"git@github.com:example/repo.git",
+			},
+			branches: []string{"main"},
+		},
+		event,
+	)
+
+	event, err = p.parse(req, []byte(`{"ref": "refs/tags/v1.0.0", "deleted": true}`))
+	require.NoError(t, err)
+	require.Nil(t, event)
+
+	req.Header.Set("X-GitHub-Event", "ping")
+	event, err = p.parse(req, []byte(testPushPayload))
+	require.NoError(t, err)
+	require.Nil(t, event)
+}
@@ -0,0 +1,55 @@
+package gitwebhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// gitlab receives webhook requests from GitLab. GitLab does not sign its
+// requests. Instead, it sends the secret token configured for the webhook
+// verbatim.
+//
+// See https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html#push-events
+type gitlab struct{}
+
+func (gitlab) handles(req *http.Request) bool {
+	return req.Header.Get("X-Gitlab-Event") != ""
+}
+
+func (gitlab) authenticate(req *http.Request, _ []byte, secret []byte) error {
+	return verifyToken(secret, req.Header.Get("X-Gitlab-Token"))
+}
+
+func (gitlab) parse(req *http.Request, body []byte) (*pushEvent, error) {
+	switch req.Header.Get("X-Gitlab-Event") {
+	case "Push Hook", "Tag Push Hook":
+	default:
+		return nil, nil
+	}
+	payload := struct {
+		Ref     string `json:"ref"`
+		After   string `json:"after"`
+		Project struct {
+			WebURL     string `json:"web_url"`
+			GitHTTPURL string `json:"git_http_url"`
+			GitSSHURL  string `json:"git_ssh_url"`
+		} `json:"project"`
+	}{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	// The ID of the commit a ref points to after it is deleted is all zeros.
+	if strings.Trim(payload.After, "0") == "" {
+		return nil, nil
+	}
+	event := &pushEvent{
+		repoURLs: []string{
+			payload.Project.WebURL,
+			payload.Project.GitHTTPURL,
+			payload.Project.GitSSHURL,
+		},
+	}
+	event.addRef(payload.Ref)
+	return event, nil
+}
@@ -0,0 +1,58 @@
+package gitwebhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitLab(t *testing.T) {
+	p := gitlab{}
+
+	req := httptest.NewRequest(http.MethodPost, Path+"fake-project", nil)
+	require.False(t, p.handles(req))
+	req.Header.Set("X-Gitlab-Event", "Tag Push Hook")
+	require.True(t, p.handles(req))
+
+	require.Error(t, p.authenticate(req, nil, []byte("fake-secret")))
+	req.Header.Set("X-Gitlab-Token", "fake-secret")
+	require.NoError(t, p.authenticate(req, nil, []byte("fake-secret")))
+
+	event, err := p.parse(req, []byte(`{
+		"ref": "refs/tags/v1.0.0",
+		"after": "abc123",
+		"project": {
+			"web_url": "https://gitlab.com/example/repo",
+			"git_http_url": "https://gitlab.com/example/repo.git",
+			"git_ssh_url": "git@gitlab.com:example/repo.git"
+		}
+	}`))
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		&pushEvent{
+			repoURLs: []string{
+				"https://gitlab.com/example/repo",
+				"https://gitlab.com/example/repo.git",
+				"git@gitlab.com:example/repo.git",
+			},
+			tags: []string{"v1.0.0"},
+		},
+		event,
+	)
+
+	// Deleted tag
+	event, err = p.parse(req, []byte(`{
+		"ref": "refs/tags/v1.0.0",
+		"after": "0000000000000000000000000000000000000000"
+	}`))
+	require.NoError(t, err)
+	require.Nil(t, event)
+
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	event, err = p.parse(req, []byte(`{}`))
+	require.NoError(t, err)
+	require.Nil(t, event)
+}
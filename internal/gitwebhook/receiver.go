@@ -0,0 +1,193 @@
+package gitwebhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+const (
+	// Path is the path beneath which the receiver is served. Git providers
+	// must send their requests to this path followed by the name of the
+	// Project whose Warehouses should be refreshed, e.g.
+	// /webhooks/git/kargo-demo.
+	Path = "/webhooks/git/"
+
+	// SecretName is the name of the Secret in a Project's namespace holding
+	// the secret shared with the git providers that send requests on behalf
+	// of the Project. Requests for Projects without this Secret are rejected.
+	SecretName = "kargo-git-webhook"
+	// SecretKey is the key of the shared secret within the Secret.
+	SecretKey = "secret"
+
+	// maxPayloadBytes is the maximum size of a request body. It matches the
+	// largest payload GitHub delivers.
+	maxPayloadBytes = 25 << 20
+)
+
+// receiver is an http.Handler that receives push events from git providers
+// and refreshes the Warehouses subscribed to the branches or tags that were
+// pushed.
+type receiver struct {
+	client client.Client
+}
+
+// NewReceiver returns an http.Handler that receives push events from git
+// providers and refreshes every Warehouse of the Project named in the request
+// path that subscribes to the branches or tags that were pushed. GitHub,
+// GitLab, Gitea, Gitee, Bitbucket Cloud, Bitbucket Server, and, through Amazon
+// SNS, AWS CodeCommit are supported. Requests must be signed with, or carry,
+// the Project's shared secret.
+func NewReceiver(c client.Client) http.Handler {
+	return &receiver{client: c}
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := logging.LoggerFromContext(req.Context())
+
+	if req.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	project := strings.TrimPrefix(req.URL.Path, Path)
+	if project == "" || strings.Contains(project, "/") {
+		http.NotFound(w, req)
+		return
+	}
+	logger = logger.WithField("project", project)
+
+	p := providerFor(req)
+	if p == nil {
+		http.Error(w, "request was not sent by a supported git provider", http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxPayloadBytes))
+	if err != nil {
+		http.Error(w, "error reading request body", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// Only the namespaces of Projects may be addressed. Any other namespace
+	// could hold a Secret with the expected name that was never meant to
+	// authenticate webhook requests.
+	kargoProject, err := kargoapi.GetProject(req.Context(), r.client, project)
+	if err != nil {
+		logger.Errorf("error getting Project: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	var secret []byte
+	if kargoProject != nil {
+		if secret, err = r.getSecret(req.Context(), project); err != nil {
+			logger.Errorf("error getting webhook secret: %s", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+	// A missing Project or a Project without a secret is indistinguishable
+	// from a request with an invalid signature, so that the existence of
+	// Projects is not revealed.
+	if secret == nil {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	if err = p.authenticate(req, body, secret); err != nil {
+		logger.Debugf("rejecting webhook request: %s", err)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	event, err := p.parse(req, body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error handling event: %s", err), http.StatusBadRequest)
+		return
+	}
+	if event == nil || event.empty() {
+		_, _ = fmt.Fprintln(w, "event ignored")
+		return
+	}
+
+	refreshed, err := r.refreshWarehouses(req.Context(), project, event)
+	if err != nil {
+		logger.Errorf("error refreshing Warehouses: %s", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	logger.WithField("warehouses", refreshed).Debug("refreshed Warehouses on push")
+	_, _ = fmt.Fprintf(w, "refreshed %d Warehouse(s)\n", len(refreshed))
+}
+
+// getSecret returns the secret shared with git providers sending requests on
+// behalf of the specified Project. If the Project has no such secret, nil is
+// returned.
+func (r *receiver) getSecret(ctx context.Context, project string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(
+		ctx,
+		client.ObjectKey{Namespace: project, Name: SecretName},
+		secret,
+	); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(secret.Data[SecretKey]) == 0 {
+		return nil, nil
+	}
+	return secret.Data[SecretKey], nil
+}
+
+// refreshWarehouses refreshes every Warehouse in the specified Project that
+// subscribes to any of the branches or tags pushed in the provided event and
+// returns the names of the refreshed Warehouses.
+func (r *receiver) refreshWarehouses(
+	ctx context.Context,
+	project string,
+	event *pushEvent,
+) ([]string, error) {
+	warehouses := &kargoapi.WarehouseList{}
+	if err := r.client.List(ctx, warehouses, client.InNamespace(project)); err != nil {
+		return nil, fmt.Errorf("error listing Warehouses: %w", err)
+	}
+	var refreshed []string
+	var errs []error
+	for _, warehouse := range warehouses.Items {
+		if !warehouseSubscribesTo(warehouse, event) {
+			continue
+		}
+		if _, err := kargoapi.RefreshWarehouse(
+			ctx,
+			r.client,
+			client.ObjectKeyFromObject(&warehouse),
+		); err != nil {
+			errs = append(errs, fmt.Errorf("error refreshing Warehouse %q: %w", warehouse.Name, err))
+			continue
+		}
+		refreshed = append(refreshed, warehouse.Name)
+	}
+	return refreshed, errors.Join(errs...)
+}
+
+// warehouseSubscribesTo returns true if any of the provided Warehouse's Git
+// subscriptions may discover new commits as a result of the provided push
+// event.
+func warehouseSubscribesTo(warehouse kargoapi.Warehouse, event *pushEvent) bool {
+	for _, sub := range warehouse.Spec.Subscriptions {
+		if sub.Git != nil && subscribesTo(*sub.Git, event) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+package gitwebhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+const testPushPayload = `{
+	"ref": "refs/heads/main",
+	"repository": {
+		"html_url": "https://github.com/example/repo",
+		"clone_url": "https://github.com/example/repo.git",
+		"ssh_url": "git@github.com:example/repo.git"
+	}
+}`
+
+// sign returns the hex-encoded HMAC-SHA256 of the provided body keyed with
+// the provided secret.
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestReceiver(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, kargoapi.AddToScheme(scheme))
+
+	testSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-project",
+			Name:      SecretName,
+		},
+		Data: map[string][]byte{SecretKey: []byte("fake-secret")},
+	}
+	newWarehouse := func(name, branch string) *kargoapi.Warehouse {
+		return &kargoapi.Warehouse{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "fake-project",
+				Name:      name,
+			},
+			Spec: kargoapi.WarehouseSpec{
+				Subscriptions: []kargoapi.RepoSubscription{{
+					Git: &kargoapi.GitSubscription{
+						RepoURL: "https://github.com/example/repo",
+						Branch:  branch,
+					},
+				}},
+			},
+		}
+	}
+	testProject := &kargoapi.Project{
+		ObjectMeta: metav1.ObjectMeta{Name: "fake-project"},
+	}
+	objects := []client.Object{
+		testProject,
+		testSecret,
+		newWarehouse("main", "main"),
+		newWarehouse("dev", "dev"),
+	}
+
+	testCases := []struct {
+		name       string
+		method     string
+		path       string
+		headers    map[string]string
+		body       string
+		objects    []client.Object
+		assertions func(*testing.T, *httptest.ResponseRecorder, client.Client)
+	}{
+		{
+			name:   "method not allowed",
+			method: http.MethodGet,
+			path:   Path + "fake-project",
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder, _ client.Client) {
+				require.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+			},
+		},
+		{
+			name:   "no Project",
+			method: http.MethodPost,
+			path:   Path,
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder, _ client.Client) {
+				require.Equal(t, http.StatusNotFound, rr.Code)
+			},
+		},
+		{
+			name:   "unsupported provider",
+			method: http.MethodPost,
+			path:   Path + "fake-project",
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder, _ client.Client) {
+				require.Equal(t, http.StatusBadRequest, rr.Code)
+			},
+		},
+		{
+			name:   "Project has no secret",
+			method: http.MethodPost,
+			path:   Path + "fake-project",
+			headers: map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": "sha256=" + sign("fake-secret", testPushPayload),
+			},
+			body:    testPushPayload,
+			objects: []client.Object{testProject},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder, _ client.Client) {
+				require.Equal(t, http.StatusUnauthorized, rr.Code)
+			},
+		},
+		{
+			name:   "namespace is not a Project",
+			method: http.MethodPost,
+			path:   Path + "fake-project",
+			headers: map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": "sha256=" + sign("fake-secret", testPushPayload),
+			},
+			body: testPushPayload,
+			objects: []client.Object{
+				testSecret,
+				newWarehouse("main", "main"),
+			},
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder, c client.Client) {
+				require.Equal(t, http.StatusUnauthorized, rr.Code)
+				requireRefreshed(t, c, "main", false)
+			},
+		},
+		{
+			name:   "invalid signature",
+			method: http.MethodPost,
+			path:   Path + "fake-project",
+			headers: map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": "sha256=" + sign("wrong-secret", testPushPayload),
+			},
+			body:    testPushPayload,
+			objects: objects,
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder, c client.Client) {
+				require.Equal(t, http.StatusUnauthorized, rr.Code)
+				requireRefreshed(t, c, "main", false)
+			},
+		},
+		{
+			name:   "event ignored",
+			method: http.MethodPost,
+			path:   Path + "fake-project",
+			headers: map[string]string{
+				"X-GitHub-Event":      "ping",
+				"X-Hub-Signature-256": "sha256=" + sign("fake-secret", "{}"),
+			},
+			body:    "{}",
+			objects: objects,
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder, c client.Client) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.Contains(t, rr.Body.String(), "event ignored")
+				requireRefreshed(t, c, "main", false)
+			},
+		},
+		{
+			name:   "refreshes subscribed Warehouses",
+			method: http.MethodPost,
+			path:   Path + "fake-project",
+			headers: map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": "sha256=" + sign("fake-secret", testPushPayload),
+			},
+			body:    testPushPayload,
+			objects: objects,
+			assertions: func(t *testing.T, rr *httptest.ResponseRecorder, c client.Client) {
+				require.Equal(t, http.StatusOK, rr.Code)
+				require.Contains(t, rr.Body.String(), "refreshed 1 Warehouse(s)")
+				requireRefreshed(t, c, "main", true)
+				requireRefreshed(t, c, "dev", false)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(testCase.objects...).
+				Build()
+			req := httptest.NewRequest(
+				testCase.method,
+				testCase.path,
+				bytes.NewBufferString(testCase.body),
+			)
+			for k, v := range testCase.headers {
+				req.Header.Set(k, v)
+			}
+			rr := httptest.NewRecorder()
+			NewReceiver(c).ServeHTTP(rr, req)
+			testCase.assertions(t, rr, c)
+		})
+	}
+}
+
+// requireRefreshed asserts whether the named Warehouse in the fake-project
+// namespace has been refreshed.
+func requireRefreshed(t *testing.T, c client.Client, name string, refreshed bool) {
+	warehouse := &kargoapi.Warehouse{}
+	require.NoError(t, c.Get(
+		context.Background(),
+		client.ObjectKey{Namespace: "fake-project", Name: name},
+		warehouse,
+	))
+	_, ok := kargoapi.RefreshAnnotationValue(warehouse.GetAnnotations())
+	require.Equal(t, refreshed, ok)
+}
@@ -216,6 +216,42 @@ func filterSemVers(semvers semver.Collection, semverConstraint string) (semver.C
 	return filtered, nil
 }
 
+// ExcludeVersions returns those of the provided versions that are not matched
+// by any of the provided exclusions. Each exclusion may be either an exact
+// version, which is compared to versions for equality, or a semver constraint
+// matching the versions to exclude. Versions that cannot be parsed as SemVer
+// are returned as-is. The order of the provided versions is preserved.
+func ExcludeVersions(versions []string, exclusions []string) ([]string, error) {
+	if len(exclusions) == 0 {
+		return versions, nil
+	}
+	matchers := make([]func(*semver.Version) bool, len(exclusions))
+	for i, exclusion := range exclusions {
+		if exact, err := semver.StrictNewVersion(strings.TrimPrefix(exclusion, "v")); err == nil {
+			matchers[i] = exact.Equal
+			continue
+		}
+		constraint, err := semver.NewConstraint(exclusion)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing version exclusion %q: %w", exclusion, err)
+		}
+		matchers[i] = constraint.Check
+	}
+	filtered := make([]string, 0, len(versions))
+versions:
+	for _, version := range versions {
+		if semverVersion, err := semver.NewVersion(version); err == nil {
+			for _, matches := range matchers {
+				if matches(semverVersion) {
+					continue versions
+				}
+			}
+		}
+		filtered = append(filtered, version)
+	}
+	return filtered, nil
+}
+
 // Login runs `helm registry login` or `helm repo add` for the provided
 // repository. The provided homePath is used to set the HOME environment
 // variable, as well as the XDG_* environment variables. This ensures that Helm
@@ -284,16 +320,78 @@ func Login(homePath, repository string, credentials Credentials) error {
 // provided chartPath. The homePath is used to set the HOME environment variable,
 // as well as the XDG_* environment variables. This ensures that Helm uses the
 // provided homePath as its configuration directory, and allows for isolation.
-func UpdateChartDependencies(homePath, chartPath string) error {
+// The provided limits apply to the Helm process.
+func UpdateChartDependencies(homePath, chartPath string, limits libExec.Limits) error {
 	cmd := exec.Command("helm", "dependency", "update", chartPath)
 	cmd.Env = append(cmd.Env, os.Environ()...)
 	cmd.Env = append(cmd.Env, helmEnv(homePath)...)
-	if _, err := libExec.Exec(cmd); err != nil {
+	if _, err := libExec.ExecWithLimits(cmd, limits); err != nil {
 		return err
 	}
 	return nil
 }
 
+// TemplateOptions describes how a chart should be rendered by RenderChart.
+type TemplateOptions struct {
+	// ReleaseName is the release name to render the chart with.
+	ReleaseName string
+	// Namespace is the namespace to render the chart for. If empty, Helm's
+	// default is used.
+	Namespace string
+	// ValuesFiles are paths to values files, in the order in which they should
+	// be merged.
+	ValuesFiles []string
+	// SetValues are key=value pairs, in the order in which they should be
+	// merged. They are merged after all ValuesFiles.
+	SetValues []string
+}
+
+// RenderChart runs `helm template` for the chart at the provided chartPath and
+// returns the rendered manifests. The homePath is used to set the HOME
+// environment variable, as well as the XDG_* environment variables. The
+// provided limits apply to the Helm process.
+func RenderChart(
+	homePath string,
+	chartPath string,
+	opts TemplateOptions,
+	limits libExec.Limits,
+) ([]byte, error) {
+	cmd := exec.Command("helm", templateArgs(chartPath, opts)...)
+	cmd.Env = append(cmd.Env, os.Environ()...)
+	cmd.Env = append(cmd.Env, helmEnv(homePath)...)
+	// Only stdout contains manifests. Anything Helm writes to stderr, e.g.
+	// warnings, must not end up in the output.
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	res, err := libExec.OutputWithLimits(cmd, limits)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error executing cmd [%s]: %s: %w",
+			cmd.String(),
+			stderr.String(),
+			err,
+		)
+	}
+	return res, nil
+}
+
+// templateArgs returns the arguments for a `helm template` command that
+// renders the chart at the provided chartPath as described by the provided
+// TemplateOptions.
+func templateArgs(chartPath string, opts TemplateOptions) []string {
+	args := []string{"template", opts.ReleaseName, chartPath}
+	if opts.Namespace != "" {
+		args = append(args, "--namespace", opts.Namespace)
+	}
+	for _, valuesFile := range opts.ValuesFiles {
+		args = append(args, "--values", valuesFile)
+	}
+	for _, setValue := range opts.SetValues {
+		args = append(args, "--set", setValue)
+	}
+	return args
+}
+
 // NormalizeChartRepositoryURL normalizes a chart repository URL for purposes
 // of comparison. Crucially, this function removes the oci:// prefix from the
 // URL if there is one.
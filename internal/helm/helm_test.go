@@ -297,6 +297,57 @@ func TestFilterSemVers(t *testing.T) {
 	})
 }
 
+func TestExcludeVersions(t *testing.T) {
+	testCases := []struct {
+		name       string
+		versions   []string
+		exclusions []string
+		assertions func(*testing.T, []string, error)
+	}{
+		{
+			name:       "no exclusions",
+			versions:   []string{"2.0.0", "1.0.0"},
+			exclusions: nil,
+			assertions: func(t *testing.T, versions []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"2.0.0", "1.0.0"}, versions)
+			},
+		},
+		{
+			name:       "exact versions",
+			versions:   []string{"v2.0.0", "1.1.0-rc.1", "1.0.0"},
+			exclusions: []string{"2.0.0", "1.1.0-rc.1"},
+			assertions: func(t *testing.T, versions []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"1.0.0"}, versions)
+			},
+		},
+		{
+			name:       "constraints",
+			versions:   []string{"2.1.0", "2.0.1", "2.0.0-rc.1", "1.0.0"},
+			exclusions: []string{"2.0.x-0"},
+			assertions: func(t *testing.T, versions []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"2.1.0", "1.0.0"}, versions)
+			},
+		},
+		{
+			name:       "invalid exclusion",
+			versions:   []string{"1.0.0"},
+			exclusions: []string{"invalid"},
+			assertions: func(t *testing.T, _ []string, err error) {
+				require.ErrorContains(t, err, "error parsing version exclusion")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			versions, err := ExcludeVersions(testCase.versions, testCase.exclusions)
+			testCase.assertions(t, versions, err)
+		})
+	}
+}
+
 func TestNormalizeChartRepositoryURL(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -347,3 +398,39 @@ func TestNormalizeChartRepositoryURL(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateArgs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		opts     TemplateOptions
+		expected []string
+	}{
+		{
+			name:     "release name only",
+			opts:     TemplateOptions{ReleaseName: "app"},
+			expected: []string{"template", "app", "chart"},
+		},
+		{
+			name: "all options",
+			opts: TemplateOptions{
+				ReleaseName: "app",
+				Namespace:   "ns",
+				ValuesFiles: []string{"a.yaml", "b.yaml"},
+				SetValues:   []string{"x=1", "y=2"},
+			},
+			expected: []string{
+				"template", "app", "chart",
+				"--namespace", "ns",
+				"--values", "a.yaml",
+				"--values", "b.yaml",
+				"--set", "x=1",
+				"--set", "y=2",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, templateArgs("chart", testCase.opts))
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+)
+
+// IsAvailable returns a bool indicating whether the image identified by the
+// provided tag and/or digest can still be retrieved from the specified
+// repository. If a tag is provided, the repository must still contain that
+// tag. If a digest is provided, the repository must still contain an image
+// with that digest. This is useful for determining whether an image that was
+// once discovered has since been deleted or untagged.
+func IsAvailable(
+	ctx context.Context,
+	repoURL string,
+	tag string,
+	digest string,
+	insecureSkipTLSVerify bool,
+	creds *Credentials,
+) (bool, error) {
+	repoClient, err := newRepositoryClient(repoURL, insecureSkipTLSVerify, creds)
+	if err != nil {
+		return false, fmt.Errorf("error creating repository client: %w", err)
+	}
+	return repoClient.isAvailable(ctx, tag, digest)
+}
+
+// CheckRepository verifies that the specified repository can be reached and
+// that its tags can be listed using the provided credentials. This is a
+// lightweight means of detecting, for instance, a mistyped repository URL or
+// missing credentials.
+func CheckRepository(
+	ctx context.Context,
+	repoURL string,
+	insecureSkipTLSVerify bool,
+	creds *Credentials,
+) error {
+	repoClient, err := newRepositoryClient(repoURL, insecureSkipTLSVerify, creds)
+	if err != nil {
+		return fmt.Errorf("error creating repository client: %w", err)
+	}
+	_, err = repoClient.getTags(ctx)
+	return err
+}
+
+// isAvailable returns a bool indicating whether the repository still contains
+// the provided tag and an image with the provided digest. Either may be empty,
+// in which case it is not checked.
+func (r *repositoryClient) isAvailable(
+	ctx context.Context,
+	tag string,
+	digest string,
+) (bool, error) {
+	if tag != "" {
+		tags, err := r.getTags(ctx)
+		if err != nil {
+			return false, err
+		}
+		if !slices.Contains(tags, tag) {
+			return false, nil
+		}
+	}
+	if digest != "" {
+		repoRef := r.repoRef.Context().Digest(digest)
+		opts := append(r.remoteOptions, remote.WithContext(ctx))
+		if _, err := r.remoteGetFn(repoRef, opts...); err != nil {
+			var te *transport.Error
+			if errors.As(err, &te) && te.StatusCode == http.StatusNotFound {
+				return false, nil
+			}
+			return false, fmt.Errorf(
+				"error getting image descriptor for digest %s from repo URL %s: %w",
+				digest, r.repoURL, err,
+			)
+		}
+	}
+	return true, nil
+}
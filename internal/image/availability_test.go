@@ -0,0 +1,92 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsAvailable(t *testing.T) {
+	testRepoRef, err := name.ParseReference("fake-url")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		tag         string
+		digest      string
+		remoteGetFn func(name.Reference, ...remote.Option) (*remote.Descriptor, error)
+		assertions  func(*testing.T, bool, error)
+	}{
+		{
+			name: "tag no longer exists",
+			tag:  "deleted-tag",
+			assertions: func(t *testing.T, available bool, err error) {
+				require.NoError(t, err)
+				require.False(t, available)
+			},
+		},
+		{
+			name: "tag exists",
+			tag:  "fake-tag",
+			assertions: func(t *testing.T, available bool, err error) {
+				require.NoError(t, err)
+				require.True(t, available)
+			},
+		},
+		{
+			name:   "digest no longer exists",
+			tag:    "fake-tag",
+			digest: "fake-digest",
+			remoteGetFn: func(name.Reference, ...remote.Option) (*remote.Descriptor, error) {
+				return nil, &transport.Error{StatusCode: http.StatusNotFound}
+			},
+			assertions: func(t *testing.T, available bool, err error) {
+				require.NoError(t, err)
+				require.False(t, available)
+			},
+		},
+		{
+			name:   "error getting descriptor by digest",
+			digest: "fake-digest",
+			remoteGetFn: func(name.Reference, ...remote.Option) (*remote.Descriptor, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, _ bool, err error) {
+				require.ErrorContains(t, err, "error getting image descriptor for digest")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name:   "tag and digest exist",
+			tag:    "fake-tag",
+			digest: "fake-digest",
+			remoteGetFn: func(name.Reference, ...remote.Option) (*remote.Descriptor, error) {
+				return &remote.Descriptor{}, nil
+			},
+			assertions: func(t *testing.T, available bool, err error) {
+				require.NoError(t, err)
+				require.True(t, available)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			client := &repositoryClient{
+				registry: newRegistry("fake-registry"),
+				repoRef:  testRepoRef,
+				remoteListFn: func(name.Repository, ...remote.Option) ([]string, error) {
+					return []string{"fake-tag", "other-tag"}, nil
+				},
+				remoteGetFn: testCase.remoteGetFn,
+			}
+			available, err := client.isAvailable(context.Background(), testCase.tag, testCase.digest)
+			testCase.assertions(t, available, err)
+		})
+	}
+}
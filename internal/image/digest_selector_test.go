@@ -9,8 +9,10 @@ import (
 func TestNewDigestSelector(t *testing.T) {
 	const testConstraint = "fake-constraint"
 	testPlatform := &platformConstraint{
-		os:   "linux",
-		arch: "amd64",
+		include: []platformPattern{{
+			os:   "linux",
+			arch: "amd64",
+		}},
 	}
 	s, err := newDigestSelector(nil, testConstraint, testPlatform)
 	require.NoError(t, err)
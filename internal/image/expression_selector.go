@@ -0,0 +1,258 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/akuity/kargo/internal/expressions"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+// expressionSelector implements the Selector interface for
+// SelectionStrategyExpression.
+type expressionSelector struct {
+	repoClient     *repositoryClient
+	allowRegex     *regexp.Regexp
+	ignore         []string
+	sortExpression *vm.Program
+	platform       *platformConstraint
+	discoveryLimit int
+}
+
+// newExpressionSelector returns an implementation of the Selector interface
+// for SelectionStrategyExpression.
+func newExpressionSelector(
+	repoClient *repositoryClient,
+	allowRegex *regexp.Regexp,
+	ignore []string,
+	sortExpression string,
+	platform *platformConstraint,
+	discoveryLimit int,
+) (Selector, error) {
+	if sortExpression == "" {
+		return nil, fmt.Errorf(
+			"sort expression is required for image selection strategy %q",
+			SelectionStrategyExpression,
+		)
+	}
+	program, err := compileSortExpression(sortExpression)
+	if err != nil {
+		return nil, err
+	}
+	return &expressionSelector{
+		repoClient:     repoClient,
+		allowRegex:     allowRegex,
+		ignore:         ignore,
+		sortExpression: program,
+		platform:       platform,
+		discoveryLimit: discoveryLimit,
+	}, nil
+}
+
+// ValidateSortExpression returns an error if the provided sort expression
+// cannot be compiled.
+func ValidateSortExpression(sortExpression string) error {
+	_, err := compileSortExpression(sortExpression)
+	return err
+}
+
+// compileSortExpression compiles the provided sort expression. Sort
+// expressions have access to the tag being evaluated as the variable tag.
+func compileSortExpression(sortExpression string) (*vm.Program, error) {
+	return expressions.CompileExpression(
+		sortExpression,
+		expr.Env(sortExpressionEnv("")),
+	)
+}
+
+// sortExpressionEnv returns the environment in which a sort expression is
+// evaluated for the provided tag.
+func sortExpressionEnv(tag string) map[string]any {
+	return map[string]any{"tag": tag}
+}
+
+// Select implements the Selector interface.
+func (e *expressionSelector) Select(ctx context.Context) ([]Image, error) {
+	logger := logging.LoggerFromContext(ctx).WithFields(log.Fields{
+		"registry":            e.repoClient.registry.name,
+		"image":               e.repoClient.repoURL,
+		"selectionStrategy":   SelectionStrategyExpression,
+		"platformConstrained": e.platform != nil,
+		"discoveryLimit":      e.discoveryLimit,
+	})
+	logger.Trace("discovering images")
+
+	ctx = logging.ContextWithLogger(ctx, logger)
+
+	tags, err := e.selectTags(ctx)
+	if err != nil || len(tags) == 0 {
+		return nil, err
+	}
+
+	limit := e.discoveryLimit
+	if limit == 0 || limit > len(tags) {
+		limit = len(tags)
+	}
+	images := make([]Image, 0, limit)
+
+	for _, tag := range tags {
+		if len(images) >= limit {
+			break
+		}
+
+		image, err := e.repoClient.getImageByTag(ctx, tag, e.platform)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving image with tag %q: %w", tag, err)
+		}
+		if image == nil {
+			logger.Tracef(
+				"image with tag %q was found, but did not match platform constraint",
+				tag,
+			)
+			continue
+		}
+
+		logger.WithFields(log.Fields{
+			"tag":    image.Tag,
+			"digest": image.Digest,
+		}).Trace("discovered image")
+		images = append(images, *image)
+	}
+
+	if len(images) == 0 {
+		logger.Trace("no images matched criteria")
+		return nil, nil
+	}
+
+	logger.Tracef("discovered %d images", len(images))
+	return images, nil
+}
+
+// keyedTag is a tag along with the sort key computed for it by a sort
+// expression.
+type keyedTag struct {
+	tag string
+	key any
+}
+
+// selectTags retrieves all tags from the repository, filters them based on the
+// allowRegex and ignore fields of the expressionSelector, and sorts them in
+// descending order by the key the sort expression computes for each. Tags for
+// which the sort expression fails or returns nil are skipped. If no tags match
+// the criteria, nil is returned.
+func (e *expressionSelector) selectTags(ctx context.Context) ([]string, error) {
+	logger := logging.LoggerFromContext(ctx)
+
+	tags, err := e.repoClient.getTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing tags: %w", err)
+	}
+	if len(tags) == 0 {
+		logger.Trace("found no tags")
+		return nil, nil
+	}
+	logger.Trace("got all tags")
+
+	keyedTags := make([]keyedTag, 0, len(tags))
+	for _, tag := range tags {
+		if !allowsTag(tag, e.allowRegex) || ignoresTag(tag, e.ignore) {
+			continue
+		}
+		key, err := expr.Run(e.sortExpression, sortExpressionEnv(tag))
+		if err != nil {
+			logger.Tracef("error evaluating sort expression for tag %q: %s", tag, err)
+			continue
+		}
+		if key == nil {
+			continue
+		}
+		keyedTags = append(keyedTags, keyedTag{tag: tag, key: key})
+	}
+	if len(keyedTags) == 0 {
+		logger.Trace("no tags matched criteria")
+		return nil, nil
+	}
+	logger.Tracef("%d tags matched criteria", len(keyedTags))
+
+	logger.Trace("sorting tags by sort expression")
+	if err = sortKeyedTags(keyedTags); err != nil {
+		return nil, err
+	}
+	sortedTags := make([]string, len(keyedTags))
+	for i, kt := range keyedTags {
+		sortedTags[i] = kt.tag
+	}
+	return sortedTags, nil
+}
+
+// sortKeyedTags sorts the provided tags in place, in descending order by key.
+// Ties are broken lexically using the tags themselves. All keys must be of the
+// same kind (numbers, strings, or times) or an error is returned.
+func sortKeyedTags(tags []keyedTag) error {
+	for i := range tags {
+		switch k := tags[i].key.(type) {
+		case int:
+			tags[i].key = float64(k)
+		case int64:
+			tags[i].key = float64(k)
+		case float32:
+			tags[i].key = float64(k)
+		case float64, string, time.Time:
+		default:
+			return fmt.Errorf(
+				"sort expression returned unsortable value of type %T for tag %q",
+				k,
+				tags[i].tag,
+			)
+		}
+		if i > 0 && reflect.TypeOf(tags[i].key) != reflect.TypeOf(tags[0].key) {
+			return fmt.Errorf(
+				"sort expression returned values of different types for tags %q and %q",
+				tags[0].tag,
+				tags[i].tag,
+			)
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		if comp := compareSortKeys(tags[i].key, tags[j].key); comp != 0 {
+			return comp > 0
+		}
+		return tags[i].tag > tags[j].tag
+	})
+	return nil
+}
+
+// compareSortKeys returns -1, 0, or 1 depending on whether a is less than,
+// equal to, or greater than b. Both keys must already have been normalized to
+// the same type by sortKeyedTags.
+func compareSortKeys(a, b any) int {
+	switch ak := a.(type) {
+	case float64:
+		bk := b.(float64) // nolint: forcetypeassert
+		switch {
+		case ak < bk:
+			return -1
+		case ak > bk:
+			return 1
+		}
+	case string:
+		bk := b.(string) // nolint: forcetypeassert
+		switch {
+		case ak < bk:
+			return -1
+		case ak > bk:
+			return 1
+		}
+	case time.Time:
+		return ak.Compare(b.(time.Time)) // nolint: forcetypeassert
+	}
+	return 0
+}
@@ -0,0 +1,129 @@
+package image
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExpressionSelector(t *testing.T) {
+	testAllowRegex := regexp.MustCompile("fake-regex")
+	testIgnore := []string{"fake-ignore"}
+	testPlatform := &platformConstraint{
+		include: []platformPattern{{
+			os:   "linux",
+			arch: "amd64",
+		}},
+	}
+	testDiscoveryLimit := 10
+
+	t.Run("missing sort expression", func(t *testing.T) {
+		_, err := newExpressionSelector(
+			nil, testAllowRegex, testIgnore, "", testPlatform, testDiscoveryLimit,
+		)
+		require.ErrorContains(t, err, "sort expression is required")
+	})
+
+	t.Run("invalid sort expression", func(t *testing.T) {
+		_, err := newExpressionSelector(
+			nil, testAllowRegex, testIgnore, "tag +", testPlatform, testDiscoveryLimit,
+		)
+		require.ErrorContains(t, err, "error compiling expression")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		s, err := newExpressionSelector(
+			nil, testAllowRegex, testIgnore, "len(tag)", testPlatform, testDiscoveryLimit,
+		)
+		require.NoError(t, err)
+		selector, ok := s.(*expressionSelector)
+		require.True(t, ok)
+		require.Equal(t, testAllowRegex, selector.allowRegex)
+		require.Equal(t, testIgnore, selector.ignore)
+		require.NotNil(t, selector.sortExpression)
+		require.Equal(t, testPlatform, selector.platform)
+		require.Equal(t, testDiscoveryLimit, selector.discoveryLimit)
+	})
+}
+
+func TestValidateSortExpression(t *testing.T) {
+	require.NoError(t, ValidateSortExpression(`int(regexpCapture("build-(\\d+)", tag)[1])`))
+	require.Error(t, ValidateSortExpression("tag +"))
+	require.Error(t, ValidateSortExpression("undefinedVariable"))
+}
+
+func TestSortKeyedTags(t *testing.T) {
+	testCases := []struct {
+		name       string
+		tags       []keyedTag
+		assertions func(t *testing.T, tags []keyedTag, err error)
+	}{
+		{
+			name: "numbers",
+			tags: []keyedTag{
+				{tag: "build-9", key: 9},
+				{tag: "build-10", key: 10},
+				{tag: "build-1", key: 1.5},
+			},
+			assertions: func(t *testing.T, tags []keyedTag, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "build-10", tags[0].tag)
+				require.Equal(t, "build-9", tags[1].tag)
+				require.Equal(t, "build-1", tags[2].tag)
+			},
+		},
+		{
+			name: "strings with ties",
+			tags: []keyedTag{
+				{tag: "a", key: "x"},
+				{tag: "b", key: "x"},
+				{tag: "c", key: "y"},
+			},
+			assertions: func(t *testing.T, tags []keyedTag, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "c", tags[0].tag)
+				require.Equal(t, "b", tags[1].tag)
+				require.Equal(t, "a", tags[2].tag)
+			},
+		},
+		{
+			name: "times",
+			tags: []keyedTag{
+				{tag: "old", key: time.Unix(0, 0)},
+				{tag: "new", key: time.Unix(60, 0)},
+			},
+			assertions: func(t *testing.T, tags []keyedTag, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "new", tags[0].tag)
+				require.Equal(t, "old", tags[1].tag)
+			},
+		},
+		{
+			name: "mixed types",
+			tags: []keyedTag{
+				{tag: "a", key: 1},
+				{tag: "b", key: "x"},
+			},
+			assertions: func(t *testing.T, _ []keyedTag, err error) {
+				require.ErrorContains(t, err, "values of different types")
+			},
+		},
+		{
+			name: "unsortable type",
+			tags: []keyedTag{
+				{tag: "a", key: []any{1}},
+			},
+			assertions: func(t *testing.T, _ []keyedTag, err error) {
+				require.ErrorContains(t, err, "unsortable value")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := sortKeyedTags(testCase.tags)
+			testCase.assertions(t, testCase.tags, err)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package image
+
+import (
+	"sync"
+
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// HostOptions represents operator-declared settings for a specific container
+// image registry.
+type HostOptions struct {
+	// RateLimit is the maximum number of requests per second sent to the
+	// registry. If zero, the registry's default limit applies.
+	RateLimit int
+	// CABundle is a PEM-encoded bundle of CA certificates to trust, in addition
+	// to the system's trusted CAs, when connecting to the registry.
+	CABundle []byte
+	// Mirror is the host of a registry that mirrors the registry. If
+	// specified, images are read from the mirror instead of the registry.
+	Mirror string
+}
+
+var (
+	// hostOptions is a map of HostOptions indexed by registry host.
+	hostOptions map[string]HostOptions
+	// hostOptionsMu is for preventing concurrent access to the hostOptions map.
+	hostOptionsMu sync.RWMutex
+)
+
+// ConfigureHosts replaces all previously configured HostOptions with the
+// provided ones, which are indexed by registry host. Settings take effect for
+// all repository clients created after this function returns.
+func ConfigureHosts(opts map[string]HostOptions) {
+	normalized := make(map[string]HostOptions, len(opts))
+	for host, o := range opts {
+		// Docker Hub is commonly referred to as docker.io, but its registry
+		// host is index.docker.io.
+		if host == "docker.io" {
+			host = name.DefaultRegistry
+		}
+		normalized[host] = o
+	}
+
+	hostOptionsMu.Lock()
+	hostOptions = normalized
+	hostOptionsMu.Unlock()
+
+	registriesMu.Lock()
+	defer registriesMu.Unlock()
+	for _, reg := range registries {
+		reg.applyHostOptions(getHostOptions(reg.imagePrefix))
+	}
+}
+
+// getHostOptions returns the HostOptions configured for the specified
+// registry host. If none are configured, zero-valued HostOptions are returned.
+func getHostOptions(host string) HostOptions {
+	hostOptionsMu.RLock()
+	defer hostOptionsMu.RUnlock()
+	return hostOptions[host]
+}
@@ -0,0 +1,29 @@
+package image
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureHosts(t *testing.T) {
+	t.Cleanup(func() {
+		ConfigureHosts(nil)
+	})
+	ConfigureHosts(map[string]HostOptions{
+		"docker.io": {
+			RateLimit: 50,
+		},
+		"registry.example.com": {
+			Mirror: "mirror.example.com",
+		},
+	})
+	// Docker Hub settings should have been normalized to its registry host
+	require.Equal(t, HostOptions{RateLimit: 50}, getHostOptions("index.docker.io"))
+	require.Equal(
+		t,
+		HostOptions{Mirror: "mirror.example.com"},
+		getHostOptions("registry.example.com"),
+	)
+	require.Equal(t, HostOptions{}, getHostOptions("ghcr.io"))
+}
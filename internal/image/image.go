@@ -6,12 +6,26 @@ import (
 	"github.com/Masterminds/semver/v3"
 )
 
+// revisionLabel is the key of the standard OCI image label that records the
+// revision of the source code an image was built from.
+const revisionLabel = "org.opencontainers.image.revision"
+
+// createdAnnotation is the key of the standard OCI annotation that records the
+// date and time an image was built. It may be set on an image index as well as
+// on an image manifest.
+const createdAnnotation = "org.opencontainers.image.created"
+
 // Image is a representation of a container image.
 type Image struct {
 	Tag       string
 	Digest    string
 	CreatedAt *time.Time
-	semVer    *semver.Version
+	// Revision is the revision of the source code the image was built from, as
+	// recorded by the image's org.opencontainers.image.revision label or, when
+	// the creation date was read from annotations, annotation. It is empty if
+	// the image has no such label.
+	Revision string
+	semVer   *semver.Version
 }
 
 // newImage initializes and returns an Image.
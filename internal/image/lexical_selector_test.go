@@ -11,8 +11,10 @@ func TestNewLexicalSelector(t *testing.T) {
 	testAllowRegex := regexp.MustCompile("fake-regex")
 	testIgnore := []string{"fake-ignore"}
 	testPlatform := &platformConstraint{
-		os:   "linux",
-		arch: "amd64",
+		include: []platformPattern{{
+			os:   "linux",
+			arch: "amd64",
+		}},
 	}
 	testDiscoveryLimit := 10
 	s := newLexicalSelector(nil, testAllowRegex, testIgnore, testPlatform, testDiscoveryLimit)
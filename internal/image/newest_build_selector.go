@@ -2,6 +2,7 @@ package image
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"sort"
@@ -118,6 +119,25 @@ func (n *newestBuildSelector) Select(ctx context.Context) ([]Image, error) {
 func (n *newestBuildSelector) selectImages(ctx context.Context) ([]Image, error) {
 	logger := logging.LoggerFromContext(ctx)
 
+	// If the registry offers an API for listing images along with their push
+	// times, we can avoid retrieving the manifest and config blob for every tag.
+	// Push times are not creation dates, however, so this is skipped when the
+	// creation dates recorded by the images' annotations are preferred.
+	if !n.repoClient.preferCreatedAnnotation {
+		images, err := n.repoClient.listImagesFn(ctx)
+		switch {
+		case err == nil:
+			logger.Trace("listed images using registry-specific API")
+			return n.filterAndSortImages(ctx, images), nil
+		case !errors.Is(err, errRegistryAPIUnsupported):
+			logger.Debugf(
+				"error listing images using registry-specific API; falling back to "+
+					"retrieving images by tag: %s",
+				err,
+			)
+		}
+	}
+
 	tags, err := n.repoClient.getTags(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("error listing tags: %w", err)
@@ -158,6 +178,31 @@ func (n *newestBuildSelector) selectImages(ctx context.Context) ([]Image, error)
 	return images, nil
 }
 
+// filterAndSortImages filters the provided Images based on the allowRegex and
+// ignore fields of the newestBuildSelector and sorts the remainder by date. If
+// no Images match the criteria, nil is returned.
+func (n *newestBuildSelector) filterAndSortImages(
+	ctx context.Context,
+	images []Image,
+) []Image {
+	logger := logging.LoggerFromContext(ctx)
+	matchedImages := make([]Image, 0, len(images))
+	for _, image := range images {
+		if allowsTag(image.Tag, n.allowRegex) && !ignoresTag(image.Tag, n.ignore) {
+			matchedImages = append(matchedImages, image)
+		}
+	}
+	if len(matchedImages) == 0 {
+		logger.Trace("no tags matched criteria")
+		return nil
+	}
+	logger.Tracef("%d tags matched criteria", len(matchedImages))
+
+	logger.Trace("sorting images by date")
+	sortImagesByDate(matchedImages)
+	return matchedImages
+}
+
 // getImagesByTags returns Image structs for the provided tags. Since the number
 // of tags can often be large, this is done concurrently, with a package-level
 // semaphore being used to limit the total number of running goroutines. The
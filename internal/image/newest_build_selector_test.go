@@ -1,10 +1,14 @@
 package image
 
 import (
+	"context"
+	"errors"
 	"regexp"
 	"testing"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/stretchr/testify/require"
 )
 
@@ -12,8 +16,10 @@ func TestNewNewestBuildSelector(t *testing.T) {
 	testAllowRegex := regexp.MustCompile("fake-regex")
 	testIgnore := []string{"fake-ignore"}
 	testPlatform := &platformConstraint{
-		os:   "linux",
-		arch: "amd64",
+		include: []platformPattern{{
+			os:   "linux",
+			arch: "amd64",
+		}},
 	}
 	testDiscoveryLimit := 10
 	s := newNewestBuildSelector(nil, testAllowRegex, testIgnore, testPlatform, testDiscoveryLimit)
@@ -25,6 +31,50 @@ func TestNewNewestBuildSelector(t *testing.T) {
 	require.Equal(t, testDiscoveryLimit, selector.discoveryLimit)
 }
 
+func TestNewestBuildSelectorSelectImagesWithRegistryAPI(t *testing.T) {
+	now := time.Now().UTC()
+	older := now.Add(-time.Hour)
+	s := &newestBuildSelector{
+		repoClient: &repositoryClient{
+			listImagesFn: func(context.Context) ([]Image, error) {
+				return []Image{
+					newImage("old", "sha256:old", &older),
+					newImage("ignored", "sha256:ignored", &now),
+					newImage("new", "sha256:new", &now),
+				}, nil
+			},
+		},
+		ignore: []string{"ignored"},
+	}
+	images, err := s.selectImages(context.Background())
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+	require.Equal(t, "new", images[0].Tag)
+	require.Equal(t, "old", images[1].Tag)
+}
+
+func TestNewestBuildSelectorSelectImagesPreferringCreatedAnnotation(t *testing.T) {
+	testRepoRef, err := name.ParseReference("fake-url")
+	require.NoError(t, err)
+	s := &newestBuildSelector{
+		repoClient: &repositoryClient{
+			registry:                newRegistry("fake-registry"),
+			repoRef:                 testRepoRef,
+			sharedKey:               "fake-url",
+			preferCreatedAnnotation: true,
+			listImagesFn: func(context.Context) ([]Image, error) {
+				return nil, errors.New("registry API should not have been used")
+			},
+			remoteListFn: func(name.Repository, ...remote.Option) ([]string, error) {
+				return nil, nil
+			},
+		},
+	}
+	images, err := s.selectImages(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, images)
+}
+
 func TestSortImagesByDate(t *testing.T) {
 	timePtr := func(t time.Time) *time.Time {
 		return &t
@@ -2,12 +2,28 @@ package image
 
 import (
 	"fmt"
+	"path"
 	"strings"
 )
 
-// platformConstraint represents an operating system, system architecture, and
-// (optionally) variant thereof that can be used to filter images by platform.
+// platformConstraint represents a set of platforms that can be used to filter
+// images by platform. A constraint is expressed as a comma-delimited list of
+// platform patterns of the form <os>/<arch>[/<variant>]. Each component of a
+// pattern may contain shell-style wildcards (e.g. linux/*). Patterns prefixed
+// with a "!" exclude matching platforms. A platform satisfies the constraint
+// if it matches at least one inclusive pattern (or there are no inclusive
+// patterns) and matches no exclusive patterns. e.g. "linux/*,!linux/386"
+// matches all Linux platforms without a variant, except for linux/386.
 type platformConstraint struct {
+	// raw is the original string representation of the constraint.
+	raw     string
+	include []platformPattern
+	exclude []platformPattern
+}
+
+// platformPattern represents a single operating system, system architecture,
+// and (optionally) variant thereof, any of which may contain wildcards.
+type platformPattern struct {
 	os      string
 	arch    string
 	variant string
@@ -15,6 +31,21 @@ type platformConstraint struct {
 
 // String implements fmt.Stringer.
 func (p *platformConstraint) String() string {
+	if p.raw != "" {
+		return p.raw
+	}
+	terms := make([]string, 0, len(p.include)+len(p.exclude))
+	for _, i := range p.include {
+		terms = append(terms, i.String())
+	}
+	for _, e := range p.exclude {
+		terms = append(terms, "!"+e.String())
+	}
+	return strings.Join(terms, ",")
+}
+
+// String implements fmt.Stringer.
+func (p platformPattern) String() string {
 	if p.variant == "" {
 		return fmt.Sprintf("%s/%s", p.os, p.arch)
 	}
@@ -31,24 +62,96 @@ func ValidatePlatformConstraint(platformStr string) bool {
 // parsePlatformConstraint parses a the provided platform constraint string
 // and returns a platformConstraint struct.
 func parsePlatformConstraint(platformStr string) (platformConstraint, error) {
-	tokens := strings.SplitN(platformStr, "/", 3)
-	if len(tokens) < 2 {
-		return platformConstraint{}, fmt.Errorf("error parsing platform constraint %q", platformStr)
-	}
 	platform := platformConstraint{
+		raw: platformStr,
+	}
+	for _, term := range strings.Split(platformStr, ",") {
+		term = strings.TrimSpace(term)
+		exclude := strings.HasPrefix(term, "!")
+		if exclude {
+			term = strings.TrimSpace(strings.TrimPrefix(term, "!"))
+		}
+		pattern, err := parsePlatformPattern(term)
+		if err != nil {
+			return platformConstraint{},
+				fmt.Errorf("error parsing platform constraint %q: %w", platformStr, err)
+		}
+		if exclude {
+			platform.exclude = append(platform.exclude, pattern)
+		} else {
+			platform.include = append(platform.include, pattern)
+		}
+	}
+	return platform, nil
+}
+
+// parsePlatformPattern parses a single platform pattern of the form
+// <os>/<arch>[/<variant>] and returns a platformPattern struct.
+func parsePlatformPattern(patternStr string) (platformPattern, error) {
+	tokens := strings.Split(patternStr, "/")
+	if len(tokens) < 2 || len(tokens) > 3 {
+		return platformPattern{}, fmt.Errorf(
+			"platform %q is not of the form <os>/<arch>[/<variant>]", patternStr,
+		)
+	}
+	for _, token := range tokens {
+		if token == "" {
+			return platformPattern{}, fmt.Errorf(
+				"platform %q contains an empty component", patternStr,
+			)
+		}
+		if _, err := path.Match(token, ""); err != nil {
+			return platformPattern{}, fmt.Errorf(
+				"platform %q contains an invalid pattern: %w", patternStr, err,
+			)
+		}
+	}
+	pattern := platformPattern{
 		os:   tokens[0],
 		arch: tokens[1],
 	}
 	if len(tokens) == 3 {
-		platform.variant = tokens[2]
+		pattern.variant = tokens[2]
 	}
-	return platform, nil
+	return pattern, nil
 }
 
 // matches returns a boolean indicating whether the provided operating system,
 // system architecture, and variant satisfy the platform constraint.
 func (p *platformConstraint) matches(os, arch, variant string) bool {
-	return p.os == os &&
-		p.arch == arch &&
-		p.variant == variant
+	for _, e := range p.exclude {
+		if e.matches(os, arch, variant) {
+			return false
+		}
+	}
+	if len(p.include) == 0 {
+		return true
+	}
+	for _, i := range p.include {
+		if i.matches(os, arch, variant) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns a boolean indicating whether the provided operating system,
+// system architecture, and variant match the platform pattern. A pattern
+// without a variant only matches platforms without a variant.
+func (p platformPattern) matches(os, arch, variant string) bool {
+	return matchPlatformComponent(p.os, os) &&
+		matchPlatformComponent(p.arch, arch) &&
+		matchPlatformComponent(p.variant, variant)
+}
+
+// matchPlatformComponent returns a boolean indicating whether the provided
+// platform component (os, arch, or variant) matches the pattern. Patterns have
+// already been validated, so any error from path.Match is treated as a
+// non-match.
+func matchPlatformComponent(pattern, s string) bool {
+	if pattern == s {
+		return true
+	}
+	matched, _ := path.Match(pattern, s)
+	return matched
 }
@@ -15,20 +15,45 @@ func TestPlatformConstraintString(t *testing.T) {
 		{
 			name: "without variant",
 			platform: &platformConstraint{
-				os:   "linux",
-				arch: "amd64",
+				include: []platformPattern{{
+					os:   "linux",
+					arch: "amd64",
+				}},
 			},
 			expected: "linux/amd64",
 		},
 		{
 			name: "with variant",
 			platform: &platformConstraint{
-				os:      "linux",
-				arch:    "amd64",
-				variant: "fake-variant",
+				include: []platformPattern{{
+					os:      "linux",
+					arch:    "amd64",
+					variant: "fake-variant",
+				}},
 			},
 			expected: "linux/amd64/fake-variant",
 		},
+		{
+			name: "with inclusions and exclusions",
+			platform: &platformConstraint{
+				include: []platformPattern{{
+					os:   "linux",
+					arch: "*",
+				}},
+				exclude: []platformPattern{{
+					os:   "linux",
+					arch: "386",
+				}},
+			},
+			expected: "linux/*,!linux/386",
+		},
+		{
+			name: "parsed",
+			platform: &platformConstraint{
+				raw: "linux/*, !linux/386",
+			},
+			expected: "linux/*, !linux/386",
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -48,6 +73,26 @@ func TestValidatePlatformConstraint(t *testing.T) {
 			platformStr: "invalid",
 			valid:       false,
 		},
+		{
+			name:        "too many components",
+			platformStr: "linux/arm/v7/extra",
+			valid:       false,
+		},
+		{
+			name:        "empty component",
+			platformStr: "linux/",
+			valid:       false,
+		},
+		{
+			name:        "invalid pattern",
+			platformStr: "linux/[",
+			valid:       false,
+		},
+		{
+			name:        "invalid term in list",
+			platformStr: "linux/amd64,invalid",
+			valid:       false,
+		},
 		{
 			name:        "valid without variant",
 			platformStr: "linux/amd64",
@@ -58,6 +103,11 @@ func TestValidatePlatformConstraint(t *testing.T) {
 			platformStr: "linux/amd64/fake-variant",
 			valid:       true,
 		},
+		{
+			name:        "valid with wildcards and exclusions",
+			platformStr: "linux/*, !linux/386",
+			valid:       true,
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -88,9 +138,12 @@ func TestParsePlatformConstraint(t *testing.T) {
 			platformStr: "linux/amd64",
 			assertions: func(t *testing.T, p platformConstraint, err error) {
 				require.NoError(t, err)
-				require.Equal(t, "linux", p.os)
-				require.Equal(t, "amd64", p.arch)
-				require.Empty(t, p.variant)
+				require.Equal(
+					t,
+					[]platformPattern{{os: "linux", arch: "amd64"}},
+					p.include,
+				)
+				require.Empty(t, p.exclude)
 			},
 		},
 		{
@@ -98,9 +151,37 @@ func TestParsePlatformConstraint(t *testing.T) {
 			platformStr: "linux/amd64/fake-variant",
 			assertions: func(t *testing.T, p platformConstraint, err error) {
 				require.NoError(t, err)
-				require.Equal(t, "linux", p.os)
-				require.Equal(t, "amd64", p.arch)
-				require.Equal(t, "fake-variant", p.variant)
+				require.Equal(
+					t,
+					[]platformPattern{{
+						os:      "linux",
+						arch:    "amd64",
+						variant: "fake-variant",
+					}},
+					p.include,
+				)
+				require.Empty(t, p.exclude)
+			},
+		},
+		{
+			name:        "valid with inclusions and exclusions",
+			platformStr: "linux/*, windows/amd64, !linux/386",
+			assertions: func(t *testing.T, p platformConstraint, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "linux/*, windows/amd64, !linux/386", p.raw)
+				require.Equal(
+					t,
+					[]platformPattern{
+						{os: "linux", arch: "*"},
+						{os: "windows", arch: "amd64"},
+					},
+					p.include,
+				)
+				require.Equal(
+					t,
+					[]platformPattern{{os: "linux", arch: "386"}},
+					p.exclude,
+				)
 			},
 		},
 	}
@@ -114,40 +195,87 @@ func TestParsePlatformConstraint(t *testing.T) {
 
 func TestPlatformConstraintMatches(t *testing.T) {
 	testCases := []struct {
-		name       string
-		os         string
-		arch       string
-		variant    string
-		constraint *platformConstraint
-		matches    bool
+		name          string
+		os            string
+		arch          string
+		variant       string
+		constraintStr string
+		matches       bool
 	}{
 		{
-			name: "matches",
-			os:   "linux",
-			arch: "amd64",
-			constraint: &platformConstraint{
-				os:   "linux",
-				arch: "amd64",
-			},
-			matches: true,
+			name:          "matches",
+			os:            "linux",
+			arch:          "amd64",
+			constraintStr: "linux/amd64",
+			matches:       true,
 		},
 		{
-			name: "does not match",
-			os:   "linux",
-			arch: "arm64",
-			constraint: &platformConstraint{
-				os:   "linux",
-				arch: "amd64",
-			},
-			matches: false,
+			name:          "does not match",
+			os:            "linux",
+			arch:          "arm64",
+			constraintStr: "linux/amd64",
+			matches:       false,
+		},
+		{
+			name:          "does not match variant",
+			os:            "linux",
+			arch:          "arm64",
+			variant:       "v8",
+			constraintStr: "linux/arm64",
+			matches:       false,
+		},
+		{
+			name:          "matches wildcard",
+			os:            "linux",
+			arch:          "arm64",
+			constraintStr: "linux/*",
+			matches:       true,
+		},
+		{
+			name:          "matches wildcard variant",
+			os:            "linux",
+			arch:          "arm",
+			variant:       "v7",
+			constraintStr: "linux/arm/*",
+			matches:       true,
+		},
+		{
+			name:          "matches one of several",
+			os:            "windows",
+			arch:          "amd64",
+			constraintStr: "linux/amd64,windows/amd64",
+			matches:       true,
+		},
+		{
+			name:          "excluded",
+			os:            "linux",
+			arch:          "386",
+			constraintStr: "linux/*,!linux/386",
+			matches:       false,
+		},
+		{
+			name:          "only exclusions, not excluded",
+			os:            "linux",
+			arch:          "amd64",
+			constraintStr: "!linux/386",
+			matches:       true,
+		},
+		{
+			name:          "only exclusions, excluded",
+			os:            "linux",
+			arch:          "386",
+			constraintStr: "!linux/386",
+			matches:       false,
 		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
+			constraint, err := parsePlatformConstraint(testCase.constraintStr)
+			require.NoError(t, err)
 			require.Equal(
 				t,
 				testCase.matches,
-				testCase.constraint.matches(
+				constraint.matches(
 					testCase.os,
 					testCase.arch,
 					testCase.variant,
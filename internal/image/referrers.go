@@ -0,0 +1,156 @@
+package image
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/patrickmn/go-cache"
+)
+
+// referrerCacheKeyPrefix is the prefix of the keys under which referrers are
+// cached alongside images.
+const referrerCacheKeyPrefix = "referrer:"
+
+// maxReferrerContentSize is the maximum number of bytes of a referrer
+// artifact's content that will be read.
+const maxReferrerContentSize = 1 << 20 // 1 MiB
+
+// Referrer represents an artifact, such as a build provenance attestation,
+// that refers to an image by way of the OCI referrers API.
+type Referrer struct {
+	// Digest is the digest of the referrer's manifest.
+	Digest string
+	// Annotations are the annotations of the referrer's manifest.
+	Annotations map[string]string
+	// Content is the JSON content of the referrer's first layer, decoded into
+	// its generic representation. If the content is a DSSE envelope, as is the
+	// case for in-toto attestations, it is the envelope's decoded payload
+	// instead.
+	Content any
+}
+
+// GetReferrer returns the first artifact of the provided artifact type that
+// refers to the image with the provided digest in the specified repository. If
+// no such artifact exists, nil is returned.
+func GetReferrer(
+	ctx context.Context,
+	repoURL string,
+	digest string,
+	artifactType string,
+	insecureSkipTLSVerify bool,
+	creds *Credentials,
+) (*Referrer, error) {
+	repoClient, err := newRepositoryClient(repoURL, insecureSkipTLSVerify, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating repository client: %w", err)
+	}
+	return repoClient.getReferrer(ctx, digest, artifactType)
+}
+
+// getReferrer returns the first artifact of the provided artifact type that
+// refers to the image with the provided digest. If no such artifact exists,
+// nil is returned.
+func (r *repositoryClient) getReferrer(
+	ctx context.Context,
+	digest string,
+	artifactType string,
+) (*Referrer, error) {
+	opts := append(r.remoteOptions, remote.WithContext(ctx))
+	idx, err := r.remoteReferrersFn(
+		r.repoRef.Context().Digest(digest),
+		append(opts, remote.WithFilter("artifactType", artifactType))...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error listing referrers of digest %s from repo URL %s: %w",
+			digest, r.repoURL, err,
+		)
+	}
+	// Registries are not required to honor the filter, so it is applied again
+	// here.
+	for _, desc := range idx.Manifests {
+		if desc.ArtifactType != artifactType {
+			continue
+		}
+		// The content of a referrer is addressed by its digest and therefore
+		// never changes, so it can be cached. Which referrers an image has,
+		// however, can change, so the listing above is never cached.
+		cacheKey := referrerCacheKeyPrefix + desc.Digest.String()
+		if entry, exists := r.registry.imageCache.Get(cacheKey); exists {
+			referrer := entry.(Referrer) // nolint: forcetypeassert
+			return &referrer, nil
+		}
+		img, err := r.remoteImageFn(
+			r.repoRef.Context().Digest(desc.Digest.String()),
+			opts...,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error getting referrer %s from repo URL %s: %w",
+				desc.Digest, r.repoURL, err,
+			)
+		}
+		content, err := getReferrerContent(img)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error reading content of referrer %s from repo URL %s: %w",
+				desc.Digest, r.repoURL, err,
+			)
+		}
+		referrer := Referrer{
+			Digest:      desc.Digest.String(),
+			Annotations: desc.Annotations,
+			Content:     content,
+		}
+		r.registry.imageCache.Set(cacheKey, referrer, cache.DefaultExpiration)
+		return &referrer, nil
+	}
+	return nil, nil
+}
+
+// getReferrerContent reads the JSON content of the first layer of the provided
+// referrer artifact and decodes it into its generic representation. If the
+// content is a DSSE envelope, its payload is decoded instead.
+func getReferrerContent(img v1.Image) (any, error) {
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("error getting layers: %w", err)
+	}
+	if len(layers) == 0 {
+		return nil, errors.New("referrer has no layers")
+	}
+	rc, err := layers[0].Uncompressed()
+	if err != nil {
+		return nil, fmt.Errorf("error reading layer: %w", err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, maxReferrerContentSize))
+	if err != nil {
+		return nil, fmt.Errorf("error reading layer: %w", err)
+	}
+	var content any
+	if err = json.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("error unmarshaling layer as JSON: %w", err)
+	}
+	envelope, ok := content.(map[string]any)
+	if !ok {
+		return content, nil
+	}
+	payload, ok := envelope["payload"].(string)
+	if _, isDSSE := envelope["payloadType"]; !ok || !isDSSE {
+		return content, nil
+	}
+	if data, err = base64.StdEncoding.DecodeString(payload); err != nil {
+		return nil, fmt.Errorf("error decoding DSSE envelope payload: %w", err)
+	}
+	if err = json.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("error unmarshaling DSSE envelope payload as JSON: %w", err)
+	}
+	return content, nil
+}
@@ -0,0 +1,214 @@
+package image
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReferrer(t *testing.T) {
+	const testArtifactType = "application/vnd.in-toto+json"
+	const testDigest = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	testRepoRef, err := name.ParseReference("fake-url")
+	require.NoError(t, err)
+	testReferrerDigest := v1.Hash{
+		Algorithm: "sha256",
+		Hex:       "1111111111111111111111111111111111111111111111111111111111111111",
+	}
+	testIndex := &v1.IndexManifest{
+		Manifests: []v1.Descriptor{
+			{
+				// Registries are not required to honor the filter, so this should
+				// be skipped.
+				ArtifactType: "application/vnd.dev.sigstore.bundle+json",
+			},
+			{
+				ArtifactType: testArtifactType,
+				Digest:       testReferrerDigest,
+				Annotations:  map[string]string{"foo": "bar"},
+			},
+		},
+	}
+	newReferrerImage := func(t *testing.T, content string) v1.Image {
+		img, err := mutate.AppendLayers(
+			empty.Image,
+			static.NewLayer([]byte(content), types.MediaType(testArtifactType)),
+		)
+		require.NoError(t, err)
+		return img
+	}
+
+	testCases := []struct {
+		name              string
+		remoteReferrersFn func(name.Digest, ...remote.Option) (*v1.IndexManifest, error)
+		remoteImageFn     func(name.Reference, ...remote.Option) (v1.Image, error)
+		assertions        func(*testing.T, *Referrer, error)
+	}{
+		{
+			name: "error listing referrers",
+			remoteReferrersFn: func(name.Digest, ...remote.Option) (*v1.IndexManifest, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, _ *Referrer, err error) {
+				require.ErrorContains(t, err, "error listing referrers of digest")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "no matching referrer",
+			remoteReferrersFn: func(name.Digest, ...remote.Option) (*v1.IndexManifest, error) {
+				return &v1.IndexManifest{}, nil
+			},
+			assertions: func(t *testing.T, referrer *Referrer, err error) {
+				require.NoError(t, err)
+				require.Nil(t, referrer)
+			},
+		},
+		{
+			name: "error getting referrer",
+			remoteReferrersFn: func(name.Digest, ...remote.Option) (*v1.IndexManifest, error) {
+				return testIndex, nil
+			},
+			remoteImageFn: func(name.Reference, ...remote.Option) (v1.Image, error) {
+				return nil, errors.New("something went wrong")
+			},
+			assertions: func(t *testing.T, _ *Referrer, err error) {
+				require.ErrorContains(t, err, "error getting referrer")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "referrer content is not JSON",
+			remoteReferrersFn: func(name.Digest, ...remote.Option) (*v1.IndexManifest, error) {
+				return testIndex, nil
+			},
+			remoteImageFn: func(name.Reference, ...remote.Option) (v1.Image, error) {
+				return newReferrerImage(t, "not json"), nil
+			},
+			assertions: func(t *testing.T, _ *Referrer, err error) {
+				require.ErrorContains(t, err, "error reading content of referrer")
+				require.ErrorContains(t, err, "error unmarshaling layer as JSON")
+			},
+		},
+		{
+			name: "plain JSON content",
+			remoteReferrersFn: func(name.Digest, ...remote.Option) (*v1.IndexManifest, error) {
+				return testIndex, nil
+			},
+			remoteImageFn: func(ref name.Reference, _ ...remote.Option) (v1.Image, error) {
+				require.Equal(t, testReferrerDigest.String(), ref.Identifier())
+				return newReferrerImage(t, `{"buildURL":"https://ci.example.com/1"}`), nil
+			},
+			assertions: func(t *testing.T, referrer *Referrer, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					&Referrer{
+						Digest:      testReferrerDigest.String(),
+						Annotations: map[string]string{"foo": "bar"},
+						Content: map[string]any{
+							"buildURL": "https://ci.example.com/1",
+						},
+					},
+					referrer,
+				)
+			},
+		},
+		{
+			name: "DSSE envelope content",
+			remoteReferrersFn: func(name.Digest, ...remote.Option) (*v1.IndexManifest, error) {
+				return testIndex, nil
+			},
+			remoteImageFn: func(name.Reference, ...remote.Option) (v1.Image, error) {
+				payload := base64.StdEncoding.EncodeToString(
+					[]byte(`{"predicate":{"commit":"abc123"}}`),
+				)
+				return newReferrerImage(
+					t,
+					`{"payloadType":"application/vnd.in-toto+json","payload":"`+payload+`"}`,
+				), nil
+			},
+			assertions: func(t *testing.T, referrer *Referrer, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					map[string]any{
+						"predicate": map[string]any{"commit": "abc123"},
+					},
+					referrer.Content,
+				)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			client := &repositoryClient{
+				registry: &registry{
+					imageCache: cache.New(30*time.Minute, time.Hour),
+				},
+				repoRef:           testRepoRef,
+				remoteReferrersFn: testCase.remoteReferrersFn,
+				remoteImageFn:     testCase.remoteImageFn,
+			}
+			referrer, err := client.getReferrer(
+				context.Background(),
+				testDigest,
+				testArtifactType,
+			)
+			testCase.assertions(t, referrer, err)
+		})
+	}
+}
+
+func TestGetReferrerIsCached(t *testing.T) {
+	const testArtifactType = "application/vnd.in-toto+json"
+	testRepoRef, err := name.ParseReference("fake-url")
+	require.NoError(t, err)
+	var imageCalls int
+	client := &repositoryClient{
+		registry: &registry{
+			imageCache: cache.New(30*time.Minute, time.Hour),
+		},
+		repoRef: testRepoRef,
+		remoteReferrersFn: func(name.Digest, ...remote.Option) (*v1.IndexManifest, error) {
+			return &v1.IndexManifest{
+				Manifests: []v1.Descriptor{{
+					ArtifactType: testArtifactType,
+					Digest: v1.Hash{
+						Algorithm: "sha256",
+						Hex:       "1111111111111111111111111111111111111111111111111111111111111111",
+					},
+				}},
+			}, nil
+		},
+		remoteImageFn: func(name.Reference, ...remote.Option) (v1.Image, error) {
+			imageCalls++
+			return mutate.AppendLayers(
+				empty.Image,
+				static.NewLayer([]byte(`{"commit":"abc123"}`), types.MediaType(testArtifactType)),
+			)
+		},
+	}
+	for i := 0; i < 2; i++ {
+		referrer, err := client.getReferrer(
+			context.Background(),
+			"sha256:0000000000000000000000000000000000000000000000000000000000000000",
+			testArtifactType,
+		)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"commit": "abc123"}, referrer.Content)
+	}
+	require.Equal(t, 1, imageCalls)
+}
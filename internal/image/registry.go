@@ -1,14 +1,25 @@
 package image
 
 import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/patrickmn/go-cache"
 	"go.uber.org/ratelimit"
+	"golang.org/x/sync/singleflight"
 )
 
+// sharedResultTTL is how long the results of requests for mutable information
+// (e.g. a repository's tags) are shared among all clients of a registry before
+// being requested again. This prevents many Warehouses subscribed to the same
+// repository from each independently scanning the repository.
+const sharedResultTTL = time.Minute
+
 // dockerRegistry is registry configuration for Docker Hub.
 var dockerRegistry = &registry{
 	name:             "Docker Hub",
@@ -18,7 +29,67 @@ var dockerRegistry = &registry{
 		30*time.Minute, // Default ttl for each entry
 		time.Hour,      // Cleanup interval
 	),
-	rateLimiter: ratelimit.New(10),
+	sharedResults: cache.New(
+		sharedResultTTL, // Default ttl for each entry
+		time.Minute,     // Cleanup interval
+	),
+	responseCache: cache.New(
+		cachedResponseTTL, // Default ttl for each entry
+		time.Hour,         // Cleanup interval
+	),
+	defaultRateLimit: 10,
+	rateLimiter:      ratelimit.New(10),
+	backoff:          &registryBackoff{},
+	api:              registryAPIGeneric,
+}
+
+// ghcrRegistry is registry configuration for the GitHub Container Registry.
+// GHCR issues anonymous bearer tokens for public images, but aggressively
+// rate limits anonymous clients.
+var ghcrRegistry = &registry{
+	name:        "GitHub Container Registry",
+	imagePrefix: "ghcr.io",
+	imageCache: cache.New(
+		30*time.Minute, // Default ttl for each entry
+		time.Hour,      // Cleanup interval
+	),
+	sharedResults: cache.New(
+		sharedResultTTL, // Default ttl for each entry
+		time.Minute,     // Cleanup interval
+	),
+	responseCache: cache.New(
+		cachedResponseTTL, // Default ttl for each entry
+		time.Hour,         // Cleanup interval
+	),
+	defaultRateLimit: 10,
+	rateLimiter:      ratelimit.New(10),
+	backoff:          &registryBackoff{},
+	api:              registryAPIGeneric,
+}
+
+// ecrPublicRegistry is registry configuration for the Amazon ECR Public
+// Gallery. ECR Public issues anonymous bearer tokens from its own token
+// endpoint (advertised via the WWW-Authenticate header) and limits
+// unauthenticated clients to a small number of requests per second.
+var ecrPublicRegistry = &registry{
+	name:        "ECR Public Gallery",
+	imagePrefix: "public.ecr.aws",
+	imageCache: cache.New(
+		30*time.Minute, // Default ttl for each entry
+		time.Hour,      // Cleanup interval
+	),
+	sharedResults: cache.New(
+		sharedResultTTL, // Default ttl for each entry
+		time.Minute,     // Cleanup interval
+	),
+	responseCache: cache.New(
+		cachedResponseTTL, // Default ttl for each entry
+		time.Hour,         // Cleanup interval
+	),
+	defaultRateLimit: 5,
+	rateLimiter:      ratelimit.New(5),
+	backoff:          &registryBackoff{},
+	api:              registryAPIGeneric,
 }
 
 var (
@@ -26,8 +97,10 @@ var (
 	// with known registries whose settings cannot be inferred from an image's
 	// prefix.
 	registries = map[string]*registry{
-		"":                         dockerRegistry,
-		dockerRegistry.imagePrefix: dockerRegistry,
+		"":                            dockerRegistry,
+		dockerRegistry.imagePrefix:    dockerRegistry,
+		ghcrRegistry.imagePrefix:      ghcrRegistry,
+		ecrPublicRegistry.imagePrefix: ecrPublicRegistry,
 	}
 	// registriesMu is for preventing concurrent access to the registries map.
 	registriesMu sync.Mutex
@@ -40,7 +113,32 @@ type registry struct {
 	imagePrefix      string
 	defaultNamespace string
 	imageCache       *cache.Cache
-	rateLimiter      ratelimit.Limiter
+	// sharedResults caches the results of requests for mutable information for
+	// a short time so they can be shared among all clients of the registry.
+	sharedResults *cache.Cache
+	// responseCache caches the registry's responses to requests for
+	// repositories' tags and manifests so they can be revalidated using
+	// conditional requests.
+	responseCache *cache.Cache
+	// requests coalesces concurrent, identical requests to the registry.
+	requests singleflight.Group
+	// defaultRateLimit is the maximum number of requests per second sent to the
+	// registry unless an operator has configured a different limit.
+	defaultRateLimit int
+	// settingsMu is for preventing concurrent access to settings that may be
+	// changed by operator-declared host options.
+	settingsMu  sync.RWMutex
+	rateLimiter ratelimit.Limiter
+	backoff     *registryBackoff
+
+	// apiMu is for preventing concurrent detection of the registry's API.
+	apiMu sync.Mutex
+	// api is the registry-specific API, if any, that the registry offers. If
+	// empty, it has not yet been detected.
+	api registryAPI
+	// apiDetectedAt is when the api field was last detected. If api is set but
+	// apiDetectedAt is zero, api is fixed and is never re-detected.
+	apiDetectedAt time.Time
 }
 
 // newRegistry initializes and returns a new registry.
@@ -52,9 +150,55 @@ func newRegistry(imagePrefix string) *registry {
 			30*time.Minute, // Default ttl for each entry
 			time.Hour,      // Cleanup interval
 		),
+		sharedResults: cache.New(
+			sharedResultTTL, // Default ttl for each entry
+			time.Minute,     // Cleanup interval
+		),
+		responseCache: cache.New(
+			cachedResponseTTL, // Default ttl for each entry
+			time.Hour,         // Cleanup interval
+		),
 		// TODO: Make this configurable.
-		rateLimiter: ratelimit.New(20),
+		defaultRateLimit: 20,
+		rateLimiter:      ratelimit.New(20),
+		backoff:          &registryBackoff{},
+	}
+}
+
+// getAPI returns the registry-specific API offered by the registry, using the
+// provided function to detect it if it has not been detected recently.
+func (r *registry) getAPI(
+	ctx context.Context,
+	detect func(context.Context) registryAPI,
+) registryAPI {
+	r.apiMu.Lock()
+	defer r.apiMu.Unlock()
+	if r.api != "" &&
+		(r.apiDetectedAt.IsZero() || time.Since(r.apiDetectedAt) < registryAPIDetectionTTL) {
+		return r.api
 	}
+	r.api = detect(ctx)
+	r.apiDetectedAt = time.Now()
+	return r.api
+}
+
+// getRateLimiter returns the registry's current rate limiter.
+func (r *registry) getRateLimiter() ratelimit.Limiter {
+	r.settingsMu.RLock()
+	defer r.settingsMu.RUnlock()
+	return r.rateLimiter
+}
+
+// applyHostOptions applies operator-declared settings to the registry.
+// Clients that already exist are unaffected.
+func (r *registry) applyHostOptions(opts HostOptions) {
+	rateLimit := r.defaultRateLimit
+	if opts.RateLimit > 0 {
+		rateLimit = opts.RateLimit
+	}
+	r.settingsMu.Lock()
+	defer r.settingsMu.Unlock()
+	r.rateLimiter = ratelimit.New(rateLimit)
 }
 
 // getRegistry retrieves the registry associated with the given image prefix. If
@@ -67,6 +211,98 @@ func getRegistry(imagePrefix string) *registry {
 		return registry
 	}
 	registry := newRegistry(imagePrefix)
+	registry.applyHostOptions(getHostOptions(imagePrefix))
 	registries[registry.imagePrefix] = registry
 	return registry
 }
+
+const (
+	// minRateLimitBackoff is the shortest amount of time for which requests to a
+	// registry are paused after the registry has responded with a rate limit
+	// error that did not specify how long to wait.
+	minRateLimitBackoff = time.Second
+	// maxRateLimitBackoff is the longest amount of time for which requests to a
+	// registry are paused after the registry has responded with a rate limit
+	// error.
+	maxRateLimitBackoff = 2 * time.Minute
+)
+
+// registryBackoff tracks rate limit responses from a registry so that ALL
+// requests to that registry can be paused, with exponentially increasing
+// delays, for as long as the registry continues to rate limit us.
+type registryBackoff struct {
+	mu sync.Mutex
+	// until is the time before which no further requests should be sent to the
+	// registry.
+	until time.Time
+	// failures is the number of consecutive rate limit responses received from
+	// the registry.
+	failures int
+}
+
+// wait blocks until the registry is no longer in a backoff period or the
+// provided context is canceled, whichever comes first.
+func (b *registryBackoff) wait(ctx context.Context) error {
+	b.mu.Lock()
+	delay := time.Until(b.until)
+	b.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimited records a rate limit response from the registry and extends the
+// backoff period accordingly. If the response specified how long to wait using
+// a Retry-After header, that is honored (up to maxRateLimitBackoff).
+// Otherwise, the backoff period grows exponentially with each consecutive rate
+// limit response.
+func (b *registryBackoff) rateLimited(resp *http.Response) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	delay := retryAfter(resp)
+	if delay <= 0 {
+		delay = time.Duration(
+			float64(minRateLimitBackoff) * math.Pow(2, float64(b.failures-1)),
+		)
+	}
+	if delay > maxRateLimitBackoff {
+		delay = maxRateLimitBackoff
+	}
+	if until := time.Now().Add(delay); until.After(b.until) {
+		b.until = until
+	}
+}
+
+// succeeded records a response from the registry that was not a rate limit
+// error, resetting the exponential growth of the backoff period.
+func (b *registryBackoff) succeeded() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+}
+
+// retryAfter returns the delay specified by the Retry-After header of the
+// provided response, if any. Both the delay-seconds and HTTP-date forms of the
+// header are supported. Zero is returned if the header is absent or invalid.
+func retryAfter(resp *http.Response) time.Duration {
+	val := resp.Header.Get("Retry-After")
+	if val == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(val); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(val); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
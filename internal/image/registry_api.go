@@ -0,0 +1,298 @@
+package image
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// registryAPI identifies a registry implementation that offers a proprietary
+// API for listing a repository's tags along with the digest and push time of
+// the image each tag references. Where such an API is available, it allows
+// images to be selected by push time (i.e. using SelectionStrategyNewestBuild)
+// without retrieving a manifest and config blob for every tag.
+type registryAPI string
+
+const (
+	// registryAPIGeneric indicates that no registry-specific API is available
+	// and only the standard OCI distribution API should be used.
+	registryAPIGeneric     registryAPI = "generic"
+	registryAPIHarbor      registryAPI = "harbor"
+	registryAPIQuay        registryAPI = "quay"
+	registryAPIArtifactory registryAPI = "artifactory"
+)
+
+const (
+	// registryAPIDetectionTTL is how long the result of detecting a registry's
+	// API is cached before detection is attempted again.
+	registryAPIDetectionTTL = time.Hour
+	// registryAPIPageSize is the number of results requested per page from
+	// registry-specific APIs.
+	registryAPIPageSize = 100
+	// maxRegistryAPIPages is a safeguard against paginating endlessly through a
+	// misbehaving registry-specific API.
+	maxRegistryAPIPages = 1000
+)
+
+// errRegistryAPIUnsupported is returned when a registry does not offer a
+// registry-specific API for listing images.
+var errRegistryAPIUnsupported = errors.New("registry does not offer an API for listing images")
+
+// registryAPIClient is a minimal client for registry-specific HTTP APIs.
+type registryAPIClient struct {
+	// baseURL is the scheme and host of the registry, e.g.
+	// https://harbor.example.com.
+	baseURL    string
+	httpClient *http.Client
+	creds      *Credentials
+}
+
+// imageLister lists all tags in the repository at the provided path (relative
+// to the registry) along with the digest and push time of the image each tag
+// references.
+type imageLister func(
+	ctx context.Context,
+	c *registryAPIClient,
+	repoPath string,
+) ([]Image, error)
+
+// imageListers maps registry APIs to their imageLister implementations.
+var imageListers = map[registryAPI]imageLister{
+	registryAPIHarbor:      listHarborImages,
+	registryAPIQuay:        listQuayImages,
+	registryAPIArtifactory: listArtifactoryImages,
+}
+
+// detectRegistryAPI probes the registry to determine whether it offers a
+// registry-specific API for listing images. Probes are unauthenticated and
+// any probe that fails is simply treated as a negative result.
+func detectRegistryAPI(ctx context.Context, c *registryAPIClient) registryAPI {
+	u, err := url.Parse(c.baseURL)
+	if err == nil && u.Hostname() == "quay.io" {
+		return registryAPIQuay
+	}
+	var harborInfo struct {
+		HarborVersion string `json:"harbor_version"`
+	}
+	if err = c.getJSON(ctx, "/api/v2.0/systeminfo", "", false, &harborInfo); err == nil &&
+		harborInfo.HarborVersion != "" {
+		return registryAPIHarbor
+	}
+	if body, err := c.get(ctx, "/api/v1/discovery", "", false); err == nil &&
+		strings.Contains(strings.ToLower(string(body)), "quay") {
+		return registryAPIQuay
+	}
+	if body, err := c.get(ctx, "/artifactory/api/system/ping", "", false); err == nil &&
+		strings.TrimSpace(string(body)) == "OK" {
+		return registryAPIArtifactory
+	}
+	return registryAPIGeneric
+}
+
+// listHarborImages lists images using Harbor's artifacts API. The first
+// component of the repository path is the Harbor project.
+func listHarborImages(
+	ctx context.Context,
+	c *registryAPIClient,
+	repoPath string,
+) ([]Image, error) {
+	project, repo, ok := strings.Cut(repoPath, "/")
+	if !ok {
+		return nil, fmt.Errorf("Harbor repository %q does not include a project", repoPath)
+	}
+	// Harbor requires slashes in repository names to be double-encoded.
+	path := fmt.Sprintf(
+		"/api/v2.0/projects/%s/repositories/%s/artifacts",
+		url.PathEscape(project),
+		url.PathEscape(url.PathEscape(repo)),
+	)
+	var images []Image
+	for page := 1; page <= maxRegistryAPIPages; page++ {
+		var artifacts []struct {
+			Digest   string    `json:"digest"`
+			PushTime time.Time `json:"push_time"`
+			Tags     []struct {
+				Name     string    `json:"name"`
+				PushTime time.Time `json:"push_time"`
+			} `json:"tags"`
+		}
+		if err := c.getJSON(
+			ctx,
+			path,
+			url.Values{
+				"with_tag":  []string{"true"},
+				"page":      []string{strconv.Itoa(page)},
+				"page_size": []string{strconv.Itoa(registryAPIPageSize)},
+			}.Encode(),
+			true,
+			&artifacts,
+		); err != nil {
+			return nil, err
+		}
+		for _, artifact := range artifacts {
+			for _, tag := range artifact.Tags {
+				pushTime := tag.PushTime
+				if pushTime.IsZero() {
+					pushTime = artifact.PushTime
+				}
+				images = append(images, newImage(tag.Name, artifact.Digest, &pushTime))
+			}
+		}
+		if len(artifacts) < registryAPIPageSize {
+			return images, nil
+		}
+	}
+	return nil, fmt.Errorf("exceeded %d pages listing Harbor artifacts", maxRegistryAPIPages)
+}
+
+// listQuayImages lists images using Quay's tags API. The first component of
+// the repository path is the Quay namespace.
+func listQuayImages(
+	ctx context.Context,
+	c *registryAPIClient,
+	repoPath string,
+) ([]Image, error) {
+	path := fmt.Sprintf("/api/v1/repository/%s/tag/", repoPath)
+	var images []Image
+	for page := 1; page <= maxRegistryAPIPages; page++ {
+		var res struct {
+			Tags []struct {
+				Name           string `json:"name"`
+				ManifestDigest string `json:"manifest_digest"`
+				StartTS        int64  `json:"start_ts"`
+			} `json:"tags"`
+			HasAdditional bool `json:"has_additional"`
+		}
+		if err := c.getJSON(
+			ctx,
+			path,
+			url.Values{
+				"onlyActiveTags": []string{"true"},
+				"page":           []string{strconv.Itoa(page)},
+				"limit":          []string{strconv.Itoa(registryAPIPageSize)},
+			}.Encode(),
+			true,
+			&res,
+		); err != nil {
+			return nil, err
+		}
+		for _, tag := range res.Tags {
+			pushTime := time.Unix(tag.StartTS, 0).UTC()
+			images = append(images, newImage(tag.Name, tag.ManifestDigest, &pushTime))
+		}
+		if !res.HasAdditional {
+			return images, nil
+		}
+	}
+	return nil, fmt.Errorf("exceeded %d pages listing Quay tags", maxRegistryAPIPages)
+}
+
+// listArtifactoryImages lists images using Artifactory's storage API. Only the
+// "repository path" method of accessing Docker registries is supported, so
+// the first component of the repository path is the Artifactory repository
+// key. Each tag is a folder containing either a manifest.json or (for
+// multi-platform images) a list.manifest.json file, the SHA-256 checksum of
+// which is the digest of the image.
+func listArtifactoryImages(
+	ctx context.Context,
+	c *registryAPIClient,
+	repoPath string,
+) ([]Image, error) {
+	if !strings.Contains(repoPath, "/") {
+		return nil, fmt.Errorf(
+			"Artifactory repository %q does not include a repository key", repoPath,
+		)
+	}
+	var res struct {
+		Files []struct {
+			URI          string    `json:"uri"`
+			LastModified time.Time `json:"lastModified"`
+			SHA2         string    `json:"sha2"`
+		} `json:"files"`
+	}
+	if err := c.getJSON(
+		ctx,
+		"/artifactory/api/storage/"+repoPath,
+		// Artifactory expects the list parameter to be valueless
+		"list&deep=1&depth=2&listFolders=0",
+		true,
+		&res,
+	); err != nil {
+		return nil, err
+	}
+	images := make([]Image, 0, len(res.Files))
+	for _, file := range res.Files {
+		tag, fileName, ok := strings.Cut(strings.TrimPrefix(file.URI, "/"), "/")
+		if !ok || (fileName != "manifest.json" && fileName != "list.manifest.json") {
+			continue
+		}
+		if file.SHA2 == "" {
+			continue
+		}
+		lastModified := file.LastModified
+		images = append(images, newImage(tag, "sha256:"+file.SHA2, &lastModified))
+	}
+	return images, nil
+}
+
+// getJSON performs a GET request against the registry and unmarshals the JSON
+// response body into out. Credentials, if any, are only sent if authenticate
+// is true.
+func (c *registryAPIClient) getJSON(
+	ctx context.Context,
+	path string,
+	rawQuery string,
+	authenticate bool,
+	out any,
+) error {
+	body, err := c.get(ctx, path, rawQuery, authenticate)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("error unmarshaling response from %s: %w", path, err)
+	}
+	return nil
+}
+
+// get performs a GET request against the registry and returns the response
+// body. An error is returned for any non-200 response.
+func (c *registryAPIClient) get(
+	ctx context.Context,
+	path string,
+	rawQuery string,
+	authenticate bool,
+) ([]byte, error) {
+	u := c.baseURL + path
+	if rawQuery != "" {
+		u += "?" + rawQuery
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request for %s: %w", path, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if authenticate && c.creds != nil && (c.creds.Username != "" || c.creds.Password != "") {
+		req.SetBasicAuth(c.creds.Username, c.creds.Password)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request to %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, path)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response from %s: %w", path, err)
+	}
+	return body, nil
+}
@@ -0,0 +1,193 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectRegistryAPI(t *testing.T) {
+	testCases := []struct {
+		name     string
+		handler  http.HandlerFunc
+		expected registryAPI
+	}{
+		{
+			name: "Harbor",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/v2.0/systeminfo" {
+					_, _ = w.Write([]byte(`{"harbor_version":"v2.10.0"}`))
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+			},
+			expected: registryAPIHarbor,
+		},
+		{
+			name: "Quay",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/api/v1/discovery" {
+					_, _ = w.Write([]byte(`{"info":{"title":"Quay Frontend"}}`))
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+			},
+			expected: registryAPIQuay,
+		},
+		{
+			name: "Artifactory",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/artifactory/api/system/ping" {
+					_, _ = w.Write([]byte("OK"))
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+			},
+			expected: registryAPIArtifactory,
+		},
+		{
+			name: "generic",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			expected: registryAPIGeneric,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			srv := httptest.NewServer(testCase.handler)
+			defer srv.Close()
+			c := &registryAPIClient{
+				baseURL:    srv.URL,
+				httpClient: srv.Client(),
+			}
+			require.Equal(t, testCase.expected, detectRegistryAPI(context.Background(), c))
+		})
+	}
+}
+
+func TestListHarborImages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(
+			t,
+			"/api/v2.0/projects/proj/repositories/foo%252Fbar/artifacts",
+			r.URL.EscapedPath(),
+		)
+		require.Equal(t, "true", r.URL.Query().Get("with_tag"))
+		user, pass, ok := r.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "user", user)
+		require.Equal(t, "pass", pass)
+		_, _ = w.Write([]byte(`[
+			{
+				"digest": "sha256:abc",
+				"push_time": "2024-01-01T00:00:00Z",
+				"tags": [
+					{"name": "v1", "push_time": "2024-01-02T00:00:00Z"},
+					{"name": "v1.0"}
+				]
+			}
+		]`))
+	}))
+	defer srv.Close()
+
+	images, err := listHarborImages(
+		context.Background(),
+		&registryAPIClient{
+			baseURL:    srv.URL,
+			httpClient: srv.Client(),
+			creds:      &Credentials{Username: "user", Password: "pass"},
+		},
+		"proj/foo/bar",
+	)
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+	require.Equal(t, "v1", images[0].Tag)
+	require.Equal(t, "sha256:abc", images[0].Digest)
+	require.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), *images[0].CreatedAt)
+	require.Equal(t, "v1.0", images[1].Tag)
+	require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *images[1].CreatedAt)
+
+	_, err = listHarborImages(context.Background(), &registryAPIClient{}, "foo")
+	require.ErrorContains(t, err, "does not include a project")
+}
+
+func TestListQuayImages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/repository/ns/repo/tag/", r.URL.Path)
+		page := r.URL.Query().Get("page")
+		_, _ = fmt.Fprintf(
+			w,
+			`{"tags":[{"name":"tag-%s","manifest_digest":"sha256:%s","start_ts":60}],"has_additional":%t}`,
+			page,
+			page,
+			page == "1",
+		)
+	}))
+	defer srv.Close()
+
+	images, err := listQuayImages(
+		context.Background(),
+		&registryAPIClient{
+			baseURL:    srv.URL,
+			httpClient: srv.Client(),
+		},
+		"ns/repo",
+	)
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+	require.Equal(t, "tag-1", images[0].Tag)
+	require.Equal(t, "sha256:1", images[0].Digest)
+	require.Equal(t, time.Unix(60, 0).UTC(), *images[0].CreatedAt)
+	require.Equal(t, "tag-2", images[1].Tag)
+}
+
+func TestListArtifactoryImages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/artifactory/api/storage/docker-local/foo", r.URL.Path)
+		require.Equal(t, "list&deep=1&depth=2&listFolders=0", r.URL.RawQuery)
+		_, _ = w.Write([]byte(`{"files":[
+			{"uri":"/v1/manifest.json","lastModified":"2024-01-01T00:00:00.000Z","sha2":"abc"},
+			{"uri":"/v2/list.manifest.json","lastModified":"2024-01-02T00:00:00.000+02:00","sha2":"def"},
+			{"uri":"/v1/sha256__123","lastModified":"2024-01-01T00:00:00.000Z","sha2":"123"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	images, err := listArtifactoryImages(
+		context.Background(),
+		&registryAPIClient{
+			baseURL:    srv.URL,
+			httpClient: srv.Client(),
+		},
+		"docker-local/foo",
+	)
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+	require.Equal(t, "v1", images[0].Tag)
+	require.Equal(t, "sha256:abc", images[0].Digest)
+	require.Equal(t, "v2", images[1].Tag)
+	require.Equal(t, "sha256:def", images[1].Digest)
+	require.True(t, time.Date(2024, 1, 1, 22, 0, 0, 0, time.UTC).Equal(*images[1].CreatedAt))
+
+	_, err = listArtifactoryImages(context.Background(), &registryAPIClient{}, "foo")
+	require.ErrorContains(t, err, "does not include a repository key")
+}
+
+func TestRegistryAPIClientGet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	c := &registryAPIClient{
+		baseURL:    srv.URL,
+		httpClient: srv.Client(),
+	}
+	_, err := c.get(context.Background(), "/foo", "", true)
+	require.ErrorContains(t, err, "unexpected status code 401")
+}
@@ -1,7 +1,10 @@
 package image
 
 import (
+	"context"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -14,6 +17,7 @@ func TestNewRegistry(t *testing.T) {
 	require.NotEmpty(t, testPrefix, r.imagePrefix)
 	require.Empty(t, r.defaultNamespace)
 	require.NotNil(t, r.imageCache)
+	require.NotNil(t, r.backoff)
 }
 
 func TestGetRegistry(t *testing.T) {
@@ -30,6 +34,22 @@ func TestGetRegistry(t *testing.T) {
 				require.Equal(t, "Docker Hub", reg.name)
 			},
 		},
+		{
+			name:        "hit GHCR",
+			imagePrefix: "ghcr.io",
+			assertions: func(t *testing.T, reg *registry) {
+				require.NotNil(t, reg)
+				require.Equal(t, "GitHub Container Registry", reg.name)
+			},
+		},
+		{
+			name:        "hit ECR Public",
+			imagePrefix: "public.ecr.aws",
+			assertions: func(t *testing.T, reg *registry) {
+				require.NotNil(t, reg)
+				require.Equal(t, "ECR Public Gallery", reg.name)
+			},
+		},
 		{
 			name:        "miss",
 			imagePrefix: "fake-prefix",
@@ -48,3 +68,99 @@ func TestGetRegistry(t *testing.T) {
 		})
 	}
 }
+
+func TestRegistryGetAPI(t *testing.T) {
+	var detections int
+	detect := func(context.Context) registryAPI {
+		detections++
+		return registryAPIHarbor
+	}
+
+	r := newRegistry("fake-prefix")
+	require.Equal(t, registryAPIHarbor, r.getAPI(context.Background(), detect))
+	require.Equal(t, registryAPIHarbor, r.getAPI(context.Background(), detect))
+	require.Equal(t, 1, detections)
+
+	// Detection is repeated once the previous result is stale
+	r.apiDetectedAt = time.Now().Add(-2 * registryAPIDetectionTTL)
+	require.Equal(t, registryAPIHarbor, r.getAPI(context.Background(), detect))
+	require.Equal(t, 2, detections)
+
+	// Registries with a fixed API are never probed
+	require.Equal(t, registryAPIGeneric, ghcrRegistry.getAPI(context.Background(), detect))
+	require.Equal(t, 2, detections)
+}
+
+func TestRegistryBackoff(t *testing.T) {
+	b := &registryBackoff{}
+
+	// No backoff period yet
+	require.NoError(t, b.wait(context.Background()))
+
+	// A rate limit response without Retry-After starts an exponential backoff
+	b.rateLimited(&http.Response{Header: http.Header{}})
+	require.Equal(t, 1, b.failures)
+	require.WithinDuration(t, time.Now().Add(minRateLimitBackoff), b.until, time.Second)
+
+	b.rateLimited(&http.Response{Header: http.Header{}})
+	require.Equal(t, 2, b.failures)
+	require.WithinDuration(t, time.Now().Add(2*minRateLimitBackoff), b.until, time.Second)
+
+	// Retry-After is honored, but capped
+	b.rateLimited(&http.Response{Header: http.Header{"Retry-After": []string{"3600"}}})
+	require.WithinDuration(t, time.Now().Add(maxRateLimitBackoff), b.until, time.Second)
+
+	// Waiting respects context cancellation
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, b.wait(ctx), context.Canceled)
+
+	// Success resets consecutive failures
+	b.succeeded()
+	require.Zero(t, b.failures)
+}
+
+func TestRetryAfter(t *testing.T) {
+	testCases := []struct {
+		name       string
+		header     string
+		assertions func(*testing.T, time.Duration)
+	}{
+		{
+			name: "absent",
+			assertions: func(t *testing.T, d time.Duration) {
+				require.Zero(t, d)
+			},
+		},
+		{
+			name:   "seconds",
+			header: "42",
+			assertions: func(t *testing.T, d time.Duration) {
+				require.Equal(t, 42*time.Second, d)
+			},
+		},
+		{
+			name:   "HTTP date",
+			header: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat),
+			assertions: func(t *testing.T, d time.Duration) {
+				require.InDelta(t, float64(time.Minute), float64(d), float64(2*time.Second))
+			},
+		},
+		{
+			name:   "invalid",
+			header: "soon",
+			assertions: func(t *testing.T, d time.Duration) {
+				require.Zero(t, d)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if testCase.header != "" {
+				resp.Header.Set("Retry-After", testCase.header)
+			}
+			testCase.assertions(t, retryAfter(resp))
+		})
+	}
+}
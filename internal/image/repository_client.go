@@ -2,10 +2,14 @@ package image
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/google/go-containerregistry/pkg/authn"
@@ -41,6 +45,25 @@ type repositoryClient struct {
 	repoURL       string
 	repoRef       name.Reference
 	remoteOptions []remote.Option
+	apiClient     *registryAPIClient
+	// sharedKey uniquely identifies the repository and the credentials used to
+	// access it. Results of requests are only shared among clients with the same
+	// sharedKey so that results obtained using one set of credentials are never
+	// leaked to clients using different (or no) credentials.
+	sharedKey string
+	// preferCreatedAnnotation indicates whether the creation date of an image
+	// should preferably be read from the org.opencontainers.image.created
+	// annotation of its index or manifest rather than from its config blob.
+	preferCreatedAnnotation bool
+	// cacheResponses indicates whether the registry's responses to requests for
+	// the repository's tags and manifests are cached and revalidated using
+	// conditional requests. When they are, images are also remembered by the
+	// digest each tag references so that tags whose manifests have not changed
+	// need not be examined again.
+	cacheResponses bool
+	// transport is the http.RoundTripper used for all requests to the
+	// registry.
+	transport http.RoundTripper
 
 	// The following behaviors are overridable for testing purposes:
 
@@ -75,9 +98,17 @@ type repositoryClient struct {
 		platform *platformConstraint,
 	) (*Image, error)
 
+	listImagesFn func(context.Context) ([]Image, error)
+
 	remoteListFn func(name.Repository, ...remote.Option) ([]string, error)
 
+	remoteCatalogFn func(context.Context, name.Registry, ...remote.Option) ([]string, error)
+
 	remoteGetFn func(name.Reference, ...remote.Option) (*remote.Descriptor, error)
+
+	remoteReferrersFn func(name.Digest, ...remote.Option) (*v1.IndexManifest, error)
+
+	remoteImageFn func(name.Reference, ...remote.Option) (v1.Image, error)
 }
 
 // newRepositoryClient parses the provided repository URL to infer registry
@@ -93,20 +124,55 @@ func newRepositoryClient(
 		return nil, fmt.Errorf("error parsing image repo URL %s: %w", repoURL, err)
 	}
 	reg := getRegistry(repoRef.Context().RegistryStr())
+	hostOpts := getHostOptions(reg.imagePrefix)
+
+	// If the registry is mirrored, read from the mirror instead.
+	if hostOpts.Mirror != "" {
+		mirrorURL := fmt.Sprintf("%s/%s", hostOpts.Mirror, repoRef.Context().RepositoryStr())
+		if repoRef, err = name.ParseReference(mirrorURL); err != nil {
+			return nil, fmt.Errorf(
+				"error parsing mirror %q of image repo URL %s: %w",
+				hostOpts.Mirror,
+				repoURL,
+				err,
+			)
+		}
+	}
 
 	httpTransport := cleanhttp.DefaultTransport()
-	if insecureSkipTLSVerify {
-		httpTransport.TLSClientConfig = &tls.Config{
+	if insecureSkipTLSVerify || len(hostOpts.CABundle) > 0 {
+		tlsConfig := &tls.Config{
 			InsecureSkipVerify: insecureSkipTLSVerify, // nolint: gosec
 		}
+		if len(hostOpts.CABundle) > 0 {
+			if tlsConfig.RootCAs, err = x509.SystemCertPool(); err != nil {
+				tlsConfig.RootCAs = x509.NewCertPool()
+			}
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(hostOpts.CABundle) {
+				return nil, fmt.Errorf(
+					"error parsing CA bundle for registry %s", reg.imagePrefix,
+				)
+			}
+		}
+		httpTransport.TLSClientConfig = tlsConfig
 	}
 
-	if creds == nil {
-		creds = &Credentials{}
+	// Without credentials, explicitly use anonymous authentication. Registries
+	// that support anonymous access to public images (e.g. GHCR and ECR Public)
+	// will still challenge us, and the anonymous challenge is answered by
+	// obtaining an anonymous bearer token from the registry's token endpoint.
+	var auth = authn.Anonymous
+	if creds != nil && (creds.Username != "" || creds.Password != "") {
+		auth = &authn.Basic{
+			Username: creds.Username,
+			Password: creds.Password,
+		}
 	}
-	var auth authn.Authenticator = &authn.Basic{
-		Username: creds.Username,
-		Password: creds.Password,
+
+	transport := &rateLimitedRoundTripper{
+		limiter:              reg.getRateLimiter(),
+		backoff:              reg.backoff,
+		internalRoundTripper: httpTransport,
 	}
 
 	r := &repositoryClient{
@@ -114,12 +180,20 @@ func newRepositoryClient(
 		repoURL:  repoURL,
 		repoRef:  repoRef,
 		remoteOptions: []remote.Option{
-			remote.WithTransport(&rateLimitedRoundTripper{
-				limiter:              reg.rateLimiter,
-				internalRoundTripper: httpTransport,
-			}),
+			remote.WithTransport(transport),
 			remote.WithAuth(auth),
 		},
+		apiClient: &registryAPIClient{
+			baseURL: fmt.Sprintf(
+				"%s://%s",
+				repoRef.Context().Registry.Scheme(),
+				repoRef.Context().RegistryStr(),
+			),
+			httpClient: &http.Client{Transport: transport},
+			creds:      creds,
+		},
+		sharedKey: sharedKeyFor(repoRef.Context().Name(), creds),
+		transport: transport,
 	}
 
 	r.getImageByTagFn = r.getImageByTag
@@ -127,48 +201,174 @@ func newRepositoryClient(
 	r.getImageFromRemoteDescFn = r.getImageFromRemoteDesc
 	r.getImageFromV1ImageIndexFn = r.getImageFromV1ImageIndex
 	r.getImageFromV1ImageFn = r.getImageFromV1Image
+	r.listImagesFn = r.listImages
 	r.remoteListFn = remote.List
+	r.remoteCatalogFn = remote.Catalog
 	r.remoteGetFn = remote.Get
+	r.remoteReferrersFn = remote.Referrers
+	r.remoteImageFn = remote.Image
 
 	return r, nil
 }
 
+// enableResponseCaching causes the registry's responses to requests for the
+// repository's tags and manifests to be cached and revalidated using
+// conditional requests.
+func (r *repositoryClient) enableResponseCaching() {
+	r.cacheResponses = true
+	// Options are applied in order, so this takes precedence over the
+	// transport specified when the client was created.
+	r.remoteOptions = append(
+		slices.Clone(r.remoteOptions),
+		remote.WithTransport(&cachingRoundTripper{
+			cache:                r.registry.responseCache,
+			keyPrefix:            r.sharedKey,
+			internalRoundTripper: r.transport,
+		}),
+	)
+}
+
+// getTags lists all of the repository's tags. Results are briefly shared with
+// other clients of the same repository using the same credentials.
 func (r *repositoryClient) getTags(ctx context.Context) ([]string, error) {
-	opts := append(r.remoteOptions, remote.WithContext(ctx))
-	tags, err := r.remoteListFn(r.repoRef.Context(), opts...)
+	res, err := r.doShared("tags", func() (any, error) {
+		opts := append(r.remoteOptions, remote.WithContext(ctx))
+		return r.remoteListFn(r.repoRef.Context(), opts...)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error listing tags for repo URL %s: %w", r.repoURL, err)
 	}
-	return tags, nil
+	// Callers may sort the tags in place, so return a copy
+	return slices.Clone(res.([]string)), nil // nolint: forcetypeassert
 }
 
-// getImageByTag retrieves an Image by tag. This function uses no cache since
-// tags can be mutable.
+// listImages lists all of the repository's tags along with the digest and push
+// time of the image each tag references using a registry-specific API. This
+// is far more efficient than retrieving the manifest and config blob for each
+// tag, but is only possible for registries that offer such an API. If the
+// registry does not, errRegistryAPIUnsupported is returned.
+func (r *repositoryClient) listImages(ctx context.Context) ([]Image, error) {
+	api := r.registry.getAPI(ctx, func(ctx context.Context) registryAPI {
+		return detectRegistryAPI(ctx, r.apiClient)
+	})
+	lister, ok := imageListers[api]
+	if !ok {
+		return nil, errRegistryAPIUnsupported
+	}
+	res, err := r.doShared("images", func() (any, error) {
+		return lister(ctx, r.apiClient, r.repoRef.Context().RepositoryStr())
+	})
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error listing images for repo URL %s using %s API: %w",
+			r.repoURL,
+			api,
+			err,
+		)
+	}
+	// Callers may sort the images in place, so return a copy
+	return slices.Clone(res.([]Image)), nil // nolint: forcetypeassert
+}
+
+// getImageByTag retrieves an Image by tag. Since tags can be mutable, this
+// function uses no long-lived cache, but results are briefly shared with other
+// clients of the same repository using the same credentials.
 func (r *repositoryClient) getImageByTag(
 	ctx context.Context,
 	tag string,
 	platform *platformConstraint,
 ) (*Image, error) {
-	repoRef := r.repoRef.Context().Tag(tag)
-	opts := append(r.remoteOptions, remote.WithContext(ctx))
-	desc, err := r.remoteGetFn(repoRef, opts...)
-	if err != nil {
-		return nil, fmt.Errorf(
-			"error getting image descriptor for tag %q from repo URL %s: %w",
-			tag, r.repoURL, err,
-		)
+	key := "tag/" + tag
+	if platform != nil {
+		key += "/" + platform.String()
 	}
-	img, err := r.getImageFromRemoteDescFn(ctx, desc, platform)
+	if r.preferCreatedAnnotation {
+		key += "/created-annotation"
+	}
+	res, err := r.doShared(key, func() (any, error) {
+		repoRef := r.repoRef.Context().Tag(tag)
+		opts := append(r.remoteOptions, remote.WithContext(ctx))
+		desc, err := r.remoteGetFn(repoRef, opts...)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error getting image descriptor for tag %q from repo URL %s: %w",
+				tag, r.repoURL, err,
+			)
+		}
+		// Whatever a tag references, information retrieved by digest will never
+		// change, so if we've seen this digest before, there's no need to examine
+		// the image again.
+		cacheKey := "tag-image/" + desc.Digest.String()
+		if platform != nil {
+			cacheKey += "/" + platform.String()
+		}
+		if r.preferCreatedAnnotation {
+			cacheKey += "/created-annotation"
+		}
+		if r.cacheResponses {
+			if entry, exists := r.registry.imageCache.Get(cacheKey); exists {
+				image := entry.(Image) // nolint: forcetypeassert
+				return &image, nil
+			}
+		}
+		img, err := r.getImageFromRemoteDescFn(ctx, desc, platform)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error getting image from descriptor for tag %q from repo URL %s: %w",
+				tag, r.repoURL, err,
+			)
+		}
+		if r.cacheResponses && img != nil {
+			r.registry.imageCache.Set(cacheKey, *img, cache.DefaultExpiration)
+		}
+		return img, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf(
-			"error getting image from descriptor for tag %q from repo URL %s: %w",
-			tag, r.repoURL, err,
-		)
+		return nil, err
 	}
-	if img != nil {
-		img.Tag = tag
+	img := res.(*Image) // nolint: forcetypeassert
+	if img == nil {
+		return nil, nil
 	}
-	return img, nil
+	// Return a copy so that callers cannot modify the shared result
+	image := *img
+	image.Tag = tag
+	return &image, nil
+}
+
+// doShared executes the provided function and returns its result, unless the
+// same request (identified by key) was recently made, or is currently being
+// made, by any client of the same repository using the same credentials. In
+// that case, the result of the earlier or in-flight request is returned
+// instead. Errors are never cached.
+func (r *repositoryClient) doShared(
+	key string,
+	fn func() (any, error),
+) (any, error) {
+	key = r.sharedKey + "/" + key
+	if res, ok := r.registry.sharedResults.Get(key); ok {
+		return res, nil
+	}
+	res, err, _ := r.registry.requests.Do(key, func() (any, error) {
+		res, err := fn()
+		if err != nil {
+			return nil, err
+		}
+		r.registry.sharedResults.SetDefault(key, res)
+		return res, nil
+	})
+	return res, err
+}
+
+// sharedKeyFor returns a key that uniquely identifies the provided repository
+// and credentials. The credentials are hashed so that they are never stored in
+// plain text.
+func sharedKeyFor(repo string, creds *Credentials) string {
+	if creds == nil || (creds.Username == "" && creds.Password == "") {
+		return repo
+	}
+	sum := sha256.Sum256([]byte(creds.Username + ":" + creds.Password))
+	return fmt.Sprintf("%s@%x", repo, sum[:8])
 }
 
 // getImageByDigest retrieves an Image for a given digest. This function uses a
@@ -181,7 +381,13 @@ func (r *repositoryClient) getImageByDigest(
 	logger := logging.LoggerFromContext(ctx)
 	logger.Tracef("retrieving image with digest %s", digest)
 
-	if entry, exists := r.registry.imageCache.Get(digest); exists {
+	cacheKey := digest
+	if r.preferCreatedAnnotation {
+		// Images whose creation dates were read from annotations must not be
+		// mixed up with those whose creation dates were read from config blobs.
+		cacheKey += "/created-annotation"
+	}
+	if entry, exists := r.registry.imageCache.Get(cacheKey); exists {
 		image := entry.(Image) // nolint: forcetypeassert
 		return &image, nil
 	}
@@ -208,7 +414,7 @@ func (r *repositoryClient) getImageByDigest(
 
 	if img != nil {
 		// Cache the image
-		r.registry.imageCache.Set(digest, *img, cache.DefaultExpiration)
+		r.registry.imageCache.Set(cacheKey, *img, cache.DefaultExpiration)
 		logger.Tracef("cached image for digest %s", digest)
 	}
 
@@ -278,30 +484,23 @@ func (r *repositoryClient) getImageFromV1ImageIndex(
 	// If there's a platform constraint, find the ref that matches it and
 	// that's the information we're really after.
 	if platform != nil {
-		var matchedRefs []v1.Descriptor
-		for _, ref := range refs {
-			if !platform.matches(
-				ref.Platform.OS,
-				ref.Platform.Architecture,
-				ref.Platform.Variant,
+		// A constraint containing wildcards or multiple platforms may match more
+		// than one ref. When that happens, the first matching ref wins.
+		var ref *v1.Descriptor
+		for i := range refs {
+			if platform.matches(
+				refs[i].Platform.OS,
+				refs[i].Platform.Architecture,
+				refs[i].Platform.Variant,
 			) {
-				continue
+				ref = &refs[i]
+				break
 			}
-			matchedRefs = append(matchedRefs, ref)
 		}
-		if len(matchedRefs) == 0 {
+		if ref == nil {
 			// No refs matched the platform
 			return nil, nil
 		}
-		if len(matchedRefs) > 1 {
-			// This really shouldn't happen.
-			return nil, fmt.Errorf(
-				"expected only one reference to match platform %q, but found %d",
-				platform.String(),
-				len(matchedRefs),
-			)
-		}
-		ref := matchedRefs[0]
 		img, err := r.getImageByDigestFn(ctx, ref.Digest.String(), platform)
 		if err != nil {
 			return nil, fmt.Errorf(
@@ -324,10 +523,26 @@ func (r *repositoryClient) getImageFromV1ImageIndex(
 
 	// If we get to here there was no platform constraint.
 
-	// Manifest lists and indices don't have a createdAt timestamp, and we had no
-	// platform constraint, so we'll follow ALL the references to find the most
-	// recently pushed manifest's createdAt timestamp.
 	var createdAt *time.Time
+	var revision string
+	if r.preferCreatedAnnotation {
+		createdAt = createdAtFromAnnotations(idxManifest.Annotations)
+		revision = idxManifest.Annotations[revisionLabel]
+		if createdAt != nil && revision != "" {
+			// Everything we need is in the index itself, so there's no need to
+			// retrieve any of the manifests it references.
+			return &Image{
+				Digest:    digest,
+				CreatedAt: createdAt,
+				Revision:  revision,
+			}, nil
+		}
+	}
+	indexCreatedAt := createdAt
+
+	// Manifest lists and indices don't usually have a createdAt timestamp, and
+	// we had no platform constraint, so we'll follow ALL the references to find
+	// the most recently pushed manifest's createdAt timestamp.
 	for _, ref := range refs {
 		img, err := r.getImageByDigestFn(ctx, ref.Digest.String(), platform)
 		if err != nil {
@@ -339,13 +554,19 @@ func (r *repositoryClient) getImageFromV1ImageIndex(
 			// This really shouldn't happen.
 			return nil, fmt.Errorf("found no image with digest %s", ref.Digest)
 		}
-		if createdAt == nil || img.CreatedAt.After(*createdAt) {
+		if indexCreatedAt == nil && (createdAt == nil || img.CreatedAt.After(*createdAt)) {
 			createdAt = img.CreatedAt
 		}
+		// Images for all platforms are expected to have been built from the
+		// same revision, so the first one found is used.
+		if revision == "" {
+			revision = img.Revision
+		}
 	}
 	return &Image{
 		Digest:    digest,
 		CreatedAt: createdAt,
+		Revision:  revision,
 	}, nil
 }
 
@@ -357,6 +578,29 @@ func (r *repositoryClient) getImageFromV1Image(
 	img v1.Image,
 	platform *platformConstraint,
 ) (*Image, error) {
+	var createdAt *time.Time
+	var revision string
+	if r.preferCreatedAnnotation {
+		manifest, err := img.Manifest()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error getting manifest for image with digest %s: %w",
+				digest, err,
+			)
+		}
+		createdAt = createdAtFromAnnotations(manifest.Annotations)
+		revision = manifest.Annotations[revisionLabel]
+		if createdAt != nil && revision != "" && platform == nil {
+			// Everything we need is in the manifest itself, so there's no need
+			// to retrieve the config blob.
+			return &Image{
+				Digest:    digest,
+				CreatedAt: createdAt,
+				Revision:  revision,
+			}, nil
+		}
+	}
+
 	cfg, err := img.ConfigFile()
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -368,16 +612,47 @@ func (r *repositoryClient) getImageFromV1Image(
 		// This image doesn't match the platform constraint.
 		return nil, nil
 	}
+	if createdAt == nil {
+		createdAt = &cfg.Created.Time
+	}
+	if revision == "" {
+		revision = cfg.Config.Labels[revisionLabel]
+	}
 	return &Image{
 		Digest:    digest,
-		CreatedAt: &cfg.Created.Time,
+		CreatedAt: createdAt,
+		Revision:  revision,
 	}, nil
 }
 
+// createdAtFromAnnotations returns the date and time recorded by the
+// org.opencontainers.image.created annotation among the provided annotations.
+// It returns nil if there is no such annotation, if its value cannot be
+// parsed, or if it records the zero time or the Unix epoch, which reproducible
+// builds commonly use in place of a real date.
+func createdAtFromAnnotations(annotations map[string]string) *time.Time {
+	value, ok := annotations[createdAnnotation]
+	if !ok {
+		return nil
+	}
+	createdAt, err := time.Parse(time.RFC3339, value)
+	if err != nil || createdAt.IsZero() || createdAt.Unix() == 0 {
+		return nil
+	}
+	return &createdAt
+}
+
+// maxRateLimitRetries is the maximum number of times a single request will be
+// retried after a registry responds with a rate limit error.
+const maxRateLimitRetries = 5
+
 // rateLimitedRoundTripper is a rate limited implementation of
-// http.RoundTripper.
+// http.RoundTripper. In addition to proactively limiting the rate of requests
+// to a registry, it reacts to rate limit responses from the registry by
+// pausing all requests to that registry and retrying.
 type rateLimitedRoundTripper struct {
 	limiter              ratelimit.Limiter
+	backoff              *registryBackoff
 	internalRoundTripper http.RoundTripper
 }
 
@@ -385,6 +660,42 @@ type rateLimitedRoundTripper struct {
 func (r *rateLimitedRoundTripper) RoundTrip(
 	req *http.Request,
 ) (*http.Response, error) {
-	r.limiter.Take()
-	return r.internalRoundTripper.RoundTrip(req)
+	for attempt := 0; ; attempt++ {
+		if r.backoff != nil {
+			if err := r.backoff.wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+		r.limiter.Take()
+		resp, err := r.internalRoundTripper.RoundTrip(req)
+		if err != nil || r.backoff == nil {
+			return resp, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests {
+			r.backoff.succeeded()
+			return resp, nil
+		}
+		r.backoff.rateLimited(resp)
+		if attempt >= maxRateLimitRetries || !isReplayable(req) {
+			return resp, nil
+		}
+		logging.LoggerFromContext(req.Context()).WithField("url", req.URL.String()).
+			Debug("registry responded with rate limit error; backing off")
+		// Drain and close the body so the underlying connection can be reused.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		if req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// isReplayable returns a boolean indicating whether the provided request can
+// safely be sent again.
+func isReplayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
 }
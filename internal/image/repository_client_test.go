@@ -3,6 +3,8 @@ package image
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/patrickmn/go-cache"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/ratelimit"
 	"k8s.io/utils/ptr"
 )
 
@@ -30,6 +33,8 @@ func TestNewRepository(t *testing.T) {
 	require.NotNil(t, client.getImageFromV1ImageFn)
 	require.NotNil(t, client.remoteListFn)
 	require.NotNil(t, client.remoteGetFn)
+	require.NotNil(t, client.remoteReferrersFn)
+	require.NotNil(t, client.remoteImageFn)
 }
 
 func TestGetImageByTag(t *testing.T) {
@@ -52,7 +57,8 @@ func TestGetImageByTag(t *testing.T) {
 		{
 			name: "error getting descriptor by tag",
 			client: &repositoryClient{
-				repoRef: testRepoRef,
+				registry: newRegistry("fake-registry"),
+				repoRef:  testRepoRef,
 				remoteGetFn: func(
 					name.Reference,
 					...remote.Option,
@@ -68,7 +74,8 @@ func TestGetImageByTag(t *testing.T) {
 		{
 			name: "error getting image from descriptor",
 			client: &repositoryClient{
-				repoRef: testRepoRef,
+				registry: newRegistry("fake-registry"),
+				repoRef:  testRepoRef,
 				remoteGetFn: func(
 					name.Reference,
 					...remote.Option,
@@ -91,7 +98,8 @@ func TestGetImageByTag(t *testing.T) {
 		{
 			name: "success",
 			client: &repositoryClient{
-				repoRef: testRepoRef,
+				registry: newRegistry("fake-registry"),
+				repoRef:  testRepoRef,
 				remoteGetFn: func(
 					name.Reference,
 					...remote.Option,
@@ -124,6 +132,114 @@ func TestGetImageByTag(t *testing.T) {
 	}
 }
 
+func TestGetImageByTagIsShared(t *testing.T) {
+	testRepoRef, err := name.ParseReference("fake-url")
+	require.NoError(t, err)
+	var requests int
+	reg := newRegistry("fake-registry")
+	newClient := func(creds *Credentials) *repositoryClient {
+		return &repositoryClient{
+			registry:  reg,
+			repoRef:   testRepoRef,
+			sharedKey: sharedKeyFor("fake-url", creds),
+			remoteGetFn: func(
+				name.Reference,
+				...remote.Option,
+			) (*remote.Descriptor, error) {
+				requests++
+				return &remote.Descriptor{}, nil
+			},
+			getImageFromRemoteDescFn: func(
+				context.Context,
+				*remote.Descriptor,
+				*platformConstraint,
+			) (*Image, error) {
+				return &Image{Digest: "fake-digest"}, nil
+			},
+		}
+	}
+
+	// Two clients without credentials share results
+	img, err := newClient(nil).getImageByTag(context.Background(), "fake-tag", nil)
+	require.NoError(t, err)
+	require.Equal(t, "fake-tag", img.Tag)
+	// Modifying the result must not affect the shared result
+	img.Digest = "modified"
+	img, err = newClient(nil).getImageByTag(context.Background(), "fake-tag", nil)
+	require.NoError(t, err)
+	require.Equal(t, "fake-digest", img.Digest)
+	require.Equal(t, 1, requests)
+
+	// A client with credentials does not share results with clients without
+	_, err = newClient(&Credentials{Username: "user", Password: "pass"}).getImageByTag(
+		context.Background(),
+		"fake-tag",
+		nil,
+	)
+	require.NoError(t, err)
+	require.Equal(t, 2, requests)
+}
+
+func TestGetImageByTagWithResponseCaching(t *testing.T) {
+	testRepoRef, err := name.ParseReference("fake-url")
+	require.NoError(t, err)
+	testDigest, err := v1.NewHash(
+		"sha256:0000000000000000000000000000000000000000000000000000000000000000",
+	)
+	require.NoError(t, err)
+	var examined int
+	reg := newRegistry("fake-registry")
+	client := &repositoryClient{
+		registry:       reg,
+		repoRef:        testRepoRef,
+		sharedKey:      "fake-url",
+		cacheResponses: true,
+		remoteGetFn: func(
+			name.Reference,
+			...remote.Option,
+		) (*remote.Descriptor, error) {
+			return &remote.Descriptor{
+				Descriptor: v1.Descriptor{Digest: testDigest},
+			}, nil
+		},
+		getImageFromRemoteDescFn: func(
+			context.Context,
+			*remote.Descriptor,
+			*platformConstraint,
+		) (*Image, error) {
+			examined++
+			return &Image{Digest: testDigest.String()}, nil
+		},
+	}
+
+	img, err := client.getImageByTag(context.Background(), "fake-tag", nil)
+	require.NoError(t, err)
+	require.Equal(t, "fake-tag", img.Tag)
+	require.Equal(t, 1, examined)
+
+	// Once shared results have expired, the tag is looked up again, but the
+	// image it references is not examined again since its digest is unchanged.
+	reg.sharedResults.Flush()
+	img, err = client.getImageByTag(context.Background(), "other-tag", nil)
+	require.NoError(t, err)
+	require.Equal(t, "other-tag", img.Tag)
+	require.Equal(t, testDigest.String(), img.Digest)
+	require.Equal(t, 1, examined)
+}
+
+func TestSharedKeyFor(t *testing.T) {
+	require.Equal(t, "repo", sharedKeyFor("repo", nil))
+	require.Equal(t, "repo", sharedKeyFor("repo", &Credentials{}))
+	withCreds := sharedKeyFor("repo", &Credentials{Username: "user", Password: "pass"})
+	require.NotEqual(t, "repo", withCreds)
+	require.NotContains(t, withCreds, "pass")
+	require.NotEqual(
+		t,
+		withCreds,
+		sharedKeyFor("repo", &Credentials{Username: "user", Password: "other"}),
+	)
+}
+
 func TestGetImageByDigest(t *testing.T) {
 	const testRepoURL = "fake-url"
 	const testDigest = "fake-digest"
@@ -321,8 +437,10 @@ func TestImageFromV1ImageIndex(t *testing.T) {
 				},
 			},
 			platform: &platformConstraint{
-				os:   "linux",
-				arch: "arm64",
+				include: []platformPattern{{
+					os:   "linux",
+					arch: "arm64",
+				}},
 			},
 			client: &repositoryClient{},
 			assertions: func(t *testing.T, img *Image, err error) {
@@ -331,17 +449,25 @@ func TestImageFromV1ImageIndex(t *testing.T) {
 			},
 		},
 		{
-			name: "multiples refs match platform constraint",
+			name: "multiple refs match platform constraint",
 			idx: &mockImageIndex{
 				indexManifest: &v1.IndexManifest{
 					Manifests: []v1.Descriptor{
 						{
+							Digest: v1.Hash{
+								Algorithm: "sha256",
+								Hex:       "first",
+							},
 							Platform: &v1.Platform{
 								OS:           "linux",
 								Architecture: "amd64",
 							},
 						},
 						{
+							Digest: v1.Hash{
+								Algorithm: "sha256",
+								Hex:       "second",
+							},
 							Platform: &v1.Platform{
 								OS:           "linux",
 								Architecture: "amd64",
@@ -351,12 +477,22 @@ func TestImageFromV1ImageIndex(t *testing.T) {
 				},
 			},
 			platform: &platformConstraint{
-				os:   "linux",
-				arch: "amd64",
+				include: []platformPattern{{
+					os:   "linux",
+					arch: "amd64",
+				}},
 			},
-			client: &repositoryClient{},
-			assertions: func(t *testing.T, _ *Image, err error) {
-				require.ErrorContains(t, err, "expected only one reference to match platform")
+			client: &repositoryClient{
+				getImageByDigestFn: func(
+					_ context.Context, digest string, _ *platformConstraint,
+				) (*Image, error) {
+					require.Equal(t, "sha256:first", digest)
+					return &testImage, nil
+				},
+			},
+			assertions: func(t *testing.T, img *Image, err error) {
+				require.NoError(t, err)
+				require.Equal(t, testImage, *img)
 			},
 		},
 		{
@@ -372,8 +508,10 @@ func TestImageFromV1ImageIndex(t *testing.T) {
 				},
 			},
 			platform: &platformConstraint{
-				os:   "linux",
-				arch: "amd64",
+				include: []platformPattern{{
+					os:   "linux",
+					arch: "amd64",
+				}},
 			},
 			client: &repositoryClient{
 				getImageByDigestFn: func(
@@ -400,8 +538,10 @@ func TestImageFromV1ImageIndex(t *testing.T) {
 				},
 			},
 			platform: &platformConstraint{
-				os:   "linux",
-				arch: "amd64",
+				include: []platformPattern{{
+					os:   "linux",
+					arch: "amd64",
+				}},
 			},
 			client: &repositoryClient{
 				getImageByDigestFn: func(
@@ -428,8 +568,10 @@ func TestImageFromV1ImageIndex(t *testing.T) {
 				},
 			},
 			platform: &platformConstraint{
-				os:   "linux",
-				arch: "amd64",
+				include: []platformPattern{{
+					os:   "linux",
+					arch: "amd64",
+				}},
 			},
 			client: &repositoryClient{
 				getImageByDigestFn: func(
@@ -514,6 +656,75 @@ func TestImageFromV1ImageIndex(t *testing.T) {
 				require.Equal(t, testImage, *img)
 			},
 		},
+		{
+			name: "created and revision annotations on index preferred",
+			idx: &mockImageIndex{
+				indexManifest: &v1.IndexManifest{
+					Annotations: map[string]string{
+						createdAnnotation: "2024-06-01T12:00:00Z",
+						revisionLabel:     "fake-revision",
+					},
+					Manifests: []v1.Descriptor{{
+						Platform: &v1.Platform{
+							OS:           "linux",
+							Architecture: "amd64",
+						},
+					}},
+				},
+			},
+			client: &repositoryClient{
+				preferCreatedAnnotation: true,
+				getImageByDigestFn: func(
+					context.Context, string, *platformConstraint,
+				) (*Image, error) {
+					return nil, errors.New("manifests should not have been retrieved")
+				},
+			},
+			assertions: func(t *testing.T, img *Image, err error) {
+				require.NoError(t, err)
+				require.Equal(
+					t,
+					Image{
+						Digest:    testDigest,
+						CreatedAt: ptr.To(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)),
+						Revision:  "fake-revision",
+					},
+					*img,
+				)
+			},
+		},
+		{
+			name: "created annotation on index preferred, revision from manifests",
+			idx: &mockImageIndex{
+				indexManifest: &v1.IndexManifest{
+					Annotations: map[string]string{
+						createdAnnotation: "2024-06-01T12:00:00Z",
+					},
+					Manifests: []v1.Descriptor{{
+						Platform: &v1.Platform{
+							OS:           "linux",
+							Architecture: "amd64",
+						},
+					}},
+				},
+			},
+			client: &repositoryClient{
+				preferCreatedAnnotation: true,
+				getImageByDigestFn: func(
+					context.Context, string, *platformConstraint,
+				) (*Image, error) {
+					return &Image{
+						CreatedAt: ptr.To(time.Unix(0, 0)),
+						Revision:  "fake-revision",
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, img *Image, err error) {
+				require.NoError(t, err)
+				require.Equal(t, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), *img.CreatedAt)
+				require.Equal(t, "fake-revision", img.Revision)
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -551,6 +762,84 @@ func TestGetImageFromV1Image(t *testing.T) {
 				require.NotNil(t, img.CreatedAt)
 			},
 		},
+		{
+			name: "with revision label",
+			img: &mockImage{
+				configFile: &v1.ConfigFile{
+					Config: v1.Config{
+						Labels: map[string]string{
+							revisionLabel: "fake-revision",
+						},
+					},
+				},
+			},
+			client: &repositoryClient{},
+			assertions: func(t *testing.T, img *Image, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, img)
+				require.Equal(t, "fake-revision", img.Revision)
+			},
+		},
+		{
+			name: "created and revision annotations preferred",
+			img: &mockImage{
+				manifest: &v1.Manifest{
+					Annotations: map[string]string{
+						createdAnnotation: "2024-06-01T12:00:00Z",
+						revisionLabel:     "fake-revision",
+					},
+				},
+			},
+			client: &repositoryClient{preferCreatedAnnotation: true},
+			assertions: func(t *testing.T, img *Image, err error) {
+				// The config blob must not have been retrieved, or the mock would
+				// have returned an error.
+				require.NoError(t, err)
+				require.NotNil(t, img)
+				require.Equal(t, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), *img.CreatedAt)
+				require.Equal(t, "fake-revision", img.Revision)
+			},
+		},
+		{
+			name: "created annotation preferred over zero config date",
+			img: &mockImage{
+				manifest: &v1.Manifest{
+					Annotations: map[string]string{
+						createdAnnotation: "2024-06-01T12:00:00Z",
+					},
+				},
+				configFile: &v1.ConfigFile{
+					Created: v1.Time{Time: time.Unix(0, 0)},
+					Config: v1.Config{
+						Labels: map[string]string{
+							revisionLabel: "fake-revision",
+						},
+					},
+				},
+			},
+			client: &repositoryClient{preferCreatedAnnotation: true},
+			assertions: func(t *testing.T, img *Image, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, img)
+				require.Equal(t, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), *img.CreatedAt)
+				require.Equal(t, "fake-revision", img.Revision)
+			},
+		},
+		{
+			name: "created annotation preferred but absent",
+			img: &mockImage{
+				manifest: &v1.Manifest{},
+				configFile: &v1.ConfigFile{
+					Created: v1.Time{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				},
+			},
+			client: &repositoryClient{preferCreatedAnnotation: true},
+			assertions: func(t *testing.T, img *Image, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, img)
+				require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *img.CreatedAt)
+			},
+		},
 		{
 			name: "does not match platform constraint",
 			img: &mockImage{
@@ -560,8 +849,10 @@ func TestGetImageFromV1Image(t *testing.T) {
 				},
 			},
 			platform: &platformConstraint{
-				os:   "linux",
-				arch: "arm64",
+				include: []platformPattern{{
+					os:   "linux",
+					arch: "arm64",
+				}},
 			},
 			client: &repositoryClient{},
 			assertions: func(t *testing.T, img *Image, err error) {
@@ -578,8 +869,10 @@ func TestGetImageFromV1Image(t *testing.T) {
 				},
 			},
 			platform: &platformConstraint{
-				os:   "linux",
-				arch: "amd64",
+				include: []platformPattern{{
+					os:   "linux",
+					arch: "amd64",
+				}},
 			},
 			client: &repositoryClient{},
 			assertions: func(t *testing.T, img *Image, err error) {
@@ -636,6 +929,7 @@ func (m *mockImageIndex) ImageIndex(v1.Hash) (v1.ImageIndex, error) {
 
 type mockImage struct {
 	configFile *v1.ConfigFile
+	manifest   *v1.Manifest
 }
 
 func (m *mockImage) Layers() ([]v1.Layer, error) {
@@ -655,6 +949,9 @@ func (m *mockImage) ConfigName() (v1.Hash, error) {
 }
 
 func (m *mockImage) ConfigFile() (*v1.ConfigFile, error) {
+	if m.configFile == nil {
+		return nil, errNotImplemented
+	}
 	return m.configFile, nil
 }
 
@@ -667,7 +964,10 @@ func (m *mockImage) Digest() (v1.Hash, error) {
 }
 
 func (m *mockImage) Manifest() (*v1.Manifest, error) {
-	return nil, errNotImplemented
+	if m.manifest == nil {
+		return nil, errNotImplemented
+	}
+	return m.manifest, nil
 }
 
 func (m *mockImage) RawManifest() ([]byte, error) {
@@ -683,3 +983,34 @@ func (m *mockImage) LayerByDiffID(v1.Hash) (v1.Layer, error) {
 }
 
 var errNotImplemented = errors.New("not implemented")
+
+func TestRateLimitedRoundTripper(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	b := &registryBackoff{}
+	rt := &rateLimitedRoundTripper{
+		limiter:              ratelimit.NewUnlimited(),
+		backoff:              b,
+		internalRoundTripper: http.DefaultTransport,
+	}
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	start := time.Now()
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, 2, requests)
+	require.GreaterOrEqual(t, time.Since(start), time.Second)
+	require.Zero(t, b.failures)
+}
@@ -0,0 +1,253 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+// repoURLWildcard is the wildcard that may be used in the path of an image
+// repository URL to subscribe to all matching repositories.
+const repoURLWildcard = "*"
+
+// repositoryLister lists the paths (relative to the registry) of all
+// repositories in the specified namespace of a registry using a
+// registry-specific API.
+type repositoryLister func(
+	ctx context.Context,
+	c *registryAPIClient,
+	namespace string,
+) ([]string, error)
+
+// repositoryListers maps registry APIs to their repositoryLister
+// implementations. Registries without an entry are expected to support the
+// catalog endpoint of the OCI distribution API.
+var repositoryListers = map[registryAPI]repositoryLister{
+	registryAPIHarbor: listHarborRepositories,
+	registryAPIQuay:   listQuayRepositories,
+}
+
+// IsRepoURLPattern returns a bool indicating whether the provided image
+// repository URL contains wildcards and therefore refers to any number of
+// repositories.
+func IsRepoURLPattern(repoURL string) bool {
+	return strings.Contains(repoURL, repoURLWildcard)
+}
+
+// ValidateRepoURLPattern returns an error if the provided image repository URL
+// pattern uses wildcards anywhere other than in the repository's path.
+func ValidateRepoURLPattern(pattern string) error {
+	registry, _, err := splitRepoURLPattern(pattern)
+	if err != nil {
+		return err
+	}
+	if strings.Contains(registry, repoURLWildcard) {
+		return errors.New("wildcards are not permitted in the registry of an image repository URL")
+	}
+	return nil
+}
+
+// RepoURLMatches returns a bool indicating whether the provided image
+// repository URL is matched by the provided pattern. A wildcard in the pattern
+// matches any sequence of characters other than a slash. A pattern without
+// wildcards only matches itself.
+func RepoURLMatches(pattern, repoURL string) bool {
+	if !IsRepoURLPattern(pattern) {
+		return pattern == repoURL
+	}
+	matched, err := path.Match(pattern, repoURL)
+	return err == nil && matched
+}
+
+// ListRepositories returns the URLs of all repositories matching the provided
+// image repository URL pattern (see RepoURLMatches), in lexical order. Where
+// the registry offers a registry-specific API for listing repositories, it is
+// used. Otherwise, the registry must support the catalog endpoint of the OCI
+// distribution API, which some public registries (e.g. Docker Hub and GHCR) do
+// not.
+func ListRepositories(
+	ctx context.Context,
+	pattern string,
+	insecureSkipTLSVerify bool,
+	creds *Credentials,
+) ([]string, error) {
+	if err := ValidateRepoURLPattern(pattern); err != nil {
+		return nil, err
+	}
+	// The repository client only uses the repository URL to determine the
+	// registry, so any repository matched by the pattern will do.
+	repoClient, err := newRepositoryClient(
+		strings.ReplaceAll(pattern, repoURLWildcard, "xx"),
+		insecureSkipTLSVerify,
+		creds,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating repository client: %w", err)
+	}
+	return repoClient.listRepositories(ctx, pattern)
+}
+
+// listRepositories returns the URLs of all repositories in the client's
+// registry that match the provided pattern.
+func (r *repositoryClient) listRepositories(
+	ctx context.Context,
+	pattern string,
+) ([]string, error) {
+	registry, pathPattern, err := splitRepoURLPattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	namespace := repoPathNamespace(pathPattern)
+
+	var paths []string
+	api := r.registry.getAPI(ctx, func(ctx context.Context) registryAPI {
+		return detectRegistryAPI(ctx, r.apiClient)
+	})
+	if lister, ok := repositoryListers[api]; ok && namespace != "" {
+		if paths, err = lister(ctx, r.apiClient, namespace); err != nil {
+			return nil, fmt.Errorf(
+				"error listing repositories matching %s using %s API: %w",
+				pattern,
+				api,
+				err,
+			)
+		}
+	} else {
+		opts := append(r.remoteOptions, remote.WithContext(ctx))
+		if paths, err = r.remoteCatalogFn(ctx, r.repoRef.Context().Registry, opts...); err != nil {
+			return nil, fmt.Errorf(
+				"error listing repositories matching %s using registry catalog: %w",
+				pattern,
+				err,
+			)
+		}
+	}
+
+	var repoURLs []string
+	for _, p := range paths {
+		if matched, _ := path.Match(pathPattern, p); !matched {
+			continue
+		}
+		if registry != "" {
+			p = registry + "/" + p
+		}
+		repoURLs = append(repoURLs, p)
+	}
+	slices.Sort(repoURLs)
+	return slices.Compact(repoURLs), nil
+}
+
+// splitRepoURLPattern splits the provided image repository URL pattern into
+// the registry, exactly as it appears in the pattern, and the pattern for the
+// repository's path. If the pattern does not explicitly specify a registry
+// (i.e. it refers to Docker Hub), the returned registry is empty.
+func splitRepoURLPattern(pattern string) (string, string, error) {
+	ref, err := name.ParseReference(strings.ReplaceAll(pattern, repoURLWildcard, "xx"))
+	if err != nil {
+		return "", "", fmt.Errorf("error parsing image repo URL %s: %w", pattern, err)
+	}
+	// The repository's path has as many components as the path of the parsed
+	// reference. Any components preceding these make up the registry.
+	pathLen := strings.Count(ref.Context().RepositoryStr(), "/") + 1
+	components := strings.Split(pattern, "/")
+	if len(components) < pathLen {
+		// Docker Hub's official images are implicitly in the library namespace.
+		return "", "library/" + pattern, nil
+	}
+	split := len(components) - pathLen
+	return strings.Join(components[:split], "/"), strings.Join(components[split:], "/"), nil
+}
+
+// repoPathNamespace returns the leading components of the provided repository
+// path pattern that contain no wildcards.
+func repoPathNamespace(pathPattern string) string {
+	components := strings.Split(pathPattern, "/")
+	var namespace []string
+	for _, c := range components[:len(components)-1] {
+		if strings.Contains(c, repoURLWildcard) {
+			break
+		}
+		namespace = append(namespace, c)
+	}
+	return strings.Join(namespace, "/")
+}
+
+// listHarborRepositories lists repositories using Harbor's repositories API.
+// The first component of the namespace is the Harbor project.
+func listHarborRepositories(
+	ctx context.Context,
+	c *registryAPIClient,
+	namespace string,
+) ([]string, error) {
+	project, _, _ := strings.Cut(namespace, "/")
+	path := fmt.Sprintf("/api/v2.0/projects/%s/repositories", url.PathEscape(project))
+	var repos []string
+	for page := 1; page <= maxRegistryAPIPages; page++ {
+		var res []struct {
+			Name string `json:"name"`
+		}
+		if err := c.getJSON(
+			ctx,
+			path,
+			url.Values{
+				"page":      []string{strconv.Itoa(page)},
+				"page_size": []string{strconv.Itoa(registryAPIPageSize)},
+			}.Encode(),
+			true,
+			&res,
+		); err != nil {
+			return nil, err
+		}
+		for _, repo := range res {
+			repos = append(repos, repo.Name)
+		}
+		if len(res) < registryAPIPageSize {
+			return repos, nil
+		}
+	}
+	return nil, fmt.Errorf("exceeded %d pages listing Harbor repositories", maxRegistryAPIPages)
+}
+
+// listQuayRepositories lists repositories using Quay's repository API. The
+// first component of the namespace is the Quay namespace.
+func listQuayRepositories(
+	ctx context.Context,
+	c *registryAPIClient,
+	namespace string,
+) ([]string, error) {
+	namespace, _, _ = strings.Cut(namespace, "/")
+	var repos []string
+	var nextPage string
+	for page := 1; page <= maxRegistryAPIPages; page++ {
+		query := url.Values{"namespace": []string{namespace}}
+		if nextPage != "" {
+			query.Set("next_page", nextPage)
+		}
+		var res struct {
+			Repositories []struct {
+				Namespace string `json:"namespace"`
+				Name      string `json:"name"`
+			} `json:"repositories"`
+			NextPage string `json:"next_page"`
+		}
+		if err := c.getJSON(ctx, "/api/v1/repository", query.Encode(), true, &res); err != nil {
+			return nil, err
+		}
+		for _, repo := range res.Repositories {
+			repos = append(repos, repo.Namespace+"/"+repo.Name)
+		}
+		if res.NextPage == "" {
+			return repos, nil
+		}
+		nextPage = res.NextPage
+	}
+	return nil, fmt.Errorf("exceeded %d pages listing Quay repositories", maxRegistryAPIPages)
+}
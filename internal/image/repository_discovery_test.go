@@ -0,0 +1,196 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoURLMatches(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		repoURL  string
+		expected bool
+	}{
+		{"ghcr.io/acme/service", "ghcr.io/acme/service", true},
+		{"ghcr.io/acme/service", "ghcr.io/acme/service-a", false},
+		{"ghcr.io/acme/service-*", "ghcr.io/acme/service-a", true},
+		{"ghcr.io/acme/service-*", "ghcr.io/acme/service-", true},
+		{"ghcr.io/acme/service-*", "ghcr.io/acme/other", false},
+		{"ghcr.io/acme/service-*", "ghcr.io/acme/service-a/b", false},
+		{"ghcr.io/acme/*/api", "ghcr.io/acme/foo/api", true},
+		{"ghcr.io/acme/*/api", "ghcr.io/acme/foo/web", false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.pattern+" "+testCase.repoURL, func(t *testing.T) {
+			require.Equal(
+				t,
+				testCase.expected,
+				RepoURLMatches(testCase.pattern, testCase.repoURL),
+			)
+		})
+	}
+}
+
+func TestValidateRepoURLPattern(t *testing.T) {
+	require.NoError(t, ValidateRepoURLPattern("ghcr.io/acme/service-*"))
+	require.NoError(t, ValidateRepoURLPattern("acme/*"))
+	require.NoError(t, ValidateRepoURLPattern("registry.example.com:5000/*"))
+	require.ErrorContains(
+		t,
+		ValidateRepoURLPattern("*.example.com/acme/service"),
+		"wildcards are not permitted in the registry",
+	)
+}
+
+func TestSplitRepoURLPattern(t *testing.T) {
+	testCases := []struct {
+		pattern      string
+		registry     string
+		pathPattern  string
+		errorMessage string
+	}{
+		{
+			pattern:     "ghcr.io/acme/service-*",
+			registry:    "ghcr.io",
+			pathPattern: "acme/service-*",
+		},
+		{
+			pattern:     "registry.example.com:5000/acme/*/api",
+			registry:    "registry.example.com:5000",
+			pathPattern: "acme/*/api",
+		},
+		{
+			pattern:     "acme/service-*",
+			pathPattern: "acme/service-*",
+		},
+		{
+			pattern:     "service-*",
+			pathPattern: "library/service-*",
+		},
+		{
+			pattern:      "ghcr.io/acme/Service-*",
+			errorMessage: "error parsing image repo URL",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.pattern, func(t *testing.T) {
+			registry, pathPattern, err := splitRepoURLPattern(testCase.pattern)
+			if testCase.errorMessage != "" {
+				require.ErrorContains(t, err, testCase.errorMessage)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testCase.registry, registry)
+			require.Equal(t, testCase.pathPattern, pathPattern)
+		})
+	}
+}
+
+func TestListRepositories(t *testing.T) {
+	testRepoRef, err := name.ParseReference("registry.example.com/acme/x")
+	require.NoError(t, err)
+
+	t.Run("error listing catalog", func(t *testing.T) {
+		client := &repositoryClient{
+			registry: &registry{api: registryAPIGeneric},
+			repoRef:  testRepoRef,
+			remoteCatalogFn: func(context.Context, name.Registry, ...remote.Option) ([]string, error) {
+				return nil, errors.New("something went wrong")
+			},
+		}
+		_, err := client.listRepositories(context.Background(), "registry.example.com/acme/*")
+		require.ErrorContains(t, err, "error listing repositories")
+		require.ErrorContains(t, err, "something went wrong")
+	})
+
+	t.Run("catalog", func(t *testing.T) {
+		client := &repositoryClient{
+			registry: &registry{api: registryAPIGeneric},
+			repoRef:  testRepoRef,
+			remoteCatalogFn: func(context.Context, name.Registry, ...remote.Option) ([]string, error) {
+				return []string{
+					"acme/service-b",
+					"acme/service-a",
+					"acme/other",
+					"acme/service-c/nested",
+					"other/service-d",
+				}, nil
+			},
+		}
+		repoURLs, err := client.listRepositories(
+			context.Background(),
+			"registry.example.com/acme/service-*",
+		)
+		require.NoError(t, err)
+		require.Equal(
+			t,
+			[]string{
+				"registry.example.com/acme/service-a",
+				"registry.example.com/acme/service-b",
+			},
+			repoURLs,
+		)
+	})
+
+	t.Run("Harbor", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/api/v2.0/projects/acme/repositories", r.URL.Path)
+			_, _ = w.Write([]byte(`[
+				{"name": "acme/service-a"},
+				{"name": "acme/other"}
+			]`))
+		}))
+		defer srv.Close()
+		client := &repositoryClient{
+			registry: &registry{api: registryAPIHarbor},
+			repoRef:  testRepoRef,
+			apiClient: &registryAPIClient{
+				baseURL:    srv.URL,
+				httpClient: srv.Client(),
+			},
+		}
+		repoURLs, err := client.listRepositories(
+			context.Background(),
+			"registry.example.com/acme/service-*",
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{"registry.example.com/acme/service-a"}, repoURLs)
+	})
+}
+
+func TestListQuayRepositories(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v1/repository", r.URL.Path)
+		require.Equal(t, "acme", r.URL.Query().Get("namespace"))
+		if r.URL.Query().Get("next_page") == "" {
+			_, _ = w.Write([]byte(`{
+				"repositories": [{"namespace": "acme", "name": "service-a"}],
+				"next_page": "abc"
+			}`))
+			return
+		}
+		require.Equal(t, "abc", r.URL.Query().Get("next_page"))
+		_, _ = w.Write([]byte(`{
+			"repositories": [{"namespace": "acme", "name": "service-b"}]
+		}`))
+	}))
+	defer srv.Close()
+
+	repos, err := listQuayRepositories(
+		context.Background(),
+		&registryAPIClient{
+			baseURL:    srv.URL,
+			httpClient: srv.Client(),
+		},
+		"acme",
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{"acme/service-a", "acme/service-b"}, repos)
+}
@@ -0,0 +1,145 @@
+package image
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+
+	"github.com/akuity/kargo/internal/logging"
+)
+
+const (
+	// cachedResponseTTL is how long a registry's response to a request for a
+	// repository's tags or a manifest is retained for revalidation using a
+	// conditional request.
+	cachedResponseTTL = 6 * time.Hour
+	// maxCachedResponseBytes is the size of the largest response body that will
+	// be cached. This matches the maximum size of a manifest that registries are
+	// expected to accept. Larger responses are passed through uncached.
+	maxCachedResponseBytes = 4 << 20
+)
+
+// cacheableResponsePathRegex matches the paths of the registry endpoints whose
+// responses can be cached and revalidated using conditional requests, i.e.
+// those for listing a repository's tags and for retrieving manifests.
+var cacheableResponsePathRegex = regexp.MustCompile(`^/v2/.+/(tags/list|manifests/[^/]+)$`)
+
+// cachedResponse is a registry's response to a request along with the
+// validators that can be used to determine whether it is still current.
+type cachedResponse struct {
+	etag         string
+	lastModified string
+	header       http.Header
+	body         []byte
+}
+
+// cachingRoundTripper is an implementation of http.RoundTripper that caches
+// a registry's responses to requests for a repository's tags and manifests.
+// When a cached response exists for a request, the request is made
+// conditional using the cached response's ETag and Last-Modified headers. If
+// the registry responds that nothing has changed, the cached response is
+// returned in place of the registry's (empty) response.
+type cachingRoundTripper struct {
+	cache *cache.Cache
+	// keyPrefix is prepended to the key of every cached response. It must
+	// uniquely identify the credentials used to make requests so that responses
+	// obtained using one set of credentials are never returned to clients using
+	// different (or no) credentials.
+	keyPrefix            string
+	internalRoundTripper http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (c *cachingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || !cacheableResponsePathRegex.MatchString(req.URL.Path) {
+		return c.internalRoundTripper.RoundTrip(req)
+	}
+
+	// Manifests may be requested in different formats, so the Accept header is
+	// part of the key.
+	key := c.keyPrefix + " " + req.URL.String() + " " + req.Header.Get("Accept")
+
+	var cached *cachedResponse
+	if entry, ok := c.cache.Get(key); ok {
+		cached = entry.(*cachedResponse) // nolint: forcetypeassert
+		// Per the http.RoundTripper contract, the request must not be modified.
+		req = req.Clone(req.Context())
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	resp, err := c.internalRoundTripper.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		logging.LoggerFromContext(req.Context()).WithField("url", req.URL.String()).
+			Trace("registry reported no change; using cached response")
+		// Drain and close the body so the underlying connection can be reused.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		// Extend the life of the cached response.
+		c.cache.SetDefault(key, cached)
+		return cached.toResponse(req), nil
+	case resp.StatusCode == http.StatusOK:
+		etag := resp.Header.Get("ETag")
+		lastModified := resp.Header.Get("Last-Modified")
+		if etag == "" && lastModified == "" {
+			// The response cannot be revalidated, so there's no point caching it.
+			return resp, nil
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxCachedResponseBytes+1))
+		if err != nil {
+			_ = resp.Body.Close()
+			return nil, err
+		}
+		if len(body) > maxCachedResponseBytes {
+			// Too large to cache. Hand back what we've read followed by the rest.
+			resp.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+			return resp, nil
+		}
+		_ = resp.Body.Close()
+		c.cache.SetDefault(key, &cachedResponse{
+			etag:         etag,
+			lastModified: lastModified,
+			header:       resp.Header.Clone(),
+			body:         body,
+		})
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	default:
+		return resp, nil
+	}
+}
+
+// toResponse returns a new http.Response for the provided request whose
+// status, headers, and body are those of the cached response.
+func (c *cachedResponse) toResponse(req *http.Request) *http.Response {
+	header := c.header.Clone()
+	header.Set("Content-Length", strconv.Itoa(len(c.body)))
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(c.body)),
+		ContentLength: int64(len(c.body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,116 @@
+package image
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachingRoundTripper(t *testing.T) {
+	const testETag = `"fake-etag"`
+	var requests, notModified int
+	var body = "fake-body"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/v2/fake/repo/blobs/fake-digest" {
+			_, _ = w.Write([]byte(body))
+			return
+		}
+		if r.Header.Get("If-None-Match") == testETag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", testETag)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	newRoundTripper := func(keyPrefix string) *cachingRoundTripper {
+		return &cachingRoundTripper{
+			cache:                cache.New(time.Minute, time.Minute),
+			keyPrefix:            keyPrefix,
+			internalRoundTripper: http.DefaultTransport,
+		}
+	}
+	get := func(t *testing.T, rt http.RoundTripper, path string) string {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+		require.NoError(t, err)
+		resp, err := rt.RoundTrip(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	testCases := []struct {
+		name       string
+		assertions func(*testing.T, *cachingRoundTripper)
+	}{
+		{
+			name: "tags are revalidated",
+			assertions: func(t *testing.T, rt *cachingRoundTripper) {
+				require.Equal(t, "fake-body", get(t, rt, "/v2/fake/repo/tags/list"))
+				require.Equal(t, "fake-body", get(t, rt, "/v2/fake/repo/tags/list"))
+				require.Equal(t, 2, requests)
+				require.Equal(t, 1, notModified)
+				// A different key prefix does not share cached responses
+				require.Equal(
+					t,
+					"fake-body",
+					get(t, newRoundTripper("other"), "/v2/fake/repo/tags/list"),
+				)
+				require.Equal(t, 3, requests)
+				require.Equal(t, 1, notModified)
+			},
+		},
+		{
+			name: "manifests are revalidated",
+			assertions: func(t *testing.T, rt *cachingRoundTripper) {
+				require.Equal(t, "fake-body", get(t, rt, "/v2/fake/repo/manifests/latest"))
+				require.Equal(t, "fake-body", get(t, rt, "/v2/fake/repo/manifests/latest"))
+				require.Equal(t, 2, requests)
+				require.Equal(t, 1, notModified)
+			},
+		},
+		{
+			name: "other requests are not cached",
+			assertions: func(t *testing.T, rt *cachingRoundTripper) {
+				require.Equal(t, "fake-body", get(t, rt, "/v2/fake/repo/blobs/fake-digest"))
+				require.Equal(t, "fake-body", get(t, rt, "/v2/fake/repo/blobs/fake-digest"))
+				require.Equal(t, 2, requests)
+				require.Zero(t, notModified)
+				require.Zero(t, rt.cache.ItemCount())
+			},
+		},
+		{
+			name: "changed responses replace cached responses",
+			assertions: func(t *testing.T, rt *cachingRoundTripper) {
+				require.Equal(t, "fake-body", get(t, rt, "/v2/fake/repo/tags/list"))
+				for key, item := range rt.cache.Items() {
+					cached := item.Object.(*cachedResponse) // nolint: forcetypeassert
+					cached.etag = `"stale-etag"`
+					rt.cache.SetDefault(key, cached)
+				}
+				body = "new-body"
+				require.Equal(t, "new-body", get(t, rt, "/v2/fake/repo/tags/list"))
+				require.Equal(t, "new-body", get(t, rt, "/v2/fake/repo/tags/list"))
+				require.Equal(t, 3, requests)
+				require.Equal(t, 1, notModified)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			requests, notModified, body = 0, 0, "fake-body"
+			testCase.assertions(t, newRoundTripper("fake-prefix"))
+		})
+	}
+}
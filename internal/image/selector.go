@@ -17,6 +17,13 @@ const (
 	// a constraint that must exactly match the name of a, presumably, mutable
 	// tag.
 	SelectionStrategyDigest SelectionStrategy = "Digest"
+	// SelectionStrategyExpression represents an image selection strategy that
+	// is useful for finding the image referenced by the tag that sorts last
+	// according to a user-supplied expression. The expression maps each tag to a
+	// sortable key (a number, string, or time) and tags are selected in
+	// descending order by that key. This strategy is useful for tagging schemes
+	// not served well by any other strategy, e.g. tags that embed a build number.
+	SelectionStrategyExpression SelectionStrategy = "Expression"
 	// SelectionStrategyLexical represents an image selection strategy that is
 	// useful for finding the the image referenced by the tag that is lexically
 	// last among those matched by a regular expression and not explicitly
@@ -53,6 +60,9 @@ type SelectorOptions struct {
 	// AllowRegex is an optional regular expression that can be used to constrain
 	// image selection based on eligible tags.
 	AllowRegex string
+	// SortExpression is an expression that maps a tag to a sortable key. It is
+	// required by, and only used by, SelectionStrategyExpression.
+	SortExpression string
 	// Ignore is an optional list of tags that should explicitly be ignored when
 	// selecting an image.
 	Ignore []string
@@ -66,6 +76,16 @@ type SelectorOptions struct {
 	// InsecureSkipTLSVerify is an optional flag, that if set to true, will
 	// disable verification of the image repository's TLS certificate.
 	InsecureSkipTLSVerify bool
+	// PreferCreatedAnnotation is an optional flag, that if set to true, will
+	// cause the creation date of each image to be read from the
+	// org.opencontainers.image.created annotation of its index or manifest,
+	// when present, instead of from its config blob. This affects the order in
+	// which SelectionStrategyNewestBuild selects images.
+	PreferCreatedAnnotation bool
+	// CacheResponses is an optional flag, that if set to true, will cause the
+	// registry's responses to requests for the repository's tags and manifests
+	// to be cached and revalidated using conditional requests.
+	CacheResponses bool
 	// DiscoveryLimit is an optional limit on the number of images that can be
 	// discovered by the Selector. The limit is applied after filtering images
 	// based on the AllowRegex and Ignore fields. If the limit is zero, all
@@ -114,10 +134,23 @@ func NewSelector(
 			err,
 		)
 	}
+	repoClient.preferCreatedAnnotation = opts.PreferCreatedAnnotation
+	if opts.CacheResponses {
+		repoClient.enableResponseCaching()
+	}
 
 	switch strategy {
 	case SelectionStrategyDigest:
 		return newDigestSelector(repoClient, opts.Constraint, platform)
+	case SelectionStrategyExpression:
+		return newExpressionSelector(
+			repoClient,
+			allowRegex,
+			opts.Ignore,
+			opts.SortExpression,
+			platform,
+			opts.DiscoveryLimit,
+		)
 	case SelectionStrategyLexical:
 		return newLexicalSelector(
 			repoClient,
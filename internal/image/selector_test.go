@@ -58,6 +58,18 @@ func TestNewSelector(t *testing.T) {
 				require.IsType(t, &digestSelector{}, selector)
 			},
 		},
+		{
+			name:     "success with expression image selector",
+			strategy: SelectionStrategyExpression,
+			opts: &SelectorOptions{
+				SortExpression: "tag",
+			},
+			repoURL: "debian",
+			assertions: func(t *testing.T, selector Selector, err error) {
+				require.NoError(t, err)
+				require.IsType(t, &expressionSelector{}, selector)
+			},
+		},
 		{
 			name:     "success with lexical image selector",
 			strategy: SelectionStrategyLexical,
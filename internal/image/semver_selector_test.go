@@ -11,8 +11,10 @@ func TestNewSemVerSelector(t *testing.T) {
 	testAllowRegex := regexp.MustCompile("fake-regex")
 	testIgnore := []string{"fake-ignore"}
 	testPlatform := &platformConstraint{
-		os:   "linux",
-		arch: "amd64",
+		include: []platformPattern{{
+			os:   "linux",
+			arch: "amd64",
+		}},
 	}
 	testDiscoveryLimit := 10
 	testCases := []struct {
@@ -41,6 +41,9 @@ type Request struct {
 	// RepoCreds encapsulates read/write credentials for the remote GitOps
 	// repository referenced by the RepoURL field.
 	RepoCreds git.RepoCredentials `json:"repoCreds,omitempty"`
+	// Limits specifies limits on the resources Kargo Render may consume while
+	// rendering manifests.
+	Limits libExec.Limits `json:"-"`
 }
 
 // Response encapsulates details of a successful rendering of some
@@ -77,7 +80,7 @@ func RenderManifests(req Request) error { // nolint: revive
 	)
 
 	res := Response{}
-	resBytes, err := libExec.Exec(cmd)
+	resBytes, err := libExec.ExecWithLimits(cmd, req.Limits)
 	if err != nil {
 		return fmt.Errorf("error rendering manifests: %w", err)
 	}
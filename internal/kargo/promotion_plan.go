@@ -0,0 +1,121 @@
+package kargo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/oklog/ulid/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/api/user"
+)
+
+// NewPromotionPlan returns a new PromotionPlan for promoting the given freight
+// all the way to the given stage, named using the same naming convention as
+// Promotions. The PromotionPlan belongs to the same shard as the stage.
+func NewPromotionPlan(
+	ctx context.Context,
+	stage kargoapi.Stage,
+	freight string,
+) kargoapi.PromotionPlan {
+	shortHash := freight
+	if len(shortHash) > 7 {
+		shortHash = freight[0:7]
+	}
+	shortStageName := stage.Name
+	if len(stage.Name) > maxStageNamePrefixLength {
+		shortStageName = shortStageName[0:maxStageNamePrefixLength]
+	}
+
+	annotations := make(map[string]string, 1)
+	if u, ok := user.InfoFromContext(ctx); ok {
+		annotations[kargoapi.AnnotationKeyCreateActor] = kargoapi.FormatEventUserActor(u)
+	}
+
+	var labels map[string]string
+	if shard, ok := stage.Labels[kargoapi.ShardLabelKey]; ok {
+		labels = map[string]string{kargoapi.ShardLabelKey: shard}
+	}
+
+	return kargoapi.PromotionPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: strings.ToLower(
+				fmt.Sprintf("%s.%s.%s", shortStageName, ulid.Make(), shortHash),
+			),
+			Namespace:   stage.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: kargoapi.PromotionPlanSpec{
+			Stage:   stage.Name,
+			Freight: freight,
+		},
+	}
+}
+
+// PromotionPlanStages returns, in the order the provided Freight should be
+// promoted to them, the Stages the Freight must be promoted to for it to reach
+// the target Stage. The path is found by walking upstream from the target
+// Stage until reaching a Stage the Freight is already available to, e.g.
+// because it has been verified in one of that Stage's upstream Stages. Where
+// a Stage has more than one upstream Stage, the first one through which the
+// Freight can reach it is used. The target Stage is always included. An error
+// is returned if the Freight cannot reach the target Stage.
+func PromotionPlanStages(
+	freight *kargoapi.Freight,
+	stages []kargoapi.Stage,
+	target string,
+) ([]string, error) {
+	if freight.IsBlocked() {
+		return nil, fmt.Errorf("Freight %q is blocked", freight.Name)
+	}
+	stagesByName := make(map[string]*kargoapi.Stage, len(stages))
+	for i := range stages {
+		stagesByName[stages[i].Name] = &stages[i]
+	}
+	if _, ok := stagesByName[target]; !ok {
+		return nil, fmt.Errorf("Stage %q not found", target)
+	}
+
+	var pathTo func(name string, visited []string) []string
+	pathTo = func(name string, visited []string) []string {
+		stage, ok := stagesByName[name]
+		if !ok || slices.Contains(visited, name) {
+			return nil
+		}
+		subs := stage.Spec.Subscriptions
+		if subs.Service != "" && freight.Service != subs.Service {
+			return nil
+		}
+		if subs.Warehouse != "" && subs.Warehouse != freight.Warehouse {
+			return nil
+		}
+		upstreams := make([]string, len(subs.UpstreamStages))
+		for i, upstream := range subs.UpstreamStages {
+			upstreams[i] = upstream.Name
+		}
+		if kargoapi.IsFreightAvailable(freight, name, upstreams) {
+			return []string{name}
+		}
+		visited = append(visited, name)
+		for _, upstream := range upstreams {
+			if path := pathTo(upstream, visited); path != nil {
+				return append(path, name)
+			}
+		}
+		return nil
+	}
+
+	path := pathTo(target, nil)
+	if path == nil {
+		return nil, fmt.Errorf(
+			"Freight %q cannot reach Stage %q through any of its upstream Stages",
+			freight.Name,
+			target,
+		)
+	}
+	return path, nil
+}
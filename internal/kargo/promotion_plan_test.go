@@ -0,0 +1,172 @@
+package kargo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/api/user"
+)
+
+func TestNewPromotionPlan(t *testing.T) {
+	const testFreight = "f08b2e72c9b2b7b263da6d55f9536e49b5ce972c"
+	stage := kargoapi.Stage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "prod",
+			Namespace: "kargo-demo",
+			Labels: map[string]string{
+				kargoapi.ShardLabelKey: "shard",
+				"unrelated":            "label",
+			},
+		},
+	}
+	ctx := user.ContextWithInfo(context.Background(), user.Info{Subject: "alice"})
+
+	plan := NewPromotionPlan(ctx, stage, testFreight)
+
+	parts := strings.Split(plan.Name, ".")
+	require.Len(t, parts, 3)
+	require.Equal(t, "prod", parts[0])
+	require.Equal(t, testFreight[0:7], parts[2])
+	require.Equal(t, "kargo-demo", plan.Namespace)
+	require.Equal(t, map[string]string{kargoapi.ShardLabelKey: "shard"}, plan.Labels)
+	require.Equal(
+		t,
+		kargoapi.FormatEventUserActor(user.Info{Subject: "alice"}),
+		plan.Annotations[kargoapi.AnnotationKeyCreateActor],
+	)
+	require.Equal(t, "prod", plan.Spec.Stage)
+	require.Equal(t, testFreight, plan.Spec.Freight)
+}
+
+func TestPromotionPlanStages(t *testing.T) {
+	newStage := func(name string, subs kargoapi.Subscriptions) kargoapi.Stage {
+		return kargoapi.Stage{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kargoapi.StageSpec{
+				Subscriptions: subs,
+			},
+		}
+	}
+	upstreams := func(names ...string) kargoapi.Subscriptions {
+		subs := kargoapi.Subscriptions{}
+		for _, name := range names {
+			subs.UpstreamStages = append(
+				subs.UpstreamStages,
+				kargoapi.StageSubscription{Name: name},
+			)
+		}
+		return subs
+	}
+	// test -> uat-a -> prod
+	//      -> uat-b ->
+	stages := []kargoapi.Stage{
+		newStage("test", kargoapi.Subscriptions{Warehouse: "warehouse"}),
+		newStage("uat-a", upstreams("test")),
+		newStage("uat-b", upstreams("test")),
+		newStage("prod", upstreams("uat-a", "uat-b")),
+		newStage("other", kargoapi.Subscriptions{Warehouse: "other-warehouse"}),
+		newStage("other-prod", upstreams("other")),
+	}
+
+	testCases := []struct {
+		name       string
+		freight    *kargoapi.Freight
+		target     string
+		assertions func(*testing.T, []string, error)
+	}{
+		{
+			name: "Freight is blocked",
+			freight: &kargoapi.Freight{
+				ObjectMeta: metav1.ObjectMeta{Name: "freight"},
+				Warehouse:  "warehouse",
+				Status: kargoapi.FreightStatus{
+					Blocked: &kargoapi.FreightBlock{Reason: "bad"},
+				},
+			},
+			target: "prod",
+			assertions: func(t *testing.T, _ []string, err error) {
+				require.ErrorContains(t, err, "is blocked")
+			},
+		},
+		{
+			name: "target Stage not found",
+			freight: &kargoapi.Freight{
+				ObjectMeta: metav1.ObjectMeta{Name: "freight"},
+				Warehouse:  "warehouse",
+			},
+			target: "missing",
+			assertions: func(t *testing.T, _ []string, err error) {
+				require.ErrorContains(t, err, `Stage "missing" not found`)
+			},
+		},
+		{
+			name: "Freight from another Warehouse",
+			freight: &kargoapi.Freight{
+				ObjectMeta: metav1.ObjectMeta{Name: "freight"},
+				Warehouse:  "warehouse",
+			},
+			target: "other-prod",
+			assertions: func(t *testing.T, _ []string, err error) {
+				require.ErrorContains(t, err, "cannot reach Stage")
+			},
+		},
+		{
+			name: "new Freight",
+			freight: &kargoapi.Freight{
+				ObjectMeta: metav1.ObjectMeta{Name: "freight"},
+				Warehouse:  "warehouse",
+			},
+			target: "prod",
+			assertions: func(t *testing.T, path []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"test", "uat-a", "prod"}, path)
+			},
+		},
+		{
+			name: "Freight verified in some upstream Stages",
+			freight: &kargoapi.Freight{
+				ObjectMeta: metav1.ObjectMeta{Name: "freight"},
+				Warehouse:  "warehouse",
+				Status: kargoapi.FreightStatus{
+					VerifiedIn: map[string]kargoapi.VerifiedStage{
+						"test":  {},
+						"uat-b": {},
+					},
+				},
+			},
+			target: "prod",
+			assertions: func(t *testing.T, path []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"prod"}, path)
+			},
+		},
+		{
+			name: "Freight approved for an intermediate Stage",
+			freight: &kargoapi.Freight{
+				ObjectMeta: metav1.ObjectMeta{Name: "freight"},
+				Warehouse:  "warehouse",
+				Status: kargoapi.FreightStatus{
+					ApprovedFor: map[string]kargoapi.ApprovedStage{
+						"uat-a": {},
+					},
+				},
+			},
+			target: "prod",
+			assertions: func(t *testing.T, path []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"uat-a", "prod"}, path)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := PromotionPlanStages(tc.freight, stages, tc.target)
+			tc.assertions(t, path, err)
+		})
+	}
+}
@@ -7,21 +7,102 @@ import (
 	libExec "github.com/akuity/kargo/internal/exec"
 )
 
-// SetImage runs `kustomize edit set image ...` in the specified directory.
-// The specified directory must already exist and contain a kustomization.yaml
-// file.
-func SetImage(dir, fqImageRef string) error {
-	_, err := libExec.Exec(buildSetImageCmd(dir, fqImageRef))
+// Patch describes a patch in a kustomization and, optionally, the resources it
+// targets.
+type Patch struct {
+	Path               string
+	Group              string
+	Version            string
+	Kind               string
+	Name               string
+	Namespace          string
+	LabelSelector      string
+	AnnotationSelector string
+}
+
+// SetImage runs `kustomize edit set image ...` in the specified directory,
+// subject to the provided limits. The specified directory must already exist
+// and contain a kustomization.yaml file.
+func SetImage(dir, fqImageRef string, limits libExec.Limits) error {
+	_, err := libExec.ExecWithLimits(buildSetImageCmd(dir, fqImageRef), limits)
+	return err
+}
+
+// AddResources runs `kustomize edit add resource ...` in the specified
+// directory, subject to the provided limits. The specified directory must
+// already exist and contain a kustomization.yaml file.
+func AddResources(dir string, resources []string, limits libExec.Limits) error {
+	_, err := libExec.ExecWithLimits(
+		buildEditCmd(dir, append([]string{"add", "resource"}, resources...)...),
+		limits,
+	)
+	return err
+}
+
+// RemoveResources runs `kustomize edit remove resource ...` in the specified
+// directory, subject to the provided limits. The specified directory must
+// already exist and contain a kustomization.yaml file.
+func RemoveResources(dir string, resources []string, limits libExec.Limits) error {
+	_, err := libExec.ExecWithLimits(
+		buildEditCmd(dir, append([]string{"remove", "resource"}, resources...)...),
+		limits,
+	)
+	return err
+}
+
+// AddPatch runs `kustomize edit add patch ...` in the specified directory,
+// subject to the provided limits. The specified directory must already exist
+// and contain a kustomization.yaml file.
+func AddPatch(dir string, patch Patch, limits libExec.Limits) error {
+	_, err := libExec.ExecWithLimits(
+		buildEditCmd(dir, append([]string{"add", "patch"}, patch.flags()...)...),
+		limits,
+	)
 	return err
 }
 
+// RemovePatch runs `kustomize edit remove patch ...` in the specified
+// directory, subject to the provided limits. The specified directory must
+// already exist and contain a kustomization.yaml file.
+func RemovePatch(dir string, patch Patch, limits libExec.Limits) error {
+	_, err := libExec.ExecWithLimits(
+		buildEditCmd(dir, append([]string{"remove", "patch"}, patch.flags()...)...),
+		limits,
+	)
+	return err
+}
+
+// flags returns the flags of `kustomize edit add patch` and `kustomize edit
+// remove patch` that describe the patch.
+func (p Patch) flags() []string {
+	flags := []string{"--path", p.Path}
+	for _, flag := range []struct {
+		name  string
+		value string
+	}{
+		{"--group", p.Group},
+		{"--version", p.Version},
+		{"--kind", p.Kind},
+		{"--name", p.Name},
+		{"--namespace", p.Namespace},
+		{"--label-selector", p.LabelSelector},
+		{"--annotation-selector", p.AnnotationSelector},
+	} {
+		if flag.value != "" {
+			flags = append(flags, flag.name, flag.value)
+		}
+	}
+	return flags
+}
+
 func buildSetImageCmd(dir, fqImageRef string) *exec.Cmd {
+	return buildEditCmd(dir, "set", "image", fqImageRef)
+}
+
+func buildEditCmd(dir string, args ...string) *exec.Cmd {
 	cmd := exec.Command( // nolint: gosec
 		"kustomize",
-		"edit",
-		"set",
-		"image",
-		fqImageRef,
+		append([]string{"edit"}, args...)...,
 	)
 	cmd.Env = append(cmd.Env, os.Environ()...)
 	cmd.Dir = dir
@@ -26,3 +26,56 @@ func TestBuildSetImageCmd(t *testing.T) {
 	)
 	require.Equal(t, testDir, cmd.Dir)
 }
+
+func TestBuildEditCmd(t *testing.T) {
+	const testDir = "/some-dir"
+	cmd := buildEditCmd(testDir, "add", "resource", "a.yaml", "b.yaml")
+	require.NotNil(t, cmd)
+	require.True(t, strings.HasSuffix(cmd.Path, "kustomize"))
+	require.Equal(
+		t,
+		[]string{"kustomize", "edit", "add", "resource", "a.yaml", "b.yaml"},
+		cmd.Args,
+	)
+	require.Equal(t, testDir, cmd.Dir)
+}
+
+func TestPatchFlags(t *testing.T) {
+	testCases := []struct {
+		name     string
+		patch    Patch
+		expected []string
+	}{
+		{
+			name:     "path only",
+			patch:    Patch{Path: "patch.yaml"},
+			expected: []string{"--path", "patch.yaml"},
+		},
+		{
+			name: "path and target",
+			patch: Patch{
+				Path:          "patch.yaml",
+				Group:         "apps",
+				Version:       "v1",
+				Kind:          "Deployment",
+				Name:          "my-app",
+				Namespace:     "my-namespace",
+				LabelSelector: "app=my-app",
+			},
+			expected: []string{
+				"--path", "patch.yaml",
+				"--group", "apps",
+				"--version", "v1",
+				"--kind", "Deployment",
+				"--name", "my-app",
+				"--namespace", "my-namespace",
+				"--label-selector", "app=my-app",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			require.Equal(t, testCase.expected, testCase.patch.flags())
+		})
+	}
+}
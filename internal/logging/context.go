@@ -11,6 +11,7 @@ import (
 	runtimelog "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/akuity/kargo/internal/os"
+	"github.com/akuity/kargo/internal/redact"
 )
 
 type loggerContextKey struct{}
@@ -24,6 +25,8 @@ func init() {
 		panic(err)
 	}
 	globalLogger.Logger.SetLevel(level)
+	// Mask any secrets known to the context of a log entry.
+	globalLogger.Logger.AddHook(redact.LogHook{})
 	SetKLogLevel(os.GetEnvInt("KLOG_LEVEL", 0))
 
 	runtimelog.SetLogger(logrusr.New(globalLogger))
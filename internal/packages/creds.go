@@ -0,0 +1,14 @@
+package packages
+
+// Credentials represents the credentials for connecting to a private package
+// registry.
+type Credentials struct {
+	// Username identifies a principal, which combined with the value of the
+	// Password field, can be used for reading from some package registry. If
+	// it is empty, the value of the Password field is used as a bearer token
+	// instead.
+	Username string
+	// Password, when combined with the principal identified by the Username
+	// field, can be used for reading from some package registry.
+	Password string
+}
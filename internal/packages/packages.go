@@ -0,0 +1,308 @@
+package packages
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+const (
+	// DefaultPyPIRepoURL is the URL of the simple repository API of the
+	// public Python package index.
+	DefaultPyPIRepoURL = "https://pypi.org/simple"
+	// DefaultNPMRepoURL is the URL of the public npm registry.
+	DefaultNPMRepoURL = "https://registry.npmjs.org"
+	// DefaultMavenRepoURL is the URL of Maven Central.
+	DefaultMavenRepoURL = "https://repo.maven.apache.org/maven2"
+
+	// maxMetadataSize is the maximum number of bytes of package metadata read
+	// from a registry.
+	maxMetadataSize = 32 << 20 // 32 MiB
+)
+
+// RepoURL returns the provided registry URL without any trailing slash or, if
+// it is empty, the URL of the public registry for the provided package type.
+func RepoURL(pkgType kargoapi.PackageType, repoURL string) string {
+	if repoURL != "" {
+		return strings.TrimSuffix(repoURL, "/")
+	}
+	switch pkgType {
+	case kargoapi.PackageTypePyPI:
+		return DefaultPyPIRepoURL
+	case kargoapi.PackageTypeNPM:
+		return DefaultNPMRepoURL
+	case kargoapi.PackageTypeMaven:
+		return DefaultMavenRepoURL
+	}
+	return ""
+}
+
+// DiscoverVersions connects to the specified package registry and retrieves
+// all available versions of the specified package, optionally filtering by a
+// SemVer constraint. It then returns the versions in descending order.
+// Versions that cannot be parsed as SemVer are ignored.
+//
+// For Maven repositories, the name argument must specify the group ID and
+// artifact ID of the package, separated by a colon.
+//
+// The credentials argument may be nil for public registries, but must be
+// non-nil for private registries.
+//
+// It returns an error if the registry cannot be reached or if the versions
+// cannot be retrieved, but it does not return an error if no versions of the
+// package are found in the registry.
+func DiscoverVersions(
+	ctx context.Context,
+	pkgType kargoapi.PackageType,
+	repoURL string,
+	name string,
+	semverConstraint string,
+	creds *Credentials,
+) ([]string, error) {
+	repoURL = RepoURL(pkgType, repoURL)
+	var versions []string
+	var err error
+	switch pkgType {
+	case kargoapi.PackageTypePyPI:
+		versions, err = getPyPIVersions(ctx, repoURL, name, creds)
+	case kargoapi.PackageTypeNPM:
+		versions, err = getNPMVersions(ctx, repoURL, name, creds)
+	case kargoapi.PackageTypeMaven:
+		versions, err = getMavenVersions(ctx, repoURL, name, creds)
+	default:
+		return nil, fmt.Errorf("package type %q is unsupported", pkgType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error retrieving versions of package %q from registry %q: %w",
+			name,
+			repoURL,
+			err,
+		)
+	}
+
+	semvers := make(semver.Collection, 0, len(versions))
+	for _, version := range versions {
+		if sv, err := semver.NewVersion(version); err == nil {
+			semvers = append(semvers, sv)
+		}
+	}
+	if semverConstraint != "" {
+		constraint, err := semver.NewConstraint(semverConstraint)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing constraint %q: %w", semverConstraint, err)
+		}
+		filtered := make(semver.Collection, 0, len(semvers))
+		for _, sv := range semvers {
+			if constraint.Check(sv) {
+				filtered = append(filtered, sv)
+			}
+		}
+		semvers = filtered
+	}
+	if len(semvers) == 0 {
+		return nil, nil
+	}
+
+	// NB: semver.Collection sorts in ascending order by default. We want to
+	// return the versions in descending order.
+	sort.Sort(sort.Reverse(semvers))
+	res := make([]string, len(semvers))
+	for i, sv := range semvers {
+		res[i] = sv.Original()
+	}
+	return res, nil
+}
+
+// pypiNameSeparatorRegex matches the runs of characters that are equivalent
+// in the names of Python packages.
+var pypiNameSeparatorRegex = regexp.MustCompile(`[-_.]+`)
+
+// getPyPIVersions retrieves all available versions of the specified package
+// from the Python package index at repoURL using the JSON variant of the
+// simple repository API (PEP 691). Versions are taken from the list of
+// versions of the project, if the index provides one (PEP 700), and are
+// otherwise inferred from the names of the project's files.
+func getPyPIVersions(
+	ctx context.Context,
+	repoURL string,
+	name string,
+	creds *Credentials,
+) ([]string, error) {
+	normalized := strings.ToLower(pypiNameSeparatorRegex.ReplaceAllString(name, "-"))
+	project := struct {
+		Versions []string `json:"versions"`
+		Files    []struct {
+			Filename string `json:"filename"`
+		} `json:"files"`
+	}{}
+	if err := getMetadata(
+		ctx,
+		fmt.Sprintf("%s/%s/", repoURL, normalized),
+		"application/vnd.pypi.simple.v1+json",
+		creds,
+		func(r io.Reader) error { return json.NewDecoder(r).Decode(&project) },
+	); err != nil {
+		return nil, err
+	}
+	if project.Versions != nil {
+		return project.Versions, nil
+	}
+	seen := map[string]struct{}{}
+	versions := make([]string, 0, len(project.Files))
+	for _, file := range project.Files {
+		version := pypiFileVersion(file.Filename)
+		if _, ok := seen[version]; ok || version == "" {
+			continue
+		}
+		seen[version] = struct{}{}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// pypiFileVersion returns the version of a Python distribution with the
+// provided file name, or an empty string if the file name is not that of a
+// wheel or source distribution.
+func pypiFileVersion(filename string) string {
+	if base, ok := strings.CutSuffix(filename, ".whl"); ok {
+		// {name}-{version}(-{build})?-{python}-{abi}-{platform}.whl
+		parts := strings.Split(base, "-")
+		if len(parts) < 5 {
+			return ""
+		}
+		return parts[1]
+	}
+	for _, ext := range []string{".tar.gz", ".zip"} {
+		if base, ok := strings.CutSuffix(filename, ext); ok {
+			// {name}-{version}.tar.gz, where the name may contain hyphens, but
+			// the version may not.
+			if i := strings.LastIndex(base, "-"); i > 0 {
+				return base[i+1:]
+			}
+		}
+	}
+	return ""
+}
+
+// getNPMVersions retrieves all available versions of the specified package
+// from the npm registry at repoURL.
+func getNPMVersions(
+	ctx context.Context,
+	repoURL string,
+	name string,
+	creds *Credentials,
+) ([]string, error) {
+	pkg := struct {
+		Versions map[string]json.RawMessage `json:"versions"`
+	}{}
+	if err := getMetadata(
+		ctx,
+		// The slash separating the scope of a scoped package from its name must
+		// be escaped.
+		fmt.Sprintf("%s/%s", repoURL, url.PathEscape(name)),
+		// Abbreviated metadata is much smaller and contains everything needed.
+		"application/vnd.npm.install-v1+json",
+		creds,
+		func(r io.Reader) error { return json.NewDecoder(r).Decode(&pkg) },
+	); err != nil {
+		return nil, err
+	}
+	versions := make([]string, 0, len(pkg.Versions))
+	for version := range pkg.Versions {
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// getMavenVersions retrieves all available versions of the specified package
+// from the Maven repository at repoURL. The name must consist of the group ID
+// and artifact ID of the package, separated by a colon.
+func getMavenVersions(
+	ctx context.Context,
+	repoURL string,
+	name string,
+	creds *Credentials,
+) ([]string, error) {
+	groupID, artifactID, ok := strings.Cut(name, ":")
+	if !ok || groupID == "" || artifactID == "" {
+		return nil, fmt.Errorf(
+			"name %q is not of the form groupId:artifactId",
+			name,
+		)
+	}
+	metadata := struct {
+		Versions []string `xml:"versioning>versions>version"`
+	}{}
+	if err := getMetadata(
+		ctx,
+		fmt.Sprintf(
+			"%s/%s/%s/maven-metadata.xml",
+			repoURL,
+			strings.ReplaceAll(groupID, ".", "/"),
+			artifactID,
+		),
+		"application/xml",
+		creds,
+		func(r io.Reader) error { return xml.NewDecoder(r).Decode(&metadata) },
+	); err != nil {
+		return nil, err
+	}
+	return metadata.Versions, nil
+}
+
+// getMetadata retrieves package metadata from the provided URL, requesting
+// the provided media type, and decodes it using the provided function. A
+// package that is not found is not treated as an error, in which case the
+// decode function is not called.
+func getMetadata(
+	ctx context.Context,
+	metadataURL string,
+	mediaType string,
+	creds *Credentials,
+	decode func(io.Reader) error,
+) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataURL, nil)
+	if err != nil {
+		return fmt.Errorf("error preparing HTTP/S request to %q: %w", metadataURL, err)
+	}
+	req.Header.Set("Accept", mediaType)
+	if creds != nil {
+		if creds.Username == "" {
+			req.Header.Set("Authorization", "Bearer "+creds.Password)
+		} else {
+			req.SetBasicAuth(creds.Username, creds.Password)
+		}
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error querying package metadata at %q: %w", metadataURL, err)
+	}
+	defer res.Body.Close()
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf(
+			"received unexpected HTTP %d when querying package metadata at %q",
+			res.StatusCode,
+			metadataURL,
+		)
+	}
+	if err = decode(io.LimitReader(res.Body, maxMetadataSize)); err != nil {
+		return fmt.Errorf("error decoding package metadata from %q: %w", metadataURL, err)
+	}
+	return nil
+}
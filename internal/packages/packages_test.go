@@ -0,0 +1,184 @@
+package packages
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestRepoURL(t *testing.T) {
+	require.Equal(t, DefaultPyPIRepoURL, RepoURL(kargoapi.PackageTypePyPI, ""))
+	require.Equal(t, DefaultNPMRepoURL, RepoURL(kargoapi.PackageTypeNPM, ""))
+	require.Equal(t, DefaultMavenRepoURL, RepoURL(kargoapi.PackageTypeMaven, ""))
+	require.Equal(
+		t,
+		"https://npm.example.com",
+		RepoURL(kargoapi.PackageTypeNPM, "https://npm.example.com/"),
+	)
+}
+
+func TestDiscoverVersions(t *testing.T) {
+	type request struct {
+		path   string
+		accept string
+		auth   string
+	}
+	testCases := []struct {
+		name             string
+		pkgType          kargoapi.PackageType
+		pkgName          string
+		semverConstraint string
+		creds            *Credentials
+		status           int
+		body             string
+		assertions       func(*testing.T, request, []string, error)
+	}{
+		{
+			name:    "unsupported type",
+			pkgType: "bogus",
+			assertions: func(t *testing.T, _ request, _ []string, err error) {
+				require.ErrorContains(t, err, `package type "bogus" is unsupported`)
+			},
+		},
+		{
+			name:    "PyPI with versions",
+			pkgType: kargoapi.PackageTypePyPI,
+			pkgName: "My_Package",
+			creds:   &Credentials{Username: "user", Password: "pass"},
+			status:  http.StatusOK,
+			body:    `{"versions": ["1.0.0", "1.1.0", "2.0.0rc1"]}`,
+			assertions: func(t *testing.T, req request, versions []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "/my-package/", req.path)
+				require.Equal(t, "application/vnd.pypi.simple.v1+json", req.accept)
+				require.Contains(t, req.auth, "Basic ")
+				require.Equal(t, []string{"1.1.0", "1.0.0"}, versions)
+			},
+		},
+		{
+			name:    "PyPI with files only",
+			pkgType: kargoapi.PackageTypePyPI,
+			pkgName: "my-package",
+			status:  http.StatusOK,
+			body: `{"files": [
+				{"filename": "my_package-1.0.0.tar.gz"},
+				{"filename": "my_package-1.0.0-py3-none-any.whl"},
+				{"filename": "my-package-1.2.0.zip"},
+				{"filename": "my_package-1.2.0.exe"}
+			]}`,
+			assertions: func(t *testing.T, _ request, versions []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, []string{"1.2.0", "1.0.0"}, versions)
+			},
+		},
+		{
+			name:    "npm scoped package with token",
+			pkgType: kargoapi.PackageTypeNPM,
+			pkgName: "@example/lib",
+			creds:   &Credentials{Password: "token"},
+			status:  http.StatusOK,
+			body:    `{"versions": {"1.0.0": {}, "1.1.0-beta.1": {}, "1.1.0": {}}}`,
+			assertions: func(t *testing.T, req request, versions []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "/@example%2Flib", req.path)
+				require.Equal(t, "application/vnd.npm.install-v1+json", req.accept)
+				require.Equal(t, "Bearer token", req.auth)
+				require.Equal(t, []string{"1.1.0", "1.1.0-beta.1", "1.0.0"}, versions)
+			},
+		},
+		{
+			name:             "Maven with constraint",
+			pkgType:          kargoapi.PackageTypeMaven,
+			pkgName:          "com.example:lib",
+			semverConstraint: "^1.0.0",
+			status:           http.StatusOK,
+			body: `<metadata><versioning><versions>
+				<version>1.0.0</version>
+				<version>1.1.0</version>
+				<version>2.0.0</version>
+			</versions></versioning></metadata>`,
+			assertions: func(t *testing.T, req request, versions []string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, "/com/example/lib/maven-metadata.xml", req.path)
+				require.Equal(t, []string{"1.1.0", "1.0.0"}, versions)
+			},
+		},
+		{
+			name:    "Maven with invalid name",
+			pkgType: kargoapi.PackageTypeMaven,
+			pkgName: "lib",
+			assertions: func(t *testing.T, _ request, _ []string, err error) {
+				require.ErrorContains(t, err, "not of the form groupId:artifactId")
+			},
+		},
+		{
+			name:    "package not found",
+			pkgType: kargoapi.PackageTypeNPM,
+			pkgName: "lib",
+			status:  http.StatusNotFound,
+			assertions: func(t *testing.T, _ request, versions []string, err error) {
+				require.NoError(t, err)
+				require.Empty(t, versions)
+			},
+		},
+		{
+			name:    "unexpected status",
+			pkgType: kargoapi.PackageTypeNPM,
+			pkgName: "lib",
+			status:  http.StatusUnauthorized,
+			assertions: func(t *testing.T, _ request, _ []string, err error) {
+				require.ErrorContains(t, err, "received unexpected HTTP 401")
+			},
+		},
+		{
+			name:    "invalid metadata",
+			pkgType: kargoapi.PackageTypeNPM,
+			pkgName: "lib",
+			status:  http.StatusOK,
+			body:    "{",
+			assertions: func(t *testing.T, _ request, _ []string, err error) {
+				require.ErrorContains(t, err, "error decoding package metadata")
+			},
+		},
+		{
+			name:             "invalid constraint",
+			pkgType:          kargoapi.PackageTypeNPM,
+			pkgName:          "lib",
+			semverConstraint: "bogus",
+			status:           http.StatusOK,
+			body:             `{"versions": {"1.0.0": {}}}`,
+			assertions: func(t *testing.T, _ request, _ []string, err error) {
+				require.ErrorContains(t, err, "error parsing constraint")
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var req request
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				req = request{
+					path:   r.URL.EscapedPath(),
+					accept: r.Header.Get("Accept"),
+					auth:   r.Header.Get("Authorization"),
+				}
+				w.WriteHeader(testCase.status)
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			t.Cleanup(srv.Close)
+			versions, err := DiscoverVersions(
+				context.Background(),
+				testCase.pkgType,
+				srv.URL+"/",
+				testCase.pkgName,
+				testCase.semverConstraint,
+				testCase.creds,
+			)
+			testCase.assertions(t, req, versions, err)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package redact
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Mask is what every occurrence of a secret value is replaced with.
+const Mask = "********"
+
+// minSecretLength is the minimum length of a secret value for it to be
+// masked. Shorter values are ignored, as masking them would mangle innocuous
+// text in which they happen to occur.
+const minSecretLength = 4
+
+type secretsContextKey struct{}
+
+// Secrets is a set of secret values, such as passwords and tokens, that must
+// never be disclosed. It is safe for concurrent use. The zero value is an
+// empty set that is ready to use.
+type Secrets struct {
+	mu sync.RWMutex
+	// values are the secret values, longest first, so that a value containing
+	// another value is masked as a whole.
+	values []string
+}
+
+// Add adds the provided values to the set. Empty and very short values are
+// ignored. Each line of a multi-line value (e.g. a private key) is also added
+// individually so that partial disclosures are masked as well.
+func (s *Secrets) Add(values ...string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, value := range values {
+		s.add(value)
+		if strings.Contains(value, "\n") {
+			for _, line := range strings.Split(value, "\n") {
+				s.add(strings.TrimSpace(line))
+			}
+		}
+	}
+	slices.SortStableFunc(s.values, func(a, b string) int {
+		return len(b) - len(a)
+	})
+}
+
+// add adds the provided value to the set unless it is too short or already
+// present. The caller must hold the write lock.
+func (s *Secrets) add(value string) {
+	if len(value) < minSecretLength || slices.Contains(s.values, value) {
+		return
+	}
+	s.values = append(s.values, value)
+}
+
+// Redact returns the provided text with every occurrence of every value in the
+// set replaced with Mask. Calling Redact on a nil *Secrets returns the text
+// unchanged.
+func (s *Secrets) Redact(text string) string {
+	if s == nil || text == "" {
+		return text
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, value := range s.values {
+		text = strings.ReplaceAll(text, value, Mask)
+	}
+	return text
+}
+
+// ContextWithSecrets returns a context.Context that has been augmented with
+// the provided Secrets.
+func ContextWithSecrets(ctx context.Context, secrets *Secrets) context.Context {
+	return context.WithValue(ctx, secretsContextKey{}, secrets)
+}
+
+// SecretsFromContext extracts Secrets from the provided context.Context and
+// returns them. If there are none, nil is returned. Since a nil *Secrets
+// redacts nothing, the result is always safe to use.
+func SecretsFromContext(ctx context.Context) *Secrets {
+	if ctx == nil {
+		return nil
+	}
+	secrets, _ := ctx.Value(secretsContextKey{}).(*Secrets)
+	return secrets
+}
+
+// AddSecrets adds the provided values to the Secrets of the provided
+// context.Context, if it has any. Otherwise, it does nothing.
+func AddSecrets(ctx context.Context, values ...string) {
+	SecretsFromContext(ctx).Add(values...)
+}
+
+// LogHook is a logrus hook that masks the Secrets of the context.Context of
+// each log entry, if it has any, in the entry's message and string fields.
+type LogHook struct{}
+
+// Levels implements log.Hook.
+func (LogHook) Levels() []log.Level {
+	return log.AllLevels
+}
+
+// Fire implements log.Hook.
+func (LogHook) Fire(entry *log.Entry) error {
+	secrets := SecretsFromContext(entry.Context)
+	if secrets == nil {
+		return nil
+	}
+	entry.Message = secrets.Redact(entry.Message)
+	for k, v := range entry.Data {
+		switch v := v.(type) {
+		case string:
+			entry.Data[k] = secrets.Redact(v)
+		case error:
+			entry.Data[k] = secrets.Redact(v.Error())
+		}
+	}
+	return nil
+}
@@ -174,7 +174,8 @@ func (w *webhook) ValidateCreate(
 
 	if len(freight.Commits) == 0 &&
 		len(freight.Images) == 0 &&
-		len(freight.Charts) == 0 {
+		len(freight.Charts) == 0 &&
+		len(freight.Packages) == 0 {
 		return nil, apierrors.NewInvalid(
 			freightGroupKind,
 			freight.Name,
@@ -182,7 +183,7 @@ func (w *webhook) ValidateCreate(
 				field.Invalid(
 					field.NewPath(""),
 					freight,
-					"freight must contain at least one commit, image, or chart",
+					"freight must contain at least one commit, image, chart, or package",
 				),
 			},
 		)
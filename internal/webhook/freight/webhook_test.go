@@ -256,7 +256,7 @@ func TestValidateCreate(t *testing.T) {
 			},
 			assertions: func(t *testing.T, err error) {
 				require.ErrorContains(
-					t, err, "freight must contain at least one commit, image, or chart",
+					t, err, "freight must contain at least one commit, image, chart, or package",
 				)
 			},
 		},
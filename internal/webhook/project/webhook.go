@@ -189,6 +189,18 @@ func (w *webhook) validatePromotionPolicies(
 		}
 		stageNames[promotionPolicy.Stage] = struct{}{}
 	}
+	for i, promotionPolicy := range promotionPolicies {
+		if promotionPolicy.AutoPromotionMinInterval != nil &&
+			promotionPolicy.AutoPromotionMinInterval.Duration < 0 {
+			return field.ErrorList{
+				field.Invalid(
+					f.Index(i).Child("autoPromotionMinInterval"),
+					promotionPolicy.AutoPromotionMinInterval.Duration.String(),
+					"must not be negative",
+				),
+			}
+		}
+	}
 	return nil
 }
 
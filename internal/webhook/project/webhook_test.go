@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -140,11 +141,39 @@ func TestValidateSpec(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "negative auto-promotion interval",
+			spec: &kargoapi.ProjectSpec{
+				PromotionPolicies: []kargoapi.PromotionPolicy{
+					{
+						Stage:                    "fake-stage",
+						AutoPromotionMinInterval: &metav1.Duration{Duration: -time.Minute},
+					},
+				},
+			},
+			assertions: func(t *testing.T, _ *kargoapi.ProjectSpec, errs field.ErrorList) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "spec.promotionPolicies[0].autoPromotionMinInterval",
+							BadValue: "-1m0s",
+							Detail:   "must not be negative",
+						},
+					},
+					errs,
+				)
+			},
+		},
 		{
 			name: "valid",
 			spec: &kargoapi.ProjectSpec{
 				PromotionPolicies: []kargoapi.PromotionPolicy{
-					{Stage: "fake-stage"},
+					{
+						Stage:                    "fake-stage",
+						AutoPromotionMinInterval: &metav1.Duration{Duration: 30 * time.Minute},
+					},
 				},
 			},
 			assertions: func(t *testing.T, _ *kargoapi.ProjectSpec, errs field.ErrorList) {
@@ -3,6 +3,7 @@ package promotion
 import (
 	"context"
 	"fmt"
+	"time"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	authzv1 "k8s.io/api/authorization/v1"
@@ -55,6 +56,12 @@ type webhook struct {
 		types.NamespacedName,
 	) (*kargoapi.Stage, error)
 
+	getProjectFn func(
+		context.Context,
+		client.Client,
+		string,
+	) (*kargoapi.Project, error)
+
 	validateProjectFn func(
 		context.Context,
 		client.Client,
@@ -110,6 +117,7 @@ func newWebhook(
 	}
 	w.getFreightFn = kargoapi.GetFreight
 	w.getStageFn = kargoapi.GetStage
+	w.getProjectFn = kargoapi.GetProject
 	w.validateProjectFn = libWebhook.ValidateProject
 	w.authorizeFn = w.authorize
 	w.admissionRequestFromContextFn = admission.RequestFromContext
@@ -215,20 +223,56 @@ func (w *webhook) ValidateCreate(
 		return nil, err
 	}
 
+	freight, err := w.getFreightFn(ctx, w.client, types.NamespacedName{
+		Namespace: promo.Namespace,
+		Name:      promo.Spec.Freight,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get freight: %w", err)
+	}
+	if freight != nil && freight.IsBlocked() {
+		return nil, apierrors.NewInvalid(
+			promotionGroupKind,
+			promo.Name,
+			field.ErrorList{
+				field.Forbidden(
+					field.NewPath("spec", "freight"),
+					fmt.Sprintf(
+						"Freight %q is blocked: %s",
+						freight.Name,
+						freight.Status.Blocked.Reason,
+					),
+				),
+			},
+		)
+	}
+
 	req, err := w.admissionRequestFromContextFn(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("get admission request from context: %w", err)
 	}
 
-	// Record Promotion created event if the request doesn't come from Kargo controlplane
+	// Promotions created by the Kargo controlplane have either been requested
+	// through the Kargo API server, which performs this check itself, or are
+	// automatic, in which case the controllers are responsible for pausing them.
 	if !w.isRequestFromKargoControlplaneFn(req) {
-		freight, err := w.getFreightFn(ctx, w.client, types.NamespacedName{
-			Namespace: promo.Namespace,
-			Name:      promo.Spec.Freight,
-		})
+		project, err := w.getProjectFn(ctx, w.client, promo.Namespace)
 		if err != nil {
-			return nil, fmt.Errorf("get freight: %w", err)
+			return nil, fmt.Errorf("get project: %w", err)
 		}
+		if project != nil {
+			if m := project.ActiveMaintenanceMode(time.Now()); m != nil && m.RejectManualPromotions {
+				return nil, apierrors.NewForbidden(
+					promotionGroupResource,
+					promo.Name,
+					fmt.Errorf("manual promotions are not permitted: %s", m.Describe()),
+				)
+			}
+		}
+	}
+
+	// Record Promotion created event if the request doesn't come from Kargo controlplane
+	if !w.isRequestFromKargoControlplaneFn(req) {
 		w.recordPromotionCreatedEvent(ctx, req, promo, freight)
 	}
 	return nil, nil
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	authnv1 "k8s.io/api/authentication/v1"
 	authzv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -183,6 +184,39 @@ func TestValidateCreate(t *testing.T) {
 				require.Equal(t, "something went wrong", err.Error())
 			},
 		},
+		{
+			name: "blocked Freight",
+			webhook: &webhook{
+				validateProjectFn: func(
+					context.Context,
+					client.Client,
+					schema.GroupKind,
+					client.Object,
+				) error {
+					return nil
+				},
+				authorizeFn: func(context.Context, *kargoapi.Promotion, string) error {
+					return nil
+				},
+				getFreightFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{
+						ObjectMeta: v1.ObjectMeta{Name: "fake-freight"},
+						Status: kargoapi.FreightStatus{
+							Blocked: &kargoapi.FreightBlock{Reason: "bad release"},
+						},
+					}, nil
+				},
+			},
+			assertions: func(t *testing.T, r *fakeevent.EventRecorder, err error) {
+				require.ErrorContains(t, err, `Freight "fake-freight" is blocked: bad release`)
+				require.True(t, apierrors.IsInvalid(err))
+				require.Empty(t, r.Events)
+			},
+		},
 		{
 			name: "record promotion created event on non-controlplane request",
 			webhook: &webhook{
@@ -205,6 +239,13 @@ func TestValidateCreate(t *testing.T) {
 				) (*kargoapi.Freight, error) {
 					return nil, nil
 				},
+				getProjectFn: func(
+					context.Context,
+					client.Client,
+					string,
+				) (*kargoapi.Project, error) {
+					return &kargoapi.Project{}, nil
+				},
 				isRequestFromKargoControlplaneFn: libWebhook.IsRequestFromKargoControlplane(
 					regexp.MustCompile("^system:serviceaccount:kargo:(kargo-api|kargo-controller)$"),
 				),
@@ -219,6 +260,55 @@ func TestValidateCreate(t *testing.T) {
 				require.Equal(t, kargoapi.EventReasonPromotionCreated, event.Reason)
 			},
 		},
+		{
+			name: "manual promotions rejected by maintenance mode",
+			webhook: &webhook{
+				validateProjectFn: func(
+					context.Context,
+					client.Client,
+					schema.GroupKind,
+					client.Object,
+				) error {
+					return nil
+				},
+				authorizeFn: func(context.Context, *kargoapi.Promotion, string) error {
+					return nil
+				},
+				admissionRequestFromContextFn: admission.RequestFromContext,
+				getFreightFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{}, nil
+				},
+				getProjectFn: func(
+					context.Context,
+					client.Client,
+					string,
+				) (*kargoapi.Project, error) {
+					return &kargoapi.Project{
+						Spec: &kargoapi.ProjectSpec{
+							MaintenanceMode: &kargoapi.MaintenanceMode{
+								Enabled:                true,
+								RejectManualPromotions: true,
+							},
+						},
+					}, nil
+				},
+				isRequestFromKargoControlplaneFn: libWebhook.IsRequestFromKargoControlplane(
+					regexp.MustCompile("^system:serviceaccount:kargo:(kargo-api|kargo-controller)$"),
+				),
+			},
+			userInfo: &authnv1.UserInfo{
+				Username: "fake-user",
+			},
+			assertions: func(t *testing.T, r *fakeevent.EventRecorder, err error) {
+				require.True(t, apierrors.IsForbidden(err))
+				require.ErrorContains(t, err, "maintenance mode")
+				require.Empty(t, r.Events)
+			},
+		},
 		{
 			name: "skip recording promotion created event on controlplane request",
 			webhook: &webhook{
@@ -233,6 +323,13 @@ func TestValidateCreate(t *testing.T) {
 				authorizeFn: func(context.Context, *kargoapi.Promotion, string) error {
 					return nil
 				},
+				getFreightFn: func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Freight, error) {
+					return &kargoapi.Freight{}, nil
+				},
 				admissionRequestFromContextFn: admission.RequestFromContext,
 				isRequestFromKargoControlplaneFn: libWebhook.IsRequestFromKargoControlplane(
 					regexp.MustCompile("^system:serviceaccount:kargo:(kargo-api|kargo-controller)$"),
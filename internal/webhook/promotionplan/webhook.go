@@ -0,0 +1,210 @@
+package promotionplan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	authzv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+	"github.com/akuity/kargo/internal/kargo"
+	"github.com/akuity/kargo/internal/logging"
+)
+
+var (
+	promotionPlanGroupKind = schema.GroupKind{
+		Group: kargoapi.GroupVersion.Group,
+		Kind:  "PromotionPlan",
+	}
+	promotionPlanGroupResource = schema.GroupResource{
+		Group:    kargoapi.GroupVersion.Group,
+		Resource: "PromotionPlan",
+	}
+)
+
+type webhook struct {
+	client client.Client
+
+	// The following behaviors are overridable for testing purposes:
+
+	getFreightFn func(
+		context.Context,
+		client.Client,
+		types.NamespacedName,
+	) (*kargoapi.Freight, error)
+
+	listStagesFn func(context.Context, client.ObjectList, ...client.ListOption) error
+
+	admissionRequestFromContextFn func(context.Context) (admission.Request, error)
+
+	createSubjectAccessReviewFn func(
+		context.Context,
+		client.Object,
+		...client.CreateOption,
+	) error
+}
+
+func SetupWebhookWithManager(mgr ctrl.Manager) error {
+	w := newWebhook(mgr.GetClient())
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&kargoapi.PromotionPlan{}).
+		WithValidator(w).
+		Complete()
+}
+
+func newWebhook(kubeClient client.Client) *webhook {
+	w := &webhook{
+		client: kubeClient,
+	}
+	w.getFreightFn = kargoapi.GetFreight
+	w.listStagesFn = kubeClient.List
+	w.admissionRequestFromContextFn = admission.RequestFromContext
+	w.createSubjectAccessReviewFn = w.client.Create
+	return w
+}
+
+// ValidateCreate ensures that the subject creating a PromotionPlan is
+// permitted to promote to every one of the Stages the PromotionPlan will
+// promote its Freight to. Without this, the PromotionPlan could be used to
+// create Promotions the subject is not permitted to create directly.
+func (w *webhook) ValidateCreate(
+	ctx context.Context,
+	obj runtime.Object,
+) (admission.Warnings, error) {
+	plan := obj.(*kargoapi.PromotionPlan) // nolint: forcetypeassert
+
+	freight, err := w.getFreightFn(
+		ctx,
+		w.client,
+		types.NamespacedName{
+			Namespace: plan.Namespace,
+			Name:      plan.Spec.Freight,
+		},
+	)
+	if err != nil {
+		return nil, apierrors.NewInternalError(
+			fmt.Errorf("get freight: %w", err),
+		)
+	}
+	if freight == nil {
+		return nil, apierrors.NewInvalid(
+			promotionPlanGroupKind,
+			plan.Name,
+			field.ErrorList{
+				field.NotFound(field.NewPath("spec", "freight"), plan.Spec.Freight),
+			},
+		)
+	}
+
+	stages := kargoapi.StageList{}
+	if err = w.listStagesFn(
+		ctx,
+		&stages,
+		client.InNamespace(plan.Namespace),
+	); err != nil {
+		return nil, apierrors.NewInternalError(
+			fmt.Errorf("list stages: %w", err),
+		)
+	}
+	path, err := kargo.PromotionPlanStages(freight, stages.Items, plan.Spec.Stage)
+	if err != nil {
+		return nil, apierrors.NewInvalid(
+			promotionPlanGroupKind,
+			plan.Name,
+			field.ErrorList{
+				field.Invalid(field.NewPath("spec"), plan.Spec, err.Error()),
+			},
+		)
+	}
+
+	for _, stage := range path {
+		if err = w.authorize(ctx, plan, stage); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (w *webhook) ValidateUpdate(
+	context.Context,
+	runtime.Object,
+	runtime.Object,
+) (admission.Warnings, error) {
+	// The immutability of a PromotionPlan's spec is enforced by the CRD.
+	return nil, nil
+}
+
+func (w *webhook) ValidateDelete(
+	context.Context,
+	runtime.Object,
+) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (w *webhook) authorize(
+	ctx context.Context,
+	plan *kargoapi.PromotionPlan,
+	stage string,
+) error {
+	logger := logging.LoggerFromContext(ctx)
+
+	req, err := w.admissionRequestFromContextFn(ctx)
+	if err != nil {
+		logger.Error(err)
+		return apierrors.NewForbidden(
+			promotionPlanGroupResource,
+			plan.Name,
+			errors.New(
+				"error retrieving admission request from context; refusing to "+
+					"create PromotionPlan",
+			),
+		)
+	}
+
+	accessReview := &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			Groups: req.UserInfo.Groups,
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Group:     kargoapi.GroupVersion.Group,
+				Resource:  "stages",
+				Name:      stage,
+				Verb:      "promote",
+				Namespace: plan.Namespace,
+			},
+		},
+	}
+	if err := w.createSubjectAccessReviewFn(ctx, accessReview); err != nil {
+		logger.Error(err)
+		return apierrors.NewForbidden(
+			promotionPlanGroupResource,
+			plan.Name,
+			errors.New(
+				"error creating SubjectAccessReview; refusing to create PromotionPlan",
+			),
+		)
+	}
+
+	if !accessReview.Status.Allowed {
+		return apierrors.NewForbidden(
+			promotionPlanGroupResource,
+			plan.Name,
+			fmt.Errorf(
+				"subject %q is not permitted to promote to Stage %q",
+				req.UserInfo.Username,
+				stage,
+			),
+		)
+	}
+
+	return nil
+}
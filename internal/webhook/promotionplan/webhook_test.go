@@ -0,0 +1,207 @@
+package promotionplan
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kargoapi "github.com/akuity/kargo/api/v1alpha1"
+)
+
+func TestNewWebhook(t *testing.T) {
+	kubeClient := fake.NewClientBuilder().Build()
+	w := newWebhook(kubeClient)
+	// Assert that all overridable behaviors were initialized to a default:
+	require.NotNil(t, w.getFreightFn)
+	require.NotNil(t, w.listStagesFn)
+	require.NotNil(t, w.admissionRequestFromContextFn)
+	require.NotNil(t, w.createSubjectAccessReviewFn)
+}
+
+func TestValidateCreate(t *testing.T) {
+	testPlan := &kargoapi.PromotionPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "fake-project",
+			Name:      "fake-plan",
+		},
+		Spec: kargoapi.PromotionPlanSpec{
+			Freight: "fake-freight",
+			Stage:   "prod",
+		},
+	}
+	testStages := []kargoapi.Stage{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: kargoapi.StageSpec{
+				Subscriptions: kargoapi.Subscriptions{Warehouse: "fake-warehouse"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "prod"},
+			Spec: kargoapi.StageSpec{
+				Subscriptions: kargoapi.Subscriptions{
+					UpstreamStages: []kargoapi.StageSubscription{{Name: "test"}},
+				},
+			},
+		},
+	}
+
+	// newWebhook returns a webhook whose requests are permitted to promote to
+	// the provided Stages only.
+	newWebhook := func(authorized ...string) *webhook {
+		return &webhook{
+			getFreightFn: func(
+				context.Context,
+				client.Client,
+				types.NamespacedName,
+			) (*kargoapi.Freight, error) {
+				return &kargoapi.Freight{
+					ObjectMeta: metav1.ObjectMeta{Name: "fake-freight"},
+					Warehouse:  "fake-warehouse",
+				}, nil
+			},
+			listStagesFn: func(
+				_ context.Context,
+				list client.ObjectList,
+				_ ...client.ListOption,
+			) error {
+				list.(*kargoapi.StageList).Items = testStages // nolint: forcetypeassert
+				return nil
+			},
+			admissionRequestFromContextFn: func(context.Context) (admission.Request, error) {
+				return admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						UserInfo: authnv1.UserInfo{Username: "alice"},
+					},
+				}, nil
+			},
+			createSubjectAccessReviewFn: func(
+				_ context.Context,
+				obj client.Object,
+				_ ...client.CreateOption,
+			) error {
+				review := obj.(*authzv1.SubjectAccessReview) // nolint: forcetypeassert
+				for _, stage := range authorized {
+					if stage == review.Spec.ResourceAttributes.Name {
+						review.Status.Allowed = true
+					}
+				}
+				return nil
+			},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		webhook    *webhook
+		assertions func(*testing.T, error)
+	}{
+		{
+			name: "error getting Freight",
+			webhook: func() *webhook {
+				w := newWebhook("test", "prod")
+				w.getFreightFn = func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Freight, error) {
+					return nil, errors.New("something went wrong")
+				}
+				return w
+			}(),
+			assertions: func(t *testing.T, err error) {
+				require.True(t, apierrors.IsInternalError(err))
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "Freight not found",
+			webhook: func() *webhook {
+				w := newWebhook("test", "prod")
+				w.getFreightFn = func(
+					context.Context,
+					client.Client,
+					types.NamespacedName,
+				) (*kargoapi.Freight, error) {
+					return nil, nil
+				}
+				return w
+			}(),
+			assertions: func(t *testing.T, err error) {
+				require.True(t, apierrors.IsInvalid(err))
+			},
+		},
+		{
+			name: "error listing Stages",
+			webhook: func() *webhook {
+				w := newWebhook("test", "prod")
+				w.listStagesFn = func(
+					context.Context,
+					client.ObjectList,
+					...client.ListOption,
+				) error {
+					return errors.New("something went wrong")
+				}
+				return w
+			}(),
+			assertions: func(t *testing.T, err error) {
+				require.True(t, apierrors.IsInternalError(err))
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "Freight cannot reach Stage",
+			webhook: func() *webhook {
+				w := newWebhook("test", "prod")
+				w.listStagesFn = func(
+					_ context.Context,
+					list client.ObjectList,
+					_ ...client.ListOption,
+				) error {
+					list.(*kargoapi.StageList).Items = testStages[1:] // nolint: forcetypeassert
+					return nil
+				}
+				return w
+			}(),
+			assertions: func(t *testing.T, err error) {
+				require.True(t, apierrors.IsInvalid(err))
+				require.ErrorContains(t, err, "cannot reach Stage")
+			},
+		},
+		{
+			name:    "not permitted to promote to intermediate Stage",
+			webhook: newWebhook("prod"),
+			assertions: func(t *testing.T, err error) {
+				require.True(t, apierrors.IsForbidden(err))
+				require.ErrorContains(t, err, `not permitted to promote to Stage "test"`)
+			},
+		},
+		{
+			name:    "success",
+			webhook: newWebhook("test", "prod"),
+			assertions: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := testCase.webhook.ValidateCreate(
+				context.Background(),
+				testPlan.DeepCopy(),
+			)
+			testCase.assertions(t, err)
+		})
+	}
+}
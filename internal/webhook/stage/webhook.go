@@ -32,6 +32,12 @@ type webhook struct {
 
 	admissionRequestFromContextFn func(context.Context) (admission.Request, error)
 
+	getProjectFn func(
+		context.Context,
+		client.Client,
+		string,
+	) (*kargoapi.Project, error)
+
 	validateProjectFn func(
 		context.Context,
 		client.Client,
@@ -39,7 +45,10 @@ type webhook struct {
 		client.Object,
 	) error
 
-	validateCreateOrUpdateFn func(*kargoapi.Stage) (admission.Warnings, error)
+	validateCreateOrUpdateFn func(
+		context.Context,
+		*kargoapi.Stage,
+	) (admission.Warnings, error)
 
 	validateSpecFn func(*field.Path, *kargoapi.StageSpec) field.ErrorList
 
@@ -72,6 +81,7 @@ func newWebhook(
 		decoder: decoder,
 	}
 	w.admissionRequestFromContextFn = admission.RequestFromContext
+	w.getProjectFn = kargoapi.GetProject
 	w.validateProjectFn = libWebhook.ValidateProject
 	w.validateCreateOrUpdateFn = w.validateCreateOrUpdate
 	w.validateSpecFn = w.validateSpec
@@ -158,16 +168,16 @@ func (w *webhook) ValidateCreate(
 		w.validateProjectFn(ctx, w.client, stageGroupKind, stage); err != nil {
 		return nil, err
 	}
-	return w.validateCreateOrUpdateFn(stage)
+	return w.validateCreateOrUpdateFn(ctx, stage)
 }
 
 func (w *webhook) ValidateUpdate(
-	_ context.Context,
+	ctx context.Context,
 	_ runtime.Object,
 	newObj runtime.Object,
 ) (admission.Warnings, error) {
 	stage := newObj.(*kargoapi.Stage) // nolint: forcetypeassert
-	return w.validateCreateOrUpdateFn(stage)
+	return w.validateCreateOrUpdateFn(ctx, stage)
 }
 
 func (w *webhook) ValidateDelete(
@@ -179,14 +189,58 @@ func (w *webhook) ValidateDelete(
 }
 
 func (w *webhook) validateCreateOrUpdate(
+	ctx context.Context,
 	s *kargoapi.Stage,
 ) (admission.Warnings, error) {
-	if errs := w.validateSpecFn(field.NewPath("spec"), &s.Spec); len(errs) > 0 {
+	f := field.NewPath("spec")
+	errs := w.validateSpecFn(f, &s.Spec)
+	if len(s.Spec.ImagePullSecrets) > 0 {
+		project, err := w.getProjectFn(ctx, w.client, s.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("get project: %w", err)
+		}
+		errs = append(
+			errs,
+			validateImagePullSecrets(
+				f.Child("imagePullSecrets"),
+				project,
+				s.Spec.ImagePullSecrets,
+			)...,
+		)
+	}
+	if len(errs) > 0 {
 		return nil, apierrors.NewInvalid(stageGroupKind, s.Name, errs)
 	}
 	return nil, nil
 }
 
+// validateImagePullSecrets forbids image pull Secrets in any namespace that
+// the provided Project does not list among its ImagePullSecretTargets. Without
+// this, anyone permitted to edit a Stage could have the Kargo controller write
+// Secrets to, and patch ServiceAccounts in, arbitrary namespaces.
+func validateImagePullSecrets(
+	f *field.Path,
+	project *kargoapi.Project,
+	secrets []kargoapi.ImagePullSecret,
+) field.ErrorList {
+	var errs field.ErrorList
+	for i, ips := range secrets {
+		if project != nil && project.AllowsImagePullSecret(ips) {
+			continue
+		}
+		errs = append(errs, field.Forbidden(
+			f.Index(i),
+			fmt.Sprintf(
+				"namespace %q in cluster %q is not among the Project's "+
+					"imagePullSecretTargets",
+				ips.Namespace,
+				ips.Cluster,
+			),
+		))
+	}
+	return errs
+}
+
 func (w *webhook) validateSpec(
 	f *field.Path,
 	spec *kargoapi.StageSpec,
@@ -226,6 +280,18 @@ func (w *webhook) validateSubs(
 			),
 		}
 	}
+	if subs.Service != "" && subs.Warehouse == "" {
+		return field.ErrorList{
+			field.Forbidden(
+				f.Child("service"),
+				fmt.Sprintf(
+					"%s.service may only be defined in conjunction with %s.warehouse",
+					f.String(),
+					f.String(),
+				),
+			),
+		}
+	}
 	return nil
 }
 
@@ -252,10 +318,53 @@ func (w *webhook) validatePromotionMechanisms(
 			),
 		}
 	}
-	return w.validateGitRepoUpdates(
+	errs := w.validateGitRepoUpdates(
 		f.Child("gitRepoUpdates"),
 		promoMechs.GitRepoUpdates,
 	)
+	errs = append(
+		errs,
+		validatePromotionHooks(f.Child("prePromotionHooks"), promoMechs.PrePromotionHooks)...,
+	)
+	return append(
+		errs,
+		validatePromotionHooks(f.Child("postPromotionHooks"), promoMechs.PostPromotionHooks)...,
+	)
+}
+
+func validatePromotionHooks(
+	f *field.Path,
+	hooks []kargoapi.PromotionHook,
+) field.ErrorList {
+	var errs field.ErrorList
+	for i, hook := range hooks {
+		var defined int
+		for _, isDefined := range []bool{
+			hook.HTTP != nil,
+			hook.SSH != nil,
+			hook.ProjectHook != "",
+		} {
+			if isDefined {
+				defined++
+			}
+		}
+		if defined != 1 {
+			errs = append(
+				errs,
+				field.Invalid(
+					f.Index(i),
+					hook,
+					fmt.Sprintf(
+						"exactly one of %s.http, %s.ssh, or %s.projectHook must be defined",
+						f.Index(i).String(),
+						f.Index(i).String(),
+						f.Index(i).String(),
+					),
+				),
+			)
+		}
+	}
+	return errs
 }
 
 func (w *webhook) validateGitRepoUpdates(
@@ -298,7 +407,70 @@ func (w *webhook) validateGitRepoUpdate(
 			),
 		}
 	}
-	return w.validateHelmPromotionMechanism(f.Child("helm"), update.Helm)
+	return append(
+		w.validateKustomizePromotionMechanism(f.Child("kustomize"), update.Kustomize),
+		w.validateHelmPromotionMechanism(f.Child("helm"), update.Helm)...,
+	)
+}
+
+func (w *webhook) validateKustomizePromotionMechanism(
+	f *field.Path,
+	promoMech *kargoapi.KustomizePromotionMechanism,
+) field.ErrorList {
+	if promoMech == nil {
+		return nil
+	}
+	// This mechanism must define at least one change to apply
+	if len(promoMech.Images) == 0 && len(promoMech.Resources) == 0 &&
+		len(promoMech.Patches) == 0 {
+		return field.ErrorList{
+			field.Invalid(
+				f,
+				promoMech,
+				fmt.Sprintf(
+					"at least one of %s.images, %s.resources, or %s.patches must be "+
+						"non-empty",
+					f.String(),
+					f.String(),
+					f.String(),
+				),
+			),
+		}
+	}
+	var errs field.ErrorList
+	for i, update := range promoMech.Resources {
+		if len(update.Add) == 0 && len(update.Remove) == 0 {
+			errs = append(
+				errs,
+				field.Invalid(
+					f.Child("resources").Index(i),
+					update,
+					fmt.Sprintf(
+						"at least one of %s.add or %s.remove must be non-empty",
+						f.Child("resources").Index(i).String(),
+						f.Child("resources").Index(i).String(),
+					),
+				),
+			)
+		}
+	}
+	for i, update := range promoMech.Patches {
+		if len(update.Add) == 0 && len(update.Remove) == 0 {
+			errs = append(
+				errs,
+				field.Invalid(
+					f.Child("patches").Index(i),
+					update,
+					fmt.Sprintf(
+						"at least one of %s.add or %s.remove must be non-empty",
+						f.Child("patches").Index(i).String(),
+						f.Child("patches").Index(i).String(),
+					),
+				),
+			)
+		}
+	}
+	return errs
 }
 
 func (w *webhook) validateHelmPromotionMechanism(
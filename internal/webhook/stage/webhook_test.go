@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	admissionv1 "k8s.io/api/admission/v1"
 	authnv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -30,6 +31,7 @@ func TestNewWebhook(t *testing.T) {
 	)
 	// Assert that all overridable behaviors were initialized to a default:
 	require.NotNil(t, w.admissionRequestFromContextFn)
+	require.NotNil(t, w.getProjectFn)
 	require.NotNil(t, w.validateProjectFn)
 	require.NotNil(t, w.validateCreateOrUpdateFn)
 	require.NotNil(t, w.validateSpecFn)
@@ -869,6 +871,7 @@ func TestValidateCreate(t *testing.T) {
 					return nil
 				},
 				validateCreateOrUpdateFn: func(
+					context.Context,
 					*kargoapi.Stage,
 				) (admission.Warnings, error) {
 					return nil, errors.New("something went wrong")
@@ -891,6 +894,7 @@ func TestValidateCreate(t *testing.T) {
 					return nil
 				},
 				validateCreateOrUpdateFn: func(
+					context.Context,
 					*kargoapi.Stage,
 				) (admission.Warnings, error) {
 					return nil, nil
@@ -922,6 +926,7 @@ func TestValidateUpdate(t *testing.T) {
 			name: "error validating stage",
 			webhook: &webhook{
 				validateCreateOrUpdateFn: func(
+					context.Context,
 					*kargoapi.Stage,
 				) (admission.Warnings, error) {
 					return nil, errors.New("something went wrong")
@@ -936,6 +941,7 @@ func TestValidateUpdate(t *testing.T) {
 			name: "success",
 			webhook: &webhook{
 				validateCreateOrUpdateFn: func(
+					context.Context,
 					*kargoapi.Stage,
 				) (admission.Warnings, error) {
 					return nil, nil
@@ -968,6 +974,7 @@ func TestValidateCreateOrUpdate(t *testing.T) {
 	testCases := []struct {
 		name       string
 		webhook    *webhook
+		stage      *kargoapi.Stage
 		assertions func(*testing.T, error)
 	}{
 		{
@@ -984,6 +991,89 @@ func TestValidateCreateOrUpdate(t *testing.T) {
 				require.Error(t, err)
 			},
 		},
+		{
+			name: "error getting project",
+			webhook: &webhook{
+				validateSpecFn: func(
+					*field.Path,
+					*kargoapi.StageSpec,
+				) field.ErrorList {
+					return nil
+				},
+				getProjectFn: func(
+					context.Context,
+					client.Client,
+					string,
+				) (*kargoapi.Project, error) {
+					return nil, errors.New("something went wrong")
+				},
+			},
+			stage: &kargoapi.Stage{
+				Spec: kargoapi.StageSpec{
+					ImagePullSecrets: []kargoapi.ImagePullSecret{{
+						Namespace: "fake-namespace",
+						Name:      "fake-secret",
+					}},
+				},
+			},
+			assertions: func(t *testing.T, err error) {
+				require.ErrorContains(t, err, "get project")
+				require.ErrorContains(t, err, "something went wrong")
+			},
+		},
+		{
+			name: "image pull Secret namespace not allowed",
+			webhook: &webhook{
+				validateSpecFn: func(
+					*field.Path,
+					*kargoapi.StageSpec,
+				) field.ErrorList {
+					return nil
+				},
+				getProjectFn: func(
+					context.Context,
+					client.Client,
+					string,
+				) (*kargoapi.Project, error) {
+					return &kargoapi.Project{
+						Spec: &kargoapi.ProjectSpec{
+							ImagePullSecretTargets: []kargoapi.ImagePullSecretTarget{{
+								Namespace: "fake-namespace",
+							}},
+						},
+					}, nil
+				},
+			},
+			stage: &kargoapi.Stage{
+				Spec: kargoapi.StageSpec{
+					ImagePullSecrets: []kargoapi.ImagePullSecret{
+						{
+							Namespace: "fake-namespace",
+							Name:      "fake-secret",
+						},
+						{
+							Namespace: "kube-system",
+							Name:      "fake-secret",
+						},
+					},
+				},
+			},
+			assertions: func(t *testing.T, err error) {
+				statusErr, ok := err.(*apierrors.StatusError)
+				require.True(t, ok)
+				require.Len(t, statusErr.ErrStatus.Details.Causes, 1)
+				require.Equal(
+					t,
+					"spec.imagePullSecrets[1]",
+					statusErr.ErrStatus.Details.Causes[0].Field,
+				)
+				require.Equal(
+					t,
+					metav1.CauseType(field.ErrorTypeForbidden),
+					statusErr.ErrStatus.Details.Causes[0].Type,
+				)
+			},
+		},
 		{
 			name: "success",
 			webhook: &webhook{
@@ -1001,12 +1091,101 @@ func TestValidateCreateOrUpdate(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			_, err := testCase.webhook.validateCreateOrUpdate(&kargoapi.Stage{})
+			stage := testCase.stage
+			if stage == nil {
+				stage = &kargoapi.Stage{}
+			}
+			_, err := testCase.webhook.validateCreateOrUpdate(
+				context.Background(),
+				stage,
+			)
 			testCase.assertions(t, err)
 		})
 	}
 }
 
+func TestValidateImagePullSecrets(t *testing.T) {
+	secrets := []kargoapi.ImagePullSecret{
+		{
+			Namespace: "fake-namespace",
+			Name:      "fake-secret",
+		},
+		{
+			Cluster:   "fake-cluster",
+			Namespace: "fake-namespace",
+			Name:      "fake-secret",
+		},
+	}
+	testCases := []struct {
+		name       string
+		project    *kargoapi.Project
+		assertions func(*testing.T, field.ErrorList)
+	}{
+		{
+			name: "project not found",
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 2)
+			},
+		},
+		{
+			name:    "no targets",
+			project: &kargoapi.Project{Spec: &kargoapi.ProjectSpec{}},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 2)
+			},
+		},
+		{
+			name: "some targets",
+			project: &kargoapi.Project{
+				Spec: &kargoapi.ProjectSpec{
+					ImagePullSecretTargets: []kargoapi.ImagePullSecretTarget{{
+						Namespace: "fake-namespace",
+					}},
+				},
+			},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						field.Forbidden(
+							field.NewPath("imagePullSecrets").Index(1),
+							`namespace "fake-namespace" in cluster "fake-cluster" is `+
+								"not among the Project's imagePullSecretTargets",
+						),
+					},
+					errs,
+				)
+			},
+		},
+		{
+			name: "all targets",
+			project: &kargoapi.Project{
+				Spec: &kargoapi.ProjectSpec{
+					ImagePullSecretTargets: []kargoapi.ImagePullSecretTarget{
+						{Namespace: "fake-namespace"},
+						{Cluster: "fake-cluster", Namespace: "fake-namespace"},
+					},
+				},
+			},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Empty(t, errs)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				t,
+				validateImagePullSecrets(
+					field.NewPath("imagePullSecrets"),
+					testCase.project,
+					secrets,
+				),
+			)
+		})
+	}
+}
+
 func TestValidateSpec(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -1145,6 +1324,31 @@ func TestValidateSubs(t *testing.T) {
 			},
 		},
 
+		{
+			name: "has service without warehouse sub",
+			subs: &kargoapi.Subscriptions{
+				Service: "test-service",
+				UpstreamStages: []kargoapi.StageSubscription{
+					{},
+				},
+			},
+			assertions: func(t *testing.T, _ *kargoapi.Subscriptions, errs field.ErrorList) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeForbidden,
+							Field:    "subscriptions.service",
+							BadValue: "",
+							Detail: "subscriptions.service may only be defined in " +
+								"conjunction with subscriptions.warehouse",
+						},
+					},
+					errs,
+				)
+			},
+		},
+
 		{
 			name: "success",
 			subs: &kargoapi.Subscriptions{
@@ -1154,6 +1358,17 @@ func TestValidateSubs(t *testing.T) {
 				require.Nil(t, errs)
 			},
 		},
+
+		{
+			name: "success with service",
+			subs: &kargoapi.Subscriptions{
+				Warehouse: "test-warehouse",
+				Service:   "test-service",
+			},
+			assertions: func(t *testing.T, _ *kargoapi.Subscriptions, errs field.ErrorList) {
+				require.Nil(t, errs)
+			},
+		},
 	}
 	w := &webhook{}
 	for _, testCase := range testCases {
@@ -1209,12 +1424,82 @@ func TestValidatePromotionMechanisms(t *testing.T) {
 			},
 		},
 
+		{
+			name: "invalid hooks",
+			promoMechs: &kargoapi.PromotionMechanisms{
+				GitRepoUpdates: []kargoapi.GitRepoUpdate{
+					{
+						Kustomize: &kargoapi.KustomizePromotionMechanism{
+							Images: []kargoapi.KustomizeImageUpdate{{}},
+						},
+					},
+				},
+				PrePromotionHooks: []kargoapi.PromotionHook{{}},
+				PostPromotionHooks: []kargoapi.PromotionHook{
+					{ProjectHook: "warm-caches"},
+					{
+						HTTP:        &kargoapi.HTTPPromotionHook{URL: "https://example.com"},
+						ProjectHook: "warm-caches",
+					},
+					{
+						SSH: &kargoapi.SSHPromotionHook{
+							Host:       "vm.example.com",
+							SecretName: "vm-creds",
+							Command:    "systemctl restart app",
+						},
+					},
+					{
+						HTTP: &kargoapi.HTTPPromotionHook{URL: "https://example.com"},
+						SSH:  &kargoapi.SSHPromotionHook{Host: "vm.example.com"},
+					},
+				},
+			},
+			assertions: func(
+				t *testing.T,
+				promoMechs *kargoapi.PromotionMechanisms,
+				errs field.ErrorList,
+			) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "promotionMechanisms.prePromotionHooks[0]",
+							BadValue: promoMechs.PrePromotionHooks[0],
+							Detail: "exactly one of promotionMechanisms.prePromotionHooks[0].http, " +
+								"promotionMechanisms.prePromotionHooks[0].ssh, " +
+								"or promotionMechanisms.prePromotionHooks[0].projectHook must be defined",
+						},
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "promotionMechanisms.postPromotionHooks[1]",
+							BadValue: promoMechs.PostPromotionHooks[1],
+							Detail: "exactly one of promotionMechanisms.postPromotionHooks[1].http, " +
+								"promotionMechanisms.postPromotionHooks[1].ssh, " +
+								"or promotionMechanisms.postPromotionHooks[1].projectHook must be defined",
+						},
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "promotionMechanisms.postPromotionHooks[3]",
+							BadValue: promoMechs.PostPromotionHooks[3],
+							Detail: "exactly one of promotionMechanisms.postPromotionHooks[3].http, " +
+								"promotionMechanisms.postPromotionHooks[3].ssh, " +
+								"or promotionMechanisms.postPromotionHooks[3].projectHook must be defined",
+						},
+					},
+					errs,
+				)
+			},
+		},
+
 		{
 			name: "valid",
 			promoMechs: &kargoapi.PromotionMechanisms{
 				GitRepoUpdates: []kargoapi.GitRepoUpdate{
 					{
-						Kustomize: &kargoapi.KustomizePromotionMechanism{},
+						Kustomize: &kargoapi.KustomizePromotionMechanism{
+							Images: []kargoapi.KustomizeImageUpdate{{}},
+						},
 					},
 				},
 			},
@@ -1272,7 +1557,9 @@ func TestValidateGitRepoUpdates(t *testing.T) {
 		{
 			name: "valid",
 			update: kargoapi.GitRepoUpdate{
-				Kustomize: &kargoapi.KustomizePromotionMechanism{},
+				Kustomize: &kargoapi.KustomizePromotionMechanism{
+					Images: []kargoapi.KustomizeImageUpdate{{}},
+				},
 			},
 			assertions: func(t *testing.T, _ kargoapi.GitRepoUpdate, errs field.ErrorList) {
 				require.Nil(t, errs)
@@ -1330,7 +1617,9 @@ func TestValidateGitRepoUpdate(t *testing.T) {
 		{
 			name: "valid",
 			update: kargoapi.GitRepoUpdate{
-				Kustomize: &kargoapi.KustomizePromotionMechanism{},
+				Kustomize: &kargoapi.KustomizePromotionMechanism{
+					Images: []kargoapi.KustomizeImageUpdate{{}},
+				},
 			},
 			assertions: func(t *testing.T, _ kargoapi.GitRepoUpdate, errs field.ErrorList) {
 				require.Nil(t, errs)
@@ -1352,6 +1641,110 @@ func TestValidateGitRepoUpdate(t *testing.T) {
 	}
 }
 
+func TestValidateKustomizePromotionMechanism(t *testing.T) {
+	testCases := []struct {
+		name       string
+		promoMech  *kargoapi.KustomizePromotionMechanism
+		assertions func(*testing.T, *kargoapi.KustomizePromotionMechanism, field.ErrorList)
+	}{
+		{
+			name: "nil",
+			assertions: func(t *testing.T, _ *kargoapi.KustomizePromotionMechanism, errs field.ErrorList) {
+				require.Empty(t, errs)
+			},
+		},
+
+		{
+			name: "no changes",
+			// Doesn't define any changes
+			promoMech: &kargoapi.KustomizePromotionMechanism{},
+			assertions: func(
+				t *testing.T,
+				promoMech *kargoapi.KustomizePromotionMechanism,
+				errs field.ErrorList,
+			) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "kustomize",
+							BadValue: promoMech,
+							Detail: "at least one of kustomize.images, kustomize.resources, or " +
+								"kustomize.patches must be non-empty",
+						},
+					},
+					errs,
+				)
+			},
+		},
+
+		{
+			name: "resources and patches updates without changes",
+			promoMech: &kargoapi.KustomizePromotionMechanism{
+				Resources: []kargoapi.KustomizeResourcesUpdate{{Path: "fake-path"}},
+				Patches:   []kargoapi.KustomizePatchesUpdate{{Path: "fake-path"}},
+			},
+			assertions: func(
+				t *testing.T,
+				promoMech *kargoapi.KustomizePromotionMechanism,
+				errs field.ErrorList,
+			) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "kustomize.resources[0]",
+							BadValue: promoMech.Resources[0],
+							Detail: "at least one of kustomize.resources[0].add or " +
+								"kustomize.resources[0].remove must be non-empty",
+						},
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "kustomize.patches[0]",
+							BadValue: promoMech.Patches[0],
+							Detail: "at least one of kustomize.patches[0].add or " +
+								"kustomize.patches[0].remove must be non-empty",
+						},
+					},
+					errs,
+				)
+			},
+		},
+
+		{
+			name: "valid",
+			promoMech: &kargoapi.KustomizePromotionMechanism{
+				Resources: []kargoapi.KustomizeResourcesUpdate{{
+					Path: "fake-path",
+					Add:  []string{"fake-resource.yaml"},
+				}},
+				Patches: []kargoapi.KustomizePatchesUpdate{{
+					Path:   "fake-path",
+					Remove: []kargoapi.KustomizePatch{{Path: "fake-patch.yaml"}},
+				}},
+			},
+			assertions: func(t *testing.T, _ *kargoapi.KustomizePromotionMechanism, errs field.ErrorList) {
+				require.Empty(t, errs)
+			},
+		},
+	}
+	w := &webhook{}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				t,
+				testCase.promoMech,
+				w.validateKustomizePromotionMechanism(
+					field.NewPath("kustomize"),
+					testCase.promoMech,
+				),
+			)
+		})
+	}
+}
+
 func TestValidateHelmPromotionMechanism(t *testing.T) {
 	testCases := []struct {
 		name       string
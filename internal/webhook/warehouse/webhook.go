@@ -7,6 +7,7 @@ import (
 
 	"github.com/Masterminds/semver/v3"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -18,6 +19,7 @@ import (
 	"github.com/akuity/kargo/internal/git"
 	"github.com/akuity/kargo/internal/helm"
 	"github.com/akuity/kargo/internal/image"
+	"github.com/akuity/kargo/internal/packages"
 	libWebhook "github.com/akuity/kargo/internal/webhook"
 )
 
@@ -128,7 +130,55 @@ func (w *webhook) validateSpec(
 	if spec == nil { // nil spec is caught by declarative validations
 		return nil
 	}
-	return w.validateSubs(f.Child("subscriptions"), spec.Subscriptions)
+	return append(
+		w.validateSubs(f.Child("subscriptions"), spec.Subscriptions),
+		validatePolling(f.Child("polling"), spec.Polling)...,
+	)
+}
+
+// validatePolling validates that the intervals of the provided polling
+// configuration are positive and that the minimum interval does not exceed
+// the interval.
+func validatePolling(
+	f *field.Path,
+	polling *kargoapi.WarehousePolling,
+) field.ErrorList {
+	if polling == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	for _, interval := range []struct {
+		name     string
+		duration *metav1.Duration
+	}{
+		{name: "interval", duration: polling.Interval},
+		{name: "minInterval", duration: polling.MinInterval},
+	} {
+		if interval.duration != nil && interval.duration.Duration <= 0 {
+			errs = append(
+				errs,
+				field.Invalid(
+					f.Child(interval.name),
+					interval.duration.Duration.String(),
+					"must be positive",
+				),
+			)
+		}
+	}
+	if len(errs) == 0 &&
+		polling.Interval != nil &&
+		polling.MinInterval != nil &&
+		polling.MinInterval.Duration > polling.Interval.Duration {
+		errs = append(
+			errs,
+			field.Invalid(
+				f.Child("minInterval"),
+				polling.MinInterval.Duration.String(),
+				fmt.Sprintf("must not exceed %s", f.Child("interval")),
+			),
+		)
+	}
+	return errs
 }
 
 func (w *webhook) validateSubs(
@@ -143,6 +193,39 @@ func (w *webhook) validateSubs(
 	for i, sub := range subs {
 		errs = append(errs, w.validateSub(f.Index(i), sub, seen)...)
 	}
+	return append(errs, validateRevisionChecks(f, subs)...)
+}
+
+// validateRevisionChecks validates that every Git repository that images are
+// expected to have been built from, by way of an image subscription's
+// RevisionCheck, is also subscribed to.
+func validateRevisionChecks(
+	f *field.Path,
+	subs []kargoapi.RepoSubscription,
+) field.ErrorList {
+	gitRepoURLs := make(map[string]struct{}, len(subs))
+	for _, sub := range subs {
+		if sub.Git != nil {
+			gitRepoURLs[git.NormalizeURL(sub.Git.RepoURL)] = struct{}{}
+		}
+	}
+	var errs field.ErrorList
+	for i, sub := range subs {
+		if sub.Image == nil || sub.Image.RevisionCheck == nil {
+			continue
+		}
+		repoURL := sub.Image.RevisionCheck.GitRepoURL
+		if _, ok := gitRepoURLs[git.NormalizeURL(repoURL)]; !ok {
+			errs = append(
+				errs,
+				field.Invalid(
+					f.Index(i).Child("image", "revisionCheck", "gitRepoURL"),
+					repoURL,
+					"no subscription to this git repository exists",
+				),
+			)
+		}
+	}
 	return errs
 }
 
@@ -165,6 +248,10 @@ func (w *webhook) validateSub(
 		repoTypes++
 		errs = append(errs, w.validateChartSub(f.Child("chart"), *sub.Chart, seen)...)
 	}
+	if sub.Package != nil {
+		repoTypes++
+		errs = append(errs, w.validatePackageSub(f.Child("package"), *sub.Package, seen)...)
+	}
 	if repoTypes != 1 {
 		errs = append(
 			errs,
@@ -172,7 +259,8 @@ func (w *webhook) validateSub(
 				f,
 				sub,
 				fmt.Sprintf(
-					"exactly one of %s.git, %s.image, or %s.chart must be non-empty",
+					"exactly one of %s.git, %s.image, %s.chart, or %s.package must be non-empty",
+					f.String(),
 					f.String(),
 					f.String(),
 					f.String(),
@@ -195,9 +283,208 @@ func (w *webhook) validateGitSub(
 	); err != nil {
 		errs = append(errs, err)
 	}
+	if sub.CommitSelectionStrategy == kargoapi.CommitSelectionStrategyCalVer {
+		if sub.CalVerLayout == "" {
+			errs = append(
+				errs,
+				field.Required(
+					f.Child("calVerLayout"),
+					"calVerLayout is required when commitSelectionStrategy is CalVer",
+				),
+			)
+		} else if _, err := git.NewCalVerLayout(sub.CalVerLayout); err != nil {
+			errs = append(
+				errs,
+				field.Invalid(f.Child("calVerLayout"), sub.CalVerLayout, err.Error()),
+			)
+		}
+	}
+	if sub.ExcludeMergeCommits && sub.OnlyMergeCommits {
+		errs = append(
+			errs,
+			field.Forbidden(
+				f.Child("onlyMergeCommits"),
+				"onlyMergeCommits cannot be used in conjunction with excludeMergeCommits",
+			),
+		)
+	}
+	if err := git.ValidateCommitFilter(sub.ExpressionFilter); err != nil {
+		errs = append(
+			errs,
+			field.Invalid(f.Child("expressionFilter"), sub.ExpressionFilter, err.Error()),
+		)
+	}
 	if err := seen.addGit(sub, f); err != nil {
 		errs = append(errs, field.Invalid(f, sub.RepoURL, err.Error()))
 	}
+	if len(sub.Services) > 0 {
+		if len(sub.IncludePaths) > 0 {
+			errs = append(
+				errs,
+				field.Forbidden(
+					f.Child("includePaths"),
+					"includePaths cannot be used in conjunction with services",
+				),
+			)
+		}
+		if err := seen.addFreightSplit("services", f); err != nil {
+			errs = append(errs, field.Forbidden(f.Child("services"), err.Error()))
+		}
+	}
+	if sub.DiscoveryMode == kargoapi.GitDiscoveryModeProviderAPI {
+		errs = append(errs, validateProviderAPIDiscovery(f, sub)...)
+	}
+	if sub.CloneDepth > 0 {
+		errs = append(errs, validateCloneDepth(f, sub)...)
+	}
+	if sub.SkipMarkers != nil && sub.CommitSelectionStrategy != "" &&
+		sub.CommitSelectionStrategy != kargoapi.CommitSelectionStrategyNewestFromBranch {
+		errs = append(
+			errs,
+			field.Forbidden(
+				f.Child("skipMarkers"),
+				fmt.Sprintf(
+					"skipMarkers can only be used with commit selection strategy %s",
+					kargoapi.CommitSelectionStrategyNewestFromBranch,
+				),
+			),
+		)
+	}
+	if git.IsBranchPattern(sub.Branch) {
+		errs = append(errs, validateBranchPattern(f, sub)...)
+		if len(sub.Services) == 0 {
+			if err := seen.addFreightSplit("a branch pattern", f); err != nil {
+				errs = append(errs, field.Forbidden(f.Child("branch"), err.Error()))
+			}
+		}
+	}
+	return errs
+}
+
+// validateBranchPattern validates the branch pattern of the provided
+// GitSubscription. Commits are only discovered from every matching branch when
+// the subscription selects the newest commit from a branch.
+func validateBranchPattern(f *field.Path, sub kargoapi.GitSubscription) field.ErrorList {
+	var errs field.ErrorList
+	if _, err := git.NewBranchMatcher(sub.Branch); err != nil {
+		errs = append(errs, field.Invalid(f.Child("branch"), sub.Branch, err.Error()))
+	}
+	if sub.CommitSelectionStrategy != "" &&
+		sub.CommitSelectionStrategy != kargoapi.CommitSelectionStrategyNewestFromBranch {
+		errs = append(
+			errs,
+			field.Forbidden(
+				f.Child("branch"),
+				fmt.Sprintf(
+					"branch patterns can only be used with commit selection strategy %s",
+					kargoapi.CommitSelectionStrategyNewestFromBranch,
+				),
+			),
+		)
+	}
+	return errs
+}
+
+// validateCloneDepth validates that the provided GitSubscription, which limits
+// the depth of the clone commits are discovered from, only discovers commits
+// from the history of branches and does not filter them by path. Tags and the
+// commits they point to may lie beyond the depth of the clone, and the paths
+// changed by the oldest commit in the clone cannot be determined, since its
+// parent is missing.
+func validateCloneDepth(f *field.Path, sub kargoapi.GitSubscription) field.ErrorList {
+	var errs field.ErrorList
+	if sub.CommitSelectionStrategy != "" &&
+		sub.CommitSelectionStrategy != kargoapi.CommitSelectionStrategyNewestFromBranch {
+		errs = append(
+			errs,
+			field.Forbidden(
+				f.Child("cloneDepth"),
+				fmt.Sprintf(
+					"cloneDepth can only be used with commit selection strategy %s",
+					kargoapi.CommitSelectionStrategyNewestFromBranch,
+				),
+			),
+		)
+	}
+	if sub.DiscoveryMode == kargoapi.GitDiscoveryModeProviderAPI {
+		errs = append(
+			errs,
+			field.Forbidden(
+				f.Child("cloneDepth"),
+				"cloneDepth cannot be used when discoveryMode is ProviderAPI",
+			),
+		)
+	}
+	if len(sub.IncludePaths) > 0 || len(sub.ExcludePaths) > 0 {
+		errs = append(
+			errs,
+			field.Forbidden(
+				f.Child("cloneDepth"),
+				"cloneDepth cannot be used with includePaths or excludePaths",
+			),
+		)
+	}
+	return errs
+}
+
+// validateProviderAPIDiscovery validates that the provided GitSubscription
+// only uses features that are supported when commits and tags are discovered
+// through the git provider's API instead of a clone of the repository.
+func validateProviderAPIDiscovery(f *field.Path, sub kargoapi.GitSubscription) field.ErrorList {
+	var errs field.ErrorList
+	if sub.VerifySignatures != nil {
+		errs = append(
+			errs,
+			field.Forbidden(
+				f.Child("verifySignatures"),
+				"verifySignatures cannot be used when discoveryMode is ProviderAPI",
+			),
+		)
+	}
+	if sub.RestrictTagsToBranch {
+		errs = append(
+			errs,
+			field.Forbidden(
+				f.Child("restrictTagsToBranch"),
+				"restrictTagsToBranch cannot be used when discoveryMode is ProviderAPI",
+			),
+		)
+	}
+	if sub.MirrorURL != "" {
+		errs = append(
+			errs,
+			field.Forbidden(
+				f.Child("mirrorURL"),
+				"mirrorURL cannot be used when discoveryMode is ProviderAPI",
+			),
+		)
+	}
+	switch sub.CommitSelectionStrategy {
+	case kargoapi.CommitSelectionStrategyNewestTag:
+		errs = append(
+			errs,
+			field.Forbidden(
+				f.Child("commitSelectionStrategy"),
+				fmt.Sprintf(
+					"commit selection strategy %s cannot be used when discoveryMode is ProviderAPI",
+					kargoapi.CommitSelectionStrategyNewestTag,
+				),
+			),
+		)
+	case kargoapi.CommitSelectionStrategyCalVer,
+		kargoapi.CommitSelectionStrategyLexical,
+		kargoapi.CommitSelectionStrategySemVer:
+		if sub.ExpressionFilter != "" {
+			errs = append(
+				errs,
+				field.Forbidden(
+					f.Child("expressionFilter"),
+					"expressionFilter can only be used with commit selection strategy "+
+						"NewestFromBranch when discoveryMode is ProviderAPI",
+				),
+			)
+		}
+	}
 	return errs
 }
 
@@ -213,11 +500,32 @@ func (w *webhook) validateImageSub(
 	); err != nil {
 		errs = field.ErrorList{err}
 	}
+	if image.IsRepoURLPattern(sub.RepoURL) {
+		if err := image.ValidateRepoURLPattern(sub.RepoURL); err != nil {
+			errs = append(errs, field.Invalid(f.Child("repoURL"), sub.RepoURL, err.Error()))
+		}
+	}
 	if sub.Platform != "" {
 		if !image.ValidatePlatformConstraint(sub.Platform) {
 			errs = append(errs, field.Invalid(f.Child("platform"), sub.Platform, ""))
 		}
 	}
+	if sub.ImageSelectionStrategy == kargoapi.ImageSelectionStrategyExpression {
+		if sub.SortExpression == "" {
+			errs = append(
+				errs,
+				field.Required(
+					f.Child("sortExpression"),
+					"sortExpression is required when imageSelectionStrategy is Expression",
+				),
+			)
+		} else if err := image.ValidateSortExpression(sub.SortExpression); err != nil {
+			errs = append(
+				errs,
+				field.Invalid(f.Child("sortExpression"), sub.SortExpression, err.Error()),
+			)
+		}
+	}
 	if err := seen.addImage(sub, f); err != nil {
 		errs = append(errs, field.Invalid(f, sub.RepoURL, err.Error()))
 	}
@@ -236,6 +544,18 @@ func (w *webhook) validateChartSub(
 	); err != nil {
 		errs = append(errs, err)
 	}
+	for i, exclusion := range sub.ExcludeVersions {
+		if _, err := semver.NewConstraint(exclusion); err != nil {
+			errs = append(
+				errs,
+				field.Invalid(
+					f.Child("excludeVersions").Index(i),
+					exclusion,
+					"must be a valid version or semver constraint",
+				),
+			)
+		}
+	}
 	if strings.HasPrefix(sub.RepoURL, "oci://") && sub.Name != "" {
 		errs = append(
 			errs,
@@ -263,6 +583,36 @@ func (w *webhook) validateChartSub(
 	return errs
 }
 
+func (w *webhook) validatePackageSub(
+	f *field.Path,
+	sub kargoapi.PackageSubscription,
+	seen uniqueSubSet,
+) field.ErrorList {
+	var errs field.ErrorList
+	if err := validateSemverConstraint(
+		f.Child("semverConstraint"),
+		sub.SemverConstraint,
+	); err != nil {
+		errs = append(errs, err)
+	}
+	if sub.Type == kargoapi.PackageTypeMaven {
+		if groupID, artifactID, ok := strings.Cut(sub.Name, ":"); !ok || groupID == "" || artifactID == "" {
+			errs = append(
+				errs,
+				field.Invalid(
+					f.Child("name"),
+					sub.Name,
+					"must be of the form groupId:artifactId for Maven packages",
+				),
+			)
+		}
+	}
+	if err := seen.addPackage(sub, f); err != nil {
+		errs = append(errs, field.Invalid(f, sub.Name, err.Error()))
+	}
+	return errs
+}
+
 func validateSemverConstraint(
 	f *field.Path,
 	semverConstraint string,
@@ -292,6 +642,21 @@ func (s uniqueSubSet) addGit(sub kargoapi.GitSubscription, p *field.Path) error
 	return nil
 }
 
+// addFreightSplit records that the subscription at the provided path specifies
+// services or a branch pattern, as described by what. Only one subscription of
+// a Warehouse may do either, because a distinct piece of Freight is produced
+// for each service or branch.
+func (s uniqueSubSet) addFreightSplit(what string, p *field.Path) error {
+	for _, other := range []string{"services", "a branch pattern"} {
+		k := subscriptionKey{kind: "split", id: other}
+		if existing, exists := s[k]; exists {
+			return fmt.Errorf("subscription specifying %s already exists at %q", other, existing)
+		}
+	}
+	s[subscriptionKey{kind: "split", id: what}] = p
+	return nil
+}
+
 func (s uniqueSubSet) addImage(sub kargoapi.ImageSubscription, p *field.Path) error {
 	// The normalization of Helm chart repository URLs can also be used here
 	// to ensure the uniqueness of the image reference as it does the job of
@@ -318,3 +683,18 @@ func (s uniqueSubSet) addChart(sub kargoapi.ChartSubscription, isHTTP bool, p *f
 	s[k] = p
 	return nil
 }
+
+func (s uniqueSubSet) addPackage(sub kargoapi.PackageSubscription, p *field.Path) error {
+	k := subscriptionKey{
+		kind: "package",
+		id: strings.Join(
+			[]string{string(sub.Type), packages.RepoURL(sub.Type, sub.RepoURL), sub.Name},
+			":",
+		),
+	}
+	if _, exists := s[k]; exists {
+		return fmt.Errorf("subscription for package %q already exists at %q", sub.Name, s[k])
+	}
+	s[k] = p
+	return nil
+}
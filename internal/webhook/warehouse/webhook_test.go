@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -301,8 +302,8 @@ func TestValidateSpec(t *testing.T) {
 							Field:    "spec.subscriptions[0]",
 							BadValue: spec.Subscriptions[0],
 							Detail: "exactly one of spec.subscriptions[0].git, " +
-								"spec.subscriptions[0].image, or spec.subscriptions[0].chart " +
-								"must be non-empty",
+								"spec.subscriptions[0].image, spec.subscriptions[0].chart, " +
+								"or spec.subscriptions[0].package must be non-empty",
 						},
 						{
 							Type:     field.ErrorTypeInvalid,
@@ -400,8 +401,8 @@ func TestValidateSubs(t *testing.T) {
 							Type:     field.ErrorTypeInvalid,
 							Field:    "subs[0]",
 							BadValue: subs[0],
-							Detail: "exactly one of subs[0].git, subs[0].image, or " +
-								"subs[0].chart must be non-empty",
+							Detail: "exactly one of subs[0].git, subs[0].image, " +
+								"subs[0].chart, or subs[0].package must be non-empty",
 						},
 						{
 							Type:     field.ErrorTypeInvalid,
@@ -414,6 +415,149 @@ func TestValidateSubs(t *testing.T) {
 				)
 			},
 		},
+		{
+			name: "multiple subscriptions specifying services",
+			subs: []kargoapi.RepoSubscription{
+				{
+					Git: &kargoapi.GitSubscription{
+						RepoURL:  "https://github.com/example/repo-a",
+						Services: []kargoapi.GitService{{Name: "foo", Path: "foo"}},
+					},
+				},
+				{
+					Git: &kargoapi.GitSubscription{
+						RepoURL:  "https://github.com/example/repo-b",
+						Services: []kargoapi.GitService{{Name: "bar", Path: "bar"}},
+					},
+				},
+			},
+			assertions: func(t *testing.T, _ []kargoapi.RepoSubscription, errs field.ErrorList) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeForbidden,
+							Field:    "subs[1].git.services",
+							BadValue: "",
+							Detail:   "subscription specifying services already exists at \"subs[0].git\"",
+						},
+					},
+					errs,
+				)
+			},
+		},
+		{
+			name: "invalid branch patterns",
+			subs: []kargoapi.RepoSubscription{
+				{
+					Git: &kargoapi.GitSubscription{
+						RepoURL:                 "https://github.com/example/repo-a",
+						CommitSelectionStrategy: kargoapi.CommitSelectionStrategySemVer,
+						Branch:                  "regexp:(",
+					},
+				},
+				{
+					Git: &kargoapi.GitSubscription{
+						RepoURL:  "https://github.com/example/repo-b",
+						Services: []kargoapi.GitService{{Name: "foo", Path: "foo"}},
+					},
+				},
+				{
+					Git: &kargoapi.GitSubscription{
+						RepoURL: "https://github.com/example/repo-c",
+						Branch:  "glob:release/*",
+					},
+				},
+			},
+			assertions: func(t *testing.T, _ []kargoapi.RepoSubscription, errs field.ErrorList) {
+				require.Len(t, errs, 4)
+				require.Equal(t, "subs[0].git.branch", errs[0].Field)
+				require.Contains(t, errs[0].Detail, "error parsing regular expression")
+				require.Equal(
+					t,
+					&field.Error{
+						Type:     field.ErrorTypeForbidden,
+						Field:    "subs[0].git.branch",
+						BadValue: "",
+						Detail:   "branch patterns can only be used with commit selection strategy NewestFromBranch",
+					},
+					errs[1],
+				)
+				require.Equal(
+					t,
+					&field.Error{
+						Type:     field.ErrorTypeForbidden,
+						Field:    "subs[1].git.services",
+						BadValue: "",
+						Detail:   "subscription specifying a branch pattern already exists at \"subs[0].git\"",
+					},
+					errs[2],
+				)
+				require.Equal(
+					t,
+					&field.Error{
+						Type:     field.ErrorTypeForbidden,
+						Field:    "subs[2].git.branch",
+						BadValue: "",
+						Detail:   "subscription specifying a branch pattern already exists at \"subs[0].git\"",
+					},
+					errs[3],
+				)
+			},
+		},
+		{
+			name: "revision check against git repository without subscription",
+			subs: []kargoapi.RepoSubscription{
+				{
+					Git: &kargoapi.GitSubscription{
+						RepoURL: "https://github.com/example/repo-a",
+					},
+				},
+				{
+					Image: &kargoapi.ImageSubscription{
+						RepoURL: "example/image",
+						RevisionCheck: &kargoapi.ImageRevisionCheck{
+							GitRepoURL: "https://github.com/example/repo-b",
+						},
+					},
+				},
+			},
+			assertions: func(t *testing.T, _ []kargoapi.RepoSubscription, errs field.ErrorList) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "subs[1].image.revisionCheck.gitRepoURL",
+							BadValue: "https://github.com/example/repo-b",
+							Detail:   "no subscription to this git repository exists",
+						},
+					},
+					errs,
+				)
+			},
+		},
+		{
+			name: "valid revision check",
+			subs: []kargoapi.RepoSubscription{
+				{
+					Git: &kargoapi.GitSubscription{
+						RepoURL: "https://github.com/example/repo.git",
+					},
+				},
+				{
+					Image: &kargoapi.ImageSubscription{
+						RepoURL: "example/image",
+						RevisionCheck: &kargoapi.ImageRevisionCheck{
+							GitRepoURL: "https://github.com/example/repo",
+						},
+					},
+				},
+			},
+			assertions: func(t *testing.T, _ []kargoapi.RepoSubscription, errs field.ErrorList) {
+				require.Nil(t, errs)
+			},
+		},
 		{
 			name: "valid",
 			subs: []kargoapi.RepoSubscription{
@@ -493,7 +637,7 @@ func TestValidateSub(t *testing.T) {
 							Type:     field.ErrorTypeInvalid,
 							Field:    "sub",
 							BadValue: sub,
-							Detail:   "exactly one of sub.git, sub.image, or sub.chart must be non-empty",
+							Detail:   "exactly one of sub.git, sub.image, sub.chart, or sub.package must be non-empty",
 						},
 					},
 					errs,
@@ -530,6 +674,194 @@ func TestValidateGitSub(t *testing.T) {
 		seen       uniqueSubSet
 		assertions func(*testing.T, field.ErrorList)
 	}{
+		{
+			name: "invalid expressionFilter",
+			sub: kargoapi.GitSubscription{
+				RepoURL:          "https://github.com/example/repo",
+				ExpressionFilter: "author ==",
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, field.ErrorTypeInvalid, errs[0].Type)
+				require.Equal(t, "git.expressionFilter", errs[0].Field)
+				require.Contains(t, errs[0].Detail, "error compiling expression")
+			},
+		},
+		{
+			name: "both merge commit filters",
+			sub: kargoapi.GitSubscription{
+				RepoURL:             "https://github.com/example/repo",
+				ExcludeMergeCommits: true,
+				OnlyMergeCommits:    true,
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, field.ErrorTypeForbidden, errs[0].Type)
+				require.Equal(t, "git.onlyMergeCommits", errs[0].Field)
+			},
+		},
+		{
+			name: "ProviderAPI discovery with unsupported features",
+			sub: kargoapi.GitSubscription{
+				RepoURL:                 "https://github.com/example/repo",
+				DiscoveryMode:           kargoapi.GitDiscoveryModeProviderAPI,
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategySemVer,
+				ExpressionFilter:        `author contains "bot"`,
+				RestrictTagsToBranch:    true,
+				VerifySignatures: &kargoapi.GitSignatureVerification{
+					SecretName: "keys",
+				},
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 3)
+				require.Equal(t, "git.verifySignatures", errs[0].Field)
+				require.Equal(t, "git.restrictTagsToBranch", errs[1].Field)
+				require.Equal(t, "git.expressionFilter", errs[2].Field)
+			},
+		},
+		{
+			name: "mirror with ProviderAPI discovery",
+			sub: kargoapi.GitSubscription{
+				RepoURL:       "https://github.com/example/repo",
+				DiscoveryMode: kargoapi.GitDiscoveryModeProviderAPI,
+				MirrorURL:     "file:///mirrors/repo.bundle",
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, field.ErrorTypeForbidden, errs[0].Type)
+				require.Equal(t, "git.mirrorURL", errs[0].Field)
+			},
+		},
+		{
+			name: "skip markers with unsupported strategy",
+			sub: kargoapi.GitSubscription{
+				RepoURL:                 "https://github.com/example/repo",
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategySemVer,
+				SkipMarkers:             &kargoapi.GitCommitSkipMarkers{},
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, field.ErrorTypeForbidden, errs[0].Type)
+				require.Equal(t, "git.skipMarkers", errs[0].Field)
+			},
+		},
+		{
+			name: "clone depth with unsupported strategy",
+			sub: kargoapi.GitSubscription{
+				RepoURL:                 "https://github.com/example/repo",
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategySemVer,
+				CloneDepth:              10,
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, field.ErrorTypeForbidden, errs[0].Type)
+				require.Equal(t, "git.cloneDepth", errs[0].Field)
+			},
+		},
+		{
+			name: "clone depth with ProviderAPI discovery",
+			sub: kargoapi.GitSubscription{
+				RepoURL:       "https://github.com/example/repo",
+				DiscoveryMode: kargoapi.GitDiscoveryModeProviderAPI,
+				CloneDepth:    10,
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, field.ErrorTypeForbidden, errs[0].Type)
+				require.Equal(t, "git.cloneDepth", errs[0].Field)
+			},
+		},
+		{
+			name: "clone depth with path filters",
+			sub: kargoapi.GitSubscription{
+				RepoURL:      "https://github.com/example/repo",
+				Branch:       "main",
+				CloneDepth:   10,
+				IncludePaths: []string{"apps/"},
+				ExcludePaths: []string{"apps/test/"},
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, field.ErrorTypeForbidden, errs[0].Type)
+				require.Equal(t, "git.cloneDepth", errs[0].Field)
+				require.Contains(t, errs[0].Detail, "includePaths or excludePaths")
+			},
+		},
+		{
+			name: "clone depth",
+			sub: kargoapi.GitSubscription{
+				RepoURL:    "https://github.com/example/repo",
+				Branch:     "main",
+				CloneDepth: 10,
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Empty(t, errs)
+			},
+		},
+		{
+			name: "ProviderAPI discovery with NewestTag strategy",
+			sub: kargoapi.GitSubscription{
+				RepoURL:                 "https://github.com/example/repo",
+				DiscoveryMode:           kargoapi.GitDiscoveryModeProviderAPI,
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategyNewestTag,
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, field.ErrorTypeForbidden, errs[0].Type)
+				require.Equal(t, "git.commitSelectionStrategy", errs[0].Field)
+			},
+		},
+		{
+			name: "ProviderAPI discovery",
+			sub: kargoapi.GitSubscription{
+				RepoURL:          "https://github.com/example/repo",
+				DiscoveryMode:    kargoapi.GitDiscoveryModeProviderAPI,
+				ExpressionFilter: `author contains "bot"`,
+				IncludePaths:     []string{"charts/"},
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Empty(t, errs)
+			},
+		},
+		{
+			name: "CalVer strategy without layout",
+			sub: kargoapi.GitSubscription{
+				RepoURL:                 "https://github.com/example/repo",
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategyCalVer,
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, field.ErrorTypeRequired, errs[0].Type)
+				require.Equal(t, "git.calVerLayout", errs[0].Field)
+			},
+		},
+		{
+			name: "CalVer strategy with invalid layout",
+			sub: kargoapi.GitSubscription{
+				RepoURL:                 "https://github.com/example/repo",
+				CommitSelectionStrategy: kargoapi.CommitSelectionStrategyCalVer,
+				CalVerLayout:            "MAJOR.MINOR.MICRO",
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, field.ErrorTypeInvalid, errs[0].Type)
+				require.Equal(t, "git.calVerLayout", errs[0].Field)
+				require.Contains(t, errs[0].Detail, "must contain exactly one of the tokens")
+			},
+		},
 		{
 			name: "invalid",
 			sub: kargoapi.GitSubscription{
@@ -563,6 +895,30 @@ func TestValidateGitSub(t *testing.T) {
 			},
 		},
 
+		{
+			name: "services with include paths",
+			sub: kargoapi.GitSubscription{
+				RepoURL:      "https://github.com/example/repo",
+				IncludePaths: []string{"foo"},
+				Services:     []kargoapi.GitService{{Name: "foo", Path: "foo"}},
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeForbidden,
+							Field:    "git.includePaths",
+							BadValue: "",
+							Detail:   "includePaths cannot be used in conjunction with services",
+						},
+					},
+					errs,
+				)
+			},
+		},
+
 		{
 			name: "valid",
 			seen: uniqueSubSet{},
@@ -570,6 +926,21 @@ func TestValidateGitSub(t *testing.T) {
 				require.Nil(t, errs)
 			},
 		},
+
+		{
+			name: "valid with services",
+			sub: kargoapi.GitSubscription{
+				RepoURL: "https://github.com/example/repo",
+				Services: []kargoapi.GitService{
+					{Name: "foo", Path: "services/foo"},
+					{Name: "bar", Path: "services/bar"},
+				},
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Nil(t, errs)
+			},
+		},
 	}
 	w := &webhook{}
 	for _, testCase := range testCases {
@@ -631,7 +1002,67 @@ func TestValidateImageSub(t *testing.T) {
 				)
 			},
 		},
-
+		{
+			name: "expression strategy without sort expression",
+			sub: kargoapi.ImageSubscription{
+				RepoURL:                "example.com/foo",
+				ImageSelectionStrategy: kargoapi.ImageSelectionStrategyExpression,
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, field.ErrorTypeRequired, errs[0].Type)
+				require.Equal(t, "image.sortExpression", errs[0].Field)
+			},
+		},
+		{
+			name: "expression strategy with invalid sort expression",
+			sub: kargoapi.ImageSubscription{
+				RepoURL:                "example.com/foo",
+				ImageSelectionStrategy: kargoapi.ImageSelectionStrategyExpression,
+				SortExpression:         "tag +",
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, field.ErrorTypeInvalid, errs[0].Type)
+				require.Equal(t, "image.sortExpression", errs[0].Field)
+			},
+		},
+		{
+			name: "wildcard in registry of repo URL pattern",
+			sub: kargoapi.ImageSubscription{
+				RepoURL: "*.example.com/foo",
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Len(t, errs, 1)
+				require.Equal(t, field.ErrorTypeInvalid, errs[0].Type)
+				require.Equal(t, "image.repoURL", errs[0].Field)
+			},
+		},
+		{
+			name: "valid repo URL pattern",
+			sub: kargoapi.ImageSubscription{
+				RepoURL: "example.com/acme/service-*",
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Nil(t, errs)
+			},
+		},
+		{
+			name: "valid expression strategy",
+			sub: kargoapi.ImageSubscription{
+				RepoURL:                "example.com/foo",
+				ImageSelectionStrategy: kargoapi.ImageSelectionStrategyExpression,
+				SortExpression:         "len(tag)",
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Nil(t, errs)
+			},
+		},
 		{
 			name: "valid",
 			seen: uniqueSubSet{},
@@ -691,6 +1122,29 @@ func TestValidateChartSub(t *testing.T) {
 			},
 		},
 
+		{
+			name: "invalid excludeVersions",
+			sub: kargoapi.ChartSubscription{
+				RepoURL:         "oci://fake-url",
+				ExcludeVersions: []string{"1.2.3", ">=2.0.0 <2.1.0", "bogus"},
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "chart.excludeVersions[2]",
+							BadValue: "bogus",
+							Detail:   "must be a valid version or semver constraint",
+						},
+					},
+					errs,
+				)
+			},
+		},
+
 		{
 			name: "https repoURL without name",
 			sub: kargoapi.ChartSubscription{
@@ -792,6 +1246,103 @@ func TestValidateChartSub(t *testing.T) {
 	}
 }
 
+func TestValidatePackageSub(t *testing.T) {
+	testCases := []struct {
+		name       string
+		sub        kargoapi.PackageSubscription
+		seen       uniqueSubSet
+		assertions func(*testing.T, field.ErrorList)
+	}{
+		{
+			name: "invalid semverConstraint and Maven name",
+			sub: kargoapi.PackageSubscription{
+				Type:             kargoapi.PackageTypeMaven,
+				Name:             "lib",
+				SemverConstraint: "bogus",
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "package.semverConstraint",
+							BadValue: "bogus",
+						},
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "package.name",
+							BadValue: "lib",
+							Detail:   "must be of the form groupId:artifactId for Maven packages",
+						},
+					},
+					errs,
+				)
+			},
+		},
+		{
+			name: "duplicate subscription to public registry",
+			sub: kargoapi.PackageSubscription{
+				Type:    kargoapi.PackageTypeNPM,
+				RepoURL: "https://registry.npmjs.org/",
+				Name:    "@example/lib",
+			},
+			seen: func() uniqueSubSet {
+				seen := uniqueSubSet{}
+				require.NoError(t, seen.addPackage(
+					kargoapi.PackageSubscription{
+						Type: kargoapi.PackageTypeNPM,
+						Name: "@example/lib",
+					},
+					field.NewPath("spec", "subscriptions").Index(0).Child("package"),
+				))
+				return seen
+			}(),
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "package",
+							BadValue: "@example/lib",
+							Detail: "subscription for package \"@example/lib\" already exists at " +
+								"\"spec.subscriptions[0].package\"",
+						},
+					},
+					errs,
+				)
+			},
+		},
+		{
+			name: "valid",
+			sub: kargoapi.PackageSubscription{
+				Type:             kargoapi.PackageTypeMaven,
+				Name:             "com.example:lib",
+				SemverConstraint: "^1.0.0",
+			},
+			seen: uniqueSubSet{},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Nil(t, errs)
+			},
+		},
+	}
+	w := &webhook{}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				t,
+				w.validatePackageSub(
+					field.NewPath("package"),
+					testCase.sub,
+					testCase.seen,
+				),
+			)
+		})
+	}
+}
+
 func TestValidateSemverConstraint(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -842,3 +1393,86 @@ func TestValidateSemverConstraint(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePolling(t *testing.T) {
+	testCases := []struct {
+		name       string
+		polling    *kargoapi.WarehousePolling
+		assertions func(*testing.T, field.ErrorList)
+	}{
+		{
+			name: "nil",
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Nil(t, errs)
+			},
+		},
+		{
+			name: "non-positive intervals",
+			polling: &kargoapi.WarehousePolling{
+				Interval:    &metav1.Duration{},
+				MinInterval: &metav1.Duration{Duration: -time.Minute},
+			},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "polling.interval",
+							BadValue: "0s",
+							Detail:   "must be positive",
+						},
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "polling.minInterval",
+							BadValue: "-1m0s",
+							Detail:   "must be positive",
+						},
+					},
+					errs,
+				)
+			},
+		},
+		{
+			name: "min interval exceeds interval",
+			polling: &kargoapi.WarehousePolling{
+				Mode:        kargoapi.WarehousePollingModeAdaptive,
+				Interval:    &metav1.Duration{Duration: time.Minute},
+				MinInterval: &metav1.Duration{Duration: 2 * time.Minute},
+			},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Equal(
+					t,
+					field.ErrorList{
+						{
+							Type:     field.ErrorTypeInvalid,
+							Field:    "polling.minInterval",
+							BadValue: "2m0s",
+							Detail:   "must not exceed polling.interval",
+						},
+					},
+					errs,
+				)
+			},
+		},
+		{
+			name: "valid",
+			polling: &kargoapi.WarehousePolling{
+				Mode:        kargoapi.WarehousePollingModeAdaptive,
+				Interval:    &metav1.Duration{Duration: 10 * time.Minute},
+				MinInterval: &metav1.Duration{Duration: 30 * time.Second},
+			},
+			assertions: func(t *testing.T, errs field.ErrorList) {
+				require.Nil(t, errs)
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.assertions(
+				t,
+				validatePolling(field.NewPath("polling"), testCase.polling),
+			)
+		})
+	}
+}
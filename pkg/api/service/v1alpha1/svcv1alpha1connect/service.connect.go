@@ -41,6 +41,9 @@ const (
 	// KargoServiceGetPublicConfigProcedure is the fully-qualified name of the KargoService's
 	// GetPublicConfig RPC.
 	KargoServiceGetPublicConfigProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/GetPublicConfig"
+	// KargoServiceListAnnouncementsProcedure is the fully-qualified name of the KargoService's
+	// ListAnnouncements RPC.
+	KargoServiceListAnnouncementsProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/ListAnnouncements"
 	// KargoServiceAdminLoginProcedure is the fully-qualified name of the KargoService's AdminLogin RPC.
 	KargoServiceAdminLoginProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/AdminLogin"
 	// KargoServiceCreateResourceProcedure is the fully-qualified name of the KargoService's
@@ -80,6 +83,12 @@ const (
 	// KargoServiceWatchPromotionProcedure is the fully-qualified name of the KargoService's
 	// WatchPromotion RPC.
 	KargoServiceWatchPromotionProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/WatchPromotion"
+	// KargoServiceComparePromotionsProcedure is the fully-qualified name of the KargoService's
+	// ComparePromotions RPC.
+	KargoServiceComparePromotionsProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/ComparePromotions"
+	// KargoServiceGetPromotionPlanProcedure is the fully-qualified name of the KargoService's
+	// GetPromotionPlan RPC.
+	KargoServiceGetPromotionPlanProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/GetPromotionPlan"
 	// KargoServiceDeleteProjectProcedure is the fully-qualified name of the KargoService's
 	// DeleteProject RPC.
 	KargoServiceDeleteProjectProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/DeleteProject"
@@ -88,14 +97,26 @@ const (
 	// KargoServiceListProjectsProcedure is the fully-qualified name of the KargoService's ListProjects
 	// RPC.
 	KargoServiceListProjectsProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/ListProjects"
+	// KargoServiceGetProjectUsageSummaryProcedure is the fully-qualified name of the KargoService's
+	// GetProjectUsageSummary RPC.
+	KargoServiceGetProjectUsageSummaryProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/GetProjectUsageSummary"
 	// KargoServiceApproveFreightProcedure is the fully-qualified name of the KargoService's
 	// ApproveFreight RPC.
 	KargoServiceApproveFreightProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/ApproveFreight"
+	// KargoServiceBlockFreightProcedure is the fully-qualified name of the KargoService's BlockFreight
+	// RPC.
+	KargoServiceBlockFreightProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/BlockFreight"
 	// KargoServiceDeleteFreightProcedure is the fully-qualified name of the KargoService's
 	// DeleteFreight RPC.
 	KargoServiceDeleteFreightProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/DeleteFreight"
 	// KargoServiceGetFreightProcedure is the fully-qualified name of the KargoService's GetFreight RPC.
 	KargoServiceGetFreightProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/GetFreight"
+	// KargoServiceGetFreightLeadTimesProcedure is the fully-qualified name of the KargoService's
+	// GetFreightLeadTimes RPC.
+	KargoServiceGetFreightLeadTimesProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/GetFreightLeadTimes"
+	// KargoServicePromoteThroughStagesProcedure is the fully-qualified name of the KargoService's
+	// PromoteThroughStages RPC.
+	KargoServicePromoteThroughStagesProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/PromoteThroughStages"
 	// KargoServicePromoteToStageProcedure is the fully-qualified name of the KargoService's
 	// PromoteToStage RPC.
 	KargoServicePromoteToStageProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/PromoteToStage"
@@ -105,6 +126,9 @@ const (
 	// KargoServiceQueryFreightProcedure is the fully-qualified name of the KargoService's QueryFreight
 	// RPC.
 	KargoServiceQueryFreightProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/QueryFreight"
+	// KargoServiceUnblockFreightProcedure is the fully-qualified name of the KargoService's
+	// UnblockFreight RPC.
+	KargoServiceUnblockFreightProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/UnblockFreight"
 	// KargoServiceUpdateFreightAliasProcedure is the fully-qualified name of the KargoService's
 	// UpdateFreightAlias RPC.
 	KargoServiceUpdateFreightAliasProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/UpdateFreightAlias"
@@ -128,6 +152,12 @@ const (
 	// KargoServiceRefreshWarehouseProcedure is the fully-qualified name of the KargoService's
 	// RefreshWarehouse RPC.
 	KargoServiceRefreshWarehouseProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/RefreshWarehouse"
+	// KargoServicePreviewWarehouseProcedure is the fully-qualified name of the KargoService's
+	// PreviewWarehouse RPC.
+	KargoServicePreviewWarehouseProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/PreviewWarehouse"
+	// KargoServiceValidateWarehouseProcedure is the fully-qualified name of the KargoService's
+	// ValidateWarehouse RPC.
+	KargoServiceValidateWarehouseProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/ValidateWarehouse"
 	// KargoServiceCreateCredentialsProcedure is the fully-qualified name of the KargoService's
 	// CreateCredentials RPC.
 	KargoServiceCreateCredentialsProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/CreateCredentials"
@@ -155,6 +185,9 @@ const (
 	// KargoServiceGetAnalysisRunProcedure is the fully-qualified name of the KargoService's
 	// GetAnalysisRun RPC.
 	KargoServiceGetAnalysisRunProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/GetAnalysisRun"
+	// KargoServiceGetAnalysisRunLogsProcedure is the fully-qualified name of the KargoService's
+	// GetAnalysisRunLogs RPC.
+	KargoServiceGetAnalysisRunLogsProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/GetAnalysisRunLogs"
 	// KargoServiceListProjectEventsProcedure is the fully-qualified name of the KargoService's
 	// ListProjectEvents RPC.
 	KargoServiceListProjectEventsProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/ListProjectEvents"
@@ -172,6 +205,8 @@ const (
 	KargoServiceRevokeProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/Revoke"
 	// KargoServiceUpdateRoleProcedure is the fully-qualified name of the KargoService's UpdateRole RPC.
 	KargoServiceUpdateRoleProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/UpdateRole"
+	// KargoServiceWhoAmIProcedure is the fully-qualified name of the KargoService's WhoAmI RPC.
+	KargoServiceWhoAmIProcedure = "/akuity.io.kargo.service.v1alpha1.KargoService/WhoAmI"
 )
 
 // These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
@@ -180,6 +215,7 @@ var (
 	kargoServiceGetVersionInfoMethodDescriptor            = kargoServiceServiceDescriptor.Methods().ByName("GetVersionInfo")
 	kargoServiceGetConfigMethodDescriptor                 = kargoServiceServiceDescriptor.Methods().ByName("GetConfig")
 	kargoServiceGetPublicConfigMethodDescriptor           = kargoServiceServiceDescriptor.Methods().ByName("GetPublicConfig")
+	kargoServiceListAnnouncementsMethodDescriptor         = kargoServiceServiceDescriptor.Methods().ByName("ListAnnouncements")
 	kargoServiceAdminLoginMethodDescriptor                = kargoServiceServiceDescriptor.Methods().ByName("AdminLogin")
 	kargoServiceCreateResourceMethodDescriptor            = kargoServiceServiceDescriptor.Methods().ByName("CreateResource")
 	kargoServiceCreateOrUpdateResourceMethodDescriptor    = kargoServiceServiceDescriptor.Methods().ByName("CreateOrUpdateResource")
@@ -194,15 +230,22 @@ var (
 	kargoServiceWatchPromotionsMethodDescriptor           = kargoServiceServiceDescriptor.Methods().ByName("WatchPromotions")
 	kargoServiceGetPromotionMethodDescriptor              = kargoServiceServiceDescriptor.Methods().ByName("GetPromotion")
 	kargoServiceWatchPromotionMethodDescriptor            = kargoServiceServiceDescriptor.Methods().ByName("WatchPromotion")
+	kargoServiceComparePromotionsMethodDescriptor         = kargoServiceServiceDescriptor.Methods().ByName("ComparePromotions")
+	kargoServiceGetPromotionPlanMethodDescriptor          = kargoServiceServiceDescriptor.Methods().ByName("GetPromotionPlan")
 	kargoServiceDeleteProjectMethodDescriptor             = kargoServiceServiceDescriptor.Methods().ByName("DeleteProject")
 	kargoServiceGetProjectMethodDescriptor                = kargoServiceServiceDescriptor.Methods().ByName("GetProject")
 	kargoServiceListProjectsMethodDescriptor              = kargoServiceServiceDescriptor.Methods().ByName("ListProjects")
+	kargoServiceGetProjectUsageSummaryMethodDescriptor    = kargoServiceServiceDescriptor.Methods().ByName("GetProjectUsageSummary")
 	kargoServiceApproveFreightMethodDescriptor            = kargoServiceServiceDescriptor.Methods().ByName("ApproveFreight")
+	kargoServiceBlockFreightMethodDescriptor              = kargoServiceServiceDescriptor.Methods().ByName("BlockFreight")
 	kargoServiceDeleteFreightMethodDescriptor             = kargoServiceServiceDescriptor.Methods().ByName("DeleteFreight")
 	kargoServiceGetFreightMethodDescriptor                = kargoServiceServiceDescriptor.Methods().ByName("GetFreight")
+	kargoServiceGetFreightLeadTimesMethodDescriptor       = kargoServiceServiceDescriptor.Methods().ByName("GetFreightLeadTimes")
+	kargoServicePromoteThroughStagesMethodDescriptor      = kargoServiceServiceDescriptor.Methods().ByName("PromoteThroughStages")
 	kargoServicePromoteToStageMethodDescriptor            = kargoServiceServiceDescriptor.Methods().ByName("PromoteToStage")
 	kargoServicePromoteToStageSubscribersMethodDescriptor = kargoServiceServiceDescriptor.Methods().ByName("PromoteToStageSubscribers")
 	kargoServiceQueryFreightMethodDescriptor              = kargoServiceServiceDescriptor.Methods().ByName("QueryFreight")
+	kargoServiceUnblockFreightMethodDescriptor            = kargoServiceServiceDescriptor.Methods().ByName("UnblockFreight")
 	kargoServiceUpdateFreightAliasMethodDescriptor        = kargoServiceServiceDescriptor.Methods().ByName("UpdateFreightAlias")
 	kargoServiceReverifyMethodDescriptor                  = kargoServiceServiceDescriptor.Methods().ByName("Reverify")
 	kargoServiceAbortVerificationMethodDescriptor         = kargoServiceServiceDescriptor.Methods().ByName("AbortVerification")
@@ -211,6 +254,8 @@ var (
 	kargoServiceWatchWarehousesMethodDescriptor           = kargoServiceServiceDescriptor.Methods().ByName("WatchWarehouses")
 	kargoServiceDeleteWarehouseMethodDescriptor           = kargoServiceServiceDescriptor.Methods().ByName("DeleteWarehouse")
 	kargoServiceRefreshWarehouseMethodDescriptor          = kargoServiceServiceDescriptor.Methods().ByName("RefreshWarehouse")
+	kargoServicePreviewWarehouseMethodDescriptor          = kargoServiceServiceDescriptor.Methods().ByName("PreviewWarehouse")
+	kargoServiceValidateWarehouseMethodDescriptor         = kargoServiceServiceDescriptor.Methods().ByName("ValidateWarehouse")
 	kargoServiceCreateCredentialsMethodDescriptor         = kargoServiceServiceDescriptor.Methods().ByName("CreateCredentials")
 	kargoServiceDeleteCredentialsMethodDescriptor         = kargoServiceServiceDescriptor.Methods().ByName("DeleteCredentials")
 	kargoServiceGetCredentialsMethodDescriptor            = kargoServiceServiceDescriptor.Methods().ByName("GetCredentials")
@@ -220,6 +265,7 @@ var (
 	kargoServiceGetAnalysisTemplateMethodDescriptor       = kargoServiceServiceDescriptor.Methods().ByName("GetAnalysisTemplate")
 	kargoServiceDeleteAnalysisTemplateMethodDescriptor    = kargoServiceServiceDescriptor.Methods().ByName("DeleteAnalysisTemplate")
 	kargoServiceGetAnalysisRunMethodDescriptor            = kargoServiceServiceDescriptor.Methods().ByName("GetAnalysisRun")
+	kargoServiceGetAnalysisRunLogsMethodDescriptor        = kargoServiceServiceDescriptor.Methods().ByName("GetAnalysisRunLogs")
 	kargoServiceListProjectEventsMethodDescriptor         = kargoServiceServiceDescriptor.Methods().ByName("ListProjectEvents")
 	kargoServiceCreateRoleMethodDescriptor                = kargoServiceServiceDescriptor.Methods().ByName("CreateRole")
 	kargoServiceDeleteRoleMethodDescriptor                = kargoServiceServiceDescriptor.Methods().ByName("DeleteRole")
@@ -228,6 +274,7 @@ var (
 	kargoServiceListRolesMethodDescriptor                 = kargoServiceServiceDescriptor.Methods().ByName("ListRoles")
 	kargoServiceRevokeMethodDescriptor                    = kargoServiceServiceDescriptor.Methods().ByName("Revoke")
 	kargoServiceUpdateRoleMethodDescriptor                = kargoServiceServiceDescriptor.Methods().ByName("UpdateRole")
+	kargoServiceWhoAmIMethodDescriptor                    = kargoServiceServiceDescriptor.Methods().ByName("WhoAmI")
 )
 
 // KargoServiceClient is a client for the akuity.io.kargo.service.v1alpha1.KargoService service.
@@ -235,6 +282,7 @@ type KargoServiceClient interface {
 	GetVersionInfo(context.Context, *connect.Request[v1alpha1.GetVersionInfoRequest]) (*connect.Response[v1alpha1.GetVersionInfoResponse], error)
 	GetConfig(context.Context, *connect.Request[v1alpha1.GetConfigRequest]) (*connect.Response[v1alpha1.GetConfigResponse], error)
 	GetPublicConfig(context.Context, *connect.Request[v1alpha1.GetPublicConfigRequest]) (*connect.Response[v1alpha1.GetPublicConfigResponse], error)
+	ListAnnouncements(context.Context, *connect.Request[v1alpha1.ListAnnouncementsRequest]) (*connect.Response[v1alpha1.ListAnnouncementsResponse], error)
 	AdminLogin(context.Context, *connect.Request[v1alpha1.AdminLoginRequest]) (*connect.Response[v1alpha1.AdminLoginResponse], error)
 	// TODO(devholic): Add ApplyResource API
 	// rpc ApplyResource(ApplyResourceRequest) returns (ApplyResourceRequest);
@@ -251,15 +299,22 @@ type KargoServiceClient interface {
 	WatchPromotions(context.Context, *connect.Request[v1alpha1.WatchPromotionsRequest]) (*connect.ServerStreamForClient[v1alpha1.WatchPromotionsResponse], error)
 	GetPromotion(context.Context, *connect.Request[v1alpha1.GetPromotionRequest]) (*connect.Response[v1alpha1.GetPromotionResponse], error)
 	WatchPromotion(context.Context, *connect.Request[v1alpha1.WatchPromotionRequest]) (*connect.ServerStreamForClient[v1alpha1.WatchPromotionResponse], error)
+	ComparePromotions(context.Context, *connect.Request[v1alpha1.ComparePromotionsRequest]) (*connect.Response[v1alpha1.ComparePromotionsResponse], error)
+	GetPromotionPlan(context.Context, *connect.Request[v1alpha1.GetPromotionPlanRequest]) (*connect.Response[v1alpha1.GetPromotionPlanResponse], error)
 	DeleteProject(context.Context, *connect.Request[v1alpha1.DeleteProjectRequest]) (*connect.Response[v1alpha1.DeleteProjectResponse], error)
 	GetProject(context.Context, *connect.Request[v1alpha1.GetProjectRequest]) (*connect.Response[v1alpha1.GetProjectResponse], error)
 	ListProjects(context.Context, *connect.Request[v1alpha1.ListProjectsRequest]) (*connect.Response[v1alpha1.ListProjectsResponse], error)
+	GetProjectUsageSummary(context.Context, *connect.Request[v1alpha1.GetProjectUsageSummaryRequest]) (*connect.Response[v1alpha1.GetProjectUsageSummaryResponse], error)
 	ApproveFreight(context.Context, *connect.Request[v1alpha1.ApproveFreightRequest]) (*connect.Response[v1alpha1.ApproveFreightResponse], error)
+	BlockFreight(context.Context, *connect.Request[v1alpha1.BlockFreightRequest]) (*connect.Response[v1alpha1.BlockFreightResponse], error)
 	DeleteFreight(context.Context, *connect.Request[v1alpha1.DeleteFreightRequest]) (*connect.Response[v1alpha1.DeleteFreightResponse], error)
 	GetFreight(context.Context, *connect.Request[v1alpha1.GetFreightRequest]) (*connect.Response[v1alpha1.GetFreightResponse], error)
+	GetFreightLeadTimes(context.Context, *connect.Request[v1alpha1.GetFreightLeadTimesRequest]) (*connect.Response[v1alpha1.GetFreightLeadTimesResponse], error)
+	PromoteThroughStages(context.Context, *connect.Request[v1alpha1.PromoteThroughStagesRequest]) (*connect.Response[v1alpha1.PromoteThroughStagesResponse], error)
 	PromoteToStage(context.Context, *connect.Request[v1alpha1.PromoteToStageRequest]) (*connect.Response[v1alpha1.PromoteToStageResponse], error)
 	PromoteToStageSubscribers(context.Context, *connect.Request[v1alpha1.PromoteToStageSubscribersRequest]) (*connect.Response[v1alpha1.PromoteToStageSubscribersResponse], error)
 	QueryFreight(context.Context, *connect.Request[v1alpha1.QueryFreightRequest]) (*connect.Response[v1alpha1.QueryFreightResponse], error)
+	UnblockFreight(context.Context, *connect.Request[v1alpha1.UnblockFreightRequest]) (*connect.Response[v1alpha1.UnblockFreightResponse], error)
 	UpdateFreightAlias(context.Context, *connect.Request[v1alpha1.UpdateFreightAliasRequest]) (*connect.Response[v1alpha1.UpdateFreightAliasResponse], error)
 	Reverify(context.Context, *connect.Request[v1alpha1.ReverifyRequest]) (*connect.Response[v1alpha1.ReverifyResponse], error)
 	AbortVerification(context.Context, *connect.Request[v1alpha1.AbortVerificationRequest]) (*connect.Response[v1alpha1.AbortVerificationResponse], error)
@@ -268,6 +323,8 @@ type KargoServiceClient interface {
 	WatchWarehouses(context.Context, *connect.Request[v1alpha1.WatchWarehousesRequest]) (*connect.ServerStreamForClient[v1alpha1.WatchWarehousesResponse], error)
 	DeleteWarehouse(context.Context, *connect.Request[v1alpha1.DeleteWarehouseRequest]) (*connect.Response[v1alpha1.DeleteWarehouseResponse], error)
 	RefreshWarehouse(context.Context, *connect.Request[v1alpha1.RefreshWarehouseRequest]) (*connect.Response[v1alpha1.RefreshWarehouseResponse], error)
+	PreviewWarehouse(context.Context, *connect.Request[v1alpha1.PreviewWarehouseRequest]) (*connect.Response[v1alpha1.PreviewWarehouseResponse], error)
+	ValidateWarehouse(context.Context, *connect.Request[v1alpha1.ValidateWarehouseRequest]) (*connect.Response[v1alpha1.ValidateWarehouseResponse], error)
 	CreateCredentials(context.Context, *connect.Request[v1alpha1.CreateCredentialsRequest]) (*connect.Response[v1alpha1.CreateCredentialsResponse], error)
 	DeleteCredentials(context.Context, *connect.Request[v1alpha1.DeleteCredentialsRequest]) (*connect.Response[v1alpha1.DeleteCredentialsResponse], error)
 	GetCredentials(context.Context, *connect.Request[v1alpha1.GetCredentialsRequest]) (*connect.Response[v1alpha1.GetCredentialsResponse], error)
@@ -277,6 +334,7 @@ type KargoServiceClient interface {
 	GetAnalysisTemplate(context.Context, *connect.Request[v1alpha1.GetAnalysisTemplateRequest]) (*connect.Response[v1alpha1.GetAnalysisTemplateResponse], error)
 	DeleteAnalysisTemplate(context.Context, *connect.Request[v1alpha1.DeleteAnalysisTemplateRequest]) (*connect.Response[v1alpha1.DeleteAnalysisTemplateResponse], error)
 	GetAnalysisRun(context.Context, *connect.Request[v1alpha1.GetAnalysisRunRequest]) (*connect.Response[v1alpha1.GetAnalysisRunResponse], error)
+	GetAnalysisRunLogs(context.Context, *connect.Request[v1alpha1.GetAnalysisRunLogsRequest]) (*connect.ServerStreamForClient[v1alpha1.GetAnalysisRunLogsResponse], error)
 	ListProjectEvents(context.Context, *connect.Request[v1alpha1.ListProjectEventsRequest]) (*connect.Response[v1alpha1.ListProjectEventsResponse], error)
 	CreateRole(context.Context, *connect.Request[v1alpha1.CreateRoleRequest]) (*connect.Response[v1alpha1.CreateRoleResponse], error)
 	DeleteRole(context.Context, *connect.Request[v1alpha1.DeleteRoleRequest]) (*connect.Response[v1alpha1.DeleteRoleResponse], error)
@@ -285,6 +343,7 @@ type KargoServiceClient interface {
 	ListRoles(context.Context, *connect.Request[v1alpha1.ListRolesRequest]) (*connect.Response[v1alpha1.ListRolesResponse], error)
 	Revoke(context.Context, *connect.Request[v1alpha1.RevokeRequest]) (*connect.Response[v1alpha1.RevokeResponse], error)
 	UpdateRole(context.Context, *connect.Request[v1alpha1.UpdateRoleRequest]) (*connect.Response[v1alpha1.UpdateRoleResponse], error)
+	WhoAmI(context.Context, *connect.Request[v1alpha1.WhoAmIRequest]) (*connect.Response[v1alpha1.WhoAmIResponse], error)
 }
 
 // NewKargoServiceClient constructs a client for the akuity.io.kargo.service.v1alpha1.KargoService
@@ -315,6 +374,12 @@ func NewKargoServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(kargoServiceGetPublicConfigMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		listAnnouncements: connect.NewClient[v1alpha1.ListAnnouncementsRequest, v1alpha1.ListAnnouncementsResponse](
+			httpClient,
+			baseURL+KargoServiceListAnnouncementsProcedure,
+			connect.WithSchema(kargoServiceListAnnouncementsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		adminLogin: connect.NewClient[v1alpha1.AdminLoginRequest, v1alpha1.AdminLoginResponse](
 			httpClient,
 			baseURL+KargoServiceAdminLoginProcedure,
@@ -399,6 +464,18 @@ func NewKargoServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(kargoServiceWatchPromotionMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		comparePromotions: connect.NewClient[v1alpha1.ComparePromotionsRequest, v1alpha1.ComparePromotionsResponse](
+			httpClient,
+			baseURL+KargoServiceComparePromotionsProcedure,
+			connect.WithSchema(kargoServiceComparePromotionsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getPromotionPlan: connect.NewClient[v1alpha1.GetPromotionPlanRequest, v1alpha1.GetPromotionPlanResponse](
+			httpClient,
+			baseURL+KargoServiceGetPromotionPlanProcedure,
+			connect.WithSchema(kargoServiceGetPromotionPlanMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		deleteProject: connect.NewClient[v1alpha1.DeleteProjectRequest, v1alpha1.DeleteProjectResponse](
 			httpClient,
 			baseURL+KargoServiceDeleteProjectProcedure,
@@ -417,12 +494,24 @@ func NewKargoServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(kargoServiceListProjectsMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		getProjectUsageSummary: connect.NewClient[v1alpha1.GetProjectUsageSummaryRequest, v1alpha1.GetProjectUsageSummaryResponse](
+			httpClient,
+			baseURL+KargoServiceGetProjectUsageSummaryProcedure,
+			connect.WithSchema(kargoServiceGetProjectUsageSummaryMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		approveFreight: connect.NewClient[v1alpha1.ApproveFreightRequest, v1alpha1.ApproveFreightResponse](
 			httpClient,
 			baseURL+KargoServiceApproveFreightProcedure,
 			connect.WithSchema(kargoServiceApproveFreightMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		blockFreight: connect.NewClient[v1alpha1.BlockFreightRequest, v1alpha1.BlockFreightResponse](
+			httpClient,
+			baseURL+KargoServiceBlockFreightProcedure,
+			connect.WithSchema(kargoServiceBlockFreightMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		deleteFreight: connect.NewClient[v1alpha1.DeleteFreightRequest, v1alpha1.DeleteFreightResponse](
 			httpClient,
 			baseURL+KargoServiceDeleteFreightProcedure,
@@ -435,6 +524,18 @@ func NewKargoServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(kargoServiceGetFreightMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		getFreightLeadTimes: connect.NewClient[v1alpha1.GetFreightLeadTimesRequest, v1alpha1.GetFreightLeadTimesResponse](
+			httpClient,
+			baseURL+KargoServiceGetFreightLeadTimesProcedure,
+			connect.WithSchema(kargoServiceGetFreightLeadTimesMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		promoteThroughStages: connect.NewClient[v1alpha1.PromoteThroughStagesRequest, v1alpha1.PromoteThroughStagesResponse](
+			httpClient,
+			baseURL+KargoServicePromoteThroughStagesProcedure,
+			connect.WithSchema(kargoServicePromoteThroughStagesMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		promoteToStage: connect.NewClient[v1alpha1.PromoteToStageRequest, v1alpha1.PromoteToStageResponse](
 			httpClient,
 			baseURL+KargoServicePromoteToStageProcedure,
@@ -453,6 +554,12 @@ func NewKargoServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(kargoServiceQueryFreightMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		unblockFreight: connect.NewClient[v1alpha1.UnblockFreightRequest, v1alpha1.UnblockFreightResponse](
+			httpClient,
+			baseURL+KargoServiceUnblockFreightProcedure,
+			connect.WithSchema(kargoServiceUnblockFreightMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		updateFreightAlias: connect.NewClient[v1alpha1.UpdateFreightAliasRequest, v1alpha1.UpdateFreightAliasResponse](
 			httpClient,
 			baseURL+KargoServiceUpdateFreightAliasProcedure,
@@ -501,6 +608,18 @@ func NewKargoServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(kargoServiceRefreshWarehouseMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		previewWarehouse: connect.NewClient[v1alpha1.PreviewWarehouseRequest, v1alpha1.PreviewWarehouseResponse](
+			httpClient,
+			baseURL+KargoServicePreviewWarehouseProcedure,
+			connect.WithSchema(kargoServicePreviewWarehouseMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		validateWarehouse: connect.NewClient[v1alpha1.ValidateWarehouseRequest, v1alpha1.ValidateWarehouseResponse](
+			httpClient,
+			baseURL+KargoServiceValidateWarehouseProcedure,
+			connect.WithSchema(kargoServiceValidateWarehouseMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		createCredentials: connect.NewClient[v1alpha1.CreateCredentialsRequest, v1alpha1.CreateCredentialsResponse](
 			httpClient,
 			baseURL+KargoServiceCreateCredentialsProcedure,
@@ -555,6 +674,12 @@ func NewKargoServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(kargoServiceGetAnalysisRunMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		getAnalysisRunLogs: connect.NewClient[v1alpha1.GetAnalysisRunLogsRequest, v1alpha1.GetAnalysisRunLogsResponse](
+			httpClient,
+			baseURL+KargoServiceGetAnalysisRunLogsProcedure,
+			connect.WithSchema(kargoServiceGetAnalysisRunLogsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 		listProjectEvents: connect.NewClient[v1alpha1.ListProjectEventsRequest, v1alpha1.ListProjectEventsResponse](
 			httpClient,
 			baseURL+KargoServiceListProjectEventsProcedure,
@@ -603,6 +728,12 @@ func NewKargoServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(kargoServiceUpdateRoleMethodDescriptor),
 			connect.WithClientOptions(opts...),
 		),
+		whoAmI: connect.NewClient[v1alpha1.WhoAmIRequest, v1alpha1.WhoAmIResponse](
+			httpClient,
+			baseURL+KargoServiceWhoAmIProcedure,
+			connect.WithSchema(kargoServiceWhoAmIMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -611,6 +742,7 @@ type kargoServiceClient struct {
 	getVersionInfo            *connect.Client[v1alpha1.GetVersionInfoRequest, v1alpha1.GetVersionInfoResponse]
 	getConfig                 *connect.Client[v1alpha1.GetConfigRequest, v1alpha1.GetConfigResponse]
 	getPublicConfig           *connect.Client[v1alpha1.GetPublicConfigRequest, v1alpha1.GetPublicConfigResponse]
+	listAnnouncements         *connect.Client[v1alpha1.ListAnnouncementsRequest, v1alpha1.ListAnnouncementsResponse]
 	adminLogin                *connect.Client[v1alpha1.AdminLoginRequest, v1alpha1.AdminLoginResponse]
 	createResource            *connect.Client[v1alpha1.CreateResourceRequest, v1alpha1.CreateResourceResponse]
 	createOrUpdateResource    *connect.Client[v1alpha1.CreateOrUpdateResourceRequest, v1alpha1.CreateOrUpdateResourceResponse]
@@ -625,15 +757,22 @@ type kargoServiceClient struct {
 	watchPromotions           *connect.Client[v1alpha1.WatchPromotionsRequest, v1alpha1.WatchPromotionsResponse]
 	getPromotion              *connect.Client[v1alpha1.GetPromotionRequest, v1alpha1.GetPromotionResponse]
 	watchPromotion            *connect.Client[v1alpha1.WatchPromotionRequest, v1alpha1.WatchPromotionResponse]
+	comparePromotions         *connect.Client[v1alpha1.ComparePromotionsRequest, v1alpha1.ComparePromotionsResponse]
+	getPromotionPlan          *connect.Client[v1alpha1.GetPromotionPlanRequest, v1alpha1.GetPromotionPlanResponse]
 	deleteProject             *connect.Client[v1alpha1.DeleteProjectRequest, v1alpha1.DeleteProjectResponse]
 	getProject                *connect.Client[v1alpha1.GetProjectRequest, v1alpha1.GetProjectResponse]
 	listProjects              *connect.Client[v1alpha1.ListProjectsRequest, v1alpha1.ListProjectsResponse]
+	getProjectUsageSummary    *connect.Client[v1alpha1.GetProjectUsageSummaryRequest, v1alpha1.GetProjectUsageSummaryResponse]
 	approveFreight            *connect.Client[v1alpha1.ApproveFreightRequest, v1alpha1.ApproveFreightResponse]
+	blockFreight              *connect.Client[v1alpha1.BlockFreightRequest, v1alpha1.BlockFreightResponse]
 	deleteFreight             *connect.Client[v1alpha1.DeleteFreightRequest, v1alpha1.DeleteFreightResponse]
 	getFreight                *connect.Client[v1alpha1.GetFreightRequest, v1alpha1.GetFreightResponse]
+	getFreightLeadTimes       *connect.Client[v1alpha1.GetFreightLeadTimesRequest, v1alpha1.GetFreightLeadTimesResponse]
+	promoteThroughStages      *connect.Client[v1alpha1.PromoteThroughStagesRequest, v1alpha1.PromoteThroughStagesResponse]
 	promoteToStage            *connect.Client[v1alpha1.PromoteToStageRequest, v1alpha1.PromoteToStageResponse]
 	promoteToStageSubscribers *connect.Client[v1alpha1.PromoteToStageSubscribersRequest, v1alpha1.PromoteToStageSubscribersResponse]
 	queryFreight              *connect.Client[v1alpha1.QueryFreightRequest, v1alpha1.QueryFreightResponse]
+	unblockFreight            *connect.Client[v1alpha1.UnblockFreightRequest, v1alpha1.UnblockFreightResponse]
 	updateFreightAlias        *connect.Client[v1alpha1.UpdateFreightAliasRequest, v1alpha1.UpdateFreightAliasResponse]
 	reverify                  *connect.Client[v1alpha1.ReverifyRequest, v1alpha1.ReverifyResponse]
 	abortVerification         *connect.Client[v1alpha1.AbortVerificationRequest, v1alpha1.AbortVerificationResponse]
@@ -642,6 +781,8 @@ type kargoServiceClient struct {
 	watchWarehouses           *connect.Client[v1alpha1.WatchWarehousesRequest, v1alpha1.WatchWarehousesResponse]
 	deleteWarehouse           *connect.Client[v1alpha1.DeleteWarehouseRequest, v1alpha1.DeleteWarehouseResponse]
 	refreshWarehouse          *connect.Client[v1alpha1.RefreshWarehouseRequest, v1alpha1.RefreshWarehouseResponse]
+	previewWarehouse          *connect.Client[v1alpha1.PreviewWarehouseRequest, v1alpha1.PreviewWarehouseResponse]
+	validateWarehouse         *connect.Client[v1alpha1.ValidateWarehouseRequest, v1alpha1.ValidateWarehouseResponse]
 	createCredentials         *connect.Client[v1alpha1.CreateCredentialsRequest, v1alpha1.CreateCredentialsResponse]
 	deleteCredentials         *connect.Client[v1alpha1.DeleteCredentialsRequest, v1alpha1.DeleteCredentialsResponse]
 	getCredentials            *connect.Client[v1alpha1.GetCredentialsRequest, v1alpha1.GetCredentialsResponse]
@@ -651,6 +792,7 @@ type kargoServiceClient struct {
 	getAnalysisTemplate       *connect.Client[v1alpha1.GetAnalysisTemplateRequest, v1alpha1.GetAnalysisTemplateResponse]
 	deleteAnalysisTemplate    *connect.Client[v1alpha1.DeleteAnalysisTemplateRequest, v1alpha1.DeleteAnalysisTemplateResponse]
 	getAnalysisRun            *connect.Client[v1alpha1.GetAnalysisRunRequest, v1alpha1.GetAnalysisRunResponse]
+	getAnalysisRunLogs        *connect.Client[v1alpha1.GetAnalysisRunLogsRequest, v1alpha1.GetAnalysisRunLogsResponse]
 	listProjectEvents         *connect.Client[v1alpha1.ListProjectEventsRequest, v1alpha1.ListProjectEventsResponse]
 	createRole                *connect.Client[v1alpha1.CreateRoleRequest, v1alpha1.CreateRoleResponse]
 	deleteRole                *connect.Client[v1alpha1.DeleteRoleRequest, v1alpha1.DeleteRoleResponse]
@@ -659,6 +801,7 @@ type kargoServiceClient struct {
 	listRoles                 *connect.Client[v1alpha1.ListRolesRequest, v1alpha1.ListRolesResponse]
 	revoke                    *connect.Client[v1alpha1.RevokeRequest, v1alpha1.RevokeResponse]
 	updateRole                *connect.Client[v1alpha1.UpdateRoleRequest, v1alpha1.UpdateRoleResponse]
+	whoAmI                    *connect.Client[v1alpha1.WhoAmIRequest, v1alpha1.WhoAmIResponse]
 }
 
 // GetVersionInfo calls akuity.io.kargo.service.v1alpha1.KargoService.GetVersionInfo.
@@ -676,6 +819,11 @@ func (c *kargoServiceClient) GetPublicConfig(ctx context.Context, req *connect.R
 	return c.getPublicConfig.CallUnary(ctx, req)
 }
 
+// ListAnnouncements calls akuity.io.kargo.service.v1alpha1.KargoService.ListAnnouncements.
+func (c *kargoServiceClient) ListAnnouncements(ctx context.Context, req *connect.Request[v1alpha1.ListAnnouncementsRequest]) (*connect.Response[v1alpha1.ListAnnouncementsResponse], error) {
+	return c.listAnnouncements.CallUnary(ctx, req)
+}
+
 // AdminLogin calls akuity.io.kargo.service.v1alpha1.KargoService.AdminLogin.
 func (c *kargoServiceClient) AdminLogin(ctx context.Context, req *connect.Request[v1alpha1.AdminLoginRequest]) (*connect.Response[v1alpha1.AdminLoginResponse], error) {
 	return c.adminLogin.CallUnary(ctx, req)
@@ -747,6 +895,16 @@ func (c *kargoServiceClient) WatchPromotion(ctx context.Context, req *connect.Re
 	return c.watchPromotion.CallServerStream(ctx, req)
 }
 
+// ComparePromotions calls akuity.io.kargo.service.v1alpha1.KargoService.ComparePromotions.
+func (c *kargoServiceClient) ComparePromotions(ctx context.Context, req *connect.Request[v1alpha1.ComparePromotionsRequest]) (*connect.Response[v1alpha1.ComparePromotionsResponse], error) {
+	return c.comparePromotions.CallUnary(ctx, req)
+}
+
+// GetPromotionPlan calls akuity.io.kargo.service.v1alpha1.KargoService.GetPromotionPlan.
+func (c *kargoServiceClient) GetPromotionPlan(ctx context.Context, req *connect.Request[v1alpha1.GetPromotionPlanRequest]) (*connect.Response[v1alpha1.GetPromotionPlanResponse], error) {
+	return c.getPromotionPlan.CallUnary(ctx, req)
+}
+
 // DeleteProject calls akuity.io.kargo.service.v1alpha1.KargoService.DeleteProject.
 func (c *kargoServiceClient) DeleteProject(ctx context.Context, req *connect.Request[v1alpha1.DeleteProjectRequest]) (*connect.Response[v1alpha1.DeleteProjectResponse], error) {
 	return c.deleteProject.CallUnary(ctx, req)
@@ -762,11 +920,22 @@ func (c *kargoServiceClient) ListProjects(ctx context.Context, req *connect.Requ
 	return c.listProjects.CallUnary(ctx, req)
 }
 
+// GetProjectUsageSummary calls
+// akuity.io.kargo.service.v1alpha1.KargoService.GetProjectUsageSummary.
+func (c *kargoServiceClient) GetProjectUsageSummary(ctx context.Context, req *connect.Request[v1alpha1.GetProjectUsageSummaryRequest]) (*connect.Response[v1alpha1.GetProjectUsageSummaryResponse], error) {
+	return c.getProjectUsageSummary.CallUnary(ctx, req)
+}
+
 // ApproveFreight calls akuity.io.kargo.service.v1alpha1.KargoService.ApproveFreight.
 func (c *kargoServiceClient) ApproveFreight(ctx context.Context, req *connect.Request[v1alpha1.ApproveFreightRequest]) (*connect.Response[v1alpha1.ApproveFreightResponse], error) {
 	return c.approveFreight.CallUnary(ctx, req)
 }
 
+// BlockFreight calls akuity.io.kargo.service.v1alpha1.KargoService.BlockFreight.
+func (c *kargoServiceClient) BlockFreight(ctx context.Context, req *connect.Request[v1alpha1.BlockFreightRequest]) (*connect.Response[v1alpha1.BlockFreightResponse], error) {
+	return c.blockFreight.CallUnary(ctx, req)
+}
+
 // DeleteFreight calls akuity.io.kargo.service.v1alpha1.KargoService.DeleteFreight.
 func (c *kargoServiceClient) DeleteFreight(ctx context.Context, req *connect.Request[v1alpha1.DeleteFreightRequest]) (*connect.Response[v1alpha1.DeleteFreightResponse], error) {
 	return c.deleteFreight.CallUnary(ctx, req)
@@ -777,6 +946,16 @@ func (c *kargoServiceClient) GetFreight(ctx context.Context, req *connect.Reques
 	return c.getFreight.CallUnary(ctx, req)
 }
 
+// GetFreightLeadTimes calls akuity.io.kargo.service.v1alpha1.KargoService.GetFreightLeadTimes.
+func (c *kargoServiceClient) GetFreightLeadTimes(ctx context.Context, req *connect.Request[v1alpha1.GetFreightLeadTimesRequest]) (*connect.Response[v1alpha1.GetFreightLeadTimesResponse], error) {
+	return c.getFreightLeadTimes.CallUnary(ctx, req)
+}
+
+// PromoteThroughStages calls akuity.io.kargo.service.v1alpha1.KargoService.PromoteThroughStages.
+func (c *kargoServiceClient) PromoteThroughStages(ctx context.Context, req *connect.Request[v1alpha1.PromoteThroughStagesRequest]) (*connect.Response[v1alpha1.PromoteThroughStagesResponse], error) {
+	return c.promoteThroughStages.CallUnary(ctx, req)
+}
+
 // PromoteToStage calls akuity.io.kargo.service.v1alpha1.KargoService.PromoteToStage.
 func (c *kargoServiceClient) PromoteToStage(ctx context.Context, req *connect.Request[v1alpha1.PromoteToStageRequest]) (*connect.Response[v1alpha1.PromoteToStageResponse], error) {
 	return c.promoteToStage.CallUnary(ctx, req)
@@ -793,6 +972,11 @@ func (c *kargoServiceClient) QueryFreight(ctx context.Context, req *connect.Requ
 	return c.queryFreight.CallUnary(ctx, req)
 }
 
+// UnblockFreight calls akuity.io.kargo.service.v1alpha1.KargoService.UnblockFreight.
+func (c *kargoServiceClient) UnblockFreight(ctx context.Context, req *connect.Request[v1alpha1.UnblockFreightRequest]) (*connect.Response[v1alpha1.UnblockFreightResponse], error) {
+	return c.unblockFreight.CallUnary(ctx, req)
+}
+
 // UpdateFreightAlias calls akuity.io.kargo.service.v1alpha1.KargoService.UpdateFreightAlias.
 func (c *kargoServiceClient) UpdateFreightAlias(ctx context.Context, req *connect.Request[v1alpha1.UpdateFreightAliasRequest]) (*connect.Response[v1alpha1.UpdateFreightAliasResponse], error) {
 	return c.updateFreightAlias.CallUnary(ctx, req)
@@ -833,6 +1017,16 @@ func (c *kargoServiceClient) RefreshWarehouse(ctx context.Context, req *connect.
 	return c.refreshWarehouse.CallUnary(ctx, req)
 }
 
+// PreviewWarehouse calls akuity.io.kargo.service.v1alpha1.KargoService.PreviewWarehouse.
+func (c *kargoServiceClient) PreviewWarehouse(ctx context.Context, req *connect.Request[v1alpha1.PreviewWarehouseRequest]) (*connect.Response[v1alpha1.PreviewWarehouseResponse], error) {
+	return c.previewWarehouse.CallUnary(ctx, req)
+}
+
+// ValidateWarehouse calls akuity.io.kargo.service.v1alpha1.KargoService.ValidateWarehouse.
+func (c *kargoServiceClient) ValidateWarehouse(ctx context.Context, req *connect.Request[v1alpha1.ValidateWarehouseRequest]) (*connect.Response[v1alpha1.ValidateWarehouseResponse], error) {
+	return c.validateWarehouse.CallUnary(ctx, req)
+}
+
 // CreateCredentials calls akuity.io.kargo.service.v1alpha1.KargoService.CreateCredentials.
 func (c *kargoServiceClient) CreateCredentials(ctx context.Context, req *connect.Request[v1alpha1.CreateCredentialsRequest]) (*connect.Response[v1alpha1.CreateCredentialsResponse], error) {
 	return c.createCredentials.CallUnary(ctx, req)
@@ -879,6 +1073,11 @@ func (c *kargoServiceClient) GetAnalysisRun(ctx context.Context, req *connect.Re
 	return c.getAnalysisRun.CallUnary(ctx, req)
 }
 
+// GetAnalysisRunLogs calls akuity.io.kargo.service.v1alpha1.KargoService.GetAnalysisRunLogs.
+func (c *kargoServiceClient) GetAnalysisRunLogs(ctx context.Context, req *connect.Request[v1alpha1.GetAnalysisRunLogsRequest]) (*connect.ServerStreamForClient[v1alpha1.GetAnalysisRunLogsResponse], error) {
+	return c.getAnalysisRunLogs.CallServerStream(ctx, req)
+}
+
 // ListProjectEvents calls akuity.io.kargo.service.v1alpha1.KargoService.ListProjectEvents.
 func (c *kargoServiceClient) ListProjectEvents(ctx context.Context, req *connect.Request[v1alpha1.ListProjectEventsRequest]) (*connect.Response[v1alpha1.ListProjectEventsResponse], error) {
 	return c.listProjectEvents.CallUnary(ctx, req)
@@ -919,12 +1118,18 @@ func (c *kargoServiceClient) UpdateRole(ctx context.Context, req *connect.Reques
 	return c.updateRole.CallUnary(ctx, req)
 }
 
+// WhoAmI calls akuity.io.kargo.service.v1alpha1.KargoService.WhoAmI.
+func (c *kargoServiceClient) WhoAmI(ctx context.Context, req *connect.Request[v1alpha1.WhoAmIRequest]) (*connect.Response[v1alpha1.WhoAmIResponse], error) {
+	return c.whoAmI.CallUnary(ctx, req)
+}
+
 // KargoServiceHandler is an implementation of the akuity.io.kargo.service.v1alpha1.KargoService
 // service.
 type KargoServiceHandler interface {
 	GetVersionInfo(context.Context, *connect.Request[v1alpha1.GetVersionInfoRequest]) (*connect.Response[v1alpha1.GetVersionInfoResponse], error)
 	GetConfig(context.Context, *connect.Request[v1alpha1.GetConfigRequest]) (*connect.Response[v1alpha1.GetConfigResponse], error)
 	GetPublicConfig(context.Context, *connect.Request[v1alpha1.GetPublicConfigRequest]) (*connect.Response[v1alpha1.GetPublicConfigResponse], error)
+	ListAnnouncements(context.Context, *connect.Request[v1alpha1.ListAnnouncementsRequest]) (*connect.Response[v1alpha1.ListAnnouncementsResponse], error)
 	AdminLogin(context.Context, *connect.Request[v1alpha1.AdminLoginRequest]) (*connect.Response[v1alpha1.AdminLoginResponse], error)
 	// TODO(devholic): Add ApplyResource API
 	// rpc ApplyResource(ApplyResourceRequest) returns (ApplyResourceRequest);
@@ -941,15 +1146,22 @@ type KargoServiceHandler interface {
 	WatchPromotions(context.Context, *connect.Request[v1alpha1.WatchPromotionsRequest], *connect.ServerStream[v1alpha1.WatchPromotionsResponse]) error
 	GetPromotion(context.Context, *connect.Request[v1alpha1.GetPromotionRequest]) (*connect.Response[v1alpha1.GetPromotionResponse], error)
 	WatchPromotion(context.Context, *connect.Request[v1alpha1.WatchPromotionRequest], *connect.ServerStream[v1alpha1.WatchPromotionResponse]) error
+	ComparePromotions(context.Context, *connect.Request[v1alpha1.ComparePromotionsRequest]) (*connect.Response[v1alpha1.ComparePromotionsResponse], error)
+	GetPromotionPlan(context.Context, *connect.Request[v1alpha1.GetPromotionPlanRequest]) (*connect.Response[v1alpha1.GetPromotionPlanResponse], error)
 	DeleteProject(context.Context, *connect.Request[v1alpha1.DeleteProjectRequest]) (*connect.Response[v1alpha1.DeleteProjectResponse], error)
 	GetProject(context.Context, *connect.Request[v1alpha1.GetProjectRequest]) (*connect.Response[v1alpha1.GetProjectResponse], error)
 	ListProjects(context.Context, *connect.Request[v1alpha1.ListProjectsRequest]) (*connect.Response[v1alpha1.ListProjectsResponse], error)
+	GetProjectUsageSummary(context.Context, *connect.Request[v1alpha1.GetProjectUsageSummaryRequest]) (*connect.Response[v1alpha1.GetProjectUsageSummaryResponse], error)
 	ApproveFreight(context.Context, *connect.Request[v1alpha1.ApproveFreightRequest]) (*connect.Response[v1alpha1.ApproveFreightResponse], error)
+	BlockFreight(context.Context, *connect.Request[v1alpha1.BlockFreightRequest]) (*connect.Response[v1alpha1.BlockFreightResponse], error)
 	DeleteFreight(context.Context, *connect.Request[v1alpha1.DeleteFreightRequest]) (*connect.Response[v1alpha1.DeleteFreightResponse], error)
 	GetFreight(context.Context, *connect.Request[v1alpha1.GetFreightRequest]) (*connect.Response[v1alpha1.GetFreightResponse], error)
+	GetFreightLeadTimes(context.Context, *connect.Request[v1alpha1.GetFreightLeadTimesRequest]) (*connect.Response[v1alpha1.GetFreightLeadTimesResponse], error)
+	PromoteThroughStages(context.Context, *connect.Request[v1alpha1.PromoteThroughStagesRequest]) (*connect.Response[v1alpha1.PromoteThroughStagesResponse], error)
 	PromoteToStage(context.Context, *connect.Request[v1alpha1.PromoteToStageRequest]) (*connect.Response[v1alpha1.PromoteToStageResponse], error)
 	PromoteToStageSubscribers(context.Context, *connect.Request[v1alpha1.PromoteToStageSubscribersRequest]) (*connect.Response[v1alpha1.PromoteToStageSubscribersResponse], error)
 	QueryFreight(context.Context, *connect.Request[v1alpha1.QueryFreightRequest]) (*connect.Response[v1alpha1.QueryFreightResponse], error)
+	UnblockFreight(context.Context, *connect.Request[v1alpha1.UnblockFreightRequest]) (*connect.Response[v1alpha1.UnblockFreightResponse], error)
 	UpdateFreightAlias(context.Context, *connect.Request[v1alpha1.UpdateFreightAliasRequest]) (*connect.Response[v1alpha1.UpdateFreightAliasResponse], error)
 	Reverify(context.Context, *connect.Request[v1alpha1.ReverifyRequest]) (*connect.Response[v1alpha1.ReverifyResponse], error)
 	AbortVerification(context.Context, *connect.Request[v1alpha1.AbortVerificationRequest]) (*connect.Response[v1alpha1.AbortVerificationResponse], error)
@@ -958,6 +1170,8 @@ type KargoServiceHandler interface {
 	WatchWarehouses(context.Context, *connect.Request[v1alpha1.WatchWarehousesRequest], *connect.ServerStream[v1alpha1.WatchWarehousesResponse]) error
 	DeleteWarehouse(context.Context, *connect.Request[v1alpha1.DeleteWarehouseRequest]) (*connect.Response[v1alpha1.DeleteWarehouseResponse], error)
 	RefreshWarehouse(context.Context, *connect.Request[v1alpha1.RefreshWarehouseRequest]) (*connect.Response[v1alpha1.RefreshWarehouseResponse], error)
+	PreviewWarehouse(context.Context, *connect.Request[v1alpha1.PreviewWarehouseRequest]) (*connect.Response[v1alpha1.PreviewWarehouseResponse], error)
+	ValidateWarehouse(context.Context, *connect.Request[v1alpha1.ValidateWarehouseRequest]) (*connect.Response[v1alpha1.ValidateWarehouseResponse], error)
 	CreateCredentials(context.Context, *connect.Request[v1alpha1.CreateCredentialsRequest]) (*connect.Response[v1alpha1.CreateCredentialsResponse], error)
 	DeleteCredentials(context.Context, *connect.Request[v1alpha1.DeleteCredentialsRequest]) (*connect.Response[v1alpha1.DeleteCredentialsResponse], error)
 	GetCredentials(context.Context, *connect.Request[v1alpha1.GetCredentialsRequest]) (*connect.Response[v1alpha1.GetCredentialsResponse], error)
@@ -967,6 +1181,7 @@ type KargoServiceHandler interface {
 	GetAnalysisTemplate(context.Context, *connect.Request[v1alpha1.GetAnalysisTemplateRequest]) (*connect.Response[v1alpha1.GetAnalysisTemplateResponse], error)
 	DeleteAnalysisTemplate(context.Context, *connect.Request[v1alpha1.DeleteAnalysisTemplateRequest]) (*connect.Response[v1alpha1.DeleteAnalysisTemplateResponse], error)
 	GetAnalysisRun(context.Context, *connect.Request[v1alpha1.GetAnalysisRunRequest]) (*connect.Response[v1alpha1.GetAnalysisRunResponse], error)
+	GetAnalysisRunLogs(context.Context, *connect.Request[v1alpha1.GetAnalysisRunLogsRequest], *connect.ServerStream[v1alpha1.GetAnalysisRunLogsResponse]) error
 	ListProjectEvents(context.Context, *connect.Request[v1alpha1.ListProjectEventsRequest]) (*connect.Response[v1alpha1.ListProjectEventsResponse], error)
 	CreateRole(context.Context, *connect.Request[v1alpha1.CreateRoleRequest]) (*connect.Response[v1alpha1.CreateRoleResponse], error)
 	DeleteRole(context.Context, *connect.Request[v1alpha1.DeleteRoleRequest]) (*connect.Response[v1alpha1.DeleteRoleResponse], error)
@@ -975,6 +1190,7 @@ type KargoServiceHandler interface {
 	ListRoles(context.Context, *connect.Request[v1alpha1.ListRolesRequest]) (*connect.Response[v1alpha1.ListRolesResponse], error)
 	Revoke(context.Context, *connect.Request[v1alpha1.RevokeRequest]) (*connect.Response[v1alpha1.RevokeResponse], error)
 	UpdateRole(context.Context, *connect.Request[v1alpha1.UpdateRoleRequest]) (*connect.Response[v1alpha1.UpdateRoleResponse], error)
+	WhoAmI(context.Context, *connect.Request[v1alpha1.WhoAmIRequest]) (*connect.Response[v1alpha1.WhoAmIResponse], error)
 }
 
 // NewKargoServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -1001,6 +1217,12 @@ func NewKargoServiceHandler(svc KargoServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(kargoServiceGetPublicConfigMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	kargoServiceListAnnouncementsHandler := connect.NewUnaryHandler(
+		KargoServiceListAnnouncementsProcedure,
+		svc.ListAnnouncements,
+		connect.WithSchema(kargoServiceListAnnouncementsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	kargoServiceAdminLoginHandler := connect.NewUnaryHandler(
 		KargoServiceAdminLoginProcedure,
 		svc.AdminLogin,
@@ -1085,6 +1307,18 @@ func NewKargoServiceHandler(svc KargoServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(kargoServiceWatchPromotionMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	kargoServiceComparePromotionsHandler := connect.NewUnaryHandler(
+		KargoServiceComparePromotionsProcedure,
+		svc.ComparePromotions,
+		connect.WithSchema(kargoServiceComparePromotionsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	kargoServiceGetPromotionPlanHandler := connect.NewUnaryHandler(
+		KargoServiceGetPromotionPlanProcedure,
+		svc.GetPromotionPlan,
+		connect.WithSchema(kargoServiceGetPromotionPlanMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	kargoServiceDeleteProjectHandler := connect.NewUnaryHandler(
 		KargoServiceDeleteProjectProcedure,
 		svc.DeleteProject,
@@ -1103,12 +1337,24 @@ func NewKargoServiceHandler(svc KargoServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(kargoServiceListProjectsMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	kargoServiceGetProjectUsageSummaryHandler := connect.NewUnaryHandler(
+		KargoServiceGetProjectUsageSummaryProcedure,
+		svc.GetProjectUsageSummary,
+		connect.WithSchema(kargoServiceGetProjectUsageSummaryMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	kargoServiceApproveFreightHandler := connect.NewUnaryHandler(
 		KargoServiceApproveFreightProcedure,
 		svc.ApproveFreight,
 		connect.WithSchema(kargoServiceApproveFreightMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	kargoServiceBlockFreightHandler := connect.NewUnaryHandler(
+		KargoServiceBlockFreightProcedure,
+		svc.BlockFreight,
+		connect.WithSchema(kargoServiceBlockFreightMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	kargoServiceDeleteFreightHandler := connect.NewUnaryHandler(
 		KargoServiceDeleteFreightProcedure,
 		svc.DeleteFreight,
@@ -1121,6 +1367,18 @@ func NewKargoServiceHandler(svc KargoServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(kargoServiceGetFreightMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	kargoServiceGetFreightLeadTimesHandler := connect.NewUnaryHandler(
+		KargoServiceGetFreightLeadTimesProcedure,
+		svc.GetFreightLeadTimes,
+		connect.WithSchema(kargoServiceGetFreightLeadTimesMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	kargoServicePromoteThroughStagesHandler := connect.NewUnaryHandler(
+		KargoServicePromoteThroughStagesProcedure,
+		svc.PromoteThroughStages,
+		connect.WithSchema(kargoServicePromoteThroughStagesMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	kargoServicePromoteToStageHandler := connect.NewUnaryHandler(
 		KargoServicePromoteToStageProcedure,
 		svc.PromoteToStage,
@@ -1139,6 +1397,12 @@ func NewKargoServiceHandler(svc KargoServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(kargoServiceQueryFreightMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	kargoServiceUnblockFreightHandler := connect.NewUnaryHandler(
+		KargoServiceUnblockFreightProcedure,
+		svc.UnblockFreight,
+		connect.WithSchema(kargoServiceUnblockFreightMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	kargoServiceUpdateFreightAliasHandler := connect.NewUnaryHandler(
 		KargoServiceUpdateFreightAliasProcedure,
 		svc.UpdateFreightAlias,
@@ -1187,6 +1451,18 @@ func NewKargoServiceHandler(svc KargoServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(kargoServiceRefreshWarehouseMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	kargoServicePreviewWarehouseHandler := connect.NewUnaryHandler(
+		KargoServicePreviewWarehouseProcedure,
+		svc.PreviewWarehouse,
+		connect.WithSchema(kargoServicePreviewWarehouseMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	kargoServiceValidateWarehouseHandler := connect.NewUnaryHandler(
+		KargoServiceValidateWarehouseProcedure,
+		svc.ValidateWarehouse,
+		connect.WithSchema(kargoServiceValidateWarehouseMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	kargoServiceCreateCredentialsHandler := connect.NewUnaryHandler(
 		KargoServiceCreateCredentialsProcedure,
 		svc.CreateCredentials,
@@ -1241,6 +1517,12 @@ func NewKargoServiceHandler(svc KargoServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(kargoServiceGetAnalysisRunMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	kargoServiceGetAnalysisRunLogsHandler := connect.NewServerStreamHandler(
+		KargoServiceGetAnalysisRunLogsProcedure,
+		svc.GetAnalysisRunLogs,
+		connect.WithSchema(kargoServiceGetAnalysisRunLogsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	kargoServiceListProjectEventsHandler := connect.NewUnaryHandler(
 		KargoServiceListProjectEventsProcedure,
 		svc.ListProjectEvents,
@@ -1289,6 +1571,12 @@ func NewKargoServiceHandler(svc KargoServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(kargoServiceUpdateRoleMethodDescriptor),
 		connect.WithHandlerOptions(opts...),
 	)
+	kargoServiceWhoAmIHandler := connect.NewUnaryHandler(
+		KargoServiceWhoAmIProcedure,
+		svc.WhoAmI,
+		connect.WithSchema(kargoServiceWhoAmIMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/akuity.io.kargo.service.v1alpha1.KargoService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case KargoServiceGetVersionInfoProcedure:
@@ -1297,6 +1585,8 @@ func NewKargoServiceHandler(svc KargoServiceHandler, opts ...connect.HandlerOpti
 			kargoServiceGetConfigHandler.ServeHTTP(w, r)
 		case KargoServiceGetPublicConfigProcedure:
 			kargoServiceGetPublicConfigHandler.ServeHTTP(w, r)
+		case KargoServiceListAnnouncementsProcedure:
+			kargoServiceListAnnouncementsHandler.ServeHTTP(w, r)
 		case KargoServiceAdminLoginProcedure:
 			kargoServiceAdminLoginHandler.ServeHTTP(w, r)
 		case KargoServiceCreateResourceProcedure:
@@ -1325,24 +1615,38 @@ func NewKargoServiceHandler(svc KargoServiceHandler, opts ...connect.HandlerOpti
 			kargoServiceGetPromotionHandler.ServeHTTP(w, r)
 		case KargoServiceWatchPromotionProcedure:
 			kargoServiceWatchPromotionHandler.ServeHTTP(w, r)
+		case KargoServiceComparePromotionsProcedure:
+			kargoServiceComparePromotionsHandler.ServeHTTP(w, r)
+		case KargoServiceGetPromotionPlanProcedure:
+			kargoServiceGetPromotionPlanHandler.ServeHTTP(w, r)
 		case KargoServiceDeleteProjectProcedure:
 			kargoServiceDeleteProjectHandler.ServeHTTP(w, r)
 		case KargoServiceGetProjectProcedure:
 			kargoServiceGetProjectHandler.ServeHTTP(w, r)
 		case KargoServiceListProjectsProcedure:
 			kargoServiceListProjectsHandler.ServeHTTP(w, r)
+		case KargoServiceGetProjectUsageSummaryProcedure:
+			kargoServiceGetProjectUsageSummaryHandler.ServeHTTP(w, r)
 		case KargoServiceApproveFreightProcedure:
 			kargoServiceApproveFreightHandler.ServeHTTP(w, r)
+		case KargoServiceBlockFreightProcedure:
+			kargoServiceBlockFreightHandler.ServeHTTP(w, r)
 		case KargoServiceDeleteFreightProcedure:
 			kargoServiceDeleteFreightHandler.ServeHTTP(w, r)
 		case KargoServiceGetFreightProcedure:
 			kargoServiceGetFreightHandler.ServeHTTP(w, r)
+		case KargoServiceGetFreightLeadTimesProcedure:
+			kargoServiceGetFreightLeadTimesHandler.ServeHTTP(w, r)
+		case KargoServicePromoteThroughStagesProcedure:
+			kargoServicePromoteThroughStagesHandler.ServeHTTP(w, r)
 		case KargoServicePromoteToStageProcedure:
 			kargoServicePromoteToStageHandler.ServeHTTP(w, r)
 		case KargoServicePromoteToStageSubscribersProcedure:
 			kargoServicePromoteToStageSubscribersHandler.ServeHTTP(w, r)
 		case KargoServiceQueryFreightProcedure:
 			kargoServiceQueryFreightHandler.ServeHTTP(w, r)
+		case KargoServiceUnblockFreightProcedure:
+			kargoServiceUnblockFreightHandler.ServeHTTP(w, r)
 		case KargoServiceUpdateFreightAliasProcedure:
 			kargoServiceUpdateFreightAliasHandler.ServeHTTP(w, r)
 		case KargoServiceReverifyProcedure:
@@ -1359,6 +1663,10 @@ func NewKargoServiceHandler(svc KargoServiceHandler, opts ...connect.HandlerOpti
 			kargoServiceDeleteWarehouseHandler.ServeHTTP(w, r)
 		case KargoServiceRefreshWarehouseProcedure:
 			kargoServiceRefreshWarehouseHandler.ServeHTTP(w, r)
+		case KargoServicePreviewWarehouseProcedure:
+			kargoServicePreviewWarehouseHandler.ServeHTTP(w, r)
+		case KargoServiceValidateWarehouseProcedure:
+			kargoServiceValidateWarehouseHandler.ServeHTTP(w, r)
 		case KargoServiceCreateCredentialsProcedure:
 			kargoServiceCreateCredentialsHandler.ServeHTTP(w, r)
 		case KargoServiceDeleteCredentialsProcedure:
@@ -1377,6 +1685,8 @@ func NewKargoServiceHandler(svc KargoServiceHandler, opts ...connect.HandlerOpti
 			kargoServiceDeleteAnalysisTemplateHandler.ServeHTTP(w, r)
 		case KargoServiceGetAnalysisRunProcedure:
 			kargoServiceGetAnalysisRunHandler.ServeHTTP(w, r)
+		case KargoServiceGetAnalysisRunLogsProcedure:
+			kargoServiceGetAnalysisRunLogsHandler.ServeHTTP(w, r)
 		case KargoServiceListProjectEventsProcedure:
 			kargoServiceListProjectEventsHandler.ServeHTTP(w, r)
 		case KargoServiceCreateRoleProcedure:
@@ -1393,6 +1703,8 @@ func NewKargoServiceHandler(svc KargoServiceHandler, opts ...connect.HandlerOpti
 			kargoServiceRevokeHandler.ServeHTTP(w, r)
 		case KargoServiceUpdateRoleProcedure:
 			kargoServiceUpdateRoleHandler.ServeHTTP(w, r)
+		case KargoServiceWhoAmIProcedure:
+			kargoServiceWhoAmIHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -1414,6 +1726,10 @@ func (UnimplementedKargoServiceHandler) GetPublicConfig(context.Context, *connec
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.GetPublicConfig is not implemented"))
 }
 
+func (UnimplementedKargoServiceHandler) ListAnnouncements(context.Context, *connect.Request[v1alpha1.ListAnnouncementsRequest]) (*connect.Response[v1alpha1.ListAnnouncementsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.ListAnnouncements is not implemented"))
+}
+
 func (UnimplementedKargoServiceHandler) AdminLogin(context.Context, *connect.Request[v1alpha1.AdminLoginRequest]) (*connect.Response[v1alpha1.AdminLoginResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.AdminLogin is not implemented"))
 }
@@ -1470,6 +1786,14 @@ func (UnimplementedKargoServiceHandler) WatchPromotion(context.Context, *connect
 	return connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.WatchPromotion is not implemented"))
 }
 
+func (UnimplementedKargoServiceHandler) ComparePromotions(context.Context, *connect.Request[v1alpha1.ComparePromotionsRequest]) (*connect.Response[v1alpha1.ComparePromotionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.ComparePromotions is not implemented"))
+}
+
+func (UnimplementedKargoServiceHandler) GetPromotionPlan(context.Context, *connect.Request[v1alpha1.GetPromotionPlanRequest]) (*connect.Response[v1alpha1.GetPromotionPlanResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.GetPromotionPlan is not implemented"))
+}
+
 func (UnimplementedKargoServiceHandler) DeleteProject(context.Context, *connect.Request[v1alpha1.DeleteProjectRequest]) (*connect.Response[v1alpha1.DeleteProjectResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.DeleteProject is not implemented"))
 }
@@ -1482,10 +1806,18 @@ func (UnimplementedKargoServiceHandler) ListProjects(context.Context, *connect.R
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.ListProjects is not implemented"))
 }
 
+func (UnimplementedKargoServiceHandler) GetProjectUsageSummary(context.Context, *connect.Request[v1alpha1.GetProjectUsageSummaryRequest]) (*connect.Response[v1alpha1.GetProjectUsageSummaryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.GetProjectUsageSummary is not implemented"))
+}
+
 func (UnimplementedKargoServiceHandler) ApproveFreight(context.Context, *connect.Request[v1alpha1.ApproveFreightRequest]) (*connect.Response[v1alpha1.ApproveFreightResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.ApproveFreight is not implemented"))
 }
 
+func (UnimplementedKargoServiceHandler) BlockFreight(context.Context, *connect.Request[v1alpha1.BlockFreightRequest]) (*connect.Response[v1alpha1.BlockFreightResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.BlockFreight is not implemented"))
+}
+
 func (UnimplementedKargoServiceHandler) DeleteFreight(context.Context, *connect.Request[v1alpha1.DeleteFreightRequest]) (*connect.Response[v1alpha1.DeleteFreightResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.DeleteFreight is not implemented"))
 }
@@ -1494,6 +1826,14 @@ func (UnimplementedKargoServiceHandler) GetFreight(context.Context, *connect.Req
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.GetFreight is not implemented"))
 }
 
+func (UnimplementedKargoServiceHandler) GetFreightLeadTimes(context.Context, *connect.Request[v1alpha1.GetFreightLeadTimesRequest]) (*connect.Response[v1alpha1.GetFreightLeadTimesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.GetFreightLeadTimes is not implemented"))
+}
+
+func (UnimplementedKargoServiceHandler) PromoteThroughStages(context.Context, *connect.Request[v1alpha1.PromoteThroughStagesRequest]) (*connect.Response[v1alpha1.PromoteThroughStagesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.PromoteThroughStages is not implemented"))
+}
+
 func (UnimplementedKargoServiceHandler) PromoteToStage(context.Context, *connect.Request[v1alpha1.PromoteToStageRequest]) (*connect.Response[v1alpha1.PromoteToStageResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.PromoteToStage is not implemented"))
 }
@@ -1506,6 +1846,10 @@ func (UnimplementedKargoServiceHandler) QueryFreight(context.Context, *connect.R
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.QueryFreight is not implemented"))
 }
 
+func (UnimplementedKargoServiceHandler) UnblockFreight(context.Context, *connect.Request[v1alpha1.UnblockFreightRequest]) (*connect.Response[v1alpha1.UnblockFreightResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.UnblockFreight is not implemented"))
+}
+
 func (UnimplementedKargoServiceHandler) UpdateFreightAlias(context.Context, *connect.Request[v1alpha1.UpdateFreightAliasRequest]) (*connect.Response[v1alpha1.UpdateFreightAliasResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.UpdateFreightAlias is not implemented"))
 }
@@ -1538,6 +1882,14 @@ func (UnimplementedKargoServiceHandler) RefreshWarehouse(context.Context, *conne
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.RefreshWarehouse is not implemented"))
 }
 
+func (UnimplementedKargoServiceHandler) PreviewWarehouse(context.Context, *connect.Request[v1alpha1.PreviewWarehouseRequest]) (*connect.Response[v1alpha1.PreviewWarehouseResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.PreviewWarehouse is not implemented"))
+}
+
+func (UnimplementedKargoServiceHandler) ValidateWarehouse(context.Context, *connect.Request[v1alpha1.ValidateWarehouseRequest]) (*connect.Response[v1alpha1.ValidateWarehouseResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.ValidateWarehouse is not implemented"))
+}
+
 func (UnimplementedKargoServiceHandler) CreateCredentials(context.Context, *connect.Request[v1alpha1.CreateCredentialsRequest]) (*connect.Response[v1alpha1.CreateCredentialsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.CreateCredentials is not implemented"))
 }
@@ -1574,6 +1926,10 @@ func (UnimplementedKargoServiceHandler) GetAnalysisRun(context.Context, *connect
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.GetAnalysisRun is not implemented"))
 }
 
+func (UnimplementedKargoServiceHandler) GetAnalysisRunLogs(context.Context, *connect.Request[v1alpha1.GetAnalysisRunLogsRequest], *connect.ServerStream[v1alpha1.GetAnalysisRunLogsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.GetAnalysisRunLogs is not implemented"))
+}
+
 func (UnimplementedKargoServiceHandler) ListProjectEvents(context.Context, *connect.Request[v1alpha1.ListProjectEventsRequest]) (*connect.Response[v1alpha1.ListProjectEventsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.ListProjectEvents is not implemented"))
 }
@@ -1605,3 +1961,7 @@ func (UnimplementedKargoServiceHandler) Revoke(context.Context, *connect.Request
 func (UnimplementedKargoServiceHandler) UpdateRole(context.Context, *connect.Request[v1alpha1.UpdateRoleRequest]) (*connect.Response[v1alpha1.UpdateRoleResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.UpdateRole is not implemented"))
 }
+
+func (UnimplementedKargoServiceHandler) WhoAmI(context.Context, *connect.Request[v1alpha1.WhoAmIRequest]) (*connect.Response[v1alpha1.WhoAmIResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("akuity.io.kargo.service.v1alpha1.KargoService.WhoAmI is not implemented"))
+}